@@ -23,6 +23,7 @@ type Applier struct {
 	routeRepo           shared.CFRouteRepository
 	serviceInstanceRepo shared.CFServiceInstanceRepository
 	serviceBindingRepo  shared.CFServiceBindingRepository
+	featureFlagRepo     shared.CFFeatureFlagRepository
 }
 
 func NewApplier(
@@ -32,6 +33,7 @@ func NewApplier(
 	routeRepo shared.CFRouteRepository,
 	serviceInstanceRepo shared.CFServiceInstanceRepository,
 	serviceBindingRepo shared.CFServiceBindingRepository,
+	featureFlagRepo shared.CFFeatureFlagRepository,
 ) *Applier {
 	return &Applier{
 		appRepo:             appRepo,
@@ -40,6 +42,7 @@ func NewApplier(
 		routeRepo:           routeRepo,
 		serviceInstanceRepo: serviceInstanceRepo,
 		serviceBindingRepo:  serviceBindingRepo,
+		featureFlagRepo:     featureFlagRepo,
 	}
 }
 
@@ -84,7 +87,14 @@ func (a *Applier) applyProcesses(
 ) error {
 	for _, processInfo := range appInfo.Processes {
 		if process, ok := appState.Processes[processInfo.Type]; ok {
-			if _, err := a.processRepo.PatchProcess(ctx, authInfo, processInfo.ToProcessPatchMessage(process.GUID, appState.App.SpaceGUID)); err != nil {
+			patchMessage := processInfo.ToProcessPatchMessage(process.GUID, appState.App.SpaceGUID)
+			if scalesProcess(process, patchMessage) {
+				if err := a.featureFlagRepo.EnsureFeatureEnabled(ctx, authInfo, korifiv1alpha1.FeatureFlagAppScaling); err != nil {
+					return err
+				}
+			}
+
+			if _, err := a.processRepo.PatchProcess(ctx, authInfo, patchMessage); err != nil {
 				return err
 			}
 			continue
@@ -99,6 +109,15 @@ func (a *Applier) applyProcesses(
 	return nil
 }
 
+// scalesProcess tells whether applying the manifest changes the instances,
+// memory or disk of an existing process, which is subject to the app_scaling
+// feature flag
+func scalesProcess(process repositories.ProcessRecord, message repositories.PatchProcessMessage) bool {
+	return (message.DesiredInstances != nil && *message.DesiredInstances != process.DesiredInstances) ||
+		(message.MemoryMB != nil && *message.MemoryMB != process.MemoryMB) ||
+		(message.DiskQuotaMB != nil && *message.DiskQuotaMB != process.DiskQuotaMB)
+}
+
 func (a *Applier) applyRoutes(ctx context.Context, authInfo authorization.Info, appInfo payloads.ManifestApplication, appState AppState) error {
 	if appInfo.NoRoute {
 		return a.deleteAppDestinations(ctx, authInfo, appState.App.GUID, appState.Routes)
@@ -26,6 +26,7 @@ var _ = Describe("Applier", func() {
 		routeRepo           *fake.CFRouteRepository
 		serviceInstanceRepo *fake.CFServiceInstanceRepository
 		serviceBindingRepo  *fake.CFServiceBindingRepository
+		featureFlagRepo     *fake.CFFeatureFlagRepository
 		applier             *manifest.Applier
 		applierErr          error
 		ctx                 context.Context
@@ -41,7 +42,8 @@ var _ = Describe("Applier", func() {
 		routeRepo = new(fake.CFRouteRepository)
 		serviceInstanceRepo = new(fake.CFServiceInstanceRepository)
 		serviceBindingRepo = new(fake.CFServiceBindingRepository)
-		applier = manifest.NewApplier(appRepo, domainRepo, processRepo, routeRepo, serviceInstanceRepo, serviceBindingRepo)
+		featureFlagRepo = new(fake.CFFeatureFlagRepository)
+		applier = manifest.NewApplier(appRepo, domainRepo, processRepo, routeRepo, serviceInstanceRepo, serviceBindingRepo, featureFlagRepo)
 		ctx = context.Background()
 		authInfo = authorization.Info{Token: "a-token"}
 		appInfo = payloads.ManifestApplication{
@@ -261,6 +263,45 @@ var _ = Describe("Applier", func() {
 					Expect(applierErr).To(MatchError("process-patch-error"))
 				})
 			})
+
+			It("checks the app_scaling feature flag as the process is scaled", func() {
+				Expect(featureFlagRepo.EnsureFeatureEnabledCallCount()).To(Equal(1))
+				_, actualAuthInfo, actualName := featureFlagRepo.EnsureFeatureEnabledArgsForCall(0)
+				Expect(actualAuthInfo).To(Equal(authInfo))
+				Expect(actualName).To(Equal(korifiv1alpha1.FeatureFlagAppScaling))
+			})
+
+			When("the app_scaling feature flag is disabled", func() {
+				BeforeEach(func() {
+					featureFlagRepo.EnsureFeatureEnabledReturns(apierrors.NewFeatureDisabledError(nil, "app_scaling"))
+				})
+
+				It("does not patch the process", func() {
+					Expect(applierErr).To(BeAssignableToTypeOf(apierrors.FeatureDisabledError{}))
+					Expect(processRepo.PatchProcessCallCount()).To(BeZero())
+				})
+
+				When("the manifest does not change the process scale", func() {
+					BeforeEach(func() {
+						appState.Processes["ben"] = repositories.ProcessRecord{
+							GUID:             "process-guid",
+							DesiredInstances: 3,
+							MemoryMB:         1024,
+							DiskQuotaMB:      256,
+						}
+					})
+
+					It("patches the process", func() {
+						Expect(applierErr).NotTo(HaveOccurred())
+						Expect(featureFlagRepo.EnsureFeatureEnabledCallCount()).To(BeZero())
+						Expect(processRepo.PatchProcessCallCount()).To(Equal(1))
+					})
+				})
+			})
+		})
+
+		It("does not check the app_scaling feature flag for new processes", func() {
+			Expect(featureFlagRepo.EnsureFeatureEnabledCallCount()).To(BeZero())
 		})
 	})
 
@@ -82,10 +82,11 @@ func (n Normalizer) normalizeProcesses(appInfo payloads.ManifestApplication, app
 		webProc = &processes[len(processes)-1]
 	}
 
-	if appInfo.Memory != nil || appInfo.DiskQuota != nil || appInfo.Instances != nil || appInfo.Command != nil ||
+	if appInfo.Memory != nil || appInfo.CPU != nil || appInfo.DiskQuota != nil || appInfo.Instances != nil || appInfo.Command != nil ||
 		appInfo.HealthCheckHTTPEndpoint != nil || appInfo.HealthCheckType != nil || appInfo.HealthCheckInvocationTimeout != nil || appInfo.Timeout != nil {
 
 		webProc.Memory = procValIfSet(appInfo.Memory, webProc.Memory)
+		webProc.CPU = procValIfSet(appInfo.CPU, webProc.CPU)
 		webProc.DiskQuota = procValIfSet(appInfo.DiskQuota, webProc.DiskQuota)
 		webProc.Instances = procValIfSet(appInfo.Instances, webProc.Instances)
 		webProc.Command = procValIfSet(appInfo.Command, webProc.Command)
@@ -13,6 +13,7 @@ import (
 type prcParams struct {
 	Command                      *string
 	Memory                       *string
+	CPU                          *string
 	DiskQuota                    *string
 	Instances                    *int32
 	HealthCheckHTTPEndpoint      *string
@@ -160,6 +161,7 @@ var _ = Describe("Normalizer", func() {
 		DescribeTable("when app-level values are provided",
 			func(app appParams, process prcParams, effective expParams) {
 				appInfo.Memory = app.Memory
+				appInfo.CPU = app.CPU
 				appInfo.DiskQuota = app.DiskQuota
 				appInfo.Instances = app.Instances
 				appInfo.Command = app.Command
@@ -172,6 +174,7 @@ var _ = Describe("Normalizer", func() {
 					appInfo.Processes = append(appInfo.Processes, payloads.ManifestApplicationProcess{
 						Type:                         "web",
 						Memory:                       process.Memory,
+						CPU:                          process.CPU,
 						DiskQuota:                    process.DiskQuota,
 						Instances:                    process.Instances,
 						Command:                      process.Command,
@@ -186,6 +189,7 @@ var _ = Describe("Normalizer", func() {
 				webProc := getWebProcess(updatedAppInfo)
 
 				Expect(webProc.Memory).To(Equal(effective.Memory))
+				Expect(webProc.CPU).To(Equal(effective.CPU))
 				Expect(webProc.DiskQuota).To(Equal(effective.DiskQuota))
 				Expect(webProc.Instances).To(Equal(effective.Instances))
 				Expect(webProc.Command).To(Equal(effective.Command))
@@ -202,6 +206,9 @@ var _ = Describe("Normalizer", func() {
 			Entry("app-level memory only",
 				appParams{Memory: tools.PtrTo("512M")}, prcParams{},
 				expParams{Memory: tools.PtrTo("512M")}),
+			Entry("app-level cpu only",
+				appParams{CPU: tools.PtrTo("500m")}, prcParams{},
+				expParams{CPU: tools.PtrTo("500m")}),
 			Entry("app-level disk_quota only",
 				appParams{DiskQuota: tools.PtrTo("2G")}, prcParams{},
 				expParams{DiskQuota: tools.PtrTo("2G")}),
@@ -229,6 +236,10 @@ var _ = Describe("Normalizer", func() {
 				appParams{Memory: tools.PtrTo("512M")},
 				prcParams{Instances: tools.PtrTo[int32](3)},
 				expParams{Memory: tools.PtrTo("512M"), Instances: tools.PtrTo[int32](3)}),
+			Entry("empty proc with app cpu",
+				appParams{CPU: tools.PtrTo("500m")},
+				prcParams{Instances: tools.PtrTo[int32](3)},
+				expParams{CPU: tools.PtrTo("500m"), Instances: tools.PtrTo[int32](3)}),
 			Entry("empty proc with disk quota",
 				appParams{DiskQuota: tools.PtrTo("2G")},
 				prcParams{Instances: tools.PtrTo[int32](3)},
@@ -263,6 +274,10 @@ var _ = Describe("Normalizer", func() {
 				appParams{Memory: tools.PtrTo("256M")},
 				prcParams{Memory: tools.PtrTo("512M")},
 				expParams{Memory: tools.PtrTo("512M")}),
+			Entry("value from proc cpu used",
+				appParams{CPU: tools.PtrTo("500m")},
+				prcParams{CPU: tools.PtrTo("2")},
+				expParams{CPU: tools.PtrTo("2")}),
 			Entry("value from proc disk_quota used",
 				appParams{DiskQuota: tools.PtrTo("2G")},
 				prcParams{DiskQuota: tools.PtrTo("3G")},
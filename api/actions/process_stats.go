@@ -35,8 +35,11 @@ type MetricsRepository interface {
 type Usage struct {
 	Timestamp *time.Time
 	CPU       *float64
-	Mem       *int64
-	Disk      *int64
+	// CPUEntitlement is the percentage of the CPU the instance is entitled
+	// to that it uses
+	CPUEntitlement *float64
+	Mem            *int64
+	Disk           *int64
 }
 
 type PodStatsRecord struct {
@@ -95,7 +98,7 @@ func (a *ProcessStats) FetchStats(ctx context.Context, authInfo authorization.In
 	}
 
 	// Initialize records slice with the pod instances we expect to exist
-	records := make([]PodStatsRecord, processRecord.DesiredInstances)
+	records := make([]PodStatsRecord, processRecord.ExpectedInstances())
 	for i := range records {
 		records[i] = PodStatsRecord{
 			ProcessType: processRecord.Type,
@@ -133,6 +136,11 @@ func (a *ProcessStats) FetchStats(ctx context.Context, authInfo authorization.In
 			// Convert the number of nanoCPU to CPU for greatest accuracy.
 			percentage := value / 1e9
 			records[index].Usage.CPU = &percentage
+
+			if entitlement, ok := cpuEntitlement(m.Pod); ok {
+				entitlementPercentage := value * 100 / float64(entitlement.ScaledValue(resource.Nano))
+				records[index].Usage.CPUEntitlement = &entitlementPercentage
+			}
 		}
 
 		if memQuantity, ok := metricsMap["memory"]; ok {
@@ -199,6 +207,12 @@ func extractIndex(pod corev1.Pod) (int, error) {
 // STARTING => default
 
 func getPodState(pod corev1.Pod) string {
+	// pods being stopped, e.g. when a workload scales to zero, are down
+	// rather than crashed
+	if !pod.DeletionTimestamp.IsZero() {
+		return stateDown
+	}
+
 	// return running when all containers are ready
 	if podConditionStatus(pod, corev1.PodReady) {
 		return stateRunning
@@ -235,6 +249,23 @@ func podConditionStatus(pod corev1.Pod, conditionType corev1.PodConditionType) b
 	return false
 }
 
+func cpuEntitlement(pod corev1.Pod) (resource.Quantity, bool) {
+	for _, container := range pod.Spec.Containers {
+		if container.Name != ApplicationContainerName {
+			continue
+		}
+
+		cpuRequest, ok := container.Resources.Requests[corev1.ResourceCPU]
+		if !ok || cpuRequest.IsZero() {
+			return resource.Quantity{}, false
+		}
+
+		return cpuRequest, true
+	}
+
+	return resource.Quantity{}, false
+}
+
 func aggregateContainerMetrics(containers []metricsv1beta1.ContainerMetrics) map[string]resource.Quantity {
 	metrics := map[string]resource.Quantity{}
 
@@ -118,6 +118,7 @@ var _ = Describe("ProcessStats", func() {
 			Expect(responseRecords[0].Usage.Timestamp).To(PointTo(Equal(time.UnixMilli(1000).UTC())))
 
 			Expect(responseRecords[0].Usage.CPU).To(Equal(tools.PtrTo(0.123)))
+			Expect(responseRecords[0].Usage.CPUEntitlement).To(Equal(tools.PtrTo(123.0)))
 			Expect(responseRecords[0].Usage.Mem).To(Equal(tools.PtrTo(int64(456))))
 			Expect(responseRecords[0].Usage.Disk).To(Equal(tools.PtrTo(int64(890))))
 			Expect(responseRecords[0].MemQuota).To(Equal(tools.PtrTo(int64(1024 * 1024 * 1024))))
@@ -131,6 +132,7 @@ var _ = Describe("ProcessStats", func() {
 			Expect(responseRecords[1].Usage.Timestamp).To(PointTo(Equal(time.UnixMilli(2000).UTC())))
 
 			Expect(responseRecords[1].Usage.CPU).To(Equal(tools.PtrTo(0.124)))
+			Expect(responseRecords[1].Usage.CPUEntitlement).To(Equal(tools.PtrTo(124.0)))
 			Expect(responseRecords[1].Usage.Mem).To(Equal(tools.PtrTo(int64(457))))
 			Expect(responseRecords[1].Usage.Disk).To(Equal(tools.PtrTo(int64(891))))
 			Expect(responseRecords[1].MemQuota).To(Equal(tools.PtrTo(int64(1024 * 1024 * 1024))))
@@ -158,6 +160,24 @@ var _ = Describe("ProcessStats", func() {
 			})
 		})
 
+		When("the process is autoscaled", func() {
+			BeforeEach(func() {
+				processRepo.GetProcessReturns(repositories.ProcessRecord{
+					AppGUID:          "the-app-guid",
+					DesiredInstances: 2,
+					ActualInstances:  3,
+					Autoscaling:      &repositories.AutoscalingPolicy{MinInstances: 1, MaxInstances: 5},
+					Type:             "web",
+				}, nil)
+			})
+
+			It("returns stats for the actual instances", func() {
+				Expect(responseErr).NotTo(HaveOccurred())
+				Expect(responseRecords).To(HaveLen(3))
+				Expect(responseRecords[2].State).To(Equal("DOWN"))
+			})
+		})
+
 		When("getting the app fails", func() {
 			BeforeEach(func() {
 				appRepo.GetAppReturns(repositories.AppRecord{}, errors.New("get-app-err"))
@@ -278,6 +298,16 @@ var _ = Describe("ProcessStats", func() {
 				})
 			})
 
+			When("the pod is terminating", func() {
+				BeforeEach(func() {
+					podMetrics[0].Pod.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+				})
+
+				It("is down", func() {
+					Expect(responseRecords[0].State).To(Equal("DOWN"))
+				})
+			})
+
 			When("scheduled but not running", func() {
 				BeforeEach(func() {
 					podMetrics[0].Pod.Status.Conditions = makeConditions("Initialized")
@@ -288,6 +318,18 @@ var _ = Describe("ProcessStats", func() {
 				})
 			})
 		})
+
+		When("the application container does not request CPU", func() {
+			BeforeEach(func() {
+				podMetrics[0].Pod.Spec.Containers[0].Resources = corev1.ResourceRequirements{}
+			})
+
+			It("does not report the CPU entitlement usage", func() {
+				Expect(responseErr).NotTo(HaveOccurred())
+				Expect(responseRecords[0].Usage.CPU).To(Equal(tools.PtrTo(0.123)))
+				Expect(responseRecords[0].Usage.CPUEntitlement).To(BeNil())
+			})
+		})
 	})
 
 	Describe("FetchAppProcessesStats", func() {
@@ -395,6 +437,11 @@ func createPod(index, version string) corev1.Pod {
 				{
 					Name:  "application",
 					Image: "some-image",
+					Resources: corev1.ResourceRequirements{
+						Requests: corev1.ResourceList{
+							corev1.ResourceCPU: resource.MustParse("100m"),
+						},
+					},
 				},
 			},
 		},
@@ -0,0 +1,117 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fake
+
+import (
+	"context"
+	"sync"
+
+	"code.cloudfoundry.org/korifi/api/actions/shared"
+	"code.cloudfoundry.org/korifi/api/authorization"
+)
+
+type CFFeatureFlagRepository struct {
+	EnsureFeatureEnabledStub        func(context.Context, authorization.Info, string) error
+	ensureFeatureEnabledMutex       sync.RWMutex
+	ensureFeatureEnabledArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+	}
+	ensureFeatureEnabledReturns struct {
+		result1 error
+	}
+	ensureFeatureEnabledReturnsOnCall map[int]struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *CFFeatureFlagRepository) EnsureFeatureEnabled(arg1 context.Context, arg2 authorization.Info, arg3 string) error {
+	fake.ensureFeatureEnabledMutex.Lock()
+	ret, specificReturn := fake.ensureFeatureEnabledReturnsOnCall[len(fake.ensureFeatureEnabledArgsForCall)]
+	fake.ensureFeatureEnabledArgsForCall = append(fake.ensureFeatureEnabledArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.EnsureFeatureEnabledStub
+	fakeReturns := fake.ensureFeatureEnabledReturns
+	fake.recordInvocation("EnsureFeatureEnabled", []interface{}{arg1, arg2, arg3})
+	fake.ensureFeatureEnabledMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *CFFeatureFlagRepository) EnsureFeatureEnabledCallCount() int {
+	fake.ensureFeatureEnabledMutex.RLock()
+	defer fake.ensureFeatureEnabledMutex.RUnlock()
+	return len(fake.ensureFeatureEnabledArgsForCall)
+}
+
+func (fake *CFFeatureFlagRepository) EnsureFeatureEnabledCalls(stub func(context.Context, authorization.Info, string) error) {
+	fake.ensureFeatureEnabledMutex.Lock()
+	defer fake.ensureFeatureEnabledMutex.Unlock()
+	fake.EnsureFeatureEnabledStub = stub
+}
+
+func (fake *CFFeatureFlagRepository) EnsureFeatureEnabledArgsForCall(i int) (context.Context, authorization.Info, string) {
+	fake.ensureFeatureEnabledMutex.RLock()
+	defer fake.ensureFeatureEnabledMutex.RUnlock()
+	argsForCall := fake.ensureFeatureEnabledArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *CFFeatureFlagRepository) EnsureFeatureEnabledReturns(result1 error) {
+	fake.ensureFeatureEnabledMutex.Lock()
+	defer fake.ensureFeatureEnabledMutex.Unlock()
+	fake.EnsureFeatureEnabledStub = nil
+	fake.ensureFeatureEnabledReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *CFFeatureFlagRepository) EnsureFeatureEnabledReturnsOnCall(i int, result1 error) {
+	fake.ensureFeatureEnabledMutex.Lock()
+	defer fake.ensureFeatureEnabledMutex.Unlock()
+	fake.EnsureFeatureEnabledStub = nil
+	if fake.ensureFeatureEnabledReturnsOnCall == nil {
+		fake.ensureFeatureEnabledReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.ensureFeatureEnabledReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *CFFeatureFlagRepository) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.ensureFeatureEnabledMutex.RLock()
+	defer fake.ensureFeatureEnabledMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *CFFeatureFlagRepository) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ shared.CFFeatureFlagRepository = new(CFFeatureFlagRepository)
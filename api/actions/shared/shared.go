@@ -53,3 +53,9 @@ type CFServiceBindingRepository interface {
 type CFServiceInstanceRepository interface {
 	ListServiceInstances(context.Context, authorization.Info, repositories.ListServiceInstanceMessage) ([]repositories.ServiceInstanceRecord, error)
 }
+
+//counterfeiter:generate -o fake -fake-name CFFeatureFlagRepository . CFFeatureFlagRepository
+
+type CFFeatureFlagRepository interface {
+	EnsureFeatureEnabled(context.Context, authorization.Info, string) error
+}
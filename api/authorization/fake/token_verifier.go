@@ -0,0 +1,121 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fake
+
+import (
+	"sync"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+)
+
+type TokenVerifier struct {
+	VerifyStub        func(string) (authorization.Identity, bool, error)
+	verifyMutex       sync.RWMutex
+	verifyArgsForCall []struct {
+		arg1 string
+	}
+	verifyReturns struct {
+		result1 authorization.Identity
+		result2 bool
+		result3 error
+	}
+	verifyReturnsOnCall map[int]struct {
+		result1 authorization.Identity
+		result2 bool
+		result3 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *TokenVerifier) Verify(arg1 string) (authorization.Identity, bool, error) {
+	fake.verifyMutex.Lock()
+	ret, specificReturn := fake.verifyReturnsOnCall[len(fake.verifyArgsForCall)]
+	fake.verifyArgsForCall = append(fake.verifyArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.VerifyStub
+	fakeReturns := fake.verifyReturns
+	fake.recordInvocation("Verify", []interface{}{arg1})
+	fake.verifyMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *TokenVerifier) VerifyCallCount() int {
+	fake.verifyMutex.RLock()
+	defer fake.verifyMutex.RUnlock()
+	return len(fake.verifyArgsForCall)
+}
+
+func (fake *TokenVerifier) VerifyCalls(stub func(string) (authorization.Identity, bool, error)) {
+	fake.verifyMutex.Lock()
+	defer fake.verifyMutex.Unlock()
+	fake.VerifyStub = stub
+}
+
+func (fake *TokenVerifier) VerifyArgsForCall(i int) string {
+	fake.verifyMutex.RLock()
+	defer fake.verifyMutex.RUnlock()
+	argsForCall := fake.verifyArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *TokenVerifier) VerifyReturns(result1 authorization.Identity, result2 bool, result3 error) {
+	fake.verifyMutex.Lock()
+	defer fake.verifyMutex.Unlock()
+	fake.VerifyStub = nil
+	fake.verifyReturns = struct {
+		result1 authorization.Identity
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *TokenVerifier) VerifyReturnsOnCall(i int, result1 authorization.Identity, result2 bool, result3 error) {
+	fake.verifyMutex.Lock()
+	defer fake.verifyMutex.Unlock()
+	fake.VerifyStub = nil
+	if fake.verifyReturnsOnCall == nil {
+		fake.verifyReturnsOnCall = make(map[int]struct {
+			result1 authorization.Identity
+			result2 bool
+			result3 error
+		})
+	}
+	fake.verifyReturnsOnCall[i] = struct {
+		result1 authorization.Identity
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *TokenVerifier) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.verifyMutex.RLock()
+	defer fake.verifyMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *TokenVerifier) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ authorization.TokenVerifier = new(TokenVerifier)
@@ -5,6 +5,7 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"fmt"
+	"slices"
 )
 
 const (
@@ -19,12 +20,24 @@ const (
 type Identity struct {
 	Name string
 	Kind string
+
+	// Groups are the groups the identity is a member of, as Kubernetes
+	// would see them
+	Groups []string
 }
 
+// Hash identifies the identity together with its groups, as what the identity
+// is allowed to do depends on the groups it is a member of
 func (i *Identity) Hash() string {
-	key := append([]byte(i.Name), []byte(i.Kind)...)
+	groups := slices.Clone(i.Groups)
+	slices.Sort(groups)
+
 	hasher := sha256.New()
-	return hex.EncodeToString(hasher.Sum(key))
+	for _, field := range append([]string{i.Name, i.Kind}, groups...) {
+		hasher.Write([]byte(field))
+		hasher.Write([]byte{0})
+	}
+	return hex.EncodeToString(hasher.Sum(nil))
 }
 
 type TokenIdentityInspector interface {
@@ -48,6 +61,10 @@ func NewCertTokenIdentityProvider(tokenInspector TokenIdentityInspector, certIns
 }
 
 func (p *CertTokenIdentityProvider) GetIdentity(ctx context.Context, info Info) (Identity, error) {
+	if info.Identity != nil {
+		return *info.Identity, nil
+	}
+
 	if info.Token != "" {
 		return p.tokenInspector.WhoAmI(ctx, info.Token)
 	}
@@ -62,6 +62,18 @@ var _ = Describe("IdentityProvider", func() {
 				Expect(err).To(MatchError(ContainSubstring("boom")))
 			})
 		})
+
+		When("the token has already been verified", func() {
+			BeforeEach(func() {
+				authInfo.Identity = &authorization.Identity{Kind: rbacv1.UserKind, Name: "bob"}
+			})
+
+			It("returns the verified identity", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(id).To(Equal(authorization.Identity{Kind: rbacv1.UserKind, Name: "bob"}))
+				Expect(tokenInspector.WhoAmICallCount()).To(BeZero())
+			})
+		})
 	})
 
 	When("the authorization.Info contains a client cert", func() {
@@ -101,3 +113,29 @@ var _ = Describe("IdentityProvider", func() {
 		})
 	})
 })
+
+var _ = Describe("Identity", func() {
+	Describe("Hash", func() {
+		var alice authorization.Identity
+
+		BeforeEach(func() {
+			alice = authorization.Identity{Kind: rbacv1.UserKind, Name: "alice", Groups: []string{"devs", "ops"}}
+		})
+
+		It("does not depend on the order of the groups", func() {
+			reordered := authorization.Identity{Kind: rbacv1.UserKind, Name: "alice", Groups: []string{"ops", "devs"}}
+			Expect(reordered.Hash()).To(Equal(alice.Hash()))
+		})
+
+		It("differs when the groups differ", func() {
+			withoutOps := authorization.Identity{Kind: rbacv1.UserKind, Name: "alice", Groups: []string{"devs"}}
+			Expect(withoutOps.Hash()).NotTo(Equal(alice.Hash()))
+		})
+
+		It("does not sort the groups of the identity in place", func() {
+			reordered := authorization.Identity{Kind: rbacv1.UserKind, Name: "alice", Groups: []string{"ops", "devs"}}
+			reordered.Hash()
+			Expect(reordered.Groups).To(Equal([]string{"ops", "devs"}))
+		})
+	})
+})
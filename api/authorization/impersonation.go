@@ -0,0 +1,37 @@
+package authorization
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"k8s.io/client-go/rest"
+)
+
+// Kubernetes reserves user and group names with this prefix for its own
+// components, e.g. system:masters
+const reservedNamePrefix = "system:"
+
+//+kubebuilder:rbac:groups="",resources=users;groups,verbs=impersonate
+
+// impersonatingConfig returns a config acting on behalf of identities whose
+// tokens have been verified by the API rather than by Kubernetes. Identities
+// with reserved names cannot be impersonated and reserved groups are dropped,
+// whatever the identity source, so that the API never acts with more
+// privileges than the roles of the identity grant.
+func impersonatingConfig(impersonatorConfig *rest.Config, identity Identity) (*rest.Config, error) {
+	if strings.HasPrefix(identity.Name, reservedNamePrefix) {
+		return nil, apierrors.NewNotAuthenticatedError(fmt.Errorf("identity %q has a reserved name", identity.Name))
+	}
+
+	config := rest.CopyConfig(impersonatorConfig)
+	config.Impersonate = rest.ImpersonationConfig{
+		UserName: identity.Name,
+		Groups: slices.DeleteFunc(slices.Clone(identity.Groups), func(group string) bool {
+			return strings.HasPrefix(group, reservedNamePrefix)
+		}),
+	}
+
+	return config, nil
+}
@@ -10,6 +10,11 @@ type Info struct {
 	Token         string
 	CertData      []byte
 	RawAuthHeader string
+
+	// Identity is only set for tokens verified by the API itself rather
+	// than by Kubernetes. Requests on behalf of such identities are made by
+	// impersonating them.
+	Identity *Identity
 }
 
 type key int
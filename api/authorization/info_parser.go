@@ -8,10 +8,22 @@ import (
 	apierrors "code.cloudfoundry.org/korifi/api/errors"
 )
 
-type InfoParser struct{}
+//counterfeiter:generate -o fake -fake-name TokenVerifier . TokenVerifier
 
-func NewInfoParser() *InfoParser {
-	return &InfoParser{}
+// TokenVerifier verifies bearer tokens that have not been issued by Kubernetes
+type TokenVerifier interface {
+	// Verify returns false if the token was not issued by the verifier's issuer
+	Verify(token string) (Identity, bool, error)
+}
+
+type InfoParser struct {
+	tokenVerifiers []TokenVerifier
+}
+
+func NewInfoParser(tokenVerifiers ...TokenVerifier) *InfoParser {
+	return &InfoParser{
+		tokenVerifiers: tokenVerifiers,
+	}
 }
 
 func (p *InfoParser) Parse(authorizationHeader string) (Info, error) {
@@ -27,7 +39,7 @@ func (p *InfoParser) Parse(authorizationHeader string) (Info, error) {
 	scheme, data := values[0], values[1]
 	switch strings.ToLower(scheme) {
 	case BearerScheme:
-		return Info{Token: data, RawAuthHeader: authorizationHeader}, nil
+		return p.parseToken(data, authorizationHeader)
 	case CertScheme:
 		certBytes, err := base64.StdEncoding.DecodeString(data)
 		if err != nil {
@@ -38,3 +50,21 @@ func (p *InfoParser) Parse(authorizationHeader string) (Info, error) {
 		return Info{}, apierrors.NewInvalidAuthError(errors.New("unsupported authorization scheme"))
 	}
 }
+
+func (p *InfoParser) parseToken(token, authorizationHeader string) (Info, error) {
+	info := Info{Token: token, RawAuthHeader: authorizationHeader}
+
+	for _, verifier := range p.tokenVerifiers {
+		identity, ok, err := verifier.Verify(token)
+		if err != nil {
+			return Info{}, apierrors.NewInvalidAuthError(err)
+		}
+
+		if ok {
+			info.Identity = &identity
+			return info, nil
+		}
+	}
+
+	return info, nil
+}
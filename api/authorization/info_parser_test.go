@@ -1,10 +1,14 @@
 package authorization_test
 
 import (
+	"errors"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	rbacv1 "k8s.io/api/rbac/v1"
 
 	"code.cloudfoundry.org/korifi/api/authorization"
+	"code.cloudfoundry.org/korifi/api/authorization/fake"
 	apierrors "code.cloudfoundry.org/korifi/api/errors"
 	"code.cloudfoundry.org/korifi/tests/matchers"
 )
@@ -14,11 +18,13 @@ var _ = Describe("InfoParser", func() {
 		authHeader string
 		info       authorization.Info
 		infoParser *authorization.InfoParser
+		verifier   *fake.TokenVerifier
 		err        error
 	)
 
 	BeforeEach(func() {
-		infoParser = authorization.NewInfoParser()
+		verifier = new(fake.TokenVerifier)
+		infoParser = authorization.NewInfoParser(verifier)
 	})
 
 	JustBeforeEach(func() {
@@ -51,6 +57,36 @@ var _ = Describe("InfoParser", func() {
 				}))
 			})
 		})
+
+		It("asks the verifiers about the token", func() {
+			Expect(verifier.VerifyCallCount()).To(Equal(1))
+			Expect(verifier.VerifyArgsForCall(0)).To(Equal("token"))
+		})
+
+		When("the token is verified by a verifier", func() {
+			BeforeEach(func() {
+				verifier.VerifyReturns(authorization.Identity{Name: "alice", Kind: rbacv1.UserKind}, true, nil)
+			})
+
+			It("sets the verified identity", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(info).To(Equal(authorization.Info{
+					Token:         "token",
+					RawAuthHeader: authHeader,
+					Identity:      &authorization.Identity{Name: "alice", Kind: rbacv1.UserKind},
+				}))
+			})
+		})
+
+		When("the token verification fails", func() {
+			BeforeEach(func() {
+				verifier.VerifyReturns(authorization.Identity{}, true, errors.New("expired"))
+			})
+
+			It("returns an error", func() {
+				Expect(err).To(matchers.WrapErrorAssignableToTypeOf(apierrors.InvalidAuthError{}))
+			})
+		})
 	})
 
 	When("the Authorization header contains a ClientCert", func() {
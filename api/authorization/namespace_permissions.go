@@ -3,6 +3,7 @@ package authorization
 import (
 	"context"
 	"fmt"
+	"slices"
 	"strings"
 
 	apierrors "code.cloudfoundry.org/korifi/api/errors"
@@ -67,7 +68,7 @@ func (o *NamespacePermissions) getAuthorizedNamespaces(ctx context.Context, info
 
 	for _, roleBinding := range rolebindings.Items {
 		for _, subject := range roleBinding.Subjects {
-			isMatch, err := SameSubject(subject, identity)
+			isMatch, err := BoundToIdentity(subject, identity)
 			if err != nil {
 				return nil, err
 			}
@@ -91,7 +92,7 @@ func (o *NamespacePermissions) AuthorizedIn(ctx context.Context, identity Identi
 
 	for _, roleBinding := range rolebindings.Items {
 		for _, subject := range roleBinding.Subjects {
-			isMatch, err := SameSubject(subject, identity)
+			isMatch, err := BoundToIdentity(subject, identity)
 			if err != nil {
 				return false, err
 			}
@@ -104,6 +105,16 @@ func (o *NamespacePermissions) AuthorizedIn(ctx context.Context, identity Identi
 	return false, nil
 }
 
+// BoundToIdentity checks whether a role binding subject refers to the
+// identity itself or to one of the groups it is a member of
+func BoundToIdentity(subject rbacv1.Subject, identity Identity) (bool, error) {
+	if subject.Kind == rbacv1.GroupKind && slices.Contains(identity.Groups, subject.Name) {
+		return true, nil
+	}
+
+	return SameSubject(subject, identity)
+}
+
 func SameSubject(subject rbacv1.Subject, identity Identity) (bool, error) {
 	if identity.Kind != subject.Kind {
 		return false, nil
@@ -85,6 +85,10 @@ var _ = Describe("Namespace Permissions", func() {
 		return createRoleBindingForSubject(rbacv1.Subject{Name: user, Kind: "User"}, roleName, namespace)
 	}
 
+	createRoleBindingForGroup := func(group, roleName, namespace string) *rbacv1.RoleBinding {
+		return createRoleBindingForSubject(rbacv1.Subject{Name: group, Kind: rbacv1.GroupKind}, roleName, namespace)
+	}
+
 	createRoleBindingForServiceAccount := func(serviceAccountName, serviceAccountNS, roleName, namespace string) *rbacv1.RoleBinding {
 		return createRoleBindingForSubject(rbacv1.Subject{Name: serviceAccountName, Namespace: serviceAccountNS, Kind: "ServiceAccount"}, roleName, namespace)
 	}
@@ -244,6 +248,23 @@ var _ = Describe("Namespace Permissions", func() {
 			})
 		})
 
+		When("a user is authenticated as a member of a group", func() {
+			var groupName string
+
+			BeforeEach(func() {
+				groupName = generateGUID("devs")
+				userIdentity.Groups = []string{"some-other-group", groupName}
+				identityProvider.GetIdentityReturns(userIdentity, nil)
+				createRoleBindingForGroup(groupName, roleName1, org1NS)
+				createRoleBindingForGroup("yet-another-group", roleName1, org2NS)
+			})
+
+			It("lists the namespaces with bindings for the user's groups", func() {
+				Expect(getErr).NotTo(HaveOccurred())
+				Expect(namespaces).To(Equal(map[string]bool{org1NS: true}))
+			})
+		})
+
 		When("the id provider fails", func() {
 			BeforeEach(func() {
 				identityProvider.GetIdentityReturns(authorization.Identity{}, errors.New("boom"))
@@ -335,6 +356,19 @@ var _ = Describe("Namespace Permissions", func() {
 				})
 			})
 		})
+
+		When("a user is authenticated as a member of a group", func() {
+			BeforeEach(func() {
+				userIdentity.Groups = []string{"space-devs"}
+				identityProvider.GetIdentityReturns(userIdentity, nil)
+				createRoleBindingForGroup("space-devs", roleName1, space2NS)
+			})
+
+			It("lists the namespaces with bindings for the user's groups", func() {
+				Expect(getErr).NotTo(HaveOccurred())
+				Expect(namespaces).To(Equal(map[string]bool{space2NS: true}))
+			})
+		})
 	})
 
 	Describe("Authorized In", func() {
@@ -405,6 +439,31 @@ var _ = Describe("Namespace Permissions", func() {
 				})
 			})
 		})
+
+		When("a group has a rolebinding in the namespace", func() {
+			BeforeEach(func() {
+				createRoleBindingForGroup("org-devs", roleName1, org2NS)
+			})
+
+			It("authorizes members of the group", func() {
+				userIdentity.Groups = []string{"org-devs"}
+				authorized, err := nsPerms.AuthorizedIn(ctx, userIdentity, org2NS)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(authorized).To(BeTrue())
+			})
+
+			It("does not authorize other users", func() {
+				authorized, err := nsPerms.AuthorizedIn(ctx, userIdentity, org2NS)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(authorized).To(BeFalse())
+			})
+
+			It("authorizes the group itself", func() {
+				authorized, err := nsPerms.AuthorizedIn(ctx, authorization.Identity{Name: "org-devs", Kind: rbacv1.GroupKind}, org2NS)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(authorized).To(BeTrue())
+			})
+		})
 	})
 })
 
@@ -0,0 +1,211 @@
+package authorization
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"gopkg.in/square/go-jose.v2"
+	rbacv1 "k8s.io/api/rbac/v1"
+)
+
+const keysRefetchInterval = time.Minute
+
+// systemPrefix is reserved by Kubernetes for its own users and groups, e.g.
+// `system:masters`
+const systemPrefix = "system:"
+
+// OIDCClaimsMapping derives identities from OIDC claims the same way the
+// Kubernetes OIDC authenticator does
+type OIDCClaimsMapping struct {
+	UsernameClaim  string
+	UsernamePrefix string
+	GroupsClaim    string
+	GroupsPrefix   string
+}
+
+func (m OIDCClaimsMapping) Identity(claims map[string]any) (Identity, error) {
+	usernameClaim := m.UsernameClaim
+	if usernameClaim == "" {
+		usernameClaim = "sub"
+	}
+
+	username, ok := claims[usernameClaim].(string)
+	if !ok || username == "" {
+		return Identity{}, fmt.Errorf("missing %q claim", usernameClaim)
+	}
+
+	identity := Identity{
+		Name: m.UsernamePrefix + username,
+		Kind: rbacv1.UserKind,
+	}
+	if strings.HasPrefix(identity.Name, systemPrefix) {
+		return Identity{}, fmt.Errorf("username %q is reserved", identity.Name)
+	}
+
+	if m.GroupsClaim == "" {
+		return identity, nil
+	}
+
+	switch groups := claims[m.GroupsClaim].(type) {
+	case string:
+		identity.Groups = []string{m.GroupsPrefix + groups}
+	case []any:
+		for _, group := range groups {
+			if groupName, ok := group.(string); ok {
+				identity.Groups = append(identity.Groups, m.GroupsPrefix+groupName)
+			}
+		}
+	}
+
+	for _, group := range identity.Groups {
+		if strings.HasPrefix(group, systemPrefix) {
+			return Identity{}, fmt.Errorf("group %q is reserved", group)
+		}
+	}
+
+	return identity, nil
+}
+
+// OIDCVerifier verifies tokens issued by an OIDC provider against the keys
+// the provider publishes, so that the cluster does not need to be configured
+// with the provider
+type OIDCVerifier struct {
+	issuerURL     string
+	clientID      string
+	claimsMapping OIDCClaimsMapping
+	httpClient    *http.Client
+
+	mu            sync.Mutex
+	keys          map[string]any
+	keysFetchedAt time.Time
+}
+
+func NewOIDCVerifier(issuerURL, clientID string, claimsMapping OIDCClaimsMapping, httpClient *http.Client) *OIDCVerifier {
+	return &OIDCVerifier{
+		issuerURL:     issuerURL,
+		clientID:      clientID,
+		claimsMapping: claimsMapping,
+		httpClient:    httpClient,
+		keys:          map[string]any{},
+	}
+}
+
+func (v *OIDCVerifier) Verify(token string) (Identity, bool, error) {
+	claims := jwt.MapClaims{}
+	if _, _, err := new(jwt.Parser).ParseUnverified(token, claims); err != nil || claims["iss"] != v.issuerURL {
+		return Identity{}, false, nil
+	}
+
+	claims = jwt.MapClaims{}
+	if _, err := jwt.ParseWithClaims(token, claims, v.keyFunc); err != nil {
+		return Identity{}, true, fmt.Errorf("invalid token: %w", err)
+	}
+
+	if !claims.VerifyExpiresAt(time.Now().Unix(), true) {
+		return Identity{}, true, errors.New("invalid token: missing expiry")
+	}
+
+	if !claims.VerifyAudience(v.clientID, true) {
+		return Identity{}, true, errors.New("invalid token: unexpected audience")
+	}
+
+	identity, err := v.claimsMapping.Identity(claims)
+	if err != nil {
+		return Identity{}, true, fmt.Errorf("invalid token: %w", err)
+	}
+
+	return identity, true, nil
+}
+
+func (v *OIDCVerifier) keyFunc(token *jwt.Token) (any, error) {
+	switch token.Method.(type) {
+	case *jwt.SigningMethodRSA, *jwt.SigningMethodRSAPSS, *jwt.SigningMethodECDSA:
+	default:
+		return nil, fmt.Errorf("unexpected signing method %q", token.Method.Alg())
+	}
+
+	keyID, _ := token.Header["kid"].(string)
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	key, ok := v.lookupKey(keyID)
+	if ok {
+		return key, nil
+	}
+
+	// providers rotate their keys, so unknown keys trigger a refetch, although
+	// not more often than the refetch interval
+	if time.Since(v.keysFetchedAt) < keysRefetchInterval {
+		return nil, fmt.Errorf("unknown signing key %q", keyID)
+	}
+
+	if err := v.fetchKeys(); err != nil {
+		return nil, err
+	}
+
+	key, ok = v.lookupKey(keyID)
+	if !ok {
+		return nil, fmt.Errorf("unknown signing key %q", keyID)
+	}
+
+	return key, nil
+}
+
+func (v *OIDCVerifier) lookupKey(keyID string) (any, bool) {
+	if keyID == "" && len(v.keys) == 1 {
+		for _, key := range v.keys {
+			return key, true
+		}
+	}
+
+	key, ok := v.keys[keyID]
+	return key, ok
+}
+
+func (v *OIDCVerifier) fetchKeys() error {
+	v.keysFetchedAt = time.Now()
+
+	discovery := struct {
+		JWKSURI string `json:"jwks_uri"`
+	}{}
+	if err := v.getJSON(strings.TrimSuffix(v.issuerURL, "/")+"/.well-known/openid-configuration", &discovery); err != nil {
+		return fmt.Errorf("failed to discover OIDC provider: %w", err)
+	}
+
+	keySet := jose.JSONWebKeySet{}
+	if err := v.getJSON(discovery.JWKSURI, &keySet); err != nil {
+		return fmt.Errorf("failed to get OIDC provider keys: %w", err)
+	}
+
+	keys := map[string]any{}
+	for _, key := range keySet.Keys {
+		if key.Use == "enc" {
+			continue
+		}
+		keys[key.KeyID] = key.Public().Key
+	}
+	v.keys = keys
+
+	return nil
+}
+
+func (v *OIDCVerifier) getJSON(url string, result any) error {
+	resp, err := v.httpClient.Get(url)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+
+	return json.NewDecoder(resp.Body).Decode(result)
+}
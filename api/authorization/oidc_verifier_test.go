@@ -0,0 +1,179 @@
+package authorization_test
+
+import (
+	"net/http"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+
+	"github.com/golang-jwt/jwt"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	rbacv1 "k8s.io/api/rbac/v1"
+)
+
+var _ = Describe("OIDCVerifier", func() {
+	var (
+		verifier      *authorization.OIDCVerifier
+		claimsMapping authorization.OIDCClaimsMapping
+		token         string
+		identity      authorization.Identity
+		ok            bool
+		verifyErr     error
+	)
+
+	BeforeEach(func() {
+		claimsMapping = authorization.OIDCClaimsMapping{
+			UsernamePrefix: "oidc:",
+			GroupsClaim:    "groups",
+			GroupsPrefix:   "oidc:",
+		}
+		token = authProvider.GenerateJWTToken("alice", "devs", "ops")
+	})
+
+	JustBeforeEach(func() {
+		verifier = authorization.NewOIDCVerifier(authProvider.IssuerURL(), authProvider.Audience(), claimsMapping, authProvider.HTTPClient())
+		identity, ok, verifyErr = verifier.Verify(token)
+	})
+
+	It("maps the claims onto the identity", func() {
+		Expect(verifyErr).NotTo(HaveOccurred())
+		Expect(ok).To(BeTrue())
+		Expect(identity).To(Equal(authorization.Identity{
+			Name:   "oidc:alice",
+			Kind:   rbacv1.UserKind,
+			Groups: []string{"oidc:devs", "oidc:ops"},
+		}))
+	})
+
+	When("the username claim is configured", func() {
+		BeforeEach(func() {
+			claimsMapping.UsernameClaim = "email"
+			token = authProvider.GenerateJWTTokenWithClaims(jwt.MapClaims{
+				"sub":   "1234",
+				"email": "alice@example.com",
+			})
+		})
+
+		It("uses it", func() {
+			Expect(verifyErr).NotTo(HaveOccurred())
+			Expect(identity.Name).To(Equal("oidc:alice@example.com"))
+			Expect(identity.Groups).To(BeEmpty())
+		})
+
+		When("the token does not have the claim", func() {
+			BeforeEach(func() {
+				token = authProvider.GenerateJWTToken("alice")
+			})
+
+			It("returns an error", func() {
+				Expect(ok).To(BeTrue())
+				Expect(verifyErr).To(MatchError(ContainSubstring(`missing "email" claim`)))
+			})
+		})
+	})
+
+	When("the username is reserved by Kubernetes", func() {
+		BeforeEach(func() {
+			claimsMapping.UsernamePrefix = ""
+			token = authProvider.GenerateJWTToken("system:admin")
+		})
+
+		It("returns an error", func() {
+			Expect(ok).To(BeTrue())
+			Expect(verifyErr).To(MatchError(ContainSubstring(`username "system:admin" is reserved`)))
+		})
+	})
+
+	When("a group is reserved by Kubernetes", func() {
+		BeforeEach(func() {
+			claimsMapping.GroupsPrefix = ""
+			token = authProvider.GenerateJWTToken("alice", "devs", "system:masters")
+		})
+
+		It("returns an error", func() {
+			Expect(ok).To(BeTrue())
+			Expect(verifyErr).To(MatchError(ContainSubstring(`group "system:masters" is reserved`)))
+		})
+	})
+
+	When("the groups claim is a single group", func() {
+		BeforeEach(func() {
+			token = authProvider.GenerateJWTTokenWithClaims(jwt.MapClaims{
+				"sub":    "alice",
+				"groups": "devs",
+			})
+		})
+
+		It("maps it", func() {
+			Expect(verifyErr).NotTo(HaveOccurred())
+			Expect(identity.Groups).To(ConsistOf("oidc:devs"))
+		})
+	})
+
+	When("the token has been issued by someone else", func() {
+		BeforeEach(func() {
+			token = authProvider.GenerateJWTTokenWithClaims(jwt.MapClaims{
+				"iss": "https://kubernetes.default.svc",
+				"sub": "alice",
+			})
+		})
+
+		It("leaves it to someone else", func() {
+			Expect(verifyErr).NotTo(HaveOccurred())
+			Expect(ok).To(BeFalse())
+		})
+	})
+
+	When("the token is for another audience", func() {
+		BeforeEach(func() {
+			token = authProvider.GenerateJWTTokenWithClaims(jwt.MapClaims{
+				"aud": "another-audience",
+				"sub": "alice",
+			})
+		})
+
+		It("returns an error", func() {
+			Expect(ok).To(BeTrue())
+			Expect(verifyErr).To(MatchError(ContainSubstring("unexpected audience")))
+		})
+	})
+
+	When("the token has expired", func() {
+		BeforeEach(func() {
+			token = authProvider.GenerateJWTTokenWithClaims(jwt.MapClaims{
+				"exp": 1,
+				"sub": "alice",
+			})
+		})
+
+		It("returns an error", func() {
+			Expect(ok).To(BeTrue())
+			Expect(verifyErr).To(MatchError(ContainSubstring("expired")))
+		})
+	})
+
+	When("the token has been signed with another key", func() {
+		BeforeEach(func() {
+			parts := []byte(token)
+			parts[len(parts)-2] ^= 1
+			token = string(parts)
+		})
+
+		It("returns an error", func() {
+			Expect(ok).To(BeTrue())
+			Expect(verifyErr).To(MatchError(ContainSubstring("invalid token")))
+		})
+	})
+
+	When("the provider cannot be reached", func() {
+		JustBeforeEach(func() {
+			verifier = authorization.NewOIDCVerifier(authProvider.IssuerURL(), authProvider.Audience(), claimsMapping, http.DefaultClient)
+			identity, ok, verifyErr = verifier.Verify(token)
+		})
+
+		It("returns an error", func() {
+			Expect(ok).To(BeTrue())
+			Expect(verifyErr).To(MatchError(ContainSubstring("failed to discover OIDC provider")))
+		})
+	})
+})
@@ -52,19 +52,45 @@ func NewAuthProvider() *AuthProvider {
 }
 
 func (p *AuthProvider) GenerateJWTToken(subject string, groups ...string) string {
-	atClaims := jwt.MapClaims{}
-	atClaims["iss"] = p.server.URL()
-	atClaims["aud"] = audience
-	atClaims["sub"] = subject
-	atClaims["exp"] = time.Now().Add(time.Minute * 15).Unix()
-	atClaims["groups"] = groups
+	return p.GenerateJWTTokenWithClaims(jwt.MapClaims{
+		"sub":    subject,
+		"groups": groups,
+	})
+}
+
+// GenerateJWTTokenWithClaims generates a token with the given claims, which
+// override the default issuer, audience and expiry claims
+func (p *AuthProvider) GenerateJWTTokenWithClaims(claims jwt.MapClaims) string {
+	atClaims := jwt.MapClaims{
+		"iss": p.server.URL(),
+		"aud": audience,
+		"exp": time.Now().Add(time.Minute * 15).Unix(),
+	}
+	for claim, value := range claims {
+		atClaims[claim] = value
+	}
+
 	at := jwt.NewWithClaims(jwt.SigningMethodRS256, atClaims)
+	at.Header["kid"] = "1"
 	token, err := at.SignedString(p.signingKey)
 	gomega.Expect(err).NotTo(gomega.HaveOccurred())
 
 	return token
 }
 
+func (p *AuthProvider) IssuerURL() string {
+	return p.server.URL()
+}
+
+func (p *AuthProvider) Audience() string {
+	return audience
+}
+
+// HTTPClient returns a client trusting the provider's certificate
+func (p *AuthProvider) HTTPClient() *http.Client {
+	return p.server.HTTPTestServer.Client()
+}
+
 func writeCAToTempFile(server *ghttp.Server) string {
 	caDerBytes := server.HTTPTestServer.TLS.Certificates[0].Certificate[0]
 
@@ -40,6 +40,16 @@ var _ = Describe("TokenReviewer", func() {
 		Expect(id.Name).To(Equal(oidcPrefix + "alice"))
 	})
 
+	When("the token carries groups", func() {
+		BeforeEach(func() {
+			token = authProvider.GenerateJWTToken("alice", "devs")
+		})
+
+		It("extracts the groups of the identity", func() {
+			Expect(id.Groups).To(ContainElements("devs", "system:authenticated"))
+		})
+	})
+
 	When("the token is issued for a serviceaccount", func() {
 		BeforeEach(func() {
 			restartEnvTest(authProvider.APIServerExtraArgs("system:serviceaccount:cf:"))
@@ -20,16 +20,18 @@ type UserClientFactory interface {
 }
 
 type UnprivilegedClientFactory struct {
-	config   *rest.Config
-	mapper   meta.RESTMapper
-	wrappers []ClientWrappingFunc
+	config             *rest.Config
+	impersonatorConfig *rest.Config
+	mapper             meta.RESTMapper
+	wrappers           []ClientWrappingFunc
 }
 
 func NewUnprivilegedClientFactory(config *rest.Config, mapper meta.RESTMapper) UnprivilegedClientFactory {
 	return UnprivilegedClientFactory{
-		config:   rest.AnonymousClientConfig(rest.CopyConfig(config)),
-		mapper:   mapper,
-		wrappers: []ClientWrappingFunc{},
+		config:             rest.AnonymousClientConfig(rest.CopyConfig(config)),
+		impersonatorConfig: rest.CopyConfig(config),
+		mapper:             mapper,
+		wrappers:           []ClientWrappingFunc{},
 	}
 }
 
@@ -43,6 +45,14 @@ func (f UnprivilegedClientFactory) BuildClient(authInfo Info) (client.WithWatch,
 
 	switch strings.ToLower(authInfo.Scheme()) {
 	case BearerScheme:
+		if authInfo.Identity != nil {
+			var err error
+			config, err = impersonatingConfig(f.impersonatorConfig, *authInfo.Identity)
+			if err != nil {
+				return nil, err
+			}
+			break
+		}
 		config.BearerToken = authInfo.Token
 
 	case CertScheme:
@@ -135,6 +135,40 @@ var _ = Describe("Unprivileged User Client Factory", func() {
 				})
 			})
 		})
+
+		Context("verified identities", func() {
+			BeforeEach(func() {
+				authInfo.Token = "korifi-issued-token"
+				authInfo.Identity = &authorization.Identity{Name: userName, Kind: rbacv1.UserKind}
+			})
+
+			It("impersonates the identity and forbids access to the user", func() {
+				Expect(buildClientErr).NotTo(HaveOccurred())
+				Expect(k8serrors.IsForbidden(podListErr)).To(BeTrue())
+			})
+
+			When("a role binding exists", func() {
+				BeforeEach(func() {
+					allowListingPods(userName)
+				})
+
+				It("allows listing pods", func() {
+					Expect(buildClientErr).NotTo(HaveOccurred())
+					Expect(podListErr).NotTo(HaveOccurred())
+				})
+			})
+
+			When("the identity is a member of reserved groups", func() {
+				BeforeEach(func() {
+					authInfo.Identity.Groups = []string{"system:masters"}
+				})
+
+				It("does not impersonate the reserved groups", func() {
+					Expect(buildClientErr).NotTo(HaveOccurred())
+					Expect(k8serrors.IsForbidden(podListErr)).To(BeTrue())
+				})
+			})
+		})
 	})
 
 	Context("isolation", func() {
@@ -207,6 +241,17 @@ var _ = Describe("Unprivileged User Client Factory", func() {
 			})
 		})
 
+		When("the verified identity has a reserved name", func() {
+			BeforeEach(func() {
+				authInfo.Token = "korifi-issued-token"
+				authInfo.Identity = &authorization.Identity{Name: "system:kube-controller-manager", Kind: rbacv1.UserKind}
+			})
+
+			It("fails", func() {
+				Expect(buildClientErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotAuthenticatedError{}))
+			})
+		})
+
 		When("the token is not valid", func() {
 			BeforeEach(func() {
 				authInfo.Token = "xxx"
@@ -17,12 +17,14 @@ type UserClientsetFactory interface {
 }
 
 type UnprivilegedClientsetFactory struct {
-	config *rest.Config
+	config             *rest.Config
+	impersonatorConfig *rest.Config
 }
 
 func NewUnprivilegedClientsetFactory(config *rest.Config) UnprivilegedClientsetFactory {
 	return UnprivilegedClientsetFactory{
-		config: rest.AnonymousClientConfig(rest.CopyConfig(config)),
+		config:             rest.AnonymousClientConfig(rest.CopyConfig(config)),
+		impersonatorConfig: rest.CopyConfig(config),
 	}
 }
 
@@ -31,6 +33,14 @@ func (f UnprivilegedClientsetFactory) BuildClientset(authInfo Info) (k8sclient.I
 
 	switch strings.ToLower(authInfo.Scheme()) {
 	case BearerScheme:
+		if authInfo.Identity != nil {
+			var err error
+			config, err = impersonatingConfig(f.impersonatorConfig, *authInfo.Identity)
+			if err != nil {
+				return nil, err
+			}
+			break
+		}
 		config.BearerToken = authInfo.Token
 
 	case CertScheme:
@@ -15,6 +15,9 @@ const (
 	defaultExternalProtocol           = "https"
 	OrgRole                 RoleLevel = "org"
 	SpaceRole               RoleLevel = "space"
+
+	OAuthIdentityBackendTokenReview = "tokenreview"
+	OAuthIdentityBackendOIDC        = "oidc"
 )
 
 type (
@@ -54,11 +57,14 @@ type (
 	Experimental struct {
 		ManagedServices  ManagedServices `yaml:"managedServices"`
 		UAA              UAA             `yaml:"uaa"`
+		OAuth            OAuth           `yaml:"oauth"`
+		OIDC             OIDC            `yaml:"oidc"`
 		ExternalLogCache ExtenalLogCache `yaml:"externalLogCache"`
 	}
 
 	ManagedServices struct {
-		Enabled bool `yaml:"enabled"`
+		Enabled              bool `yaml:"enabled"`
+		TrustInsecureBrokers bool `yaml:"trustInsecureBrokers"`
 	}
 
 	UAA struct {
@@ -66,6 +72,40 @@ type (
 		URL     string `yaml:"url"`
 	}
 
+	// OIDC configures an OIDC provider whose tokens the API verifies itself,
+	// rather than relying on the cluster being configured with the provider.
+	// The same provider backs the oidc identity backend of the token service.
+	OIDC struct {
+		Enabled          bool     `yaml:"enabled"`
+		IssuerURL        string   `yaml:"issuerURL"`
+		ClientID         string   `yaml:"clientID"`
+		ClientSecretName string   `yaml:"clientSecretName"`
+		Scopes           []string `yaml:"scopes"`
+		UsernameClaim    string   `yaml:"usernameClaim"`
+		UsernamePrefix   string   `yaml:"usernamePrefix"`
+		GroupsClaim      string   `yaml:"groupsClaim"`
+		GroupsPrefix     string   `yaml:"groupsPrefix"`
+	}
+
+	// OAuth configures the token service embedded in the API, which is an
+	// alternative to an external UAA
+	OAuth struct {
+		Enabled              bool          `yaml:"enabled"`
+		AccessTokenValidity  string        `yaml:"accessTokenValidity"`
+		RefreshTokenValidity string        `yaml:"refreshTokenValidity"`
+		KeyRotationInterval  string        `yaml:"keyRotationInterval"`
+		KeysSecretName       string        `yaml:"keysSecretName"`
+		IdentityBackend      string        `yaml:"identityBackend"`
+		Clients              []OAuthClient `yaml:"clients"`
+	}
+
+	// OAuthClient is a confidential client whose secret is stored under the
+	// `secret` key of a secret in the root namespace
+	OAuthClient struct {
+		ID         string `yaml:"id"`
+		SecretName string `yaml:"secretName"`
+	}
+
 	ExtenalLogCache struct {
 		Enabled               bool   `yaml:"enabled"`
 		URL                   string `yaml:"url"`
@@ -149,9 +189,120 @@ func (c *APIConfig) validate() error {
 		return errors.New("BuilderName must have a value")
 	}
 
+	if c.Experimental.OIDC.Enabled {
+		if c.Experimental.OIDC.IssuerURL == "" || c.Experimental.OIDC.ClientID == "" {
+			return errors.New("oidc requires an issuerURL and a clientID")
+		}
+	}
+
+	if c.Experimental.OAuth.Enabled {
+		if err := c.Experimental.OAuth.validate(); err != nil {
+			return err
+		}
+
+		if c.Experimental.OAuth.IdentityBackend == OAuthIdentityBackendOIDC && !c.Experimental.OIDC.Enabled {
+			return errors.New("the oidc identity backend requires oidc to be enabled")
+		}
+
+		if c.Experimental.UAA.Enabled {
+			return errors.New("the embedded oauth token service cannot be enabled together with uaa")
+		}
+	}
+
 	return nil
 }
 
+func (o *OAuth) validate() error {
+	for name, duration := range map[string]string{
+		"accessTokenValidity":  o.AccessTokenValidity,
+		"refreshTokenValidity": o.RefreshTokenValidity,
+		"keyRotationInterval":  o.KeyRotationInterval,
+	} {
+		if duration == "" {
+			continue
+		}
+		if _, err := time.ParseDuration(duration); err != nil {
+			return fmt.Errorf(`invalid duration format for oauth %s. Use a format like "1h"`, name)
+		}
+	}
+
+	switch o.IdentityBackend {
+	case "", OAuthIdentityBackendTokenReview, OAuthIdentityBackendOIDC:
+	default:
+		return fmt.Errorf("unsupported oauth identityBackend %q", o.IdentityBackend)
+	}
+
+	for _, client := range o.Clients {
+		if client.ID == "" || client.SecretName == "" {
+			return errors.New("oauth clients require an id and a secretName")
+		}
+	}
+
+	return nil
+}
+
+func (o *OAuth) GetAccessTokenValidity() time.Duration {
+	return durationOrDefault(o.AccessTokenValidity, time.Hour)
+}
+
+func (o *OAuth) GetRefreshTokenValidity() time.Duration {
+	return durationOrDefault(o.RefreshTokenValidity, 7*24*time.Hour)
+}
+
+func (o *OAuth) GetKeyRotationInterval() time.Duration {
+	return durationOrDefault(o.KeyRotationInterval, 24*time.Hour)
+}
+
+func (o *OAuth) GetKeysSecretName() string {
+	if o.KeysSecretName == "" {
+		return "korifi-api-token-keys"
+	}
+	return o.KeysSecretName
+}
+
+// GetUsernamePrefix defaults the username prefix the way the Kubernetes OIDC
+// authenticator does: usernames are prefixed with the issuer URL and `#`,
+// unless they are taken from the `email` claim. `-` disables the prefix.
+func (o *OIDC) GetUsernamePrefix() string {
+	if o.UsernamePrefix == "" && o.UsernameClaim == "email" {
+		return ""
+	}
+	return oidcPrefixOrDefault(o.UsernamePrefix, o.IssuerURL)
+}
+
+// GetGroupsPrefix defaults the groups prefix to the issuer URL and `#`, so
+// that groups of the provider cannot be mistaken for cluster groups. `-`
+// disables the prefix.
+func (o *OIDC) GetGroupsPrefix() string {
+	return oidcPrefixOrDefault(o.GroupsPrefix, o.IssuerURL)
+}
+
+func oidcPrefixOrDefault(prefix, issuerURL string) string {
+	switch prefix {
+	case "":
+		return issuerURL + "#"
+	case "-":
+		return ""
+	default:
+		return prefix
+	}
+}
+
+func (o *OIDC) GetScopes() []string {
+	if len(o.Scopes) == 0 {
+		return []string{"openid", "profile", "email"}
+	}
+	return o.Scopes
+}
+
+func durationOrDefault(duration string, defaultDuration time.Duration) time.Duration {
+	if duration == "" {
+		return defaultDuration
+	}
+	d, _ := time.ParseDuration(duration)
+	return d
+}
+
 func (c *APIConfig) GetUserCertificateDuration() time.Duration {
 	if c.UserCertificateExpirationWarningDuration == "" {
 		return time.Hour * 24 * 7
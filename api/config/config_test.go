@@ -2,6 +2,7 @@ package config_test
 
 import (
 	"os"
+	"time"
 
 	"go.uber.org/zap/zapcore"
 
@@ -211,4 +212,150 @@ var _ = Describe("Config", func() {
 			Expect(cfg.ServerURL).To(Equal("https://api.foo:1234"))
 		})
 	})
+
+	When("the oauth token service is enabled", func() {
+		var oauthConfig map[string]any
+
+		BeforeEach(func() {
+			oauthConfig = map[string]any{
+				"enabled": true,
+			}
+			configMap["experimental"].(map[string]any)["oauth"] = oauthConfig
+		})
+
+		It("uses default durations", func() {
+			Expect(loadErr).NotTo(HaveOccurred())
+			Expect(cfg.Experimental.OAuth.GetAccessTokenValidity()).To(Equal(time.Hour))
+			Expect(cfg.Experimental.OAuth.GetRefreshTokenValidity()).To(Equal(7 * 24 * time.Hour))
+			Expect(cfg.Experimental.OAuth.GetKeyRotationInterval()).To(Equal(24 * time.Hour))
+			Expect(cfg.Experimental.OAuth.GetKeysSecretName()).To(Equal("korifi-api-token-keys"))
+		})
+
+		When("durations are configured", func() {
+			BeforeEach(func() {
+				oauthConfig["accessTokenValidity"] = "10m"
+				oauthConfig["refreshTokenValidity"] = "2h"
+				oauthConfig["keyRotationInterval"] = "3h"
+			})
+
+			It("uses them", func() {
+				Expect(loadErr).NotTo(HaveOccurred())
+				Expect(cfg.Experimental.OAuth.GetAccessTokenValidity()).To(Equal(10 * time.Minute))
+				Expect(cfg.Experimental.OAuth.GetRefreshTokenValidity()).To(Equal(2 * time.Hour))
+				Expect(cfg.Experimental.OAuth.GetKeyRotationInterval()).To(Equal(3 * time.Hour))
+			})
+		})
+
+		When("a duration is invalid", func() {
+			BeforeEach(func() {
+				oauthConfig["accessTokenValidity"] = "forever"
+			})
+
+			It("returns an error", func() {
+				Expect(loadErr).To(MatchError(ContainSubstring("invalid duration format for oauth accessTokenValidity")))
+			})
+		})
+
+		When("the oidc identity backend is used without oidc", func() {
+			BeforeEach(func() {
+				oauthConfig["identityBackend"] = "oidc"
+			})
+
+			It("returns an error", func() {
+				Expect(loadErr).To(MatchError("the oidc identity backend requires oidc to be enabled"))
+			})
+		})
+
+		When("the identity backend is not supported", func() {
+			BeforeEach(func() {
+				oauthConfig["identityBackend"] = "ldap"
+			})
+
+			It("returns an error", func() {
+				Expect(loadErr).To(MatchError(ContainSubstring(`unsupported oauth identityBackend "ldap"`)))
+			})
+		})
+
+		When("a client has no secret name", func() {
+			BeforeEach(func() {
+				oauthConfig["clients"] = []map[string]any{{"id": "my-client"}}
+			})
+
+			It("returns an error", func() {
+				Expect(loadErr).To(MatchError("oauth clients require an id and a secretName"))
+			})
+		})
+
+		When("uaa is enabled too", func() {
+			BeforeEach(func() {
+				configMap["experimental"].(map[string]any)["uaa"] = map[string]any{"enabled": true}
+			})
+
+			It("returns an error", func() {
+				Expect(loadErr).To(MatchError(ContainSubstring("cannot be enabled together with uaa")))
+			})
+		})
+	})
+
+	When("oidc is enabled", func() {
+		var oidcConfig map[string]any
+
+		BeforeEach(func() {
+			oidcConfig = map[string]any{
+				"enabled":     true,
+				"issuerURL":   "https://dex.example.com",
+				"clientID":    "korifi",
+				"groupsClaim": "groups",
+			}
+			configMap["experimental"].(map[string]any)["oidc"] = oidcConfig
+		})
+
+		It("populates the config", func() {
+			Expect(loadErr).NotTo(HaveOccurred())
+			Expect(cfg.Experimental.OIDC.IssuerURL).To(Equal("https://dex.example.com"))
+			Expect(cfg.Experimental.OIDC.ClientID).To(Equal("korifi"))
+			Expect(cfg.Experimental.OIDC.GroupsClaim).To(Equal("groups"))
+			Expect(cfg.Experimental.OIDC.GetScopes()).To(Equal([]string{"openid", "profile", "email"}))
+		})
+
+		It("prefixes usernames and groups with the issuer URL by default", func() {
+			Expect(loadErr).NotTo(HaveOccurred())
+			Expect(cfg.Experimental.OIDC.GetUsernamePrefix()).To(Equal("https://dex.example.com#"))
+			Expect(cfg.Experimental.OIDC.GetGroupsPrefix()).To(Equal("https://dex.example.com#"))
+		})
+
+		When("the username claim is email", func() {
+			BeforeEach(func() {
+				oidcConfig["usernameClaim"] = "email"
+			})
+
+			It("does not prefix usernames by default", func() {
+				Expect(loadErr).NotTo(HaveOccurred())
+				Expect(cfg.Experimental.OIDC.GetUsernamePrefix()).To(BeEmpty())
+			})
+		})
+
+		When("the prefixes are set", func() {
+			BeforeEach(func() {
+				oidcConfig["usernamePrefix"] = "oidc:"
+				oidcConfig["groupsPrefix"] = "-"
+			})
+
+			It("uses them, with `-` disabling the prefix", func() {
+				Expect(loadErr).NotTo(HaveOccurred())
+				Expect(cfg.Experimental.OIDC.GetUsernamePrefix()).To(Equal("oidc:"))
+				Expect(cfg.Experimental.OIDC.GetGroupsPrefix()).To(BeEmpty())
+			})
+		})
+
+		When("the issuer is not set", func() {
+			BeforeEach(func() {
+				delete(oidcConfig, "issuerURL")
+			})
+
+			It("returns an error", func() {
+				Expect(loadErr).To(MatchError("oidc requires an issuerURL and a clientID"))
+			})
+		})
+	})
 })
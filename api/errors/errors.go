@@ -370,8 +370,34 @@ func NewRollingDeployNotSupportedError(runnerName string) RollingDeployNotSuppor
 	}
 }
 
+type FeatureDisabledError struct {
+	apiError
+}
+
+// NewFeatureDisabledError returns the error raised when an operation is
+// prevented by a disabled feature flag. The feature is either the name of the
+// feature flag or the custom error message configured on it.
+func NewFeatureDisabledError(cause error, feature string) FeatureDisabledError {
+	return newFeatureDisabledError(cause, fmt.Sprintf(validation.FeatureDisabledErrorMessageTemplate, feature))
+}
+
+func newFeatureDisabledError(cause error, detail string) FeatureDisabledError {
+	return FeatureDisabledError{
+		apiError: apiError{
+			cause:      cause,
+			title:      "CF-FeatureDisabled",
+			detail:     detail,
+			code:       330002,
+			httpStatus: http.StatusForbidden,
+		},
+	}
+}
+
 func FromK8sError(err error, resourceType string) error {
 	if webhookValidationError, ok := validation.WebhookErrorToValidationError(err); ok {
+		if webhookValidationError.Type == validation.FeatureDisabledErrorType {
+			return newFeatureDisabledError(err, webhookValidationError.GetMessage())
+		}
 		return NewUnprocessableEntityError(err, webhookValidationError.GetMessage())
 	}
 
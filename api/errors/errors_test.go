@@ -5,8 +5,10 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net/http"
 
 	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/controllers/webhooks/validation"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
@@ -62,6 +64,20 @@ var _ = Describe("FromK8sError", func() {
 		})
 	})
 
+	When("feature disabled webhook error", func() {
+		BeforeEach(func() {
+			err = validation.ValidationError{
+				Type:    validation.FeatureDisabledErrorType,
+				Message: "Feature Disabled: diego_docker",
+			}.ExportJSONError()
+		})
+
+		It("translates it to feature disabled api error", func() {
+			Expect(actualErr).To(Equal(apierrors.NewFeatureDisabledError(err, "diego_docker")))
+			Expect(actualErr.(apierrors.FeatureDisabledError).HttpStatus()).To(Equal(http.StatusForbidden))
+		})
+	})
+
 	When("unknown error", func() {
 		BeforeEach(func() {
 			err = errors.New("bar")
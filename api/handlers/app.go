@@ -79,6 +79,7 @@ type App struct {
 	podRepo                 PodRepository
 	gaugesCollector         GaugesCollector
 	instancesStateCollector InstancesStateCollector
+	featureFlagRepo         CFFeatureFlagRepository
 }
 
 func NewApp(
@@ -94,6 +95,7 @@ func NewApp(
 	podRepo PodRepository,
 	gaugesCollector GaugesCollector,
 	instancesStateCollector InstancesStateCollector,
+	featureFlagRepo CFFeatureFlagRepository,
 ) *App {
 	return &App{
 		serverURL:               serverURL,
@@ -108,6 +110,7 @@ func NewApp(
 		podRepo:                 podRepo,
 		gaugesCollector:         gaugesCollector,
 		instancesStateCollector: instancesStateCollector,
+		featureFlagRepo:         featureFlagRepo,
 	}
 }
 
@@ -387,6 +390,10 @@ func (h *App) scaleProcess(r *http.Request) (*routing.Response, error) {
 		return nil, apierrors.LogAndReturn(logger, err, "failed to decode json payload")
 	}
 
+	if err := h.featureFlagRepo.EnsureFeatureEnabled(r.Context(), authInfo, korifiv1alpha1.FeatureFlagAppScaling); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "app scaling is disabled")
+	}
+
 	app, err := h.appRepo.GetApp(r.Context(), authInfo, appGUID)
 	if err != nil {
 		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "falied to get app")
@@ -42,6 +42,7 @@ var _ = Describe("App", func() {
 		requestValidator        *fake.RequestValidator
 		gaugesCollector         *fake.GaugesCollector
 		instancesStateCollector *fake.InstancesStateCollector
+		featureFlagRepo         *fake.CFFeatureFlagRepository
 		req                     *http.Request
 
 		appRecord repositories.AppRecord
@@ -59,6 +60,7 @@ var _ = Describe("App", func() {
 		podRepo = new(fake.PodRepository)
 		gaugesCollector = new(fake.GaugesCollector)
 		instancesStateCollector = new(fake.InstancesStateCollector)
+		featureFlagRepo = new(fake.CFFeatureFlagRepository)
 
 		apiHandler := NewApp(
 			*serverURL,
@@ -73,6 +75,7 @@ var _ = Describe("App", func() {
 			podRepo,
 			gaugesCollector,
 			instancesStateCollector,
+			featureFlagRepo,
 		)
 
 		appRecord = repositories.AppRecord{
@@ -1126,6 +1129,17 @@ var _ = Describe("App", func() {
 			})
 		})
 
+		When("the app_scaling feature flag is disabled", func() {
+			BeforeEach(func() {
+				featureFlagRepo.EnsureFeatureEnabledReturns(apierrors.NewFeatureDisabledError(nil, "scaling is not allowed"))
+			})
+
+			It("returns a feature disabled error with the custom message", func() {
+				expectErrorResponse(http.StatusForbidden, "CF-FeatureDisabled", "Feature Disabled: scaling is not allowed", 330002)
+				Expect(processRepo.ScaleProcessCallCount()).To(BeZero())
+			})
+		})
+
 		When("the user does not have permissions to get the app", func() {
 			BeforeEach(func() {
 				appRepo.GetAppReturns(repositories.AppRecord{}, apierrors.NewForbiddenError(nil, "App"))
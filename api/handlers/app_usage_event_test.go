@@ -0,0 +1,195 @@
+package handlers_test
+
+import (
+	"errors"
+	"net/http"
+
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/handlers"
+	"code.cloudfoundry.org/korifi/api/handlers/fake"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	. "code.cloudfoundry.org/korifi/tests/matchers"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("AppUsageEvent", func() {
+	var (
+		apiHandler        *handlers.AppUsageEvent
+		appUsageEventRepo *fake.CFAppUsageEventRepository
+		requestValidator  *fake.RequestValidator
+		req               *http.Request
+	)
+
+	BeforeEach(func() {
+		requestValidator = new(fake.RequestValidator)
+		appUsageEventRepo = new(fake.CFAppUsageEventRepository)
+		apiHandler = handlers.NewAppUsageEvent(*serverURL, requestValidator, appUsageEventRepo)
+		routerBuilder.LoadRoutes(apiHandler)
+	})
+
+	JustBeforeEach(func() {
+		routerBuilder.Build().ServeHTTP(rr, req)
+	})
+
+	Describe("GET /v3/app_usage_events/:guid", func() {
+		BeforeEach(func() {
+			appUsageEventRepo.GetAppUsageEventReturns(repositories.AppUsageEventRecord{
+				GUID:    "event-guid",
+				State:   "STARTED",
+				AppGUID: "app-guid",
+			}, nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "GET", "/v3/app_usage_events/event-guid", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns the app usage event", func() {
+			Expect(appUsageEventRepo.GetAppUsageEventCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualGUID := appUsageEventRepo.GetAppUsageEventArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualGUID).To(Equal("event-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.guid", "event-guid"),
+				MatchJSONPath("$.state.current", "STARTED"),
+				MatchJSONPath("$.app.guid", "app-guid"),
+				MatchJSONPath("$.links.self.href", "https://api.example.org/v3/app_usage_events/event-guid"),
+			)))
+		})
+
+		When("the user is not authorized to get the event", func() {
+			BeforeEach(func() {
+				appUsageEventRepo.GetAppUsageEventReturns(repositories.AppUsageEventRecord{}, apierrors.NewForbiddenError(nil, repositories.AppUsageEventResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.AppUsageEventResourceType)
+			})
+		})
+
+		When("getting the event fails", func() {
+			BeforeEach(func() {
+				appUsageEventRepo.GetAppUsageEventReturns(repositories.AppUsageEventRecord{}, errors.New("get-err"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("GET /v3/app_usage_events", func() {
+		BeforeEach(func() {
+			requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(&payloads.AppUsageEventList{
+				AfterGUID: "after-guid",
+			})
+
+			appUsageEventRepo.ListAppUsageEventsReturns(repositories.ListResult[repositories.AppUsageEventRecord]{
+				PageInfo: repositories.PageInfo{TotalResults: 1, TotalPages: 1, PageNumber: 1, PageSize: 50},
+				Records: []repositories.AppUsageEventRecord{
+					{GUID: "event-guid"},
+				},
+			}, nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "GET", "/v3/app_usage_events?after_guid=after-guid", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("lists the app usage events", func() {
+			Expect(appUsageEventRepo.ListAppUsageEventsCallCount()).To(Equal(1))
+			_, actualAuthInfo, listMessage := appUsageEventRepo.ListAppUsageEventsArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(listMessage.AfterGUID).To(Equal("after-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.pagination.total_results", BeEquivalentTo(1)),
+				MatchJSONPath("$.resources[0].guid", "event-guid"),
+			)))
+		})
+
+		When("there are more pages", func() {
+			BeforeEach(func() {
+				appUsageEventRepo.ListAppUsageEventsReturns(repositories.ListResult[repositories.AppUsageEventRecord]{
+					PageInfo: repositories.PageInfo{TotalResults: 3, TotalPages: 3, PageNumber: 2, PageSize: 1},
+					Records:  []repositories.AppUsageEventRecord{{GUID: "event-guid"}},
+				}, nil)
+			})
+
+			It("links the other pages", func() {
+				Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+				Expect(rr).To(HaveHTTPBody(SatisfyAll(
+					MatchJSONPath("$.pagination.total_results", BeEquivalentTo(3)),
+					MatchJSONPath("$.pagination.total_pages", BeEquivalentTo(3)),
+					MatchJSONPath("$.pagination.first.href", "https://api.example.org/v3/app_usage_events?after_guid=after-guid&page=1&per_page=1"),
+					MatchJSONPath("$.pagination.last.href", "https://api.example.org/v3/app_usage_events?after_guid=after-guid&page=3&per_page=1"),
+					MatchJSONPath("$.pagination.next.href", "https://api.example.org/v3/app_usage_events?after_guid=after-guid&page=3&per_page=1"),
+					MatchJSONPath("$.pagination.previous.href", "https://api.example.org/v3/app_usage_events?after_guid=after-guid&page=1&per_page=1"),
+				)))
+			})
+		})
+
+		When("decoding the query parameters fails", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateURLValuesReturns(errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+
+		When("listing fails", func() {
+			BeforeEach(func() {
+				appUsageEventRepo.ListAppUsageEventsReturns(repositories.ListResult[repositories.AppUsageEventRecord]{}, errors.New("list-err"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("POST /v3/app_usage_events/actions/destructively_purge_all_and_reseed", func() {
+		BeforeEach(func() {
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "POST", "/v3/app_usage_events/actions/destructively_purge_all_and_reseed", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("purges and reseeds the app usage events", func() {
+			Expect(appUsageEventRepo.PurgeAndReseedCallCount()).To(Equal(1))
+			_, actualAuthInfo := appUsageEventRepo.PurgeAndReseedArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+		})
+
+		When("the user is not authorized", func() {
+			BeforeEach(func() {
+				appUsageEventRepo.PurgeAndReseedReturns(apierrors.NewForbiddenError(nil, repositories.AppUsageEventResourceType))
+			})
+
+			It("returns a forbidden error", func() {
+				expectNotAuthorizedError()
+			})
+		})
+
+		When("purging fails", func() {
+			BeforeEach(func() {
+				appUsageEventRepo.PurgeAndReseedReturns(errors.New("purge-err"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+})
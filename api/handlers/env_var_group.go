@@ -0,0 +1,87 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/api/routing"
+
+	"github.com/go-logr/logr"
+)
+
+const (
+	EnvVarGroupPath = "/v3/environment_variable_groups/{name}"
+)
+
+//counterfeiter:generate -o fake -fake-name CFEnvVarGroupRepository . CFEnvVarGroupRepository
+
+type CFEnvVarGroupRepository interface {
+	GetEnvVarGroup(context.Context, authorization.Info, string) (repositories.EnvVarGroupRecord, error)
+	UpdateEnvVarGroup(context.Context, authorization.Info, repositories.UpdateEnvVarGroupMessage) (repositories.EnvVarGroupRecord, error)
+}
+
+type EnvVarGroup struct {
+	serverURL        url.URL
+	envVarGroupRepo  CFEnvVarGroupRepository
+	requestValidator RequestValidator
+}
+
+func NewEnvVarGroup(
+	serverURL url.URL,
+	envVarGroupRepo CFEnvVarGroupRepository,
+	requestValidator RequestValidator,
+) *EnvVarGroup {
+	return &EnvVarGroup{
+		serverURL:        serverURL,
+		envVarGroupRepo:  envVarGroupRepo,
+		requestValidator: requestValidator,
+	}
+}
+
+func (h *EnvVarGroup) get(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.env-var-group.get")
+	name := routing.URLParam(r, "name")
+
+	envVarGroup, err := h.envVarGroupRepo.GetEnvVarGroup(r.Context(), authInfo, name)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to fetch environment variable group", "name", name)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForEnvVarGroup(envVarGroup, h.serverURL)), nil
+}
+
+func (h *EnvVarGroup) update(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.env-var-group.update")
+	name := routing.URLParam(r, "name")
+
+	var payload payloads.EnvVarGroupUpdate
+	if err := h.requestValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	envVarGroup, err := h.envVarGroupRepo.UpdateEnvVarGroup(r.Context(), authInfo, payload.ToMessage(name))
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to update environment variable group", "name", name)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForEnvVarGroup(envVarGroup, h.serverURL)), nil
+}
+
+func (h *EnvVarGroup) UnauthenticatedRoutes() []routing.Route {
+	return nil
+}
+
+func (h *EnvVarGroup) AuthenticatedRoutes() []routing.Route {
+	return []routing.Route{
+		{Method: "GET", Pattern: EnvVarGroupPath, Handler: h.get},
+		{Method: "PATCH", Pattern: EnvVarGroupPath, Handler: h.update},
+	}
+}
@@ -0,0 +1,365 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fake
+
+import (
+	"context"
+	"sync"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	"code.cloudfoundry.org/korifi/api/handlers"
+	"code.cloudfoundry.org/korifi/api/repositories"
+)
+
+type CFFeatureFlagRepository struct {
+	EnsureFeatureEnabledStub        func(context.Context, authorization.Info, string) error
+	ensureFeatureEnabledMutex       sync.RWMutex
+	ensureFeatureEnabledArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+	}
+	ensureFeatureEnabledReturns struct {
+		result1 error
+	}
+	ensureFeatureEnabledReturnsOnCall map[int]struct {
+		result1 error
+	}
+	GetFeatureFlagStub        func(context.Context, authorization.Info, string) (repositories.FeatureFlagRecord, error)
+	getFeatureFlagMutex       sync.RWMutex
+	getFeatureFlagArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+	}
+	getFeatureFlagReturns struct {
+		result1 repositories.FeatureFlagRecord
+		result2 error
+	}
+	getFeatureFlagReturnsOnCall map[int]struct {
+		result1 repositories.FeatureFlagRecord
+		result2 error
+	}
+	ListFeatureFlagsStub        func(context.Context, authorization.Info) ([]repositories.FeatureFlagRecord, error)
+	listFeatureFlagsMutex       sync.RWMutex
+	listFeatureFlagsArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+	}
+	listFeatureFlagsReturns struct {
+		result1 []repositories.FeatureFlagRecord
+		result2 error
+	}
+	listFeatureFlagsReturnsOnCall map[int]struct {
+		result1 []repositories.FeatureFlagRecord
+		result2 error
+	}
+	UpdateFeatureFlagStub        func(context.Context, authorization.Info, repositories.UpdateFeatureFlagMessage) (repositories.FeatureFlagRecord, error)
+	updateFeatureFlagMutex       sync.RWMutex
+	updateFeatureFlagArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.UpdateFeatureFlagMessage
+	}
+	updateFeatureFlagReturns struct {
+		result1 repositories.FeatureFlagRecord
+		result2 error
+	}
+	updateFeatureFlagReturnsOnCall map[int]struct {
+		result1 repositories.FeatureFlagRecord
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *CFFeatureFlagRepository) EnsureFeatureEnabled(arg1 context.Context, arg2 authorization.Info, arg3 string) error {
+	fake.ensureFeatureEnabledMutex.Lock()
+	ret, specificReturn := fake.ensureFeatureEnabledReturnsOnCall[len(fake.ensureFeatureEnabledArgsForCall)]
+	fake.ensureFeatureEnabledArgsForCall = append(fake.ensureFeatureEnabledArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.EnsureFeatureEnabledStub
+	fakeReturns := fake.ensureFeatureEnabledReturns
+	fake.recordInvocation("EnsureFeatureEnabled", []interface{}{arg1, arg2, arg3})
+	fake.ensureFeatureEnabledMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *CFFeatureFlagRepository) EnsureFeatureEnabledCallCount() int {
+	fake.ensureFeatureEnabledMutex.RLock()
+	defer fake.ensureFeatureEnabledMutex.RUnlock()
+	return len(fake.ensureFeatureEnabledArgsForCall)
+}
+
+func (fake *CFFeatureFlagRepository) EnsureFeatureEnabledCalls(stub func(context.Context, authorization.Info, string) error) {
+	fake.ensureFeatureEnabledMutex.Lock()
+	defer fake.ensureFeatureEnabledMutex.Unlock()
+	fake.EnsureFeatureEnabledStub = stub
+}
+
+func (fake *CFFeatureFlagRepository) EnsureFeatureEnabledArgsForCall(i int) (context.Context, authorization.Info, string) {
+	fake.ensureFeatureEnabledMutex.RLock()
+	defer fake.ensureFeatureEnabledMutex.RUnlock()
+	argsForCall := fake.ensureFeatureEnabledArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *CFFeatureFlagRepository) EnsureFeatureEnabledReturns(result1 error) {
+	fake.ensureFeatureEnabledMutex.Lock()
+	defer fake.ensureFeatureEnabledMutex.Unlock()
+	fake.EnsureFeatureEnabledStub = nil
+	fake.ensureFeatureEnabledReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *CFFeatureFlagRepository) EnsureFeatureEnabledReturnsOnCall(i int, result1 error) {
+	fake.ensureFeatureEnabledMutex.Lock()
+	defer fake.ensureFeatureEnabledMutex.Unlock()
+	fake.EnsureFeatureEnabledStub = nil
+	if fake.ensureFeatureEnabledReturnsOnCall == nil {
+		fake.ensureFeatureEnabledReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.ensureFeatureEnabledReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *CFFeatureFlagRepository) GetFeatureFlag(arg1 context.Context, arg2 authorization.Info, arg3 string) (repositories.FeatureFlagRecord, error) {
+	fake.getFeatureFlagMutex.Lock()
+	ret, specificReturn := fake.getFeatureFlagReturnsOnCall[len(fake.getFeatureFlagArgsForCall)]
+	fake.getFeatureFlagArgsForCall = append(fake.getFeatureFlagArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.GetFeatureFlagStub
+	fakeReturns := fake.getFeatureFlagReturns
+	fake.recordInvocation("GetFeatureFlag", []interface{}{arg1, arg2, arg3})
+	fake.getFeatureFlagMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *CFFeatureFlagRepository) GetFeatureFlagCallCount() int {
+	fake.getFeatureFlagMutex.RLock()
+	defer fake.getFeatureFlagMutex.RUnlock()
+	return len(fake.getFeatureFlagArgsForCall)
+}
+
+func (fake *CFFeatureFlagRepository) GetFeatureFlagCalls(stub func(context.Context, authorization.Info, string) (repositories.FeatureFlagRecord, error)) {
+	fake.getFeatureFlagMutex.Lock()
+	defer fake.getFeatureFlagMutex.Unlock()
+	fake.GetFeatureFlagStub = stub
+}
+
+func (fake *CFFeatureFlagRepository) GetFeatureFlagArgsForCall(i int) (context.Context, authorization.Info, string) {
+	fake.getFeatureFlagMutex.RLock()
+	defer fake.getFeatureFlagMutex.RUnlock()
+	argsForCall := fake.getFeatureFlagArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *CFFeatureFlagRepository) GetFeatureFlagReturns(result1 repositories.FeatureFlagRecord, result2 error) {
+	fake.getFeatureFlagMutex.Lock()
+	defer fake.getFeatureFlagMutex.Unlock()
+	fake.GetFeatureFlagStub = nil
+	fake.getFeatureFlagReturns = struct {
+		result1 repositories.FeatureFlagRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFFeatureFlagRepository) GetFeatureFlagReturnsOnCall(i int, result1 repositories.FeatureFlagRecord, result2 error) {
+	fake.getFeatureFlagMutex.Lock()
+	defer fake.getFeatureFlagMutex.Unlock()
+	fake.GetFeatureFlagStub = nil
+	if fake.getFeatureFlagReturnsOnCall == nil {
+		fake.getFeatureFlagReturnsOnCall = make(map[int]struct {
+			result1 repositories.FeatureFlagRecord
+			result2 error
+		})
+	}
+	fake.getFeatureFlagReturnsOnCall[i] = struct {
+		result1 repositories.FeatureFlagRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFFeatureFlagRepository) ListFeatureFlags(arg1 context.Context, arg2 authorization.Info) ([]repositories.FeatureFlagRecord, error) {
+	fake.listFeatureFlagsMutex.Lock()
+	ret, specificReturn := fake.listFeatureFlagsReturnsOnCall[len(fake.listFeatureFlagsArgsForCall)]
+	fake.listFeatureFlagsArgsForCall = append(fake.listFeatureFlagsArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+	}{arg1, arg2})
+	stub := fake.ListFeatureFlagsStub
+	fakeReturns := fake.listFeatureFlagsReturns
+	fake.recordInvocation("ListFeatureFlags", []interface{}{arg1, arg2})
+	fake.listFeatureFlagsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *CFFeatureFlagRepository) ListFeatureFlagsCallCount() int {
+	fake.listFeatureFlagsMutex.RLock()
+	defer fake.listFeatureFlagsMutex.RUnlock()
+	return len(fake.listFeatureFlagsArgsForCall)
+}
+
+func (fake *CFFeatureFlagRepository) ListFeatureFlagsCalls(stub func(context.Context, authorization.Info) ([]repositories.FeatureFlagRecord, error)) {
+	fake.listFeatureFlagsMutex.Lock()
+	defer fake.listFeatureFlagsMutex.Unlock()
+	fake.ListFeatureFlagsStub = stub
+}
+
+func (fake *CFFeatureFlagRepository) ListFeatureFlagsArgsForCall(i int) (context.Context, authorization.Info) {
+	fake.listFeatureFlagsMutex.RLock()
+	defer fake.listFeatureFlagsMutex.RUnlock()
+	argsForCall := fake.listFeatureFlagsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *CFFeatureFlagRepository) ListFeatureFlagsReturns(result1 []repositories.FeatureFlagRecord, result2 error) {
+	fake.listFeatureFlagsMutex.Lock()
+	defer fake.listFeatureFlagsMutex.Unlock()
+	fake.ListFeatureFlagsStub = nil
+	fake.listFeatureFlagsReturns = struct {
+		result1 []repositories.FeatureFlagRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFFeatureFlagRepository) ListFeatureFlagsReturnsOnCall(i int, result1 []repositories.FeatureFlagRecord, result2 error) {
+	fake.listFeatureFlagsMutex.Lock()
+	defer fake.listFeatureFlagsMutex.Unlock()
+	fake.ListFeatureFlagsStub = nil
+	if fake.listFeatureFlagsReturnsOnCall == nil {
+		fake.listFeatureFlagsReturnsOnCall = make(map[int]struct {
+			result1 []repositories.FeatureFlagRecord
+			result2 error
+		})
+	}
+	fake.listFeatureFlagsReturnsOnCall[i] = struct {
+		result1 []repositories.FeatureFlagRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFFeatureFlagRepository) UpdateFeatureFlag(arg1 context.Context, arg2 authorization.Info, arg3 repositories.UpdateFeatureFlagMessage) (repositories.FeatureFlagRecord, error) {
+	fake.updateFeatureFlagMutex.Lock()
+	ret, specificReturn := fake.updateFeatureFlagReturnsOnCall[len(fake.updateFeatureFlagArgsForCall)]
+	fake.updateFeatureFlagArgsForCall = append(fake.updateFeatureFlagArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.UpdateFeatureFlagMessage
+	}{arg1, arg2, arg3})
+	stub := fake.UpdateFeatureFlagStub
+	fakeReturns := fake.updateFeatureFlagReturns
+	fake.recordInvocation("UpdateFeatureFlag", []interface{}{arg1, arg2, arg3})
+	fake.updateFeatureFlagMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *CFFeatureFlagRepository) UpdateFeatureFlagCallCount() int {
+	fake.updateFeatureFlagMutex.RLock()
+	defer fake.updateFeatureFlagMutex.RUnlock()
+	return len(fake.updateFeatureFlagArgsForCall)
+}
+
+func (fake *CFFeatureFlagRepository) UpdateFeatureFlagCalls(stub func(context.Context, authorization.Info, repositories.UpdateFeatureFlagMessage) (repositories.FeatureFlagRecord, error)) {
+	fake.updateFeatureFlagMutex.Lock()
+	defer fake.updateFeatureFlagMutex.Unlock()
+	fake.UpdateFeatureFlagStub = stub
+}
+
+func (fake *CFFeatureFlagRepository) UpdateFeatureFlagArgsForCall(i int) (context.Context, authorization.Info, repositories.UpdateFeatureFlagMessage) {
+	fake.updateFeatureFlagMutex.RLock()
+	defer fake.updateFeatureFlagMutex.RUnlock()
+	argsForCall := fake.updateFeatureFlagArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *CFFeatureFlagRepository) UpdateFeatureFlagReturns(result1 repositories.FeatureFlagRecord, result2 error) {
+	fake.updateFeatureFlagMutex.Lock()
+	defer fake.updateFeatureFlagMutex.Unlock()
+	fake.UpdateFeatureFlagStub = nil
+	fake.updateFeatureFlagReturns = struct {
+		result1 repositories.FeatureFlagRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFFeatureFlagRepository) UpdateFeatureFlagReturnsOnCall(i int, result1 repositories.FeatureFlagRecord, result2 error) {
+	fake.updateFeatureFlagMutex.Lock()
+	defer fake.updateFeatureFlagMutex.Unlock()
+	fake.UpdateFeatureFlagStub = nil
+	if fake.updateFeatureFlagReturnsOnCall == nil {
+		fake.updateFeatureFlagReturnsOnCall = make(map[int]struct {
+			result1 repositories.FeatureFlagRecord
+			result2 error
+		})
+	}
+	fake.updateFeatureFlagReturnsOnCall[i] = struct {
+		result1 repositories.FeatureFlagRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFFeatureFlagRepository) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.ensureFeatureEnabledMutex.RLock()
+	defer fake.ensureFeatureEnabledMutex.RUnlock()
+	fake.getFeatureFlagMutex.RLock()
+	defer fake.getFeatureFlagMutex.RUnlock()
+	fake.listFeatureFlagsMutex.RLock()
+	defer fake.listFeatureFlagsMutex.RUnlock()
+	fake.updateFeatureFlagMutex.RLock()
+	defer fake.updateFeatureFlagMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *CFFeatureFlagRepository) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ handlers.CFFeatureFlagRepository = new(CFFeatureFlagRepository)
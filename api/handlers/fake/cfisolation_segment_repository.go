@@ -0,0 +1,611 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fake
+
+import (
+	"context"
+	"sync"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	"code.cloudfoundry.org/korifi/api/handlers"
+	"code.cloudfoundry.org/korifi/api/repositories"
+)
+
+type CFIsolationSegmentRepository struct {
+	CreateIsolationSegmentStub        func(context.Context, authorization.Info, repositories.CreateIsolationSegmentMessage) (repositories.IsolationSegmentRecord, error)
+	createIsolationSegmentMutex       sync.RWMutex
+	createIsolationSegmentArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.CreateIsolationSegmentMessage
+	}
+	createIsolationSegmentReturns struct {
+		result1 repositories.IsolationSegmentRecord
+		result2 error
+	}
+	createIsolationSegmentReturnsOnCall map[int]struct {
+		result1 repositories.IsolationSegmentRecord
+		result2 error
+	}
+	DeleteIsolationSegmentStub        func(context.Context, authorization.Info, string) error
+	deleteIsolationSegmentMutex       sync.RWMutex
+	deleteIsolationSegmentArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+	}
+	deleteIsolationSegmentReturns struct {
+		result1 error
+	}
+	deleteIsolationSegmentReturnsOnCall map[int]struct {
+		result1 error
+	}
+	EntitleOrganizationsStub        func(context.Context, authorization.Info, repositories.EntitleIsolationSegmentMessage) (repositories.IsolationSegmentRecord, error)
+	entitleOrganizationsMutex       sync.RWMutex
+	entitleOrganizationsArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.EntitleIsolationSegmentMessage
+	}
+	entitleOrganizationsReturns struct {
+		result1 repositories.IsolationSegmentRecord
+		result2 error
+	}
+	entitleOrganizationsReturnsOnCall map[int]struct {
+		result1 repositories.IsolationSegmentRecord
+		result2 error
+	}
+	GetIsolationSegmentStub        func(context.Context, authorization.Info, string) (repositories.IsolationSegmentRecord, error)
+	getIsolationSegmentMutex       sync.RWMutex
+	getIsolationSegmentArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+	}
+	getIsolationSegmentReturns struct {
+		result1 repositories.IsolationSegmentRecord
+		result2 error
+	}
+	getIsolationSegmentReturnsOnCall map[int]struct {
+		result1 repositories.IsolationSegmentRecord
+		result2 error
+	}
+	ListIsolationSegmentsStub        func(context.Context, authorization.Info, repositories.ListIsolationSegmentsMessage) ([]repositories.IsolationSegmentRecord, error)
+	listIsolationSegmentsMutex       sync.RWMutex
+	listIsolationSegmentsArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.ListIsolationSegmentsMessage
+	}
+	listIsolationSegmentsReturns struct {
+		result1 []repositories.IsolationSegmentRecord
+		result2 error
+	}
+	listIsolationSegmentsReturnsOnCall map[int]struct {
+		result1 []repositories.IsolationSegmentRecord
+		result2 error
+	}
+	RevokeOrganizationStub        func(context.Context, authorization.Info, repositories.RevokeIsolationSegmentMessage) error
+	revokeOrganizationMutex       sync.RWMutex
+	revokeOrganizationArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.RevokeIsolationSegmentMessage
+	}
+	revokeOrganizationReturns struct {
+		result1 error
+	}
+	revokeOrganizationReturnsOnCall map[int]struct {
+		result1 error
+	}
+	UpdateIsolationSegmentStub        func(context.Context, authorization.Info, repositories.UpdateIsolationSegmentMessage) (repositories.IsolationSegmentRecord, error)
+	updateIsolationSegmentMutex       sync.RWMutex
+	updateIsolationSegmentArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.UpdateIsolationSegmentMessage
+	}
+	updateIsolationSegmentReturns struct {
+		result1 repositories.IsolationSegmentRecord
+		result2 error
+	}
+	updateIsolationSegmentReturnsOnCall map[int]struct {
+		result1 repositories.IsolationSegmentRecord
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *CFIsolationSegmentRepository) CreateIsolationSegment(arg1 context.Context, arg2 authorization.Info, arg3 repositories.CreateIsolationSegmentMessage) (repositories.IsolationSegmentRecord, error) {
+	fake.createIsolationSegmentMutex.Lock()
+	ret, specificReturn := fake.createIsolationSegmentReturnsOnCall[len(fake.createIsolationSegmentArgsForCall)]
+	fake.createIsolationSegmentArgsForCall = append(fake.createIsolationSegmentArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.CreateIsolationSegmentMessage
+	}{arg1, arg2, arg3})
+	stub := fake.CreateIsolationSegmentStub
+	fakeReturns := fake.createIsolationSegmentReturns
+	fake.recordInvocation("CreateIsolationSegment", []interface{}{arg1, arg2, arg3})
+	fake.createIsolationSegmentMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *CFIsolationSegmentRepository) CreateIsolationSegmentCallCount() int {
+	fake.createIsolationSegmentMutex.RLock()
+	defer fake.createIsolationSegmentMutex.RUnlock()
+	return len(fake.createIsolationSegmentArgsForCall)
+}
+
+func (fake *CFIsolationSegmentRepository) CreateIsolationSegmentCalls(stub func(context.Context, authorization.Info, repositories.CreateIsolationSegmentMessage) (repositories.IsolationSegmentRecord, error)) {
+	fake.createIsolationSegmentMutex.Lock()
+	defer fake.createIsolationSegmentMutex.Unlock()
+	fake.CreateIsolationSegmentStub = stub
+}
+
+func (fake *CFIsolationSegmentRepository) CreateIsolationSegmentArgsForCall(i int) (context.Context, authorization.Info, repositories.CreateIsolationSegmentMessage) {
+	fake.createIsolationSegmentMutex.RLock()
+	defer fake.createIsolationSegmentMutex.RUnlock()
+	argsForCall := fake.createIsolationSegmentArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *CFIsolationSegmentRepository) CreateIsolationSegmentReturns(result1 repositories.IsolationSegmentRecord, result2 error) {
+	fake.createIsolationSegmentMutex.Lock()
+	defer fake.createIsolationSegmentMutex.Unlock()
+	fake.CreateIsolationSegmentStub = nil
+	fake.createIsolationSegmentReturns = struct {
+		result1 repositories.IsolationSegmentRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFIsolationSegmentRepository) CreateIsolationSegmentReturnsOnCall(i int, result1 repositories.IsolationSegmentRecord, result2 error) {
+	fake.createIsolationSegmentMutex.Lock()
+	defer fake.createIsolationSegmentMutex.Unlock()
+	fake.CreateIsolationSegmentStub = nil
+	if fake.createIsolationSegmentReturnsOnCall == nil {
+		fake.createIsolationSegmentReturnsOnCall = make(map[int]struct {
+			result1 repositories.IsolationSegmentRecord
+			result2 error
+		})
+	}
+	fake.createIsolationSegmentReturnsOnCall[i] = struct {
+		result1 repositories.IsolationSegmentRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFIsolationSegmentRepository) DeleteIsolationSegment(arg1 context.Context, arg2 authorization.Info, arg3 string) error {
+	fake.deleteIsolationSegmentMutex.Lock()
+	ret, specificReturn := fake.deleteIsolationSegmentReturnsOnCall[len(fake.deleteIsolationSegmentArgsForCall)]
+	fake.deleteIsolationSegmentArgsForCall = append(fake.deleteIsolationSegmentArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.DeleteIsolationSegmentStub
+	fakeReturns := fake.deleteIsolationSegmentReturns
+	fake.recordInvocation("DeleteIsolationSegment", []interface{}{arg1, arg2, arg3})
+	fake.deleteIsolationSegmentMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *CFIsolationSegmentRepository) DeleteIsolationSegmentCallCount() int {
+	fake.deleteIsolationSegmentMutex.RLock()
+	defer fake.deleteIsolationSegmentMutex.RUnlock()
+	return len(fake.deleteIsolationSegmentArgsForCall)
+}
+
+func (fake *CFIsolationSegmentRepository) DeleteIsolationSegmentCalls(stub func(context.Context, authorization.Info, string) error) {
+	fake.deleteIsolationSegmentMutex.Lock()
+	defer fake.deleteIsolationSegmentMutex.Unlock()
+	fake.DeleteIsolationSegmentStub = stub
+}
+
+func (fake *CFIsolationSegmentRepository) DeleteIsolationSegmentArgsForCall(i int) (context.Context, authorization.Info, string) {
+	fake.deleteIsolationSegmentMutex.RLock()
+	defer fake.deleteIsolationSegmentMutex.RUnlock()
+	argsForCall := fake.deleteIsolationSegmentArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *CFIsolationSegmentRepository) DeleteIsolationSegmentReturns(result1 error) {
+	fake.deleteIsolationSegmentMutex.Lock()
+	defer fake.deleteIsolationSegmentMutex.Unlock()
+	fake.DeleteIsolationSegmentStub = nil
+	fake.deleteIsolationSegmentReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *CFIsolationSegmentRepository) DeleteIsolationSegmentReturnsOnCall(i int, result1 error) {
+	fake.deleteIsolationSegmentMutex.Lock()
+	defer fake.deleteIsolationSegmentMutex.Unlock()
+	fake.DeleteIsolationSegmentStub = nil
+	if fake.deleteIsolationSegmentReturnsOnCall == nil {
+		fake.deleteIsolationSegmentReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.deleteIsolationSegmentReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *CFIsolationSegmentRepository) EntitleOrganizations(arg1 context.Context, arg2 authorization.Info, arg3 repositories.EntitleIsolationSegmentMessage) (repositories.IsolationSegmentRecord, error) {
+	fake.entitleOrganizationsMutex.Lock()
+	ret, specificReturn := fake.entitleOrganizationsReturnsOnCall[len(fake.entitleOrganizationsArgsForCall)]
+	fake.entitleOrganizationsArgsForCall = append(fake.entitleOrganizationsArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.EntitleIsolationSegmentMessage
+	}{arg1, arg2, arg3})
+	stub := fake.EntitleOrganizationsStub
+	fakeReturns := fake.entitleOrganizationsReturns
+	fake.recordInvocation("EntitleOrganizations", []interface{}{arg1, arg2, arg3})
+	fake.entitleOrganizationsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *CFIsolationSegmentRepository) EntitleOrganizationsCallCount() int {
+	fake.entitleOrganizationsMutex.RLock()
+	defer fake.entitleOrganizationsMutex.RUnlock()
+	return len(fake.entitleOrganizationsArgsForCall)
+}
+
+func (fake *CFIsolationSegmentRepository) EntitleOrganizationsCalls(stub func(context.Context, authorization.Info, repositories.EntitleIsolationSegmentMessage) (repositories.IsolationSegmentRecord, error)) {
+	fake.entitleOrganizationsMutex.Lock()
+	defer fake.entitleOrganizationsMutex.Unlock()
+	fake.EntitleOrganizationsStub = stub
+}
+
+func (fake *CFIsolationSegmentRepository) EntitleOrganizationsArgsForCall(i int) (context.Context, authorization.Info, repositories.EntitleIsolationSegmentMessage) {
+	fake.entitleOrganizationsMutex.RLock()
+	defer fake.entitleOrganizationsMutex.RUnlock()
+	argsForCall := fake.entitleOrganizationsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *CFIsolationSegmentRepository) EntitleOrganizationsReturns(result1 repositories.IsolationSegmentRecord, result2 error) {
+	fake.entitleOrganizationsMutex.Lock()
+	defer fake.entitleOrganizationsMutex.Unlock()
+	fake.EntitleOrganizationsStub = nil
+	fake.entitleOrganizationsReturns = struct {
+		result1 repositories.IsolationSegmentRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFIsolationSegmentRepository) EntitleOrganizationsReturnsOnCall(i int, result1 repositories.IsolationSegmentRecord, result2 error) {
+	fake.entitleOrganizationsMutex.Lock()
+	defer fake.entitleOrganizationsMutex.Unlock()
+	fake.EntitleOrganizationsStub = nil
+	if fake.entitleOrganizationsReturnsOnCall == nil {
+		fake.entitleOrganizationsReturnsOnCall = make(map[int]struct {
+			result1 repositories.IsolationSegmentRecord
+			result2 error
+		})
+	}
+	fake.entitleOrganizationsReturnsOnCall[i] = struct {
+		result1 repositories.IsolationSegmentRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFIsolationSegmentRepository) GetIsolationSegment(arg1 context.Context, arg2 authorization.Info, arg3 string) (repositories.IsolationSegmentRecord, error) {
+	fake.getIsolationSegmentMutex.Lock()
+	ret, specificReturn := fake.getIsolationSegmentReturnsOnCall[len(fake.getIsolationSegmentArgsForCall)]
+	fake.getIsolationSegmentArgsForCall = append(fake.getIsolationSegmentArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.GetIsolationSegmentStub
+	fakeReturns := fake.getIsolationSegmentReturns
+	fake.recordInvocation("GetIsolationSegment", []interface{}{arg1, arg2, arg3})
+	fake.getIsolationSegmentMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *CFIsolationSegmentRepository) GetIsolationSegmentCallCount() int {
+	fake.getIsolationSegmentMutex.RLock()
+	defer fake.getIsolationSegmentMutex.RUnlock()
+	return len(fake.getIsolationSegmentArgsForCall)
+}
+
+func (fake *CFIsolationSegmentRepository) GetIsolationSegmentCalls(stub func(context.Context, authorization.Info, string) (repositories.IsolationSegmentRecord, error)) {
+	fake.getIsolationSegmentMutex.Lock()
+	defer fake.getIsolationSegmentMutex.Unlock()
+	fake.GetIsolationSegmentStub = stub
+}
+
+func (fake *CFIsolationSegmentRepository) GetIsolationSegmentArgsForCall(i int) (context.Context, authorization.Info, string) {
+	fake.getIsolationSegmentMutex.RLock()
+	defer fake.getIsolationSegmentMutex.RUnlock()
+	argsForCall := fake.getIsolationSegmentArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *CFIsolationSegmentRepository) GetIsolationSegmentReturns(result1 repositories.IsolationSegmentRecord, result2 error) {
+	fake.getIsolationSegmentMutex.Lock()
+	defer fake.getIsolationSegmentMutex.Unlock()
+	fake.GetIsolationSegmentStub = nil
+	fake.getIsolationSegmentReturns = struct {
+		result1 repositories.IsolationSegmentRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFIsolationSegmentRepository) GetIsolationSegmentReturnsOnCall(i int, result1 repositories.IsolationSegmentRecord, result2 error) {
+	fake.getIsolationSegmentMutex.Lock()
+	defer fake.getIsolationSegmentMutex.Unlock()
+	fake.GetIsolationSegmentStub = nil
+	if fake.getIsolationSegmentReturnsOnCall == nil {
+		fake.getIsolationSegmentReturnsOnCall = make(map[int]struct {
+			result1 repositories.IsolationSegmentRecord
+			result2 error
+		})
+	}
+	fake.getIsolationSegmentReturnsOnCall[i] = struct {
+		result1 repositories.IsolationSegmentRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFIsolationSegmentRepository) ListIsolationSegments(arg1 context.Context, arg2 authorization.Info, arg3 repositories.ListIsolationSegmentsMessage) ([]repositories.IsolationSegmentRecord, error) {
+	fake.listIsolationSegmentsMutex.Lock()
+	ret, specificReturn := fake.listIsolationSegmentsReturnsOnCall[len(fake.listIsolationSegmentsArgsForCall)]
+	fake.listIsolationSegmentsArgsForCall = append(fake.listIsolationSegmentsArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.ListIsolationSegmentsMessage
+	}{arg1, arg2, arg3})
+	stub := fake.ListIsolationSegmentsStub
+	fakeReturns := fake.listIsolationSegmentsReturns
+	fake.recordInvocation("ListIsolationSegments", []interface{}{arg1, arg2, arg3})
+	fake.listIsolationSegmentsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *CFIsolationSegmentRepository) ListIsolationSegmentsCallCount() int {
+	fake.listIsolationSegmentsMutex.RLock()
+	defer fake.listIsolationSegmentsMutex.RUnlock()
+	return len(fake.listIsolationSegmentsArgsForCall)
+}
+
+func (fake *CFIsolationSegmentRepository) ListIsolationSegmentsCalls(stub func(context.Context, authorization.Info, repositories.ListIsolationSegmentsMessage) ([]repositories.IsolationSegmentRecord, error)) {
+	fake.listIsolationSegmentsMutex.Lock()
+	defer fake.listIsolationSegmentsMutex.Unlock()
+	fake.ListIsolationSegmentsStub = stub
+}
+
+func (fake *CFIsolationSegmentRepository) ListIsolationSegmentsArgsForCall(i int) (context.Context, authorization.Info, repositories.ListIsolationSegmentsMessage) {
+	fake.listIsolationSegmentsMutex.RLock()
+	defer fake.listIsolationSegmentsMutex.RUnlock()
+	argsForCall := fake.listIsolationSegmentsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *CFIsolationSegmentRepository) ListIsolationSegmentsReturns(result1 []repositories.IsolationSegmentRecord, result2 error) {
+	fake.listIsolationSegmentsMutex.Lock()
+	defer fake.listIsolationSegmentsMutex.Unlock()
+	fake.ListIsolationSegmentsStub = nil
+	fake.listIsolationSegmentsReturns = struct {
+		result1 []repositories.IsolationSegmentRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFIsolationSegmentRepository) ListIsolationSegmentsReturnsOnCall(i int, result1 []repositories.IsolationSegmentRecord, result2 error) {
+	fake.listIsolationSegmentsMutex.Lock()
+	defer fake.listIsolationSegmentsMutex.Unlock()
+	fake.ListIsolationSegmentsStub = nil
+	if fake.listIsolationSegmentsReturnsOnCall == nil {
+		fake.listIsolationSegmentsReturnsOnCall = make(map[int]struct {
+			result1 []repositories.IsolationSegmentRecord
+			result2 error
+		})
+	}
+	fake.listIsolationSegmentsReturnsOnCall[i] = struct {
+		result1 []repositories.IsolationSegmentRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFIsolationSegmentRepository) RevokeOrganization(arg1 context.Context, arg2 authorization.Info, arg3 repositories.RevokeIsolationSegmentMessage) error {
+	fake.revokeOrganizationMutex.Lock()
+	ret, specificReturn := fake.revokeOrganizationReturnsOnCall[len(fake.revokeOrganizationArgsForCall)]
+	fake.revokeOrganizationArgsForCall = append(fake.revokeOrganizationArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.RevokeIsolationSegmentMessage
+	}{arg1, arg2, arg3})
+	stub := fake.RevokeOrganizationStub
+	fakeReturns := fake.revokeOrganizationReturns
+	fake.recordInvocation("RevokeOrganization", []interface{}{arg1, arg2, arg3})
+	fake.revokeOrganizationMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *CFIsolationSegmentRepository) RevokeOrganizationCallCount() int {
+	fake.revokeOrganizationMutex.RLock()
+	defer fake.revokeOrganizationMutex.RUnlock()
+	return len(fake.revokeOrganizationArgsForCall)
+}
+
+func (fake *CFIsolationSegmentRepository) RevokeOrganizationCalls(stub func(context.Context, authorization.Info, repositories.RevokeIsolationSegmentMessage) error) {
+	fake.revokeOrganizationMutex.Lock()
+	defer fake.revokeOrganizationMutex.Unlock()
+	fake.RevokeOrganizationStub = stub
+}
+
+func (fake *CFIsolationSegmentRepository) RevokeOrganizationArgsForCall(i int) (context.Context, authorization.Info, repositories.RevokeIsolationSegmentMessage) {
+	fake.revokeOrganizationMutex.RLock()
+	defer fake.revokeOrganizationMutex.RUnlock()
+	argsForCall := fake.revokeOrganizationArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *CFIsolationSegmentRepository) RevokeOrganizationReturns(result1 error) {
+	fake.revokeOrganizationMutex.Lock()
+	defer fake.revokeOrganizationMutex.Unlock()
+	fake.RevokeOrganizationStub = nil
+	fake.revokeOrganizationReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *CFIsolationSegmentRepository) RevokeOrganizationReturnsOnCall(i int, result1 error) {
+	fake.revokeOrganizationMutex.Lock()
+	defer fake.revokeOrganizationMutex.Unlock()
+	fake.RevokeOrganizationStub = nil
+	if fake.revokeOrganizationReturnsOnCall == nil {
+		fake.revokeOrganizationReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.revokeOrganizationReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *CFIsolationSegmentRepository) UpdateIsolationSegment(arg1 context.Context, arg2 authorization.Info, arg3 repositories.UpdateIsolationSegmentMessage) (repositories.IsolationSegmentRecord, error) {
+	fake.updateIsolationSegmentMutex.Lock()
+	ret, specificReturn := fake.updateIsolationSegmentReturnsOnCall[len(fake.updateIsolationSegmentArgsForCall)]
+	fake.updateIsolationSegmentArgsForCall = append(fake.updateIsolationSegmentArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.UpdateIsolationSegmentMessage
+	}{arg1, arg2, arg3})
+	stub := fake.UpdateIsolationSegmentStub
+	fakeReturns := fake.updateIsolationSegmentReturns
+	fake.recordInvocation("UpdateIsolationSegment", []interface{}{arg1, arg2, arg3})
+	fake.updateIsolationSegmentMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *CFIsolationSegmentRepository) UpdateIsolationSegmentCallCount() int {
+	fake.updateIsolationSegmentMutex.RLock()
+	defer fake.updateIsolationSegmentMutex.RUnlock()
+	return len(fake.updateIsolationSegmentArgsForCall)
+}
+
+func (fake *CFIsolationSegmentRepository) UpdateIsolationSegmentCalls(stub func(context.Context, authorization.Info, repositories.UpdateIsolationSegmentMessage) (repositories.IsolationSegmentRecord, error)) {
+	fake.updateIsolationSegmentMutex.Lock()
+	defer fake.updateIsolationSegmentMutex.Unlock()
+	fake.UpdateIsolationSegmentStub = stub
+}
+
+func (fake *CFIsolationSegmentRepository) UpdateIsolationSegmentArgsForCall(i int) (context.Context, authorization.Info, repositories.UpdateIsolationSegmentMessage) {
+	fake.updateIsolationSegmentMutex.RLock()
+	defer fake.updateIsolationSegmentMutex.RUnlock()
+	argsForCall := fake.updateIsolationSegmentArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *CFIsolationSegmentRepository) UpdateIsolationSegmentReturns(result1 repositories.IsolationSegmentRecord, result2 error) {
+	fake.updateIsolationSegmentMutex.Lock()
+	defer fake.updateIsolationSegmentMutex.Unlock()
+	fake.UpdateIsolationSegmentStub = nil
+	fake.updateIsolationSegmentReturns = struct {
+		result1 repositories.IsolationSegmentRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFIsolationSegmentRepository) UpdateIsolationSegmentReturnsOnCall(i int, result1 repositories.IsolationSegmentRecord, result2 error) {
+	fake.updateIsolationSegmentMutex.Lock()
+	defer fake.updateIsolationSegmentMutex.Unlock()
+	fake.UpdateIsolationSegmentStub = nil
+	if fake.updateIsolationSegmentReturnsOnCall == nil {
+		fake.updateIsolationSegmentReturnsOnCall = make(map[int]struct {
+			result1 repositories.IsolationSegmentRecord
+			result2 error
+		})
+	}
+	fake.updateIsolationSegmentReturnsOnCall[i] = struct {
+		result1 repositories.IsolationSegmentRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFIsolationSegmentRepository) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.createIsolationSegmentMutex.RLock()
+	defer fake.createIsolationSegmentMutex.RUnlock()
+	fake.deleteIsolationSegmentMutex.RLock()
+	defer fake.deleteIsolationSegmentMutex.RUnlock()
+	fake.entitleOrganizationsMutex.RLock()
+	defer fake.entitleOrganizationsMutex.RUnlock()
+	fake.getIsolationSegmentMutex.RLock()
+	defer fake.getIsolationSegmentMutex.RUnlock()
+	fake.listIsolationSegmentsMutex.RLock()
+	defer fake.listIsolationSegmentsMutex.RUnlock()
+	fake.revokeOrganizationMutex.RLock()
+	defer fake.revokeOrganizationMutex.RUnlock()
+	fake.updateIsolationSegmentMutex.RLock()
+	defer fake.updateIsolationSegmentMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *CFIsolationSegmentRepository) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ handlers.CFIsolationSegmentRepository = new(CFIsolationSegmentRepository)
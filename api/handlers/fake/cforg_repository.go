@@ -27,6 +27,21 @@ type CFOrgRepository struct {
 		result1 repositories.OrgRecord
 		result2 error
 	}
+	CreateOrgAsManagerStub        func(context.Context, authorization.Identity, repositories.CreateOrgMessage) (repositories.OrgRecord, error)
+	createOrgAsManagerMutex       sync.RWMutex
+	createOrgAsManagerArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Identity
+		arg3 repositories.CreateOrgMessage
+	}
+	createOrgAsManagerReturns struct {
+		result1 repositories.OrgRecord
+		result2 error
+	}
+	createOrgAsManagerReturnsOnCall map[int]struct {
+		result1 repositories.OrgRecord
+		result2 error
+	}
 	DeleteOrgStub        func(context.Context, authorization.Info, repositories.DeleteOrgMessage) error
 	deleteOrgMutex       sync.RWMutex
 	deleteOrgArgsForCall []struct {
@@ -170,6 +185,72 @@ func (fake *CFOrgRepository) CreateOrgReturnsOnCall(i int, result1 repositories.
 	}{result1, result2}
 }
 
+func (fake *CFOrgRepository) CreateOrgAsManager(arg1 context.Context, arg2 authorization.Identity, arg3 repositories.CreateOrgMessage) (repositories.OrgRecord, error) {
+	fake.createOrgAsManagerMutex.Lock()
+	ret, specificReturn := fake.createOrgAsManagerReturnsOnCall[len(fake.createOrgAsManagerArgsForCall)]
+	fake.createOrgAsManagerArgsForCall = append(fake.createOrgAsManagerArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Identity
+		arg3 repositories.CreateOrgMessage
+	}{arg1, arg2, arg3})
+	stub := fake.CreateOrgAsManagerStub
+	fakeReturns := fake.createOrgAsManagerReturns
+	fake.recordInvocation("CreateOrgAsManager", []interface{}{arg1, arg2, arg3})
+	fake.createOrgAsManagerMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *CFOrgRepository) CreateOrgAsManagerCallCount() int {
+	fake.createOrgAsManagerMutex.RLock()
+	defer fake.createOrgAsManagerMutex.RUnlock()
+	return len(fake.createOrgAsManagerArgsForCall)
+}
+
+func (fake *CFOrgRepository) CreateOrgAsManagerCalls(stub func(context.Context, authorization.Identity, repositories.CreateOrgMessage) (repositories.OrgRecord, error)) {
+	fake.createOrgAsManagerMutex.Lock()
+	defer fake.createOrgAsManagerMutex.Unlock()
+	fake.CreateOrgAsManagerStub = stub
+}
+
+func (fake *CFOrgRepository) CreateOrgAsManagerArgsForCall(i int) (context.Context, authorization.Identity, repositories.CreateOrgMessage) {
+	fake.createOrgAsManagerMutex.RLock()
+	defer fake.createOrgAsManagerMutex.RUnlock()
+	argsForCall := fake.createOrgAsManagerArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *CFOrgRepository) CreateOrgAsManagerReturns(result1 repositories.OrgRecord, result2 error) {
+	fake.createOrgAsManagerMutex.Lock()
+	defer fake.createOrgAsManagerMutex.Unlock()
+	fake.CreateOrgAsManagerStub = nil
+	fake.createOrgAsManagerReturns = struct {
+		result1 repositories.OrgRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFOrgRepository) CreateOrgAsManagerReturnsOnCall(i int, result1 repositories.OrgRecord, result2 error) {
+	fake.createOrgAsManagerMutex.Lock()
+	defer fake.createOrgAsManagerMutex.Unlock()
+	fake.CreateOrgAsManagerStub = nil
+	if fake.createOrgAsManagerReturnsOnCall == nil {
+		fake.createOrgAsManagerReturnsOnCall = make(map[int]struct {
+			result1 repositories.OrgRecord
+			result2 error
+		})
+	}
+	fake.createOrgAsManagerReturnsOnCall[i] = struct {
+		result1 repositories.OrgRecord
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *CFOrgRepository) DeleteOrg(arg1 context.Context, arg2 authorization.Info, arg3 repositories.DeleteOrgMessage) error {
 	fake.deleteOrgMutex.Lock()
 	ret, specificReturn := fake.deleteOrgReturnsOnCall[len(fake.deleteOrgArgsForCall)]
@@ -502,6 +583,8 @@ func (fake *CFOrgRepository) Invocations() map[string][][]interface{} {
 	defer fake.invocationsMutex.RUnlock()
 	fake.createOrgMutex.RLock()
 	defer fake.createOrgMutex.RUnlock()
+	fake.createOrgAsManagerMutex.RLock()
+	defer fake.createOrgAsManagerMutex.RUnlock()
 	fake.deleteOrgMutex.RLock()
 	defer fake.deleteOrgMutex.RUnlock()
 	fake.getDeletedAtMutex.RLock()
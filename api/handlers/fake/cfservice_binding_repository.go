@@ -69,6 +69,21 @@ type CFServiceBindingRepository struct {
 		result1 repositories.ServiceBindingDetailsRecord
 		result2 error
 	}
+	GetServiceBindingParametersStub        func(context.Context, authorization.Info, string) (map[string]any, error)
+	getServiceBindingParametersMutex       sync.RWMutex
+	getServiceBindingParametersArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+	}
+	getServiceBindingParametersReturns struct {
+		result1 map[string]any
+		result2 error
+	}
+	getServiceBindingParametersReturnsOnCall map[int]struct {
+		result1 map[string]any
+		result2 error
+	}
 	ListServiceBindingsStub        func(context.Context, authorization.Info, repositories.ListServiceBindingsMessage) ([]repositories.ServiceBindingRecord, error)
 	listServiceBindingsMutex       sync.RWMutex
 	listServiceBindingsArgsForCall []struct {
@@ -84,6 +99,21 @@ type CFServiceBindingRepository struct {
 		result1 []repositories.ServiceBindingRecord
 		result2 error
 	}
+	RotateServiceBindingCredentialsStub        func(context.Context, authorization.Info, string) (repositories.ServiceBindingRecord, error)
+	rotateServiceBindingCredentialsMutex       sync.RWMutex
+	rotateServiceBindingCredentialsArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+	}
+	rotateServiceBindingCredentialsReturns struct {
+		result1 repositories.ServiceBindingRecord
+		result2 error
+	}
+	rotateServiceBindingCredentialsReturnsOnCall map[int]struct {
+		result1 repositories.ServiceBindingRecord
+		result2 error
+	}
 	UpdateServiceBindingStub        func(context.Context, authorization.Info, repositories.UpdateServiceBindingMessage) (repositories.ServiceBindingRecord, error)
 	updateServiceBindingMutex       sync.RWMutex
 	updateServiceBindingArgsForCall []struct {
@@ -364,6 +394,72 @@ func (fake *CFServiceBindingRepository) GetServiceBindingDetailsReturnsOnCall(i
 	}{result1, result2}
 }
 
+func (fake *CFServiceBindingRepository) GetServiceBindingParameters(arg1 context.Context, arg2 authorization.Info, arg3 string) (map[string]any, error) {
+	fake.getServiceBindingParametersMutex.Lock()
+	ret, specificReturn := fake.getServiceBindingParametersReturnsOnCall[len(fake.getServiceBindingParametersArgsForCall)]
+	fake.getServiceBindingParametersArgsForCall = append(fake.getServiceBindingParametersArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.GetServiceBindingParametersStub
+	fakeReturns := fake.getServiceBindingParametersReturns
+	fake.recordInvocation("GetServiceBindingParameters", []interface{}{arg1, arg2, arg3})
+	fake.getServiceBindingParametersMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *CFServiceBindingRepository) GetServiceBindingParametersCallCount() int {
+	fake.getServiceBindingParametersMutex.RLock()
+	defer fake.getServiceBindingParametersMutex.RUnlock()
+	return len(fake.getServiceBindingParametersArgsForCall)
+}
+
+func (fake *CFServiceBindingRepository) GetServiceBindingParametersCalls(stub func(context.Context, authorization.Info, string) (map[string]any, error)) {
+	fake.getServiceBindingParametersMutex.Lock()
+	defer fake.getServiceBindingParametersMutex.Unlock()
+	fake.GetServiceBindingParametersStub = stub
+}
+
+func (fake *CFServiceBindingRepository) GetServiceBindingParametersArgsForCall(i int) (context.Context, authorization.Info, string) {
+	fake.getServiceBindingParametersMutex.RLock()
+	defer fake.getServiceBindingParametersMutex.RUnlock()
+	argsForCall := fake.getServiceBindingParametersArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *CFServiceBindingRepository) GetServiceBindingParametersReturns(result1 map[string]any, result2 error) {
+	fake.getServiceBindingParametersMutex.Lock()
+	defer fake.getServiceBindingParametersMutex.Unlock()
+	fake.GetServiceBindingParametersStub = nil
+	fake.getServiceBindingParametersReturns = struct {
+		result1 map[string]any
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFServiceBindingRepository) GetServiceBindingParametersReturnsOnCall(i int, result1 map[string]any, result2 error) {
+	fake.getServiceBindingParametersMutex.Lock()
+	defer fake.getServiceBindingParametersMutex.Unlock()
+	fake.GetServiceBindingParametersStub = nil
+	if fake.getServiceBindingParametersReturnsOnCall == nil {
+		fake.getServiceBindingParametersReturnsOnCall = make(map[int]struct {
+			result1 map[string]any
+			result2 error
+		})
+	}
+	fake.getServiceBindingParametersReturnsOnCall[i] = struct {
+		result1 map[string]any
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *CFServiceBindingRepository) ListServiceBindings(arg1 context.Context, arg2 authorization.Info, arg3 repositories.ListServiceBindingsMessage) ([]repositories.ServiceBindingRecord, error) {
 	fake.listServiceBindingsMutex.Lock()
 	ret, specificReturn := fake.listServiceBindingsReturnsOnCall[len(fake.listServiceBindingsArgsForCall)]
@@ -430,6 +526,72 @@ func (fake *CFServiceBindingRepository) ListServiceBindingsReturnsOnCall(i int,
 	}{result1, result2}
 }
 
+func (fake *CFServiceBindingRepository) RotateServiceBindingCredentials(arg1 context.Context, arg2 authorization.Info, arg3 string) (repositories.ServiceBindingRecord, error) {
+	fake.rotateServiceBindingCredentialsMutex.Lock()
+	ret, specificReturn := fake.rotateServiceBindingCredentialsReturnsOnCall[len(fake.rotateServiceBindingCredentialsArgsForCall)]
+	fake.rotateServiceBindingCredentialsArgsForCall = append(fake.rotateServiceBindingCredentialsArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.RotateServiceBindingCredentialsStub
+	fakeReturns := fake.rotateServiceBindingCredentialsReturns
+	fake.recordInvocation("RotateServiceBindingCredentials", []interface{}{arg1, arg2, arg3})
+	fake.rotateServiceBindingCredentialsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *CFServiceBindingRepository) RotateServiceBindingCredentialsCallCount() int {
+	fake.rotateServiceBindingCredentialsMutex.RLock()
+	defer fake.rotateServiceBindingCredentialsMutex.RUnlock()
+	return len(fake.rotateServiceBindingCredentialsArgsForCall)
+}
+
+func (fake *CFServiceBindingRepository) RotateServiceBindingCredentialsCalls(stub func(context.Context, authorization.Info, string) (repositories.ServiceBindingRecord, error)) {
+	fake.rotateServiceBindingCredentialsMutex.Lock()
+	defer fake.rotateServiceBindingCredentialsMutex.Unlock()
+	fake.RotateServiceBindingCredentialsStub = stub
+}
+
+func (fake *CFServiceBindingRepository) RotateServiceBindingCredentialsArgsForCall(i int) (context.Context, authorization.Info, string) {
+	fake.rotateServiceBindingCredentialsMutex.RLock()
+	defer fake.rotateServiceBindingCredentialsMutex.RUnlock()
+	argsForCall := fake.rotateServiceBindingCredentialsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *CFServiceBindingRepository) RotateServiceBindingCredentialsReturns(result1 repositories.ServiceBindingRecord, result2 error) {
+	fake.rotateServiceBindingCredentialsMutex.Lock()
+	defer fake.rotateServiceBindingCredentialsMutex.Unlock()
+	fake.RotateServiceBindingCredentialsStub = nil
+	fake.rotateServiceBindingCredentialsReturns = struct {
+		result1 repositories.ServiceBindingRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFServiceBindingRepository) RotateServiceBindingCredentialsReturnsOnCall(i int, result1 repositories.ServiceBindingRecord, result2 error) {
+	fake.rotateServiceBindingCredentialsMutex.Lock()
+	defer fake.rotateServiceBindingCredentialsMutex.Unlock()
+	fake.RotateServiceBindingCredentialsStub = nil
+	if fake.rotateServiceBindingCredentialsReturnsOnCall == nil {
+		fake.rotateServiceBindingCredentialsReturnsOnCall = make(map[int]struct {
+			result1 repositories.ServiceBindingRecord
+			result2 error
+		})
+	}
+	fake.rotateServiceBindingCredentialsReturnsOnCall[i] = struct {
+		result1 repositories.ServiceBindingRecord
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *CFServiceBindingRepository) UpdateServiceBinding(arg1 context.Context, arg2 authorization.Info, arg3 repositories.UpdateServiceBindingMessage) (repositories.ServiceBindingRecord, error) {
 	fake.updateServiceBindingMutex.Lock()
 	ret, specificReturn := fake.updateServiceBindingReturnsOnCall[len(fake.updateServiceBindingArgsForCall)]
@@ -507,8 +669,12 @@ func (fake *CFServiceBindingRepository) Invocations() map[string][][]interface{}
 	defer fake.getServiceBindingMutex.RUnlock()
 	fake.getServiceBindingDetailsMutex.RLock()
 	defer fake.getServiceBindingDetailsMutex.RUnlock()
+	fake.getServiceBindingParametersMutex.RLock()
+	defer fake.getServiceBindingParametersMutex.RUnlock()
 	fake.listServiceBindingsMutex.RLock()
 	defer fake.listServiceBindingsMutex.RUnlock()
+	fake.rotateServiceBindingCredentialsMutex.RLock()
+	defer fake.rotateServiceBindingCredentialsMutex.RUnlock()
 	fake.updateServiceBindingMutex.RLock()
 	defer fake.updateServiceBindingMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
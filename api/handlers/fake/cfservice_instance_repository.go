@@ -86,6 +86,21 @@ type CFServiceInstanceRepository struct {
 		result1 map[string]any
 		result2 error
 	}
+	GetServiceInstanceParametersStub        func(context.Context, authorization.Info, string) (map[string]any, error)
+	getServiceInstanceParametersMutex       sync.RWMutex
+	getServiceInstanceParametersArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+	}
+	getServiceInstanceParametersReturns struct {
+		result1 map[string]any
+		result2 error
+	}
+	getServiceInstanceParametersReturnsOnCall map[int]struct {
+		result1 map[string]any
+		result2 error
+	}
 	ListServiceInstancesStub        func(context.Context, authorization.Info, repositories.ListServiceInstanceMessage) ([]repositories.ServiceInstanceRecord, error)
 	listServiceInstancesMutex       sync.RWMutex
 	listServiceInstancesArgsForCall []struct {
@@ -450,6 +465,72 @@ func (fake *CFServiceInstanceRepository) GetServiceInstanceCredentialsReturnsOnC
 	}{result1, result2}
 }
 
+func (fake *CFServiceInstanceRepository) GetServiceInstanceParameters(arg1 context.Context, arg2 authorization.Info, arg3 string) (map[string]any, error) {
+	fake.getServiceInstanceParametersMutex.Lock()
+	ret, specificReturn := fake.getServiceInstanceParametersReturnsOnCall[len(fake.getServiceInstanceParametersArgsForCall)]
+	fake.getServiceInstanceParametersArgsForCall = append(fake.getServiceInstanceParametersArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.GetServiceInstanceParametersStub
+	fakeReturns := fake.getServiceInstanceParametersReturns
+	fake.recordInvocation("GetServiceInstanceParameters", []interface{}{arg1, arg2, arg3})
+	fake.getServiceInstanceParametersMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *CFServiceInstanceRepository) GetServiceInstanceParametersCallCount() int {
+	fake.getServiceInstanceParametersMutex.RLock()
+	defer fake.getServiceInstanceParametersMutex.RUnlock()
+	return len(fake.getServiceInstanceParametersArgsForCall)
+}
+
+func (fake *CFServiceInstanceRepository) GetServiceInstanceParametersCalls(stub func(context.Context, authorization.Info, string) (map[string]any, error)) {
+	fake.getServiceInstanceParametersMutex.Lock()
+	defer fake.getServiceInstanceParametersMutex.Unlock()
+	fake.GetServiceInstanceParametersStub = stub
+}
+
+func (fake *CFServiceInstanceRepository) GetServiceInstanceParametersArgsForCall(i int) (context.Context, authorization.Info, string) {
+	fake.getServiceInstanceParametersMutex.RLock()
+	defer fake.getServiceInstanceParametersMutex.RUnlock()
+	argsForCall := fake.getServiceInstanceParametersArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *CFServiceInstanceRepository) GetServiceInstanceParametersReturns(result1 map[string]any, result2 error) {
+	fake.getServiceInstanceParametersMutex.Lock()
+	defer fake.getServiceInstanceParametersMutex.Unlock()
+	fake.GetServiceInstanceParametersStub = nil
+	fake.getServiceInstanceParametersReturns = struct {
+		result1 map[string]any
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFServiceInstanceRepository) GetServiceInstanceParametersReturnsOnCall(i int, result1 map[string]any, result2 error) {
+	fake.getServiceInstanceParametersMutex.Lock()
+	defer fake.getServiceInstanceParametersMutex.Unlock()
+	fake.GetServiceInstanceParametersStub = nil
+	if fake.getServiceInstanceParametersReturnsOnCall == nil {
+		fake.getServiceInstanceParametersReturnsOnCall = make(map[int]struct {
+			result1 map[string]any
+			result2 error
+		})
+	}
+	fake.getServiceInstanceParametersReturnsOnCall[i] = struct {
+		result1 map[string]any
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *CFServiceInstanceRepository) ListServiceInstances(arg1 context.Context, arg2 authorization.Info, arg3 repositories.ListServiceInstanceMessage) ([]repositories.ServiceInstanceRecord, error) {
 	fake.listServiceInstancesMutex.Lock()
 	ret, specificReturn := fake.listServiceInstancesReturnsOnCall[len(fake.listServiceInstancesArgsForCall)]
@@ -595,6 +676,8 @@ func (fake *CFServiceInstanceRepository) Invocations() map[string][][]interface{
 	defer fake.getServiceInstanceMutex.RUnlock()
 	fake.getServiceInstanceCredentialsMutex.RLock()
 	defer fake.getServiceInstanceCredentialsMutex.RUnlock()
+	fake.getServiceInstanceParametersMutex.RLock()
+	defer fake.getServiceInstanceParametersMutex.RUnlock()
 	fake.listServiceInstancesMutex.RLock()
 	defer fake.listServiceInstancesMutex.RUnlock()
 	fake.patchServiceInstanceMutex.RLock()
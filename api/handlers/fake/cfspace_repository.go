@@ -12,6 +12,21 @@ import (
 )
 
 type CFSpaceRepository struct {
+	AssignIsolationSegmentStub        func(context.Context, authorization.Info, repositories.AssignSpaceIsolationSegmentMessage) (repositories.SpaceRecord, error)
+	assignIsolationSegmentMutex       sync.RWMutex
+	assignIsolationSegmentArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.AssignSpaceIsolationSegmentMessage
+	}
+	assignIsolationSegmentReturns struct {
+		result1 repositories.SpaceRecord
+		result2 error
+	}
+	assignIsolationSegmentReturnsOnCall map[int]struct {
+		result1 repositories.SpaceRecord
+		result2 error
+	}
 	CreateSpaceStub        func(context.Context, authorization.Info, repositories.CreateSpaceMessage) (repositories.SpaceRecord, error)
 	createSpaceMutex       sync.RWMutex
 	createSpaceArgsForCall []struct {
@@ -104,6 +119,72 @@ type CFSpaceRepository struct {
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *CFSpaceRepository) AssignIsolationSegment(arg1 context.Context, arg2 authorization.Info, arg3 repositories.AssignSpaceIsolationSegmentMessage) (repositories.SpaceRecord, error) {
+	fake.assignIsolationSegmentMutex.Lock()
+	ret, specificReturn := fake.assignIsolationSegmentReturnsOnCall[len(fake.assignIsolationSegmentArgsForCall)]
+	fake.assignIsolationSegmentArgsForCall = append(fake.assignIsolationSegmentArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.AssignSpaceIsolationSegmentMessage
+	}{arg1, arg2, arg3})
+	stub := fake.AssignIsolationSegmentStub
+	fakeReturns := fake.assignIsolationSegmentReturns
+	fake.recordInvocation("AssignIsolationSegment", []interface{}{arg1, arg2, arg3})
+	fake.assignIsolationSegmentMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *CFSpaceRepository) AssignIsolationSegmentCallCount() int {
+	fake.assignIsolationSegmentMutex.RLock()
+	defer fake.assignIsolationSegmentMutex.RUnlock()
+	return len(fake.assignIsolationSegmentArgsForCall)
+}
+
+func (fake *CFSpaceRepository) AssignIsolationSegmentCalls(stub func(context.Context, authorization.Info, repositories.AssignSpaceIsolationSegmentMessage) (repositories.SpaceRecord, error)) {
+	fake.assignIsolationSegmentMutex.Lock()
+	defer fake.assignIsolationSegmentMutex.Unlock()
+	fake.AssignIsolationSegmentStub = stub
+}
+
+func (fake *CFSpaceRepository) AssignIsolationSegmentArgsForCall(i int) (context.Context, authorization.Info, repositories.AssignSpaceIsolationSegmentMessage) {
+	fake.assignIsolationSegmentMutex.RLock()
+	defer fake.assignIsolationSegmentMutex.RUnlock()
+	argsForCall := fake.assignIsolationSegmentArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *CFSpaceRepository) AssignIsolationSegmentReturns(result1 repositories.SpaceRecord, result2 error) {
+	fake.assignIsolationSegmentMutex.Lock()
+	defer fake.assignIsolationSegmentMutex.Unlock()
+	fake.AssignIsolationSegmentStub = nil
+	fake.assignIsolationSegmentReturns = struct {
+		result1 repositories.SpaceRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFSpaceRepository) AssignIsolationSegmentReturnsOnCall(i int, result1 repositories.SpaceRecord, result2 error) {
+	fake.assignIsolationSegmentMutex.Lock()
+	defer fake.assignIsolationSegmentMutex.Unlock()
+	fake.AssignIsolationSegmentStub = nil
+	if fake.assignIsolationSegmentReturnsOnCall == nil {
+		fake.assignIsolationSegmentReturnsOnCall = make(map[int]struct {
+			result1 repositories.SpaceRecord
+			result2 error
+		})
+	}
+	fake.assignIsolationSegmentReturnsOnCall[i] = struct {
+		result1 repositories.SpaceRecord
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *CFSpaceRepository) CreateSpace(arg1 context.Context, arg2 authorization.Info, arg3 repositories.CreateSpaceMessage) (repositories.SpaceRecord, error) {
 	fake.createSpaceMutex.Lock()
 	ret, specificReturn := fake.createSpaceReturnsOnCall[len(fake.createSpaceArgsForCall)]
@@ -500,6 +581,8 @@ func (fake *CFSpaceRepository) PatchSpaceMetadataReturnsOnCall(i int, result1 re
 func (fake *CFSpaceRepository) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.assignIsolationSegmentMutex.RLock()
+	defer fake.assignIsolationSegmentMutex.RUnlock()
 	fake.createSpaceMutex.RLock()
 	defer fake.createSpaceMutex.RUnlock()
 	fake.deleteSpaceMutex.RLock()
@@ -0,0 +1,450 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fake
+
+import (
+	"context"
+	"sync"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	"code.cloudfoundry.org/korifi/api/handlers"
+	"code.cloudfoundry.org/korifi/api/repositories"
+)
+
+type CFTaskScheduleRepository struct {
+	CreateTaskScheduleStub        func(context.Context, authorization.Info, repositories.CreateTaskScheduleMessage) (repositories.TaskScheduleRecord, error)
+	createTaskScheduleMutex       sync.RWMutex
+	createTaskScheduleArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.CreateTaskScheduleMessage
+	}
+	createTaskScheduleReturns struct {
+		result1 repositories.TaskScheduleRecord
+		result2 error
+	}
+	createTaskScheduleReturnsOnCall map[int]struct {
+		result1 repositories.TaskScheduleRecord
+		result2 error
+	}
+	DeleteTaskScheduleStub        func(context.Context, authorization.Info, repositories.DeleteTaskScheduleMessage) error
+	deleteTaskScheduleMutex       sync.RWMutex
+	deleteTaskScheduleArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.DeleteTaskScheduleMessage
+	}
+	deleteTaskScheduleReturns struct {
+		result1 error
+	}
+	deleteTaskScheduleReturnsOnCall map[int]struct {
+		result1 error
+	}
+	GetTaskScheduleStub        func(context.Context, authorization.Info, string) (repositories.TaskScheduleRecord, error)
+	getTaskScheduleMutex       sync.RWMutex
+	getTaskScheduleArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+	}
+	getTaskScheduleReturns struct {
+		result1 repositories.TaskScheduleRecord
+		result2 error
+	}
+	getTaskScheduleReturnsOnCall map[int]struct {
+		result1 repositories.TaskScheduleRecord
+		result2 error
+	}
+	ListTaskSchedulesStub        func(context.Context, authorization.Info, repositories.ListTaskScheduleMessage) ([]repositories.TaskScheduleRecord, error)
+	listTaskSchedulesMutex       sync.RWMutex
+	listTaskSchedulesArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.ListTaskScheduleMessage
+	}
+	listTaskSchedulesReturns struct {
+		result1 []repositories.TaskScheduleRecord
+		result2 error
+	}
+	listTaskSchedulesReturnsOnCall map[int]struct {
+		result1 []repositories.TaskScheduleRecord
+		result2 error
+	}
+	PatchTaskScheduleStub        func(context.Context, authorization.Info, repositories.PatchTaskScheduleMessage) (repositories.TaskScheduleRecord, error)
+	patchTaskScheduleMutex       sync.RWMutex
+	patchTaskScheduleArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.PatchTaskScheduleMessage
+	}
+	patchTaskScheduleReturns struct {
+		result1 repositories.TaskScheduleRecord
+		result2 error
+	}
+	patchTaskScheduleReturnsOnCall map[int]struct {
+		result1 repositories.TaskScheduleRecord
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *CFTaskScheduleRepository) CreateTaskSchedule(arg1 context.Context, arg2 authorization.Info, arg3 repositories.CreateTaskScheduleMessage) (repositories.TaskScheduleRecord, error) {
+	fake.createTaskScheduleMutex.Lock()
+	ret, specificReturn := fake.createTaskScheduleReturnsOnCall[len(fake.createTaskScheduleArgsForCall)]
+	fake.createTaskScheduleArgsForCall = append(fake.createTaskScheduleArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.CreateTaskScheduleMessage
+	}{arg1, arg2, arg3})
+	stub := fake.CreateTaskScheduleStub
+	fakeReturns := fake.createTaskScheduleReturns
+	fake.recordInvocation("CreateTaskSchedule", []interface{}{arg1, arg2, arg3})
+	fake.createTaskScheduleMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *CFTaskScheduleRepository) CreateTaskScheduleCallCount() int {
+	fake.createTaskScheduleMutex.RLock()
+	defer fake.createTaskScheduleMutex.RUnlock()
+	return len(fake.createTaskScheduleArgsForCall)
+}
+
+func (fake *CFTaskScheduleRepository) CreateTaskScheduleCalls(stub func(context.Context, authorization.Info, repositories.CreateTaskScheduleMessage) (repositories.TaskScheduleRecord, error)) {
+	fake.createTaskScheduleMutex.Lock()
+	defer fake.createTaskScheduleMutex.Unlock()
+	fake.CreateTaskScheduleStub = stub
+}
+
+func (fake *CFTaskScheduleRepository) CreateTaskScheduleArgsForCall(i int) (context.Context, authorization.Info, repositories.CreateTaskScheduleMessage) {
+	fake.createTaskScheduleMutex.RLock()
+	defer fake.createTaskScheduleMutex.RUnlock()
+	argsForCall := fake.createTaskScheduleArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *CFTaskScheduleRepository) CreateTaskScheduleReturns(result1 repositories.TaskScheduleRecord, result2 error) {
+	fake.createTaskScheduleMutex.Lock()
+	defer fake.createTaskScheduleMutex.Unlock()
+	fake.CreateTaskScheduleStub = nil
+	fake.createTaskScheduleReturns = struct {
+		result1 repositories.TaskScheduleRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFTaskScheduleRepository) CreateTaskScheduleReturnsOnCall(i int, result1 repositories.TaskScheduleRecord, result2 error) {
+	fake.createTaskScheduleMutex.Lock()
+	defer fake.createTaskScheduleMutex.Unlock()
+	fake.CreateTaskScheduleStub = nil
+	if fake.createTaskScheduleReturnsOnCall == nil {
+		fake.createTaskScheduleReturnsOnCall = make(map[int]struct {
+			result1 repositories.TaskScheduleRecord
+			result2 error
+		})
+	}
+	fake.createTaskScheduleReturnsOnCall[i] = struct {
+		result1 repositories.TaskScheduleRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFTaskScheduleRepository) DeleteTaskSchedule(arg1 context.Context, arg2 authorization.Info, arg3 repositories.DeleteTaskScheduleMessage) error {
+	fake.deleteTaskScheduleMutex.Lock()
+	ret, specificReturn := fake.deleteTaskScheduleReturnsOnCall[len(fake.deleteTaskScheduleArgsForCall)]
+	fake.deleteTaskScheduleArgsForCall = append(fake.deleteTaskScheduleArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.DeleteTaskScheduleMessage
+	}{arg1, arg2, arg3})
+	stub := fake.DeleteTaskScheduleStub
+	fakeReturns := fake.deleteTaskScheduleReturns
+	fake.recordInvocation("DeleteTaskSchedule", []interface{}{arg1, arg2, arg3})
+	fake.deleteTaskScheduleMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *CFTaskScheduleRepository) DeleteTaskScheduleCallCount() int {
+	fake.deleteTaskScheduleMutex.RLock()
+	defer fake.deleteTaskScheduleMutex.RUnlock()
+	return len(fake.deleteTaskScheduleArgsForCall)
+}
+
+func (fake *CFTaskScheduleRepository) DeleteTaskScheduleCalls(stub func(context.Context, authorization.Info, repositories.DeleteTaskScheduleMessage) error) {
+	fake.deleteTaskScheduleMutex.Lock()
+	defer fake.deleteTaskScheduleMutex.Unlock()
+	fake.DeleteTaskScheduleStub = stub
+}
+
+func (fake *CFTaskScheduleRepository) DeleteTaskScheduleArgsForCall(i int) (context.Context, authorization.Info, repositories.DeleteTaskScheduleMessage) {
+	fake.deleteTaskScheduleMutex.RLock()
+	defer fake.deleteTaskScheduleMutex.RUnlock()
+	argsForCall := fake.deleteTaskScheduleArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *CFTaskScheduleRepository) DeleteTaskScheduleReturns(result1 error) {
+	fake.deleteTaskScheduleMutex.Lock()
+	defer fake.deleteTaskScheduleMutex.Unlock()
+	fake.DeleteTaskScheduleStub = nil
+	fake.deleteTaskScheduleReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *CFTaskScheduleRepository) DeleteTaskScheduleReturnsOnCall(i int, result1 error) {
+	fake.deleteTaskScheduleMutex.Lock()
+	defer fake.deleteTaskScheduleMutex.Unlock()
+	fake.DeleteTaskScheduleStub = nil
+	if fake.deleteTaskScheduleReturnsOnCall == nil {
+		fake.deleteTaskScheduleReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.deleteTaskScheduleReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *CFTaskScheduleRepository) GetTaskSchedule(arg1 context.Context, arg2 authorization.Info, arg3 string) (repositories.TaskScheduleRecord, error) {
+	fake.getTaskScheduleMutex.Lock()
+	ret, specificReturn := fake.getTaskScheduleReturnsOnCall[len(fake.getTaskScheduleArgsForCall)]
+	fake.getTaskScheduleArgsForCall = append(fake.getTaskScheduleArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.GetTaskScheduleStub
+	fakeReturns := fake.getTaskScheduleReturns
+	fake.recordInvocation("GetTaskSchedule", []interface{}{arg1, arg2, arg3})
+	fake.getTaskScheduleMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *CFTaskScheduleRepository) GetTaskScheduleCallCount() int {
+	fake.getTaskScheduleMutex.RLock()
+	defer fake.getTaskScheduleMutex.RUnlock()
+	return len(fake.getTaskScheduleArgsForCall)
+}
+
+func (fake *CFTaskScheduleRepository) GetTaskScheduleCalls(stub func(context.Context, authorization.Info, string) (repositories.TaskScheduleRecord, error)) {
+	fake.getTaskScheduleMutex.Lock()
+	defer fake.getTaskScheduleMutex.Unlock()
+	fake.GetTaskScheduleStub = stub
+}
+
+func (fake *CFTaskScheduleRepository) GetTaskScheduleArgsForCall(i int) (context.Context, authorization.Info, string) {
+	fake.getTaskScheduleMutex.RLock()
+	defer fake.getTaskScheduleMutex.RUnlock()
+	argsForCall := fake.getTaskScheduleArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *CFTaskScheduleRepository) GetTaskScheduleReturns(result1 repositories.TaskScheduleRecord, result2 error) {
+	fake.getTaskScheduleMutex.Lock()
+	defer fake.getTaskScheduleMutex.Unlock()
+	fake.GetTaskScheduleStub = nil
+	fake.getTaskScheduleReturns = struct {
+		result1 repositories.TaskScheduleRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFTaskScheduleRepository) GetTaskScheduleReturnsOnCall(i int, result1 repositories.TaskScheduleRecord, result2 error) {
+	fake.getTaskScheduleMutex.Lock()
+	defer fake.getTaskScheduleMutex.Unlock()
+	fake.GetTaskScheduleStub = nil
+	if fake.getTaskScheduleReturnsOnCall == nil {
+		fake.getTaskScheduleReturnsOnCall = make(map[int]struct {
+			result1 repositories.TaskScheduleRecord
+			result2 error
+		})
+	}
+	fake.getTaskScheduleReturnsOnCall[i] = struct {
+		result1 repositories.TaskScheduleRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFTaskScheduleRepository) ListTaskSchedules(arg1 context.Context, arg2 authorization.Info, arg3 repositories.ListTaskScheduleMessage) ([]repositories.TaskScheduleRecord, error) {
+	fake.listTaskSchedulesMutex.Lock()
+	ret, specificReturn := fake.listTaskSchedulesReturnsOnCall[len(fake.listTaskSchedulesArgsForCall)]
+	fake.listTaskSchedulesArgsForCall = append(fake.listTaskSchedulesArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.ListTaskScheduleMessage
+	}{arg1, arg2, arg3})
+	stub := fake.ListTaskSchedulesStub
+	fakeReturns := fake.listTaskSchedulesReturns
+	fake.recordInvocation("ListTaskSchedules", []interface{}{arg1, arg2, arg3})
+	fake.listTaskSchedulesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *CFTaskScheduleRepository) ListTaskSchedulesCallCount() int {
+	fake.listTaskSchedulesMutex.RLock()
+	defer fake.listTaskSchedulesMutex.RUnlock()
+	return len(fake.listTaskSchedulesArgsForCall)
+}
+
+func (fake *CFTaskScheduleRepository) ListTaskSchedulesCalls(stub func(context.Context, authorization.Info, repositories.ListTaskScheduleMessage) ([]repositories.TaskScheduleRecord, error)) {
+	fake.listTaskSchedulesMutex.Lock()
+	defer fake.listTaskSchedulesMutex.Unlock()
+	fake.ListTaskSchedulesStub = stub
+}
+
+func (fake *CFTaskScheduleRepository) ListTaskSchedulesArgsForCall(i int) (context.Context, authorization.Info, repositories.ListTaskScheduleMessage) {
+	fake.listTaskSchedulesMutex.RLock()
+	defer fake.listTaskSchedulesMutex.RUnlock()
+	argsForCall := fake.listTaskSchedulesArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *CFTaskScheduleRepository) ListTaskSchedulesReturns(result1 []repositories.TaskScheduleRecord, result2 error) {
+	fake.listTaskSchedulesMutex.Lock()
+	defer fake.listTaskSchedulesMutex.Unlock()
+	fake.ListTaskSchedulesStub = nil
+	fake.listTaskSchedulesReturns = struct {
+		result1 []repositories.TaskScheduleRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFTaskScheduleRepository) ListTaskSchedulesReturnsOnCall(i int, result1 []repositories.TaskScheduleRecord, result2 error) {
+	fake.listTaskSchedulesMutex.Lock()
+	defer fake.listTaskSchedulesMutex.Unlock()
+	fake.ListTaskSchedulesStub = nil
+	if fake.listTaskSchedulesReturnsOnCall == nil {
+		fake.listTaskSchedulesReturnsOnCall = make(map[int]struct {
+			result1 []repositories.TaskScheduleRecord
+			result2 error
+		})
+	}
+	fake.listTaskSchedulesReturnsOnCall[i] = struct {
+		result1 []repositories.TaskScheduleRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFTaskScheduleRepository) PatchTaskSchedule(arg1 context.Context, arg2 authorization.Info, arg3 repositories.PatchTaskScheduleMessage) (repositories.TaskScheduleRecord, error) {
+	fake.patchTaskScheduleMutex.Lock()
+	ret, specificReturn := fake.patchTaskScheduleReturnsOnCall[len(fake.patchTaskScheduleArgsForCall)]
+	fake.patchTaskScheduleArgsForCall = append(fake.patchTaskScheduleArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.PatchTaskScheduleMessage
+	}{arg1, arg2, arg3})
+	stub := fake.PatchTaskScheduleStub
+	fakeReturns := fake.patchTaskScheduleReturns
+	fake.recordInvocation("PatchTaskSchedule", []interface{}{arg1, arg2, arg3})
+	fake.patchTaskScheduleMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *CFTaskScheduleRepository) PatchTaskScheduleCallCount() int {
+	fake.patchTaskScheduleMutex.RLock()
+	defer fake.patchTaskScheduleMutex.RUnlock()
+	return len(fake.patchTaskScheduleArgsForCall)
+}
+
+func (fake *CFTaskScheduleRepository) PatchTaskScheduleCalls(stub func(context.Context, authorization.Info, repositories.PatchTaskScheduleMessage) (repositories.TaskScheduleRecord, error)) {
+	fake.patchTaskScheduleMutex.Lock()
+	defer fake.patchTaskScheduleMutex.Unlock()
+	fake.PatchTaskScheduleStub = stub
+}
+
+func (fake *CFTaskScheduleRepository) PatchTaskScheduleArgsForCall(i int) (context.Context, authorization.Info, repositories.PatchTaskScheduleMessage) {
+	fake.patchTaskScheduleMutex.RLock()
+	defer fake.patchTaskScheduleMutex.RUnlock()
+	argsForCall := fake.patchTaskScheduleArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *CFTaskScheduleRepository) PatchTaskScheduleReturns(result1 repositories.TaskScheduleRecord, result2 error) {
+	fake.patchTaskScheduleMutex.Lock()
+	defer fake.patchTaskScheduleMutex.Unlock()
+	fake.PatchTaskScheduleStub = nil
+	fake.patchTaskScheduleReturns = struct {
+		result1 repositories.TaskScheduleRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFTaskScheduleRepository) PatchTaskScheduleReturnsOnCall(i int, result1 repositories.TaskScheduleRecord, result2 error) {
+	fake.patchTaskScheduleMutex.Lock()
+	defer fake.patchTaskScheduleMutex.Unlock()
+	fake.PatchTaskScheduleStub = nil
+	if fake.patchTaskScheduleReturnsOnCall == nil {
+		fake.patchTaskScheduleReturnsOnCall = make(map[int]struct {
+			result1 repositories.TaskScheduleRecord
+			result2 error
+		})
+	}
+	fake.patchTaskScheduleReturnsOnCall[i] = struct {
+		result1 repositories.TaskScheduleRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFTaskScheduleRepository) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.createTaskScheduleMutex.RLock()
+	defer fake.createTaskScheduleMutex.RUnlock()
+	fake.deleteTaskScheduleMutex.RLock()
+	defer fake.deleteTaskScheduleMutex.RUnlock()
+	fake.getTaskScheduleMutex.RLock()
+	defer fake.getTaskScheduleMutex.RUnlock()
+	fake.listTaskSchedulesMutex.RLock()
+	defer fake.listTaskSchedulesMutex.RUnlock()
+	fake.patchTaskScheduleMutex.RLock()
+	defer fake.patchTaskScheduleMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *CFTaskScheduleRepository) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ handlers.CFTaskScheduleRepository = new(CFTaskScheduleRepository)
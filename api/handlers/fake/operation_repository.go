@@ -0,0 +1,123 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fake
+
+import (
+	"context"
+	"sync"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	"code.cloudfoundry.org/korifi/api/handlers"
+	"code.cloudfoundry.org/korifi/model"
+)
+
+type OperationRepository struct {
+	GetOperationStub        func(context.Context, authorization.Info, string) (model.CFResourceOperation, error)
+	getOperationMutex       sync.RWMutex
+	getOperationArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+	}
+	getOperationReturns struct {
+		result1 model.CFResourceOperation
+		result2 error
+	}
+	getOperationReturnsOnCall map[int]struct {
+		result1 model.CFResourceOperation
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *OperationRepository) GetOperation(arg1 context.Context, arg2 authorization.Info, arg3 string) (model.CFResourceOperation, error) {
+	fake.getOperationMutex.Lock()
+	ret, specificReturn := fake.getOperationReturnsOnCall[len(fake.getOperationArgsForCall)]
+	fake.getOperationArgsForCall = append(fake.getOperationArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.GetOperationStub
+	fakeReturns := fake.getOperationReturns
+	fake.recordInvocation("GetOperation", []interface{}{arg1, arg2, arg3})
+	fake.getOperationMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *OperationRepository) GetOperationCallCount() int {
+	fake.getOperationMutex.RLock()
+	defer fake.getOperationMutex.RUnlock()
+	return len(fake.getOperationArgsForCall)
+}
+
+func (fake *OperationRepository) GetOperationCalls(stub func(context.Context, authorization.Info, string) (model.CFResourceOperation, error)) {
+	fake.getOperationMutex.Lock()
+	defer fake.getOperationMutex.Unlock()
+	fake.GetOperationStub = stub
+}
+
+func (fake *OperationRepository) GetOperationArgsForCall(i int) (context.Context, authorization.Info, string) {
+	fake.getOperationMutex.RLock()
+	defer fake.getOperationMutex.RUnlock()
+	argsForCall := fake.getOperationArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *OperationRepository) GetOperationReturns(result1 model.CFResourceOperation, result2 error) {
+	fake.getOperationMutex.Lock()
+	defer fake.getOperationMutex.Unlock()
+	fake.GetOperationStub = nil
+	fake.getOperationReturns = struct {
+		result1 model.CFResourceOperation
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *OperationRepository) GetOperationReturnsOnCall(i int, result1 model.CFResourceOperation, result2 error) {
+	fake.getOperationMutex.Lock()
+	defer fake.getOperationMutex.Unlock()
+	fake.GetOperationStub = nil
+	if fake.getOperationReturnsOnCall == nil {
+		fake.getOperationReturnsOnCall = make(map[int]struct {
+			result1 model.CFResourceOperation
+			result2 error
+		})
+	}
+	fake.getOperationReturnsOnCall[i] = struct {
+		result1 model.CFResourceOperation
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *OperationRepository) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.getOperationMutex.RLock()
+	defer fake.getOperationMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *OperationRepository) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ handlers.OperationRepository = new(OperationRepository)
@@ -0,0 +1,205 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fake
+
+import (
+	"context"
+	"sync"
+
+	"code.cloudfoundry.org/korifi/api/handlers"
+	"code.cloudfoundry.org/korifi/api/oauth"
+)
+
+type SSOProvider struct {
+	AuthorizationURLStub        func(context.Context, string, string) (string, error)
+	authorizationURLMutex       sync.RWMutex
+	authorizationURLArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+	}
+	authorizationURLReturns struct {
+		result1 string
+		result2 error
+	}
+	authorizationURLReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
+	ExchangeCodeStub        func(context.Context, string, string) (oauth.Login, error)
+	exchangeCodeMutex       sync.RWMutex
+	exchangeCodeArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+	}
+	exchangeCodeReturns struct {
+		result1 oauth.Login
+		result2 error
+	}
+	exchangeCodeReturnsOnCall map[int]struct {
+		result1 oauth.Login
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *SSOProvider) AuthorizationURL(arg1 context.Context, arg2 string, arg3 string) (string, error) {
+	fake.authorizationURLMutex.Lock()
+	ret, specificReturn := fake.authorizationURLReturnsOnCall[len(fake.authorizationURLArgsForCall)]
+	fake.authorizationURLArgsForCall = append(fake.authorizationURLArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.AuthorizationURLStub
+	fakeReturns := fake.authorizationURLReturns
+	fake.recordInvocation("AuthorizationURL", []interface{}{arg1, arg2, arg3})
+	fake.authorizationURLMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *SSOProvider) AuthorizationURLCallCount() int {
+	fake.authorizationURLMutex.RLock()
+	defer fake.authorizationURLMutex.RUnlock()
+	return len(fake.authorizationURLArgsForCall)
+}
+
+func (fake *SSOProvider) AuthorizationURLCalls(stub func(context.Context, string, string) (string, error)) {
+	fake.authorizationURLMutex.Lock()
+	defer fake.authorizationURLMutex.Unlock()
+	fake.AuthorizationURLStub = stub
+}
+
+func (fake *SSOProvider) AuthorizationURLArgsForCall(i int) (context.Context, string, string) {
+	fake.authorizationURLMutex.RLock()
+	defer fake.authorizationURLMutex.RUnlock()
+	argsForCall := fake.authorizationURLArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *SSOProvider) AuthorizationURLReturns(result1 string, result2 error) {
+	fake.authorizationURLMutex.Lock()
+	defer fake.authorizationURLMutex.Unlock()
+	fake.AuthorizationURLStub = nil
+	fake.authorizationURLReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *SSOProvider) AuthorizationURLReturnsOnCall(i int, result1 string, result2 error) {
+	fake.authorizationURLMutex.Lock()
+	defer fake.authorizationURLMutex.Unlock()
+	fake.AuthorizationURLStub = nil
+	if fake.authorizationURLReturnsOnCall == nil {
+		fake.authorizationURLReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.authorizationURLReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *SSOProvider) ExchangeCode(arg1 context.Context, arg2 string, arg3 string) (oauth.Login, error) {
+	fake.exchangeCodeMutex.Lock()
+	ret, specificReturn := fake.exchangeCodeReturnsOnCall[len(fake.exchangeCodeArgsForCall)]
+	fake.exchangeCodeArgsForCall = append(fake.exchangeCodeArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.ExchangeCodeStub
+	fakeReturns := fake.exchangeCodeReturns
+	fake.recordInvocation("ExchangeCode", []interface{}{arg1, arg2, arg3})
+	fake.exchangeCodeMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *SSOProvider) ExchangeCodeCallCount() int {
+	fake.exchangeCodeMutex.RLock()
+	defer fake.exchangeCodeMutex.RUnlock()
+	return len(fake.exchangeCodeArgsForCall)
+}
+
+func (fake *SSOProvider) ExchangeCodeCalls(stub func(context.Context, string, string) (oauth.Login, error)) {
+	fake.exchangeCodeMutex.Lock()
+	defer fake.exchangeCodeMutex.Unlock()
+	fake.ExchangeCodeStub = stub
+}
+
+func (fake *SSOProvider) ExchangeCodeArgsForCall(i int) (context.Context, string, string) {
+	fake.exchangeCodeMutex.RLock()
+	defer fake.exchangeCodeMutex.RUnlock()
+	argsForCall := fake.exchangeCodeArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *SSOProvider) ExchangeCodeReturns(result1 oauth.Login, result2 error) {
+	fake.exchangeCodeMutex.Lock()
+	defer fake.exchangeCodeMutex.Unlock()
+	fake.ExchangeCodeStub = nil
+	fake.exchangeCodeReturns = struct {
+		result1 oauth.Login
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *SSOProvider) ExchangeCodeReturnsOnCall(i int, result1 oauth.Login, result2 error) {
+	fake.exchangeCodeMutex.Lock()
+	defer fake.exchangeCodeMutex.Unlock()
+	fake.ExchangeCodeStub = nil
+	if fake.exchangeCodeReturnsOnCall == nil {
+		fake.exchangeCodeReturnsOnCall = make(map[int]struct {
+			result1 oauth.Login
+			result2 error
+		})
+	}
+	fake.exchangeCodeReturnsOnCall[i] = struct {
+		result1 oauth.Login
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *SSOProvider) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.authorizationURLMutex.RLock()
+	defer fake.authorizationURLMutex.RUnlock()
+	fake.exchangeCodeMutex.RLock()
+	defer fake.exchangeCodeMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *SSOProvider) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ handlers.SSOProvider = new(SSOProvider)
@@ -1,123 +0,0 @@
-// Code generated by counterfeiter. DO NOT EDIT.
-package fake
-
-import (
-	"context"
-	"sync"
-
-	"code.cloudfoundry.org/korifi/api/authorization"
-	"code.cloudfoundry.org/korifi/api/handlers"
-	"code.cloudfoundry.org/korifi/model"
-)
-
-type StateRepository struct {
-	GetStateStub        func(context.Context, authorization.Info, string) (model.CFResourceState, error)
-	getStateMutex       sync.RWMutex
-	getStateArgsForCall []struct {
-		arg1 context.Context
-		arg2 authorization.Info
-		arg3 string
-	}
-	getStateReturns struct {
-		result1 model.CFResourceState
-		result2 error
-	}
-	getStateReturnsOnCall map[int]struct {
-		result1 model.CFResourceState
-		result2 error
-	}
-	invocations      map[string][][]interface{}
-	invocationsMutex sync.RWMutex
-}
-
-func (fake *StateRepository) GetState(arg1 context.Context, arg2 authorization.Info, arg3 string) (model.CFResourceState, error) {
-	fake.getStateMutex.Lock()
-	ret, specificReturn := fake.getStateReturnsOnCall[len(fake.getStateArgsForCall)]
-	fake.getStateArgsForCall = append(fake.getStateArgsForCall, struct {
-		arg1 context.Context
-		arg2 authorization.Info
-		arg3 string
-	}{arg1, arg2, arg3})
-	stub := fake.GetStateStub
-	fakeReturns := fake.getStateReturns
-	fake.recordInvocation("GetState", []interface{}{arg1, arg2, arg3})
-	fake.getStateMutex.Unlock()
-	if stub != nil {
-		return stub(arg1, arg2, arg3)
-	}
-	if specificReturn {
-		return ret.result1, ret.result2
-	}
-	return fakeReturns.result1, fakeReturns.result2
-}
-
-func (fake *StateRepository) GetStateCallCount() int {
-	fake.getStateMutex.RLock()
-	defer fake.getStateMutex.RUnlock()
-	return len(fake.getStateArgsForCall)
-}
-
-func (fake *StateRepository) GetStateCalls(stub func(context.Context, authorization.Info, string) (model.CFResourceState, error)) {
-	fake.getStateMutex.Lock()
-	defer fake.getStateMutex.Unlock()
-	fake.GetStateStub = stub
-}
-
-func (fake *StateRepository) GetStateArgsForCall(i int) (context.Context, authorization.Info, string) {
-	fake.getStateMutex.RLock()
-	defer fake.getStateMutex.RUnlock()
-	argsForCall := fake.getStateArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
-}
-
-func (fake *StateRepository) GetStateReturns(result1 model.CFResourceState, result2 error) {
-	fake.getStateMutex.Lock()
-	defer fake.getStateMutex.Unlock()
-	fake.GetStateStub = nil
-	fake.getStateReturns = struct {
-		result1 model.CFResourceState
-		result2 error
-	}{result1, result2}
-}
-
-func (fake *StateRepository) GetStateReturnsOnCall(i int, result1 model.CFResourceState, result2 error) {
-	fake.getStateMutex.Lock()
-	defer fake.getStateMutex.Unlock()
-	fake.GetStateStub = nil
-	if fake.getStateReturnsOnCall == nil {
-		fake.getStateReturnsOnCall = make(map[int]struct {
-			result1 model.CFResourceState
-			result2 error
-		})
-	}
-	fake.getStateReturnsOnCall[i] = struct {
-		result1 model.CFResourceState
-		result2 error
-	}{result1, result2}
-}
-
-func (fake *StateRepository) Invocations() map[string][][]interface{} {
-	fake.invocationsMutex.RLock()
-	defer fake.invocationsMutex.RUnlock()
-	fake.getStateMutex.RLock()
-	defer fake.getStateMutex.RUnlock()
-	copiedInvocations := map[string][][]interface{}{}
-	for key, value := range fake.invocations {
-		copiedInvocations[key] = value
-	}
-	return copiedInvocations
-}
-
-func (fake *StateRepository) recordInvocation(key string, args []interface{}) {
-	fake.invocationsMutex.Lock()
-	defer fake.invocationsMutex.Unlock()
-	if fake.invocations == nil {
-		fake.invocations = map[string][][]interface{}{}
-	}
-	if fake.invocations[key] == nil {
-		fake.invocations[key] = [][]interface{}{}
-	}
-	fake.invocations[key] = append(fake.invocations[key], args)
-}
-
-var _ handlers.StateRepository = new(StateRepository)
@@ -0,0 +1,266 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fake
+
+import (
+	"context"
+	"sync"
+
+	"code.cloudfoundry.org/korifi/api/handlers"
+	"code.cloudfoundry.org/korifi/api/oauth"
+)
+
+type TokenIssuer struct {
+	GrantStub        func(context.Context, oauth.GrantRequest) (oauth.Token, error)
+	grantMutex       sync.RWMutex
+	grantArgsForCall []struct {
+		arg1 context.Context
+		arg2 oauth.GrantRequest
+	}
+	grantReturns struct {
+		result1 oauth.Token
+		result2 error
+	}
+	grantReturnsOnCall map[int]struct {
+		result1 oauth.Token
+		result2 error
+	}
+	IssuePasscodeStub        func(oauth.Login, string) (string, error)
+	issuePasscodeMutex       sync.RWMutex
+	issuePasscodeArgsForCall []struct {
+		arg1 oauth.Login
+		arg2 string
+	}
+	issuePasscodeReturns struct {
+		result1 string
+		result2 error
+	}
+	issuePasscodeReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
+	PublicKeysStub        func() []oauth.PublicKey
+	publicKeysMutex       sync.RWMutex
+	publicKeysArgsForCall []struct {
+	}
+	publicKeysReturns struct {
+		result1 []oauth.PublicKey
+	}
+	publicKeysReturnsOnCall map[int]struct {
+		result1 []oauth.PublicKey
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *TokenIssuer) Grant(arg1 context.Context, arg2 oauth.GrantRequest) (oauth.Token, error) {
+	fake.grantMutex.Lock()
+	ret, specificReturn := fake.grantReturnsOnCall[len(fake.grantArgsForCall)]
+	fake.grantArgsForCall = append(fake.grantArgsForCall, struct {
+		arg1 context.Context
+		arg2 oauth.GrantRequest
+	}{arg1, arg2})
+	stub := fake.GrantStub
+	fakeReturns := fake.grantReturns
+	fake.recordInvocation("Grant", []interface{}{arg1, arg2})
+	fake.grantMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *TokenIssuer) GrantCallCount() int {
+	fake.grantMutex.RLock()
+	defer fake.grantMutex.RUnlock()
+	return len(fake.grantArgsForCall)
+}
+
+func (fake *TokenIssuer) GrantCalls(stub func(context.Context, oauth.GrantRequest) (oauth.Token, error)) {
+	fake.grantMutex.Lock()
+	defer fake.grantMutex.Unlock()
+	fake.GrantStub = stub
+}
+
+func (fake *TokenIssuer) GrantArgsForCall(i int) (context.Context, oauth.GrantRequest) {
+	fake.grantMutex.RLock()
+	defer fake.grantMutex.RUnlock()
+	argsForCall := fake.grantArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *TokenIssuer) GrantReturns(result1 oauth.Token, result2 error) {
+	fake.grantMutex.Lock()
+	defer fake.grantMutex.Unlock()
+	fake.GrantStub = nil
+	fake.grantReturns = struct {
+		result1 oauth.Token
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *TokenIssuer) GrantReturnsOnCall(i int, result1 oauth.Token, result2 error) {
+	fake.grantMutex.Lock()
+	defer fake.grantMutex.Unlock()
+	fake.GrantStub = nil
+	if fake.grantReturnsOnCall == nil {
+		fake.grantReturnsOnCall = make(map[int]struct {
+			result1 oauth.Token
+			result2 error
+		})
+	}
+	fake.grantReturnsOnCall[i] = struct {
+		result1 oauth.Token
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *TokenIssuer) IssuePasscode(arg1 oauth.Login, arg2 string) (string, error) {
+	fake.issuePasscodeMutex.Lock()
+	ret, specificReturn := fake.issuePasscodeReturnsOnCall[len(fake.issuePasscodeArgsForCall)]
+	fake.issuePasscodeArgsForCall = append(fake.issuePasscodeArgsForCall, struct {
+		arg1 oauth.Login
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.IssuePasscodeStub
+	fakeReturns := fake.issuePasscodeReturns
+	fake.recordInvocation("IssuePasscode", []interface{}{arg1, arg2})
+	fake.issuePasscodeMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *TokenIssuer) IssuePasscodeCallCount() int {
+	fake.issuePasscodeMutex.RLock()
+	defer fake.issuePasscodeMutex.RUnlock()
+	return len(fake.issuePasscodeArgsForCall)
+}
+
+func (fake *TokenIssuer) IssuePasscodeCalls(stub func(oauth.Login, string) (string, error)) {
+	fake.issuePasscodeMutex.Lock()
+	defer fake.issuePasscodeMutex.Unlock()
+	fake.IssuePasscodeStub = stub
+}
+
+func (fake *TokenIssuer) IssuePasscodeArgsForCall(i int) (oauth.Login, string) {
+	fake.issuePasscodeMutex.RLock()
+	defer fake.issuePasscodeMutex.RUnlock()
+	argsForCall := fake.issuePasscodeArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *TokenIssuer) IssuePasscodeReturns(result1 string, result2 error) {
+	fake.issuePasscodeMutex.Lock()
+	defer fake.issuePasscodeMutex.Unlock()
+	fake.IssuePasscodeStub = nil
+	fake.issuePasscodeReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *TokenIssuer) IssuePasscodeReturnsOnCall(i int, result1 string, result2 error) {
+	fake.issuePasscodeMutex.Lock()
+	defer fake.issuePasscodeMutex.Unlock()
+	fake.IssuePasscodeStub = nil
+	if fake.issuePasscodeReturnsOnCall == nil {
+		fake.issuePasscodeReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.issuePasscodeReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *TokenIssuer) PublicKeys() []oauth.PublicKey {
+	fake.publicKeysMutex.Lock()
+	ret, specificReturn := fake.publicKeysReturnsOnCall[len(fake.publicKeysArgsForCall)]
+	fake.publicKeysArgsForCall = append(fake.publicKeysArgsForCall, struct {
+	}{})
+	stub := fake.PublicKeysStub
+	fakeReturns := fake.publicKeysReturns
+	fake.recordInvocation("PublicKeys", []interface{}{})
+	fake.publicKeysMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *TokenIssuer) PublicKeysCallCount() int {
+	fake.publicKeysMutex.RLock()
+	defer fake.publicKeysMutex.RUnlock()
+	return len(fake.publicKeysArgsForCall)
+}
+
+func (fake *TokenIssuer) PublicKeysCalls(stub func() []oauth.PublicKey) {
+	fake.publicKeysMutex.Lock()
+	defer fake.publicKeysMutex.Unlock()
+	fake.PublicKeysStub = stub
+}
+
+func (fake *TokenIssuer) PublicKeysReturns(result1 []oauth.PublicKey) {
+	fake.publicKeysMutex.Lock()
+	defer fake.publicKeysMutex.Unlock()
+	fake.PublicKeysStub = nil
+	fake.publicKeysReturns = struct {
+		result1 []oauth.PublicKey
+	}{result1}
+}
+
+func (fake *TokenIssuer) PublicKeysReturnsOnCall(i int, result1 []oauth.PublicKey) {
+	fake.publicKeysMutex.Lock()
+	defer fake.publicKeysMutex.Unlock()
+	fake.PublicKeysStub = nil
+	if fake.publicKeysReturnsOnCall == nil {
+		fake.publicKeysReturnsOnCall = make(map[int]struct {
+			result1 []oauth.PublicKey
+		})
+	}
+	fake.publicKeysReturnsOnCall[i] = struct {
+		result1 []oauth.PublicKey
+	}{result1}
+}
+
+func (fake *TokenIssuer) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.grantMutex.RLock()
+	defer fake.grantMutex.RUnlock()
+	fake.issuePasscodeMutex.RLock()
+	defer fake.issuePasscodeMutex.RUnlock()
+	fake.publicKeysMutex.RLock()
+	defer fake.publicKeysMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *TokenIssuer) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ handlers.TokenIssuer = new(TokenIssuer)
@@ -0,0 +1,103 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/api/routing"
+
+	"github.com/go-logr/logr"
+)
+
+const (
+	FeatureFlagsPath = "/v3/feature_flags"
+	FeatureFlagPath  = "/v3/feature_flags/{name}"
+)
+
+//counterfeiter:generate -o fake -fake-name CFFeatureFlagRepository . CFFeatureFlagRepository
+
+type CFFeatureFlagRepository interface {
+	GetFeatureFlag(context.Context, authorization.Info, string) (repositories.FeatureFlagRecord, error)
+	ListFeatureFlags(context.Context, authorization.Info) ([]repositories.FeatureFlagRecord, error)
+	UpdateFeatureFlag(context.Context, authorization.Info, repositories.UpdateFeatureFlagMessage) (repositories.FeatureFlagRecord, error)
+	EnsureFeatureEnabled(context.Context, authorization.Info, string) error
+}
+
+type FeatureFlag struct {
+	serverURL        url.URL
+	featureFlagRepo  CFFeatureFlagRepository
+	requestValidator RequestValidator
+}
+
+func NewFeatureFlag(
+	serverURL url.URL,
+	featureFlagRepo CFFeatureFlagRepository,
+	requestValidator RequestValidator,
+) *FeatureFlag {
+	return &FeatureFlag{
+		serverURL:        serverURL,
+		featureFlagRepo:  featureFlagRepo,
+		requestValidator: requestValidator,
+	}
+}
+
+func (h *FeatureFlag) get(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.feature-flag.get")
+	name := routing.URLParam(r, "name")
+
+	featureFlag, err := h.featureFlagRepo.GetFeatureFlag(r.Context(), authInfo, name)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to fetch feature flag", "name", name)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForFeatureFlag(featureFlag, h.serverURL)), nil
+}
+
+func (h *FeatureFlag) list(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.feature-flag.list")
+
+	featureFlags, err := h.featureFlagRepo.ListFeatureFlags(r.Context(), authInfo)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to list feature flags")
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForList(presenter.ForFeatureFlag, featureFlags, h.serverURL, *r.URL)), nil
+}
+
+func (h *FeatureFlag) update(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.feature-flag.update")
+	name := routing.URLParam(r, "name")
+
+	var payload payloads.FeatureFlagUpdate
+	if err := h.requestValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	featureFlag, err := h.featureFlagRepo.UpdateFeatureFlag(r.Context(), authInfo, payload.ToMessage(name))
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to update feature flag", "name", name)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForFeatureFlag(featureFlag, h.serverURL)), nil
+}
+
+func (h *FeatureFlag) UnauthenticatedRoutes() []routing.Route {
+	return nil
+}
+
+func (h *FeatureFlag) AuthenticatedRoutes() []routing.Route {
+	return []routing.Route{
+		{Method: "GET", Pattern: FeatureFlagsPath, Handler: h.list},
+		{Method: "GET", Pattern: FeatureFlagPath, Handler: h.get},
+		{Method: "PATCH", Pattern: FeatureFlagPath, Handler: h.update},
+	}
+}
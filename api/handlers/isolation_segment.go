@@ -0,0 +1,357 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/api/routing"
+
+	"github.com/go-logr/logr"
+)
+
+const (
+	IsolationSegmentsPath                    = "/v3/isolation_segments"
+	IsolationSegmentPath                     = "/v3/isolation_segments/{guid}"
+	IsolationSegmentOrgsRelationshipPath     = "/v3/isolation_segments/{guid}/relationships/organizations"
+	IsolationSegmentOrgRelationshipPath      = "/v3/isolation_segments/{guid}/relationships/organizations/{org_guid}"
+	IsolationSegmentSpacesRelationshipPath   = "/v3/isolation_segments/{guid}/relationships/spaces"
+	SpaceIsolationSegmentRelationshipPath    = "/v3/spaces/{guid}/relationships/isolation_segment"
+	invalidIsolationSegmentMsg               = "Unable to assign isolation segment. Ensure it has been entitled to the organization that this space belongs to."
+	invalidIsolationSegmentEntitlementMsg    = "Unable to entitle isolation segment. Ensure the organizations exist and you have access to them."
+	isolationSegmentInUseMsg                 = "Cannot remove the entitlement while the isolation segment is assigned to spaces in the organization."
+	isolationSegmentEntitledOrganizationsMsg = "Cannot delete an isolation segment that is entitled to organizations."
+)
+
+//counterfeiter:generate -o fake -fake-name CFIsolationSegmentRepository . CFIsolationSegmentRepository
+
+type CFIsolationSegmentRepository interface {
+	CreateIsolationSegment(context.Context, authorization.Info, repositories.CreateIsolationSegmentMessage) (repositories.IsolationSegmentRecord, error)
+	GetIsolationSegment(context.Context, authorization.Info, string) (repositories.IsolationSegmentRecord, error)
+	ListIsolationSegments(context.Context, authorization.Info, repositories.ListIsolationSegmentsMessage) ([]repositories.IsolationSegmentRecord, error)
+	UpdateIsolationSegment(context.Context, authorization.Info, repositories.UpdateIsolationSegmentMessage) (repositories.IsolationSegmentRecord, error)
+	EntitleOrganizations(context.Context, authorization.Info, repositories.EntitleIsolationSegmentMessage) (repositories.IsolationSegmentRecord, error)
+	RevokeOrganization(context.Context, authorization.Info, repositories.RevokeIsolationSegmentMessage) error
+	DeleteIsolationSegment(context.Context, authorization.Info, string) error
+}
+
+type IsolationSegment struct {
+	serverURL            url.URL
+	requestValidator     RequestValidator
+	isolationSegmentRepo CFIsolationSegmentRepository
+	orgRepo              CFOrgRepository
+	spaceRepo            CFSpaceRepository
+}
+
+func NewIsolationSegment(
+	serverURL url.URL,
+	requestValidator RequestValidator,
+	isolationSegmentRepo CFIsolationSegmentRepository,
+	orgRepo CFOrgRepository,
+	spaceRepo CFSpaceRepository,
+) *IsolationSegment {
+	return &IsolationSegment{
+		serverURL:            serverURL,
+		requestValidator:     requestValidator,
+		isolationSegmentRepo: isolationSegmentRepo,
+		orgRepo:              orgRepo,
+		spaceRepo:            spaceRepo,
+	}
+}
+
+func (h *IsolationSegment) create(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.isolation-segment.create")
+
+	var payload payloads.IsolationSegmentCreate
+	if err := h.requestValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	isolationSegment, err := h.isolationSegmentRepo.CreateIsolationSegment(r.Context(), authInfo, payload.ToMessage())
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to create isolation segment")
+	}
+
+	return routing.NewResponse(http.StatusCreated).WithBody(presenter.ForIsolationSegment(isolationSegment, h.serverURL)), nil
+}
+
+func (h *IsolationSegment) get(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.isolation-segment.get")
+
+	guid := routing.URLParam(r, "guid")
+
+	isolationSegment, err := h.isolationSegmentRepo.GetIsolationSegment(r.Context(), authInfo, guid)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get isolation segment", "guid", guid)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForIsolationSegment(isolationSegment, h.serverURL)), nil
+}
+
+func (h *IsolationSegment) list(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.isolation-segment.list")
+
+	payload := new(payloads.IsolationSegmentList)
+	if err := h.requestValidator.DecodeAndValidateURLValues(r, payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Unable to decode request query parameters")
+	}
+
+	isolationSegments, err := h.isolationSegmentRepo.ListIsolationSegments(r.Context(), authInfo, payload.ToMessage())
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to list isolation segments")
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForList(presenter.ForIsolationSegment, isolationSegments, h.serverURL, *r.URL)), nil
+}
+
+func (h *IsolationSegment) update(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.isolation-segment.update")
+
+	guid := routing.URLParam(r, "guid")
+
+	var payload payloads.IsolationSegmentUpdate
+	if err := h.requestValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	_, err := h.isolationSegmentRepo.GetIsolationSegment(r.Context(), authInfo, guid)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get isolation segment", "guid", guid)
+	}
+
+	isolationSegment, err := h.isolationSegmentRepo.UpdateIsolationSegment(r.Context(), authInfo, payload.ToMessage(guid))
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to update isolation segment", "guid", guid)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForIsolationSegment(isolationSegment, h.serverURL)), nil
+}
+
+func (h *IsolationSegment) delete(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.isolation-segment.delete")
+
+	guid := routing.URLParam(r, "guid")
+
+	isolationSegment, err := h.isolationSegmentRepo.GetIsolationSegment(r.Context(), authInfo, guid)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get isolation segment", "guid", guid)
+	}
+
+	if len(isolationSegment.Organizations) > 0 {
+		return nil, apierrors.LogAndReturn(
+			logger,
+			apierrors.NewUnprocessableEntityError(fmt.Errorf("isolation segment %s is entitled to organizations", guid), isolationSegmentEntitledOrganizationsMsg),
+			isolationSegmentEntitledOrganizationsMsg,
+		)
+	}
+
+	err = h.isolationSegmentRepo.DeleteIsolationSegment(r.Context(), authInfo, guid)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to delete isolation segment", "guid", guid)
+	}
+
+	return routing.NewResponse(http.StatusNoContent), nil
+}
+
+func (h *IsolationSegment) listOrgs(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.isolation-segment.list-orgs")
+
+	guid := routing.URLParam(r, "guid")
+
+	isolationSegment, err := h.isolationSegmentRepo.GetIsolationSegment(r.Context(), authInfo, guid)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get isolation segment", "guid", guid)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForIsolationSegmentOrganizations(isolationSegment, h.serverURL)), nil
+}
+
+func (h *IsolationSegment) entitleOrgs(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.isolation-segment.entitle-orgs")
+
+	guid := routing.URLParam(r, "guid")
+
+	var payload payloads.IsolationSegmentEntitleOrgs
+	if err := h.requestValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	_, err := h.isolationSegmentRepo.GetIsolationSegment(r.Context(), authInfo, guid)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get isolation segment", "guid", guid)
+	}
+
+	message := payload.ToMessage(guid)
+	for _, orgGUID := range message.OrganizationGUIDs {
+		if _, err = h.orgRepo.GetOrg(r.Context(), authInfo, orgGUID); err != nil {
+			return nil, apierrors.LogAndReturn(
+				logger,
+				apierrors.AsUnprocessableEntity(err, invalidIsolationSegmentEntitlementMsg, apierrors.ForbiddenError{}, apierrors.NotFoundError{}),
+				"failed to get organization", "orgGUID", orgGUID,
+			)
+		}
+	}
+
+	isolationSegment, err := h.isolationSegmentRepo.EntitleOrganizations(r.Context(), authInfo, message)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to entitle organizations", "guid", guid)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForIsolationSegmentOrganizations(isolationSegment, h.serverURL)), nil
+}
+
+func (h *IsolationSegment) revokeOrg(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.isolation-segment.revoke-org")
+
+	guid := routing.URLParam(r, "guid")
+	orgGUID := routing.URLParam(r, "org_guid")
+
+	_, err := h.isolationSegmentRepo.GetIsolationSegment(r.Context(), authInfo, guid)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get isolation segment", "guid", guid)
+	}
+
+	spaces, err := h.spaceRepo.ListSpaces(r.Context(), authInfo, repositories.ListSpacesMessage{
+		OrganizationGUIDs:     []string{orgGUID},
+		IsolationSegmentGUIDs: []string{guid},
+	})
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to list spaces", "guid", guid, "orgGUID", orgGUID)
+	}
+
+	if len(spaces) > 0 {
+		return nil, apierrors.LogAndReturn(
+			logger,
+			apierrors.NewUnprocessableEntityError(fmt.Errorf("isolation segment %s is assigned to spaces in org %s", guid, orgGUID), isolationSegmentInUseMsg),
+			isolationSegmentInUseMsg,
+		)
+	}
+
+	err = h.isolationSegmentRepo.RevokeOrganization(r.Context(), authInfo, repositories.RevokeIsolationSegmentMessage{
+		GUID:             guid,
+		OrganizationGUID: orgGUID,
+	})
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to revoke organization", "guid", guid, "orgGUID", orgGUID)
+	}
+
+	return routing.NewResponse(http.StatusNoContent), nil
+}
+
+func (h *IsolationSegment) listSpaces(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.isolation-segment.list-spaces")
+
+	guid := routing.URLParam(r, "guid")
+
+	_, err := h.isolationSegmentRepo.GetIsolationSegment(r.Context(), authInfo, guid)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get isolation segment", "guid", guid)
+	}
+
+	spaces, err := h.spaceRepo.ListSpaces(r.Context(), authInfo, repositories.ListSpacesMessage{
+		IsolationSegmentGUIDs: []string{guid},
+	})
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to list spaces", "guid", guid)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForIsolationSegmentSpaces(guid, spaces, h.serverURL)), nil
+}
+
+func (h *IsolationSegment) getSpaceIsolationSegment(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.isolation-segment.get-space-isolation-segment")
+
+	spaceGUID := routing.URLParam(r, "guid")
+
+	space, err := h.spaceRepo.GetSpace(r.Context(), authInfo, spaceGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get space", "spaceGUID", spaceGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForSpaceIsolationSegment(space, h.serverURL)), nil
+}
+
+func (h *IsolationSegment) assignSpaceIsolationSegment(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.isolation-segment.assign-space-isolation-segment")
+
+	spaceGUID := routing.URLParam(r, "guid")
+
+	var payload payloads.SpaceIsolationSegmentAssign
+	if err := h.requestValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	space, err := h.spaceRepo.GetSpace(r.Context(), authInfo, spaceGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get space", "spaceGUID", spaceGUID)
+	}
+
+	isolationSegmentGUID := payload.IsolationSegmentGUID()
+	if isolationSegmentGUID != "" {
+		isolationSegment, err := h.isolationSegmentRepo.GetIsolationSegment(r.Context(), authInfo, isolationSegmentGUID)
+		if err != nil {
+			return nil, apierrors.LogAndReturn(
+				logger,
+				apierrors.AsUnprocessableEntity(err, invalidIsolationSegmentMsg, apierrors.ForbiddenError{}, apierrors.NotFoundError{}),
+				"failed to get isolation segment", "guid", isolationSegmentGUID,
+			)
+		}
+
+		if !slices.Contains(isolationSegment.Organizations, space.OrganizationGUID) {
+			return nil, apierrors.LogAndReturn(
+				logger,
+				apierrors.NewUnprocessableEntityError(fmt.Errorf("isolation segment %s is not entitled to org %s", isolationSegmentGUID, space.OrganizationGUID), invalidIsolationSegmentMsg),
+				invalidIsolationSegmentMsg,
+			)
+		}
+	}
+
+	space, err = h.spaceRepo.AssignIsolationSegment(r.Context(), authInfo, repositories.AssignSpaceIsolationSegmentMessage{
+		GUID:                 space.GUID,
+		OrgGUID:              space.OrganizationGUID,
+		IsolationSegmentGUID: isolationSegmentGUID,
+	})
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to assign isolation segment", "spaceGUID", spaceGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForSpaceIsolationSegment(space, h.serverURL)), nil
+}
+
+func (h *IsolationSegment) UnauthenticatedRoutes() []routing.Route {
+	return nil
+}
+
+func (h *IsolationSegment) AuthenticatedRoutes() []routing.Route {
+	return []routing.Route{
+		{Method: "POST", Pattern: IsolationSegmentsPath, Handler: h.create},
+		{Method: "GET", Pattern: IsolationSegmentsPath, Handler: h.list},
+		{Method: "GET", Pattern: IsolationSegmentPath, Handler: h.get},
+		{Method: "PATCH", Pattern: IsolationSegmentPath, Handler: h.update},
+		{Method: "DELETE", Pattern: IsolationSegmentPath, Handler: h.delete},
+		{Method: "GET", Pattern: IsolationSegmentOrgsRelationshipPath, Handler: h.listOrgs},
+		{Method: "POST", Pattern: IsolationSegmentOrgsRelationshipPath, Handler: h.entitleOrgs},
+		{Method: "DELETE", Pattern: IsolationSegmentOrgRelationshipPath, Handler: h.revokeOrg},
+		{Method: "GET", Pattern: IsolationSegmentSpacesRelationshipPath, Handler: h.listSpaces},
+		{Method: "GET", Pattern: SpaceIsolationSegmentRelationshipPath, Handler: h.getSpaceIsolationSegment},
+		{Method: "PATCH", Pattern: SpaceIsolationSegmentRelationshipPath, Handler: h.assignSpaceIsolationSegment},
+	}
+}
@@ -0,0 +1,492 @@
+package handlers_test
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/handlers"
+	"code.cloudfoundry.org/korifi/api/handlers/fake"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	. "code.cloudfoundry.org/korifi/tests/matchers"
+	"code.cloudfoundry.org/korifi/tools"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+)
+
+var _ = Describe("IsolationSegment", func() {
+	var (
+		apiHandler           *handlers.IsolationSegment
+		isolationSegmentRepo *fake.CFIsolationSegmentRepository
+		orgRepo              *fake.CFOrgRepository
+		spaceRepo            *fake.CFSpaceRepository
+		requestValidator     *fake.RequestValidator
+		req                  *http.Request
+	)
+
+	BeforeEach(func() {
+		requestValidator = new(fake.RequestValidator)
+		isolationSegmentRepo = new(fake.CFIsolationSegmentRepository)
+		orgRepo = new(fake.CFOrgRepository)
+		spaceRepo = new(fake.CFSpaceRepository)
+		apiHandler = handlers.NewIsolationSegment(
+			*serverURL,
+			requestValidator,
+			isolationSegmentRepo,
+			orgRepo,
+			spaceRepo,
+		)
+		routerBuilder.LoadRoutes(apiHandler)
+
+		isolationSegmentRepo.GetIsolationSegmentReturns(repositories.IsolationSegmentRecord{
+			GUID:          "iso-seg-guid",
+			Name:          "my-iso-seg",
+			Organizations: []string{"org-guid"},
+		}, nil)
+	})
+
+	JustBeforeEach(func() {
+		routerBuilder.Build().ServeHTTP(rr, req)
+	})
+
+	Describe("POST /v3/isolation_segments", func() {
+		BeforeEach(func() {
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.IsolationSegmentCreate{
+				Name: "my-iso-seg",
+				Metadata: payloads.Metadata{
+					Labels: map[string]string{"foo": "bar"},
+				},
+			})
+
+			isolationSegmentRepo.CreateIsolationSegmentReturns(repositories.IsolationSegmentRecord{
+				GUID: "iso-seg-guid",
+				Name: "my-iso-seg",
+			}, nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "POST", "/v3/isolation_segments", strings.NewReader("the-json-body"))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("creates the isolation segment", func() {
+			Expect(requestValidator.DecodeAndValidateJSONPayloadCallCount()).To(Equal(1))
+			actualReq, _ := requestValidator.DecodeAndValidateJSONPayloadArgsForCall(0)
+			Expect(bodyString(actualReq)).To(Equal("the-json-body"))
+
+			Expect(isolationSegmentRepo.CreateIsolationSegmentCallCount()).To(Equal(1))
+			_, actualAuthInfo, createMessage := isolationSegmentRepo.CreateIsolationSegmentArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(createMessage.Name).To(Equal("my-iso-seg"))
+			Expect(createMessage.Metadata.Labels).To(Equal(map[string]string{"foo": "bar"}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusCreated))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.guid", "iso-seg-guid"),
+				MatchJSONPath("$.name", "my-iso-seg"),
+				MatchJSONPath("$.links.self.href", "https://api.example.org/v3/isolation_segments/iso-seg-guid"),
+			)))
+		})
+
+		When("decoding the payload fails", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateJSONPayloadReturns(apierrors.NewUnprocessableEntityError(nil, "oops"))
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError("oops")
+			})
+		})
+
+		When("creating the isolation segment fails", func() {
+			BeforeEach(func() {
+				isolationSegmentRepo.CreateIsolationSegmentReturns(repositories.IsolationSegmentRecord{}, errors.New("create-err"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("GET /v3/isolation_segments/:guid", func() {
+		BeforeEach(func() {
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "GET", "/v3/isolation_segments/iso-seg-guid", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns the isolation segment", func() {
+			Expect(isolationSegmentRepo.GetIsolationSegmentCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualGUID := isolationSegmentRepo.GetIsolationSegmentArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualGUID).To(Equal("iso-seg-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.guid", "iso-seg-guid"),
+				MatchJSONPath("$.links.organizations.href", "https://api.example.org/v3/isolation_segments/iso-seg-guid/organizations"),
+			)))
+		})
+
+		When("the isolation segment is forbidden", func() {
+			BeforeEach(func() {
+				isolationSegmentRepo.GetIsolationSegmentReturns(repositories.IsolationSegmentRecord{}, apierrors.NewForbiddenError(nil, repositories.IsolationSegmentResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.IsolationSegmentResourceType)
+			})
+		})
+	})
+
+	Describe("GET /v3/isolation_segments", func() {
+		BeforeEach(func() {
+			requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(&payloads.IsolationSegmentList{
+				Names: "my-iso-seg",
+			})
+
+			isolationSegmentRepo.ListIsolationSegmentsReturns([]repositories.IsolationSegmentRecord{
+				{GUID: "iso-seg-guid", Name: "my-iso-seg"},
+			}, nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "GET", "/v3/isolation_segments?names=my-iso-seg", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("lists the isolation segments", func() {
+			Expect(isolationSegmentRepo.ListIsolationSegmentsCallCount()).To(Equal(1))
+			_, actualAuthInfo, listMessage := isolationSegmentRepo.ListIsolationSegmentsArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(listMessage.Names).To(ConsistOf("my-iso-seg"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.pagination.total_results", BeEquivalentTo(1)),
+				MatchJSONPath("$.resources[0].guid", "iso-seg-guid"),
+			)))
+		})
+
+		When("listing fails", func() {
+			BeforeEach(func() {
+				isolationSegmentRepo.ListIsolationSegmentsReturns(nil, errors.New("list-err"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("PATCH /v3/isolation_segments/:guid", func() {
+		BeforeEach(func() {
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.IsolationSegmentUpdate{
+				Name: tools.PtrTo("new-name"),
+			})
+
+			isolationSegmentRepo.UpdateIsolationSegmentReturns(repositories.IsolationSegmentRecord{
+				GUID: "iso-seg-guid",
+				Name: "new-name",
+			}, nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "PATCH", "/v3/isolation_segments/iso-seg-guid", strings.NewReader("the-json-body"))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("updates the isolation segment", func() {
+			Expect(isolationSegmentRepo.UpdateIsolationSegmentCallCount()).To(Equal(1))
+			_, _, updateMessage := isolationSegmentRepo.UpdateIsolationSegmentArgsForCall(0)
+			Expect(updateMessage.GUID).To(Equal("iso-seg-guid"))
+			Expect(updateMessage.Name).To(PointTo(Equal("new-name")))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(MatchJSONPath("$.name", "new-name")))
+		})
+
+		When("the isolation segment does not exist", func() {
+			BeforeEach(func() {
+				isolationSegmentRepo.GetIsolationSegmentReturns(repositories.IsolationSegmentRecord{}, apierrors.NewNotFoundError(nil, repositories.IsolationSegmentResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.IsolationSegmentResourceType)
+				Expect(isolationSegmentRepo.UpdateIsolationSegmentCallCount()).To(BeZero())
+			})
+		})
+	})
+
+	Describe("DELETE /v3/isolation_segments/:guid", func() {
+		BeforeEach(func() {
+			isolationSegmentRepo.GetIsolationSegmentReturns(repositories.IsolationSegmentRecord{
+				GUID: "iso-seg-guid",
+			}, nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "DELETE", "/v3/isolation_segments/iso-seg-guid", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("deletes the isolation segment", func() {
+			Expect(isolationSegmentRepo.DeleteIsolationSegmentCallCount()).To(Equal(1))
+			_, _, actualGUID := isolationSegmentRepo.DeleteIsolationSegmentArgsForCall(0)
+			Expect(actualGUID).To(Equal("iso-seg-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusNoContent))
+		})
+
+		When("the isolation segment is entitled to organizations", func() {
+			BeforeEach(func() {
+				isolationSegmentRepo.GetIsolationSegmentReturns(repositories.IsolationSegmentRecord{
+					GUID:          "iso-seg-guid",
+					Organizations: []string{"org-guid"},
+				}, nil)
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("Cannot delete an isolation segment that is entitled to organizations.")
+				Expect(isolationSegmentRepo.DeleteIsolationSegmentCallCount()).To(BeZero())
+			})
+		})
+	})
+
+	Describe("GET /v3/isolation_segments/:guid/relationships/organizations", func() {
+		BeforeEach(func() {
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "GET", "/v3/isolation_segments/iso-seg-guid/relationships/organizations", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns the entitled organizations", func() {
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.data[0].guid", "org-guid"),
+				MatchJSONPath("$.links.self.href", "https://api.example.org/v3/isolation_segments/iso-seg-guid/relationships/organizations"),
+			)))
+		})
+	})
+
+	Describe("POST /v3/isolation_segments/:guid/relationships/organizations", func() {
+		BeforeEach(func() {
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.IsolationSegmentEntitleOrgs{
+				Data: []payloads.RelationshipData{{GUID: "org-guid"}, {GUID: "other-org-guid"}},
+			})
+
+			isolationSegmentRepo.EntitleOrganizationsReturns(repositories.IsolationSegmentRecord{
+				GUID:          "iso-seg-guid",
+				Organizations: []string{"org-guid", "other-org-guid"},
+			}, nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "POST", "/v3/isolation_segments/iso-seg-guid/relationships/organizations", strings.NewReader("the-json-body"))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("entitles the organizations", func() {
+			Expect(orgRepo.GetOrgCallCount()).To(Equal(2))
+
+			Expect(isolationSegmentRepo.EntitleOrganizationsCallCount()).To(Equal(1))
+			_, _, entitleMessage := isolationSegmentRepo.EntitleOrganizationsArgsForCall(0)
+			Expect(entitleMessage).To(Equal(repositories.EntitleIsolationSegmentMessage{
+				GUID:              "iso-seg-guid",
+				OrganizationGUIDs: []string{"org-guid", "other-org-guid"},
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(MatchJSONPath("$.data[*].guid", ConsistOf("org-guid", "other-org-guid"))))
+		})
+
+		When("an organization does not exist", func() {
+			BeforeEach(func() {
+				orgRepo.GetOrgReturns(repositories.OrgRecord{}, apierrors.NewNotFoundError(nil, repositories.OrgResourceType))
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("Unable to entitle isolation segment. Ensure the organizations exist and you have access to them.")
+				Expect(isolationSegmentRepo.EntitleOrganizationsCallCount()).To(BeZero())
+			})
+		})
+	})
+
+	Describe("DELETE /v3/isolation_segments/:guid/relationships/organizations/:org_guid", func() {
+		BeforeEach(func() {
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "DELETE", "/v3/isolation_segments/iso-seg-guid/relationships/organizations/org-guid", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("revokes the organization entitlement", func() {
+			Expect(spaceRepo.ListSpacesCallCount()).To(Equal(1))
+			_, _, listMessage := spaceRepo.ListSpacesArgsForCall(0)
+			Expect(listMessage.OrganizationGUIDs).To(ConsistOf("org-guid"))
+			Expect(listMessage.IsolationSegmentGUIDs).To(ConsistOf("iso-seg-guid"))
+
+			Expect(isolationSegmentRepo.RevokeOrganizationCallCount()).To(Equal(1))
+			_, _, revokeMessage := isolationSegmentRepo.RevokeOrganizationArgsForCall(0)
+			Expect(revokeMessage).To(Equal(repositories.RevokeIsolationSegmentMessage{
+				GUID:             "iso-seg-guid",
+				OrganizationGUID: "org-guid",
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusNoContent))
+		})
+
+		When("the isolation segment is assigned to spaces in the organization", func() {
+			BeforeEach(func() {
+				spaceRepo.ListSpacesReturns([]repositories.SpaceRecord{{GUID: "space-guid"}}, nil)
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("Cannot remove the entitlement while the isolation segment is assigned to spaces in the organization.")
+				Expect(isolationSegmentRepo.RevokeOrganizationCallCount()).To(BeZero())
+			})
+		})
+	})
+
+	Describe("GET /v3/isolation_segments/:guid/relationships/spaces", func() {
+		BeforeEach(func() {
+			spaceRepo.ListSpacesReturns([]repositories.SpaceRecord{{GUID: "space-guid"}}, nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "GET", "/v3/isolation_segments/iso-seg-guid/relationships/spaces", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns the assigned spaces", func() {
+			Expect(spaceRepo.ListSpacesCallCount()).To(Equal(1))
+			_, _, listMessage := spaceRepo.ListSpacesArgsForCall(0)
+			Expect(listMessage.IsolationSegmentGUIDs).To(ConsistOf("iso-seg-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.data[0].guid", "space-guid"),
+				MatchJSONPath("$.links.self.href", "https://api.example.org/v3/isolation_segments/iso-seg-guid/relationships/spaces"),
+			)))
+		})
+	})
+
+	Describe("GET /v3/spaces/:guid/relationships/isolation_segment", func() {
+		BeforeEach(func() {
+			spaceRepo.GetSpaceReturns(repositories.SpaceRecord{
+				GUID:                 "space-guid",
+				OrganizationGUID:     "org-guid",
+				IsolationSegmentGUID: "iso-seg-guid",
+			}, nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "GET", "/v3/spaces/space-guid/relationships/isolation_segment", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns the space isolation segment", func() {
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.data.guid", "iso-seg-guid"),
+				MatchJSONPath("$.links.self.href", "https://api.example.org/v3/spaces/space-guid/relationships/isolation_segment"),
+				MatchJSONPath("$.links.related.href", "https://api.example.org/v3/isolation_segments/iso-seg-guid"),
+			)))
+		})
+
+		When("the space is not assigned to an isolation segment", func() {
+			BeforeEach(func() {
+				spaceRepo.GetSpaceReturns(repositories.SpaceRecord{GUID: "space-guid"}, nil)
+			})
+
+			It("returns null data", func() {
+				Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+				Expect(rr).To(HaveHTTPBody(MatchJSONPath("$.data", BeNil())))
+			})
+		})
+
+		When("the space is forbidden", func() {
+			BeforeEach(func() {
+				spaceRepo.GetSpaceReturns(repositories.SpaceRecord{}, apierrors.NewForbiddenError(nil, repositories.SpaceResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.SpaceResourceType)
+			})
+		})
+	})
+
+	Describe("PATCH /v3/spaces/:guid/relationships/isolation_segment", func() {
+		var payload *payloads.SpaceIsolationSegmentAssign
+
+		BeforeEach(func() {
+			payload = &payloads.SpaceIsolationSegmentAssign{
+				Data: &payloads.RelationshipData{GUID: "iso-seg-guid"},
+			}
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(payload)
+
+			spaceRepo.GetSpaceReturns(repositories.SpaceRecord{
+				GUID:             "space-guid",
+				OrganizationGUID: "org-guid",
+			}, nil)
+			spaceRepo.AssignIsolationSegmentReturns(repositories.SpaceRecord{
+				GUID:                 "space-guid",
+				OrganizationGUID:     "org-guid",
+				IsolationSegmentGUID: "iso-seg-guid",
+			}, nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "PATCH", "/v3/spaces/space-guid/relationships/isolation_segment", strings.NewReader("the-json-body"))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("assigns the isolation segment to the space", func() {
+			Expect(spaceRepo.AssignIsolationSegmentCallCount()).To(Equal(1))
+			_, actualAuthInfo, assignMessage := spaceRepo.AssignIsolationSegmentArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(assignMessage).To(Equal(repositories.AssignSpaceIsolationSegmentMessage{
+				GUID:                 "space-guid",
+				OrgGUID:              "org-guid",
+				IsolationSegmentGUID: "iso-seg-guid",
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(MatchJSONPath("$.data.guid", "iso-seg-guid")))
+		})
+
+		When("the isolation segment is not entitled to the space organization", func() {
+			BeforeEach(func() {
+				isolationSegmentRepo.GetIsolationSegmentReturns(repositories.IsolationSegmentRecord{
+					GUID:          "iso-seg-guid",
+					Organizations: []string{"another-org-guid"},
+				}, nil)
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("Unable to assign isolation segment. Ensure it has been entitled to the organization that this space belongs to.")
+				Expect(spaceRepo.AssignIsolationSegmentCallCount()).To(BeZero())
+			})
+		})
+
+		When("the isolation segment does not exist", func() {
+			BeforeEach(func() {
+				isolationSegmentRepo.GetIsolationSegmentReturns(repositories.IsolationSegmentRecord{}, apierrors.NewNotFoundError(nil, repositories.IsolationSegmentResourceType))
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("Unable to assign isolation segment. Ensure it has been entitled to the organization that this space belongs to.")
+			})
+		})
+
+		When("the isolation segment is being unassigned", func() {
+			BeforeEach(func() {
+				payload.Data = nil
+			})
+
+			It("clears the space isolation segment", func() {
+				Expect(isolationSegmentRepo.GetIsolationSegmentCallCount()).To(BeZero())
+				Expect(spaceRepo.AssignIsolationSegmentCallCount()).To(Equal(1))
+				_, _, assignMessage := spaceRepo.AssignIsolationSegmentArgsForCall(0)
+				Expect(assignMessage.IsolationSegmentGUID).To(BeEmpty())
+			})
+		})
+	})
+})
@@ -30,8 +30,10 @@ const (
 	ServiceBrokerDeleteJobType          = "service_broker.delete"
 	ManagedServiceInstanceDeleteJobType = "managed_service_instance.delete"
 	ManagedServiceInstanceCreateJobType = "managed_service_instance.create"
+	ManagedServiceInstanceUpdateJobType = "managed_service_instance.update"
 	ManagedServiceBindingCreateJobType  = "managed_service_binding.create"
 	ManagedServiceBindingDeleteJobType  = "managed_service_binding.delete"
+	ManagedServiceBindingRotateJobType  = "managed_service_binding.rotate_credentials"
 	JobTimeoutDuration                  = 120.0
 )
 
@@ -42,29 +44,38 @@ type DeletionRepository interface {
 	GetDeletedAt(context.Context, authorization.Info, string) (*time.Time, error)
 }
 
-//counterfeiter:generate -o fake -fake-name StateRepository . StateRepository
-type StateRepository interface {
-	GetState(context.Context, authorization.Info, string) (model.CFResourceState, error)
+//counterfeiter:generate -o fake -fake-name OperationRepository . OperationRepository
+type OperationRepository interface {
+	GetOperation(context.Context, authorization.Info, string) (model.CFResourceOperation, error)
+}
+
+// DeletionOperationRepository is implemented by repositories of resources
+// whose deletion can fail, e.g. because a service broker refuses it
+//
+//counterfeiter:generate -o fake -fake-name DeletionOperationRepository . DeletionOperationRepository
+type DeletionOperationRepository interface {
+	DeletionRepository
+	OperationRepository
 }
 
 type Job struct {
-	serverURL            url.URL
-	deletionRepositories map[string]DeletionRepository
-	stateRepositories    map[string]StateRepository
-	pollingInterval      time.Duration
+	serverURL             url.URL
+	deletionRepositories  map[string]DeletionRepository
+	operationRepositories map[string]OperationRepository
+	pollingInterval       time.Duration
 }
 
 func NewJob(
 	serverURL url.URL,
 	deletionRepositories map[string]DeletionRepository,
-	stateRepositories map[string]StateRepository,
+	operationRepositories map[string]OperationRepository,
 	pollingInterval time.Duration,
 ) *Job {
 	return &Job{
-		serverURL:            serverURL,
-		deletionRepositories: deletionRepositories,
-		stateRepositories:    stateRepositories,
-		pollingInterval:      pollingInterval,
+		serverURL:             serverURL,
+		deletionRepositories:  deletionRepositories,
+		operationRepositories: operationRepositories,
+		pollingInterval:       pollingInterval,
 	}
 }
 
@@ -96,9 +107,9 @@ func (h *Job) get(r *http.Request) (*routing.Response, error) {
 			return routing.NewResponse(http.StatusOK).WithBody(jobResponse), nil
 		}
 
-		stateRepository, ok := h.stateRepositories[job.Type]
+		operationRepository, ok := h.operationRepositories[job.Type]
 		if ok {
-			jobResponse, err := h.handleStateJob(ctx, stateRepository, job)
+			jobResponse, err := h.handleOperationJob(ctx, operationRepository, job)
 			if err != nil {
 				return nil, err
 			}
@@ -145,6 +156,31 @@ func (h *Job) handleDeleteJob(ctx context.Context, repository DeletionRepository
 		)
 	}
 
+	if operationRepository, ok := repository.(OperationRepository); ok {
+		authInfo, _ := authorization.InfoFromContext(ctx)
+		operation, err := operationRepository.GetOperation(ctx, authInfo, job.ResourceGUID)
+		if err != nil {
+			if errors.As(err, &apierrors.NotFoundError{}) || errors.As(err, &apierrors.ForbiddenError{}) {
+				return presenter.ForJob(job,
+					[]presenter.JobResponseError{},
+					presenter.StateComplete,
+					h.serverURL,
+				), nil
+			}
+
+			return presenter.JobResponse{}, apierrors.LogAndReturn(
+				log,
+				err,
+				"failed to get "+job.ResourceType+" operation from Kubernetes",
+				job.ResourceType+"GUID", job.ResourceGUID,
+			)
+		}
+
+		if operation.State == model.CFResourceStateFailed {
+			return presenter.ForOperationJob(job, operation, h.serverURL), nil
+		}
+	}
+
 	if time.Since(*deletedAt).Seconds() < JobTimeoutDuration {
 		return presenter.ForJob(
 			job,
@@ -166,10 +202,10 @@ func (h *Job) handleDeleteJob(ctx context.Context, repository DeletionRepository
 	), nil
 }
 
-func (h *Job) handleStateJob(ctx context.Context, repository StateRepository, job presenter.Job) (presenter.JobResponse, error) {
-	ctx, log := logger.FromContext(ctx, "handleStateJob")
+func (h *Job) handleOperationJob(ctx context.Context, repository OperationRepository, job presenter.Job) (presenter.JobResponse, error) {
+	ctx, log := logger.FromContext(ctx, "handleOperationJob")
 	authInfo, _ := authorization.InfoFromContext(ctx)
-	state, err := repository.GetState(ctx, authInfo, job.ResourceGUID)
+	operation, err := repository.GetOperation(ctx, authInfo, job.ResourceGUID)
 	if err != nil {
 		if errors.As(err, &apierrors.ForbiddenError{}) {
 			return presenter.ForJob(job,
@@ -181,26 +217,12 @@ func (h *Job) handleStateJob(ctx context.Context, repository StateRepository, jo
 		return presenter.JobResponse{}, apierrors.LogAndReturn(
 			log,
 			err,
-			"failed to get "+job.ResourceType+" state from Kubernetes",
+			"failed to get "+job.ResourceType+" operation from Kubernetes",
 			job.ResourceType+"GUID", job.ResourceGUID,
 		)
 	}
 
-	switch state {
-	case model.CFResourceStateReady:
-		return presenter.ForJob(job,
-			[]presenter.JobResponseError{},
-			presenter.StateComplete,
-			h.serverURL,
-		), nil
-
-	default:
-		return presenter.ForJob(job,
-			[]presenter.JobResponseError{},
-			presenter.StateProcessing,
-			h.serverURL,
-		), nil
-	}
+	return presenter.ForOperationJob(job, operation, h.serverURL), nil
 }
 
 func (h *Job) retryGetDeletedAt(ctx context.Context, repository DeletionRepository, job presenter.Job) (*time.Time, error) {
@@ -19,20 +19,20 @@ import (
 
 var _ = Describe("Job", func() {
 	var (
-		handler       *handlers.Job
-		deletionRepos map[string]handlers.DeletionRepository
-		stateRepos    map[string]handlers.StateRepository
-		jobGUID       string
-		req           *http.Request
+		handler        *handlers.Job
+		deletionRepos  map[string]handlers.DeletionRepository
+		operationRepos map[string]handlers.OperationRepository
+		jobGUID        string
+		req            *http.Request
 	)
 
 	BeforeEach(func() {
 		deletionRepos = map[string]handlers.DeletionRepository{}
-		stateRepos = map[string]handlers.StateRepository{}
+		operationRepos = map[string]handlers.OperationRepository{}
 	})
 
 	JustBeforeEach(func() {
-		handler = handlers.NewJob(*serverURL, deletionRepos, stateRepos, 0)
+		handler = handlers.NewJob(*serverURL, deletionRepos, operationRepos, 0)
 		routerBuilder.LoadRoutes(handler)
 
 		var err error
@@ -134,6 +134,72 @@ var _ = Describe("Job", func() {
 			})
 		})
 
+		When("the repository reports the deletion operation", func() {
+			var deletionOperationRepo *fake.DeletionOperationRepository
+
+			BeforeEach(func() {
+				deletionOperationRepo = new(fake.DeletionOperationRepository)
+				deletionOperationRepo.GetDeletedAtReturns(tools.PtrTo(time.Now()), nil)
+				deletionOperationRepo.GetOperationReturns(model.CFResourceOperation{State: model.CFResourceStateUnknown}, nil)
+				deletionRepos["testing.delete"] = deletionOperationRepo
+			})
+
+			It("returns a processing status", func() {
+				Expect(deletionOperationRepo.GetOperationCallCount()).To(Equal(1))
+				_, actualAuthInfo, actualResourceGUID := deletionOperationRepo.GetOperationArgsForCall(0)
+				Expect(actualAuthInfo).To(Equal(authInfo))
+				Expect(actualResourceGUID).To(Equal("my-resource-guid"))
+
+				Expect(rr).To(HaveHTTPBody(SatisfyAll(
+					MatchJSONPath("$.state", "PROCESSING"),
+					MatchJSONPath("$.errors", BeEmpty()),
+				)))
+			})
+
+			When("the deletion has failed", func() {
+				BeforeEach(func() {
+					deletionOperationRepo.GetOperationReturns(model.CFResourceOperation{
+						State:  model.CFResourceStateFailed,
+						Errors: []string{"broker said no"},
+					}, nil)
+				})
+
+				It("returns a failed status with the operation errors", func() {
+					Expect(rr).To(HaveHTTPBody(SatisfyAll(
+						MatchJSONPath("$.state", "FAILED"),
+						MatchJSONPath("$.errors", ConsistOf(map[string]interface{}{
+							"code":   float64(10008),
+							"detail": "broker said no",
+							"title":  "CF-UnprocessableEntity",
+						})),
+					)))
+				})
+			})
+
+			When("the resource is gone by the time the operation is fetched", func() {
+				BeforeEach(func() {
+					deletionOperationRepo.GetOperationReturns(model.CFResourceOperation{}, fmt.Errorf("wrapped error: %w", apierrors.NewNotFoundError(nil, "foo")))
+				})
+
+				It("returns a complete status", func() {
+					Expect(rr).To(HaveHTTPBody(SatisfyAll(
+						MatchJSONPath("$.state", "COMPLETE"),
+						MatchJSONPath("$.errors", BeEmpty()),
+					)))
+				})
+			})
+
+			When("getting the operation fails", func() {
+				BeforeEach(func() {
+					deletionOperationRepo.GetOperationReturns(model.CFResourceOperation{}, errors.New("get-operation-error"))
+				})
+
+				It("returns an error", func() {
+					expectUnknownError()
+				})
+			})
+		})
+
 		When("the resource has not been marked for deletion", func() {
 			BeforeEach(func() {
 				deletionRepo.GetDeletedAtReturns(nil, nil)
@@ -150,20 +216,20 @@ var _ = Describe("Job", func() {
 		})
 	})
 
-	Describe("GET /v3/jobs/*state*", func() {
-		var stateRepo *fake.StateRepository
+	Describe("GET /v3/jobs/*operation*", func() {
+		var operationRepo *fake.OperationRepository
 
 		BeforeEach(func() {
-			stateRepo = new(fake.StateRepository)
-			stateRepo.GetStateReturns(model.CFResourceStateUnknown, nil)
-			stateRepos["testing.state"] = stateRepo
+			operationRepo = new(fake.OperationRepository)
+			operationRepo.GetOperationReturns(model.CFResourceOperation{State: model.CFResourceStateUnknown}, nil)
+			operationRepos["testing.operation"] = operationRepo
 
-			jobGUID = "testing.state~my-resource-guid"
+			jobGUID = "testing.operation~my-resource-guid"
 		})
 
 		It("returns a processing status", func() {
-			Expect(stateRepo.GetStateCallCount()).To(Equal(1))
-			_, actualAuthInfo, actualResourceGUID := stateRepo.GetStateArgsForCall(0)
+			Expect(operationRepo.GetOperationCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualResourceGUID := operationRepo.GetOperationArgsForCall(0)
 			Expect(actualAuthInfo).To(Equal(authInfo))
 			Expect(actualResourceGUID).To(Equal("my-resource-guid"))
 
@@ -171,15 +237,34 @@ var _ = Describe("Job", func() {
 			Expect(rr).To(HaveHTTPBody(SatisfyAll(
 				MatchJSONPath("$.guid", jobGUID),
 				MatchJSONPath("$.links.self.href", defaultServerURL+"/v3/jobs/"+jobGUID),
-				MatchJSONPath("$.operation", "testing.state"),
+				MatchJSONPath("$.operation", "testing.operation"),
 				MatchJSONPath("$.state", "PROCESSING"),
 				MatchJSONPath("$.errors", BeEmpty()),
+				MatchJSONPath("$.warnings", BeEmpty()),
 			)))
 		})
 
-		When("the resource state is Ready", func() {
+		When("the operation has warnings", func() {
+			BeforeEach(func() {
+				operationRepo.GetOperationReturns(model.CFResourceOperation{
+					State:    model.CFResourceStateUnknown,
+					Warnings: []string{"catalog not available yet"},
+				}, nil)
+			})
+
+			It("returns the warnings", func() {
+				Expect(rr).To(HaveHTTPBody(SatisfyAll(
+					MatchJSONPath("$.state", "PROCESSING"),
+					MatchJSONPath("$.warnings", ConsistOf(map[string]interface{}{
+						"detail": "catalog not available yet",
+					})),
+				)))
+			})
+		})
+
+		When("the operation is complete", func() {
 			BeforeEach(func() {
-				stateRepo.GetStateReturns(model.CFResourceStateReady, nil)
+				operationRepo.GetOperationReturns(model.CFResourceOperation{State: model.CFResourceStateReady}, nil)
 			})
 
 			It("returns a complete status", func() {
@@ -190,9 +275,42 @@ var _ = Describe("Job", func() {
 			})
 		})
 
+		When("the operation has failed", func() {
+			BeforeEach(func() {
+				operationRepo.GetOperationReturns(model.CFResourceOperation{
+					State:  model.CFResourceStateFailed,
+					Errors: []string{"provision failed"},
+				}, nil)
+			})
+
+			It("returns a failed status with the operation errors", func() {
+				Expect(rr).To(HaveHTTPBody(SatisfyAll(
+					MatchJSONPath("$.state", "FAILED"),
+					MatchJSONPath("$.errors", ConsistOf(map[string]interface{}{
+						"code":   float64(10008),
+						"detail": "provision failed",
+						"title":  "CF-UnprocessableEntity",
+					})),
+				)))
+			})
+		})
+
+		When("the job is a managed service instance job", func() {
+			BeforeEach(func() {
+				operationRepos[handlers.ManagedServiceInstanceCreateJobType] = operationRepo
+				jobGUID = handlers.ManagedServiceInstanceCreateJobType + "~my-resource-guid"
+			})
+
+			It("links the service instance", func() {
+				Expect(rr).To(HaveHTTPBody(
+					MatchJSONPath("$.links.service_instances.href", defaultServerURL+"/v3/service_instances/my-resource-guid"),
+				))
+			})
+		})
+
 		When("the user does not have permission to see the resource", func() {
 			BeforeEach(func() {
-				stateRepo.GetStateReturns(model.CFResourceStateUnknown, fmt.Errorf("wrapped err: %w", apierrors.NewForbiddenError(nil, "foo")))
+				operationRepo.GetOperationReturns(model.CFResourceOperation{}, fmt.Errorf("wrapped err: %w", apierrors.NewForbiddenError(nil, "foo")))
 			})
 
 			It("returns a complete status", func() {
@@ -203,9 +321,9 @@ var _ = Describe("Job", func() {
 			})
 		})
 
-		When("getting the state fails", func() {
+		When("getting the operation fails", func() {
 			BeforeEach(func() {
-				stateRepo.GetStateReturns(model.CFResourceStateUnknown, errors.New("get-state-error"))
+				operationRepo.GetOperationReturns(model.CFResourceOperation{}, errors.New("get-operation-error"))
 			})
 
 			It("returns an error", func() {
@@ -1,30 +1,139 @@
 package handlers
 
 import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
 	"net/http"
 	"net/url"
 	"time"
 
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/oauth"
+	"code.cloudfoundry.org/korifi/api/presenter"
 	"code.cloudfoundry.org/korifi/api/routing"
 
+	"github.com/go-logr/logr"
 	"github.com/golang-jwt/jwt"
 )
 
 const (
-	OAuthTokenPath = "/oauth/token"
+	OAuthTokenPath       = "/oauth/token"
+	TokenKeysPath        = "/token_keys"
+	LoginPath            = "/login"
+	PasscodePath         = "/passcode"
+	PasscodeCallbackPath = "/passcode/callback"
+
+	ssoStateCookie  = "korifi-sso-state"
+	ssoClientCookie = "korifi-sso-client"
+
+	// defaultSSOClient is the client passcodes are issued to unless the
+	// passcode is requested for another client, e.g. with `/passcode?client_id=`
+	defaultSSOClient = "cf"
 )
 
+//counterfeiter:generate -o fake -fake-name TokenIssuer . TokenIssuer
+//counterfeiter:generate -o fake -fake-name SSOProvider . SSOProvider
+
+type TokenIssuer interface {
+	Grant(context.Context, oauth.GrantRequest) (oauth.Token, error)
+	IssuePasscode(oauth.Login, string) (string, error)
+	PublicKeys() []oauth.PublicKey
+}
+
+// SSOProvider authenticates users in the browser on behalf of clients that
+// log in with a passcode, such as `cf login --sso`
+type SSOProvider interface {
+	AuthorizationURL(ctx context.Context, redirectURI, state string) (string, error)
+	ExchangeCode(ctx context.Context, code, redirectURI string) (oauth.Login, error)
+}
+
 type OAuth struct {
-	apiBaseURL url.URL
+	apiBaseURL  url.URL
+	tokenIssuer TokenIssuer
+	ssoProvider SSOProvider
 }
 
-func NewOAuth(apiBaseURL url.URL) *OAuth {
+// NewOAuth serves the token endpoints of the embedded token service. Without a
+// token issuer, the token endpoint only serves placeholder tokens to clients
+// that authenticate with Kubernetes credentials. The passcode endpoints are
+// only served when there is an SSO provider.
+func NewOAuth(apiBaseURL url.URL, tokenIssuer TokenIssuer, ssoProvider SSOProvider) *OAuth {
 	return &OAuth{
-		apiBaseURL: apiBaseURL,
+		apiBaseURL:  apiBaseURL,
+		tokenIssuer: tokenIssuer,
+		ssoProvider: ssoProvider,
 	}
 }
 
 func (h *OAuth) token(r *http.Request) (*routing.Response, error) {
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.oauth.token")
+
+	if h.tokenIssuer == nil {
+		return placeholderToken(), nil
+	}
+
+	if err := r.ParseForm(); err != nil {
+		return oauthErrorResponse(oauth.NewInvalidRequestError("invalid form")), nil
+	}
+
+	clientID, clientSecret, ok := basicAuth(r)
+	if !ok {
+		clientID, clientSecret = r.PostForm.Get("client_id"), r.PostForm.Get("client_secret")
+	}
+
+	token, err := h.tokenIssuer.Grant(r.Context(), oauth.GrantRequest{
+		GrantType:    r.PostForm.Get("grant_type"),
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		Username:     r.PostForm.Get("username"),
+		Password:     r.PostForm.Get("password"),
+		Passcode:     r.PostForm.Get("passcode"),
+		Code:         r.PostForm.Get("code"),
+		RedirectURI:  r.PostForm.Get("redirect_uri"),
+		RefreshToken: r.PostForm.Get("refresh_token"),
+	})
+	if err != nil {
+		var oauthErr oauth.Error
+		if errors.As(err, &oauthErr) {
+			logger.Info("token request rejected", "reason", oauthErr.Error())
+			return oauthErrorResponse(oauthErr), nil
+		}
+		return nil, apierrors.LogAndReturn(logger, err, "failed to grant token")
+	}
+
+	return routing.NewResponse(http.StatusOK).
+		WithHeader("Cache-Control", "no-store").
+		WithBody(presenter.ForOAuthToken(token)), nil
+}
+
+// basicAuth returns the client credentials, which are form-urlencoded as per
+// RFC 6749, section 2.3.1
+func basicAuth(r *http.Request) (string, string, bool) {
+	username, password, ok := r.BasicAuth()
+	if !ok {
+		return "", "", false
+	}
+
+	clientID, err := url.QueryUnescape(username)
+	if err != nil {
+		return "", "", false
+	}
+
+	clientSecret, err := url.QueryUnescape(password)
+	if err != nil {
+		return "", "", false
+	}
+
+	return clientID, clientSecret, true
+}
+
+func oauthErrorResponse(err oauth.Error) *routing.Response {
+	return routing.NewResponse(err.HTTPStatus()).WithBody(presenter.ForOAuthError(err))
+}
+
+func placeholderToken() *routing.Response {
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
 		"exp": time.Now().Add(time.Hour).Unix(),
 	})
@@ -38,13 +147,123 @@ func (h *OAuth) token(r *http.Request) (*routing.Response, error) {
 	return routing.NewResponse(http.StatusOK).WithBody(map[string]string{
 		"token_type":   "bearer",
 		"access_token": tokenString,
-	}), nil
+	})
+}
+
+func (h *OAuth) tokenKeys(r *http.Request) (*routing.Response, error) {
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForTokenKeys(h.tokenIssuer.PublicKeys())), nil
+}
+
+func (h *OAuth) login(r *http.Request) (*routing.Response, error) {
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForLogin(h.apiBaseURL, h.ssoProvider != nil)), nil
+}
+
+func (h *OAuth) passcode(r *http.Request) (*routing.Response, error) {
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.oauth.passcode")
+
+	stateBytes := make([]byte, 16)
+	if _, err := rand.Read(stateBytes); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to generate sso state")
+	}
+	state := hex.EncodeToString(stateBytes)
+
+	authorizationURL, err := h.ssoProvider.AuthorizationURL(r.Context(), h.passcodeCallbackURL(), state)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to build the sso authorization url")
+	}
+
+	clientID := r.URL.Query().Get("client_id")
+	if clientID == "" {
+		clientID = defaultSSOClient
+	}
+
+	return routing.NewResponse(http.StatusFound).
+		WithHeader("Location", authorizationURL).
+		WithHeader("Set-Cookie", ssoCookie(ssoStateCookie, state)).
+		WithHeader("Set-Cookie", ssoCookie(ssoClientCookie, clientID)), nil
+}
+
+// ssoCookie remembers the state of the sso flow until the OIDC provider
+// redirects back to the passcode callback
+func ssoCookie(name, value string) string {
+	cookie := &http.Cookie{
+		Name:     name,
+		Value:    value,
+		Path:     PasscodeCallbackPath,
+		MaxAge:   int((10 * time.Minute).Seconds()),
+		Secure:   true,
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	}
+
+	return cookie.String()
+}
+
+func (h *OAuth) passcodeCallback(r *http.Request) (*routing.Response, error) {
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.oauth.passcode-callback")
+
+	query := r.URL.Query()
+	if upstreamErr := query.Get("error"); upstreamErr != "" {
+		return oauthErrorResponse(oauth.NewInvalidRequestError("sso failed: " + upstreamErr)), nil
+	}
+
+	stateCookie, err := r.Cookie(ssoStateCookie)
+	if err != nil || query.Get("state") == "" || stateCookie.Value != query.Get("state") {
+		return oauthErrorResponse(oauth.NewInvalidRequestError("invalid sso state")), nil
+	}
+
+	clientCookie, err := r.Cookie(ssoClientCookie)
+	if err != nil {
+		return oauthErrorResponse(oauth.NewInvalidRequestError("invalid sso state")), nil
+	}
+
+	login, err := h.ssoProvider.ExchangeCode(r.Context(), query.Get("code"), h.passcodeCallbackURL())
+	if err != nil {
+		var oauthErr oauth.Error
+		if errors.As(err, &oauthErr) {
+			return oauthErrorResponse(oauthErr), nil
+		}
+		return nil, apierrors.LogAndReturn(logger, err, "failed to exchange the sso code")
+	}
+
+	passcode, err := h.tokenIssuer.IssuePasscode(login, clientCookie.Value)
+	if err != nil {
+		var oauthErr oauth.Error
+		if errors.As(err, &oauthErr) {
+			return oauthErrorResponse(oauthErr), nil
+		}
+		return nil, apierrors.LogAndReturn(logger, err, "failed to issue passcode")
+	}
+
+	return routing.NewResponse(http.StatusOK).
+		WithHeader("Cache-Control", "no-store").
+		WithBody(presenter.ForPasscode(passcode)), nil
+}
+
+func (h *OAuth) passcodeCallbackURL() string {
+	return h.apiBaseURL.JoinPath(PasscodeCallbackPath).String()
 }
 
 func (h *OAuth) UnauthenticatedRoutes() []routing.Route {
-	return []routing.Route{
+	routes := []routing.Route{
 		{Method: "POST", Pattern: OAuthTokenPath, Handler: h.token},
 	}
+
+	if h.tokenIssuer != nil {
+		routes = append(routes,
+			routing.Route{Method: "GET", Pattern: TokenKeysPath, Handler: h.tokenKeys},
+			routing.Route{Method: "GET", Pattern: LoginPath, Handler: h.login},
+		)
+	}
+
+	if h.tokenIssuer != nil && h.ssoProvider != nil {
+		routes = append(routes,
+			routing.Route{Method: "GET", Pattern: PasscodePath, Handler: h.passcode},
+			routing.Route{Method: "GET", Pattern: PasscodeCallbackPath, Handler: h.passcodeCallback},
+		)
+	}
+
+	return routes
 }
 
 func (h *OAuth) AuthenticatedRoutes() []routing.Route {
@@ -1,46 +1,386 @@
 package handlers_test
 
 import (
+	"crypto/rsa"
 	"encoding/json"
+	"errors"
+	"math/big"
 	"net/http"
+	"net/url"
+	"strings"
 	"time"
 
+	"code.cloudfoundry.org/korifi/api/authorization"
 	"code.cloudfoundry.org/korifi/api/handlers"
+	"code.cloudfoundry.org/korifi/api/handlers/fake"
+	"code.cloudfoundry.org/korifi/api/oauth"
+	. "code.cloudfoundry.org/korifi/tests/matchers"
 
 	"github.com/SermoDigital/jose/jws"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	rbacv1 "k8s.io/api/rbac/v1"
 )
 
 var _ = Describe("OAuth", func() {
-	var apiHandler *handlers.OAuth
+	var (
+		apiHandler  *handlers.OAuth
+		tokenIssuer *fake.TokenIssuer
+		ssoProvider *fake.SSOProvider
+		req         *http.Request
+	)
 
 	BeforeEach(func() {
-		apiHandler = handlers.NewOAuth(*serverURL)
-		routerBuilder.LoadRoutes(apiHandler)
+		tokenIssuer = new(fake.TokenIssuer)
+		tokenIssuer.GrantReturns(oauth.Token{
+			AccessToken:  "access-token",
+			RefreshToken: "refresh-token",
+			ExpiresIn:    time.Hour,
+			JTI:          "jti",
+		}, nil)
+		tokenIssuer.PublicKeysReturns([]oauth.PublicKey{{
+			ID:  "key-1",
+			Key: &rsa.PublicKey{N: big.NewInt(0xabcdef), E: 65537},
+		}})
+
+		tokenIssuer.IssuePasscodeReturns("the-passcode", nil)
+
+		ssoProvider = new(fake.SSOProvider)
+		ssoProvider.AuthorizationURLReturns("https://dex.example.com/auth?foo=bar", nil)
+		ssoProvider.ExchangeCodeReturns(oauth.Login{Identity: authorization.Identity{Name: "alice", Kind: rbacv1.UserKind}}, nil)
+
+		apiHandler = handlers.NewOAuth(*serverURL, tokenIssuer, ssoProvider)
 	})
 
 	JustBeforeEach(func() {
-		req, err := http.NewRequest(http.MethodPost, "/oauth/token", nil)
-		Expect(err).NotTo(HaveOccurred())
-
+		routerBuilder.LoadRoutes(apiHandler)
 		routerBuilder.Build().ServeHTTP(rr, req)
 	})
 
 	Describe("POST /oauth/token", func() {
-		It("returns 201 with appropriate success JSON", func() {
+		BeforeEach(func() {
+			form := url.Values{
+				"grant_type": {"password"},
+				"username":   {"alice"},
+				"password":   {"secret"},
+			}
+
+			var err error
+			req, err = http.NewRequest(http.MethodPost, "/oauth/token", strings.NewReader(form.Encode()))
+			Expect(err).NotTo(HaveOccurred())
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			req.SetBasicAuth("cf", "")
+		})
+
+		It("grants a token", func() {
+			Expect(tokenIssuer.GrantCallCount()).To(Equal(1))
+			_, grantRequest := tokenIssuer.GrantArgsForCall(0)
+			Expect(grantRequest).To(Equal(oauth.GrantRequest{
+				GrantType: "password",
+				ClientID:  "cf",
+				Username:  "alice",
+				Password:  "secret",
+			}))
+
 			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
 			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
-			jsonBody := map[string]string{}
-			Expect(json.NewDecoder(rr.Body).Decode(&jsonBody)).To(Succeed())
-			Expect(jsonBody).To(HaveKeyWithValue("token_type", "bearer"))
-			Expect(jsonBody).To(HaveKeyWithValue("access_token", Not(BeEmpty())))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Cache-Control", "no-store"))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.token_type", "bearer"),
+				MatchJSONPath("$.access_token", "access-token"),
+				MatchJSONPath("$.refresh_token", "refresh-token"),
+				MatchJSONPath("$.expires_in", BeEquivalentTo(3600)),
+			)))
+		})
+
+		When("the client credentials are sent in the form", func() {
+			BeforeEach(func() {
+				form := url.Values{
+					"grant_type":    {"client_credentials"},
+					"client_id":     {"my-client"},
+					"client_secret": {"my-secret"},
+				}
+
+				var err error
+				req, err = http.NewRequest(http.MethodPost, "/oauth/token", strings.NewReader(form.Encode()))
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			})
+
+			It("uses them", func() {
+				Expect(tokenIssuer.GrantCallCount()).To(Equal(1))
+				_, grantRequest := tokenIssuer.GrantArgsForCall(0)
+				Expect(grantRequest.ClientID).To(Equal("my-client"))
+				Expect(grantRequest.ClientSecret).To(Equal("my-secret"))
+			})
+		})
+
+		When("the grant is rejected", func() {
+			BeforeEach(func() {
+				tokenIssuer.GrantReturns(oauth.Token{}, oauth.NewInvalidGrantError("bad credentials"))
+			})
+
+			It("returns an oauth error", func() {
+				Expect(rr).To(HaveHTTPStatus(http.StatusBadRequest))
+				Expect(rr).To(HaveHTTPBody(MatchJSON(`{
+					"error": "invalid_grant",
+					"error_description": "bad credentials"
+				}`)))
+			})
+		})
+
+		When("the client is rejected", func() {
+			BeforeEach(func() {
+				tokenIssuer.GrantReturns(oauth.Token{}, oauth.NewInvalidClientError("unknown client"))
+			})
+
+			It("returns an unauthorized oauth error", func() {
+				Expect(rr).To(HaveHTTPStatus(http.StatusUnauthorized))
+				Expect(rr).To(HaveHTTPBody(MatchJSONPath("$.error", "invalid_client")))
+			})
+		})
+
+		When("granting the token fails", func() {
+			BeforeEach(func() {
+				tokenIssuer.GrantReturns(oauth.Token{}, errors.New("grant-failed"))
+			})
 
-			tokenout, err := jws.ParseJWT([]byte(jsonBody["access_token"]))
+			It("returns an unknown error", func() {
+				expectUnknownError()
+			})
+		})
+
+		When("the token service is not enabled", func() {
+			BeforeEach(func() {
+				apiHandler = handlers.NewOAuth(*serverURL, nil, nil)
+			})
+
+			It("returns a placeholder token", func() {
+				Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+				jsonBody := map[string]string{}
+				Expect(json.NewDecoder(rr.Body).Decode(&jsonBody)).To(Succeed())
+				Expect(jsonBody).To(HaveKeyWithValue("token_type", "bearer"))
+				Expect(jsonBody).To(HaveKeyWithValue("access_token", Not(BeEmpty())))
+
+				tokenout, err := jws.ParseJWT([]byte(jsonBody["access_token"]))
+				Expect(err).NotTo(HaveOccurred())
+				expiration, ok := tokenout.Claims().Expiration()
+				Expect(ok).To(BeTrue())
+				Expect(expiration.Unix()).To(BeNumerically(">", time.Now().Add(time.Minute*59).Unix()))
+			})
+		})
+	})
+
+	Describe("GET /token_keys", func() {
+		BeforeEach(func() {
+			var err error
+			req, err = http.NewRequest(http.MethodGet, "/token_keys", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns the public signing keys", func() {
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.keys[0].kid", "key-1"),
+				MatchJSONPath("$.keys[0].alg", "RS256"),
+			)))
+		})
+
+		When("the token service is not enabled", func() {
+			BeforeEach(func() {
+				apiHandler = handlers.NewOAuth(*serverURL, nil, nil)
+			})
+
+			It("is not found", func() {
+				Expect(rr).To(HaveHTTPStatus(http.StatusNotFound))
+			})
+		})
+	})
+
+	Describe("GET /login", func() {
+		BeforeEach(func() {
+			var err error
+			req, err = http.NewRequest(http.MethodGet, "/login", nil)
 			Expect(err).NotTo(HaveOccurred())
-			expiration, ok := tokenout.Claims().Expiration()
-			Expect(ok).To(BeTrue())
-			Expect(expiration.Unix()).To(BeNumerically(">", time.Now().Add(time.Minute*59).Unix()))
+		})
+
+		It("returns the login links and prompts", func() {
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.links.uaa", defaultServerURL),
+				MatchJSONPath("$.prompts.password[0]", "password"),
+				MatchJSONPath("$.prompts.passcode[0]", "password"),
+			)))
+		})
+
+		When("sso is not available", func() {
+			BeforeEach(func() {
+				apiHandler = handlers.NewOAuth(*serverURL, tokenIssuer, nil)
+			})
+
+			It("does not prompt for a passcode", func() {
+				Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+				Expect(rr).To(HaveHTTPBody(MatchJSONPathError("$.prompts.passcode", MatchError(ContainSubstring("unknown key passcode")))))
+			})
+		})
+	})
+
+	Describe("GET /passcode", func() {
+		BeforeEach(func() {
+			var err error
+			req, err = http.NewRequest(http.MethodGet, "/passcode", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("redirects to the sso provider", func() {
+			Expect(rr).To(HaveHTTPStatus(http.StatusFound))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Location", "https://dex.example.com/auth?foo=bar"))
+
+			Expect(ssoProvider.AuthorizationURLCallCount()).To(Equal(1))
+			_, redirectURI, state := ssoProvider.AuthorizationURLArgsForCall(0)
+			Expect(redirectURI).To(Equal(defaultServerURL + "/passcode/callback"))
+			Expect(rr.Result().Cookies()).To(ConsistOf(
+				SatisfyAll(
+					HaveField("Name", "korifi-sso-state"),
+					HaveField("Value", state),
+					HaveField("HttpOnly", true),
+				),
+				SatisfyAll(
+					HaveField("Name", "korifi-sso-client"),
+					HaveField("Value", "cf"),
+					HaveField("HttpOnly", true),
+				),
+			))
+		})
+
+		When("the passcode is requested for another client", func() {
+			BeforeEach(func() {
+				var err error
+				req, err = http.NewRequest(http.MethodGet, "/passcode?client_id=my-client", nil)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("remembers the client", func() {
+				Expect(rr.Result().Cookies()).To(ContainElement(SatisfyAll(
+					HaveField("Name", "korifi-sso-client"),
+					HaveField("Value", "my-client"),
+				)))
+			})
+		})
+
+		When("building the authorization url fails", func() {
+			BeforeEach(func() {
+				ssoProvider.AuthorizationURLReturns("", errors.New("discovery-failed"))
+			})
+
+			It("returns an unknown error", func() {
+				expectUnknownError()
+			})
+		})
+
+		When("sso is not available", func() {
+			BeforeEach(func() {
+				apiHandler = handlers.NewOAuth(*serverURL, tokenIssuer, nil)
+			})
+
+			It("is not found", func() {
+				Expect(rr).To(HaveHTTPStatus(http.StatusNotFound))
+			})
+		})
+	})
+
+	Describe("GET /passcode/callback", func() {
+		BeforeEach(func() {
+			var err error
+			req, err = http.NewRequest(http.MethodGet, "/passcode/callback?code=the-code&state=the-state", nil)
+			Expect(err).NotTo(HaveOccurred())
+			req.AddCookie(&http.Cookie{Name: "korifi-sso-state", Value: "the-state"})
+			req.AddCookie(&http.Cookie{Name: "korifi-sso-client", Value: "cf"})
+		})
+
+		It("exchanges the code and returns a passcode", func() {
+			Expect(ssoProvider.ExchangeCodeCallCount()).To(Equal(1))
+			_, code, redirectURI := ssoProvider.ExchangeCodeArgsForCall(0)
+			Expect(code).To(Equal("the-code"))
+			Expect(redirectURI).To(Equal(defaultServerURL + "/passcode/callback"))
+
+			Expect(tokenIssuer.IssuePasscodeCallCount()).To(Equal(1))
+			login, clientID := tokenIssuer.IssuePasscodeArgsForCall(0)
+			Expect(login.Identity).To(Equal(authorization.Identity{Name: "alice", Kind: rbacv1.UserKind}))
+			Expect(clientID).To(Equal("cf"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(MatchJSONPath("$.passcode", "the-passcode")))
+		})
+
+		When("the state does not match", func() {
+			BeforeEach(func() {
+				req.Header.Del("Cookie")
+				req.AddCookie(&http.Cookie{Name: "korifi-sso-state", Value: "another-state"})
+			})
+
+			It("returns an invalid request error", func() {
+				Expect(rr).To(HaveHTTPStatus(http.StatusBadRequest))
+				Expect(rr).To(HaveHTTPBody(MatchJSONPath("$.error_description", "invalid sso state")))
+				Expect(ssoProvider.ExchangeCodeCallCount()).To(BeZero())
+			})
+		})
+
+		When("the client cookie is missing", func() {
+			BeforeEach(func() {
+				req.Header.Del("Cookie")
+				req.AddCookie(&http.Cookie{Name: "korifi-sso-state", Value: "the-state"})
+			})
+
+			It("returns an invalid request error", func() {
+				Expect(rr).To(HaveHTTPStatus(http.StatusBadRequest))
+				Expect(rr).To(HaveHTTPBody(MatchJSONPath("$.error_description", "invalid sso state")))
+			})
+		})
+
+		When("the sso provider returns an error", func() {
+			BeforeEach(func() {
+				var err error
+				req, err = http.NewRequest(http.MethodGet, "/passcode/callback?error=access_denied", nil)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("returns an invalid request error", func() {
+				Expect(rr).To(HaveHTTPStatus(http.StatusBadRequest))
+				Expect(rr).To(HaveHTTPBody(MatchJSONPath("$.error_description", "sso failed: access_denied")))
+			})
+		})
+
+		When("the code is rejected", func() {
+			BeforeEach(func() {
+				ssoProvider.ExchangeCodeReturns(oauth.Login{}, oauth.NewInvalidGrantError("bad credentials"))
+			})
+
+			It("returns an invalid grant error", func() {
+				Expect(rr).To(HaveHTTPStatus(http.StatusBadRequest))
+				Expect(rr).To(HaveHTTPBody(MatchJSONPath("$.error", "invalid_grant")))
+			})
+		})
+
+		When("the client is unknown", func() {
+			BeforeEach(func() {
+				tokenIssuer.IssuePasscodeReturns("", oauth.NewInvalidClientError("unknown client"))
+			})
+
+			It("returns an invalid client error", func() {
+				Expect(rr).To(HaveHTTPStatus(http.StatusUnauthorized))
+				Expect(rr).To(HaveHTTPBody(MatchJSONPath("$.error", "invalid_client")))
+			})
+		})
+
+		When("issuing the passcode fails", func() {
+			BeforeEach(func() {
+				tokenIssuer.IssuePasscodeReturns("", errors.New("no-keys"))
+			})
+
+			It("returns an unknown error", func() {
+				expectUnknownError()
+			})
 		})
 	})
 })
@@ -4,6 +4,7 @@ import (
 	"context"
 	"crypto/x509"
 	"encoding/pem"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
@@ -18,6 +19,7 @@ import (
 	"code.cloudfoundry.org/korifi/api/repositories"
 	"code.cloudfoundry.org/korifi/api/routing"
 	"code.cloudfoundry.org/korifi/api/tools/singleton"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 )
 
 const (
@@ -30,6 +32,7 @@ const (
 //counterfeiter:generate -o fake -fake-name CFOrgRepository . CFOrgRepository
 type CFOrgRepository interface {
 	CreateOrg(context.Context, authorization.Info, repositories.CreateOrgMessage) (repositories.OrgRecord, error)
+	CreateOrgAsManager(context.Context, authorization.Identity, repositories.CreateOrgMessage) (repositories.OrgRecord, error)
 	ListOrgs(context.Context, authorization.Info, repositories.ListOrgsMessage) ([]repositories.OrgRecord, error)
 	DeleteOrg(context.Context, authorization.Info, repositories.DeleteOrgMessage) error
 	GetOrg(context.Context, authorization.Info, string) (repositories.OrgRecord, error)
@@ -41,16 +44,20 @@ type Org struct {
 	apiBaseURL                               url.URL
 	orgRepo                                  CFOrgRepository
 	domainRepo                               CFDomainRepository
+	featureFlagRepo                          CFFeatureFlagRepository
+	identityProvider                         IdentityProvider
 	requestValidator                         RequestValidator
 	userCertificateExpirationWarningDuration time.Duration
 	defaultDomainName                        string
 }
 
-func NewOrg(apiBaseURL url.URL, orgRepo CFOrgRepository, domainRepo CFDomainRepository, requestValidator RequestValidator, userCertificateExpirationWarningDuration time.Duration, defaultDomainName string) *Org {
+func NewOrg(apiBaseURL url.URL, orgRepo CFOrgRepository, domainRepo CFDomainRepository, featureFlagRepo CFFeatureFlagRepository, identityProvider IdentityProvider, requestValidator RequestValidator, userCertificateExpirationWarningDuration time.Duration, defaultDomainName string) *Org {
 	return &Org{
 		apiBaseURL:                               apiBaseURL,
 		orgRepo:                                  orgRepo,
 		domainRepo:                               domainRepo,
+		featureFlagRepo:                          featureFlagRepo,
+		identityProvider:                         identityProvider,
 		requestValidator:                         requestValidator,
 		userCertificateExpirationWarningDuration: userCertificateExpirationWarningDuration,
 		defaultDomainName:                        defaultDomainName,
@@ -68,6 +75,9 @@ func (h *Org) create(r *http.Request) (*routing.Response, error) {
 
 	org := payload.ToMessage()
 	record, err := h.orgRepo.CreateOrg(r.Context(), authInfo, org)
+	if errors.As(err, &apierrors.ForbiddenError{}) {
+		record, err = h.createUserOrg(r.Context(), authInfo, org)
+	}
 	if err != nil {
 		return nil, apierrors.LogAndReturn(logger, err, "Failed to create org", "Org Name", payload.Name)
 	}
@@ -75,6 +85,21 @@ func (h *Org) create(r *http.Request) (*routing.Response, error) {
 	return routing.NewResponse(http.StatusCreated).WithBody(presenter.ForOrg(record, h.apiBaseURL)), nil
 }
 
+// createUserOrg lets users that are not allowed to create orgs create one
+// when user_org_creation is enabled, making them its manager
+func (h *Org) createUserOrg(ctx context.Context, authInfo authorization.Info, org repositories.CreateOrgMessage) (repositories.OrgRecord, error) {
+	if err := h.featureFlagRepo.EnsureFeatureEnabled(ctx, authInfo, korifiv1alpha1.FeatureFlagUserOrgCreation); err != nil {
+		return repositories.OrgRecord{}, err
+	}
+
+	identity, err := h.identityProvider.GetIdentity(ctx, authInfo)
+	if err != nil {
+		return repositories.OrgRecord{}, fmt.Errorf("failed to get identity: %w", err)
+	}
+
+	return h.orgRepo.CreateOrgAsManager(ctx, identity, org)
+}
+
 func (h *Org) update(r *http.Request) (*routing.Response, error) {
 	authInfo, _ := authorization.InfoFromContext(r.Context())
 	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.org.update")
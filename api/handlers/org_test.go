@@ -6,6 +6,7 @@ import (
 	"strings"
 	"time"
 
+	"code.cloudfoundry.org/korifi/api/authorization"
 	apierrors "code.cloudfoundry.org/korifi/api/errors"
 	"code.cloudfoundry.org/korifi/api/handlers"
 	"code.cloudfoundry.org/korifi/api/handlers/fake"
@@ -17,6 +18,7 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	. "github.com/onsi/gomega/gstruct"
+	rbacv1 "k8s.io/api/rbac/v1"
 )
 
 var _ = Describe("Org", func() {
@@ -25,6 +27,8 @@ var _ = Describe("Org", func() {
 		orgRepo          *fake.CFOrgRepository
 		now              time.Time
 		domainRepo       *fake.CFDomainRepository
+		featureFlagRepo  *fake.CFFeatureFlagRepository
+		identityProvider *fake.IdentityProvider
 		requestValidator *fake.RequestValidator
 	)
 
@@ -33,9 +37,11 @@ var _ = Describe("Org", func() {
 
 		orgRepo = new(fake.CFOrgRepository)
 		domainRepo = new(fake.CFDomainRepository)
+		featureFlagRepo = new(fake.CFFeatureFlagRepository)
+		identityProvider = new(fake.IdentityProvider)
 		requestValidator = new(fake.RequestValidator)
 
-		apiHandler = handlers.NewOrg(*serverURL, orgRepo, domainRepo, requestValidator, time.Hour, "the-default.domain")
+		apiHandler = handlers.NewOrg(*serverURL, orgRepo, domainRepo, featureFlagRepo, identityProvider, requestValidator, time.Hour, "the-default.domain")
 		routerBuilder.LoadRoutes(apiHandler)
 	})
 
@@ -96,6 +102,57 @@ var _ = Describe("Org", func() {
 			)))
 		})
 
+		It("does not check the user_org_creation feature flag", func() {
+			Expect(featureFlagRepo.EnsureFeatureEnabledCallCount()).To(BeZero())
+			Expect(orgRepo.CreateOrgAsManagerCallCount()).To(BeZero())
+		})
+
+		When("the user is not allowed to create orgs", func() {
+			BeforeEach(func() {
+				orgRepo.CreateOrgReturns(repositories.OrgRecord{}, apierrors.NewForbiddenError(nil, repositories.OrgResourceType))
+				identityProvider.GetIdentityReturns(authorization.Identity{Name: "alice", Kind: rbacv1.UserKind}, nil)
+				orgRepo.CreateOrgAsManagerReturns(repositories.OrgRecord{GUID: "user-org-guid"}, nil)
+			})
+
+			It("checks the user_org_creation feature flag", func() {
+				Expect(featureFlagRepo.EnsureFeatureEnabledCallCount()).To(Equal(1))
+				_, actualAuthInfo, actualName := featureFlagRepo.EnsureFeatureEnabledArgsForCall(0)
+				Expect(actualAuthInfo).To(Equal(authInfo))
+				Expect(actualName).To(Equal("user_org_creation"))
+			})
+
+			It("creates the org with the user as its manager", func() {
+				Expect(orgRepo.CreateOrgAsManagerCallCount()).To(Equal(1))
+				_, manager, message := orgRepo.CreateOrgAsManagerArgsForCall(0)
+				Expect(manager).To(Equal(authorization.Identity{Name: "alice", Kind: rbacv1.UserKind}))
+				Expect(message.Name).To(Equal("the-org"))
+
+				Expect(rr).To(HaveHTTPStatus(http.StatusCreated))
+				Expect(rr).To(HaveHTTPBody(MatchJSONPath("$.guid", "user-org-guid")))
+			})
+
+			When("the user_org_creation feature flag is disabled", func() {
+				BeforeEach(func() {
+					featureFlagRepo.EnsureFeatureEnabledReturns(apierrors.NewFeatureDisabledError(nil, "user_org_creation"))
+				})
+
+				It("returns a feature disabled error", func() {
+					expectErrorResponse(http.StatusForbidden, "CF-FeatureDisabled", "Feature Disabled: user_org_creation", 330002)
+					Expect(orgRepo.CreateOrgAsManagerCallCount()).To(BeZero())
+				})
+			})
+
+			When("getting the identity fails", func() {
+				BeforeEach(func() {
+					identityProvider.GetIdentityReturns(authorization.Identity{}, errors.New("boom"))
+				})
+
+				It("returns unknown error", func() {
+					expectUnknownError()
+				})
+			})
+		})
+
 		When("the org repo returns an error", func() {
 			BeforeEach(func() {
 				orgRepo.CreateOrgReturns(repositories.OrgRecord{}, errors.New("boom"))
@@ -15,6 +15,7 @@ import (
 	"code.cloudfoundry.org/korifi/api/presenter"
 	"code.cloudfoundry.org/korifi/api/repositories"
 	"code.cloudfoundry.org/korifi/api/routing"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 
 	"github.com/go-logr/logr"
 )
@@ -26,6 +27,7 @@ const (
 	ProcessStatsPath           = "/v3/processes/{guid}/stats"
 	ProcessesPath              = "/v3/processes"
 	ProcessInstanceRestartPath = "/v3/processes/{guid}/instances/{instanceID}"
+	ProcessAutoscalingPath     = "/v3/processes/{guid}/autoscaling_policy"
 )
 
 //counterfeiter:generate -o fake -fake-name CFProcessRepository . CFProcessRepository
@@ -36,6 +38,7 @@ type CFProcessRepository interface {
 	PatchProcess(context.Context, authorization.Info, repositories.PatchProcessMessage) (repositories.ProcessRecord, error)
 	CreateProcess(context.Context, authorization.Info, repositories.CreateProcessMessage) error
 	ScaleProcess(ctx context.Context, authInfo authorization.Info, scaleProcessMessage repositories.ScaleProcessMessage) (repositories.ProcessRecord, error)
+	SetProcessAutoscaling(context.Context, authorization.Info, repositories.SetProcessAutoscalingMessage) (repositories.ProcessRecord, error)
 }
 
 //counterfeiter:generate -o fake -fake-name GaugesCollector . GaugesCollector
@@ -55,6 +58,7 @@ type Process struct {
 	podRepo                 PodRepository
 	gaugesCollector         GaugesCollector
 	instancesStateCollector InstancesStateCollector
+	featureFlagRepo         CFFeatureFlagRepository
 }
 
 func NewProcess(
@@ -64,6 +68,7 @@ func NewProcess(
 	podRepo PodRepository,
 	gaugesCollector GaugesCollector,
 	instancesStateCollector InstancesStateCollector,
+	featureFlagRepo CFFeatureFlagRepository,
 ) *Process {
 	return &Process{
 		serverURL:               serverURL,
@@ -72,6 +77,7 @@ func NewProcess(
 		podRepo:                 podRepo,
 		gaugesCollector:         gaugesCollector,
 		instancesStateCollector: instancesStateCollector,
+		featureFlagRepo:         featureFlagRepo,
 	}
 }
 
@@ -121,7 +127,7 @@ func (h *Process) restartProcessInstance(r *http.Request) (*routing.Response, er
 			"InstanceID", instanceID,
 		)
 	}
-	if int(process.DesiredInstances) <= instance {
+	if int(process.ExpectedInstances()) <= instance {
 		return nil, apierrors.LogAndReturn(logger,
 			apierrors.NewNotFoundError(nil, fmt.Sprintf("Instance %d of process %s", instance, process.Type)), "Instance not found", "AppGUID", process.AppGUID, "InstanceID", instanceID, "Process", process.Type)
 	}
@@ -173,6 +179,10 @@ func (h *Process) scale(r *http.Request) (*routing.Response, error) {
 		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
 	}
 
+	if err := h.featureFlagRepo.EnsureFeatureEnabled(r.Context(), authInfo, korifiv1alpha1.FeatureFlagAppScaling); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "app scaling is disabled")
+	}
+
 	process, err := h.processRepo.GetProcess(r.Context(), authInfo, processGUID)
 	if err != nil {
 		return nil, apierrors.ForbiddenAsNotFound(err)
@@ -255,6 +265,74 @@ func (h *Process) update(r *http.Request) (*routing.Response, error) {
 	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForProcess(updatedProcess, h.serverURL)), nil
 }
 
+func (h *Process) getAutoscalingPolicy(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.process.get-autoscaling-policy")
+
+	processGUID := routing.URLParam(r, "guid")
+
+	process, err := h.processRepo.GetProcess(r.Context(), authInfo, processGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to fetch process from Kubernetes", "ProcessGUID", processGUID)
+	}
+
+	if process.Autoscaling == nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.NewNotFoundError(nil, "Autoscaling policy"), "Process is not autoscaled", "ProcessGUID", processGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForProcessAutoscalingPolicy(process.GUID, *process.Autoscaling, h.serverURL)), nil
+}
+
+func (h *Process) setAutoscalingPolicy(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.process.set-autoscaling-policy")
+
+	processGUID := routing.URLParam(r, "guid")
+
+	var payload payloads.ProcessAutoscalingPolicy
+	if err := h.requestValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	if err := h.featureFlagRepo.EnsureFeatureEnabled(r.Context(), authInfo, korifiv1alpha1.FeatureFlagAppScaling); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "app scaling is disabled")
+	}
+
+	process, err := h.processRepo.GetProcess(r.Context(), authInfo, processGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to fetch process from Kubernetes", "ProcessGUID", processGUID)
+	}
+
+	updatedProcess, err := h.processRepo.SetProcessAutoscaling(r.Context(), authInfo, payload.ToMessage(process.GUID, process.SpaceGUID))
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to set process autoscaling policy", "ProcessGUID", processGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForProcessAutoscalingPolicy(updatedProcess.GUID, *updatedProcess.Autoscaling, h.serverURL)), nil
+}
+
+func (h *Process) deleteAutoscalingPolicy(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.process.delete-autoscaling-policy")
+
+	processGUID := routing.URLParam(r, "guid")
+
+	process, err := h.processRepo.GetProcess(r.Context(), authInfo, processGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to fetch process from Kubernetes", "ProcessGUID", processGUID)
+	}
+
+	_, err = h.processRepo.SetProcessAutoscaling(r.Context(), authInfo, repositories.SetProcessAutoscalingMessage{
+		ProcessGUID: process.GUID,
+		SpaceGUID:   process.SpaceGUID,
+	})
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to delete process autoscaling policy", "ProcessGUID", processGUID)
+	}
+
+	return routing.NewResponse(http.StatusNoContent), nil
+}
+
 func (h *Process) UnauthenticatedRoutes() []routing.Route {
 	return nil
 }
@@ -268,5 +346,8 @@ func (h *Process) AuthenticatedRoutes() []routing.Route {
 		{Method: "GET", Pattern: ProcessesPath, Handler: h.list},
 		{Method: "PATCH", Pattern: ProcessPath, Handler: h.update},
 		{Method: "DELETE", Pattern: ProcessInstanceRestartPath, Handler: h.restartProcessInstance},
+		{Method: "GET", Pattern: ProcessAutoscalingPath, Handler: h.getAutoscalingPolicy},
+		{Method: "PUT", Pattern: ProcessAutoscalingPath, Handler: h.setAutoscalingPolicy},
+		{Method: "DELETE", Pattern: ProcessAutoscalingPath, Handler: h.deleteAutoscalingPolicy},
 	}
 }
@@ -26,6 +26,7 @@ var _ = Describe("Process", func() {
 		podRepo                 *fake.PodRepository
 		gaugesCollector         *fake.GaugesCollector
 		instancesStateCollector *fake.InstancesStateCollector
+		featureFlagRepo         *fake.CFFeatureFlagRepository
 	)
 
 	BeforeEach(func() {
@@ -34,6 +35,7 @@ var _ = Describe("Process", func() {
 		podRepo = new(fake.PodRepository)
 		gaugesCollector = new(fake.GaugesCollector)
 		instancesStateCollector = new(fake.InstancesStateCollector)
+		featureFlagRepo = new(fake.CFFeatureFlagRepository)
 
 		apiHandler := NewProcess(
 			*serverURL,
@@ -42,6 +44,7 @@ var _ = Describe("Process", func() {
 			podRepo,
 			gaugesCollector,
 			instancesStateCollector,
+			featureFlagRepo,
 		)
 		routerBuilder.LoadRoutes(apiHandler)
 	})
@@ -152,9 +155,10 @@ var _ = Describe("Process", func() {
 			}, nil)
 
 			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.ProcessScale{
-				Instances: tools.PtrTo[int32](3),
-				MemoryMB:  tools.PtrTo[int64](512),
-				DiskMB:    tools.PtrTo[int64](256),
+				Instances:     tools.PtrTo[int32](3),
+				MemoryMB:      tools.PtrTo[int64](512),
+				DiskMB:        tools.PtrTo[int64](256),
+				CPUMillicores: tools.PtrTo[int64](1000),
 			})
 		})
 
@@ -181,9 +185,10 @@ var _ = Describe("Process", func() {
 				GUID:      "process-guid",
 				SpaceGUID: spaceGUID,
 				ProcessScaleValues: repositories.ProcessScaleValues{
-					Instances: tools.PtrTo[int32](3),
-					MemoryMB:  tools.PtrTo(int64(512)),
-					DiskMB:    tools.PtrTo(int64(256)),
+					Instances:     tools.PtrTo[int32](3),
+					MemoryMB:      tools.PtrTo(int64(512)),
+					DiskMB:        tools.PtrTo(int64(256)),
+					CPUMillicores: tools.PtrTo(int64(1000)),
 				},
 			}))
 		})
@@ -207,6 +212,19 @@ var _ = Describe("Process", func() {
 			})
 		})
 
+		When("the app_scaling feature flag is disabled", func() {
+			BeforeEach(func() {
+				featureFlagRepo.EnsureFeatureEnabledReturns(apierrors.NewFeatureDisabledError(nil, "app_scaling"))
+			})
+
+			It("returns a feature disabled error", func() {
+				_, _, actualName := featureFlagRepo.EnsureFeatureEnabledArgsForCall(0)
+				Expect(actualName).To(Equal("app_scaling"))
+				expectErrorResponse(http.StatusForbidden, "CF-FeatureDisabled", "Feature Disabled: app_scaling", 330002)
+				Expect(processRepo.ScaleProcessCallCount()).To(BeZero())
+			})
+		})
+
 		When("the user does not have permissions to get the process", func() {
 			BeforeEach(func() {
 				processRepo.GetProcessReturns(repositories.ProcessRecord{}, apierrors.NewForbiddenError(nil, "Process"))
@@ -552,6 +570,26 @@ var _ = Describe("Process", func() {
 			})
 		})
 
+		When("the process is autoscaled", func() {
+			BeforeEach(func() {
+				processRepo.GetProcessReturns(repositories.ProcessRecord{
+					GUID:             "process-guid",
+					AppGUID:          "app-guid",
+					SpaceGUID:        "space-guid",
+					DesiredInstances: 2,
+					ActualInstances:  4,
+					Autoscaling:      &repositories.AutoscalingPolicy{MinInstances: 1, MaxInstances: 5},
+					Type:             "web",
+				}, nil)
+				instance = "3"
+			})
+
+			It("restarts instances up to the actual instances", func() {
+				Expect(rr).To(HaveHTTPStatus(http.StatusNoContent))
+				Expect(podRepo.DeletePodCallCount()).To(Equal(1))
+			})
+		})
+
 		When("the process is not found", func() {
 			BeforeEach(func() {
 				processRepo.GetProcessReturns(repositories.ProcessRecord{}, apierrors.NewForbiddenError(errors.New("access denied or something"), repositories.ProcessResourceType))
@@ -562,4 +600,210 @@ var _ = Describe("Process", func() {
 			})
 		})
 	})
+
+	Describe("the GET /v3/processes/:guid/autoscaling_policy endpoint", func() {
+		BeforeEach(func() {
+			processRepo.GetProcessReturns(repositories.ProcessRecord{
+				GUID: "process-guid",
+				Autoscaling: &repositories.AutoscalingPolicy{
+					MinInstances:   1,
+					MaxInstances:   5,
+					CPUUtilization: tools.PtrTo[int32](70),
+				},
+			}, nil)
+		})
+
+		JustBeforeEach(func() {
+			req, err := http.NewRequestWithContext(ctx, "GET", "/v3/processes/process-guid/autoscaling_policy", nil)
+			Expect(err).NotTo(HaveOccurred())
+			routerBuilder.Build().ServeHTTP(rr, req)
+		})
+
+		It("returns the autoscaling policy", func() {
+			Expect(processRepo.GetProcessCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualProcessGUID := processRepo.GetProcessArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualProcessGUID).To(Equal("process-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.min_instances", BeEquivalentTo(1)),
+				MatchJSONPath("$.max_instances", BeEquivalentTo(5)),
+				MatchJSONPath("$.cpu_utilization", BeEquivalentTo(70)),
+				MatchJSONPath("$.links.self.href", "https://api.example.org/v3/processes/process-guid/autoscaling_policy"),
+			)))
+		})
+
+		When("the process is not autoscaled", func() {
+			BeforeEach(func() {
+				processRepo.GetProcessReturns(repositories.ProcessRecord{GUID: "process-guid"}, nil)
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError("Autoscaling policy")
+			})
+		})
+
+		When("the user lacks access", func() {
+			BeforeEach(func() {
+				processRepo.GetProcessReturns(repositories.ProcessRecord{}, apierrors.NewForbiddenError(nil, repositories.ProcessResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError("Process")
+			})
+		})
+
+		When("getting the process errors", func() {
+			BeforeEach(func() {
+				processRepo.GetProcessReturns(repositories.ProcessRecord{}, errors.New("unknown!"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("the PUT /v3/processes/:guid/autoscaling_policy endpoint", func() {
+		BeforeEach(func() {
+			processRepo.GetProcessReturns(repositories.ProcessRecord{
+				GUID:      "process-guid",
+				SpaceGUID: spaceGUID,
+			}, nil)
+
+			processRepo.SetProcessAutoscalingReturns(repositories.ProcessRecord{
+				GUID: "process-guid",
+				Autoscaling: &repositories.AutoscalingPolicy{
+					MinInstances: 2,
+					MaxInstances: 4,
+				},
+			}, nil)
+
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.ProcessAutoscalingPolicy{
+				MinInstances: 2,
+				MaxInstances: 4,
+			})
+		})
+
+		JustBeforeEach(func() {
+			req, err := http.NewRequestWithContext(ctx, "PUT", "/v3/processes/process-guid/autoscaling_policy", strings.NewReader("the-json-body"))
+			Expect(err).NotTo(HaveOccurred())
+			routerBuilder.Build().ServeHTTP(rr, req)
+		})
+
+		It("sets the autoscaling policy", func() {
+			Expect(requestValidator.DecodeAndValidateJSONPayloadCallCount()).To(Equal(1))
+			actualReq, _ := requestValidator.DecodeAndValidateJSONPayloadArgsForCall(0)
+			Expect(bodyString(actualReq)).To(Equal("the-json-body"))
+
+			Expect(processRepo.SetProcessAutoscalingCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualMessage := processRepo.SetProcessAutoscalingArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualMessage).To(Equal(repositories.SetProcessAutoscalingMessage{
+				ProcessGUID: "process-guid",
+				SpaceGUID:   spaceGUID,
+				Policy: &repositories.AutoscalingPolicy{
+					MinInstances: 2,
+					MaxInstances: 4,
+				},
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.min_instances", BeEquivalentTo(2)),
+				MatchJSONPath("$.max_instances", BeEquivalentTo(4)),
+			)))
+		})
+
+		When("the request JSON is invalid", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateJSONPayloadReturns(errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+
+		When("the app_scaling feature flag is disabled", func() {
+			BeforeEach(func() {
+				featureFlagRepo.EnsureFeatureEnabledReturns(apierrors.NewFeatureDisabledError(nil, "app_scaling"))
+			})
+
+			It("returns a feature disabled error", func() {
+				expectErrorResponse(http.StatusForbidden, "CF-FeatureDisabled", "Feature Disabled: app_scaling", 330002)
+				Expect(processRepo.SetProcessAutoscalingCallCount()).To(BeZero())
+			})
+		})
+
+		When("the user lacks access to the process", func() {
+			BeforeEach(func() {
+				processRepo.GetProcessReturns(repositories.ProcessRecord{}, apierrors.NewForbiddenError(nil, repositories.ProcessResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError("Process")
+			})
+		})
+
+		When("setting the policy errors", func() {
+			BeforeEach(func() {
+				processRepo.SetProcessAutoscalingReturns(repositories.ProcessRecord{}, errors.New("unknown!"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("the DELETE /v3/processes/:guid/autoscaling_policy endpoint", func() {
+		BeforeEach(func() {
+			processRepo.GetProcessReturns(repositories.ProcessRecord{
+				GUID:      "process-guid",
+				SpaceGUID: spaceGUID,
+			}, nil)
+		})
+
+		JustBeforeEach(func() {
+			req, err := http.NewRequestWithContext(ctx, "DELETE", "/v3/processes/process-guid/autoscaling_policy", nil)
+			Expect(err).NotTo(HaveOccurred())
+			routerBuilder.Build().ServeHTTP(rr, req)
+		})
+
+		It("removes the autoscaling policy", func() {
+			Expect(processRepo.SetProcessAutoscalingCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualMessage := processRepo.SetProcessAutoscalingArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualMessage).To(Equal(repositories.SetProcessAutoscalingMessage{
+				ProcessGUID: "process-guid",
+				SpaceGUID:   spaceGUID,
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusNoContent))
+		})
+
+		When("the user lacks access to the process", func() {
+			BeforeEach(func() {
+				processRepo.GetProcessReturns(repositories.ProcessRecord{}, apierrors.NewForbiddenError(nil, repositories.ProcessResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError("Process")
+			})
+		})
+
+		When("removing the policy errors", func() {
+			BeforeEach(func() {
+				processRepo.SetProcessAutoscalingReturns(repositories.ProcessRecord{}, errors.New("unknown!"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
 })
@@ -17,9 +17,11 @@ import (
 )
 
 const (
-	ServiceBindingsPath       = "/v3/service_credential_bindings"
-	ServiceBindingPath        = "/v3/service_credential_bindings/{guid}"
-	ServiceBindingDetailsPath = "/v3/service_credential_bindings/{guid}/details"
+	ServiceBindingsPath          = "/v3/service_credential_bindings"
+	ServiceBindingPath           = "/v3/service_credential_bindings/{guid}"
+	ServiceBindingDetailsPath    = "/v3/service_credential_bindings/{guid}/details"
+	ServiceBindingParametersPath = "/v3/service_credential_bindings/{guid}/parameters"
+	ServiceBindingRotatePath     = "/v3/service_credential_bindings/{guid}/actions/rotate_credentials"
 )
 
 type ServiceBinding struct {
@@ -38,6 +40,8 @@ type CFServiceBindingRepository interface {
 	GetServiceBinding(context.Context, authorization.Info, string) (repositories.ServiceBindingRecord, error)
 	UpdateServiceBinding(context.Context, authorization.Info, repositories.UpdateServiceBindingMessage) (repositories.ServiceBindingRecord, error)
 	GetServiceBindingDetails(context.Context, authorization.Info, string) (repositories.ServiceBindingDetailsRecord, error)
+	GetServiceBindingParameters(context.Context, authorization.Info, string) (map[string]any, error)
+	RotateServiceBindingCredentials(context.Context, authorization.Info, string) (repositories.ServiceBindingRecord, error)
 }
 
 func NewServiceBinding(serverURL url.URL, serviceBindingRepo CFServiceBindingRepository, appRepo CFAppRepository, serviceInstanceRepo CFServiceInstanceRepository, requestValidator RequestValidator) *ServiceBinding {
@@ -82,7 +86,7 @@ func (h *ServiceBinding) create(r *http.Request) (*routing.Response, error) {
 		}
 	}
 
-	if serviceInstance.Type == korifiv1alpha1.UserProvidedType {
+	if serviceInstance.Type != korifiv1alpha1.ManagedType {
 		return h.createUserProvided(ctx, &payload, serviceInstance)
 	}
 
@@ -154,6 +158,44 @@ func (h *ServiceBinding) delete(r *http.Request) (*routing.Response, error) {
 	return routing.NewResponse(http.StatusNoContent), nil
 }
 
+func (h *ServiceBinding) rotateCredentials(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.service-binding.rotate-credentials")
+
+	serviceBindingGUID := routing.URLParam(r, "guid")
+	serviceBinding, err := h.serviceBindingRepo.GetServiceBinding(r.Context(), authInfo, serviceBindingGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get "+repositories.ServiceBindingResourceType)
+	}
+
+	serviceInstance, err := h.serviceInstanceRepo.GetServiceInstance(r.Context(), authInfo, serviceBinding.ServiceInstanceGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(
+			logger,
+			apierrors.NewUnprocessableEntityError(err, "failed to get service instance"),
+			"failed to get "+repositories.ServiceInstanceResourceType,
+			"instance-guid", serviceBinding.ServiceInstanceGUID,
+		)
+	}
+
+	if serviceInstance.Type != korifiv1alpha1.ManagedType {
+		return nil, apierrors.LogAndReturn(
+			logger,
+			apierrors.NewUnprocessableEntityError(nil, "Credential rotation is only supported for bindings to managed service instances."),
+			"cannot rotate credentials of user-provided service binding",
+			"guid", serviceBindingGUID,
+		)
+	}
+
+	_, err = h.serviceBindingRepo.RotateServiceBindingCredentials(r.Context(), authInfo, serviceBindingGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to rotate service binding credentials", "guid", serviceBindingGUID)
+	}
+
+	return routing.NewResponse(http.StatusAccepted).
+		WithHeader("Location", presenter.JobURLForRedirects(serviceBindingGUID, presenter.ManagedServiceBindingRotateCredentialsOperation, h.serverURL)), nil
+}
+
 func (h *ServiceBinding) list(r *http.Request) (*routing.Response, error) {
 	authInfo, _ := authorization.InfoFromContext(r.Context())
 	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.service-binding.list")
@@ -235,6 +277,19 @@ func (h *ServiceBinding) getDetails(r *http.Request) (*routing.Response, error)
 	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForServiceBindingDetails(bindingDetails)), nil
 }
 
+func (h *ServiceBinding) getParameters(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.service-binding.get-parameters")
+
+	serviceBindingGUID := routing.URLParam(r, "guid")
+
+	parameters, err := h.serviceBindingRepo.GetServiceBindingParameters(r.Context(), authInfo, serviceBindingGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Error getting service binding parameters in repository")
+	}
+	return routing.NewResponse(http.StatusOK).WithBody(parameters), nil
+}
+
 func (h *ServiceBinding) UnauthenticatedRoutes() []routing.Route {
 	return nil
 }
@@ -247,5 +302,7 @@ func (h *ServiceBinding) AuthenticatedRoutes() []routing.Route {
 		{Method: "PATCH", Pattern: ServiceBindingPath, Handler: h.update},
 		{Method: "GET", Pattern: ServiceBindingPath, Handler: h.get},
 		{Method: "GET", Pattern: ServiceBindingDetailsPath, Handler: h.getDetails},
+		{Method: "GET", Pattern: ServiceBindingParametersPath, Handler: h.getParameters},
+		{Method: "POST", Pattern: ServiceBindingRotatePath, Handler: h.rotateCredentials},
 	}
 }
@@ -201,6 +201,27 @@ var _ = Describe("ServiceBinding", func() {
 				})
 			})
 
+			When("binding to a kubernetes service instance", func() {
+				BeforeEach(func() {
+					serviceInstanceRepo.GetServiceInstanceReturns(repositories.ServiceInstanceRecord{
+						GUID:      "service-instance-guid",
+						SpaceGUID: "space-guid",
+						Type:      korifiv1alpha1.KubernetesType,
+					}, nil)
+
+					serviceBindingRepo.CreateServiceBindingReturns(repositories.ServiceBindingRecord{
+						GUID: "service-binding-guid",
+						Type: korifiv1alpha1.CFServiceBindingTypeApp,
+					}, nil)
+				})
+
+				It("creates the service binding synchronously", func() {
+					Expect(serviceBindingRepo.CreateServiceBindingCallCount()).To(Equal(1))
+					Expect(rr).To(HaveHTTPStatus(http.StatusCreated))
+					Expect(rr).To(HaveHTTPBody(MatchJSONPath("$.guid", "service-binding-guid")))
+				})
+			})
+
 			When("binding to a managed service instance", func() {
 				BeforeEach(func() {
 					serviceInstanceRepo.GetServiceInstanceReturns(repositories.ServiceInstanceRecord{
@@ -397,6 +418,84 @@ var _ = Describe("ServiceBinding", func() {
 		})
 	})
 
+	Describe("POST /v3/service_credential_bindings/:guid/actions/rotate_credentials", func() {
+		BeforeEach(func() {
+			requestMethod = http.MethodPost
+			requestPath = "/v3/service_credential_bindings/service-binding-guid/actions/rotate_credentials"
+			requestBody = ""
+
+			serviceInstanceRepo.GetServiceInstanceReturns(repositories.ServiceInstanceRecord{
+				GUID:      "service-instance-guid",
+				SpaceGUID: "space-guid",
+				Type:      korifiv1alpha1.ManagedType,
+			}, nil)
+		})
+
+		It("requests a credential rotation in a job", func() {
+			Expect(serviceBindingRepo.GetServiceBindingCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualBindingGUID := serviceBindingRepo.GetServiceBindingArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualBindingGUID).To(Equal("service-binding-guid"))
+
+			Expect(serviceInstanceRepo.GetServiceInstanceCallCount()).To(Equal(1))
+			_, _, actualInstanceGUID := serviceInstanceRepo.GetServiceInstanceArgsForCall(0)
+			Expect(actualInstanceGUID).To(Equal("service-instance-guid"))
+
+			Expect(serviceBindingRepo.RotateServiceBindingCredentialsCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualBindingGUID = serviceBindingRepo.RotateServiceBindingCredentialsArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualBindingGUID).To(Equal("service-binding-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusAccepted))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Location",
+				ContainSubstring("/v3/jobs/managed_service_binding.rotate_credentials~service-binding-guid")))
+		})
+
+		When("getting the service binding is forbidden", func() {
+			BeforeEach(func() {
+				serviceBindingRepo.GetServiceBindingReturns(repositories.ServiceBindingRecord{}, apierrors.NewForbiddenError(nil, repositories.ServiceBindingResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.ServiceBindingResourceType)
+			})
+		})
+
+		When("getting the service instance fails", func() {
+			BeforeEach(func() {
+				serviceInstanceRepo.GetServiceInstanceReturns(repositories.ServiceInstanceRecord{}, errors.New("getting-instance-failed"))
+			})
+
+			It("returns error", func() {
+				expectUnprocessableEntityError("failed to get service instance")
+			})
+		})
+
+		When("the service instance is user-provided", func() {
+			BeforeEach(func() {
+				serviceInstanceRepo.GetServiceInstanceReturns(repositories.ServiceInstanceRecord{
+					GUID: "service-instance-guid",
+					Type: korifiv1alpha1.UserProvidedType,
+				}, nil)
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("Credential rotation is only supported for bindings to managed service instances.")
+				Expect(serviceBindingRepo.RotateServiceBindingCredentialsCallCount()).To(BeZero())
+			})
+		})
+
+		When("requesting the rotation fails", func() {
+			BeforeEach(func() {
+				serviceBindingRepo.RotateServiceBindingCredentialsReturns(repositories.ServiceBindingRecord{}, errors.New("rotate-failed"))
+			})
+
+			It("returns unknown error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
 	Describe("DELETE /v3/service_credential_bindings/:guid", func() {
 		BeforeEach(func() {
 			requestMethod = "DELETE"
@@ -571,6 +670,60 @@ var _ = Describe("ServiceBinding", func() {
 		})
 	})
 
+	Describe("GET /v3/service_credential_bindings/{guid}/parameters", func() {
+		var serviceBindingGUID string = uuid.NewString()
+
+		BeforeEach(func() {
+			serviceBindingRepo.GetServiceBindingParametersReturns(map[string]any{
+				"foo": "bar",
+			}, nil)
+
+			requestMethod = http.MethodGet
+			requestPath = fmt.Sprintf("/v3/service_credential_bindings/%s/parameters", serviceBindingGUID)
+			requestBody = ""
+		})
+
+		It("returns the service binding parameters", func() {
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+			Expect(rr).To(HaveHTTPBody(MatchJSON(`{"foo": "bar"}`)))
+			Expect(serviceBindingRepo.GetServiceBindingParametersCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualGUID := serviceBindingRepo.GetServiceBindingParametersArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualGUID).To(Equal(serviceBindingGUID))
+		})
+
+		When("the service does not support fetching parameters", func() {
+			BeforeEach(func() {
+				serviceBindingRepo.GetServiceBindingParametersReturns(nil, apierrors.NewInvalidRequestError(nil, "not supported"))
+			})
+
+			It("returns a 400 Bad Request error", func() {
+				expectErrorResponse(http.StatusBadRequest, "CF-InvalidRequest", "not supported", 10004)
+			})
+		})
+
+		When("getting the service binding is forbidden", func() {
+			BeforeEach(func() {
+				serviceBindingRepo.GetServiceBindingParametersReturns(nil, apierrors.NewForbiddenError(nil, repositories.ServiceBindingResourceType))
+			})
+
+			It("returns 404 NotFound", func() {
+				expectNotFoundError(repositories.ServiceBindingResourceType)
+			})
+		})
+
+		When("the service binding repo returns an error", func() {
+			BeforeEach(func() {
+				serviceBindingRepo.GetServiceBindingParametersReturns(nil, errors.New("get-service-binding-parameters-error"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
 	Describe("GET /v3/service_credential_bindings/{guid}/details", func() {
 		var serviceBindingGUID string = uuid.NewString()
 
@@ -31,17 +31,20 @@ type CFServiceBrokerRepository interface {
 type ServiceBroker struct {
 	serverURL         url.URL
 	serviceBrokerRepo CFServiceBrokerRepository
+	spaceRepo         CFSpaceRepository
 	requestValidator  RequestValidator
 }
 
 func NewServiceBroker(
 	serverURL url.URL,
 	serviceBrokerRepo CFServiceBrokerRepository,
+	spaceRepo CFSpaceRepository,
 	requestValidator RequestValidator,
 ) *ServiceBroker {
 	return &ServiceBroker{
 		serverURL:         serverURL,
 		serviceBrokerRepo: serviceBrokerRepo,
+		spaceRepo:         spaceRepo,
 		requestValidator:  requestValidator,
 	}
 }
@@ -55,7 +58,19 @@ func (h *ServiceBroker) create(r *http.Request) (*routing.Response, error) {
 		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
 	}
 
-	broker, err := h.serviceBrokerRepo.CreateServiceBroker(r.Context(), authInfo, payload.ToMessage())
+	message := payload.ToMessage()
+	if message.SpaceGUID != "" {
+		if _, err := h.spaceRepo.GetSpace(r.Context(), authInfo, message.SpaceGUID); err != nil {
+			return nil, apierrors.LogAndReturn(
+				logger,
+				apierrors.AsUnprocessableEntity(err, "Invalid space. Ensure that the space exists and you have access to it.", apierrors.NotFoundError{}, apierrors.ForbiddenError{}),
+				"failed to get space",
+				"spaceGUID", message.SpaceGUID,
+			)
+		}
+	}
+
+	broker, err := h.serviceBrokerRepo.CreateServiceBroker(r.Context(), authInfo, message)
 	if err != nil {
 		return nil, apierrors.LogAndReturn(logger, err, "failed to create service broker")
 	}
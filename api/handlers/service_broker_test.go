@@ -22,6 +22,7 @@ import (
 var _ = Describe("ServiceBroker", func() {
 	var (
 		serviceBrokerRepo *fake.CFServiceBrokerRepository
+		spaceRepo         *fake.CFSpaceRepository
 		requestValidator  *fake.RequestValidator
 
 		req     *http.Request
@@ -30,10 +31,12 @@ var _ = Describe("ServiceBroker", func() {
 
 	BeforeEach(func() {
 		serviceBrokerRepo = new(fake.CFServiceBrokerRepository)
+		spaceRepo = new(fake.CFSpaceRepository)
 		requestValidator = new(fake.RequestValidator)
 		handler = handlers.NewServiceBroker(
 			*serverURL,
 			serviceBrokerRepo,
+			spaceRepo,
 			requestValidator,
 		)
 	})
@@ -79,6 +82,57 @@ var _ = Describe("ServiceBroker", func() {
 			Expect(rr).To(HaveHTTPHeaderWithValue("Location", "https://api.example.org/v3/jobs/service_broker.create~service-broker-guid"))
 		})
 
+		It("does not check any space", func() {
+			Expect(spaceRepo.GetSpaceCallCount()).To(BeZero())
+		})
+
+		When("the broker is space scoped", func() {
+			BeforeEach(func() {
+				payload.Relationships = &payloads.ServiceBrokerRelationships{
+					Space: &payloads.Relationship{
+						Data: &payloads.RelationshipData{GUID: "space-guid"},
+					},
+				}
+			})
+
+			It("creates the service broker in the space", func() {
+				Expect(spaceRepo.GetSpaceCallCount()).To(Equal(1))
+				_, actualAuthInfo, actualSpaceGUID := spaceRepo.GetSpaceArgsForCall(0)
+				Expect(actualAuthInfo).To(Equal(authInfo))
+				Expect(actualSpaceGUID).To(Equal("space-guid"))
+
+				Expect(serviceBrokerRepo.CreateServiceBrokerCallCount()).To(Equal(1))
+				_, _, actualCreateMsg := serviceBrokerRepo.CreateServiceBrokerArgsForCall(0)
+				Expect(actualCreateMsg.SpaceGUID).To(Equal("space-guid"))
+
+				Expect(rr).To(HaveHTTPStatus(http.StatusAccepted))
+			})
+
+			When("the space does not exist", func() {
+				BeforeEach(func() {
+					spaceRepo.GetSpaceReturns(
+						repositories.SpaceRecord{},
+						apierrors.NewNotFoundError(errors.New("not found"), repositories.SpaceResourceType),
+					)
+				})
+
+				It("returns an error", func() {
+					expectUnprocessableEntityError("Invalid space. Ensure that the space exists and you have access to it.")
+					Expect(serviceBrokerRepo.CreateServiceBrokerCallCount()).To(BeZero())
+				})
+			})
+
+			When("getting the space fails", func() {
+				BeforeEach(func() {
+					spaceRepo.GetSpaceReturns(repositories.SpaceRecord{}, errors.New("get-space-error"))
+				})
+
+				It("returns an error", func() {
+					expectUnknownError()
+				})
+			})
+		})
+
 		When("the request body is invalid json", func() {
 			BeforeEach(func() {
 				requestValidator.DecodeAndValidateJSONPayloadReturns(errors.New("boom"))
@@ -134,7 +188,8 @@ var _ = Describe("ServiceBroker", func() {
 		When("filtering query params are provided", func() {
 			BeforeEach(func() {
 				requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(&payloads.ServiceBrokerList{
-					Names: "b1,b2",
+					Names:      "b1,b2",
+					SpaceGUIDs: "s1,s2",
 				})
 			})
 
@@ -143,6 +198,7 @@ var _ = Describe("ServiceBroker", func() {
 				_, _, message := serviceBrokerRepo.ListServiceBrokersArgsForCall(0)
 
 				Expect(message.Names).To(ConsistOf("b1", "b2"))
+				Expect(message.SpaceGUIDs).To(ConsistOf("s1", "s2"))
 			})
 		})
 
@@ -298,8 +354,9 @@ var _ = Describe("ServiceBroker", func() {
 			_, actualAuthInfo, actualUpdateMesage := serviceBrokerRepo.UpdateServiceBrokerArgsForCall(0)
 			Expect(actualAuthInfo).To(Equal(authInfo))
 			Expect(actualUpdateMesage).To(Equal(repositories.UpdateServiceBrokerMessage{
-				GUID: "service-broker-guid",
-				Name: tools.PtrTo("new-name"),
+				GUID:           "service-broker-guid",
+				Name:           tools.PtrTo("new-name"),
+				RefreshCatalog: true,
 			}))
 
 			Expect(rr).To(HaveHTTPStatus(http.StatusAccepted))
@@ -261,6 +261,57 @@ var _ = Describe("ServiceInstance", func() {
 		})
 	})
 
+	Describe("GET /v3/service_instances/:guid/parameters", func() {
+		BeforeEach(func() {
+			serviceInstanceRepo.GetServiceInstanceParametersReturns(map[string]any{
+				"foo": "bar",
+			}, nil)
+
+			reqPath += "/service-instance-guid/parameters"
+		})
+
+		It("gets the service instance parameters", func() {
+			Expect(serviceInstanceRepo.GetServiceInstanceParametersCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualInstanceGUID := serviceInstanceRepo.GetServiceInstanceParametersArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualInstanceGUID).To(Equal("service-instance-guid"))
+
+			Expect(rr).Should(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+			Expect(rr).To(HaveHTTPBody(MatchJSON(`{"foo": "bar"}`)))
+		})
+
+		When("the service does not support fetching parameters", func() {
+			BeforeEach(func() {
+				serviceInstanceRepo.GetServiceInstanceParametersReturns(nil, apierrors.NewInvalidRequestError(nil, "not supported"))
+			})
+
+			It("returns a 400 Bad Request error", func() {
+				expectErrorResponse(http.StatusBadRequest, "CF-InvalidRequest", "not supported", 10004)
+			})
+		})
+
+		When("getting the parameters is forbidden", func() {
+			BeforeEach(func() {
+				serviceInstanceRepo.GetServiceInstanceParametersReturns(nil, apierrors.NewForbiddenError(nil, repositories.ServiceInstanceResourceType))
+			})
+
+			It("returns 404 Not Found", func() {
+				expectNotFoundError("Service Instance")
+			})
+		})
+
+		When("getting the parameters fails", func() {
+			BeforeEach(func() {
+				serviceInstanceRepo.GetServiceInstanceParametersReturns(nil, errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
 	Describe("GET /v3/service_instances/:guid/credentials", func() {
 		BeforeEach(func() {
 			serviceInstanceRepo.GetServiceInstanceReturns(repositories.ServiceInstanceRecord{
@@ -739,6 +790,21 @@ var _ = Describe("ServiceInstance", func() {
 			)))
 		})
 
+		When("the service instance is managed", func() {
+			BeforeEach(func() {
+				serviceInstanceRepo.PatchServiceInstanceReturns(repositories.ServiceInstanceRecord{
+					Name: "new-name",
+					GUID: "service-instance-guid",
+					Type: korifiv1alpha1.ManagedType,
+				}, nil)
+			})
+
+			It("returns 202 with a location header pointing to the update job", func() {
+				Expect(rr).To(HaveHTTPStatus(http.StatusAccepted))
+				Expect(rr).To(HaveHTTPHeaderWithValue("Location", "https://api.example.org/v3/jobs/managed_service_instance.update~service-instance-guid"))
+			})
+		})
+
 		When("decoding the payload fails", func() {
 			BeforeEach(func() {
 				requestValidator.DecodeAndValidateJSONPayloadReturns(apierrors.NewUnprocessableEntityError(nil, "nope"))
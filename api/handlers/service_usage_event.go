@@ -0,0 +1,102 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/api/routing"
+
+	"github.com/go-logr/logr"
+)
+
+const (
+	ServiceUsageEventsPath               = "/v3/service_usage_events"
+	ServiceUsageEventPath                = "/v3/service_usage_events/{guid}"
+	ServiceUsageEventsPurgeAndReseedPath = "/v3/service_usage_events/actions/destructively_purge_all_and_reseed"
+)
+
+//counterfeiter:generate -o fake -fake-name CFServiceUsageEventRepository . CFServiceUsageEventRepository
+
+type CFServiceUsageEventRepository interface {
+	GetServiceUsageEvent(context.Context, authorization.Info, string) (repositories.ServiceUsageEventRecord, error)
+	ListServiceUsageEvents(context.Context, authorization.Info, repositories.ListServiceUsageEventsMessage) (repositories.ListResult[repositories.ServiceUsageEventRecord], error)
+	PurgeAndReseed(context.Context, authorization.Info) error
+}
+
+type ServiceUsageEvent struct {
+	serverURL             url.URL
+	requestValidator      RequestValidator
+	serviceUsageEventRepo CFServiceUsageEventRepository
+}
+
+func NewServiceUsageEvent(
+	serverURL url.URL,
+	requestValidator RequestValidator,
+	serviceUsageEventRepo CFServiceUsageEventRepository,
+) *ServiceUsageEvent {
+	return &ServiceUsageEvent{
+		serverURL:             serverURL,
+		requestValidator:      requestValidator,
+		serviceUsageEventRepo: serviceUsageEventRepo,
+	}
+}
+
+func (h *ServiceUsageEvent) get(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.service-usage-event.get")
+
+	guid := routing.URLParam(r, "guid")
+
+	event, err := h.serviceUsageEventRepo.GetServiceUsageEvent(r.Context(), authInfo, guid)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get service usage event", "guid", guid)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForServiceUsageEvent(event, h.serverURL)), nil
+}
+
+func (h *ServiceUsageEvent) list(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.service-usage-event.list")
+
+	payload := new(payloads.ServiceUsageEventList)
+	if err := h.requestValidator.DecodeAndValidateURLValues(r, payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Unable to decode request query parameters")
+	}
+
+	events, err := h.serviceUsageEventRepo.ListServiceUsageEvents(r.Context(), authInfo, payload.ToMessage())
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to list service usage events")
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForPaginatedList(presenter.ForServiceUsageEvent, events, h.serverURL, *r.URL)), nil
+}
+
+func (h *ServiceUsageEvent) purgeAndReseed(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.service-usage-event.purge-and-reseed")
+
+	if err := h.serviceUsageEventRepo.PurgeAndReseed(r.Context(), authInfo); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to purge and reseed service usage events")
+	}
+
+	return routing.NewResponse(http.StatusOK), nil
+}
+
+func (h *ServiceUsageEvent) UnauthenticatedRoutes() []routing.Route {
+	return nil
+}
+
+func (h *ServiceUsageEvent) AuthenticatedRoutes() []routing.Route {
+	return []routing.Route{
+		{Method: "GET", Pattern: ServiceUsageEventsPath, Handler: h.list},
+		{Method: "GET", Pattern: ServiceUsageEventPath, Handler: h.get},
+		{Method: "POST", Pattern: ServiceUsageEventsPurgeAndReseedPath, Handler: h.purgeAndReseed},
+	}
+}
@@ -0,0 +1,176 @@
+package handlers_test
+
+import (
+	"errors"
+	"net/http"
+
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/handlers"
+	"code.cloudfoundry.org/korifi/api/handlers/fake"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	. "code.cloudfoundry.org/korifi/tests/matchers"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ServiceUsageEvent", func() {
+	var (
+		apiHandler            *handlers.ServiceUsageEvent
+		serviceUsageEventRepo *fake.CFServiceUsageEventRepository
+		requestValidator      *fake.RequestValidator
+		req                   *http.Request
+	)
+
+	BeforeEach(func() {
+		requestValidator = new(fake.RequestValidator)
+		serviceUsageEventRepo = new(fake.CFServiceUsageEventRepository)
+		apiHandler = handlers.NewServiceUsageEvent(*serverURL, requestValidator, serviceUsageEventRepo)
+		routerBuilder.LoadRoutes(apiHandler)
+	})
+
+	JustBeforeEach(func() {
+		routerBuilder.Build().ServeHTTP(rr, req)
+	})
+
+	Describe("GET /v3/service_usage_events/:guid", func() {
+		BeforeEach(func() {
+			serviceUsageEventRepo.GetServiceUsageEventReturns(repositories.ServiceUsageEventRecord{
+				GUID:                "event-guid",
+				State:               "CREATED",
+				ServiceInstanceGUID: "instance-guid",
+				ServiceInstanceType: "managed",
+			}, nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "GET", "/v3/service_usage_events/event-guid", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns the service usage event", func() {
+			Expect(serviceUsageEventRepo.GetServiceUsageEventCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualGUID := serviceUsageEventRepo.GetServiceUsageEventArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualGUID).To(Equal("event-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.guid", "event-guid"),
+				MatchJSONPath("$.state", "CREATED"),
+				MatchJSONPath("$.service_instance.guid", "instance-guid"),
+				MatchJSONPath("$.service_instance.type", "managed_service_instance"),
+				MatchJSONPath("$.links.self.href", "https://api.example.org/v3/service_usage_events/event-guid"),
+			)))
+		})
+
+		When("the user is not authorized to get the event", func() {
+			BeforeEach(func() {
+				serviceUsageEventRepo.GetServiceUsageEventReturns(repositories.ServiceUsageEventRecord{}, apierrors.NewForbiddenError(nil, repositories.ServiceUsageEventResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.ServiceUsageEventResourceType)
+			})
+		})
+
+		When("getting the event fails", func() {
+			BeforeEach(func() {
+				serviceUsageEventRepo.GetServiceUsageEventReturns(repositories.ServiceUsageEventRecord{}, errors.New("get-err"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("GET /v3/service_usage_events", func() {
+		BeforeEach(func() {
+			requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(&payloads.ServiceUsageEventList{
+				AfterGUID: "after-guid",
+			})
+
+			serviceUsageEventRepo.ListServiceUsageEventsReturns(repositories.ListResult[repositories.ServiceUsageEventRecord]{
+				PageInfo: repositories.PageInfo{TotalResults: 1, TotalPages: 1, PageNumber: 1, PageSize: 50},
+				Records: []repositories.ServiceUsageEventRecord{
+					{GUID: "event-guid"},
+				},
+			}, nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "GET", "/v3/service_usage_events?after_guid=after-guid", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("lists the service usage events", func() {
+			Expect(serviceUsageEventRepo.ListServiceUsageEventsCallCount()).To(Equal(1))
+			_, actualAuthInfo, listMessage := serviceUsageEventRepo.ListServiceUsageEventsArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(listMessage.AfterGUID).To(Equal("after-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.pagination.total_results", BeEquivalentTo(1)),
+				MatchJSONPath("$.resources[0].guid", "event-guid"),
+			)))
+		})
+
+		When("decoding the query parameters fails", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateURLValuesReturns(errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+
+		When("listing fails", func() {
+			BeforeEach(func() {
+				serviceUsageEventRepo.ListServiceUsageEventsReturns(repositories.ListResult[repositories.ServiceUsageEventRecord]{}, errors.New("list-err"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("POST /v3/service_usage_events/actions/destructively_purge_all_and_reseed", func() {
+		BeforeEach(func() {
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "POST", "/v3/service_usage_events/actions/destructively_purge_all_and_reseed", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("purges and reseeds the service usage events", func() {
+			Expect(serviceUsageEventRepo.PurgeAndReseedCallCount()).To(Equal(1))
+			_, actualAuthInfo := serviceUsageEventRepo.PurgeAndReseedArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+		})
+
+		When("the user is not authorized", func() {
+			BeforeEach(func() {
+				serviceUsageEventRepo.PurgeAndReseedReturns(apierrors.NewForbiddenError(nil, repositories.ServiceUsageEventResourceType))
+			})
+
+			It("returns a forbidden error", func() {
+				expectNotAuthorizedError()
+			})
+		})
+
+		When("purging fails", func() {
+			BeforeEach(func() {
+				serviceUsageEventRepo.PurgeAndReseedReturns(errors.New("purge-err"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+})
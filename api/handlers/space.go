@@ -29,6 +29,7 @@ type CFSpaceRepository interface {
 	GetSpace(context.Context, authorization.Info, string) (repositories.SpaceRecord, error)
 	DeleteSpace(context.Context, authorization.Info, repositories.DeleteSpaceMessage) error
 	PatchSpaceMetadata(context.Context, authorization.Info, repositories.PatchSpaceMetadataMessage) (repositories.SpaceRecord, error)
+	AssignIsolationSegment(context.Context, authorization.Info, repositories.AssignSpaceIsolationSegmentMessage) (repositories.SpaceRecord, error)
 	GetDeletedAt(context.Context, authorization.Info, string) (*time.Time, error)
 }
 
@@ -22,12 +22,13 @@ import (
 //counterfeiter:generate -o fake -fake-name HttpHandler net/http.Handler
 
 type ProcessGauges struct {
-	Index     int
-	CPU       *float64
-	Mem       *int64
-	Disk      *int64
-	MemQuota  *int64
-	DiskQuota *int64
+	Index          int
+	CPU            *float64
+	CPUEntitlement *float64
+	Mem            *int64
+	Disk           *int64
+	MemQuota       *int64
+	DiskQuota      *int64
 }
 
 type LogCacheGaugesCollector struct {
@@ -75,6 +76,7 @@ func (c *LogCacheGaugesCollector) CollectProcessGauges(ctx context.Context, appG
 
 			metrics := e.GetGauge().GetMetrics()
 			stats.CPU = tools.IfNil(stats.CPU, gaugeValueOrNil[float64](metrics["cpu"]))
+			stats.CPUEntitlement = tools.IfNil(stats.CPUEntitlement, gaugeValueOrNil[float64](metrics["cpu_entitlement"]))
 			stats.Mem = tools.IfNil(stats.Mem, gaugeValueOrNil[int64](metrics["memory"]))
 			stats.Disk = tools.IfNil(stats.Disk, gaugeValueOrNil[int64](metrics["disk"]))
 			stats.MemQuota = tools.IfNil(stats.MemQuota, gaugeValueOrNil[int64](metrics["memory_quota"]))
@@ -50,6 +50,10 @@ var _ = Describe("LogCacheGaugesCollector", func() {
 									"unit":  "percentage",
 									"value": 1.23,
 								},
+								"cpu_entitlement": map[string]any{
+									"unit":  "percentage",
+									"value": 45.6,
+								},
 
 								"disk": map[string]any{
 									"unit":  "bytes",
@@ -152,12 +156,13 @@ var _ = Describe("LogCacheGaugesCollector", func() {
 	It("returns the proces stats", func() {
 		Expect(gaugesErr).NotTo(HaveOccurred())
 		Expect(gauges).To(ConsistOf(stats.ProcessGauges{
-			Index:     3,
-			CPU:       tools.PtrTo(1.23),
-			Mem:       tools.PtrTo[int64](7776),
-			Disk:      tools.PtrTo[int64](6665),
-			MemQuota:  tools.PtrTo[int64](7777),
-			DiskQuota: tools.PtrTo[int64](6666),
+			Index:          3,
+			CPU:            tools.PtrTo(1.23),
+			CPUEntitlement: tools.PtrTo(45.6),
+			Mem:            tools.PtrTo[int64](7776),
+			Disk:           tools.PtrTo[int64](6665),
+			MemQuota:       tools.PtrTo[int64](7777),
+			DiskQuota:      tools.PtrTo[int64](6666),
 		}))
 	})
 
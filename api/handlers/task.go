@@ -10,6 +10,7 @@ import (
 	"code.cloudfoundry.org/korifi/api/payloads"
 	"code.cloudfoundry.org/korifi/api/presenter"
 	"code.cloudfoundry.org/korifi/api/routing"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 
 	"code.cloudfoundry.org/korifi/api/repositories"
 	"github.com/go-logr/logr"
@@ -36,6 +37,9 @@ type Task struct {
 	serverURL        url.URL
 	appRepo          CFAppRepository
 	taskRepo         CFTaskRepository
+	dropletRepo      CFDropletRepository
+	processRepo      CFProcessRepository
+	featureFlagRepo  CFFeatureFlagRepository
 	requestValidator RequestValidator
 }
 
@@ -43,12 +47,18 @@ func NewTask(
 	serverURL url.URL,
 	appRepo CFAppRepository,
 	taskRepo CFTaskRepository,
+	dropletRepo CFDropletRepository,
+	processRepo CFProcessRepository,
+	featureFlagRepo CFFeatureFlagRepository,
 	requestValidator RequestValidator,
 ) *Task {
 	return &Task{
 		serverURL:        serverURL,
 		taskRepo:         taskRepo,
 		appRepo:          appRepo,
+		dropletRepo:      dropletRepo,
+		processRepo:      processRepo,
+		featureFlagRepo:  featureFlagRepo,
 		requestValidator: requestValidator,
 	}
 }
@@ -83,6 +93,12 @@ func (h *Task) create(r *http.Request) (*routing.Response, error) {
 	authInfo, _ := authorization.InfoFromContext(r.Context())
 	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.task.create")
 
+	// The feature flag is only checked for tasks created through the API, as
+	// the runs of task schedules are recorded as tasks by the controllers
+	if err := h.featureFlagRepo.EnsureFeatureEnabled(r.Context(), authInfo, korifiv1alpha1.FeatureFlagTaskCreation); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "task creation is disabled")
+	}
+
 	appGUID := routing.URLParam(r, "appGUID")
 
 	var payload payloads.TaskCreate
@@ -95,7 +111,24 @@ func (h *Task) create(r *http.Request) (*routing.Response, error) {
 		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "error finding app", "appGUID", appGUID)
 	}
 
-	if !appRecord.IsStaged {
+	if payload.DropletGUID != "" {
+		dropletRecord, err := h.dropletRepo.GetDroplet(r.Context(), authInfo, payload.DropletGUID)
+		if err != nil {
+			return nil, apierrors.LogAndReturn(
+				logger,
+				apierrors.AsUnprocessableEntity(err, "Unable to use droplet. Ensure that the droplet exists and you have access to it.", apierrors.NotFoundError{}, apierrors.ForbiddenError{}),
+				"error finding droplet", "dropletGUID", payload.DropletGUID,
+			)
+		}
+
+		if dropletRecord.AppGUID != appGUID {
+			return nil, apierrors.LogAndReturn(
+				logger,
+				apierrors.NewUnprocessableEntityError(nil, "The droplet does not belong to the app."),
+				"droplet belongs to another app", "dropletGUID", payload.DropletGUID, "appGUID", appGUID,
+			)
+		}
+	} else if !appRecord.IsStaged {
 		return nil, apierrors.LogAndReturn(
 			logger,
 			apierrors.NewUnprocessableEntityError(nil, "Task must have a droplet. Assign current droplet to app."),
@@ -103,7 +136,37 @@ func (h *Task) create(r *http.Request) (*routing.Response, error) {
 		)
 	}
 
-	taskRecord, err := h.taskRepo.CreateTask(r.Context(), authInfo, payload.ToMessage(appRecord))
+	createMessage := payload.ToMessage(appRecord)
+
+	if processGUID := payload.TemplateProcessGUID(); processGUID != "" {
+		processRecord, err := h.processRepo.GetProcess(r.Context(), authInfo, processGUID)
+		if err != nil {
+			return nil, apierrors.LogAndReturn(
+				logger,
+				apierrors.AsUnprocessableEntity(err, "Unable to use template process. Ensure that the process exists and you have access to it.", apierrors.NotFoundError{}, apierrors.ForbiddenError{}),
+				"error finding template process", "processGUID", processGUID,
+			)
+		}
+
+		if processRecord.AppGUID != appGUID {
+			return nil, apierrors.LogAndReturn(
+				logger,
+				apierrors.NewUnprocessableEntityError(nil, "The template process does not belong to the app."),
+				"template process belongs to another app", "processGUID", processGUID, "appGUID", appGUID,
+			)
+		}
+
+		createMessage = inheritFromProcess(createMessage, processRecord)
+		if createMessage.Command == "" {
+			return nil, apierrors.LogAndReturn(
+				logger,
+				apierrors.NewUnprocessableEntityError(nil, "The template process has no command. Specify a command for the task."),
+				"template process has no command", "processGUID", processGUID,
+			)
+		}
+	}
+
+	taskRecord, err := h.taskRepo.CreateTask(r.Context(), authInfo, createMessage)
 	if err != nil {
 		return nil, apierrors.LogAndReturn(logger, err, "failed to create task")
 	}
@@ -111,6 +174,22 @@ func (h *Task) create(r *http.Request) (*routing.Response, error) {
 	return routing.NewResponse(http.StatusCreated).WithBody(presenter.ForTask(taskRecord, h.serverURL)), nil
 }
 
+func inheritFromProcess(message repositories.CreateTaskMessage, process repositories.ProcessRecord) repositories.CreateTaskMessage {
+	if message.Command == "" {
+		message.Command = process.Command
+	}
+
+	if message.MemoryMB == 0 {
+		message.MemoryMB = process.MemoryMB
+	}
+
+	if message.DiskMB == 0 {
+		message.DiskMB = process.DiskQuotaMB
+	}
+
+	return message
+}
+
 func (h *Task) listForApp(r *http.Request) (*routing.Response, error) {
 	authInfo, _ := authorization.InfoFromContext(r.Context())
 	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.task.list-for-app")
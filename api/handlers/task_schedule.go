@@ -0,0 +1,170 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/api/routing"
+
+	"github.com/go-logr/logr"
+)
+
+const (
+	AppTaskSchedulesPath = "/v3/apps/{appGUID}/task_schedules"
+	TaskScheduleRoot     = "/v3/task_schedules"
+	TaskSchedulePath     = TaskScheduleRoot + "/{guid}"
+)
+
+//counterfeiter:generate -o fake -fake-name CFTaskScheduleRepository . CFTaskScheduleRepository
+type CFTaskScheduleRepository interface {
+	CreateTaskSchedule(context.Context, authorization.Info, repositories.CreateTaskScheduleMessage) (repositories.TaskScheduleRecord, error)
+	GetTaskSchedule(context.Context, authorization.Info, string) (repositories.TaskScheduleRecord, error)
+	ListTaskSchedules(context.Context, authorization.Info, repositories.ListTaskScheduleMessage) ([]repositories.TaskScheduleRecord, error)
+	PatchTaskSchedule(context.Context, authorization.Info, repositories.PatchTaskScheduleMessage) (repositories.TaskScheduleRecord, error)
+	DeleteTaskSchedule(context.Context, authorization.Info, repositories.DeleteTaskScheduleMessage) error
+}
+
+type TaskSchedule struct {
+	serverURL        url.URL
+	appRepo          CFAppRepository
+	scheduleRepo     CFTaskScheduleRepository
+	requestValidator RequestValidator
+}
+
+func NewTaskSchedule(
+	serverURL url.URL,
+	appRepo CFAppRepository,
+	scheduleRepo CFTaskScheduleRepository,
+	requestValidator RequestValidator,
+) *TaskSchedule {
+	return &TaskSchedule{
+		serverURL:        serverURL,
+		appRepo:          appRepo,
+		scheduleRepo:     scheduleRepo,
+		requestValidator: requestValidator,
+	}
+}
+
+func (h *TaskSchedule) create(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.task-schedule.create")
+
+	appGUID := routing.URLParam(r, "appGUID")
+
+	var payload payloads.TaskScheduleCreate
+	if err := h.requestValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	appRecord, err := h.appRepo.GetApp(r.Context(), authInfo, appGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "error finding app", "appGUID", appGUID)
+	}
+
+	scheduleRecord, err := h.scheduleRepo.CreateTaskSchedule(r.Context(), authInfo, payload.ToMessage(appRecord))
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to create task schedule")
+	}
+
+	return routing.NewResponse(http.StatusCreated).WithBody(presenter.ForTaskSchedule(scheduleRecord, h.serverURL)), nil
+}
+
+func (h *TaskSchedule) listForApp(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.task-schedule.list-for-app")
+
+	appGUID := routing.URLParam(r, "appGUID")
+
+	if _, err := h.appRepo.GetApp(r.Context(), authInfo, appGUID); err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "error finding app", "appGUID", appGUID)
+	}
+
+	schedules, err := h.scheduleRepo.ListTaskSchedules(r.Context(), authInfo, repositories.ListTaskScheduleMessage{
+		AppGUIDs: []string{appGUID},
+	})
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to list task schedules")
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForList(presenter.ForTaskSchedule, schedules, h.serverURL, *r.URL)), nil
+}
+
+func (h *TaskSchedule) get(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.task-schedule.get")
+
+	scheduleGUID := routing.URLParam(r, "guid")
+
+	scheduleRecord, err := h.scheduleRepo.GetTaskSchedule(r.Context(), authInfo, scheduleGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get task schedule", "guid", scheduleGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForTaskSchedule(scheduleRecord, h.serverURL)), nil
+}
+
+func (h *TaskSchedule) update(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.task-schedule.update")
+
+	scheduleGUID := routing.URLParam(r, "guid")
+
+	scheduleRecord, err := h.scheduleRepo.GetTaskSchedule(r.Context(), authInfo, scheduleGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get task schedule", "guid", scheduleGUID)
+	}
+
+	var payload payloads.TaskSchedulePatch
+	if err = h.requestValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	scheduleRecord, err = h.scheduleRepo.PatchTaskSchedule(r.Context(), authInfo, payload.ToMessage(scheduleGUID, scheduleRecord.SpaceGUID))
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to patch task schedule", "guid", scheduleGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForTaskSchedule(scheduleRecord, h.serverURL)), nil
+}
+
+func (h *TaskSchedule) delete(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.task-schedule.delete")
+
+	scheduleGUID := routing.URLParam(r, "guid")
+
+	scheduleRecord, err := h.scheduleRepo.GetTaskSchedule(r.Context(), authInfo, scheduleGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get task schedule", "guid", scheduleGUID)
+	}
+
+	err = h.scheduleRepo.DeleteTaskSchedule(r.Context(), authInfo, repositories.DeleteTaskScheduleMessage{
+		GUID:      scheduleGUID,
+		SpaceGUID: scheduleRecord.SpaceGUID,
+	})
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to delete task schedule", "guid", scheduleGUID)
+	}
+
+	return routing.NewResponse(http.StatusNoContent), nil
+}
+
+func (h *TaskSchedule) UnauthenticatedRoutes() []routing.Route {
+	return nil
+}
+
+func (h *TaskSchedule) AuthenticatedRoutes() []routing.Route {
+	return []routing.Route{
+		{Method: "POST", Pattern: AppTaskSchedulesPath, Handler: h.create},
+		{Method: "GET", Pattern: AppTaskSchedulesPath, Handler: h.listForApp},
+		{Method: "GET", Pattern: TaskSchedulePath, Handler: h.get},
+		{Method: "PATCH", Pattern: TaskSchedulePath, Handler: h.update},
+		{Method: "DELETE", Pattern: TaskSchedulePath, Handler: h.delete},
+	}
+}
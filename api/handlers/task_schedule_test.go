@@ -0,0 +1,303 @@
+package handlers_test
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/handlers"
+	"code.cloudfoundry.org/korifi/api/handlers/fake"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	. "code.cloudfoundry.org/korifi/tests/matchers"
+	"code.cloudfoundry.org/korifi/tools"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("TaskSchedule", func() {
+	var (
+		requestMethod    string
+		requestPath      string
+		appRepo          *fake.CFAppRepository
+		scheduleRepo     *fake.CFTaskScheduleRepository
+		requestValidator *fake.RequestValidator
+	)
+
+	BeforeEach(func() {
+		appRepo = new(fake.CFAppRepository)
+		appRepo.GetAppReturns(repositories.AppRecord{
+			GUID:      "the-app-guid",
+			SpaceGUID: "the-space-guid",
+		}, nil)
+
+		scheduleRepo = new(fake.CFTaskScheduleRepository)
+		scheduleRepo.GetTaskScheduleReturns(repositories.TaskScheduleRecord{
+			GUID:      "the-schedule-guid",
+			SpaceGUID: "the-space-guid",
+			AppGUID:   "the-app-guid",
+		}, nil)
+
+		requestValidator = new(fake.RequestValidator)
+
+		apiHandler := handlers.NewTaskSchedule(*serverURL, appRepo, scheduleRepo, requestValidator)
+		routerBuilder.LoadRoutes(apiHandler)
+	})
+
+	JustBeforeEach(func() {
+		req, err := http.NewRequestWithContext(ctx, requestMethod, requestPath, strings.NewReader("the-json-body"))
+		Expect(err).NotTo(HaveOccurred())
+		routerBuilder.Build().ServeHTTP(rr, req)
+	})
+
+	Describe("POST /v3/apps/:app-guid/task_schedules", func() {
+		BeforeEach(func() {
+			requestMethod = http.MethodPost
+			requestPath = "/v3/apps/the-app-guid/task_schedules"
+
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.TaskScheduleCreate{
+				Name:              "nightly",
+				Command:           "rake db:cleanup",
+				Schedule:          "0 0 * * *",
+				ConcurrencyPolicy: "forbid",
+			})
+
+			scheduleRepo.CreateTaskScheduleReturns(repositories.TaskScheduleRecord{
+				GUID:    "the-schedule-guid",
+				AppGUID: "the-app-guid",
+			}, nil)
+		})
+
+		It("creates the task schedule", func() {
+			Expect(scheduleRepo.CreateTaskScheduleCallCount()).To(Equal(1))
+			_, actualAuthInfo, createMessage := scheduleRepo.CreateTaskScheduleArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(createMessage).To(Equal(repositories.CreateTaskScheduleMessage{
+				Name:              "nightly",
+				Command:           "rake db:cleanup",
+				SpaceGUID:         "the-space-guid",
+				AppGUID:           "the-app-guid",
+				Schedule:          "0 0 * * *",
+				ConcurrencyPolicy: korifiv1alpha1.ConcurrencyPolicyForbid,
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusCreated))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.guid", "the-schedule-guid"),
+				MatchJSONPath("$.links.self.href", "https://api.example.org/v3/task_schedules/the-schedule-guid"),
+			)))
+		})
+
+		When("the payload is invalid", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateJSONPayloadReturns(apierrors.NewUnprocessableEntityError(nil, "oops"))
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("oops")
+			})
+		})
+
+		When("the app does not exist", func() {
+			BeforeEach(func() {
+				appRepo.GetAppReturns(repositories.AppRecord{}, apierrors.NewNotFoundError(nil, repositories.AppResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.AppResourceType)
+			})
+		})
+
+		When("the user cannot see the app", func() {
+			BeforeEach(func() {
+				appRepo.GetAppReturns(repositories.AppRecord{}, apierrors.NewForbiddenError(nil, repositories.AppResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.AppResourceType)
+			})
+		})
+
+		When("creating the task schedule fails", func() {
+			BeforeEach(func() {
+				scheduleRepo.CreateTaskScheduleReturns(repositories.TaskScheduleRecord{}, errors.New("boom"))
+			})
+
+			It("returns an unknown error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("GET /v3/apps/:app-guid/task_schedules", func() {
+		BeforeEach(func() {
+			requestMethod = http.MethodGet
+			requestPath = "/v3/apps/the-app-guid/task_schedules"
+
+			scheduleRepo.ListTaskSchedulesReturns([]repositories.TaskScheduleRecord{
+				{GUID: "schedule-1", AppGUID: "the-app-guid"},
+				{GUID: "schedule-2", AppGUID: "the-app-guid"},
+			}, nil)
+		})
+
+		It("lists the task schedules of the app", func() {
+			Expect(scheduleRepo.ListTaskSchedulesCallCount()).To(Equal(1))
+			_, _, listMessage := scheduleRepo.ListTaskSchedulesArgsForCall(0)
+			Expect(listMessage.AppGUIDs).To(ConsistOf("the-app-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.pagination.total_results", BeEquivalentTo(2)),
+				MatchJSONPath("$.resources[0].guid", "schedule-1"),
+				MatchJSONPath("$.resources[1].guid", "schedule-2"),
+			)))
+		})
+
+		When("the app does not exist", func() {
+			BeforeEach(func() {
+				appRepo.GetAppReturns(repositories.AppRecord{}, apierrors.NewNotFoundError(nil, repositories.AppResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.AppResourceType)
+			})
+		})
+
+		When("listing the task schedules fails", func() {
+			BeforeEach(func() {
+				scheduleRepo.ListTaskSchedulesReturns(nil, errors.New("boom"))
+			})
+
+			It("returns an unknown error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("GET /v3/task_schedules/:guid", func() {
+		BeforeEach(func() {
+			requestMethod = http.MethodGet
+			requestPath = "/v3/task_schedules/the-schedule-guid"
+		})
+
+		It("returns the task schedule", func() {
+			Expect(scheduleRepo.GetTaskScheduleCallCount()).To(Equal(1))
+			_, _, actualGUID := scheduleRepo.GetTaskScheduleArgsForCall(0)
+			Expect(actualGUID).To(Equal("the-schedule-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(MatchJSONPath("$.guid", "the-schedule-guid")))
+		})
+
+		When("the user cannot see the task schedule", func() {
+			BeforeEach(func() {
+				scheduleRepo.GetTaskScheduleReturns(repositories.TaskScheduleRecord{}, apierrors.NewForbiddenError(nil, repositories.TaskScheduleResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.TaskScheduleResourceType)
+			})
+		})
+	})
+
+	Describe("PATCH /v3/task_schedules/:guid", func() {
+		BeforeEach(func() {
+			requestMethod = http.MethodPatch
+			requestPath = "/v3/task_schedules/the-schedule-guid"
+
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.TaskSchedulePatch{
+				Suspended: tools.PtrTo(true),
+			})
+
+			scheduleRepo.PatchTaskScheduleReturns(repositories.TaskScheduleRecord{
+				GUID:      "the-schedule-guid",
+				Suspended: true,
+			}, nil)
+		})
+
+		It("patches the task schedule", func() {
+			Expect(scheduleRepo.PatchTaskScheduleCallCount()).To(Equal(1))
+			_, _, patchMessage := scheduleRepo.PatchTaskScheduleArgsForCall(0)
+			Expect(patchMessage.GUID).To(Equal("the-schedule-guid"))
+			Expect(patchMessage.SpaceGUID).To(Equal("the-space-guid"))
+			Expect(patchMessage.Suspended).To(Equal(tools.PtrTo(true)))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(MatchJSONPath("$.suspended", BeTrue())))
+		})
+
+		When("the task schedule does not exist", func() {
+			BeforeEach(func() {
+				scheduleRepo.GetTaskScheduleReturns(repositories.TaskScheduleRecord{}, apierrors.NewNotFoundError(nil, repositories.TaskScheduleResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.TaskScheduleResourceType)
+				Expect(scheduleRepo.PatchTaskScheduleCallCount()).To(BeZero())
+			})
+		})
+
+		When("the payload is invalid", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateJSONPayloadReturns(apierrors.NewUnprocessableEntityError(nil, "oops"))
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("oops")
+			})
+		})
+
+		When("patching the task schedule fails", func() {
+			BeforeEach(func() {
+				scheduleRepo.PatchTaskScheduleReturns(repositories.TaskScheduleRecord{}, errors.New("boom"))
+			})
+
+			It("returns an unknown error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("DELETE /v3/task_schedules/:guid", func() {
+		BeforeEach(func() {
+			requestMethod = http.MethodDelete
+			requestPath = "/v3/task_schedules/the-schedule-guid"
+		})
+
+		It("deletes the task schedule", func() {
+			Expect(scheduleRepo.DeleteTaskScheduleCallCount()).To(Equal(1))
+			_, _, deleteMessage := scheduleRepo.DeleteTaskScheduleArgsForCall(0)
+			Expect(deleteMessage).To(Equal(repositories.DeleteTaskScheduleMessage{
+				GUID:      "the-schedule-guid",
+				SpaceGUID: "the-space-guid",
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusNoContent))
+		})
+
+		When("the task schedule does not exist", func() {
+			BeforeEach(func() {
+				scheduleRepo.GetTaskScheduleReturns(repositories.TaskScheduleRecord{}, apierrors.NewNotFoundError(nil, repositories.TaskScheduleResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.TaskScheduleResourceType)
+				Expect(scheduleRepo.DeleteTaskScheduleCallCount()).To(BeZero())
+			})
+		})
+
+		When("deleting the task schedule fails", func() {
+			BeforeEach(func() {
+				scheduleRepo.DeleteTaskScheduleReturns(errors.New("boom"))
+			})
+
+			It("returns an unknown error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+})
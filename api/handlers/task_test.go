@@ -26,6 +26,9 @@ var _ = Describe("Task", func() {
 		requestPath      string
 		appRepo          *fake.CFAppRepository
 		taskRepo         *fake.CFTaskRepository
+		dropletRepo      *fake.CFDropletRepository
+		processRepo      *fake.CFProcessRepository
+		featureFlagRepo  *fake.CFFeatureFlagRepository
 		requestValidator *fake.RequestValidator
 	)
 
@@ -47,9 +50,25 @@ var _ = Describe("Task", func() {
 			SpaceGUID: "the-space-guid",
 		}, nil)
 
+		dropletRepo = new(fake.CFDropletRepository)
+		dropletRepo.GetDropletReturns(repositories.DropletRecord{
+			GUID:    "the-droplet-guid",
+			AppGUID: "the-app-guid",
+		}, nil)
+
+		processRepo = new(fake.CFProcessRepository)
+		processRepo.GetProcessReturns(repositories.ProcessRecord{
+			GUID:        "the-process-guid",
+			AppGUID:     "the-app-guid",
+			Command:     "bundle exec rake",
+			MemoryMB:    1024,
+			DiskQuotaMB: 2048,
+		}, nil)
+
+		featureFlagRepo = new(fake.CFFeatureFlagRepository)
 		requestValidator = new(fake.RequestValidator)
 
-		apiHandler := handlers.NewTask(*serverURL, appRepo, taskRepo, requestValidator)
+		apiHandler := handlers.NewTask(*serverURL, appRepo, taskRepo, dropletRepo, processRepo, featureFlagRepo, requestValidator)
 		routerBuilder.LoadRoutes(apiHandler)
 	})
 
@@ -99,6 +118,24 @@ var _ = Describe("Task", func() {
 			)))
 		})
 
+		It("checks the task_creation feature flag", func() {
+			Expect(featureFlagRepo.EnsureFeatureEnabledCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualName := featureFlagRepo.EnsureFeatureEnabledArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualName).To(Equal("task_creation"))
+		})
+
+		When("task creation is disabled", func() {
+			BeforeEach(func() {
+				featureFlagRepo.EnsureFeatureEnabledReturns(apierrors.NewFeatureDisabledError(nil, "task_creation"))
+			})
+
+			It("returns a feature disabled error", func() {
+				expectErrorResponse(http.StatusForbidden, "CF-FeatureDisabled", "Feature Disabled: task_creation", 330002)
+				Expect(taskRepo.CreateTaskCallCount()).To(BeZero())
+			})
+		})
+
 		When("the app does not exist", func() {
 			BeforeEach(func() {
 				appRepo.GetAppReturns(repositories.AppRecord{}, apierrors.NewNotFoundError(nil, repositories.AppResourceType))
@@ -143,6 +180,156 @@ var _ = Describe("Task", func() {
 			})
 		})
 
+		When("a droplet is specified", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.TaskCreate{
+					Command:     "echo hello",
+					DropletGUID: "the-droplet-guid",
+				})
+			})
+
+			It("creates a task running the droplet", func() {
+				Expect(dropletRepo.GetDropletCallCount()).To(Equal(1))
+				_, actualAuthInfo, dropletGUID := dropletRepo.GetDropletArgsForCall(0)
+				Expect(actualAuthInfo).To(Equal(authInfo))
+				Expect(dropletGUID).To(Equal("the-droplet-guid"))
+
+				Expect(taskRepo.CreateTaskCallCount()).To(Equal(1))
+				_, _, createTaskMessage := taskRepo.CreateTaskArgsForCall(0)
+				Expect(createTaskMessage.DropletGUID).To(Equal("the-droplet-guid"))
+
+				Expect(rr).To(HaveHTTPStatus(http.StatusCreated))
+			})
+
+			When("the app is not staged", func() {
+				BeforeEach(func() {
+					appRepo.GetAppReturns(repositories.AppRecord{
+						GUID:      "the-app-guid",
+						SpaceGUID: "the-space-guid",
+						IsStaged:  false,
+					}, nil)
+				})
+
+				It("creates the task", func() {
+					Expect(rr).To(HaveHTTPStatus(http.StatusCreated))
+				})
+			})
+
+			When("the droplet does not exist", func() {
+				BeforeEach(func() {
+					dropletRepo.GetDropletReturns(repositories.DropletRecord{}, apierrors.NewNotFoundError(nil, repositories.DropletResourceType))
+				})
+
+				It("returns an Unprocessable Entity error", func() {
+					expectUnprocessableEntityError("Unable to use droplet. Ensure that the droplet exists and you have access to it.")
+				})
+			})
+
+			When("the droplet belongs to another app", func() {
+				BeforeEach(func() {
+					dropletRepo.GetDropletReturns(repositories.DropletRecord{
+						GUID:    "the-droplet-guid",
+						AppGUID: "another-app-guid",
+					}, nil)
+				})
+
+				It("returns an Unprocessable Entity error", func() {
+					expectUnprocessableEntityError("The droplet does not belong to the app.")
+				})
+			})
+		})
+
+		When("a template process is specified", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.TaskCreate{
+					Template: &payloads.TaskTemplate{
+						Process: payloads.TaskTemplateProcess{GUID: "the-process-guid"},
+					},
+				})
+			})
+
+			It("creates a task inheriting the process command and resources", func() {
+				Expect(processRepo.GetProcessCallCount()).To(Equal(1))
+				_, actualAuthInfo, processGUID := processRepo.GetProcessArgsForCall(0)
+				Expect(actualAuthInfo).To(Equal(authInfo))
+				Expect(processGUID).To(Equal("the-process-guid"))
+
+				Expect(taskRepo.CreateTaskCallCount()).To(Equal(1))
+				_, _, createTaskMessage := taskRepo.CreateTaskArgsForCall(0)
+				Expect(createTaskMessage.Command).To(Equal("bundle exec rake"))
+				Expect(createTaskMessage.MemoryMB).To(BeEquivalentTo(1024))
+				Expect(createTaskMessage.DiskMB).To(BeEquivalentTo(2048))
+			})
+
+			When("the task specifies its command and resources", func() {
+				BeforeEach(func() {
+					requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.TaskCreate{
+						Command:    "echo hello",
+						MemoryInMB: tools.PtrTo(int64(256)),
+						DiskInMB:   tools.PtrTo(int64(512)),
+						Template: &payloads.TaskTemplate{
+							Process: payloads.TaskTemplateProcess{GUID: "the-process-guid"},
+						},
+					})
+				})
+
+				It("does not override them", func() {
+					Expect(taskRepo.CreateTaskCallCount()).To(Equal(1))
+					_, _, createTaskMessage := taskRepo.CreateTaskArgsForCall(0)
+					Expect(createTaskMessage.Command).To(Equal("echo hello"))
+					Expect(createTaskMessage.MemoryMB).To(BeEquivalentTo(256))
+					Expect(createTaskMessage.DiskMB).To(BeEquivalentTo(512))
+				})
+			})
+
+			When("the process has no command", func() {
+				BeforeEach(func() {
+					processRepo.GetProcessReturns(repositories.ProcessRecord{
+						GUID:    "the-process-guid",
+						AppGUID: "the-app-guid",
+					}, nil)
+				})
+
+				It("returns an Unprocessable Entity error", func() {
+					expectUnprocessableEntityError("The template process has no command. Specify a command for the task.")
+				})
+			})
+
+			When("the process cannot be found", func() {
+				BeforeEach(func() {
+					processRepo.GetProcessReturns(repositories.ProcessRecord{}, apierrors.NewForbiddenError(nil, repositories.ProcessResourceType))
+				})
+
+				It("returns an Unprocessable Entity error", func() {
+					expectUnprocessableEntityError("Unable to use template process. Ensure that the process exists and you have access to it.")
+				})
+			})
+
+			When("the process belongs to another app", func() {
+				BeforeEach(func() {
+					processRepo.GetProcessReturns(repositories.ProcessRecord{
+						GUID:    "the-process-guid",
+						AppGUID: "another-app-guid",
+						Command: "bundle exec rake",
+					}, nil)
+				})
+
+				It("returns an Unprocessable Entity error", func() {
+					expectUnprocessableEntityError("The template process does not belong to the app.")
+				})
+			})
+
+			When("getting the process fails", func() {
+				BeforeEach(func() {
+					processRepo.GetProcessReturns(repositories.ProcessRecord{}, errors.New("boom"))
+				})
+
+				It("returns an Internal Server Error", func() {
+					expectUnknownError()
+				})
+			})
+		})
+
 		When("the user cannot create tasks", func() {
 			BeforeEach(func() {
 				taskRepo.CreateTaskReturns(repositories.TaskRecord{}, apierrors.NewForbiddenError(nil, repositories.TaskResourceType))
@@ -1,43 +1,128 @@
 package handlers
 
 import (
+	"context"
 	"net/http"
 	"net/url"
-	"strings"
 
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/payloads"
 	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
 	"code.cloudfoundry.org/korifi/api/routing"
+
+	"github.com/go-logr/logr"
 )
 
 const (
 	usersPath = "/v3/users"
+	userPath  = "/v3/users/{guid}"
 )
 
+//counterfeiter:generate -o fake -fake-name CFUserRepository . CFUserRepository
+
+type CFUserRepository interface {
+	CreateUser(context.Context, authorization.Info, repositories.CreateUserMessage) (repositories.UserRecord, error)
+	GetUser(context.Context, authorization.Info, string) (repositories.UserRecord, error)
+	ListUsers(context.Context, authorization.Info, repositories.ListUsersMessage) ([]repositories.UserRecord, error)
+	DeleteUser(context.Context, authorization.Info, string) error
+}
+
 type User struct {
-	apiBaseURL url.URL
+	apiBaseURL       url.URL
+	requestValidator RequestValidator
+	userRepo         CFUserRepository
 }
 
-func NewUser(apiBaseURL url.URL) User {
-	return User{
-		apiBaseURL: apiBaseURL,
+func NewUser(
+	apiBaseURL url.URL,
+	requestValidator RequestValidator,
+	userRepo CFUserRepository,
+) *User {
+	return &User{
+		apiBaseURL:       apiBaseURL,
+		requestValidator: requestValidator,
+		userRepo:         userRepo,
 	}
 }
 
-func (h User) list(req *http.Request) (*routing.Response, error) {
-	usernames := req.URL.Query().Get("usernames")
-	users := []string{}
-	if len(usernames) > 0 {
-		users = strings.Split(usernames, ",")
+func (h *User) create(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.user.create")
+
+	var payload payloads.UserCreate
+	if err := h.requestValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	user, err := h.userRepo.CreateUser(r.Context(), authInfo, payload.ToMessage())
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to create user", "username", payload.Username)
 	}
-	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForList(presenter.ForUser, users, h.apiBaseURL, *req.URL)), nil
+
+	return routing.NewResponse(http.StatusCreated).WithBody(presenter.ForUser(user, h.apiBaseURL)), nil
+}
+
+func (h *User) get(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.user.get")
+
+	guid := routing.URLParam(r, "guid")
+
+	user, err := h.userRepo.GetUser(r.Context(), authInfo, guid)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get user", "guid", guid)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForUser(user, h.apiBaseURL)), nil
+}
+
+func (h *User) list(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.user.list")
+
+	payload := new(payloads.UserList)
+	if err := h.requestValidator.DecodeAndValidateURLValues(r, payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Unable to decode request query parameters")
+	}
+
+	users, err := h.userRepo.ListUsers(r.Context(), authInfo, payload.ToMessage())
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to list users")
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForList(presenter.ForUser, users, h.apiBaseURL, *r.URL)), nil
+}
+
+func (h *User) delete(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.user.delete")
+
+	guid := routing.URLParam(r, "guid")
+
+	_, err := h.userRepo.GetUser(r.Context(), authInfo, guid)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get user", "guid", guid)
+	}
+
+	err = h.userRepo.DeleteUser(r.Context(), authInfo, guid)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to delete user", "guid", guid)
+	}
+
+	return routing.NewResponse(http.StatusNoContent), nil
 }
 
-func (h User) UnauthenticatedRoutes() []routing.Route {
+func (h *User) UnauthenticatedRoutes() []routing.Route {
 	return nil
 }
 
-func (h User) AuthenticatedRoutes() []routing.Route {
+func (h *User) AuthenticatedRoutes() []routing.Route {
 	return []routing.Route{
+		{Method: "POST", Pattern: usersPath, Handler: h.create},
+		{Method: "GET", Pattern: userPath, Handler: h.get},
 		{Method: "GET", Pattern: usersPath, Handler: h.list},
+		{Method: "DELETE", Pattern: userPath, Handler: h.delete},
 	}
 }
@@ -1,55 +1,240 @@
 package handlers_test
 
 import (
+	"errors"
 	"net/http"
+	"strings"
 
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
 	"code.cloudfoundry.org/korifi/api/handlers"
+	"code.cloudfoundry.org/korifi/api/handlers/fake"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
 	. "code.cloudfoundry.org/korifi/tests/matchers"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 )
 
 var _ = Describe("User", func() {
-	var query string
+	var (
+		userRepo         *fake.CFUserRepository
+		requestValidator *fake.RequestValidator
+		req              *http.Request
+	)
 
 	BeforeEach(func() {
-		query = ""
-		userHandler := handlers.NewUser(*serverURL)
+		requestValidator = new(fake.RequestValidator)
+		userRepo = new(fake.CFUserRepository)
+		userHandler := handlers.NewUser(*serverURL, requestValidator, userRepo)
 		routerBuilder.LoadRoutes(userHandler)
+
+		userRepo.GetUserReturns(repositories.UserRecord{
+			GUID:     "oidc:alice",
+			Username: "oidc:alice",
+		}, nil)
 	})
 
 	JustBeforeEach(func() {
-		req, err := http.NewRequestWithContext(ctx, "GET", "/v3/users"+query, nil)
-		Expect(err).NotTo(HaveOccurred())
 		routerBuilder.Build().ServeHTTP(rr, req)
 	})
 
+	Describe("POST /v3/users", func() {
+		BeforeEach(func() {
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.UserCreate{
+				Username:         "oidc:alice",
+				Origin:           "oidc",
+				PresentationName: "Alice",
+			})
+
+			userRepo.CreateUserReturns(repositories.UserRecord{
+				GUID:             "oidc:alice",
+				Username:         "oidc:alice",
+				PresentationName: "Alice",
+				Origin:           "oidc",
+			}, nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "POST", "/v3/users", strings.NewReader("the-json-body"))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("creates the user", func() {
+			Expect(requestValidator.DecodeAndValidateJSONPayloadCallCount()).To(Equal(1))
+			actualReq, _ := requestValidator.DecodeAndValidateJSONPayloadArgsForCall(0)
+			Expect(bodyString(actualReq)).To(Equal("the-json-body"))
+
+			Expect(userRepo.CreateUserCallCount()).To(Equal(1))
+			_, actualAuthInfo, createMessage := userRepo.CreateUserArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(createMessage).To(Equal(repositories.CreateUserMessage{
+				Username:         "oidc:alice",
+				Origin:           "oidc",
+				PresentationName: "Alice",
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusCreated))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.guid", "oidc:alice"),
+				MatchJSONPath("$.username", "oidc:alice"),
+				MatchJSONPath("$.presentation_name", "Alice"),
+				MatchJSONPath("$.origin", "oidc"),
+				MatchJSONPath("$.links.self.href", "https://api.example.org/v3/users/oidc:alice"),
+			)))
+		})
+
+		When("decoding the payload fails", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateJSONPayloadReturns(apierrors.NewUnprocessableEntityError(nil, "oops"))
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError("oops")
+			})
+		})
+
+		When("creating the user fails", func() {
+			BeforeEach(func() {
+				userRepo.CreateUserReturns(repositories.UserRecord{}, errors.New("create-err"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("GET /v3/users/:guid", func() {
+		BeforeEach(func() {
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "GET", "/v3/users/oidc:alice", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns the user", func() {
+			Expect(userRepo.GetUserCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualGUID := userRepo.GetUserArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualGUID).To(Equal("oidc:alice"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.guid", "oidc:alice"),
+				MatchJSONPath("$.username", "oidc:alice"),
+			)))
+		})
+
+		When("the user is forbidden", func() {
+			BeforeEach(func() {
+				userRepo.GetUserReturns(repositories.UserRecord{}, apierrors.NewForbiddenError(nil, repositories.UserResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.UserResourceType)
+			})
+		})
+
+		When("getting the user fails", func() {
+			BeforeEach(func() {
+				userRepo.GetUserReturns(repositories.UserRecord{}, errors.New("get-err"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
 	Describe("GET /v3/users", func() {
-		When("no parameters are provided", func() {
-			It("returns an empty list", func() {
-				Expect(rr).To(HaveHTTPStatus(http.StatusOK))
-				Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
-				Expect(rr).To(HaveHTTPBody(SatisfyAll(
-					MatchJSONPath("$.pagination.total_results", BeZero()),
-					MatchJSONPath("$.pagination.first.href", "https://api.example.org/v3/users"),
-				)))
+		BeforeEach(func() {
+			requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(&payloads.UserList{
+				Usernames: "oidc:alice,oidc:bob",
+				Origins:   "oidc",
+			})
+
+			userRepo.ListUsersReturns([]repositories.UserRecord{
+				{GUID: "oidc:alice", Username: "oidc:alice"},
+				{GUID: "oidc:bob", Username: "oidc:bob"},
+			}, nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "GET", "/v3/users?usernames=oidc:alice,oidc:bob&origins=oidc", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("lists the users", func() {
+			Expect(userRepo.ListUsersCallCount()).To(Equal(1))
+			_, actualAuthInfo, listMessage := userRepo.ListUsersArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(listMessage.Usernames).To(ConsistOf("oidc:alice", "oidc:bob"))
+			Expect(listMessage.Origins).To(ConsistOf("oidc"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.pagination.total_results", BeEquivalentTo(2)),
+				MatchJSONPath("$.pagination.first.href", "https://api.example.org/v3/users?usernames=oidc:alice,oidc:bob&origins=oidc"),
+				MatchJSONPath("$.resources[0].username", "oidc:alice"),
+				MatchJSONPath("$.resources[1].username", "oidc:bob"),
+			)))
+		})
+
+		When("decoding the query parameters fails", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateURLValuesReturns(errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+
+		When("listing fails", func() {
+			BeforeEach(func() {
+				userRepo.ListUsersReturns(nil, errors.New("list-err"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("DELETE /v3/users/:guid", func() {
+		BeforeEach(func() {
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "DELETE", "/v3/users/oidc:alice", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("deletes the user", func() {
+			Expect(userRepo.DeleteUserCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualGUID := userRepo.DeleteUserArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualGUID).To(Equal("oidc:alice"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusNoContent))
+		})
+
+		When("the user is not visible", func() {
+			BeforeEach(func() {
+				userRepo.GetUserReturns(repositories.UserRecord{}, apierrors.NewForbiddenError(nil, repositories.UserResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.UserResourceType)
+				Expect(userRepo.DeleteUserCallCount()).To(BeZero())
 			})
 		})
 
-		When("usernames are passed", func() {
+		When("deleting the user fails", func() {
 			BeforeEach(func() {
-				query = "?usernames=foo,bar"
+				userRepo.DeleteUserReturns(errors.New("delete-err"))
 			})
 
-			It("returns a list of users matching the usernames", func() {
-				Expect(rr).To(HaveHTTPStatus(http.StatusOK))
-				Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
-				Expect(rr).To(HaveHTTPBody(SatisfyAll(
-					MatchJSONPath("$.pagination.total_results", BeEquivalentTo(2)),
-					MatchJSONPath("$.pagination.first.href", "https://api.example.org/v3/users?usernames=foo,bar"),
-					MatchJSONPath("$.resources[0].username", "foo"),
-					MatchJSONPath("$.resources[1].username", "bar"),
-				)))
+			It("returns an error", func() {
+				expectUnknownError()
 			})
 		})
 	})
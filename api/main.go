@@ -18,6 +18,8 @@ import (
 	"code.cloudfoundry.org/korifi/api/handlers"
 	"code.cloudfoundry.org/korifi/api/handlers/stats"
 	"code.cloudfoundry.org/korifi/api/middleware"
+	"code.cloudfoundry.org/korifi/api/oauth"
+	"code.cloudfoundry.org/korifi/api/oauth/keys"
 	"code.cloudfoundry.org/korifi/api/payloads"
 	"code.cloudfoundry.org/korifi/api/payloads/validation"
 	"code.cloudfoundry.org/korifi/api/repositories"
@@ -25,6 +27,7 @@ import (
 	"code.cloudfoundry.org/korifi/api/repositories/relationships"
 	"code.cloudfoundry.org/korifi/api/routing"
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/controllers/services/osbapi"
 	"code.cloudfoundry.org/korifi/tools"
 	"code.cloudfoundry.org/korifi/tools/image"
 	"code.cloudfoundry.org/korifi/tools/k8s"
@@ -32,7 +35,9 @@ import (
 	"code.cloudfoundry.org/korifi/version"
 
 	chiMiddlewares "github.com/go-chi/chi/middleware"
+	"github.com/go-logr/logr"
 	buildv1alpha2 "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/util/cache"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/client-go/dynamic"
@@ -135,10 +140,12 @@ func main() {
 		userClientFactoryUnfiltered,
 		nsPermissions,
 		conditions.NewConditionAwaiter[*korifiv1alpha1.CFOrg, korifiv1alpha1.CFOrg, korifiv1alpha1.CFOrgList](conditionTimeout),
+		cfg.RoleMappings,
 	)
 	spaceRepo := repositories.NewSpaceRepo(
 		namespaceRetriever,
 		orgRepo,
+		privilegedClient,
 		userClientFactoryUnfiltered,
 		nsPermissions,
 		conditions.NewConditionAwaiter[*korifiv1alpha1.CFSpace, korifiv1alpha1.CFSpace, korifiv1alpha1.CFSpaceList](conditionTimeout),
@@ -155,6 +162,7 @@ func main() {
 		userClientFactory,
 		conditions.NewConditionAwaiter[*korifiv1alpha1.CFApp, korifiv1alpha1.CFApp, korifiv1alpha1.CFAppList](conditionTimeout),
 		repositories.NewAppSorter(),
+		cfg.RootNamespace,
 	)
 	dropletRepo := repositories.NewDropletRepo(
 		userClientFactory,
@@ -188,25 +196,40 @@ func main() {
 		cfg.RunnerName,
 		cfg.RootNamespace,
 	)
+	imageClient := image.NewClient(privilegedClientset)
 	packageRepo := repositories.NewPackageRepo(
 		userClientFactory,
 		namespaceRetriever,
-		toolsregistry.NewRepositoryCreator(cfg.ContainerRegistryType),
+		toolsregistry.NewRepositoryManager(cfg.ContainerRegistryType, imageClient, image.Creds{
+			Namespace:   cfg.RootNamespace,
+			SecretNames: cfg.PackageRegistrySecretNames,
+		}),
 		cfg.ContainerRepositoryPrefix,
 		conditions.NewConditionAwaiter[*korifiv1alpha1.CFPackage, korifiv1alpha1.CFPackage, korifiv1alpha1.CFPackageList](conditionTimeout),
 		repositories.NewPackageSorter(),
 	)
+	brokerAssets := osbapi.NewAssets(privilegedClient, cfg.RootNamespace)
+	brokerClientFactory := repositories.NewUserBrokerClientFactory(
+		cfg.RootNamespace,
+		osbapi.NewClientFactory(privilegedClient, cfg.Experimental.ManagedServices.TrustInsecureBrokers),
+		userClientFactory,
+		cfg.Experimental.ManagedServices.TrustInsecureBrokers,
+	)
 	serviceInstanceRepo := repositories.NewServiceInstanceRepo(
 		namespaceRetriever,
 		userClientFactory,
 		conditions.NewConditionAwaiter[*korifiv1alpha1.CFServiceInstance, korifiv1alpha1.CFServiceInstance, korifiv1alpha1.CFServiceInstanceList](conditionTimeout),
 		repositories.NewServiceInstanceSorter(),
+		brokerAssets,
+		brokerClientFactory,
 		cfg.RootNamespace,
 	)
 	serviceBindingRepo := repositories.NewServiceBindingRepo(
 		namespaceRetriever,
 		userClientFactory,
 		conditions.NewConditionAwaiter[*korifiv1alpha1.CFServiceBinding, korifiv1alpha1.CFServiceBinding, korifiv1alpha1.CFServiceBindingList](conditionTimeout),
+		brokerAssets,
+		brokerClientFactory,
 	)
 	stackRepo := repositories.NewStackRepository(cfg.BuilderName,
 		userClientFactoryUnfiltered,
@@ -217,6 +240,11 @@ func main() {
 		cfg.RootNamespace,
 		repositories.NewBuildpackSorter(),
 	)
+	oidcUsernamePrefix := korifiv1alpha1.UserOriginOIDC + ":"
+	if cfg.Experimental.OIDC.Enabled {
+		oidcUsernamePrefix = cfg.Experimental.OIDC.GetUsernamePrefix()
+	}
+	userIdentities := repositories.NewUserIdentities(oidcUsernamePrefix)
 	roleRepo := repositories.NewRoleRepo(
 		userClientFactory,
 		spaceRepo,
@@ -226,8 +254,9 @@ func main() {
 		cfg.RoleMappings,
 		namespaceRetriever,
 		repositories.NewRoleSorter(),
+		userIdentities,
 	)
-	imageClient := image.NewClient(privilegedClientset)
+	userRepo := repositories.NewUserRepo(userClientFactory, roleRepo, cfg.RootNamespace, userIdentities)
 	imageRepo := repositories.NewImageRepository(
 		userClientFactoryUnfiltered,
 		imageClient,
@@ -239,10 +268,16 @@ func main() {
 		namespaceRetriever,
 		conditions.NewConditionAwaiter[*korifiv1alpha1.CFTask, korifiv1alpha1.CFTask, korifiv1alpha1.CFTaskList](conditionTimeout),
 	)
+	taskScheduleRepo := repositories.NewTaskScheduleRepo(userClientFactory, namespaceRetriever)
 	metricsRepo := repositories.NewMetricsRepo(userClientFactoryUnfiltered)
-	serviceBrokerRepo := repositories.NewServiceBrokerRepo(userClientFactory, cfg.RootNamespace)
-	serviceOfferingRepo := repositories.NewServiceOfferingRepo(userClientFactory, cfg.RootNamespace, serviceBrokerRepo, nsPermissions)
-	servicePlanRepo := repositories.NewServicePlanRepo(userClientFactory, cfg.RootNamespace, orgRepo)
+	serviceBrokerRepo := repositories.NewServiceBrokerRepo(userClientFactory, namespaceRetriever, cfg.RootNamespace)
+	serviceOfferingRepo := repositories.NewServiceOfferingRepo(userClientFactory, namespaceRetriever, cfg.RootNamespace, serviceBrokerRepo, nsPermissions)
+	servicePlanRepo := repositories.NewServicePlanRepo(userClientFactory, namespaceRetriever, cfg.RootNamespace, orgRepo)
+	isolationSegmentRepo := repositories.NewIsolationSegmentRepo(userClientFactory, nsPermissions, cfg.RootNamespace)
+	featureFlagRepo := repositories.NewFeatureFlagRepo(userClientFactory, cfg.RootNamespace)
+	envVarGroupRepo := repositories.NewEnvVarGroupRepo(userClientFactory, cfg.RootNamespace)
+	appUsageEventRepo := repositories.NewAppUsageEventRepo(userClientFactory, cfg.RootNamespace)
+	serviceUsageEventRepo := repositories.NewServiceUsageEventRepo(userClientFactory, cfg.RootNamespace)
 
 	processStats := actions.NewProcessStats(processRepo, appRepo, metricsRepo)
 	manifest := actions.NewManifest(
@@ -250,7 +285,7 @@ func main() {
 		cfg.DefaultDomainName,
 		manifest.NewStateCollector(appRepo, domainRepo, processRepo, routeRepo, serviceInstanceRepo, serviceBindingRepo),
 		manifest.NewNormalizer(cfg.DefaultDomainName),
-		manifest.NewApplier(appRepo, domainRepo, processRepo, routeRepo, serviceInstanceRepo, serviceBindingRepo),
+		manifest.NewApplier(appRepo, domainRepo, processRepo, routeRepo, serviceInstanceRepo, serviceBindingRepo, featureFlagRepo),
 	)
 
 	requestValidator := validation.NewDefaultDecoderValidator()
@@ -267,7 +302,29 @@ func main() {
 		routerBuilder.UseMiddleware(middleware.DisableManagedServices)
 	}
 
-	authInfoParser := authorization.NewInfoParser()
+	uaaConfig := cfg.Experimental.UAA
+	var tokenIssuer handlers.TokenIssuer
+	var ssoProvider handlers.SSOProvider
+	tokenVerifiers := []authorization.TokenVerifier{}
+	if cfg.Experimental.OIDC.Enabled {
+		tokenVerifiers = append(tokenVerifiers, authorization.NewOIDCVerifier(
+			cfg.Experimental.OIDC.IssuerURL,
+			cfg.Experimental.OIDC.ClientID,
+			oidcClaimsMapping(cfg.Experimental.OIDC),
+			&http.Client{Timeout: 30 * time.Second},
+		))
+	}
+	if cfg.Experimental.OAuth.Enabled {
+		tokenService, oidcBackend := wireTokenService(privilegedClient, cfg)
+		tokenIssuer = tokenService
+		tokenVerifiers = append(tokenVerifiers, tokenService)
+		if oidcBackend != nil {
+			ssoProvider = oidcBackend
+		}
+		uaaConfig = config.UAA{Enabled: true, URL: cfg.ServerURL}
+	}
+
+	authInfoParser := authorization.NewInfoParser(tokenVerifiers...)
 	routerBuilder.UseAuthMiddleware(
 		middleware.Authentication(
 			authInfoParser,
@@ -315,7 +372,7 @@ func main() {
 
 	apiHandlers := []routing.Routable{
 		handlers.NewRootV3(*serverURL),
-		handlers.NewRoot(*serverURL, cfg.Experimental.UAA, *logCacheURL),
+		handlers.NewRoot(*serverURL, uaaConfig, *logCacheURL),
 		handlers.NewInfoV3(
 			*serverURL,
 			cfg.InfoConfig,
@@ -334,6 +391,7 @@ func main() {
 			podRepo,
 			gaugesCollector,
 			instancesStateCollector,
+			featureFlagRepo,
 		),
 		handlers.NewRoute(
 			*serverURL,
@@ -374,6 +432,7 @@ func main() {
 			podRepo,
 			gaugesCollector,
 			instancesStateCollector,
+			featureFlagRepo,
 		),
 		handlers.NewDomain(
 			*serverURL,
@@ -404,11 +463,13 @@ func main() {
 				handlers.ManagedServiceInstanceDeleteJobType: serviceInstanceRepo,
 				handlers.ManagedServiceBindingDeleteJobType:  serviceBindingRepo,
 			},
-			map[string]handlers.StateRepository{
+			map[string]handlers.OperationRepository{
 				handlers.ServiceBrokerCreateJobType:          serviceBrokerRepo,
 				handlers.ServiceBrokerUpdateJobType:          serviceBrokerRepo,
 				handlers.ManagedServiceInstanceCreateJobType: serviceInstanceRepo,
+				handlers.ManagedServiceInstanceUpdateJobType: serviceInstanceRepo,
 				handlers.ManagedServiceBindingCreateJobType:  serviceBindingRepo,
+				handlers.ManagedServiceBindingRotateJobType:  serviceBindingRepo,
 			},
 			500*time.Millisecond,
 		),
@@ -416,6 +477,8 @@ func main() {
 			*serverURL,
 			orgRepo,
 			domainRepo,
+			featureFlagRepo,
+			cachingIdentityProvider,
 			requestValidator,
 			cfg.GetUserCertificateDuration(),
 			cfg.DefaultDomainName,
@@ -431,13 +494,44 @@ func main() {
 			spaceRepo,
 			requestValidator,
 		),
+		handlers.NewFeatureFlag(
+			*serverURL,
+			featureFlagRepo,
+			requestValidator,
+		),
+		handlers.NewEnvVarGroup(
+			*serverURL,
+			envVarGroupRepo,
+			requestValidator,
+		),
+		handlers.NewAppUsageEvent(
+			*serverURL,
+			requestValidator,
+			appUsageEventRepo,
+		),
+		handlers.NewServiceUsageEvent(
+			*serverURL,
+			requestValidator,
+			serviceUsageEventRepo,
+		),
+		handlers.NewIsolationSegment(
+			*serverURL,
+			requestValidator,
+			isolationSegmentRepo,
+			orgRepo,
+			spaceRepo,
+		),
 		handlers.NewRole(
 			*serverURL,
 			roleRepo,
 			requestValidator,
 		),
 		handlers.NewWhoAmI(cachingIdentityProvider, *serverURL),
-		handlers.NewUser(*serverURL),
+		handlers.NewUser(
+			*serverURL,
+			requestValidator,
+			userRepo,
+		),
 		handlers.NewBuildpack(
 			*serverURL,
 			buildpackRepo,
@@ -461,14 +555,26 @@ func main() {
 			*serverURL,
 			appRepo,
 			taskRepo,
+			dropletRepo,
+			processRepo,
+			featureFlagRepo,
+			requestValidator,
+		),
+		handlers.NewTaskSchedule(
+			*serverURL,
+			appRepo,
+			taskScheduleRepo,
 			requestValidator,
 		),
 		handlers.NewOAuth(
 			*serverURL,
+			tokenIssuer,
+			ssoProvider,
 		),
 		handlers.NewServiceBroker(
 			*serverURL,
 			serviceBrokerRepo,
+			spaceRepo,
 			requestValidator,
 		),
 		handlers.NewServiceOffering(
@@ -560,3 +666,75 @@ func wireIdentityProvider(client client.Client, restConfig *rest.Config) authori
 	certInspector := authorization.NewCertInspector(restConfig)
 	return authorization.NewCertTokenIdentityProvider(tokenReviewer, certInspector)
 }
+
+func wireTokenService(k8sClient client.Client, cfg *config.APIConfig) (*oauth.TokenService, *oauth.OIDCBackend) {
+	ctx := logr.NewContext(context.Background(), ctrl.Log)
+	oauthConfig := cfg.Experimental.OAuth
+
+	keyRing := keys.NewKeyRing(
+		k8sClient,
+		cfg.RootNamespace,
+		oauthConfig.GetKeysSecretName(),
+		oauthConfig.GetKeyRotationInterval(),
+		oauthConfig.GetRefreshTokenValidity(),
+		time.Minute,
+	)
+	if err := keyRing.Refresh(ctx); err != nil {
+		panic(fmt.Sprintf("could not load token signing keys: %v", err))
+	}
+	go keyRing.Run(ctx)
+
+	var identityBackend oauth.IdentityBackend = oauth.NewTokenReviewBackend(authorization.NewTokenReviewer(k8sClient))
+	var oidcBackend *oauth.OIDCBackend
+	if oauthConfig.IdentityBackend == config.OAuthIdentityBackendOIDC {
+		oidcConfig := cfg.Experimental.OIDC
+		oidcBackend = oauth.NewOIDCBackend(oauth.OIDCBackendConfig{
+			IssuerURL:     oidcConfig.IssuerURL,
+			ClientID:      oidcConfig.ClientID,
+			ClientSecret:  readClientSecret(ctx, k8sClient, cfg.RootNamespace, oidcConfig.ClientSecretName),
+			Scopes:        oidcConfig.GetScopes(),
+			ClaimsMapping: oidcClaimsMapping(oidcConfig),
+		}, &http.Client{Timeout: 30 * time.Second})
+		identityBackend = oidcBackend
+	}
+
+	// the CF CLI is a public client
+	clients := []oauth.Client{{ID: "cf"}}
+	for _, c := range oauthConfig.Clients {
+		clients = append(clients, oauth.Client{
+			ID:     c.ID,
+			Secret: readClientSecret(ctx, k8sClient, cfg.RootNamespace, c.SecretName),
+		})
+	}
+
+	return oauth.NewTokenService(
+		cfg.ServerURL+handlers.OAuthTokenPath,
+		keyRing,
+		identityBackend,
+		clients,
+		oauthConfig.GetAccessTokenValidity(),
+		oauthConfig.GetRefreshTokenValidity(),
+	), oidcBackend
+}
+
+func oidcClaimsMapping(oidcConfig config.OIDC) authorization.OIDCClaimsMapping {
+	return authorization.OIDCClaimsMapping{
+		UsernameClaim:  oidcConfig.UsernameClaim,
+		UsernamePrefix: oidcConfig.GetUsernamePrefix(),
+		GroupsClaim:    oidcConfig.GroupsClaim,
+		GroupsPrefix:   oidcConfig.GetGroupsPrefix(),
+	}
+}
+
+func readClientSecret(ctx context.Context, k8sClient client.Client, namespace, secretName string) string {
+	if secretName == "" {
+		return ""
+	}
+
+	secret := &corev1.Secret{}
+	if err := k8sClient.Get(ctx, client.ObjectKey{Namespace: namespace, Name: secretName}, secret); err != nil {
+		panic(fmt.Sprintf("could not read oauth client secret %q: %v", secretName, err))
+	}
+
+	return string(secret.Data["secret"])
+}
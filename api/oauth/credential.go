@@ -0,0 +1,74 @@
+package oauth
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"errors"
+	"fmt"
+
+	"code.cloudfoundry.org/korifi/api/oauth/keys"
+)
+
+// sealCredential encrypts identity backend credentials before they are put in
+// refresh tokens and passcodes, whose claims can be read by their holders. The
+// encryption key is derived from the signing key of the token, which is kept
+// for as long as tokens signed with it are valid.
+func sealCredential(key keys.Key, credential string) (string, error) {
+	if credential == "" {
+		return "", nil
+	}
+
+	aead, err := credentialCipher(key)
+	if err != nil {
+		return "", err
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err = rand.Read(nonce); err != nil {
+		return "", fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(aead.Seal(nonce, nonce, []byte(credential), []byte(key.ID))), nil
+}
+
+func openCredential(key keys.Key, sealedCredential string) (string, error) {
+	if sealedCredential == "" {
+		return "", nil
+	}
+
+	sealed, err := base64.RawURLEncoding.DecodeString(sealedCredential)
+	if err != nil {
+		return "", errors.New("invalid credential")
+	}
+
+	aead, err := credentialCipher(key)
+	if err != nil {
+		return "", err
+	}
+
+	if len(sealed) < aead.NonceSize() {
+		return "", errors.New("invalid credential")
+	}
+
+	credential, err := aead.Open(nil, sealed[:aead.NonceSize()], sealed[aead.NonceSize():], []byte(key.ID))
+	if err != nil {
+		return "", errors.New("invalid credential")
+	}
+
+	return string(credential), nil
+}
+
+func credentialCipher(key keys.Key) (cipher.AEAD, error) {
+	encryptionKey := sha256.Sum256(append([]byte("korifi-credential:"), x509.MarshalPKCS1PrivateKey(key.PrivateKey)...))
+
+	block, err := aes.NewCipher(encryptionKey[:])
+	if err != nil {
+		return nil, fmt.Errorf("failed to create credential cipher: %w", err)
+	}
+
+	return cipher.NewGCM(block)
+}
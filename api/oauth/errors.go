@@ -0,0 +1,49 @@
+package oauth
+
+import "net/http"
+
+const (
+	InvalidRequest       = "invalid_request"
+	InvalidClient        = "invalid_client"
+	InvalidGrant         = "invalid_grant"
+	UnauthorizedClient   = "unauthorized_client"
+	UnsupportedGrantType = "unsupported_grant_type"
+)
+
+// Error is an OAuth2 error response as per RFC 6749, section 5.2
+type Error struct {
+	Code        string
+	Description string
+}
+
+func (e Error) Error() string {
+	return e.Code + ": " + e.Description
+}
+
+func (e Error) HTTPStatus() int {
+	if e.Code == InvalidClient {
+		return http.StatusUnauthorized
+	}
+
+	return http.StatusBadRequest
+}
+
+func NewInvalidRequestError(description string) Error {
+	return Error{Code: InvalidRequest, Description: description}
+}
+
+func NewInvalidClientError(description string) Error {
+	return Error{Code: InvalidClient, Description: description}
+}
+
+func NewInvalidGrantError(description string) Error {
+	return Error{Code: InvalidGrant, Description: description}
+}
+
+func NewUnauthorizedClientError(description string) Error {
+	return Error{Code: UnauthorizedClient, Description: description}
+}
+
+func NewUnsupportedGrantTypeError(grantType string) Error {
+	return Error{Code: UnsupportedGrantType, Description: "unsupported grant type: " + grantType}
+}
@@ -0,0 +1,285 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fake
+
+import (
+	"context"
+	"sync"
+
+	"code.cloudfoundry.org/korifi/api/oauth"
+)
+
+type IdentityBackend struct {
+	ExchangeCodeStub        func(context.Context, string, string) (oauth.Login, error)
+	exchangeCodeMutex       sync.RWMutex
+	exchangeCodeArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+	}
+	exchangeCodeReturns struct {
+		result1 oauth.Login
+		result2 error
+	}
+	exchangeCodeReturnsOnCall map[int]struct {
+		result1 oauth.Login
+		result2 error
+	}
+	PasswordLoginStub        func(context.Context, string, string) (oauth.Login, error)
+	passwordLoginMutex       sync.RWMutex
+	passwordLoginArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+	}
+	passwordLoginReturns struct {
+		result1 oauth.Login
+		result2 error
+	}
+	passwordLoginReturnsOnCall map[int]struct {
+		result1 oauth.Login
+		result2 error
+	}
+	RefreshStub        func(context.Context, string) (oauth.Login, error)
+	refreshMutex       sync.RWMutex
+	refreshArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	refreshReturns struct {
+		result1 oauth.Login
+		result2 error
+	}
+	refreshReturnsOnCall map[int]struct {
+		result1 oauth.Login
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *IdentityBackend) ExchangeCode(arg1 context.Context, arg2 string, arg3 string) (oauth.Login, error) {
+	fake.exchangeCodeMutex.Lock()
+	ret, specificReturn := fake.exchangeCodeReturnsOnCall[len(fake.exchangeCodeArgsForCall)]
+	fake.exchangeCodeArgsForCall = append(fake.exchangeCodeArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.ExchangeCodeStub
+	fakeReturns := fake.exchangeCodeReturns
+	fake.recordInvocation("ExchangeCode", []interface{}{arg1, arg2, arg3})
+	fake.exchangeCodeMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *IdentityBackend) ExchangeCodeCallCount() int {
+	fake.exchangeCodeMutex.RLock()
+	defer fake.exchangeCodeMutex.RUnlock()
+	return len(fake.exchangeCodeArgsForCall)
+}
+
+func (fake *IdentityBackend) ExchangeCodeCalls(stub func(context.Context, string, string) (oauth.Login, error)) {
+	fake.exchangeCodeMutex.Lock()
+	defer fake.exchangeCodeMutex.Unlock()
+	fake.ExchangeCodeStub = stub
+}
+
+func (fake *IdentityBackend) ExchangeCodeArgsForCall(i int) (context.Context, string, string) {
+	fake.exchangeCodeMutex.RLock()
+	defer fake.exchangeCodeMutex.RUnlock()
+	argsForCall := fake.exchangeCodeArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *IdentityBackend) ExchangeCodeReturns(result1 oauth.Login, result2 error) {
+	fake.exchangeCodeMutex.Lock()
+	defer fake.exchangeCodeMutex.Unlock()
+	fake.ExchangeCodeStub = nil
+	fake.exchangeCodeReturns = struct {
+		result1 oauth.Login
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *IdentityBackend) ExchangeCodeReturnsOnCall(i int, result1 oauth.Login, result2 error) {
+	fake.exchangeCodeMutex.Lock()
+	defer fake.exchangeCodeMutex.Unlock()
+	fake.ExchangeCodeStub = nil
+	if fake.exchangeCodeReturnsOnCall == nil {
+		fake.exchangeCodeReturnsOnCall = make(map[int]struct {
+			result1 oauth.Login
+			result2 error
+		})
+	}
+	fake.exchangeCodeReturnsOnCall[i] = struct {
+		result1 oauth.Login
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *IdentityBackend) PasswordLogin(arg1 context.Context, arg2 string, arg3 string) (oauth.Login, error) {
+	fake.passwordLoginMutex.Lock()
+	ret, specificReturn := fake.passwordLoginReturnsOnCall[len(fake.passwordLoginArgsForCall)]
+	fake.passwordLoginArgsForCall = append(fake.passwordLoginArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.PasswordLoginStub
+	fakeReturns := fake.passwordLoginReturns
+	fake.recordInvocation("PasswordLogin", []interface{}{arg1, arg2, arg3})
+	fake.passwordLoginMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *IdentityBackend) PasswordLoginCallCount() int {
+	fake.passwordLoginMutex.RLock()
+	defer fake.passwordLoginMutex.RUnlock()
+	return len(fake.passwordLoginArgsForCall)
+}
+
+func (fake *IdentityBackend) PasswordLoginCalls(stub func(context.Context, string, string) (oauth.Login, error)) {
+	fake.passwordLoginMutex.Lock()
+	defer fake.passwordLoginMutex.Unlock()
+	fake.PasswordLoginStub = stub
+}
+
+func (fake *IdentityBackend) PasswordLoginArgsForCall(i int) (context.Context, string, string) {
+	fake.passwordLoginMutex.RLock()
+	defer fake.passwordLoginMutex.RUnlock()
+	argsForCall := fake.passwordLoginArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *IdentityBackend) PasswordLoginReturns(result1 oauth.Login, result2 error) {
+	fake.passwordLoginMutex.Lock()
+	defer fake.passwordLoginMutex.Unlock()
+	fake.PasswordLoginStub = nil
+	fake.passwordLoginReturns = struct {
+		result1 oauth.Login
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *IdentityBackend) PasswordLoginReturnsOnCall(i int, result1 oauth.Login, result2 error) {
+	fake.passwordLoginMutex.Lock()
+	defer fake.passwordLoginMutex.Unlock()
+	fake.PasswordLoginStub = nil
+	if fake.passwordLoginReturnsOnCall == nil {
+		fake.passwordLoginReturnsOnCall = make(map[int]struct {
+			result1 oauth.Login
+			result2 error
+		})
+	}
+	fake.passwordLoginReturnsOnCall[i] = struct {
+		result1 oauth.Login
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *IdentityBackend) Refresh(arg1 context.Context, arg2 string) (oauth.Login, error) {
+	fake.refreshMutex.Lock()
+	ret, specificReturn := fake.refreshReturnsOnCall[len(fake.refreshArgsForCall)]
+	fake.refreshArgsForCall = append(fake.refreshArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.RefreshStub
+	fakeReturns := fake.refreshReturns
+	fake.recordInvocation("Refresh", []interface{}{arg1, arg2})
+	fake.refreshMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *IdentityBackend) RefreshCallCount() int {
+	fake.refreshMutex.RLock()
+	defer fake.refreshMutex.RUnlock()
+	return len(fake.refreshArgsForCall)
+}
+
+func (fake *IdentityBackend) RefreshCalls(stub func(context.Context, string) (oauth.Login, error)) {
+	fake.refreshMutex.Lock()
+	defer fake.refreshMutex.Unlock()
+	fake.RefreshStub = stub
+}
+
+func (fake *IdentityBackend) RefreshArgsForCall(i int) (context.Context, string) {
+	fake.refreshMutex.RLock()
+	defer fake.refreshMutex.RUnlock()
+	argsForCall := fake.refreshArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *IdentityBackend) RefreshReturns(result1 oauth.Login, result2 error) {
+	fake.refreshMutex.Lock()
+	defer fake.refreshMutex.Unlock()
+	fake.RefreshStub = nil
+	fake.refreshReturns = struct {
+		result1 oauth.Login
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *IdentityBackend) RefreshReturnsOnCall(i int, result1 oauth.Login, result2 error) {
+	fake.refreshMutex.Lock()
+	defer fake.refreshMutex.Unlock()
+	fake.RefreshStub = nil
+	if fake.refreshReturnsOnCall == nil {
+		fake.refreshReturnsOnCall = make(map[int]struct {
+			result1 oauth.Login
+			result2 error
+		})
+	}
+	fake.refreshReturnsOnCall[i] = struct {
+		result1 oauth.Login
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *IdentityBackend) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.exchangeCodeMutex.RLock()
+	defer fake.exchangeCodeMutex.RUnlock()
+	fake.passwordLoginMutex.RLock()
+	defer fake.passwordLoginMutex.RUnlock()
+	fake.refreshMutex.RLock()
+	defer fake.refreshMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *IdentityBackend) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ oauth.IdentityBackend = new(IdentityBackend)
@@ -0,0 +1,252 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fake
+
+import (
+	"sync"
+
+	"code.cloudfoundry.org/korifi/api/oauth"
+	"code.cloudfoundry.org/korifi/api/oauth/keys"
+)
+
+type SigningKeys struct {
+	KeyStub        func(string) (keys.Key, bool)
+	keyMutex       sync.RWMutex
+	keyArgsForCall []struct {
+		arg1 string
+	}
+	keyReturns struct {
+		result1 keys.Key
+		result2 bool
+	}
+	keyReturnsOnCall map[int]struct {
+		result1 keys.Key
+		result2 bool
+	}
+	KeysStub        func() []keys.Key
+	keysMutex       sync.RWMutex
+	keysArgsForCall []struct {
+	}
+	keysReturns struct {
+		result1 []keys.Key
+	}
+	keysReturnsOnCall map[int]struct {
+		result1 []keys.Key
+	}
+	SigningKeyStub        func() (keys.Key, error)
+	signingKeyMutex       sync.RWMutex
+	signingKeyArgsForCall []struct {
+	}
+	signingKeyReturns struct {
+		result1 keys.Key
+		result2 error
+	}
+	signingKeyReturnsOnCall map[int]struct {
+		result1 keys.Key
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *SigningKeys) Key(arg1 string) (keys.Key, bool) {
+	fake.keyMutex.Lock()
+	ret, specificReturn := fake.keyReturnsOnCall[len(fake.keyArgsForCall)]
+	fake.keyArgsForCall = append(fake.keyArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.KeyStub
+	fakeReturns := fake.keyReturns
+	fake.recordInvocation("Key", []interface{}{arg1})
+	fake.keyMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *SigningKeys) KeyCallCount() int {
+	fake.keyMutex.RLock()
+	defer fake.keyMutex.RUnlock()
+	return len(fake.keyArgsForCall)
+}
+
+func (fake *SigningKeys) KeyCalls(stub func(string) (keys.Key, bool)) {
+	fake.keyMutex.Lock()
+	defer fake.keyMutex.Unlock()
+	fake.KeyStub = stub
+}
+
+func (fake *SigningKeys) KeyArgsForCall(i int) string {
+	fake.keyMutex.RLock()
+	defer fake.keyMutex.RUnlock()
+	argsForCall := fake.keyArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *SigningKeys) KeyReturns(result1 keys.Key, result2 bool) {
+	fake.keyMutex.Lock()
+	defer fake.keyMutex.Unlock()
+	fake.KeyStub = nil
+	fake.keyReturns = struct {
+		result1 keys.Key
+		result2 bool
+	}{result1, result2}
+}
+
+func (fake *SigningKeys) KeyReturnsOnCall(i int, result1 keys.Key, result2 bool) {
+	fake.keyMutex.Lock()
+	defer fake.keyMutex.Unlock()
+	fake.KeyStub = nil
+	if fake.keyReturnsOnCall == nil {
+		fake.keyReturnsOnCall = make(map[int]struct {
+			result1 keys.Key
+			result2 bool
+		})
+	}
+	fake.keyReturnsOnCall[i] = struct {
+		result1 keys.Key
+		result2 bool
+	}{result1, result2}
+}
+
+func (fake *SigningKeys) Keys() []keys.Key {
+	fake.keysMutex.Lock()
+	ret, specificReturn := fake.keysReturnsOnCall[len(fake.keysArgsForCall)]
+	fake.keysArgsForCall = append(fake.keysArgsForCall, struct {
+	}{})
+	stub := fake.KeysStub
+	fakeReturns := fake.keysReturns
+	fake.recordInvocation("Keys", []interface{}{})
+	fake.keysMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *SigningKeys) KeysCallCount() int {
+	fake.keysMutex.RLock()
+	defer fake.keysMutex.RUnlock()
+	return len(fake.keysArgsForCall)
+}
+
+func (fake *SigningKeys) KeysCalls(stub func() []keys.Key) {
+	fake.keysMutex.Lock()
+	defer fake.keysMutex.Unlock()
+	fake.KeysStub = stub
+}
+
+func (fake *SigningKeys) KeysReturns(result1 []keys.Key) {
+	fake.keysMutex.Lock()
+	defer fake.keysMutex.Unlock()
+	fake.KeysStub = nil
+	fake.keysReturns = struct {
+		result1 []keys.Key
+	}{result1}
+}
+
+func (fake *SigningKeys) KeysReturnsOnCall(i int, result1 []keys.Key) {
+	fake.keysMutex.Lock()
+	defer fake.keysMutex.Unlock()
+	fake.KeysStub = nil
+	if fake.keysReturnsOnCall == nil {
+		fake.keysReturnsOnCall = make(map[int]struct {
+			result1 []keys.Key
+		})
+	}
+	fake.keysReturnsOnCall[i] = struct {
+		result1 []keys.Key
+	}{result1}
+}
+
+func (fake *SigningKeys) SigningKey() (keys.Key, error) {
+	fake.signingKeyMutex.Lock()
+	ret, specificReturn := fake.signingKeyReturnsOnCall[len(fake.signingKeyArgsForCall)]
+	fake.signingKeyArgsForCall = append(fake.signingKeyArgsForCall, struct {
+	}{})
+	stub := fake.SigningKeyStub
+	fakeReturns := fake.signingKeyReturns
+	fake.recordInvocation("SigningKey", []interface{}{})
+	fake.signingKeyMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *SigningKeys) SigningKeyCallCount() int {
+	fake.signingKeyMutex.RLock()
+	defer fake.signingKeyMutex.RUnlock()
+	return len(fake.signingKeyArgsForCall)
+}
+
+func (fake *SigningKeys) SigningKeyCalls(stub func() (keys.Key, error)) {
+	fake.signingKeyMutex.Lock()
+	defer fake.signingKeyMutex.Unlock()
+	fake.SigningKeyStub = stub
+}
+
+func (fake *SigningKeys) SigningKeyReturns(result1 keys.Key, result2 error) {
+	fake.signingKeyMutex.Lock()
+	defer fake.signingKeyMutex.Unlock()
+	fake.SigningKeyStub = nil
+	fake.signingKeyReturns = struct {
+		result1 keys.Key
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *SigningKeys) SigningKeyReturnsOnCall(i int, result1 keys.Key, result2 error) {
+	fake.signingKeyMutex.Lock()
+	defer fake.signingKeyMutex.Unlock()
+	fake.SigningKeyStub = nil
+	if fake.signingKeyReturnsOnCall == nil {
+		fake.signingKeyReturnsOnCall = make(map[int]struct {
+			result1 keys.Key
+			result2 error
+		})
+	}
+	fake.signingKeyReturnsOnCall[i] = struct {
+		result1 keys.Key
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *SigningKeys) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.keyMutex.RLock()
+	defer fake.keyMutex.RUnlock()
+	fake.keysMutex.RLock()
+	defer fake.keysMutex.RUnlock()
+	fake.signingKeyMutex.RLock()
+	defer fake.signingKeyMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *SigningKeys) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ oauth.SigningKeys = new(SigningKeys)
@@ -0,0 +1,257 @@
+package keys
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+//+kubebuilder:rbac:groups="",resources=secrets,verbs=get;create;update,namespace=ROOT_NAMESPACE
+
+const (
+	keyIDPrefix = "korifi-"
+	keySize     = 2048
+)
+
+type Key struct {
+	ID         string
+	PrivateKey *rsa.PrivateKey
+	CreatedAt  time.Time
+}
+
+// KeyRing keeps the RSA keys tokens are signed with in a secret, so that all
+// API replicas share them. A new key is generated every rotation interval and
+// retired keys are kept around for as long as tokens signed with them may
+// still be valid.
+type KeyRing struct {
+	k8sClient        client.Client
+	namespace        string
+	secretName       string
+	rotationInterval time.Duration
+	retention        time.Duration
+	refreshInterval  time.Duration
+
+	mu   sync.RWMutex
+	keys []Key
+}
+
+func NewKeyRing(
+	k8sClient client.Client,
+	namespace string,
+	secretName string,
+	rotationInterval time.Duration,
+	retention time.Duration,
+	refreshInterval time.Duration,
+) *KeyRing {
+	return &KeyRing{
+		k8sClient:        k8sClient,
+		namespace:        namespace,
+		secretName:       secretName,
+		rotationInterval: rotationInterval,
+		retention:        retention,
+		refreshInterval:  refreshInterval,
+	}
+}
+
+// Run refreshes the keys every refresh interval until the context is done
+func (r *KeyRing) Run(ctx context.Context) {
+	logger := logr.FromContextOrDiscard(ctx).WithName("keyring")
+
+	ticker := time.NewTicker(r.refreshInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := r.Refresh(ctx); err != nil {
+				logger.Error(err, "failed to refresh signing keys")
+			}
+		}
+	}
+}
+
+// Refresh loads the keys from the secret, rotating and pruning them as needed
+func (r *KeyRing) Refresh(ctx context.Context) error {
+	secret := &corev1.Secret{}
+	err := r.k8sClient.Get(ctx, client.ObjectKey{Namespace: r.namespace, Name: r.secretName}, secret)
+	if k8serrors.IsNotFound(err) {
+		secret, err = r.createSecret(ctx)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get keys secret: %w", err)
+	}
+
+	keys, err := parseKeys(secret.Data)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	updatedKeys := slices.DeleteFunc(slices.Clone(keys), func(key Key) bool {
+		return now.After(key.CreatedAt.Add(r.rotationInterval + r.retention))
+	})
+	if len(updatedKeys) == 0 || now.After(updatedKeys[0].CreatedAt.Add(r.rotationInterval)) {
+		var key Key
+		key, err = generateKey(now)
+		if err != nil {
+			return err
+		}
+		updatedKeys = append([]Key{key}, updatedKeys...)
+	}
+
+	if len(updatedKeys) != len(keys) || updatedKeys[0].ID != keys[0].ID {
+		secret.Data = encodeKeys(updatedKeys)
+		// conflicts mean another replica has rotated the keys in the meantime,
+		// they are picked up on the next refresh
+		err = r.k8sClient.Update(ctx, secret)
+		if err != nil {
+			return fmt.Errorf("failed to update keys secret: %w", err)
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.keys = updatedKeys
+
+	return nil
+}
+
+func (r *KeyRing) createSecret(ctx context.Context) (*corev1.Secret, error) {
+	key, err := generateKey(time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: r.namespace,
+			Name:      r.secretName,
+		},
+		Data: encodeKeys([]Key{key}),
+	}
+
+	err = r.k8sClient.Create(ctx, secret)
+	if k8serrors.IsAlreadyExists(err) {
+		err = r.k8sClient.Get(ctx, client.ObjectKeyFromObject(secret), secret)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return secret, nil
+}
+
+// SigningKey returns the newest key that all replicas are expected to know
+// about, i.e. one that has been around for at least a refresh interval
+func (r *KeyRing) SigningKey() (Key, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if len(r.keys) == 0 {
+		return Key{}, errors.New("no signing keys available")
+	}
+
+	activeBefore := time.Now().Add(-r.refreshInterval)
+	for _, key := range r.keys {
+		if key.CreatedAt.Before(activeBefore) {
+			return key, nil
+		}
+	}
+
+	return r.keys[len(r.keys)-1], nil
+}
+
+func (r *KeyRing) Key(id string) (Key, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, key := range r.keys {
+		if key.ID == id {
+			return key, true
+		}
+	}
+
+	return Key{}, false
+}
+
+// Keys returns all the keys tokens may have been signed with, newest first
+func (r *KeyRing) Keys() []Key {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return slices.Clone(r.keys)
+}
+
+func generateKey(createdAt time.Time) (Key, error) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, keySize)
+	if err != nil {
+		return Key{}, fmt.Errorf("failed to generate signing key: %w", err)
+	}
+
+	return Key{
+		ID:         keyIDPrefix + strconv.FormatInt(createdAt.Unix(), 10),
+		PrivateKey: privateKey,
+		CreatedAt:  time.Unix(createdAt.Unix(), 0),
+	}, nil
+}
+
+func encodeKeys(keys []Key) map[string][]byte {
+	data := map[string][]byte{}
+	for _, key := range keys {
+		data[key.ID] = pem.EncodeToMemory(&pem.Block{
+			Type:  "RSA PRIVATE KEY",
+			Bytes: x509.MarshalPKCS1PrivateKey(key.PrivateKey),
+		})
+	}
+
+	return data
+}
+
+func parseKeys(data map[string][]byte) ([]Key, error) {
+	keys := []Key{}
+	for id, keyPEM := range data {
+		createdAt, err := strconv.ParseInt(strings.TrimPrefix(id, keyIDPrefix), 10, 64)
+		if err != nil || !strings.HasPrefix(id, keyIDPrefix) {
+			return nil, fmt.Errorf("invalid key id %q", id)
+		}
+
+		block, _ := pem.Decode(keyPEM)
+		if block == nil {
+			return nil, fmt.Errorf("failed to decode key %q", id)
+		}
+
+		privateKey, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse key %q: %w", id, err)
+		}
+
+		keys = append(keys, Key{
+			ID:         id,
+			PrivateKey: privateKey,
+			CreatedAt:  time.Unix(createdAt, 0),
+		})
+	}
+
+	slices.SortFunc(keys, func(a, b Key) int {
+		return b.CreatedAt.Compare(a.CreatedAt)
+	})
+
+	return keys, nil
+}
@@ -0,0 +1,147 @@
+package keys_test
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/oauth/keys"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("KeyRing", func() {
+	var (
+		keyRing    *keys.KeyRing
+		refreshErr error
+	)
+
+	BeforeEach(func() {
+		keyRing = keys.NewKeyRing(k8sClient, testNamespace, "token-keys", time.Hour, 2*time.Hour, time.Minute)
+	})
+
+	JustBeforeEach(func() {
+		refreshErr = keyRing.Refresh(ctx)
+	})
+
+	getSecret := func() *corev1.Secret {
+		secret := &corev1.Secret{}
+		Expect(k8sClient.Get(ctx, client.ObjectKey{Namespace: testNamespace, Name: "token-keys"}, secret)).To(Succeed())
+		return secret
+	}
+
+	It("creates the keys secret with a single key", func() {
+		Expect(refreshErr).NotTo(HaveOccurred())
+
+		secret := getSecret()
+		Expect(secret.Data).To(HaveLen(1))
+
+		ringKeys := keyRing.Keys()
+		Expect(ringKeys).To(HaveLen(1))
+		Expect(secret.Data).To(HaveKey(ringKeys[0].ID))
+	})
+
+	It("signs with the only key", func() {
+		signingKey, err := keyRing.SigningKey()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(signingKey.ID).To(Equal(keyRing.Keys()[0].ID))
+	})
+
+	When("the secret already contains keys", func() {
+		var recentKeyID, oldKeyID, expiredKeyID string
+
+		createKey := func(age time.Duration) (string, []byte) {
+			privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+			Expect(err).NotTo(HaveOccurred())
+
+			return fmt.Sprintf("korifi-%d", time.Now().Add(-age).Unix()), pem.EncodeToMemory(&pem.Block{
+				Type:  "RSA PRIVATE KEY",
+				Bytes: x509.MarshalPKCS1PrivateKey(privateKey),
+			})
+		}
+
+		BeforeEach(func() {
+			var recentKey, oldKey, expiredKey []byte
+			recentKeyID, recentKey = createKey(10 * time.Minute)
+			oldKeyID, oldKey = createKey(90 * time.Minute)
+			expiredKeyID, expiredKey = createKey(4 * time.Hour)
+
+			Expect(k8sClient.Create(ctx, &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: testNamespace,
+					Name:      "token-keys",
+				},
+				Data: map[string][]byte{
+					recentKeyID:  recentKey,
+					oldKeyID:     oldKey,
+					expiredKeyID: expiredKey,
+				},
+			})).To(Succeed())
+		})
+
+		It("drops expired keys and keeps the rest", func() {
+			Expect(refreshErr).NotTo(HaveOccurred())
+
+			Expect(getSecret().Data).To(SatisfyAll(
+				HaveLen(2),
+				HaveKey(recentKeyID),
+				HaveKey(oldKeyID),
+			))
+
+			Expect(keyRing.Keys()).To(HaveLen(2))
+			Expect(keyRing.Keys()[0].ID).To(Equal(recentKeyID))
+
+			_, ok := keyRing.Key(expiredKeyID)
+			Expect(ok).To(BeFalse())
+		})
+
+		It("signs with the newest key", func() {
+			signingKey, err := keyRing.SigningKey()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(signingKey.ID).To(Equal(recentKeyID))
+		})
+
+		When("the newest key is older than the rotation interval", func() {
+			BeforeEach(func() {
+				secret := getSecret()
+				delete(secret.Data, recentKeyID)
+				Expect(k8sClient.Update(ctx, secret)).To(Succeed())
+			})
+
+			It("generates a new key", func() {
+				Expect(refreshErr).NotTo(HaveOccurred())
+
+				ringKeys := keyRing.Keys()
+				Expect(ringKeys).To(HaveLen(2))
+				Expect(ringKeys[0].ID).NotTo(Equal(oldKeyID))
+				Expect(ringKeys[1].ID).To(Equal(oldKeyID))
+				Expect(getSecret().Data).To(HaveKey(ringKeys[0].ID))
+			})
+
+			It("keeps signing with the previous key until the new one has propagated", func() {
+				signingKey, err := keyRing.SigningKey()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(signingKey.ID).To(Equal(oldKeyID))
+			})
+		})
+
+		When("the secret contains an invalid key", func() {
+			BeforeEach(func() {
+				secret := getSecret()
+				secret.Data["not-a-key"] = []byte("foo")
+				Expect(k8sClient.Update(ctx, secret)).To(Succeed())
+			})
+
+			It("returns an error", func() {
+				Expect(refreshErr).To(MatchError(ContainSubstring("invalid key id")))
+			})
+		})
+	})
+})
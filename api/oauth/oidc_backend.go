@@ -0,0 +1,210 @@
+package oauth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+)
+
+type OIDCBackendConfig struct {
+	IssuerURL     string
+	ClientID      string
+	ClientSecret  string
+	Scopes        []string
+	ClaimsMapping authorization.OIDCClaimsMapping
+}
+
+type oidcEndpoints struct {
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	UserinfoEndpoint      string `json:"userinfo_endpoint"`
+}
+
+// OIDCBackend delegates credential verification to an upstream OIDC provider.
+// Identities are derived from the userinfo claims the same way the Kubernetes
+// OIDC authenticator does, so that role bindings apply to both. The upstream
+// refresh token is used to fetch the userinfo again when tokens are refreshed.
+type OIDCBackend struct {
+	config     OIDCBackendConfig
+	httpClient *http.Client
+
+	mu        sync.Mutex
+	endpoints *oidcEndpoints
+}
+
+func NewOIDCBackend(config OIDCBackendConfig, httpClient *http.Client) *OIDCBackend {
+	return &OIDCBackend{
+		config:     config,
+		httpClient: httpClient,
+	}
+}
+
+func (b *OIDCBackend) PasswordLogin(ctx context.Context, username, password string) (Login, error) {
+	return b.login(ctx, url.Values{
+		"grant_type": {PasswordGrant},
+		"username":   {username},
+		"password":   {password},
+		"scope":      {strings.Join(b.config.Scopes, " ")},
+	})
+}
+
+// AuthorizationURL returns the upstream URL users authenticate at in order to
+// obtain a code for the given redirect URI
+func (b *OIDCBackend) AuthorizationURL(ctx context.Context, redirectURI, state string) (string, error) {
+	endpoints, err := b.discover(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	authorizationURL, err := url.Parse(endpoints.AuthorizationEndpoint)
+	if err != nil {
+		return "", fmt.Errorf("invalid authorization endpoint: %w", err)
+	}
+
+	query := authorizationURL.Query()
+	query.Set("response_type", "code")
+	query.Set("client_id", b.config.ClientID)
+	query.Set("redirect_uri", redirectURI)
+	query.Set("scope", strings.Join(b.config.Scopes, " "))
+	query.Set("state", state)
+	authorizationURL.RawQuery = query.Encode()
+
+	return authorizationURL.String(), nil
+}
+
+func (b *OIDCBackend) ExchangeCode(ctx context.Context, code, redirectURI string) (Login, error) {
+	return b.login(ctx, url.Values{
+		"grant_type":   {AuthorizationCodeGrant},
+		"code":         {code},
+		"redirect_uri": {redirectURI},
+	})
+}
+
+// Refresh fails if the upstream provider has not issued a refresh token, e.g.
+// because the `offline_access` scope has not been requested. Users then have
+// to log in again once their access token expires.
+func (b *OIDCBackend) Refresh(ctx context.Context, credential string) (Login, error) {
+	if credential == "" {
+		return Login{}, NewInvalidGrantError("login cannot be refreshed")
+	}
+
+	login, err := b.login(ctx, url.Values{
+		"grant_type":    {RefreshTokenGrant},
+		"refresh_token": {credential},
+	})
+	if err != nil {
+		return Login{}, err
+	}
+
+	// providers are not required to rotate refresh tokens
+	if login.Credential == "" {
+		login.Credential = credential
+	}
+
+	return login, nil
+}
+
+func (b *OIDCBackend) login(ctx context.Context, form url.Values) (Login, error) {
+	endpoints, err := b.discover(ctx)
+	if err != nil {
+		return Login{}, err
+	}
+
+	tokens, err := b.requestToken(ctx, endpoints.TokenEndpoint, form)
+	if err != nil {
+		return Login{}, err
+	}
+
+	userinfo := map[string]any{}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoints.UserinfoEndpoint, nil)
+	if err != nil {
+		return Login{}, err
+	}
+	req.Header.Set("Authorization", "Bearer "+tokens.AccessToken)
+	if err = b.do(req, &userinfo); err != nil {
+		return Login{}, fmt.Errorf("failed to get userinfo: %w", err)
+	}
+
+	identity, err := b.config.ClaimsMapping.Identity(userinfo)
+	if err != nil {
+		return Login{}, NewInvalidGrantError("userinfo " + err.Error())
+	}
+
+	return Login{Identity: identity, Credential: tokens.RefreshToken}, nil
+}
+
+type upstreamTokens struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+}
+
+func (b *OIDCBackend) requestToken(ctx context.Context, tokenEndpoint string, form url.Values) (upstreamTokens, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, tokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return upstreamTokens{}, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.SetBasicAuth(url.QueryEscape(b.config.ClientID), url.QueryEscape(b.config.ClientSecret))
+
+	resp, err := b.httpClient.Do(req)
+	if err != nil {
+		return upstreamTokens{}, fmt.Errorf("failed to request upstream token: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusBadRequest || resp.StatusCode == http.StatusUnauthorized {
+		return upstreamTokens{}, NewInvalidGrantError("bad credentials")
+	}
+	if resp.StatusCode != http.StatusOK {
+		return upstreamTokens{}, fmt.Errorf("upstream token request failed with status %d", resp.StatusCode)
+	}
+
+	tokens := upstreamTokens{}
+	if err = json.NewDecoder(resp.Body).Decode(&tokens); err != nil {
+		return upstreamTokens{}, fmt.Errorf("failed to decode upstream token response: %w", err)
+	}
+
+	return tokens, nil
+}
+
+func (b *OIDCBackend) discover(ctx context.Context) (oidcEndpoints, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.endpoints != nil {
+		return *b.endpoints, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(b.config.IssuerURL, "/")+"/.well-known/openid-configuration", nil)
+	if err != nil {
+		return oidcEndpoints{}, err
+	}
+
+	endpoints := oidcEndpoints{}
+	if err = b.do(req, &endpoints); err != nil {
+		return oidcEndpoints{}, fmt.Errorf("failed to discover OIDC provider: %w", err)
+	}
+
+	b.endpoints = &endpoints
+	return endpoints, nil
+}
+
+func (b *OIDCBackend) do(req *http.Request, result any) error {
+	resp, err := b.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+
+	return json.NewDecoder(resp.Body).Decode(result)
+}
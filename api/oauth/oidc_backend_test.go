@@ -0,0 +1,238 @@
+package oauth_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	"code.cloudfoundry.org/korifi/api/oauth"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+	rbacv1 "k8s.io/api/rbac/v1"
+)
+
+var _ = Describe("OIDCBackend", func() {
+	var (
+		server          *ghttp.Server
+		backend         *oauth.OIDCBackend
+		tokenStatusCode int
+		userinfo        map[string]any
+	)
+
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+		DeferCleanup(server.Close)
+
+		tokenStatusCode = http.StatusOK
+		userinfo = map[string]any{"sub": "1234", "email": "alice@example.com", "groups": []string{"devs"}}
+
+		server.RouteToHandler(http.MethodGet, "/.well-known/openid-configuration", ghttp.RespondWithJSONEncoded(http.StatusOK, map[string]string{
+			"authorization_endpoint": server.URL() + "/auth",
+			"token_endpoint":         server.URL() + "/token",
+			"userinfo_endpoint":      server.URL() + "/userinfo",
+		}))
+		server.RouteToHandler(http.MethodPost, "/token", ghttp.CombineHandlers(
+			ghttp.VerifyBasicAuth("korifi", "korifi-secret"),
+			ghttp.RespondWithJSONEncodedPtr(&tokenStatusCode, map[string]string{"access_token": "upstream-token"}),
+		))
+		server.RouteToHandler(http.MethodGet, "/userinfo", ghttp.CombineHandlers(
+			ghttp.VerifyHeaderKV("Authorization", "Bearer upstream-token"),
+			func(w http.ResponseWriter, _ *http.Request) {
+				Expect(json.NewEncoder(w).Encode(userinfo)).To(Succeed())
+			},
+		))
+
+		backend = oauth.NewOIDCBackend(oauth.OIDCBackendConfig{
+			IssuerURL:    server.URL(),
+			ClientID:     "korifi",
+			ClientSecret: "korifi-secret",
+			Scopes:       []string{"openid", "groups"},
+			ClaimsMapping: authorization.OIDCClaimsMapping{
+				UsernameClaim:  "email",
+				UsernamePrefix: "oidc:",
+				GroupsClaim:    "groups",
+				GroupsPrefix:   "oidc:",
+			},
+		}, http.DefaultClient)
+	})
+
+	Describe("PasswordLogin", func() {
+		var (
+			login    oauth.Login
+			loginErr error
+		)
+
+		BeforeEach(func() {
+			server.RouteToHandler(http.MethodPost, "/token", ghttp.CombineHandlers(
+				ghttp.VerifyBasicAuth("korifi", "korifi-secret"),
+				ghttp.VerifyForm(map[string][]string{
+					"grant_type": {"password"},
+					"username":   {"alice"},
+					"password":   {"secret"},
+					"scope":      {"openid groups"},
+				}),
+				ghttp.RespondWithJSONEncodedPtr(&tokenStatusCode, map[string]string{"access_token": "upstream-token", "refresh_token": "upstream-refresh-token"}),
+			))
+		})
+
+		JustBeforeEach(func() {
+			login, loginErr = backend.PasswordLogin(context.Background(), "alice", "secret")
+		})
+
+		It("maps the userinfo claims onto the identity", func() {
+			Expect(loginErr).NotTo(HaveOccurred())
+			Expect(login.Identity).To(Equal(authorization.Identity{
+				Name:   "oidc:alice@example.com",
+				Kind:   rbacv1.UserKind,
+				Groups: []string{"oidc:devs"},
+			}))
+		})
+
+		It("returns the upstream refresh token as credential", func() {
+			Expect(loginErr).NotTo(HaveOccurred())
+			Expect(login.Credential).To(Equal("upstream-refresh-token"))
+		})
+
+		When("the upstream provider rejects the credentials", func() {
+			BeforeEach(func() {
+				tokenStatusCode = http.StatusUnauthorized
+			})
+
+			It("returns an invalid grant error", func() {
+				Expect(loginErr).To(MatchError(oauth.NewInvalidGrantError("bad credentials")))
+			})
+		})
+
+		When("the upstream provider fails", func() {
+			BeforeEach(func() {
+				tokenStatusCode = http.StatusInternalServerError
+			})
+
+			It("returns an error", func() {
+				Expect(loginErr).To(MatchError(ContainSubstring("status 500")))
+			})
+		})
+
+		When("the userinfo does not contain the username claim", func() {
+			BeforeEach(func() {
+				delete(userinfo, "email")
+			})
+
+			It("returns an invalid grant error", func() {
+				Expect(loginErr).To(MatchError(ContainSubstring("invalid_grant")))
+			})
+		})
+	})
+
+	Describe("ExchangeCode", func() {
+		var (
+			login       oauth.Login
+			exchangeErr error
+		)
+
+		BeforeEach(func() {
+			server.RouteToHandler(http.MethodPost, "/token", ghttp.CombineHandlers(
+				ghttp.VerifyForm(map[string][]string{
+					"grant_type":   {"authorization_code"},
+					"code":         {"the-code"},
+					"redirect_uri": {"https://example.com/callback"},
+				}),
+				ghttp.RespondWithJSONEncoded(http.StatusOK, map[string]string{"access_token": "upstream-token"}),
+			))
+		})
+
+		JustBeforeEach(func() {
+			login, exchangeErr = backend.ExchangeCode(context.Background(), "the-code", "https://example.com/callback")
+		})
+
+		It("exchanges the code upstream", func() {
+			Expect(exchangeErr).NotTo(HaveOccurred())
+			Expect(login.Identity.Name).To(Equal("oidc:alice@example.com"))
+		})
+	})
+
+	Describe("Refresh", func() {
+		var (
+			credential string
+			tokens     map[string]string
+			login      oauth.Login
+			refreshErr error
+		)
+
+		BeforeEach(func() {
+			credential = "upstream-refresh-token"
+			tokens = map[string]string{"access_token": "upstream-token", "refresh_token": "rotated-refresh-token"}
+
+			server.RouteToHandler(http.MethodPost, "/token", ghttp.CombineHandlers(
+				ghttp.VerifyForm(map[string][]string{
+					"grant_type":    {"refresh_token"},
+					"refresh_token": {"upstream-refresh-token"},
+				}),
+				ghttp.RespondWithJSONEncodedPtr(&tokenStatusCode, &tokens),
+			))
+		})
+
+		JustBeforeEach(func() {
+			login, refreshErr = backend.Refresh(context.Background(), credential)
+		})
+
+		It("resolves the identity again with the refreshed upstream token", func() {
+			Expect(refreshErr).NotTo(HaveOccurred())
+			Expect(login.Identity.Name).To(Equal("oidc:alice@example.com"))
+			Expect(login.Credential).To(Equal("rotated-refresh-token"))
+		})
+
+		When("the upstream provider does not rotate the refresh token", func() {
+			BeforeEach(func() {
+				delete(tokens, "refresh_token")
+			})
+
+			It("keeps the refresh token", func() {
+				Expect(refreshErr).NotTo(HaveOccurred())
+				Expect(login.Credential).To(Equal("upstream-refresh-token"))
+			})
+		})
+
+		When("the upstream provider rejects the refresh token", func() {
+			BeforeEach(func() {
+				tokenStatusCode = http.StatusBadRequest
+			})
+
+			It("returns an invalid grant error", func() {
+				Expect(refreshErr).To(MatchError(oauth.NewInvalidGrantError("bad credentials")))
+			})
+		})
+
+		When("there is no upstream refresh token", func() {
+			BeforeEach(func() {
+				credential = ""
+			})
+
+			It("returns an invalid grant error", func() {
+				Expect(refreshErr).To(MatchError(oauth.NewInvalidGrantError("login cannot be refreshed")))
+			})
+		})
+	})
+
+	Describe("AuthorizationURL", func() {
+		It("returns the upstream authorization URL", func() {
+			authorizationURL, err := backend.AuthorizationURL(context.Background(), "https://api.example.com/passcode/callback", "the-state")
+			Expect(err).NotTo(HaveOccurred())
+
+			parsedURL, err := url.Parse(authorizationURL)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(parsedURL.Path).To(Equal("/auth"))
+			Expect(parsedURL.Query()).To(Equal(url.Values{
+				"response_type": {"code"},
+				"client_id":     {"korifi"},
+				"redirect_uri":  {"https://api.example.com/passcode/callback"},
+				"scope":         {"openid groups"},
+				"state":         {"the-state"},
+			}))
+		})
+	})
+})
@@ -0,0 +1,41 @@
+package oauth
+
+import (
+	"sync"
+	"time"
+)
+
+// passcodeStore remembers the passcodes that have been redeemed until they
+// expire, so that each passcode can only be redeemed once. Redeemed passcodes
+// are not shared between API replicas.
+type passcodeStore struct {
+	mu       sync.Mutex
+	redeemed map[string]time.Time
+}
+
+func newPasscodeStore() *passcodeStore {
+	return &passcodeStore{
+		redeemed: map[string]time.Time{},
+	}
+}
+
+// redeem returns false if the passcode with the given id has already been
+// redeemed
+func (s *passcodeStore) redeem(id string, expiresAt time.Time) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	for redeemedID, redeemedExpiresAt := range s.redeemed {
+		if now.After(redeemedExpiresAt) {
+			delete(s.redeemed, redeemedID)
+		}
+	}
+
+	if _, ok := s.redeemed[id]; ok {
+		return false
+	}
+
+	s.redeemed[id] = expiresAt
+	return true
+}
@@ -0,0 +1,52 @@
+package oauth
+
+import (
+	"context"
+	"errors"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+)
+
+// TokenReviewBackend accepts Kubernetes bearer tokens as passwords, which lets
+// users exchange their cluster credentials for API tokens. The bearer token is
+// reviewed again when the API tokens are refreshed, so refreshing stops
+// working once the bearer token expires or is revoked.
+type TokenReviewBackend struct {
+	tokenInspector authorization.TokenIdentityInspector
+}
+
+func NewTokenReviewBackend(tokenInspector authorization.TokenIdentityInspector) *TokenReviewBackend {
+	return &TokenReviewBackend{
+		tokenInspector: tokenInspector,
+	}
+}
+
+func (b *TokenReviewBackend) PasswordLogin(ctx context.Context, username, password string) (Login, error) {
+	login, err := b.Refresh(ctx, password)
+	if err != nil {
+		return Login{}, err
+	}
+
+	if login.Identity.Name != username {
+		return Login{}, NewInvalidGrantError("bad credentials")
+	}
+
+	return login, nil
+}
+
+func (b *TokenReviewBackend) ExchangeCode(ctx context.Context, code, redirectURI string) (Login, error) {
+	return Login{}, NewUnsupportedGrantTypeError(AuthorizationCodeGrant)
+}
+
+func (b *TokenReviewBackend) Refresh(ctx context.Context, credential string) (Login, error) {
+	identity, err := b.tokenInspector.WhoAmI(ctx, credential)
+	if err != nil {
+		if errors.As(err, &apierrors.InvalidAuthError{}) {
+			return Login{}, NewInvalidGrantError("bad credentials")
+		}
+		return Login{}, err
+	}
+
+	return Login{Identity: identity, Credential: credential}, nil
+}
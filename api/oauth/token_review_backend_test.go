@@ -0,0 +1,117 @@
+package oauth_test
+
+import (
+	"context"
+	"errors"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	authfake "code.cloudfoundry.org/korifi/api/authorization/fake"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/oauth"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	rbacv1 "k8s.io/api/rbac/v1"
+)
+
+var _ = Describe("TokenReviewBackend", func() {
+	var (
+		tokenInspector *authfake.TokenIdentityInspector
+		backend        *oauth.TokenReviewBackend
+	)
+
+	BeforeEach(func() {
+		tokenInspector = new(authfake.TokenIdentityInspector)
+		tokenInspector.WhoAmIReturns(authorization.Identity{Name: "alice", Kind: rbacv1.UserKind}, nil)
+
+		backend = oauth.NewTokenReviewBackend(tokenInspector)
+	})
+
+	Describe("PasswordLogin", func() {
+		var (
+			username string
+			login    oauth.Login
+			loginErr error
+		)
+
+		BeforeEach(func() {
+			username = "alice"
+		})
+
+		JustBeforeEach(func() {
+			login, loginErr = backend.PasswordLogin(context.Background(), username, "k8s-token")
+		})
+
+		It("reviews the password as a token", func() {
+			Expect(loginErr).NotTo(HaveOccurred())
+			Expect(login).To(Equal(oauth.Login{
+				Identity:   authorization.Identity{Name: "alice", Kind: rbacv1.UserKind},
+				Credential: "k8s-token",
+			}))
+
+			Expect(tokenInspector.WhoAmICallCount()).To(Equal(1))
+			_, token := tokenInspector.WhoAmIArgsForCall(0)
+			Expect(token).To(Equal("k8s-token"))
+		})
+
+		When("the token belongs to someone else", func() {
+			BeforeEach(func() {
+				username = "bob"
+			})
+
+			It("returns an invalid grant error", func() {
+				Expect(loginErr).To(MatchError(oauth.NewInvalidGrantError("bad credentials")))
+			})
+		})
+
+		When("the token is not valid", func() {
+			BeforeEach(func() {
+				tokenInspector.WhoAmIReturns(authorization.Identity{}, apierrors.NewInvalidAuthError(errors.New("nope")))
+			})
+
+			It("returns an invalid grant error", func() {
+				Expect(loginErr).To(MatchError(oauth.NewInvalidGrantError("bad credentials")))
+			})
+		})
+
+		When("the token review fails", func() {
+			BeforeEach(func() {
+				tokenInspector.WhoAmIReturns(authorization.Identity{}, errors.New("review-failed"))
+			})
+
+			It("returns the error", func() {
+				Expect(loginErr).To(MatchError("review-failed"))
+			})
+		})
+	})
+
+	Describe("Refresh", func() {
+		It("reviews the token again", func() {
+			login, err := backend.Refresh(context.Background(), "k8s-token")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(login.Identity.Name).To(Equal("alice"))
+
+			Expect(tokenInspector.WhoAmICallCount()).To(Equal(1))
+			_, token := tokenInspector.WhoAmIArgsForCall(0)
+			Expect(token).To(Equal("k8s-token"))
+		})
+
+		When("the token has expired", func() {
+			BeforeEach(func() {
+				tokenInspector.WhoAmIReturns(authorization.Identity{}, apierrors.NewInvalidAuthError(errors.New("expired")))
+			})
+
+			It("returns an invalid grant error", func() {
+				_, err := backend.Refresh(context.Background(), "k8s-token")
+				Expect(err).To(MatchError(oauth.NewInvalidGrantError("bad credentials")))
+			})
+		})
+	})
+
+	Describe("ExchangeCode", func() {
+		It("is not supported", func() {
+			_, err := backend.ExchangeCode(context.Background(), "code", "uri")
+			Expect(err).To(MatchError(oauth.NewUnsupportedGrantTypeError("authorization_code")))
+		})
+	})
+})
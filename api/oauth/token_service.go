@@ -0,0 +1,442 @@
+package oauth
+
+import (
+	"context"
+	"crypto/rsa"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	"code.cloudfoundry.org/korifi/api/oauth/keys"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/google/uuid"
+	rbacv1 "k8s.io/api/rbac/v1"
+)
+
+const (
+	PasswordGrant          = "password"
+	RefreshTokenGrant      = "refresh_token"
+	ClientCredentialsGrant = "client_credentials"
+	AuthorizationCodeGrant = "authorization_code"
+
+	// ClientUsernamePrefix is prepended to the id of clients authenticating on
+	// their own behalf, so that they cannot be mistaken for users
+	ClientUsernamePrefix = "client:"
+
+	accessTokenUse   = "access"
+	refreshTokenUse  = "refresh"
+	passcodeTokenUse = "passcode"
+	audience         = "korifi"
+
+	passcodeValidity = 5 * time.Minute
+)
+
+//counterfeiter:generate -o fake -fake-name SigningKeys . SigningKeys
+//counterfeiter:generate -o fake -fake-name IdentityBackend . IdentityBackend
+
+type SigningKeys interface {
+	SigningKey() (keys.Key, error)
+	Key(id string) (keys.Key, bool)
+	Keys() []keys.Key
+}
+
+// IdentityBackend verifies user credentials. Invalid credentials are reported
+// as InvalidGrant errors.
+type IdentityBackend interface {
+	PasswordLogin(ctx context.Context, username, password string) (Login, error)
+	ExchangeCode(ctx context.Context, code, redirectURI string) (Login, error)
+	Refresh(ctx context.Context, credential string) (Login, error)
+}
+
+// Login is the outcome of authenticating with an identity backend. The
+// credential is what the backend needs to resolve the identity again when the
+// tokens are refreshed, so that changes to the user, such as their groups,
+// are picked up. It is only stored encrypted in refresh tokens and passcodes.
+type Login struct {
+	Identity   authorization.Identity
+	Credential string
+}
+
+// Client is an OAuth client allowed to request tokens. Public clients, such
+// as the CF CLI, have no secret and cannot use the client credentials grant.
+type Client struct {
+	ID     string
+	Secret string
+}
+
+type GrantRequest struct {
+	GrantType    string
+	ClientID     string
+	ClientSecret string
+	Username     string
+	Password     string
+	Passcode     string
+	Code         string
+	RedirectURI  string
+	RefreshToken string
+}
+
+type Token struct {
+	AccessToken  string
+	RefreshToken string
+	ExpiresIn    time.Duration
+	JTI          string
+}
+
+type PublicKey struct {
+	ID  string
+	Key *rsa.PublicKey
+}
+
+type claims struct {
+	jwt.StandardClaims
+	UserName  string   `json:"user_name,omitempty"`
+	UserID    string   `json:"user_id,omitempty"`
+	Kind      string   `json:"kind"`
+	Groups    []string `json:"groups,omitempty"`
+	ClientID  string   `json:"client_id"`
+	CID       string   `json:"cid"`
+	GrantType string   `json:"grant_type"`
+	TokenUse  string   `json:"token_use"`
+	// Credential is the sealed identity backend credential
+	Credential string `json:"credential,omitempty"`
+}
+
+func (c claims) identity() authorization.Identity {
+	return authorization.Identity{
+		Name:   c.UserName,
+		Kind:   c.Kind,
+		Groups: c.Groups,
+	}
+}
+
+type TokenService struct {
+	issuer               string
+	signingKeys          SigningKeys
+	identityBackend      IdentityBackend
+	clients              map[string]Client
+	accessTokenValidity  time.Duration
+	refreshTokenValidity time.Duration
+	redeemedPasscodes    *passcodeStore
+}
+
+func NewTokenService(
+	issuer string,
+	signingKeys SigningKeys,
+	identityBackend IdentityBackend,
+	clients []Client,
+	accessTokenValidity time.Duration,
+	refreshTokenValidity time.Duration,
+) *TokenService {
+	clientsByID := map[string]Client{}
+	for _, c := range clients {
+		clientsByID[c.ID] = c
+	}
+
+	return &TokenService{
+		issuer:               issuer,
+		signingKeys:          signingKeys,
+		identityBackend:      identityBackend,
+		clients:              clientsByID,
+		accessTokenValidity:  accessTokenValidity,
+		refreshTokenValidity: refreshTokenValidity,
+		redeemedPasscodes:    newPasscodeStore(),
+	}
+}
+
+func (s *TokenService) Grant(ctx context.Context, request GrantRequest) (Token, error) {
+	client, err := s.authenticateClient(request.ClientID, request.ClientSecret)
+	if err != nil {
+		return Token{}, err
+	}
+
+	var login Login
+	switch request.GrantType {
+	case PasswordGrant:
+		if request.Passcode != "" {
+			login, err = s.redeemPasscode(client, request.Passcode)
+			break
+		}
+		if request.Username == "" || request.Password == "" {
+			return Token{}, NewInvalidRequestError("username and password are required")
+		}
+		login, err = s.identityBackend.PasswordLogin(ctx, request.Username, request.Password)
+	case AuthorizationCodeGrant:
+		if request.Code == "" {
+			return Token{}, NewInvalidRequestError("code is required")
+		}
+		login, err = s.identityBackend.ExchangeCode(ctx, request.Code, request.RedirectURI)
+	case RefreshTokenGrant:
+		login, err = s.refresh(ctx, client, request.RefreshToken)
+	case ClientCredentialsGrant:
+		if client.Secret == "" {
+			return Token{}, NewUnauthorizedClientError("public clients cannot use the client_credentials grant")
+		}
+		login = Login{Identity: authorization.Identity{Name: ClientUsernamePrefix + client.ID, Kind: rbacv1.UserKind}}
+	default:
+		return Token{}, NewUnsupportedGrantTypeError(request.GrantType)
+	}
+	if err != nil {
+		return Token{}, err
+	}
+
+	return s.issue(login, client, request.GrantType)
+}
+
+func (s *TokenService) authenticateClient(clientID, clientSecret string) (Client, error) {
+	client, ok := s.clients[clientID]
+	if !ok {
+		return Client{}, NewInvalidClientError("unknown client")
+	}
+
+	if subtle.ConstantTimeCompare([]byte(client.Secret), []byte(clientSecret)) != 1 {
+		return Client{}, NewInvalidClientError("bad client credentials")
+	}
+
+	return client, nil
+}
+
+// refresh resolves the identity the refresh token was issued for again with
+// the identity backend, so that refreshed tokens reflect the current groups
+// of the user and users that can no longer log in cannot refresh their tokens
+func (s *TokenService) refresh(ctx context.Context, client Client, refreshToken string) (Login, error) {
+	if refreshToken == "" {
+		return Login{}, NewInvalidRequestError("refresh_token is required")
+	}
+
+	tokenClaims, key, err := s.parse(refreshToken, refreshTokenUse)
+	if err != nil {
+		return Login{}, NewInvalidGrantError(err.Error())
+	}
+
+	if tokenClaims.ClientID != client.ID {
+		return Login{}, NewInvalidGrantError("refresh token was issued to another client")
+	}
+
+	credential, err := openCredential(key, tokenClaims.Credential)
+	if err != nil {
+		return Login{}, NewInvalidGrantError(err.Error())
+	}
+
+	login, err := s.identityBackend.Refresh(ctx, credential)
+	if err != nil {
+		return Login{}, err
+	}
+
+	if login.Identity.Name != tokenClaims.UserName {
+		return Login{}, NewInvalidGrantError("refresh token was issued to another user")
+	}
+
+	return login, nil
+}
+
+// IssuePasscode issues a short-lived passcode for users that have logged in
+// with a browser, which the client that started the login, such as the CF CLI
+// with `cf login --sso`, exchanges for tokens using the password grant
+func (s *TokenService) IssuePasscode(login Login, clientID string) (string, error) {
+	client, ok := s.clients[clientID]
+	if !ok {
+		return "", NewInvalidClientError("unknown client")
+	}
+
+	key, err := s.signingKeys.SigningKey()
+	if err != nil {
+		return "", err
+	}
+
+	passcodeClaims, err := s.newClaims(key, uuid.NewString(), login, client, AuthorizationCodeGrant, passcodeTokenUse, time.Now(), passcodeValidity)
+	if err != nil {
+		return "", err
+	}
+
+	return s.sign(key, passcodeClaims)
+}
+
+// redeemPasscode accepts each passcode only once, and only from the client it
+// was issued to
+func (s *TokenService) redeemPasscode(client Client, passcode string) (Login, error) {
+	tokenClaims, key, err := s.parse(passcode, passcodeTokenUse)
+	if err != nil {
+		return Login{}, NewInvalidGrantError("invalid passcode")
+	}
+
+	if tokenClaims.ClientID != client.ID {
+		return Login{}, NewInvalidGrantError("passcode was issued to another client")
+	}
+
+	credential, err := openCredential(key, tokenClaims.Credential)
+	if err != nil {
+		return Login{}, NewInvalidGrantError("invalid passcode")
+	}
+
+	if !s.redeemedPasscodes.redeem(tokenClaims.Id, time.Unix(tokenClaims.ExpiresAt, 0)) {
+		return Login{}, NewInvalidGrantError("passcode has already been used")
+	}
+
+	return Login{Identity: tokenClaims.identity(), Credential: credential}, nil
+}
+
+func (s *TokenService) issue(login Login, client Client, grantType string) (Token, error) {
+	key, err := s.signingKeys.SigningKey()
+	if err != nil {
+		return Token{}, err
+	}
+
+	now := time.Now()
+	jti := uuid.NewString()
+	accessToken, err := s.sign(key, s.newAccessTokenClaims(jti, login.Identity, client, grantType, now))
+	if err != nil {
+		return Token{}, err
+	}
+
+	token := Token{
+		AccessToken: accessToken,
+		ExpiresIn:   s.accessTokenValidity,
+		JTI:         jti,
+	}
+
+	// as with UAA, client credentials tokens are not refreshable
+	if grantType != ClientCredentialsGrant {
+		refreshTokenClaims, err := s.newClaims(key, jti+"-r", login, client, grantType, refreshTokenUse, now, s.refreshTokenValidity)
+		if err != nil {
+			return Token{}, err
+		}
+
+		token.RefreshToken, err = s.sign(key, refreshTokenClaims)
+		if err != nil {
+			return Token{}, err
+		}
+	}
+
+	return token, nil
+}
+
+// newClaims returns the claims of refresh tokens and passcodes, which carry
+// the sealed identity backend credential
+func (s *TokenService) newClaims(
+	key keys.Key,
+	jti string,
+	login Login,
+	client Client,
+	grantType string,
+	tokenUse string,
+	issuedAt time.Time,
+	validity time.Duration,
+) (claims, error) {
+	sealedCredential, err := sealCredential(key, login.Credential)
+	if err != nil {
+		return claims{}, err
+	}
+
+	tokenClaims := s.newIdentityClaims(jti, login.Identity, client, grantType, tokenUse, issuedAt, validity)
+	tokenClaims.Credential = sealedCredential
+
+	return tokenClaims, nil
+}
+
+func (s *TokenService) newAccessTokenClaims(jti string, identity authorization.Identity, client Client, grantType string, issuedAt time.Time) claims {
+	return s.newIdentityClaims(jti, identity, client, grantType, accessTokenUse, issuedAt, s.accessTokenValidity)
+}
+
+func (s *TokenService) newIdentityClaims(
+	jti string,
+	identity authorization.Identity,
+	client Client,
+	grantType string,
+	tokenUse string,
+	issuedAt time.Time,
+	validity time.Duration,
+) claims {
+	return claims{
+		StandardClaims: jwt.StandardClaims{
+			Id:        jti,
+			Subject:   identity.Name,
+			Issuer:    s.issuer,
+			Audience:  audience,
+			IssuedAt:  issuedAt.Unix(),
+			ExpiresAt: issuedAt.Add(validity).Unix(),
+		},
+		UserName:  identity.Name,
+		UserID:    identity.Name,
+		Kind:      identity.Kind,
+		Groups:    identity.Groups,
+		ClientID:  client.ID,
+		CID:       client.ID,
+		GrantType: grantType,
+		TokenUse:  tokenUse,
+	}
+}
+
+func (s *TokenService) sign(key keys.Key, tokenClaims claims) (string, error) {
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, tokenClaims)
+	token.Header["kid"] = key.ID
+
+	signed, err := token.SignedString(key.PrivateKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign token: %w", err)
+	}
+
+	return signed, nil
+}
+
+// Verify verifies access tokens issued by the service. Tokens from other
+// issuers are left for Kubernetes to verify.
+func (s *TokenService) Verify(token string) (authorization.Identity, bool, error) {
+	unverifiedClaims := claims{}
+	if _, _, err := new(jwt.Parser).ParseUnverified(token, &unverifiedClaims); err != nil || unverifiedClaims.Issuer != s.issuer {
+		return authorization.Identity{}, false, nil
+	}
+
+	tokenClaims, _, err := s.parse(token, accessTokenUse)
+	if err != nil {
+		return authorization.Identity{}, true, err
+	}
+
+	return tokenClaims.identity(), true, nil
+}
+
+// parse verifies the token and returns its claims along with the key it has
+// been signed with
+func (s *TokenService) parse(token, tokenUse string) (claims, keys.Key, error) {
+	tokenClaims := claims{}
+	var signingKey keys.Key
+	_, err := jwt.ParseWithClaims(token, &tokenClaims, func(t *jwt.Token) (any, error) {
+		if t.Method != jwt.SigningMethodRS256 {
+			return nil, fmt.Errorf("unexpected signing method %q", t.Method.Alg())
+		}
+
+		keyID, _ := t.Header["kid"].(string)
+		key, ok := s.signingKeys.Key(keyID)
+		if !ok {
+			return nil, fmt.Errorf("unknown signing key %q", keyID)
+		}
+		signingKey = key
+
+		return &key.PrivateKey.PublicKey, nil
+	})
+	if err != nil {
+		return claims{}, keys.Key{}, fmt.Errorf("invalid token: %w", err)
+	}
+
+	if !tokenClaims.VerifyIssuer(s.issuer, true) || !tokenClaims.VerifyAudience(audience, true) {
+		return claims{}, keys.Key{}, errors.New("invalid token: unexpected issuer or audience")
+	}
+
+	if tokenClaims.TokenUse != tokenUse || tokenClaims.UserName == "" {
+		return claims{}, keys.Key{}, fmt.Errorf("invalid token: expected token use %q", tokenUse)
+	}
+
+	return tokenClaims, signingKey, nil
+}
+
+func (s *TokenService) PublicKeys() []PublicKey {
+	publicKeys := []PublicKey{}
+	for _, key := range s.signingKeys.Keys() {
+		publicKeys = append(publicKeys, PublicKey{ID: key.ID, Key: &key.PrivateKey.PublicKey})
+	}
+
+	return publicKeys
+}
@@ -0,0 +1,533 @@
+package oauth_test
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"errors"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	"code.cloudfoundry.org/korifi/api/oauth"
+	"code.cloudfoundry.org/korifi/api/oauth/fake"
+	"code.cloudfoundry.org/korifi/api/oauth/keys"
+
+	"github.com/golang-jwt/jwt"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	rbacv1 "k8s.io/api/rbac/v1"
+)
+
+var _ = Describe("TokenService", func() {
+	var (
+		ctx             context.Context
+		signingKeys     *fake.SigningKeys
+		identityBackend *fake.IdentityBackend
+		signingKey      keys.Key
+		tokenService    *oauth.TokenService
+		request         oauth.GrantRequest
+		token           oauth.Token
+		grantErr        error
+	)
+
+	generateKey := func(id string) keys.Key {
+		privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+		Expect(err).NotTo(HaveOccurred())
+		return keys.Key{ID: id, PrivateKey: privateKey, CreatedAt: time.Now()}
+	}
+
+	parseClaims := func(token string) jwt.MapClaims {
+		claims := jwt.MapClaims{}
+		_, _, err := new(jwt.Parser).ParseUnverified(token, claims)
+		Expect(err).NotTo(HaveOccurred())
+		return claims
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+
+		signingKey = generateKey("key-1")
+		signingKeys = new(fake.SigningKeys)
+		signingKeys.SigningKeyReturns(signingKey, nil)
+		signingKeys.KeyStub = func(id string) (keys.Key, bool) {
+			return signingKey, id == signingKey.ID
+		}
+		signingKeys.KeysReturns([]keys.Key{signingKey})
+
+		identityBackend = new(fake.IdentityBackend)
+		identityBackend.PasswordLoginReturns(oauth.Login{
+			Identity:   authorization.Identity{Name: "alice", Kind: rbacv1.UserKind, Groups: []string{"devs"}},
+			Credential: "alice-credential",
+		}, nil)
+		identityBackend.ExchangeCodeReturns(oauth.Login{Identity: authorization.Identity{Name: "bob", Kind: rbacv1.UserKind}}, nil)
+		identityBackend.RefreshReturns(oauth.Login{
+			Identity:   authorization.Identity{Name: "alice", Kind: rbacv1.UserKind, Groups: []string{"devs", "admins"}},
+			Credential: "new-alice-credential",
+		}, nil)
+
+		tokenService = oauth.NewTokenService(
+			"https://api.example.com/oauth/token",
+			signingKeys,
+			identityBackend,
+			[]oauth.Client{{ID: "cf"}, {ID: "my-client", Secret: "my-secret"}},
+			time.Hour,
+			24*time.Hour,
+		)
+
+		request = oauth.GrantRequest{
+			GrantType: "password",
+			ClientID:  "cf",
+			Username:  "alice",
+			Password:  "password",
+		}
+	})
+
+	JustBeforeEach(func() {
+		token, grantErr = tokenService.Grant(ctx, request)
+	})
+
+	Describe("password grant", func() {
+		It("logs in with the identity backend", func() {
+			Expect(grantErr).NotTo(HaveOccurred())
+			Expect(identityBackend.PasswordLoginCallCount()).To(Equal(1))
+			_, username, password := identityBackend.PasswordLoginArgsForCall(0)
+			Expect(username).To(Equal("alice"))
+			Expect(password).To(Equal("password"))
+		})
+
+		It("issues an access token", func() {
+			Expect(grantErr).NotTo(HaveOccurred())
+			Expect(token.ExpiresIn).To(Equal(time.Hour))
+			Expect(token.JTI).NotTo(BeEmpty())
+
+			claims := parseClaims(token.AccessToken)
+			Expect(claims).To(SatisfyAll(
+				HaveKeyWithValue("iss", "https://api.example.com/oauth/token"),
+				HaveKeyWithValue("user_name", "alice"),
+				HaveKeyWithValue("client_id", "cf"),
+				HaveKeyWithValue("grant_type", "password"),
+				HaveKeyWithValue("jti", token.JTI),
+				HaveKeyWithValue("groups", ConsistOf("devs")),
+			))
+			Expect(claims["exp"]).To(BeNumerically("~", time.Now().Add(time.Hour).Unix(), 5))
+		})
+
+		It("signs the tokens with the signing key", func() {
+			Expect(grantErr).NotTo(HaveOccurred())
+
+			parsed, err := jwt.Parse(token.AccessToken, func(t *jwt.Token) (any, error) {
+				Expect(t.Header).To(HaveKeyWithValue("kid", "key-1"))
+				return &signingKey.PrivateKey.PublicKey, nil
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(parsed.Method).To(Equal(jwt.SigningMethodRS256))
+		})
+
+		It("issues a refresh token", func() {
+			Expect(grantErr).NotTo(HaveOccurred())
+			Expect(token.RefreshToken).NotTo(BeEmpty())
+			Expect(parseClaims(token.RefreshToken)["exp"]).To(BeNumerically("~", time.Now().Add(24*time.Hour).Unix(), 5))
+		})
+
+		It("does not reveal the identity backend credential", func() {
+			Expect(grantErr).NotTo(HaveOccurred())
+			Expect(parseClaims(token.AccessToken)).NotTo(HaveKey("credential"))
+
+			refreshClaims := parseClaims(token.RefreshToken)
+			Expect(refreshClaims).To(HaveKey("credential"))
+			Expect(refreshClaims["credential"]).NotTo(ContainSubstring("alice-credential"))
+		})
+
+		When("the password is missing", func() {
+			BeforeEach(func() {
+				request.Password = ""
+			})
+
+			It("returns an invalid request error", func() {
+				Expect(grantErr).To(MatchError(oauth.NewInvalidRequestError("username and password are required")))
+			})
+		})
+
+		When("the identity backend rejects the credentials", func() {
+			BeforeEach(func() {
+				identityBackend.PasswordLoginReturns(oauth.Login{}, oauth.NewInvalidGrantError("bad credentials"))
+			})
+
+			It("returns the error", func() {
+				Expect(grantErr).To(MatchError(oauth.NewInvalidGrantError("bad credentials")))
+			})
+		})
+
+		When("there are no signing keys", func() {
+			BeforeEach(func() {
+				signingKeys.SigningKeyReturns(keys.Key{}, errors.New("no-keys"))
+			})
+
+			It("returns the error", func() {
+				Expect(grantErr).To(MatchError("no-keys"))
+			})
+		})
+	})
+
+	Describe("client authentication", func() {
+		When("the client is unknown", func() {
+			BeforeEach(func() {
+				request.ClientID = "unknown"
+			})
+
+			It("returns an invalid client error", func() {
+				Expect(grantErr).To(MatchError(oauth.NewInvalidClientError("unknown client")))
+			})
+		})
+
+		When("the client secret is wrong", func() {
+			BeforeEach(func() {
+				request.ClientID = "my-client"
+				request.ClientSecret = "not-my-secret"
+			})
+
+			It("returns an invalid client error", func() {
+				Expect(grantErr).To(MatchError(oauth.NewInvalidClientError("bad client credentials")))
+			})
+		})
+	})
+
+	Describe("authorization code grant", func() {
+		BeforeEach(func() {
+			request = oauth.GrantRequest{
+				GrantType:   "authorization_code",
+				ClientID:    "cf",
+				Code:        "the-code",
+				RedirectURI: "https://example.com/callback",
+			}
+		})
+
+		It("exchanges the code with the identity backend", func() {
+			Expect(grantErr).NotTo(HaveOccurred())
+			Expect(identityBackend.ExchangeCodeCallCount()).To(Equal(1))
+			_, code, redirectURI := identityBackend.ExchangeCodeArgsForCall(0)
+			Expect(code).To(Equal("the-code"))
+			Expect(redirectURI).To(Equal("https://example.com/callback"))
+
+			Expect(parseClaims(token.AccessToken)).To(HaveKeyWithValue("user_name", "bob"))
+		})
+	})
+
+	Describe("refresh token grant", func() {
+		var refreshToken string
+
+		BeforeEach(func() {
+			passwordToken, err := tokenService.Grant(ctx, request)
+			Expect(err).NotTo(HaveOccurred())
+			refreshToken = passwordToken.RefreshToken
+
+			request = oauth.GrantRequest{
+				GrantType:    "refresh_token",
+				ClientID:     "cf",
+				RefreshToken: refreshToken,
+			}
+		})
+
+		It("refreshes the login with the identity backend", func() {
+			Expect(grantErr).NotTo(HaveOccurred())
+			Expect(identityBackend.RefreshCallCount()).To(Equal(1))
+			_, credential := identityBackend.RefreshArgsForCall(0)
+			Expect(credential).To(Equal("alice-credential"))
+		})
+
+		It("issues new tokens with the refreshed identity", func() {
+			Expect(grantErr).NotTo(HaveOccurred())
+			Expect(parseClaims(token.AccessToken)).To(SatisfyAll(
+				HaveKeyWithValue("user_name", "alice"),
+				HaveKeyWithValue("groups", ConsistOf("devs", "admins")),
+			))
+			Expect(token.RefreshToken).NotTo(BeEmpty())
+		})
+
+		It("carries the refreshed credential in the new refresh token", func() {
+			Expect(grantErr).NotTo(HaveOccurred())
+
+			_, err := tokenService.Grant(ctx, oauth.GrantRequest{
+				GrantType:    "refresh_token",
+				ClientID:     "cf",
+				RefreshToken: token.RefreshToken,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(identityBackend.RefreshCallCount()).To(Equal(2))
+			_, credential := identityBackend.RefreshArgsForCall(1)
+			Expect(credential).To(Equal("new-alice-credential"))
+		})
+
+		When("the identity backend rejects the credential", func() {
+			BeforeEach(func() {
+				identityBackend.RefreshReturns(oauth.Login{}, oauth.NewInvalidGrantError("bad credentials"))
+			})
+
+			It("returns the error", func() {
+				Expect(grantErr).To(MatchError(oauth.NewInvalidGrantError("bad credentials")))
+			})
+		})
+
+		When("the identity backend resolves another user", func() {
+			BeforeEach(func() {
+				identityBackend.RefreshReturns(oauth.Login{Identity: authorization.Identity{Name: "mallory", Kind: rbacv1.UserKind}}, nil)
+			})
+
+			It("returns an invalid grant error", func() {
+				Expect(grantErr).To(MatchError(oauth.NewInvalidGrantError("refresh token was issued to another user")))
+			})
+		})
+
+		When("the refresh token was issued to another client", func() {
+			BeforeEach(func() {
+				request.ClientID = "my-client"
+				request.ClientSecret = "my-secret"
+			})
+
+			It("returns an invalid grant error", func() {
+				Expect(grantErr).To(MatchError(oauth.NewInvalidGrantError("refresh token was issued to another client")))
+			})
+		})
+
+		When("an access token is used as refresh token", func() {
+			BeforeEach(func() {
+				passwordToken, err := tokenService.Grant(ctx, oauth.GrantRequest{
+					GrantType: "password",
+					ClientID:  "cf",
+					Username:  "alice",
+					Password:  "password",
+				})
+				Expect(err).NotTo(HaveOccurred())
+				request.RefreshToken = passwordToken.AccessToken
+			})
+
+			It("returns an invalid grant error", func() {
+				Expect(grantErr).To(MatchError(ContainSubstring("invalid_grant")))
+			})
+		})
+
+		When("the refresh token has been signed with an unknown key", func() {
+			BeforeEach(func() {
+				signingKeys.KeyReturns(keys.Key{}, false)
+				signingKeys.KeyStub = nil
+			})
+
+			It("returns an invalid grant error", func() {
+				Expect(grantErr).To(MatchError(ContainSubstring("unknown signing key")))
+			})
+		})
+	})
+
+	Describe("client credentials grant", func() {
+		BeforeEach(func() {
+			request = oauth.GrantRequest{
+				GrantType:    "client_credentials",
+				ClientID:     "my-client",
+				ClientSecret: "my-secret",
+			}
+		})
+
+		It("issues an access token for the client", func() {
+			Expect(grantErr).NotTo(HaveOccurred())
+			Expect(parseClaims(token.AccessToken)).To(HaveKeyWithValue("user_name", "client:my-client"))
+		})
+
+		It("does not issue a refresh token", func() {
+			Expect(grantErr).NotTo(HaveOccurred())
+			Expect(token.RefreshToken).To(BeEmpty())
+		})
+
+		When("the client is public", func() {
+			BeforeEach(func() {
+				request.ClientID = "cf"
+				request.ClientSecret = ""
+			})
+
+			It("returns an unauthorized client error", func() {
+				Expect(grantErr).To(MatchError(ContainSubstring("unauthorized_client")))
+			})
+		})
+	})
+
+	Describe("passcodes", func() {
+		var passcode string
+
+		BeforeEach(func() {
+			var err error
+			passcode, err = tokenService.IssuePasscode(oauth.Login{
+				Identity:   authorization.Identity{Name: "carol", Kind: rbacv1.UserKind, Groups: []string{"ops"}},
+				Credential: "carol-credential",
+			}, "cf")
+			Expect(err).NotTo(HaveOccurred())
+
+			request = oauth.GrantRequest{
+				GrantType: "password",
+				ClientID:  "cf",
+				Passcode:  passcode,
+			}
+		})
+
+		It("issues tokens for the identity the passcode was issued for", func() {
+			Expect(grantErr).NotTo(HaveOccurred())
+			Expect(identityBackend.PasswordLoginCallCount()).To(BeZero())
+			Expect(parseClaims(token.AccessToken)).To(SatisfyAll(
+				HaveKeyWithValue("user_name", "carol"),
+				HaveKeyWithValue("groups", ConsistOf("ops")),
+			))
+			Expect(token.RefreshToken).NotTo(BeEmpty())
+		})
+
+		It("carries the credential over to the refresh token", func() {
+			Expect(grantErr).NotTo(HaveOccurred())
+
+			identityBackend.RefreshReturns(oauth.Login{Identity: authorization.Identity{Name: "carol", Kind: rbacv1.UserKind}}, nil)
+			_, err := tokenService.Grant(ctx, oauth.GrantRequest{
+				GrantType:    "refresh_token",
+				ClientID:     "cf",
+				RefreshToken: token.RefreshToken,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			_, credential := identityBackend.RefreshArgsForCall(0)
+			Expect(credential).To(Equal("carol-credential"))
+		})
+
+		It("can only be redeemed once", func() {
+			Expect(grantErr).NotTo(HaveOccurred())
+
+			_, err := tokenService.Grant(ctx, request)
+			Expect(err).To(MatchError(oauth.NewInvalidGrantError("passcode has already been used")))
+		})
+
+		It("cannot be used as an access token", func() {
+			_, ok, err := tokenService.Verify(passcode)
+			Expect(ok).To(BeTrue())
+			Expect(err).To(HaveOccurred())
+		})
+
+		When("the passcode is redeemed by another client", func() {
+			BeforeEach(func() {
+				request.ClientID = "my-client"
+				request.ClientSecret = "my-secret"
+			})
+
+			It("returns an invalid grant error", func() {
+				Expect(grantErr).To(MatchError(oauth.NewInvalidGrantError("passcode was issued to another client")))
+			})
+		})
+
+		When("the passcode is not valid", func() {
+			BeforeEach(func() {
+				request.Passcode = "not-a-passcode"
+			})
+
+			It("returns an invalid grant error", func() {
+				Expect(grantErr).To(MatchError(oauth.NewInvalidGrantError("invalid passcode")))
+			})
+		})
+	})
+
+	When("the grant type is not supported", func() {
+		BeforeEach(func() {
+			request.GrantType = "implicit"
+		})
+
+		It("returns an unsupported grant type error", func() {
+			Expect(grantErr).To(MatchError(oauth.NewUnsupportedGrantTypeError("implicit")))
+		})
+	})
+
+	Describe("Verify", func() {
+		var (
+			tokenToVerify string
+			identity      authorization.Identity
+			ok            bool
+			verifyErr     error
+		)
+
+		BeforeEach(func() {
+			passwordToken, err := tokenService.Grant(ctx, request)
+			Expect(err).NotTo(HaveOccurred())
+			tokenToVerify = passwordToken.AccessToken
+		})
+
+		JustBeforeEach(func() {
+			identity, ok, verifyErr = tokenService.Verify(tokenToVerify)
+		})
+
+		It("returns the identity the token was issued for", func() {
+			Expect(verifyErr).NotTo(HaveOccurred())
+			Expect(ok).To(BeTrue())
+			Expect(identity).To(Equal(authorization.Identity{Name: "alice", Kind: rbacv1.UserKind, Groups: []string{"devs"}}))
+		})
+
+		When("the token is a refresh token", func() {
+			BeforeEach(func() {
+				passwordToken, err := tokenService.Grant(ctx, request)
+				Expect(err).NotTo(HaveOccurred())
+				tokenToVerify = passwordToken.RefreshToken
+			})
+
+			It("returns an error", func() {
+				Expect(ok).To(BeTrue())
+				Expect(verifyErr).To(MatchError(ContainSubstring("expected token use")))
+			})
+		})
+
+		When("the token has been issued by someone else", func() {
+			BeforeEach(func() {
+				otherKey := generateKey("other")
+				var err error
+				tokenToVerify, err = jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+					"iss": "https://kubernetes.default.svc",
+				}).SignedString(otherKey.PrivateKey)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("leaves it to someone else", func() {
+				Expect(verifyErr).NotTo(HaveOccurred())
+				Expect(ok).To(BeFalse())
+			})
+		})
+
+		When("the token is not a JWT", func() {
+			BeforeEach(func() {
+				tokenToVerify = "not-a-jwt"
+			})
+
+			It("leaves it to someone else", func() {
+				Expect(verifyErr).NotTo(HaveOccurred())
+				Expect(ok).To(BeFalse())
+			})
+		})
+
+		When("the token has been tampered with", func() {
+			BeforeEach(func() {
+				otherKey := generateKey("key-1")
+				var err error
+				tokenToVerify, err = jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+					"iss":       "https://api.example.com/oauth/token",
+					"aud":       "korifi",
+					"user_name": "admin",
+					"token_use": "access",
+				}).SignedString(otherKey.PrivateKey)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("returns an error", func() {
+				Expect(ok).To(BeTrue())
+				Expect(verifyErr).To(MatchError(ContainSubstring("invalid token")))
+			})
+		})
+	})
+
+	Describe("PublicKeys", func() {
+		It("returns the public part of the signing keys", func() {
+			Expect(tokenService.PublicKeys()).To(ConsistOf(oauth.PublicKey{
+				ID:  "key-1",
+				Key: &signingKey.PrivateKey.PublicKey,
+			}))
+		})
+	})
+})
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"net/url"
 	"regexp"
+	"strconv"
 
 	"code.cloudfoundry.org/korifi/api/config"
 	"code.cloudfoundry.org/korifi/api/payloads/parse"
@@ -135,31 +136,38 @@ func (p AppPatchEnvVars) Validate() error {
 }
 
 func (a *AppPatchEnvVars) ToMessage(appGUID, spaceGUID string) repositories.PatchAppEnvVarsMessage {
-	message := repositories.PatchAppEnvVarsMessage{
+	return repositories.PatchAppEnvVarsMessage{
 		AppGUID:              appGUID,
 		SpaceGUID:            spaceGUID,
-		EnvironmentVariables: map[string]*string{},
+		EnvironmentVariables: envVarsToStringPtrs(a.Var),
 	}
+}
+
+func envVarsToStringPtrs(envVars map[string]interface{}) map[string]*string {
+	result := map[string]*string{}
 
-	for k, v := range a.Var {
+	for k, v := range envVars {
 		switch v := v.(type) {
 		case nil:
-			message.EnvironmentVariables[k] = nil
+			result[k] = nil
 		case bool:
 			stringVar := fmt.Sprintf("%t", v)
-			message.EnvironmentVariables[k] = &stringVar
+			result[k] = &stringVar
 		case float32:
 			stringVar := fmt.Sprintf("%f", v)
-			message.EnvironmentVariables[k] = &stringVar
+			result[k] = &stringVar
+		case float64:
+			stringVar := strconv.FormatFloat(v, 'f', -1, 64)
+			result[k] = &stringVar
 		case int:
 			stringVar := fmt.Sprintf("%d", v)
-			message.EnvironmentVariables[k] = &stringVar
+			result[k] = &stringVar
 		case string:
-			message.EnvironmentVariables[k] = &v
+			result[k] = &v
 		}
 	}
 
-	return message
+	return result
 }
 
 type AppPatch struct {
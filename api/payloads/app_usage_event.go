@@ -0,0 +1,39 @@
+package payloads
+
+import (
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/payloads/parse"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	jellidation "github.com/jellydator/validation"
+)
+
+type AppUsageEventList struct {
+	GUIDs      string
+	AfterGUID  string
+	Pagination Pagination
+}
+
+func (l AppUsageEventList) Validate() error {
+	return jellidation.ValidateStruct(&l,
+		jellidation.Field(&l.Pagination),
+	)
+}
+
+func (l *AppUsageEventList) ToMessage() repositories.ListAppUsageEventsMessage {
+	return repositories.ListAppUsageEventsMessage{
+		GUIDs:      parse.ArrayParam(l.GUIDs),
+		AfterGUID:  l.AfterGUID,
+		Pagination: l.Pagination.ToMessage(),
+	}
+}
+
+func (l *AppUsageEventList) SupportedKeys() []string {
+	return []string{"guids", "after_guid", "order_by", "per_page", "page"}
+}
+
+func (l *AppUsageEventList) DecodeFromURLValues(values url.Values) error {
+	l.GUIDs = values.Get("guids")
+	l.AfterGUID = values.Get("after_guid")
+	return l.Pagination.DecodeFromURLValues(values)
+}
@@ -0,0 +1,74 @@
+package payloads_test
+
+import (
+	"net/http"
+
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("AppUsageEventList", func() {
+	Describe("decodes from url values", func() {
+		It("succeeds", func() {
+			appUsageEventList := payloads.AppUsageEventList{}
+			req, err := http.NewRequest("GET", "http://foo.com/bar?guids=g1,g2&after_guid=a1", nil)
+			Expect(err).NotTo(HaveOccurred())
+			err = validator.DecodeAndValidateURLValues(req, &appUsageEventList)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(appUsageEventList).To(Equal(payloads.AppUsageEventList{
+				GUIDs:      "g1,g2",
+				AfterGUID:  "a1",
+				Pagination: payloads.Pagination{PerPage: 50, Page: 1},
+			}))
+		})
+
+		It("decodes the pagination parameters", func() {
+			appUsageEventList := payloads.AppUsageEventList{}
+			req, err := http.NewRequest("GET", "http://foo.com/bar?per_page=10&page=3", nil)
+			Expect(err).NotTo(HaveOccurred())
+			err = validator.DecodeAndValidateURLValues(req, &appUsageEventList)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(appUsageEventList.Pagination).To(Equal(payloads.Pagination{PerPage: 10, Page: 3}))
+		})
+
+		When("per_page is out of range", func() {
+			It("returns an error", func() {
+				appUsageEventList := payloads.AppUsageEventList{}
+				req, err := http.NewRequest("GET", "http://foo.com/bar?per_page=5001", nil)
+				Expect(err).NotTo(HaveOccurred())
+				err = validator.DecodeAndValidateURLValues(req, &appUsageEventList)
+				expectUnprocessableEntityError(err, "must be no greater than 5000")
+			})
+		})
+
+		When("page is not a number", func() {
+			It("returns an error", func() {
+				appUsageEventList := payloads.AppUsageEventList{}
+				req, err := http.NewRequest("GET", "http://foo.com/bar?page=first", nil)
+				Expect(err).NotTo(HaveOccurred())
+				err = validator.DecodeAndValidateURLValues(req, &appUsageEventList)
+				Expect(err).To(HaveOccurred())
+			})
+		})
+	})
+
+	Describe("ToMessage", func() {
+		It("splits parameters to strings", func() {
+			appUsageEventList := payloads.AppUsageEventList{
+				GUIDs:      "g1,g2",
+				AfterGUID:  "a1",
+				Pagination: payloads.Pagination{PerPage: 10, Page: 3},
+			}
+			Expect(appUsageEventList.ToMessage()).To(Equal(repositories.ListAppUsageEventsMessage{
+				GUIDs:      []string{"g1", "g2"},
+				AfterGUID:  "a1",
+				Pagination: repositories.Pagination{PerPage: 10, Page: 3},
+			}))
+		})
+	})
+})
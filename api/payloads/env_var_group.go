@@ -0,0 +1,30 @@
+package payloads
+
+import (
+	"code.cloudfoundry.org/korifi/api/payloads/validation"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	jellidation "github.com/jellydator/validation"
+)
+
+type EnvVarGroupUpdate struct {
+	Var map[string]interface{} `json:"var"`
+}
+
+func (u EnvVarGroupUpdate) Validate() error {
+	return jellidation.ValidateStruct(&u,
+		jellidation.Field(&u.Var,
+			validation.StrictlyRequired,
+			jellidation.Map().Keys(
+				validation.NotStartWith("VCAP_"),
+				validation.NotStartWith("VMC_"),
+				validation.NotEqual("PORT"),
+			).AllowExtraKeys(),
+		))
+}
+
+func (u EnvVarGroupUpdate) ToMessage(name string) repositories.UpdateEnvVarGroupMessage {
+	return repositories.UpdateEnvVarGroupMessage{
+		Name:                 name,
+		EnvironmentVariables: envVarsToStringPtrs(u.Var),
+	}
+}
@@ -0,0 +1,98 @@
+package payloads_test
+
+import (
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/tools"
+	"github.com/onsi/gomega/gstruct"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("EnvVarGroupUpdate", func() {
+	var (
+		updatePayload  payloads.EnvVarGroupUpdate
+		decodedPayload *payloads.EnvVarGroupUpdate
+		validatorErr   error
+	)
+
+	BeforeEach(func() {
+		decodedPayload = new(payloads.EnvVarGroupUpdate)
+		updatePayload = payloads.EnvVarGroupUpdate{
+			Var: map[string]interface{}{
+				"HTTP_PROXY": "http://proxy.example.com",
+				"NO_PROXY":   nil,
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		validatorErr = validator.DecodeAndValidateJSONPayload(createJSONRequest(updatePayload), decodedPayload)
+	})
+
+	It("succeeds", func() {
+		Expect(validatorErr).NotTo(HaveOccurred())
+		Expect(decodedPayload).To(gstruct.PointTo(Equal(updatePayload)))
+	})
+
+	When("var is missing", func() {
+		BeforeEach(func() {
+			updatePayload.Var = nil
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "var cannot be blank")
+		})
+	})
+
+	When("it contains a 'PORT' key", func() {
+		BeforeEach(func() {
+			updatePayload.Var["PORT"] = "2222"
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "value PORT is not allowed")
+		})
+	})
+
+	When("it contains a key with prefix 'VCAP_'", func() {
+		BeforeEach(func() {
+			updatePayload.Var["VCAP_foo"] = "bar"
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "prefix VCAP_ is not allowed")
+		})
+	})
+
+	Describe("ToMessage", func() {
+		It("converts to a repo message", func() {
+			Expect(updatePayload.ToMessage("running")).To(Equal(repositories.UpdateEnvVarGroupMessage{
+				Name: "running",
+				EnvironmentVariables: map[string]*string{
+					"HTTP_PROXY": tools.PtrTo("http://proxy.example.com"),
+					"NO_PROXY":   nil,
+				},
+			}))
+		})
+
+		When("it contains numbers and booleans", func() {
+			BeforeEach(func() {
+				updatePayload.Var["INSTANCES"] = 3
+				updatePayload.Var["RATIO"] = 0.5
+				updatePayload.Var["ENABLED"] = true
+			})
+
+			It("converts them to strings", func() {
+				Expect(decodedPayload.ToMessage("running").EnvironmentVariables).To(gstruct.MatchAllKeys(gstruct.Keys{
+					"HTTP_PROXY": gstruct.PointTo(Equal("http://proxy.example.com")),
+					"NO_PROXY":   BeNil(),
+					"INSTANCES":  gstruct.PointTo(Equal("3")),
+					"RATIO":      gstruct.PointTo(Equal("0.5")),
+					"ENABLED":    gstruct.PointTo(Equal("true")),
+				}))
+			})
+		})
+	})
+})
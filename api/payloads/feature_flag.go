@@ -0,0 +1,25 @@
+package payloads
+
+import (
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"github.com/jellydator/validation"
+)
+
+type FeatureFlagUpdate struct {
+	Enabled            *bool   `json:"enabled"`
+	CustomErrorMessage *string `json:"custom_error_message"`
+}
+
+func (u FeatureFlagUpdate) Validate() error {
+	return validation.ValidateStruct(&u,
+		validation.Field(&u.CustomErrorMessage, validation.NilOrNotEmpty),
+	)
+}
+
+func (u FeatureFlagUpdate) ToMessage(name string) repositories.UpdateFeatureFlagMessage {
+	return repositories.UpdateFeatureFlagMessage{
+		Name:               name,
+		Enabled:            u.Enabled,
+		CustomErrorMessage: u.CustomErrorMessage,
+	}
+}
@@ -0,0 +1,56 @@
+package payloads_test
+
+import (
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/tools"
+	"github.com/onsi/gomega/gstruct"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("FeatureFlagUpdate", func() {
+	var (
+		updatePayload  payloads.FeatureFlagUpdate
+		decodedPayload *payloads.FeatureFlagUpdate
+		validatorErr   error
+	)
+
+	BeforeEach(func() {
+		decodedPayload = new(payloads.FeatureFlagUpdate)
+		updatePayload = payloads.FeatureFlagUpdate{
+			Enabled:            tools.PtrTo(true),
+			CustomErrorMessage: tools.PtrTo("nope"),
+		}
+	})
+
+	JustBeforeEach(func() {
+		validatorErr = validator.DecodeAndValidateJSONPayload(createJSONRequest(updatePayload), decodedPayload)
+	})
+
+	It("succeeds", func() {
+		Expect(validatorErr).NotTo(HaveOccurred())
+		Expect(decodedPayload).To(gstruct.PointTo(Equal(updatePayload)))
+	})
+
+	When("the custom error message is empty", func() {
+		BeforeEach(func() {
+			updatePayload.CustomErrorMessage = tools.PtrTo("")
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "custom_error_message cannot be blank")
+		})
+	})
+
+	Describe("ToMessage", func() {
+		It("converts to a repo message", func() {
+			Expect(updatePayload.ToMessage("diego_docker")).To(Equal(repositories.UpdateFeatureFlagMessage{
+				Name:               "diego_docker",
+				Enabled:            tools.PtrTo(true),
+				CustomErrorMessage: tools.PtrTo("nope"),
+			}))
+		})
+	})
+})
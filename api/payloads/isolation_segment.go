@@ -0,0 +1,118 @@
+package payloads
+
+import (
+	"net/url"
+	"slices"
+
+	"code.cloudfoundry.org/korifi/api/payloads/parse"
+	payload_validation "code.cloudfoundry.org/korifi/api/payloads/validation"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"github.com/BooleanCat/go-functional/v2/it"
+	"github.com/jellydator/validation"
+)
+
+type IsolationSegmentCreate struct {
+	Name     string   `json:"name"`
+	Metadata Metadata `json:"metadata"`
+}
+
+func (c IsolationSegmentCreate) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.Name, payload_validation.StrictlyRequired),
+		validation.Field(&c.Metadata),
+	)
+}
+
+func (c IsolationSegmentCreate) ToMessage() repositories.CreateIsolationSegmentMessage {
+	return repositories.CreateIsolationSegmentMessage{
+		Name: c.Name,
+		Metadata: repositories.Metadata{
+			Labels:      c.Metadata.Labels,
+			Annotations: c.Metadata.Annotations,
+		},
+	}
+}
+
+type IsolationSegmentUpdate struct {
+	Name     *string       `json:"name"`
+	Metadata MetadataPatch `json:"metadata"`
+}
+
+func (u IsolationSegmentUpdate) Validate() error {
+	return validation.ValidateStruct(&u,
+		validation.Field(&u.Name, validation.NilOrNotEmpty),
+		validation.Field(&u.Metadata),
+	)
+}
+
+func (u IsolationSegmentUpdate) ToMessage(guid string) repositories.UpdateIsolationSegmentMessage {
+	return repositories.UpdateIsolationSegmentMessage{
+		GUID: guid,
+		Name: u.Name,
+		MetadataPatch: repositories.MetadataPatch{
+			Labels:      u.Metadata.Labels,
+			Annotations: u.Metadata.Annotations,
+		},
+	}
+}
+
+type IsolationSegmentList struct {
+	GUIDs             string
+	Names             string
+	OrganizationGUIDs string
+}
+
+func (l *IsolationSegmentList) ToMessage() repositories.ListIsolationSegmentsMessage {
+	return repositories.ListIsolationSegmentsMessage{
+		GUIDs:             parse.ArrayParam(l.GUIDs),
+		Names:             parse.ArrayParam(l.Names),
+		OrganizationGUIDs: parse.ArrayParam(l.OrganizationGUIDs),
+	}
+}
+
+func (l *IsolationSegmentList) SupportedKeys() []string {
+	return []string{"guids", "names", "organization_guids", "order_by", "per_page", "page"}
+}
+
+func (l *IsolationSegmentList) DecodeFromURLValues(values url.Values) error {
+	l.GUIDs = values.Get("guids")
+	l.Names = values.Get("names")
+	l.OrganizationGUIDs = values.Get("organization_guids")
+	return nil
+}
+
+type IsolationSegmentEntitleOrgs struct {
+	Data []RelationshipData `json:"data"`
+}
+
+func (e IsolationSegmentEntitleOrgs) Validate() error {
+	return validation.ValidateStruct(&e,
+		validation.Field(&e.Data, validation.Required),
+	)
+}
+
+func (e IsolationSegmentEntitleOrgs) ToMessage(guid string) repositories.EntitleIsolationSegmentMessage {
+	return repositories.EntitleIsolationSegmentMessage{
+		GUID: guid,
+		OrganizationGUIDs: slices.Collect(it.Map(slices.Values(e.Data), func(d RelationshipData) string {
+			return d.GUID
+		})),
+	}
+}
+
+type SpaceIsolationSegmentAssign struct {
+	Data *RelationshipData `json:"data"`
+}
+
+func (a SpaceIsolationSegmentAssign) Validate() error {
+	return validation.ValidateStruct(&a,
+		validation.Field(&a.Data),
+	)
+}
+
+func (a SpaceIsolationSegmentAssign) IsolationSegmentGUID() string {
+	if a.Data == nil {
+		return ""
+	}
+	return a.Data.GUID
+}
@@ -0,0 +1,228 @@
+package payloads_test
+
+import (
+	"net/http"
+
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/tools"
+	"github.com/onsi/gomega/gstruct"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("IsolationSegmentCreate", func() {
+	var (
+		createPayload  payloads.IsolationSegmentCreate
+		decodedPayload *payloads.IsolationSegmentCreate
+		validatorErr   error
+	)
+
+	BeforeEach(func() {
+		decodedPayload = new(payloads.IsolationSegmentCreate)
+		createPayload = payloads.IsolationSegmentCreate{
+			Name: "my-iso-seg",
+			Metadata: payloads.Metadata{
+				Labels: map[string]string{"foo": "bar"},
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		validatorErr = validator.DecodeAndValidateJSONPayload(createJSONRequest(createPayload), decodedPayload)
+	})
+
+	It("succeeds", func() {
+		Expect(validatorErr).NotTo(HaveOccurred())
+		Expect(decodedPayload).To(gstruct.PointTo(Equal(createPayload)))
+	})
+
+	When("name is empty", func() {
+		BeforeEach(func() {
+			createPayload.Name = ""
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "name cannot be blank")
+		})
+	})
+
+	When("metadata is invalid", func() {
+		BeforeEach(func() {
+			createPayload.Metadata = payloads.Metadata{
+				Labels: map[string]string{"foo.cloudfoundry.org/bar": "jim"},
+			}
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "cannot use the cloudfoundry.org domain")
+		})
+	})
+
+	Describe("ToMessage", func() {
+		It("converts to a repo message", func() {
+			Expect(createPayload.ToMessage()).To(Equal(repositories.CreateIsolationSegmentMessage{
+				Name: "my-iso-seg",
+				Metadata: repositories.Metadata{
+					Labels: map[string]string{"foo": "bar"},
+				},
+			}))
+		})
+	})
+})
+
+var _ = Describe("IsolationSegmentUpdate", func() {
+	var (
+		updatePayload  payloads.IsolationSegmentUpdate
+		decodedPayload *payloads.IsolationSegmentUpdate
+		validatorErr   error
+	)
+
+	BeforeEach(func() {
+		decodedPayload = new(payloads.IsolationSegmentUpdate)
+		updatePayload = payloads.IsolationSegmentUpdate{
+			Name: tools.PtrTo("new-name"),
+			Metadata: payloads.MetadataPatch{
+				Labels: map[string]*string{"foo": tools.PtrTo("bar")},
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		validatorErr = validator.DecodeAndValidateJSONPayload(createJSONRequest(updatePayload), decodedPayload)
+	})
+
+	It("succeeds", func() {
+		Expect(validatorErr).NotTo(HaveOccurred())
+		Expect(decodedPayload).To(gstruct.PointTo(Equal(updatePayload)))
+	})
+
+	When("name is empty", func() {
+		BeforeEach(func() {
+			updatePayload.Name = tools.PtrTo("")
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "name cannot be blank")
+		})
+	})
+
+	Describe("ToMessage", func() {
+		It("converts to a repo message", func() {
+			Expect(updatePayload.ToMessage("iso-seg-guid")).To(Equal(repositories.UpdateIsolationSegmentMessage{
+				GUID: "iso-seg-guid",
+				Name: tools.PtrTo("new-name"),
+				MetadataPatch: repositories.MetadataPatch{
+					Labels: map[string]*string{"foo": tools.PtrTo("bar")},
+				},
+			}))
+		})
+	})
+})
+
+var _ = Describe("IsolationSegmentList", func() {
+	Describe("decodes from url values", func() {
+		It("succeeds", func() {
+			isolationSegmentList := payloads.IsolationSegmentList{}
+			req, err := http.NewRequest("GET", "http://foo.com/bar?guids=g1,g2&names=foo,bar&organization_guids=o1", nil)
+			Expect(err).NotTo(HaveOccurred())
+			err = validator.DecodeAndValidateURLValues(req, &isolationSegmentList)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(isolationSegmentList).To(Equal(payloads.IsolationSegmentList{
+				GUIDs:             "g1,g2",
+				Names:             "foo,bar",
+				OrganizationGUIDs: "o1",
+			}))
+		})
+	})
+
+	Describe("ToMessage", func() {
+		It("splits parameters to strings", func() {
+			isolationSegmentList := payloads.IsolationSegmentList{
+				GUIDs:             "g1,g2",
+				Names:             "foo,bar",
+				OrganizationGUIDs: "o1",
+			}
+			Expect(isolationSegmentList.ToMessage()).To(Equal(repositories.ListIsolationSegmentsMessage{
+				GUIDs:             []string{"g1", "g2"},
+				Names:             []string{"foo", "bar"},
+				OrganizationGUIDs: []string{"o1"},
+			}))
+		})
+	})
+})
+
+var _ = Describe("IsolationSegmentEntitleOrgs", func() {
+	var (
+		entitlePayload payloads.IsolationSegmentEntitleOrgs
+		decodedPayload *payloads.IsolationSegmentEntitleOrgs
+		validatorErr   error
+	)
+
+	BeforeEach(func() {
+		decodedPayload = new(payloads.IsolationSegmentEntitleOrgs)
+		entitlePayload = payloads.IsolationSegmentEntitleOrgs{
+			Data: []payloads.RelationshipData{{GUID: "org-1"}, {GUID: "org-2"}},
+		}
+	})
+
+	JustBeforeEach(func() {
+		validatorErr = validator.DecodeAndValidateJSONPayload(createJSONRequest(entitlePayload), decodedPayload)
+	})
+
+	It("succeeds", func() {
+		Expect(validatorErr).NotTo(HaveOccurred())
+		Expect(decodedPayload).To(gstruct.PointTo(Equal(entitlePayload)))
+		Expect(decodedPayload.ToMessage("iso-seg-guid")).To(Equal(repositories.EntitleIsolationSegmentMessage{
+			GUID:              "iso-seg-guid",
+			OrganizationGUIDs: []string{"org-1", "org-2"},
+		}))
+	})
+
+	When("data is empty", func() {
+		BeforeEach(func() {
+			entitlePayload.Data = nil
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "data cannot be blank")
+		})
+	})
+})
+
+var _ = Describe("SpaceIsolationSegmentAssign", func() {
+	var (
+		assignPayload  payloads.SpaceIsolationSegmentAssign
+		decodedPayload *payloads.SpaceIsolationSegmentAssign
+		validatorErr   error
+	)
+
+	BeforeEach(func() {
+		decodedPayload = new(payloads.SpaceIsolationSegmentAssign)
+		assignPayload = payloads.SpaceIsolationSegmentAssign{
+			Data: &payloads.RelationshipData{GUID: "iso-seg-guid"},
+		}
+	})
+
+	JustBeforeEach(func() {
+		validatorErr = validator.DecodeAndValidateJSONPayload(createJSONRequest(assignPayload), decodedPayload)
+	})
+
+	It("succeeds", func() {
+		Expect(validatorErr).NotTo(HaveOccurred())
+		Expect(decodedPayload.IsolationSegmentGUID()).To(Equal("iso-seg-guid"))
+	})
+
+	When("data is null", func() {
+		BeforeEach(func() {
+			assignPayload.Data = nil
+		})
+
+		It("returns an empty isolation segment guid", func() {
+			Expect(validatorErr).NotTo(HaveOccurred())
+			Expect(decodedPayload.IsolationSegmentGUID()).To(BeEmpty())
+		})
+	})
+})
@@ -10,6 +10,7 @@ import (
 	"code.cloudfoundry.org/korifi/tools"
 	"github.com/jellydator/validation"
 	"gopkg.in/yaml.v3"
+	"k8s.io/apimachinery/pkg/api/resource"
 
 	"code.cloudfoundry.org/bytefmt"
 )
@@ -28,6 +29,7 @@ type ManifestApplication struct {
 	Command      *string           `yaml:"command"`
 	Instances    *int32            `json:"instances" yaml:"instances"`
 	Memory       *string           `json:"memory" yaml:"memory"`
+	CPU          *string           `json:"cpu" yaml:"cpu"`
 	DiskQuota    *string           `json:"disk_quota" yaml:"disk_quota"`
 	// AltDiskQuota supports `disk-quota` with a hyphen for backwards compatibility.
 	// Do not set both DiskQuota and AltDiskQuota.
@@ -64,6 +66,7 @@ type ManifestApplicationProcess struct {
 	HealthCheckType              *string `json:"health-check-type" yaml:"health-check-type"`
 	Instances                    *int32  `json:"instances" yaml:"instances"`
 	Memory                       *string `json:"memory" yaml:"memory"`
+	CPU                          *string `json:"cpu" yaml:"cpu"`
 	Timeout                      *int32  `json:"timeout" yaml:"timeout"`
 }
 
@@ -180,6 +183,10 @@ func (p ManifestApplicationProcess) ToProcessCreateMessage(appGUID, spaceGUID st
 		msg.DiskQuotaMB = parseMegabytes(*p.DiskQuota)
 	}
 
+	if p.CPU != nil {
+		msg.CPUMillicores = parseMillicores(*p.CPU)
+	}
+
 	return msg
 }
 
@@ -205,6 +212,9 @@ func (p ManifestApplicationProcess) ToProcessPatchMessage(processGUID, spaceGUID
 	if p.Memory != nil {
 		message.MemoryMB = tools.PtrTo(parseMegabytes(*p.Memory))
 	}
+	if p.CPU != nil {
+		message.CPUMillicores = tools.PtrTo(parseMillicores(*p.CPU))
+	}
 	return message
 }
 
@@ -223,6 +233,7 @@ func (a ManifestApplication) Validate() error {
 		validation.Field(&a.HealthCheckInvocationTimeout, validation.Min(1), validation.NilOrNotEmpty.Error("must be no less than 1")),
 		validation.Field(&a.HealthCheckType, validation.In("none", "process", "port", "http")),
 		validation.Field(&a.Memory, validation.By(validateAmountWithUnit)),
+		validation.Field(&a.CPU, validation.By(validateCPU)),
 		validation.Field(&a.Timeout, validation.Min(1), validation.NilOrNotEmpty.Error("must be no less than 1")),
 		validation.Field(&a.Processes),
 		validation.Field(&a.Routes),
@@ -241,6 +252,7 @@ func (p ManifestApplicationProcess) Validate() error {
 		validation.Field(&p.HealthCheckType, validation.In("none", "process", "port", "http")),
 		validation.Field(&p.Instances, validation.Min(0)),
 		validation.Field(&p.Memory, validation.By(validateAmountWithUnit)),
+		validation.Field(&p.CPU, validation.By(validateCPU)),
 		validation.Field(&p.Timeout, validation.Min(1), validation.NilOrNotEmpty.Error("must be no less than 1")),
 	)
 }
@@ -281,6 +293,30 @@ func validateAmountWithUnit(value any) error {
 	return nil
 }
 
+func validateCPU(value any) error {
+	v, isNil := validation.Indirect(value)
+	if isNil {
+		return nil
+	}
+
+	cpu, err := resource.ParseQuantity(v.(string))
+	if err != nil {
+		return errors.New("must be a number of cores (e.g. 2 or 0.5) or millicores (e.g. 500m)")
+	}
+
+	if cpu.MilliValue() <= 0 {
+		return errors.New("must be greater than 0")
+	}
+
+	return nil
+}
+
+func parseMillicores(s string) int64 {
+	// error intentionally ignored as the manifest is validated beforehand
+	cpu, _ := resource.ParseQuantity(s)
+	return cpu.MilliValue()
+}
+
 func parseMegabytes(s string) int64 {
 	// error intentinally ignored as the manifesst is validated beforehand
 	mb, _ := bytefmt.ToMegabytes(s)
@@ -224,6 +224,26 @@ var _ = Describe("Manifest payload", func() {
 				})
 			})
 
+			When("the cpu is not a quantity", func() {
+				BeforeEach(func() {
+					testManifest.CPU = tools.PtrTo("lots")
+				})
+
+				It("returns a validation error", func() {
+					expectUnprocessableEntityError(validateErr, "cpu must be a number of cores (e.g. 2 or 0.5) or millicores (e.g. 500m)")
+				})
+			})
+
+			When("the cpu is not positive", func() {
+				BeforeEach(func() {
+					testManifest.CPU = tools.PtrTo("0m")
+				})
+
+				It("returns a validation error", func() {
+					expectUnprocessableEntityError(validateErr, "cpu must be greater than 0")
+				})
+			})
+
 			When("random-route and default-route flags are both set", func() {
 				BeforeEach(func() {
 					testManifest.DefaultRoute = true
@@ -557,6 +577,26 @@ var _ = Describe("Manifest payload", func() {
 				})
 			})
 
+			When("the cpu is a number of cores", func() {
+				BeforeEach(func() {
+					testManifestProcess.CPU = tools.PtrTo("1.5")
+				})
+
+				It("does not return a validation error", func() {
+					Expect(validateErr).NotTo(HaveOccurred())
+				})
+			})
+
+			When("the cpu is not positive", func() {
+				BeforeEach(func() {
+					testManifestProcess.CPU = tools.PtrTo("-1")
+				})
+
+				It("returns a validation error", func() {
+					expectUnprocessableEntityError(validateErr, "cpu must be greater than 0")
+				})
+			})
+
 			When("Timeout is not positive", func() {
 				BeforeEach(func() {
 					testManifestProcess.Timeout = tools.PtrTo(int32(0))
@@ -583,6 +623,7 @@ var _ = Describe("Manifest payload", func() {
 						HealthCheckType:              tools.PtrTo("http"),
 						Instances:                    tools.PtrTo[int32](3),
 						Memory:                       tools.PtrTo("1G"),
+						CPU:                          tools.PtrTo("1.5"),
 						Timeout:                      tools.PtrTo(int32(60)),
 					}
 				})
@@ -606,6 +647,7 @@ var _ = Describe("Manifest payload", func() {
 						},
 						DesiredInstances: tools.PtrTo[int32](3),
 						MemoryMB:         1024,
+						CPUMillicores:    1500,
 					}))
 				})
 
@@ -732,6 +774,26 @@ var _ = Describe("Manifest payload", func() {
 				})
 			})
 
+			When("CPU is specified", func() {
+				BeforeEach(func() {
+					processInfo.CPU = tools.PtrTo("250m")
+				})
+
+				It("returns a message with CPUMillicores set to the parsed value", func() {
+					Expect(
+						processInfo.ToProcessPatchMessage(processGUID, spaceGUID).CPUMillicores,
+					).To(PointTo(BeEquivalentTo(250)))
+				})
+			})
+
+			When("CPU is unspecified", func() {
+				It("returns a message with CPUMillicores unset", func() {
+					Expect(
+						processInfo.ToProcessPatchMessage(processGUID, spaceGUID).CPUMillicores,
+					).To(BeNil())
+				})
+			})
+
 			When("Instances is specified", func() {
 				BeforeEach(func() {
 					processInfo.Instances = tools.PtrTo[int32](3)
@@ -0,0 +1,53 @@
+package payloads
+
+import (
+	"net/url"
+	"strconv"
+
+	"code.cloudfoundry.org/korifi/api/repositories"
+	jellidation "github.com/jellydator/validation"
+)
+
+const (
+	defaultPerPage = 50
+	maxPerPage     = 5000
+)
+
+// Pagination holds the per_page and page query parameters of list requests
+type Pagination struct {
+	PerPage int
+	Page    int
+}
+
+func (p Pagination) Validate() error {
+	return jellidation.ValidateStruct(&p,
+		jellidation.Field(&p.PerPage, jellidation.Min(1), jellidation.Max(maxPerPage)),
+		jellidation.Field(&p.Page, jellidation.Min(1)),
+	)
+}
+
+func (p *Pagination) DecodeFromURLValues(values url.Values) error {
+	var err error
+	if p.PerPage, err = getIntOrDefault(values, "per_page", defaultPerPage); err != nil {
+		return err
+	}
+	if p.Page, err = getIntOrDefault(values, "page", 1); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (p Pagination) ToMessage() repositories.Pagination {
+	return repositories.Pagination{
+		PerPage: p.PerPage,
+		Page:    p.Page,
+	}
+}
+
+func getIntOrDefault(values url.Values, key string, defaultValue int) (int, error) {
+	if !values.Has(key) {
+		return defaultValue, nil
+	}
+
+	return strconv.Atoi(values.Get(key))
+}
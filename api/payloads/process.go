@@ -1,17 +1,25 @@
 package payloads
 
 import (
+	"fmt"
 	"net/url"
+	"regexp"
+	"slices"
+	"time"
 
 	"code.cloudfoundry.org/korifi/api/payloads/parse"
 	"code.cloudfoundry.org/korifi/api/repositories"
+	"github.com/BooleanCat/go-functional/v2/it"
 	"github.com/jellydator/validation"
 )
 
+var timeOfDayRegex = regexp.MustCompile(`^([01][0-9]|2[0-3]):[0-5][0-9]$`)
+
 type ProcessScale struct {
-	Instances *int32 `json:"instances"`
-	MemoryMB  *int64 `json:"memory_in_mb"`
-	DiskMB    *int64 `json:"disk_in_mb"`
+	Instances     *int32 `json:"instances"`
+	MemoryMB      *int64 `json:"memory_in_mb"`
+	DiskMB        *int64 `json:"disk_in_mb"`
+	CPUMillicores *int64 `json:"cpu_in_millicores"`
 }
 
 func (p ProcessScale) Validate() error {
@@ -19,6 +27,7 @@ func (p ProcessScale) Validate() error {
 		validation.Field(&p.Instances, validation.Min(0).Error("must be 0 or greater")),
 		validation.Field(&p.MemoryMB, validation.Min(1).Error("must be greater than 0")),
 		validation.Field(&p.DiskMB, validation.Min(1).Error("must be greater than 0")),
+		validation.Field(&p.CPUMillicores, validation.Min(0).Error("must be 0 or greater")),
 	)
 }
 
@@ -41,9 +50,10 @@ type Data struct {
 
 func (p ProcessScale) ToRecord() repositories.ProcessScaleValues {
 	return repositories.ProcessScaleValues{
-		Instances: p.Instances,
-		MemoryMB:  p.MemoryMB,
-		DiskMB:    p.DiskMB,
+		Instances:     p.Instances,
+		MemoryMB:      p.MemoryMB,
+		DiskMB:        p.DiskMB,
+		CPUMillicores: p.CPUMillicores,
 	}
 }
 
@@ -92,3 +102,82 @@ func (p ProcessPatch) ToProcessPatchMessage(processGUID, spaceGUID string) repos
 
 	return message
 }
+
+type ProcessAutoscalingPolicy struct {
+	MinInstances      int32                        `json:"min_instances"`
+	MaxInstances      int32                        `json:"max_instances"`
+	CPUUtilization    *int32                       `json:"cpu_utilization"`
+	MemoryUtilization *int32                       `json:"memory_utilization"`
+	Schedules         []ProcessAutoscalingSchedule `json:"schedules"`
+}
+
+func (p ProcessAutoscalingPolicy) Validate() error {
+	return validation.ValidateStruct(&p,
+		validation.Field(&p.MinInstances, validation.Required.Error("must be greater than 0"), validation.Min(1).Error("must be greater than 0")),
+		validation.Field(&p.MaxInstances, validation.Required.Error("must be greater than 0"), validation.Min(p.MinInstances).Error("must be greater than or equal to min_instances")),
+		validation.Field(&p.CPUUtilization, validation.NilOrNotEmpty.Error("must be greater than 0"), validation.Min(1).Error("must be greater than 0")),
+		validation.Field(&p.MemoryUtilization,
+			validation.NilOrNotEmpty.Error("must be between 1 and 100"),
+			validation.Min(1).Error("must be between 1 and 100"),
+			validation.Max(100).Error("must be between 1 and 100"),
+		),
+		validation.Field(&p.Schedules),
+	)
+}
+
+func (p ProcessAutoscalingPolicy) ToMessage(processGUID, spaceGUID string) repositories.SetProcessAutoscalingMessage {
+	return repositories.SetProcessAutoscalingMessage{
+		ProcessGUID: processGUID,
+		SpaceGUID:   spaceGUID,
+		Policy: &repositories.AutoscalingPolicy{
+			MinInstances:      p.MinInstances,
+			MaxInstances:      p.MaxInstances,
+			CPUUtilization:    p.CPUUtilization,
+			MemoryUtilization: p.MemoryUtilization,
+			Schedules: slices.Collect(it.Map(slices.Values(p.Schedules), func(s ProcessAutoscalingSchedule) repositories.AutoscalingSchedule {
+				return repositories.AutoscalingSchedule{
+					StartTime:    s.StartTime,
+					EndTime:      s.EndTime,
+					DaysOfWeek:   s.DaysOfWeek,
+					TimeZone:     s.TimeZone,
+					MinInstances: s.MinInstances,
+					MaxInstances: s.MaxInstances,
+				}
+			})),
+		},
+	}
+}
+
+type ProcessAutoscalingSchedule struct {
+	StartTime    string  `json:"start_time"`
+	EndTime      string  `json:"end_time"`
+	DaysOfWeek   []int32 `json:"days_of_week"`
+	TimeZone     string  `json:"time_zone"`
+	MinInstances int32   `json:"min_instances"`
+	MaxInstances int32   `json:"max_instances"`
+}
+
+func (s ProcessAutoscalingSchedule) Validate() error {
+	return validation.ValidateStruct(&s,
+		validation.Field(&s.StartTime, validation.Required, validation.Match(timeOfDayRegex).Error("must be a time of day formatted as HH:MM")),
+		validation.Field(&s.EndTime, validation.Required, validation.Match(timeOfDayRegex).Error("must be a time of day formatted as HH:MM")),
+		validation.Field(&s.DaysOfWeek, validation.Each(
+			validation.Min(1).Error("must be between 1 (Monday) and 7 (Sunday)"),
+			validation.Max(7).Error("must be between 1 (Monday) and 7 (Sunday)"),
+		)),
+		validation.Field(&s.TimeZone, validation.By(func(value any) error {
+			timeZone, ok := value.(string)
+			if !ok {
+				return fmt.Errorf("%T is not supported, string is expected", value)
+			}
+
+			if _, err := time.LoadLocation(timeZone); err != nil {
+				return fmt.Errorf("must be an IANA time zone name")
+			}
+
+			return nil
+		})),
+		validation.Field(&s.MinInstances, validation.Required.Error("must be greater than 0"), validation.Min(1).Error("must be greater than 0")),
+		validation.Field(&s.MaxInstances, validation.Required.Error("must be greater than 0"), validation.Min(s.MinInstances).Error("must be greater than or equal to min_instances")),
+	)
+}
@@ -4,6 +4,7 @@ import (
 	"net/http"
 
 	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
 	"code.cloudfoundry.org/korifi/tools"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -37,9 +38,10 @@ var _ = Describe("Process payload validation", func() {
 
 		BeforeEach(func() {
 			payload = payloads.ProcessScale{
-				Instances: tools.PtrTo[int32](1),
-				MemoryMB:  tools.PtrTo[int64](2),
-				DiskMB:    tools.PtrTo[int64](3),
+				Instances:     tools.PtrTo[int32](1),
+				MemoryMB:      tools.PtrTo[int64](2),
+				DiskMB:        tools.PtrTo[int64](3),
+				CPUMillicores: tools.PtrTo[int64](4),
 			}
 
 			decodedPayload = new(payloads.ProcessScale)
@@ -83,5 +85,155 @@ var _ = Describe("Process payload validation", func() {
 				expectUnprocessableEntityError(validatorErr, "disk_in_mb must be greater than 0")
 			})
 		})
+
+		When("cpu is negative", func() {
+			BeforeEach(func() {
+				payload.CPUMillicores = tools.PtrTo[int64](-1)
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError(validatorErr, "cpu_in_millicores must be 0 or greater")
+			})
+		})
+	})
+})
+
+var _ = Describe("ProcessAutoscalingPolicy", func() {
+	var (
+		payload        payloads.ProcessAutoscalingPolicy
+		decodedPayload *payloads.ProcessAutoscalingPolicy
+		validatorErr   error
+	)
+
+	BeforeEach(func() {
+		payload = payloads.ProcessAutoscalingPolicy{
+			MinInstances:      1,
+			MaxInstances:      5,
+			CPUUtilization:    tools.PtrTo[int32](70),
+			MemoryUtilization: tools.PtrTo[int32](80),
+			Schedules: []payloads.ProcessAutoscalingSchedule{{
+				StartTime:    "08:00",
+				EndTime:      "18:30",
+				DaysOfWeek:   []int32{1, 5, 7},
+				TimeZone:     "Europe/Berlin",
+				MinInstances: 3,
+				MaxInstances: 10,
+			}},
+		}
+
+		decodedPayload = new(payloads.ProcessAutoscalingPolicy)
+	})
+
+	JustBeforeEach(func() {
+		validatorErr = validator.DecodeAndValidateJSONPayload(createJSONRequest(payload), decodedPayload)
+	})
+
+	It("succeeds", func() {
+		Expect(validatorErr).NotTo(HaveOccurred())
+		Expect(decodedPayload).To(gstruct.PointTo(Equal(payload)))
+	})
+
+	When("min instances is not set", func() {
+		BeforeEach(func() {
+			payload.MinInstances = 0
+		})
+
+		It("returns an error", func() {
+			expectUnprocessableEntityError(validatorErr, "min_instances must be greater than 0")
+		})
+	})
+
+	When("max instances is less than min instances", func() {
+		BeforeEach(func() {
+			payload.MinInstances = 2
+			payload.MaxInstances = 1
+		})
+
+		It("returns an error", func() {
+			expectUnprocessableEntityError(validatorErr, "max_instances must be greater than or equal to min_instances")
+		})
+	})
+
+	When("the cpu utilization is zero", func() {
+		BeforeEach(func() {
+			payload.CPUUtilization = tools.PtrTo[int32](0)
+		})
+
+		It("returns an error", func() {
+			expectUnprocessableEntityError(validatorErr, "cpu_utilization must be greater than 0")
+		})
+	})
+
+	When("the memory utilization is over 100", func() {
+		BeforeEach(func() {
+			payload.MemoryUtilization = tools.PtrTo[int32](101)
+		})
+
+		It("returns an error", func() {
+			expectUnprocessableEntityError(validatorErr, "memory_utilization must be between 1 and 100")
+		})
+	})
+
+	When("a schedule time is invalid", func() {
+		BeforeEach(func() {
+			payload.Schedules[0].StartTime = "24:00"
+		})
+
+		It("returns an error", func() {
+			expectUnprocessableEntityError(validatorErr, "start_time must be a time of day formatted as HH:MM")
+		})
+	})
+
+	When("a schedule day is invalid", func() {
+		BeforeEach(func() {
+			payload.Schedules[0].DaysOfWeek = []int32{8}
+		})
+
+		It("returns an error", func() {
+			expectUnprocessableEntityError(validatorErr, "must be between 1 (Monday) and 7 (Sunday)")
+		})
+	})
+
+	When("a schedule time zone is invalid", func() {
+		BeforeEach(func() {
+			payload.Schedules[0].TimeZone = "Not/AZone"
+		})
+
+		It("returns an error", func() {
+			expectUnprocessableEntityError(validatorErr, "time_zone must be an IANA time zone name")
+		})
+	})
+
+	When("a schedule max instances is less than its min instances", func() {
+		BeforeEach(func() {
+			payload.Schedules[0].MaxInstances = 2
+		})
+
+		It("returns an error", func() {
+			expectUnprocessableEntityError(validatorErr, "max_instances must be greater than or equal to min_instances")
+		})
+	})
+
+	Describe("ToMessage", func() {
+		It("converts the payload to a message", func() {
+			Expect(payload.ToMessage("process-guid", "space-guid")).To(Equal(repositories.SetProcessAutoscalingMessage{
+				ProcessGUID: "process-guid",
+				SpaceGUID:   "space-guid",
+				Policy: &repositories.AutoscalingPolicy{
+					MinInstances:      1,
+					MaxInstances:      5,
+					CPUUtilization:    tools.PtrTo[int32](70),
+					MemoryUtilization: tools.PtrTo[int32](80),
+					Schedules: []repositories.AutoscalingSchedule{{
+						StartTime:    "08:00",
+						EndTime:      "18:30",
+						DaysOfWeek:   []int32{1, 5, 7},
+						TimeZone:     "Europe/Berlin",
+						MinInstances: 3,
+						MaxInstances: 10,
+					}},
+				},
+			}))
+		})
 	})
 })
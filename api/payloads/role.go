@@ -62,22 +62,32 @@ func (p RoleCreate) ToMessage() repositories.CreateRoleMessage {
 		message.Org = p.Relationships.Organization.Data.GUID
 	}
 
+	if p.Relationships.Group != nil {
+		message.Kind = rbacv1.GroupKind
+		message.User = p.Relationships.Group.Data.Name
+
+		// Groups are prefixed with their origin just like users, see below
+		if p.Relationships.Group.Data.Origin != "" {
+			message.User = p.Relationships.Group.Data.Origin + ":" + message.User
+		}
+
+		return message
+	}
+
 	message.Kind = rbacv1.UserKind
 	message.User = p.Relationships.User.Data.Username
 
-	// For UAA Authenticated users, prefix the Origin as our Cluster uses the Orgin:User for
-	// Authentication verification (via OIDC prefixs)
-	// --kube-apiserver-arg oidc-username-prefix="<origin>:"
-	// --kube-apiserver-arg oidc-groups-prefix="<origin>:"
-	if p.Relationships.User.Data.Origin != "" {
-		message.User = p.Relationships.User.Data.Origin + ":" + message.User
-	}
+	// The repository prefixes the username according to the origin, as the
+	// cluster knows users of other origins by their prefixed name, see
+	// repositories.UserIdentities
+	message.Origin = p.Relationships.User.Data.Origin
 
 	if p.Relationships.User.Data.GUID != "" {
 		message.User = p.Relationships.User.Data.GUID
+		message.Origin = ""
 	}
 
-	if authorization.HasServiceAccountPrefix(message.User) {
+	if message.Origin == "" && authorization.HasServiceAccountPrefix(message.User) {
 		namespace, user := authorization.ServiceAccountNSAndName(message.User)
 
 		message.Kind = rbacv1.ServiceAccountKind
@@ -89,16 +99,21 @@ func (p RoleCreate) ToMessage() repositories.CreateRoleMessage {
 }
 
 type RoleRelationships struct {
-	User         UserRelationship `json:"user"`
-	Space        *Relationship    `json:"space"`
-	Organization *Relationship    `json:"organization"`
+	User         UserRelationship   `json:"user"`
+	Group        *GroupRelationship `json:"group"`
+	Space        *Relationship      `json:"space"`
+	Organization *Relationship      `json:"organization"`
 }
 
 func (r RoleRelationships) ValidateWithContext(ctx context.Context) error {
 	roleType := ctx.Value(typeKey)
 
 	return jellidation.ValidateStruct(&r,
-		jellidation.Field(&r.User, validation.StrictlyRequired),
+		jellidation.Field(&r.User, jellidation.When(r.Group == nil, validation.StrictlyRequired)),
+
+		jellidation.Field(&r.Group,
+			jellidation.When(r.User != UserRelationship{},
+				jellidation.Nil.Error("cannot pass both 'user' and 'group' in a create role request"))),
 
 		jellidation.Field(&r.Space,
 			jellidation.When(r.Organization != nil,
@@ -130,6 +145,29 @@ type UserRelationshipData struct {
 	Origin   string `json:"origin"`
 }
 
+// GroupRelationship refers to a group of the identity provider rather than
+// to a single user. This is a Korifi extension to the CF API.
+type GroupRelationship struct {
+	Data GroupRelationshipData `json:"data"`
+}
+
+func (r GroupRelationship) Validate() error {
+	return jellidation.ValidateStruct(&r,
+		jellidation.Field(&r.Data),
+	)
+}
+
+type GroupRelationshipData struct {
+	Name   string `json:"name"`
+	Origin string `json:"origin"`
+}
+
+func (d GroupRelationshipData) Validate() error {
+	return jellidation.ValidateStruct(&d,
+		jellidation.Field(&d.Name, jellidation.Required),
+	)
+}
+
 type RoleList struct {
 	GUIDs      map[string]bool
 	Types      map[string]bool
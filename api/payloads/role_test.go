@@ -91,6 +91,63 @@ var _ = Describe("RoleCreate", func() {
 		})
 	})
 
+	When("a group is specified", func() {
+		BeforeEach(func() {
+			createPayload.Relationships.User = payloads.UserRelationship{}
+			createPayload.Relationships.Group = &payloads.GroupRelationship{
+				Data: payloads.GroupRelationshipData{
+					Name: "devs",
+				},
+			}
+		})
+
+		It("succeeds", func() {
+			Expect(validatorErr).NotTo(HaveOccurred())
+			Expect(roleCreate).To(PointTo(Equal(createPayload)))
+		})
+
+		When("the group name is missing", func() {
+			BeforeEach(func() {
+				createPayload.Relationships.Group.Data.Name = ""
+			})
+
+			It("fails", func() {
+				Expect(apiError).To(HaveOccurred())
+				Expect(apiError.Detail()).To(ContainSubstring("name cannot be blank"))
+			})
+		})
+
+		When("a user is specified too", func() {
+			BeforeEach(func() {
+				createPayload.Relationships.User.Data.Username = "bob"
+			})
+
+			It("fails", func() {
+				Expect(apiError).To(HaveOccurred())
+				Expect(apiError.Detail()).To(ContainSubstring("cannot pass both 'user' and 'group' in a create role request"))
+			})
+		})
+
+		Context("ToMessage()", func() {
+			It("converts to a group role message", func() {
+				msg := roleCreate.ToMessage()
+				Expect(msg.Space).To(Equal("cf-space-guid"))
+				Expect(msg.User).To(Equal("devs"))
+				Expect(msg.Kind).To(Equal(rbacv1.GroupKind))
+			})
+
+			When("the group origin is specified", func() {
+				BeforeEach(func() {
+					createPayload.Relationships.Group.Data.Origin = "my-origin"
+				})
+
+				It("uses the origin in the message user", func() {
+					Expect(roleCreate.ToMessage().User).To(Equal("my-origin:devs"))
+				})
+			})
+		})
+	})
+
 	Context("ToMessage()", func() {
 		var msg repositories.CreateRoleMessage
 
@@ -110,8 +167,9 @@ var _ = Describe("RoleCreate", func() {
 				createPayload.Relationships.User.Data.Origin = "my-origin"
 			})
 
-			It("uses the origin in the message user", func() {
-				Expect(msg.User).To(Equal("my-origin:cf-service-account"))
+			It("sets the origin of the message user", func() {
+				Expect(msg.User).To(Equal("cf-service-account"))
+				Expect(msg.Origin).To(Equal("my-origin"))
 			})
 		})
 	})
@@ -25,7 +25,18 @@ func (a BrokerAuthentication) Validate() error {
 type ServiceBrokerCreate struct {
 	services.ServiceBroker
 	model.Metadata
-	Authentication *BrokerAuthentication `json:"authentication"`
+	Authentication *BrokerAuthentication       `json:"authentication"`
+	Relationships  *ServiceBrokerRelationships `json:"relationships"`
+}
+
+type ServiceBrokerRelationships struct {
+	Space *Relationship `json:"space"`
+}
+
+func (r ServiceBrokerRelationships) Validate() error {
+	return jellidation.ValidateStruct(&r,
+		jellidation.Field(&r.Space, jellidation.NotNil),
+	)
 }
 
 func (c ServiceBrokerCreate) Validate() error {
@@ -33,33 +44,43 @@ func (c ServiceBrokerCreate) Validate() error {
 		jellidation.Field(&c.Name, jellidation.Required),
 		jellidation.Field(&c.URL, jellidation.Required),
 		jellidation.Field(&c.Authentication, jellidation.Required),
+		jellidation.Field(&c.Relationships),
 	)
 }
 
 func (c ServiceBrokerCreate) ToMessage() repositories.CreateServiceBrokerMessage {
-	return repositories.CreateServiceBrokerMessage{
+	message := repositories.CreateServiceBrokerMessage{
 		Broker:      c.ServiceBroker,
 		Metadata:    c.Metadata,
 		Credentials: c.Authentication.Credentials,
 	}
+
+	if c.Relationships != nil {
+		message.SpaceGUID = c.Relationships.Space.Data.GUID
+	}
+
+	return message
 }
 
 type ServiceBrokerList struct {
-	Names string
+	Names      string
+	SpaceGUIDs string
 }
 
 func (b *ServiceBrokerList) DecodeFromURLValues(values url.Values) error {
 	b.Names = values.Get("names")
+	b.SpaceGUIDs = values.Get("space_guids")
 	return nil
 }
 
 func (b *ServiceBrokerList) SupportedKeys() []string {
-	return []string{"names", "page", "per_page"}
+	return []string{"names", "space_guids", "page", "per_page"}
 }
 
 func (b *ServiceBrokerList) ToMessage() repositories.ListServiceBrokerMessage {
 	return repositories.ListServiceBrokerMessage{
-		Names: parse.ArrayParam(b.Names),
+		Names:      parse.ArrayParam(b.Names),
+		SpaceGUIDs: parse.ArrayParam(b.SpaceGUIDs),
 	}
 }
 
@@ -84,10 +105,11 @@ func (b *ServiceBrokerUpdate) IsAsyncRequest() bool {
 
 func (b *ServiceBrokerUpdate) ToMessage(brokerGUID string) repositories.UpdateServiceBrokerMessage {
 	message := repositories.UpdateServiceBrokerMessage{
-		GUID:          brokerGUID,
-		Name:          b.Name,
-		URL:           b.URL,
-		MetadataPatch: repositories.MetadataPatch(b.Metadata),
+		GUID:           brokerGUID,
+		Name:           b.Name,
+		URL:            b.URL,
+		MetadataPatch:  repositories.MetadataPatch(b.Metadata),
+		RefreshCatalog: b.IsAsyncRequest(),
 	}
 
 	if b.Authentication != nil {
@@ -94,6 +94,28 @@ var _ = Describe("ServiceBrokerCreate", func() {
 		})
 	})
 
+	When("the space relationship has no data", func() {
+		BeforeEach(func() {
+			createPayload.Relationships = &payloads.ServiceBrokerRelationships{
+				Space: &payloads.Relationship{},
+			}
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "data is required")
+		})
+	})
+
+	When("the relationships have no space", func() {
+		BeforeEach(func() {
+			createPayload.Relationships = &payloads.ServiceBrokerRelationships{}
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "space is required")
+		})
+	})
+
 	Describe("ToMessage()", func() {
 		It("converts to repo message correctly", func() {
 			msg := serviceBrokerCreate.ToMessage()
@@ -112,6 +134,20 @@ var _ = Describe("ServiceBrokerCreate", func() {
 				},
 			}))
 		})
+
+		When("the broker is space scoped", func() {
+			BeforeEach(func() {
+				createPayload.Relationships = &payloads.ServiceBrokerRelationships{
+					Space: &payloads.Relationship{
+						Data: &payloads.RelationshipData{GUID: "space-guid"},
+					},
+				}
+			})
+
+			It("sets the space guid", func() {
+				Expect(serviceBrokerCreate.ToMessage().SpaceGUID).To(Equal("space-guid"))
+			})
+		})
 	})
 })
 
@@ -120,25 +156,28 @@ var _ = Describe("ServiceBrokerList", func() {
 
 	BeforeEach(func() {
 		serviceBrokerList = payloads.ServiceBrokerList{
-			Names: "b1, b2",
+			Names:      "b1, b2",
+			SpaceGUIDs: "s1, s2",
 		}
 	})
 
 	Describe("decodes from url values", func() {
 		It("succeeds", func() {
-			req, err := http.NewRequest("GET", "http://foo.com/bar?names=foo,bar", nil)
+			req, err := http.NewRequest("GET", "http://foo.com/bar?names=foo,bar&space_guids=s1,s2", nil)
 			Expect(err).NotTo(HaveOccurred())
 			err = validator.DecodeAndValidateURLValues(req, &serviceBrokerList)
 
 			Expect(err).NotTo(HaveOccurred())
 			Expect(serviceBrokerList.Names).To(Equal("foo,bar"))
+			Expect(serviceBrokerList.SpaceGUIDs).To(Equal("s1,s2"))
 		})
 	})
 
 	Describe("ToMessage", func() {
 		It("converts to repo message correctly", func() {
 			Expect(serviceBrokerList.ToMessage()).To(Equal(repositories.ListServiceBrokerMessage{
-				Names: []string{"b1", "b2"},
+				Names:      []string{"b1", "b2"},
+				SpaceGUIDs: []string{"s1", "s2"},
 			}))
 		})
 	})
@@ -214,9 +253,21 @@ var _ = Describe("ServiceBrokerUpdate", func() {
 						"baz": tools.PtrTo("qux"),
 					},
 				},
+				RefreshCatalog: true,
 			}))
 		})
 
+		When("only metadata is updated", func() {
+			BeforeEach(func() {
+				updatePayload.Name = nil
+				updatePayload.URL = nil
+			})
+
+			It("does not request a catalog refresh", func() {
+				Expect(updatePayload.ToMessage("broker-guid").RefreshCatalog).To(BeFalse())
+			})
+		})
+
 		When("the message has cretentials", func() {
 			BeforeEach(func() {
 				updatePayload.Authentication = &payloads.BrokerAuthentication{
@@ -0,0 +1,70 @@
+package payloads
+
+import (
+	"fmt"
+	"net/url"
+	"slices"
+
+	"code.cloudfoundry.org/korifi/api/payloads/parse"
+	"code.cloudfoundry.org/korifi/api/payloads/validation"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"github.com/BooleanCat/go-functional/v2/it"
+	jellidation "github.com/jellydator/validation"
+)
+
+const (
+	ManagedServiceInstanceUsageType      = "managed_service_instance"
+	UserProvidedServiceInstanceUsageType = "user_provided_service_instance"
+)
+
+var serviceInstanceUsageTypes = map[string]string{
+	ManagedServiceInstanceUsageType:      korifiv1alpha1.ManagedType,
+	UserProvidedServiceInstanceUsageType: korifiv1alpha1.UserProvidedType,
+}
+
+type ServiceUsageEventList struct {
+	GUIDs                string
+	AfterGUID            string
+	ServiceInstanceTypes string
+	Pagination           Pagination
+}
+
+func (l ServiceUsageEventList) Validate() error {
+	return jellidation.ValidateStruct(&l,
+		jellidation.Field(&l.ServiceInstanceTypes, jellidation.By(func(value any) error {
+			serviceInstanceTypes, ok := value.(string)
+			if !ok {
+				return fmt.Errorf("%T is not supported, string is expected", value)
+			}
+
+			return jellidation.Each(validation.OneOf(
+				ManagedServiceInstanceUsageType,
+				UserProvidedServiceInstanceUsageType,
+			)).Validate(parse.ArrayParam(serviceInstanceTypes))
+		})),
+		jellidation.Field(&l.Pagination),
+	)
+}
+
+func (l *ServiceUsageEventList) ToMessage() repositories.ListServiceUsageEventsMessage {
+	return repositories.ListServiceUsageEventsMessage{
+		GUIDs:     parse.ArrayParam(l.GUIDs),
+		AfterGUID: l.AfterGUID,
+		ServiceInstanceTypes: slices.Collect(it.Map(slices.Values(parse.ArrayParam(l.ServiceInstanceTypes)), func(t string) string {
+			return serviceInstanceUsageTypes[t]
+		})),
+		Pagination: l.Pagination.ToMessage(),
+	}
+}
+
+func (l *ServiceUsageEventList) SupportedKeys() []string {
+	return []string{"guids", "after_guid", "service_instance_types", "order_by", "per_page", "page"}
+}
+
+func (l *ServiceUsageEventList) DecodeFromURLValues(values url.Values) error {
+	l.GUIDs = values.Get("guids")
+	l.AfterGUID = values.Get("after_guid")
+	l.ServiceInstanceTypes = values.Get("service_instance_types")
+	return l.Pagination.DecodeFromURLValues(values)
+}
@@ -0,0 +1,69 @@
+package payloads_test
+
+import (
+	"net/http"
+
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ServiceUsageEventList", func() {
+	Describe("decodes from url values", func() {
+		var (
+			serviceUsageEventList payloads.ServiceUsageEventList
+			query                 string
+			decodeErr             error
+		)
+
+		BeforeEach(func() {
+			serviceUsageEventList = payloads.ServiceUsageEventList{}
+			query = "guids=g1,g2&after_guid=a1&service_instance_types=managed_service_instance"
+		})
+
+		JustBeforeEach(func() {
+			req, err := http.NewRequest("GET", "http://foo.com/bar?"+query, nil)
+			Expect(err).NotTo(HaveOccurred())
+			decodeErr = validator.DecodeAndValidateURLValues(req, &serviceUsageEventList)
+		})
+
+		It("succeeds", func() {
+			Expect(decodeErr).NotTo(HaveOccurred())
+			Expect(serviceUsageEventList).To(Equal(payloads.ServiceUsageEventList{
+				GUIDs:                "g1,g2",
+				AfterGUID:            "a1",
+				ServiceInstanceTypes: "managed_service_instance",
+				Pagination:           payloads.Pagination{PerPage: 50, Page: 1},
+			}))
+		})
+
+		When("the service instance type is invalid", func() {
+			BeforeEach(func() {
+				query = "service_instance_types=managed"
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError(decodeErr, "value must be one of")
+			})
+		})
+	})
+
+	Describe("ToMessage", func() {
+		It("translates the service instance types", func() {
+			serviceUsageEventList := payloads.ServiceUsageEventList{
+				GUIDs:                "g1,g2",
+				AfterGUID:            "a1",
+				ServiceInstanceTypes: "managed_service_instance,user_provided_service_instance",
+				Pagination:           payloads.Pagination{PerPage: 50, Page: 1},
+			}
+			Expect(serviceUsageEventList.ToMessage()).To(Equal(repositories.ListServiceUsageEventsMessage{
+				GUIDs:                []string{"g1", "g2"},
+				AfterGUID:            "a1",
+				ServiceInstanceTypes: []string{"managed", "user-provided"},
+				Pagination:           repositories.Pagination{PerPage: 50, Page: 1},
+			}))
+		})
+	})
+})
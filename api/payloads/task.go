@@ -6,27 +6,104 @@ import (
 	"strings"
 
 	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/tools"
 	"github.com/jellydator/validation"
 )
 
 type TaskCreate struct {
-	Command  string   `json:"command"`
-	Metadata Metadata `json:"metadata"`
+	Name                         string        `json:"name"`
+	Command                      string        `json:"command"`
+	DropletGUID                  string        `json:"droplet_guid"`
+	MemoryInMB                   *int64        `json:"memory_in_mb"`
+	DiskInMB                     *int64        `json:"disk_in_mb"`
+	LogRateLimitInBytesPerSecond *int64        `json:"log_rate_limit_in_bytes_per_second"`
+	Template                     *TaskTemplate `json:"template"`
+	Timeout                      *int64        `json:"timeout"`
+	Retry                        *TaskRetry    `json:"retry"`
+	Metadata                     Metadata      `json:"metadata"`
+}
+
+// TaskRetry controls how often a failed task is rerun before it is reported
+// as failed. Failures with one of the non retryable exit codes are final.
+type TaskRetry struct {
+	MaxAttempts           *int32  `json:"max_attempts"`
+	NonRetryableExitCodes []int32 `json:"non_retryable_exit_codes"`
+}
+
+// TaskTemplate points at a process whose command and resources the task
+// inherits, unless the task specifies them
+type TaskTemplate struct {
+	Process TaskTemplateProcess `json:"process"`
+}
+
+type TaskTemplateProcess struct {
+	GUID string `json:"guid"`
 }
 
 func (c TaskCreate) Validate() error {
 	return validation.ValidateStruct(&c,
-		validation.Field(&c.Command, validation.Required),
+		validation.Field(&c.Command, validation.When(c.TemplateProcessGUID() == "", validation.Required)),
+		validation.Field(&c.MemoryInMB, validation.Min(int64(1)), validation.NilOrNotEmpty.Error("must be no less than 1")),
+		validation.Field(&c.DiskInMB, validation.Min(int64(1)), validation.NilOrNotEmpty.Error("must be no less than 1")),
+		validation.Field(&c.LogRateLimitInBytesPerSecond, validation.Min(int64(-1))),
+		validation.Field(&c.Timeout, validation.Min(int64(1)), validation.NilOrNotEmpty.Error("must be no less than 1")),
+		validation.Field(&c.Template),
+		validation.Field(&c.Retry),
 		validation.Field(&c.Metadata),
 	)
 }
 
+func (r TaskRetry) Validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.MaxAttempts, validation.Required, validation.Min(int32(1)), validation.Max(int32(10))),
+		validation.Field(&r.NonRetryableExitCodes, validation.Each(validation.Min(int32(1)), validation.Max(int32(255)))),
+	)
+}
+
+func (t TaskTemplate) Validate() error {
+	return validation.ValidateStruct(&t,
+		validation.Field(&t.Process),
+	)
+}
+
+func (p TaskTemplateProcess) Validate() error {
+	return validation.ValidateStruct(&p,
+		validation.Field(&p.GUID, validation.Required),
+	)
+}
+
+func (c TaskCreate) TemplateProcessGUID() string {
+	if c.Template == nil {
+		return ""
+	}
+
+	return c.Template.Process.GUID
+}
+
 func (p TaskCreate) ToMessage(appRecord repositories.AppRecord) repositories.CreateTaskMessage {
 	return repositories.CreateTaskMessage{
-		Command:   p.Command,
-		SpaceGUID: appRecord.SpaceGUID,
-		AppGUID:   appRecord.GUID,
-		Metadata:  repositories.Metadata(p.Metadata),
+		Name:                       p.Name,
+		Command:                    p.Command,
+		SpaceGUID:                  appRecord.SpaceGUID,
+		AppGUID:                    appRecord.GUID,
+		DropletGUID:                p.DropletGUID,
+		MemoryMB:                   tools.ZeroIfNil(p.MemoryInMB),
+		DiskMB:                     tools.ZeroIfNil(p.DiskInMB),
+		LogRateLimitBytesPerSecond: p.LogRateLimitInBytesPerSecond,
+		TimeoutSeconds:             tools.ZeroIfNil(p.Timeout),
+		Retry:                      p.Retry.toPolicy(),
+		Metadata:                   repositories.Metadata(p.Metadata),
+	}
+}
+
+func (r *TaskRetry) toPolicy() *repositories.TaskRetryPolicy {
+	if r == nil {
+		return nil
+	}
+
+	return &repositories.TaskRetryPolicy{
+		MaxAttempts:           tools.ZeroIfNil(r.MaxAttempts),
+		NonRetryableExitCodes: r.NonRetryableExitCodes,
 	}
 }
 
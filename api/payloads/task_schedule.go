@@ -0,0 +1,132 @@
+package payloads
+
+import (
+	"errors"
+	"slices"
+	"strings"
+
+	"code.cloudfoundry.org/korifi/api/payloads/validation"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools"
+	jellidation "github.com/jellydator/validation"
+)
+
+var concurrencyPolicies = map[string]string{
+	"allow":   korifiv1alpha1.ConcurrencyPolicyAllow,
+	"forbid":  korifiv1alpha1.ConcurrencyPolicyForbid,
+	"replace": korifiv1alpha1.ConcurrencyPolicyReplace,
+}
+
+var scheduleMacros = []string{"@yearly", "@annually", "@monthly", "@weekly", "@daily", "@midnight", "@hourly"}
+
+// cronSchedule checks the shape of a schedule only; the schedule itself is
+// parsed by kubernetes when the cron job is created
+var cronSchedule = jellidation.By(func(value any) error {
+	value, _ = jellidation.Indirect(value)
+	schedule, ok := value.(string)
+	if !ok || schedule == "" {
+		return nil
+	}
+
+	if strings.HasPrefix(schedule, "@") {
+		if !slices.Contains(scheduleMacros, schedule) {
+			return errors.New("must be one of: " + strings.Join(scheduleMacros, ", "))
+		}
+		return nil
+	}
+
+	if len(strings.Fields(schedule)) != 5 {
+		return errors.New("must be a cron expression with five fields")
+	}
+
+	return nil
+})
+
+type TaskScheduleCreate struct {
+	Name              string   `json:"name"`
+	Command           string   `json:"command"`
+	Schedule          string   `json:"schedule"`
+	ConcurrencyPolicy string   `json:"concurrency_policy"`
+	Suspended         bool     `json:"suspended"`
+	MemoryInMB        *int64   `json:"memory_in_mb"`
+	DiskInMB          *int64   `json:"disk_in_mb"`
+	Timeout           *int64   `json:"timeout"`
+	Metadata          Metadata `json:"metadata"`
+}
+
+func (c TaskScheduleCreate) Validate() error {
+	return jellidation.ValidateStruct(&c,
+		jellidation.Field(&c.Command, jellidation.Required),
+		jellidation.Field(&c.Schedule, jellidation.Required, cronSchedule),
+		jellidation.Field(&c.ConcurrencyPolicy, validation.OneOf("allow", "forbid", "replace")),
+		jellidation.Field(&c.MemoryInMB, jellidation.Min(int64(1)), jellidation.NilOrNotEmpty.Error("must be no less than 1")),
+		jellidation.Field(&c.DiskInMB, jellidation.Min(int64(1)), jellidation.NilOrNotEmpty.Error("must be no less than 1")),
+		jellidation.Field(&c.Timeout, jellidation.Min(int64(1)), jellidation.NilOrNotEmpty.Error("must be no less than 1")),
+		jellidation.Field(&c.Metadata),
+	)
+}
+
+func (c TaskScheduleCreate) ToMessage(appRecord repositories.AppRecord) repositories.CreateTaskScheduleMessage {
+	return repositories.CreateTaskScheduleMessage{
+		Name:              c.Name,
+		Command:           c.Command,
+		SpaceGUID:         appRecord.SpaceGUID,
+		AppGUID:           appRecord.GUID,
+		Schedule:          c.Schedule,
+		ConcurrencyPolicy: concurrencyPolicies[c.ConcurrencyPolicy],
+		Suspended:         c.Suspended,
+		MemoryMB:          tools.ZeroIfNil(c.MemoryInMB),
+		DiskMB:            tools.ZeroIfNil(c.DiskInMB),
+		TimeoutSeconds:    tools.ZeroIfNil(c.Timeout),
+		Metadata:          repositories.Metadata(c.Metadata),
+	}
+}
+
+type TaskSchedulePatch struct {
+	Name              *string       `json:"name"`
+	Command           *string       `json:"command"`
+	Schedule          *string       `json:"schedule"`
+	ConcurrencyPolicy *string       `json:"concurrency_policy"`
+	Suspended         *bool         `json:"suspended"`
+	MemoryInMB        *int64        `json:"memory_in_mb"`
+	DiskInMB          *int64        `json:"disk_in_mb"`
+	Timeout           *int64        `json:"timeout"`
+	Metadata          MetadataPatch `json:"metadata"`
+}
+
+func (p TaskSchedulePatch) Validate() error {
+	return jellidation.ValidateStruct(&p,
+		jellidation.Field(&p.Command, jellidation.NilOrNotEmpty),
+		jellidation.Field(&p.Schedule, jellidation.NilOrNotEmpty, cronSchedule),
+		jellidation.Field(&p.ConcurrencyPolicy, validation.OneOf("allow", "forbid", "replace")),
+		jellidation.Field(&p.MemoryInMB, jellidation.Min(int64(1)), jellidation.NilOrNotEmpty.Error("must be no less than 1")),
+		jellidation.Field(&p.DiskInMB, jellidation.Min(int64(1)), jellidation.NilOrNotEmpty.Error("must be no less than 1")),
+		jellidation.Field(&p.Timeout, jellidation.Min(int64(1)), jellidation.NilOrNotEmpty.Error("must be no less than 1")),
+		jellidation.Field(&p.Metadata),
+	)
+}
+
+func (p TaskSchedulePatch) ToMessage(guid, spaceGUID string) repositories.PatchTaskScheduleMessage {
+	message := repositories.PatchTaskScheduleMessage{
+		GUID:           guid,
+		SpaceGUID:      spaceGUID,
+		Name:           p.Name,
+		Command:        p.Command,
+		Schedule:       p.Schedule,
+		Suspended:      p.Suspended,
+		MemoryMB:       p.MemoryInMB,
+		DiskMB:         p.DiskInMB,
+		TimeoutSeconds: p.Timeout,
+		MetadataPatch: repositories.MetadataPatch{
+			Annotations: p.Metadata.Annotations,
+			Labels:      p.Metadata.Labels,
+		},
+	}
+
+	if p.ConcurrencyPolicy != nil {
+		message.ConcurrencyPolicy = tools.PtrTo(concurrencyPolicies[*p.ConcurrencyPolicy])
+	}
+
+	return message
+}
@@ -0,0 +1,235 @@
+package payloads_test
+
+import (
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gstruct"
+)
+
+var _ = Describe("TaskScheduleCreate", func() {
+	var payload payloads.TaskScheduleCreate
+
+	BeforeEach(func() {
+		payload = payloads.TaskScheduleCreate{
+			Name:              "nightly",
+			Command:           "rake db:cleanup",
+			Schedule:          "0 0 * * *",
+			ConcurrencyPolicy: "forbid",
+			Metadata: payloads.Metadata{
+				Labels: map[string]string{"foo": "bar"},
+			},
+		}
+	})
+
+	Describe("Validate", func() {
+		var (
+			decodedPayload *payloads.TaskScheduleCreate
+			validatorErr   error
+		)
+
+		JustBeforeEach(func() {
+			decodedPayload = new(payloads.TaskScheduleCreate)
+			validatorErr = validator.DecodeAndValidateJSONPayload(createJSONRequest(payload), decodedPayload)
+		})
+
+		It("succeeds", func() {
+			Expect(validatorErr).NotTo(HaveOccurred())
+			Expect(decodedPayload).To(gstruct.PointTo(Equal(payload)))
+		})
+
+		When("no command is set", func() {
+			BeforeEach(func() {
+				payload.Command = ""
+			})
+
+			It("returns an appropriate error", func() {
+				expectUnprocessableEntityError(validatorErr, "command cannot be blank")
+			})
+		})
+
+		When("no schedule is set", func() {
+			BeforeEach(func() {
+				payload.Schedule = ""
+			})
+
+			It("returns an appropriate error", func() {
+				expectUnprocessableEntityError(validatorErr, "schedule cannot be blank")
+			})
+		})
+
+		When("the schedule does not have five fields", func() {
+			BeforeEach(func() {
+				payload.Schedule = "0 0 * *"
+			})
+
+			It("returns an appropriate error", func() {
+				expectUnprocessableEntityError(validatorErr, "schedule must be a cron expression with five fields")
+			})
+		})
+
+		When("the schedule is a macro", func() {
+			BeforeEach(func() {
+				payload.Schedule = "@hourly"
+			})
+
+			It("succeeds", func() {
+				Expect(validatorErr).NotTo(HaveOccurred())
+			})
+		})
+
+		When("the schedule is an unknown macro", func() {
+			BeforeEach(func() {
+				payload.Schedule = "@fortnightly"
+			})
+
+			It("returns an appropriate error", func() {
+				expectUnprocessableEntityError(validatorErr, "schedule must be one of: @yearly")
+			})
+		})
+
+		When("the concurrency policy is invalid", func() {
+			BeforeEach(func() {
+				payload.ConcurrencyPolicy = "sometimes"
+			})
+
+			It("returns an appropriate error", func() {
+				expectUnprocessableEntityError(validatorErr, "concurrency_policy value must be one of: allow, forbid, replace")
+			})
+		})
+
+		When("memory_in_mb is not positive", func() {
+			BeforeEach(func() {
+				payload.MemoryInMB = tools.PtrTo(int64(0))
+			})
+
+			It("returns an appropriate error", func() {
+				expectUnprocessableEntityError(validatorErr, "memory_in_mb must be no less than 1")
+			})
+		})
+
+		When("timeout is not positive", func() {
+			BeforeEach(func() {
+				payload.Timeout = tools.PtrTo(int64(0))
+			})
+
+			It("returns an appropriate error", func() {
+				expectUnprocessableEntityError(validatorErr, "timeout must be no less than 1")
+			})
+		})
+	})
+
+	Describe("ToMessage", func() {
+		BeforeEach(func() {
+			payload.MemoryInMB = tools.PtrTo(int64(256))
+			payload.Timeout = tools.PtrTo(int64(60))
+		})
+
+		It("converts to a create message", func() {
+			Expect(payload.ToMessage(repositories.AppRecord{GUID: "app-guid", SpaceGUID: "space-guid"})).To(Equal(repositories.CreateTaskScheduleMessage{
+				Name:              "nightly",
+				Command:           "rake db:cleanup",
+				SpaceGUID:         "space-guid",
+				AppGUID:           "app-guid",
+				Schedule:          "0 0 * * *",
+				ConcurrencyPolicy: korifiv1alpha1.ConcurrencyPolicyForbid,
+				MemoryMB:          256,
+				TimeoutSeconds:    60,
+				Metadata: repositories.Metadata{
+					Labels: map[string]string{"foo": "bar"},
+				},
+			}))
+		})
+
+		When("no concurrency policy is set", func() {
+			BeforeEach(func() {
+				payload.ConcurrencyPolicy = ""
+			})
+
+			It("leaves the concurrency policy to the default", func() {
+				Expect(payload.ToMessage(repositories.AppRecord{}).ConcurrencyPolicy).To(BeEmpty())
+			})
+		})
+	})
+})
+
+var _ = Describe("TaskSchedulePatch", func() {
+	var payload payloads.TaskSchedulePatch
+
+	BeforeEach(func() {
+		payload = payloads.TaskSchedulePatch{
+			Schedule:          tools.PtrTo("*/5 * * * *"),
+			ConcurrencyPolicy: tools.PtrTo("replace"),
+			Suspended:         tools.PtrTo(true),
+			Metadata: payloads.MetadataPatch{
+				Labels: map[string]*string{"foo": tools.PtrTo("bar")},
+			},
+		}
+	})
+
+	Describe("Validate", func() {
+		var (
+			decodedPayload *payloads.TaskSchedulePatch
+			validatorErr   error
+		)
+
+		JustBeforeEach(func() {
+			decodedPayload = new(payloads.TaskSchedulePatch)
+			validatorErr = validator.DecodeAndValidateJSONPayload(createJSONRequest(payload), decodedPayload)
+		})
+
+		It("succeeds", func() {
+			Expect(validatorErr).NotTo(HaveOccurred())
+			Expect(decodedPayload).To(gstruct.PointTo(Equal(payload)))
+		})
+
+		When("the command is empty", func() {
+			BeforeEach(func() {
+				payload.Command = tools.PtrTo("")
+			})
+
+			It("returns an appropriate error", func() {
+				expectUnprocessableEntityError(validatorErr, "command cannot be blank")
+			})
+		})
+
+		When("the schedule is invalid", func() {
+			BeforeEach(func() {
+				payload.Schedule = tools.PtrTo("every day")
+			})
+
+			It("returns an appropriate error", func() {
+				expectUnprocessableEntityError(validatorErr, "schedule must be a cron expression with five fields")
+			})
+		})
+
+		When("the concurrency policy is invalid", func() {
+			BeforeEach(func() {
+				payload.ConcurrencyPolicy = tools.PtrTo("sometimes")
+			})
+
+			It("returns an appropriate error", func() {
+				expectUnprocessableEntityError(validatorErr, "concurrency_policy value must be one of: allow, forbid, replace")
+			})
+		})
+	})
+
+	Describe("ToMessage", func() {
+		It("converts to a patch message", func() {
+			Expect(payload.ToMessage("schedule-guid", "space-guid")).To(Equal(repositories.PatchTaskScheduleMessage{
+				GUID:              "schedule-guid",
+				SpaceGUID:         "space-guid",
+				Schedule:          tools.PtrTo("*/5 * * * *"),
+				ConcurrencyPolicy: tools.PtrTo(korifiv1alpha1.ConcurrencyPolicyReplace),
+				Suspended:         tools.PtrTo(true),
+				MetadataPatch: repositories.MetadataPatch{
+					Labels: map[string]*string{"foo": tools.PtrTo("bar")},
+				},
+			}))
+		})
+	})
+})
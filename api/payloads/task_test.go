@@ -81,6 +81,114 @@ var _ = Describe("TaskCreate", func() {
 			})
 		})
 
+		When("no command is set but a template process is", func() {
+			BeforeEach(func() {
+				payload.Command = ""
+				payload.Template = &payloads.TaskTemplate{
+					Process: payloads.TaskTemplateProcess{GUID: "process-guid"},
+				}
+			})
+
+			It("succeeds", func() {
+				Expect(validatorErr).NotTo(HaveOccurred())
+				Expect(decodedPayload).To(gstruct.PointTo(Equal(payload)))
+			})
+		})
+
+		When("the template has no process guid", func() {
+			BeforeEach(func() {
+				payload.Template = &payloads.TaskTemplate{}
+			})
+
+			It("returns an appropriate error", func() {
+				expectUnprocessableEntityError(validatorErr, "template.process.guid cannot be blank")
+			})
+		})
+
+		When("memory_in_mb is not positive", func() {
+			BeforeEach(func() {
+				payload.MemoryInMB = tools.PtrTo(int64(0))
+			})
+
+			It("returns an appropriate error", func() {
+				expectUnprocessableEntityError(validatorErr, "memory_in_mb must be no less than 1")
+			})
+		})
+
+		When("disk_in_mb is not positive", func() {
+			BeforeEach(func() {
+				payload.DiskInMB = tools.PtrTo(int64(-5))
+			})
+
+			It("returns an appropriate error", func() {
+				expectUnprocessableEntityError(validatorErr, "disk_in_mb must be no less than 1")
+			})
+		})
+
+		When("log_rate_limit_in_bytes_per_second is less than -1", func() {
+			BeforeEach(func() {
+				payload.LogRateLimitInBytesPerSecond = tools.PtrTo(int64(-2))
+			})
+
+			It("returns an appropriate error", func() {
+				expectUnprocessableEntityError(validatorErr, "log_rate_limit_in_bytes_per_second must be no less than -1")
+			})
+		})
+
+		When("timeout is not positive", func() {
+			BeforeEach(func() {
+				payload.Timeout = tools.PtrTo(int64(0))
+			})
+
+			It("returns an appropriate error", func() {
+				expectUnprocessableEntityError(validatorErr, "timeout must be no less than 1")
+			})
+		})
+
+		When("a retry policy is set", func() {
+			BeforeEach(func() {
+				payload.Retry = &payloads.TaskRetry{
+					MaxAttempts:           tools.PtrTo(int32(3)),
+					NonRetryableExitCodes: []int32{2, 127},
+				}
+			})
+
+			It("succeeds", func() {
+				Expect(validatorErr).NotTo(HaveOccurred())
+				Expect(decodedPayload.Retry).To(Equal(payload.Retry))
+			})
+
+			When("max_attempts is not set", func() {
+				BeforeEach(func() {
+					payload.Retry.MaxAttempts = nil
+				})
+
+				It("returns an appropriate error", func() {
+					expectUnprocessableEntityError(validatorErr, "max_attempts cannot be blank")
+				})
+			})
+
+			When("max_attempts is too large", func() {
+				BeforeEach(func() {
+					payload.Retry.MaxAttempts = tools.PtrTo(int32(11))
+				})
+
+				It("returns an appropriate error", func() {
+					expectUnprocessableEntityError(validatorErr, "max_attempts must be no greater than 10")
+				})
+			})
+
+			When("an exit code is out of range", func() {
+				BeforeEach(func() {
+					payload.Retry.NonRetryableExitCodes = []int32{256}
+				})
+
+				It("returns an appropriate error", func() {
+					expectUnprocessableEntityError(validatorErr, "retry.non_retryable_exit_codes0 must be no greater than 255")
+				})
+			})
+		})
+
 		When("metadata is invalid", func() {
 			BeforeEach(func() {
 				payload.Metadata = payloads.Metadata{
@@ -109,6 +217,35 @@ var _ = Describe("TaskCreate", func() {
 				"example.org/jim": "hello",
 			}))
 		})
+
+		When("the task details are set", func() {
+			BeforeEach(func() {
+				payload.Name = "migrate"
+				payload.DropletGUID = "droplet-guid"
+				payload.MemoryInMB = tools.PtrTo(int64(2048))
+				payload.DiskInMB = tools.PtrTo(int64(1024))
+				payload.LogRateLimitInBytesPerSecond = tools.PtrTo(int64(512))
+				payload.Timeout = tools.PtrTo(int64(600))
+				payload.Retry = &payloads.TaskRetry{
+					MaxAttempts:           tools.PtrTo(int32(3)),
+					NonRetryableExitCodes: []int32{2},
+				}
+			})
+
+			It("sets them on the message", func() {
+				msg := payload.ToMessage(repositories.AppRecord{GUID: "appGUID", SpaceGUID: "spaceGUID"})
+				Expect(msg.Name).To(Equal("migrate"))
+				Expect(msg.DropletGUID).To(Equal("droplet-guid"))
+				Expect(msg.MemoryMB).To(BeEquivalentTo(2048))
+				Expect(msg.DiskMB).To(BeEquivalentTo(1024))
+				Expect(msg.LogRateLimitBytesPerSecond).To(gstruct.PointTo(BeEquivalentTo(512)))
+				Expect(msg.TimeoutSeconds).To(BeEquivalentTo(600))
+				Expect(msg.Retry).To(Equal(&repositories.TaskRetryPolicy{
+					MaxAttempts:           3,
+					NonRetryableExitCodes: []int32{2},
+				}))
+			})
+		})
 	})
 })
 
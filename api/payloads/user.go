@@ -0,0 +1,76 @@
+package payloads
+
+import (
+	"errors"
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	"code.cloudfoundry.org/korifi/api/payloads/parse"
+	"code.cloudfoundry.org/korifi/api/payloads/validation"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	jellidation "github.com/jellydator/validation"
+)
+
+type UserCreate struct {
+	Username         string   `json:"username"`
+	Origin           string   `json:"origin"`
+	PresentationName string   `json:"presentation_name"`
+	Metadata         Metadata `json:"metadata"`
+}
+
+func (c UserCreate) Validate() error {
+	return jellidation.ValidateStruct(&c,
+		jellidation.Field(&c.Username,
+			validation.StrictlyRequired,
+			jellidation.When(c.Origin == korifiv1alpha1.UserOriginServiceAccount, jellidation.By(func(value any) error {
+				if !authorization.HasServiceAccountPrefix(c.Username) {
+					return errors.New("must be of the form system:serviceaccount:<namespace>:<name> for service accounts")
+				}
+				return nil
+			})),
+		),
+		jellidation.Field(&c.Origin,
+			validation.StrictlyRequired,
+			validation.OneOf(korifiv1alpha1.UserOriginOIDC, korifiv1alpha1.UserOriginCert, korifiv1alpha1.UserOriginServiceAccount),
+		),
+		jellidation.Field(&c.Metadata),
+	)
+}
+
+func (c UserCreate) ToMessage() repositories.CreateUserMessage {
+	return repositories.CreateUserMessage{
+		Username:         c.Username,
+		Origin:           c.Origin,
+		PresentationName: c.PresentationName,
+		Metadata: repositories.Metadata{
+			Labels:      c.Metadata.Labels,
+			Annotations: c.Metadata.Annotations,
+		},
+	}
+}
+
+type UserList struct {
+	GUIDs     string
+	Usernames string
+	Origins   string
+}
+
+func (l *UserList) ToMessage() repositories.ListUsersMessage {
+	return repositories.ListUsersMessage{
+		GUIDs:     parse.ArrayParam(l.GUIDs),
+		Usernames: parse.ArrayParam(l.Usernames),
+		Origins:   parse.ArrayParam(l.Origins),
+	}
+}
+
+func (l *UserList) SupportedKeys() []string {
+	return []string{"guids", "usernames", "origins", "order_by", "per_page", "page"}
+}
+
+func (l *UserList) DecodeFromURLValues(values url.Values) error {
+	l.GUIDs = values.Get("guids")
+	l.Usernames = values.Get("usernames")
+	l.Origins = values.Get("origins")
+	return nil
+}
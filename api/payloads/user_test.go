@@ -0,0 +1,149 @@
+package payloads_test
+
+import (
+	"net/http"
+
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"github.com/onsi/gomega/gstruct"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("UserCreate", func() {
+	var (
+		createPayload  payloads.UserCreate
+		decodedPayload *payloads.UserCreate
+		validatorErr   error
+	)
+
+	BeforeEach(func() {
+		decodedPayload = new(payloads.UserCreate)
+		createPayload = payloads.UserCreate{
+			Username:         "oidc:alice",
+			Origin:           "oidc",
+			PresentationName: "Alice",
+			Metadata: payloads.Metadata{
+				Labels: map[string]string{"foo": "bar"},
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		validatorErr = validator.DecodeAndValidateJSONPayload(createJSONRequest(createPayload), decodedPayload)
+	})
+
+	It("succeeds", func() {
+		Expect(validatorErr).NotTo(HaveOccurred())
+		Expect(decodedPayload).To(gstruct.PointTo(Equal(createPayload)))
+	})
+
+	When("username is empty", func() {
+		BeforeEach(func() {
+			createPayload.Username = ""
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "username cannot be blank")
+		})
+	})
+
+	When("origin is empty", func() {
+		BeforeEach(func() {
+			createPayload.Origin = ""
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "origin cannot be blank")
+		})
+	})
+
+	When("origin is not supported", func() {
+		BeforeEach(func() {
+			createPayload.Origin = "uaa"
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "origin value must be one of")
+		})
+	})
+
+	When("the origin is service-account", func() {
+		BeforeEach(func() {
+			createPayload.Origin = "service-account"
+			createPayload.Username = "system:serviceaccount:my-ns:my-sa"
+		})
+
+		It("succeeds", func() {
+			Expect(validatorErr).NotTo(HaveOccurred())
+		})
+
+		When("the username is not a service account name", func() {
+			BeforeEach(func() {
+				createPayload.Username = "my-sa"
+			})
+
+			It("returns an appropriate error", func() {
+				expectUnprocessableEntityError(validatorErr, "username must be of the form system:serviceaccount:<namespace>:<name>")
+			})
+		})
+	})
+
+	When("metadata is invalid", func() {
+		BeforeEach(func() {
+			createPayload.Metadata = payloads.Metadata{
+				Labels: map[string]string{"foo.cloudfoundry.org/bar": "jim"},
+			}
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "cannot use the cloudfoundry.org domain")
+		})
+	})
+
+	Describe("ToMessage", func() {
+		It("converts to a repo message", func() {
+			Expect(createPayload.ToMessage()).To(Equal(repositories.CreateUserMessage{
+				Username:         "oidc:alice",
+				Origin:           "oidc",
+				PresentationName: "Alice",
+				Metadata: repositories.Metadata{
+					Labels: map[string]string{"foo": "bar"},
+				},
+			}))
+		})
+	})
+})
+
+var _ = Describe("UserList", func() {
+	Describe("decodes from url values", func() {
+		It("succeeds", func() {
+			req, err := http.NewRequest("GET", "http://foo.com/bar?guids=g1,g2&usernames=u1,u2&origins=oidc", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			userList := payloads.UserList{}
+			Expect(validator.DecodeAndValidateURLValues(req, &userList)).To(Succeed())
+			Expect(userList).To(Equal(payloads.UserList{
+				GUIDs:     "g1,g2",
+				Usernames: "u1,u2",
+				Origins:   "oidc",
+			}))
+		})
+	})
+
+	Describe("ToMessage", func() {
+		It("converts to a repo message", func() {
+			userList := payloads.UserList{
+				GUIDs:     "g1,g2",
+				Usernames: "u1,u2",
+				Origins:   "oidc",
+			}
+			Expect(userList.ToMessage()).To(Equal(repositories.ListUsersMessage{
+				GUIDs:     []string{"g1", "g2"},
+				Usernames: []string{"u1", "u2"},
+				Origins:   []string{"oidc"},
+			}))
+		})
+	})
+})
@@ -167,8 +167,8 @@ type AppEnvResponse struct {
 func ForAppEnv(envVarRecord repositories.AppEnvRecord) AppEnvResponse {
 	return AppEnvResponse{
 		EnvironmentVariables: envVarRecord.EnvironmentVariables,
-		StagingEnvJSON:       map[string]string{},
-		RunningEnvJSON:       map[string]string{},
+		StagingEnvJSON:       emptyMapIfNil(envVarRecord.StagingEnv),
+		RunningEnvJSON:       emptyMapIfNil(envVarRecord.RunningEnv),
 		SystemEnvJSON:        emptyMapToAnyIfEmpty(envVarRecord.SystemEnv),
 		ApplicationEnvJSON:   emptyMapToAnyIfEmpty(envVarRecord.AppEnv),
 	}
@@ -215,6 +215,8 @@ var _ = Describe("App", func() {
 		BeforeEach(func() {
 			record = repositories.AppEnvRecord{
 				EnvironmentVariables: map[string]string{"VAR": "VAL"},
+				StagingEnv:           map[string]string{"STAGING_VAR": "STAGING_VAL"},
+				RunningEnv:           map[string]string{"RUNNING_VAR": "RUNNING_VAL"},
 				SystemEnv: map[string]any{
 					"VCAP_SERVICES": map[string]any{
 						"mysql": map[string]any{
@@ -239,8 +241,12 @@ var _ = Describe("App", func() {
 
 		It("returns the expected output", func() {
 			Expect(output).To(MatchJSON(`{
-				"staging_env_json": {},
-				"running_env_json": {},
+				"staging_env_json": {
+					"STAGING_VAR": "STAGING_VAL"
+				},
+				"running_env_json": {
+					"RUNNING_VAR": "RUNNING_VAL"
+				},
 				"environment_variables": {
 					"VAR": "VAL"
 				},
@@ -259,6 +265,18 @@ var _ = Describe("App", func() {
 			}`))
 		})
 
+		When("the environment variable groups are nil", func() {
+			BeforeEach(func() {
+				record.StagingEnv = nil
+				record.RunningEnv = nil
+			})
+
+			It("returns empty objects", func() {
+				Expect(output).To(MatchJSONPath("$.staging_env_json", Not(BeNil())))
+				Expect(output).To(MatchJSONPath("$.running_env_json", Not(BeNil())))
+			})
+		})
+
 		When("system env is nil", func() {
 			BeforeEach(func() {
 				record.SystemEnv = nil
@@ -0,0 +1,97 @@
+package presenter
+
+import (
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/model"
+	"code.cloudfoundry.org/korifi/tools"
+)
+
+const (
+	appUsageEventsBase = "/v3/app_usage_events"
+)
+
+type AppUsageEventResponse struct {
+	GUID                  string                  `json:"guid"`
+	CreatedAt             string                  `json:"created_at"`
+	UpdatedAt             string                  `json:"updated_at"`
+	State                 UsageEventValue[string] `json:"state"`
+	App                   UsageEventResource      `json:"app"`
+	Process               UsageEventProcess       `json:"process"`
+	Space                 UsageEventResource      `json:"space"`
+	Organization          UsageEventOrganization  `json:"organization"`
+	MemoryInMBPerInstance UsageEventValue[int64]  `json:"memory_in_mb_per_instance"`
+	InstanceCount         UsageEventValue[int32]  `json:"instance_count"`
+	Links                 AppUsageEventLinks      `json:"links"`
+}
+
+// UsageEventValue holds the value of an event field after the event, along
+// with the value before it. Previous is nil when there is no prior state.
+type UsageEventValue[T any] struct {
+	Current  T  `json:"current"`
+	Previous *T `json:"previous"`
+}
+
+type UsageEventResource struct {
+	GUID string `json:"guid"`
+	Name string `json:"name"`
+}
+
+type UsageEventProcess struct {
+	GUID string `json:"guid"`
+	Type string `json:"type"`
+}
+
+type UsageEventOrganization struct {
+	GUID string `json:"guid"`
+}
+
+type AppUsageEventLinks struct {
+	Self Link `json:"self"`
+}
+
+func ForAppUsageEvent(event repositories.AppUsageEventRecord, baseURL url.URL, includes ...model.IncludedResource) AppUsageEventResponse {
+	response := AppUsageEventResponse{
+		GUID:      event.GUID,
+		CreatedAt: tools.ZeroIfNil(formatTimestamp(&event.CreatedAt)),
+		UpdatedAt: tools.ZeroIfNil(formatTimestamp(event.UpdatedAt)),
+		State: UsageEventValue[string]{
+			Current: event.State,
+		},
+		App: UsageEventResource{
+			GUID: event.AppGUID,
+			Name: event.AppName,
+		},
+		Process: UsageEventProcess{
+			GUID: event.ProcessGUID,
+			Type: event.ProcessType,
+		},
+		Space: UsageEventResource{
+			GUID: event.SpaceGUID,
+			Name: event.SpaceName,
+		},
+		Organization: UsageEventOrganization{
+			GUID: event.OrgGUID,
+		},
+		MemoryInMBPerInstance: UsageEventValue[int64]{
+			Current: event.MemoryInMBPerInstance,
+		},
+		InstanceCount: UsageEventValue[int32]{
+			Current: event.InstanceCount,
+		},
+		Links: AppUsageEventLinks{
+			Self: Link{
+				HRef: buildURL(baseURL).appendPath(appUsageEventsBase, event.GUID).build(),
+			},
+		},
+	}
+
+	if event.PreviousState != "" {
+		response.State.Previous = tools.PtrTo(event.PreviousState)
+		response.MemoryInMBPerInstance.Previous = tools.PtrTo(event.PreviousMemoryInMBPerInstance)
+		response.InstanceCount.Previous = tools.PtrTo(event.PreviousInstanceCount)
+	}
+
+	return response
+}
@@ -0,0 +1,107 @@
+package presenter_test
+
+import (
+	"encoding/json"
+	"net/url"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	. "code.cloudfoundry.org/korifi/tests/matchers"
+	"code.cloudfoundry.org/korifi/tools"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("App Usage Events", func() {
+	var (
+		baseURL *url.URL
+		output  []byte
+		record  repositories.AppUsageEventRecord
+	)
+
+	BeforeEach(func() {
+		var err error
+		baseURL, err = url.Parse("https://api.example.org")
+		Expect(err).NotTo(HaveOccurred())
+		record = repositories.AppUsageEventRecord{
+			GUID:                          "event-guid",
+			State:                         "STARTED",
+			PreviousState:                 "STOPPED",
+			AppGUID:                       "app-guid",
+			AppName:                       "app-name",
+			ProcessGUID:                   "process-guid",
+			ProcessType:                   "web",
+			SpaceGUID:                     "space-guid",
+			SpaceName:                     "space-name",
+			OrgGUID:                       "org-guid",
+			InstanceCount:                 2,
+			PreviousInstanceCount:         1,
+			MemoryInMBPerInstance:         256,
+			PreviousMemoryInMBPerInstance: 128,
+			CreatedAt:                     time.UnixMilli(1000),
+			UpdatedAt:                     tools.PtrTo(time.UnixMilli(2000)),
+		}
+	})
+
+	JustBeforeEach(func() {
+		var err error
+		output, err = json.Marshal(presenter.ForAppUsageEvent(record, *baseURL))
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("produces the expected json", func() {
+		Expect(output).To(MatchJSON(`{
+			"guid": "event-guid",
+			"created_at": "1970-01-01T00:00:01Z",
+			"updated_at": "1970-01-01T00:00:02Z",
+			"state": {
+				"current": "STARTED",
+				"previous": "STOPPED"
+			},
+			"app": {
+				"guid": "app-guid",
+				"name": "app-name"
+			},
+			"process": {
+				"guid": "process-guid",
+				"type": "web"
+			},
+			"space": {
+				"guid": "space-guid",
+				"name": "space-name"
+			},
+			"organization": {
+				"guid": "org-guid"
+			},
+			"memory_in_mb_per_instance": {
+				"current": 256,
+				"previous": 128
+			},
+			"instance_count": {
+				"current": 2,
+				"previous": 1
+			},
+			"links": {
+				"self": {
+					"href": "https://api.example.org/v3/app_usage_events/event-guid"
+				}
+			}
+		}`))
+	})
+
+	When("the event has no previous state", func() {
+		BeforeEach(func() {
+			record.PreviousState = ""
+		})
+
+		It("renders the previous values as null", func() {
+			Expect(output).To(SatisfyAll(
+				MatchJSONPath("$.state.previous", BeNil()),
+				MatchJSONPath("$.memory_in_mb_per_instance.previous", BeNil()),
+				MatchJSONPath("$.instance_count.previous", BeNil()),
+			))
+		})
+	})
+})
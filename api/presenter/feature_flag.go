@@ -0,0 +1,43 @@
+package presenter
+
+import (
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/model"
+)
+
+const (
+	featureFlagsBase = "/v3/feature_flags"
+)
+
+type FeatureFlagResponse struct {
+	Name               string           `json:"name"`
+	Enabled            bool             `json:"enabled"`
+	UpdatedAt          *string          `json:"updated_at"`
+	CustomErrorMessage *string          `json:"custom_error_message"`
+	Links              FeatureFlagLinks `json:"links"`
+}
+
+type FeatureFlagLinks struct {
+	Self Link `json:"self"`
+}
+
+func ForFeatureFlag(featureFlag repositories.FeatureFlagRecord, baseURL url.URL, includes ...model.IncludedResource) FeatureFlagResponse {
+	var customErrorMessage *string
+	if featureFlag.CustomErrorMessage != "" {
+		customErrorMessage = &featureFlag.CustomErrorMessage
+	}
+
+	return FeatureFlagResponse{
+		Name:               featureFlag.Name,
+		Enabled:            featureFlag.Enabled,
+		UpdatedAt:          formatTimestamp(featureFlag.UpdatedAt),
+		CustomErrorMessage: customErrorMessage,
+		Links: FeatureFlagLinks{
+			Self: Link{
+				HRef: buildURL(baseURL).appendPath(featureFlagsBase, featureFlag.Name).build(),
+			},
+		},
+	}
+}
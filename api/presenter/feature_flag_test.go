@@ -0,0 +1,77 @@
+package presenter_test
+
+import (
+	"encoding/json"
+	"net/url"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/tools"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Feature Flags", func() {
+	var (
+		baseURL *url.URL
+		output  []byte
+		record  repositories.FeatureFlagRecord
+	)
+
+	BeforeEach(func() {
+		var err error
+		baseURL, err = url.Parse("https://api.example.org")
+		Expect(err).NotTo(HaveOccurred())
+		record = repositories.FeatureFlagRecord{
+			Name:               "diego_docker",
+			Enabled:            false,
+			CustomErrorMessage: "no docker",
+			UpdatedAt:          tools.PtrTo(time.UnixMilli(2000)),
+		}
+	})
+
+	JustBeforeEach(func() {
+		var err error
+		output, err = json.Marshal(presenter.ForFeatureFlag(record, *baseURL))
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("produces the expected json", func() {
+		Expect(output).To(MatchJSON(`{
+			"name": "diego_docker",
+			"enabled": false,
+			"updated_at": "1970-01-01T00:00:02Z",
+			"custom_error_message": "no docker",
+			"links": {
+				"self": {
+					"href": "https://api.example.org/v3/feature_flags/diego_docker"
+				}
+			}
+		}`))
+	})
+
+	When("the feature flag has never been updated", func() {
+		BeforeEach(func() {
+			record = repositories.FeatureFlagRecord{
+				Name:    "diego_docker",
+				Enabled: true,
+			}
+		})
+
+		It("renders nulls for the missing fields", func() {
+			Expect(output).To(MatchJSON(`{
+				"name": "diego_docker",
+				"enabled": true,
+				"updated_at": null,
+				"custom_error_message": null,
+				"links": {
+					"self": {
+						"href": "https://api.example.org/v3/feature_flags/diego_docker"
+					}
+				}
+			}`))
+		})
+	})
+})
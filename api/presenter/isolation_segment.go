@@ -0,0 +1,124 @@
+package presenter
+
+import (
+	"net/url"
+	"slices"
+
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/model"
+	"code.cloudfoundry.org/korifi/tools"
+	"github.com/BooleanCat/go-functional/v2/it"
+)
+
+const (
+	isolationSegmentsBase = "/v3/isolation_segments"
+)
+
+type IsolationSegmentResponse struct {
+	Name      string                `json:"name"`
+	GUID      string                `json:"guid"`
+	CreatedAt string                `json:"created_at"`
+	UpdatedAt string                `json:"updated_at"`
+	Metadata  Metadata              `json:"metadata"`
+	Links     IsolationSegmentLinks `json:"links"`
+}
+
+type IsolationSegmentLinks struct {
+	Self          Link `json:"self"`
+	Organizations Link `json:"organizations"`
+}
+
+func ForIsolationSegment(isolationSegment repositories.IsolationSegmentRecord, baseURL url.URL, includes ...model.IncludedResource) IsolationSegmentResponse {
+	return IsolationSegmentResponse{
+		Name:      isolationSegment.Name,
+		GUID:      isolationSegment.GUID,
+		CreatedAt: tools.ZeroIfNil(formatTimestamp(&isolationSegment.CreatedAt)),
+		UpdatedAt: tools.ZeroIfNil(formatTimestamp(isolationSegment.UpdatedAt)),
+		Metadata: Metadata{
+			Labels:      emptyMapIfNil(isolationSegment.Labels),
+			Annotations: emptyMapIfNil(isolationSegment.Annotations),
+		},
+		Links: IsolationSegmentLinks{
+			Self: Link{
+				HRef: buildURL(baseURL).appendPath(isolationSegmentsBase, isolationSegment.GUID).build(),
+			},
+			Organizations: Link{
+				HRef: buildURL(baseURL).appendPath(isolationSegmentsBase, isolationSegment.GUID, "organizations").build(),
+			},
+		},
+	}
+}
+
+type ToManyRelationshipResponse struct {
+	Data  []RelationshipData      `json:"data"`
+	Links ToManyRelationshipLinks `json:"links"`
+}
+
+type ToManyRelationshipLinks struct {
+	Self    Link  `json:"self"`
+	Related *Link `json:"related,omitempty"`
+}
+
+func ForIsolationSegmentOrganizations(isolationSegment repositories.IsolationSegmentRecord, baseURL url.URL) ToManyRelationshipResponse {
+	return ToManyRelationshipResponse{
+		Data: toRelationshipData(isolationSegment.Organizations),
+		Links: ToManyRelationshipLinks{
+			Self: Link{
+				HRef: buildURL(baseURL).appendPath(isolationSegmentsBase, isolationSegment.GUID, "relationships/organizations").build(),
+			},
+			Related: &Link{
+				HRef: buildURL(baseURL).appendPath(isolationSegmentsBase, isolationSegment.GUID, "organizations").build(),
+			},
+		},
+	}
+}
+
+func ForIsolationSegmentSpaces(isolationSegmentGUID string, spaces []repositories.SpaceRecord, baseURL url.URL) ToManyRelationshipResponse {
+	return ToManyRelationshipResponse{
+		Data: toRelationshipData(slices.Collect(it.Map(slices.Values(spaces), func(s repositories.SpaceRecord) string {
+			return s.GUID
+		}))),
+		Links: ToManyRelationshipLinks{
+			Self: Link{
+				HRef: buildURL(baseURL).appendPath(isolationSegmentsBase, isolationSegmentGUID, "relationships/spaces").build(),
+			},
+		},
+	}
+}
+
+type SpaceIsolationSegmentResponse struct {
+	Data  *RelationshipData          `json:"data"`
+	Links SpaceIsolationSegmentLinks `json:"links"`
+}
+
+type SpaceIsolationSegmentLinks struct {
+	Self    Link  `json:"self"`
+	Related *Link `json:"related,omitempty"`
+}
+
+func ForSpaceIsolationSegment(space repositories.SpaceRecord, baseURL url.URL) SpaceIsolationSegmentResponse {
+	response := SpaceIsolationSegmentResponse{
+		Links: SpaceIsolationSegmentLinks{
+			Self: Link{
+				HRef: buildURL(baseURL).appendPath(spacesBase, space.GUID, "relationships/isolation_segment").build(),
+			},
+		},
+	}
+
+	if space.IsolationSegmentGUID != "" {
+		response.Data = &RelationshipData{GUID: space.IsolationSegmentGUID}
+		response.Links.Related = &Link{
+			HRef: buildURL(baseURL).appendPath(isolationSegmentsBase, space.IsolationSegmentGUID).build(),
+		}
+	}
+
+	return response
+}
+
+func toRelationshipData(guids []string) []RelationshipData {
+	data := []RelationshipData{}
+	for _, guid := range guids {
+		data = append(data, RelationshipData{GUID: guid})
+	}
+	return data
+}
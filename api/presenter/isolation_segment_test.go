@@ -0,0 +1,177 @@
+package presenter_test
+
+import (
+	"encoding/json"
+	"net/url"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	. "code.cloudfoundry.org/korifi/tests/matchers"
+	"code.cloudfoundry.org/korifi/tools"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Isolation Segments", func() {
+	var (
+		baseURL *url.URL
+		output  []byte
+		record  repositories.IsolationSegmentRecord
+	)
+
+	BeforeEach(func() {
+		var err error
+		baseURL, err = url.Parse("https://api.example.org")
+		Expect(err).NotTo(HaveOccurred())
+		record = repositories.IsolationSegmentRecord{
+			Name:          "my-iso-seg",
+			GUID:          "iso-seg-guid",
+			Organizations: []string{"org-1", "org-2"},
+			Labels:        map[string]string{"foo": "bar"},
+			Annotations:   map[string]string{"bar": "baz"},
+			CreatedAt:     time.UnixMilli(1000),
+			UpdatedAt:     tools.PtrTo(time.UnixMilli(2000)),
+		}
+	})
+
+	Describe("ForIsolationSegment", func() {
+		JustBeforeEach(func() {
+			var err error
+			output, err = json.Marshal(presenter.ForIsolationSegment(record, *baseURL))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("produces the expected json", func() {
+			Expect(output).To(MatchJSON(`{
+				"name": "my-iso-seg",
+				"guid": "iso-seg-guid",
+				"created_at": "1970-01-01T00:00:01Z",
+				"updated_at": "1970-01-01T00:00:02Z",
+				"metadata": {
+					"labels": {
+						"foo": "bar"
+					},
+					"annotations": {
+						"bar": "baz"
+					}
+				},
+				"links": {
+					"self": {
+						"href": "https://api.example.org/v3/isolation_segments/iso-seg-guid"
+					},
+					"organizations": {
+						"href": "https://api.example.org/v3/isolation_segments/iso-seg-guid/organizations"
+					}
+				}
+			}`))
+		})
+	})
+
+	Describe("ForIsolationSegmentOrganizations", func() {
+		JustBeforeEach(func() {
+			var err error
+			output, err = json.Marshal(presenter.ForIsolationSegmentOrganizations(record, *baseURL))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("produces the expected json", func() {
+			Expect(output).To(MatchJSON(`{
+				"data": [
+					{ "guid": "org-1" },
+					{ "guid": "org-2" }
+				],
+				"links": {
+					"self": {
+						"href": "https://api.example.org/v3/isolation_segments/iso-seg-guid/relationships/organizations"
+					},
+					"related": {
+						"href": "https://api.example.org/v3/isolation_segments/iso-seg-guid/organizations"
+					}
+				}
+			}`))
+		})
+
+		When("the isolation segment is not entitled to any organizations", func() {
+			BeforeEach(func() {
+				record.Organizations = nil
+			})
+
+			It("returns an empty data list", func() {
+				Expect(output).To(MatchJSONPath("$.data", BeEmpty()))
+			})
+		})
+	})
+
+	Describe("ForIsolationSegmentSpaces", func() {
+		JustBeforeEach(func() {
+			var err error
+			output, err = json.Marshal(presenter.ForIsolationSegmentSpaces("iso-seg-guid", []repositories.SpaceRecord{{GUID: "space-1"}}, *baseURL))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("produces the expected json", func() {
+			Expect(output).To(MatchJSON(`{
+				"data": [
+					{ "guid": "space-1" }
+				],
+				"links": {
+					"self": {
+						"href": "https://api.example.org/v3/isolation_segments/iso-seg-guid/relationships/spaces"
+					}
+				}
+			}`))
+		})
+	})
+
+	Describe("ForSpaceIsolationSegment", func() {
+		var space repositories.SpaceRecord
+
+		BeforeEach(func() {
+			space = repositories.SpaceRecord{
+				GUID:                 "space-guid",
+				IsolationSegmentGUID: "iso-seg-guid",
+			}
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			output, err = json.Marshal(presenter.ForSpaceIsolationSegment(space, *baseURL))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("produces the expected json", func() {
+			Expect(output).To(MatchJSON(`{
+				"data": {
+					"guid": "iso-seg-guid"
+				},
+				"links": {
+					"self": {
+						"href": "https://api.example.org/v3/spaces/space-guid/relationships/isolation_segment"
+					},
+					"related": {
+						"href": "https://api.example.org/v3/isolation_segments/iso-seg-guid"
+					}
+				}
+			}`))
+		})
+
+		When("the space is not assigned to an isolation segment", func() {
+			BeforeEach(func() {
+				space.IsolationSegmentGUID = ""
+			})
+
+			It("produces null data and no related link", func() {
+				Expect(output).To(MatchJSON(`{
+					"data": null,
+					"links": {
+						"self": {
+							"href": "https://api.example.org/v3/spaces/space-guid/relationships/isolation_segment"
+						}
+					}
+				}`))
+			})
+		})
+	})
+})
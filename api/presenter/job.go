@@ -4,7 +4,9 @@ import (
 	"fmt"
 	"net/url"
 	"regexp"
+	"strings"
 
+	"code.cloudfoundry.org/korifi/model"
 	"golang.org/x/text/cases"
 	"golang.org/x/text/language"
 )
@@ -29,8 +31,14 @@ const (
 
 	ManagedServiceInstanceCreateOperation = "managed_service_instance.create"
 	ManagedServiceInstanceDeleteOperation = "managed_service_instance.delete"
+	ManagedServiceInstanceUpdateOperation = "managed_service_instance.update"
 	ManagedServiceBindingCreateOperation  = "managed_service_binding.create"
 	ManagedServiceBindingDeleteOperation  = "managed_service_binding.delete"
+
+	ManagedServiceBindingRotateCredentialsOperation = "managed_service_binding.rotate_credentials"
+
+	jobErrorCode  = 10008
+	jobErrorTitle = "CF-UnprocessableEntity"
 )
 
 var (
@@ -67,20 +75,27 @@ type JobResponseError struct {
 	Code   int    `json:"code"`
 }
 
+type JobResponseWarning struct {
+	Detail string `json:"detail"`
+}
+
 type JobResponse struct {
-	GUID      string             `json:"guid"`
-	Errors    []JobResponseError `json:"errors"`
-	Warnings  *string            `json:"warnings"`
-	Operation string             `json:"operation"`
-	State     string             `json:"state"`
-	CreatedAt string             `json:"created_at"`
-	UpdatedAt string             `json:"updated_at"`
-	Links     JobLinks           `json:"links"`
+	GUID      string               `json:"guid"`
+	Errors    []JobResponseError   `json:"errors"`
+	Warnings  []JobResponseWarning `json:"warnings"`
+	Operation string               `json:"operation"`
+	State     string               `json:"state"`
+	CreatedAt string               `json:"created_at"`
+	UpdatedAt string               `json:"updated_at"`
+	Links     JobLinks             `json:"links"`
 }
 
 type JobLinks struct {
-	Self  Link  `json:"self"`
-	Space *Link `json:"space,omitempty"`
+	Self                      Link  `json:"self"`
+	Space                     *Link `json:"space,omitempty"`
+	ServiceBrokers            *Link `json:"service_brokers,omitempty"`
+	ServiceInstances          *Link `json:"service_instances,omitempty"`
+	ServiceCredentialBindings *Link `json:"service_credential_bindings,omitempty"`
 }
 
 func ForManifestApplyJob(job Job, baseURL url.URL) JobResponse {
@@ -95,17 +110,74 @@ func ForJob(job Job, errors []JobResponseError, state string, baseURL url.URL) J
 	return JobResponse{
 		GUID:      job.GUID,
 		Errors:    errors,
-		Warnings:  nil,
+		Warnings:  []JobResponseWarning{},
 		Operation: job.Type,
 		State:     state,
 		CreatedAt: "",
 		UpdatedAt: "",
-		Links: JobLinks{
-			Self: Link{
-				HRef: buildURL(baseURL).appendPath("/v3/jobs", job.GUID).build(),
-			},
+		Links:     jobLinks(job, baseURL),
+	}
+}
+
+// ForOperationJob presents a job whose state, errors and warnings are derived
+// from the last operation recorded in the status of the job resource
+func ForOperationJob(job Job, operation model.CFResourceOperation, baseURL url.URL) JobResponse {
+	errors := []JobResponseError{}
+	for _, detail := range operation.Errors {
+		errors = append(errors, JobResponseError{
+			Detail: detail,
+			Title:  jobErrorTitle,
+			Code:   jobErrorCode,
+		})
+	}
+
+	response := ForJob(job, errors, jobState(operation.State), baseURL)
+	for _, detail := range operation.Warnings {
+		response.Warnings = append(response.Warnings, JobResponseWarning{Detail: detail})
+	}
+
+	return response
+}
+
+func jobState(state model.CFResourceState) string {
+	switch state {
+	case model.CFResourceStateReady:
+		return StateComplete
+	case model.CFResourceStateFailed:
+		return StateFailed
+	default:
+		return StateProcessing
+	}
+}
+
+func jobLinks(job Job, baseURL url.URL) JobLinks {
+	links := JobLinks{
+		Self: Link{
+			HRef: buildURL(baseURL).appendPath("/v3/jobs", job.GUID).build(),
 		},
 	}
+
+	// Deleted resources cannot be linked to
+	if strings.HasSuffix(job.Type, ".delete") {
+		return links
+	}
+
+	switch {
+	case strings.HasPrefix(job.Type, "service_broker."):
+		links.ServiceBrokers = &Link{
+			HRef: buildURL(baseURL).appendPath(serviceBrokersBase, job.ResourceGUID).build(),
+		}
+	case strings.HasPrefix(job.Type, "managed_service_instance."):
+		links.ServiceInstances = &Link{
+			HRef: buildURL(baseURL).appendPath(serviceInstancesBase, job.ResourceGUID).build(),
+		}
+	case strings.HasPrefix(job.Type, "managed_service_binding."):
+		links.ServiceCredentialBindings = &Link{
+			HRef: buildURL(baseURL).appendPath(serviceCredentialBindingsBase, job.ResourceGUID).build(),
+		}
+	}
+
+	return links
 }
 
 func JobURLForRedirects(resourceGUID string, operation string, baseURL url.URL) string {
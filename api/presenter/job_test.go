@@ -5,6 +5,8 @@ import (
 	"net/url"
 
 	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/model"
+	. "code.cloudfoundry.org/korifi/tests/matchers"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 )
@@ -75,7 +77,7 @@ var _ = Describe("", func() {
 				"operation": "space.apply_manifest",
 				"state": "COMPLETE",
 				"updated_at": "",
-				"warnings": null
+				"warnings": []
 			}`))
 		})
 	})
@@ -114,11 +116,119 @@ var _ = Describe("", func() {
 				"operation": "the.operation",
 				"state": "COMPLETE",
 				"updated_at": "",
-				"warnings": null
+				"warnings": []
 			}`))
 		})
 	})
 
+	Describe("ForOperationJob", func() {
+		var (
+			job       presenter.Job
+			operation model.CFResourceOperation
+		)
+
+		BeforeEach(func() {
+			job = presenter.Job{
+				GUID:         "the-job-guid",
+				Type:         presenter.ManagedServiceInstanceCreateOperation,
+				ResourceGUID: "the-instance-guid",
+			}
+			operation = model.CFResourceOperation{
+				State:    model.CFResourceStateFailed,
+				Errors:   []string{"provision failed"},
+				Warnings: []string{"broker is slow"},
+			}
+		})
+
+		JustBeforeEach(func() {
+			response := presenter.ForOperationJob(job, operation, *baseURL)
+			var err error
+			output, err = json.Marshal(response)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("renders the job", func() {
+			Expect(output).To(MatchJSON(`{
+				"created_at": "",
+				"errors": [
+					{
+						"code": 10008,
+						"detail": "provision failed",
+						"title": "CF-UnprocessableEntity"
+					}
+				],
+				"guid": "the-job-guid",
+				"links": {
+					"self": {
+						"href": "https://api.example.org/v3/jobs/the-job-guid"
+					},
+					"service_instances": {
+						"href": "https://api.example.org/v3/service_instances/the-instance-guid"
+					}
+				},
+				"operation": "managed_service_instance.create",
+				"state": "FAILED",
+				"updated_at": "",
+				"warnings": [
+					{
+						"detail": "broker is slow"
+					}
+				]
+			}`))
+		})
+
+		When("the operation is complete", func() {
+			BeforeEach(func() {
+				operation = model.CFResourceOperation{State: model.CFResourceStateReady}
+			})
+
+			It("renders a complete job", func() {
+				Expect(output).To(MatchJSONPath("$.state", "COMPLETE"))
+				Expect(output).To(MatchJSONPath("$.errors", BeEmpty()))
+			})
+		})
+
+		When("the operation is in progress", func() {
+			BeforeEach(func() {
+				operation = model.CFResourceOperation{State: model.CFResourceStateUnknown}
+			})
+
+			It("renders a processing job", func() {
+				Expect(output).To(MatchJSONPath("$.state", "PROCESSING"))
+			})
+		})
+
+		When("the job is a service broker job", func() {
+			BeforeEach(func() {
+				job.Type = presenter.ServiceBrokerCreateOperation
+			})
+
+			It("links the service broker", func() {
+				Expect(output).To(MatchJSONPath("$.links.service_brokers.href", "https://api.example.org/v3/service_brokers/the-instance-guid"))
+			})
+		})
+
+		When("the job is a service binding job", func() {
+			BeforeEach(func() {
+				job.Type = presenter.ManagedServiceBindingCreateOperation
+			})
+
+			It("links the service credential binding", func() {
+				Expect(output).To(MatchJSONPath("$.links.service_credential_bindings.href", "https://api.example.org/v3/service_credential_bindings/the-instance-guid"))
+			})
+		})
+
+		When("the job is a delete job", func() {
+			BeforeEach(func() {
+				job.Type = presenter.ManagedServiceInstanceDeleteOperation
+			})
+
+			It("does not link the deleted resource", func() {
+				Expect(output).NotTo(ContainSubstring("service_instances"))
+			})
+		})
+	})
+
 	Describe("JobURLForRedirects", func() {
 	})
 })
@@ -120,6 +120,10 @@ func ForStats(appRecord repositories.AppRecord, appPodStats []actions.PodStatsRe
 						Unit:  "percentage",
 						Value: GaugeFloat(tools.ZeroIfNil(podStats.Usage.CPU)),
 					},
+					"cpu_entitlement": {
+						Unit:  "percentage",
+						Value: GaugeFloat(tools.ZeroIfNil(podStats.Usage.CPUEntitlement)),
+					},
 					"memory": {
 						Unit:  "bytes",
 						Value: GaugeInt(tools.ZeroIfNil(podStats.Usage.Mem)),
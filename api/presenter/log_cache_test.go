@@ -88,10 +88,11 @@ var _ = Describe("ForStats", func() {
 			ProcessGUID: "process-guid",
 			Index:       1,
 			Usage: actions.Usage{
-				Timestamp: tools.PtrTo(time.UnixMilli(1000).UTC()),
-				CPU:       tools.PtrTo(1e-05),
-				Mem:       tools.PtrTo[int64](2),
-				Disk:      tools.PtrTo[int64](3),
+				Timestamp:      tools.PtrTo(time.UnixMilli(1000).UTC()),
+				CPU:            tools.PtrTo(1e-05),
+				CPUEntitlement: tools.PtrTo(0.01),
+				Mem:            tools.PtrTo[int64](2),
+				Disk:           tools.PtrTo[int64](3),
 			},
 			MemQuota:  tools.PtrTo[int64](4),
 			DiskQuota: tools.PtrTo[int64](5),
@@ -126,6 +127,10 @@ var _ = Describe("ForStats", func() {
 					  "unit": "percentage",
 					  "value": 0.00001
 					},
+					"cpu_entitlement": {
+					  "unit": "percentage",
+					  "value": 0.01
+					},
 					"disk": {
 					  "unit": "bytes",
 					  "value": 3
@@ -0,0 +1,117 @@
+package presenter
+
+import (
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/oauth"
+)
+
+type OAuthTokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	TokenType    string `json:"token_type"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+	ExpiresIn    int64  `json:"expires_in"`
+	JTI          string `json:"jti"`
+}
+
+func ForOAuthToken(token oauth.Token) OAuthTokenResponse {
+	return OAuthTokenResponse{
+		AccessToken:  token.AccessToken,
+		TokenType:    "bearer",
+		RefreshToken: token.RefreshToken,
+		ExpiresIn:    int64(token.ExpiresIn.Seconds()),
+		JTI:          token.JTI,
+	}
+}
+
+type OAuthErrorResponse struct {
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+func ForOAuthError(err oauth.Error) OAuthErrorResponse {
+	return OAuthErrorResponse{
+		Error:            err.Code,
+		ErrorDescription: err.Description,
+	}
+}
+
+type TokenKeysResponse struct {
+	Keys []TokenKeyResponse `json:"keys"`
+}
+
+// TokenKeyResponse is a JSON web key along with its PEM encoding, as served by UAA
+type TokenKeyResponse struct {
+	Kty   string `json:"kty"`
+	Alg   string `json:"alg"`
+	Use   string `json:"use"`
+	Kid   string `json:"kid"`
+	N     string `json:"n"`
+	E     string `json:"e"`
+	Value string `json:"value"`
+}
+
+func ForTokenKeys(keys []oauth.PublicKey) TokenKeysResponse {
+	response := TokenKeysResponse{Keys: []TokenKeyResponse{}}
+	for _, key := range keys {
+		// marshalling RSA public keys cannot fail
+		publicKeyBytes, _ := x509.MarshalPKIXPublicKey(key.Key)
+
+		response.Keys = append(response.Keys, TokenKeyResponse{
+			Kty: "RSA",
+			Alg: "RS256",
+			Use: "sig",
+			Kid: key.ID,
+			N:   base64.RawURLEncoding.EncodeToString(key.Key.N.Bytes()),
+			E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.Key.E)).Bytes()),
+			Value: string(pem.EncodeToMemory(&pem.Block{
+				Type:  "PUBLIC KEY",
+				Bytes: publicKeyBytes,
+			})),
+		})
+	}
+
+	return response
+}
+
+type LoginResponse struct {
+	Links   map[string]string   `json:"links"`
+	Prompts map[string][]string `json:"prompts"`
+}
+
+func ForLogin(baseURL url.URL, ssoEnabled bool) LoginResponse {
+	response := LoginResponse{
+		Links: map[string]string{
+			"uaa":   buildURL(baseURL).build(),
+			"login": buildURL(baseURL).build(),
+		},
+		Prompts: map[string][]string{
+			"username": {"text", "Username"},
+			"password": {"password", "Password"},
+		},
+	}
+
+	if ssoEnabled {
+		response.Prompts["passcode"] = []string{
+			"password",
+			fmt.Sprintf("Temporary Authentication Code ( Get one at %s )", buildURL(baseURL).appendPath("passcode").build()),
+		}
+	}
+
+	return response
+}
+
+type PasscodeResponse struct {
+	Passcode string `json:"passcode"`
+}
+
+func ForPasscode(passcode string) PasscodeResponse {
+	return PasscodeResponse{
+		Passcode: passcode,
+	}
+}
@@ -0,0 +1,133 @@
+package presenter_test
+
+import (
+	"crypto/rsa"
+	"encoding/json"
+	"math/big"
+	"net/url"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/oauth"
+	"code.cloudfoundry.org/korifi/api/presenter"
+	. "code.cloudfoundry.org/korifi/tests/matchers"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("OAuth", func() {
+	var output []byte
+
+	Describe("ForOAuthToken", func() {
+		BeforeEach(func() {
+			var err error
+			output, err = json.Marshal(presenter.ForOAuthToken(oauth.Token{
+				AccessToken:  "access",
+				RefreshToken: "refresh",
+				ExpiresIn:    time.Hour,
+				JTI:          "jti",
+			}))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("produces the expected json", func() {
+			Expect(output).To(MatchJSON(`{
+				"access_token": "access",
+				"token_type": "bearer",
+				"refresh_token": "refresh",
+				"expires_in": 3600,
+				"jti": "jti"
+			}`))
+		})
+	})
+
+	Describe("ForOAuthError", func() {
+		BeforeEach(func() {
+			var err error
+			output, err = json.Marshal(presenter.ForOAuthError(oauth.NewInvalidGrantError("bad credentials")))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("produces the expected json", func() {
+			Expect(output).To(MatchJSON(`{
+				"error": "invalid_grant",
+				"error_description": "bad credentials"
+			}`))
+		})
+	})
+
+	Describe("ForTokenKeys", func() {
+		BeforeEach(func() {
+			var err error
+			output, err = json.Marshal(presenter.ForTokenKeys([]oauth.PublicKey{{
+				ID:  "key-1",
+				Key: &rsa.PublicKey{N: big.NewInt(0xabcdef), E: 65537},
+			}}))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("produces the expected json", func() {
+			Expect(output).To(MatchJSONPath("$.keys[0].kty", "RSA"))
+			Expect(output).To(MatchJSONPath("$.keys[0].alg", "RS256"))
+			Expect(output).To(MatchJSONPath("$.keys[0].use", "sig"))
+			Expect(output).To(MatchJSONPath("$.keys[0].kid", "key-1"))
+			Expect(output).To(MatchJSONPath("$.keys[0].n", "q83v"))
+			Expect(output).To(MatchJSONPath("$.keys[0].e", "AQAB"))
+			Expect(output).To(MatchJSONPath("$.keys[0].value", HavePrefix("-----BEGIN PUBLIC KEY-----")))
+		})
+	})
+
+	Describe("ForLogin", func() {
+		var ssoEnabled bool
+
+		BeforeEach(func() {
+			ssoEnabled = false
+		})
+
+		JustBeforeEach(func() {
+			baseURL, err := url.Parse("https://api.example.org")
+			Expect(err).NotTo(HaveOccurred())
+
+			output, err = json.Marshal(presenter.ForLogin(*baseURL, ssoEnabled))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		When("sso is enabled", func() {
+			BeforeEach(func() {
+				ssoEnabled = true
+			})
+
+			It("adds the passcode prompt", func() {
+				Expect(output).To(MatchJSONPath("$.prompts.passcode", ConsistOf(
+					"password",
+					"Temporary Authentication Code ( Get one at https://api.example.org/passcode )",
+				)))
+			})
+		})
+
+		It("produces the expected json", func() {
+			Expect(output).To(MatchJSON(`{
+				"links": {
+					"uaa": "https://api.example.org",
+					"login": "https://api.example.org"
+				},
+				"prompts": {
+					"username": ["text", "Username"],
+					"password": ["password", "Password"]
+				}
+			}`))
+		})
+	})
+
+	Describe("ForPasscode", func() {
+		BeforeEach(func() {
+			var err error
+			output, err = json.Marshal(presenter.ForPasscode("the-passcode"))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("produces the expected json", func() {
+			Expect(output).To(MatchJSON(`{"passcode": "the-passcode"}`))
+		})
+	})
+})
@@ -21,6 +21,7 @@ type ProcessResponse struct {
 	Instances     int32                              `json:"instances"`
 	MemoryMB      int64                              `json:"memory_in_mb"`
 	DiskQuotaMB   int64                              `json:"disk_in_mb"`
+	CPUMillicores int64                              `json:"cpu_in_millicores"`
 	HealthCheck   ProcessResponseHealthCheck         `json:"health_check"`
 	Relationships map[string]model.ToOneRelationship `json:"relationships"`
 	Metadata      Metadata                           `json:"metadata"`
@@ -100,12 +101,13 @@ type ProcessResponseProcessHealthCheckData struct {
 
 func ForProcess(responseProcess repositories.ProcessRecord, baseURL url.URL) ProcessResponse {
 	return ProcessResponse{
-		GUID:        responseProcess.GUID,
-		Type:        responseProcess.Type,
-		Command:     responseProcess.Command,
-		Instances:   responseProcess.DesiredInstances,
-		MemoryMB:    responseProcess.MemoryMB,
-		DiskQuotaMB: responseProcess.DiskQuotaMB,
+		GUID:          responseProcess.GUID,
+		Type:          responseProcess.Type,
+		Command:       responseProcess.Command,
+		Instances:     responseProcess.DesiredInstances,
+		MemoryMB:      responseProcess.MemoryMB,
+		DiskQuotaMB:   responseProcess.DiskQuotaMB,
+		CPUMillicores: responseProcess.CPUMillicores,
 		HealthCheck: ProcessResponseHealthCheck{
 			Type: string(responseProcess.HealthCheck.Type),
 			Data: ProcessResponseHealthCheckData{
@@ -150,3 +152,61 @@ func ForProcessList(processRecordList []repositories.ProcessRecord, baseURL, req
 		return processResponse
 	}, processRecordList, baseURL, requestURL)
 }
+
+type ProcessAutoscalingPolicyResponse struct {
+	MinInstances      int32                                `json:"min_instances"`
+	MaxInstances      int32                                `json:"max_instances"`
+	CPUUtilization    *int32                               `json:"cpu_utilization"`
+	MemoryUtilization *int32                               `json:"memory_utilization"`
+	Schedules         []ProcessAutoscalingScheduleResponse `json:"schedules"`
+	Links             ProcessAutoscalingPolicyLinks        `json:"links"`
+}
+
+type ProcessAutoscalingScheduleResponse struct {
+	StartTime    string  `json:"start_time"`
+	EndTime      string  `json:"end_time"`
+	DaysOfWeek   []int32 `json:"days_of_week"`
+	TimeZone     string  `json:"time_zone"`
+	MinInstances int32   `json:"min_instances"`
+	MaxInstances int32   `json:"max_instances"`
+}
+
+type ProcessAutoscalingPolicyLinks struct {
+	Self    Link `json:"self"`
+	Process Link `json:"process"`
+}
+
+func ForProcessAutoscalingPolicy(processGUID string, policy repositories.AutoscalingPolicy, baseURL url.URL) ProcessAutoscalingPolicyResponse {
+	schedules := []ProcessAutoscalingScheduleResponse{}
+	for _, schedule := range policy.Schedules {
+		daysOfWeek := schedule.DaysOfWeek
+		if daysOfWeek == nil {
+			daysOfWeek = []int32{}
+		}
+
+		schedules = append(schedules, ProcessAutoscalingScheduleResponse{
+			StartTime:    schedule.StartTime,
+			EndTime:      schedule.EndTime,
+			DaysOfWeek:   daysOfWeek,
+			TimeZone:     schedule.TimeZone,
+			MinInstances: schedule.MinInstances,
+			MaxInstances: schedule.MaxInstances,
+		})
+	}
+
+	return ProcessAutoscalingPolicyResponse{
+		MinInstances:      policy.MinInstances,
+		MaxInstances:      policy.MaxInstances,
+		CPUUtilization:    policy.CPUUtilization,
+		MemoryUtilization: policy.MemoryUtilization,
+		Schedules:         schedules,
+		Links: ProcessAutoscalingPolicyLinks{
+			Self: Link{
+				HRef: buildURL(baseURL).appendPath(processesBase, processGUID, "autoscaling_policy").build(),
+			},
+			Process: Link{
+				HRef: buildURL(baseURL).appendPath(processesBase, processGUID).build(),
+			},
+		},
+	}
+}
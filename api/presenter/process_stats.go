@@ -25,10 +25,11 @@ type ProcessStatsResource struct {
 }
 
 type ProcessUsage struct {
-	Time *string  `json:"time,omitempty"`
-	CPU  *float64 `json:"cpu,omitempty"`
-	Mem  *int64   `json:"mem,omitempty"`
-	Disk *int64   `json:"disk,omitempty"`
+	Time           *string  `json:"time,omitempty"`
+	CPU            *float64 `json:"cpu,omitempty"`
+	CPUEntitlement *float64 `json:"cpu_entitlement,omitempty"`
+	Mem            *int64   `json:"mem,omitempty"`
+	Disk           *int64   `json:"disk,omitempty"`
 }
 
 func ForProcessStats(gauges []stats.ProcessGauges, instancesState []stats.ProcessInstanceState, now time.Time) ProcessStatsResponse {
@@ -48,10 +49,11 @@ func ForProcessStats(gauges []stats.ProcessGauges, instancesState []stats.Proces
 
 		if gauge, hasGauge := gaugesMap[instanceState.ID]; hasGauge {
 			statsResource.Usage = tools.PtrTo(ProcessUsage{
-				Time: formatTimestamp(tools.PtrTo(now)),
-				CPU:  gauge.CPU,
-				Mem:  gauge.Mem,
-				Disk: gauge.Disk,
+				Time:           formatTimestamp(tools.PtrTo(now)),
+				CPU:            gauge.CPU,
+				CPUEntitlement: gauge.CPUEntitlement,
+				Mem:            gauge.Mem,
+				Disk:           gauge.Disk,
 			})
 			statsResource.MemQuota = gauge.MemQuota
 			statsResource.DiskQuota = gauge.DiskQuota
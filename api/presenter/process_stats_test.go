@@ -23,12 +23,13 @@ var _ = Describe("Process Stats", func() {
 
 	BeforeEach(func() {
 		gauges = []stats.ProcessGauges{{
-			Index:     0,
-			CPU:       tools.PtrTo(500.0),
-			Mem:       tools.PtrTo(int64(512)),
-			Disk:      tools.PtrTo(int64(256)),
-			MemQuota:  tools.PtrTo(int64(1024)),
-			DiskQuota: tools.PtrTo(int64(2048)),
+			Index:          0,
+			CPU:            tools.PtrTo(500.0),
+			CPUEntitlement: tools.PtrTo(25.0),
+			Mem:            tools.PtrTo(int64(512)),
+			Disk:           tools.PtrTo(int64(256)),
+			MemQuota:       tools.PtrTo(int64(1024)),
+			DiskQuota:      tools.PtrTo(int64(2048)),
 		}, {
 			Index:     1,
 			CPU:       tools.PtrTo(501.0),
@@ -73,6 +74,7 @@ var _ = Describe("Process Stats", func() {
 					"usage": {
 						"time": "1970-01-01T00:00:10Z",
 						"cpu": 500,
+						"cpu_entitlement": 25,
 						"mem": 512,
 						"disk": 256
 					}
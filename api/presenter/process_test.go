@@ -37,6 +37,7 @@ var _ = Describe("Process", func() {
 				DesiredInstances: 5,
 				MemoryMB:         256,
 				DiskQuotaMB:      1024,
+				CPUMillicores:    500,
 				HealthCheck: repositories.HealthCheck{
 					Type: "port",
 				},
@@ -66,6 +67,7 @@ var _ = Describe("Process", func() {
 				"instances": 5,
 				"memory_in_mb": 256,
 				"disk_in_mb": 1024,
+				"cpu_in_millicores": 500,
 				"health_check": {
 					"type": "port",
 					"data": {
@@ -111,4 +113,71 @@ var _ = Describe("Process", func() {
 			}`))
 		})
 	})
+
+	Describe("Process Autoscaling Policy Response", func() {
+		var policy repositories.AutoscalingPolicy
+
+		BeforeEach(func() {
+			policy = repositories.AutoscalingPolicy{
+				MinInstances:   1,
+				MaxInstances:   5,
+				CPUUtilization: tools.PtrTo[int32](70),
+				Schedules: []repositories.AutoscalingSchedule{{
+					StartTime:    "08:00",
+					EndTime:      "18:00",
+					DaysOfWeek:   []int32{1, 2},
+					TimeZone:     "Europe/Berlin",
+					MinInstances: 3,
+					MaxInstances: 10,
+				}, {
+					StartTime:    "22:00",
+					EndTime:      "02:00",
+					MinInstances: 1,
+					MaxInstances: 1,
+				}},
+			}
+		})
+
+		JustBeforeEach(func() {
+			response := presenter.ForProcessAutoscalingPolicy("process-guid", policy, *baseURL)
+			var err error
+			output, err = json.Marshal(response)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("produces the expected JSON", func() {
+			Expect(output).To(MatchJSON(`{
+				"min_instances": 1,
+				"max_instances": 5,
+				"cpu_utilization": 70,
+				"memory_utilization": null,
+				"schedules": [
+					{
+						"start_time": "08:00",
+						"end_time": "18:00",
+						"days_of_week": [1, 2],
+						"time_zone": "Europe/Berlin",
+						"min_instances": 3,
+						"max_instances": 10
+					},
+					{
+						"start_time": "22:00",
+						"end_time": "02:00",
+						"days_of_week": [],
+						"time_zone": "",
+						"min_instances": 1,
+						"max_instances": 1
+					}
+				],
+				"links": {
+					"self": {
+						"href": "https://api.example.org/v3/processes/process-guid/autoscaling_policy"
+					},
+					"process": {
+						"href": "https://api.example.org/v3/processes/process-guid"
+					}
+				}
+			}`))
+		})
+	})
 })
@@ -6,6 +6,7 @@ import (
 	"code.cloudfoundry.org/korifi/api/repositories"
 	"code.cloudfoundry.org/korifi/model"
 	"code.cloudfoundry.org/korifi/tools"
+	rbacv1 "k8s.io/api/rbac/v1"
 )
 
 const (
@@ -23,7 +24,7 @@ type RoleResponse struct {
 
 type RoleLinks struct {
 	Self         *Link `json:"self"`
-	User         *Link `json:"user"`
+	User         *Link `json:"user,omitempty"`
 	Space        *Link `json:"space,omitempty"`
 	Organization *Link `json:"organization,omitempty"`
 }
@@ -39,12 +40,16 @@ func ForRole(role repositories.RoleRecord, apiBaseURL url.URL, includes ...model
 			Self: &Link{
 				HRef: buildURL(apiBaseURL).appendPath(rolesBase, role.GUID).build(),
 			},
-			User: &Link{
-				HRef: buildURL(apiBaseURL).appendPath(usersBase, role.User).build(),
-			},
 		},
 	}
 
+	// groups are not CF users, so there is no user to link to
+	if role.Kind != rbacv1.GroupKind {
+		resp.Links.User = &Link{
+			HRef: buildURL(apiBaseURL).appendPath(usersBase, role.User).build(),
+		}
+	}
+
 	if role.Org != "" {
 		resp.Links.Organization = &Link{
 			HRef: buildURL(apiBaseURL).appendPath(orgsBase, role.Org).build(),
@@ -12,6 +12,7 @@ import (
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	rbacv1 "k8s.io/api/rbac/v1"
 )
 
 var _ = Describe("Role", func() {
@@ -66,6 +67,19 @@ var _ = Describe("Role", func() {
 		}`))
 	})
 
+	When("presenting a group role", func() {
+		BeforeEach(func() {
+			record.User = "the-group"
+			record.Kind = rbacv1.GroupKind
+		})
+
+		It("relates the role to the group", func() {
+			Expect(output).To(MatchJSONPath("$.relationships.group.data.guid", "the-group"))
+			Expect(output).To(MatchJSONPathError("$.relationships.user", MatchError("unknown key user")))
+			Expect(output).To(MatchJSONPathError("$.links.user", MatchError("unknown key user")))
+		})
+	})
+
 	When("presenting an org role", func() {
 		BeforeEach(func() {
 			record.Org = "the-org-guid"
@@ -18,12 +18,14 @@ type ServiceBrokerLinks struct {
 
 type ServiceBrokerResponse struct {
 	repositories.ServiceBrokerRecord
-	Links ServiceBrokerLinks `json:"links"`
+	Relationships map[string]model.ToOneRelationship `json:"relationships"`
+	Links         ServiceBrokerLinks                 `json:"links"`
 }
 
 func ForServiceBroker(serviceBrokerRecord repositories.ServiceBrokerRecord, baseURL url.URL, includes ...model.IncludedResource) ServiceBrokerResponse {
 	return ServiceBrokerResponse{
 		serviceBrokerRecord,
+		ForRelationships(serviceBrokerRecord.Relationships()),
 		ServiceBrokerLinks{
 			Self: Link{
 				HRef: buildURL(baseURL).appendPath(serviceBrokersBase, serviceBrokerRecord.GUID).build(),
@@ -9,6 +9,7 @@ import (
 	"code.cloudfoundry.org/korifi/api/repositories"
 	"code.cloudfoundry.org/korifi/model"
 	"code.cloudfoundry.org/korifi/model/services"
+	. "code.cloudfoundry.org/korifi/tests/matchers"
 	"code.cloudfoundry.org/korifi/tools"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -68,6 +69,7 @@ var _ = Describe("Service Broker", func() {
 				"annotation": "broker-annotation"
 			  }
 			},
+			"relationships": {},
 			"links": {
 			  "self": {
 				"href": "https://api.example.org/v3/service_brokers/resource-guid"
@@ -78,4 +80,14 @@ var _ = Describe("Service Broker", func() {
 			}
 		}`))
 	})
+
+	When("the broker is space scoped", func() {
+		BeforeEach(func() {
+			record.SpaceGUID = "space-guid"
+		})
+
+		It("includes the space relationship", func() {
+			Expect(output).To(MatchJSONPath("$.relationships.space.data.guid", "space-guid"))
+		})
+	})
 })
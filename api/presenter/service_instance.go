@@ -4,6 +4,7 @@ import (
 	"net/url"
 
 	"code.cloudfoundry.org/korifi/api/repositories"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	"code.cloudfoundry.org/korifi/model"
 	"code.cloudfoundry.org/korifi/model/services"
 	"code.cloudfoundry.org/korifi/tools"
@@ -53,7 +54,7 @@ func ForServiceInstance(serviceInstanceRecord repositories.ServiceInstanceRecord
 	response := ServiceInstanceResponse{
 		Name: serviceInstanceRecord.Name,
 		GUID: serviceInstanceRecord.GUID,
-		Type: serviceInstanceRecord.Type,
+		Type: presentedInstanceType(serviceInstanceRecord.Type),
 		Tags: emptySliceIfNil(serviceInstanceRecord.Tags),
 		LastOperation: lastOperation{
 			CreatedAt:   tools.ZeroIfNil(formatTimestamp(&serviceInstanceRecord.CreatedAt)),
@@ -96,3 +97,14 @@ func ForServiceInstance(serviceInstanceRecord repositories.ServiceInstanceRecord
 
 	return response
 }
+
+// CF clients only know managed and user-provided service instances.
+// Kubernetes service instances get their credentials from outside of CF, just
+// like user-provided ones, so they are presented as such.
+func presentedInstanceType(instanceType string) string {
+	if instanceType == korifiv1alpha1.KubernetesType {
+		return korifiv1alpha1.UserProvidedType
+	}
+
+	return instanceType
+}
@@ -129,6 +129,16 @@ var _ = Describe("Service Instance", func() {
 		})
 	})
 
+	When("the service instance is of type kubernetes", func() {
+		BeforeEach(func() {
+			record.Type = "kubernetes"
+		})
+
+		It("presents it as user-provided", func() {
+			Expect(output).To(MatchJSONPath("$.type", Equal("user-provided")))
+		})
+	})
+
 	When("the service instance is managed", func() {
 		BeforeEach(func() {
 			record.Type = "managed"
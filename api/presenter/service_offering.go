@@ -22,6 +22,7 @@ type ServiceOfferingLinks struct {
 type ServiceOfferingResponse struct {
 	services.ServiceOffering
 	model.CFResource
+	Available     bool                         `json:"available"`
 	Relationships ServiceOfferingRelationships `json:"relationships"`
 	Links         ServiceOfferingLinks         `json:"links"`
 	Included      map[string][]any             `json:"included,omitempty"`
@@ -35,6 +36,7 @@ func ForServiceOffering(serviceOffering repositories.ServiceOfferingRecord, base
 	return ServiceOfferingResponse{
 		ServiceOffering: serviceOffering.ServiceOffering,
 		CFResource:      serviceOffering.CFResource,
+		Available:       serviceOffering.Available,
 		Relationships: ServiceOfferingRelationships{
 			ServiceBroker: model.ToOneRelationship{
 				Data: model.Relationship{
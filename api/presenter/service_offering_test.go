@@ -61,6 +61,7 @@ var _ = Describe("Service Offering", func() {
 				},
 			},
 			ServiceBrokerGUID: "broker-guid",
+			Available:         true,
 		}
 	})
 
@@ -98,6 +99,7 @@ var _ = Describe("Service Offering", func() {
 			"guid": "resource-guid",
 			"created_at": "1970-01-01T00:00:01Z",
 			"updated_at": "1970-01-01T00:00:02Z",
+			"available": true,
 			"metadata": {
 				"labels": {
 					"label": "label-foo"
@@ -0,0 +1,92 @@
+package presenter
+
+import (
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/repositories"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/model"
+	"code.cloudfoundry.org/korifi/tools"
+)
+
+const (
+	serviceUsageEventsBase = "/v3/service_usage_events"
+)
+
+type ServiceUsageEventResponse struct {
+	GUID            string                     `json:"guid"`
+	CreatedAt       string                     `json:"created_at"`
+	UpdatedAt       string                     `json:"updated_at"`
+	State           string                     `json:"state"`
+	Space           UsageEventResource         `json:"space"`
+	Organization    UsageEventOrganization     `json:"organization"`
+	ServiceInstance UsageEventServiceInstance  `json:"service_instance"`
+	ServicePlan     UsageEventOptionalResource `json:"service_plan"`
+	ServiceOffering UsageEventOptionalResource `json:"service_offering"`
+	ServiceBroker   UsageEventOptionalResource `json:"service_broker"`
+	Links           ServiceUsageEventLinks     `json:"links"`
+}
+
+type UsageEventServiceInstance struct {
+	GUID string `json:"guid"`
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// UsageEventOptionalResource is presented with null fields for user-provided
+// service instances, which have no plan, offering or broker
+type UsageEventOptionalResource struct {
+	GUID *string `json:"guid"`
+	Name *string `json:"name"`
+}
+
+type ServiceUsageEventLinks struct {
+	Self Link `json:"self"`
+}
+
+func ForServiceUsageEvent(event repositories.ServiceUsageEventRecord, baseURL url.URL, includes ...model.IncludedResource) ServiceUsageEventResponse {
+	return ServiceUsageEventResponse{
+		GUID:      event.GUID,
+		CreatedAt: tools.ZeroIfNil(formatTimestamp(&event.CreatedAt)),
+		UpdatedAt: tools.ZeroIfNil(formatTimestamp(event.UpdatedAt)),
+		State:     event.State,
+		Space: UsageEventResource{
+			GUID: event.SpaceGUID,
+			Name: event.SpaceName,
+		},
+		Organization: UsageEventOrganization{
+			GUID: event.OrgGUID,
+		},
+		ServiceInstance: UsageEventServiceInstance{
+			GUID: event.ServiceInstanceGUID,
+			Name: event.ServiceInstanceName,
+			Type: serviceInstanceUsageType(event.ServiceInstanceType),
+		},
+		ServicePlan:     usageEventOptionalResource(event.ServicePlanGUID, event.ServicePlanName),
+		ServiceOffering: usageEventOptionalResource(event.ServiceOfferingGUID, event.ServiceOfferingName),
+		ServiceBroker:   usageEventOptionalResource(event.ServiceBrokerGUID, event.ServiceBrokerName),
+		Links: ServiceUsageEventLinks{
+			Self: Link{
+				HRef: buildURL(baseURL).appendPath(serviceUsageEventsBase, event.GUID).build(),
+			},
+		},
+	}
+}
+
+func serviceInstanceUsageType(instanceType string) string {
+	if instanceType == korifiv1alpha1.ManagedType {
+		return "managed_service_instance"
+	}
+	return "user_provided_service_instance"
+}
+
+func usageEventOptionalResource(guid, name string) UsageEventOptionalResource {
+	if guid == "" {
+		return UsageEventOptionalResource{}
+	}
+
+	return UsageEventOptionalResource{
+		GUID: tools.PtrTo(guid),
+		Name: tools.PtrTo(name),
+	}
+}
@@ -0,0 +1,112 @@
+package presenter_test
+
+import (
+	"encoding/json"
+	"net/url"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	. "code.cloudfoundry.org/korifi/tests/matchers"
+	"code.cloudfoundry.org/korifi/tools"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Service Usage Events", func() {
+	var (
+		baseURL *url.URL
+		output  []byte
+		record  repositories.ServiceUsageEventRecord
+	)
+
+	BeforeEach(func() {
+		var err error
+		baseURL, err = url.Parse("https://api.example.org")
+		Expect(err).NotTo(HaveOccurred())
+		record = repositories.ServiceUsageEventRecord{
+			GUID:                "event-guid",
+			State:               "CREATED",
+			ServiceInstanceGUID: "instance-guid",
+			ServiceInstanceName: "instance-name",
+			ServiceInstanceType: "managed",
+			ServicePlanGUID:     "plan-guid",
+			ServicePlanName:     "plan-name",
+			ServiceOfferingGUID: "offering-guid",
+			ServiceOfferingName: "offering-name",
+			ServiceBrokerGUID:   "broker-guid",
+			ServiceBrokerName:   "broker-name",
+			SpaceGUID:           "space-guid",
+			SpaceName:           "space-name",
+			OrgGUID:             "org-guid",
+			CreatedAt:           time.UnixMilli(1000),
+			UpdatedAt:           tools.PtrTo(time.UnixMilli(2000)),
+		}
+	})
+
+	JustBeforeEach(func() {
+		var err error
+		output, err = json.Marshal(presenter.ForServiceUsageEvent(record, *baseURL))
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("produces the expected json", func() {
+		Expect(output).To(MatchJSON(`{
+			"guid": "event-guid",
+			"created_at": "1970-01-01T00:00:01Z",
+			"updated_at": "1970-01-01T00:00:02Z",
+			"state": "CREATED",
+			"space": {
+				"guid": "space-guid",
+				"name": "space-name"
+			},
+			"organization": {
+				"guid": "org-guid"
+			},
+			"service_instance": {
+				"guid": "instance-guid",
+				"name": "instance-name",
+				"type": "managed_service_instance"
+			},
+			"service_plan": {
+				"guid": "plan-guid",
+				"name": "plan-name"
+			},
+			"service_offering": {
+				"guid": "offering-guid",
+				"name": "offering-name"
+			},
+			"service_broker": {
+				"guid": "broker-guid",
+				"name": "broker-name"
+			},
+			"links": {
+				"self": {
+					"href": "https://api.example.org/v3/service_usage_events/event-guid"
+				}
+			}
+		}`))
+	})
+
+	When("the service instance is user-provided", func() {
+		BeforeEach(func() {
+			record = repositories.ServiceUsageEventRecord{
+				GUID:                "event-guid",
+				State:               "DELETED",
+				ServiceInstanceGUID: "instance-guid",
+				ServiceInstanceName: "instance-name",
+				ServiceInstanceType: "user-provided",
+			}
+		})
+
+		It("renders null plan, offering and broker", func() {
+			Expect(output).To(SatisfyAll(
+				MatchJSONPath("$.service_instance.type", "user_provided_service_instance"),
+				MatchJSONPath("$.service_plan.guid", BeNil()),
+				MatchJSONPath("$.service_offering.name", BeNil()),
+				MatchJSONPath("$.service_broker.guid", BeNil()),
+			))
+		})
+	})
+})
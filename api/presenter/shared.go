@@ -4,8 +4,10 @@ import (
 	"maps"
 	"net/url"
 	"path"
+	"strconv"
 	"time"
 
+	"code.cloudfoundry.org/korifi/api/repositories"
 	"code.cloudfoundry.org/korifi/model"
 	"code.cloudfoundry.org/korifi/tools"
 	"github.com/BooleanCat/go-functional/v2/it"
@@ -52,12 +54,12 @@ type ListResponse[T any] struct {
 }
 
 type PaginationData struct {
-	TotalResults int     `json:"total_results"`
-	TotalPages   int     `json:"total_pages"`
-	First        PageRef `json:"first"`
-	Last         PageRef `json:"last"`
-	Next         *int    `json:"next"`
-	Previous     *int    `json:"previous"`
+	TotalResults int      `json:"total_results"`
+	TotalPages   int      `json:"total_pages"`
+	First        PageRef  `json:"first"`
+	Last         PageRef  `json:"last"`
+	Next         *PageRef `json:"next"`
+	Previous     *PageRef `json:"previous"`
 }
 
 type PageRef struct {
@@ -87,6 +89,37 @@ func ForList[T, S any](itemPresenter itemPresenter[T, S], resources []T, baseURL
 	}
 }
 
+// ForPaginatedList presents a single page of a list, linking the first, last,
+// next and previous pages of the request
+func ForPaginatedList[T, S any](itemPresenter itemPresenter[T, S], result repositories.ListResult[T], baseURL, requestURL url.URL) ListResponse[S] {
+	response := ForList(itemPresenter, result.Records, baseURL, requestURL)
+	pageInfo := result.PageInfo
+
+	pageRef := func(page int) PageRef {
+		query := requestURL.Query()
+		query.Set("page", strconv.Itoa(page))
+		query.Set("per_page", strconv.Itoa(pageInfo.PageSize))
+		return PageRef{
+			HREF: buildURL(baseURL).appendPath(requestURL.Path).setQuery(query.Encode()).build(),
+		}
+	}
+
+	response.PaginationData = PaginationData{
+		TotalResults: pageInfo.TotalResults,
+		TotalPages:   pageInfo.TotalPages,
+		First:        pageRef(1),
+		Last:         pageRef(pageInfo.TotalPages),
+	}
+	if pageInfo.PageNumber < pageInfo.TotalPages {
+		response.PaginationData.Next = tools.PtrTo(pageRef(pageInfo.PageNumber + 1))
+	}
+	if pageInfo.PageNumber > 1 {
+		response.PaginationData.Previous = tools.PtrTo(pageRef(min(pageInfo.PageNumber-1, pageInfo.TotalPages)))
+	}
+
+	return response
+}
+
 func includedResources(includes ...model.IncludedResource) map[string][]any {
 	resources := map[string][]any{}
 	for _, include := range includes {
@@ -133,9 +166,9 @@ func emptyMapIfNil[V any](m map[string]V) map[string]V {
 	return m
 }
 
-func emptySliceIfNil(m []string) []string {
+func emptySliceIfNil[T any](m []T) []T {
 	if m == nil {
-		return []string{}
+		return []T{}
 	}
 	return m
 }
@@ -26,12 +26,22 @@ type TaskResponse struct {
 	UpdatedAt     string                             `json:"updated_at"`
 	MemoryMB      int64                              `json:"memory_in_mb"`
 	DiskMB        int64                              `json:"disk_in_mb"`
+	LogRateLimit  int64                              `json:"log_rate_limit_in_bytes_per_second"`
+	Timeout       *int64                             `json:"timeout"`
+	Retry         *TaskRetry                         `json:"retry"`
+	Attempts      int32                              `json:"attempts"`
 	State         string                             `json:"state"`
 	Result        TaskResult                         `json:"result"`
 }
 
+type TaskRetry struct {
+	MaxAttempts           int32   `json:"max_attempts"`
+	NonRetryableExitCodes []int32 `json:"non_retryable_exit_codes"`
+}
+
 type TaskResult struct {
-	FailureReason *string `json:"failure_reason"`
+	FailureReason     *string `json:"failure_reason"`
+	LastFailureReason *string `json:"last_failure_reason"`
 }
 
 type TaskLinks struct {
@@ -48,18 +58,39 @@ func ForTask(responseTask repositories.TaskRecord, baseURL url.URL, includes ...
 		result.FailureReason = &responseTask.FailureReason
 	}
 
+	if responseTask.LastFailureReason != "" {
+		result.LastFailureReason = &responseTask.LastFailureReason
+	}
+
+	var timeout *int64
+	if responseTask.TimeoutSeconds > 0 {
+		timeout = &responseTask.TimeoutSeconds
+	}
+
+	var retry *TaskRetry
+	if responseTask.Retry != nil {
+		retry = &TaskRetry{
+			MaxAttempts:           responseTask.Retry.MaxAttempts,
+			NonRetryableExitCodes: emptySliceIfNil(responseTask.Retry.NonRetryableExitCodes),
+		}
+	}
+
 	return TaskResponse{
-		Name:        responseTask.Name,
-		GUID:        responseTask.GUID,
-		Command:     responseTask.Command,
-		SequenceID:  responseTask.SequenceID,
-		DropletGUID: responseTask.DropletGUID,
-		CreatedAt:   tools.ZeroIfNil(formatTimestamp(&responseTask.CreatedAt)),
-		UpdatedAt:   tools.ZeroIfNil(formatTimestamp(responseTask.UpdatedAt)),
-		MemoryMB:    responseTask.MemoryMB,
-		DiskMB:      responseTask.DiskMB,
-		State:       responseTask.State,
-		Result:      result,
+		Name:         responseTask.Name,
+		GUID:         responseTask.GUID,
+		Command:      responseTask.Command,
+		SequenceID:   responseTask.SequenceID,
+		DropletGUID:  responseTask.DropletGUID,
+		CreatedAt:    tools.ZeroIfNil(formatTimestamp(&responseTask.CreatedAt)),
+		UpdatedAt:    tools.ZeroIfNil(formatTimestamp(responseTask.UpdatedAt)),
+		MemoryMB:     responseTask.MemoryMB,
+		DiskMB:       responseTask.DiskMB,
+		LogRateLimit: responseTask.LogRateLimitBytesPerSecond,
+		Timeout:      timeout,
+		Retry:        retry,
+		Attempts:     responseTask.Attempts,
+		State:        responseTask.State,
+		Result:       result,
 		Metadata: Metadata{
 			Labels:      emptyMapIfNil(responseTask.Labels),
 			Annotations: emptyMapIfNil(responseTask.Annotations),
@@ -0,0 +1,79 @@
+package presenter
+
+import (
+	"net/url"
+	"strings"
+
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/model"
+	"code.cloudfoundry.org/korifi/tools"
+)
+
+const (
+	taskSchedulesBase = "/v3/task_schedules"
+)
+
+type TaskScheduleResponse struct {
+	Name              string                             `json:"name"`
+	GUID              string                             `json:"guid"`
+	Command           string                             `json:"command"`
+	Schedule          string                             `json:"schedule"`
+	ConcurrencyPolicy string                             `json:"concurrency_policy"`
+	Suspended         bool                               `json:"suspended"`
+	MemoryMB          *int64                             `json:"memory_in_mb"`
+	DiskMB            *int64                             `json:"disk_in_mb"`
+	Timeout           *int64                             `json:"timeout"`
+	LastScheduleTime  *string                            `json:"last_schedule_time"`
+	CreatedAt         string                             `json:"created_at"`
+	UpdatedAt         string                             `json:"updated_at"`
+	Metadata          Metadata                           `json:"metadata"`
+	Relationships     map[string]model.ToOneRelationship `json:"relationships"`
+	Links             TaskScheduleLinks                  `json:"links"`
+}
+
+type TaskScheduleLinks struct {
+	Self  Link `json:"self"`
+	App   Link `json:"app"`
+	Tasks Link `json:"tasks"`
+}
+
+func ForTaskSchedule(record repositories.TaskScheduleRecord, baseURL url.URL, includes ...model.IncludedResource) TaskScheduleResponse {
+	return TaskScheduleResponse{
+		Name:              record.Name,
+		GUID:              record.GUID,
+		Command:           record.Command,
+		Schedule:          record.Schedule,
+		ConcurrencyPolicy: strings.ToLower(record.ConcurrencyPolicy),
+		Suspended:         record.Suspended,
+		MemoryMB:          positiveOrNil(record.MemoryMB),
+		DiskMB:            positiveOrNil(record.DiskMB),
+		Timeout:           positiveOrNil(record.TimeoutSeconds),
+		LastScheduleTime:  formatTimestamp(record.LastScheduleTime),
+		CreatedAt:         tools.ZeroIfNil(formatTimestamp(&record.CreatedAt)),
+		UpdatedAt:         tools.ZeroIfNil(formatTimestamp(record.UpdatedAt)),
+		Metadata: Metadata{
+			Labels:      emptyMapIfNil(record.Labels),
+			Annotations: emptyMapIfNil(record.Annotations),
+		},
+		Relationships: ForRelationships(record.Relationships()),
+		Links: TaskScheduleLinks{
+			Self: Link{
+				HRef: buildURL(baseURL).appendPath(taskSchedulesBase, record.GUID).build(),
+			},
+			App: Link{
+				HRef: buildURL(baseURL).appendPath(appsBase, record.AppGUID).build(),
+			},
+			Tasks: Link{
+				HRef: buildURL(baseURL).appendPath(appsBase, record.AppGUID, "tasks").build(),
+			},
+		},
+	}
+}
+
+func positiveOrNil(value int64) *int64 {
+	if value <= 0 {
+		return nil
+	}
+
+	return &value
+}
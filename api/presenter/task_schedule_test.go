@@ -0,0 +1,102 @@
+package presenter_test
+
+import (
+	"encoding/json"
+	"net/url"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	. "code.cloudfoundry.org/korifi/tests/matchers"
+	"code.cloudfoundry.org/korifi/tools"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("TaskSchedule", func() {
+	var (
+		baseURL *url.URL
+		output  []byte
+		record  repositories.TaskScheduleRecord
+	)
+
+	BeforeEach(func() {
+		var err error
+		baseURL, err = url.Parse("https://api.example.org")
+		Expect(err).NotTo(HaveOccurred())
+		record = repositories.TaskScheduleRecord{
+			Name:              "nightly",
+			GUID:              "schedule-guid",
+			SpaceGUID:         "space-guid",
+			AppGUID:           "app-guid",
+			Command:           "rake db:cleanup",
+			Schedule:          "0 0 * * *",
+			ConcurrencyPolicy: "Forbid",
+			Suspended:         true,
+			MemoryMB:          256,
+			TimeoutSeconds:    600,
+			LastScheduleTime:  tools.PtrTo(time.UnixMilli(3000)),
+			Labels:            map[string]string{"l": "l1"},
+			Annotations:       map[string]string{"a": "a1"},
+			CreatedAt:         time.UnixMilli(1000),
+			UpdatedAt:         tools.PtrTo(time.UnixMilli(2000)),
+		}
+	})
+
+	JustBeforeEach(func() {
+		response := presenter.ForTaskSchedule(record, *baseURL)
+		var err error
+		output, err = json.Marshal(response)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("produces expected task schedule json", func() {
+		Expect(output).To(MatchJSON(`{
+			"name": "nightly",
+			"guid": "schedule-guid",
+			"command": "rake db:cleanup",
+			"schedule": "0 0 * * *",
+			"concurrency_policy": "forbid",
+			"suspended": true,
+			"memory_in_mb": 256,
+			"disk_in_mb": null,
+			"timeout": 600,
+			"last_schedule_time": "1970-01-01T00:00:03Z",
+			"created_at": "1970-01-01T00:00:01Z",
+			"updated_at": "1970-01-01T00:00:02Z",
+			"metadata": {
+				"labels": {"l": "l1"},
+				"annotations": {"a": "a1"}
+			},
+			"relationships": {
+				"app": {
+					"data": {
+						"guid": "app-guid"
+					}
+				}
+			},
+			"links": {
+				"self": {
+					"href": "https://api.example.org/v3/task_schedules/schedule-guid"
+				},
+				"app": {
+					"href": "https://api.example.org/v3/apps/app-guid"
+				},
+				"tasks": {
+					"href": "https://api.example.org/v3/apps/app-guid/tasks"
+				}
+			}
+		}`))
+	})
+
+	When("the schedule has never run", func() {
+		BeforeEach(func() {
+			record.LastScheduleTime = nil
+		})
+
+		It("presents a null last schedule time", func() {
+			Expect(output).To(MatchJSONPath("$.last_schedule_time", BeNil()))
+		})
+	})
+})
@@ -41,6 +41,8 @@ var _ = Describe("Task", func() {
 			DiskMB:        200,
 			State:         "ok",
 			FailureReason: "nope",
+
+			LogRateLimitBytesPerSecond: 1024,
 		}
 	})
 
@@ -61,6 +63,10 @@ var _ = Describe("Task", func() {
 			"updated_at": "1970-01-01T00:00:02Z",
 			"memory_in_mb": 100,
 			"disk_in_mb": 200,
+			"log_rate_limit_in_bytes_per_second": 1024,
+			"timeout": null,
+			"retry": null,
+			"attempts": 0,
 			"droplet_guid": "droplet-guid",
 			"state": "ok",
 			"metadata": {
@@ -75,7 +81,8 @@ var _ = Describe("Task", func() {
 				}
 			},
 			"result": {
-				"failure_reason": "nope"
+				"failure_reason": "nope",
+				"last_failure_reason": null
 			},
 			"links": {
 				"self": {
@@ -95,6 +102,46 @@ var _ = Describe("Task", func() {
 		}`))
 	})
 
+	When("the task has a timeout", func() {
+		BeforeEach(func() {
+			record.TimeoutSeconds = 600
+		})
+
+		It("presents the timeout", func() {
+			Expect(output).To(MatchJSONPath("$.timeout", BeEquivalentTo(600)))
+		})
+	})
+
+	When("the task has a retry policy", func() {
+		BeforeEach(func() {
+			record.Retry = &repositories.TaskRetryPolicy{
+				MaxAttempts:           3,
+				NonRetryableExitCodes: []int32{2},
+			}
+			record.Attempts = 2
+			record.LastFailureReason = "Failed with exit code: 1"
+		})
+
+		It("presents the retry policy and attempts", func() {
+			Expect(output).To(SatisfyAll(
+				MatchJSONPath("$.retry.max_attempts", BeEquivalentTo(3)),
+				MatchJSONPath("$.retry.non_retryable_exit_codes", ConsistOf(BeEquivalentTo(2))),
+				MatchJSONPath("$.attempts", BeEquivalentTo(2)),
+				MatchJSONPath("$.result.last_failure_reason", "Failed with exit code: 1"),
+			))
+		})
+	})
+
+	When("the task is a run of a task schedule", func() {
+		BeforeEach(func() {
+			record.ScheduleGUID = "schedule-guid"
+		})
+
+		It("presents the task schedule relationship", func() {
+			Expect(output).To(MatchJSONPath("$.relationships.task_schedule.data.guid", "schedule-guid"))
+		})
+	})
+
 	When("labels is nil", func() {
 		BeforeEach(func() {
 			record.Labels = nil
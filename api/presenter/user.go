@@ -3,16 +3,44 @@ package presenter
 import (
 	"net/url"
 
+	"code.cloudfoundry.org/korifi/api/repositories"
 	"code.cloudfoundry.org/korifi/model"
+	"code.cloudfoundry.org/korifi/tools"
 )
 
 const usersBase = "/v3/users"
 
 type UserResponse struct {
-	Name string `json:"username"`
-	GUID string `json:"guid"`
+	GUID             string    `json:"guid"`
+	CreatedAt        string    `json:"created_at"`
+	UpdatedAt        string    `json:"updated_at"`
+	Username         string    `json:"username"`
+	PresentationName string    `json:"presentation_name"`
+	Origin           string    `json:"origin"`
+	Metadata         Metadata  `json:"metadata"`
+	Links            UserLinks `json:"links"`
 }
 
-func ForUser(name string, _ url.URL, includes ...model.IncludedResource) UserResponse {
-	return UserResponse{Name: name, GUID: name}
+type UserLinks struct {
+	Self Link `json:"self"`
+}
+
+func ForUser(user repositories.UserRecord, baseURL url.URL, includes ...model.IncludedResource) UserResponse {
+	return UserResponse{
+		GUID:             user.GUID,
+		CreatedAt:        tools.ZeroIfNil(formatTimestamp(&user.CreatedAt)),
+		UpdatedAt:        tools.ZeroIfNil(formatTimestamp(user.UpdatedAt)),
+		Username:         user.Username,
+		PresentationName: user.PresentationName,
+		Origin:           user.Origin,
+		Metadata: Metadata{
+			Labels:      emptyMapIfNil(user.Labels),
+			Annotations: emptyMapIfNil(user.Annotations),
+		},
+		Links: UserLinks{
+			Self: Link{
+				HRef: buildURL(baseURL).appendPath(usersBase, user.GUID).build(),
+			},
+		},
+	}
 }
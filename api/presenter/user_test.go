@@ -3,8 +3,11 @@ package presenter_test
 import (
 	"encoding/json"
 	"net/url"
+	"time"
 
 	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/tools"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -14,18 +17,27 @@ var _ = Describe("User", func() {
 	var (
 		baseURL *url.URL
 		output  []byte
-		name    string
+		record  repositories.UserRecord
 	)
 
 	BeforeEach(func() {
 		var err error
 		baseURL, err = url.Parse("https://api.example.org")
 		Expect(err).NotTo(HaveOccurred())
-		name = "bob"
+		record = repositories.UserRecord{
+			GUID:             "oidc:bob",
+			Username:         "oidc:bob",
+			PresentationName: "Bob",
+			Origin:           "oidc",
+			Labels:           map[string]string{"foo": "bar"},
+			Annotations:      map[string]string{"bar": "baz"},
+			CreatedAt:        time.UnixMilli(1000),
+			UpdatedAt:        tools.PtrTo(time.UnixMilli(2000)),
+		}
 	})
 
 	JustBeforeEach(func() {
-		response := presenter.ForUser(name, *baseURL)
+		response := presenter.ForUser(record, *baseURL)
 		var err error
 		output, err = json.Marshal(response)
 		Expect(err).NotTo(HaveOccurred())
@@ -33,8 +45,25 @@ var _ = Describe("User", func() {
 
 	It("produces expected user json", func() {
 		Expect(output).To(MatchJSON(`{
-			"guid": "bob",
-			"username": "bob"
+			"guid": "oidc:bob",
+			"created_at": "1970-01-01T00:00:01Z",
+			"updated_at": "1970-01-01T00:00:02Z",
+			"username": "oidc:bob",
+			"presentation_name": "Bob",
+			"origin": "oidc",
+			"metadata": {
+				"labels": {
+					"foo": "bar"
+				},
+				"annotations": {
+					"bar": "baz"
+				}
+			},
+			"links": {
+				"self": {
+					"href": "https://api.example.org/v3/users/oidc:bob"
+				}
+			}
 		}`))
 	})
 })
@@ -44,6 +44,7 @@ type AppRepo struct {
 	userClientFactory  authorization.UserClientFactory
 	appAwaiter         Awaiter[*korifiv1alpha1.CFApp]
 	sorter             AppSorter
+	rootNamespace      string
 }
 
 //counterfeiter:generate -o fake -fake-name AppSorter . AppSorter
@@ -94,12 +95,14 @@ func NewAppRepo(
 	userClientFactory authorization.UserClientFactory,
 	appAwaiter Awaiter[*korifiv1alpha1.CFApp],
 	sorter AppSorter,
+	rootNamespace string,
 ) *AppRepo {
 	return &AppRepo{
 		namespaceRetriever: namespaceRetriever,
 		userClientFactory:  userClientFactory,
 		appAwaiter:         appAwaiter,
 		sorter:             sorter,
+		rootNamespace:      rootNamespace,
 	}
 }
 
@@ -166,6 +169,8 @@ type AppEnvRecord struct {
 	AppGUID              string
 	SpaceGUID            string
 	EnvironmentVariables map[string]string
+	StagingEnv           map[string]string
+	RunningEnv           map[string]string
 	SystemEnv            map[string]interface{}
 	AppEnv               map[string]interface{}
 }
@@ -527,10 +532,22 @@ func (f *AppRepo) GetAppEnv(ctx context.Context, authInfo authorization.Info, ap
 		return AppEnvRecord{}, err
 	}
 
+	stagingEnvVarGroup, err := getEnvVarGroup(ctx, userClient, f.rootNamespace, korifiv1alpha1.StagingEnvironmentVariableGroupName)
+	if err != nil {
+		return AppEnvRecord{}, err
+	}
+
+	runningEnvVarGroup, err := getEnvVarGroup(ctx, userClient, f.rootNamespace, korifiv1alpha1.RunningEnvironmentVariableGroupName)
+	if err != nil {
+		return AppEnvRecord{}, err
+	}
+
 	appEnvRecord := AppEnvRecord{
 		AppGUID:              appGUID,
 		SpaceGUID:            app.SpaceGUID,
 		EnvironmentVariables: appEnvVarMap,
+		StagingEnv:           stagingEnvVarGroup.EnvironmentVariables,
+		RunningEnv:           runningEnvVarGroup.EnvironmentVariables,
 		SystemEnv:            systemEnvMap,
 		AppEnv:               appEnvMap,
 	}
@@ -62,7 +62,7 @@ var _ = Describe("AppRepository", func() {
 		userClientFactory = userClientFactory.WithWrappingFunc(func(client client.WithWatch) client.WithWatch {
 			return authorization.NewSpaceFilteringClient(client, k8sClient, nsPerms)
 		})
-		appRepo = repositories.NewAppRepo(namespaceRetriever, userClientFactory, appAwaiter, sorter)
+		appRepo = repositories.NewAppRepo(namespaceRetriever, userClientFactory, appAwaiter, sorter, rootNamespace)
 
 		cfOrg = createOrgWithCleanup(ctx, prefixedGUID("org"))
 		cfSpace = createSpaceWithCleanup(ctx, cfOrg.Name, prefixedGUID("space1"))
@@ -1093,6 +1093,41 @@ var _ = Describe("AppRepository", func() {
 				Expect(appEnvRecord.EnvironmentVariables).To(Equal(envVars))
 				Expect(appEnvRecord.SystemEnv).To(BeEmpty())
 				Expect(appEnvRecord.AppEnv).To(BeEmpty())
+				Expect(appEnvRecord.StagingEnv).To(BeEmpty())
+				Expect(appEnvRecord.RunningEnv).To(BeEmpty())
+			})
+
+			When("the environment variable groups exist", func() {
+				BeforeEach(func() {
+					Expect(k8sClient.Create(ctx, &korifiv1alpha1.CFEnvironmentVariableGroup{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: rootNamespace,
+							Name:      korifiv1alpha1.StagingEnvironmentVariableGroupName,
+						},
+						Spec: korifiv1alpha1.CFEnvironmentVariableGroupSpec{
+							EnvironmentVariables: map[string]string{"STAGING": "true"},
+						},
+					})).To(Succeed())
+					Expect(k8sClient.Create(ctx, &korifiv1alpha1.CFEnvironmentVariableGroup{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: rootNamespace,
+							Name:      korifiv1alpha1.RunningEnvironmentVariableGroupName,
+						},
+						Spec: korifiv1alpha1.CFEnvironmentVariableGroupSpec{
+							EnvironmentVariables: map[string]string{"RUNNING": "true"},
+						},
+					})).To(Succeed())
+				})
+
+				AfterEach(func() {
+					Expect(k8sClient.DeleteAllOf(ctx, &korifiv1alpha1.CFEnvironmentVariableGroup{}, client.InNamespace(rootNamespace))).To(Succeed())
+				})
+
+				It("returns the environment variable groups", func() {
+					Expect(getAppEnvErr).NotTo(HaveOccurred())
+					Expect(appEnvRecord.StagingEnv).To(Equal(map[string]string{"STAGING": "true"}))
+					Expect(appEnvRecord.RunningEnv).To(Equal(map[string]string{"RUNNING": "true"}))
+				})
 			})
 
 			When("the app has a service-binding secret", func() {
@@ -0,0 +1,286 @@
+package repositories
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/controllers/usage"
+	"code.cloudfoundry.org/korifi/tools"
+
+	"github.com/BooleanCat/go-functional/v2/it"
+	"github.com/BooleanCat/go-functional/v2/it/itx"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	AppUsageEventResourceType = "App Usage Event"
+)
+
+type AppUsageEventRecord struct {
+	GUID                          string
+	State                         string
+	PreviousState                 string
+	AppGUID                       string
+	AppName                       string
+	ProcessGUID                   string
+	ProcessType                   string
+	SpaceGUID                     string
+	SpaceName                     string
+	OrgGUID                       string
+	InstanceCount                 int32
+	PreviousInstanceCount         int32
+	MemoryInMBPerInstance         int64
+	PreviousMemoryInMBPerInstance int64
+	CreatedAt                     time.Time
+	UpdatedAt                     *time.Time
+}
+
+type ListAppUsageEventsMessage struct {
+	GUIDs      []string
+	AfterGUID  string
+	Pagination Pagination
+}
+
+func (m *ListAppUsageEventsMessage) matches(e korifiv1alpha1.CFAppUsageEvent) bool {
+	return tools.EmptyOrContains(m.GUIDs, e.Name)
+}
+
+type AppUsageEventRepo struct {
+	userClientFactory authorization.UserClientFactory
+	rootNamespace     string
+}
+
+func NewAppUsageEventRepo(
+	userClientFactory authorization.UserClientFactory,
+	rootNamespace string,
+) *AppUsageEventRepo {
+	return &AppUsageEventRepo{
+		userClientFactory: userClientFactory,
+		rootNamespace:     rootNamespace,
+	}
+}
+
+func (r *AppUsageEventRepo) GetAppUsageEvent(ctx context.Context, authInfo authorization.Info, guid string) (AppUsageEventRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return AppUsageEventRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	event := &korifiv1alpha1.CFAppUsageEvent{}
+	err = userClient.Get(ctx, client.ObjectKey{Namespace: r.rootNamespace, Name: guid}, event)
+	if err != nil {
+		return AppUsageEventRecord{}, apierrors.FromK8sError(err, AppUsageEventResourceType)
+	}
+
+	return appUsageEventToRecord(*event), nil
+}
+
+func (r *AppUsageEventRepo) ListAppUsageEvents(ctx context.Context, authInfo authorization.Info, message ListAppUsageEventsMessage) (ListResult[AppUsageEventRecord], error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return ListResult[AppUsageEventRecord]{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	events := &korifiv1alpha1.CFAppUsageEventList{}
+	err = userClient.List(ctx, events, client.InNamespace(r.rootNamespace))
+	if err != nil {
+		if k8serrors.IsForbidden(err) {
+			return paginate([]AppUsageEventRecord{}, message.Pagination), nil
+		}
+		return ListResult[AppUsageEventRecord]{}, apierrors.FromK8sError(err, AppUsageEventResourceType)
+	}
+
+	items, err := usageEventsAfter(events.Items, message.AfterGUID, AppUsageEventResourceType)
+	if err != nil {
+		return ListResult[AppUsageEventRecord]{}, err
+	}
+
+	items, err = recordedUsageEvents(ctx, userClient, r.rootNamespace, usage.AppEventsSequenceKey, items)
+	if err != nil {
+		return ListResult[AppUsageEventRecord]{}, err
+	}
+
+	records := slices.Collect(it.Map(
+		itx.FromSlice(items).Filter(message.matches),
+		appUsageEventToRecord,
+	))
+
+	return paginate(records, message.Pagination), nil
+}
+
+// PurgeAndReseed deletes all app usage events and records a STARTED event for
+// every process that is currently billed as started
+func (r *AppUsageEventRepo) PurgeAndReseed(ctx context.Context, authInfo authorization.Info) error {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	err = userClient.DeleteAllOf(ctx, &korifiv1alpha1.CFAppUsageEvent{}, client.InNamespace(r.rootNamespace))
+	if err != nil {
+		return apierrors.FromK8sError(err, AppUsageEventResourceType)
+	}
+
+	spaces, err := listUsageEventSpaces(ctx, userClient)
+	if err != nil {
+		return err
+	}
+
+	appList := &korifiv1alpha1.CFAppList{}
+	err = userClient.List(ctx, appList)
+	if err != nil {
+		return apierrors.FromK8sError(err, AppResourceType)
+	}
+	appNames := map[string]string{}
+	appGenerations := map[string]int64{}
+	for _, app := range appList.Items {
+		appNames[app.Name] = app.Spec.DisplayName
+		appGenerations[app.Name] = app.Generation
+	}
+
+	processList := &korifiv1alpha1.CFProcessList{}
+	err = userClient.List(ctx, processList)
+	if err != nil {
+		return apierrors.FromK8sError(err, ProcessResourceType)
+	}
+
+	for _, process := range processList.Items {
+		if process.Status.Usage == nil || process.Status.Usage.State != korifiv1alpha1.StartedState {
+			continue
+		}
+
+		sequence, err := usage.NextSequence(ctx, userClient, userClient, r.rootNamespace, usage.AppEventsSequenceKey)
+		if err != nil {
+			return apierrors.FromK8sError(err, AppUsageEventResourceType)
+		}
+
+		space := spaces[process.Namespace]
+		seededUsage := korifiv1alpha1.ProcessUsage{State: korifiv1alpha1.StoppedState}
+		err = userClient.Create(ctx, &korifiv1alpha1.CFAppUsageEvent{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: r.rootNamespace,
+				Name:      usage.AppUsageEventName(process.Name, appGenerations[process.Spec.AppRef.Name], process.Generation, seededUsage, *process.Status.Usage),
+			},
+			Spec: korifiv1alpha1.CFAppUsageEventSpec{
+				Sequence:              sequence,
+				State:                 korifiv1alpha1.StartedState,
+				AppGUID:               process.Spec.AppRef.Name,
+				AppName:               appNames[process.Spec.AppRef.Name],
+				ProcessGUID:           process.Name,
+				ProcessType:           process.Spec.ProcessType,
+				SpaceGUID:             process.Namespace,
+				SpaceName:             space.Spec.DisplayName,
+				OrgGUID:               space.Namespace,
+				InstanceCount:         process.Status.Usage.Instances,
+				MemoryInMBPerInstance: process.Status.Usage.MemoryMB,
+			},
+		})
+
+		releaseErr := usage.ReleaseSequence(ctx, userClient, userClient, r.rootNamespace, usage.AppEventsSequenceKey, sequence)
+		if err != nil {
+			return apierrors.FromK8sError(err, AppUsageEventResourceType)
+		}
+		if releaseErr != nil {
+			return apierrors.FromK8sError(releaseErr, AppUsageEventResourceType)
+		}
+	}
+
+	return nil
+}
+
+func appUsageEventToRecord(event korifiv1alpha1.CFAppUsageEvent) AppUsageEventRecord {
+	return AppUsageEventRecord{
+		GUID:                          event.Name,
+		State:                         string(event.Spec.State),
+		PreviousState:                 string(event.Spec.PreviousState),
+		AppGUID:                       event.Spec.AppGUID,
+		AppName:                       event.Spec.AppName,
+		ProcessGUID:                   event.Spec.ProcessGUID,
+		ProcessType:                   event.Spec.ProcessType,
+		SpaceGUID:                     event.Spec.SpaceGUID,
+		SpaceName:                     event.Spec.SpaceName,
+		OrgGUID:                       event.Spec.OrgGUID,
+		InstanceCount:                 event.Spec.InstanceCount,
+		PreviousInstanceCount:         event.Spec.PreviousInstanceCount,
+		MemoryInMBPerInstance:         event.Spec.MemoryInMBPerInstance,
+		PreviousMemoryInMBPerInstance: event.Spec.PreviousMemoryInMBPerInstance,
+		CreatedAt:                     event.CreationTimestamp.Time,
+		UpdatedAt:                     getLastUpdatedTime(&event),
+	}
+}
+
+// listUsageEventSpaces returns the spaces visible to the user, keyed by guid
+func listUsageEventSpaces(ctx context.Context, userClient client.Client) (map[string]korifiv1alpha1.CFSpace, error) {
+	spaceList := &korifiv1alpha1.CFSpaceList{}
+	err := userClient.List(ctx, spaceList)
+	if err != nil {
+		return nil, apierrors.FromK8sError(err, SpaceResourceType)
+	}
+
+	spaces := map[string]korifiv1alpha1.CFSpace{}
+	for _, space := range spaceList.Items {
+		spaces[space.Name] = space
+	}
+
+	return spaces, nil
+}
+
+// recordedUsageEvents hides the sorted events from the first sequence number
+// still in flight on, as the events with lower numbers that are still being
+// recorded would otherwise be skipped by clients paging with after_guid
+func recordedUsageEvents[T any, PT interface {
+	*T
+	GetSequence() int64
+}](ctx context.Context, userClient client.Client, rootNamespace string, sequenceKey string, events []T) ([]T, error) {
+	firstInFlight, ok, err := usage.FirstInFlightSequence(ctx, userClient, rootNamespace, sequenceKey)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return events, nil
+	}
+
+	return slices.DeleteFunc(events, func(e T) bool {
+		return PT(&e).GetSequence() >= firstInFlight
+	}), nil
+}
+
+// usageEventsAfter sorts the events in the order they were recorded and
+// returns the ones recorded after the event with the given guid
+func usageEventsAfter[T any, PT interface {
+	*T
+	client.Object
+	GetSequence() int64
+}](events []T, afterGUID string, resourceType string) ([]T, error) {
+	slices.SortFunc(events, func(a, b T) int {
+		return cmp.Compare(PT(&a).GetSequence(), PT(&b).GetSequence())
+	})
+
+	if afterGUID == "" {
+		return events, nil
+	}
+
+	afterIndex := slices.IndexFunc(events, func(e T) bool {
+		return PT(&e).GetName() == afterGUID
+	})
+	if afterIndex < 0 {
+		return nil, apierrors.NewInvalidRequestError(
+			fmt.Errorf("%s %q not found", resourceType, afterGUID),
+			fmt.Sprintf("After guid filter must be a valid %s guid.", strings.ToLower(resourceType)),
+		)
+	}
+
+	afterSequence := PT(&events[afterIndex]).GetSequence()
+	return slices.DeleteFunc(events, func(e T) bool {
+		return PT(&e).GetSequence() <= afterSequence
+	}), nil
+}
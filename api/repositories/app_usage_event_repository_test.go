@@ -0,0 +1,302 @@
+package repositories_test
+
+import (
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	. "code.cloudfoundry.org/korifi/api/repositories"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/controllers/usage"
+	"code.cloudfoundry.org/korifi/tests/matchers"
+	"code.cloudfoundry.org/korifi/tools/k8s"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("AppUsageEventRepository", func() {
+	var (
+		appUsageEventRepo *AppUsageEventRepo
+		event1GUID        string
+		event2GUID        string
+	)
+
+	createAppUsageEvent := func(guid string, state korifiv1alpha1.AppState, sequence int64) {
+		Expect(k8sClient.Create(ctx, &korifiv1alpha1.CFAppUsageEvent{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      guid,
+				Namespace: rootNamespace,
+			},
+			Spec: korifiv1alpha1.CFAppUsageEventSpec{
+				Sequence:              sequence,
+				State:                 state,
+				PreviousState:         korifiv1alpha1.StoppedState,
+				AppGUID:               "app-guid",
+				AppName:               "app-name",
+				ProcessGUID:           "process-guid",
+				ProcessType:           "web",
+				SpaceGUID:             "space-guid",
+				InstanceCount:         2,
+				MemoryInMBPerInstance: 256,
+			},
+		})).To(Succeed())
+	}
+
+	BeforeEach(func() {
+		appUsageEventRepo = NewAppUsageEventRepo(userClientFactory, rootNamespace)
+
+		event1GUID = uuid.NewString()
+		event2GUID = uuid.NewString()
+		createAppUsageEvent(event1GUID, korifiv1alpha1.StartedState, 1)
+		createAppUsageEvent(event2GUID, korifiv1alpha1.StoppedState, 2)
+	})
+
+	Describe("GetAppUsageEvent", func() {
+		var (
+			guid   string
+			record AppUsageEventRecord
+			getErr error
+		)
+
+		BeforeEach(func() {
+			guid = event1GUID
+		})
+
+		JustBeforeEach(func() {
+			record, getErr = appUsageEventRepo.GetAppUsageEvent(ctx, authInfo, guid)
+		})
+
+		It("returns a forbidden error", func() {
+			Expect(getErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is an admin", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+			})
+
+			It("returns the event", func() {
+				Expect(getErr).NotTo(HaveOccurred())
+				Expect(record).To(MatchFields(IgnoreExtras, Fields{
+					"GUID":                  Equal(event1GUID),
+					"State":                 Equal("STARTED"),
+					"PreviousState":         Equal("STOPPED"),
+					"AppGUID":               Equal("app-guid"),
+					"AppName":               Equal("app-name"),
+					"ProcessGUID":           Equal("process-guid"),
+					"ProcessType":           Equal("web"),
+					"SpaceGUID":             Equal("space-guid"),
+					"InstanceCount":         BeEquivalentTo(2),
+					"MemoryInMBPerInstance": BeEquivalentTo(256),
+				}))
+			})
+
+			When("the event does not exist", func() {
+				BeforeEach(func() {
+					guid = "i-do-not-exist"
+				})
+
+				It("returns a not found error", func() {
+					Expect(getErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
+				})
+			})
+		})
+	})
+
+	Describe("ListAppUsageEvents", func() {
+		var (
+			message ListAppUsageEventsMessage
+			result  ListResult[AppUsageEventRecord]
+			records []AppUsageEventRecord
+			listErr error
+		)
+
+		BeforeEach(func() {
+			message = ListAppUsageEventsMessage{}
+		})
+
+		JustBeforeEach(func() {
+			result, listErr = appUsageEventRepo.ListAppUsageEvents(ctx, authInfo, message)
+			records = result.Records
+		})
+
+		It("returns an empty list", func() {
+			Expect(listErr).NotTo(HaveOccurred())
+			Expect(records).To(BeEmpty())
+		})
+
+		When("the user is an admin", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+			})
+
+			It("returns the events in the order they were recorded", func() {
+				Expect(listErr).NotTo(HaveOccurred())
+				Expect(records).To(HaveLen(2))
+				Expect(records[0].GUID).To(Equal(event1GUID))
+				Expect(records[1].GUID).To(Equal(event2GUID))
+			})
+
+			When("filtering by guids", func() {
+				BeforeEach(func() {
+					message.GUIDs = []string{event2GUID}
+				})
+
+				It("returns the matching events", func() {
+					Expect(listErr).NotTo(HaveOccurred())
+					Expect(records).To(ConsistOf(MatchFields(IgnoreExtras, Fields{"GUID": Equal(event2GUID)})))
+				})
+			})
+
+			When("filtering by after_guid", func() {
+				BeforeEach(func() {
+					message.AfterGUID = event1GUID
+				})
+
+				It("returns the events recorded after it", func() {
+					Expect(listErr).NotTo(HaveOccurred())
+					Expect(records).To(ConsistOf(MatchFields(IgnoreExtras, Fields{"GUID": Equal(event2GUID)})))
+				})
+
+				When("the event does not exist", func() {
+					BeforeEach(func() {
+						message.AfterGUID = "i-do-not-exist"
+					})
+
+					It("returns an invalid request error", func() {
+						Expect(listErr).To(BeAssignableToTypeOf(apierrors.InvalidRequestError{}))
+					})
+				})
+			})
+
+			When("an event is still being recorded", func() {
+				var sequence int64
+
+				BeforeEach(func() {
+					Expect(k8sClient.Create(ctx, &corev1.ConfigMap{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: rootNamespace,
+							Name:      usage.SequenceConfigMapName,
+						},
+						Data: map[string]string{usage.AppEventsSequenceKey: "1"},
+					})).To(Succeed())
+
+					var err error
+					sequence, err = usage.NextSequence(ctx, k8sClient, k8sClient, rootNamespace, usage.AppEventsSequenceKey)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(sequence).To(BeEquivalentTo(2))
+				})
+
+				It("hides the events from its sequence number on", func() {
+					Expect(listErr).NotTo(HaveOccurred())
+					Expect(records).To(ConsistOf(MatchFields(IgnoreExtras, Fields{"GUID": Equal(event1GUID)})))
+				})
+
+				When("the sequence number is released", func() {
+					BeforeEach(func() {
+						Expect(usage.ReleaseSequence(ctx, k8sClient, k8sClient, rootNamespace, usage.AppEventsSequenceKey, sequence)).To(Succeed())
+					})
+
+					It("returns all events", func() {
+						Expect(listErr).NotTo(HaveOccurred())
+						Expect(records).To(HaveLen(2))
+					})
+				})
+			})
+
+			When("paginating", func() {
+				BeforeEach(func() {
+					message.Pagination = Pagination{PerPage: 1, Page: 2}
+				})
+
+				It("returns the requested page", func() {
+					Expect(listErr).NotTo(HaveOccurred())
+					Expect(records).To(ConsistOf(MatchFields(IgnoreExtras, Fields{"GUID": Equal(event2GUID)})))
+					Expect(result.PageInfo).To(Equal(PageInfo{
+						TotalResults: 2,
+						TotalPages:   2,
+						PageNumber:   2,
+						PageSize:     1,
+					}))
+				})
+			})
+		})
+	})
+
+	Describe("PurgeAndReseed", func() {
+		var (
+			cfSpace   *korifiv1alpha1.CFSpace
+			cfApp     *korifiv1alpha1.CFApp
+			cfProcess *korifiv1alpha1.CFProcess
+			purgeErr  error
+		)
+
+		BeforeEach(func() {
+			cfOrg := createOrgWithCleanup(ctx, uuid.NewString())
+			cfSpace = createSpaceWithCleanup(ctx, cfOrg.Name, "my-space")
+			cfApp = createApp(cfSpace.Name)
+
+			cfProcess = &korifiv1alpha1.CFProcess{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      uuid.NewString(),
+					Namespace: cfSpace.Name,
+				},
+				Spec: korifiv1alpha1.CFProcessSpec{
+					AppRef:      corev1.LocalObjectReference{Name: cfApp.Name},
+					ProcessType: "web",
+				},
+			}
+			Expect(k8sClient.Create(ctx, cfProcess)).To(Succeed())
+			Expect(k8s.Patch(ctx, k8sClient, cfProcess, func() {
+				cfProcess.Status.Usage = &korifiv1alpha1.ProcessUsage{
+					State:     korifiv1alpha1.StartedState,
+					Instances: 3,
+					MemoryMB:  512,
+				}
+			})).To(Succeed())
+		})
+
+		JustBeforeEach(func() {
+			purgeErr = appUsageEventRepo.PurgeAndReseed(ctx, authInfo)
+		})
+
+		It("returns a forbidden error", func() {
+			Expect(purgeErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is an admin", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+				createRoleBinding(ctx, userName, adminRole.Name, cfSpace.Namespace)
+				createRoleBinding(ctx, userName, adminRole.Name, cfSpace.Name)
+			})
+
+			It("replaces the existing events with events for the started processes", func() {
+				Expect(purgeErr).NotTo(HaveOccurred())
+
+				events := &korifiv1alpha1.CFAppUsageEventList{}
+				Expect(k8sClient.List(ctx, events, client.InNamespace(rootNamespace))).To(Succeed())
+				Expect(events.Items).To(HaveLen(1))
+				Expect(events.Items[0].Spec.Sequence).To(BeNumerically(">", 0))
+				Expect(events.Items).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+					"Spec": Equal(korifiv1alpha1.CFAppUsageEventSpec{
+						Sequence:              events.Items[0].Spec.Sequence,
+						State:                 korifiv1alpha1.StartedState,
+						AppGUID:               cfApp.Name,
+						AppName:               cfApp.Spec.DisplayName,
+						ProcessGUID:           cfProcess.Name,
+						ProcessType:           "web",
+						SpaceGUID:             cfSpace.Name,
+						SpaceName:             "my-space",
+						OrgGUID:               cfSpace.Namespace,
+						InstanceCount:         3,
+						MemoryInMBPerInstance: 512,
+					}),
+				})))
+			})
+		})
+	})
+})
@@ -0,0 +1,60 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/controllers/services/osbapi"
+)
+
+//counterfeiter:generate -o fake -fake-name BrokerClientFactory . BrokerClientFactory
+
+type BrokerClientFactory interface {
+	CreateClient(context.Context, authorization.Info, *korifiv1alpha1.CFServiceBroker) (osbapi.BrokerClient, error)
+}
+
+// UserBrokerClientFactory creates broker clients without granting the API
+// cluster-wide access to secrets. The credentials of global brokers are read
+// through the API role in the root namespace, the credentials of space-scoped
+// brokers are read on behalf of the user.
+type UserBrokerClientFactory struct {
+	rootNamespace        string
+	rootClientFactory    osbapi.BrokerClientFactory
+	userClientFactory    authorization.UserClientFactory
+	trustInsecureBrokers bool
+}
+
+func NewUserBrokerClientFactory(
+	rootNamespace string,
+	rootClientFactory osbapi.BrokerClientFactory,
+	userClientFactory authorization.UserClientFactory,
+	trustInsecureBrokers bool,
+) *UserBrokerClientFactory {
+	return &UserBrokerClientFactory{
+		rootNamespace:        rootNamespace,
+		rootClientFactory:    rootClientFactory,
+		userClientFactory:    userClientFactory,
+		trustInsecureBrokers: trustInsecureBrokers,
+	}
+}
+
+func (f *UserBrokerClientFactory) CreateClient(ctx context.Context, authInfo authorization.Info, broker *korifiv1alpha1.CFServiceBroker) (osbapi.BrokerClient, error) {
+	if broker.Namespace == f.rootNamespace {
+		return f.rootClientFactory.CreateClient(ctx, broker)
+	}
+
+	userClient, err := f.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	brokerClient, err := osbapi.NewClientFactory(userClient, f.trustInsecureBrokers).CreateClient(ctx, broker)
+	if err != nil {
+		return nil, apierrors.FromK8sError(err, ServiceBrokerResourceType)
+	}
+
+	return brokerClient, nil
+}
@@ -0,0 +1,106 @@
+package repositories_test
+
+import (
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/controllers/services/osbapi"
+	osbapifake "code.cloudfoundry.org/korifi/controllers/controllers/services/osbapi/fake"
+	"code.cloudfoundry.org/korifi/tests/matchers"
+	"code.cloudfoundry.org/korifi/tools"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("UserBrokerClientFactory", func() {
+	var (
+		rootClientFactory *osbapifake.BrokerClientFactory
+		rootBrokerClient  *osbapifake.BrokerClient
+		factory           *repositories.UserBrokerClientFactory
+		broker            *korifiv1alpha1.CFServiceBroker
+		brokerClient      osbapi.BrokerClient
+		createErr         error
+	)
+
+	createBroker := func(namespace string) *korifiv1alpha1.CFServiceBroker {
+		credentialsData, err := tools.ToCredentialsSecretData(map[string]any{
+			"username": "broker-user",
+			"password": "broker-password",
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		credentialsSecret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: namespace,
+				Name:      uuid.NewString(),
+			},
+			Data: credentialsData,
+		}
+		Expect(k8sClient.Create(ctx, credentialsSecret)).To(Succeed())
+
+		return &korifiv1alpha1.CFServiceBroker{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: namespace,
+				Name:      uuid.NewString(),
+			},
+			Spec: korifiv1alpha1.CFServiceBrokerSpec{
+				Credentials: corev1.LocalObjectReference{
+					Name: credentialsSecret.Name,
+				},
+			},
+		}
+	}
+
+	BeforeEach(func() {
+		rootBrokerClient = new(osbapifake.BrokerClient)
+		rootClientFactory = new(osbapifake.BrokerClientFactory)
+		rootClientFactory.CreateClientReturns(rootBrokerClient, nil)
+
+		factory = repositories.NewUserBrokerClientFactory(rootNamespace, rootClientFactory, userClientFactory, false)
+		broker = createBroker(rootNamespace)
+	})
+
+	JustBeforeEach(func() {
+		brokerClient, createErr = factory.CreateClient(ctx, authInfo, broker)
+	})
+
+	It("creates the client of a global broker with the root client factory", func() {
+		Expect(createErr).NotTo(HaveOccurred())
+		Expect(brokerClient).To(Equal(rootBrokerClient))
+
+		Expect(rootClientFactory.CreateClientCallCount()).To(Equal(1))
+		_, actualBroker := rootClientFactory.CreateClientArgsForCall(0)
+		Expect(actualBroker).To(Equal(broker))
+	})
+
+	When("the broker is space-scoped", func() {
+		var space *korifiv1alpha1.CFSpace
+
+		BeforeEach(func() {
+			org := createOrgWithCleanup(ctx, uuid.NewString())
+			space = createSpaceWithCleanup(ctx, org.Name, uuid.NewString())
+			broker = createBroker(space.Name)
+		})
+
+		It("returns a forbidden error", func() {
+			Expect(createErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+			Expect(rootClientFactory.CreateClientCallCount()).To(BeZero())
+		})
+
+		When("the user can read secrets in the space", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
+			})
+
+			It("creates the client with the user credentials", func() {
+				Expect(createErr).NotTo(HaveOccurred())
+				Expect(brokerClient).NotTo(BeNil())
+				Expect(rootClientFactory.CreateClientCallCount()).To(BeZero())
+			})
+		})
+	})
+})
@@ -0,0 +1,148 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools/k8s"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	EnvVarGroupResourceType = "Environment Variable Group"
+)
+
+type EnvVarGroupRecord struct {
+	Name                 string
+	EnvironmentVariables map[string]string
+	UpdatedAt            *time.Time
+}
+
+type UpdateEnvVarGroupMessage struct {
+	Name                 string
+	EnvironmentVariables map[string]*string
+}
+
+func (m *UpdateEnvVarGroupMessage) apply(envVarGroup *korifiv1alpha1.CFEnvironmentVariableGroup) {
+	if envVarGroup.Spec.EnvironmentVariables == nil {
+		envVarGroup.Spec.EnvironmentVariables = map[string]string{}
+	}
+
+	for k, v := range m.EnvironmentVariables {
+		if v == nil {
+			delete(envVarGroup.Spec.EnvironmentVariables, k)
+		} else {
+			envVarGroup.Spec.EnvironmentVariables[k] = *v
+		}
+	}
+}
+
+type EnvVarGroupRepo struct {
+	userClientFactory authorization.UserClientFactory
+	rootNamespace     string
+}
+
+func NewEnvVarGroupRepo(
+	userClientFactory authorization.UserClientFactory,
+	rootNamespace string,
+) *EnvVarGroupRepo {
+	return &EnvVarGroupRepo{
+		userClientFactory: userClientFactory,
+		rootNamespace:     rootNamespace,
+	}
+}
+
+func (r *EnvVarGroupRepo) GetEnvVarGroup(ctx context.Context, authInfo authorization.Info, name string) (EnvVarGroupRecord, error) {
+	if !isEnvVarGroupName(name) {
+		return EnvVarGroupRecord{}, apierrors.NewNotFoundError(nil, EnvVarGroupResourceType)
+	}
+
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return EnvVarGroupRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	return getEnvVarGroup(ctx, userClient, r.rootNamespace, name)
+}
+
+func (r *EnvVarGroupRepo) UpdateEnvVarGroup(ctx context.Context, authInfo authorization.Info, message UpdateEnvVarGroupMessage) (EnvVarGroupRecord, error) {
+	if !isEnvVarGroupName(message.Name) {
+		return EnvVarGroupRecord{}, apierrors.NewNotFoundError(nil, EnvVarGroupResourceType)
+	}
+
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return EnvVarGroupRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	envVarGroup := &korifiv1alpha1.CFEnvironmentVariableGroup{}
+	err = userClient.Get(ctx, client.ObjectKey{Namespace: r.rootNamespace, Name: message.Name}, envVarGroup)
+	if err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return EnvVarGroupRecord{}, apierrors.FromK8sError(err, EnvVarGroupResourceType)
+		}
+
+		envVarGroup = &korifiv1alpha1.CFEnvironmentVariableGroup{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: r.rootNamespace,
+				Name:      message.Name,
+			},
+		}
+		message.apply(envVarGroup)
+
+		err = userClient.Create(ctx, envVarGroup)
+		if err != nil {
+			return EnvVarGroupRecord{}, apierrors.FromK8sError(err, EnvVarGroupResourceType)
+		}
+
+		return envVarGroupToRecord(*envVarGroup), nil
+	}
+
+	err = k8s.PatchResource(ctx, userClient, envVarGroup, func() {
+		message.apply(envVarGroup)
+	})
+	if err != nil {
+		return EnvVarGroupRecord{}, apierrors.FromK8sError(err, EnvVarGroupResourceType)
+	}
+
+	return envVarGroupToRecord(*envVarGroup), nil
+}
+
+// getEnvVarGroup returns an empty group when it has not been created yet
+func getEnvVarGroup(ctx context.Context, userClient client.Client, rootNamespace, name string) (EnvVarGroupRecord, error) {
+	envVarGroup := &korifiv1alpha1.CFEnvironmentVariableGroup{}
+	err := userClient.Get(ctx, client.ObjectKey{Namespace: rootNamespace, Name: name}, envVarGroup)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return EnvVarGroupRecord{Name: name, EnvironmentVariables: map[string]string{}}, nil
+		}
+		return EnvVarGroupRecord{}, apierrors.FromK8sError(err, EnvVarGroupResourceType)
+	}
+
+	return envVarGroupToRecord(*envVarGroup), nil
+}
+
+func isEnvVarGroupName(name string) bool {
+	return name == korifiv1alpha1.RunningEnvironmentVariableGroupName ||
+		name == korifiv1alpha1.StagingEnvironmentVariableGroupName
+}
+
+func envVarGroupToRecord(envVarGroup korifiv1alpha1.CFEnvironmentVariableGroup) EnvVarGroupRecord {
+	environmentVariables := envVarGroup.Spec.EnvironmentVariables
+	if environmentVariables == nil {
+		environmentVariables = map[string]string{}
+	}
+
+	return EnvVarGroupRecord{
+		Name:                 envVarGroup.Name,
+		EnvironmentVariables: environmentVariables,
+		UpdatedAt:            getLastUpdatedTime(&envVarGroup),
+	}
+}
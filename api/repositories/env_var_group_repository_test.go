@@ -0,0 +1,163 @@
+package repositories_test
+
+import (
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	. "code.cloudfoundry.org/korifi/api/repositories"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tests/matchers"
+	"code.cloudfoundry.org/korifi/tools"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("EnvVarGroupRepository", func() {
+	var (
+		envVarGroupRepo *EnvVarGroupRepo
+		runningGroup    *korifiv1alpha1.CFEnvironmentVariableGroup
+	)
+
+	BeforeEach(func() {
+		envVarGroupRepo = NewEnvVarGroupRepo(userClientFactory, rootNamespace)
+
+		runningGroup = &korifiv1alpha1.CFEnvironmentVariableGroup{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      korifiv1alpha1.RunningEnvironmentVariableGroupName,
+				Namespace: rootNamespace,
+			},
+			Spec: korifiv1alpha1.CFEnvironmentVariableGroupSpec{
+				EnvironmentVariables: map[string]string{
+					"HTTP_PROXY": "http://proxy.example.com",
+					"NO_PROXY":   "localhost",
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, runningGroup)).To(Succeed())
+	})
+
+	AfterEach(func() {
+		Expect(k8sClient.DeleteAllOf(ctx, &korifiv1alpha1.CFEnvironmentVariableGroup{}, client.InNamespace(rootNamespace))).To(Succeed())
+	})
+
+	Describe("GetEnvVarGroup", func() {
+		var (
+			name   string
+			record EnvVarGroupRecord
+			getErr error
+		)
+
+		BeforeEach(func() {
+			name = korifiv1alpha1.RunningEnvironmentVariableGroupName
+		})
+
+		JustBeforeEach(func() {
+			record, getErr = envVarGroupRepo.GetEnvVarGroup(ctx, authInfo, name)
+		})
+
+		It("returns the environment variable group", func() {
+			Expect(getErr).NotTo(HaveOccurred())
+			Expect(record.Name).To(Equal(korifiv1alpha1.RunningEnvironmentVariableGroupName))
+			Expect(record.EnvironmentVariables).To(Equal(map[string]string{
+				"HTTP_PROXY": "http://proxy.example.com",
+				"NO_PROXY":   "localhost",
+			}))
+		})
+
+		When("the environment variable group has not been created yet", func() {
+			BeforeEach(func() {
+				name = korifiv1alpha1.StagingEnvironmentVariableGroupName
+			})
+
+			It("returns an empty group", func() {
+				Expect(getErr).NotTo(HaveOccurred())
+				Expect(record).To(Equal(EnvVarGroupRecord{
+					Name:                 korifiv1alpha1.StagingEnvironmentVariableGroupName,
+					EnvironmentVariables: map[string]string{},
+				}))
+			})
+		})
+
+		When("the environment variable group name is unknown", func() {
+			BeforeEach(func() {
+				name = "not-a-group"
+			})
+
+			It("returns a not found error", func() {
+				Expect(getErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
+			})
+		})
+	})
+
+	Describe("UpdateEnvVarGroup", func() {
+		var (
+			message   UpdateEnvVarGroupMessage
+			record    EnvVarGroupRecord
+			updateErr error
+		)
+
+		BeforeEach(func() {
+			message = UpdateEnvVarGroupMessage{
+				Name: korifiv1alpha1.RunningEnvironmentVariableGroupName,
+				EnvironmentVariables: map[string]*string{
+					"HTTP_PROXY": tools.PtrTo("http://other-proxy.example.com"),
+					"NO_PROXY":   nil,
+				},
+			}
+		})
+
+		JustBeforeEach(func() {
+			record, updateErr = envVarGroupRepo.UpdateEnvVarGroup(ctx, authInfo, message)
+		})
+
+		It("fails because the user is not a CF admin", func() {
+			Expect(updateErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is a CF admin", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+			})
+
+			It("patches the environment variables", func() {
+				Expect(updateErr).NotTo(HaveOccurred())
+				Expect(record.EnvironmentVariables).To(Equal(map[string]string{
+					"HTTP_PROXY": "http://other-proxy.example.com",
+				}))
+
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(runningGroup), runningGroup)).To(Succeed())
+				Expect(runningGroup.Spec.EnvironmentVariables).To(Equal(map[string]string{
+					"HTTP_PROXY": "http://other-proxy.example.com",
+				}))
+			})
+
+			When("the environment variable group has not been created yet", func() {
+				BeforeEach(func() {
+					message.Name = korifiv1alpha1.StagingEnvironmentVariableGroupName
+				})
+
+				It("creates it", func() {
+					Expect(updateErr).NotTo(HaveOccurred())
+					Expect(record.EnvironmentVariables).To(Equal(map[string]string{
+						"HTTP_PROXY": "http://other-proxy.example.com",
+					}))
+
+					created := &korifiv1alpha1.CFEnvironmentVariableGroup{}
+					Expect(k8sClient.Get(ctx, client.ObjectKey{Namespace: rootNamespace, Name: korifiv1alpha1.StagingEnvironmentVariableGroupName}, created)).To(Succeed())
+					Expect(created.Spec.EnvironmentVariables).To(HaveKeyWithValue("HTTP_PROXY", "http://other-proxy.example.com"))
+				})
+			})
+
+			When("the environment variable group name is unknown", func() {
+				BeforeEach(func() {
+					message.Name = "not-a-group"
+				})
+
+				It("returns a not found error", func() {
+					Expect(updateErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
+				})
+			})
+		})
+	})
+})
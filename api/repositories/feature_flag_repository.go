@@ -0,0 +1,221 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools/k8s"
+
+	authv1 "k8s.io/api/authorization/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	FeatureFlagResourceType = "Feature Flag"
+)
+
+type FeatureFlagRecord struct {
+	Name               string
+	Enabled            bool
+	CustomErrorMessage string
+	UpdatedAt          *time.Time
+}
+
+type UpdateFeatureFlagMessage struct {
+	Name               string
+	Enabled            *bool
+	CustomErrorMessage *string
+}
+
+func (m *UpdateFeatureFlagMessage) apply(featureFlag *korifiv1alpha1.CFFeatureFlag) {
+	if m.Enabled != nil {
+		featureFlag.Spec.Enabled = *m.Enabled
+	}
+	if m.CustomErrorMessage != nil {
+		featureFlag.Spec.CustomErrorMessage = *m.CustomErrorMessage
+	}
+}
+
+type FeatureFlagRepo struct {
+	userClientFactory authorization.UserClientFactory
+	rootNamespace     string
+}
+
+func NewFeatureFlagRepo(
+	userClientFactory authorization.UserClientFactory,
+	rootNamespace string,
+) *FeatureFlagRepo {
+	return &FeatureFlagRepo{
+		userClientFactory: userClientFactory,
+		rootNamespace:     rootNamespace,
+	}
+}
+
+func (r *FeatureFlagRepo) GetFeatureFlag(ctx context.Context, authInfo authorization.Info, name string) (FeatureFlagRecord, error) {
+	defaultEnabled, known := korifiv1alpha1.FeatureFlagDefaults[name]
+	if !known {
+		return FeatureFlagRecord{}, apierrors.NewNotFoundError(nil, FeatureFlagResourceType)
+	}
+
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return FeatureFlagRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfFeatureFlag := &korifiv1alpha1.CFFeatureFlag{}
+	err = userClient.Get(ctx, client.ObjectKey{Namespace: r.rootNamespace, Name: name}, cfFeatureFlag)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return FeatureFlagRecord{Name: name, Enabled: defaultEnabled}, nil
+		}
+		return FeatureFlagRecord{}, apierrors.FromK8sError(err, FeatureFlagResourceType)
+	}
+
+	return cfFeatureFlagToRecord(*cfFeatureFlag), nil
+}
+
+// EnsureFeatureEnabled returns a FeatureDisabledError when the named feature
+// flag is turned off. As in CF, admins are not subject to feature flags.
+func (r *FeatureFlagRepo) EnsureFeatureEnabled(ctx context.Context, authInfo authorization.Info, name string) error {
+	featureFlag, err := r.GetFeatureFlag(ctx, authInfo, name)
+	if err != nil {
+		return err
+	}
+
+	if featureFlag.Enabled {
+		return nil
+	}
+
+	isAdmin, err := r.canIPatchFeatureFlags(ctx, authInfo)
+	if err != nil {
+		return err
+	}
+
+	if isAdmin {
+		return nil
+	}
+
+	if featureFlag.CustomErrorMessage != "" {
+		return apierrors.NewFeatureDisabledError(nil, featureFlag.CustomErrorMessage)
+	}
+
+	return apierrors.NewFeatureDisabledError(nil, name)
+}
+
+// canIPatchFeatureFlags tells admins apart, as they are the only ones allowed
+// to patch feature flags
+func (r *FeatureFlagRepo) canIPatchFeatureFlags(ctx context.Context, authInfo authorization.Info) (bool, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return false, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	review := authv1.SelfSubjectAccessReview{
+		Spec: authv1.SelfSubjectAccessReviewSpec{
+			ResourceAttributes: &authv1.ResourceAttributes{
+				Namespace: r.rootNamespace,
+				Verb:      "patch",
+				Group:     "korifi.cloudfoundry.org",
+				Resource:  "cffeatureflags",
+			},
+		},
+	}
+	if err := userClient.Create(ctx, &review); err != nil {
+		return false, fmt.Errorf("failed to create self subject access review: %w", apierrors.FromK8sError(err, FeatureFlagResourceType))
+	}
+
+	return review.Status.Allowed, nil
+}
+
+func (r *FeatureFlagRepo) ListFeatureFlags(ctx context.Context, authInfo authorization.Info) ([]FeatureFlagRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return []FeatureFlagRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfFeatureFlags := &korifiv1alpha1.CFFeatureFlagList{}
+	err = userClient.List(ctx, cfFeatureFlags, client.InNamespace(r.rootNamespace))
+	if err != nil {
+		return []FeatureFlagRecord{}, apierrors.FromK8sError(err, FeatureFlagResourceType)
+	}
+
+	overrides := map[string]korifiv1alpha1.CFFeatureFlag{}
+	for _, cfFeatureFlag := range cfFeatureFlags.Items {
+		overrides[cfFeatureFlag.Name] = cfFeatureFlag
+	}
+
+	records := []FeatureFlagRecord{}
+	for _, name := range slices.Sorted(maps.Keys(korifiv1alpha1.FeatureFlagDefaults)) {
+		if cfFeatureFlag, ok := overrides[name]; ok {
+			records = append(records, cfFeatureFlagToRecord(cfFeatureFlag))
+			continue
+		}
+		records = append(records, FeatureFlagRecord{Name: name, Enabled: korifiv1alpha1.FeatureFlagDefaults[name]})
+	}
+
+	return records, nil
+}
+
+func (r *FeatureFlagRepo) UpdateFeatureFlag(ctx context.Context, authInfo authorization.Info, message UpdateFeatureFlagMessage) (FeatureFlagRecord, error) {
+	defaultEnabled, known := korifiv1alpha1.FeatureFlagDefaults[message.Name]
+	if !known {
+		return FeatureFlagRecord{}, apierrors.NewNotFoundError(nil, FeatureFlagResourceType)
+	}
+
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return FeatureFlagRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfFeatureFlag := &korifiv1alpha1.CFFeatureFlag{}
+	err = userClient.Get(ctx, client.ObjectKey{Namespace: r.rootNamespace, Name: message.Name}, cfFeatureFlag)
+	if err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return FeatureFlagRecord{}, apierrors.FromK8sError(err, FeatureFlagResourceType)
+		}
+
+		cfFeatureFlag = &korifiv1alpha1.CFFeatureFlag{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: r.rootNamespace,
+				Name:      message.Name,
+			},
+			Spec: korifiv1alpha1.CFFeatureFlagSpec{
+				Enabled: defaultEnabled,
+			},
+		}
+		message.apply(cfFeatureFlag)
+
+		err = userClient.Create(ctx, cfFeatureFlag)
+		if err != nil {
+			return FeatureFlagRecord{}, apierrors.FromK8sError(err, FeatureFlagResourceType)
+		}
+
+		return cfFeatureFlagToRecord(*cfFeatureFlag), nil
+	}
+
+	err = k8s.PatchResource(ctx, userClient, cfFeatureFlag, func() {
+		message.apply(cfFeatureFlag)
+	})
+	if err != nil {
+		return FeatureFlagRecord{}, apierrors.FromK8sError(err, FeatureFlagResourceType)
+	}
+
+	return cfFeatureFlagToRecord(*cfFeatureFlag), nil
+}
+
+func cfFeatureFlagToRecord(cfFeatureFlag korifiv1alpha1.CFFeatureFlag) FeatureFlagRecord {
+	return FeatureFlagRecord{
+		Name:               cfFeatureFlag.Name,
+		Enabled:            cfFeatureFlag.Spec.Enabled,
+		CustomErrorMessage: cfFeatureFlag.Spec.CustomErrorMessage,
+		UpdatedAt:          getLastUpdatedTime(&cfFeatureFlag),
+	}
+}
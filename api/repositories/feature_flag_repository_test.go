@@ -0,0 +1,229 @@
+package repositories_test
+
+import (
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	. "code.cloudfoundry.org/korifi/api/repositories"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tests/matchers"
+	"code.cloudfoundry.org/korifi/tools"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("FeatureFlagRepository", func() {
+	var (
+		featureFlagRepo *FeatureFlagRepo
+		cfFeatureFlag   *korifiv1alpha1.CFFeatureFlag
+	)
+
+	BeforeEach(func() {
+		featureFlagRepo = NewFeatureFlagRepo(userClientFactory, rootNamespace)
+
+		cfFeatureFlag = &korifiv1alpha1.CFFeatureFlag{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      korifiv1alpha1.FeatureFlagDiegoDocker,
+				Namespace: rootNamespace,
+			},
+			Spec: korifiv1alpha1.CFFeatureFlagSpec{
+				Enabled:            false,
+				CustomErrorMessage: "no docker",
+			},
+		}
+		Expect(k8sClient.Create(ctx, cfFeatureFlag)).To(Succeed())
+	})
+
+	AfterEach(func() {
+		Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, cfFeatureFlag))).To(Succeed())
+		Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, &korifiv1alpha1.CFFeatureFlag{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      korifiv1alpha1.FeatureFlagTaskCreation,
+				Namespace: rootNamespace,
+			},
+		}))).To(Succeed())
+	})
+
+	Describe("GetFeatureFlag", func() {
+		var (
+			name   string
+			record FeatureFlagRecord
+			getErr error
+		)
+
+		BeforeEach(func() {
+			name = korifiv1alpha1.FeatureFlagDiegoDocker
+		})
+
+		JustBeforeEach(func() {
+			record, getErr = featureFlagRepo.GetFeatureFlag(ctx, authInfo, name)
+		})
+
+		It("returns the overridden feature flag", func() {
+			Expect(getErr).NotTo(HaveOccurred())
+			Expect(record.Name).To(Equal(korifiv1alpha1.FeatureFlagDiegoDocker))
+			Expect(record.Enabled).To(BeFalse())
+			Expect(record.CustomErrorMessage).To(Equal("no docker"))
+		})
+
+		When("the feature flag is not overridden", func() {
+			BeforeEach(func() {
+				name = korifiv1alpha1.FeatureFlagTaskCreation
+			})
+
+			It("returns the default value", func() {
+				Expect(getErr).NotTo(HaveOccurred())
+				Expect(record).To(Equal(FeatureFlagRecord{
+					Name:    korifiv1alpha1.FeatureFlagTaskCreation,
+					Enabled: true,
+				}))
+			})
+		})
+
+		When("the feature flag is unknown", func() {
+			BeforeEach(func() {
+				name = "not_a_flag"
+			})
+
+			It("returns a not found error", func() {
+				Expect(getErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
+			})
+		})
+	})
+
+	Describe("EnsureFeatureEnabled", func() {
+		var (
+			name      string
+			ensureErr error
+		)
+
+		BeforeEach(func() {
+			name = korifiv1alpha1.FeatureFlagDiegoDocker
+		})
+
+		JustBeforeEach(func() {
+			ensureErr = featureFlagRepo.EnsureFeatureEnabled(ctx, authInfo, name)
+		})
+
+		It("returns a feature disabled error with the custom message", func() {
+			Expect(ensureErr).To(MatchError(ContainSubstring("Feature Disabled: no docker")))
+			Expect(ensureErr).To(BeAssignableToTypeOf(apierrors.FeatureDisabledError{}))
+		})
+
+		When("the feature flag is enabled", func() {
+			BeforeEach(func() {
+				name = korifiv1alpha1.FeatureFlagTaskCreation
+			})
+
+			It("succeeds", func() {
+				Expect(ensureErr).NotTo(HaveOccurred())
+			})
+		})
+
+		When("the user is an admin", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+			})
+
+			It("succeeds as admins are not subject to feature flags", func() {
+				Expect(ensureErr).NotTo(HaveOccurred())
+			})
+		})
+	})
+
+	Describe("ListFeatureFlags", func() {
+		var (
+			records []FeatureFlagRecord
+			listErr error
+		)
+
+		JustBeforeEach(func() {
+			records, listErr = featureFlagRepo.ListFeatureFlags(ctx, authInfo)
+		})
+
+		It("returns all known feature flags", func() {
+			Expect(listErr).NotTo(HaveOccurred())
+			Expect(records).To(HaveLen(len(korifiv1alpha1.FeatureFlagDefaults)))
+			Expect(records).To(ContainElements(
+				MatchFields(IgnoreExtras, Fields{
+					"Name":    Equal(korifiv1alpha1.FeatureFlagDiegoDocker),
+					"Enabled": BeFalse(),
+				}),
+				MatchFields(IgnoreExtras, Fields{
+					"Name":    Equal(korifiv1alpha1.FeatureFlagTaskCreation),
+					"Enabled": BeTrue(),
+				}),
+			))
+		})
+	})
+
+	Describe("UpdateFeatureFlag", func() {
+		var (
+			message   UpdateFeatureFlagMessage
+			record    FeatureFlagRecord
+			updateErr error
+		)
+
+		BeforeEach(func() {
+			message = UpdateFeatureFlagMessage{
+				Name:    korifiv1alpha1.FeatureFlagDiegoDocker,
+				Enabled: tools.PtrTo(true),
+			}
+		})
+
+		JustBeforeEach(func() {
+			record, updateErr = featureFlagRepo.UpdateFeatureFlag(ctx, authInfo, message)
+		})
+
+		It("fails because the user is not a CF admin", func() {
+			Expect(updateErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is a CF admin", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+			})
+
+			It("updates the feature flag", func() {
+				Expect(updateErr).NotTo(HaveOccurred())
+				Expect(record.Enabled).To(BeTrue())
+				Expect(record.CustomErrorMessage).To(Equal("no docker"))
+
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfFeatureFlag), cfFeatureFlag)).To(Succeed())
+				Expect(cfFeatureFlag.Spec.Enabled).To(BeTrue())
+			})
+
+			When("the feature flag is not overridden yet", func() {
+				BeforeEach(func() {
+					message = UpdateFeatureFlagMessage{
+						Name:               korifiv1alpha1.FeatureFlagTaskCreation,
+						CustomErrorMessage: tools.PtrTo("no tasks"),
+					}
+				})
+
+				It("creates the override keeping the default value", func() {
+					Expect(updateErr).NotTo(HaveOccurred())
+					Expect(record.Enabled).To(BeTrue())
+					Expect(record.CustomErrorMessage).To(Equal("no tasks"))
+
+					created := &korifiv1alpha1.CFFeatureFlag{}
+					Expect(k8sClient.Get(ctx, client.ObjectKey{Namespace: rootNamespace, Name: korifiv1alpha1.FeatureFlagTaskCreation}, created)).To(Succeed())
+					Expect(created.Spec.Enabled).To(BeTrue())
+					Expect(created.Spec.CustomErrorMessage).To(Equal("no tasks"))
+				})
+			})
+
+			When("the feature flag is unknown", func() {
+				BeforeEach(func() {
+					message.Name = "not_a_flag"
+				})
+
+				It("returns a not found error", func() {
+					Expect(updateErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
+				})
+			})
+		})
+	})
+})
@@ -0,0 +1,303 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools"
+	"code.cloudfoundry.org/korifi/tools/k8s"
+
+	"github.com/BooleanCat/go-functional/v2/it"
+	"github.com/BooleanCat/go-functional/v2/it/itx"
+	"github.com/google/uuid"
+	authv1 "k8s.io/api/authorization/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	IsolationSegmentResourceType = "Isolation Segment"
+)
+
+type IsolationSegmentRecord struct {
+	Name          string
+	GUID          string
+	Organizations []string
+	Labels        map[string]string
+	Annotations   map[string]string
+	CreatedAt     time.Time
+	UpdatedAt     *time.Time
+	DeletedAt     *time.Time
+}
+
+type CreateIsolationSegmentMessage struct {
+	Name     string
+	Metadata Metadata
+}
+
+type UpdateIsolationSegmentMessage struct {
+	GUID          string
+	Name          *string
+	MetadataPatch MetadataPatch
+}
+
+func (m *UpdateIsolationSegmentMessage) apply(isolationSegment *korifiv1alpha1.CFIsolationSegment) {
+	if m.Name != nil {
+		isolationSegment.Spec.Name = *m.Name
+	}
+	m.MetadataPatch.Apply(isolationSegment)
+}
+
+type ListIsolationSegmentsMessage struct {
+	GUIDs             []string
+	Names             []string
+	OrganizationGUIDs []string
+}
+
+func (m *ListIsolationSegmentsMessage) matches(s korifiv1alpha1.CFIsolationSegment) bool {
+	return tools.EmptyOrContains(m.GUIDs, s.Name) &&
+		tools.EmptyOrContains(m.Names, s.Spec.Name) &&
+		(len(m.OrganizationGUIDs) == 0 || slices.ContainsFunc(m.OrganizationGUIDs, s.IsEntitled))
+}
+
+type EntitleIsolationSegmentMessage struct {
+	GUID              string
+	OrganizationGUIDs []string
+}
+
+func (m *EntitleIsolationSegmentMessage) apply(isolationSegment *korifiv1alpha1.CFIsolationSegment) {
+	isolationSegment.Spec.Organizations = tools.Uniq(append(isolationSegment.Spec.Organizations, m.OrganizationGUIDs...))
+}
+
+type RevokeIsolationSegmentMessage struct {
+	GUID             string
+	OrganizationGUID string
+}
+
+func (m *RevokeIsolationSegmentMessage) apply(isolationSegment *korifiv1alpha1.CFIsolationSegment) {
+	isolationSegment.Spec.Organizations = slices.DeleteFunc(isolationSegment.Spec.Organizations, func(org string) bool {
+		return org == m.OrganizationGUID
+	})
+}
+
+type IsolationSegmentRepo struct {
+	userClientFactory authorization.UserClientFactory
+	nsPerms           *authorization.NamespacePermissions
+	rootNamespace     string
+}
+
+func NewIsolationSegmentRepo(
+	userClientFactory authorization.UserClientFactory,
+	nsPerms *authorization.NamespacePermissions,
+	rootNamespace string,
+) *IsolationSegmentRepo {
+	return &IsolationSegmentRepo{
+		userClientFactory: userClientFactory,
+		nsPerms:           nsPerms,
+		rootNamespace:     rootNamespace,
+	}
+}
+
+func (r *IsolationSegmentRepo) CreateIsolationSegment(ctx context.Context, authInfo authorization.Info, message CreateIsolationSegmentMessage) (IsolationSegmentRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return IsolationSegmentRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfIsolationSegment := &korifiv1alpha1.CFIsolationSegment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        uuid.NewString(),
+			Namespace:   r.rootNamespace,
+			Labels:      message.Metadata.Labels,
+			Annotations: message.Metadata.Annotations,
+		},
+		Spec: korifiv1alpha1.CFIsolationSegmentSpec{
+			Name: message.Name,
+		},
+	}
+
+	err = userClient.Create(ctx, cfIsolationSegment)
+	if err != nil {
+		return IsolationSegmentRecord{}, apierrors.FromK8sError(err, IsolationSegmentResourceType)
+	}
+
+	return cfIsolationSegmentToRecord(*cfIsolationSegment), nil
+}
+
+func (r *IsolationSegmentRepo) GetIsolationSegment(ctx context.Context, authInfo authorization.Info, guid string) (IsolationSegmentRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return IsolationSegmentRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfIsolationSegment := &korifiv1alpha1.CFIsolationSegment{}
+	err = userClient.Get(ctx, client.ObjectKey{Namespace: r.rootNamespace, Name: guid}, cfIsolationSegment)
+	if err != nil {
+		return IsolationSegmentRecord{}, apierrors.FromK8sError(err, IsolationSegmentResourceType)
+	}
+
+	isVisible, err := r.visibilityFilter(ctx, authInfo, userClient)
+	if err != nil {
+		return IsolationSegmentRecord{}, err
+	}
+
+	if !isVisible(*cfIsolationSegment) {
+		return IsolationSegmentRecord{}, apierrors.NewNotFoundError(nil, IsolationSegmentResourceType)
+	}
+
+	return cfIsolationSegmentToRecord(*cfIsolationSegment), nil
+}
+
+func (r *IsolationSegmentRepo) ListIsolationSegments(ctx context.Context, authInfo authorization.Info, message ListIsolationSegmentsMessage) ([]IsolationSegmentRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return []IsolationSegmentRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfIsolationSegments := &korifiv1alpha1.CFIsolationSegmentList{}
+	err = userClient.List(ctx, cfIsolationSegments, client.InNamespace(r.rootNamespace))
+	if err != nil {
+		if k8serrors.IsForbidden(err) {
+			return []IsolationSegmentRecord{}, nil
+		}
+		return []IsolationSegmentRecord{}, apierrors.FromK8sError(err, IsolationSegmentResourceType)
+	}
+
+	isVisible, err := r.visibilityFilter(ctx, authInfo, userClient)
+	if err != nil {
+		return []IsolationSegmentRecord{}, err
+	}
+
+	records := slices.Collect(it.Map(
+		itx.FromSlice(cfIsolationSegments.Items).Filter(isVisible).Filter(message.matches),
+		cfIsolationSegmentToRecord,
+	))
+	sort.Slice(records, func(i, j int) bool {
+		return records[i].CreatedAt.Before(records[j].CreatedAt)
+	})
+
+	return records, nil
+}
+
+// visibilityFilter returns whether an isolation segment can be seen by the
+// requester. As in CF, admins see all isolation segments while other users
+// only see the ones entitled to the orgs they belong to.
+func (r *IsolationSegmentRepo) visibilityFilter(
+	ctx context.Context,
+	authInfo authorization.Info,
+	userClient client.Client,
+) (func(korifiv1alpha1.CFIsolationSegment) bool, error) {
+	isAdmin, err := r.canICreateIsolationSegments(ctx, userClient)
+	if err != nil {
+		return nil, err
+	}
+
+	if isAdmin {
+		return func(korifiv1alpha1.CFIsolationSegment) bool { return true }, nil
+	}
+
+	authorizedOrgs, err := r.nsPerms.GetAuthorizedOrgNamespaces(ctx, authInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(isolationSegment korifiv1alpha1.CFIsolationSegment) bool {
+		return slices.ContainsFunc(isolationSegment.Spec.Organizations, func(org string) bool {
+			return authorizedOrgs[org]
+		})
+	}, nil
+}
+
+func (r *IsolationSegmentRepo) canICreateIsolationSegments(ctx context.Context, userClient client.Client) (bool, error) {
+	review := authv1.SelfSubjectAccessReview{
+		Spec: authv1.SelfSubjectAccessReviewSpec{
+			ResourceAttributes: &authv1.ResourceAttributes{
+				Namespace: r.rootNamespace,
+				Verb:      "create",
+				Group:     "korifi.cloudfoundry.org",
+				Resource:  "cfisolationsegments",
+			},
+		},
+	}
+	if err := userClient.Create(ctx, &review); err != nil {
+		return false, fmt.Errorf("failed to create self subject access review: %w", apierrors.FromK8sError(err, IsolationSegmentResourceType))
+	}
+
+	return review.Status.Allowed, nil
+}
+
+func (r *IsolationSegmentRepo) UpdateIsolationSegment(ctx context.Context, authInfo authorization.Info, message UpdateIsolationSegmentMessage) (IsolationSegmentRecord, error) {
+	return r.patchIsolationSegment(ctx, authInfo, message.GUID, message.apply)
+}
+
+func (r *IsolationSegmentRepo) EntitleOrganizations(ctx context.Context, authInfo authorization.Info, message EntitleIsolationSegmentMessage) (IsolationSegmentRecord, error) {
+	return r.patchIsolationSegment(ctx, authInfo, message.GUID, message.apply)
+}
+
+func (r *IsolationSegmentRepo) RevokeOrganization(ctx context.Context, authInfo authorization.Info, message RevokeIsolationSegmentMessage) error {
+	_, err := r.patchIsolationSegment(ctx, authInfo, message.GUID, message.apply)
+	return err
+}
+
+func (r *IsolationSegmentRepo) DeleteIsolationSegment(ctx context.Context, authInfo authorization.Info, guid string) error {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	err = userClient.Delete(ctx, &korifiv1alpha1.CFIsolationSegment{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: r.rootNamespace,
+			Name:      guid,
+		},
+	})
+
+	return apierrors.FromK8sError(err, IsolationSegmentResourceType)
+}
+
+func (r *IsolationSegmentRepo) patchIsolationSegment(
+	ctx context.Context,
+	authInfo authorization.Info,
+	guid string,
+	patchFunc func(*korifiv1alpha1.CFIsolationSegment),
+) (IsolationSegmentRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return IsolationSegmentRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfIsolationSegment := &korifiv1alpha1.CFIsolationSegment{}
+	err = userClient.Get(ctx, client.ObjectKey{Namespace: r.rootNamespace, Name: guid}, cfIsolationSegment)
+	if err != nil {
+		return IsolationSegmentRecord{}, apierrors.FromK8sError(err, IsolationSegmentResourceType)
+	}
+
+	err = k8s.PatchResource(ctx, userClient, cfIsolationSegment, func() {
+		patchFunc(cfIsolationSegment)
+	})
+	if err != nil {
+		return IsolationSegmentRecord{}, apierrors.FromK8sError(err, IsolationSegmentResourceType)
+	}
+
+	return cfIsolationSegmentToRecord(*cfIsolationSegment), nil
+}
+
+func cfIsolationSegmentToRecord(cfIsolationSegment korifiv1alpha1.CFIsolationSegment) IsolationSegmentRecord {
+	return IsolationSegmentRecord{
+		Name:          cfIsolationSegment.Spec.Name,
+		GUID:          cfIsolationSegment.Name,
+		Organizations: cfIsolationSegment.Spec.Organizations,
+		Labels:        cfIsolationSegment.Labels,
+		Annotations:   cfIsolationSegment.Annotations,
+		CreatedAt:     cfIsolationSegment.CreationTimestamp.Time,
+		UpdatedAt:     getLastUpdatedTime(&cfIsolationSegment),
+		DeletedAt:     golangTime(cfIsolationSegment.DeletionTimestamp),
+	}
+}
@@ -0,0 +1,303 @@
+package repositories_test
+
+import (
+	"time"
+
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	. "code.cloudfoundry.org/korifi/api/repositories"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tests/matchers"
+	"code.cloudfoundry.org/korifi/tools"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("IsolationSegmentRepository", func() {
+	var (
+		isolationSegmentRepo *IsolationSegmentRepo
+		cfIsolationSegment   *korifiv1alpha1.CFIsolationSegment
+		cfOrg                *korifiv1alpha1.CFOrg
+	)
+
+	BeforeEach(func() {
+		cfOrg = createOrgWithCleanup(ctx, prefixedGUID("org"))
+
+		cfIsolationSegment = &korifiv1alpha1.CFIsolationSegment{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      uuid.NewString(),
+				Namespace: rootNamespace,
+			},
+			Spec: korifiv1alpha1.CFIsolationSegmentSpec{
+				Name:          "my-iso-seg",
+				Organizations: []string{cfOrg.Name},
+				NodeSelector:  map[string]string{"pool": "isolated"},
+			},
+		}
+		Expect(k8sClient.Create(ctx, cfIsolationSegment)).To(Succeed())
+
+		isolationSegmentRepo = NewIsolationSegmentRepo(userClientFactory, nsPerms, rootNamespace)
+	})
+
+	AfterEach(func() {
+		Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, cfIsolationSegment))).To(Succeed())
+	})
+
+	Describe("GetIsolationSegment", func() {
+		var (
+			record IsolationSegmentRecord
+			getErr error
+		)
+
+		JustBeforeEach(func() {
+			record, getErr = isolationSegmentRepo.GetIsolationSegment(ctx, authInfo, cfIsolationSegment.Name)
+		})
+
+		It("returns a not found error as the isolation segment is not entitled to the user orgs", func() {
+			Expect(getErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
+		})
+
+		When("the user belongs to an entitled org", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, orgUserRole.Name, cfOrg.Name)
+			})
+
+			It("fetches the isolation segment", func() {
+				Expect(getErr).NotTo(HaveOccurred())
+				Expect(record.GUID).To(Equal(cfIsolationSegment.Name))
+				Expect(record.Name).To(Equal("my-iso-seg"))
+				Expect(record.Organizations).To(ConsistOf(cfOrg.Name))
+			})
+		})
+
+		When("the user is a CF admin", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+			})
+
+			It("fetches the isolation segment", func() {
+				Expect(getErr).NotTo(HaveOccurred())
+				Expect(record.GUID).To(Equal(cfIsolationSegment.Name))
+			})
+		})
+
+		When("the isolation segment does not exist", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+				Expect(k8sClient.Delete(ctx, cfIsolationSegment)).To(Succeed())
+			})
+
+			It("returns a not found error", func() {
+				Expect(getErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
+			})
+		})
+	})
+
+	Describe("ListIsolationSegments", func() {
+		var (
+			message ListIsolationSegmentsMessage
+			records []IsolationSegmentRecord
+			listErr error
+		)
+
+		var unentitledIsolationSegment *korifiv1alpha1.CFIsolationSegment
+
+		BeforeEach(func() {
+			message = ListIsolationSegmentsMessage{}
+
+			unentitledIsolationSegment = &korifiv1alpha1.CFIsolationSegment{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      uuid.NewString(),
+					Namespace: rootNamespace,
+				},
+				Spec: korifiv1alpha1.CFIsolationSegmentSpec{
+					Name: "other-iso-seg",
+				},
+			}
+			Expect(k8sClient.Create(ctx, unentitledIsolationSegment)).To(Succeed())
+			DeferCleanup(func() {
+				Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, unentitledIsolationSegment))).To(Succeed())
+			})
+
+			createRoleBinding(ctx, userName, orgUserRole.Name, cfOrg.Name)
+		})
+
+		JustBeforeEach(func() {
+			records, listErr = isolationSegmentRepo.ListIsolationSegments(ctx, authInfo, message)
+		})
+
+		It("lists the isolation segments entitled to the user orgs", func() {
+			Expect(listErr).NotTo(HaveOccurred())
+			Expect(records).To(ContainElement(MatchFields(IgnoreExtras, Fields{
+				"GUID": Equal(cfIsolationSegment.Name),
+			})))
+			Expect(records).NotTo(ContainElement(MatchFields(IgnoreExtras, Fields{
+				"GUID": Equal(unentitledIsolationSegment.Name),
+			})))
+		})
+
+		When("the user is a CF admin", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+			})
+
+			It("lists all isolation segments", func() {
+				Expect(listErr).NotTo(HaveOccurred())
+				Expect(records).To(ContainElements(
+					MatchFields(IgnoreExtras, Fields{"GUID": Equal(cfIsolationSegment.Name)}),
+					MatchFields(IgnoreExtras, Fields{"GUID": Equal(unentitledIsolationSegment.Name)}),
+				))
+			})
+		})
+
+		When("filtering by organization guid", func() {
+			BeforeEach(func() {
+				message.OrganizationGUIDs = []string{"org-2"}
+			})
+
+			It("only returns isolation segments entitled to the organization", func() {
+				Expect(listErr).NotTo(HaveOccurred())
+				Expect(records).To(BeEmpty())
+			})
+		})
+	})
+
+	Describe("CreateIsolationSegment", func() {
+		var (
+			record    IsolationSegmentRecord
+			createErr error
+		)
+
+		JustBeforeEach(func() {
+			record, createErr = isolationSegmentRepo.CreateIsolationSegment(ctx, authInfo, CreateIsolationSegmentMessage{
+				Name: "new-iso-seg",
+				Metadata: Metadata{
+					Labels: map[string]string{"foo": "bar"},
+				},
+			})
+		})
+
+		It("fails because the user is not a CF admin", func() {
+			Expect(createErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is a CF admin", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+			})
+
+			It("creates the isolation segment", func() {
+				Expect(createErr).NotTo(HaveOccurred())
+				Expect(record.GUID).To(matchers.BeValidUUID())
+				Expect(record.Name).To(Equal("new-iso-seg"))
+				Expect(record.Labels).To(HaveKeyWithValue("foo", "bar"))
+				Expect(record.CreatedAt).To(BeTemporally("~", time.Now(), timeCheckThreshold))
+
+				created := &korifiv1alpha1.CFIsolationSegment{}
+				Expect(k8sClient.Get(ctx, client.ObjectKey{Namespace: rootNamespace, Name: record.GUID}, created)).To(Succeed())
+				Expect(created.Spec.Name).To(Equal("new-iso-seg"))
+			})
+		})
+	})
+
+	Describe("UpdateIsolationSegment", func() {
+		var (
+			record    IsolationSegmentRecord
+			updateErr error
+		)
+
+		BeforeEach(func() {
+			createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+		})
+
+		JustBeforeEach(func() {
+			record, updateErr = isolationSegmentRepo.UpdateIsolationSegment(ctx, authInfo, UpdateIsolationSegmentMessage{
+				GUID: cfIsolationSegment.Name,
+				Name: tools.PtrTo("renamed"),
+			})
+		})
+
+		It("updates the isolation segment name", func() {
+			Expect(updateErr).NotTo(HaveOccurred())
+			Expect(record.Name).To(Equal("renamed"))
+
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfIsolationSegment), cfIsolationSegment)).To(Succeed())
+			Expect(cfIsolationSegment.Spec.Name).To(Equal("renamed"))
+			Expect(cfIsolationSegment.Spec.NodeSelector).To(HaveKeyWithValue("pool", "isolated"))
+		})
+	})
+
+	Describe("EntitleOrganizations", func() {
+		var (
+			record     IsolationSegmentRecord
+			entitleErr error
+		)
+
+		BeforeEach(func() {
+			createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+		})
+
+		JustBeforeEach(func() {
+			record, entitleErr = isolationSegmentRepo.EntitleOrganizations(ctx, authInfo, EntitleIsolationSegmentMessage{
+				GUID:              cfIsolationSegment.Name,
+				OrganizationGUIDs: []string{cfOrg.Name, "org-2"},
+			})
+		})
+
+		It("adds the organizations without duplicates", func() {
+			Expect(entitleErr).NotTo(HaveOccurred())
+			Expect(record.Organizations).To(ConsistOf(cfOrg.Name, "org-2"))
+		})
+	})
+
+	Describe("RevokeOrganization", func() {
+		var revokeErr error
+
+		BeforeEach(func() {
+			createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+		})
+
+		JustBeforeEach(func() {
+			revokeErr = isolationSegmentRepo.RevokeOrganization(ctx, authInfo, RevokeIsolationSegmentMessage{
+				GUID:             cfIsolationSegment.Name,
+				OrganizationGUID: cfOrg.Name,
+			})
+		})
+
+		It("removes the organization entitlement", func() {
+			Expect(revokeErr).NotTo(HaveOccurred())
+
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfIsolationSegment), cfIsolationSegment)).To(Succeed())
+			Expect(cfIsolationSegment.Spec.Organizations).To(BeEmpty())
+		})
+	})
+
+	Describe("DeleteIsolationSegment", func() {
+		var deleteErr error
+
+		JustBeforeEach(func() {
+			deleteErr = isolationSegmentRepo.DeleteIsolationSegment(ctx, authInfo, cfIsolationSegment.Name)
+		})
+
+		It("fails because the user is not a CF admin", func() {
+			Expect(deleteErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is a CF admin", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+			})
+
+			It("deletes the isolation segment", func() {
+				Expect(deleteErr).NotTo(HaveOccurred())
+				err := k8sClient.Get(ctx, client.ObjectKeyFromObject(cfIsolationSegment), &korifiv1alpha1.CFIsolationSegment{})
+				Expect(client.IgnoreNotFound(err)).To(Succeed())
+				Expect(err).To(HaveOccurred())
+			})
+		})
+	})
+})
@@ -11,9 +11,10 @@ import (
 	"k8s.io/client-go/dynamic"
 )
 
-//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfapps;cfbuilds;cfpackages;cfprocesses;cfspaces;cftasks,verbs=list
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfapps;cfbuilds;cfpackages;cfprocesses;cfspaces;cftasks;cftaskschedules,verbs=list
 //+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfdomains;cfroutes,verbs=list
 //+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfservicebindings;cfserviceinstances,verbs=list
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfservicebrokers;cfserviceofferings;cfserviceplans,verbs=list
 
 var (
 	CFAppsGVR = schema.GroupVersionResource{
@@ -70,6 +71,24 @@ var (
 		Resource: "cfserviceinstances",
 	}
 
+	CFServiceBrokersGVR = schema.GroupVersionResource{
+		Group:    "korifi.cloudfoundry.org",
+		Version:  "v1alpha1",
+		Resource: "cfservicebrokers",
+	}
+
+	CFServiceOfferingsGVR = schema.GroupVersionResource{
+		Group:    "korifi.cloudfoundry.org",
+		Version:  "v1alpha1",
+		Resource: "cfserviceofferings",
+	}
+
+	CFServicePlansGVR = schema.GroupVersionResource{
+		Group:    "korifi.cloudfoundry.org",
+		Version:  "v1alpha1",
+		Resource: "cfserviceplans",
+	}
+
 	CFSpacesGVR = schema.GroupVersionResource{
 		Group:    "korifi.cloudfoundry.org",
 		Version:  "v1alpha1",
@@ -82,6 +101,12 @@ var (
 		Resource: "cftasks",
 	}
 
+	CFTaskSchedulesGVR = schema.GroupVersionResource{
+		Group:    "korifi.cloudfoundry.org",
+		Version:  "v1alpha1",
+		Resource: "cftaskschedules",
+	}
+
 	ResourceMap = map[string]schema.GroupVersionResource{
 		AppResourceType:             CFAppsGVR,
 		BuildResourceType:           CFBuildsGVR,
@@ -92,8 +117,12 @@ var (
 		RouteResourceType:           CFRoutesGVR,
 		ServiceBindingResourceType:  CFServiceBindingsGVR,
 		ServiceInstanceResourceType: CFServiceInstancesGVR,
+		ServiceBrokerResourceType:   CFServiceBrokersGVR,
+		ServiceOfferingResourceType: CFServiceOfferingsGVR,
+		ServicePlanResourceType:     CFServicePlansGVR,
 		SpaceResourceType:           CFSpacesGVR,
 		TaskResourceType:            CFTasksGVR,
+		TaskScheduleResourceType:    CFTaskSchedulesGVR,
 	}
 )
 
@@ -7,14 +7,17 @@ import (
 	"time"
 
 	"code.cloudfoundry.org/korifi/api/authorization"
+	"code.cloudfoundry.org/korifi/api/config"
 	apierrors "code.cloudfoundry.org/korifi/api/errors"
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/model"
 	"code.cloudfoundry.org/korifi/tools"
 	"code.cloudfoundry.org/korifi/tools/k8s"
 
 	"github.com/BooleanCat/go-functional/v2/it"
 	"github.com/BooleanCat/go-functional/v2/it/itx"
 	"github.com/google/uuid"
+	rbacv1 "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -24,6 +27,14 @@ const (
 	OrgResourceType = "Org"
 )
 
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cforgs,verbs=create;get;list;watch,namespace=ROOT_NAMESPACE
+//+kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=rolebindings,verbs=create
+//+kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=clusterroles,verbs=bind,resourceNames=korifi-controllers-organization-manager;korifi-controllers-organization-user
+
+// orgCreatorRoles are the roles users get in the orgs they create when
+// user_org_creation is enabled
+var orgCreatorRoles = []string{"organization_manager", "organization_user"}
+
 type CreateOrgMessage struct {
 	Name        string
 	Suspended   bool
@@ -68,6 +79,7 @@ type OrgRepo struct {
 	userClientFactory authorization.UserClientFactory
 	nsPerms           *authorization.NamespacePermissions
 	conditionAwaiter  Awaiter[*korifiv1alpha1.CFOrg]
+	roleMappings      map[string]config.Role
 }
 
 func NewOrgRepo(
@@ -76,6 +88,7 @@ func NewOrgRepo(
 	userClientFactory authorization.UserClientFactory,
 	nsPerms *authorization.NamespacePermissions,
 	conditionAwaiter Awaiter[*korifiv1alpha1.CFOrg],
+	roleMappings map[string]config.Role,
 ) *OrgRepo {
 	return &OrgRepo{
 		rootNamespace:     rootNamespace,
@@ -83,27 +96,31 @@ func NewOrgRepo(
 		userClientFactory: userClientFactory,
 		nsPerms:           nsPerms,
 		conditionAwaiter:  conditionAwaiter,
+		roleMappings:      roleMappings,
 	}
 }
 
-func (r *OrgRepo) CreateOrg(ctx context.Context, info authorization.Info, message CreateOrgMessage) (OrgRecord, error) {
-	userClient, err := r.userClientFactory.BuildClient(info)
-	if err != nil {
-		return OrgRecord{}, fmt.Errorf("failed to build user client: %w", err)
-	}
-
-	cfOrg := &korifiv1alpha1.CFOrg{
+func (m CreateOrgMessage) toCFOrg(rootNamespace string) *korifiv1alpha1.CFOrg {
+	return &korifiv1alpha1.CFOrg{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:        uuid.NewString(),
-			Namespace:   r.rootNamespace,
-			Labels:      message.Labels,
-			Annotations: message.Annotations,
+			Namespace:   rootNamespace,
+			Labels:      m.Labels,
+			Annotations: m.Annotations,
 		},
 		Spec: korifiv1alpha1.CFOrgSpec{
-			DisplayName: message.Name,
+			DisplayName: m.Name,
 		},
 	}
+}
 
+func (r *OrgRepo) CreateOrg(ctx context.Context, info authorization.Info, message CreateOrgMessage) (OrgRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(info)
+	if err != nil {
+		return OrgRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfOrg := message.toCFOrg(r.rootNamespace)
 	err = userClient.Create(ctx, cfOrg)
 	if err != nil {
 		return OrgRecord{}, fmt.Errorf("failed to create cf org: %w", apierrors.FromK8sError(err, OrgResourceType))
@@ -117,6 +134,42 @@ func (r *OrgRepo) CreateOrg(ctx context.Context, info authorization.Info, messag
 	return cfOrgToOrgRecord(*cfOrg), nil
 }
 
+// CreateOrgAsManager creates an org on behalf of a user that is not allowed
+// to create orgs, which the user_org_creation feature flag allows. As in CF,
+// the user becomes a manager and a user of the new org.
+func (r *OrgRepo) CreateOrgAsManager(ctx context.Context, manager authorization.Identity, message CreateOrgMessage) (OrgRecord, error) {
+	cfOrg := message.toCFOrg(r.rootNamespace)
+	err := r.privilegedClient.Create(ctx, cfOrg)
+	if err != nil {
+		return OrgRecord{}, fmt.Errorf("failed to create cf org: %w", apierrors.FromK8sError(err, OrgResourceType))
+	}
+
+	cfOrg, err = r.conditionAwaiter.AwaitCondition(ctx, r.privilegedClient, cfOrg, korifiv1alpha1.StatusConditionReady)
+	if err != nil {
+		return OrgRecord{}, apierrors.FromK8sError(err, OrgResourceType)
+	}
+
+	subjectName, subjectNamespace := manager.Name, ""
+	if manager.Kind == rbacv1.ServiceAccountKind {
+		subjectNamespace, subjectName = authorization.ServiceAccountNSAndName(manager.Name)
+	}
+
+	for _, roleType := range orgCreatorRoles {
+		roleConfig, ok := r.roleMappings[roleType]
+		if !ok {
+			return OrgRecord{}, fmt.Errorf("no role mapping for %q", roleType)
+		}
+
+		roleBinding := createRoleBinding(cfOrg.Name, roleType, manager.Kind, subjectName, subjectNamespace, uuid.NewString(), roleConfig.Name, roleConfig.Propagate)
+		err = r.privilegedClient.Create(ctx, &roleBinding)
+		if err != nil {
+			return OrgRecord{}, fmt.Errorf("failed to assign role %q: %w", roleType, apierrors.FromK8sError(err, RoleResourceType))
+		}
+	}
+
+	return cfOrgToOrgRecord(*cfOrg), nil
+}
+
 func (r *OrgRepo) ListOrgs(ctx context.Context, info authorization.Info, message ListOrgsMessage) ([]OrgRecord, error) {
 	authorizedNamespaces, err := r.nsPerms.GetAuthorizedOrgNamespaces(ctx, info)
 	if err != nil {
@@ -212,6 +265,21 @@ func (r *OrgRepo) GetDeletedAt(ctx context.Context, authInfo authorization.Info,
 	return nil, err
 }
 
+func (r *OrgRepo) GetOperation(ctx context.Context, authInfo authorization.Info, orgGUID string) (model.CFResourceOperation, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return model.CFResourceOperation{State: model.CFResourceStateUnknown}, fmt.Errorf("get-operation failed to build user client: %w", err)
+	}
+
+	cfOrg := new(korifiv1alpha1.CFOrg)
+	err = userClient.Get(ctx, client.ObjectKey{Namespace: r.rootNamespace, Name: orgGUID}, cfOrg)
+	if err != nil {
+		return model.CFResourceOperation{State: model.CFResourceStateUnknown}, apierrors.FromK8sError(err, OrgResourceType)
+	}
+
+	return namespaceOperation(cfOrg, cfOrg.Status.Conditions), nil
+}
+
 func cfOrgToOrgRecord(cfOrg korifiv1alpha1.CFOrg) OrgRecord {
 	return OrgRecord{
 		GUID:        cfOrg.Name,
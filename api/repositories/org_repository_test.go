@@ -6,10 +6,12 @@ import (
 	"time"
 
 	"code.cloudfoundry.org/korifi/api/authorization"
+	"code.cloudfoundry.org/korifi/api/config"
 	apierrors "code.cloudfoundry.org/korifi/api/errors"
 	"code.cloudfoundry.org/korifi/api/repositories"
 	"code.cloudfoundry.org/korifi/api/repositories/fakeawaiter"
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/model"
 	"code.cloudfoundry.org/korifi/tests/matchers"
 	"code.cloudfoundry.org/korifi/tools"
 	"code.cloudfoundry.org/korifi/tools/k8s"
@@ -18,6 +20,7 @@ import (
 	. "github.com/onsi/gomega"
 	. "github.com/onsi/gomega/gstruct"
 	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -41,7 +44,10 @@ var _ = Describe("OrgRepository", func() {
 			korifiv1alpha1.CFOrgList,
 			*korifiv1alpha1.CFOrgList,
 		]{}
-		orgRepo = repositories.NewOrgRepo(rootNamespace, k8sClient, userClientFactory, nsPerms, conditionAwaiter)
+		orgRepo = repositories.NewOrgRepo(rootNamespace, k8sClient, userClientFactory, nsPerms, conditionAwaiter, map[string]config.Role{
+			"organization_manager": {Name: orgManagerRole.Name, Level: config.OrgRole, Propagate: true},
+			"organization_user":    {Name: orgUserRole.Name, Level: config.OrgRole},
+		})
 	})
 
 	Describe("CreateOrg", func() {
@@ -163,6 +169,56 @@ var _ = Describe("OrgRepository", func() {
 		})
 	})
 
+	Describe("CreateOrgAsManager", func() {
+		var (
+			createErr error
+			orgRecord repositories.OrgRecord
+		)
+
+		BeforeEach(func() {
+			conditionAwaiter.AwaitConditionStub = func(ctx context.Context, _ client.WithWatch, object client.Object, _ string) (*korifiv1alpha1.CFOrg, error) {
+				cfOrg, ok := object.(*korifiv1alpha1.CFOrg)
+				Expect(ok).To(BeTrue())
+
+				Expect(k8sClient.Create(ctx, &corev1.Namespace{
+					ObjectMeta: metav1.ObjectMeta{Name: cfOrg.Name},
+				})).To(Succeed())
+
+				return cfOrg, nil
+			}
+		})
+
+		JustBeforeEach(func() {
+			orgRecord, createErr = orgRepo.CreateOrgAsManager(ctx, authorization.Identity{Name: userName, Kind: rbacv1.UserKind}, repositories.CreateOrgMessage{
+				Name: prefixedGUID("org"),
+			})
+		})
+
+		It("creates the org although the user is not allowed to", func() {
+			Expect(createErr).NotTo(HaveOccurred())
+
+			cfOrg := new(korifiv1alpha1.CFOrg)
+			Expect(k8sClient.Get(ctx, client.ObjectKey{Namespace: rootNamespace, Name: orgRecord.GUID}, cfOrg)).To(Succeed())
+		})
+
+		It("makes the user a manager and a user of the org", func() {
+			Expect(createErr).NotTo(HaveOccurred())
+
+			roleBindings := &rbacv1.RoleBindingList{}
+			Expect(k8sClient.List(ctx, roleBindings, client.InNamespace(orgRecord.GUID))).To(Succeed())
+			Expect(roleBindings.Items).To(ConsistOf(
+				MatchFields(IgnoreExtras, Fields{
+					"RoleRef":  MatchFields(IgnoreExtras, Fields{"Name": Equal(orgManagerRole.Name)}),
+					"Subjects": ConsistOf(MatchFields(IgnoreExtras, Fields{"Kind": Equal(rbacv1.UserKind), "Name": Equal(userName)})),
+				}),
+				MatchFields(IgnoreExtras, Fields{
+					"RoleRef":  MatchFields(IgnoreExtras, Fields{"Name": Equal(orgUserRole.Name)}),
+					"Subjects": ConsistOf(MatchFields(IgnoreExtras, Fields{"Kind": Equal(rbacv1.UserKind), "Name": Equal(userName)})),
+				}),
+			))
+		})
+	})
+
 	Describe("ListOrgs", func() {
 		var cfOrg1, cfOrg2, cfOrg3 *korifiv1alpha1.CFOrg
 
@@ -676,4 +732,85 @@ var _ = Describe("OrgRepository", func() {
 			})
 		})
 	})
+
+	Describe("GetOperation", func() {
+		var (
+			cfOrg     *korifiv1alpha1.CFOrg
+			operation model.CFResourceOperation
+			getErr    error
+		)
+
+		BeforeEach(func() {
+			cfOrg = createOrgWithCleanup(ctx, prefixedGUID("the-org"))
+			createRoleBinding(ctx, userName, orgUserRole.Name, cfOrg.Name)
+		})
+
+		JustBeforeEach(func() {
+			operation, getErr = orgRepo.GetOperation(ctx, authInfo, cfOrg.Name)
+		})
+
+		It("returns unknown state", func() {
+			Expect(getErr).NotTo(HaveOccurred())
+			Expect(operation.State).To(Equal(model.CFResourceStateUnknown))
+		})
+
+		When("the org is being deleted", func() {
+			BeforeEach(func() {
+				Expect(k8s.PatchResource(ctx, k8sClient, cfOrg, func() {
+					cfOrg.Finalizers = append(cfOrg.Finalizers, "foo")
+				})).To(Succeed())
+				Expect(k8sClient.Delete(ctx, cfOrg)).To(Succeed())
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfOrg), cfOrg)).To(Succeed())
+			})
+
+			When("deleting the namespace has failed", func() {
+				BeforeEach(func() {
+					Expect(k8s.Patch(ctx, k8sClient, cfOrg, func() {
+						meta.SetStatusCondition(&cfOrg.Status.Conditions, metav1.Condition{
+							Type:               korifiv1alpha1.StatusConditionReady,
+							Status:             metav1.ConditionFalse,
+							ObservedGeneration: cfOrg.Generation,
+							Reason:             "Unknown",
+							Message:            "failed to delete namespace",
+						})
+					})).To(Succeed())
+				})
+
+				It("returns failed state with the not ready message as an error", func() {
+					Expect(getErr).NotTo(HaveOccurred())
+					Expect(operation.State).To(Equal(model.CFResourceStateFailed))
+					Expect(operation.Errors).To(ConsistOf("failed to delete namespace"))
+				})
+			})
+
+			When("the ready condition is stale", func() {
+				BeforeEach(func() {
+					Expect(k8s.Patch(ctx, k8sClient, cfOrg, func() {
+						meta.SetStatusCondition(&cfOrg.Status.Conditions, metav1.Condition{
+							Type:               korifiv1alpha1.StatusConditionReady,
+							Status:             metav1.ConditionFalse,
+							ObservedGeneration: cfOrg.Generation - 1,
+							Reason:             "Unknown",
+							Message:            "failed to delete namespace",
+						})
+					})).To(Succeed())
+				})
+
+				It("returns unknown state", func() {
+					Expect(getErr).NotTo(HaveOccurred())
+					Expect(operation.State).To(Equal(model.CFResourceStateUnknown))
+				})
+			})
+		})
+
+		When("the org isn't found", func() {
+			BeforeEach(func() {
+				Expect(k8sClient.Delete(ctx, cfOrg)).To(Succeed())
+			})
+
+			It("errors", func() {
+				Expect(getErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
+			})
+		})
+	})
 })
@@ -0,0 +1,48 @@
+package repositories
+
+// Pagination selects a page of a list. A zero PerPage selects all the
+// results.
+type Pagination struct {
+	PerPage int
+	Page    int
+}
+
+type PageInfo struct {
+	TotalResults int
+	TotalPages   int
+	PageNumber   int
+	PageSize     int
+}
+
+type ListResult[T any] struct {
+	PageInfo PageInfo
+	Records  []T
+}
+
+func paginate[T any](records []T, pagination Pagination) ListResult[T] {
+	if pagination.PerPage <= 0 {
+		return ListResult[T]{
+			PageInfo: PageInfo{
+				TotalResults: len(records),
+				TotalPages:   1,
+				PageNumber:   1,
+				PageSize:     len(records),
+			},
+			Records: records,
+		}
+	}
+
+	page := max(pagination.Page, 1)
+	start := min((page-1)*pagination.PerPage, len(records))
+	end := min(start+pagination.PerPage, len(records))
+
+	return ListResult[T]{
+		PageInfo: PageInfo{
+			TotalResults: len(records),
+			TotalPages:   max((len(records)+pagination.PerPage-1)/pagination.PerPage, 1),
+			PageNumber:   page,
+			PageSize:     pagination.PerPage,
+		},
+		Records: records[start:end],
+	}
+}
@@ -40,9 +40,12 @@ type ProcessRecord struct {
 	Type             string
 	Command          string
 	DesiredInstances int32
+	ActualInstances  int32
 	MemoryMB         int64
 	DiskQuotaMB      int64
+	CPUMillicores    int64
 	HealthCheck      HealthCheck
+	Autoscaling      *AutoscalingPolicy
 	Labels           map[string]string
 	Annotations      map[string]string
 	CreatedAt        time.Time
@@ -50,6 +53,16 @@ type ProcessRecord struct {
 	InstancesStatus  map[string]korifiv1alpha1.InstanceStatus
 }
 
+// ExpectedInstances returns the number of instances the process should be
+// running. The autoscaler decides it for autoscaled processes.
+func (r ProcessRecord) ExpectedInstances() int32 {
+	if r.Autoscaling != nil {
+		return r.ActualInstances
+	}
+
+	return r.DesiredInstances
+}
+
 func (r ProcessRecord) Relationships() map[string]string {
 	return map[string]string{
 		"app": r.AppGUID,
@@ -71,6 +84,23 @@ type HealthCheckData struct {
 	TimeoutSeconds           int32
 }
 
+type AutoscalingPolicy struct {
+	MinInstances      int32
+	MaxInstances      int32
+	CPUUtilization    *int32
+	MemoryUtilization *int32
+	Schedules         []AutoscalingSchedule
+}
+
+type AutoscalingSchedule struct {
+	StartTime    string
+	EndTime      string
+	DaysOfWeek   []int32
+	TimeZone     string
+	MinInstances int32
+	MaxInstances int32
+}
+
 type ScaleProcessMessage struct {
 	GUID      string
 	SpaceGUID string
@@ -78,9 +108,10 @@ type ScaleProcessMessage struct {
 }
 
 type ProcessScaleValues struct {
-	Instances *int32
-	MemoryMB  *int64
-	DiskMB    *int64
+	Instances     *int32
+	MemoryMB      *int64
+	DiskMB        *int64
+	CPUMillicores *int64
 }
 
 type CreateProcessMessage struct {
@@ -92,6 +123,7 @@ type CreateProcessMessage struct {
 	HealthCheck      HealthCheck
 	DesiredInstances *int32
 	MemoryMB         int64
+	CPUMillicores    int64
 }
 
 type PatchProcessMessage struct {
@@ -105,9 +137,17 @@ type PatchProcessMessage struct {
 	HealthCheckType                     *string
 	DesiredInstances                    *int32
 	MemoryMB                            *int64
+	CPUMillicores                       *int64
 	MetadataPatch                       *MetadataPatch
 }
 
+type SetProcessAutoscalingMessage struct {
+	ProcessGUID string
+	SpaceGUID   string
+	// Policy is nil to stop autoscaling the process
+	Policy *AutoscalingPolicy
+}
+
 type ListProcessesMessage struct {
 	AppGUIDs     []string
 	ProcessTypes []string
@@ -185,6 +225,9 @@ func (r *ProcessRepo) ScaleProcess(ctx context.Context, authInfo authorization.I
 		if scaleProcessMessage.DiskMB != nil {
 			cfProcess.Spec.DiskQuotaMB = *scaleProcessMessage.DiskMB
 		}
+		if scaleProcessMessage.CPUMillicores != nil {
+			cfProcess.Spec.CPUMillicores = *scaleProcessMessage.CPUMillicores
+		}
 	})
 	if err != nil {
 		return ProcessRecord{}, fmt.Errorf("failed to scale process %q: %w", scaleProcessMessage.GUID, apierrors.FromK8sError(err, ProcessResourceType))
@@ -215,6 +258,7 @@ func (r *ProcessRepo) CreateProcess(ctx context.Context, authInfo authorization.
 			DesiredInstances: message.DesiredInstances,
 			MemoryMB:         message.MemoryMB,
 			DiskQuotaMB:      message.DiskQuotaMB,
+			CPUMillicores:    message.CPUMillicores,
 		},
 	}
 	err = userClient.Create(ctx, process)
@@ -271,6 +315,9 @@ func (r *ProcessRepo) PatchProcess(ctx context.Context, authInfo authorization.I
 		if message.DiskQuotaMB != nil {
 			updatedProcess.Spec.DiskQuotaMB = *message.DiskQuotaMB
 		}
+		if message.CPUMillicores != nil {
+			updatedProcess.Spec.CPUMillicores = *message.CPUMillicores
+		}
 		if message.HealthCheckType != nil {
 			// TODO: how do we handle when the type changes? Clear the HTTPEndpoint when type != http? Should we require the endpoint when type == http?
 			updatedProcess.Spec.HealthCheck.Type = korifiv1alpha1.HealthCheckType(*message.HealthCheckType)
@@ -295,6 +342,60 @@ func (r *ProcessRepo) PatchProcess(ctx context.Context, authInfo authorization.I
 	return cfProcessToProcessRecord(*updatedProcess), nil
 }
 
+func (r *ProcessRepo) SetProcessAutoscaling(ctx context.Context, authInfo authorization.Info, message SetProcessAutoscalingMessage) (ProcessRecord, error) {
+	userClient, err := r.clientFactory.BuildClient(authInfo)
+	if err != nil {
+		return ProcessRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfProcess := &korifiv1alpha1.CFProcess{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      message.ProcessGUID,
+			Namespace: message.SpaceGUID,
+		},
+	}
+	err = k8s.PatchResource(ctx, userClient, cfProcess, func() {
+		cfProcess.Spec.Autoscaling = toCFAutoscalingPolicy(message.Policy)
+	})
+	if err != nil {
+		return ProcessRecord{}, fmt.Errorf("failed to set autoscaling policy of process %q: %w", message.ProcessGUID, apierrors.FromK8sError(err, ProcessResourceType))
+	}
+
+	return cfProcessToProcessRecord(*cfProcess), nil
+}
+
+func toCFAutoscalingPolicy(policy *AutoscalingPolicy) *korifiv1alpha1.AutoscalingPolicy {
+	if policy == nil {
+		return nil
+	}
+
+	return &korifiv1alpha1.AutoscalingPolicy{
+		MinInstances:      policy.MinInstances,
+		MaxInstances:      policy.MaxInstances,
+		CPUUtilization:    policy.CPUUtilization,
+		MemoryUtilization: policy.MemoryUtilization,
+		Schedules: slices.Collect(it.Map(slices.Values(policy.Schedules), func(s AutoscalingSchedule) korifiv1alpha1.AutoscalingSchedule {
+			return korifiv1alpha1.AutoscalingSchedule(s)
+		})),
+	}
+}
+
+func toAutoscalingPolicy(policy *korifiv1alpha1.AutoscalingPolicy) *AutoscalingPolicy {
+	if policy == nil {
+		return nil
+	}
+
+	return &AutoscalingPolicy{
+		MinInstances:      policy.MinInstances,
+		MaxInstances:      policy.MaxInstances,
+		CPUUtilization:    policy.CPUUtilization,
+		MemoryUtilization: policy.MemoryUtilization,
+		Schedules: slices.Collect(it.Map(slices.Values(policy.Schedules), func(s korifiv1alpha1.AutoscalingSchedule) AutoscalingSchedule {
+			return AutoscalingSchedule(s)
+		})),
+	}
+}
+
 func cfProcessToProcessRecord(cfProcess korifiv1alpha1.CFProcess) ProcessRecord {
 	cmd := cfProcess.Spec.Command
 	if cmd == "" {
@@ -308,8 +409,10 @@ func cfProcessToProcessRecord(cfProcess korifiv1alpha1.CFProcess) ProcessRecord
 		Type:             cfProcess.Spec.ProcessType,
 		Command:          cmd,
 		DesiredInstances: tools.ZeroIfNil(cfProcess.Spec.DesiredInstances),
+		ActualInstances:  cfProcess.Status.ActualInstances,
 		MemoryMB:         cfProcess.Spec.MemoryMB,
 		DiskQuotaMB:      cfProcess.Spec.DiskQuotaMB,
+		CPUMillicores:    cfProcess.Spec.CPUMillicores,
 		HealthCheck: HealthCheck{
 			Type: string(cfProcess.Spec.HealthCheck.Type),
 			Data: HealthCheckData{
@@ -318,6 +421,7 @@ func cfProcessToProcessRecord(cfProcess korifiv1alpha1.CFProcess) ProcessRecord
 				TimeoutSeconds:           cfProcess.Spec.HealthCheck.Data.TimeoutSeconds,
 			},
 		},
+		Autoscaling:     toAutoscalingPolicy(cfProcess.Spec.Autoscaling),
 		Labels:          cfProcess.Labels,
 		Annotations:     cfProcess.Annotations,
 		CreatedAt:       cfProcess.CreationTimestamp.Time,
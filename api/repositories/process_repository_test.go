@@ -66,6 +66,7 @@ var _ = Describe("ProcessRepo", func() {
 				DesiredInstances: tools.PtrTo[int32](1),
 				MemoryMB:         500,
 				DiskQuotaMB:      512,
+				CPUMillicores:    250,
 			},
 		}
 		Expect(k8sClient.Create(ctx, cfProcess)).To(Succeed())
@@ -114,6 +115,7 @@ var _ = Describe("ProcessRepo", func() {
 				Expect(processRecord.DesiredInstances).To(BeEquivalentTo(1))
 				Expect(processRecord.MemoryMB).To(BeEquivalentTo(500))
 				Expect(processRecord.DiskQuotaMB).To(BeEquivalentTo(512))
+				Expect(processRecord.CPUMillicores).To(BeEquivalentTo(250))
 				Expect(processRecord.HealthCheck.Type).To(Equal("process"))
 				Expect(processRecord.HealthCheck.Data.InvocationTimeoutSeconds).To(BeEquivalentTo(5))
 				Expect(processRecord.HealthCheck.Data.TimeoutSeconds).To(BeEquivalentTo(6))
@@ -127,6 +129,32 @@ var _ = Describe("ProcessRepo", func() {
 				Expect(processRecord.Relationships()).To(Equal(map[string]string{
 					"app": appGUID,
 				}))
+				Expect(processRecord.Autoscaling).To(BeNil())
+				Expect(processRecord.ExpectedInstances()).To(BeEquivalentTo(1))
+			})
+
+			When("the process is autoscaled", func() {
+				BeforeEach(func() {
+					Expect(k8s.Patch(ctx, k8sClient, cfProcess, func() {
+						cfProcess.Spec.Autoscaling = &korifiv1alpha1.AutoscalingPolicy{
+							MinInstances:   2,
+							MaxInstances:   4,
+							CPUUtilization: tools.PtrTo[int32](60),
+						}
+						cfProcess.Status.ActualInstances = 3
+					})).To(Succeed())
+				})
+
+				It("returns the autoscaling policy and the actual instances", func() {
+					Expect(getErr).NotTo(HaveOccurred())
+					Expect(processRecord.Autoscaling).To(PointTo(Equal(repositories.AutoscalingPolicy{
+						MinInstances:   2,
+						MaxInstances:   4,
+						CPUUtilization: tools.PtrTo[int32](60),
+					})))
+					Expect(processRecord.ActualInstances).To(BeEquivalentTo(3))
+					Expect(processRecord.ExpectedInstances()).To(BeEquivalentTo(3))
+				})
 			})
 
 			When("desired instances are not set on the process spec", func() {
@@ -314,9 +342,10 @@ var _ = Describe("ProcessRepo", func() {
 				GUID:      cfProcess.Name,
 				SpaceGUID: space.Name,
 				ProcessScaleValues: repositories.ProcessScaleValues{
-					Instances: tools.PtrTo[int32](7),
-					MemoryMB:  tools.PtrTo[int64](900),
-					DiskMB:    tools.PtrTo[int64](80),
+					Instances:     tools.PtrTo[int32](7),
+					MemoryMB:      tools.PtrTo[int64](900),
+					DiskMB:        tools.PtrTo[int64](80),
+					CPUMillicores: tools.PtrTo[int64](2000),
 				},
 			}
 		})
@@ -346,6 +375,9 @@ var _ = Describe("ProcessRepo", func() {
 
 				Expect(scaledRecord.MemoryMB).To(BeEquivalentTo(900))
 				Expect(cfProcess.Spec.MemoryMB).To(BeEquivalentTo(900))
+
+				Expect(scaledRecord.CPUMillicores).To(BeEquivalentTo(2000))
+				Expect(cfProcess.Spec.CPUMillicores).To(BeEquivalentTo(2000))
 			})
 
 			When("process scale values are not specified", func() {
@@ -365,6 +397,9 @@ var _ = Describe("ProcessRepo", func() {
 
 					Expect(scaledRecord.MemoryMB).To(BeEquivalentTo(500))
 					Expect(cfProcess.Spec.MemoryMB).To(BeEquivalentTo(500))
+
+					Expect(scaledRecord.CPUMillicores).To(BeEquivalentTo(250))
+					Expect(cfProcess.Spec.CPUMillicores).To(BeEquivalentTo(250))
 				})
 			})
 
@@ -380,6 +415,95 @@ var _ = Describe("ProcessRepo", func() {
 		})
 	})
 
+	Describe("SetProcessAutoscaling", func() {
+		var (
+			message       repositories.SetProcessAutoscalingMessage
+			setErr        error
+			updatedRecord repositories.ProcessRecord
+		)
+
+		BeforeEach(func() {
+			message = repositories.SetProcessAutoscalingMessage{
+				ProcessGUID: cfProcess.Name,
+				SpaceGUID:   space.Name,
+				Policy: &repositories.AutoscalingPolicy{
+					MinInstances:      1,
+					MaxInstances:      5,
+					MemoryUtilization: tools.PtrTo[int32](75),
+					Schedules: []repositories.AutoscalingSchedule{{
+						StartTime:    "08:00",
+						EndTime:      "18:00",
+						DaysOfWeek:   []int32{1, 2, 3, 4, 5},
+						TimeZone:     "Europe/London",
+						MinInstances: 3,
+						MaxInstances: 8,
+					}},
+				},
+			}
+		})
+
+		JustBeforeEach(func() {
+			updatedRecord, setErr = processRepo.SetProcessAutoscaling(ctx, authInfo, message)
+		})
+
+		It("returns a forbidden error to unauthorized users", func() {
+			Expect(setErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user has the SpaceDeveloper role", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
+			})
+
+			It("sets the autoscaling policy of the process", func() {
+				Expect(setErr).NotTo(HaveOccurred())
+				Expect(updatedRecord.Autoscaling).To(Equal(message.Policy))
+
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfProcess), cfProcess)).To(Succeed())
+				Expect(cfProcess.Spec.Autoscaling).To(PointTo(Equal(korifiv1alpha1.AutoscalingPolicy{
+					MinInstances:      1,
+					MaxInstances:      5,
+					MemoryUtilization: tools.PtrTo[int32](75),
+					Schedules: []korifiv1alpha1.AutoscalingSchedule{{
+						StartTime:    "08:00",
+						EndTime:      "18:00",
+						DaysOfWeek:   []int32{1, 2, 3, 4, 5},
+						TimeZone:     "Europe/London",
+						MinInstances: 3,
+						MaxInstances: 8,
+					}},
+				})))
+			})
+
+			When("the policy is nil", func() {
+				BeforeEach(func() {
+					Expect(k8s.Patch(ctx, k8sClient, cfProcess, func() {
+						cfProcess.Spec.Autoscaling = &korifiv1alpha1.AutoscalingPolicy{MinInstances: 1, MaxInstances: 2}
+					})).To(Succeed())
+					message.Policy = nil
+				})
+
+				It("removes the autoscaling policy of the process", func() {
+					Expect(setErr).NotTo(HaveOccurred())
+					Expect(updatedRecord.Autoscaling).To(BeNil())
+
+					Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfProcess), cfProcess)).To(Succeed())
+					Expect(cfProcess.Spec.Autoscaling).To(BeNil())
+				})
+			})
+
+			When("the process does not exist", func() {
+				BeforeEach(func() {
+					message.ProcessGUID = "i-dont-exist"
+				})
+
+				It("returns a not found error", func() {
+					Expect(setErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
+				})
+			})
+		})
+	})
+
 	Describe("CreateProcess", func() {
 		var (
 			createErr    error
@@ -407,6 +531,7 @@ var _ = Describe("ProcessRepo", func() {
 				},
 				DesiredInstances: tools.PtrTo[int32](42),
 				MemoryMB:         456,
+				CPUMillicores:    300,
 			})
 		})
 
@@ -441,6 +566,7 @@ var _ = Describe("ProcessRepo", func() {
 					DesiredInstances: tools.PtrTo[int32](42),
 					MemoryMB:         456,
 					DiskQuotaMB:      123,
+					CPUMillicores:    300,
 				}))
 			})
 
@@ -533,6 +659,7 @@ var _ = Describe("ProcessRepo", func() {
 				DesiredInstances:                    tools.PtrTo[int32](42),
 				MemoryMB:                            tools.PtrTo(int64(456)),
 				DiskQuotaMB:                         tools.PtrTo(int64(123)),
+				CPUMillicores:                       tools.PtrTo(int64(750)),
 				MetadataPatch: &repositories.MetadataPatch{
 					Labels:      map[string]*string{"fool": tools.PtrTo("fool")},
 					Annotations: map[string]*string{"fooa": tools.PtrTo("fooa")},
@@ -571,6 +698,7 @@ var _ = Describe("ProcessRepo", func() {
 					"DesiredInstances": PointTo(BeEquivalentTo(42)),
 					"MemoryMB":         BeEquivalentTo(456),
 					"DiskQuotaMB":      BeEquivalentTo(123),
+					"CPUMillicores":    BeEquivalentTo(750),
 				}))
 			})
 		})
@@ -45,6 +45,7 @@ type CreateRoleMessage struct {
 	Space                   string
 	Org                     string
 	User                    string
+	Origin                  string
 	Kind                    string
 	ServiceAccountNamespace string
 }
@@ -68,9 +69,13 @@ type RoleRecord struct {
 }
 
 func (r RoleRecord) Relationships() map[string]string {
-	relationships := map[string]string{
-		"user": r.User,
+	relationships := map[string]string{}
+	if r.Kind == rbacv1.GroupKind {
+		relationships["group"] = r.User
+	} else {
+		relationships["user"] = r.User
 	}
+
 	if r.Org != "" {
 		relationships["organization"] = r.Org
 	}
@@ -95,6 +100,7 @@ type RoleRepo struct {
 	spaceRepo            *SpaceRepo
 	namespaceRetriever   NamespaceRetriever
 	sorter               RoleSorter
+	userIdentities       UserIdentities
 }
 
 //counterfeiter:generate -o fake -fake-name RoleSorter . RoleSorter
@@ -145,6 +151,7 @@ func NewRoleRepo(
 	roleMappings map[string]config.Role,
 	namespaceRetriever NamespaceRetriever,
 	sorter RoleSorter,
+	userIdentities UserIdentities,
 ) *RoleRepo {
 	return &RoleRepo{
 		rootNamespace:        rootNamespace,
@@ -155,6 +162,7 @@ func NewRoleRepo(
 		spaceRepo:            spaceRepo,
 		namespaceRetriever:   namespaceRetriever,
 		sorter:               sorter,
+		userIdentities:       userIdentities,
 	}
 }
 
@@ -169,6 +177,10 @@ func (r *RoleRepo) CreateRole(ctx context.Context, authInfo authorization.Info,
 		return RoleRecord{}, fmt.Errorf("invalid role type: %q", role.Type)
 	}
 
+	if role.Kind == rbacv1.UserKind {
+		role.User = r.userIdentities.Name(role.User, role.Origin)
+	}
+
 	userIdentity := authorization.Identity{
 		Name: role.User,
 		Kind: role.Kind,
@@ -194,7 +206,11 @@ func (r *RoleRepo) CreateRole(ctx context.Context, authInfo authorization.Info,
 	err = userClient.Create(ctx, &roleBinding)
 	if err != nil {
 		if k8serrors.IsAlreadyExists(err) {
-			errorDetail := fmt.Sprintf("User '%s' already has '%s' role", role.User, role.Type)
+			subjectKind := "User"
+			if role.Kind == rbacv1.GroupKind {
+				subjectKind = "Group"
+			}
+			errorDetail := fmt.Sprintf("%s '%s' already has '%s' role", subjectKind, role.User, role.Type)
 			return RoleRecord{}, apierrors.NewUnprocessableEntityError(
 				fmt.Errorf("rolebinding %s:%s already exists", roleBinding.Namespace, roleBinding.Name),
 				errorDetail,
@@ -250,8 +266,12 @@ func (r *RoleRepo) validateOrgRequirements(ctx context.Context, role CreateRoleM
 	return nil
 }
 
-func calculateRoleBindingName(roleType, roleServiceAccountNamespace, roleUser string) string {
+func calculateRoleBindingName(roleType, roleKind, roleServiceAccountNamespace, roleUser string) string {
 	roleBindingName := roleType + "::"
+	if roleKind == rbacv1.GroupKind {
+		// keep group bindings apart from the bindings of users with the same name
+		roleBindingName = roleBindingName + rbacv1.GroupKind + "/"
+	}
 	if roleServiceAccountNamespace != "" {
 		roleBindingName = roleBindingName + roleServiceAccountNamespace + "/"
 	}
@@ -266,7 +286,7 @@ func createRoleBinding(namespace, roleType, roleKind, roleUser, roleServiceAccou
 	return rbacv1.RoleBinding{
 		ObjectMeta: metav1.ObjectMeta{
 			Namespace: namespace,
-			Name:      calculateRoleBindingName(roleType, roleServiceAccountNamespace, roleUser),
+			Name:      calculateRoleBindingName(roleType, roleKind, roleServiceAccountNamespace, roleUser),
 			Labels: map[string]string{
 				RoleGuidLabel: roleGUID,
 			},
@@ -413,6 +433,48 @@ func (r *RoleRepo) DeleteRole(ctx context.Context, authInfo authorization.Info,
 	return nil
 }
 
+// DeleteUserRoles deletes all the roles of the given user the requester can
+// see, as well as the binding granting the user access to the root namespace
+func (r *RoleRepo) DeleteUserRoles(ctx context.Context, authInfo authorization.Info, username string) error {
+	roles, err := r.ListRoles(ctx, authInfo, ListRolesMessage{})
+	if err != nil {
+		return err
+	}
+
+	for _, role := range roles {
+		if role.User != username || role.Kind == rbacv1.GroupKind {
+			continue
+		}
+
+		err = r.DeleteRole(ctx, authInfo, DeleteRoleMessage{GUID: role.GUID, Space: role.Space, Org: role.Org})
+		if err != nil {
+			return err
+		}
+	}
+
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	var serviceAccountNamespace string
+	if authorization.HasServiceAccountPrefix(username) {
+		serviceAccountNamespace, username = authorization.ServiceAccountNSAndName(username)
+	}
+
+	err = userClient.Delete(ctx, &rbacv1.RoleBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: r.rootNamespace,
+			Name:      calculateRoleBindingName(cfUserRoleType, rbacv1.UserKind, serviceAccountNamespace, username),
+		},
+	})
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete root namespace role binding for user %q: %w", username, apierrors.FromK8sError(err, RoleResourceType))
+	}
+
+	return nil
+}
+
 func (r *RoleRepo) GetDeletedAt(ctx context.Context, authInfo authorization.Info, roleGUID string) (*time.Time, error) {
 	role, err := r.GetRole(ctx, authInfo, roleGUID)
 	return role.DeletedAt, err
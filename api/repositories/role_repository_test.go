@@ -55,8 +55,8 @@ var _ = Describe("RoleRepository", func() {
 			korifiv1alpha1.CFOrg,
 			korifiv1alpha1.CFOrgList,
 			*korifiv1alpha1.CFOrgList,
-		]{})
-		spaceRepo := repositories.NewSpaceRepo(namespaceRetriever, orgRepo, userClientFactory, nsPerms, &fakeawaiter.FakeAwaiter[
+		]{}, roleMappings)
+		spaceRepo := repositories.NewSpaceRepo(namespaceRetriever, orgRepo, k8sClient, userClientFactory, nsPerms, &fakeawaiter.FakeAwaiter[
 			*korifiv1alpha1.CFSpace,
 			korifiv1alpha1.CFSpace,
 			korifiv1alpha1.CFSpaceList,
@@ -71,6 +71,7 @@ var _ = Describe("RoleRepository", func() {
 			roleMappings,
 			namespaceRetriever,
 			sorter,
+			repositories.NewUserIdentities("oidc:"),
 		)
 
 		roleCreateMessage = repositories.CreateRoleMessage{}
@@ -200,6 +201,44 @@ var _ = Describe("RoleRepository", func() {
 				})
 			})
 
+			When("assigning the role to a group", func() {
+				BeforeEach(func() {
+					roleCreateMessage.Kind = rbacv1.GroupKind
+					roleCreateMessage.User = "my-group"
+					// Sha256 sum of "organization_manager::Group/my-group"
+					expectedName = "cf-a5255e7bff60504caaa86ebda7aeb010a42622055b1cbb2ac2dd7e1f377fe54c"
+					// Sha256 sum of "cf_user::Group/my-group"
+					cfUserExpectedName = "cf-b0b84b640906b237e966055e1b1a3e70596bf3f42eed5d0792a8cb902ba8db3b"
+				})
+
+				It("succeeds and uses a group subject kind", func() {
+					Expect(createErr).NotTo(HaveOccurred())
+					Expect(createdRole.Kind).To(Equal(rbacv1.GroupKind))
+
+					roleBinding := getTheRoleBinding(expectedName, cfOrg.Name)
+					Expect(roleBinding.Subjects).To(HaveLen(1))
+					Expect(roleBinding.Subjects[0].Name).To(Equal("my-group"))
+					Expect(roleBinding.Subjects[0].Kind).To(Equal(rbacv1.GroupKind))
+				})
+
+				It("grants the group access to the root namespace", func() {
+					roleBinding := getTheRoleBinding(cfUserExpectedName, rootNamespace)
+					Expect(roleBinding.Subjects).To(HaveLen(1))
+					Expect(roleBinding.Subjects[0].Name).To(Equal("my-group"))
+					Expect(roleBinding.Subjects[0].Kind).To(Equal(rbacv1.GroupKind))
+				})
+
+				When("the group is already bound to that role", func() {
+					It("returns an unprocessable entity error", func() {
+						roleCreateMessage.GUID = uuid.NewString()
+						_, createErr = roleRepo.CreateRole(ctx, authInfo, roleCreateMessage)
+						var apiErr apierrors.UnprocessableEntityError
+						Expect(errors.As(createErr, &apiErr)).To(BeTrue())
+						Expect(apiErr.Detail()).To(Equal("Group 'my-group' already has 'organization_manager' role"))
+					})
+				})
+			})
+
 			When("the org does not exist", func() {
 				BeforeEach(func() {
 					roleCreateMessage.Org = "i-do-not-exist"
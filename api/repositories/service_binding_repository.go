@@ -14,6 +14,7 @@ import (
 	"code.cloudfoundry.org/korifi/api/authorization"
 	apierrors "code.cloudfoundry.org/korifi/api/errors"
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/controllers/services/osbapi"
 	"code.cloudfoundry.org/korifi/controllers/webhooks/services/bindings"
 	"code.cloudfoundry.org/korifi/controllers/webhooks/validation"
 	"code.cloudfoundry.org/korifi/model"
@@ -39,17 +40,23 @@ type ServiceBindingRepo struct {
 	userClientFactory       authorization.UserClientFactory
 	namespaceRetriever      NamespaceRetriever
 	bindingConditionAwaiter Awaiter[*korifiv1alpha1.CFServiceBinding]
+	brokerAssets            *osbapi.Assets
+	brokerClientFactory     BrokerClientFactory
 }
 
 func NewServiceBindingRepo(
 	namespaceRetriever NamespaceRetriever,
 	userClientFactory authorization.UserClientFactory,
 	bindingConditionAwaiter Awaiter[*korifiv1alpha1.CFServiceBinding],
+	brokerAssets *osbapi.Assets,
+	brokerClientFactory BrokerClientFactory,
 ) *ServiceBindingRepo {
 	return &ServiceBindingRepo{
 		userClientFactory:       userClientFactory,
 		namespaceRetriever:      namespaceRetriever,
 		bindingConditionAwaiter: bindingConditionAwaiter,
+		brokerAssets:            brokerAssets,
+		brokerClientFactory:     brokerClientFactory,
 	}
 }
 
@@ -202,7 +209,7 @@ func (r *ServiceBindingRepo) CreateServiceBinding(ctx context.Context, authInfo
 		}
 	}
 
-	if cfServiceInstance.Spec.Type == korifiv1alpha1.UserProvidedType {
+	if cfServiceInstance.Spec.Type != korifiv1alpha1.ManagedType {
 		cfServiceBinding, err = r.bindingConditionAwaiter.AwaitCondition(ctx, userClient, cfServiceBinding, korifiv1alpha1.StatusConditionReady)
 		if err != nil {
 			return ServiceBindingRecord{}, err
@@ -298,6 +305,82 @@ func (r *ServiceBindingRepo) GetServiceBindingDetails(ctx context.Context, authI
 	return ServiceBindingDetailsRecord{Credentials: credentials}, nil
 }
 
+// RotateServiceBindingCredentials requests a credential rotation. The
+// controller binds to the service instance again and swaps the binding
+// credentials.
+func (r *ServiceBindingRepo) RotateServiceBindingCredentials(ctx context.Context, authInfo authorization.Info, guid string) (ServiceBindingRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return ServiceBindingRecord{}, fmt.Errorf("failed to create user client: %w", err)
+	}
+
+	serviceBinding, err := r.getServiceBinding(ctx, authInfo, guid)
+	if err != nil {
+		return ServiceBindingRecord{}, err
+	}
+
+	err = k8s.PatchResource(ctx, userClient, &serviceBinding, func() {
+		serviceBinding.Spec.CredentialRotation++
+	})
+	if err != nil {
+		return ServiceBindingRecord{}, fmt.Errorf("failed to request credential rotation: %w", apierrors.FromK8sError(err, ServiceBindingResourceType))
+	}
+
+	return serviceBindingToRecord(serviceBinding), nil
+}
+
+// GetServiceBindingParameters returns the parameters of a binding to a
+// managed service instance as reported by the broker. The parameters of
+// bindings to user-provided instances are read from the parameters secret
+// instead.
+func (r *ServiceBindingRepo) GetServiceBindingParameters(ctx context.Context, authInfo authorization.Info, guid string) (map[string]any, error) {
+	binding, err := r.getServiceBinding(ctx, authInfo, guid)
+	if err != nil {
+		return nil, fmt.Errorf("get-service-binding-parameters failed: %w", err)
+	}
+
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return nil, fmt.Errorf("get-service-binding-parameters failed to create user client: %w", err)
+	}
+
+	serviceInstance := &korifiv1alpha1.CFServiceInstance{}
+	err = userClient.Get(ctx, client.ObjectKey{Namespace: binding.Namespace, Name: binding.Spec.Service.Name}, serviceInstance)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get service instance: %w", apierrors.FromK8sError(err, ServiceInstanceResourceType))
+	}
+
+	if serviceInstance.Spec.Type != korifiv1alpha1.ManagedType {
+		return getStoredParameters(ctx, userClient, binding.Namespace, binding.Spec.Parameters.Name, ServiceBindingResourceType)
+	}
+
+	assets, err := r.brokerAssets.GetServiceInstanceAssets(ctx, serviceInstance)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get service instance assets: %w", err)
+	}
+
+	if !assets.ServiceOffering.Spec.BrokerCatalog.Features.BindingsRetrievable {
+		return nil, apierrors.NewInvalidRequestError(nil, "This service does not support fetching service binding parameters.")
+	}
+
+	brokerClient, err := r.brokerClientFactory.CreateClient(ctx, authInfo, assets.ServiceBroker)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create client for broker %q: %w", assets.ServiceBroker.Name, err)
+	}
+
+	brokerBinding, err := brokerClient.FetchBinding(ctx, osbapi.GetBindingRequest{
+		InstanceID: serviceInstance.Name,
+		BindingID:  binding.Name,
+		ServiceId:  assets.ServiceOffering.Spec.BrokerCatalog.ID,
+		PlanID:     assets.ServicePlan.Spec.BrokerCatalog.ID,
+	})
+	if err != nil {
+		return nil, apierrors.NewUnprocessableEntityError(err, "The service broker failed to return the service binding parameters.")
+	}
+
+	return ensureParameters(brokerBinding.Parameters), nil
+}
+
 func (r *ServiceBindingRepo) getServiceBinding(ctx context.Context, authInfo authorization.Info, bindingGUID string) (korifiv1alpha1.CFServiceBinding, error) {
 	namespace, err := r.namespaceRetriever.NamespaceFor(ctx, bindingGUID, ServiceBindingResourceType)
 	if err != nil {
@@ -423,17 +506,32 @@ func (r *ServiceBindingRepo) UpdateServiceBinding(ctx context.Context, authInfo
 	return serviceBindingToRecord(*serviceBinding), nil
 }
 
-func (r *ServiceBindingRepo) GetState(ctx context.Context, authInfo authorization.Info, guid string) (model.CFResourceState, error) {
-	bindingRecord, err := r.GetServiceBinding(ctx, authInfo, guid)
+func (r *ServiceBindingRepo) GetOperation(ctx context.Context, authInfo authorization.Info, guid string) (model.CFResourceOperation, error) {
+	binding, err := r.getServiceBinding(ctx, authInfo, guid)
 	if err != nil {
-		return model.CFResourceStateUnknown, err
+		return model.CFResourceOperation{State: model.CFResourceStateUnknown}, fmt.Errorf("get-service-binding-operation failed: %w", err)
+	}
+
+	if errs := trueConditionMessages(
+		binding.Status.Conditions,
+		korifiv1alpha1.BindingFailedCondition,
+		korifiv1alpha1.UnbindingFailedCondition,
+	); len(errs) > 0 {
+		return model.CFResourceOperation{State: model.CFResourceStateFailed, Errors: errs}, nil
+	}
+
+	// A failed rotation leaves the binding ready with its previous
+	// credentials, so it only fails the rotation that is currently requested
+	rotationFailed := meta.FindStatusCondition(binding.Status.Conditions, korifiv1alpha1.CredentialRotationFailedCondition)
+	if rotationFailed != nil && rotationFailed.Status == metav1.ConditionTrue && rotationFailed.ObservedGeneration == binding.Generation {
+		return model.CFResourceOperation{State: model.CFResourceStateFailed, Errors: []string{rotationFailed.Message}}, nil
 	}
 
-	if bindingRecord.Ready {
-		return model.CFResourceStateReady, nil
+	if isBindingReady(binding) {
+		return model.CFResourceOperation{State: model.CFResourceStateReady}, nil
 	}
 
-	return model.CFResourceStateUnknown, nil
+	return model.CFResourceOperation{State: model.CFResourceStateUnknown}, nil
 }
 
 func (r *ServiceBindingRepo) GetDeletedAt(ctx context.Context, authInfo authorization.Info, bindingGUID string) (*time.Time, error) {
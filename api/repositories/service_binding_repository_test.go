@@ -8,9 +8,13 @@ import (
 	"code.cloudfoundry.org/korifi/api/authorization"
 	apierrors "code.cloudfoundry.org/korifi/api/errors"
 	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/api/repositories/fake"
 	"code.cloudfoundry.org/korifi/api/repositories/fakeawaiter"
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/controllers/services/osbapi"
+	osbapifake "code.cloudfoundry.org/korifi/controllers/controllers/services/osbapi/fake"
 	"code.cloudfoundry.org/korifi/model"
+	"code.cloudfoundry.org/korifi/model/services"
 	"code.cloudfoundry.org/korifi/tests/matchers"
 	"code.cloudfoundry.org/korifi/tools"
 	"code.cloudfoundry.org/korifi/tools/k8s"
@@ -40,6 +44,8 @@ var _ = Describe("ServiceBindingRepo", func() {
 			korifiv1alpha1.CFServiceBindingList,
 			*korifiv1alpha1.CFServiceBindingList,
 		]
+		brokerClient        *osbapifake.BrokerClient
+		brokerClientFactory *fake.BrokerClientFactory
 	)
 
 	BeforeEach(func() {
@@ -49,12 +55,19 @@ var _ = Describe("ServiceBindingRepo", func() {
 			korifiv1alpha1.CFServiceBindingList,
 			*korifiv1alpha1.CFServiceBindingList,
 		]{}
+		brokerClient = new(osbapifake.BrokerClient)
+		brokerClientFactory = new(fake.BrokerClientFactory)
+		brokerClientFactory.CreateClientReturns(brokerClient, nil)
+
 		repo = repositories.NewServiceBindingRepo(
 			namespaceRetriever,
 			userClientFactory.WithWrappingFunc(func(client client.WithWatch) client.WithWatch {
 				return authorization.NewSpaceFilteringClient(client, k8sClient, nsPerms)
 			}),
-			conditionAwaiter)
+			conditionAwaiter,
+			osbapi.NewAssets(k8sClient, rootNamespace),
+			brokerClientFactory,
+		)
 
 		org = createOrgWithCleanup(ctx, prefixedGUID("org"))
 		space = createSpaceWithCleanup(ctx, org.Name, prefixedGUID("space1"))
@@ -83,11 +96,11 @@ var _ = Describe("ServiceBindingRepo", func() {
 		).To(Succeed())
 	})
 
-	Describe("GetState", func() {
+	Describe("GetOperation", func() {
 		var (
 			cfServiceBinding *korifiv1alpha1.CFServiceBinding
-			state            model.CFResourceState
-			stateErr         error
+			operation        model.CFResourceOperation
+			operationErr     error
 		)
 		BeforeEach(func() {
 			cfServiceBinding = &korifiv1alpha1.CFServiceBinding{
@@ -116,11 +129,11 @@ var _ = Describe("ServiceBindingRepo", func() {
 		})
 
 		JustBeforeEach(func() {
-			state, stateErr = repo.GetState(ctx, authInfo, cfServiceBinding.Name)
+			operation, operationErr = repo.GetOperation(ctx, authInfo, cfServiceBinding.Name)
 		})
 
 		It("returns a forbidden error", func() {
-			Expect(stateErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+			Expect(operationErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
 		})
 
 		When("the user can get CFServiceBinding", func() {
@@ -129,8 +142,8 @@ var _ = Describe("ServiceBindingRepo", func() {
 			})
 
 			It("returns unknown state", func() {
-				Expect(stateErr).NotTo(HaveOccurred())
-				Expect(state).To(Equal(model.CFResourceStateUnknown))
+				Expect(operationErr).NotTo(HaveOccurred())
+				Expect(operation.State).To(Equal(model.CFResourceStateUnknown))
 			})
 
 			When("the service binding is ready", func() {
@@ -147,8 +160,8 @@ var _ = Describe("ServiceBindingRepo", func() {
 				})
 
 				It("returns ready state", func() {
-					Expect(stateErr).NotTo(HaveOccurred())
-					Expect(state).To(Equal(model.CFResourceStateReady))
+					Expect(operationErr).NotTo(HaveOccurred())
+					Expect(operation.State).To(Equal(model.CFResourceStateReady))
 				})
 
 				When("the ready status is stale ", func() {
@@ -159,11 +172,63 @@ var _ = Describe("ServiceBindingRepo", func() {
 					})
 
 					It("returns unknown state", func() {
-						Expect(stateErr).NotTo(HaveOccurred())
-						Expect(state).To(Equal(model.CFResourceStateUnknown))
+						Expect(operationErr).NotTo(HaveOccurred())
+						Expect(operation.State).To(Equal(model.CFResourceStateUnknown))
+					})
+				})
+
+				When("credential rotation has failed", func() {
+					BeforeEach(func() {
+						Expect(k8s.Patch(ctx, k8sClient, cfServiceBinding, func() {
+							meta.SetStatusCondition(&cfServiceBinding.Status.Conditions, metav1.Condition{
+								Type:               korifiv1alpha1.CredentialRotationFailedCondition,
+								Status:             metav1.ConditionTrue,
+								ObservedGeneration: cfServiceBinding.Generation,
+								Message:            "rotation-failed",
+								Reason:             "Failed",
+							})
+						})).To(Succeed())
+					})
+
+					It("returns failed state with the failure message", func() {
+						Expect(operationErr).NotTo(HaveOccurred())
+						Expect(operation.State).To(Equal(model.CFResourceStateFailed))
+						Expect(operation.Errors).To(ConsistOf("rotation-failed"))
+					})
+
+					When("the failure is from a previous rotation", func() {
+						BeforeEach(func() {
+							Expect(k8s.Patch(ctx, k8sClient, cfServiceBinding, func() {
+								meta.FindStatusCondition(cfServiceBinding.Status.Conditions, korifiv1alpha1.CredentialRotationFailedCondition).ObservedGeneration = cfServiceBinding.Generation - 1
+							})).To(Succeed())
+						})
+
+						It("returns ready state", func() {
+							Expect(operationErr).NotTo(HaveOccurred())
+							Expect(operation.State).To(Equal(model.CFResourceStateReady))
+						})
 					})
 				})
 			})
+
+			When("binding has failed", func() {
+				BeforeEach(func() {
+					Expect(k8s.Patch(ctx, k8sClient, cfServiceBinding, func() {
+						meta.SetStatusCondition(&cfServiceBinding.Status.Conditions, metav1.Condition{
+							Type:    korifiv1alpha1.BindingFailedCondition,
+							Status:  metav1.ConditionTrue,
+							Message: "bind-failed",
+							Reason:  "Failed",
+						})
+					})).To(Succeed())
+				})
+
+				It("returns failed state with the failure message", func() {
+					Expect(operationErr).NotTo(HaveOccurred())
+					Expect(operation.State).To(Equal(model.CFResourceStateFailed))
+					Expect(operation.Errors).To(ConsistOf("bind-failed"))
+				})
+			})
 		})
 	})
 
@@ -1149,6 +1214,65 @@ var _ = Describe("ServiceBindingRepo", func() {
 		})
 	})
 
+	Describe("RotateServiceBindingCredentials", func() {
+		var (
+			serviceBinding *korifiv1alpha1.CFServiceBinding
+			rotateErr      error
+		)
+
+		BeforeEach(func() {
+			serviceBinding = &korifiv1alpha1.CFServiceBinding{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      prefixedGUID("binding"),
+					Namespace: space.Name,
+				},
+				Spec: korifiv1alpha1.CFServiceBindingSpec{
+					Type: korifiv1alpha1.CFServiceBindingTypeApp,
+					Service: corev1.ObjectReference{
+						Kind:       "CFServiceInstance",
+						APIVersion: korifiv1alpha1.SchemeGroupVersion.Identifier(),
+						Name:       uuid.NewString(),
+					},
+					AppRef: corev1.LocalObjectReference{
+						Name: appGUID,
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, serviceBinding)).To(Succeed())
+		})
+
+		JustBeforeEach(func() {
+			_, rotateErr = repo.RotateServiceBindingCredentials(ctx, authInfo, serviceBinding.Name)
+		})
+
+		It("fails because the user has no bindings", func() {
+			Expect(rotateErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is a space developer", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
+			})
+
+			It("requests a credential rotation", func() {
+				Expect(rotateErr).NotTo(HaveOccurred())
+
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(serviceBinding), serviceBinding)).To(Succeed())
+				Expect(serviceBinding.Spec.CredentialRotation).To(BeEquivalentTo(1))
+			})
+
+			When("the binding does not exist", func() {
+				BeforeEach(func() {
+					serviceBinding.Name = "does-not-exist"
+				})
+
+				It("returns a not found error", func() {
+					Expect(rotateErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
+				})
+			})
+		})
+	})
+
 	Describe("UpdateServiceBinding", func() {
 		var (
 			serviceBinding        *korifiv1alpha1.CFServiceBinding
@@ -1239,6 +1363,136 @@ var _ = Describe("ServiceBindingRepo", func() {
 		})
 	})
 
+	Describe("GetServiceBindingParameters", func() {
+		var (
+			serviceInstance *korifiv1alpha1.CFServiceInstance
+			serviceBinding  *korifiv1alpha1.CFServiceBinding
+			parameters      map[string]any
+			getErr          error
+		)
+
+		BeforeEach(func() {
+			serviceInstance = &korifiv1alpha1.CFServiceInstance{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      uuid.NewString(),
+					Namespace: space.Name,
+				},
+				Spec: korifiv1alpha1.CFServiceInstanceSpec{
+					Type: korifiv1alpha1.UserProvidedType,
+				},
+			}
+			Expect(k8sClient.Create(ctx, serviceInstance)).To(Succeed())
+
+			serviceBinding = &korifiv1alpha1.CFServiceBinding{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      uuid.NewString(),
+					Namespace: space.Name,
+				},
+				Spec: korifiv1alpha1.CFServiceBindingSpec{
+					Service: corev1.ObjectReference{
+						Kind:       "CFServiceInstance",
+						APIVersion: korifiv1alpha1.SchemeGroupVersion.Identifier(),
+						Name:       serviceInstance.Name,
+					},
+					AppRef: corev1.LocalObjectReference{
+						Name: appGUID,
+					},
+					Parameters: corev1.LocalObjectReference{
+						Name: uuid.NewString(),
+					},
+					Type: "app",
+				},
+			}
+			Expect(k8sClient.Create(ctx, serviceBinding)).To(Succeed())
+
+			paramsData, err := tools.ToParametersSecretData(map[string]any{"foo": "bar"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(k8sClient.Create(ctx, &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      serviceBinding.Spec.Parameters.Name,
+					Namespace: space.Name,
+				},
+				Data: paramsData,
+			})).To(Succeed())
+		})
+
+		JustBeforeEach(func() {
+			parameters, getErr = repo.GetServiceBindingParameters(ctx, authInfo, serviceBinding.Name)
+		})
+
+		It("returns a forbidden error", func() {
+			Expect(getErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is a space developer", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
+			})
+
+			It("returns the stored parameters of the binding", func() {
+				Expect(getErr).NotTo(HaveOccurred())
+				Expect(parameters).To(Equal(map[string]any{"foo": "bar"}))
+				Expect(brokerClientFactory.CreateClientCallCount()).To(BeZero())
+			})
+
+			When("the instance is managed", func() {
+				BeforeEach(func() {
+					plan := createManagedServiceAssets(services.BrokerCatalogFeatures{BindingsRetrievable: true})
+					Expect(k8s.PatchResource(ctx, k8sClient, serviceInstance, func() {
+						serviceInstance.Spec.Type = korifiv1alpha1.ManagedType
+						serviceInstance.Spec.PlanGUID = plan.Name
+					})).To(Succeed())
+
+					brokerClient.FetchBindingReturns(osbapi.GetBindingResponse{
+						Parameters: map[string]any{"broker": "param"},
+					}, nil)
+				})
+
+				It("fetches the parameters from the broker", func() {
+					Expect(getErr).NotTo(HaveOccurred())
+					Expect(parameters).To(Equal(map[string]any{"broker": "param"}))
+
+					Expect(brokerClientFactory.CreateClientCallCount()).To(Equal(1))
+					_, actualAuthInfo, _ := brokerClientFactory.CreateClientArgsForCall(0)
+					Expect(actualAuthInfo).To(Equal(authInfo))
+
+					Expect(brokerClient.FetchBindingCallCount()).To(Equal(1))
+					_, request := brokerClient.FetchBindingArgsForCall(0)
+					Expect(request).To(Equal(osbapi.GetBindingRequest{
+						InstanceID: serviceInstance.Name,
+						BindingID:  serviceBinding.Name,
+						ServiceId:  "offering-catalog-id",
+						PlanID:     "plan-catalog-id",
+					}))
+				})
+
+				When("the broker fails to return the binding", func() {
+					BeforeEach(func() {
+						brokerClient.FetchBindingReturns(osbapi.GetBindingResponse{}, errors.New("fetch-err"))
+					})
+
+					It("returns an unprocessable entity error", func() {
+						Expect(getErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+					})
+				})
+
+				When("the offering does not support retrieving bindings", func() {
+					BeforeEach(func() {
+						plan := createManagedServiceAssets(services.BrokerCatalogFeatures{InstancesRetrievable: true})
+						Expect(k8s.PatchResource(ctx, k8sClient, serviceInstance, func() {
+							serviceInstance.Spec.PlanGUID = plan.Name
+						})).To(Succeed())
+					})
+
+					It("returns an invalid request error", func() {
+						Expect(getErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.InvalidRequestError{}))
+						Expect(brokerClient.FetchBindingCallCount()).To(BeZero())
+					})
+				})
+			})
+		})
+	})
+
 	Describe("GetServiceBindingDetails", func() {
 		var (
 			serviceBindingGUID   string
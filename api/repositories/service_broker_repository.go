@@ -29,24 +29,28 @@ type CreateServiceBrokerMessage struct {
 	Metadata    model.Metadata
 	Broker      services.ServiceBroker
 	Credentials services.BrokerCredentials
+	SpaceGUID   string
 }
 
 type ListServiceBrokerMessage struct {
-	Names []string
-	GUIDs []string
+	Names      []string
+	GUIDs      []string
+	SpaceGUIDs []string
 }
 
 func (l ListServiceBrokerMessage) matches(b korifiv1alpha1.CFServiceBroker) bool {
 	return tools.EmptyOrContains(l.Names, b.Spec.Name) &&
-		tools.EmptyOrContains(l.GUIDs, b.Name)
+		tools.EmptyOrContains(l.GUIDs, b.Name) &&
+		tools.EmptyOrContains(l.SpaceGUIDs, b.Labels[korifiv1alpha1.SpaceGUIDKey])
 }
 
 type UpdateServiceBrokerMessage struct {
-	GUID          string
-	Name          *string
-	URL           *string
-	Credentials   *services.BrokerCredentials
-	MetadataPatch MetadataPatch
+	GUID           string
+	Name           *string
+	URL            *string
+	Credentials    *services.BrokerCredentials
+	MetadataPatch  MetadataPatch
+	RefreshCatalog bool
 }
 
 func (m UpdateServiceBrokerMessage) apply(broker *korifiv1alpha1.CFServiceBroker) {
@@ -58,30 +62,44 @@ func (m UpdateServiceBrokerMessage) apply(broker *korifiv1alpha1.CFServiceBroker
 		broker.Spec.URL = *m.URL
 	}
 
+	if m.RefreshCatalog {
+		broker.Spec.CatalogRefreshRequestedAt = tools.PtrTo(metav1.Now())
+	}
+
 	m.MetadataPatch.Apply(broker)
 }
 
 type ServiceBrokerRepo struct {
-	userClientFactory authorization.UserClientFactory
-	rootNamespace     string
+	userClientFactory  authorization.UserClientFactory
+	namespaceRetriever NamespaceRetriever
+	rootNamespace      string
 }
 
 type ServiceBrokerRecord struct {
 	services.ServiceBroker
 	model.CFResource
+	SpaceGUID string `json:"-"`
 }
 
 func (r ServiceBrokerRecord) Relationships() map[string]string {
-	return nil
+	if r.SpaceGUID == "" {
+		return nil
+	}
+
+	return map[string]string{
+		"space": r.SpaceGUID,
+	}
 }
 
 func NewServiceBrokerRepo(
 	userClientFactory authorization.UserClientFactory,
+	namespaceRetriever NamespaceRetriever,
 	rootNamespace string,
 ) *ServiceBrokerRepo {
 	return &ServiceBrokerRepo{
-		userClientFactory: userClientFactory,
-		rootNamespace:     rootNamespace,
+		userClientFactory:  userClientFactory,
+		namespaceRetriever: namespaceRetriever,
+		rootNamespace:      rootNamespace,
 	}
 }
 
@@ -96,12 +114,19 @@ func (r *ServiceBrokerRepo) CreateServiceBroker(ctx context.Context, authInfo au
 		return ServiceBrokerRecord{}, fmt.Errorf("failed to create credentials secret data: %w", err)
 	}
 
+	namespace := r.rootNamespace
+	labels := message.Metadata.Labels
+	if message.SpaceGUID != "" {
+		namespace = message.SpaceGUID
+		labels = tools.SetMapValue(labels, korifiv1alpha1.SpaceGUIDKey, message.SpaceGUID)
+	}
+
 	credentialsSecretName := uuid.NewString()
 	cfServiceBroker := &korifiv1alpha1.CFServiceBroker{
 		ObjectMeta: metav1.ObjectMeta{
-			Namespace:   r.rootNamespace,
+			Namespace:   namespace,
 			Name:        uuid.NewString(),
-			Labels:      message.Metadata.Labels,
+			Labels:      labels,
 			Annotations: message.Metadata.Annotations,
 		},
 		Spec: korifiv1alpha1.CFServiceBrokerSpec{
@@ -117,7 +142,7 @@ func (r *ServiceBrokerRepo) CreateServiceBroker(ctx context.Context, authInfo au
 
 	credentialsSecret := &corev1.Secret{
 		ObjectMeta: metav1.ObjectMeta{
-			Namespace: r.rootNamespace,
+			Namespace: namespace,
 			Name:      credentialsSecretName,
 		},
 		Data: credsSecretData,
@@ -145,35 +170,44 @@ func toServiceBrokerRecord(cfServiceBroker korifiv1alpha1.CFServiceBroker) Servi
 				Annotations: cfServiceBroker.Annotations,
 			},
 		},
+		SpaceGUID: cfServiceBroker.Labels[korifiv1alpha1.SpaceGUIDKey],
 	}
 }
 
-func (r *ServiceBrokerRepo) GetState(ctx context.Context, authInfo authorization.Info, brokerGUID string) (model.CFResourceState, error) {
-	userClient, err := r.userClientFactory.BuildClient(authInfo)
+func (r *ServiceBrokerRepo) GetOperation(ctx context.Context, authInfo authorization.Info, brokerGUID string) (model.CFResourceOperation, error) {
+	cfServiceBroker, err := r.getServiceBroker(ctx, authInfo, brokerGUID)
 	if err != nil {
-		return model.CFResourceStateUnknown, fmt.Errorf("failed to build user client: %w", err)
+		return model.CFResourceOperation{State: model.CFResourceStateUnknown}, err
 	}
 
-	cfServiceBroker := &korifiv1alpha1.CFServiceBroker{
-		ObjectMeta: metav1.ObjectMeta{
-			Namespace: r.rootNamespace,
-			Name:      brokerGUID,
-		},
+	if cfServiceBroker.Generation != cfServiceBroker.Status.ObservedGeneration {
+		return model.CFResourceOperation{State: model.CFResourceStateUnknown}, nil
 	}
 
-	if err = userClient.Get(ctx, client.ObjectKeyFromObject(cfServiceBroker), cfServiceBroker); err != nil {
-		return model.CFResourceStateUnknown, apierrors.FromK8sError(err, ServiceBrokerResourceType)
+	readyCondition := meta.FindStatusCondition(cfServiceBroker.Status.Conditions, korifiv1alpha1.StatusConditionReady)
+	if readyCondition == nil {
+		return model.CFResourceOperation{State: model.CFResourceStateUnknown}, nil
 	}
 
-	if cfServiceBroker.Generation != cfServiceBroker.Status.ObservedGeneration {
-		return model.CFResourceStateUnknown, nil
+	if readyCondition.Status == metav1.ConditionTrue {
+		return model.CFResourceOperation{State: model.CFResourceStateReady}, nil
+	}
+
+	// Failing to talk to the broker or to fetch its catalog fails the
+	// operation, like it does in CF, even though the broker controller keeps
+	// retrying
+	if readyCondition.Reason == korifiv1alpha1.OSBAPIClientCreationFailedReason || readyCondition.Reason == korifiv1alpha1.GetCatalogFailedReason {
+		return model.CFResourceOperation{State: model.CFResourceStateFailed, Errors: []string{readyCondition.Message}}, nil
 	}
 
-	if meta.IsStatusConditionTrue(cfServiceBroker.Status.Conditions, korifiv1alpha1.StatusConditionReady) {
-		return model.CFResourceStateReady, nil
+	// Other reasons for the broker not being ready yet, e.g. its credentials
+	// secret not being available yet, are reported as warnings
+	operation := model.CFResourceOperation{State: model.CFResourceStateUnknown}
+	if readyCondition.Message != "" {
+		operation.Warnings = []string{readyCondition.Message}
 	}
 
-	return model.CFResourceStateUnknown, nil
+	return operation, nil
 }
 
 func (r *ServiceBrokerRepo) ListServiceBrokers(ctx context.Context, authInfo authorization.Info, message ListServiceBrokerMessage) ([]ServiceBrokerRecord, error) {
@@ -191,7 +225,13 @@ func (r *ServiceBrokerRepo) ListServiceBrokers(ctx context.Context, authInfo aut
 		return nil, fmt.Errorf("failed to list brokers: %w", apierrors.FromK8sError(err, ServiceBrokerResourceType))
 	}
 
-	brokers := itx.FromSlice(brokersList.Items).Filter(message.matches)
+	spaceBrokersList := &korifiv1alpha1.CFServiceBrokerList{}
+	err = userClient.List(ctx, spaceBrokersList, client.HasLabels{korifiv1alpha1.SpaceGUIDKey})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list space scoped brokers: %w", apierrors.FromK8sError(err, ServiceBrokerResourceType))
+	}
+
+	brokers := itx.FromSlice(slices.Concat(brokersList.Items, spaceBrokersList.Items)).Filter(message.matches)
 
 	return slices.Collect(it.Map(brokers, toServiceBrokerRecord)), nil
 }
@@ -210,9 +250,14 @@ func (r *ServiceBrokerRepo) getServiceBroker(ctx context.Context, authInfo autho
 		return nil, fmt.Errorf("failed to build user client: %w", err)
 	}
 
+	ns, err := r.namespaceRetriever.NamespaceFor(ctx, guid, ServiceBrokerResourceType)
+	if err != nil {
+		return nil, err
+	}
+
 	serviceBroker := &korifiv1alpha1.CFServiceBroker{
 		ObjectMeta: metav1.ObjectMeta{
-			Namespace: r.rootNamespace,
+			Namespace: ns,
 			Name:      guid,
 		},
 	}
@@ -230,10 +275,15 @@ func (r *ServiceBrokerRepo) UpdateServiceBroker(ctx context.Context, authInfo au
 		return ServiceBrokerRecord{}, fmt.Errorf("failed to build user client: %w", err)
 	}
 
+	ns, err := r.namespaceRetriever.NamespaceFor(ctx, message.GUID, ServiceBrokerResourceType)
+	if err != nil {
+		return ServiceBrokerRecord{}, err
+	}
+
 	cfServiceBroker := &korifiv1alpha1.CFServiceBroker{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      message.GUID,
-			Namespace: r.rootNamespace,
+			Namespace: ns,
 		},
 	}
 
@@ -251,7 +301,7 @@ func (r *ServiceBrokerRepo) UpdateServiceBroker(ctx context.Context, authInfo au
 
 		credentialsSecret := &corev1.Secret{
 			ObjectMeta: metav1.ObjectMeta{
-				Namespace: r.rootNamespace,
+				Namespace: ns,
 				Name:      cfServiceBroker.Spec.Credentials.Name,
 			},
 		}
@@ -272,10 +322,15 @@ func (r *ServiceBrokerRepo) DeleteServiceBroker(ctx context.Context, authInfo au
 		return fmt.Errorf("failed to build user client: %w", err)
 	}
 
+	ns, err := r.namespaceRetriever.NamespaceFor(ctx, guid, ServiceBrokerResourceType)
+	if err != nil {
+		return err
+	}
+
 	serviceBroker := &korifiv1alpha1.CFServiceBroker{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      guid,
-			Namespace: r.rootNamespace,
+			Namespace: ns,
 		},
 	}
 
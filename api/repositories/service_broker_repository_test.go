@@ -1,6 +1,7 @@
 package repositories_test
 
 import (
+	"fmt"
 	"time"
 
 	apierrors "code.cloudfoundry.org/korifi/api/errors"
@@ -26,7 +27,7 @@ var _ = Describe("ServiceBrokerRepo", func() {
 	var repo *repositories.ServiceBrokerRepo
 
 	BeforeEach(func() {
-		repo = repositories.NewServiceBrokerRepo(userClientFactory, rootNamespace)
+		repo = repositories.NewServiceBrokerRepo(userClientFactory, namespaceRetriever, rootNamespace)
 	})
 
 	Describe("Create", func() {
@@ -143,13 +144,62 @@ var _ = Describe("ServiceBrokerRepo", func() {
 				})))
 			})
 		})
+
+		When("the broker is space scoped", func() {
+			var space *korifiv1alpha1.CFSpace
+
+			BeforeEach(func() {
+				org := createOrgWithCleanup(ctx, uuid.NewString())
+				space = createSpaceWithCleanup(ctx, org.Name, uuid.NewString())
+				createMsg.SpaceGUID = space.Name
+			})
+
+			It("returns a forbidden error", func() {
+				Expect(createErr).To(BeAssignableToTypeOf(apierrors.ForbiddenError{}))
+			})
+
+			When("the user is a space developer", func() {
+				BeforeEach(func() {
+					createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
+				})
+
+				It("returns a space scoped ServiceBrokerRecord", func() {
+					Expect(createErr).NotTo(HaveOccurred())
+					Expect(brokerRecord.SpaceGUID).To(Equal(space.Name))
+					Expect(brokerRecord.Relationships()).To(Equal(map[string]string{"space": space.Name}))
+				})
+
+				It("creates the CFServiceBroker and its credentials secret in the space namespace", func() {
+					Expect(createErr).NotTo(HaveOccurred())
+					cfServiceBroker := &korifiv1alpha1.CFServiceBroker{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: space.Name,
+							Name:      brokerRecord.GUID,
+						},
+					}
+					Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfServiceBroker), cfServiceBroker)).To(Succeed())
+					Expect(cfServiceBroker.Labels).To(SatisfyAll(
+						HaveKeyWithValue("label", "label-value"),
+						HaveKeyWithValue(korifiv1alpha1.SpaceGUIDKey, space.Name),
+					))
+
+					credentialsSecret := &corev1.Secret{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: space.Name,
+							Name:      cfServiceBroker.Spec.Credentials.Name,
+						},
+					}
+					Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(credentialsSecret), credentialsSecret)).To(Succeed())
+				})
+			})
+		})
 	})
 
-	Describe("GetState", func() {
+	Describe("GetOperation", func() {
 		var (
 			cfServiceBroker *korifiv1alpha1.CFServiceBroker
-			state           model.CFResourceState
-			getStateErr     error
+			operation       model.CFResourceOperation
+			getOperationErr error
 		)
 
 		BeforeEach(func() {
@@ -163,11 +213,11 @@ var _ = Describe("ServiceBrokerRepo", func() {
 		})
 
 		JustBeforeEach(func() {
-			state, getStateErr = repo.GetState(ctx, authInfo, cfServiceBroker.Name)
+			operation, getOperationErr = repo.GetOperation(ctx, authInfo, cfServiceBroker.Name)
 		})
 
 		It("returns a forbidden error", func() {
-			Expect(getStateErr).To(BeAssignableToTypeOf(apierrors.ForbiddenError{}))
+			Expect(getOperationErr).To(BeAssignableToTypeOf(apierrors.ForbiddenError{}))
 		})
 
 		When("the user can get CFServiceBrokers", func() {
@@ -176,12 +226,12 @@ var _ = Describe("ServiceBrokerRepo", func() {
 			})
 
 			JustBeforeEach(func() {
-				Expect(getStateErr).NotTo(HaveOccurred())
+				Expect(getOperationErr).NotTo(HaveOccurred())
 			})
 
 			It("returns unknown state", func() {
-				Expect(getStateErr).NotTo(HaveOccurred())
-				Expect(state).To(Equal(model.CFResourceStateUnknown))
+				Expect(getOperationErr).NotTo(HaveOccurred())
+				Expect(operation.State).To(Equal(model.CFResourceStateUnknown))
 			})
 
 			When("the broker is ready", func() {
@@ -198,8 +248,8 @@ var _ = Describe("ServiceBrokerRepo", func() {
 				})
 
 				It("returns ready state", func() {
-					Expect(getStateErr).NotTo(HaveOccurred())
-					Expect(state).To(Equal(model.CFResourceStateReady))
+					Expect(getOperationErr).NotTo(HaveOccurred())
+					Expect(operation.State).To(Equal(model.CFResourceStateReady))
 				})
 
 				When("the ready status is stale ", func() {
@@ -210,8 +260,8 @@ var _ = Describe("ServiceBrokerRepo", func() {
 					})
 
 					It("returns unknown state", func() {
-						Expect(getStateErr).NotTo(HaveOccurred())
-						Expect(state).To(Equal(model.CFResourceStateUnknown))
+						Expect(getOperationErr).NotTo(HaveOccurred())
+						Expect(operation.State).To(Equal(model.CFResourceStateUnknown))
 					})
 				})
 			})
@@ -229,8 +279,60 @@ var _ = Describe("ServiceBrokerRepo", func() {
 				})
 
 				It("returns unknown state", func() {
-					Expect(getStateErr).NotTo(HaveOccurred())
-					Expect(state).To(Equal(model.CFResourceStateUnknown))
+					Expect(getOperationErr).NotTo(HaveOccurred())
+					Expect(operation.State).To(Equal(model.CFResourceStateUnknown))
+				})
+
+				When("the not ready status is up to date", func() {
+					BeforeEach(func() {
+						Expect(k8s.Patch(ctx, k8sClient, cfServiceBroker, func() {
+							cfServiceBroker.Status.ObservedGeneration = cfServiceBroker.Generation
+						})).To(Succeed())
+					})
+
+					It("returns the not ready message as a warning", func() {
+						Expect(getOperationErr).NotTo(HaveOccurred())
+						Expect(operation.State).To(Equal(model.CFResourceStateUnknown))
+						Expect(operation.Warnings).To(ConsistOf("NotReady"))
+					})
+
+					When("fetching the catalog has failed", func() {
+						BeforeEach(func() {
+							Expect(k8s.Patch(ctx, k8sClient, cfServiceBroker, func() {
+								meta.SetStatusCondition(&cfServiceBroker.Status.Conditions, metav1.Condition{
+									Type:    korifiv1alpha1.StatusConditionReady,
+									Status:  metav1.ConditionFalse,
+									Message: "unauthorized",
+									Reason:  korifiv1alpha1.GetCatalogFailedReason,
+								})
+							})).To(Succeed())
+						})
+
+						It("returns failed state with the not ready message as an error", func() {
+							Expect(getOperationErr).NotTo(HaveOccurred())
+							Expect(operation.State).To(Equal(model.CFResourceStateFailed))
+							Expect(operation.Errors).To(ConsistOf("unauthorized"))
+						})
+					})
+
+					When("creating the broker client has failed", func() {
+						BeforeEach(func() {
+							Expect(k8s.Patch(ctx, k8sClient, cfServiceBroker, func() {
+								meta.SetStatusCondition(&cfServiceBroker.Status.Conditions, metav1.Condition{
+									Type:    korifiv1alpha1.StatusConditionReady,
+									Status:  metav1.ConditionFalse,
+									Message: "invalid url",
+									Reason:  korifiv1alpha1.OSBAPIClientCreationFailedReason,
+								})
+							})).To(Succeed())
+						})
+
+						It("returns failed state", func() {
+							Expect(getOperationErr).NotTo(HaveOccurred())
+							Expect(operation.State).To(Equal(model.CFResourceStateFailed))
+							Expect(operation.Errors).To(ConsistOf("invalid url"))
+						})
+					})
 				})
 			})
 		})
@@ -287,6 +389,7 @@ var _ = Describe("ServiceBrokerRepo", func() {
 		It("returns a list of brokers", func() {
 			Expect(brokers).To(ConsistOf(
 				MatchAllFields(Fields{
+					"SpaceGUID": BeEmpty(),
 					"ServiceBroker": MatchAllFields(Fields{
 						"Name": Equal("first-broker"),
 						"URL":  Equal("https://first.broker"),
@@ -301,6 +404,7 @@ var _ = Describe("ServiceBrokerRepo", func() {
 					}),
 				}),
 				MatchAllFields(Fields{
+					"SpaceGUID": BeEmpty(),
 					"ServiceBroker": MatchAllFields(Fields{
 						"Name": Equal("second-broker"),
 						"URL":  Equal("https://second.broker"),
@@ -317,6 +421,68 @@ var _ = Describe("ServiceBrokerRepo", func() {
 			))
 		})
 
+		When("there are space scoped brokers", func() {
+			var (
+				space           *korifiv1alpha1.CFSpace
+				spaceBrokerGUID string
+			)
+
+			BeforeEach(func() {
+				org := createOrgWithCleanup(ctx, uuid.NewString())
+				space = createSpaceWithCleanup(ctx, org.Name, uuid.NewString())
+				spaceBrokerGUID = uuid.NewString()
+
+				Expect(k8sClient.Create(ctx, &korifiv1alpha1.CFServiceBroker{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: space.Name,
+						Name:      spaceBrokerGUID,
+						Labels: map[string]string{
+							korifiv1alpha1.SpaceGUIDKey: space.Name,
+						},
+					},
+					Spec: korifiv1alpha1.CFServiceBrokerSpec{
+						ServiceBroker: services.ServiceBroker{
+							Name: "space-broker",
+							URL:  "https://space.broker",
+						},
+					},
+				})).To(Succeed())
+			})
+
+			It("does not return them", func() {
+				Expect(brokers).To(HaveLen(2))
+			})
+
+			When("the user has access to the space", func() {
+				BeforeEach(func() {
+					createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
+				})
+
+				It("returns them", func() {
+					Expect(brokers).To(ContainElement(MatchFields(IgnoreExtras, Fields{
+						"SpaceGUID": Equal(space.Name),
+						"CFResource": MatchFields(IgnoreExtras, Fields{
+							"GUID": Equal(spaceBrokerGUID),
+						}),
+					})))
+				})
+
+				When("a space filter is applied", func() {
+					BeforeEach(func() {
+						message.SpaceGUIDs = []string{space.Name}
+					})
+
+					It("only returns the brokers in the space", func() {
+						Expect(brokers).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+							"CFResource": MatchFields(IgnoreExtras, Fields{
+								"GUID": Equal(spaceBrokerGUID),
+							}),
+						})))
+					})
+				})
+			})
+		})
+
 		When("a name filter is applied", func() {
 			BeforeEach(func() {
 				message.Names = []string{"second-broker"}
@@ -362,15 +528,17 @@ var _ = Describe("ServiceBrokerRepo", func() {
 
 	Describe("GetServiceBroker", func() {
 		var (
+			brokerGUID    string
 			serviceBroker repositories.ServiceBrokerRecord
 			getErr        error
 		)
 
 		BeforeEach(func() {
+			brokerGUID = uuid.NewString()
 			Expect(k8sClient.Create(ctx, &korifiv1alpha1.CFServiceBroker{
 				ObjectMeta: metav1.ObjectMeta{
 					Namespace: rootNamespace,
-					Name:      "broker-1",
+					Name:      brokerGUID,
 					Labels: map[string]string{
 						"broker-label": "broker-label-value",
 					},
@@ -388,13 +556,13 @@ var _ = Describe("ServiceBrokerRepo", func() {
 		})
 
 		JustBeforeEach(func() {
-			serviceBroker, getErr = repo.GetServiceBroker(ctx, authInfo, "broker-1")
+			serviceBroker, getErr = repo.GetServiceBroker(ctx, authInfo, brokerGUID)
 		})
 
 		It("returns a forbidden error", func() {
 			Expect(getErr).To(SatisfyAll(
 				BeAssignableToTypeOf(apierrors.ForbiddenError{}),
-				MatchError(ContainSubstring(`cfservicebrokers.korifi.cloudfoundry.org "broker-1" is forbidden`)),
+				MatchError(ContainSubstring(fmt.Sprintf(`cfservicebrokers.korifi.cloudfoundry.org %q is forbidden`, brokerGUID))),
 			))
 		})
 
@@ -406,12 +574,13 @@ var _ = Describe("ServiceBrokerRepo", func() {
 			It("returns the broker", func() {
 				Expect(getErr).NotTo(HaveOccurred())
 				Expect(serviceBroker).To(MatchAllFields(Fields{
+					"SpaceGUID": BeEmpty(),
 					"ServiceBroker": MatchAllFields(Fields{
 						"Name": Equal("first-broker"),
 						"URL":  Equal("https://first.broker"),
 					}),
 					"CFResource": MatchFields(IgnoreExtras, Fields{
-						"GUID":      Equal("broker-1"),
+						"GUID":      Equal(brokerGUID),
 						"CreatedAt": Not(BeZero()),
 						"Metadata": MatchAllFields(Fields{
 							"Labels":      HaveKeyWithValue("broker-label", "broker-label-value"),
@@ -421,6 +590,16 @@ var _ = Describe("ServiceBrokerRepo", func() {
 				}))
 			})
 		})
+
+		When("the broker does not exist", func() {
+			JustBeforeEach(func() {
+				serviceBroker, getErr = repo.GetServiceBroker(ctx, authInfo, "i-do-not-exist")
+			})
+
+			It("returns a not found error", func() {
+				Expect(getErr).To(BeAssignableToTypeOf(apierrors.NotFoundError{}))
+			})
+		})
 	})
 
 	Describe("UpdateServiceBroker", func() {
@@ -518,6 +697,19 @@ var _ = Describe("ServiceBrokerRepo", func() {
 
 				Expect(cfServiceBroker.Labels).To(HaveKeyWithValue("foo", "bar"))
 				Expect(cfServiceBroker.Annotations).To(HaveKeyWithValue("baz", "qux"))
+				Expect(cfServiceBroker.Spec.CatalogRefreshRequestedAt).To(BeNil())
+			})
+
+			When("a catalog refresh is requested", func() {
+				BeforeEach(func() {
+					updateMessage.RefreshCatalog = true
+				})
+
+				It("bumps the catalog refresh request time", func() {
+					Expect(updateErr).NotTo(HaveOccurred())
+					Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfServiceBroker), cfServiceBroker)).To(Succeed())
+					Expect(cfServiceBroker.Spec.CatalogRefreshRequestedAt).NotTo(BeNil())
+				})
 			})
 
 			It("updates the service borker credentials secret", func() {
@@ -12,6 +12,7 @@ import (
 	apierrors "code.cloudfoundry.org/korifi/api/errors"
 	"code.cloudfoundry.org/korifi/api/repositories/compare"
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/controllers/services/osbapi"
 	"code.cloudfoundry.org/korifi/model"
 	"code.cloudfoundry.org/korifi/model/services"
 	"code.cloudfoundry.org/korifi/tools"
@@ -21,6 +22,7 @@ import (
 	"github.com/BooleanCat/go-functional/v2/it/itx"
 	"github.com/google/uuid"
 	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
@@ -39,11 +41,13 @@ type NamespaceGetter interface {
 }
 
 type ServiceInstanceRepo struct {
-	namespaceRetriever NamespaceRetriever
-	userClientFactory  authorization.UserClientFactory
-	awaiter            Awaiter[*korifiv1alpha1.CFServiceInstance]
-	sorter             ServiceInstanceSorter
-	rootNamespace      string
+	namespaceRetriever  NamespaceRetriever
+	userClientFactory   authorization.UserClientFactory
+	awaiter             Awaiter[*korifiv1alpha1.CFServiceInstance]
+	sorter              ServiceInstanceSorter
+	brokerAssets        *osbapi.Assets
+	brokerClientFactory BrokerClientFactory
+	rootNamespace       string
 }
 
 //counterfeiter:generate -o fake -fake-name ServiceInstanceSorter . ServiceInstanceSorter
@@ -90,14 +94,18 @@ func NewServiceInstanceRepo(
 	userClientFactory authorization.UserClientFactory,
 	awaiter Awaiter[*korifiv1alpha1.CFServiceInstance],
 	sorter ServiceInstanceSorter,
+	brokerAssets *osbapi.Assets,
+	brokerClientFactory BrokerClientFactory,
 	rootNamespace string,
 ) *ServiceInstanceRepo {
 	return &ServiceInstanceRepo{
-		namespaceRetriever: namespaceRetriever,
-		userClientFactory:  userClientFactory,
-		awaiter:            awaiter,
-		sorter:             sorter,
-		rootNamespace:      rootNamespace,
+		namespaceRetriever:  namespaceRetriever,
+		userClientFactory:   userClientFactory,
+		awaiter:             awaiter,
+		sorter:              sorter,
+		brokerAssets:        brokerAssets,
+		brokerClientFactory: brokerClientFactory,
+		rootNamespace:       rootNamespace,
 	}
 }
 
@@ -295,10 +303,23 @@ func (r *ServiceInstanceRepo) servicePlanVisible(ctx context.Context, userClient
 		},
 	}
 	err := userClient.Get(ctx, client.ObjectKeyFromObject(servicePlan), servicePlan)
+	if k8serrors.IsNotFound(err) {
+		// space scoped plans live in the namespace of their broker space
+		servicePlan.Namespace = spaceGUID
+		err = userClient.Get(ctx, client.ObjectKeyFromObject(servicePlan), servicePlan)
+	}
 	if err != nil {
 		return false, err
 	}
 
+	if servicePlan.Spec.Unavailable {
+		return false, nil
+	}
+
+	if servicePlan.Spec.Visibility.Type == korifiv1alpha1.SpaceServicePlanVisibilityType {
+		return servicePlan.Namespace == spaceGUID, nil
+	}
+
 	if servicePlan.Spec.Visibility.Type == korifiv1alpha1.PublicServicePlanVisibilityType {
 		return true, nil
 	}
@@ -437,22 +458,31 @@ func (r *ServiceInstanceRepo) ListServiceInstances(ctx context.Context, authInfo
 }
 
 func (r *ServiceInstanceRepo) GetServiceInstance(ctx context.Context, authInfo authorization.Info, guid string) (ServiceInstanceRecord, error) {
+	serviceInstance, err := r.getServiceInstance(ctx, authInfo, guid)
+	if err != nil {
+		return ServiceInstanceRecord{}, err
+	}
+
+	return cfServiceInstanceToRecord(*serviceInstance), nil
+}
+
+func (r *ServiceInstanceRepo) getServiceInstance(ctx context.Context, authInfo authorization.Info, guid string) (*korifiv1alpha1.CFServiceInstance, error) {
 	userClient, err := r.userClientFactory.BuildClient(authInfo)
 	if err != nil {
-		return ServiceInstanceRecord{}, fmt.Errorf("failed to build user client: %w", err)
+		return nil, fmt.Errorf("failed to build user client: %w", err)
 	}
 
 	namespace, err := r.namespaceRetriever.NamespaceFor(ctx, guid, ServiceInstanceResourceType)
 	if err != nil {
-		return ServiceInstanceRecord{}, fmt.Errorf("failed to get namespace for service instance: %w", err)
+		return nil, fmt.Errorf("failed to get namespace for service instance: %w", err)
 	}
 
 	serviceInstance := &korifiv1alpha1.CFServiceInstance{}
 	if err := userClient.Get(ctx, client.ObjectKey{Namespace: namespace, Name: guid}, serviceInstance); err != nil {
-		return ServiceInstanceRecord{}, fmt.Errorf("failed to get service instance: %w", apierrors.FromK8sError(err, ServiceInstanceResourceType))
+		return nil, fmt.Errorf("failed to get service instance: %w", apierrors.FromK8sError(err, ServiceInstanceResourceType))
 	}
 
-	return cfServiceInstanceToRecord(*serviceInstance), nil
+	return serviceInstance, nil
 }
 
 func (r *ServiceInstanceRepo) GetServiceInstanceCredentials(ctx context.Context, authInfo authorization.Info, instanceGUID string) (map[string]any, error) {
@@ -490,6 +520,85 @@ func (r *ServiceInstanceRepo) GetServiceInstanceCredentials(ctx context.Context,
 	return credentials, nil
 }
 
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfservicebrokers;cfserviceinstances;cfserviceofferings;cfserviceplans,verbs=get
+
+// GetServiceInstanceParameters returns the parameters of a managed service
+// instance as reported by its broker. The parameters of user-provided
+// instances are read from the parameters secret instead.
+func (r *ServiceInstanceRepo) GetServiceInstanceParameters(ctx context.Context, authInfo authorization.Info, instanceGUID string) (map[string]any, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	namespace, err := r.namespaceRetriever.NamespaceFor(ctx, instanceGUID, ServiceInstanceResourceType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get namespace for service instance: %w", err)
+	}
+
+	serviceInstance := &korifiv1alpha1.CFServiceInstance{}
+	if err = userClient.Get(ctx, client.ObjectKey{Namespace: namespace, Name: instanceGUID}, serviceInstance); err != nil {
+		return nil, fmt.Errorf("failed to get service instance: %w", apierrors.FromK8sError(err, ServiceInstanceResourceType))
+	}
+
+	if serviceInstance.Spec.Type != korifiv1alpha1.ManagedType {
+		return getStoredParameters(ctx, userClient, namespace, serviceInstance.Spec.Parameters.Name, ServiceInstanceResourceType)
+	}
+
+	assets, err := r.brokerAssets.GetServiceInstanceAssets(ctx, serviceInstance)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get service instance assets: %w", err)
+	}
+
+	if !assets.ServiceOffering.Spec.BrokerCatalog.Features.InstancesRetrievable {
+		return nil, apierrors.NewInvalidRequestError(nil, "This service does not support fetching service instance parameters.")
+	}
+
+	brokerClient, err := r.brokerClientFactory.CreateClient(ctx, authInfo, assets.ServiceBroker)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create client for broker %q: %w", assets.ServiceBroker.Name, err)
+	}
+
+	instance, err := brokerClient.FetchInstance(ctx, osbapi.GetInstanceRequest{
+		InstanceID: serviceInstance.Name,
+		ServiceId:  assets.ServiceOffering.Spec.BrokerCatalog.ID,
+		PlanID:     assets.ServicePlan.Spec.BrokerCatalog.ID,
+	})
+	if err != nil {
+		return nil, apierrors.NewUnprocessableEntityError(err, "The service broker failed to return the service instance parameters.")
+	}
+
+	return ensureParameters(instance.Parameters), nil
+}
+
+func getStoredParameters(ctx context.Context, userClient client.Client, namespace string, secretName string, resourceType string) (map[string]any, error) {
+	if secretName == "" {
+		return map[string]any{}, nil
+	}
+
+	paramsSecret := &corev1.Secret{}
+	if err := userClient.Get(ctx, client.ObjectKey{Namespace: namespace, Name: secretName}, paramsSecret); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return map[string]any{}, nil
+		}
+		return nil, fmt.Errorf("failed to get parameters secret: %w", apierrors.FromK8sError(err, resourceType))
+	}
+
+	parameters, err := tools.FromParametersSecretData(paramsSecret.Data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode parameters secret: %w", err)
+	}
+
+	return ensureParameters(parameters), nil
+}
+
+func ensureParameters(parameters map[string]any) map[string]any {
+	if parameters == nil {
+		return map[string]any{}
+	}
+	return parameters
+}
+
 func (r *ServiceInstanceRepo) DeleteServiceInstance(ctx context.Context, authInfo authorization.Info, message DeleteServiceInstanceMessage) (ServiceInstanceRecord, error) {
 	userClient, err := r.userClientFactory.BuildClient(authInfo)
 	if err != nil {
@@ -535,17 +644,25 @@ func (r ServiceInstanceRecord) GetResourceType() string {
 	return ServiceInstanceResourceType
 }
 
-func (r *ServiceInstanceRepo) GetState(ctx context.Context, authInfo authorization.Info, guid string) (model.CFResourceState, error) {
-	instanceRecord, err := r.GetServiceInstance(ctx, authInfo, guid)
+func (r *ServiceInstanceRepo) GetOperation(ctx context.Context, authInfo authorization.Info, guid string) (model.CFResourceOperation, error) {
+	serviceInstance, err := r.getServiceInstance(ctx, authInfo, guid)
 	if err != nil {
-		return model.CFResourceStateUnknown, err
+		return model.CFResourceOperation{State: model.CFResourceStateUnknown}, err
+	}
+
+	if errs := trueConditionMessages(
+		serviceInstance.Status.Conditions,
+		korifiv1alpha1.ProvisioningFailedCondition,
+		korifiv1alpha1.DeprovisioningFailedCondition,
+	); len(errs) > 0 {
+		return model.CFResourceOperation{State: model.CFResourceStateFailed, Errors: errs}, nil
 	}
 
-	if instanceRecord.Ready {
-		return model.CFResourceStateReady, nil
+	if isInstanceReady(*serviceInstance) {
+		return model.CFResourceOperation{State: model.CFResourceStateReady}, nil
 	}
 
-	return model.CFResourceStateUnknown, nil
+	return model.CFResourceOperation{State: model.CFResourceStateUnknown}, nil
 }
 
 func (r *ServiceInstanceRepo) GetDeletedAt(ctx context.Context, authInfo authorization.Info, instanceGUID string) (*time.Time, error) {
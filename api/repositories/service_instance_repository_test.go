@@ -13,6 +13,8 @@ import (
 	"code.cloudfoundry.org/korifi/api/repositories/fake"
 	"code.cloudfoundry.org/korifi/api/repositories/fakeawaiter"
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/controllers/services/osbapi"
+	osbapifake "code.cloudfoundry.org/korifi/controllers/controllers/services/osbapi/fake"
 	"code.cloudfoundry.org/korifi/model"
 	"code.cloudfoundry.org/korifi/model/services"
 	"code.cloudfoundry.org/korifi/tests/matchers"
@@ -43,6 +45,9 @@ var _ = Describe("ServiceInstanceRepository", func() {
 		]
 		sorter *fake.ServiceInstanceSorter
 
+		brokerClient        *osbapifake.BrokerClient
+		brokerClientFactory *fake.BrokerClientFactory
+
 		org                 *korifiv1alpha1.CFOrg
 		space               *korifiv1alpha1.CFSpace
 		serviceInstanceName string
@@ -60,6 +65,10 @@ var _ = Describe("ServiceInstanceRepository", func() {
 			return records
 		}
 
+		brokerClient = new(osbapifake.BrokerClient)
+		brokerClientFactory = new(fake.BrokerClientFactory)
+		brokerClientFactory.CreateClientReturns(brokerClient, nil)
+
 		serviceInstanceRepo = repositories.NewServiceInstanceRepo(
 			namespaceRetriever,
 			userClientFactory.WithWrappingFunc(func(client client.WithWatch) client.WithWatch {
@@ -67,6 +76,8 @@ var _ = Describe("ServiceInstanceRepository", func() {
 			}),
 			conditionAwaiter,
 			sorter,
+			osbapi.NewAssets(k8sClient, rootNamespace),
+			brokerClientFactory,
 			rootNamespace,
 		)
 
@@ -288,6 +299,18 @@ var _ = Describe("ServiceInstanceRepository", func() {
 				})
 			})
 
+			When("the service plan is unavailable", func() {
+				BeforeEach(func() {
+					Expect(k8s.PatchResource(ctx, k8sClient, servicePlan, func() {
+						servicePlan.Spec.Unavailable = true
+					})).To(Succeed())
+				})
+
+				It("returns unprocessable entity error", func() {
+					Expect(createErr).To(BeAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+				})
+			})
+
 			When("the service plan visibility type is organization", func() {
 				BeforeEach(func() {
 					Expect(k8s.PatchResource(ctx, k8sClient, servicePlan, func() {
@@ -324,6 +347,27 @@ var _ = Describe("ServiceInstanceRepository", func() {
 				})
 			})
 
+			When("the service plan is space scoped", func() {
+				BeforeEach(func() {
+					serviceInstanceCreateMessage.PlanGUID = createSpaceScopedPlan(space.Name)
+				})
+
+				It("succeeds", func() {
+					Expect(createErr).NotTo(HaveOccurred())
+				})
+
+				When("the plan belongs to another space", func() {
+					BeforeEach(func() {
+						otherSpace := createSpaceWithCleanup(ctx, org.Name, prefixedGUID("other-space"))
+						serviceInstanceCreateMessage.PlanGUID = createSpaceScopedPlan(otherSpace.Name)
+					})
+
+					It("returns unprocessable entity error", func() {
+						Expect(createErr).To(BeAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+					})
+				})
+			})
+
 			When("the service plan does not exist", func() {
 				BeforeEach(func() {
 					serviceInstanceCreateMessage.PlanGUID = "does-not-exist"
@@ -439,11 +483,11 @@ var _ = Describe("ServiceInstanceRepository", func() {
 		})
 	})
 
-	Describe("GetState", func() {
+	Describe("GetOperation", func() {
 		var (
 			cfServiceInstance *korifiv1alpha1.CFServiceInstance
-			state             model.CFResourceState
-			stateErr          error
+			operation         model.CFResourceOperation
+			operationErr      error
 		)
 		BeforeEach(func() {
 			cfServiceInstance = &korifiv1alpha1.CFServiceInstance{
@@ -460,11 +504,11 @@ var _ = Describe("ServiceInstanceRepository", func() {
 		})
 
 		JustBeforeEach(func() {
-			state, stateErr = serviceInstanceRepo.GetState(ctx, authInfo, cfServiceInstance.Name)
+			operation, operationErr = serviceInstanceRepo.GetOperation(ctx, authInfo, cfServiceInstance.Name)
 		})
 
 		It("returns a forbidden error", func() {
-			Expect(stateErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+			Expect(operationErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
 		})
 
 		When("the user can get CFServiceInstance", func() {
@@ -473,8 +517,8 @@ var _ = Describe("ServiceInstanceRepository", func() {
 			})
 
 			It("returns unknown state", func() {
-				Expect(stateErr).NotTo(HaveOccurred())
-				Expect(state).To(Equal(model.CFResourceStateUnknown))
+				Expect(operationErr).NotTo(HaveOccurred())
+				Expect(operation.State).To(Equal(model.CFResourceStateUnknown))
 			})
 
 			When("the service instance is ready", func() {
@@ -491,8 +535,8 @@ var _ = Describe("ServiceInstanceRepository", func() {
 				})
 
 				It("returns ready state", func() {
-					Expect(stateErr).NotTo(HaveOccurred())
-					Expect(state).To(Equal(model.CFResourceStateReady))
+					Expect(operationErr).NotTo(HaveOccurred())
+					Expect(operation.State).To(Equal(model.CFResourceStateReady))
 				})
 
 				When("the ready status is stale ", func() {
@@ -503,11 +547,50 @@ var _ = Describe("ServiceInstanceRepository", func() {
 					})
 
 					It("returns unknown state", func() {
-						Expect(stateErr).NotTo(HaveOccurred())
-						Expect(state).To(Equal(model.CFResourceStateUnknown))
+						Expect(operationErr).NotTo(HaveOccurred())
+						Expect(operation.State).To(Equal(model.CFResourceStateUnknown))
 					})
 				})
 			})
+
+			When("provisioning has failed", func() {
+				BeforeEach(func() {
+					Expect(k8s.Patch(ctx, k8sClient, cfServiceInstance, func() {
+						meta.SetStatusCondition(&cfServiceInstance.Status.Conditions, metav1.Condition{
+							Type:    korifiv1alpha1.ProvisioningFailedCondition,
+							Status:  metav1.ConditionTrue,
+							Message: "provision-failed",
+							Reason:  "Failed",
+						})
+						cfServiceInstance.Status.ObservedGeneration = cfServiceInstance.Generation
+					})).To(Succeed())
+				})
+
+				It("returns failed state with the failure message", func() {
+					Expect(operationErr).NotTo(HaveOccurred())
+					Expect(operation.State).To(Equal(model.CFResourceStateFailed))
+					Expect(operation.Errors).To(ConsistOf("provision-failed"))
+				})
+			})
+
+			When("deprovisioning has failed", func() {
+				BeforeEach(func() {
+					Expect(k8s.Patch(ctx, k8sClient, cfServiceInstance, func() {
+						meta.SetStatusCondition(&cfServiceInstance.Status.Conditions, metav1.Condition{
+							Type:    korifiv1alpha1.DeprovisioningFailedCondition,
+							Status:  metav1.ConditionTrue,
+							Message: "deprovision-failed",
+							Reason:  "Failed",
+						})
+					})).To(Succeed())
+				})
+
+				It("returns failed state with the failure message", func() {
+					Expect(operationErr).NotTo(HaveOccurred())
+					Expect(operation.State).To(Equal(model.CFResourceStateFailed))
+					Expect(operation.Errors).To(ConsistOf("deprovision-failed"))
+				})
+			})
 		})
 	})
 
@@ -1153,6 +1236,128 @@ var _ = Describe("ServiceInstanceRepository", func() {
 		})
 	})
 
+	Describe("GetServiceInstanceParameters", func() {
+		var (
+			serviceInstance *korifiv1alpha1.CFServiceInstance
+			parameters      map[string]any
+			getErr          error
+		)
+
+		BeforeEach(func() {
+			serviceInstance = &korifiv1alpha1.CFServiceInstance{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      uuid.NewString(),
+					Namespace: space.Name,
+				},
+				Spec: korifiv1alpha1.CFServiceInstanceSpec{
+					Type: korifiv1alpha1.UserProvidedType,
+					Parameters: corev1.LocalObjectReference{
+						Name: uuid.NewString(),
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, serviceInstance)).To(Succeed())
+
+			paramsData, err := tools.ToParametersSecretData(map[string]any{"foo": "bar"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(k8sClient.Create(ctx, &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      serviceInstance.Spec.Parameters.Name,
+					Namespace: space.Name,
+				},
+				Data: paramsData,
+			})).To(Succeed())
+		})
+
+		JustBeforeEach(func() {
+			parameters, getErr = serviceInstanceRepo.GetServiceInstanceParameters(ctx, authInfo, serviceInstance.Name)
+		})
+
+		It("returns a forbidden error", func() {
+			Expect(getErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is a space developer", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
+			})
+
+			It("returns the stored parameters of the user-provided instance", func() {
+				Expect(getErr).NotTo(HaveOccurred())
+				Expect(parameters).To(Equal(map[string]any{"foo": "bar"}))
+				Expect(brokerClientFactory.CreateClientCallCount()).To(BeZero())
+			})
+
+			When("the instance is managed", func() {
+				var plan *korifiv1alpha1.CFServicePlan
+
+				BeforeEach(func() {
+					plan = createManagedServiceAssets(services.BrokerCatalogFeatures{InstancesRetrievable: true})
+					Expect(k8s.PatchResource(ctx, k8sClient, serviceInstance, func() {
+						serviceInstance.Spec.Type = korifiv1alpha1.ManagedType
+						serviceInstance.Spec.PlanGUID = plan.Name
+					})).To(Succeed())
+
+					brokerClient.FetchInstanceReturns(osbapi.GetInstanceResponse{
+						Parameters: map[string]any{"broker": "param"},
+					}, nil)
+				})
+
+				It("fetches the parameters from the broker", func() {
+					Expect(getErr).NotTo(HaveOccurred())
+					Expect(parameters).To(Equal(map[string]any{"broker": "param"}))
+
+					Expect(brokerClientFactory.CreateClientCallCount()).To(Equal(1))
+					_, actualAuthInfo, actualBroker := brokerClientFactory.CreateClientArgsForCall(0)
+					Expect(actualAuthInfo).To(Equal(authInfo))
+					Expect(actualBroker.Name).To(Equal(plan.Labels[korifiv1alpha1.RelServiceBrokerGUIDLabel]))
+
+					Expect(brokerClient.FetchInstanceCallCount()).To(Equal(1))
+					_, request := brokerClient.FetchInstanceArgsForCall(0)
+					Expect(request).To(Equal(osbapi.GetInstanceRequest{
+						InstanceID: serviceInstance.Name,
+						ServiceId:  "offering-catalog-id",
+						PlanID:     "plan-catalog-id",
+					}))
+				})
+
+				When("the broker fails to return the instance", func() {
+					BeforeEach(func() {
+						brokerClient.FetchInstanceReturns(osbapi.GetInstanceResponse{}, errors.New("fetch-err"))
+					})
+
+					It("returns an unprocessable entity error", func() {
+						Expect(getErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+					})
+				})
+
+				When("the offering does not support retrieving instances", func() {
+					BeforeEach(func() {
+						plan = createManagedServiceAssets(services.BrokerCatalogFeatures{})
+						Expect(k8s.PatchResource(ctx, k8sClient, serviceInstance, func() {
+							serviceInstance.Spec.PlanGUID = plan.Name
+						})).To(Succeed())
+					})
+
+					It("returns an invalid request error", func() {
+						Expect(getErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.InvalidRequestError{}))
+						Expect(brokerClient.FetchInstanceCallCount()).To(BeZero())
+					})
+				})
+			})
+
+			When("the service instance does not exist", func() {
+				BeforeEach(func() {
+					serviceInstance.Name = "does-not-exist"
+				})
+
+				It("returns a not found error", func() {
+					Expect(getErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
+				})
+			})
+		})
+	})
+
 	Describe("DeleteServiceInstance", func() {
 		var (
 			serviceInstance *korifiv1alpha1.CFServiceInstance
@@ -1310,3 +1515,81 @@ var _ = DescribeTable("ServiceInstanceSorter",
 		BeNumerically(">", 0),
 	),
 )
+
+func createSpaceScopedPlan(spaceGUID string) string {
+	plan := &korifiv1alpha1.CFServicePlan{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: spaceGUID,
+			Name:      uuid.NewString(),
+			Labels: map[string]string{
+				korifiv1alpha1.SpaceGUIDKey: spaceGUID,
+			},
+		},
+		Spec: korifiv1alpha1.CFServicePlanSpec{
+			Visibility: korifiv1alpha1.ServicePlanVisibility{
+				Type: korifiv1alpha1.SpaceServicePlanVisibilityType,
+			},
+		},
+	}
+	Expect(k8sClient.Create(ctx, plan)).To(Succeed())
+
+	return plan.Name
+}
+
+func createManagedServiceAssets(features services.BrokerCatalogFeatures) *korifiv1alpha1.CFServicePlan {
+	broker := &korifiv1alpha1.CFServiceBroker{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: rootNamespace,
+			Name:      uuid.NewString(),
+		},
+		Spec: korifiv1alpha1.CFServiceBrokerSpec{
+			Credentials: corev1.LocalObjectReference{
+				Name: uuid.NewString(),
+			},
+		},
+	}
+	Expect(k8sClient.Create(ctx, broker)).To(Succeed())
+
+	offering := &korifiv1alpha1.CFServiceOffering{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: rootNamespace,
+			Name:      uuid.NewString(),
+			Labels: map[string]string{
+				korifiv1alpha1.RelServiceBrokerGUIDLabel: broker.Name,
+			},
+		},
+		Spec: korifiv1alpha1.CFServiceOfferingSpec{
+			ServiceOffering: services.ServiceOffering{
+				BrokerCatalog: services.ServiceBrokerCatalog{
+					ID:       "offering-catalog-id",
+					Features: features,
+				},
+			},
+		},
+	}
+	Expect(k8sClient.Create(ctx, offering)).To(Succeed())
+
+	plan := &korifiv1alpha1.CFServicePlan{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: rootNamespace,
+			Name:      uuid.NewString(),
+			Labels: map[string]string{
+				korifiv1alpha1.RelServiceBrokerGUIDLabel:   broker.Name,
+				korifiv1alpha1.RelServiceOfferingGUIDLabel: offering.Name,
+			},
+		},
+		Spec: korifiv1alpha1.CFServicePlanSpec{
+			ServicePlan: services.ServicePlan{
+				BrokerCatalog: services.ServicePlanBrokerCatalog{
+					ID: "plan-catalog-id",
+				},
+			},
+			Visibility: korifiv1alpha1.ServicePlanVisibility{
+				Type: korifiv1alpha1.PublicServicePlanVisibilityType,
+			},
+		},
+	}
+	Expect(k8sClient.Create(ctx, plan)).To(Succeed())
+
+	return plan
+}
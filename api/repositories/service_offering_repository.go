@@ -26,6 +26,7 @@ type ServiceOfferingRecord struct {
 	services.ServiceOffering
 	model.CFResource
 	ServiceBrokerGUID string
+	Available         bool
 }
 
 func (r ServiceOfferingRecord) Relationships() map[string]string {
@@ -36,6 +37,7 @@ func (r ServiceOfferingRecord) Relationships() map[string]string {
 
 type ServiceOfferingRepo struct {
 	userClientFactory    authorization.UserClientFactory
+	namespaceRetriever   NamespaceRetriever
 	rootNamespace        string
 	brokerRepo           *ServiceBrokerRepo
 	namespacePermissions *authorization.NamespacePermissions
@@ -60,12 +62,14 @@ func (m *ListServiceOfferingMessage) matches(cfServiceOffering korifiv1alpha1.CF
 
 func NewServiceOfferingRepo(
 	userClientFactory authorization.UserClientFactory,
+	namespaceRetriever NamespaceRetriever,
 	rootNamespace string,
 	brokerRepo *ServiceBrokerRepo,
 	namespacePermissions *authorization.NamespacePermissions,
 ) *ServiceOfferingRepo {
 	return &ServiceOfferingRepo{
 		userClientFactory:    userClientFactory,
+		namespaceRetriever:   namespaceRetriever,
 		rootNamespace:        rootNamespace,
 		brokerRepo:           brokerRepo,
 		namespacePermissions: namespacePermissions,
@@ -78,9 +82,14 @@ func (r *ServiceOfferingRepo) GetServiceOffering(ctx context.Context, authInfo a
 		return ServiceOfferingRecord{}, fmt.Errorf("failed to build user client: %w", err)
 	}
 
+	ns, err := r.namespaceRetriever.NamespaceFor(ctx, guid, ServiceOfferingResourceType)
+	if err != nil {
+		return ServiceOfferingRecord{}, err
+	}
+
 	offering := &korifiv1alpha1.CFServiceOffering{
 		ObjectMeta: metav1.ObjectMeta{
-			Namespace: r.rootNamespace,
+			Namespace: ns,
 			Name:      guid,
 		},
 	}
@@ -110,7 +119,17 @@ func (r *ServiceOfferingRepo) ListOfferings(ctx context.Context, authInfo author
 		)
 	}
 
-	return slices.Collect(it.Map(itx.FromSlice(offeringsList.Items).Filter(message.matches), offeringToRecord)), nil
+	spaceOfferingsList := &korifiv1alpha1.CFServiceOfferingList{}
+	err = userClient.List(ctx, spaceOfferingsList, client.HasLabels{korifiv1alpha1.SpaceGUIDKey})
+	if err != nil {
+		return []ServiceOfferingRecord{}, fmt.Errorf("failed to list space scoped service offerings: %w",
+			apierrors.FromK8sError(err, ServiceOfferingResourceType),
+		)
+	}
+
+	offerings := itx.FromSlice(slices.Concat(offeringsList.Items, spaceOfferingsList.Items)).Filter(message.matches)
+
+	return slices.Collect(it.Map(offerings, offeringToRecord)), nil
 }
 
 func (r *ServiceOfferingRepo) DeleteOffering(ctx context.Context, authInfo authorization.Info, message DeleteServiceOfferingMessage) error {
@@ -119,9 +138,14 @@ func (r *ServiceOfferingRepo) DeleteOffering(ctx context.Context, authInfo autho
 		return fmt.Errorf("failed to build user client: %w", err)
 	}
 
+	ns, err := r.namespaceRetriever.NamespaceFor(ctx, message.GUID, ServiceOfferingResourceType)
+	if err != nil {
+		return err
+	}
+
 	offering := &korifiv1alpha1.CFServiceOffering{
 		ObjectMeta: metav1.ObjectMeta{
-			Namespace: r.rootNamespace,
+			Namespace: ns,
 			Name:      message.GUID,
 		},
 	}
@@ -131,7 +155,7 @@ func (r *ServiceOfferingRepo) DeleteOffering(ctx context.Context, authInfo autho
 	}
 
 	if message.Purge {
-		if err = r.purgeRelatedResources(ctx, authInfo, userClient, message.GUID); err != nil {
+		if err = r.purgeRelatedResources(ctx, authInfo, userClient, offering); err != nil {
 			return fmt.Errorf("failed to purge service offering resources: %w", apierrors.FromK8sError(err, ServiceOfferingResourceType))
 		}
 	}
@@ -155,11 +179,12 @@ func offeringToRecord(offering korifiv1alpha1.CFServiceOffering) ServiceOffering
 			},
 		},
 		ServiceBrokerGUID: offering.Labels[korifiv1alpha1.RelServiceBrokerGUIDLabel],
+		Available:         !offering.Spec.Unavailable,
 	}
 }
 
-func (r *ServiceOfferingRepo) purgeRelatedResources(ctx context.Context, authInfo authorization.Info, userClient client.WithWatch, offeringGUID string) error {
-	planGUIDs, err := r.deleteServicePlans(ctx, userClient, offeringGUID)
+func (r *ServiceOfferingRepo) purgeRelatedResources(ctx context.Context, authInfo authorization.Info, userClient client.WithWatch, offering *korifiv1alpha1.CFServiceOffering) error {
+	planGUIDs, err := r.deleteServicePlans(ctx, userClient, offering)
 	if err != nil {
 		return fmt.Errorf("failed to delete service plans: %w", apierrors.FromK8sError(err, ServicePlanResourceType))
 	}
@@ -205,12 +230,12 @@ func (r *ServiceOfferingRepo) purgeRelatedResources(ctx context.Context, authInf
 	return nil
 }
 
-func (r *ServiceOfferingRepo) deleteServicePlans(ctx context.Context, userClient client.WithWatch, offeringGUID string) ([]string, error) {
+func (r *ServiceOfferingRepo) deleteServicePlans(ctx context.Context, userClient client.WithWatch, offering *korifiv1alpha1.CFServiceOffering) ([]string, error) {
 	var planGUIDs []string
 	plans := &korifiv1alpha1.CFServicePlanList{}
 
-	if err := userClient.List(ctx, plans, client.InNamespace(r.rootNamespace), client.MatchingLabels{
-		korifiv1alpha1.RelServiceOfferingGUIDLabel: offeringGUID,
+	if err := userClient.List(ctx, plans, client.InNamespace(offering.Namespace), client.MatchingLabels{
+		korifiv1alpha1.RelServiceOfferingGUIDLabel: offering.Name,
 	}); err != nil {
 		return []string{}, fmt.Errorf("failed to list service plans: %w", err)
 	}
@@ -10,6 +10,7 @@ import (
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	"code.cloudfoundry.org/korifi/model/services"
 	"code.cloudfoundry.org/korifi/tools"
+	"code.cloudfoundry.org/korifi/tools/k8s"
 	. "github.com/onsi/gomega/gstruct"
 	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
@@ -32,9 +33,11 @@ var _ = Describe("ServiceOfferingRepo", func() {
 	BeforeEach(func() {
 		repo = repositories.NewServiceOfferingRepo(
 			userClientFactory,
+			namespaceRetriever,
 			rootNamespace,
 			repositories.NewServiceBrokerRepo(
 				userClientFactory,
+				namespaceRetriever,
 				rootNamespace,
 			),
 			nsPerms,
@@ -143,10 +146,30 @@ var _ = Describe("ServiceOfferingRepo", func() {
 						}),
 					}),
 					"ServiceBrokerGUID": Equal(broker.Name),
+					"Available":         BeTrue(),
 				}),
 			)
 		})
 
+		When("the service offering is unavailable", func() {
+			BeforeEach(func() {
+				offering := &korifiv1alpha1.CFServiceOffering{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: rootNamespace,
+						Name:      offeringGUID,
+					},
+				}
+				Expect(k8s.PatchResource(ctx, k8sClient, offering, func() {
+					offering.Spec.Unavailable = true
+				})).To(Succeed())
+			})
+
+			It("returns an unavailable offering", func() {
+				Expect(getErr).NotTo(HaveOccurred())
+				Expect(desiredOffering.Available).To(BeFalse())
+			})
+		})
+
 		When("the service offering does not exist", func() {
 			BeforeEach(func() {
 				offeringGUID = "does-not-exist"
@@ -42,9 +42,10 @@ type PlanVisibility struct {
 }
 
 type ServicePlanRepo struct {
-	userClientFactory authorization.UserClientFactory
-	rootNamespace     string
-	orgRepo           *OrgRepo
+	userClientFactory  authorization.UserClientFactory
+	namespaceRetriever NamespaceRetriever
+	rootNamespace      string
+	orgRepo            *OrgRepo
 }
 
 type ListServicePlanMessage struct {
@@ -68,7 +69,8 @@ func (m *ListServicePlanMessage) matches(cfServicePlan korifiv1alpha1.CFServiceP
 }
 
 func isAvailable(cfServicePlan korifiv1alpha1.CFServicePlan) bool {
-	return cfServicePlan.Spec.Visibility.Type != korifiv1alpha1.AdminServicePlanVisibilityType
+	return cfServicePlan.Spec.Visibility.Type != korifiv1alpha1.AdminServicePlanVisibilityType &&
+		!cfServicePlan.Spec.Unavailable
 }
 
 type ApplyServicePlanVisibilityMessage struct {
@@ -114,13 +116,15 @@ func (m *DeleteServicePlanVisibilityMessage) apply(cfServicePlan *korifiv1alpha1
 
 func NewServicePlanRepo(
 	userClientFactory authorization.UserClientFactory,
+	namespaceRetriever NamespaceRetriever,
 	rootNamespace string,
 	orgRepo *OrgRepo,
 ) *ServicePlanRepo {
 	return &ServicePlanRepo{
-		userClientFactory: userClientFactory,
-		rootNamespace:     rootNamespace,
-		orgRepo:           orgRepo,
+		userClientFactory:  userClientFactory,
+		namespaceRetriever: namespaceRetriever,
+		rootNamespace:      rootNamespace,
+		orgRepo:            orgRepo,
 	}
 }
 
@@ -135,7 +139,14 @@ func (r *ServicePlanRepo) ListPlans(ctx context.Context, authInfo authorization.
 		return nil, apierrors.FromK8sError(err, ServicePlanResourceType)
 	}
 
-	return it.TryCollect(it.MapError(itx.FromSlice(cfServicePlans.Items).Filter(message.matches), func(plan korifiv1alpha1.CFServicePlan) (ServicePlanRecord, error) {
+	spaceServicePlans := &korifiv1alpha1.CFServicePlanList{}
+	if err := userClient.List(ctx, spaceServicePlans, client.HasLabels{korifiv1alpha1.SpaceGUIDKey}); err != nil {
+		return nil, apierrors.FromK8sError(err, ServicePlanResourceType)
+	}
+
+	plans := itx.FromSlice(slices.Concat(cfServicePlans.Items, spaceServicePlans.Items)).Filter(message.matches)
+
+	return it.TryCollect(it.MapError(plans, func(plan korifiv1alpha1.CFServicePlan) (ServicePlanRecord, error) {
 		return r.planToRecord(ctx, authInfo, plan)
 	}))
 }
@@ -146,9 +157,14 @@ func (r *ServicePlanRepo) GetPlan(ctx context.Context, authInfo authorization.In
 		return ServicePlanRecord{}, fmt.Errorf("failed to build user client: %w", err)
 	}
 
+	ns, err := r.namespaceRetriever.NamespaceFor(ctx, planGUID, ServicePlanResourceType)
+	if err != nil {
+		return ServicePlanRecord{}, err
+	}
+
 	cfServicePlan := &korifiv1alpha1.CFServicePlan{
 		ObjectMeta: metav1.ObjectMeta{
-			Namespace: r.rootNamespace,
+			Namespace: ns,
 			Name:      planGUID,
 		},
 	}
@@ -182,9 +198,14 @@ func (r *ServicePlanRepo) DeletePlan(ctx context.Context, authInfo authorization
 		return fmt.Errorf("failed to build user client: %w", err)
 	}
 
+	ns, err := r.namespaceRetriever.NamespaceFor(ctx, planGUID, ServicePlanResourceType)
+	if err != nil {
+		return err
+	}
+
 	cfServicePlan := &korifiv1alpha1.CFServicePlan{
 		ObjectMeta: metav1.ObjectMeta{
-			Namespace: r.rootNamespace,
+			Namespace: ns,
 			Name:      planGUID,
 		},
 	}
@@ -207,9 +228,18 @@ func (r *ServicePlanRepo) patchServicePlan(
 		return ServicePlanRecord{}, fmt.Errorf("failed to build user client: %w", err)
 	}
 
+	ns, err := r.namespaceRetriever.NamespaceFor(ctx, planGUID, ServicePlanResourceType)
+	if err != nil {
+		return ServicePlanRecord{}, err
+	}
+
+	if ns != r.rootNamespace {
+		return ServicePlanRecord{}, apierrors.NewUnprocessableEntityError(nil, "Cannot update visibility of space-scoped service plans")
+	}
+
 	cfServicePlan := &korifiv1alpha1.CFServicePlan{
 		ObjectMeta: metav1.ObjectMeta{
-			Namespace: r.rootNamespace,
+			Namespace: ns,
 			Name:      planGUID,
 		},
 	}
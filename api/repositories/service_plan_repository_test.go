@@ -37,8 +37,8 @@ var _ = Describe("ServicePlanRepo", func() {
 			korifiv1alpha1.CFOrg,
 			korifiv1alpha1.CFOrgList,
 			*korifiv1alpha1.CFOrgList,
-		]{})
-		repo = repositories.NewServicePlanRepo(userClientFactory, rootNamespace, orgRepo)
+		]{}, nil)
+		repo = repositories.NewServicePlanRepo(userClientFactory, namespaceRetriever, rootNamespace, orgRepo)
 
 		planGUID = uuid.NewString()
 		Expect(k8sClient.Create(ctx, &korifiv1alpha1.CFServicePlan{
@@ -181,6 +181,24 @@ var _ = Describe("ServicePlanRepo", func() {
 			It("returns an available plan", func() {
 				Expect(plan.Available).To(BeTrue())
 			})
+
+			When("the plan has been removed from the broker catalog", func() {
+				BeforeEach(func() {
+					cfServicePlan := &korifiv1alpha1.CFServicePlan{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: rootNamespace,
+							Name:      planGUID,
+						},
+					}
+					Expect(k8s.PatchResource(ctx, k8sClient, cfServicePlan, func() {
+						cfServicePlan.Spec.Unavailable = true
+					})).To(Succeed())
+				})
+
+				It("returns an unavailable plan", func() {
+					Expect(plan.Available).To(BeFalse())
+				})
+			})
 		})
 	})
 
@@ -236,6 +254,61 @@ var _ = Describe("ServicePlanRepo", func() {
 			))
 		})
 
+		When("there are space scoped plans", func() {
+			var (
+				space         *korifiv1alpha1.CFSpace
+				spacePlanGUID string
+			)
+
+			BeforeEach(func() {
+				org := createOrgWithCleanup(ctx, uuid.NewString())
+				space = createSpaceWithCleanup(ctx, org.Name, uuid.NewString())
+				spacePlanGUID = uuid.NewString()
+
+				Expect(k8sClient.Create(ctx, &korifiv1alpha1.CFServicePlan{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: space.Name,
+						Name:      spacePlanGUID,
+						Labels: map[string]string{
+							korifiv1alpha1.SpaceGUIDKey: space.Name,
+						},
+					},
+					Spec: korifiv1alpha1.CFServicePlanSpec{
+						Visibility: korifiv1alpha1.ServicePlanVisibility{
+							Type: korifiv1alpha1.SpaceServicePlanVisibilityType,
+						},
+						ServicePlan: services.ServicePlan{
+							Name: "space-plan",
+						},
+					},
+				})).To(Succeed())
+			})
+
+			It("does not list them", func() {
+				Expect(listErr).NotTo(HaveOccurred())
+				Expect(listedPlans).To(HaveLen(2))
+			})
+
+			When("the user has access to the space", func() {
+				BeforeEach(func() {
+					createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
+				})
+
+				It("lists them as available", func() {
+					Expect(listErr).NotTo(HaveOccurred())
+					Expect(listedPlans).To(ContainElement(MatchFields(IgnoreExtras, Fields{
+						"CFResource": MatchFields(IgnoreExtras, Fields{
+							"GUID": Equal(spacePlanGUID),
+						}),
+						"Visibility": MatchFields(IgnoreExtras, Fields{
+							"Type": Equal(korifiv1alpha1.SpaceServicePlanVisibilityType),
+						}),
+						"Available": BeTrue(),
+					})))
+				})
+			})
+		})
+
 		When("filtering by service_offering_guid", func() {
 			BeforeEach(func() {
 				message.ServiceOfferingGUIDs = []string{"other-offering-guid"}
@@ -390,6 +463,31 @@ var _ = Describe("ServicePlanRepo", func() {
 					}))
 				})
 
+				When("the plan is space scoped", func() {
+					BeforeEach(func() {
+						space := createSpaceWithCleanup(ctx, cfOrg.Name, uuid.NewString())
+						planGUID = uuid.NewString()
+						Expect(k8sClient.Create(ctx, &korifiv1alpha1.CFServicePlan{
+							ObjectMeta: metav1.ObjectMeta{
+								Namespace: space.Name,
+								Name:      planGUID,
+								Labels: map[string]string{
+									korifiv1alpha1.SpaceGUIDKey: space.Name,
+								},
+							},
+							Spec: korifiv1alpha1.CFServicePlanSpec{
+								Visibility: korifiv1alpha1.ServicePlanVisibility{
+									Type: korifiv1alpha1.SpaceServicePlanVisibilityType,
+								},
+							},
+						})).To(Succeed())
+					})
+
+					It("returns an unprocessable entity error", func() {
+						Expect(visibilityErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+					})
+				})
+
 				It("patches the plan visibility in kubernetes", func() {
 					Expect(visibilityErr).NotTo(HaveOccurred())
 
@@ -0,0 +1,242 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/controllers/usage"
+	"code.cloudfoundry.org/korifi/tools"
+
+	"github.com/BooleanCat/go-functional/v2/it"
+	"github.com/BooleanCat/go-functional/v2/it/itx"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	ServiceUsageEventResourceType = "Service Usage Event"
+)
+
+type ServiceUsageEventRecord struct {
+	GUID                string
+	State               string
+	ServiceInstanceGUID string
+	ServiceInstanceName string
+	ServiceInstanceType string
+	ServicePlanGUID     string
+	ServicePlanName     string
+	ServiceOfferingGUID string
+	ServiceOfferingName string
+	ServiceBrokerGUID   string
+	ServiceBrokerName   string
+	SpaceGUID           string
+	SpaceName           string
+	OrgGUID             string
+	CreatedAt           time.Time
+	UpdatedAt           *time.Time
+}
+
+type ListServiceUsageEventsMessage struct {
+	GUIDs                []string
+	AfterGUID            string
+	ServiceInstanceTypes []string
+	Pagination           Pagination
+}
+
+func (m *ListServiceUsageEventsMessage) matches(e korifiv1alpha1.CFServiceUsageEvent) bool {
+	return tools.EmptyOrContains(m.GUIDs, e.Name) &&
+		tools.EmptyOrContains(m.ServiceInstanceTypes, string(e.Spec.ServiceInstanceType))
+}
+
+type ServiceUsageEventRepo struct {
+	userClientFactory authorization.UserClientFactory
+	rootNamespace     string
+}
+
+func NewServiceUsageEventRepo(
+	userClientFactory authorization.UserClientFactory,
+	rootNamespace string,
+) *ServiceUsageEventRepo {
+	return &ServiceUsageEventRepo{
+		userClientFactory: userClientFactory,
+		rootNamespace:     rootNamespace,
+	}
+}
+
+func (r *ServiceUsageEventRepo) GetServiceUsageEvent(ctx context.Context, authInfo authorization.Info, guid string) (ServiceUsageEventRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return ServiceUsageEventRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	event := &korifiv1alpha1.CFServiceUsageEvent{}
+	err = userClient.Get(ctx, client.ObjectKey{Namespace: r.rootNamespace, Name: guid}, event)
+	if err != nil {
+		return ServiceUsageEventRecord{}, apierrors.FromK8sError(err, ServiceUsageEventResourceType)
+	}
+
+	return serviceUsageEventToRecord(*event), nil
+}
+
+func (r *ServiceUsageEventRepo) ListServiceUsageEvents(ctx context.Context, authInfo authorization.Info, message ListServiceUsageEventsMessage) (ListResult[ServiceUsageEventRecord], error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return ListResult[ServiceUsageEventRecord]{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	events := &korifiv1alpha1.CFServiceUsageEventList{}
+	err = userClient.List(ctx, events, client.InNamespace(r.rootNamespace))
+	if err != nil {
+		if k8serrors.IsForbidden(err) {
+			return paginate([]ServiceUsageEventRecord{}, message.Pagination), nil
+		}
+		return ListResult[ServiceUsageEventRecord]{}, apierrors.FromK8sError(err, ServiceUsageEventResourceType)
+	}
+
+	items, err := usageEventsAfter(events.Items, message.AfterGUID, ServiceUsageEventResourceType)
+	if err != nil {
+		return ListResult[ServiceUsageEventRecord]{}, err
+	}
+
+	items, err = recordedUsageEvents(ctx, userClient, r.rootNamespace, usage.ServiceEventsSequenceKey, items)
+	if err != nil {
+		return ListResult[ServiceUsageEventRecord]{}, err
+	}
+
+	records := slices.Collect(it.Map(
+		itx.FromSlice(items).Filter(message.matches),
+		serviceUsageEventToRecord,
+	))
+
+	return paginate(records, message.Pagination), nil
+}
+
+// PurgeAndReseed deletes all service usage events and records a CREATED event
+// for every service instance that is currently billed
+func (r *ServiceUsageEventRepo) PurgeAndReseed(ctx context.Context, authInfo authorization.Info) error {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	err = userClient.DeleteAllOf(ctx, &korifiv1alpha1.CFServiceUsageEvent{}, client.InNamespace(r.rootNamespace))
+	if err != nil {
+		return apierrors.FromK8sError(err, ServiceUsageEventResourceType)
+	}
+
+	spaces, err := listUsageEventSpaces(ctx, userClient)
+	if err != nil {
+		return err
+	}
+
+	serviceInstanceList := &korifiv1alpha1.CFServiceInstanceList{}
+	err = userClient.List(ctx, serviceInstanceList)
+	if err != nil {
+		return apierrors.FromK8sError(err, ServiceInstanceResourceType)
+	}
+
+	for _, serviceInstance := range serviceInstanceList.Items {
+		if serviceInstance.Status.Usage == nil {
+			continue
+		}
+
+		space := spaces[serviceInstance.Namespace]
+		event := &korifiv1alpha1.CFServiceUsageEvent{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: r.rootNamespace,
+				Name:      usage.ServiceUsageEventName(serviceInstance.Name, serviceInstance.Generation, korifiv1alpha1.ServiceUsageEventCreated),
+			},
+			Spec: korifiv1alpha1.CFServiceUsageEventSpec{
+				State:               korifiv1alpha1.ServiceUsageEventCreated,
+				ServiceInstanceGUID: serviceInstance.Name,
+				ServiceInstanceName: serviceInstance.Spec.DisplayName,
+				ServiceInstanceType: serviceInstance.Spec.Type,
+				SpaceGUID:           serviceInstance.Namespace,
+				SpaceName:           space.Spec.DisplayName,
+				OrgGUID:             space.Namespace,
+			},
+		}
+
+		if serviceInstance.Spec.Type == korifiv1alpha1.ManagedType {
+			err = r.setServicePlanDetails(ctx, userClient, serviceInstance, &event.Spec)
+			if err != nil {
+				return err
+			}
+		}
+
+		event.Spec.Sequence, err = usage.NextSequence(ctx, userClient, userClient, r.rootNamespace, usage.ServiceEventsSequenceKey)
+		if err != nil {
+			return apierrors.FromK8sError(err, ServiceUsageEventResourceType)
+		}
+
+		err = userClient.Create(ctx, event)
+
+		releaseErr := usage.ReleaseSequence(ctx, userClient, userClient, r.rootNamespace, usage.ServiceEventsSequenceKey, event.Spec.Sequence)
+		if err != nil {
+			return apierrors.FromK8sError(err, ServiceUsageEventResourceType)
+		}
+		if releaseErr != nil {
+			return apierrors.FromK8sError(releaseErr, ServiceUsageEventResourceType)
+		}
+	}
+
+	return nil
+}
+
+func (r *ServiceUsageEventRepo) setServicePlanDetails(ctx context.Context, userClient client.Client, serviceInstance korifiv1alpha1.CFServiceInstance, eventSpec *korifiv1alpha1.CFServiceUsageEventSpec) error {
+	servicePlan := &korifiv1alpha1.CFServicePlan{}
+	err := userClient.Get(ctx, client.ObjectKey{Namespace: r.rootNamespace, Name: serviceInstance.Spec.PlanGUID}, servicePlan)
+	if k8serrors.IsNotFound(err) {
+		err = userClient.Get(ctx, client.ObjectKey{Namespace: serviceInstance.Namespace, Name: serviceInstance.Spec.PlanGUID}, servicePlan)
+	}
+	if err != nil {
+		return apierrors.FromK8sError(err, ServicePlanResourceType)
+	}
+	eventSpec.ServicePlanGUID = servicePlan.Name
+	eventSpec.ServicePlanName = servicePlan.Spec.Name
+
+	serviceOffering := &korifiv1alpha1.CFServiceOffering{}
+	err = userClient.Get(ctx, client.ObjectKey{Namespace: servicePlan.Namespace, Name: servicePlan.Labels[korifiv1alpha1.RelServiceOfferingGUIDLabel]}, serviceOffering)
+	if err != nil {
+		return apierrors.FromK8sError(err, ServiceOfferingResourceType)
+	}
+	eventSpec.ServiceOfferingGUID = serviceOffering.Name
+	eventSpec.ServiceOfferingName = serviceOffering.Spec.Name
+
+	serviceBroker := &korifiv1alpha1.CFServiceBroker{}
+	err = userClient.Get(ctx, client.ObjectKey{Namespace: servicePlan.Namespace, Name: servicePlan.Labels[korifiv1alpha1.RelServiceBrokerGUIDLabel]}, serviceBroker)
+	if err != nil {
+		return apierrors.FromK8sError(err, ServiceBrokerResourceType)
+	}
+	eventSpec.ServiceBrokerGUID = serviceBroker.Name
+	eventSpec.ServiceBrokerName = serviceBroker.Spec.Name
+
+	return nil
+}
+
+func serviceUsageEventToRecord(event korifiv1alpha1.CFServiceUsageEvent) ServiceUsageEventRecord {
+	return ServiceUsageEventRecord{
+		GUID:                event.Name,
+		State:               event.Spec.State,
+		ServiceInstanceGUID: event.Spec.ServiceInstanceGUID,
+		ServiceInstanceName: event.Spec.ServiceInstanceName,
+		ServiceInstanceType: string(event.Spec.ServiceInstanceType),
+		ServicePlanGUID:     event.Spec.ServicePlanGUID,
+		ServicePlanName:     event.Spec.ServicePlanName,
+		ServiceOfferingGUID: event.Spec.ServiceOfferingGUID,
+		ServiceOfferingName: event.Spec.ServiceOfferingName,
+		ServiceBrokerGUID:   event.Spec.ServiceBrokerGUID,
+		ServiceBrokerName:   event.Spec.ServiceBrokerName,
+		SpaceGUID:           event.Spec.SpaceGUID,
+		SpaceName:           event.Spec.SpaceName,
+		OrgGUID:             event.Spec.OrgGUID,
+		CreatedAt:           event.CreationTimestamp.Time,
+		UpdatedAt:           getLastUpdatedTime(&event),
+	}
+}
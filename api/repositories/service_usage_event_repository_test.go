@@ -0,0 +1,217 @@
+package repositories_test
+
+import (
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	. "code.cloudfoundry.org/korifi/api/repositories"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tests/matchers"
+	"code.cloudfoundry.org/korifi/tools/k8s"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("ServiceUsageEventRepository", func() {
+	var (
+		serviceUsageEventRepo *ServiceUsageEventRepo
+		managedEventGUID      string
+		upsiEventGUID         string
+	)
+
+	createServiceUsageEvent := func(guid string, instanceType korifiv1alpha1.InstanceType, sequence int64) {
+		Expect(k8sClient.Create(ctx, &korifiv1alpha1.CFServiceUsageEvent{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      guid,
+				Namespace: rootNamespace,
+			},
+			Spec: korifiv1alpha1.CFServiceUsageEventSpec{
+				Sequence:            sequence,
+				State:               korifiv1alpha1.ServiceUsageEventCreated,
+				ServiceInstanceGUID: "instance-guid",
+				ServiceInstanceName: "instance-name",
+				ServiceInstanceType: instanceType,
+				SpaceGUID:           "space-guid",
+			},
+		})).To(Succeed())
+	}
+
+	BeforeEach(func() {
+		serviceUsageEventRepo = NewServiceUsageEventRepo(userClientFactory, rootNamespace)
+
+		managedEventGUID = uuid.NewString()
+		upsiEventGUID = uuid.NewString()
+		createServiceUsageEvent(managedEventGUID, korifiv1alpha1.ManagedType, 1)
+		createServiceUsageEvent(upsiEventGUID, korifiv1alpha1.UserProvidedType, 2)
+	})
+
+	Describe("GetServiceUsageEvent", func() {
+		var (
+			record ServiceUsageEventRecord
+			getErr error
+		)
+
+		JustBeforeEach(func() {
+			record, getErr = serviceUsageEventRepo.GetServiceUsageEvent(ctx, authInfo, managedEventGUID)
+		})
+
+		It("returns a forbidden error", func() {
+			Expect(getErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is an admin", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+			})
+
+			It("returns the event", func() {
+				Expect(getErr).NotTo(HaveOccurred())
+				Expect(record).To(MatchFields(IgnoreExtras, Fields{
+					"GUID":                Equal(managedEventGUID),
+					"State":               Equal("CREATED"),
+					"ServiceInstanceGUID": Equal("instance-guid"),
+					"ServiceInstanceName": Equal("instance-name"),
+					"ServiceInstanceType": Equal("managed"),
+					"SpaceGUID":           Equal("space-guid"),
+				}))
+			})
+		})
+	})
+
+	Describe("ListServiceUsageEvents", func() {
+		var (
+			message ListServiceUsageEventsMessage
+			records []ServiceUsageEventRecord
+			listErr error
+		)
+
+		BeforeEach(func() {
+			message = ListServiceUsageEventsMessage{}
+		})
+
+		JustBeforeEach(func() {
+			var result ListResult[ServiceUsageEventRecord]
+			result, listErr = serviceUsageEventRepo.ListServiceUsageEvents(ctx, authInfo, message)
+			records = result.Records
+		})
+
+		It("returns an empty list", func() {
+			Expect(listErr).NotTo(HaveOccurred())
+			Expect(records).To(BeEmpty())
+		})
+
+		When("the user is an admin", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+			})
+
+			It("returns the events in the order they were recorded", func() {
+				Expect(listErr).NotTo(HaveOccurred())
+				Expect(records).To(HaveLen(2))
+				Expect(records[0].GUID).To(Equal(managedEventGUID))
+				Expect(records[1].GUID).To(Equal(upsiEventGUID))
+			})
+
+			When("filtering by service instance type", func() {
+				BeforeEach(func() {
+					message.ServiceInstanceTypes = []string{korifiv1alpha1.UserProvidedType}
+				})
+
+				It("returns the matching events", func() {
+					Expect(listErr).NotTo(HaveOccurred())
+					Expect(records).To(ConsistOf(MatchFields(IgnoreExtras, Fields{"GUID": Equal(upsiEventGUID)})))
+				})
+			})
+
+			When("filtering by after_guid", func() {
+				BeforeEach(func() {
+					message.AfterGUID = managedEventGUID
+				})
+
+				It("returns the events recorded after it", func() {
+					Expect(listErr).NotTo(HaveOccurred())
+					Expect(records).To(ConsistOf(MatchFields(IgnoreExtras, Fields{"GUID": Equal(upsiEventGUID)})))
+				})
+			})
+		})
+	})
+
+	Describe("PurgeAndReseed", func() {
+		var (
+			cfSpace         *korifiv1alpha1.CFSpace
+			serviceInstance *korifiv1alpha1.CFServiceInstance
+			purgeErr        error
+		)
+
+		BeforeEach(func() {
+			cfOrg := createOrgWithCleanup(ctx, uuid.NewString())
+			cfSpace = createSpaceWithCleanup(ctx, cfOrg.Name, "my-space")
+
+			serviceInstance = &korifiv1alpha1.CFServiceInstance{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      uuid.NewString(),
+					Namespace: cfSpace.Name,
+				},
+				Spec: korifiv1alpha1.CFServiceInstanceSpec{
+					DisplayName: "my-instance",
+					Type:        korifiv1alpha1.UserProvidedType,
+				},
+			}
+			Expect(k8sClient.Create(ctx, serviceInstance)).To(Succeed())
+			Expect(k8s.Patch(ctx, k8sClient, serviceInstance, func() {
+				serviceInstance.Status.Usage = &korifiv1alpha1.ServiceInstanceUsage{Name: "my-instance"}
+			})).To(Succeed())
+
+			Expect(k8sClient.Create(ctx, &korifiv1alpha1.CFServiceInstance{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      uuid.NewString(),
+					Namespace: cfSpace.Name,
+				},
+				Spec: korifiv1alpha1.CFServiceInstanceSpec{
+					DisplayName: "unbilled-instance",
+					Type:        korifiv1alpha1.UserProvidedType,
+				},
+			})).To(Succeed())
+		})
+
+		JustBeforeEach(func() {
+			purgeErr = serviceUsageEventRepo.PurgeAndReseed(ctx, authInfo)
+		})
+
+		It("returns a forbidden error", func() {
+			Expect(purgeErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is an admin", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+				createRoleBinding(ctx, userName, adminRole.Name, cfSpace.Namespace)
+				createRoleBinding(ctx, userName, adminRole.Name, cfSpace.Name)
+			})
+
+			It("replaces the existing events with events for the billed service instances", func() {
+				Expect(purgeErr).NotTo(HaveOccurred())
+
+				events := &korifiv1alpha1.CFServiceUsageEventList{}
+				Expect(k8sClient.List(ctx, events, client.InNamespace(rootNamespace))).To(Succeed())
+				Expect(events.Items).To(HaveLen(1))
+				Expect(events.Items[0].Spec.Sequence).To(BeNumerically(">", 0))
+				Expect(events.Items).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+					"Spec": Equal(korifiv1alpha1.CFServiceUsageEventSpec{
+						Sequence:            events.Items[0].Spec.Sequence,
+						State:               korifiv1alpha1.ServiceUsageEventCreated,
+						ServiceInstanceGUID: serviceInstance.Name,
+						ServiceInstanceName: "my-instance",
+						ServiceInstanceType: korifiv1alpha1.UserProvidedType,
+						SpaceGUID:           cfSpace.Name,
+						SpaceName:           "my-space",
+						OrgGUID:             cfSpace.Namespace,
+					}),
+				})))
+			})
+		})
+	})
+})
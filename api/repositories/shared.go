@@ -7,6 +7,8 @@ import (
 	"time"
 
 	"code.cloudfoundry.org/korifi/api/authorization"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/model"
 	"github.com/BooleanCat/go-functional/v2/it/itx"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -64,6 +66,35 @@ func getConditionValue(conditions *[]metav1.Condition, conditionType string) met
 	return conditionStatusValue
 }
 
+// trueConditionMessages returns the messages of the conditions of the given
+// types that are true, e.g. the errors of a failed operation
+func trueConditionMessages(conditions []metav1.Condition, conditionTypes ...string) []string {
+	messages := []string{}
+	for _, conditionType := range conditionTypes {
+		if condition := meta.FindStatusCondition(conditions, conditionType); condition != nil && condition.Status == metav1.ConditionTrue {
+			messages = append(messages, condition.Message)
+		}
+	}
+	return messages
+}
+
+// namespaceOperation reports the last operation on an org or a space, as
+// recorded in its ready condition. The operation has failed when the
+// controller could not reconcile the current generation, e.g. when it could
+// not delete the namespace of a deleted org or space.
+func namespaceOperation(obj client.Object, conditions []metav1.Condition) model.CFResourceOperation {
+	readyCondition := meta.FindStatusCondition(conditions, korifiv1alpha1.StatusConditionReady)
+	if readyCondition == nil || readyCondition.ObservedGeneration != obj.GetGeneration() {
+		return model.CFResourceOperation{State: model.CFResourceStateUnknown}
+	}
+
+	if readyCondition.Status == metav1.ConditionTrue {
+		return model.CFResourceOperation{State: model.CFResourceStateReady}
+	}
+
+	return model.CFResourceOperation{State: model.CFResourceStateFailed, Errors: []string{readyCondition.Message}}
+}
+
 func getLabelOrAnnotation(mapObj map[string]string, key string) string {
 	if mapObj == nil {
 		return ""
@@ -9,12 +9,14 @@ import (
 	"code.cloudfoundry.org/korifi/api/authorization"
 	apierrors "code.cloudfoundry.org/korifi/api/errors"
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/model"
 	"code.cloudfoundry.org/korifi/tools"
 	"code.cloudfoundry.org/korifi/tools/k8s"
 
 	"github.com/BooleanCat/go-functional/v2/it"
 	"github.com/BooleanCat/go-functional/v2/it/itx"
 	"github.com/google/uuid"
+	authv1 "k8s.io/api/authorization/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -31,15 +33,17 @@ type CreateSpaceMessage struct {
 }
 
 type ListSpacesMessage struct {
-	Names             []string
-	GUIDs             []string
-	OrganizationGUIDs []string
+	Names                 []string
+	GUIDs                 []string
+	OrganizationGUIDs     []string
+	IsolationSegmentGUIDs []string
 }
 
 func (m *ListSpacesMessage) matches(space korifiv1alpha1.CFSpace) bool {
 	return meta.IsStatusConditionTrue(space.Status.Conditions, korifiv1alpha1.StatusConditionReady) &&
 		tools.EmptyOrContains(m.GUIDs, space.Name) &&
-		tools.EmptyOrContains(m.Names, space.Spec.DisplayName)
+		tools.EmptyOrContains(m.Names, space.Spec.DisplayName) &&
+		tools.EmptyOrContains(m.IsolationSegmentGUIDs, space.Spec.IsolationSegmentGUID)
 }
 
 func (m *ListSpacesMessage) matchesNamespace(ns string) bool {
@@ -57,15 +61,22 @@ type PatchSpaceMetadataMessage struct {
 	OrgGUID string
 }
 
+type AssignSpaceIsolationSegmentMessage struct {
+	GUID                 string
+	OrgGUID              string
+	IsolationSegmentGUID string
+}
+
 type SpaceRecord struct {
-	Name             string
-	GUID             string
-	OrganizationGUID string
-	Labels           map[string]string
-	Annotations      map[string]string
-	CreatedAt        time.Time
-	UpdatedAt        *time.Time
-	DeletedAt        *time.Time
+	Name                 string
+	GUID                 string
+	OrganizationGUID     string
+	IsolationSegmentGUID string
+	Labels               map[string]string
+	Annotations          map[string]string
+	CreatedAt            time.Time
+	UpdatedAt            *time.Time
+	DeletedAt            *time.Time
 }
 
 func (r SpaceRecord) Relationships() map[string]string {
@@ -77,6 +88,7 @@ func (r SpaceRecord) Relationships() map[string]string {
 type SpaceRepo struct {
 	orgRepo            *OrgRepo
 	namespaceRetriever NamespaceRetriever
+	privilegedClient   client.Client
 	userClientFactory  authorization.UserClientFactory
 	nsPerms            *authorization.NamespacePermissions
 	conditionAwaiter   Awaiter[*korifiv1alpha1.CFSpace]
@@ -85,6 +97,7 @@ type SpaceRepo struct {
 func NewSpaceRepo(
 	namespaceRetriever NamespaceRetriever,
 	orgRepo *OrgRepo,
+	privilegedClient client.Client,
 	userClientFactory authorization.UserClientFactory,
 	nsPerms *authorization.NamespacePermissions,
 	conditionAwaiter Awaiter[*korifiv1alpha1.CFSpace],
@@ -92,6 +105,7 @@ func NewSpaceRepo(
 	return &SpaceRepo{
 		orgRepo:            orgRepo,
 		namespaceRetriever: namespaceRetriever,
+		privilegedClient:   privilegedClient,
 		userClientFactory:  userClientFactory,
 		nsPerms:            nsPerms,
 		conditionAwaiter:   conditionAwaiter,
@@ -191,14 +205,15 @@ func (r *SpaceRepo) GetSpace(ctx context.Context, info authorization.Info, space
 
 func cfSpaceToSpaceRecord(cfSpace korifiv1alpha1.CFSpace) SpaceRecord {
 	return SpaceRecord{
-		Name:             cfSpace.Spec.DisplayName,
-		GUID:             cfSpace.Name,
-		OrganizationGUID: cfSpace.Namespace,
-		Annotations:      cfSpace.Annotations,
-		Labels:           cfSpace.Labels,
-		CreatedAt:        cfSpace.CreationTimestamp.Time,
-		UpdatedAt:        getLastUpdatedTime(&cfSpace),
-		DeletedAt:        golangTime(cfSpace.DeletionTimestamp),
+		Name:                 cfSpace.Spec.DisplayName,
+		GUID:                 cfSpace.Name,
+		OrganizationGUID:     cfSpace.Namespace,
+		IsolationSegmentGUID: cfSpace.Spec.IsolationSegmentGUID,
+		Annotations:          cfSpace.Annotations,
+		Labels:               cfSpace.Labels,
+		CreatedAt:            cfSpace.CreationTimestamp.Time,
+		UpdatedAt:            getLastUpdatedTime(&cfSpace),
+		DeletedAt:            golangTime(cfSpace.DeletionTimestamp),
 	}
 }
 
@@ -240,6 +255,70 @@ func (r *SpaceRepo) PatchSpaceMetadata(ctx context.Context, authInfo authorizati
 	return cfSpaceToSpaceRecord(*cfSpace), nil
 }
 
+// AssignIsolationSegment lets org managers assign isolation segments to the
+// spaces of their orgs. They are not allowed to patch spaces, so the space is
+// patched with the privileged client once the requester is known to be able
+// to manage the spaces of the org.
+func (r *SpaceRepo) AssignIsolationSegment(ctx context.Context, authInfo authorization.Info, message AssignSpaceIsolationSegmentMessage) (SpaceRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return SpaceRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfSpace := new(korifiv1alpha1.CFSpace)
+	err = userClient.Get(ctx, client.ObjectKey{Namespace: message.OrgGUID, Name: message.GUID}, cfSpace)
+	if err != nil {
+		return SpaceRecord{}, fmt.Errorf("failed to get space: %w", apierrors.FromK8sError(err, SpaceResourceType))
+	}
+
+	review := authv1.SelfSubjectAccessReview{
+		Spec: authv1.SelfSubjectAccessReviewSpec{
+			ResourceAttributes: &authv1.ResourceAttributes{
+				Namespace: message.OrgGUID,
+				Verb:      "create",
+				Group:     "korifi.cloudfoundry.org",
+				Resource:  "cfspaces",
+			},
+		},
+	}
+	if err = userClient.Create(ctx, &review); err != nil {
+		return SpaceRecord{}, fmt.Errorf("failed to create self subject access review: %w", apierrors.FromK8sError(err, SpaceResourceType))
+	}
+
+	if !review.Status.Allowed {
+		return SpaceRecord{}, apierrors.NewForbiddenError(nil, SpaceResourceType)
+	}
+
+	err = k8s.PatchResource(ctx, r.privilegedClient, cfSpace, func() {
+		cfSpace.Spec.IsolationSegmentGUID = message.IsolationSegmentGUID
+	})
+	if err != nil {
+		return SpaceRecord{}, apierrors.FromK8sError(err, SpaceResourceType)
+	}
+
+	return cfSpaceToSpaceRecord(*cfSpace), nil
+}
+
+func (r *SpaceRepo) GetOperation(ctx context.Context, authInfo authorization.Info, spaceGUID string) (model.CFResourceOperation, error) {
+	ns, err := r.namespaceRetriever.NamespaceFor(ctx, spaceGUID, SpaceResourceType)
+	if err != nil {
+		return model.CFResourceOperation{State: model.CFResourceStateUnknown}, err
+	}
+
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return model.CFResourceOperation{State: model.CFResourceStateUnknown}, fmt.Errorf("get-operation failed to build user client: %w", err)
+	}
+
+	cfSpace := new(korifiv1alpha1.CFSpace)
+	err = userClient.Get(ctx, client.ObjectKey{Namespace: ns, Name: spaceGUID}, cfSpace)
+	if err != nil {
+		return model.CFResourceOperation{State: model.CFResourceStateUnknown}, apierrors.FromK8sError(err, SpaceResourceType)
+	}
+
+	return namespaceOperation(cfSpace, cfSpace.Status.Conditions), nil
+}
+
 func (r *SpaceRepo) GetDeletedAt(ctx context.Context, authInfo authorization.Info, spaceGUID string) (*time.Time, error) {
 	space, err := r.GetSpace(ctx, authInfo, spaceGUID)
 	if err != nil {
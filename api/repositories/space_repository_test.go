@@ -9,6 +9,7 @@ import (
 	"code.cloudfoundry.org/korifi/api/repositories"
 	"code.cloudfoundry.org/korifi/api/repositories/fakeawaiter"
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/model"
 	"code.cloudfoundry.org/korifi/tests/matchers"
 	"code.cloudfoundry.org/korifi/tools"
 	"code.cloudfoundry.org/korifi/tools/k8s"
@@ -40,7 +41,7 @@ var _ = Describe("SpaceRepository", func() {
 			korifiv1alpha1.CFOrg,
 			korifiv1alpha1.CFOrgList,
 			*korifiv1alpha1.CFOrgList,
-		]{})
+		]{}, nil)
 
 		conditionAwaiter = &fakeawaiter.FakeAwaiter[
 			*korifiv1alpha1.CFSpace,
@@ -48,7 +49,7 @@ var _ = Describe("SpaceRepository", func() {
 			korifiv1alpha1.CFSpaceList,
 			*korifiv1alpha1.CFSpaceList,
 		]{}
-		spaceRepo = repositories.NewSpaceRepo(namespaceRetriever, orgRepo, userClientFactory, nsPerms, conditionAwaiter)
+		spaceRepo = repositories.NewSpaceRepo(namespaceRetriever, orgRepo, k8sClient, userClientFactory, nsPerms, conditionAwaiter)
 	})
 
 	Describe("CreateSpace", func() {
@@ -690,6 +691,56 @@ var _ = Describe("SpaceRepository", func() {
 		})
 	})
 
+	Describe("AssignIsolationSegment", func() {
+		var (
+			cfOrg       *korifiv1alpha1.CFOrg
+			cfSpace     *korifiv1alpha1.CFSpace
+			spaceRecord repositories.SpaceRecord
+			assignErr   error
+		)
+
+		BeforeEach(func() {
+			cfOrg = createOrgWithCleanup(ctx, prefixedGUID("org"))
+			cfSpace = createSpaceWithCleanup(ctx, cfOrg.Name, "the-space")
+		})
+
+		JustBeforeEach(func() {
+			spaceRecord, assignErr = spaceRepo.AssignIsolationSegment(ctx, authInfo, repositories.AssignSpaceIsolationSegmentMessage{
+				GUID:                 cfSpace.Name,
+				OrgGUID:              cfOrg.Name,
+				IsolationSegmentGUID: "the-iso-seg",
+			})
+		})
+
+		When("the user is an org manager", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, orgManagerRole.Name, cfOrg.Name)
+			})
+
+			It("assigns the isolation segment to the space", func() {
+				Expect(assignErr).NotTo(HaveOccurred())
+				Expect(spaceRecord.IsolationSegmentGUID).To(Equal("the-iso-seg"))
+
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfSpace), cfSpace)).To(Succeed())
+				Expect(cfSpace.Spec.IsolationSegmentGUID).To(Equal("the-iso-seg"))
+			})
+		})
+
+		When("the user can only see the space", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, orgUserRole.Name, cfOrg.Name)
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, cfSpace.Name)
+			})
+
+			It("returns a forbidden error", func() {
+				Expect(assignErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfSpace), cfSpace)).To(Succeed())
+				Expect(cfSpace.Spec.IsolationSegmentGUID).To(BeEmpty())
+			})
+		})
+	})
+
 	Describe("GetDeletedAt", func() {
 		var (
 			cfSpace      *korifiv1alpha1.CFSpace
@@ -737,4 +788,86 @@ var _ = Describe("SpaceRepository", func() {
 			})
 		})
 	})
+
+	Describe("GetOperation", func() {
+		var (
+			cfSpace   *korifiv1alpha1.CFSpace
+			operation model.CFResourceOperation
+			getErr    error
+		)
+
+		BeforeEach(func() {
+			cfOrg := createOrgWithCleanup(ctx, "the-org")
+			createRoleBinding(ctx, userName, orgUserRole.Name, cfOrg.Name)
+			cfSpace = createSpaceWithCleanup(ctx, cfOrg.Name, "the-space")
+		})
+
+		JustBeforeEach(func() {
+			operation, getErr = spaceRepo.GetOperation(ctx, authInfo, cfSpace.Name)
+		})
+
+		It("returns unknown state", func() {
+			Expect(getErr).NotTo(HaveOccurred())
+			Expect(operation.State).To(Equal(model.CFResourceStateUnknown))
+		})
+
+		When("the space is being deleted", func() {
+			BeforeEach(func() {
+				Expect(k8s.PatchResource(ctx, k8sClient, cfSpace, func() {
+					cfSpace.Finalizers = append(cfSpace.Finalizers, "foo")
+				})).To(Succeed())
+				Expect(k8sClient.Delete(ctx, cfSpace)).To(Succeed())
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfSpace), cfSpace)).To(Succeed())
+			})
+
+			When("deleting the namespace has failed", func() {
+				BeforeEach(func() {
+					Expect(k8s.Patch(ctx, k8sClient, cfSpace, func() {
+						meta.SetStatusCondition(&cfSpace.Status.Conditions, metav1.Condition{
+							Type:               korifiv1alpha1.StatusConditionReady,
+							Status:             metav1.ConditionFalse,
+							ObservedGeneration: cfSpace.Generation,
+							Reason:             "Unknown",
+							Message:            "failed to delete namespace",
+						})
+					})).To(Succeed())
+				})
+
+				It("returns failed state with the not ready message as an error", func() {
+					Expect(getErr).NotTo(HaveOccurred())
+					Expect(operation.State).To(Equal(model.CFResourceStateFailed))
+					Expect(operation.Errors).To(ConsistOf("failed to delete namespace"))
+				})
+			})
+
+			When("the ready condition is stale", func() {
+				BeforeEach(func() {
+					Expect(k8s.Patch(ctx, k8sClient, cfSpace, func() {
+						meta.SetStatusCondition(&cfSpace.Status.Conditions, metav1.Condition{
+							Type:               korifiv1alpha1.StatusConditionReady,
+							Status:             metav1.ConditionFalse,
+							ObservedGeneration: cfSpace.Generation - 1,
+							Reason:             "Unknown",
+							Message:            "failed to delete namespace",
+						})
+					})).To(Succeed())
+				})
+
+				It("returns unknown state", func() {
+					Expect(getErr).NotTo(HaveOccurred())
+					Expect(operation.State).To(Equal(model.CFResourceStateUnknown))
+				})
+			})
+		})
+
+		When("the space isn't found", func() {
+			BeforeEach(func() {
+				Expect(k8sClient.Delete(ctx, cfSpace)).To(Succeed())
+			})
+
+			It("errors", func() {
+				Expect(getErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
+			})
+		})
+	})
 })
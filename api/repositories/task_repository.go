@@ -33,33 +33,57 @@ const (
 )
 
 type TaskRecord struct {
-	Name          string
-	GUID          string
-	SpaceGUID     string
-	Command       string
-	AppGUID       string
-	DropletGUID   string
-	Labels        map[string]string
-	Annotations   map[string]string
-	SequenceID    int64
-	CreatedAt     time.Time
-	UpdatedAt     *time.Time
-	MemoryMB      int64
-	DiskMB        int64
-	State         string
-	FailureReason string
+	Name                       string
+	GUID                       string
+	SpaceGUID                  string
+	Command                    string
+	AppGUID                    string
+	DropletGUID                string
+	ScheduleGUID               string
+	Labels                     map[string]string
+	Annotations                map[string]string
+	SequenceID                 int64
+	CreatedAt                  time.Time
+	UpdatedAt                  *time.Time
+	MemoryMB                   int64
+	DiskMB                     int64
+	LogRateLimitBytesPerSecond int64
+	TimeoutSeconds             int64
+	Retry                      *TaskRetryPolicy
+	Attempts                   int32
+	LastFailureReason          string
+	State                      string
+	FailureReason              string
+}
+
+type TaskRetryPolicy struct {
+	MaxAttempts           int32
+	NonRetryableExitCodes []int32
 }
 
 func (t TaskRecord) Relationships() map[string]string {
-	return map[string]string{
+	relationships := map[string]string{
 		"app": t.AppGUID,
 	}
+
+	if t.ScheduleGUID != "" {
+		relationships["task_schedule"] = t.ScheduleGUID
+	}
+
+	return relationships
 }
 
 type CreateTaskMessage struct {
-	Command   string
-	SpaceGUID string
-	AppGUID   string
+	Name                       string
+	Command                    string
+	SpaceGUID                  string
+	AppGUID                    string
+	DropletGUID                string
+	MemoryMB                   int64
+	DiskMB                     int64
+	LogRateLimitBytesPerSecond *int64
+	TimeoutSeconds             int64
+	Retry                      *TaskRetryPolicy
 	Metadata
 }
 
@@ -88,14 +112,34 @@ func (m *CreateTaskMessage) toCFTask() *korifiv1alpha1.CFTask {
 			Annotations: m.Annotations,
 		},
 		Spec: korifiv1alpha1.CFTaskSpec{
-			Command: m.Command,
+			DisplayName: m.Name,
+			Command:     m.Command,
 			AppRef: v1.LocalObjectReference{
 				Name: m.AppGUID,
 			},
+			DropletRef: v1.LocalObjectReference{
+				Name: m.DropletGUID,
+			},
+			MemoryMB:                   m.MemoryMB,
+			DiskQuotaMB:                m.DiskMB,
+			LogRateLimitBytesPerSecond: m.LogRateLimitBytesPerSecond,
+			TimeoutSeconds:             m.TimeoutSeconds,
+			Retry:                      m.toRetryPolicy(),
 		},
 	}
 }
 
+func (m *CreateTaskMessage) toRetryPolicy() *korifiv1alpha1.TaskRetryPolicy {
+	if m.Retry == nil {
+		return nil
+	}
+
+	return &korifiv1alpha1.TaskRetryPolicy{
+		MaxAttempts:           m.Retry.MaxAttempts,
+		NonRetryableExitCodes: m.Retry.NonRetryableExitCodes,
+	}
+}
+
 type TaskRepo struct {
 	userClientFactory    authorization.UserClientFactory
 	namespaceRetriever   NamespaceRetriever
@@ -241,20 +285,43 @@ func (r *TaskRepo) PatchTaskMetadata(ctx context.Context, authInfo authorization
 
 func taskToRecord(task korifiv1alpha1.CFTask) TaskRecord {
 	taskRecord := TaskRecord{
-		Name:        task.Name,
-		GUID:        task.Name,
-		SpaceGUID:   task.Namespace,
-		Command:     task.Spec.Command,
-		AppGUID:     task.Spec.AppRef.Name,
-		SequenceID:  task.Status.SequenceID,
-		CreatedAt:   task.CreationTimestamp.Time,
-		UpdatedAt:   getLastUpdatedTime(&task),
-		MemoryMB:    task.Status.MemoryMB,
-		DiskMB:      task.Status.DiskQuotaMB,
-		DropletGUID: task.Status.DropletRef.Name,
-		State:       toRecordState(&task),
-		Labels:      task.Labels,
-		Annotations: task.Annotations,
+		Name:                       task.Name,
+		GUID:                       task.Name,
+		SpaceGUID:                  task.Namespace,
+		Command:                    task.Spec.Command,
+		AppGUID:                    task.Spec.AppRef.Name,
+		SequenceID:                 task.Status.SequenceID,
+		CreatedAt:                  task.CreationTimestamp.Time,
+		UpdatedAt:                  getLastUpdatedTime(&task),
+		MemoryMB:                   task.Status.MemoryMB,
+		DiskMB:                     task.Status.DiskQuotaMB,
+		LogRateLimitBytesPerSecond: -1,
+		TimeoutSeconds:             task.Spec.TimeoutSeconds,
+		DropletGUID:                task.Status.DropletRef.Name,
+		ScheduleGUID:               task.Labels[korifiv1alpha1.CFTaskScheduleGUIDLabelKey],
+		Attempts:                   task.Status.Attempts,
+		State:                      toRecordState(&task),
+		Labels:                     task.Labels,
+		Annotations:                task.Annotations,
+	}
+
+	if task.Spec.DisplayName != "" {
+		taskRecord.Name = task.Spec.DisplayName
+	}
+
+	if task.Spec.LogRateLimitBytesPerSecond != nil {
+		taskRecord.LogRateLimitBytesPerSecond = *task.Spec.LogRateLimitBytesPerSecond
+	}
+
+	if task.Spec.Retry != nil {
+		taskRecord.Retry = &TaskRetryPolicy{
+			MaxAttempts:           task.Spec.Retry.MaxAttempts,
+			NonRetryableExitCodes: task.Spec.Retry.NonRetryableExitCodes,
+		}
+	}
+
+	if len(task.Status.FailedAttempts) > 0 {
+		taskRecord.LastFailureReason = task.Status.FailedAttempts[len(task.Status.FailedAttempts)-1].Message
 	}
 
 	failedCond := meta.FindStatusCondition(task.Status.Conditions, korifiv1alpha1.TaskFailedConditionType)
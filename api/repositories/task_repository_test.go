@@ -143,6 +143,52 @@ var _ = Describe("TaskRepository", func() {
 				Expect(taskRecord.Annotations).To(Equal(map[string]string{"extra-bugs": "true"}))
 			})
 
+			It("does not set a log rate limit or a timeout", func() {
+				Expect(createErr).NotTo(HaveOccurred())
+				Expect(taskRecord.LogRateLimitBytesPerSecond).To(BeEquivalentTo(-1))
+				Expect(taskRecord.TimeoutSeconds).To(BeZero())
+			})
+
+			When("the message specifies the task details", func() {
+				BeforeEach(func() {
+					createMessage.Name = "migrate"
+					createMessage.DropletGUID = "my-droplet"
+					createMessage.MemoryMB = 2048
+					createMessage.DiskMB = 1024
+					createMessage.LogRateLimitBytesPerSecond = tools.PtrTo(int64(1024))
+					createMessage.TimeoutSeconds = 600
+					createMessage.Retry = &repositories.TaskRetryPolicy{
+						MaxAttempts:           3,
+						NonRetryableExitCodes: []int32{2},
+					}
+				})
+
+				It("creates the task with the details", func() {
+					Expect(createErr).NotTo(HaveOccurred())
+
+					cfTask := &korifiv1alpha1.CFTask{}
+					Expect(k8sClient.Get(ctx, client.ObjectKey{Namespace: space.Name, Name: taskRecord.GUID}, cfTask)).To(Succeed())
+					Expect(cfTask.Spec.DisplayName).To(Equal("migrate"))
+					Expect(cfTask.Spec.DropletRef.Name).To(Equal("my-droplet"))
+					Expect(cfTask.Spec.MemoryMB).To(BeEquivalentTo(2048))
+					Expect(cfTask.Spec.DiskQuotaMB).To(BeEquivalentTo(1024))
+					Expect(cfTask.Spec.LogRateLimitBytesPerSecond).To(gstruct.PointTo(BeEquivalentTo(1024)))
+					Expect(cfTask.Spec.TimeoutSeconds).To(BeEquivalentTo(600))
+					Expect(cfTask.Spec.Retry).To(Equal(&korifiv1alpha1.TaskRetryPolicy{
+						MaxAttempts:           3,
+						NonRetryableExitCodes: []int32{2},
+					}))
+				})
+
+				It("returns a record with the details", func() {
+					Expect(createErr).NotTo(HaveOccurred())
+					Expect(taskRecord.Name).To(Equal("migrate"))
+					Expect(taskRecord.LogRateLimitBytesPerSecond).To(BeEquivalentTo(1024))
+					Expect(taskRecord.TimeoutSeconds).To(BeEquivalentTo(600))
+					Expect(taskRecord.Retry).To(Equal(createMessage.Retry))
+				})
+			})
+
 			When("the task never becomes initialized", func() {
 				BeforeEach(func() {
 					conditionAwaiter.AwaitConditionReturns(&korifiv1alpha1.CFTask{}, errors.New("timed-out-error"))
@@ -309,6 +355,23 @@ var _ = Describe("TaskRepository", func() {
 					})
 				})
 
+				When("the task has been attempted several times", func() {
+					BeforeEach(func() {
+						Expect(k8s.Patch(ctx, k8sClient, cfTask, func() {
+							cfTask.Status.Attempts = 2
+							cfTask.Status.FailedAttempts = []korifiv1alpha1.TaskAttemptFailure{
+								{ExitCode: 1, Reason: "Error", Message: "Failed with exit code: 1", FinishedAt: metav1.Now()},
+							}
+						})).To(Succeed())
+					})
+
+					It("returns the attempts and the last failure reason", func() {
+						Expect(getErr).NotTo(HaveOccurred())
+						Expect(taskRecord.Attempts).To(BeEquivalentTo(2))
+						Expect(taskRecord.LastFailureReason).To(Equal("Failed with exit code: 1"))
+					})
+				})
+
 				When("the task was cancelled", func() {
 					BeforeEach(func() {
 						Expect(k8s.Patch(ctx, k8sClient, cfTask, func() {
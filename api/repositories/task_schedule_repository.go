@@ -0,0 +1,277 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools"
+	"code.cloudfoundry.org/korifi/tools/k8s"
+	"github.com/BooleanCat/go-functional/v2/it"
+	"github.com/BooleanCat/go-functional/v2/it/itx"
+	"github.com/google/uuid"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const TaskScheduleResourceType string = "Task Schedule"
+
+type TaskScheduleRecord struct {
+	Name              string
+	GUID              string
+	SpaceGUID         string
+	AppGUID           string
+	Command           string
+	Schedule          string
+	ConcurrencyPolicy string
+	Suspended         bool
+	MemoryMB          int64
+	DiskMB            int64
+	TimeoutSeconds    int64
+	LastScheduleTime  *time.Time
+	Labels            map[string]string
+	Annotations       map[string]string
+	CreatedAt         time.Time
+	UpdatedAt         *time.Time
+}
+
+func (t TaskScheduleRecord) Relationships() map[string]string {
+	return map[string]string{
+		"app": t.AppGUID,
+	}
+}
+
+type CreateTaskScheduleMessage struct {
+	Name              string
+	Command           string
+	SpaceGUID         string
+	AppGUID           string
+	Schedule          string
+	ConcurrencyPolicy string
+	Suspended         bool
+	MemoryMB          int64
+	DiskMB            int64
+	TimeoutSeconds    int64
+	Metadata
+}
+
+func (m *CreateTaskScheduleMessage) toCFTaskSchedule() *korifiv1alpha1.CFTaskSchedule {
+	labels := map[string]string{}
+	for k, v := range m.Labels {
+		labels[k] = v
+	}
+	labels[korifiv1alpha1.CFAppGUIDLabelKey] = m.AppGUID
+
+	return &korifiv1alpha1.CFTaskSchedule{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        uuid.NewString(),
+			Namespace:   m.SpaceGUID,
+			Labels:      labels,
+			Annotations: m.Annotations,
+		},
+		Spec: korifiv1alpha1.CFTaskScheduleSpec{
+			DisplayName: m.Name,
+			Command:     m.Command,
+			AppRef: v1.LocalObjectReference{
+				Name: m.AppGUID,
+			},
+			Schedule:          m.Schedule,
+			ConcurrencyPolicy: m.ConcurrencyPolicy,
+			Suspend:           m.Suspended,
+			MemoryMB:          m.MemoryMB,
+			DiskQuotaMB:       m.DiskMB,
+			TimeoutSeconds:    m.TimeoutSeconds,
+		},
+	}
+}
+
+type ListTaskScheduleMessage struct {
+	AppGUIDs []string
+}
+
+func (m *ListTaskScheduleMessage) matches(schedule korifiv1alpha1.CFTaskSchedule) bool {
+	return tools.EmptyOrContains(m.AppGUIDs, schedule.Spec.AppRef.Name)
+}
+
+type PatchTaskScheduleMessage struct {
+	GUID              string
+	SpaceGUID         string
+	Name              *string
+	Command           *string
+	Schedule          *string
+	ConcurrencyPolicy *string
+	Suspended         *bool
+	MemoryMB          *int64
+	DiskMB            *int64
+	TimeoutSeconds    *int64
+	MetadataPatch
+}
+
+func (m *PatchTaskScheduleMessage) apply(schedule *korifiv1alpha1.CFTaskSchedule) {
+	if m.Name != nil {
+		schedule.Spec.DisplayName = *m.Name
+	}
+	if m.Command != nil {
+		schedule.Spec.Command = *m.Command
+	}
+	if m.Schedule != nil {
+		schedule.Spec.Schedule = *m.Schedule
+	}
+	if m.ConcurrencyPolicy != nil {
+		schedule.Spec.ConcurrencyPolicy = *m.ConcurrencyPolicy
+	}
+	if m.Suspended != nil {
+		schedule.Spec.Suspend = *m.Suspended
+	}
+	if m.MemoryMB != nil {
+		schedule.Spec.MemoryMB = *m.MemoryMB
+	}
+	if m.DiskMB != nil {
+		schedule.Spec.DiskQuotaMB = *m.DiskMB
+	}
+	if m.TimeoutSeconds != nil {
+		schedule.Spec.TimeoutSeconds = *m.TimeoutSeconds
+	}
+
+	m.MetadataPatch.Apply(schedule)
+}
+
+type DeleteTaskScheduleMessage struct {
+	GUID      string
+	SpaceGUID string
+}
+
+type TaskScheduleRepo struct {
+	userClientFactory  authorization.UserClientFactory
+	namespaceRetriever NamespaceRetriever
+}
+
+func NewTaskScheduleRepo(
+	userClientFactory authorization.UserClientFactory,
+	nsRetriever NamespaceRetriever,
+) *TaskScheduleRepo {
+	return &TaskScheduleRepo{
+		userClientFactory:  userClientFactory,
+		namespaceRetriever: nsRetriever,
+	}
+}
+
+func (r *TaskScheduleRepo) CreateTaskSchedule(ctx context.Context, authInfo authorization.Info, message CreateTaskScheduleMessage) (TaskScheduleRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return TaskScheduleRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	schedule := message.toCFTaskSchedule()
+	err = userClient.Create(ctx, schedule)
+	if err != nil {
+		return TaskScheduleRecord{}, apierrors.FromK8sError(err, TaskScheduleResourceType)
+	}
+
+	return taskScheduleToRecord(*schedule), nil
+}
+
+func (r *TaskScheduleRepo) GetTaskSchedule(ctx context.Context, authInfo authorization.Info, guid string) (TaskScheduleRecord, error) {
+	namespace, err := r.namespaceRetriever.NamespaceFor(ctx, guid, TaskScheduleResourceType)
+	if err != nil {
+		return TaskScheduleRecord{}, err
+	}
+
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return TaskScheduleRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	schedule := &korifiv1alpha1.CFTaskSchedule{}
+	err = userClient.Get(ctx, client.ObjectKey{Namespace: namespace, Name: guid}, schedule)
+	if err != nil {
+		return TaskScheduleRecord{}, apierrors.FromK8sError(err, TaskScheduleResourceType)
+	}
+
+	return taskScheduleToRecord(*schedule), nil
+}
+
+func (r *TaskScheduleRepo) ListTaskSchedules(ctx context.Context, authInfo authorization.Info, message ListTaskScheduleMessage) ([]TaskScheduleRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	scheduleList := &korifiv1alpha1.CFTaskScheduleList{}
+	err = userClient.List(ctx, scheduleList)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list task schedules: %w", apierrors.FromK8sError(err, TaskScheduleResourceType))
+	}
+
+	filteredSchedules := itx.FromSlice(scheduleList.Items).Filter(message.matches)
+	return slices.Collect(it.Map(filteredSchedules, taskScheduleToRecord)), nil
+}
+
+func (r *TaskScheduleRepo) PatchTaskSchedule(ctx context.Context, authInfo authorization.Info, message PatchTaskScheduleMessage) (TaskScheduleRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return TaskScheduleRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	schedule := &korifiv1alpha1.CFTaskSchedule{}
+	err = userClient.Get(ctx, client.ObjectKey{Namespace: message.SpaceGUID, Name: message.GUID}, schedule)
+	if err != nil {
+		return TaskScheduleRecord{}, fmt.Errorf("failed to get task schedule: %w", apierrors.FromK8sError(err, TaskScheduleResourceType))
+	}
+
+	err = k8s.PatchResource(ctx, userClient, schedule, func() {
+		message.apply(schedule)
+	})
+	if err != nil {
+		return TaskScheduleRecord{}, apierrors.FromK8sError(err, TaskScheduleResourceType)
+	}
+
+	return taskScheduleToRecord(*schedule), nil
+}
+
+func (r *TaskScheduleRepo) DeleteTaskSchedule(ctx context.Context, authInfo authorization.Info, message DeleteTaskScheduleMessage) error {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	err = userClient.Delete(ctx, &korifiv1alpha1.CFTaskSchedule{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      message.GUID,
+			Namespace: message.SpaceGUID,
+		},
+	})
+
+	return apierrors.FromK8sError(err, TaskScheduleResourceType)
+}
+
+func taskScheduleToRecord(schedule korifiv1alpha1.CFTaskSchedule) TaskScheduleRecord {
+	record := TaskScheduleRecord{
+		Name:              schedule.Spec.DisplayName,
+		GUID:              schedule.Name,
+		SpaceGUID:         schedule.Namespace,
+		AppGUID:           schedule.Spec.AppRef.Name,
+		Command:           schedule.Spec.Command,
+		Schedule:          schedule.Spec.Schedule,
+		ConcurrencyPolicy: schedule.Spec.ConcurrencyPolicy,
+		Suspended:         schedule.Spec.Suspend,
+		MemoryMB:          schedule.Spec.MemoryMB,
+		DiskMB:            schedule.Spec.DiskQuotaMB,
+		TimeoutSeconds:    schedule.Spec.TimeoutSeconds,
+		Labels:            schedule.Labels,
+		Annotations:       schedule.Annotations,
+		CreatedAt:         schedule.CreationTimestamp.Time,
+		UpdatedAt:         getLastUpdatedTime(&schedule),
+	}
+
+	if schedule.Status.LastScheduleTime != nil {
+		record.LastScheduleTime = &schedule.Status.LastScheduleTime.Time
+	}
+
+	return record
+}
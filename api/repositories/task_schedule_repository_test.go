@@ -0,0 +1,355 @@
+package repositories_test
+
+import (
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tests/matchers"
+	"code.cloudfoundry.org/korifi/tools"
+	"code.cloudfoundry.org/korifi/tools/k8s"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gstruct"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("TaskScheduleRepository", func() {
+	var (
+		scheduleRepo *repositories.TaskScheduleRepo
+		org          *korifiv1alpha1.CFOrg
+		space        *korifiv1alpha1.CFSpace
+		cfApp        *korifiv1alpha1.CFApp
+	)
+
+	BeforeEach(func() {
+		scheduleRepo = repositories.NewTaskScheduleRepo(
+			userClientFactory.WithWrappingFunc(func(client client.WithWatch) client.WithWatch {
+				return authorization.NewSpaceFilteringClient(client, k8sClient, nsPerms)
+			}),
+			namespaceRetriever,
+		)
+
+		org = createOrgWithCleanup(ctx, prefixedGUID("org"))
+		space = createSpaceWithCleanup(ctx, org.Name, prefixedGUID("space"))
+
+		cfApp = createApp(space.Name)
+	})
+
+	createSchedule := func(namespace, appGUID string) *korifiv1alpha1.CFTaskSchedule {
+		schedule := &korifiv1alpha1.CFTaskSchedule{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      prefixedGUID("schedule"),
+				Namespace: namespace,
+				Labels: map[string]string{
+					korifiv1alpha1.CFAppGUIDLabelKey: appGUID,
+				},
+			},
+			Spec: korifiv1alpha1.CFTaskScheduleSpec{
+				DisplayName: "nightly",
+				Command:     "echo hello",
+				Schedule:    "0 0 * * *",
+				AppRef: corev1.LocalObjectReference{
+					Name: appGUID,
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, schedule)).To(Succeed())
+
+		return schedule
+	}
+
+	Describe("CreateTaskSchedule", func() {
+		var (
+			createMessage  repositories.CreateTaskScheduleMessage
+			scheduleRecord repositories.TaskScheduleRecord
+			createErr      error
+		)
+
+		BeforeEach(func() {
+			createMessage = repositories.CreateTaskScheduleMessage{
+				Name:              "nightly",
+				Command:           "echo hello",
+				SpaceGUID:         space.Name,
+				AppGUID:           cfApp.Name,
+				Schedule:          "0 0 * * *",
+				ConcurrencyPolicy: korifiv1alpha1.ConcurrencyPolicyForbid,
+				MemoryMB:          256,
+				DiskMB:            512,
+				TimeoutSeconds:    60,
+				Metadata: repositories.Metadata{
+					Labels: map[string]string{"foo": "bar"},
+				},
+			}
+		})
+
+		JustBeforeEach(func() {
+			scheduleRecord, createErr = scheduleRepo.CreateTaskSchedule(ctx, authInfo, createMessage)
+		})
+
+		It("returns forbidden error", func() {
+			Expect(createErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user can create task schedules", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
+			})
+
+			It("returns the task schedule record", func() {
+				Expect(createErr).NotTo(HaveOccurred())
+				Expect(scheduleRecord.GUID).NotTo(BeEmpty())
+				Expect(scheduleRecord.Name).To(Equal("nightly"))
+				Expect(scheduleRecord.Command).To(Equal("echo hello"))
+				Expect(scheduleRecord.AppGUID).To(Equal(cfApp.Name))
+				Expect(scheduleRecord.SpaceGUID).To(Equal(space.Name))
+				Expect(scheduleRecord.Schedule).To(Equal("0 0 * * *"))
+				Expect(scheduleRecord.ConcurrencyPolicy).To(Equal(korifiv1alpha1.ConcurrencyPolicyForbid))
+				Expect(scheduleRecord.Suspended).To(BeFalse())
+				Expect(scheduleRecord.MemoryMB).To(BeEquivalentTo(256))
+				Expect(scheduleRecord.DiskMB).To(BeEquivalentTo(512))
+				Expect(scheduleRecord.TimeoutSeconds).To(BeEquivalentTo(60))
+				Expect(scheduleRecord.LastScheduleTime).To(BeNil())
+				Expect(scheduleRecord.CreatedAt).To(BeTemporally("~", time.Now(), 5*time.Second))
+			})
+
+			It("creates the CFTaskSchedule labelled with the app guid", func() {
+				Expect(createErr).NotTo(HaveOccurred())
+
+				schedule := &korifiv1alpha1.CFTaskSchedule{}
+				Expect(k8sClient.Get(ctx, client.ObjectKey{Namespace: space.Name, Name: scheduleRecord.GUID}, schedule)).To(Succeed())
+				Expect(schedule.Labels).To(HaveKeyWithValue(korifiv1alpha1.CFAppGUIDLabelKey, cfApp.Name))
+				Expect(schedule.Labels).To(HaveKeyWithValue("foo", "bar"))
+				Expect(schedule.Spec.AppRef.Name).To(Equal(cfApp.Name))
+				Expect(schedule.Spec.Schedule).To(Equal("0 0 * * *"))
+			})
+		})
+
+		When("unprivileged client creation fails", func() {
+			BeforeEach(func() {
+				authInfo = authorization.Info{}
+			})
+
+			It("returns an error", func() {
+				Expect(createErr).To(MatchError(ContainSubstring("failed to build user client")))
+			})
+		})
+	})
+
+	Describe("GetTaskSchedule", func() {
+		var (
+			schedule       *korifiv1alpha1.CFTaskSchedule
+			scheduleGUID   string
+			scheduleRecord repositories.TaskScheduleRecord
+			getErr         error
+		)
+
+		BeforeEach(func() {
+			schedule = createSchedule(space.Name, cfApp.Name)
+			scheduleGUID = schedule.Name
+		})
+
+		JustBeforeEach(func() {
+			scheduleRecord, getErr = scheduleRepo.GetTaskSchedule(ctx, authInfo, scheduleGUID)
+		})
+
+		It("returns a forbidden error", func() {
+			Expect(getErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user can get task schedules", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
+			})
+
+			It("returns the task schedule", func() {
+				Expect(getErr).NotTo(HaveOccurred())
+				Expect(scheduleRecord.GUID).To(Equal(schedule.Name))
+				Expect(scheduleRecord.Name).To(Equal("nightly"))
+				Expect(scheduleRecord.AppGUID).To(Equal(cfApp.Name))
+			})
+
+			When("the schedule has run", func() {
+				var lastScheduleTime metav1.Time
+
+				BeforeEach(func() {
+					lastScheduleTime = metav1.NewTime(time.Now().Truncate(time.Second))
+					Expect(k8s.Patch(ctx, k8sClient, schedule, func() {
+						schedule.Status.LastScheduleTime = &lastScheduleTime
+					})).To(Succeed())
+				})
+
+				It("returns the last schedule time", func() {
+					Expect(getErr).NotTo(HaveOccurred())
+					Expect(scheduleRecord.LastScheduleTime).To(gstruct.PointTo(BeTemporally("==", lastScheduleTime.Time)))
+				})
+			})
+		})
+
+		When("the task schedule doesn't exist", func() {
+			BeforeEach(func() {
+				scheduleGUID = "i-do-not-exist"
+			})
+
+			It("returns a not found error", func() {
+				Expect(getErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
+			})
+		})
+	})
+
+	Describe("ListTaskSchedules", func() {
+		var (
+			space2        *korifiv1alpha1.CFSpace
+			cfApp2        *korifiv1alpha1.CFApp
+			schedule1     *korifiv1alpha1.CFTaskSchedule
+			schedule2     *korifiv1alpha1.CFTaskSchedule
+			listMessage   repositories.ListTaskScheduleMessage
+			listedRecords []repositories.TaskScheduleRecord
+			listErr       error
+		)
+
+		BeforeEach(func() {
+			space2 = createSpaceWithCleanup(ctx, org.Name, prefixedGUID("space2"))
+			cfApp2 = createApp(space2.Name)
+			schedule1 = createSchedule(space.Name, cfApp.Name)
+			schedule2 = createSchedule(space2.Name, cfApp2.Name)
+			listMessage = repositories.ListTaskScheduleMessage{}
+		})
+
+		JustBeforeEach(func() {
+			listedRecords, listErr = scheduleRepo.ListTaskSchedules(ctx, authInfo, listMessage)
+		})
+
+		It("returns an empty list due to no permissions", func() {
+			Expect(listErr).NotTo(HaveOccurred())
+			Expect(listedRecords).To(BeEmpty())
+		})
+
+		When("the user is a space developer in both spaces", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space2.Name)
+			})
+
+			It("lists all task schedules", func() {
+				Expect(listErr).NotTo(HaveOccurred())
+				Expect(listedRecords).To(ConsistOf(
+					HaveField("GUID", schedule1.Name),
+					HaveField("GUID", schedule2.Name),
+				))
+			})
+
+			When("filtering by app guid", func() {
+				BeforeEach(func() {
+					listMessage.AppGUIDs = []string{cfApp2.Name}
+				})
+
+				It("lists the task schedules of that app", func() {
+					Expect(listErr).NotTo(HaveOccurred())
+					Expect(listedRecords).To(ConsistOf(HaveField("GUID", schedule2.Name)))
+				})
+			})
+		})
+	})
+
+	Describe("PatchTaskSchedule", func() {
+		var (
+			schedule       *korifiv1alpha1.CFTaskSchedule
+			patchMessage   repositories.PatchTaskScheduleMessage
+			scheduleRecord repositories.TaskScheduleRecord
+			patchErr       error
+		)
+
+		BeforeEach(func() {
+			schedule = createSchedule(space.Name, cfApp.Name)
+			patchMessage = repositories.PatchTaskScheduleMessage{
+				GUID:              schedule.Name,
+				SpaceGUID:         space.Name,
+				Schedule:          tools.PtrTo("*/5 * * * *"),
+				ConcurrencyPolicy: tools.PtrTo(korifiv1alpha1.ConcurrencyPolicyReplace),
+				Suspended:         tools.PtrTo(true),
+				MetadataPatch: repositories.MetadataPatch{
+					Labels: map[string]*string{"foo": tools.PtrTo("bar")},
+				},
+			}
+		})
+
+		JustBeforeEach(func() {
+			scheduleRecord, patchErr = scheduleRepo.PatchTaskSchedule(ctx, authInfo, patchMessage)
+		})
+
+		It("returns a forbidden error", func() {
+			Expect(patchErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is a space developer", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
+			})
+
+			It("patches the task schedule", func() {
+				Expect(patchErr).NotTo(HaveOccurred())
+				Expect(scheduleRecord.Schedule).To(Equal("*/5 * * * *"))
+				Expect(scheduleRecord.ConcurrencyPolicy).To(Equal(korifiv1alpha1.ConcurrencyPolicyReplace))
+				Expect(scheduleRecord.Suspended).To(BeTrue())
+				Expect(scheduleRecord.Command).To(Equal("echo hello"))
+				Expect(scheduleRecord.Labels).To(HaveKeyWithValue("foo", "bar"))
+
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(schedule), schedule)).To(Succeed())
+				Expect(schedule.Spec.Schedule).To(Equal("*/5 * * * *"))
+				Expect(schedule.Spec.Suspend).To(BeTrue())
+			})
+		})
+	})
+
+	Describe("DeleteTaskSchedule", func() {
+		var (
+			schedule  *korifiv1alpha1.CFTaskSchedule
+			deleteErr error
+		)
+
+		BeforeEach(func() {
+			schedule = createSchedule(space.Name, cfApp.Name)
+		})
+
+		JustBeforeEach(func() {
+			deleteErr = scheduleRepo.DeleteTaskSchedule(ctx, authInfo, repositories.DeleteTaskScheduleMessage{
+				GUID:      schedule.Name,
+				SpaceGUID: space.Name,
+			})
+		})
+
+		It("returns a forbidden error", func() {
+			Expect(deleteErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is a space developer", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
+			})
+
+			It("deletes the task schedule", func() {
+				Expect(deleteErr).NotTo(HaveOccurred())
+
+				err := k8sClient.Get(ctx, client.ObjectKeyFromObject(schedule), &korifiv1alpha1.CFTaskSchedule{})
+				Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+			})
+		})
+
+		When("the user is a space manager", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceManagerRole.Name, space.Name)
+			})
+
+			It("returns a forbidden error", func() {
+				Expect(deleteErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+			})
+		})
+	})
+})
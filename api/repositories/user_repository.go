@@ -0,0 +1,357 @@
+package repositories
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/tools/singleton"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools"
+
+	"github.com/BooleanCat/go-functional/v2/it"
+	"github.com/BooleanCat/go-functional/v2/it/itx"
+	authv1 "k8s.io/api/authorization/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	UserResourceType = "User"
+)
+
+// UserRecord uses the username as guid, as this is how users are referred to
+// by role relationships
+type UserRecord struct {
+	GUID             string
+	Username         string
+	PresentationName string
+	Origin           string
+	Labels           map[string]string
+	Annotations      map[string]string
+	CreatedAt        time.Time
+	UpdatedAt        *time.Time
+}
+
+func (r UserRecord) GetResourceType() string {
+	return UserResourceType
+}
+
+type CreateUserMessage struct {
+	Username         string
+	Origin           string
+	PresentationName string
+	Metadata         Metadata
+}
+
+type ListUsersMessage struct {
+	GUIDs     []string
+	Usernames []string
+	Origins   []string
+}
+
+func (m *ListUsersMessage) matches(u UserRecord) bool {
+	return tools.EmptyOrContains(m.GUIDs, u.GUID) &&
+		(tools.EmptyOrContains(m.Usernames, u.Username) || slices.Contains(m.Usernames, u.GUID)) &&
+		tools.EmptyOrContains(m.Origins, u.Origin)
+}
+
+// requestedIdentities returns the identity names of the users requested by
+// username. With an origin the identity name is prefixed just like the role
+// subjects are, see UserIdentities.
+func (m *ListUsersMessage) requestedIdentities(userIdentities UserIdentities) []string {
+	origins := m.Origins
+	if len(origins) == 0 {
+		origins = []string{""}
+	}
+
+	identities := []string{}
+	for _, username := range m.Usernames {
+		for _, origin := range origins {
+			identities = append(identities, userIdentities.Name(username, origin))
+		}
+	}
+
+	return identities
+}
+
+type UserRepo struct {
+	userClientFactory authorization.UserClientFactory
+	roleRepo          *RoleRepo
+	rootNamespace     string
+	userIdentities    UserIdentities
+}
+
+func NewUserRepo(
+	userClientFactory authorization.UserClientFactory,
+	roleRepo *RoleRepo,
+	rootNamespace string,
+	userIdentities UserIdentities,
+) *UserRepo {
+	return &UserRepo{
+		userClientFactory: userClientFactory,
+		roleRepo:          roleRepo,
+		rootNamespace:     rootNamespace,
+		userIdentities:    userIdentities,
+	}
+}
+
+func (r *UserRepo) CreateUser(ctx context.Context, authInfo authorization.Info, message CreateUserMessage) (UserRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return UserRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	// users are keyed on the name Kubernetes knows them by, so that they
+	// match the subjects of their roles
+	identity := r.userIdentities.Name(message.Username, message.Origin)
+	cfUser := &korifiv1alpha1.CFUser{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        userObjectName(identity),
+			Namespace:   r.rootNamespace,
+			Labels:      message.Metadata.Labels,
+			Annotations: message.Metadata.Annotations,
+		},
+		Spec: korifiv1alpha1.CFUserSpec{
+			Username:         identity,
+			Origin:           message.Origin,
+			PresentationName: message.PresentationName,
+		},
+	}
+
+	err = userClient.Create(ctx, cfUser)
+	if err != nil {
+		if k8serrors.IsAlreadyExists(err) {
+			return UserRecord{}, apierrors.NewUniquenessError(err, fmt.Sprintf("User with username '%s' already exists.", message.Username))
+		}
+		return UserRecord{}, apierrors.FromK8sError(err, UserResourceType)
+	}
+
+	return r.userIdentities.cfUserToRecord(*cfUser), nil
+}
+
+func (r *UserRepo) GetUser(ctx context.Context, authInfo authorization.Info, guid string) (UserRecord, error) {
+	users, err := r.ListUsers(ctx, authInfo, ListUsersMessage{GUIDs: []string{guid}})
+	if err != nil {
+		return UserRecord{}, err
+	}
+
+	if len(users) == 0 {
+		return UserRecord{}, apierrors.NewNotFoundError(nil, UserResourceType)
+	}
+
+	return singleton.Get(users)
+}
+
+// ListUsers returns all users to admins. Other requesters only see the users
+// that have roles in the orgs and spaces they can see. Users are either
+// recorded as CFUsers or, as Korifi does not manage identities, derived from
+// the subjects of the roles. Users requested by username that are known to
+// neither are presented as they would be once given a role, so that roles can
+// be assigned to them by username.
+func (r *UserRepo) ListUsers(ctx context.Context, authInfo authorization.Info, message ListUsersMessage) ([]UserRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return []UserRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	roles, err := r.roleRepo.ListRoles(ctx, authInfo, ListRolesMessage{})
+	if err != nil {
+		return []UserRecord{}, err
+	}
+	roleSubjects := tools.Uniq(slices.Collect(it.Map(
+		itx.FromSlice(roles).Exclude(func(role RoleRecord) bool { return role.Kind == rbacv1.GroupKind }),
+		func(role RoleRecord) string { return role.User },
+	)))
+
+	cfUsers := &korifiv1alpha1.CFUserList{}
+	err = userClient.List(ctx, cfUsers, client.InNamespace(r.rootNamespace))
+	if k8serrors.IsForbidden(err) {
+		cfUsers.Items, err = r.getUsers(ctx, userClient, roleSubjects)
+	}
+	if err != nil {
+		return []UserRecord{}, apierrors.FromK8sError(err, UserResourceType)
+	}
+
+	users := map[string]UserRecord{}
+	for _, identity := range slices.Concat(message.requestedIdentities(r.userIdentities), roleSubjects) {
+		users[identity] = r.userIdentities.toRecord(identity)
+	}
+	for _, cfUser := range cfUsers.Items {
+		users[cfUser.Spec.Username] = r.userIdentities.cfUserToRecord(cfUser)
+	}
+
+	records := itx.From(maps.Values(users)).Filter(message.matches).Collect()
+	slices.SortFunc(records, func(a, b UserRecord) int {
+		return strings.Compare(a.GUID, b.GUID)
+	})
+
+	return records, nil
+}
+
+func (r *UserRepo) getUsers(ctx context.Context, userClient client.Client, usernames []string) ([]korifiv1alpha1.CFUser, error) {
+	cfUsers := []korifiv1alpha1.CFUser{}
+	for _, username := range usernames {
+		cfUser := korifiv1alpha1.CFUser{}
+		err := userClient.Get(ctx, client.ObjectKey{Namespace: r.rootNamespace, Name: userObjectName(username)}, &cfUser)
+		if err != nil {
+			if k8serrors.IsNotFound(err) || k8serrors.IsForbidden(err) {
+				continue
+			}
+			return nil, err
+		}
+		cfUsers = append(cfUsers, cfUser)
+	}
+
+	return cfUsers, nil
+}
+
+// DeleteUser deletes the user along with all the role bindings the requester
+// can see for it. Only admins can delete users, while users the requester
+// cannot see are not found.
+func (r *UserRepo) DeleteUser(ctx context.Context, authInfo authorization.Info, guid string) error {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	canDelete, err := r.canIDeleteUsers(ctx, userClient)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.GetUser(ctx, authInfo, guid)
+	if err != nil {
+		return err
+	}
+
+	if !canDelete {
+		return apierrors.NewForbiddenError(nil, UserResourceType)
+	}
+
+	cfUser := &korifiv1alpha1.CFUser{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: r.rootNamespace,
+			Name:      userObjectName(guid),
+		},
+	}
+
+	// make sure the user can be deleted before removing any of its roles.
+	// Users only known from their roles have no CFUser to delete.
+	err = userClient.Delete(ctx, cfUser, client.DryRunAll)
+	cfUserExists := !k8serrors.IsNotFound(err)
+	if err != nil && cfUserExists {
+		return apierrors.FromK8sError(err, UserResourceType)
+	}
+
+	err = r.roleRepo.DeleteUserRoles(ctx, authInfo, guid)
+	if err != nil {
+		return fmt.Errorf("failed to delete roles for user %q: %w", guid, err)
+	}
+
+	if !cfUserExists {
+		return nil
+	}
+
+	err = userClient.Delete(ctx, cfUser)
+	return apierrors.FromK8sError(err, UserResourceType)
+}
+
+func (r *UserRepo) canIDeleteUsers(ctx context.Context, userClient client.Client) (bool, error) {
+	review := authv1.SelfSubjectAccessReview{
+		Spec: authv1.SelfSubjectAccessReviewSpec{
+			ResourceAttributes: &authv1.ResourceAttributes{
+				Namespace: r.rootNamespace,
+				Verb:      "delete",
+				Group:     "korifi.cloudfoundry.org",
+				Resource:  "cfusers",
+			},
+		},
+	}
+	if err := userClient.Create(ctx, &review); err != nil {
+		return false, fmt.Errorf("failed to create self subject access review: %w", apierrors.FromK8sError(err, UserResourceType))
+	}
+
+	return review.Status.Allowed, nil
+}
+
+// userObjectName derives a valid object name from the username, which may
+// contain characters such as `:` that are not allowed in names
+func userObjectName(username string) string {
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(username)))
+}
+
+// UserIdentities maps the usernames and origins of users to the names
+// Kubernetes knows the users by, and back. Users of the OIDC provider are
+// known by their username prefixed with the username prefix the API has been
+// configured with, see config.OIDC, while Korifi authenticates certificate and
+// service account users by their name. The names of users from other origins
+// are prefixed with the origin and `:`.
+type UserIdentities struct {
+	oidcUsernamePrefix string
+}
+
+func NewUserIdentities(oidcUsernamePrefix string) UserIdentities {
+	return UserIdentities{
+		oidcUsernamePrefix: oidcUsernamePrefix,
+	}
+}
+
+// Name returns the name Kubernetes knows the user by
+func (i UserIdentities) Name(username, origin string) string {
+	switch origin {
+	case "", korifiv1alpha1.UserOriginCert, korifiv1alpha1.UserOriginServiceAccount:
+		return username
+	case korifiv1alpha1.UserOriginOIDC:
+		return i.oidcUsernamePrefix + username
+	default:
+		return origin + ":" + username
+	}
+}
+
+// toRecord presents a user that has not been recorded as a CFUser, inferring
+// its origin from its identity name
+func (i UserIdentities) toRecord(identity string) UserRecord {
+	record := UserRecord{
+		GUID:             identity,
+		Username:         identity,
+		PresentationName: identity,
+		Origin:           korifiv1alpha1.UserOriginCert,
+	}
+
+	if authorization.HasServiceAccountPrefix(identity) {
+		record.Origin = korifiv1alpha1.UserOriginServiceAccount
+	} else if username, ok := strings.CutPrefix(identity, i.oidcUsernamePrefix); ok && i.oidcUsernamePrefix != "" {
+		record.Origin = korifiv1alpha1.UserOriginOIDC
+		record.Username = username
+		record.PresentationName = username
+	} else if origin, username, ok := strings.Cut(identity, ":"); ok {
+		record.Origin = origin
+		record.Username = username
+		record.PresentationName = username
+	}
+
+	return record
+}
+
+func (i UserIdentities) cfUserToRecord(cfUser korifiv1alpha1.CFUser) UserRecord {
+	record := i.toRecord(cfUser.Spec.Username)
+	record.Origin = cfUser.Spec.Origin
+	if cfUser.Spec.PresentationName != "" {
+		record.PresentationName = cfUser.Spec.PresentationName
+	}
+	record.Labels = cfUser.Labels
+	record.Annotations = cfUser.Annotations
+	record.CreatedAt = cfUser.CreationTimestamp.Time
+	record.UpdatedAt = getLastUpdatedTime(&cfUser)
+
+	return record
+}
@@ -0,0 +1,414 @@
+package repositories_test
+
+import (
+	"code.cloudfoundry.org/korifi/api/config"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	. "code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/api/repositories/fake"
+	"code.cloudfoundry.org/korifi/api/repositories/fakeawaiter"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tests/matchers"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("UserRepository", func() {
+	var (
+		userRepo *UserRepo
+		roleRepo *RoleRepo
+		cfOrg    *korifiv1alpha1.CFOrg
+	)
+
+	createUser := func(username, origin string) {
+		GinkgoHelper()
+
+		_, err := userRepo.CreateUser(ctx, authInfo, CreateUserMessage{Username: username, Origin: origin})
+		Expect(err).NotTo(HaveOccurred())
+	}
+
+	BeforeEach(func() {
+		orgRepo := NewOrgRepo(rootNamespace, k8sClient, userClientFactory, nsPerms, &fakeawaiter.FakeAwaiter[
+			*korifiv1alpha1.CFOrg,
+			korifiv1alpha1.CFOrg,
+			korifiv1alpha1.CFOrgList,
+			*korifiv1alpha1.CFOrgList,
+		]{}, nil)
+		spaceRepo := NewSpaceRepo(namespaceRetriever, orgRepo, k8sClient, userClientFactory, nsPerms, &fakeawaiter.FakeAwaiter[
+			*korifiv1alpha1.CFSpace,
+			korifiv1alpha1.CFSpace,
+			korifiv1alpha1.CFSpaceList,
+			*korifiv1alpha1.CFSpaceList,
+		]{})
+		sorter := new(fake.RoleSorter)
+		sorter.SortStub = func(records []RoleRecord, _ string) []RoleRecord {
+			return records
+		}
+		roleRepo = NewRoleRepo(
+			userClientFactory,
+			spaceRepo,
+			new(fake.AuthorizedInChecker),
+			nsPerms,
+			rootNamespace,
+			map[string]config.Role{
+				"organization_user": {Name: orgUserRole.Name, Level: config.OrgRole},
+				"cf_user":           {Name: rootNamespaceUserRole.Name},
+			},
+			namespaceRetriever,
+			sorter,
+			NewUserIdentities("oidc:"),
+		)
+		userRepo = NewUserRepo(userClientFactory, roleRepo, rootNamespace, NewUserIdentities("oidc:"))
+
+		cfOrg = createOrgWithCleanup(ctx, uuid.NewString())
+	})
+
+	Describe("CreateUser", func() {
+		var (
+			record    UserRecord
+			createErr error
+		)
+
+		JustBeforeEach(func() {
+			record, createErr = userRepo.CreateUser(ctx, authInfo, CreateUserMessage{
+				Username:         "alice",
+				Origin:           korifiv1alpha1.UserOriginOIDC,
+				PresentationName: "Alice",
+				Metadata: Metadata{
+					Labels: map[string]string{"foo": "bar"},
+				},
+			})
+		})
+
+		It("returns a forbidden error", func() {
+			Expect(createErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is an admin", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+			})
+
+			It("creates the user", func() {
+				Expect(createErr).NotTo(HaveOccurred())
+				Expect(record).To(MatchFields(IgnoreExtras, Fields{
+					"GUID":             Equal("oidc:alice"),
+					"Username":         Equal("alice"),
+					"PresentationName": Equal("Alice"),
+					"Origin":           Equal("oidc"),
+					"Labels":           Equal(map[string]string{"foo": "bar"}),
+				}))
+
+				cfUsers := &korifiv1alpha1.CFUserList{}
+				Expect(k8sClient.List(ctx, cfUsers, client.InNamespace(rootNamespace))).To(Succeed())
+				Expect(cfUsers.Items).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+					"Spec": Equal(korifiv1alpha1.CFUserSpec{
+						Username:         "oidc:alice",
+						Origin:           "oidc",
+						PresentationName: "Alice",
+					}),
+				})))
+			})
+
+			When("the user already exists", func() {
+				BeforeEach(func() {
+					createUser("alice", korifiv1alpha1.UserOriginOIDC)
+				})
+
+				It("returns a uniqueness error", func() {
+					Expect(createErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.UniquenessError{}))
+				})
+			})
+		})
+	})
+
+	Describe("ListUsers", func() {
+		var (
+			message ListUsersMessage
+			records []UserRecord
+			listErr error
+		)
+
+		BeforeEach(func() {
+			message = ListUsersMessage{}
+
+			adminBinding := createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+			createUser("alice", korifiv1alpha1.UserOriginOIDC)
+			createUser("system:serviceaccount:my-ns:bob", korifiv1alpha1.UserOriginServiceAccount)
+			Expect(k8sClient.Delete(ctx, &adminBinding)).To(Succeed())
+
+			createRoleBinding(ctx, "oidc:alice", orgUserRole.Name, cfOrg.Name, RoleGuidLabel, uuid.NewString())
+		})
+
+		JustBeforeEach(func() {
+			records, listErr = userRepo.ListUsers(ctx, authInfo, message)
+		})
+
+		It("returns an empty list", func() {
+			Expect(listErr).NotTo(HaveOccurred())
+			Expect(records).To(BeEmpty())
+		})
+
+		When("the requester can see an org the user has a role in", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, orgUserRole.Name, cfOrg.Name)
+			})
+
+			It("returns the users with roles in the org", func() {
+				Expect(listErr).NotTo(HaveOccurred())
+				Expect(records).To(ConsistOf(
+					MatchFields(IgnoreExtras, Fields{"GUID": Equal("oidc:alice"), "Origin": Equal("oidc")}),
+					MatchFields(IgnoreExtras, Fields{"GUID": Equal(userName), "Origin": Equal("cert")}),
+				))
+			})
+		})
+
+		When("a user with a role has not been recorded", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, orgUserRole.Name, cfOrg.Name)
+				createRoleBinding(ctx, "my-prefix:carol", orgUserRole.Name, cfOrg.Name, RoleGuidLabel, uuid.NewString())
+			})
+
+			It("derives the user from the role subject", func() {
+				Expect(listErr).NotTo(HaveOccurred())
+				Expect(records).To(ContainElement(MatchFields(IgnoreExtras, Fields{
+					"GUID":             Equal("my-prefix:carol"),
+					"Username":         Equal("carol"),
+					"PresentationName": Equal("carol"),
+					"Origin":           Equal("my-prefix"),
+				})))
+			})
+
+			When("filtering by username and origin", func() {
+				BeforeEach(func() {
+					message.Usernames = []string{"carol"}
+					message.Origins = []string{"my-prefix"}
+				})
+
+				It("returns the user", func() {
+					Expect(listErr).NotTo(HaveOccurred())
+					Expect(records).To(ConsistOf(MatchFields(IgnoreExtras, Fields{"GUID": Equal("my-prefix:carol")})))
+				})
+			})
+		})
+
+		When("oidc usernames are prefixed with the issuer URL by default", func() {
+			BeforeEach(func() {
+				oidcConfig := config.OIDC{IssuerURL: "https://issuer.example.com"}
+				userRepo = NewUserRepo(userClientFactory, roleRepo, rootNamespace, NewUserIdentities(oidcConfig.GetUsernamePrefix()))
+
+				createRoleBinding(ctx, userName, orgUserRole.Name, cfOrg.Name)
+				createRoleBinding(ctx, "https://issuer.example.com#erin", orgUserRole.Name, cfOrg.Name, RoleGuidLabel, uuid.NewString())
+
+				message.Usernames = []string{"erin"}
+				message.Origins = []string{korifiv1alpha1.UserOriginOIDC}
+			})
+
+			It("returns the user with the oidc origin", func() {
+				Expect(listErr).NotTo(HaveOccurred())
+				Expect(records).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+					"GUID":     Equal("https://issuer.example.com#erin"),
+					"Username": Equal("erin"),
+					"Origin":   Equal("oidc"),
+				})))
+			})
+		})
+
+		When("requesting a user that is not known yet by username", func() {
+			BeforeEach(func() {
+				message.Usernames = []string{"dave"}
+				message.Origins = []string{"my-prefix"}
+			})
+
+			It("returns the user as it would be once given a role", func() {
+				Expect(listErr).NotTo(HaveOccurred())
+				Expect(records).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+					"GUID":     Equal("my-prefix:dave"),
+					"Username": Equal("dave"),
+					"Origin":   Equal("my-prefix"),
+				})))
+			})
+		})
+
+		When("the user is an admin", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+			})
+
+			It("returns all users ordered by username", func() {
+				Expect(listErr).NotTo(HaveOccurred())
+				Expect(records).To(HaveLen(2))
+				Expect(records[0].Username).To(Equal("alice"))
+				Expect(records[1].Username).To(Equal("system:serviceaccount:my-ns:bob"))
+			})
+
+			When("filtering by origin", func() {
+				BeforeEach(func() {
+					message.Origins = []string{korifiv1alpha1.UserOriginServiceAccount}
+				})
+
+				It("returns the matching users", func() {
+					Expect(listErr).NotTo(HaveOccurred())
+					Expect(records).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+						"GUID":   Equal("system:serviceaccount:my-ns:bob"),
+						"Origin": Equal("service-account"),
+					})))
+				})
+			})
+
+			When("filtering by usernames", func() {
+				BeforeEach(func() {
+					message.Usernames = []string{"alice"}
+				})
+
+				It("returns the matching users", func() {
+					Expect(listErr).NotTo(HaveOccurred())
+					Expect(records).To(ConsistOf(MatchFields(IgnoreExtras, Fields{"GUID": Equal("oidc:alice")})))
+				})
+			})
+		})
+	})
+
+	Describe("GetUser", func() {
+		var (
+			guid   string
+			record UserRecord
+			getErr error
+		)
+
+		BeforeEach(func() {
+			guid = "oidc:alice"
+			createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+			createUser("alice", korifiv1alpha1.UserOriginOIDC)
+		})
+
+		JustBeforeEach(func() {
+			record, getErr = userRepo.GetUser(ctx, authInfo, guid)
+		})
+
+		It("returns the user, defaulting the presentation name to the username", func() {
+			Expect(getErr).NotTo(HaveOccurred())
+			Expect(record.Username).To(Equal("alice"))
+			Expect(record.PresentationName).To(Equal("alice"))
+			Expect(record.Origin).To(Equal("oidc"))
+		})
+
+		When("the user also has roles", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, guid, orgUserRole.Name, cfOrg.Name, RoleGuidLabel, uuid.NewString())
+			})
+
+			It("presents the recorded user and the role subject as the same user", func() {
+				Expect(getErr).NotTo(HaveOccurred())
+				Expect(record.GUID).To(Equal("oidc:alice"))
+				Expect(record.Username).To(Equal("alice"))
+			})
+		})
+
+		When("the user does not exist", func() {
+			BeforeEach(func() {
+				guid = "oidc:i-do-not-exist"
+			})
+
+			It("returns a not found error", func() {
+				Expect(getErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
+			})
+		})
+
+		When("the user is only known from its roles", func() {
+			BeforeEach(func() {
+				guid = "my-prefix:carol"
+				createRoleBinding(ctx, userName, adminRole.Name, cfOrg.Name)
+				createRoleBinding(ctx, guid, orgUserRole.Name, cfOrg.Name, RoleGuidLabel, uuid.NewString())
+			})
+
+			It("returns the user", func() {
+				Expect(getErr).NotTo(HaveOccurred())
+				Expect(record.Username).To(Equal("carol"))
+				Expect(record.Origin).To(Equal("my-prefix"))
+			})
+		})
+	})
+
+	Describe("DeleteUser", func() {
+		var (
+			orgRoleBinding rbacv1.RoleBinding
+			deleteErr      error
+		)
+
+		BeforeEach(func() {
+			adminBinding := createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+			createUser("alice", korifiv1alpha1.UserOriginOIDC)
+			Expect(k8sClient.Delete(ctx, &adminBinding)).To(Succeed())
+
+			orgRoleBinding = createRoleBinding(ctx, "oidc:alice", orgUserRole.Name, cfOrg.Name, RoleGuidLabel, uuid.NewString())
+		})
+
+		JustBeforeEach(func() {
+			deleteErr = userRepo.DeleteUser(ctx, authInfo, "oidc:alice")
+		})
+
+		It("returns a not found error and keeps the role bindings", func() {
+			Expect(deleteErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(&orgRoleBinding), &rbacv1.RoleBinding{})).To(Succeed())
+		})
+
+		When("the requester can see the user", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, orgUserRole.Name, cfOrg.Name)
+			})
+
+			It("returns a forbidden error and keeps the role bindings", func() {
+				Expect(deleteErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(&orgRoleBinding), &rbacv1.RoleBinding{})).To(Succeed())
+			})
+		})
+
+		When("the user is an admin", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+				createRoleBinding(ctx, userName, adminRole.Name, cfOrg.Name)
+			})
+
+			It("deletes the user and its role bindings", func() {
+				Expect(deleteErr).NotTo(HaveOccurred())
+
+				cfUsers := &korifiv1alpha1.CFUserList{}
+				Expect(k8sClient.List(ctx, cfUsers, client.InNamespace(rootNamespace))).To(Succeed())
+				Expect(cfUsers.Items).To(BeEmpty())
+
+				roleBindings := &rbacv1.RoleBindingList{}
+				Expect(k8sClient.List(ctx, roleBindings, client.InNamespace(cfOrg.Name))).To(Succeed())
+				Expect(roleBindings.Items).NotTo(ContainElement(MatchFields(IgnoreExtras, Fields{
+					"Subjects": ContainElement(MatchFields(IgnoreExtras, Fields{"Name": Equal("oidc:alice")})),
+				})))
+			})
+
+			When("the user has not been recorded", func() {
+				BeforeEach(func() {
+					Expect(k8sClient.DeleteAllOf(ctx, &korifiv1alpha1.CFUser{}, client.InNamespace(rootNamespace))).To(Succeed())
+				})
+
+				It("deletes its role bindings", func() {
+					Expect(deleteErr).NotTo(HaveOccurred())
+					Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(&orgRoleBinding), &rbacv1.RoleBinding{})).To(MatchError(ContainSubstring("not found")))
+				})
+			})
+
+			When("the user does not exist", func() {
+				BeforeEach(func() {
+					Expect(k8sClient.DeleteAllOf(ctx, &korifiv1alpha1.CFUser{}, client.InNamespace(rootNamespace))).To(Succeed())
+					Expect(k8sClient.Delete(ctx, &orgRoleBinding)).To(Succeed())
+				})
+
+				It("returns a not found error", func() {
+					Expect(deleteErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
+				})
+			})
+		})
+	})
+})
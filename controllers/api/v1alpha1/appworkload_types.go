@@ -56,6 +56,19 @@ type AppWorkloadSpec struct {
 	// Reference to service credentials secrets to be projected onto the app workload
 	// They are in the [servicebinding.io](https://servicebinding.io/spec/core/1.1.0/) format
 	Services []ServiceBinding `json:"services,omitempty"`
+
+	// The node selector for the app instances, derived from the isolation segment of the space
+	// +kubebuilder:validation:Optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// The tolerations for the app instances, derived from the isolation segment of the space
+	// +kubebuilder:validation:Optional
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+
+	// The autoscaling policy of the process. Runners that support it scale
+	// the instances within its limits, starting from Instances
+	// +kubebuilder:validation:Optional
+	Autoscaling *AutoscalingPolicy `json:"autoscaling,omitempty"`
 }
 
 // AppWorkloadStatus defines the observed state of AppWorkload
@@ -71,6 +84,27 @@ type AppWorkloadStatus struct {
 
 	//+kubebuilder:validation:Optional
 	InstancesStatus map[string]InstanceStatus `json:"instancesStatus"`
+
+	// ScaledToZero is set by runners that scale idle workloads down to no
+	// instances. The instances start again on the next request.
+	//+kubebuilder:validation:Optional
+	ScaledToZero bool `json:"scaledToZero,omitempty"`
+
+	// Backend is set by runners whose workloads cannot receive requests on
+	// their pods directly, e.g. because they scale to zero. Routes to the
+	// workload send their requests to this Service instead.
+	//+kubebuilder:validation:Optional
+	Backend *AppWorkloadBackend `json:"backend,omitempty"`
+}
+
+type AppWorkloadBackend struct {
+	// ServiceName is the name of the Service in the namespace of the workload
+	ServiceName string `json:"serviceName"`
+	Port        int32  `json:"port"`
+
+	// RequestHeaders are set on the requests routed to the Service
+	//+kubebuilder:validation:Optional
+	RequestHeaders map[string]string `json:"requestHeaders,omitempty"`
 }
 
 //+kubebuilder:object:root=true
@@ -45,6 +45,14 @@ type BuildWorkloadSpec struct {
 	// The name of the builder that should reconcile this BuildWorkload resource and execute the image building
 	// +kubebuilder:validation:Required
 	BuilderName string `json:"builderName"`
+
+	// The node selector for the build, derived from the isolation segment of the space
+	// +kubebuilder:validation:Optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// The tolerations for the build, derived from the isolation segment of the space
+	// +kubebuilder:validation:Optional
+	Tolerations []v1.Toleration `json:"tolerations,omitempty"`
 }
 
 // BuildWorkloadStatus defines the observed state of BuildWorkload
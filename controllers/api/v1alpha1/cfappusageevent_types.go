@@ -0,0 +1,93 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// CFAppUsageEventSpec defines the state of a process at the time the
+// CFAppUsageEvent was recorded, alongside the state it transitioned from
+type CFAppUsageEventSpec struct {
+	// The position of the event in the order app usage events were
+	// recorded in. Sequence numbers are strictly increasing and are used to
+	// paginate events
+	// +kubebuilder:validation:Optional
+	Sequence int64 `json:"sequence"`
+
+	// The state of the app the process belongs to
+	State AppState `json:"state"`
+	// The state of the app before the event
+	// +kubebuilder:validation:Optional
+	PreviousState AppState `json:"previousState,omitempty"`
+
+	AppGUID string `json:"appGUID"`
+	AppName string `json:"appName"`
+
+	ProcessGUID string `json:"processGUID"`
+	ProcessType string `json:"processType"`
+
+	SpaceGUID string `json:"spaceGUID"`
+	// +kubebuilder:validation:Optional
+	SpaceName string `json:"spaceName,omitempty"`
+	// +kubebuilder:validation:Optional
+	OrgGUID string `json:"orgGUID,omitempty"`
+
+	InstanceCount int32 `json:"instanceCount"`
+	// +kubebuilder:validation:Optional
+	PreviousInstanceCount int32 `json:"previousInstanceCount"`
+
+	MemoryInMBPerInstance int64 `json:"memoryInMBPerInstance"`
+	// +kubebuilder:validation:Optional
+	PreviousMemoryInMBPerInstance int64 `json:"previousMemoryInMBPerInstance"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:resource:scope=Namespaced
+//+kubebuilder:printcolumn:name="App",type=string,JSONPath=`.spec.appName`
+//+kubebuilder:printcolumn:name="State",type=string,JSONPath=`.spec.state`
+//+kubebuilder:printcolumn:name="Instances",type=integer,JSONPath=`.spec.instanceCount`
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=`.metadata.creationTimestamp`
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CFAppUsageEvent is the Schema for the cfappusageevents API. App usage events
+// live in the root namespace and are named after the process usage transition
+// they record, so that a transition is recorded only once.
+type CFAppUsageEvent struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec CFAppUsageEventSpec `json:"spec,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CFAppUsageEventList contains a list of CFAppUsageEvent
+type CFAppUsageEventList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CFAppUsageEvent `json:"items"`
+}
+
+func (e *CFAppUsageEvent) GetSequence() int64 {
+	return e.Spec.Sequence
+}
+
+func init() {
+	SchemeBuilder.Register(&CFAppUsageEvent{}, &CFAppUsageEventList{})
+}
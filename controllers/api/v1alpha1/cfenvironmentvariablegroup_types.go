@@ -0,0 +1,68 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	RunningEnvironmentVariableGroupName = "running"
+	StagingEnvironmentVariableGroupName = "staging"
+
+	// The annotation recording the running group an app workload has been
+	// created with
+	RunningEnvironmentVariableGroupAnnotationKey = "korifi.cloudfoundry.org/running-env-var-group"
+)
+
+// CFEnvironmentVariableGroupSpec defines the desired state of CFEnvironmentVariableGroup
+type CFEnvironmentVariableGroupSpec struct {
+	// The environment variables set on every app workload (running group) or
+	// build workload (staging group). App-level environment variables take
+	// precedence over the ones in the group.
+	// +kubebuilder:validation:Optional
+	EnvironmentVariables map[string]string `json:"environmentVariables,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:resource:scope=Namespaced
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=`.metadata.creationTimestamp`
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CFEnvironmentVariableGroup is the Schema for the cfenvironmentvariablegroups
+// API. Groups live in the root namespace and are named either "running" or
+// "staging".
+type CFEnvironmentVariableGroup struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec CFEnvironmentVariableGroupSpec `json:"spec,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CFEnvironmentVariableGroupList contains a list of CFEnvironmentVariableGroup
+type CFEnvironmentVariableGroupList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CFEnvironmentVariableGroup `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&CFEnvironmentVariableGroup{}, &CFEnvironmentVariableGroupList{})
+}
@@ -0,0 +1,75 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// CFIsolationSegmentSpec defines the desired state of CFIsolationSegment
+type CFIsolationSegmentSpec struct {
+	// The mutable, user-friendly name of the isolation segment
+	// +kubebuilder:validation:Pattern="^[[:alnum:][:punct:][:print:]]+$"
+	Name string `json:"name"`
+
+	// The GUIDs of the organizations entitled to use the isolation segment
+	// +kubebuilder:validation:Optional
+	Organizations []string `json:"organizations,omitempty"`
+
+	// The node selector set on workloads running in spaces assigned to the isolation segment
+	// +kubebuilder:validation:Optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// The tolerations set on workloads running in spaces assigned to the isolation segment
+	// +kubebuilder:validation:Optional
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:resource:scope=Namespaced
+//+kubebuilder:printcolumn:name="Name",type=string,JSONPath=`.spec.name`
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=`.metadata.creationTimestamp`
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CFIsolationSegment is the Schema for the cfisolationsegments API
+type CFIsolationSegment struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec CFIsolationSegmentSpec `json:"spec,omitempty"`
+}
+
+func (s *CFIsolationSegment) IsEntitled(orgGUID string) bool {
+	return slices.Contains(s.Spec.Organizations, orgGUID)
+}
+
+//+kubebuilder:object:root=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CFIsolationSegmentList contains a list of CFIsolationSegment
+type CFIsolationSegmentList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CFIsolationSegment `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&CFIsolationSegment{}, &CFIsolationSegmentList{})
+}
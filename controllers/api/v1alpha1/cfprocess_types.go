@@ -51,10 +51,79 @@ type CFProcessSpec struct {
 	// The disk limit in MiB
 	DiskQuotaMB int64 `json:"diskQuotaMB"`
 
+	// The CPU entitlement in millicores, both requested and enforced as a
+	// limit. When unset, the CPU request is derived from MemoryMB and no
+	// limit is enforced
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	CPUMillicores int64 `json:"cpuMillicores,omitempty"`
+
 	// The ports to expose
 	// Deprecated: No longer used
 	// +kubebuilder:validation:Optional
 	Ports []int32 `json:"ports,omitempty"`
+
+	// Scales the process instances within limits instead of running
+	// DesiredInstances of them
+	// +kubebuilder:validation:Optional
+	Autoscaling *AutoscalingPolicy `json:"autoscaling,omitempty"`
+}
+
+// AutoscalingPolicy scales a process to meet its resource utilization targets
+type AutoscalingPolicy struct {
+	// The least number of instances to run
+	// +kubebuilder:validation:Minimum=1
+	MinInstances int32 `json:"minInstances"`
+
+	// The most number of instances to run
+	// +kubebuilder:validation:Minimum=1
+	MaxInstances int32 `json:"maxInstances"`
+
+	// The target average CPU usage, as a percentage of the requested CPU.
+	// The CPU usage is targeted at 80% when no utilization is set
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	CPUUtilization *int32 `json:"cpuUtilization,omitempty"`
+
+	// The target average memory usage, as a percentage of the requested memory
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	MemoryUtilization *int32 `json:"memoryUtilization,omitempty"`
+
+	// Instance limits that replace the policy ones during recurring time
+	// windows. The first matching schedule wins.
+	// +kubebuilder:validation:Optional
+	Schedules []AutoscalingSchedule `json:"schedules,omitempty"`
+}
+
+// AutoscalingSchedule sets the instance limits of a process during a time
+// window repeating on some days of the week
+type AutoscalingSchedule struct {
+	// The start of the window, as HH:MM
+	// +kubebuilder:validation:Pattern=`^([01][0-9]|2[0-3]):[0-5][0-9]$`
+	StartTime string `json:"startTime"`
+
+	// The end of the window, as HH:MM. Windows ending before they start
+	// end on the next day
+	// +kubebuilder:validation:Pattern=`^([01][0-9]|2[0-3]):[0-5][0-9]$`
+	EndTime string `json:"endTime"`
+
+	// The days the window starts on, from 1 for Monday to 7 for Sunday.
+	// The window starts every day when empty
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:items:Minimum=1
+	// +kubebuilder:validation:items:Maximum=7
+	DaysOfWeek []int32 `json:"daysOfWeek,omitempty"`
+
+	// The IANA time zone of the window times, UTC when empty
+	// +kubebuilder:validation:Optional
+	TimeZone string `json:"timeZone,omitempty"`
+
+	// +kubebuilder:validation:Minimum=1
+	MinInstances int32 `json:"minInstances"`
+
+	// +kubebuilder:validation:Minimum=1
+	MaxInstances int32 `json:"maxInstances"`
 }
 
 type HealthCheck struct {
@@ -93,6 +162,22 @@ type CFProcessStatus struct {
 
 	//+kubebuilder:validation:Optional
 	InstancesStatus map[string]InstanceStatus `json:"instancesStatus"`
+
+	// ScaledToZero is true when the runner has scaled the idle process down
+	// to no instances
+	//+kubebuilder:validation:Optional
+	ScaledToZero bool `json:"scaledToZero,omitempty"`
+
+	// The process usage reported in the most recent app usage event
+	//+kubebuilder:validation:Optional
+	Usage *ProcessUsage `json:"usage,omitempty"`
+}
+
+// ProcessUsage is the billable state of a process
+type ProcessUsage struct {
+	State     AppState `json:"state"`
+	Instances int32    `json:"instances"`
+	MemoryMB  int64    `json:"memoryMB"`
 }
 
 //+kubebuilder:object:root=true
@@ -8,12 +8,17 @@ import (
 // CFServiceOfferingSpec defines the desired state of CFServiceOffering
 type CFServiceOfferingSpec struct {
 	services.ServiceOffering `json:",inline"`
+
+	// Unavailable is set when the offering has been removed from the broker
+	// catalog but some of its plans are still in use by service instances
+	//+kubebuilder:validation:Optional
+	Unavailable bool `json:"unavailable,omitempty"`
 }
 
 //+kubebuilder:object:root=true
 //+kubebuilder:printcolumn:name="Offering",type=string,JSONPath=`.spec.name`
 //+kubebuilder:printcolumn:name="Description",type=string,JSONPath=`.spec.description`
-//+kubebuilder:printcolumn:name="Available",type=string,JSONPath=`.spec.available`
+//+kubebuilder:printcolumn:name="Unavailable",type=string,JSONPath=`.spec.unavailable`
 //+kubebuilder:printcolumn:name="Age",type="date",JSONPath=`.metadata.creationTimestamp`
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
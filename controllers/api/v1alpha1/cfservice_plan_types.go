@@ -8,16 +8,22 @@ import (
 type CFServicePlanSpec struct {
 	services.ServicePlan `json:",inline"`
 	Visibility           ServicePlanVisibility `json:"visibility"`
+
+	// Unavailable is set when the plan has been removed from the broker
+	// catalog but it is still in use by service instances
+	//+kubebuilder:validation:Optional
+	Unavailable bool `json:"unavailable,omitempty"`
 }
 
 const (
 	AdminServicePlanVisibilityType        = "admin"
 	PublicServicePlanVisibilityType       = "public"
 	OrganizationServicePlanVisibilityType = "organization"
+	SpaceServicePlanVisibilityType        = "space"
 )
 
 type ServicePlanVisibility struct {
-	// +kubebuilder:validation:Enum=admin;public;organization
+	// +kubebuilder:validation:Enum=admin;public;organization;space
 	Type string `json:"type"`
 	// +kubebuilder:validation:Optional
 	Organizations []string `json:"organizations,omitempty"`
@@ -25,7 +31,7 @@ type ServicePlanVisibility struct {
 
 // +kubebuilder:object:root=true
 // +kubebuilder:printcolumn:name="Plan",type=string,JSONPath=`.spec.name`
-// +kubebuilder:printcolumn:name="Available",type=string,JSONPath=`.spec.available`
+// +kubebuilder:printcolumn:name="Unavailable",type=string,JSONPath=`.spec.unavailable`
 // +kubebuilder:printcolumn:name="Free",type=string,JSONPath=`.spec.free`
 // +kubebuilder:printcolumn:name="Age",type="date",JSONPath=`.metadata.creationTimestamp`
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
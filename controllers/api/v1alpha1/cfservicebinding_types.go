@@ -24,7 +24,8 @@ import (
 )
 
 const (
-	BindingFailedCondition = "BindingFailed"
+	BindingFailedCondition            = "BindingFailed"
+	CredentialRotationFailedCondition = "CredentialRotationFailed"
 
 	UnbindingFailedCondition = "UnbindingFailed"
 
@@ -56,6 +57,12 @@ type CFServiceBindingSpec struct {
 	// The type of the binding. There are two possible values - "key" or "app"
 	// +kubebuilder:validation:Enum=app;key
 	Type string `json:"type"`
+
+	// Incrementing this counter past the last observed rotation makes the
+	// controller rotate the binding credentials. Only makes sense for
+	// bindings to managed service instances
+	// +optional
+	CredentialRotation int64 `json:"credentialRotation,omitempty"`
 }
 
 // CFServiceBindingStatus defines the observed state of CFServiceBinding
@@ -81,6 +88,45 @@ type CFServiceBindingStatus struct {
 
 	// ObservedGeneration captures the latest generation of the CFServiceBinding that has been reconciled
 	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// The ID of the binding at the service broker. Empty means that the
+	// binding has never been rotated and its ID equals the CFServiceBinding name
+	// +optional
+	BindingID string `json:"bindingID,omitempty"`
+
+	// The credential rotation counter the controller has last completed
+	// +optional
+	ObservedCredentialRotation int64 `json:"observedCredentialRotation,omitempty"`
+
+	// Broker bindings superseded by a credential rotation. They are unbound
+	// once the rotation grace period has elapsed
+	// +optional
+	RetiredBindings []RetiredServiceBinding `json:"retiredBindings,omitempty"`
+
+	// The resource version of the service secret the binding secrets have last
+	// been synced from. Only makes sense for bindings to `kubernetes` service
+	// instances
+	// +optional
+	CredentialsObservedVersion string `json:"credentialsObservedVersion,omitempty"`
+
+	// The volumes returned by volume service brokers when binding. They are
+	// mounted into the instances of the bound app
+	// +optional
+	VolumeMounts []ServiceVolumeMount `json:"volumeMounts,omitempty"`
+
+	// The broker operation unbinding a binding the broker might have created
+	// in response to an ambiguous bind request. Set while the broker is
+	// unbinding asynchronously
+	// +optional
+	OrphanMitigationOperation *string `json:"orphanMitigationOperation,omitempty"`
+}
+
+type RetiredServiceBinding struct {
+	// The ID of the binding at the service broker
+	ID string `json:"id"`
+
+	// The time at which the binding was superseded
+	RetiredAt metav1.Time `json:"retiredAt"`
 }
 
 //+kubebuilder:object:root=true
@@ -113,6 +159,14 @@ func (b *CFServiceBinding) StatusConditions() *[]metav1.Condition {
 	return &b.Status.Conditions
 }
 
+// BrokerBindingID returns the ID of the binding at the service broker
+func (b CFServiceBinding) BrokerBindingID() string {
+	if b.Status.BindingID != "" {
+		return b.Status.BindingID
+	}
+	return b.Name
+}
+
 func (b CFServiceBinding) UniqueName() string {
 	return fmt.Sprintf("sb::%s::%s::%s", b.Spec.AppRef.Name, b.Spec.Service.Namespace, b.Spec.Service.Name)
 }
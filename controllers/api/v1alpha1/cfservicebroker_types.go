@@ -11,11 +11,19 @@ import (
 const (
 	UsernameCredentialsKey = "username"
 	PasswordCredentialsKey = "password"
+
+	OSBAPIClientCreationFailedReason = "OSBAPIClientCreationFailed"
+	GetCatalogFailedReason           = "GetCatalogFailed"
 )
 
 type CFServiceBrokerSpec struct {
 	services.ServiceBroker `json:",inline"`
 	Credentials            corev1.LocalObjectReference `json:"credentials"`
+
+	// CatalogRefreshRequestedAt is bumped in order to request a refresh of
+	// the broker catalog
+	//+kubebuilder:validation:Optional
+	CatalogRefreshRequestedAt *metav1.Time `json:"catalogRefreshRequestedAt,omitempty"`
 }
 
 type CFServiceBrokerStatus struct {
@@ -27,6 +27,13 @@ import (
 const (
 	UserProvidedType = "user-provided"
 	ManagedType      = "managed"
+	KubernetesType   = "kubernetes"
+
+	// Annotation on a Secret or ProvisionedService listing the comma separated
+	// namespaces whose `kubernetes` service instances may reference it, or `*`
+	// for all namespaces. Resources are only bindable from their own namespace
+	// otherwise
+	SharedWithNamespacesAnnotationKey = "korifi.cloudfoundry.org/shared-with-namespaces"
 
 	CFServiceInstanceFinalizerName = "cfServiceInstance.korifi.cloudfoundry.org"
 
@@ -42,9 +49,19 @@ type CFServiceInstanceSpec struct {
 	// Name of a secret containing the service credentials. The Secret must be in the same namespace
 	SecretName string `json:"secretName"`
 
-	// Type of the Service Instance. Must be `user-provided` or `managed`
+	// Type of the Service Instance. Must be `user-provided`, `managed` or `kubernetes`
 	Type InstanceType `json:"type"`
 
+	// The Kubernetes resource providing the credentials of a `kubernetes`
+	// service instance. Either a Secret, or a resource implementing the
+	// [servicebinding.io](https://servicebinding.io/spec/core/1.1.0/#provisioned-service)
+	// ProvisionedService duck type, i.e. exposing the credentials secret name in
+	// `.status.binding.name`. The namespace defaults to the service instance
+	// namespace. The controllers must be allowed to get ProvisionedService
+	// resources
+	// +optional
+	ServiceRef *corev1.ObjectReference `json:"serviceRef,omitempty"`
+
 	// Service label to use when adding this instance to VCAP_SERVICES. If not
 	// set, the service instance Type would be used. For managed services the
 	// value is defaulted to the offering name
@@ -60,7 +77,7 @@ type CFServiceInstanceSpec struct {
 }
 
 // InstanceType defines the type of the Service Instance
-// +kubebuilder:validation:Enum=user-provided;managed
+// +kubebuilder:validation:Enum=user-provided;managed;kubernetes
 type InstanceType string
 
 // CFServiceInstanceStatus defines the observed state of CFServiceInstance
@@ -81,6 +98,11 @@ type CFServiceInstanceStatus struct {
 	//+kubebuilder:validation:Optional
 	CredentialsObservedVersion string `json:"credentialsObservedVersion,omitempty"`
 
+	// The Secret providing the credentials of a `kubernetes` service
+	// instance, resolved from spec.serviceRef
+	// +optional
+	ServiceSecret *corev1.SecretReference `json:"serviceSecret,omitempty"`
+
 	//+kubebuilder:validation:Optional
 	LastOperation services.LastOperation `json:"lastOperation"`
 
@@ -91,6 +113,22 @@ type CFServiceInstanceStatus struct {
 	// True if there is an upgrade available for for the service instance (i.e. the plan has a new version). Only makes seense for managed service instances
 	//+kubebuilder:validation:Optional
 	UpgradeAvailable bool `json:"upgradeAvailable"`
+
+	// The service instance usage reported in the most recent service usage event
+	//+kubebuilder:validation:Optional
+	Usage *ServiceInstanceUsage `json:"usage,omitempty"`
+
+	// The broker operation deprovisioning an instance the broker might have
+	// created in response to an ambiguous provision request. Set while the
+	// broker is deprovisioning the instance asynchronously
+	//+kubebuilder:validation:Optional
+	OrphanMitigationOperation *string `json:"orphanMitigationOperation,omitempty"`
+}
+
+// ServiceInstanceUsage is the billable state of a service instance
+type ServiceInstanceUsage struct {
+	Name     string `json:"name"`
+	PlanGUID string `json:"planGUID,omitempty"`
 }
 
 //+kubebuilder:object:root=true
@@ -0,0 +1,100 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	ServiceUsageEventCreated = "CREATED"
+	ServiceUsageEventUpdated = "UPDATED"
+	ServiceUsageEventDeleted = "DELETED"
+)
+
+// CFServiceUsageEventSpec defines the state of a service instance at the time
+// the CFServiceUsageEvent was recorded
+type CFServiceUsageEventSpec struct {
+	// The position of the event in the order service usage events were
+	// recorded in. Sequence numbers are strictly increasing and are used to
+	// paginate events
+	// +kubebuilder:validation:Optional
+	Sequence int64 `json:"sequence"`
+
+	// +kubebuilder:validation:Enum=CREATED;UPDATED;DELETED
+	State string `json:"state"`
+
+	ServiceInstanceGUID string       `json:"serviceInstanceGUID"`
+	ServiceInstanceName string       `json:"serviceInstanceName"`
+	ServiceInstanceType InstanceType `json:"serviceInstanceType"`
+
+	// The plan, offering and broker of the service instance. Only set for
+	// managed service instances
+	// +kubebuilder:validation:Optional
+	ServicePlanGUID string `json:"servicePlanGUID,omitempty"`
+	// +kubebuilder:validation:Optional
+	ServicePlanName string `json:"servicePlanName,omitempty"`
+	// +kubebuilder:validation:Optional
+	ServiceOfferingGUID string `json:"serviceOfferingGUID,omitempty"`
+	// +kubebuilder:validation:Optional
+	ServiceOfferingName string `json:"serviceOfferingName,omitempty"`
+	// +kubebuilder:validation:Optional
+	ServiceBrokerGUID string `json:"serviceBrokerGUID,omitempty"`
+	// +kubebuilder:validation:Optional
+	ServiceBrokerName string `json:"serviceBrokerName,omitempty"`
+
+	SpaceGUID string `json:"spaceGUID"`
+	// +kubebuilder:validation:Optional
+	SpaceName string `json:"spaceName,omitempty"`
+	// +kubebuilder:validation:Optional
+	OrgGUID string `json:"orgGUID,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:resource:scope=Namespaced
+//+kubebuilder:printcolumn:name="Service Instance",type=string,JSONPath=`.spec.serviceInstanceName`
+//+kubebuilder:printcolumn:name="State",type=string,JSONPath=`.spec.state`
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=`.metadata.creationTimestamp`
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CFServiceUsageEvent is the Schema for the cfserviceusageevents API. Service
+// usage events live in the root namespace and are named after the service
+// instance usage state they record, so that a state is recorded only once.
+type CFServiceUsageEvent struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec CFServiceUsageEventSpec `json:"spec,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CFServiceUsageEventList contains a list of CFServiceUsageEvent
+type CFServiceUsageEventList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CFServiceUsageEvent `json:"items"`
+}
+
+func (e *CFServiceUsageEvent) GetSequence() int64 {
+	return e.Spec.Sequence
+}
+
+func init() {
+	SchemeBuilder.Register(&CFServiceUsageEvent{}, &CFServiceUsageEventList{})
+}
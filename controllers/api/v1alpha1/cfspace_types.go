@@ -21,6 +21,7 @@ import (
 	"strings"
 
 	"code.cloudfoundry.org/korifi/controllers/api/v1alpha1/status"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -35,6 +36,10 @@ type CFSpaceSpec struct {
 	// The mutable, user-friendly name of the space. Unlike metadata.name, the user can change this field
 	// +kubebuilder:validation:Pattern="^[[:alnum:][:punct:][:print:]]+$"
 	DisplayName string `json:"displayName"`
+
+	// The GUID of the isolation segment the space is assigned to. The isolation segment must be entitled to the space organization
+	// +kubebuilder:validation:Optional
+	IsolationSegmentGUID string `json:"isolationSegmentGUID,omitempty"`
 }
 
 // CFSpaceStatus defines the observed state of CFSpace
@@ -46,6 +51,14 @@ type CFSpaceStatus struct {
 
 	// ObservedGeneration captures the latest generation of the CFSpace that has been reconciled
 	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// The node selector inherited from the isolation segment the space is assigned to
+	//+kubebuilder:validation:Optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// The tolerations inherited from the isolation segment the space is assigned to
+	//+kubebuilder:validation:Optional
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
 }
 
 //+kubebuilder:object:root=true
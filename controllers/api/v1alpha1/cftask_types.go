@@ -38,6 +38,51 @@ type CFTaskSpec struct {
 	// A boolean describing whether the CFTask has been canceled
 	// +optional
 	Canceled bool `json:"canceled"`
+	// The name of the task, defaults to the task GUID
+	// +optional
+	DisplayName string `json:"displayName,omitempty"`
+	// The memory limit of the task in MB, defaults to the default process memory
+	// +optional
+	MemoryMB int64 `json:"memoryMB,omitempty"`
+	// The disk limit of the task in MB, defaults to the default process disk quota
+	// +optional
+	DiskQuotaMB int64 `json:"diskQuotaMB,omitempty"`
+	// The log rate limit of the task in bytes per second, -1 meaning unlimited. It is not enforced.
+	// +optional
+	LogRateLimitBytesPerSecond *int64 `json:"logRateLimitBytesPerSecond,omitempty"`
+	// A reference to the CFBuild whose droplet the task runs, defaults to the current droplet of the app
+	// +optional
+	DropletRef corev1.LocalObjectReference `json:"dropletRef,omitempty"`
+	// The number of seconds the task may run for before it is failed. Unlimited if not set
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	TimeoutSeconds int64 `json:"timeoutSeconds,omitempty"`
+	// How the task is retried when it fails. Not retried if not set
+	// +optional
+	Retry *TaskRetryPolicy `json:"retry,omitempty"`
+}
+
+// TaskRetryPolicy describes how a failed task is retried. Retries are delayed
+// by the exponential backoff of kubernetes jobs, starting at ten seconds and
+// capped at six minutes
+type TaskRetryPolicy struct {
+	// The maximum number of attempts at running the task, including the first one
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=10
+	MaxAttempts int32 `json:"maxAttempts"`
+	// Exit codes that fail the task straight away, without retrying it
+	// +optional
+	// +kubebuilder:validation:items:Minimum=1
+	// +kubebuilder:validation:items:Maximum=255
+	NonRetryableExitCodes []int32 `json:"nonRetryableExitCodes,omitempty"`
+}
+
+// TaskAttemptFailure describes a failed attempt at running a task
+type TaskAttemptFailure struct {
+	ExitCode   int32       `json:"exitCode"`
+	Reason     string      `json:"reason,omitempty"`
+	Message    string      `json:"message,omitempty"`
+	FinishedAt metav1.Time `json:"finishedAt,omitempty"`
 }
 
 // CFTaskStatus defines the observed state of CFTask
@@ -53,6 +98,12 @@ type CFTaskStatus struct {
 	DiskQuotaMB int64 `json:"diskQuotaMB"`
 	// +optional
 	DropletRef corev1.LocalObjectReference `json:"dropletRef"`
+	// The number of attempts at running the task so far
+	// +optional
+	Attempts int32 `json:"attempts,omitempty"`
+	// The failed attempts at running the task, oldest first
+	// +optional
+	FailedAttempts []TaskAttemptFailure `json:"failedAttempts,omitempty"`
 
 	// ObservedGeneration captures the latest generation of the CFTask that has been reconciled
 	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
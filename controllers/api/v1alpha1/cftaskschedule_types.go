@@ -0,0 +1,113 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	CFTaskScheduleGUIDLabelKey = "korifi.cloudfoundry.org/task-schedule-guid"
+
+	ConcurrencyPolicyAllow   = "Allow"
+	ConcurrencyPolicyForbid  = "Forbid"
+	ConcurrencyPolicyReplace = "Replace"
+)
+
+// CFTaskScheduleSpec defines the desired state of CFTaskSchedule
+type CFTaskScheduleSpec struct {
+	// The name of the schedule, defaults to the schedule GUID
+	// +optional
+	DisplayName string `json:"displayName,omitempty"`
+	// A reference to the CFApp whose current droplet the scheduled tasks run
+	// +kubebuilder:validation:Required
+	AppRef corev1.LocalObjectReference `json:"appRef"`
+	// The command used to start the task process
+	// +kubebuilder:validation:Required
+	Command string `json:"command"`
+	// The schedule in cron format, e.g. "0 * * * *"
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Schedule string `json:"schedule"`
+	// What to do when a run is due while the previous one is still running
+	// +kubebuilder:validation:Enum=Allow;Forbid;Replace
+	// +kubebuilder:default=Allow
+	// +optional
+	ConcurrencyPolicy string `json:"concurrencyPolicy,omitempty"`
+	// Suspended schedules do not start new runs
+	// +optional
+	Suspend bool `json:"suspend,omitempty"`
+	// The memory limit of each run in MB, defaults to the default process memory
+	// +optional
+	MemoryMB int64 `json:"memoryMB,omitempty"`
+	// The disk limit of each run in MB, defaults to the default process disk quota
+	// +optional
+	DiskQuotaMB int64 `json:"diskQuotaMB,omitempty"`
+	// The number of seconds each run may take before it is failed. Unlimited if not set
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	TimeoutSeconds int64 `json:"timeoutSeconds,omitempty"`
+}
+
+// CFTaskScheduleStatus defines the observed state of CFTaskSchedule
+type CFTaskScheduleStatus struct {
+	//+kubebuilder:validation:Optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// The last time a run was started
+	// +optional
+	LastScheduleTime *metav1.Time `json:"lastScheduleTime,omitempty"`
+
+	// ObservedGeneration captures the latest generation of the CFTaskSchedule that has been reconciled
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Display Name",type=string,JSONPath=`.spec.displayName`
+//+kubebuilder:printcolumn:name="Schedule",type=string,JSONPath=`.spec.schedule`
+//+kubebuilder:printcolumn:name="Suspended",type=boolean,JSONPath=`.spec.suspend`
+//+kubebuilder:printcolumn:name="Last Schedule",type=date,JSONPath=`.status.lastScheduleTime`
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CFTaskSchedule is the Schema for the cftaskschedules API
+type CFTaskSchedule struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   CFTaskScheduleSpec   `json:"spec,omitempty"`
+	Status CFTaskScheduleStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CFTaskScheduleList contains a list of CFTaskSchedule
+type CFTaskScheduleList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CFTaskSchedule `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&CFTaskSchedule{}, &CFTaskScheduleList{})
+}
+
+func (s *CFTaskSchedule) StatusConditions() *[]metav1.Condition {
+	return &s.Status.Conditions
+}
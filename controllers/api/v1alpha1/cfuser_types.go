@@ -0,0 +1,71 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	UserOriginOIDC           = "oidc"
+	UserOriginCert           = "cert"
+	UserOriginServiceAccount = "service-account"
+)
+
+// CFUserSpec defines the desired state of CFUser
+type CFUserSpec struct {
+	// The name of the identity as seen by the Kubernetes API, e.g. `oidc:alice` or `system:serviceaccount:ns:name`
+	// +kubebuilder:validation:MinLength=1
+	Username string `json:"username"`
+
+	// The mechanism the identity authenticates with
+	// +kubebuilder:validation:Enum=oidc;cert;service-account
+	Origin string `json:"origin"`
+
+	// The name of the user as displayed by clients, defaults to the username
+	// +kubebuilder:validation:Optional
+	PresentationName string `json:"presentationName,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:resource:scope=Namespaced
+//+kubebuilder:printcolumn:name="Username",type=string,JSONPath=`.spec.username`
+//+kubebuilder:printcolumn:name="Origin",type=string,JSONPath=`.spec.origin`
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=`.metadata.creationTimestamp`
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CFUser is the Schema for the cfusers API
+type CFUser struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec CFUserSpec `json:"spec,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CFUserList contains a list of CFUser
+type CFUserList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CFUser `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&CFUser{}, &CFUserList{})
+}
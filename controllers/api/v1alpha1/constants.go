@@ -2,6 +2,7 @@ package v1alpha1
 
 const (
 	BuildpackLifecycle LifecycleType = "buildpack"
+	DockerLifecycle    LifecycleType = "docker"
 	DockerPackage      PackageType   = "docker"
 
 	StartedState AppState = "STARTED"
@@ -12,4 +13,9 @@ const (
 	ProcessHealthCheckType HealthCheckType = "process"
 
 	StatusConditionReady = "Ready"
+
+	// OrphanMitigationInProgressCondition is true while the platform is
+	// deleting a resource the broker might have created in response to an
+	// ambiguous provision or bind request
+	OrphanMitigationInProgressCondition = "OrphanMitigationInProgress"
 )
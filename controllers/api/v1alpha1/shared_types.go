@@ -100,4 +100,40 @@ type ServiceBinding struct {
 
 	// Name of the binding secret
 	Secret string `json:"secret"`
+
+	// Volumes provided by the binding to be mounted into the app workload
+	// +kubebuilder:validation:Optional
+	VolumeMounts []ServiceVolumeMount `json:"volumeMounts,omitempty"`
+}
+
+// +kubebuilder:validation:Enum=r;rw
+type VolumeMountMode string
+
+const (
+	VolumeMountModeReadOnly  VolumeMountMode = "r"
+	VolumeMountModeReadWrite VolumeMountMode = "rw"
+)
+
+// Volume service drivers that mount NFS shares. The mount config source of
+// their volume mounts is an nfs://<server>/<path> URL
+var NFSVolumeDrivers = []string{"nfs", "nfsdriver", "nfsv3driver"}
+
+type ServiceVolumeMount struct {
+	// The driver used to mount the volume. `nfs` volumes are mounted
+	// natively, any other driver is expected to be the name of a CSI driver
+	// installed on the cluster
+	Driver string `json:"driver"`
+
+	// The absolute path the volume is mounted at in the app container
+	ContainerDir string `json:"containerDir"`
+
+	// Whether the volume is mounted read-only (`r`) or read-write (`rw`)
+	Mode VolumeMountMode `json:"mode"`
+
+	// The ID of the volume, as returned by the broker
+	VolumeID string `json:"volumeID"`
+
+	// Driver specific mount configuration, as returned by the broker
+	// +kubebuilder:validation:Optional
+	MountConfig map[string]string `json:"mountConfig,omitempty"`
 }
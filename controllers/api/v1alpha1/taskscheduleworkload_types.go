@@ -0,0 +1,88 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// TaskScheduleWorkloadSpec defines the desired state of TaskScheduleWorkload
+type TaskScheduleWorkloadSpec struct {
+	// The schedule in cron format
+	// +kubebuilder:validation:Required
+	Schedule string `json:"schedule"`
+
+	// +kubebuilder:validation:Enum=Allow;Forbid;Replace
+	// +kubebuilder:validation:Optional
+	ConcurrencyPolicy string `json:"concurrencyPolicy,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	Suspend bool `json:"suspend,omitempty"`
+
+	// The workload each run executes
+	// +kubebuilder:validation:Required
+	Template TaskWorkloadSpec `json:"template"`
+}
+
+// TaskScheduleWorkloadStatus defines the observed state of TaskScheduleWorkload
+type TaskScheduleWorkloadStatus struct {
+	//+kubebuilder:validation:Optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// The last time a run was started
+	// +kubebuilder:validation:Optional
+	LastScheduleTime *metav1.Time `json:"lastScheduleTime,omitempty"`
+
+	// The names of the runs that have not been recorded as TaskWorkloads
+	// yet. A TaskWorkload with the same name adopts each run.
+	// +kubebuilder:validation:Optional
+	Runs []string `json:"runs,omitempty"`
+
+	// ObservedGeneration captures the latest generation of the TaskScheduleWorkload that has been reconciled
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// TaskScheduleWorkload is the Schema for the taskscheduleworkloads API
+type TaskScheduleWorkload struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   TaskScheduleWorkloadSpec   `json:"spec,omitempty"`
+	Status TaskScheduleWorkloadStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// TaskScheduleWorkloadList contains a list of TaskScheduleWorkload
+type TaskScheduleWorkloadList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []TaskScheduleWorkload `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&TaskScheduleWorkload{}, &TaskScheduleWorkloadList{})
+}
+
+func (t *TaskScheduleWorkload) StatusConditions() *[]metav1.Condition {
+	return &t.Status.Conditions
+}
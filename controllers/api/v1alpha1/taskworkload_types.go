@@ -37,6 +37,33 @@ type TaskWorkloadSpec struct {
 
 	// +kubebuilder:validation:Optional
 	Env []corev1.EnvVar `json:"env"`
+
+	// The node selector for the task, derived from the isolation segment of the space
+	// +kubebuilder:validation:Optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// The tolerations for the task, derived from the isolation segment of the space
+	// +kubebuilder:validation:Optional
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+
+	// The number of seconds the task may run for before it is failed
+	// +kubebuilder:validation:Optional
+	ActiveDeadlineSeconds *int64 `json:"activeDeadlineSeconds,omitempty"`
+
+	// How the task is retried when it fails, not retried if not set
+	// +kubebuilder:validation:Optional
+	RetryPolicy *TaskWorkloadRetryPolicy `json:"retryPolicy,omitempty"`
+}
+
+// TaskWorkloadRetryPolicy maps to the backoff limit and pod failure policy of the task job
+type TaskWorkloadRetryPolicy struct {
+	// The number of retries before the task is failed
+	// +kubebuilder:validation:Minimum=0
+	BackoffLimit int32 `json:"backoffLimit"`
+
+	// Exit codes of the task that fail it without further retries
+	// +kubebuilder:validation:Optional
+	NonRetryableExitCodes []int32 `json:"nonRetryableExitCodes,omitempty"`
 }
 
 // TaskWorkloadStatus defines the observed state of TaskWorkload
@@ -44,6 +71,14 @@ type TaskWorkloadStatus struct {
 	//+kubebuilder:validation:Optional
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
 
+	// The number of attempts at running the task so far
+	//+kubebuilder:validation:Optional
+	Attempts int32 `json:"attempts,omitempty"`
+
+	// The failed attempts at running the task, oldest first
+	//+kubebuilder:validation:Optional
+	FailedAttempts []TaskAttemptFailure `json:"failedAttempts,omitempty"`
+
 	// ObservedGeneration captures the latest generation of the TaskWorkload that has been reconciled
 	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
 }
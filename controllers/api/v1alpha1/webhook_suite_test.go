@@ -101,6 +101,7 @@ var _ = BeforeSuite(func() {
 	Expect(korifiv1alpha1.NewCFAppDefaulter().SetupWebhookWithManager(k8sManager)).To(Succeed())
 	Expect(apps.NewValidator(
 		validation.NewDuplicateValidator(coordination.NewNameRegistry(uncachedClient, apps.AppEntityType)),
+		validation.NewFeatureFlagValidator(uncachedClient, namespace),
 	).SetupWebhookWithManager(k8sManager)).To(Succeed())
 
 	Expect(korifiv1alpha1.NewCFRouteDefaulter().SetupWebhookWithManager(k8sManager)).To(Succeed())
@@ -53,6 +53,28 @@ func (in *AppWorkload) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AppWorkloadBackend) DeepCopyInto(out *AppWorkloadBackend) {
+	*out = *in
+	if in.RequestHeaders != nil {
+		in, out := &in.RequestHeaders, &out.RequestHeaders
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AppWorkloadBackend.
+func (in *AppWorkloadBackend) DeepCopy() *AppWorkloadBackend {
+	if in == nil {
+		return nil
+	}
+	out := new(AppWorkloadBackend)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AppWorkloadList) DeepCopyInto(out *AppWorkloadList) {
 	*out = *in
@@ -129,7 +151,28 @@ func (in *AppWorkloadSpec) DeepCopyInto(out *AppWorkloadSpec) {
 	if in.Services != nil {
 		in, out := &in.Services, &out.Services
 		*out = make([]ServiceBinding, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]v1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Autoscaling != nil {
+		in, out := &in.Autoscaling, &out.Autoscaling
+		*out = new(AutoscalingPolicy)
+		(*in).DeepCopyInto(*out)
 	}
 }
 
@@ -160,6 +203,11 @@ func (in *AppWorkloadStatus) DeepCopyInto(out *AppWorkloadStatus) {
 			(*out)[key] = *val.DeepCopy()
 		}
 	}
+	if in.Backend != nil {
+		in, out := &in.Backend, &out.Backend
+		*out = new(AppWorkloadBackend)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AppWorkloadStatus.
@@ -172,6 +220,58 @@ func (in *AppWorkloadStatus) DeepCopy() *AppWorkloadStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutoscalingPolicy) DeepCopyInto(out *AutoscalingPolicy) {
+	*out = *in
+	if in.CPUUtilization != nil {
+		in, out := &in.CPUUtilization, &out.CPUUtilization
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MemoryUtilization != nil {
+		in, out := &in.MemoryUtilization, &out.MemoryUtilization
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Schedules != nil {
+		in, out := &in.Schedules, &out.Schedules
+		*out = make([]AutoscalingSchedule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoscalingPolicy.
+func (in *AutoscalingPolicy) DeepCopy() *AutoscalingPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(AutoscalingPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutoscalingSchedule) DeepCopyInto(out *AutoscalingSchedule) {
+	*out = *in
+	if in.DaysOfWeek != nil {
+		in, out := &in.DaysOfWeek, &out.DaysOfWeek
+		*out = make([]int32, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoscalingSchedule.
+func (in *AutoscalingSchedule) DeepCopy() *AutoscalingSchedule {
+	if in == nil {
+		return nil
+	}
+	out := new(AutoscalingSchedule)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BuildDropletStatus) DeepCopyInto(out *BuildDropletStatus) {
 	*out = *in
@@ -279,6 +379,20 @@ func (in *BuildWorkloadSpec) DeepCopyInto(out *BuildWorkloadSpec) {
 		*out = make([]v1.ObjectReference, len(*in))
 		copy(*out, *in)
 	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]v1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BuildWorkloadSpec.
@@ -566,7 +680,9 @@ func (in *CFAppStatus) DeepCopyInto(out *CFAppStatus) {
 	if in.ServiceBindings != nil {
 		in, out := &in.ServiceBindings, &out.ServiceBindings
 		*out = make([]ServiceBinding, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
@@ -580,6 +696,79 @@ func (in *CFAppStatus) DeepCopy() *CFAppStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFAppUsageEvent) DeepCopyInto(out *CFAppUsageEvent) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFAppUsageEvent.
+func (in *CFAppUsageEvent) DeepCopy() *CFAppUsageEvent {
+	if in == nil {
+		return nil
+	}
+	out := new(CFAppUsageEvent)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CFAppUsageEvent) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFAppUsageEventList) DeepCopyInto(out *CFAppUsageEventList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CFAppUsageEvent, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFAppUsageEventList.
+func (in *CFAppUsageEventList) DeepCopy() *CFAppUsageEventList {
+	if in == nil {
+		return nil
+	}
+	out := new(CFAppUsageEventList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CFAppUsageEventList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFAppUsageEventSpec) DeepCopyInto(out *CFAppUsageEventSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFAppUsageEventSpec.
+func (in *CFAppUsageEventSpec) DeepCopy() *CFAppUsageEventSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CFAppUsageEventSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CFBuild) DeepCopyInto(out *CFBuild) {
 	*out = *in
@@ -796,26 +985,25 @@ func (in *CFDomainStatus) DeepCopy() *CFDomainStatus {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFOrg) DeepCopyInto(out *CFOrg) {
+func (in *CFEnvironmentVariableGroup) DeepCopyInto(out *CFEnvironmentVariableGroup) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
-	in.Status.DeepCopyInto(&out.Status)
+	in.Spec.DeepCopyInto(&out.Spec)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFOrg.
-func (in *CFOrg) DeepCopy() *CFOrg {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFEnvironmentVariableGroup.
+func (in *CFEnvironmentVariableGroup) DeepCopy() *CFEnvironmentVariableGroup {
 	if in == nil {
 		return nil
 	}
-	out := new(CFOrg)
+	out := new(CFEnvironmentVariableGroup)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *CFOrg) DeepCopyObject() runtime.Object {
+func (in *CFEnvironmentVariableGroup) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -823,31 +1011,31 @@ func (in *CFOrg) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFOrgList) DeepCopyInto(out *CFOrgList) {
+func (in *CFEnvironmentVariableGroupList) DeepCopyInto(out *CFEnvironmentVariableGroupList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]CFOrg, len(*in))
+		*out = make([]CFEnvironmentVariableGroup, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFOrgList.
-func (in *CFOrgList) DeepCopy() *CFOrgList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFEnvironmentVariableGroupList.
+func (in *CFEnvironmentVariableGroupList) DeepCopy() *CFEnvironmentVariableGroupList {
 	if in == nil {
 		return nil
 	}
-	out := new(CFOrgList)
+	out := new(CFEnvironmentVariableGroupList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *CFOrgList) DeepCopyObject() runtime.Object {
+func (in *CFEnvironmentVariableGroupList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -855,63 +1043,47 @@ func (in *CFOrgList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFOrgSpec) DeepCopyInto(out *CFOrgSpec) {
-	*out = *in
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFOrgSpec.
-func (in *CFOrgSpec) DeepCopy() *CFOrgSpec {
-	if in == nil {
-		return nil
-	}
-	out := new(CFOrgSpec)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFOrgStatus) DeepCopyInto(out *CFOrgStatus) {
+func (in *CFEnvironmentVariableGroupSpec) DeepCopyInto(out *CFEnvironmentVariableGroupSpec) {
 	*out = *in
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]metav1.Condition, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
+	if in.EnvironmentVariables != nil {
+		in, out := &in.EnvironmentVariables, &out.EnvironmentVariables
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFOrgStatus.
-func (in *CFOrgStatus) DeepCopy() *CFOrgStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFEnvironmentVariableGroupSpec.
+func (in *CFEnvironmentVariableGroupSpec) DeepCopy() *CFEnvironmentVariableGroupSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(CFOrgStatus)
+	out := new(CFEnvironmentVariableGroupSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFPackage) DeepCopyInto(out *CFPackage) {
+func (in *CFFeatureFlag) DeepCopyInto(out *CFFeatureFlag) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
+	out.Spec = in.Spec
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFPackage.
-func (in *CFPackage) DeepCopy() *CFPackage {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFFeatureFlag.
+func (in *CFFeatureFlag) DeepCopy() *CFFeatureFlag {
 	if in == nil {
 		return nil
 	}
-	out := new(CFPackage)
+	out := new(CFFeatureFlag)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *CFPackage) DeepCopyObject() runtime.Object {
+func (in *CFFeatureFlag) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -919,46 +1091,31 @@ func (in *CFPackage) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFPackageDefaulter) DeepCopyInto(out *CFPackageDefaulter) {
-	*out = *in
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFPackageDefaulter.
-func (in *CFPackageDefaulter) DeepCopy() *CFPackageDefaulter {
-	if in == nil {
-		return nil
-	}
-	out := new(CFPackageDefaulter)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFPackageList) DeepCopyInto(out *CFPackageList) {
+func (in *CFFeatureFlagList) DeepCopyInto(out *CFFeatureFlagList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]CFPackage, len(*in))
+		*out = make([]CFFeatureFlag, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFPackageList.
-func (in *CFPackageList) DeepCopy() *CFPackageList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFFeatureFlagList.
+func (in *CFFeatureFlagList) DeepCopy() *CFFeatureFlagList {
 	if in == nil {
 		return nil
 	}
-	out := new(CFPackageList)
+	out := new(CFFeatureFlagList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *CFPackageList) DeepCopyObject() runtime.Object {
+func (in *CFFeatureFlagList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -966,65 +1123,40 @@ func (in *CFPackageList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFPackageSpec) DeepCopyInto(out *CFPackageSpec) {
-	*out = *in
-	out.AppRef = in.AppRef
-	in.Source.DeepCopyInto(&out.Source)
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFPackageSpec.
-func (in *CFPackageSpec) DeepCopy() *CFPackageSpec {
-	if in == nil {
-		return nil
-	}
-	out := new(CFPackageSpec)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFPackageStatus) DeepCopyInto(out *CFPackageStatus) {
+func (in *CFFeatureFlagSpec) DeepCopyInto(out *CFFeatureFlagSpec) {
 	*out = *in
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]metav1.Condition, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFPackageStatus.
-func (in *CFPackageStatus) DeepCopy() *CFPackageStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFFeatureFlagSpec.
+func (in *CFFeatureFlagSpec) DeepCopy() *CFFeatureFlagSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(CFPackageStatus)
+	out := new(CFFeatureFlagSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFProcess) DeepCopyInto(out *CFProcess) {
+func (in *CFIsolationSegment) DeepCopyInto(out *CFIsolationSegment) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFProcess.
-func (in *CFProcess) DeepCopy() *CFProcess {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFIsolationSegment.
+func (in *CFIsolationSegment) DeepCopy() *CFIsolationSegment {
 	if in == nil {
 		return nil
 	}
-	out := new(CFProcess)
+	out := new(CFIsolationSegment)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *CFProcess) DeepCopyObject() runtime.Object {
+func (in *CFIsolationSegment) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1032,46 +1164,348 @@ func (in *CFProcess) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFProcessDefaulter) DeepCopyInto(out *CFProcessDefaulter) {
-	*out = *in
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFProcessDefaulter.
-func (in *CFProcessDefaulter) DeepCopy() *CFProcessDefaulter {
-	if in == nil {
-		return nil
-	}
-	out := new(CFProcessDefaulter)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFProcessList) DeepCopyInto(out *CFProcessList) {
+func (in *CFIsolationSegmentList) DeepCopyInto(out *CFIsolationSegmentList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]CFProcess, len(*in))
+		*out = make([]CFIsolationSegment, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFProcessList.
-func (in *CFProcessList) DeepCopy() *CFProcessList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFIsolationSegmentList.
+func (in *CFIsolationSegmentList) DeepCopy() *CFIsolationSegmentList {
 	if in == nil {
 		return nil
 	}
-	out := new(CFProcessList)
+	out := new(CFIsolationSegmentList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *CFProcessList) DeepCopyObject() runtime.Object {
+func (in *CFIsolationSegmentList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFIsolationSegmentSpec) DeepCopyInto(out *CFIsolationSegmentSpec) {
+	*out = *in
+	if in.Organizations != nil {
+		in, out := &in.Organizations, &out.Organizations
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]v1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFIsolationSegmentSpec.
+func (in *CFIsolationSegmentSpec) DeepCopy() *CFIsolationSegmentSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CFIsolationSegmentSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFOrg) DeepCopyInto(out *CFOrg) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFOrg.
+func (in *CFOrg) DeepCopy() *CFOrg {
+	if in == nil {
+		return nil
+	}
+	out := new(CFOrg)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CFOrg) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFOrgList) DeepCopyInto(out *CFOrgList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CFOrg, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFOrgList.
+func (in *CFOrgList) DeepCopy() *CFOrgList {
+	if in == nil {
+		return nil
+	}
+	out := new(CFOrgList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CFOrgList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFOrgSpec) DeepCopyInto(out *CFOrgSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFOrgSpec.
+func (in *CFOrgSpec) DeepCopy() *CFOrgSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CFOrgSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFOrgStatus) DeepCopyInto(out *CFOrgStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFOrgStatus.
+func (in *CFOrgStatus) DeepCopy() *CFOrgStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CFOrgStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFPackage) DeepCopyInto(out *CFPackage) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFPackage.
+func (in *CFPackage) DeepCopy() *CFPackage {
+	if in == nil {
+		return nil
+	}
+	out := new(CFPackage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CFPackage) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFPackageDefaulter) DeepCopyInto(out *CFPackageDefaulter) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFPackageDefaulter.
+func (in *CFPackageDefaulter) DeepCopy() *CFPackageDefaulter {
+	if in == nil {
+		return nil
+	}
+	out := new(CFPackageDefaulter)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFPackageList) DeepCopyInto(out *CFPackageList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CFPackage, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFPackageList.
+func (in *CFPackageList) DeepCopy() *CFPackageList {
+	if in == nil {
+		return nil
+	}
+	out := new(CFPackageList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CFPackageList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFPackageSpec) DeepCopyInto(out *CFPackageSpec) {
+	*out = *in
+	out.AppRef = in.AppRef
+	in.Source.DeepCopyInto(&out.Source)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFPackageSpec.
+func (in *CFPackageSpec) DeepCopy() *CFPackageSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CFPackageSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFPackageStatus) DeepCopyInto(out *CFPackageStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFPackageStatus.
+func (in *CFPackageStatus) DeepCopy() *CFPackageStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CFPackageStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFProcess) DeepCopyInto(out *CFProcess) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFProcess.
+func (in *CFProcess) DeepCopy() *CFProcess {
+	if in == nil {
+		return nil
+	}
+	out := new(CFProcess)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CFProcess) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFProcessDefaulter) DeepCopyInto(out *CFProcessDefaulter) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFProcessDefaulter.
+func (in *CFProcessDefaulter) DeepCopy() *CFProcessDefaulter {
+	if in == nil {
+		return nil
+	}
+	out := new(CFProcessDefaulter)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFProcessList) DeepCopyInto(out *CFProcessList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CFProcess, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFProcessList.
+func (in *CFProcessList) DeepCopy() *CFProcessList {
+	if in == nil {
+		return nil
+	}
+	out := new(CFProcessList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CFProcessList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1093,6 +1527,11 @@ func (in *CFProcessSpec) DeepCopyInto(out *CFProcessSpec) {
 		*out = make([]int32, len(*in))
 		copy(*out, *in)
 	}
+	if in.Autoscaling != nil {
+		in, out := &in.Autoscaling, &out.Autoscaling
+		*out = new(AutoscalingPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFProcessSpec.
@@ -1122,6 +1561,11 @@ func (in *CFProcessStatus) DeepCopyInto(out *CFProcessStatus) {
 			(*out)[key] = *val.DeepCopy()
 		}
 	}
+	if in.Usage != nil {
+		in, out := &in.Usage, &out.Usage
+		*out = new(ProcessUsage)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFProcessStatus.
@@ -1354,6 +1798,25 @@ func (in *CFServiceBindingStatus) DeepCopyInto(out *CFServiceBindingStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.RetiredBindings != nil {
+		in, out := &in.RetiredBindings, &out.RetiredBindings
+		*out = make([]RetiredServiceBinding, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.VolumeMounts != nil {
+		in, out := &in.VolumeMounts, &out.VolumeMounts
+		*out = make([]ServiceVolumeMount, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.OrphanMitigationOperation != nil {
+		in, out := &in.OrphanMitigationOperation, &out.OrphanMitigationOperation
+		*out = new(string)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFServiceBindingStatus.
@@ -1371,7 +1834,7 @@ func (in *CFServiceBroker) DeepCopyInto(out *CFServiceBroker) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 	in.Status.DeepCopyInto(&out.Status)
 }
 
@@ -1430,6 +1893,10 @@ func (in *CFServiceBrokerSpec) DeepCopyInto(out *CFServiceBrokerSpec) {
 	*out = *in
 	out.ServiceBroker = in.ServiceBroker
 	out.Credentials = in.Credentials
+	if in.CatalogRefreshRequestedAt != nil {
+		in, out := &in.CatalogRefreshRequestedAt, &out.CatalogRefreshRequestedAt
+		*out = (*in).DeepCopy()
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFServiceBrokerSpec.
@@ -1526,6 +1993,11 @@ func (in *CFServiceInstanceList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CFServiceInstanceSpec) DeepCopyInto(out *CFServiceInstanceSpec) {
 	*out = *in
+	if in.ServiceRef != nil {
+		in, out := &in.ServiceRef, &out.ServiceRef
+		*out = new(v1.ObjectReference)
+		**out = **in
+	}
 	if in.ServiceLabel != nil {
 		in, out := &in.ServiceLabel, &out.ServiceLabel
 		*out = new(string)
@@ -1560,8 +2032,23 @@ func (in *CFServiceInstanceStatus) DeepCopyInto(out *CFServiceInstanceStatus) {
 		}
 	}
 	out.Credentials = in.Credentials
+	if in.ServiceSecret != nil {
+		in, out := &in.ServiceSecret, &out.ServiceSecret
+		*out = new(v1.SecretReference)
+		**out = **in
+	}
 	out.LastOperation = in.LastOperation
 	out.MaintenanceInfo = in.MaintenanceInfo
+	if in.Usage != nil {
+		in, out := &in.Usage, &out.Usage
+		*out = new(ServiceInstanceUsage)
+		**out = **in
+	}
+	if in.OrphanMitigationOperation != nil {
+		in, out := &in.OrphanMitigationOperation, &out.OrphanMitigationOperation
+		*out = new(string)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFServiceInstanceStatus.
@@ -1723,6 +2210,79 @@ func (in *CFServicePlanSpec) DeepCopy() *CFServicePlanSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFServiceUsageEvent) DeepCopyInto(out *CFServiceUsageEvent) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFServiceUsageEvent.
+func (in *CFServiceUsageEvent) DeepCopy() *CFServiceUsageEvent {
+	if in == nil {
+		return nil
+	}
+	out := new(CFServiceUsageEvent)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CFServiceUsageEvent) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFServiceUsageEventList) DeepCopyInto(out *CFServiceUsageEventList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CFServiceUsageEvent, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFServiceUsageEventList.
+func (in *CFServiceUsageEventList) DeepCopy() *CFServiceUsageEventList {
+	if in == nil {
+		return nil
+	}
+	out := new(CFServiceUsageEventList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CFServiceUsageEventList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFServiceUsageEventSpec) DeepCopyInto(out *CFServiceUsageEventSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFServiceUsageEventSpec.
+func (in *CFServiceUsageEventSpec) DeepCopy() *CFServiceUsageEventSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CFServiceUsageEventSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CFSpace) DeepCopyInto(out *CFSpace) {
 	*out = *in
@@ -1783,22 +2343,245 @@ func (in *CFSpaceList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFSpaceSpec) DeepCopyInto(out *CFSpaceSpec) {
+func (in *CFSpaceSpec) DeepCopyInto(out *CFSpaceSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFSpaceSpec.
+func (in *CFSpaceSpec) DeepCopy() *CFSpaceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CFSpaceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFSpaceStatus) DeepCopyInto(out *CFSpaceStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]v1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFSpaceStatus.
+func (in *CFSpaceStatus) DeepCopy() *CFSpaceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CFSpaceStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFTask) DeepCopyInto(out *CFTask) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFTask.
+func (in *CFTask) DeepCopy() *CFTask {
+	if in == nil {
+		return nil
+	}
+	out := new(CFTask)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CFTask) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFTaskList) DeepCopyInto(out *CFTaskList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CFTask, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFTaskList.
+func (in *CFTaskList) DeepCopy() *CFTaskList {
+	if in == nil {
+		return nil
+	}
+	out := new(CFTaskList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CFTaskList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFTaskSchedule) DeepCopyInto(out *CFTaskSchedule) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFTaskSchedule.
+func (in *CFTaskSchedule) DeepCopy() *CFTaskSchedule {
+	if in == nil {
+		return nil
+	}
+	out := new(CFTaskSchedule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CFTaskSchedule) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFTaskScheduleList) DeepCopyInto(out *CFTaskScheduleList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CFTaskSchedule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFTaskScheduleList.
+func (in *CFTaskScheduleList) DeepCopy() *CFTaskScheduleList {
+	if in == nil {
+		return nil
+	}
+	out := new(CFTaskScheduleList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CFTaskScheduleList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFTaskScheduleSpec) DeepCopyInto(out *CFTaskScheduleSpec) {
+	*out = *in
+	out.AppRef = in.AppRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFTaskScheduleSpec.
+func (in *CFTaskScheduleSpec) DeepCopy() *CFTaskScheduleSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CFTaskScheduleSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFTaskScheduleStatus) DeepCopyInto(out *CFTaskScheduleStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LastScheduleTime != nil {
+		in, out := &in.LastScheduleTime, &out.LastScheduleTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFTaskScheduleStatus.
+func (in *CFTaskScheduleStatus) DeepCopy() *CFTaskScheduleStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CFTaskScheduleStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFTaskSpec) DeepCopyInto(out *CFTaskSpec) {
 	*out = *in
+	out.AppRef = in.AppRef
+	if in.LogRateLimitBytesPerSecond != nil {
+		in, out := &in.LogRateLimitBytesPerSecond, &out.LogRateLimitBytesPerSecond
+		*out = new(int64)
+		**out = **in
+	}
+	out.DropletRef = in.DropletRef
+	if in.Retry != nil {
+		in, out := &in.Retry, &out.Retry
+		*out = new(TaskRetryPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFSpaceSpec.
-func (in *CFSpaceSpec) DeepCopy() *CFSpaceSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFTaskSpec.
+func (in *CFTaskSpec) DeepCopy() *CFTaskSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(CFSpaceSpec)
+	out := new(CFTaskSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFSpaceStatus) DeepCopyInto(out *CFSpaceStatus) {
+func (in *CFTaskStatus) DeepCopyInto(out *CFTaskStatus) {
 	*out = *in
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
@@ -1807,39 +2590,46 @@ func (in *CFSpaceStatus) DeepCopyInto(out *CFSpaceStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	out.DropletRef = in.DropletRef
+	if in.FailedAttempts != nil {
+		in, out := &in.FailedAttempts, &out.FailedAttempts
+		*out = make([]TaskAttemptFailure, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFSpaceStatus.
-func (in *CFSpaceStatus) DeepCopy() *CFSpaceStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFTaskStatus.
+func (in *CFTaskStatus) DeepCopy() *CFTaskStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(CFSpaceStatus)
+	out := new(CFTaskStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFTask) DeepCopyInto(out *CFTask) {
+func (in *CFUser) DeepCopyInto(out *CFUser) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	out.Spec = in.Spec
-	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFTask.
-func (in *CFTask) DeepCopy() *CFTask {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFUser.
+func (in *CFUser) DeepCopy() *CFUser {
 	if in == nil {
 		return nil
 	}
-	out := new(CFTask)
+	out := new(CFUser)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *CFTask) DeepCopyObject() runtime.Object {
+func (in *CFUser) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1847,31 +2637,31 @@ func (in *CFTask) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFTaskList) DeepCopyInto(out *CFTaskList) {
+func (in *CFUserList) DeepCopyInto(out *CFUserList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]CFTask, len(*in))
+		*out = make([]CFUser, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFTaskList.
-func (in *CFTaskList) DeepCopy() *CFTaskList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFUserList.
+func (in *CFUserList) DeepCopy() *CFUserList {
 	if in == nil {
 		return nil
 	}
-	out := new(CFTaskList)
+	out := new(CFUserList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *CFTaskList) DeepCopyObject() runtime.Object {
+func (in *CFUserList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1879,40 +2669,16 @@ func (in *CFTaskList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFTaskSpec) DeepCopyInto(out *CFTaskSpec) {
-	*out = *in
-	out.AppRef = in.AppRef
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFTaskSpec.
-func (in *CFTaskSpec) DeepCopy() *CFTaskSpec {
-	if in == nil {
-		return nil
-	}
-	out := new(CFTaskSpec)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFTaskStatus) DeepCopyInto(out *CFTaskStatus) {
+func (in *CFUserSpec) DeepCopyInto(out *CFUserSpec) {
 	*out = *in
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]metav1.Condition, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	out.DropletRef = in.DropletRef
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFTaskStatus.
-func (in *CFTaskStatus) DeepCopy() *CFTaskStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFUserSpec.
+func (in *CFUserSpec) DeepCopy() *CFUserSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(CFTaskStatus)
+	out := new(CFUserSpec)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -2060,6 +2826,21 @@ func (in *ProcessType) DeepCopy() *ProcessType {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProcessUsage) DeepCopyInto(out *ProcessUsage) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProcessUsage.
+func (in *ProcessUsage) DeepCopy() *ProcessUsage {
+	if in == nil {
+		return nil
+	}
+	out := new(ProcessUsage)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Registry) DeepCopyInto(out *Registry) {
 	*out = *in
@@ -2095,6 +2876,22 @@ func (in *RequiredLocalObjectReference) DeepCopy() *RequiredLocalObjectReference
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RetiredServiceBinding) DeepCopyInto(out *RetiredServiceBinding) {
+	*out = *in
+	in.RetiredAt.DeepCopyInto(&out.RetiredAt)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RetiredServiceBinding.
+func (in *RetiredServiceBinding) DeepCopy() *RetiredServiceBinding {
+	if in == nil {
+		return nil
+	}
+	out := new(RetiredServiceBinding)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RunnerInfo) DeepCopyInto(out *RunnerInfo) {
 	*out = *in
@@ -2210,6 +3007,13 @@ func (in *RunnerInfoStatus) DeepCopy() *RunnerInfoStatus {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ServiceBinding) DeepCopyInto(out *ServiceBinding) {
 	*out = *in
+	if in.VolumeMounts != nil {
+		in, out := &in.VolumeMounts, &out.VolumeMounts
+		*out = make([]ServiceVolumeMount, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceBinding.
@@ -2222,6 +3026,21 @@ func (in *ServiceBinding) DeepCopy() *ServiceBinding {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceInstanceUsage) DeepCopyInto(out *ServiceInstanceUsage) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceInstanceUsage.
+func (in *ServiceInstanceUsage) DeepCopy() *ServiceInstanceUsage {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceInstanceUsage)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ServicePlanVisibility) DeepCopyInto(out *ServicePlanVisibility) {
 	*out = *in
@@ -2242,6 +3061,170 @@ func (in *ServicePlanVisibility) DeepCopy() *ServicePlanVisibility {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceVolumeMount) DeepCopyInto(out *ServiceVolumeMount) {
+	*out = *in
+	if in.MountConfig != nil {
+		in, out := &in.MountConfig, &out.MountConfig
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceVolumeMount.
+func (in *ServiceVolumeMount) DeepCopy() *ServiceVolumeMount {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceVolumeMount)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TaskAttemptFailure) DeepCopyInto(out *TaskAttemptFailure) {
+	*out = *in
+	in.FinishedAt.DeepCopyInto(&out.FinishedAt)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TaskAttemptFailure.
+func (in *TaskAttemptFailure) DeepCopy() *TaskAttemptFailure {
+	if in == nil {
+		return nil
+	}
+	out := new(TaskAttemptFailure)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TaskRetryPolicy) DeepCopyInto(out *TaskRetryPolicy) {
+	*out = *in
+	if in.NonRetryableExitCodes != nil {
+		in, out := &in.NonRetryableExitCodes, &out.NonRetryableExitCodes
+		*out = make([]int32, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TaskRetryPolicy.
+func (in *TaskRetryPolicy) DeepCopy() *TaskRetryPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(TaskRetryPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TaskScheduleWorkload) DeepCopyInto(out *TaskScheduleWorkload) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TaskScheduleWorkload.
+func (in *TaskScheduleWorkload) DeepCopy() *TaskScheduleWorkload {
+	if in == nil {
+		return nil
+	}
+	out := new(TaskScheduleWorkload)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TaskScheduleWorkload) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TaskScheduleWorkloadList) DeepCopyInto(out *TaskScheduleWorkloadList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]TaskScheduleWorkload, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TaskScheduleWorkloadList.
+func (in *TaskScheduleWorkloadList) DeepCopy() *TaskScheduleWorkloadList {
+	if in == nil {
+		return nil
+	}
+	out := new(TaskScheduleWorkloadList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TaskScheduleWorkloadList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TaskScheduleWorkloadSpec) DeepCopyInto(out *TaskScheduleWorkloadSpec) {
+	*out = *in
+	in.Template.DeepCopyInto(&out.Template)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TaskScheduleWorkloadSpec.
+func (in *TaskScheduleWorkloadSpec) DeepCopy() *TaskScheduleWorkloadSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TaskScheduleWorkloadSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TaskScheduleWorkloadStatus) DeepCopyInto(out *TaskScheduleWorkloadStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LastScheduleTime != nil {
+		in, out := &in.LastScheduleTime, &out.LastScheduleTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Runs != nil {
+		in, out := &in.Runs, &out.Runs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TaskScheduleWorkloadStatus.
+func (in *TaskScheduleWorkloadStatus) DeepCopy() *TaskScheduleWorkloadStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TaskScheduleWorkloadStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TaskWorkload) DeepCopyInto(out *TaskWorkload) {
 	*out = *in
@@ -2301,6 +3284,26 @@ func (in *TaskWorkloadList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TaskWorkloadRetryPolicy) DeepCopyInto(out *TaskWorkloadRetryPolicy) {
+	*out = *in
+	if in.NonRetryableExitCodes != nil {
+		in, out := &in.NonRetryableExitCodes, &out.NonRetryableExitCodes
+		*out = make([]int32, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TaskWorkloadRetryPolicy.
+func (in *TaskWorkloadRetryPolicy) DeepCopy() *TaskWorkloadRetryPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(TaskWorkloadRetryPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TaskWorkloadSpec) DeepCopyInto(out *TaskWorkloadSpec) {
 	*out = *in
@@ -2322,6 +3325,30 @@ func (in *TaskWorkloadSpec) DeepCopyInto(out *TaskWorkloadSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]v1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ActiveDeadlineSeconds != nil {
+		in, out := &in.ActiveDeadlineSeconds, &out.ActiveDeadlineSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.RetryPolicy != nil {
+		in, out := &in.RetryPolicy, &out.RetryPolicy
+		*out = new(TaskWorkloadRetryPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TaskWorkloadSpec.
@@ -2344,6 +3371,13 @@ func (in *TaskWorkloadStatus) DeepCopyInto(out *TaskWorkloadStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.FailedAttempts != nil {
+		in, out := &in.FailedAttempts, &out.FailedAttempts
+		*out = make([]TaskAttemptFailure, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TaskWorkloadStatus.
@@ -3,22 +3,39 @@ package cleanup
 import (
 	"context"
 	"fmt"
+	"slices"
 	"sort"
+	"strings"
 
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools/image"
 	"github.com/go-logr/logr"
 	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+//counterfeiter:generate -o fake -fake-name ImageClient . ImageClient
+
+type ImageClient interface {
+	Tags(ctx context.Context, creds image.Creds, repoRef string) (map[string]string, error)
+	Delete(ctx context.Context, creds image.Creds, imageRef string, tagsToDelete ...string) error
+}
+
 type BuildCleaner struct {
-	k8sClient      client.Client
-	retainedBuilds int
+	k8sClient           client.Client
+	imageClient         ImageClient
+	registrySecretNames []string
+	retainedBuilds      int
 }
 
-func NewBuildCleaner(k8sClient client.Client, retainedBuilds int) BuildCleaner {
-	return BuildCleaner{k8sClient: k8sClient, retainedBuilds: retainedBuilds}
+func NewBuildCleaner(k8sClient client.Client, imageClient ImageClient, registrySecretNames []string, retainedBuilds int) BuildCleaner {
+	return BuildCleaner{
+		k8sClient:           k8sClient,
+		imageClient:         imageClient,
+		registrySecretNames: registrySecretNames,
+		retainedBuilds:      retainedBuilds,
+	}
 }
 
 func (c BuildCleaner) Clean(ctx context.Context, app types.NamespacedName) error {
@@ -61,7 +78,24 @@ func (c BuildCleaner) Clean(ctx context.Context, app types.NamespacedName) error
 		return deletableBuilds[j].CreationTimestamp.Before(&deletableBuilds[i].CreationTimestamp)
 	})
 
+	if len(deletableBuilds) <= c.retainedBuilds {
+		return nil
+	}
+
+	retainedDroplets := map[string]bool{}
+	for _, cfBuild := range cfBuilds.Items {
+		if !isBuildDeleted(cfBuild, deletableBuilds[c.retainedBuilds:]) {
+			retainedDroplets[dropletImage(cfBuild)] = true
+		}
+	}
+
 	for i := c.retainedBuilds; i < len(deletableBuilds); i++ {
+		// Builds are reproducible, so a droplet image may be shared with a
+		// build that is still around
+		if !retainedDroplets[dropletImage(deletableBuilds[i])] {
+			c.deleteDropletImage(ctx, log, &deletableBuilds[i])
+		}
+
 		log.Info("deleting deletable build", "buildGUID", deletableBuilds[i].Name)
 		err = c.k8sClient.Delete(ctx, &deletableBuilds[i])
 		if err != nil {
@@ -71,3 +105,62 @@ func (c BuildCleaner) Clean(ctx context.Context, app types.NamespacedName) error
 
 	return nil
 }
+
+// deleteDropletImage deletes the tags of the droplet image pushed by the
+// builder, so that the image itself gets deleted. Docker droplets refer to
+// user provided images and are left alone. Failures are only logged, as
+// orphaned images are eventually reclaimed by the registry sweeper.
+func (c BuildCleaner) deleteDropletImage(ctx context.Context, log logr.Logger, cfBuild *korifiv1alpha1.CFBuild) {
+	if cfBuild.Spec.Lifecycle.Type != korifiv1alpha1.BuildpackLifecycle || dropletImage(*cfBuild) == "" {
+		return
+	}
+
+	log = log.WithValues("buildGUID", cfBuild.Name, "dropletImage", dropletImage(*cfBuild))
+
+	repository, digest, isDigestRef := strings.Cut(dropletImage(*cfBuild), "@")
+	if !isDigestRef {
+		log.Info("droplet image is not a digest reference - skipping deletion")
+		return
+	}
+
+	creds := image.Creds{
+		Namespace:   cfBuild.Namespace,
+		SecretNames: c.registrySecretNames,
+	}
+
+	tags, err := c.imageClient.Tags(ctx, creds, repository)
+	if err != nil {
+		log.Info("failed to list droplet image tags", "reason", err)
+		return
+	}
+
+	if err = c.imageClient.Delete(ctx, creds, dropletImage(*cfBuild), tagsPointingTo(tags, digest)...); err != nil {
+		log.Info("failed to delete droplet image", "reason", err)
+	}
+}
+
+func isBuildDeleted(cfBuild korifiv1alpha1.CFBuild, deletedBuilds []korifiv1alpha1.CFBuild) bool {
+	return slices.ContainsFunc(deletedBuilds, func(deletedBuild korifiv1alpha1.CFBuild) bool {
+		return deletedBuild.Name == cfBuild.Name
+	})
+}
+
+func dropletImage(cfBuild korifiv1alpha1.CFBuild) string {
+	if cfBuild.Status.Droplet == nil {
+		return ""
+	}
+
+	return cfBuild.Status.Droplet.Registry.Image
+}
+
+func tagsPointingTo(tags map[string]string, digest string) []string {
+	result := []string{}
+	for tag, tagDigest := range tags {
+		if tagDigest == digest {
+			result = append(result, tag)
+		}
+	}
+	sort.Strings(result)
+
+	return result
+}
@@ -1,10 +1,13 @@
 package cleanup_test
 
 import (
+	"errors"
 	"time"
 
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	"code.cloudfoundry.org/korifi/controllers/cleanup"
+	"code.cloudfoundry.org/korifi/controllers/cleanup/fake"
+	"code.cloudfoundry.org/korifi/tools/image"
 	"github.com/google/uuid"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -17,6 +20,7 @@ import (
 var _ = Describe("BuildCleaner", func() {
 	var (
 		cleaner                                                       cleanup.BuildCleaner
+		imageClient                                                   *fake.ImageClient
 		appGUID                                                       string
 		cfApp                                                         *korifiv1alpha1.CFApp
 		namespace                                                     string
@@ -25,7 +29,13 @@ var _ = Describe("BuildCleaner", func() {
 	)
 
 	BeforeEach(func() {
-		cleaner = cleanup.NewBuildCleaner(controllersClient, 1)
+		imageClient = new(fake.ImageClient)
+		imageClient.TagsReturns(map[string]string{
+			"deletable":    "sha256:deletable",
+			"deletable-v2": "sha256:deletable",
+			"ready":        "sha256:ready",
+		}, nil)
+		cleaner = cleanup.NewBuildCleaner(controllersClient, imageClient, []string{"registry-secret"}, 1)
 
 		namespace = uuid.NewString()
 		Expect(k8sClient.Create(ctx, &corev1.Namespace{
@@ -82,6 +92,74 @@ var _ = Describe("BuildCleaner", func() {
 		Expect(bldDeletable).To(BeNotFound())
 	})
 
+	It("deletes the droplet image of the deleted build", func() {
+		Expect(imageClient.TagsCallCount()).To(Equal(1))
+		_, actualCreds, actualRepository := imageClient.TagsArgsForCall(0)
+		Expect(actualCreds).To(Equal(image.Creds{
+			Namespace:   namespace,
+			SecretNames: []string{"registry-secret"},
+		}))
+		Expect(actualRepository).To(Equal("my.registry/" + appGUID + "-droplets"))
+
+		Expect(imageClient.DeleteCallCount()).To(Equal(1))
+		_, actualCreds, actualImage, actualTags := imageClient.DeleteArgsForCall(0)
+		Expect(actualCreds).To(Equal(image.Creds{
+			Namespace:   namespace,
+			SecretNames: []string{"registry-secret"},
+		}))
+		Expect(actualImage).To(Equal("my.registry/" + appGUID + "-droplets@sha256:deletable"))
+		Expect(actualTags).To(Equal([]string{"deletable", "deletable-v2"}))
+	})
+
+	When("listing the droplet image tags fails", func() {
+		BeforeEach(func() {
+			imageClient.TagsReturns(nil, errors.New("tags-err"))
+		})
+
+		It("still deletes the build", func() {
+			Expect(cleanErr).NotTo(HaveOccurred())
+			Expect(bldDeletable).To(BeNotFound())
+			Expect(imageClient.DeleteCallCount()).To(BeZero())
+		})
+	})
+
+	When("deleting the droplet image fails", func() {
+		BeforeEach(func() {
+			imageClient.DeleteReturns(errors.New("delete-err"))
+		})
+
+		It("still deletes the build", func() {
+			Expect(cleanErr).NotTo(HaveOccurred())
+			Expect(bldDeletable).To(BeNotFound())
+		})
+	})
+
+	When("a retained build shares the droplet image of the deleted build", func() {
+		BeforeEach(func() {
+			bldReady.Status.Droplet = bldDeletable.Status.Droplet
+			Expect(k8sClient.Status().Update(ctx, bldReady)).To(Succeed())
+		})
+
+		It("deletes the build but not the droplet image", func() {
+			Expect(cleanErr).NotTo(HaveOccurred())
+			Expect(bldDeletable).To(BeNotFound())
+			Expect(imageClient.DeleteCallCount()).To(BeZero())
+		})
+	})
+
+	When("the deleted build uses the docker lifecycle", func() {
+		BeforeEach(func() {
+			bldDeletable.Spec.Lifecycle.Type = korifiv1alpha1.DockerLifecycle
+			Expect(k8sClient.Update(ctx, bldDeletable)).To(Succeed())
+		})
+
+		It("deletes the build but not the user provided image", func() {
+			Expect(cleanErr).NotTo(HaveOccurred())
+			Expect(bldDeletable).To(BeNotFound())
+			Expect(imageClient.DeleteCallCount()).To(BeZero())
+		})
+	})
+
 	When("the current droplet is not set on the app", func() {
 		BeforeEach(func() {
 			cfApp.Spec.CurrentDropletRef = corev1.LocalObjectReference{}
@@ -110,7 +188,10 @@ func createBuild(namespace, appGUID, name string) *korifiv1alpha1.CFBuild {
 				korifiv1alpha1.CFAppGUIDLabelKey: appGUID,
 			},
 		},
-		Spec: korifiv1alpha1.CFBuildSpec{Lifecycle: korifiv1alpha1.Lifecycle{Type: "buildpack"}},
+		Spec: korifiv1alpha1.CFBuildSpec{
+			AppRef:    corev1.LocalObjectReference{Name: appGUID},
+			Lifecycle: korifiv1alpha1.Lifecycle{Type: korifiv1alpha1.BuildpackLifecycle},
+		},
 	}
 	Expect(k8sClient.Create(ctx, bld)).To(Succeed())
 	return bld
@@ -118,11 +199,18 @@ func createBuild(namespace, appGUID, name string) *korifiv1alpha1.CFBuild {
 
 func createSucceededBuild(namespace, appGUID, name string) *korifiv1alpha1.CFBuild {
 	bld := createBuild(namespace, appGUID, name)
+	markBuildSucceeded(bld, "my.registry/"+appGUID+"-droplets@sha256:"+name)
+	return bld
+}
+
+func markBuildSucceeded(bld *korifiv1alpha1.CFBuild, dropletImage string) {
 	meta.SetStatusCondition(&bld.Status.Conditions, metav1.Condition{
 		Type:   korifiv1alpha1.SucceededConditionType,
 		Status: metav1.ConditionTrue,
 		Reason: "Staged",
 	})
+	bld.Status.Droplet = &korifiv1alpha1.BuildDropletStatus{
+		Registry: korifiv1alpha1.Registry{Image: dropletImage},
+	}
 	Expect(k8sClient.Status().Update(ctx, bld)).To(Succeed())
-	return bld
 }
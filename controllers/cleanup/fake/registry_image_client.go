@@ -0,0 +1,285 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fake
+
+import (
+	"context"
+	"sync"
+
+	"code.cloudfoundry.org/korifi/controllers/cleanup"
+	"code.cloudfoundry.org/korifi/tools/image"
+)
+
+type RegistryImageClient struct {
+	CatalogStub        func(context.Context, image.Creds, string) ([]string, error)
+	catalogMutex       sync.RWMutex
+	catalogArgsForCall []struct {
+		arg1 context.Context
+		arg2 image.Creds
+		arg3 string
+	}
+	catalogReturns struct {
+		result1 []string
+		result2 error
+	}
+	catalogReturnsOnCall map[int]struct {
+		result1 []string
+		result2 error
+	}
+	DeleteStub        func(context.Context, image.Creds, string, ...string) error
+	deleteMutex       sync.RWMutex
+	deleteArgsForCall []struct {
+		arg1 context.Context
+		arg2 image.Creds
+		arg3 string
+		arg4 []string
+	}
+	deleteReturns struct {
+		result1 error
+	}
+	deleteReturnsOnCall map[int]struct {
+		result1 error
+	}
+	TagsStub        func(context.Context, image.Creds, string) (map[string]string, error)
+	tagsMutex       sync.RWMutex
+	tagsArgsForCall []struct {
+		arg1 context.Context
+		arg2 image.Creds
+		arg3 string
+	}
+	tagsReturns struct {
+		result1 map[string]string
+		result2 error
+	}
+	tagsReturnsOnCall map[int]struct {
+		result1 map[string]string
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *RegistryImageClient) Catalog(arg1 context.Context, arg2 image.Creds, arg3 string) ([]string, error) {
+	fake.catalogMutex.Lock()
+	ret, specificReturn := fake.catalogReturnsOnCall[len(fake.catalogArgsForCall)]
+	fake.catalogArgsForCall = append(fake.catalogArgsForCall, struct {
+		arg1 context.Context
+		arg2 image.Creds
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.CatalogStub
+	fakeReturns := fake.catalogReturns
+	fake.recordInvocation("Catalog", []interface{}{arg1, arg2, arg3})
+	fake.catalogMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *RegistryImageClient) CatalogCallCount() int {
+	fake.catalogMutex.RLock()
+	defer fake.catalogMutex.RUnlock()
+	return len(fake.catalogArgsForCall)
+}
+
+func (fake *RegistryImageClient) CatalogCalls(stub func(context.Context, image.Creds, string) ([]string, error)) {
+	fake.catalogMutex.Lock()
+	defer fake.catalogMutex.Unlock()
+	fake.CatalogStub = stub
+}
+
+func (fake *RegistryImageClient) CatalogArgsForCall(i int) (context.Context, image.Creds, string) {
+	fake.catalogMutex.RLock()
+	defer fake.catalogMutex.RUnlock()
+	argsForCall := fake.catalogArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *RegistryImageClient) CatalogReturns(result1 []string, result2 error) {
+	fake.catalogMutex.Lock()
+	defer fake.catalogMutex.Unlock()
+	fake.CatalogStub = nil
+	fake.catalogReturns = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *RegistryImageClient) CatalogReturnsOnCall(i int, result1 []string, result2 error) {
+	fake.catalogMutex.Lock()
+	defer fake.catalogMutex.Unlock()
+	fake.CatalogStub = nil
+	if fake.catalogReturnsOnCall == nil {
+		fake.catalogReturnsOnCall = make(map[int]struct {
+			result1 []string
+			result2 error
+		})
+	}
+	fake.catalogReturnsOnCall[i] = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *RegistryImageClient) Delete(arg1 context.Context, arg2 image.Creds, arg3 string, arg4 ...string) error {
+	fake.deleteMutex.Lock()
+	ret, specificReturn := fake.deleteReturnsOnCall[len(fake.deleteArgsForCall)]
+	fake.deleteArgsForCall = append(fake.deleteArgsForCall, struct {
+		arg1 context.Context
+		arg2 image.Creds
+		arg3 string
+		arg4 []string
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.DeleteStub
+	fakeReturns := fake.deleteReturns
+	fake.recordInvocation("Delete", []interface{}{arg1, arg2, arg3, arg4})
+	fake.deleteMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4...)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *RegistryImageClient) DeleteCallCount() int {
+	fake.deleteMutex.RLock()
+	defer fake.deleteMutex.RUnlock()
+	return len(fake.deleteArgsForCall)
+}
+
+func (fake *RegistryImageClient) DeleteCalls(stub func(context.Context, image.Creds, string, ...string) error) {
+	fake.deleteMutex.Lock()
+	defer fake.deleteMutex.Unlock()
+	fake.DeleteStub = stub
+}
+
+func (fake *RegistryImageClient) DeleteArgsForCall(i int) (context.Context, image.Creds, string, []string) {
+	fake.deleteMutex.RLock()
+	defer fake.deleteMutex.RUnlock()
+	argsForCall := fake.deleteArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *RegistryImageClient) DeleteReturns(result1 error) {
+	fake.deleteMutex.Lock()
+	defer fake.deleteMutex.Unlock()
+	fake.DeleteStub = nil
+	fake.deleteReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *RegistryImageClient) DeleteReturnsOnCall(i int, result1 error) {
+	fake.deleteMutex.Lock()
+	defer fake.deleteMutex.Unlock()
+	fake.DeleteStub = nil
+	if fake.deleteReturnsOnCall == nil {
+		fake.deleteReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.deleteReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *RegistryImageClient) Tags(arg1 context.Context, arg2 image.Creds, arg3 string) (map[string]string, error) {
+	fake.tagsMutex.Lock()
+	ret, specificReturn := fake.tagsReturnsOnCall[len(fake.tagsArgsForCall)]
+	fake.tagsArgsForCall = append(fake.tagsArgsForCall, struct {
+		arg1 context.Context
+		arg2 image.Creds
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.TagsStub
+	fakeReturns := fake.tagsReturns
+	fake.recordInvocation("Tags", []interface{}{arg1, arg2, arg3})
+	fake.tagsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *RegistryImageClient) TagsCallCount() int {
+	fake.tagsMutex.RLock()
+	defer fake.tagsMutex.RUnlock()
+	return len(fake.tagsArgsForCall)
+}
+
+func (fake *RegistryImageClient) TagsCalls(stub func(context.Context, image.Creds, string) (map[string]string, error)) {
+	fake.tagsMutex.Lock()
+	defer fake.tagsMutex.Unlock()
+	fake.TagsStub = stub
+}
+
+func (fake *RegistryImageClient) TagsArgsForCall(i int) (context.Context, image.Creds, string) {
+	fake.tagsMutex.RLock()
+	defer fake.tagsMutex.RUnlock()
+	argsForCall := fake.tagsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *RegistryImageClient) TagsReturns(result1 map[string]string, result2 error) {
+	fake.tagsMutex.Lock()
+	defer fake.tagsMutex.Unlock()
+	fake.TagsStub = nil
+	fake.tagsReturns = struct {
+		result1 map[string]string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *RegistryImageClient) TagsReturnsOnCall(i int, result1 map[string]string, result2 error) {
+	fake.tagsMutex.Lock()
+	defer fake.tagsMutex.Unlock()
+	fake.TagsStub = nil
+	if fake.tagsReturnsOnCall == nil {
+		fake.tagsReturnsOnCall = make(map[int]struct {
+			result1 map[string]string
+			result2 error
+		})
+	}
+	fake.tagsReturnsOnCall[i] = struct {
+		result1 map[string]string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *RegistryImageClient) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.catalogMutex.RLock()
+	defer fake.catalogMutex.RUnlock()
+	fake.deleteMutex.RLock()
+	defer fake.deleteMutex.RUnlock()
+	fake.tagsMutex.RLock()
+	defer fake.tagsMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *RegistryImageClient) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ cleanup.RegistryImageClient = new(RegistryImageClient)
@@ -0,0 +1,114 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fake
+
+import (
+	"context"
+	"sync"
+
+	"code.cloudfoundry.org/korifi/controllers/cleanup"
+)
+
+type RepositoryDeleter struct {
+	DeleteRepositoryStub        func(context.Context, string) error
+	deleteRepositoryMutex       sync.RWMutex
+	deleteRepositoryArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	deleteRepositoryReturns struct {
+		result1 error
+	}
+	deleteRepositoryReturnsOnCall map[int]struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *RepositoryDeleter) DeleteRepository(arg1 context.Context, arg2 string) error {
+	fake.deleteRepositoryMutex.Lock()
+	ret, specificReturn := fake.deleteRepositoryReturnsOnCall[len(fake.deleteRepositoryArgsForCall)]
+	fake.deleteRepositoryArgsForCall = append(fake.deleteRepositoryArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.DeleteRepositoryStub
+	fakeReturns := fake.deleteRepositoryReturns
+	fake.recordInvocation("DeleteRepository", []interface{}{arg1, arg2})
+	fake.deleteRepositoryMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *RepositoryDeleter) DeleteRepositoryCallCount() int {
+	fake.deleteRepositoryMutex.RLock()
+	defer fake.deleteRepositoryMutex.RUnlock()
+	return len(fake.deleteRepositoryArgsForCall)
+}
+
+func (fake *RepositoryDeleter) DeleteRepositoryCalls(stub func(context.Context, string) error) {
+	fake.deleteRepositoryMutex.Lock()
+	defer fake.deleteRepositoryMutex.Unlock()
+	fake.DeleteRepositoryStub = stub
+}
+
+func (fake *RepositoryDeleter) DeleteRepositoryArgsForCall(i int) (context.Context, string) {
+	fake.deleteRepositoryMutex.RLock()
+	defer fake.deleteRepositoryMutex.RUnlock()
+	argsForCall := fake.deleteRepositoryArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *RepositoryDeleter) DeleteRepositoryReturns(result1 error) {
+	fake.deleteRepositoryMutex.Lock()
+	defer fake.deleteRepositoryMutex.Unlock()
+	fake.DeleteRepositoryStub = nil
+	fake.deleteRepositoryReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *RepositoryDeleter) DeleteRepositoryReturnsOnCall(i int, result1 error) {
+	fake.deleteRepositoryMutex.Lock()
+	defer fake.deleteRepositoryMutex.Unlock()
+	fake.DeleteRepositoryStub = nil
+	if fake.deleteRepositoryReturnsOnCall == nil {
+		fake.deleteRepositoryReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.deleteRepositoryReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *RepositoryDeleter) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.deleteRepositoryMutex.RLock()
+	defer fake.deleteRepositoryMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *RepositoryDeleter) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ cleanup.RepositoryDeleter = new(RepositoryDeleter)
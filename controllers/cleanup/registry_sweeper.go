@@ -0,0 +1,343 @@
+package cleanup
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools/image"
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	packagesRepositorySuffix = "-packages"
+	dropletsRepositorySuffix = "-droplets"
+)
+
+//counterfeiter:generate -o fake -fake-name RegistryImageClient . RegistryImageClient
+
+type RegistryImageClient interface {
+	ImageClient
+	Catalog(ctx context.Context, creds image.Creds, registry string) ([]string, error)
+}
+
+//counterfeiter:generate -o fake -fake-name RepositoryDeleter . RepositoryDeleter
+
+type RepositoryDeleter interface {
+	DeleteRepository(ctx context.Context, repoRef string) error
+}
+
+// RegistrySweeper periodically compares the app repositories in the container
+// registry against the CFApps, CFPackages and CFBuilds in the cluster and
+// deletes (or, in dry run mode, only reports) the images that are no longer
+// referenced, e.g. the ones left behind by a failed finalizer.
+type RegistrySweeper struct {
+	k8sClient         client.Client
+	imageClient       RegistryImageClient
+	repositoryDeleter RepositoryDeleter
+	creds             image.Creds
+	repositoryPrefix  string
+	interval          time.Duration
+	dryRun            bool
+	log               logr.Logger
+}
+
+func NewRegistrySweeper(
+	k8sClient client.Client,
+	imageClient RegistryImageClient,
+	repositoryDeleter RepositoryDeleter,
+	creds image.Creds,
+	repositoryPrefix string,
+	interval time.Duration,
+	dryRun bool,
+	log logr.Logger,
+) *RegistrySweeper {
+	return &RegistrySweeper{
+		k8sClient:         k8sClient,
+		imageClient:       imageClient,
+		repositoryDeleter: repositoryDeleter,
+		creds:             creds,
+		repositoryPrefix:  repositoryPrefix,
+		interval:          interval,
+		dryRun:            dryRun,
+		log:               log,
+	}
+}
+
+// NeedLeaderElection makes sure that only one controllers replica sweeps the
+// registry at a time
+func (s *RegistrySweeper) NeedLeaderElection() bool {
+	return true
+}
+
+func (s *RegistrySweeper) Start(ctx context.Context) error {
+	ctx = logr.NewContext(ctx, s.log)
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if err := s.Sweep(ctx); err != nil {
+				s.log.Info("registry sweep failed", "reason", err)
+			}
+		}
+	}
+}
+
+type appImages struct {
+	appExists bool
+	// digests and tags referenced by packages and builds of the app
+	liveDigests map[string]bool
+	liveTags    map[string]bool
+	// set when a package upload or a build is in flight, so that images
+	// not yet recorded on the owning object are not considered orphans
+	inFlight bool
+}
+
+func (s *RegistrySweeper) Sweep(ctx context.Context) error {
+	log := logr.FromContextOrDiscard(ctx).WithName("RegistrySweeper").WithValues("dryRun", s.dryRun)
+
+	// The registry is listed before the cluster, so that the repositories of
+	// apps pushed in between are found alive
+	catalogRepositories := s.catalogRepositories(ctx, log)
+
+	repositories, err := s.liveRepositories(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, repository := range catalogRepositories {
+		if _, ok := repositories[repository]; !ok {
+			repositories[repository] = newAppImages()
+		}
+	}
+
+	for _, repository := range sortedKeys(repositories) {
+		s.sweepRepository(ctx, log.WithValues("repository", repository), repository, repositories[repository])
+	}
+
+	return nil
+}
+
+func (s *RegistrySweeper) sweepRepository(ctx context.Context, log logr.Logger, repository string, images *appImages) {
+	if images.inFlight {
+		log.V(1).Info("skipping repository with in-flight uploads or builds")
+		return
+	}
+
+	tags, err := s.imageClient.Tags(ctx, s.creds, repository)
+	if err != nil {
+		log.Info("failed to list repository tags", "reason", err)
+		return
+	}
+
+	images.resolveLiveTags(tags)
+
+	orphanTags := map[string][]string{}
+	for tag, digest := range tags {
+		if !images.liveDigests[digest] {
+			orphanTags[digest] = append(orphanTags[digest], tag)
+		}
+	}
+
+	for _, digest := range sortedKeys(orphanTags) {
+		imageRef := repository + "@" + digest
+		sort.Strings(orphanTags[digest])
+		log.Info("found orphaned image", "image", imageRef, "tags", orphanTags[digest])
+		if s.dryRun {
+			continue
+		}
+
+		// Packages and builds may have been created since the sweep started
+		currentImages, err := s.currentRepositoryImages(ctx, repository, tags)
+		if err != nil {
+			log.Info("failed to re-check orphaned image", "image", imageRef, "reason", err)
+			return
+		}
+		if currentImages.inFlight || currentImages.liveDigests[digest] {
+			log.Info("image is no longer orphaned", "image", imageRef)
+			continue
+		}
+
+		if err = s.imageClient.Delete(ctx, s.creds, imageRef, orphanTags[digest]...); err != nil {
+			log.Info("failed to delete orphaned image", "image", imageRef, "reason", err)
+		}
+	}
+
+	if images.appExists || len(images.liveDigests) > 0 {
+		return
+	}
+
+	log.Info("found orphaned repository")
+	if s.dryRun {
+		return
+	}
+
+	currentImages, err := s.currentRepositoryImages(ctx, repository, tags)
+	if err != nil {
+		log.Info("failed to re-check orphaned repository", "reason", err)
+		return
+	}
+	if currentImages.appExists || currentImages.inFlight || len(currentImages.liveDigests) > 0 {
+		log.Info("repository is no longer orphaned")
+		return
+	}
+
+	if err = s.repositoryDeleter.DeleteRepository(ctx, repository); err != nil {
+		log.Info("failed to delete orphaned repository", "reason", err)
+	}
+}
+
+// liveRepositories returns the package and droplet repositories of all
+// existing apps, along with the images referenced in them
+func (s *RegistrySweeper) liveRepositories(ctx context.Context) (map[string]*appImages, error) {
+	repositories := map[string]*appImages{}
+	repositoryImages := func(appGUID, suffix string) *appImages {
+		repository := s.repositoryPrefix + appGUID + suffix
+		if _, ok := repositories[repository]; !ok {
+			repositories[repository] = newAppImages()
+		}
+		return repositories[repository]
+	}
+
+	var cfApps korifiv1alpha1.CFAppList
+	if err := s.k8sClient.List(ctx, &cfApps); err != nil {
+		return nil, fmt.Errorf("failed to list apps: %w", err)
+	}
+	for _, cfApp := range cfApps.Items {
+		repositoryImages(cfApp.Name, packagesRepositorySuffix).appExists = true
+		repositoryImages(cfApp.Name, dropletsRepositorySuffix).appExists = true
+	}
+
+	var cfPackages korifiv1alpha1.CFPackageList
+	if err := s.k8sClient.List(ctx, &cfPackages); err != nil {
+		return nil, fmt.Errorf("failed to list packages: %w", err)
+	}
+	for _, cfPackage := range cfPackages.Items {
+		if cfPackage.Spec.Type == "docker" {
+			continue
+		}
+
+		images := repositoryImages(cfPackage.Spec.AppRef.Name, packagesRepositorySuffix)
+		if cfPackage.Spec.Source.Registry.Image == "" {
+			images.inFlight = true
+			continue
+		}
+		images.addLiveImage(cfPackage.Spec.Source.Registry.Image)
+	}
+
+	var cfBuilds korifiv1alpha1.CFBuildList
+	if err := s.k8sClient.List(ctx, &cfBuilds); err != nil {
+		return nil, fmt.Errorf("failed to list builds: %w", err)
+	}
+	for _, cfBuild := range cfBuilds.Items {
+		if cfBuild.Spec.Lifecycle.Type != korifiv1alpha1.BuildpackLifecycle {
+			continue
+		}
+
+		images := repositoryImages(cfBuild.Spec.AppRef.Name, dropletsRepositorySuffix)
+		if meta.FindStatusCondition(cfBuild.Status.Conditions, korifiv1alpha1.SucceededConditionType) == nil ||
+			meta.IsStatusConditionPresentAndEqual(cfBuild.Status.Conditions, korifiv1alpha1.SucceededConditionType, metav1.ConditionUnknown) {
+			images.inFlight = true
+			continue
+		}
+		images.addLiveImage(dropletImage(cfBuild))
+	}
+
+	return repositories, nil
+}
+
+// currentRepositoryImages lists the apps, packages and builds again right
+// before a delete, so that objects created since the sweep started, e.g. by a
+// push that is running concurrently, keep their images
+func (s *RegistrySweeper) currentRepositoryImages(ctx context.Context, repository string, tags map[string]string) (*appImages, error) {
+	repositories, err := s.liveRepositories(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	images, ok := repositories[repository]
+	if !ok {
+		return newAppImages(), nil
+	}
+	images.resolveLiveTags(tags)
+
+	return images, nil
+}
+
+// catalogRepositories returns the app repositories known to the registry,
+// which include the ones of apps that no longer exist. Not all registries
+// support listing their repositories, so failures are only logged.
+func (s *RegistrySweeper) catalogRepositories(ctx context.Context, log logr.Logger) []string {
+	registry, _, _ := strings.Cut(s.repositoryPrefix, "/")
+
+	catalog, err := s.imageClient.Catalog(ctx, s.creds, registry)
+	if err != nil {
+		log.Info("failed to list registry repositories - only sweeping repositories of existing apps", "reason", err)
+		return nil
+	}
+
+	repositories := []string{}
+	for _, repository := range catalog {
+		if !strings.HasPrefix(repository, s.repositoryPrefix) {
+			continue
+		}
+		if strings.HasSuffix(repository, packagesRepositorySuffix) || strings.HasSuffix(repository, dropletsRepositorySuffix) {
+			repositories = append(repositories, repository)
+		}
+	}
+
+	return repositories
+}
+
+func newAppImages() *appImages {
+	return &appImages{
+		liveDigests: map[string]bool{},
+		liveTags:    map[string]bool{},
+	}
+}
+
+func (i *appImages) addLiveImage(imageRef string) {
+	if imageRef == "" {
+		return
+	}
+
+	if _, digest, isDigestRef := strings.Cut(imageRef, "@"); isDigestRef {
+		i.liveDigests[digest] = true
+		return
+	}
+
+	// tag references are resolved against the registry tags while sweeping
+	if tagSeparator := strings.LastIndex(imageRef, ":"); tagSeparator > strings.LastIndex(imageRef, "/") {
+		i.liveTags[imageRef[tagSeparator+1:]] = true
+	}
+}
+
+// resolveLiveTags marks the digests the live tags of the repository point to
+// as live
+func (i *appImages) resolveLiveTags(tags map[string]string) {
+	for tag, digest := range tags {
+		if i.liveTags[tag] {
+			i.liveDigests[digest] = true
+		}
+	}
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
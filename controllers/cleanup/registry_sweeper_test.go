@@ -0,0 +1,316 @@
+package cleanup_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/cleanup"
+	"code.cloudfoundry.org/korifi/controllers/cleanup/fake"
+	"code.cloudfoundry.org/korifi/tools/image"
+	"github.com/go-logr/logr"
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("RegistrySweeper", func() {
+	const prefix = "my.registry/korifi/"
+
+	var (
+		imageClient       *fake.RegistryImageClient
+		repositoryDeleter *fake.RepositoryDeleter
+		creds             image.Creds
+		dryRun            bool
+		namespace         string
+		appGUID           string
+		packagesRepo      string
+		dropletsRepo      string
+		goneRepo          string
+		repoTags          map[string]map[string]string
+		cfPackage         *korifiv1alpha1.CFPackage
+		cfBuild           *korifiv1alpha1.CFBuild
+		sweepErr          error
+	)
+
+	deletedImages := func() map[string][]string {
+		deleted := map[string][]string{}
+		for i := 0; i < imageClient.DeleteCallCount(); i++ {
+			_, _, imageRef, tags := imageClient.DeleteArgsForCall(i)
+			deleted[imageRef] = tags
+		}
+		return deleted
+	}
+
+	deletedRepositories := func() []string {
+		deleted := []string{}
+		for i := 0; i < repositoryDeleter.DeleteRepositoryCallCount(); i++ {
+			_, repository := repositoryDeleter.DeleteRepositoryArgsForCall(i)
+			deleted = append(deleted, repository)
+		}
+		return deleted
+	}
+
+	sweptRepositories := func() []string {
+		swept := []string{}
+		for i := 0; i < imageClient.TagsCallCount(); i++ {
+			_, _, repository := imageClient.TagsArgsForCall(i)
+			swept = append(swept, repository)
+		}
+		return swept
+	}
+
+	BeforeEach(func() {
+		dryRun = false
+		creds = image.Creds{Namespace: "cf", SecretNames: []string{"registry-secret"}}
+
+		namespace = uuid.NewString()
+		Expect(k8sClient.Create(ctx, &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{Name: namespace},
+		})).To(Succeed())
+
+		appGUID = uuid.NewString()
+		packagesRepo = prefix + appGUID + "-packages"
+		dropletsRepo = prefix + appGUID + "-droplets"
+		goneRepo = prefix + uuid.NewString() + "-droplets"
+
+		Expect(k8sClient.Create(ctx, &korifiv1alpha1.CFApp{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      appGUID,
+				Namespace: namespace,
+			},
+			Spec: korifiv1alpha1.CFAppSpec{
+				DisplayName:  "an-app",
+				Lifecycle:    korifiv1alpha1.Lifecycle{Type: korifiv1alpha1.BuildpackLifecycle},
+				DesiredState: korifiv1alpha1.StoppedState,
+			},
+		})).To(Succeed())
+
+		cfPackage = &korifiv1alpha1.CFPackage{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      uuid.NewString(),
+				Namespace: namespace,
+			},
+			Spec: korifiv1alpha1.CFPackageSpec{
+				Type:   "bits",
+				AppRef: corev1.LocalObjectReference{Name: appGUID},
+				Source: korifiv1alpha1.PackageSource{
+					Registry: korifiv1alpha1.Registry{Image: packagesRepo + "@sha256:live-package"},
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, cfPackage)).To(Succeed())
+
+		cfBuild = createBuild(namespace, appGUID, uuid.NewString())
+		markBuildSucceeded(cfBuild, dropletsRepo+"@sha256:live-droplet")
+
+		repoTags = map[string]map[string]string{
+			packagesRepo: {
+				cfPackage.Name: "sha256:live-package",
+				"old-package":  "sha256:orphan-package",
+			},
+			dropletsRepo: {
+				cfBuild.Name:  "sha256:live-droplet",
+				"old-build":   "sha256:orphan-droplet",
+				"old-build-2": "sha256:orphan-droplet",
+			},
+			goneRepo: {
+				"gone-build": "sha256:gone-droplet",
+			},
+		}
+
+		imageClient = new(fake.RegistryImageClient)
+		imageClient.CatalogReturns([]string{
+			goneRepo,
+			packagesRepo,
+			prefix + "kpack-builder",
+			"other.registry/some-app-droplets",
+		}, nil)
+		imageClient.TagsStub = func(_ context.Context, _ image.Creds, repository string) (map[string]string, error) {
+			return repoTags[repository], nil
+		}
+
+		repositoryDeleter = new(fake.RepositoryDeleter)
+	})
+
+	JustBeforeEach(func() {
+		sweeper := cleanup.NewRegistrySweeper(controllersClient, imageClient, repositoryDeleter, creds, prefix, 0, dryRun, logr.Discard())
+		sweepErr = sweeper.Sweep(ctx)
+	})
+
+	It("lists the repositories of the registry the apps are pushed to", func() {
+		Expect(sweepErr).NotTo(HaveOccurred())
+		Expect(imageClient.CatalogCallCount()).To(Equal(1))
+		_, actualCreds, actualRegistry := imageClient.CatalogArgsForCall(0)
+		Expect(actualCreds).To(Equal(creds))
+		Expect(actualRegistry).To(Equal("my.registry"))
+	})
+
+	It("only sweeps app repositories", func() {
+		Expect(sweptRepositories()).To(ContainElements(packagesRepo, dropletsRepo, goneRepo))
+		Expect(sweptRepositories()).NotTo(ContainElements(prefix+"kpack-builder", "other.registry/some-app-droplets"))
+	})
+
+	It("deletes the images that are not referenced by any package or build", func() {
+		Expect(deletedImages()).To(HaveKeyWithValue(packagesRepo+"@sha256:orphan-package", []string{"old-package"}))
+		Expect(deletedImages()).To(HaveKeyWithValue(dropletsRepo+"@sha256:orphan-droplet", []string{"old-build", "old-build-2"}))
+		Expect(deletedImages()).To(HaveKeyWithValue(goneRepo+"@sha256:gone-droplet", []string{"gone-build"}))
+		Expect(deletedImages()).NotTo(HaveKey(packagesRepo + "@sha256:live-package"))
+		Expect(deletedImages()).NotTo(HaveKey(dropletsRepo + "@sha256:live-droplet"))
+	})
+
+	It("deletes the repositories of apps that no longer exist", func() {
+		Expect(deletedRepositories()).To(ContainElement(goneRepo))
+		Expect(deletedRepositories()).NotTo(ContainElements(packagesRepo, dropletsRepo))
+	})
+
+	When("dry run is enabled", func() {
+		BeforeEach(func() {
+			dryRun = true
+		})
+
+		It("does not delete anything", func() {
+			Expect(sweepErr).NotTo(HaveOccurred())
+			Expect(imageClient.DeleteCallCount()).To(BeZero())
+			Expect(repositoryDeleter.DeleteRepositoryCallCount()).To(BeZero())
+		})
+	})
+
+	When("the package refers to its image by tag", func() {
+		BeforeEach(func() {
+			cfPackage.Spec.Source.Registry.Image = packagesRepo + ":old-package"
+			Expect(k8sClient.Update(ctx, cfPackage)).To(Succeed())
+		})
+
+		It("keeps the image the tag points to", func() {
+			Expect(deletedImages()).NotTo(HaveKey(packagesRepo + "@sha256:orphan-package"))
+			Expect(deletedImages()).To(HaveKeyWithValue(packagesRepo+"@sha256:live-package", []string{cfPackage.Name}))
+		})
+	})
+
+	When("a package upload is in progress", func() {
+		BeforeEach(func() {
+			Expect(k8sClient.Create(ctx, &korifiv1alpha1.CFPackage{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      uuid.NewString(),
+					Namespace: namespace,
+				},
+				Spec: korifiv1alpha1.CFPackageSpec{
+					Type:   "bits",
+					AppRef: corev1.LocalObjectReference{Name: appGUID},
+				},
+			})).To(Succeed())
+		})
+
+		It("skips the packages repository", func() {
+			Expect(sweptRepositories()).NotTo(ContainElement(packagesRepo))
+			Expect(sweptRepositories()).To(ContainElement(dropletsRepo))
+		})
+	})
+
+	When("a build is in progress", func() {
+		BeforeEach(func() {
+			stagingBuild := createBuild(namespace, appGUID, uuid.NewString())
+			meta.SetStatusCondition(&stagingBuild.Status.Conditions, metav1.Condition{
+				Type:   korifiv1alpha1.SucceededConditionType,
+				Status: metav1.ConditionUnknown,
+				Reason: "Staging",
+			})
+			Expect(k8sClient.Status().Update(ctx, stagingBuild)).To(Succeed())
+		})
+
+		It("skips the droplets repository", func() {
+			Expect(sweptRepositories()).NotTo(ContainElement(dropletsRepo))
+			Expect(sweptRepositories()).To(ContainElement(packagesRepo))
+		})
+	})
+
+	When("an app is pushed while the registry is swept", func() {
+		BeforeEach(func() {
+			imageClient.TagsStub = func(_ context.Context, _ image.Creds, repository string) (map[string]string, error) {
+				if repository == goneRepo {
+					Expect(k8sClient.Create(ctx, &korifiv1alpha1.CFApp{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      strings.TrimSuffix(strings.TrimPrefix(goneRepo, prefix), "-droplets"),
+							Namespace: namespace,
+						},
+						Spec: korifiv1alpha1.CFAppSpec{
+							DisplayName:  "a-new-app",
+							Lifecycle:    korifiv1alpha1.Lifecycle{Type: korifiv1alpha1.BuildpackLifecycle},
+							DesiredState: korifiv1alpha1.StoppedState,
+						},
+					})).To(Succeed())
+				}
+				return repoTags[repository], nil
+			}
+		})
+
+		It("keeps the repository of the new app", func() {
+			Expect(sweepErr).NotTo(HaveOccurred())
+			Expect(deletedRepositories()).NotTo(ContainElement(goneRepo))
+		})
+	})
+
+	When("a package referencing an orphaned image is created while the registry is swept", func() {
+		BeforeEach(func() {
+			imageClient.TagsStub = func(_ context.Context, _ image.Creds, repository string) (map[string]string, error) {
+				if repository == packagesRepo {
+					Expect(k8sClient.Create(ctx, &korifiv1alpha1.CFPackage{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      uuid.NewString(),
+							Namespace: namespace,
+						},
+						Spec: korifiv1alpha1.CFPackageSpec{
+							Type:   "bits",
+							AppRef: corev1.LocalObjectReference{Name: appGUID},
+							Source: korifiv1alpha1.PackageSource{
+								Registry: korifiv1alpha1.Registry{Image: packagesRepo + "@sha256:orphan-package"},
+							},
+						},
+					})).To(Succeed())
+				}
+				return repoTags[repository], nil
+			}
+		})
+
+		It("keeps the image", func() {
+			Expect(sweepErr).NotTo(HaveOccurred())
+			Expect(deletedImages()).NotTo(HaveKey(packagesRepo + "@sha256:orphan-package"))
+			Expect(deletedImages()).To(HaveKey(dropletsRepo + "@sha256:orphan-droplet"))
+		})
+	})
+
+	When("the registry does not support listing repositories", func() {
+		BeforeEach(func() {
+			imageClient.CatalogReturns(nil, errors.New("catalog-err"))
+		})
+
+		It("only sweeps the repositories of existing apps", func() {
+			Expect(sweepErr).NotTo(HaveOccurred())
+			Expect(sweptRepositories()).To(ContainElements(packagesRepo, dropletsRepo))
+			Expect(sweptRepositories()).NotTo(ContainElement(goneRepo))
+			Expect(deletedRepositories()).NotTo(ContainElement(goneRepo))
+		})
+	})
+
+	When("listing the tags of a repository fails", func() {
+		BeforeEach(func() {
+			imageClient.TagsStub = func(_ context.Context, _ image.Creds, repository string) (map[string]string, error) {
+				if repository == goneRepo {
+					return nil, errors.New("tags-err")
+				}
+				return repoTags[repository], nil
+			}
+		})
+
+		It("leaves that repository alone and sweeps the others", func() {
+			Expect(sweepErr).NotTo(HaveOccurred())
+			Expect(deletedRepositories()).NotTo(ContainElement(goneRepo))
+			Expect(deletedImages()).To(HaveKey(dropletsRepo + "@sha256:orphan-droplet"))
+		})
+	})
+})
@@ -14,6 +14,8 @@ type ControllerConfig struct {
 	CFStagingResources               CFStagingResources `yaml:"cfStagingResources"`
 	CFRootNamespace                  string             `yaml:"cfRootNamespace"`
 	ContainerRegistrySecretNames     []string           `yaml:"containerRegistrySecretNames"`
+	ContainerRepositoryPrefix        string             `yaml:"containerRepositoryPrefix"`
+	ContainerRegistryType            string             `yaml:"containerRegistryType"`
 	TaskTTL                          string             `yaml:"taskTTL"`
 	BuilderName                      string             `yaml:"builderName"`
 	RunnerName                       string             `yaml:"runnerName"`
@@ -23,17 +25,29 @@ type ControllerConfig struct {
 	MaxRetainedBuildsPerApp          int                `yaml:"maxRetainedBuildsPerApp"`
 	LogLevel                         zapcore.Level      `yaml:"logLevel"`
 	SpaceFinalizerAppDeletionTimeout *int32             `yaml:"spaceFinalizerAppDeletionTimeout"`
+	// Roll changes to the running environment variable group out to all
+	// apps straight away rather than on their next restart, as CF does
+	RollOutRunningEnvVarGroup bool `yaml:"rollOutRunningEnvVarGroup"`
 
 	Networking Networking `yaml:"networking"`
 
-	ExperimentalManagedServicesEnabled bool `yaml:"experimentalManagedServicesEnabled"`
-	TrustInsecureServiceBrokers        bool `yaml:"trustInsecureServiceBrokers"`
+	RegistrySweeper RegistrySweeper `yaml:"registrySweeper"`
+
+	ExperimentalManagedServicesEnabled bool   `yaml:"experimentalManagedServicesEnabled"`
+	TrustInsecureServiceBrokers        bool   `yaml:"trustInsecureServiceBrokers"`
+	ServiceBrokerCatalogResyncInterval string `yaml:"serviceBrokerCatalogResyncInterval"`
+	CredentialRotationGracePeriod      string `yaml:"credentialRotationGracePeriod"`
+
+	VolumeServices VolumeServices `yaml:"volumeServices"`
 }
 
 type CFProcessDefaults struct {
 	MemoryMB    int64  `yaml:"memoryMB"`
 	DiskQuotaMB int64  `yaml:"diskQuotaMB"`
 	Timeout     *int32 `yaml:"timeout"`
+	// The CPU millicores requested for each GiB of memory of processes
+	// without an explicit CPU entitlement
+	CPUMillicoresPerGB int64 `yaml:"cpuMillicoresPerGB"`
 }
 
 type CFStagingResources struct {
@@ -47,11 +61,31 @@ type Networking struct {
 	GatewayNamespace string `yaml:"gatewayNamespace"`
 }
 
+type RegistrySweeper struct {
+	Enabled  bool   `yaml:"enabled"`
+	Interval string `yaml:"interval"`
+	// Only report orphaned images and repositories instead of deleting them
+	DryRun bool `yaml:"dryRun"`
+}
+
+// VolumeServices controls which volume mounts returned by service brokers
+// are mounted into app instances. Volume services are disabled by default.
+type VolumeServices struct {
+	Enabled           bool     `yaml:"enabled"`
+	AllowedDrivers    []string `yaml:"allowedDrivers"`
+	AllowedNFSServers []string `yaml:"allowedNFSServers"`
+}
+
 const (
-	defaultTaskTTL            = 30 * 24 * time.Hour
-	defaultTimeout      int32 = 60
-	defaultJobTTL             = 24 * time.Hour
-	defaultBuildCacheMB       = 2048
+	defaultTaskTTL                  = 30 * 24 * time.Hour
+	defaultTimeout            int32 = 60
+	defaultJobTTL                   = 24 * time.Hour
+	defaultBuildCacheMB             = 2048
+	defaultCPUMillicoresPerGB int64 = 100
+
+	defaultServiceBrokerCatalogResyncInterval = time.Hour
+	defaultCredentialRotationGracePeriod      = 5 * time.Minute
+	defaultRegistrySweeperInterval            = 24 * time.Hour
 )
 
 func LoadFromPath(path string) (*ControllerConfig, error) {
@@ -65,6 +99,10 @@ func LoadFromPath(path string) (*ControllerConfig, error) {
 		config.CFProcessDefaults.Timeout = tools.PtrTo(defaultTimeout)
 	}
 
+	if config.CFProcessDefaults.CPUMillicoresPerGB == 0 {
+		config.CFProcessDefaults.CPUMillicoresPerGB = defaultCPUMillicoresPerGB
+	}
+
 	if config.SpaceFinalizerAppDeletionTimeout == nil {
 		config.SpaceFinalizerAppDeletionTimeout = tools.PtrTo(defaultTimeout)
 	}
@@ -92,3 +130,27 @@ func (c ControllerConfig) ParseTaskTTL() (time.Duration, error) {
 
 	return tools.ParseDuration(c.TaskTTL)
 }
+
+func (c ControllerConfig) ParseServiceBrokerCatalogResyncInterval() (time.Duration, error) {
+	if c.ServiceBrokerCatalogResyncInterval == "" {
+		return defaultServiceBrokerCatalogResyncInterval, nil
+	}
+
+	return tools.ParseDuration(c.ServiceBrokerCatalogResyncInterval)
+}
+
+func (c ControllerConfig) ParseCredentialRotationGracePeriod() (time.Duration, error) {
+	if c.CredentialRotationGracePeriod == "" {
+		return defaultCredentialRotationGracePeriod, nil
+	}
+
+	return tools.ParseDuration(c.CredentialRotationGracePeriod)
+}
+
+func (c ControllerConfig) ParseRegistrySweeperInterval() (time.Duration, error) {
+	if c.RegistrySweeper.Interval == "" {
+		return defaultRegistrySweeperInterval, nil
+	}
+
+	return tools.ParseDuration(c.RegistrySweeper.Interval)
+}
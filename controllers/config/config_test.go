@@ -31,9 +31,10 @@ var _ = Describe("LoadFromPath", func() {
 
 		cfg = config.ControllerConfig{
 			CFProcessDefaults: config.CFProcessDefaults{
-				MemoryMB:    1024,
-				DiskQuotaMB: 512,
-				Timeout:     tools.PtrTo(int32(30)),
+				MemoryMB:           1024,
+				DiskQuotaMB:        512,
+				Timeout:            tools.PtrTo(int32(30)),
+				CPUMillicoresPerGB: 250,
 			},
 			CFStagingResources: config.CFStagingResources{
 				BuildCacheMB: 1024,
@@ -53,6 +54,11 @@ var _ = Describe("LoadFromPath", func() {
 			},
 			ExperimentalManagedServicesEnabled: true,
 			TrustInsecureServiceBrokers:        true,
+			VolumeServices: config.VolumeServices{
+				Enabled:           true,
+				AllowedDrivers:    []string{"nfs"},
+				AllowedNFSServers: []string{"nfs.example.com"},
+			},
 		}
 	})
 
@@ -72,9 +78,10 @@ var _ = Describe("LoadFromPath", func() {
 		Expect(retErr).NotTo(HaveOccurred())
 		Expect(*retConfig).To(Equal(config.ControllerConfig{
 			CFProcessDefaults: config.CFProcessDefaults{
-				MemoryMB:    1024,
-				DiskQuotaMB: 512,
-				Timeout:     tools.PtrTo(int32(30)),
+				MemoryMB:           1024,
+				DiskQuotaMB:        512,
+				Timeout:            tools.PtrTo(int32(30)),
+				CPUMillicoresPerGB: 250,
 			},
 			CFStagingResources: config.CFStagingResources{
 				BuildCacheMB: 1024,
@@ -96,6 +103,11 @@ var _ = Describe("LoadFromPath", func() {
 			},
 			ExperimentalManagedServicesEnabled: true,
 			TrustInsecureServiceBrokers:        true,
+			VolumeServices: config.VolumeServices{
+				Enabled:           true,
+				AllowedDrivers:    []string{"nfs"},
+				AllowedNFSServers: []string{"nfs.example.com"},
+			},
 		}))
 	})
 
@@ -109,6 +121,16 @@ var _ = Describe("LoadFromPath", func() {
 		})
 	})
 
+	When("the CFProcess CPU millicores per GB is not set", func() {
+		BeforeEach(func() {
+			cfg.CFProcessDefaults.CPUMillicoresPerGB = 0
+		})
+
+		It("uses the default", func() {
+			Expect(retConfig.CFProcessDefaults.CPUMillicoresPerGB).To(Equal(int64(100)))
+		})
+	})
+
 	When("log level is not set", func() {
 		BeforeEach(func() {
 			cfg.LogLevel = 0
@@ -185,3 +207,143 @@ var _ = Describe("ParseTaskTTL", func() {
 		})
 	})
 })
+
+var _ = Describe("ParseServiceBrokerCatalogResyncInterval", func() {
+	var (
+		intervalString string
+		interval       time.Duration
+		parseErr       error
+	)
+
+	BeforeEach(func() {
+		intervalString = ""
+	})
+
+	JustBeforeEach(func() {
+		cfg := config.ControllerConfig{
+			ServiceBrokerCatalogResyncInterval: intervalString,
+		}
+
+		interval, parseErr = cfg.ParseServiceBrokerCatalogResyncInterval()
+	})
+
+	It("returns one hour by default", func() {
+		Expect(parseErr).NotTo(HaveOccurred())
+		Expect(interval).To(Equal(time.Hour))
+	})
+
+	When("entering something parseable by tools.ParseDuration", func() {
+		BeforeEach(func() {
+			intervalString = "15m"
+		})
+
+		It("parses ok", func() {
+			Expect(parseErr).NotTo(HaveOccurred())
+			Expect(interval).To(Equal(15 * time.Minute))
+		})
+	})
+
+	When("entering something that cannot be parsed", func() {
+		BeforeEach(func() {
+			intervalString = "sometimes"
+		})
+
+		It("returns an error", func() {
+			Expect(parseErr).To(HaveOccurred())
+		})
+	})
+})
+
+var _ = Describe("ParseCredentialRotationGracePeriod", func() {
+	var (
+		gracePeriodString string
+		gracePeriod       time.Duration
+		parseErr          error
+	)
+
+	BeforeEach(func() {
+		gracePeriodString = ""
+	})
+
+	JustBeforeEach(func() {
+		cfg := config.ControllerConfig{
+			CredentialRotationGracePeriod: gracePeriodString,
+		}
+
+		gracePeriod, parseErr = cfg.ParseCredentialRotationGracePeriod()
+	})
+
+	It("returns five minutes by default", func() {
+		Expect(parseErr).NotTo(HaveOccurred())
+		Expect(gracePeriod).To(Equal(5 * time.Minute))
+	})
+
+	When("entering something parseable by tools.ParseDuration", func() {
+		BeforeEach(func() {
+			gracePeriodString = "1h"
+		})
+
+		It("parses ok", func() {
+			Expect(parseErr).NotTo(HaveOccurred())
+			Expect(gracePeriod).To(Equal(time.Hour))
+		})
+	})
+
+	When("entering something that cannot be parsed", func() {
+		BeforeEach(func() {
+			gracePeriodString = "eventually"
+		})
+
+		It("returns an error", func() {
+			Expect(parseErr).To(HaveOccurred())
+		})
+	})
+})
+
+var _ = Describe("ParseRegistrySweeperInterval", func() {
+	var (
+		intervalString string
+		interval       time.Duration
+		parseErr       error
+	)
+
+	BeforeEach(func() {
+		intervalString = ""
+	})
+
+	JustBeforeEach(func() {
+		cfg := config.ControllerConfig{
+			RegistrySweeper: config.RegistrySweeper{
+				Interval: intervalString,
+			},
+		}
+
+		interval, parseErr = cfg.ParseRegistrySweeperInterval()
+	})
+
+	It("returns one day by default", func() {
+		Expect(parseErr).NotTo(HaveOccurred())
+		Expect(interval).To(Equal(24 * time.Hour))
+	})
+
+	When("entering something parseable by tools.ParseDuration", func() {
+		BeforeEach(func() {
+			intervalString = "6h"
+		})
+
+		It("parses ok", func() {
+			Expect(parseErr).NotTo(HaveOccurred())
+			Expect(interval).To(Equal(6 * time.Hour))
+		})
+	})
+
+	When("entering something that cannot be parsed", func() {
+		BeforeEach(func() {
+			intervalString = "daily"
+		})
+
+		It("returns an error", func() {
+			Expect(parseErr).To(HaveOccurred())
+		})
+	})
+})
@@ -19,6 +19,8 @@ package routes
 import (
 	"context"
 	"fmt"
+	"maps"
+	"slices"
 	"strings"
 
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
@@ -66,23 +68,40 @@ func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) *builder.Builder {
 		Watches(
 			&korifiv1alpha1.CFApp{},
 			handler.EnqueueRequestsFromMapFunc(r.enqueueCFAppRequests),
+		).
+		Watches(
+			&korifiv1alpha1.AppWorkload{},
+			handler.EnqueueRequestsFromMapFunc(r.enqueueAppWorkloadRequests),
 		)
 }
 
 func (r *Reconciler) enqueueCFAppRequests(ctx context.Context, o client.Object) []reconcile.Request {
-	var requests []reconcile.Request
-
 	cfApp, ok := o.(*korifiv1alpha1.CFApp)
 	if !ok {
 		return []reconcile.Request{}
 	}
 
+	return r.appRouteRequests(ctx, cfApp.Namespace, cfApp.Name)
+}
+
+func (r *Reconciler) enqueueAppWorkloadRequests(ctx context.Context, o client.Object) []reconcile.Request {
+	appWorkload, ok := o.(*korifiv1alpha1.AppWorkload)
+	if !ok {
+		return []reconcile.Request{}
+	}
+
+	return r.appRouteRequests(ctx, appWorkload.Namespace, appWorkload.Spec.AppGUID)
+}
+
+func (r *Reconciler) appRouteRequests(ctx context.Context, appNamespace, appGUID string) []reconcile.Request {
+	var requests []reconcile.Request
+
 	var appRoutes korifiv1alpha1.CFRouteList
 	err := r.client.List(
 		ctx,
 		&appRoutes,
-		client.InNamespace(cfApp.Namespace),
-		client.MatchingFields{shared.IndexRouteDestinationAppName: cfApp.Name},
+		client.InNamespace(appNamespace),
+		client.MatchingFields{shared.IndexRouteDestinationAppName: appGUID},
 	)
 	if err != nil {
 		return []reconcile.Request{}
@@ -109,6 +128,8 @@ func (r *Reconciler) enqueueCFAppRequests(ctx context.Context, o client.Object)
 
 //+kubebuilder:rbac:groups="",resources=services,verbs=get;list;watch;create;update;patch;delete
 
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=appworkloads,verbs=get;list;watch
+
 func (r *Reconciler) ReconcileResource(ctx context.Context, cfRoute *korifiv1alpha1.CFRoute) (ctrl.Result, error) {
 	log := logr.FromContextOrDiscard(ctx)
 
@@ -308,6 +329,12 @@ func (r *Reconciler) reconcileHTTPRoute(ctx context.Context, cfRoute *korifiv1al
 		return nil
 	}
 
+	backendRefs, err := r.toBackendRefs(ctx, cfRoute)
+	if err != nil {
+		log.Info("failed to build HTTPRoute backends", "reason", err)
+		return err
+	}
+
 	result, err := controllerutil.CreateOrPatch(ctx, r.client, httpRoute, func() error {
 		httpRoute.Spec.ParentRefs = []gatewayv1beta1.ParentReference{{
 			Group:     tools.PtrTo(gatewayv1beta1.Group("gateway.networking.k8s.io")),
@@ -321,7 +348,7 @@ func (r *Reconciler) reconcileHTTPRoute(ctx context.Context, cfRoute *korifiv1al
 		}
 
 		httpRoute.Spec.Rules = []gatewayv1beta1.HTTPRouteRule{{
-			BackendRefs: toBackendRefs(cfRoute.Status.Destinations),
+			BackendRefs: backendRefs,
 		}}
 		if cfRoute.Spec.Path != "" {
 			httpRoute.Spec.Rules[0].Matches = []gatewayv1beta1.HTTPRouteMatch{{
@@ -406,10 +433,20 @@ func buildFQDN(cfRoute *korifiv1alpha1.CFRoute, cfDomain *korifiv1alpha1.CFDomai
 	return fmt.Sprintf("%s.%s", strings.ToLower(cfRoute.Spec.Host), cfDomain.Spec.Name)
 }
 
-func toBackendRefs(destinations []korifiv1alpha1.Destination) []gatewayv1beta1.HTTPBackendRef {
+func (r *Reconciler) toBackendRefs(ctx context.Context, cfRoute *korifiv1alpha1.CFRoute) ([]gatewayv1beta1.HTTPBackendRef, error) {
 	backendRefs := []gatewayv1beta1.HTTPBackendRef{}
 
-	for _, destination := range destinations {
+	for _, destination := range cfRoute.Status.Destinations {
+		workloadBackend, err := r.getWorkloadBackend(ctx, cfRoute.Namespace, destination)
+		if err != nil {
+			return nil, err
+		}
+
+		if workloadBackend != nil {
+			backendRefs = append(backendRefs, toWorkloadBackendRef(workloadBackend))
+			continue
+		}
+
 		backendRefs = append(backendRefs, gatewayv1beta1.HTTPBackendRef{
 			BackendRef: gatewayv1beta1.BackendRef{
 				BackendObjectReference: gatewayv1beta1.BackendObjectReference{
@@ -421,5 +458,64 @@ func toBackendRefs(destinations []korifiv1alpha1.Destination) []gatewayv1beta1.H
 		})
 	}
 
-	return backendRefs
+	return backendRefs, nil
+}
+
+// getWorkloadBackend returns the backend the runner of the destination
+// process publishes, if any. When a process has several workloads, e.g.
+// while it is being restarted, the newest one with a backend is used.
+func (r *Reconciler) getWorkloadBackend(ctx context.Context, namespace string, destination korifiv1alpha1.Destination) (*korifiv1alpha1.AppWorkloadBackend, error) {
+	appWorkloads := &korifiv1alpha1.AppWorkloadList{}
+	err := r.client.List(ctx, appWorkloads, client.InNamespace(namespace), client.MatchingLabels{
+		korifiv1alpha1.CFAppGUIDLabelKey:     destination.AppRef.Name,
+		korifiv1alpha1.CFProcessTypeLabelKey: destination.ProcessType,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the workloads of app %q: %w", destination.AppRef.Name, err)
+	}
+
+	var backend *korifiv1alpha1.AppWorkloadBackend
+	var backendCreated metav1.Time
+	for _, appWorkload := range appWorkloads.Items {
+		if appWorkload.Status.Backend == nil || appWorkload.CreationTimestamp.Before(&backendCreated) {
+			continue
+		}
+
+		backend = appWorkload.Status.Backend
+		backendCreated = appWorkload.CreationTimestamp
+	}
+
+	return backend, nil
+}
+
+func toWorkloadBackendRef(backend *korifiv1alpha1.AppWorkloadBackend) gatewayv1beta1.HTTPBackendRef {
+	backendRef := gatewayv1beta1.HTTPBackendRef{
+		BackendRef: gatewayv1beta1.BackendRef{
+			BackendObjectReference: gatewayv1beta1.BackendObjectReference{
+				Kind: tools.PtrTo(gatewayv1beta1.Kind("Service")),
+				Name: gatewayv1beta1.ObjectName(backend.ServiceName),
+				Port: tools.PtrTo(gatewayv1beta1.PortNumber(backend.Port)),
+			},
+		},
+	}
+
+	if len(backend.RequestHeaders) == 0 {
+		return backendRef
+	}
+
+	headers := []gatewayv1.HTTPHeader{}
+	for _, name := range slices.Sorted(maps.Keys(backend.RequestHeaders)) {
+		headers = append(headers, gatewayv1.HTTPHeader{
+			Name:  gatewayv1.HTTPHeaderName(name),
+			Value: backend.RequestHeaders[name],
+		})
+	}
+	backendRef.Filters = []gatewayv1beta1.HTTPRouteFilter{{
+		Type: gatewayv1.HTTPRouteFilterRequestHeaderModifier,
+		RequestHeaderModifier: &gatewayv1.HTTPHeaderFilter{
+			Set: headers,
+		},
+	}}
+
+	return backendRef
 }
@@ -276,6 +276,65 @@ var _ = Describe("CFRouteReconciler Integration Tests", func() {
 			})
 		})
 
+		When("the runner of the destination process publishes a backend", func() {
+			BeforeEach(func() {
+				appWorkload := &korifiv1alpha1.AppWorkload{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: ns.Name,
+						Name:      uuid.NewString(),
+						Labels: map[string]string{
+							korifiv1alpha1.CFAppGUIDLabelKey:     cfApp.Name,
+							korifiv1alpha1.CFProcessTypeLabelKey: "web",
+						},
+					},
+					Spec: korifiv1alpha1.AppWorkloadSpec{
+						GUID:        uuid.NewString(),
+						AppGUID:     cfApp.Name,
+						ProcessType: "web",
+						Image:       "my-image",
+						RunnerName:  "knative-runner",
+						Instances:   1,
+					},
+				}
+				Expect(adminClient.Create(ctx, appWorkload)).To(Succeed())
+				Expect(k8s.Patch(ctx, adminClient, appWorkload, func() {
+					appWorkload.Status.Backend = &korifiv1alpha1.AppWorkloadBackend{
+						ServiceName: "my-revision",
+						Port:        80,
+						RequestHeaders: map[string]string{
+							"Knative-Serving-Revision":  "my-revision",
+							"Knative-Serving-Namespace": ns.Name,
+						},
+					}
+				})).To(Succeed())
+			})
+
+			It("routes the requests to the published backend", func() {
+				Eventually(func(g Gomega) {
+					httpRoute := getHTTPRoute()
+					g.Expect(httpRoute.Spec.Rules).To(HaveLen(1))
+					g.Expect(httpRoute.Spec.Rules[0].BackendRefs).To(HaveLen(1))
+
+					backendRef := httpRoute.Spec.Rules[0].BackendRefs[0]
+					g.Expect(backendRef.BackendRef.BackendObjectReference).To(Equal(gatewayv1beta1.BackendObjectReference{
+						Group: tools.PtrTo(gatewayv1beta1.Group("")),
+						Kind:  tools.PtrTo(gatewayv1beta1.Kind("Service")),
+						Name:  gatewayv1beta1.ObjectName("my-revision"),
+						Port:  tools.PtrTo(gatewayv1beta1.PortNumber(80)),
+					}))
+					g.Expect(backendRef.Filters).To(ConsistOf(gatewayv1beta1.HTTPRouteFilter{
+						Type: gatewayv1.HTTPRouteFilterRequestHeaderModifier,
+						RequestHeaderModifier: &gatewayv1.HTTPHeaderFilter{
+							Set: []gatewayv1.HTTPHeader{
+								{Name: "Knative-Serving-Namespace", Value: ns.Name},
+								{Name: "Knative-Serving-Revision", Value: "my-revision"},
+							},
+						},
+					}))
+				}).Should(Succeed())
+			})
+		})
+
 		When("the destination has no port set", func() {
 			BeforeEach(func() {
 				cfRoute.Spec.Destinations[0].Port = nil
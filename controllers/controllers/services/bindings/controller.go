@@ -41,11 +41,12 @@ type DelegateReconciler interface {
 }
 
 type Reconciler struct {
-	k8sClient         client.Client
-	scheme            *runtime.Scheme
-	log               logr.Logger
-	upsiReconciler    DelegateReconciler
-	managedReconciler DelegateReconciler
+	k8sClient            client.Client
+	scheme               *runtime.Scheme
+	log                  logr.Logger
+	upsiReconciler       DelegateReconciler
+	managedReconciler    DelegateReconciler
+	kubernetesReconciler DelegateReconciler
 }
 
 func NewReconciler(
@@ -54,13 +55,15 @@ func NewReconciler(
 	log logr.Logger,
 	upsiCredentialsReconciler DelegateReconciler,
 	managedCredentialsReconciler DelegateReconciler,
+	kubernetesCredentialsReconciler DelegateReconciler,
 ) *k8s.PatchingReconciler[korifiv1alpha1.CFServiceBinding, *korifiv1alpha1.CFServiceBinding] {
 	cfBindingReconciler := &Reconciler{
-		k8sClient:         k8sClient,
-		scheme:            scheme,
-		log:               log,
-		upsiReconciler:    upsiCredentialsReconciler,
-		managedReconciler: managedCredentialsReconciler,
+		k8sClient:            k8sClient,
+		scheme:               scheme,
+		log:                  log,
+		upsiReconciler:       upsiCredentialsReconciler,
+		managedReconciler:    managedCredentialsReconciler,
+		kubernetesReconciler: kubernetesCredentialsReconciler,
 	}
 	return k8s.NewPatchingReconciler(log, k8sClient, cfBindingReconciler)
 }
@@ -142,11 +145,14 @@ func (r *Reconciler) ReconcileResource(ctx context.Context, cfServiceBinding *ko
 }
 
 func (r *Reconciler) reconcileByType(ctx context.Context, cfServiceInstance *korifiv1alpha1.CFServiceInstance, cfServiceBinding *korifiv1alpha1.CFServiceBinding) (ctrl.Result, error) {
-	if cfServiceInstance.Spec.Type == korifiv1alpha1.UserProvidedType {
+	switch cfServiceInstance.Spec.Type {
+	case korifiv1alpha1.UserProvidedType:
 		return r.upsiReconciler.ReconcileResource(ctx, cfServiceBinding)
+	case korifiv1alpha1.KubernetesType:
+		return r.kubernetesReconciler.ReconcileResource(ctx, cfServiceBinding)
+	default:
+		return r.managedReconciler.ReconcileResource(ctx, cfServiceBinding)
 	}
-
-	return r.managedReconciler.ReconcileResource(ctx, cfServiceBinding)
 }
 
 func needsRequeue(res ctrl.Result, err error) bool {
@@ -329,6 +329,161 @@ var _ = Describe("CFServiceBinding", func() {
 		})
 	})
 
+	Describe("kubernetes bindings", func() {
+		var (
+			instance      *korifiv1alpha1.CFServiceInstance
+			serviceSecret *corev1.Secret
+		)
+
+		BeforeEach(func() {
+			serviceSecret = &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      uuid.NewString(),
+					Namespace: testNamespace,
+				},
+				StringData: map[string]string{
+					"username": "bob",
+				},
+			}
+			Expect(adminClient.Create(ctx, serviceSecret)).To(Succeed())
+
+			instance = &korifiv1alpha1.CFServiceInstance{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      instanceGUID,
+					Namespace: testNamespace,
+				},
+				Spec: korifiv1alpha1.CFServiceInstanceSpec{
+					DisplayName: "kubernetes-service-instance-name",
+					Type:        korifiv1alpha1.KubernetesType,
+					Tags:        []string{},
+					ServiceRef: &corev1.ObjectReference{
+						Kind: "Secret",
+						Name: serviceSecret.Name,
+					},
+				},
+			}
+			Expect(adminClient.Create(ctx, instance)).To(Succeed())
+			Expect(k8s.Patch(ctx, adminClient, instance, func() {
+				instance.Status.ServiceSecret = &corev1.SecretReference{
+					Namespace: serviceSecret.Namespace,
+					Name:      serviceSecret.Name,
+				}
+			})).To(Succeed())
+		})
+
+		It("sets the service-instance-type annotation to kubernetes", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(binding), binding)).To(Succeed())
+				g.Expect(binding.Annotations).To(HaveKeyWithValue(
+					korifiv1alpha1.ServiceInstanceTypeAnnotationKey, "kubernetes",
+				))
+			}).Should(Succeed())
+		})
+
+		It("creates the env secret from the service secret", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(binding), binding)).To(Succeed())
+				g.Expect(binding.Status.EnvSecretRef.Name).To(Equal(binding.Name))
+
+				envSecret := &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: binding.Namespace,
+						Name:      binding.Status.EnvSecretRef.Name,
+					},
+				}
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(envSecret), envSecret)).To(Succeed())
+				creds := map[string]any{}
+				g.Expect(json.Unmarshal(envSecret.Data[tools.CredentialsSecretKey], &creds)).To(Succeed())
+				g.Expect(creds).To(Equal(map[string]any{"username": "bob"}))
+			}).Should(Succeed())
+		})
+
+		It("creates the mount secret from the service secret", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(binding), binding)).To(Succeed())
+				g.Expect(binding.Status.MountSecretRef.Name).To(Equal(binding.Name + "-sbio"))
+
+				mountSecret := &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: binding.Namespace,
+						Name:      binding.Status.MountSecretRef.Name,
+					},
+				}
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(mountSecret), mountSecret)).To(Succeed())
+				g.Expect(mountSecret.Type).To(BeEquivalentTo("servicebinding.io/kubernetes"))
+				g.Expect(mountSecret.Data).To(MatchAllKeys(Keys{
+					"type":     BeEquivalentTo("kubernetes"),
+					"username": BeEquivalentTo("bob"),
+				}))
+			}).Should(Succeed())
+		})
+
+		It("sets the binding Ready status condition to true", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(binding), binding)).To(Succeed())
+				g.Expect(binding.Status.Conditions).To(ContainElement(SatisfyAll(
+					HasType(Equal(korifiv1alpha1.StatusConditionReady)),
+					HasStatus(Equal(metav1.ConditionTrue)),
+				)))
+				g.Expect(binding.Status.CredentialsObservedVersion).NotTo(BeEmpty())
+			}).Should(Succeed())
+		})
+
+		When("the service secret has a 'type' entry", func() {
+			BeforeEach(func() {
+				Expect(k8s.Patch(ctx, adminClient, serviceSecret, func() {
+					serviceSecret.StringData = map[string]string{"type": "postgresql"}
+				})).To(Succeed())
+			})
+
+			It("sets the mount secret type accordingly", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(binding), binding)).To(Succeed())
+					mountSecret := &corev1.Secret{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: binding.Namespace,
+							Name:      binding.Name + "-sbio",
+						},
+					}
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(mountSecret), mountSecret)).To(Succeed())
+					g.Expect(mountSecret.Type).To(BeEquivalentTo("servicebinding.io/postgresql"))
+				}).Should(Succeed())
+			})
+		})
+
+		When("the service secret is not resolved yet", func() {
+			BeforeEach(func() {
+				Expect(k8s.Patch(ctx, adminClient, instance, func() {
+					instance.Status.ServiceSecret = nil
+				})).To(Succeed())
+			})
+
+			It("sets the Ready condition to false", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(binding), binding)).To(Succeed())
+					g.Expect(binding.Status.Conditions).To(ContainElement(SatisfyAll(
+						HasType(Equal(korifiv1alpha1.StatusConditionReady)),
+						HasStatus(Equal(metav1.ConditionFalse)),
+						HasReason(Equal("CredentialsSecretNotAvailable")),
+					)))
+				}).Should(Succeed())
+			})
+		})
+
+		When("the binding is deleted", func() {
+			JustBeforeEach(func() {
+				Expect(adminClient.Delete(ctx, binding)).To(Succeed())
+			})
+
+			It("is deleted", func() {
+				Eventually(func(g Gomega) {
+					err := adminClient.Get(ctx, client.ObjectKeyFromObject(binding), binding)
+					g.Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+				}).Should(Succeed())
+			})
+		})
+	})
+
 	Describe("managed service bindings", func() {
 		var (
 			brokerClient *fake.BrokerClient
@@ -668,6 +823,259 @@ var _ = Describe("CFServiceBinding", func() {
 			})
 		})
 
+		When("credential rotation is requested", func() {
+			BeforeEach(func() {
+				Eventually(func(g Gomega) {
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(binding), binding)).To(Succeed())
+					g.Expect(binding.Status.EnvSecretRef.Name).NotTo(BeEmpty())
+				}).Should(Succeed())
+
+				brokerClient.BindReturns(osbapi.BindResponse{
+					Credentials: map[string]any{
+						"foo": "rotated",
+					},
+				}, nil)
+			})
+
+			JustBeforeEach(func() {
+				Expect(k8s.Patch(ctx, adminClient, binding, func() {
+					binding.Spec.CredentialRotation++
+				})).To(Succeed())
+			})
+
+			It("binds under a new binding id", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(brokerClient.BindCallCount()).To(BeNumerically(">", 1))
+					_, payload := brokerClient.BindArgsForCall(brokerClient.BindCallCount() - 1)
+					g.Expect(payload.InstanceID).To(Equal(instance.Name))
+					g.Expect(payload.BindingID).NotTo(BeEmpty())
+					g.Expect(payload.BindingID).NotTo(Equal(binding.Name))
+				}).Should(Succeed())
+			})
+
+			It("records the new binding id and retires the old one", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(binding), binding)).To(Succeed())
+					g.Expect(binding.Status.BindingID).NotTo(BeEmpty())
+					g.Expect(binding.Status.BindingID).NotTo(Equal(binding.Name))
+					g.Expect(binding.Status.ObservedCredentialRotation).To(BeEquivalentTo(1))
+					g.Expect(binding.Status.RetiredBindings).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+						"ID": Equal(binding.Name),
+					})))
+					g.Expect(binding.Status.Conditions).To(ContainElement(SatisfyAll(
+						HasType(Equal(korifiv1alpha1.CredentialRotationFailedCondition)),
+						HasStatus(Equal(metav1.ConditionFalse)),
+						HasReason(Equal("CredentialsRotated")),
+					)))
+				}).Should(Succeed())
+			})
+
+			It("updates the credential secrets", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(binding), binding)).To(Succeed())
+
+					envSecret := &corev1.Secret{}
+					g.Expect(adminClient.Get(ctx, client.ObjectKey{Namespace: binding.Namespace, Name: binding.Status.EnvSecretRef.Name}, envSecret)).To(Succeed())
+					g.Expect(envSecret.Data).To(MatchKeys(IgnoreExtras, Keys{
+						tools.CredentialsSecretKey: BeEquivalentTo(`{"foo":"rotated"}`),
+					}))
+
+					mountSecret := &corev1.Secret{}
+					g.Expect(adminClient.Get(ctx, client.ObjectKey{Namespace: binding.Namespace, Name: binding.Status.MountSecretRef.Name}, mountSecret)).To(Succeed())
+					g.Expect(mountSecret.Data).To(MatchKeys(IgnoreExtras, Keys{
+						"foo": BeEquivalentTo("rotated"),
+					}))
+				}).Should(Succeed())
+			})
+
+			It("does not unbind the old binding before the grace period expires", func() {
+				Consistently(func(g Gomega) {
+					g.Expect(brokerClient.UnbindCallCount()).To(BeZero())
+				}).Should(Succeed())
+			})
+
+			When("the new binding is asynchronous", func() {
+				BeforeEach(func() {
+					brokerClient.BindReturns(osbapi.BindResponse{
+						IsAsync:   true,
+						Operation: "rotate-op",
+					}, nil)
+					brokerClient.GetServiceBindingLastOperationReturns(osbapi.LastOperationResponse{
+						State: "succeeded",
+					}, nil)
+					brokerClient.FetchBindingReturns(osbapi.GetBindingResponse{
+						Credentials: map[string]any{
+							"foo": "fetched",
+						},
+					}, nil)
+				})
+
+				It("fetches the credentials of the new binding from the broker", func() {
+					Eventually(func(g Gomega) {
+						g.Expect(brokerClient.FetchBindingCallCount()).To(BeNumerically(">", 0))
+						_, request := brokerClient.FetchBindingArgsForCall(brokerClient.FetchBindingCallCount() - 1)
+						g.Expect(request.InstanceID).To(Equal(instance.Name))
+						g.Expect(request.BindingID).NotTo(Equal(binding.Name))
+
+						g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(binding), binding)).To(Succeed())
+						g.Expect(binding.Status.BindingID).To(Equal(request.BindingID))
+
+						envSecret := &corev1.Secret{}
+						g.Expect(adminClient.Get(ctx, client.ObjectKey{Namespace: binding.Namespace, Name: binding.Status.EnvSecretRef.Name}, envSecret)).To(Succeed())
+						g.Expect(envSecret.Data).To(MatchKeys(IgnoreExtras, Keys{
+							tools.CredentialsSecretKey: BeEquivalentTo(`{"foo":"fetched"}`),
+						}))
+					}).Should(Succeed())
+				})
+			})
+
+			When("the broker rejects the new binding", func() {
+				BeforeEach(func() {
+					brokerClient.BindReturns(osbapi.BindResponse{}, osbapi.UnrecoverableError{Status: http.StatusBadRequest})
+				})
+
+				It("sets the credential rotation failed condition", func() {
+					Eventually(func(g Gomega) {
+						g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(binding), binding)).To(Succeed())
+						g.Expect(binding.Status.BindingID).To(BeEmpty())
+						g.Expect(binding.Status.Conditions).To(ContainElement(SatisfyAll(
+							HasType(Equal(korifiv1alpha1.CredentialRotationFailedCondition)),
+							HasStatus(Equal(metav1.ConditionTrue)),
+						)))
+					}).Should(Succeed())
+				})
+			})
+		})
+
+		When("the broker returns volume mounts", func() {
+			BeforeEach(func() {
+				brokerClient.BindReturns(osbapi.BindResponse{
+					Credentials: map[string]any{
+						"foo": "bar",
+					},
+					VolumeMounts: []osbapi.VolumeMount{{
+						Driver:       "nfs",
+						ContainerDir: "/data",
+						Mode:         "rw",
+						DeviceType:   "shared",
+						Device: osbapi.VolumeDevice{
+							VolumeID: "volume-id",
+							MountConfig: map[string]any{
+								"source": "nfs://nfs.example.com/export",
+								"uid":    1000,
+							},
+						},
+					}},
+				}, nil)
+			})
+
+			It("fails the binding as the offering does not require volume_mount", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(binding), binding)).To(Succeed())
+					g.Expect(binding.Status.VolumeMounts).To(BeEmpty())
+					g.Expect(binding.Status.Conditions).To(ContainElement(SatisfyAll(
+						HasType(Equal(korifiv1alpha1.BindingFailedCondition)),
+						HasStatus(Equal(metav1.ConditionTrue)),
+						HasReason(Equal("InvalidVolumeMounts")),
+						HasMessage(ContainSubstring("volume_mount")),
+					)))
+				}).Should(Succeed())
+			})
+
+			It("unbinds the rejected binding", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(brokerClient.UnbindCallCount()).To(BeNumerically(">", 0))
+					_, payload := brokerClient.UnbindArgsForCall(0)
+					g.Expect(payload.BindingID).To(Equal(binding.Name))
+				}).Should(Succeed())
+			})
+
+			When("the offering requires volume_mount", func() {
+				BeforeEach(func() {
+					Expect(k8s.PatchResource(ctx, adminClient, serviceOffering, func() {
+						serviceOffering.Spec.Requires = []string{"volume_mount"}
+					})).To(Succeed())
+				})
+
+				It("stores the volume mounts in the binding status", func() {
+					Eventually(func(g Gomega) {
+						g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(binding), binding)).To(Succeed())
+						g.Expect(binding.Status.VolumeMounts).To(ConsistOf(korifiv1alpha1.ServiceVolumeMount{
+							Driver:       "nfs",
+							ContainerDir: "/data",
+							Mode:         korifiv1alpha1.VolumeMountModeReadWrite,
+							VolumeID:     "volume-id",
+							MountConfig: map[string]string{
+								"source": "nfs://nfs.example.com/export",
+								"uid":    "1000",
+							},
+						}))
+					}).Should(Succeed())
+				})
+
+				When("the volume mount driver is not allowed", func() {
+					BeforeEach(func() {
+						brokerClient.BindReturns(osbapi.BindResponse{
+							VolumeMounts: []osbapi.VolumeMount{{
+								Driver:       "smb",
+								ContainerDir: "/data",
+								Mode:         "rw",
+								DeviceType:   "shared",
+								Device: osbapi.VolumeDevice{
+									VolumeID: "volume-id",
+								},
+							}},
+						}, nil)
+					})
+
+					It("fails the binding", func() {
+						Eventually(func(g Gomega) {
+							g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(binding), binding)).To(Succeed())
+							g.Expect(binding.Status.VolumeMounts).To(BeEmpty())
+							g.Expect(binding.Status.Conditions).To(ContainElement(SatisfyAll(
+								HasType(Equal(korifiv1alpha1.BindingFailedCondition)),
+								HasStatus(Equal(metav1.ConditionTrue)),
+								HasReason(Equal("InvalidVolumeMounts")),
+								HasMessage(ContainSubstring(`driver "smb" is not allowed`)),
+							)))
+						}).Should(Succeed())
+					})
+				})
+
+				When("the nfs server is not allowed", func() {
+					BeforeEach(func() {
+						brokerClient.BindReturns(osbapi.BindResponse{
+							VolumeMounts: []osbapi.VolumeMount{{
+								Driver:       "nfs",
+								ContainerDir: "/data",
+								Mode:         "rw",
+								DeviceType:   "shared",
+								Device: osbapi.VolumeDevice{
+									VolumeID: "volume-id",
+									MountConfig: map[string]any{
+										"source": "nfs://other.example.com/export",
+									},
+								},
+							}},
+						}, nil)
+					})
+
+					It("fails the binding", func() {
+						Eventually(func(g Gomega) {
+							g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(binding), binding)).To(Succeed())
+							g.Expect(binding.Status.VolumeMounts).To(BeEmpty())
+							g.Expect(binding.Status.Conditions).To(ContainElement(SatisfyAll(
+								HasType(Equal(korifiv1alpha1.BindingFailedCondition)),
+								HasStatus(Equal(metav1.ConditionTrue)),
+								HasReason(Equal("InvalidVolumeMounts")),
+								HasMessage(ContainSubstring(`nfs server "other.example.com" is not allowed`)),
+							)))
+						}).Should(Succeed())
+					})
+				})
+			})
+		})
+
 		When("binding is asynchronous", func() {
 			BeforeEach(func() {
 				brokerClient.BindReturns(osbapi.BindResponse{
@@ -872,6 +1280,132 @@ var _ = Describe("CFServiceBinding", func() {
 					}).Should(Succeed())
 				})
 			})
+
+			When("the broker response to the bind request is ambiguous", func() {
+				BeforeEach(func() {
+					brokerClient.BindReturns(osbapi.BindResponse{}, osbapi.OrphanMitigationRequiredError{
+						Cause: errors.New("binding request failed with code: 500"),
+					})
+				})
+
+				It("unbinds the binding with the broker", func() {
+					Eventually(func(g Gomega) {
+						g.Expect(brokerClient.UnbindCallCount()).To(BeNumerically(">=", 1))
+						_, actualUnbindRequest := brokerClient.UnbindArgsForCall(0)
+						g.Expect(actualUnbindRequest).To(Equal(osbapi.UnbindPayload{
+							InstanceID: instance.Name,
+							BindingID:  binding.Name,
+							UnbindRequestParameters: osbapi.UnbindRequestParameters{
+								ServiceId: "service-offering-id",
+								PlanID:    "service-plan-id",
+							},
+						}))
+					}).Should(Succeed())
+				})
+
+				It("fails the binding once the orphan is mitigated", func() {
+					Eventually(func(g Gomega) {
+						g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(binding), binding)).To(Succeed())
+						g.Expect(binding.Status.Conditions).To(ContainElements(
+							SatisfyAll(
+								HasType(Equal(korifiv1alpha1.OrphanMitigationInProgressCondition)),
+								HasStatus(Equal(metav1.ConditionFalse)),
+								HasReason(Equal("OrphanMitigated")),
+							),
+							SatisfyAll(
+								HasType(Equal(korifiv1alpha1.BindingFailedCondition)),
+								HasStatus(Equal(metav1.ConditionTrue)),
+							),
+						))
+					}).Should(Succeed())
+				})
+
+				When("the unbind request fails", func() {
+					BeforeEach(func() {
+						brokerClient.UnbindReturns(osbapi.UnbindResponse{}, errors.New("unbind-failed"))
+					})
+
+					It("keeps trying to unbind and records the mitigation progress", func() {
+						Eventually(func(g Gomega) {
+							g.Expect(brokerClient.UnbindCallCount()).To(BeNumerically(">", 1))
+							g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(binding), binding)).To(Succeed())
+							g.Expect(binding.Status.Conditions).To(ContainElement(SatisfyAll(
+								HasType(Equal(korifiv1alpha1.OrphanMitigationInProgressCondition)),
+								HasStatus(Equal(metav1.ConditionTrue)),
+								HasReason(Equal("UnbindFailed")),
+							)))
+						}).Should(Succeed())
+					})
+				})
+
+				When("the unbind is asynchronous", func() {
+					BeforeEach(func() {
+						brokerClient.UnbindReturns(osbapi.UnbindResponse{IsAsync: true, Operation: "unbind-op"}, nil)
+						brokerClient.GetServiceBindingLastOperationReturns(osbapi.LastOperationResponse{State: "in progress"}, nil)
+					})
+
+					It("stores the operation and polls it instead of unbinding again", func() {
+						Eventually(func(g Gomega) {
+							g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(binding), binding)).To(Succeed())
+							g.Expect(binding.Status.OrphanMitigationOperation).To(PointTo(Equal("unbind-op")))
+
+							g.Expect(brokerClient.GetServiceBindingLastOperationCallCount()).To(BeNumerically(">", 1))
+							_, actualLastOpPayload := brokerClient.GetServiceBindingLastOperationArgsForCall(1)
+							g.Expect(actualLastOpPayload).To(Equal(osbapi.GetBindingLastOperationRequest{
+								InstanceID: instance.Name,
+								BindingID:  binding.Name,
+								GetLastOperationRequestParameters: osbapi.GetLastOperationRequestParameters{
+									ServiceId: "service-offering-id",
+									PlanID:    "service-plan-id",
+									Operation: "unbind-op",
+								},
+							}))
+						}).Should(Succeed())
+
+						Consistently(func(g Gomega) {
+							g.Expect(brokerClient.UnbindCallCount()).To(Equal(1))
+						}).Should(Succeed())
+					})
+
+					When("the broker reports the binding is gone", func() {
+						BeforeEach(func() {
+							brokerClient.GetServiceBindingLastOperationReturns(osbapi.LastOperationResponse{}, osbapi.GoneError{})
+						})
+
+						It("fails the binding once the orphan is mitigated", func() {
+							Eventually(func(g Gomega) {
+								g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(binding), binding)).To(Succeed())
+								g.Expect(binding.Status.OrphanMitigationOperation).To(BeNil())
+								g.Expect(binding.Status.Conditions).To(ContainElements(
+									SatisfyAll(
+										HasType(Equal(korifiv1alpha1.OrphanMitigationInProgressCondition)),
+										HasStatus(Equal(metav1.ConditionFalse)),
+									),
+									SatisfyAll(
+										HasType(Equal(korifiv1alpha1.BindingFailedCondition)),
+										HasStatus(Equal(metav1.ConditionTrue)),
+									),
+								))
+							}).Should(Succeed())
+						})
+					})
+
+					When("the unbind fails", func() {
+						BeforeEach(func() {
+							brokerClient.GetServiceBindingLastOperationReturns(osbapi.LastOperationResponse{
+								State:       "failed",
+								Description: "unbind-failed",
+							}, nil)
+						})
+
+						It("unbinds again", func() {
+							Eventually(func(g Gomega) {
+								g.Expect(brokerClient.UnbindCallCount()).To(BeNumerically(">", 1))
+							}).Should(Succeed())
+						})
+					})
+				})
+			})
 		})
 
 		Describe("unbind", func() {
@@ -0,0 +1,168 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"time"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/controllers/services/credentials"
+	"code.cloudfoundry.org/korifi/tools"
+	"code.cloudfoundry.org/korifi/tools/k8s"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+type KubernetesBindingReconciler struct {
+	k8sClient client.Client
+	scheme    *runtime.Scheme
+}
+
+func NewReconciler(k8sClient client.Client, scheme *runtime.Scheme) *KubernetesBindingReconciler {
+	return &KubernetesBindingReconciler{
+		k8sClient: k8sClient,
+		scheme:    scheme,
+	}
+}
+
+// ReconcileResource follows the service secret of the bound `kubernetes`
+// service instance and copies its data into the binding env and mount
+// secrets. The instance status changes whenever the service secret does,
+// which keeps the binding secrets in sync.
+func (r *KubernetesBindingReconciler) ReconcileResource(ctx context.Context, cfServiceBinding *korifiv1alpha1.CFServiceBinding) (ctrl.Result, error) {
+	log := logr.FromContextOrDiscard(ctx)
+
+	if !cfServiceBinding.GetDeletionTimestamp().IsZero() {
+		if controllerutil.RemoveFinalizer(cfServiceBinding, korifiv1alpha1.CFServiceBindingFinalizerName) {
+			log.V(1).Info("finalizer removed")
+		}
+
+		return ctrl.Result{}, nil
+	}
+
+	cfServiceInstance := new(korifiv1alpha1.CFServiceInstance)
+	err := r.k8sClient.Get(ctx, types.NamespacedName{Name: cfServiceBinding.Spec.Service.Name, Namespace: cfServiceBinding.Namespace}, cfServiceInstance)
+	if err != nil {
+		log.Info("service instance not found", "service-instance", cfServiceBinding.Spec.Service.Name, "error", err)
+		return ctrl.Result{}, err
+	}
+
+	if cfServiceInstance.Status.ServiceSecret == nil {
+		return ctrl.Result{}, k8s.NewNotReadyError().
+			WithReason("CredentialsSecretNotAvailable").
+			WithMessage("Service instance secret not available yet").
+			WithRequeueAfter(time.Second)
+	}
+
+	serviceSecret := &corev1.Secret{}
+	err = r.k8sClient.Get(ctx, types.NamespacedName{
+		Namespace: cfServiceInstance.Status.ServiceSecret.Namespace,
+		Name:      cfServiceInstance.Status.ServiceSecret.Name,
+	}, serviceSecret)
+	if err != nil {
+		return ctrl.Result{}, k8s.NewNotReadyError().
+			WithCause(err).
+			WithReason("CredentialsSecretNotAvailable").
+			WithRequeueAfter(time.Second)
+	}
+
+	envSecret, err := r.createEnvSecret(ctx, cfServiceBinding, serviceSecret)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	cfServiceBinding.Status.EnvSecretRef.Name = envSecret.Name
+
+	mountSecret, err := r.createMountSecret(ctx, cfServiceBinding, serviceSecret)
+	if err != nil {
+		// The secret type is immutable, so the mount secret has to be
+		// recreated when the type of the service secret changes
+		if k8serrors.IsInvalid(err) {
+			err = r.k8sClient.Delete(ctx, &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      cfServiceBinding.Name + "-sbio",
+					Namespace: cfServiceBinding.Namespace,
+				},
+			})
+			if client.IgnoreNotFound(err) != nil {
+				return ctrl.Result{}, fmt.Errorf("failed to delete outdated binding secret: %w", err)
+			}
+
+			return ctrl.Result{}, k8s.NewNotReadyError().WithReason("BindingSecretOutdated").WithRequeue()
+		}
+
+		return ctrl.Result{}, err
+	}
+	cfServiceBinding.Status.MountSecretRef.Name = mountSecret.Name
+
+	// Changing the binding status triggers the app controller to rebuild
+	// VCAP_SERVICES with the new credentials
+	cfServiceBinding.Status.CredentialsObservedVersion = serviceSecret.ResourceVersion
+
+	return ctrl.Result{}, nil
+}
+
+func (r *KubernetesBindingReconciler) createEnvSecret(ctx context.Context, cfServiceBinding *korifiv1alpha1.CFServiceBinding, serviceSecret *corev1.Secret) (*corev1.Secret, error) {
+	creds := map[string]any{}
+	for key, value := range serviceSecret.Data {
+		creds[key] = string(value)
+	}
+
+	envSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cfServiceBinding.Name,
+			Namespace: cfServiceBinding.Namespace,
+		},
+	}
+	_, err := controllerutil.CreateOrPatch(ctx, r.k8sClient, envSecret, func() error {
+		envSecretData, err := tools.ToCredentialsSecretData(creds)
+		if err != nil {
+			return err
+		}
+		envSecret.Data = envSecretData
+		return controllerutil.SetControllerReference(cfServiceBinding, envSecret, r.scheme)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create credentials secret: %w", err)
+	}
+
+	return envSecret, nil
+}
+
+func (r *KubernetesBindingReconciler) createMountSecret(ctx context.Context, cfServiceBinding *korifiv1alpha1.CFServiceBinding, serviceSecret *corev1.Secret) (*corev1.Secret, error) {
+	mountSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cfServiceBinding.Name + "-sbio",
+			Namespace: cfServiceBinding.Namespace,
+		},
+	}
+
+	_, err := controllerutil.CreateOrPatch(ctx, r.k8sClient, mountSecret, func() error {
+		mountSecret.Data = maps.Clone(serviceSecret.Data)
+		if mountSecret.Data == nil {
+			mountSecret.Data = map[string][]byte{}
+		}
+
+		// The servicebinding.io spec requires a type entry, which
+		// ProvisionedService secrets already have
+		if _, hasType := mountSecret.Data["type"]; !hasType {
+			mountSecret.Data["type"] = []byte(korifiv1alpha1.KubernetesType)
+		}
+		mountSecret.Type = corev1.SecretType(credentials.ServiceBindingSecretTypePrefix + string(mountSecret.Data["type"]))
+
+		return controllerutil.SetControllerReference(cfServiceBinding, mountSecret, r.scheme)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create binding secret: %w", err)
+	}
+
+	return mountSecret, nil
+}
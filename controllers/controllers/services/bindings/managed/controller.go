@@ -2,9 +2,16 @@ package managed
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"net/url"
+	"path"
+	"slices"
+	"strconv"
 	"time"
 
+	"code.cloudfoundry.org/korifi/controllers/config"
 	"code.cloudfoundry.org/korifi/controllers/controllers/services/credentials"
 	"code.cloudfoundry.org/korifi/controllers/controllers/services/osbapi"
 	"code.cloudfoundry.org/korifi/tools"
@@ -22,19 +29,37 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 )
 
+const (
+	volumeMountRequirement = "volume_mount"
+	sharedDeviceType       = "shared"
+)
+
 type ManagedBindingsReconciler struct {
 	k8sClient           client.Client
 	osbapiClientFactory osbapi.BrokerClientFactory
 	scheme              *runtime.Scheme
 	assets              *osbapi.Assets
+	rootNamespace       string
+	rotationGracePeriod time.Duration
+	volumeServices      config.VolumeServices
 }
 
-func NewReconciler(k8sClient client.Client, brokerClientFactory osbapi.BrokerClientFactory, rootNamespace string, scheme *runtime.Scheme) *ManagedBindingsReconciler {
+func NewReconciler(
+	k8sClient client.Client,
+	brokerClientFactory osbapi.BrokerClientFactory,
+	rootNamespace string,
+	scheme *runtime.Scheme,
+	rotationGracePeriod time.Duration,
+	volumeServices config.VolumeServices,
+) *ManagedBindingsReconciler {
 	return &ManagedBindingsReconciler{
 		k8sClient:           k8sClient,
 		osbapiClientFactory: brokerClientFactory,
 		scheme:              scheme,
 		assets:              osbapi.NewAssets(k8sClient, rootNamespace),
+		rootNamespace:       rootNamespace,
+		rotationGracePeriod: rotationGracePeriod,
+		volumeServices:      volumeServices,
 	}
 }
 
@@ -57,8 +82,21 @@ func (r *ManagedBindingsReconciler) ReconcileResource(ctx context.Context, cfSer
 		return r.finalizeCFServiceBinding(ctx, cfServiceBinding, assets, osbapiClient)
 	}
 
+	if isCredentialRotationRequested(cfServiceBinding) {
+		return r.rotateCredentials(ctx, cfServiceBinding, assets, osbapiClient)
+	}
+
+	result, err := r.unbindRetiredBindings(ctx, cfServiceBinding, assets, osbapiClient, r.rotationGracePeriod)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
 	if isReconciled(cfServiceBinding) {
-		return ctrl.Result{}, nil
+		return result, nil
+	}
+
+	if isOrphanMitigationInProgress(cfServiceBinding) {
+		return r.mitigateOrphan(ctx, cfServiceBinding, assets, osbapiClient)
 	}
 
 	if isFailed(cfServiceBinding) {
@@ -74,7 +112,7 @@ func (r *ManagedBindingsReconciler) ReconcileResource(ctx context.Context, cfSer
 
 	if bindResponse.IsAsync {
 		var lastOpResponse osbapi.LastOperationResponse
-		lastOpResponse, err = r.pollLastOperation(ctx, cfServiceBinding, assets, osbapiClient, bindResponse.Operation)
+		lastOpResponse, err = r.pollLastOperation(ctx, cfServiceBinding.BrokerBindingID(), assets, osbapiClient, bindResponse.Operation)
 		if err != nil {
 			return ctrl.Result{}, err
 		}
@@ -82,15 +120,24 @@ func (r *ManagedBindingsReconciler) ReconcileResource(ctx context.Context, cfSer
 		return r.processBindOperation(cfServiceBinding, lastOpResponse)
 	}
 
+	volumeMounts, err := r.toVolumeMounts(cfServiceBinding, assets, bindResponse.VolumeMounts)
+	if err != nil {
+		log.Info("broker returned invalid volume mounts", "reason", err)
+		return r.rejectVolumeMounts(cfServiceBinding, err)
+	}
+	cfServiceBinding.Status.VolumeMounts = volumeMounts
+
 	envSecret, err := r.createEnvSecret(ctx, cfServiceBinding, bindResponse.Credentials)
 	if err != nil {
 		return ctrl.Result{}, err
 	}
 
 	cfServiceBinding.Status.EnvSecretRef.Name = envSecret.Name
+	// The credentials have just been issued, so there is nothing to rotate
+	cfServiceBinding.Status.ObservedCredentialRotation = cfServiceBinding.Spec.CredentialRotation
 
 	if cfServiceBinding.Spec.Type == korifiv1alpha1.CFServiceBindingTypeKey {
-		return ctrl.Result{}, nil
+		return result, nil
 	}
 
 	mountSecret, err := r.createMountSecret(ctx, cfServiceBinding, bindResponse.Credentials)
@@ -100,7 +147,7 @@ func (r *ManagedBindingsReconciler) ReconcileResource(ctx context.Context, cfSer
 
 	cfServiceBinding.Status.MountSecretRef.Name = mountSecret.Name
 
-	return ctrl.Result{}, nil
+	return result, nil
 }
 
 func (r *ManagedBindingsReconciler) bind(
@@ -111,24 +158,12 @@ func (r *ManagedBindingsReconciler) bind(
 ) (osbapi.BindResponse, error) {
 	log := logr.FromContextOrDiscard(ctx)
 
-	parameters, err := r.getParameters(ctx, cfServiceBinding)
+	bindPayload, err := r.bindPayload(ctx, cfServiceBinding, assets, cfServiceBinding.BrokerBindingID())
 	if err != nil {
-		return osbapi.BindResponse{}, k8s.NewNotReadyError().WithReason("InvalidParameters")
+		return osbapi.BindResponse{}, err
 	}
 
-	bindResponse, err := osbapiClient.Bind(ctx, osbapi.BindPayload{
-		BindingID:  cfServiceBinding.Name,
-		InstanceID: assets.ServiceInstance.Name,
-		BindRequest: osbapi.BindRequest{
-			ServiceId: assets.ServiceOffering.Spec.BrokerCatalog.ID,
-			PlanID:    assets.ServicePlan.Spec.BrokerCatalog.ID,
-			AppGUID:   cfServiceBinding.Spec.AppRef.Name,
-			BindResource: osbapi.BindResource{
-				AppGUID: cfServiceBinding.Spec.AppRef.Name,
-			},
-			Parameters: parameters,
-		},
-	})
+	bindResponse, err := osbapiClient.Bind(ctx, bindPayload)
 	if err != nil {
 		log.Error(err, "failed to bind")
 
@@ -144,12 +179,510 @@ func (r *ManagedBindingsReconciler) bind(
 			return osbapi.BindResponse{}, k8s.NewNotReadyError().WithReason("BindingFailed")
 		}
 
+		if osbapi.IsOrphanMitigationRequired(err) {
+			meta.SetStatusCondition(&cfServiceBinding.Status.Conditions, metav1.Condition{
+				Type:               korifiv1alpha1.OrphanMitigationInProgressCondition,
+				Status:             metav1.ConditionTrue,
+				ObservedGeneration: cfServiceBinding.Generation,
+				LastTransitionTime: metav1.NewTime(time.Now()),
+				Reason:             "AmbiguousBindResponse",
+				Message:            err.Error(),
+			})
+			return osbapi.BindResponse{}, k8s.NewNotReadyError().WithReason("OrphanMitigationInProgress").WithRequeue()
+		}
+
 		return osbapi.BindResponse{}, err
 	}
 
 	return bindResponse, nil
 }
 
+func (r *ManagedBindingsReconciler) bindPayload(
+	ctx context.Context,
+	cfServiceBinding *korifiv1alpha1.CFServiceBinding,
+	assets osbapi.ServiceBindingAssets,
+	bindingID string,
+) (osbapi.BindPayload, error) {
+	parameters, err := r.getParameters(ctx, cfServiceBinding)
+	if err != nil {
+		return osbapi.BindPayload{}, k8s.NewNotReadyError().WithReason("InvalidParameters")
+	}
+
+	return osbapi.BindPayload{
+		BindingID:  bindingID,
+		InstanceID: assets.ServiceInstance.Name,
+		BindRequest: osbapi.BindRequest{
+			ServiceId: assets.ServiceOffering.Spec.BrokerCatalog.ID,
+			PlanID:    assets.ServicePlan.Spec.BrokerCatalog.ID,
+			AppGUID:   cfServiceBinding.Spec.AppRef.Name,
+			BindResource: osbapi.BindResource{
+				AppGUID: cfServiceBinding.Spec.AppRef.Name,
+			},
+			Parameters: parameters,
+		},
+	}, nil
+}
+
+// rotateCredentials binds to the service instance again under a new binding
+// ID and replaces the credentials in the binding secrets. Apps are restarted
+// in order to pick up the new credentials. The superseded broker binding is
+// retired and unbound once the rotation grace period has elapsed.
+func (r *ManagedBindingsReconciler) rotateCredentials(
+	ctx context.Context,
+	cfServiceBinding *korifiv1alpha1.CFServiceBinding,
+	assets osbapi.ServiceBindingAssets,
+	osbapiClient osbapi.BrokerClient,
+) (ctrl.Result, error) {
+	log := logr.FromContextOrDiscard(ctx).WithName("rotate-credentials")
+
+	newBindingID := tools.NamespacedUUID(cfServiceBinding.Name, strconv.FormatInt(cfServiceBinding.Spec.CredentialRotation, 10))
+
+	bindPayload, err := r.bindPayload(ctx, cfServiceBinding, assets, newBindingID)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	bindResponse, err := osbapiClient.Bind(ctx, bindPayload)
+	if err != nil {
+		log.Error(err, "failed to bind")
+
+		if osbapi.IsUnrecoveralbeError(err) {
+			return r.failCredentialRotation(cfServiceBinding, err.Error())
+		}
+
+		return ctrl.Result{}, k8s.NewNotReadyError().WithCause(err).WithReason("CredentialRotationFailed")
+	}
+
+	credentials, brokerVolumeMounts := bindResponse.Credentials, bindResponse.VolumeMounts
+	if bindResponse.IsAsync {
+		lastOpResponse, err := r.pollLastOperation(ctx, newBindingID, assets, osbapiClient, bindResponse.Operation)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+
+		if lastOpResponse.State == "failed" {
+			return r.failCredentialRotation(cfServiceBinding, lastOpResponse.Description)
+		}
+
+		if lastOpResponse.State != "succeeded" {
+			return ctrl.Result{}, k8s.NewNotReadyError().WithReason("CredentialRotationInProgress").WithRequeue()
+		}
+
+		// Asynchronous bind responses carry no credentials, they have to be
+		// fetched from the broker once the binding has been created
+		brokerBinding, err := osbapiClient.FetchBinding(ctx, osbapi.GetBindingRequest{
+			InstanceID: assets.ServiceInstance.Name,
+			BindingID:  newBindingID,
+			ServiceId:  assets.ServiceOffering.Spec.BrokerCatalog.ID,
+			PlanID:     assets.ServicePlan.Spec.BrokerCatalog.ID,
+		})
+		if err != nil {
+			log.Error(err, "failed to fetch binding")
+
+			if osbapi.IsUnrecoveralbeError(err) {
+				return r.failCredentialRotation(cfServiceBinding, err.Error())
+			}
+
+			return ctrl.Result{}, k8s.NewNotReadyError().WithCause(err).WithReason("CredentialRotationFailed")
+		}
+		credentials, brokerVolumeMounts = brokerBinding.Credentials, brokerBinding.VolumeMounts
+	}
+
+	volumeMounts, err := r.toVolumeMounts(cfServiceBinding, assets, brokerVolumeMounts)
+	if err != nil {
+		// The new broker binding is of no use, so it is unbound right away
+		cfServiceBinding.Status.RetiredBindings = append(cfServiceBinding.Status.RetiredBindings, korifiv1alpha1.RetiredServiceBinding{
+			ID:        newBindingID,
+			RetiredAt: metav1.NewTime(time.Now().Add(-r.rotationGracePeriod)),
+		})
+		return r.failCredentialRotation(cfServiceBinding, err.Error())
+	}
+	cfServiceBinding.Status.VolumeMounts = volumeMounts
+
+	envSecret, err := r.createEnvSecret(ctx, cfServiceBinding, credentials)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	cfServiceBinding.Status.EnvSecretRef.Name = envSecret.Name
+
+	if cfServiceBinding.Spec.Type == korifiv1alpha1.CFServiceBindingTypeApp {
+		mountSecret, err := r.createMountSecret(ctx, cfServiceBinding, credentials)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		cfServiceBinding.Status.MountSecretRef.Name = mountSecret.Name
+
+		if err = r.restartApp(ctx, cfServiceBinding); err != nil {
+			log.Error(err, "failed to restart app")
+			return ctrl.Result{}, err
+		}
+	}
+
+	log.V(1).Info("credentials rotated", "retiredBindingID", cfServiceBinding.BrokerBindingID(), "bindingID", newBindingID)
+	cfServiceBinding.Status.RetiredBindings = append(cfServiceBinding.Status.RetiredBindings, korifiv1alpha1.RetiredServiceBinding{
+		ID:        cfServiceBinding.BrokerBindingID(),
+		RetiredAt: metav1.NewTime(time.Now()),
+	})
+	cfServiceBinding.Status.BindingID = newBindingID
+	cfServiceBinding.Status.ObservedCredentialRotation = cfServiceBinding.Spec.CredentialRotation
+	meta.SetStatusCondition(&cfServiceBinding.Status.Conditions, metav1.Condition{
+		Type:               korifiv1alpha1.CredentialRotationFailedCondition,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: cfServiceBinding.Generation,
+		LastTransitionTime: metav1.NewTime(time.Now()),
+		Reason:             "CredentialsRotated",
+	})
+
+	return ctrl.Result{RequeueAfter: r.rotationGracePeriod}, nil
+}
+
+// failCredentialRotation gives up on the current rotation request. The
+// binding keeps using its current credentials.
+func (r *ManagedBindingsReconciler) failCredentialRotation(cfServiceBinding *korifiv1alpha1.CFServiceBinding, message string) (ctrl.Result, error) {
+	cfServiceBinding.Status.ObservedCredentialRotation = cfServiceBinding.Spec.CredentialRotation
+	meta.SetStatusCondition(&cfServiceBinding.Status.Conditions, metav1.Condition{
+		Type:               korifiv1alpha1.CredentialRotationFailedCondition,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: cfServiceBinding.Generation,
+		LastTransitionTime: metav1.NewTime(time.Now()),
+		Reason:             "CredentialRotationFailed",
+		Message:            message,
+	})
+
+	return ctrl.Result{}, nil
+}
+
+// restartApp bumps the app revision, which makes the workload runner roll out
+// new app instances with the rotated credentials
+func (r *ManagedBindingsReconciler) restartApp(ctx context.Context, cfServiceBinding *korifiv1alpha1.CFServiceBinding) error {
+	cfApp := &korifiv1alpha1.CFApp{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: cfServiceBinding.Namespace,
+			Name:      cfServiceBinding.Spec.AppRef.Name,
+		},
+	}
+	if err := r.k8sClient.Get(ctx, client.ObjectKeyFromObject(cfApp), cfApp); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+
+	revision, err := strconv.Atoi(tools.GetMapValue(cfApp.Annotations, korifiv1alpha1.CFAppRevisionKey, korifiv1alpha1.CFAppDefaultRevision))
+	if err != nil {
+		return fmt.Errorf("expected app-rev to be an integer: %w", err)
+	}
+
+	return k8s.PatchResource(ctx, r.k8sClient, cfApp, func() {
+		cfApp.Annotations = tools.SetMapValue(cfApp.Annotations, korifiv1alpha1.CFAppRevisionKey, strconv.Itoa(revision+1))
+	})
+}
+
+// unbindRetiredBindings unbinds the retired broker bindings whose grace
+// period has elapsed. The returned result requeues the binding when the
+// next retired binding is due.
+func (r *ManagedBindingsReconciler) unbindRetiredBindings(
+	ctx context.Context,
+	cfServiceBinding *korifiv1alpha1.CFServiceBinding,
+	assets osbapi.ServiceBindingAssets,
+	osbapiClient osbapi.BrokerClient,
+	gracePeriod time.Duration,
+) (ctrl.Result, error) {
+	log := logr.FromContextOrDiscard(ctx).WithName("unbind-retired-bindings")
+
+	result := ctrl.Result{}
+	remaining := []korifiv1alpha1.RetiredServiceBinding{}
+	for _, retired := range cfServiceBinding.Status.RetiredBindings {
+		if wait := time.Until(retired.RetiredAt.Add(gracePeriod)); wait > 0 {
+			remaining = append(remaining, retired)
+			result = earliestRequeue(result, wait)
+			continue
+		}
+
+		unbindResponse, err := osbapiClient.Unbind(ctx, osbapi.UnbindPayload{
+			InstanceID: cfServiceBinding.Spec.Service.Name,
+			BindingID:  retired.ID,
+			UnbindRequestParameters: osbapi.UnbindRequestParameters{
+				ServiceId: assets.ServiceOffering.Spec.BrokerCatalog.ID,
+				PlanID:    assets.ServicePlan.Spec.BrokerCatalog.ID,
+			},
+		})
+		if osbapi.IgnoreGone(err) != nil {
+			log.Error(err, "failed to unbind retired binding", "bindingID", retired.ID)
+			return ctrl.Result{}, fmt.Errorf("failed to unbind retired binding %q: %w", retired.ID, err)
+		}
+
+		if err == nil && unbindResponse.IsAsync {
+			// The unbind request is re-issued until the broker responds with
+			// 410 Gone or completes it synchronously
+			remaining = append(remaining, retired)
+			result = earliestRequeue(result, time.Second)
+			continue
+		}
+
+		log.V(1).Info("retired binding unbound", "bindingID", retired.ID)
+	}
+
+	if len(remaining) == 0 {
+		remaining = nil
+	}
+	cfServiceBinding.Status.RetiredBindings = remaining
+
+	return result, nil
+}
+
+func earliestRequeue(result ctrl.Result, after time.Duration) ctrl.Result {
+	if result.RequeueAfter == 0 || after < result.RequeueAfter {
+		result.RequeueAfter = after
+	}
+	return result
+}
+
+// mitigateOrphan unbinds a binding the broker might have created in response
+// to an ambiguous bind request. Failed unbind requests are retried with the
+// controller backoff. Asynchronous unbinding is followed by polling the last
+// operation, until the broker confirms the binding is gone, after which the
+// binding is considered failed.
+func (r *ManagedBindingsReconciler) mitigateOrphan(
+	ctx context.Context,
+	cfServiceBinding *korifiv1alpha1.CFServiceBinding,
+	assets osbapi.ServiceBindingAssets,
+	osbapiClient osbapi.BrokerClient,
+) (ctrl.Result, error) {
+	log := logr.FromContextOrDiscard(ctx).WithName("orphan-mitigation")
+
+	if cfServiceBinding.Status.OrphanMitigationOperation != nil {
+		return r.pollOrphanMitigation(ctx, cfServiceBinding, assets, osbapiClient)
+	}
+
+	unbindResponse, err := osbapiClient.Unbind(ctx, osbapi.UnbindPayload{
+		InstanceID: cfServiceBinding.Spec.Service.Name,
+		BindingID:  cfServiceBinding.BrokerBindingID(),
+		UnbindRequestParameters: osbapi.UnbindRequestParameters{
+			ServiceId: assets.ServiceOffering.Spec.BrokerCatalog.ID,
+			PlanID:    assets.ServicePlan.Spec.BrokerCatalog.ID,
+		},
+	})
+	if osbapi.IgnoreGone(err) != nil {
+		log.Error(err, "orphan mitigation unbind failed")
+		setOrphanMitigationInProgress(cfServiceBinding, "UnbindFailed", err.Error())
+		return ctrl.Result{}, k8s.NewNotReadyError().WithCause(err).WithReason("OrphanMitigationInProgress")
+	}
+
+	if err == nil && unbindResponse.IsAsync {
+		cfServiceBinding.Status.OrphanMitigationOperation = tools.PtrTo(unbindResponse.Operation)
+		setOrphanMitigationInProgress(cfServiceBinding, "UnbindInProgress", "")
+		return ctrl.Result{}, k8s.NewNotReadyError().WithReason("OrphanMitigationInProgress").WithRequeue()
+	}
+
+	return completeOrphanMitigation(ctx, cfServiceBinding)
+}
+
+func (r *ManagedBindingsReconciler) pollOrphanMitigation(
+	ctx context.Context,
+	cfServiceBinding *korifiv1alpha1.CFServiceBinding,
+	assets osbapi.ServiceBindingAssets,
+	osbapiClient osbapi.BrokerClient,
+) (ctrl.Result, error) {
+	log := logr.FromContextOrDiscard(ctx).WithName("orphan-mitigation")
+
+	lastOpResponse, err := osbapiClient.GetServiceBindingLastOperation(ctx, osbapi.GetBindingLastOperationRequest{
+		InstanceID: cfServiceBinding.Spec.Service.Name,
+		BindingID:  cfServiceBinding.BrokerBindingID(),
+		GetLastOperationRequestParameters: osbapi.GetLastOperationRequestParameters{
+			ServiceId: assets.ServiceOffering.Spec.BrokerCatalog.ID,
+			PlanID:    assets.ServicePlan.Spec.BrokerCatalog.ID,
+			Operation: *cfServiceBinding.Status.OrphanMitigationOperation,
+		},
+	})
+	if osbapi.IgnoreGone(err) != nil {
+		log.Error(err, "getting orphan mitigation last operation failed")
+		return ctrl.Result{}, k8s.NewNotReadyError().WithCause(err).WithReason("OrphanMitigationInProgress")
+	}
+
+	// Brokers respond with 410 Gone once the binding is deleted
+	if err != nil || lastOpResponse.State == "succeeded" {
+		return completeOrphanMitigation(ctx, cfServiceBinding)
+	}
+
+	if lastOpResponse.State == "failed" {
+		// The unbind request is sent again on the next reconcile
+		cfServiceBinding.Status.OrphanMitigationOperation = nil
+		setOrphanMitigationInProgress(cfServiceBinding, "UnbindFailed", lastOpResponse.Description)
+	}
+
+	return ctrl.Result{}, k8s.NewNotReadyError().WithReason("OrphanMitigationInProgress").WithRequeue()
+}
+
+func setOrphanMitigationInProgress(cfServiceBinding *korifiv1alpha1.CFServiceBinding, reason string, message string) {
+	meta.SetStatusCondition(&cfServiceBinding.Status.Conditions, metav1.Condition{
+		Type:               korifiv1alpha1.OrphanMitigationInProgressCondition,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: cfServiceBinding.Generation,
+		LastTransitionTime: metav1.NewTime(time.Now()),
+		Reason:             reason,
+		Message:            message,
+	})
+}
+
+func completeOrphanMitigation(ctx context.Context, cfServiceBinding *korifiv1alpha1.CFServiceBinding) (ctrl.Result, error) {
+	logr.FromContextOrDiscard(ctx).V(1).Info("orphan mitigation completed")
+
+	cfServiceBinding.Status.OrphanMitigationOperation = nil
+	meta.SetStatusCondition(&cfServiceBinding.Status.Conditions, metav1.Condition{
+		Type:               korifiv1alpha1.OrphanMitigationInProgressCondition,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: cfServiceBinding.Generation,
+		LastTransitionTime: metav1.NewTime(time.Now()),
+		Reason:             "OrphanMitigated",
+	})
+	if isFailed(cfServiceBinding) {
+		// The binding has been rejected before its mitigation, keep the reason
+		return ctrl.Result{}, k8s.NewNotReadyError().WithReason("BindingFailed")
+	}
+	meta.SetStatusCondition(&cfServiceBinding.Status.Conditions, metav1.Condition{
+		Type:               korifiv1alpha1.BindingFailedCondition,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: cfServiceBinding.Generation,
+		LastTransitionTime: metav1.NewTime(time.Now()),
+		Reason:             "BindingFailed",
+		Message:            "The broker response to the bind request was ambiguous and the binding has been deleted",
+	})
+
+	return ctrl.Result{}, k8s.NewNotReadyError().WithReason("BindingFailed")
+}
+
+// rejectVolumeMounts fails a binding whose volume mounts korifi cannot mount.
+// The broker has created the binding nevertheless, so it is unbound via
+// orphan mitigation.
+func (r *ManagedBindingsReconciler) rejectVolumeMounts(cfServiceBinding *korifiv1alpha1.CFServiceBinding, err error) (ctrl.Result, error) {
+	meta.SetStatusCondition(&cfServiceBinding.Status.Conditions, metav1.Condition{
+		Type:               korifiv1alpha1.BindingFailedCondition,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: cfServiceBinding.Generation,
+		LastTransitionTime: metav1.NewTime(time.Now()),
+		Reason:             "InvalidVolumeMounts",
+		Message:            err.Error(),
+	})
+	meta.SetStatusCondition(&cfServiceBinding.Status.Conditions, metav1.Condition{
+		Type:               korifiv1alpha1.OrphanMitigationInProgressCondition,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: cfServiceBinding.Generation,
+		LastTransitionTime: metav1.NewTime(time.Now()),
+		Reason:             "InvalidVolumeMounts",
+		Message:            err.Error(),
+	})
+
+	return ctrl.Result{}, k8s.NewNotReadyError().WithReason("OrphanMitigationInProgress").WithRequeue()
+}
+
+// toVolumeMounts validates the volume mounts returned by the broker and
+// converts them to their binding status representation. Only services that
+// require volume_mount are allowed to return volume mounts. Volumes are only
+// mounted into apps, so the volume mounts of key bindings are dropped.
+func (r *ManagedBindingsReconciler) toVolumeMounts(
+	cfServiceBinding *korifiv1alpha1.CFServiceBinding,
+	assets osbapi.ServiceBindingAssets,
+	volumeMounts []osbapi.VolumeMount,
+) ([]korifiv1alpha1.ServiceVolumeMount, error) {
+	if len(volumeMounts) == 0 || cfServiceBinding.Spec.Type != korifiv1alpha1.CFServiceBindingTypeApp {
+		return nil, nil
+	}
+
+	if !slices.Contains(assets.ServiceOffering.Spec.Requires, volumeMountRequirement) {
+		return nil, errors.New("the service broker returned volume mounts, but the service offering does not require volume_mount")
+	}
+
+	if !r.volumeServices.Enabled {
+		return nil, errors.New("the service broker returned volume mounts, but volume services are disabled")
+	}
+
+	// Space developers register space-scoped brokers, they must not be able
+	// to mount arbitrary volumes into app instances
+	if assets.ServiceBroker.Namespace != r.rootNamespace {
+		return nil, errors.New("space-scoped service brokers cannot provide volume mounts")
+	}
+
+	result := []korifiv1alpha1.ServiceVolumeMount{}
+	for _, volumeMount := range volumeMounts {
+		if volumeMount.Driver == "" {
+			return nil, errors.New("volume mount driver must not be empty")
+		}
+
+		if !slices.Contains(r.volumeServices.AllowedDrivers, volumeMount.Driver) {
+			return nil, fmt.Errorf("volume mount driver %q is not allowed", volumeMount.Driver)
+		}
+
+		if !path.IsAbs(volumeMount.ContainerDir) {
+			return nil, fmt.Errorf("volume mount container_dir must be an absolute path, got %q", volumeMount.ContainerDir)
+		}
+
+		mode := korifiv1alpha1.VolumeMountMode(volumeMount.Mode)
+		if mode != korifiv1alpha1.VolumeMountModeReadOnly && mode != korifiv1alpha1.VolumeMountModeReadWrite {
+			return nil, fmt.Errorf("volume mount mode must be either %q or %q, got %q", korifiv1alpha1.VolumeMountModeReadOnly, korifiv1alpha1.VolumeMountModeReadWrite, volumeMount.Mode)
+		}
+
+		if volumeMount.DeviceType != sharedDeviceType {
+			return nil, fmt.Errorf("volume mount device_type must be %q, got %q", sharedDeviceType, volumeMount.DeviceType)
+		}
+
+		mountConfig, err := toMountConfig(volumeMount.Device.MountConfig)
+		if err != nil {
+			return nil, err
+		}
+
+		if slices.Contains(korifiv1alpha1.NFSVolumeDrivers, volumeMount.Driver) {
+			if err = r.validateNFSServer(mountConfig["source"]); err != nil {
+				return nil, err
+			}
+		}
+
+		result = append(result, korifiv1alpha1.ServiceVolumeMount{
+			Driver:       volumeMount.Driver,
+			ContainerDir: volumeMount.ContainerDir,
+			Mode:         mode,
+			VolumeID:     volumeMount.Device.VolumeID,
+			MountConfig:  mountConfig,
+		})
+	}
+
+	return result, nil
+}
+
+func (r *ManagedBindingsReconciler) validateNFSServer(source string) error {
+	sourceURL, err := url.Parse(source)
+	if err != nil || sourceURL.Scheme != "nfs" || sourceURL.Host == "" {
+		return fmt.Errorf("volume mount source must be an nfs://<server>/<path> URL, got %q", source)
+	}
+
+	if !slices.Contains(r.volumeServices.AllowedNFSServers, sourceURL.Host) {
+		return fmt.Errorf("volume mount nfs server %q is not allowed", sourceURL.Host)
+	}
+
+	return nil
+}
+
+// toMountConfig flattens the mount config values into strings, which is how
+// volume drivers consume them. Non-string values are JSON encoded.
+func toMountConfig(config map[string]any) (map[string]string, error) {
+	if len(config) == 0 {
+		return nil, nil
+	}
+
+	result := map[string]string{}
+	for key, value := range config {
+		if stringValue, ok := value.(string); ok {
+			result[key] = stringValue
+			continue
+		}
+
+		jsonValue, err := json.Marshal(value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode volume mount config %q: %w", key, err)
+		}
+		result[key] = string(jsonValue)
+	}
+
+	return result, nil
+}
+
 func (r *ManagedBindingsReconciler) getParameters(ctx context.Context, cfServiceBinding *korifiv1alpha1.CFServiceBinding) (map[string]any, error) {
 	if cfServiceBinding.Spec.Parameters.Name == "" {
 		return nil, nil
@@ -254,12 +787,21 @@ func (r *ManagedBindingsReconciler) finalizeCFServiceBinding(
 ) (ctrl.Result, error) {
 	log := logr.FromContextOrDiscard(ctx).WithName("finalize-managed-service-binding")
 
+	// Retired bindings are unbound right away, regardless of the grace period
+	result, err := r.unbindRetiredBindings(ctx, serviceBinding, assets, osbapiClient, 0)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if !result.IsZero() {
+		return ctrl.Result{}, k8s.NewNotReadyError().WithReason("UnbindingInProgress").WithRequeueAfter(result.RequeueAfter)
+	}
+
 	unbindResponse, err := r.deleteServiceBinding(ctx, serviceBinding, assets, osbapiClient)
 	if err != nil {
 		return ctrl.Result{}, err
 	}
 	if unbindResponse.IsAsync {
-		lastOpresponse, err := r.pollLastOperation(ctx, serviceBinding, assets, osbapiClient, unbindResponse.Operation)
+		lastOpresponse, err := r.pollLastOperation(ctx, serviceBinding.BrokerBindingID(), assets, osbapiClient, unbindResponse.Operation)
 		if err != nil {
 			return ctrl.Result{}, err
 		}
@@ -276,7 +818,7 @@ func (r *ManagedBindingsReconciler) finalizeCFServiceBinding(
 
 func (r *ManagedBindingsReconciler) pollLastOperation(
 	ctx context.Context,
-	serviceBinding *korifiv1alpha1.CFServiceBinding,
+	bindingID string,
 	assets osbapi.ServiceBindingAssets,
 	osbapiClient osbapi.BrokerClient,
 	operationID string,
@@ -284,8 +826,8 @@ func (r *ManagedBindingsReconciler) pollLastOperation(
 	log := logr.FromContextOrDiscard(ctx).WithName("poll-operation")
 
 	lastOpResponse, err := osbapiClient.GetServiceBindingLastOperation(ctx, osbapi.GetBindingLastOperationRequest{
-		InstanceID: serviceBinding.Spec.Service.Name,
-		BindingID:  serviceBinding.Name,
+		InstanceID: assets.ServiceInstance.Name,
+		BindingID:  bindingID,
 		GetLastOperationRequestParameters: osbapi.GetLastOperationRequestParameters{
 			ServiceId: assets.ServiceOffering.Spec.BrokerCatalog.ID,
 			PlanID:    assets.ServicePlan.Spec.BrokerCatalog.ID,
@@ -331,7 +873,7 @@ func (r *ManagedBindingsReconciler) deleteServiceBinding(
 ) (osbapi.UnbindResponse, error) {
 	unbindResponse, err := osbapiClient.Unbind(ctx, osbapi.UnbindPayload{
 		InstanceID: serviceBinding.Spec.Service.Name,
-		BindingID:  serviceBinding.Name,
+		BindingID:  serviceBinding.BrokerBindingID(),
 		UnbindRequestParameters: osbapi.UnbindRequestParameters{
 			ServiceId: assets.ServiceOffering.Spec.BrokerCatalog.ID,
 			PlanID:    assets.ServicePlan.Spec.BrokerCatalog.ID,
@@ -356,6 +898,18 @@ func (r *ManagedBindingsReconciler) deleteServiceBinding(
 	return unbindResponse, nil
 }
 
+func isCredentialRotationRequested(binding *korifiv1alpha1.CFServiceBinding) bool {
+	if binding.Status.EnvSecretRef.Name == "" {
+		return false
+	}
+
+	return binding.Spec.CredentialRotation > binding.Status.ObservedCredentialRotation
+}
+
+func isOrphanMitigationInProgress(binding *korifiv1alpha1.CFServiceBinding) bool {
+	return meta.IsStatusConditionTrue(binding.Status.Conditions, korifiv1alpha1.OrphanMitigationInProgressCondition)
+}
+
 func isFailed(binding *korifiv1alpha1.CFServiceBinding) bool {
 	return meta.IsStatusConditionTrue(binding.Status.Conditions, korifiv1alpha1.BindingFailedCondition)
 }
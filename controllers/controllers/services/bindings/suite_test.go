@@ -23,7 +23,9 @@ import (
 	"time"
 
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/config"
 	"code.cloudfoundry.org/korifi/controllers/controllers/services/bindings"
+	"code.cloudfoundry.org/korifi/controllers/controllers/services/bindings/kubernetes"
 	"code.cloudfoundry.org/korifi/controllers/controllers/services/bindings/managed"
 	"code.cloudfoundry.org/korifi/controllers/controllers/services/bindings/upsi"
 	"code.cloudfoundry.org/korifi/controllers/controllers/services/osbapi/fake"
@@ -107,7 +109,12 @@ var _ = BeforeEach(func() {
 		k8sManager.GetScheme(),
 		ctrl.Log.WithName("controllers").WithName("CFServiceBinding"),
 		upsi.NewReconciler(k8sManager.GetClient(), k8sManager.GetScheme()),
-		managed.NewReconciler(k8sManager.GetClient(), brokerClientFactory, rootNamespace, k8sManager.GetScheme()),
+		managed.NewReconciler(k8sManager.GetClient(), brokerClientFactory, rootNamespace, k8sManager.GetScheme(), time.Minute, config.VolumeServices{
+			Enabled:           true,
+			AllowedDrivers:    []string{"nfs"},
+			AllowedNFSServers: []string{"nfs.example.com"},
+		}),
+		kubernetes.NewReconciler(k8sManager.GetClient(), k8sManager.GetScheme()),
 	).SetupWithManager(k8sManager)
 	Expect(err).ToNot(HaveOccurred())
 })
@@ -48,6 +48,7 @@ type Reconciler struct {
 	osbapiClientFactory osbapi.BrokerClientFactory
 	scheme              *runtime.Scheme
 	log                 logr.Logger
+	resyncInterval      time.Duration
 }
 
 func NewReconciler(
@@ -55,6 +56,7 @@ func NewReconciler(
 	osbapiClientFactory osbapi.BrokerClientFactory,
 	scheme *runtime.Scheme,
 	log logr.Logger,
+	resyncInterval time.Duration,
 ) *k8s.PatchingReconciler[korifiv1alpha1.CFServiceBroker, *korifiv1alpha1.CFServiceBroker] {
 	return k8s.NewPatchingReconciler(
 		log,
@@ -64,6 +66,7 @@ func NewReconciler(
 			osbapiClientFactory: osbapiClientFactory,
 			scheme:              scheme,
 			log:                 log,
+			resyncInterval:      resyncInterval,
 		},
 	)
 }
@@ -104,6 +107,7 @@ func (r *Reconciler) secretToServiceBroker(ctx context.Context, o client.Object)
 //+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfservicebrokers/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfserviceofferings,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfserviceplans,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfserviceinstances,verbs=get;list;watch
 
 func (r *Reconciler) ReconcileResource(ctx context.Context, cfServiceBroker *korifiv1alpha1.CFServiceBroker) (ctrl.Result, error) {
 	log := logr.FromContextOrDiscard(ctx).WithValues("broker-id", cfServiceBroker.Name)
@@ -131,13 +135,13 @@ func (r *Reconciler) ReconcileResource(ctx context.Context, cfServiceBroker *kor
 
 	osbapiClient, err := r.osbapiClientFactory.CreateClient(ctx, cfServiceBroker)
 	if err != nil {
-		return ctrl.Result{}, k8s.NewNotReadyError().WithCause(err).WithReason("OSBAPIClientCreationFailed")
+		return ctrl.Result{}, k8s.NewNotReadyError().WithCause(err).WithReason(korifiv1alpha1.OSBAPIClientCreationFailedReason)
 	}
 
 	catalog, err := osbapiClient.GetCatalog(ctx)
 	if err != nil {
 		log.Error(err, "failed to get catalog from broker", "broker", cfServiceBroker.Name)
-		return ctrl.Result{}, k8s.NewNotReadyError().WithCause(err).WithReason("GetCatalogFailed")
+		return ctrl.Result{}, k8s.NewNotReadyError().WithCause(err).WithReason(korifiv1alpha1.GetCatalogFailedReason)
 	}
 
 	err = r.reconcileCatalog(ctx, cfServiceBroker, catalog)
@@ -146,20 +150,117 @@ func (r *Reconciler) ReconcileResource(ctx context.Context, cfServiceBroker *kor
 		return ctrl.Result{}, fmt.Errorf("failed to reconcile catalog: %v", err)
 	}
 
-	return ctrl.Result{}, nil
+	return ctrl.Result{RequeueAfter: r.resyncInterval}, nil
 }
 
 func (r *Reconciler) reconcileCatalog(ctx context.Context, cfServiceBroker *korifiv1alpha1.CFServiceBroker, catalog osbapi.Catalog) error {
+	catalogOfferings := map[string]bool{}
+	catalogPlans := map[string]bool{}
 	for _, service := range catalog.Services {
 		err := r.reconcileCatalogService(ctx, cfServiceBroker, service)
 		if err != nil {
 			return err
 		}
 
+		catalogOfferings[tools.NamespacedUUID(cfServiceBroker.Name, service.ID)] = true
+		for _, plan := range service.Plans {
+			catalogPlans[tools.NamespacedUUID(cfServiceBroker.Name, plan.ID)] = true
+		}
+	}
+
+	return r.cleanupOrphans(ctx, cfServiceBroker, catalogOfferings, catalogPlans)
+}
+
+// cleanupOrphans deals with offerings and plans that are no longer in the
+// broker catalog. The ones still in use by service instances are marked as
+// unavailable, the rest are deleted.
+func (r *Reconciler) cleanupOrphans(
+	ctx context.Context,
+	cfServiceBroker *korifiv1alpha1.CFServiceBroker,
+	catalogOfferings map[string]bool,
+	catalogPlans map[string]bool,
+) error {
+	log := logr.FromContextOrDiscard(ctx).WithName("cleanup-orphans")
+
+	servicePlans := &korifiv1alpha1.CFServicePlanList{}
+	if err := r.k8sClient.List(ctx, servicePlans,
+		client.InNamespace(cfServiceBroker.Namespace),
+		client.MatchingLabels{korifiv1alpha1.RelServiceBrokerGUIDLabel: cfServiceBroker.Name},
+	); err != nil {
+		return fmt.Errorf("failed to list service plans: %w", err)
+	}
+
+	offeringsInUse := map[string]bool{}
+	for _, servicePlan := range servicePlans.Items {
+		if catalogPlans[servicePlan.Name] {
+			continue
+		}
+
+		inUse, err := r.isPlanInUse(ctx, servicePlan.Name)
+		if err != nil {
+			return err
+		}
+
+		if inUse {
+			log.V(1).Info("marking service plan unavailable", "plan", servicePlan.Name)
+			offeringsInUse[servicePlan.Labels[korifiv1alpha1.RelServiceOfferingGUIDLabel]] = true
+			if err = k8s.PatchResource(ctx, r.k8sClient, &servicePlan, func() {
+				servicePlan.Spec.Unavailable = true
+			}); err != nil {
+				return fmt.Errorf("failed to mark service plan %q unavailable: %w", servicePlan.Name, err)
+			}
+			continue
+		}
+
+		log.V(1).Info("deleting service plan", "plan", servicePlan.Name)
+		if err = r.k8sClient.Delete(ctx, &servicePlan); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed to delete service plan %q: %w", servicePlan.Name, err)
+		}
+	}
+
+	serviceOfferings := &korifiv1alpha1.CFServiceOfferingList{}
+	if err := r.k8sClient.List(ctx, serviceOfferings,
+		client.InNamespace(cfServiceBroker.Namespace),
+		client.MatchingLabels{korifiv1alpha1.RelServiceBrokerGUIDLabel: cfServiceBroker.Name},
+	); err != nil {
+		return fmt.Errorf("failed to list service offerings: %w", err)
 	}
+
+	for _, serviceOffering := range serviceOfferings.Items {
+		if catalogOfferings[serviceOffering.Name] {
+			continue
+		}
+
+		if offeringsInUse[serviceOffering.Name] {
+			log.V(1).Info("marking service offering unavailable", "offering", serviceOffering.Name)
+			if err := k8s.PatchResource(ctx, r.k8sClient, &serviceOffering, func() {
+				serviceOffering.Spec.Unavailable = true
+			}); err != nil {
+				return fmt.Errorf("failed to mark service offering %q unavailable: %w", serviceOffering.Name, err)
+			}
+			continue
+		}
+
+		log.V(1).Info("deleting service offering", "offering", serviceOffering.Name)
+		if err := r.k8sClient.Delete(ctx, &serviceOffering); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed to delete service offering %q: %w", serviceOffering.Name, err)
+		}
+	}
+
 	return nil
 }
 
+func (r *Reconciler) isPlanInUse(ctx context.Context, planGUID string) (bool, error) {
+	serviceInstances := &korifiv1alpha1.CFServiceInstanceList{}
+	if err := r.k8sClient.List(ctx, serviceInstances,
+		client.MatchingFields{shared.IndexServiceInstancePlanGUID: planGUID},
+	); err != nil {
+		return false, fmt.Errorf("failed to list service instances for plan %q: %w", planGUID, err)
+	}
+
+	return len(serviceInstances.Items) > 0, nil
+}
+
 func (r *Reconciler) reconcileCatalogService(ctx context.Context, cfServiceBroker *korifiv1alpha1.CFServiceBroker, catalogService osbapi.Service) error {
 	serviceOffering := &korifiv1alpha1.CFServiceOffering{
 		ObjectMeta: metav1.ObjectMeta{
@@ -174,6 +275,11 @@ func (r *Reconciler) reconcileCatalogService(ctx context.Context, cfServiceBroke
 		}
 		serviceOffering.Labels[korifiv1alpha1.RelServiceBrokerGUIDLabel] = cfServiceBroker.Name
 		serviceOffering.Labels[korifiv1alpha1.RelServiceBrokerNameLabel] = cfServiceBroker.Spec.Name
+		if spaceGUID, ok := cfServiceBroker.Labels[korifiv1alpha1.SpaceGUIDKey]; ok {
+			serviceOffering.Labels[korifiv1alpha1.SpaceGUIDKey] = spaceGUID
+		}
+
+		serviceOffering.Spec.Unavailable = false
 
 		var err error
 		serviceOffering.Spec.ServiceOffering, err = toSpecServiceOffering(catalogService)
@@ -209,6 +315,9 @@ func (r *Reconciler) reconcileCatalogPlan(ctx context.Context, serviceOffering *
 		servicePlan.Labels[korifiv1alpha1.RelServiceBrokerNameLabel] = serviceOffering.Labels[korifiv1alpha1.RelServiceBrokerNameLabel]
 		servicePlan.Labels[korifiv1alpha1.RelServiceOfferingGUIDLabel] = serviceOffering.Name
 		servicePlan.Labels[korifiv1alpha1.RelServiceOfferingNameLabel] = serviceOffering.Spec.Name
+		if spaceGUID, ok := serviceOffering.Labels[korifiv1alpha1.SpaceGUIDKey]; ok {
+			servicePlan.Labels[korifiv1alpha1.SpaceGUIDKey] = spaceGUID
+		}
 
 		rawMetadata, err := json.Marshal(catalogPlan.Metadata)
 		if err != nil {
@@ -219,6 +328,9 @@ func (r *Reconciler) reconcileCatalogPlan(ctx context.Context, serviceOffering *
 		if servicePlan.Spec.Visibility.Type != "" {
 			visibilityType = servicePlan.Spec.Visibility.Type
 		}
+		if _, ok := servicePlan.Labels[korifiv1alpha1.SpaceGUIDKey]; ok {
+			visibilityType = korifiv1alpha1.SpaceServicePlanVisibilityType
+		}
 
 		servicePlan.Spec = korifiv1alpha1.CFServicePlanSpec{
 			ServicePlan: services.ServicePlan{
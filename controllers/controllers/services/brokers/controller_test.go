@@ -3,6 +3,7 @@ package brokers_test
 import (
 	"errors"
 	"slices"
+	"time"
 
 	"github.com/BooleanCat/go-functional/v2/it"
 	"github.com/BooleanCat/go-functional/v2/it/itx"
@@ -14,6 +15,7 @@ import (
 	"code.cloudfoundry.org/korifi/controllers/controllers/services/osbapi/fake"
 	"code.cloudfoundry.org/korifi/model/services"
 	. "code.cloudfoundry.org/korifi/tests/matchers"
+	"code.cloudfoundry.org/korifi/tools"
 	"code.cloudfoundry.org/korifi/tools/k8s"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -172,6 +174,7 @@ var _ = Describe("CFServiceBroker", func() {
 						})),
 					}),
 				}),
+				"Unavailable": BeFalse(),
 			}))
 		}).Should(Succeed())
 	})
@@ -244,6 +247,7 @@ var _ = Describe("CFServiceBroker", func() {
 					"Type":          Equal(korifiv1alpha1.AdminServicePlanVisibilityType),
 					"Organizations": BeEmpty(),
 				}),
+				"Unavailable": BeFalse(),
 			}))
 		}).Should(Succeed())
 	})
@@ -294,6 +298,143 @@ var _ = Describe("CFServiceBroker", func() {
 		})
 	})
 
+	When("the broker is space scoped", func() {
+		var spaceBroker *korifiv1alpha1.CFServiceBroker
+
+		BeforeEach(func() {
+			spaceGUID := uuid.NewString()
+			Expect(adminClient.Create(ctx, &corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: spaceGUID,
+				},
+			})).To(Succeed())
+
+			spaceBrokerSecret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: spaceGUID,
+					Name:      uuid.NewString(),
+				},
+			}
+			Expect(adminClient.Create(ctx, spaceBrokerSecret)).To(Succeed())
+
+			spaceBroker = &korifiv1alpha1.CFServiceBroker{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: spaceGUID,
+					Name:      uuid.NewString(),
+					Labels: map[string]string{
+						korifiv1alpha1.SpaceGUIDKey: spaceGUID,
+					},
+				},
+				Spec: korifiv1alpha1.CFServiceBrokerSpec{
+					ServiceBroker: services.ServiceBroker{
+						Name: "my-space-service-broker",
+						URL:  "some-url",
+					},
+					Credentials: corev1.LocalObjectReference{
+						Name: spaceBrokerSecret.Name,
+					},
+				},
+			}
+			Expect(adminClient.Create(ctx, spaceBroker)).To(Succeed())
+		})
+
+		It("creates space scoped offerings and plans in the space namespace", func() {
+			Eventually(func(g Gomega) {
+				offerings := &korifiv1alpha1.CFServiceOfferingList{}
+				g.Expect(adminClient.List(ctx, offerings,
+					client.InNamespace(spaceBroker.Namespace),
+					client.MatchingLabels{korifiv1alpha1.RelServiceBrokerGUIDLabel: spaceBroker.Name},
+				)).To(Succeed())
+				g.Expect(offerings.Items).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+					"ObjectMeta": MatchFields(IgnoreExtras, Fields{
+						"Labels": HaveKeyWithValue(korifiv1alpha1.SpaceGUIDKey, spaceBroker.Namespace),
+					}),
+				})))
+
+				plans := &korifiv1alpha1.CFServicePlanList{}
+				g.Expect(adminClient.List(ctx, plans,
+					client.InNamespace(spaceBroker.Namespace),
+					client.MatchingLabels{korifiv1alpha1.RelServiceBrokerGUIDLabel: spaceBroker.Name},
+				)).To(Succeed())
+				g.Expect(plans.Items).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+					"ObjectMeta": MatchFields(IgnoreExtras, Fields{
+						"Labels": HaveKeyWithValue(korifiv1alpha1.SpaceGUIDKey, spaceBroker.Namespace),
+					}),
+					"Spec": MatchFields(IgnoreExtras, Fields{
+						"Visibility": MatchFields(IgnoreExtras, Fields{
+							"Type": Equal(korifiv1alpha1.SpaceServicePlanVisibilityType),
+						}),
+					}),
+				})))
+			}).Should(Succeed())
+		})
+	})
+
+	When("offerings and plans are removed from the catalog", func() {
+		var (
+			offering *korifiv1alpha1.CFServiceOffering
+			plan     *korifiv1alpha1.CFServicePlan
+		)
+
+		BeforeEach(func() {
+			offering = &korifiv1alpha1.CFServiceOffering{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: serviceBroker.Namespace,
+					Name:      tools.NamespacedUUID(serviceBroker.Name, "service-id"),
+				},
+			}
+			plan = &korifiv1alpha1.CFServicePlan{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: serviceBroker.Namespace,
+					Name:      tools.NamespacedUUID(serviceBroker.Name, "plan-id"),
+				},
+			}
+		})
+
+		JustBeforeEach(func() {
+			Eventually(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(plan), plan)).To(Succeed())
+			}).Should(Succeed())
+
+			brokerClient.GetCatalogReturns(osbapi.Catalog{}, nil)
+			Expect(k8s.PatchResource(ctx, adminClient, serviceBroker, func() {
+				serviceBroker.Spec.CatalogRefreshRequestedAt = &metav1.Time{Time: time.Now()}
+			})).To(Succeed())
+		})
+
+		It("deletes them", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(plan), plan)).To(MatchError(ContainSubstring("not found")))
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(offering), offering)).To(MatchError(ContainSubstring("not found")))
+			}).Should(Succeed())
+		})
+
+		When("the plan is in use by a service instance", func() {
+			BeforeEach(func() {
+				Expect(adminClient.Create(ctx, &korifiv1alpha1.CFServiceInstance{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: rootNamespace,
+						Name:      uuid.NewString(),
+					},
+					Spec: korifiv1alpha1.CFServiceInstanceSpec{
+						DisplayName: "my-instance",
+						Type:        korifiv1alpha1.ManagedType,
+						PlanGUID:    plan.Name,
+					},
+				})).To(Succeed())
+			})
+
+			It("marks the plan and the offering as unavailable", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(plan), plan)).To(Succeed())
+					g.Expect(plan.Spec.Unavailable).To(BeTrue())
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(offering), offering)).To(Succeed())
+					g.Expect(offering.Spec.Unavailable).To(BeTrue())
+				}).Should(Succeed())
+			})
+		})
+	})
+
 	When("there are multiple brokers serving the same catalog", func() {
 		var anotherServiceBroker *korifiv1alpha1.CFServiceBroker
 
@@ -103,6 +103,7 @@ var _ = BeforeEach(func() {
 		brokerClientFactory,
 		k8sManager.GetScheme(),
 		ctrl.Log.WithName("controllers").WithName("CFServiceBroker"),
+		time.Hour,
 	)).SetupWithManager(k8sManager)
 	Expect(err).ToNot(HaveOccurred())
 })
@@ -0,0 +1,261 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/controllers/shared"
+	"code.cloudfoundry.org/korifi/model/services"
+	"code.cloudfoundry.org/korifi/tools/k8s"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// ProvisionedService resources can be of any kind and therefore cannot be
+// watched. Instead, instances referencing them are periodically re-resolved in
+// order to pick up changes of the binding secret name.
+const provisionedServiceResyncPeriod = time.Minute
+
+type ServiceUsageRecorder interface {
+	RecordServiceInstanceUsage(context.Context, *korifiv1alpha1.CFServiceInstance) error
+	RecordServiceInstanceDeleted(context.Context, *korifiv1alpha1.CFServiceInstance) error
+}
+
+type Reconciler struct {
+	k8sClient     client.Client
+	scheme        *runtime.Scheme
+	log           logr.Logger
+	usageRecorder ServiceUsageRecorder
+}
+
+func NewReconciler(
+	client client.Client,
+	scheme *runtime.Scheme,
+	log logr.Logger,
+	usageRecorder ServiceUsageRecorder,
+) *k8s.PatchingReconciler[korifiv1alpha1.CFServiceInstance, *korifiv1alpha1.CFServiceInstance] {
+	serviceInstanceReconciler := Reconciler{k8sClient: client, scheme: scheme, log: log, usageRecorder: usageRecorder}
+	return k8s.NewPatchingReconciler(log, client, &serviceInstanceReconciler)
+}
+
+func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) *builder.Builder {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&korifiv1alpha1.CFServiceInstance{}).
+		Named("kubernetes-cfserviceinstance").
+		WithEventFilter(predicate.NewPredicateFuncs(r.isKubernetesInstance)).
+		Watches(
+			&corev1.Secret{},
+			handler.EnqueueRequestsFromMapFunc(r.secretToServiceInstances),
+		)
+}
+
+func (r *Reconciler) isKubernetesInstance(object client.Object) bool {
+	serviceInstance, ok := object.(*korifiv1alpha1.CFServiceInstance)
+	if !ok {
+		return true
+	}
+
+	return serviceInstance.Spec.Type == korifiv1alpha1.KubernetesType
+}
+
+func (r *Reconciler) secretToServiceInstances(ctx context.Context, o client.Object) []reconcile.Request {
+	serviceInstances := korifiv1alpha1.CFServiceInstanceList{}
+	if err := r.k8sClient.List(ctx, &serviceInstances,
+		client.MatchingFields{
+			shared.IndexServiceInstanceServiceSecret: shared.ServiceSecretIndexKey(o.GetNamespace(), o.GetName()),
+		}); err != nil {
+		return []reconcile.Request{}
+	}
+
+	requests := []reconcile.Request{}
+	for _, si := range serviceInstances.Items {
+		requests = append(requests, reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      si.Name,
+				Namespace: si.Namespace,
+			},
+		})
+	}
+
+	return requests
+}
+
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfserviceinstances,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfserviceinstances/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfserviceinstances/finalizers,verbs=update
+
+//+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+
+func (r *Reconciler) ReconcileResource(ctx context.Context, cfServiceInstance *korifiv1alpha1.CFServiceInstance) (ctrl.Result, error) {
+	log := logr.FromContextOrDiscard(ctx)
+
+	cfServiceInstance.Status.ObservedGeneration = cfServiceInstance.Generation
+	log.V(1).Info("set observed generation", "generation", cfServiceInstance.Status.ObservedGeneration)
+
+	if !cfServiceInstance.GetDeletionTimestamp().IsZero() {
+		if err := r.usageRecorder.RecordServiceInstanceDeleted(ctx, cfServiceInstance); err != nil {
+			log.Info("failed to record service usage", "reason", err)
+			return ctrl.Result{}, err
+		}
+
+		controllerutil.RemoveFinalizer(cfServiceInstance, korifiv1alpha1.CFServiceInstanceFinalizerName)
+		log.V(1).Info("finalizer removed")
+
+		return ctrl.Result{}, nil
+	}
+
+	if err := r.usageRecorder.RecordServiceInstanceUsage(ctx, cfServiceInstance); err != nil {
+		log.Info("failed to record service usage", "reason", err)
+		return ctrl.Result{}, err
+	}
+
+	serviceRef := cfServiceInstance.Spec.ServiceRef
+	if serviceRef == nil || serviceRef.Name == "" {
+		cfServiceInstance.Status.LastOperation = services.LastOperation{
+			Type:  "create",
+			State: "failed",
+		}
+		return ctrl.Result{}, k8s.NewNotReadyError().
+			WithReason("ServiceRefMissing").
+			WithMessage("kubernetes service instances must reference a Secret or a ProvisionedService").
+			WithNoRequeue()
+	}
+
+	// Bindings only follow the reference while it resolves, e.g. they stop
+	// syncing the secret once its owner no longer shares it
+	cfServiceInstance.Status.ServiceSecret = nil
+
+	secretKey, err := r.resolveServiceSecret(ctx, cfServiceInstance)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	serviceSecret := &corev1.Secret{}
+	if err = r.k8sClient.Get(ctx, secretKey, serviceSecret); err != nil {
+		notReadyErr := k8s.NewNotReadyError().WithCause(err).WithReason("ServiceSecretNotAvailable")
+		if apierrors.IsNotFound(err) {
+			notReadyErr = notReadyErr.WithRequeueAfter(2 * time.Second)
+		}
+		return ctrl.Result{}, notReadyErr
+	}
+
+	log.V(1).Info("service secret", "namespace", serviceSecret.Namespace, "name", serviceSecret.Name, "version", serviceSecret.ResourceVersion)
+	cfServiceInstance.Status.ServiceSecret = &corev1.SecretReference{
+		Namespace: serviceSecret.Namespace,
+		Name:      serviceSecret.Name,
+	}
+	cfServiceInstance.Status.LastOperation = reconcileLastOperation(cfServiceInstance, serviceSecret)
+	cfServiceInstance.Status.CredentialsObservedVersion = serviceSecret.ResourceVersion
+
+	if isSecretRef(serviceRef) {
+		return ctrl.Result{}, nil
+	}
+
+	return ctrl.Result{RequeueAfter: provisionedServiceResyncPeriod}, nil
+}
+
+// resolveServiceSecret follows the service reference of the instance to the
+// secret holding its credentials
+func (r *Reconciler) resolveServiceSecret(ctx context.Context, cfServiceInstance *korifiv1alpha1.CFServiceInstance) (types.NamespacedName, error) {
+	serviceRef := cfServiceInstance.Spec.ServiceRef
+	namespace := serviceRef.Namespace
+	if namespace == "" {
+		namespace = cfServiceInstance.Namespace
+	}
+
+	if isSecretRef(serviceRef) {
+		if namespace != cfServiceInstance.Namespace {
+			secret := &corev1.Secret{}
+			if err := r.k8sClient.Get(ctx, types.NamespacedName{Namespace: namespace, Name: serviceRef.Name}, secret); err != nil {
+				return types.NamespacedName{}, k8s.NewNotReadyError().WithCause(err).WithReason("ServiceSecretNotAvailable").WithRequeueAfter(2 * time.Second)
+			}
+
+			if err := checkSharedWith(secret, cfServiceInstance.Namespace); err != nil {
+				return types.NamespacedName{}, err
+			}
+		}
+
+		return types.NamespacedName{Namespace: namespace, Name: serviceRef.Name}, nil
+	}
+
+	provisionedService := &unstructured.Unstructured{}
+	provisionedService.SetGroupVersionKind(schema.FromAPIVersionAndKind(serviceRef.APIVersion, serviceRef.Kind))
+	if err := r.k8sClient.Get(ctx, types.NamespacedName{Namespace: namespace, Name: serviceRef.Name}, provisionedService); err != nil {
+		return types.NamespacedName{}, k8s.NewNotReadyError().
+			WithCause(err).
+			WithReason("ProvisionedServiceNotAvailable").
+			WithRequeueAfter(provisionedServiceResyncPeriod)
+	}
+
+	if namespace != cfServiceInstance.Namespace {
+		if err := checkSharedWith(provisionedService, cfServiceInstance.Namespace); err != nil {
+			return types.NamespacedName{}, err
+		}
+	}
+
+	bindingSecretName, _, err := unstructured.NestedString(provisionedService.Object, "status", "binding", "name")
+	if err != nil || bindingSecretName == "" {
+		return types.NamespacedName{}, k8s.NewNotReadyError().
+			WithReason("ProvisionedServiceNotReady").
+			WithMessage(fmt.Sprintf("%s %s/%s does not expose a binding secret in .status.binding.name", serviceRef.Kind, namespace, serviceRef.Name)).
+			WithRequeueAfter(2 * time.Second)
+	}
+
+	return types.NamespacedName{Namespace: namespace, Name: bindingSecretName}, nil
+}
+
+func checkSharedWith(obj client.Object, namespace string) error {
+	sharedWith := strings.Split(obj.GetAnnotations()[korifiv1alpha1.SharedWithNamespacesAnnotationKey], ",")
+	for i := range sharedWith {
+		sharedWith[i] = strings.TrimSpace(sharedWith[i])
+	}
+
+	if slices.Contains(sharedWith, "*") || slices.Contains(sharedWith, namespace) {
+		return nil
+	}
+
+	return k8s.NewNotReadyError().
+		WithReason("ServiceNotShared").
+		WithMessage(fmt.Sprintf(
+			"%s/%s is not shared with namespace %q, add it to the %q annotation",
+			obj.GetNamespace(), obj.GetName(), namespace, korifiv1alpha1.SharedWithNamespacesAnnotationKey,
+		)).
+		WithRequeueAfter(provisionedServiceResyncPeriod)
+}
+
+func isSecretRef(serviceRef *corev1.ObjectReference) bool {
+	return serviceRef.Kind == "Secret" && (serviceRef.APIVersion == "" || serviceRef.APIVersion == "v1")
+}
+
+func reconcileLastOperation(cfServiceInstance *korifiv1alpha1.CFServiceInstance, serviceSecret *corev1.Secret) services.LastOperation {
+	if cfServiceInstance.Status.CredentialsObservedVersion == "" {
+		return services.LastOperation{
+			Type:  "create",
+			State: "succeeded",
+		}
+	}
+	if cfServiceInstance.Status.CredentialsObservedVersion != serviceSecret.ResourceVersion {
+		return services.LastOperation{
+			Type:  "update",
+			State: "succeeded",
+		}
+	}
+	return cfServiceInstance.Status.LastOperation
+}
@@ -0,0 +1,299 @@
+package kubernetes_test
+
+import (
+	"github.com/google/uuid"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/model/services"
+	. "code.cloudfoundry.org/korifi/tests/matchers"
+	"code.cloudfoundry.org/korifi/tools/k8s"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("CFServiceInstance", func() {
+	var (
+		testNamespace string
+		instance      *korifiv1alpha1.CFServiceInstance
+	)
+
+	BeforeEach(func() {
+		testNamespace = uuid.NewString()
+		Expect(adminClient.Create(ctx, &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: testNamespace,
+			},
+		})).To(Succeed())
+
+		instance = &korifiv1alpha1.CFServiceInstance{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      uuid.NewString(),
+				Namespace: testNamespace,
+				Finalizers: []string{
+					korifiv1alpha1.CFServiceInstanceFinalizerName,
+				},
+			},
+			Spec: korifiv1alpha1.CFServiceInstanceSpec{
+				DisplayName: "service-instance-name",
+				Type:        korifiv1alpha1.KubernetesType,
+				Tags:        []string{},
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		Expect(adminClient.Create(ctx, instance)).To(Succeed())
+	})
+
+	When("the service instance does not reference a service", func() {
+		It("sets the ready condition to false", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(instance), instance)).To(Succeed())
+				g.Expect(instance.Status.ObservedGeneration).To(Equal(instance.Generation))
+				g.Expect(instance.Status.Conditions).To(ContainElement(SatisfyAll(
+					HasType(Equal(korifiv1alpha1.StatusConditionReady)),
+					HasStatus(Equal(metav1.ConditionFalse)),
+					HasReason(Equal("ServiceRefMissing")),
+				)))
+				g.Expect(instance.Status.LastOperation).To(Equal(services.LastOperation{
+					Type:  "create",
+					State: "failed",
+				}))
+			}).Should(Succeed())
+		})
+	})
+
+	When("the service instance references a secret", func() {
+		var serviceSecret *corev1.Secret
+
+		BeforeEach(func() {
+			serviceSecret = &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      uuid.NewString(),
+					Namespace: testNamespace,
+				},
+				StringData: map[string]string{
+					"username": "bob",
+				},
+			}
+			Expect(adminClient.Create(ctx, serviceSecret)).To(Succeed())
+
+			instance.Spec.ServiceRef = &corev1.ObjectReference{
+				Kind: "Secret",
+				Name: serviceSecret.Name,
+			}
+		})
+
+		It("sets the ready condition to true", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(instance), instance)).To(Succeed())
+				g.Expect(instance.Status.Conditions).To(ContainElement(SatisfyAll(
+					HasType(Equal(korifiv1alpha1.StatusConditionReady)),
+					HasStatus(Equal(metav1.ConditionTrue)),
+				)))
+			}).Should(Succeed())
+		})
+
+		It("sets the service secret and observed version", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(instance), instance)).To(Succeed())
+				g.Expect(instance.Status.ServiceSecret).To(PointTo(Equal(corev1.SecretReference{
+					Namespace: testNamespace,
+					Name:      serviceSecret.Name,
+				})))
+				g.Expect(instance.Status.CredentialsObservedVersion).NotTo(BeEmpty())
+				g.Expect(instance.Status.LastOperation).To(Equal(services.LastOperation{
+					Type:  "create",
+					State: "succeeded",
+				}))
+			}).Should(Succeed())
+		})
+
+		It("records a service usage created event", func() {
+			Eventually(func(g Gomega) {
+				events := listServiceUsageEvents(g, instance.Name)
+				g.Expect(events).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+					"Spec": Equal(korifiv1alpha1.CFServiceUsageEventSpec{
+						State:               korifiv1alpha1.ServiceUsageEventCreated,
+						ServiceInstanceGUID: instance.Name,
+						ServiceInstanceName: "service-instance-name",
+						ServiceInstanceType: korifiv1alpha1.KubernetesType,
+						SpaceGUID:           testNamespace,
+					}),
+				})))
+			}).Should(Succeed())
+		})
+
+		When("the secret changes", func() {
+			var secretVersion string
+
+			JustBeforeEach(func() {
+				Eventually(func(g Gomega) {
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(instance), instance)).To(Succeed())
+					g.Expect(instance.Status.CredentialsObservedVersion).NotTo(BeEmpty())
+					secretVersion = instance.Status.CredentialsObservedVersion
+				}).Should(Succeed())
+
+				Expect(k8s.Patch(ctx, adminClient, serviceSecret, func() {
+					serviceSecret.StringData = map[string]string{"password": "secret"}
+				})).To(Succeed())
+			})
+
+			It("updates the observed version", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(instance), instance)).To(Succeed())
+					g.Expect(instance.Status.CredentialsObservedVersion).NotTo(Equal(secretVersion))
+					g.Expect(instance.Status.LastOperation).To(Equal(services.LastOperation{
+						Type:  "update",
+						State: "succeeded",
+					}))
+				}).Should(Succeed())
+			})
+		})
+
+		When("the instance is deleted", func() {
+			JustBeforeEach(func() {
+				Eventually(func(g Gomega) {
+					g.Expect(listServiceUsageEvents(g, instance.Name)).NotTo(BeEmpty())
+				}).Should(Succeed())
+
+				Expect(adminClient.Delete(ctx, instance)).To(Succeed())
+			})
+
+			It("is deleted", func() {
+				Eventually(func(g Gomega) {
+					err := adminClient.Get(ctx, client.ObjectKeyFromObject(instance), instance)
+					g.Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+				}).Should(Succeed())
+			})
+
+			It("does not delete the referenced secret", func() {
+				Consistently(func(g Gomega) {
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(serviceSecret), serviceSecret)).To(Succeed())
+				}).Should(Succeed())
+			})
+		})
+	})
+
+	When("the service instance references a secret in another namespace", func() {
+		var (
+			otherNamespace string
+			serviceSecret  *corev1.Secret
+		)
+
+		BeforeEach(func() {
+			otherNamespace = uuid.NewString()
+			Expect(adminClient.Create(ctx, &corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: otherNamespace,
+				},
+			})).To(Succeed())
+
+			serviceSecret = &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      uuid.NewString(),
+					Namespace: otherNamespace,
+				},
+				StringData: map[string]string{
+					"username": "bob",
+				},
+			}
+			Expect(adminClient.Create(ctx, serviceSecret)).To(Succeed())
+
+			instance.Spec.ServiceRef = &corev1.ObjectReference{
+				Kind:      "Secret",
+				Namespace: otherNamespace,
+				Name:      serviceSecret.Name,
+			}
+		})
+
+		It("does not bind a secret that is not shared with the instance namespace", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(instance), instance)).To(Succeed())
+				g.Expect(instance.Status.Conditions).To(ContainElement(SatisfyAll(
+					HasType(Equal(korifiv1alpha1.StatusConditionReady)),
+					HasStatus(Equal(metav1.ConditionFalse)),
+					HasReason(Equal("ServiceNotShared")),
+				)))
+				g.Expect(instance.Status.ServiceSecret).To(BeNil())
+			}).Should(Succeed())
+		})
+
+		When("the secret is shared with the instance namespace", func() {
+			BeforeEach(func() {
+				Expect(k8s.PatchResource(ctx, adminClient, serviceSecret, func() {
+					serviceSecret.Annotations = map[string]string{
+						korifiv1alpha1.SharedWithNamespacesAnnotationKey: "some-namespace, " + testNamespace,
+					}
+				})).To(Succeed())
+			})
+
+			It("sets the service secret", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(instance), instance)).To(Succeed())
+					g.Expect(instance.Status.Conditions).To(ContainElement(SatisfyAll(
+						HasType(Equal(korifiv1alpha1.StatusConditionReady)),
+						HasStatus(Equal(metav1.ConditionTrue)),
+					)))
+					g.Expect(instance.Status.ServiceSecret).To(PointTo(Equal(corev1.SecretReference{
+						Namespace: otherNamespace,
+						Name:      serviceSecret.Name,
+					})))
+				}).Should(Succeed())
+			})
+		})
+	})
+
+	When("the service instance references a missing provisioned service", func() {
+		BeforeEach(func() {
+			instance.Spec.ServiceRef = &corev1.ObjectReference{
+				APIVersion: "v1",
+				Kind:       "ConfigMap",
+				Name:       uuid.NewString(),
+			}
+		})
+
+		It("sets the ready condition to false", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(instance), instance)).To(Succeed())
+				g.Expect(instance.Status.Conditions).To(ContainElement(SatisfyAll(
+					HasType(Equal(korifiv1alpha1.StatusConditionReady)),
+					HasStatus(Equal(metav1.ConditionFalse)),
+					HasReason(Equal("ProvisionedServiceNotAvailable")),
+				)))
+			}).Should(Succeed())
+		})
+	})
+
+	When("the service instance is user-provided", func() {
+		BeforeEach(func() {
+			instance.Spec.Type = korifiv1alpha1.UserProvidedType
+		})
+
+		It("does not reconcile it", func() {
+			Consistently(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(instance), instance)).To(Succeed())
+				g.Expect(instance.Status).To(BeZero())
+			}).Should(Succeed())
+		})
+	})
+})
+
+func listServiceUsageEvents(g Gomega, serviceInstanceGUID string) []korifiv1alpha1.CFServiceUsageEvent {
+	eventsList := &korifiv1alpha1.CFServiceUsageEventList{}
+	g.Expect(adminClient.List(ctx, eventsList, client.InNamespace(rootNamespace))).To(Succeed())
+
+	events := []korifiv1alpha1.CFServiceUsageEvent{}
+	for _, event := range eventsList.Items {
+		if event.Spec.ServiceInstanceGUID == serviceInstanceGUID {
+			events = append(events, event)
+		}
+	}
+
+	return events
+}
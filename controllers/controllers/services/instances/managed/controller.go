@@ -45,6 +45,11 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
+type ServiceUsageRecorder interface {
+	RecordServiceInstanceUsage(context.Context, *korifiv1alpha1.CFServiceInstance) error
+	RecordServiceInstanceDeleted(context.Context, *korifiv1alpha1.CFServiceInstance) error
+}
+
 type Reconciler struct {
 	k8sClient           client.Client
 	osbapiClientFactory osbapi.BrokerClientFactory
@@ -52,6 +57,7 @@ type Reconciler struct {
 	rootNamespace       string
 	log                 logr.Logger
 	assets              *osbapi.Assets
+	usageRecorder       ServiceUsageRecorder
 }
 
 func NewReconciler(
@@ -60,6 +66,7 @@ func NewReconciler(
 	scheme *runtime.Scheme,
 	rootNamespace string,
 	log logr.Logger,
+	usageRecorder ServiceUsageRecorder,
 ) *k8s.PatchingReconciler[korifiv1alpha1.CFServiceInstance, *korifiv1alpha1.CFServiceInstance] {
 	return k8s.NewPatchingReconciler(log, client, &Reconciler{
 		k8sClient:           client,
@@ -68,6 +75,7 @@ func NewReconciler(
 		rootNamespace:       rootNamespace,
 		log:                 log,
 		assets:              osbapi.NewAssets(client, rootNamespace),
+		usageRecorder:       usageRecorder,
 	})
 }
 
@@ -144,7 +152,11 @@ func (r *Reconciler) ReconcileResource(ctx context.Context, serviceInstance *kor
 	serviceInstance.Status.UpgradeAvailable = serviceInstance.Status.MaintenanceInfo.Version != serviceInstanceAssets.ServicePlan.Spec.MaintenanceInfo.Version
 
 	if isReady(serviceInstance) {
-		return ctrl.Result{}, nil
+		return ctrl.Result{}, r.recordUsage(ctx, serviceInstance)
+	}
+
+	if isOrphanMitigationInProgress(serviceInstance) {
+		return r.mitigateOrphan(ctx, serviceInstance, serviceInstanceAssets, osbapiClient)
 	}
 
 	if isFailed(serviceInstance) {
@@ -177,12 +189,26 @@ func (r *Reconciler) ReconcileResource(ctx context.Context, serviceInstance *kor
 		if err != nil {
 			return ctrl.Result{}, err
 		}
-		return r.processProvisionOperation(serviceInstance, lastOpResponse)
+		result, err := r.processProvisionOperation(serviceInstance, lastOpResponse)
+		if err != nil {
+			return result, err
+		}
+		return result, r.recordUsage(ctx, serviceInstance)
 	}
 
 	serviceInstance.Status.MaintenanceInfo = serviceInstanceAssets.ServicePlan.Spec.MaintenanceInfo
 	serviceInstance.Status.LastOperation.State = "succeeded"
-	return ctrl.Result{}, nil
+	return ctrl.Result{}, r.recordUsage(ctx, serviceInstance)
+}
+
+func (r *Reconciler) recordUsage(ctx context.Context, serviceInstance *korifiv1alpha1.CFServiceInstance) error {
+	err := r.usageRecorder.RecordServiceInstanceUsage(ctx, serviceInstance)
+	if err != nil {
+		logr.FromContextOrDiscard(ctx).Info("failed to record service usage", "reason", err)
+		return err
+	}
+
+	return nil
 }
 
 func (r *Reconciler) provisionServiceInstance(
@@ -238,12 +264,140 @@ func (r *Reconciler) provisionServiceInstance(
 				k8s.NewNotReadyError().WithReason("ProvisionFailed")
 		}
 
+		if osbapi.IsOrphanMitigationRequired(err) {
+			meta.SetStatusCondition(&serviceInstance.Status.Conditions, metav1.Condition{
+				Type:               korifiv1alpha1.OrphanMitigationInProgressCondition,
+				Status:             metav1.ConditionTrue,
+				ObservedGeneration: serviceInstance.Generation,
+				LastTransitionTime: metav1.NewTime(time.Now()),
+				Reason:             "AmbiguousProvisionResponse",
+				Message:            err.Error(),
+			})
+			return osbapi.ProvisionResponse{},
+				k8s.NewNotReadyError().WithReason("OrphanMitigationInProgress").WithRequeue()
+		}
+
 		return osbapi.ProvisionResponse{}, err
 	}
 
 	return provisionResponse, nil
 }
 
+// mitigateOrphan deprovisions a service instance the broker might have
+// created in response to an ambiguous provision request. Failed deprovision
+// requests are retried with the controller backoff. Asynchronous
+// deprovisioning is followed by polling the last operation, until the broker
+// confirms the instance is gone, after which the provisioning is considered
+// failed.
+func (r *Reconciler) mitigateOrphan(
+	ctx context.Context,
+	serviceInstance *korifiv1alpha1.CFServiceInstance,
+	assets osbapi.ServiceInstanceAssets,
+	osbapiClient osbapi.BrokerClient,
+) (ctrl.Result, error) {
+	log := logr.FromContextOrDiscard(ctx).WithName("orphan-mitigation")
+
+	if serviceInstance.Status.OrphanMitigationOperation != nil {
+		return r.pollOrphanMitigation(ctx, serviceInstance, assets, osbapiClient)
+	}
+
+	deprovisionResponse, err := osbapiClient.Deprovision(ctx, osbapi.DeprovisionPayload{
+		ID: serviceInstance.Name,
+		DeprovisionRequestParamaters: osbapi.DeprovisionRequestParamaters{
+			ServiceId: assets.ServiceOffering.Spec.BrokerCatalog.ID,
+			PlanID:    assets.ServicePlan.Spec.BrokerCatalog.ID,
+		},
+	})
+	if osbapi.IgnoreGone(err) != nil {
+		log.Error(err, "orphan mitigation deprovision failed")
+		setOrphanMitigationInProgress(serviceInstance, "DeprovisionFailed", err.Error())
+		return ctrl.Result{}, k8s.NewNotReadyError().WithCause(err).WithReason("OrphanMitigationInProgress")
+	}
+
+	if err == nil && deprovisionResponse.IsAsync {
+		serviceInstance.Status.OrphanMitigationOperation = tools.PtrTo(deprovisionResponse.Operation)
+		setOrphanMitigationInProgress(serviceInstance, "DeprovisionInProgress", "")
+		return ctrl.Result{}, k8s.NewNotReadyError().WithReason("OrphanMitigationInProgress").WithRequeue()
+	}
+
+	return completeOrphanMitigation(ctx, serviceInstance)
+}
+
+func (r *Reconciler) pollOrphanMitigation(
+	ctx context.Context,
+	serviceInstance *korifiv1alpha1.CFServiceInstance,
+	assets osbapi.ServiceInstanceAssets,
+	osbapiClient osbapi.BrokerClient,
+) (ctrl.Result, error) {
+	log := logr.FromContextOrDiscard(ctx).WithName("orphan-mitigation")
+
+	lastOpResponse, err := osbapiClient.GetServiceInstanceLastOperation(ctx, osbapi.GetInstanceLastOperationRequest{
+		InstanceID: serviceInstance.Name,
+		GetLastOperationRequestParameters: osbapi.GetLastOperationRequestParameters{
+			ServiceId: assets.ServiceOffering.Spec.BrokerCatalog.ID,
+			PlanID:    assets.ServicePlan.Spec.BrokerCatalog.ID,
+			Operation: *serviceInstance.Status.OrphanMitigationOperation,
+		},
+	})
+	if osbapi.IgnoreGone(err) != nil {
+		log.Error(err, "getting orphan mitigation last operation failed")
+		return ctrl.Result{}, k8s.NewNotReadyError().WithCause(err).WithReason("OrphanMitigationInProgress")
+	}
+
+	// Brokers respond with 410 Gone once the instance is deleted
+	if err != nil || lastOpResponse.State == "succeeded" {
+		return completeOrphanMitigation(ctx, serviceInstance)
+	}
+
+	if lastOpResponse.State == "failed" {
+		// The deprovision request is sent again on the next reconcile
+		serviceInstance.Status.OrphanMitigationOperation = nil
+		setOrphanMitigationInProgress(serviceInstance, "DeprovisionFailed", lastOpResponse.Description)
+	}
+
+	return ctrl.Result{}, k8s.NewNotReadyError().WithReason("OrphanMitigationInProgress").WithRequeue()
+}
+
+func setOrphanMitigationInProgress(serviceInstance *korifiv1alpha1.CFServiceInstance, reason string, message string) {
+	meta.SetStatusCondition(&serviceInstance.Status.Conditions, metav1.Condition{
+		Type:               korifiv1alpha1.OrphanMitigationInProgressCondition,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: serviceInstance.Generation,
+		LastTransitionTime: metav1.NewTime(time.Now()),
+		Reason:             reason,
+		Message:            message,
+	})
+}
+
+func completeOrphanMitigation(ctx context.Context, serviceInstance *korifiv1alpha1.CFServiceInstance) (ctrl.Result, error) {
+	logr.FromContextOrDiscard(ctx).V(1).Info("orphan mitigation completed")
+
+	serviceInstance.Status.OrphanMitigationOperation = nil
+	meta.SetStatusCondition(&serviceInstance.Status.Conditions, metav1.Condition{
+		Type:               korifiv1alpha1.OrphanMitigationInProgressCondition,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: serviceInstance.Generation,
+		LastTransitionTime: metav1.NewTime(time.Now()),
+		Reason:             "OrphanMitigated",
+	})
+
+	serviceInstance.Status.LastOperation = services.LastOperation{
+		Type:        "create",
+		State:       "failed",
+		Description: "The broker response to the provision request was ambiguous and the instance has been deprovisioned",
+	}
+	meta.SetStatusCondition(&serviceInstance.Status.Conditions, metav1.Condition{
+		Type:               korifiv1alpha1.ProvisioningFailedCondition,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: serviceInstance.Generation,
+		LastTransitionTime: metav1.NewTime(time.Now()),
+		Reason:             "ProvisionFailed",
+		Message:            serviceInstance.Status.LastOperation.Description,
+	})
+
+	return ctrl.Result{}, k8s.NewNotReadyError().WithReason("ProvisionFailed")
+}
+
 func (r *Reconciler) processProvisionOperation(
 	serviceInstance *korifiv1alpha1.CFServiceInstance,
 	lastOpResponse osbapi.LastOperationResponse,
@@ -294,6 +448,12 @@ func (r *Reconciler) finalizeCFServiceInstance(
 		return r.processDeprovisionOperation(serviceInstance, lastOpResponse)
 	}
 
+	err = r.usageRecorder.RecordServiceInstanceDeleted(ctx, serviceInstance)
+	if err != nil {
+		log.Info("failed to record service usage", "reason", err)
+		return ctrl.Result{}, err
+	}
+
 	controllerutil.RemoveFinalizer(serviceInstance, korifiv1alpha1.CFServiceInstanceFinalizerName)
 	log.V(1).Info("finalizer removed")
 
@@ -420,6 +580,10 @@ func (r *Reconciler) isServicePlanVisible(
 		return true, nil
 	}
 
+	if servicePlan.Spec.Visibility.Type == korifiv1alpha1.SpaceServicePlanVisibilityType {
+		return servicePlan.Namespace == serviceInstance.Namespace, nil
+	}
+
 	namespace := &corev1.Namespace{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: serviceInstance.Namespace,
@@ -447,6 +611,10 @@ func (r *Reconciler) getNamespace(ctx context.Context, namespaceName string) (*c
 	return namespace, nil
 }
 
+func isOrphanMitigationInProgress(instance *korifiv1alpha1.CFServiceInstance) bool {
+	return meta.IsStatusConditionTrue(instance.Status.Conditions, korifiv1alpha1.OrphanMitigationInProgressCondition)
+}
+
 func isFailed(instance *korifiv1alpha1.CFServiceInstance) bool {
 	return meta.IsStatusConditionTrue(instance.Status.Conditions, korifiv1alpha1.ProvisioningFailedCondition)
 }
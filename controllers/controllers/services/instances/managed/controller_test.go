@@ -215,6 +215,28 @@ var _ = Describe("CFServiceInstance", func() {
 		}).Should(Succeed())
 	})
 
+	It("records a service usage created event", func() {
+		Eventually(func(g Gomega) {
+			eventsList := &korifiv1alpha1.CFServiceUsageEventList{}
+			g.Expect(adminClient.List(ctx, eventsList, client.InNamespace(rootNamespace))).To(Succeed())
+			g.Expect(eventsList.Items).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+				"Spec": Equal(korifiv1alpha1.CFServiceUsageEventSpec{
+					State:               korifiv1alpha1.ServiceUsageEventCreated,
+					ServiceInstanceGUID: instance.Name,
+					ServiceInstanceName: "service-instance-name",
+					ServiceInstanceType: korifiv1alpha1.ManagedType,
+					ServicePlanGUID:     servicePlan.Name,
+					ServiceOfferingGUID: servicePlan.Labels[korifiv1alpha1.RelServiceOfferingGUIDLabel],
+					ServiceOfferingName: "service-offering-name",
+					ServiceBrokerGUID:   serviceBroker.Name,
+					ServiceBrokerName:   "my-service-broker",
+					SpaceGUID:           instance.Namespace,
+					OrgGUID:             "org-guid",
+				}),
+			})))
+		}).Should(Succeed())
+	})
+
 	When("the service instance parameters are not set", func() {
 		BeforeEach(func() {
 			Expect(k8s.PatchResource(ctx, adminClient, instance, func() {
@@ -539,6 +561,162 @@ var _ = Describe("CFServiceInstance", func() {
 		})
 	})
 
+	When("the broker response to the provision request is ambiguous", func() {
+		BeforeEach(func() {
+			brokerClient.ProvisionReturns(osbapi.ProvisionResponse{}, osbapi.OrphanMitigationRequiredError{
+				Cause: errors.New("provision request failed with status code: 500"),
+			})
+		})
+
+		It("deprovisions the instance with the broker", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(brokerClient.DeprovisionCallCount()).To(BeNumerically(">=", 1))
+				_, actualDeprovisionRequest := brokerClient.DeprovisionArgsForCall(0)
+				g.Expect(actualDeprovisionRequest).To(Equal(osbapi.DeprovisionPayload{
+					ID: instance.Name,
+					DeprovisionRequestParamaters: osbapi.DeprovisionRequestParamaters{
+						ServiceId: "service-offering-id",
+						PlanID:    "service-plan-id",
+					},
+				}))
+			}).Should(Succeed())
+		})
+
+		It("fails the instance once the orphan is mitigated", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(instance), instance)).To(Succeed())
+				g.Expect(instance.Status.Conditions).To(ContainElements(
+					SatisfyAll(
+						HasType(Equal(korifiv1alpha1.OrphanMitigationInProgressCondition)),
+						HasStatus(Equal(metav1.ConditionFalse)),
+						HasReason(Equal("OrphanMitigated")),
+					),
+					SatisfyAll(
+						HasType(Equal(korifiv1alpha1.ProvisioningFailedCondition)),
+						HasStatus(Equal(metav1.ConditionTrue)),
+					),
+				))
+				g.Expect(instance.Status.LastOperation.State).To(Equal("failed"))
+			}).Should(Succeed())
+		})
+
+		It("does not retry provisioning", func() {
+			Consistently(func(g Gomega) {
+				g.Expect(brokerClient.ProvisionCallCount()).To(BeNumerically("<=", 1))
+			}).Should(Succeed())
+		})
+
+		When("the deprovision request fails", func() {
+			BeforeEach(func() {
+				brokerClient.DeprovisionReturns(osbapi.ProvisionResponse{}, errors.New("deprovision-failed"))
+			})
+
+			It("keeps trying to deprovision the instance", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(brokerClient.DeprovisionCallCount()).To(BeNumerically(">", 1))
+				}).Should(Succeed())
+			})
+
+			It("records the mitigation progress", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(instance), instance)).To(Succeed())
+					g.Expect(instance.Status.Conditions).To(ContainElement(SatisfyAll(
+						HasType(Equal(korifiv1alpha1.OrphanMitigationInProgressCondition)),
+						HasStatus(Equal(metav1.ConditionTrue)),
+						HasReason(Equal("DeprovisionFailed")),
+						HasMessage(ContainSubstring("deprovision-failed")),
+					)))
+				}).Should(Succeed())
+			})
+		})
+
+		When("the deprovision is asynchronous", func() {
+			BeforeEach(func() {
+				brokerClient.DeprovisionReturns(osbapi.ProvisionResponse{IsAsync: true, Operation: "deprovision-op"}, nil)
+				brokerClient.GetServiceInstanceLastOperationReturns(osbapi.LastOperationResponse{State: "in progress"}, nil)
+			})
+
+			It("keeps the mitigation in progress", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(instance), instance)).To(Succeed())
+					g.Expect(instance.Status.OrphanMitigationOperation).To(PointTo(Equal("deprovision-op")))
+					g.Expect(instance.Status.Conditions).To(ContainElement(SatisfyAll(
+						HasType(Equal(korifiv1alpha1.OrphanMitigationInProgressCondition)),
+						HasStatus(Equal(metav1.ConditionTrue)),
+						HasReason(Equal("DeprovisionInProgress")),
+					)))
+				}).Should(Succeed())
+			})
+
+			It("polls the last operation instead of deprovisioning again", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(brokerClient.GetServiceInstanceLastOperationCallCount()).To(BeNumerically(">", 1))
+					_, lastOp := brokerClient.GetServiceInstanceLastOperationArgsForCall(1)
+					g.Expect(lastOp).To(Equal(osbapi.GetInstanceLastOperationRequest{
+						InstanceID: instance.Name,
+						GetLastOperationRequestParameters: osbapi.GetLastOperationRequestParameters{
+							ServiceId: "service-offering-id",
+							PlanID:    "service-plan-id",
+							Operation: "deprovision-op",
+						},
+					}))
+				}).Should(Succeed())
+
+				Consistently(func(g Gomega) {
+					g.Expect(brokerClient.DeprovisionCallCount()).To(Equal(1))
+				}).Should(Succeed())
+			})
+
+			When("the deprovision succeeds", func() {
+				BeforeEach(func() {
+					brokerClient.GetServiceInstanceLastOperationReturns(osbapi.LastOperationResponse{State: "succeeded"}, nil)
+				})
+
+				It("completes the mitigation", func() {
+					Eventually(func(g Gomega) {
+						g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(instance), instance)).To(Succeed())
+						g.Expect(instance.Status.OrphanMitigationOperation).To(BeNil())
+						g.Expect(instance.Status.Conditions).To(ContainElement(SatisfyAll(
+							HasType(Equal(korifiv1alpha1.OrphanMitigationInProgressCondition)),
+							HasStatus(Equal(metav1.ConditionFalse)),
+						)))
+					}).Should(Succeed())
+				})
+			})
+
+			When("the broker reports the instance is gone", func() {
+				BeforeEach(func() {
+					brokerClient.GetServiceInstanceLastOperationReturns(osbapi.LastOperationResponse{}, osbapi.GoneError{})
+				})
+
+				It("completes the mitigation", func() {
+					Eventually(func(g Gomega) {
+						g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(instance), instance)).To(Succeed())
+						g.Expect(instance.Status.Conditions).To(ContainElement(SatisfyAll(
+							HasType(Equal(korifiv1alpha1.OrphanMitigationInProgressCondition)),
+							HasStatus(Equal(metav1.ConditionFalse)),
+						)))
+					}).Should(Succeed())
+				})
+			})
+
+			When("the deprovision fails", func() {
+				BeforeEach(func() {
+					brokerClient.GetServiceInstanceLastOperationReturns(osbapi.LastOperationResponse{
+						State:       "failed",
+						Description: "deprovision-failed",
+					}, nil)
+				})
+
+				It("deprovisions the instance again", func() {
+					Eventually(func(g Gomega) {
+						g.Expect(brokerClient.DeprovisionCallCount()).To(BeNumerically(">", 1))
+					}).Should(Succeed())
+				})
+			})
+		})
+	})
+
 	When("the instance has become ready", func() {
 		BeforeEach(func() {
 			Expect(k8s.Patch(ctx, adminClient, instance, func() {
@@ -760,6 +938,30 @@ var _ = Describe("CFServiceInstance", func() {
 		})
 	})
 
+	When("the service plan has space visibility type", func() {
+		BeforeEach(func() {
+			Expect(k8s.PatchResource(ctx, adminClient, servicePlan, func() {
+				servicePlan.Spec.Visibility = korifiv1alpha1.ServicePlanVisibility{
+					Type: korifiv1alpha1.SpaceServicePlanVisibilityType,
+				}
+			})).To(Succeed())
+		})
+
+		It("fails the instance as the plan is not in the instance space", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(instance), instance)).To(Succeed())
+
+				g.Expect(instance.Status.Conditions).To(ContainElements(
+					SatisfyAll(
+						HasType(Equal(korifiv1alpha1.StatusConditionReady)),
+						HasStatus(Equal(metav1.ConditionFalse)),
+						HasReason(Equal("InvalidServicePlan")),
+					),
+				))
+			}).Should(Succeed())
+		})
+	})
+
 	When("the service instance is purged", func() {
 		BeforeEach(func() {
 			Expect(k8s.PatchResource(ctx, adminClient, instance, func() {
@@ -43,18 +43,25 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
+type ServiceUsageRecorder interface {
+	RecordServiceInstanceUsage(context.Context, *korifiv1alpha1.CFServiceInstance) error
+	RecordServiceInstanceDeleted(context.Context, *korifiv1alpha1.CFServiceInstance) error
+}
+
 type Reconciler struct {
-	k8sClient client.Client
-	scheme    *runtime.Scheme
-	log       logr.Logger
+	k8sClient     client.Client
+	scheme        *runtime.Scheme
+	log           logr.Logger
+	usageRecorder ServiceUsageRecorder
 }
 
 func NewReconciler(
 	client client.Client,
 	scheme *runtime.Scheme,
 	log logr.Logger,
+	usageRecorder ServiceUsageRecorder,
 ) *k8s.PatchingReconciler[korifiv1alpha1.CFServiceInstance, *korifiv1alpha1.CFServiceInstance] {
-	serviceInstanceReconciler := Reconciler{k8sClient: client, scheme: scheme, log: log}
+	serviceInstanceReconciler := Reconciler{k8sClient: client, scheme: scheme, log: log, usageRecorder: usageRecorder}
 	return k8s.NewPatchingReconciler(log, client, &serviceInstanceReconciler)
 }
 
@@ -112,12 +119,22 @@ func (r *Reconciler) ReconcileResource(ctx context.Context, cfServiceInstance *k
 	log.V(1).Info("set observed generation", "generation", cfServiceInstance.Status.ObservedGeneration)
 
 	if !cfServiceInstance.GetDeletionTimestamp().IsZero() {
+		if err := r.usageRecorder.RecordServiceInstanceDeleted(ctx, cfServiceInstance); err != nil {
+			log.Info("failed to record service usage", "reason", err)
+			return ctrl.Result{}, err
+		}
+
 		controllerutil.RemoveFinalizer(cfServiceInstance, korifiv1alpha1.CFServiceInstanceFinalizerName)
 		log.V(1).Info("finalizer removed")
 
 		return ctrl.Result{}, nil
 	}
 
+	if err := r.usageRecorder.RecordServiceInstanceUsage(ctx, cfServiceInstance); err != nil {
+		log.Info("failed to record service usage", "reason", err)
+		return ctrl.Result{}, err
+	}
+
 	credentialsSecret := &corev1.Secret{
 		ObjectMeta: metav1.ObjectMeta{
 			Namespace: cfServiceInstance.Namespace,
@@ -11,6 +11,7 @@ import (
 	"code.cloudfoundry.org/korifi/tools/k8s"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
 	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -68,6 +69,45 @@ var _ = Describe("CFServiceInstance", func() {
 			}).Should(Succeed())
 		})
 
+		It("records a service usage created event", func() {
+			Eventually(func(g Gomega) {
+				events := listServiceUsageEvents(g, instance.Name)
+				g.Expect(events).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+					"Spec": Equal(korifiv1alpha1.CFServiceUsageEventSpec{
+						State:               korifiv1alpha1.ServiceUsageEventCreated,
+						ServiceInstanceGUID: instance.Name,
+						ServiceInstanceName: "service-instance-name",
+						ServiceInstanceType: korifiv1alpha1.UserProvidedType,
+						SpaceGUID:           testNamespace,
+					}),
+				})))
+			}).Should(Succeed())
+		})
+
+		When("the service instance gets renamed", func() {
+			BeforeEach(func() {
+				Eventually(func(g Gomega) {
+					g.Expect(listServiceUsageEvents(g, instance.Name)).To(HaveLen(1))
+				}).Should(Succeed())
+
+				Expect(k8s.PatchResource(ctx, adminClient, instance, func() {
+					instance.Spec.DisplayName = "renamed-service-instance"
+				})).To(Succeed())
+			})
+
+			It("records a service usage updated event", func() {
+				Eventually(func(g Gomega) {
+					events := listServiceUsageEvents(g, instance.Name)
+					g.Expect(events).To(ContainElement(MatchFields(IgnoreExtras, Fields{
+						"Spec": MatchFields(IgnoreExtras, Fields{
+							"State":               Equal(korifiv1alpha1.ServiceUsageEventUpdated),
+							"ServiceInstanceName": Equal("renamed-service-instance"),
+						}),
+					})))
+				}).Should(Succeed())
+			})
+		})
+
 		When("the credentials secret gets created", func() {
 			var credentialsSecret *corev1.Secret
 
@@ -217,6 +257,17 @@ var _ = Describe("CFServiceInstance", func() {
 						g.Expect(k8serrors.IsNotFound(err)).To(BeTrue())
 					}).Should(Succeed())
 				})
+
+				It("records a service usage deleted event", func() {
+					Eventually(func(g Gomega) {
+						events := listServiceUsageEvents(g, instance.Name)
+						g.Expect(events).To(ContainElement(MatchFields(IgnoreExtras, Fields{
+							"Spec": MatchFields(IgnoreExtras, Fields{
+								"State": Equal(korifiv1alpha1.ServiceUsageEventDeleted),
+							}),
+						})))
+					}).Should(Succeed())
+				})
 			})
 		})
 
@@ -245,3 +296,17 @@ var _ = Describe("CFServiceInstance", func() {
 		})
 	})
 })
+
+func listServiceUsageEvents(g Gomega, serviceInstanceGUID string) []korifiv1alpha1.CFServiceUsageEvent {
+	eventsList := &korifiv1alpha1.CFServiceUsageEventList{}
+	g.Expect(adminClient.List(ctx, eventsList, client.InNamespace(rootNamespace))).To(Succeed())
+
+	events := []korifiv1alpha1.CFServiceUsageEvent{}
+	for _, event := range eventsList.Items {
+		if event.Spec.ServiceInstanceGUID == serviceInstanceGUID {
+			events = append(events, event)
+		}
+	}
+
+	return events
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -28,17 +29,17 @@ type ServiceInstanceAssets struct {
 }
 
 func (r *Assets) GetServiceInstanceAssets(ctx context.Context, serviceInstance *korifiv1alpha1.CFServiceInstance) (ServiceInstanceAssets, error) {
-	servicePlan, err := r.getServicePlan(ctx, serviceInstance.Spec.PlanGUID)
+	servicePlan, err := r.getServicePlan(ctx, serviceInstance.Namespace, serviceInstance.Spec.PlanGUID)
 	if err != nil {
 		return ServiceInstanceAssets{}, err
 	}
 
-	serviceBroker, err := r.getServiceBroker(ctx, servicePlan.Labels[korifiv1alpha1.RelServiceBrokerGUIDLabel])
+	serviceBroker, err := r.getServiceBroker(ctx, servicePlan.Namespace, servicePlan.Labels[korifiv1alpha1.RelServiceBrokerGUIDLabel])
 	if err != nil {
 		return ServiceInstanceAssets{}, err
 	}
 
-	serviceOffering, err := r.getServiceOffering(ctx, servicePlan.Labels[korifiv1alpha1.RelServiceOfferingGUIDLabel])
+	serviceOffering, err := r.getServiceOffering(ctx, servicePlan.Namespace, servicePlan.Labels[korifiv1alpha1.RelServiceOfferingGUIDLabel])
 	if err != nil {
 		return ServiceInstanceAssets{}, err
 	}
@@ -78,11 +79,11 @@ func (r *Assets) GetServiceBindingAssets(ctx context.Context, serviceBinding *ko
 	}, nil
 }
 
-func (r *Assets) getServiceOffering(ctx context.Context, offeringGUID string) (*korifiv1alpha1.CFServiceOffering, error) {
+func (r *Assets) getServiceOffering(ctx context.Context, namespace string, offeringGUID string) (*korifiv1alpha1.CFServiceOffering, error) {
 	serviceOffering := &korifiv1alpha1.CFServiceOffering{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      offeringGUID,
-			Namespace: r.rootNamespace,
+			Namespace: namespace,
 		},
 	}
 	err := r.k8sClient.Get(ctx, client.ObjectKeyFromObject(serviceOffering), serviceOffering)
@@ -93,7 +94,9 @@ func (r *Assets) getServiceOffering(ctx context.Context, offeringGUID string) (*
 	return serviceOffering, nil
 }
 
-func (r *Assets) getServicePlan(ctx context.Context, planGUID string) (*korifiv1alpha1.CFServicePlan, error) {
+// getServicePlan looks the plan up in the root namespace first and then in
+// the instance namespace, where the plans of space scoped brokers live
+func (r *Assets) getServicePlan(ctx context.Context, instanceNamespace string, planGUID string) (*korifiv1alpha1.CFServicePlan, error) {
 	servicePlan := &korifiv1alpha1.CFServicePlan{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      planGUID,
@@ -101,17 +104,21 @@ func (r *Assets) getServicePlan(ctx context.Context, planGUID string) (*korifiv1
 		},
 	}
 	err := r.k8sClient.Get(ctx, client.ObjectKeyFromObject(servicePlan), servicePlan)
+	if apierrors.IsNotFound(err) {
+		servicePlan.Namespace = instanceNamespace
+		err = r.k8sClient.Get(ctx, client.ObjectKeyFromObject(servicePlan), servicePlan)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to get service plan %q: %w", planGUID, err)
 	}
 	return servicePlan, nil
 }
 
-func (r *Assets) getServiceBroker(ctx context.Context, brokerGUID string) (*korifiv1alpha1.CFServiceBroker, error) {
+func (r *Assets) getServiceBroker(ctx context.Context, namespace string, brokerGUID string) (*korifiv1alpha1.CFServiceBroker, error) {
 	serviceBroker := &korifiv1alpha1.CFServiceBroker{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      brokerGUID,
-			Namespace: r.rootNamespace,
+			Namespace: namespace,
 		},
 	}
 	err := r.k8sClient.Get(ctx, client.ObjectKeyFromObject(serviceBroker), serviceBroker)
@@ -8,6 +8,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
 	"net/url"
 )
@@ -28,6 +29,40 @@ func (c UnrecoverableError) Error() string {
 	return fmt.Sprintf("The server responded with status: %d", c.Status)
 }
 
+// OrphanMitigationRequiredError is returned when the broker response to a
+// provision or a bind request is ambiguous, e.g. the request timed out or
+// failed with a server error. The broker might have created the resource
+// nevertheless, so it has to be deleted in order not to leak it. See
+// https://github.com/openservicebrokerapi/servicebroker/blob/master/spec.md#orphan-mitigation
+type OrphanMitigationRequiredError struct {
+	Cause error
+}
+
+func (e OrphanMitigationRequiredError) Error() string {
+	return fmt.Sprintf("orphan mitigation required: %v", e.Cause)
+}
+
+func (e OrphanMitigationRequiredError) Unwrap() error {
+	return e.Cause
+}
+
+func IsOrphanMitigationRequired(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	return errors.As(err, &OrphanMitigationRequiredError{})
+}
+
+func isTimeout(err error) bool {
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+
+	return errors.Is(err, context.DeadlineExceeded)
+}
+
 func IgnoreGone(err error) error {
 	if errors.As(err, &GoneError{}) {
 		return nil
@@ -88,12 +123,22 @@ func (c *Client) Provision(ctx context.Context, payload ProvisionPayload) (Provi
 			payload.ProvisionRequest,
 		)
 	if err != nil {
-		return ProvisionResponse{}, fmt.Errorf("provision request failed: %w", err)
+		err = fmt.Errorf("provision request failed: %w", err)
+		if isTimeout(err) {
+			return ProvisionResponse{}, OrphanMitigationRequiredError{Cause: err}
+		}
+		return ProvisionResponse{}, err
 	}
 	if statusCode == http.StatusBadRequest || statusCode == http.StatusConflict || statusCode == http.StatusUnprocessableEntity {
 		return ProvisionResponse{}, UnrecoverableError{Status: statusCode}
 	}
 
+	if statusCode >= http.StatusInternalServerError {
+		return ProvisionResponse{}, OrphanMitigationRequiredError{
+			Cause: fmt.Errorf("provision request failed with status code: %d", statusCode),
+		}
+	}
+
 	if statusCode >= 300 {
 		return ProvisionResponse{}, fmt.Errorf("provision request failed with status code: %d", statusCode)
 	}
@@ -104,7 +149,11 @@ func (c *Client) Provision(ctx context.Context, payload ProvisionPayload) (Provi
 
 	err = json.Unmarshal(respBytes, &response)
 	if err != nil {
-		return ProvisionResponse{}, fmt.Errorf("failed to unmarshal response: %w", err)
+		err = fmt.Errorf("failed to unmarshal response: %w", err)
+		if statusCode == http.StatusCreated {
+			return ProvisionResponse{}, OrphanMitigationRequiredError{Cause: err}
+		}
+		return ProvisionResponse{}, err
 	}
 
 	return response, nil
@@ -199,13 +248,23 @@ func (c *Client) Bind(ctx context.Context, payload BindPayload) (BindResponse, e
 			payload.BindRequest,
 		)
 	if err != nil {
-		return BindResponse{}, fmt.Errorf("bind request failed: %w", err)
+		err = fmt.Errorf("bind request failed: %w", err)
+		if isTimeout(err) {
+			return BindResponse{}, OrphanMitigationRequiredError{Cause: err}
+		}
+		return BindResponse{}, err
 	}
 
 	if statusCode == http.StatusBadRequest || statusCode == http.StatusConflict || statusCode == http.StatusUnprocessableEntity {
 		return BindResponse{}, UnrecoverableError{Status: statusCode}
 	}
 
+	if statusCode >= http.StatusInternalServerError {
+		return BindResponse{}, OrphanMitigationRequiredError{
+			Cause: fmt.Errorf("binding request failed with code: %d", statusCode),
+		}
+	}
+
 	if statusCode >= 300 {
 		return BindResponse{}, fmt.Errorf("binding request failed with code: %d", statusCode)
 	}
@@ -216,7 +275,11 @@ func (c *Client) Bind(ctx context.Context, payload BindPayload) (BindResponse, e
 
 	err = json.Unmarshal(respBytes, &response)
 	if err != nil {
-		return BindResponse{}, fmt.Errorf("failed to unmarshal response: %w", err)
+		err = fmt.Errorf("failed to unmarshal response: %w", err)
+		if statusCode == http.StatusCreated {
+			return BindResponse{}, OrphanMitigationRequiredError{Cause: err}
+		}
+		return BindResponse{}, err
 	}
 
 	return response, nil
@@ -328,6 +391,74 @@ func (c *Client) GetServiceBinding(ctx context.Context, payload BindPayload) (Bi
 	return response, nil
 }
 
+func (c *Client) FetchInstance(ctx context.Context, request GetInstanceRequest) (GetInstanceResponse, error) {
+	statusCode, respBytes, err := c.newBrokerRequester().
+		forBroker(c.broker).
+		sendRequest(
+			ctx,
+			"/v2/service_instances/"+request.InstanceID,
+			http.MethodGet,
+			map[string]string{
+				"service_id": request.ServiceId,
+				"plan_id":    request.PlanID,
+			},
+			nil,
+		)
+	if err != nil {
+		return GetInstanceResponse{}, fmt.Errorf("fetching service instance failed: %w", err)
+	}
+
+	if statusCode == http.StatusNotFound || statusCode == http.StatusUnprocessableEntity {
+		return GetInstanceResponse{}, UnrecoverableError{Status: statusCode}
+	}
+
+	if statusCode != http.StatusOK {
+		return GetInstanceResponse{}, fmt.Errorf("fetching service instance failed with status code: %d", statusCode)
+	}
+
+	response := GetInstanceResponse{}
+	err = json.Unmarshal(respBytes, &response)
+	if err != nil {
+		return GetInstanceResponse{}, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	return response, nil
+}
+
+func (c *Client) FetchBinding(ctx context.Context, request GetBindingRequest) (GetBindingResponse, error) {
+	statusCode, respBytes, err := c.newBrokerRequester().
+		forBroker(c.broker).
+		sendRequest(
+			ctx,
+			"/v2/service_instances/"+request.InstanceID+"/service_bindings/"+request.BindingID,
+			http.MethodGet,
+			map[string]string{
+				"service_id": request.ServiceId,
+				"plan_id":    request.PlanID,
+			},
+			nil,
+		)
+	if err != nil {
+		return GetBindingResponse{}, fmt.Errorf("fetching service binding failed: %w", err)
+	}
+
+	if statusCode == http.StatusNotFound || statusCode == http.StatusUnprocessableEntity {
+		return GetBindingResponse{}, UnrecoverableError{Status: statusCode}
+	}
+
+	if statusCode != http.StatusOK {
+		return GetBindingResponse{}, fmt.Errorf("fetching service binding failed with status code: %d", statusCode)
+	}
+
+	response := GetBindingResponse{}
+	err = json.Unmarshal(respBytes, &response)
+	if err != nil {
+		return GetBindingResponse{}, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	return response, nil
+}
+
 func payloadToReader(payload any) (io.Reader, error) {
 	if payload == nil {
 		return nil, nil
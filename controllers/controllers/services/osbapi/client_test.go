@@ -8,6 +8,7 @@ import (
 	"io"
 	"net/http"
 	"strconv"
+	"time"
 
 	"code.cloudfoundry.org/korifi/controllers/controllers/services/osbapi"
 	"code.cloudfoundry.org/korifi/model/services"
@@ -236,6 +237,51 @@ var _ = Describe("OSBAPI Client", func() {
 				It("returns an error", func() {
 					Expect(provisionErr).To(MatchError(ContainSubstring("provision request failed")))
 				})
+
+				It("requires orphan mitigation", func() {
+					Expect(osbapi.IsOrphanMitigationRequired(provisionErr)).To(BeTrue())
+				})
+			})
+
+			When("the provision response body is invalid", func() {
+				BeforeEach(func() {
+					brokerServer = brokerServer.WithRawResponse("/v2/service_instances/{id}", []byte("not-json"), http.StatusCreated)
+				})
+
+				It("requires orphan mitigation", func() {
+					Expect(provisionErr).To(MatchError(ContainSubstring("failed to unmarshal response")))
+					Expect(osbapi.IsOrphanMitigationRequired(provisionErr)).To(BeTrue())
+				})
+			})
+
+			When("the provision request times out", func() {
+				BeforeEach(func() {
+					brokerServer = brokerServer.WithDelayedRawResponse("/v2/service_instances/{id}", []byte("{}"), http.StatusCreated, time.Second)
+				})
+
+				JustBeforeEach(func() {
+					_, provisionErr = osbapi.NewClient(osbapi.Broker{URL: brokerServer.URL()}, &http.Client{
+						Timeout: 100 * time.Millisecond,
+						Transport: &http.Transport{
+							TLSClientConfig: &tls.Config{InsecureSkipVerify: true}, //#nosec G402
+						},
+					}).Provision(ctx, osbapi.ProvisionPayload{InstanceID: "my-service-instance"})
+				})
+
+				It("requires orphan mitigation", func() {
+					Expect(osbapi.IsOrphanMitigationRequired(provisionErr)).To(BeTrue())
+				})
+			})
+
+			When("the provision request fails with a client error", func() {
+				BeforeEach(func() {
+					brokerServer = brokerServer.WithResponse("/v2/service_instances/{id}", nil, http.StatusTeapot)
+				})
+
+				It("does not require orphan mitigation", func() {
+					Expect(provisionErr).To(HaveOccurred())
+					Expect(osbapi.IsOrphanMitigationRequired(provisionErr)).To(BeFalse())
+				})
 			})
 		})
 
@@ -543,6 +589,46 @@ var _ = Describe("OSBAPI Client", func() {
 				}))
 			})
 
+			When("the broker returns volume mounts", func() {
+				BeforeEach(func() {
+					brokerServer.WithResponse(
+						"/v2/service_instances/{instance_id}/service_bindings/{binding_id}",
+						map[string]any{
+							"credentials": map[string]string{},
+							"volume_mounts": []map[string]any{{
+								"driver":        "nfs",
+								"container_dir": "/data",
+								"mode":          "rw",
+								"device_type":   "shared",
+								"device": map[string]any{
+									"volume_id": "volume-id",
+									"mount_config": map[string]any{
+										"source": "nfs://nfs.example.com/export",
+									},
+								},
+							}},
+						},
+						http.StatusCreated,
+					)
+				})
+
+				It("returns the volume mounts", func() {
+					Expect(bindErr).NotTo(HaveOccurred())
+					Expect(bindResp.VolumeMounts).To(ConsistOf(osbapi.VolumeMount{
+						Driver:       "nfs",
+						ContainerDir: "/data",
+						Mode:         "rw",
+						DeviceType:   "shared",
+						Device: osbapi.VolumeDevice{
+							VolumeID: "volume-id",
+							MountConfig: map[string]any{
+								"source": "nfs://nfs.example.com/export",
+							},
+						},
+					}))
+				})
+			})
+
 			When("bind is asynchronous", func() {
 				BeforeEach(func() {
 					brokerServer.WithResponse(
@@ -575,6 +661,39 @@ var _ = Describe("OSBAPI Client", func() {
 				It("returns an error", func() {
 					Expect(bindErr).To(MatchError(ContainSubstring("binding request failed")))
 				})
+
+				It("does not require orphan mitigation", func() {
+					Expect(osbapi.IsOrphanMitigationRequired(bindErr)).To(BeFalse())
+				})
+			})
+
+			When("binding request fails with a server error", func() {
+				BeforeEach(func() {
+					brokerServer = brokerServer.WithResponse(
+						"/v2/service_instances/{instance_id}/service_bindings/{binding_id}",
+						nil,
+						http.StatusBadGateway,
+					)
+				})
+
+				It("requires orphan mitigation", func() {
+					Expect(bindErr).To(MatchError(ContainSubstring("binding request failed")))
+					Expect(osbapi.IsOrphanMitigationRequired(bindErr)).To(BeTrue())
+				})
+			})
+
+			When("the binding response body is invalid", func() {
+				BeforeEach(func() {
+					brokerServer = brokerServer.WithRawResponse(
+						"/v2/service_instances/{instance_id}/service_bindings/{binding_id}",
+						[]byte("not-json"),
+						http.StatusCreated,
+					)
+				})
+
+				It("requires orphan mitigation", func() {
+					Expect(osbapi.IsOrphanMitigationRequired(bindErr)).To(BeTrue())
+				})
 			})
 
 			When("binding request fails with 409 Conflict", func() {
@@ -681,6 +800,181 @@ var _ = Describe("OSBAPI Client", func() {
 			})
 		})
 
+		Describe("FetchInstance", func() {
+			var (
+				instanceResp osbapi.GetInstanceResponse
+				fetchErr     error
+			)
+
+			BeforeEach(func() {
+				brokerServer.WithResponse(
+					"/v2/service_instances/{id}",
+					map[string]any{
+						"dashboard_url": "https://my.dashboard",
+						"parameters": map[string]string{
+							"billing-account": "abcde12345",
+						},
+					},
+					http.StatusOK,
+				)
+			})
+
+			JustBeforeEach(func() {
+				instanceResp, fetchErr = brokerClient.FetchInstance(ctx, osbapi.GetInstanceRequest{
+					InstanceID: "my-service-instance",
+					ServiceId:  "my-service-offering-id",
+					PlanID:     "my-plan-id",
+				})
+			})
+
+			It("fetches the service instance", func() {
+				Expect(fetchErr).NotTo(HaveOccurred())
+
+				requests := brokerServer.ServedRequests()
+				Expect(requests).To(HaveLen(1))
+				Expect(requests[0].Method).To(Equal(http.MethodGet))
+				Expect(requests[0].URL.Path).To(Equal("/v2/service_instances/my-service-instance"))
+				Expect(requests[0].URL.Query()).To(BeEquivalentTo(map[string][]string{
+					"service_id": {"my-service-offering-id"},
+					"plan_id":    {"my-plan-id"},
+				}))
+
+				Expect(instanceResp).To(Equal(osbapi.GetInstanceResponse{
+					DashboardURL: "https://my.dashboard",
+					Parameters: map[string]any{
+						"billing-account": "abcde12345",
+					},
+				}))
+			})
+
+			When("the service instance does not exist", func() {
+				BeforeEach(func() {
+					brokerServer = brokerServer.WithResponse(
+						"/v2/service_instances/{id}",
+						nil,
+						http.StatusNotFound,
+					)
+				})
+
+				It("returns an unrecoverable error", func() {
+					Expect(fetchErr).To(Equal(osbapi.UnrecoverableError{Status: http.StatusNotFound}))
+				})
+			})
+
+			When("the broker fails to fetch the instance", func() {
+				BeforeEach(func() {
+					brokerServer = brokerServer.WithResponse(
+						"/v2/service_instances/{id}",
+						nil,
+						http.StatusInternalServerError,
+					)
+				})
+
+				It("returns an error", func() {
+					Expect(fetchErr).To(MatchError(ContainSubstring("status code: 500")))
+				})
+			})
+		})
+
+		Describe("FetchBinding", func() {
+			var (
+				bindingResp osbapi.GetBindingResponse
+				fetchErr    error
+			)
+
+			BeforeEach(func() {
+				brokerServer.WithResponse(
+					"/v2/service_instances/{instance_id}/service_bindings/{binding_id}",
+					map[string]any{
+						"credentials": map[string]string{
+							"user": "alice",
+						},
+						"volume_mounts": []map[string]any{{
+							"driver":        "nfs",
+							"container_dir": "/data",
+							"mode":          "rw",
+							"device_type":   "shared",
+							"device": map[string]any{
+								"volume_id": "volume-id",
+							},
+						}},
+						"parameters": map[string]string{
+							"billing-account": "abcde12345",
+						},
+					},
+					http.StatusOK,
+				)
+			})
+
+			JustBeforeEach(func() {
+				bindingResp, fetchErr = brokerClient.FetchBinding(ctx, osbapi.GetBindingRequest{
+					InstanceID: "my-service-instance",
+					BindingID:  "my-binding-id",
+					ServiceId:  "my-service-offering-id",
+					PlanID:     "my-plan-id",
+				})
+			})
+
+			It("fetches the service binding", func() {
+				Expect(fetchErr).NotTo(HaveOccurred())
+
+				requests := brokerServer.ServedRequests()
+				Expect(requests).To(HaveLen(1))
+				Expect(requests[0].Method).To(Equal(http.MethodGet))
+				Expect(requests[0].URL.Path).To(Equal("/v2/service_instances/my-service-instance/service_bindings/my-binding-id"))
+				Expect(requests[0].URL.Query()).To(BeEquivalentTo(map[string][]string{
+					"service_id": {"my-service-offering-id"},
+					"plan_id":    {"my-plan-id"},
+				}))
+
+				Expect(bindingResp).To(Equal(osbapi.GetBindingResponse{
+					Credentials: map[string]any{
+						"user": "alice",
+					},
+					VolumeMounts: []osbapi.VolumeMount{{
+						Driver:       "nfs",
+						ContainerDir: "/data",
+						Mode:         "rw",
+						DeviceType:   "shared",
+						Device: osbapi.VolumeDevice{
+							VolumeID: "volume-id",
+						},
+					}},
+					Parameters: map[string]any{
+						"billing-account": "abcde12345",
+					},
+				}))
+			})
+
+			When("the service binding does not exist", func() {
+				BeforeEach(func() {
+					brokerServer = brokerServer.WithResponse(
+						"/v2/service_instances/{instance_id}/service_bindings/{binding_id}",
+						nil,
+						http.StatusNotFound,
+					)
+				})
+
+				It("returns an unrecoverable error", func() {
+					Expect(fetchErr).To(Equal(osbapi.UnrecoverableError{Status: http.StatusNotFound}))
+				})
+			})
+
+			When("the broker fails to fetch the binding", func() {
+				BeforeEach(func() {
+					brokerServer = brokerServer.WithResponse(
+						"/v2/service_instances/{instance_id}/service_bindings/{binding_id}",
+						nil,
+						http.StatusInternalServerError,
+					)
+				})
+
+				It("returns an error", func() {
+					Expect(fetchErr).To(MatchError(ContainSubstring("status code: 500")))
+				})
+			})
+		})
+
 		Describe("GetServiceBindingLastOperation", func() {
 			var (
 				lastOpResp osbapi.LastOperationResponse
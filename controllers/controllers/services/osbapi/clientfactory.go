@@ -25,6 +25,8 @@ type BrokerClient interface {
 	Unbind(context.Context, UnbindPayload) (UnbindResponse, error)
 	GetServiceBindingLastOperation(context.Context, GetBindingLastOperationRequest) (LastOperationResponse, error)
 	GetServiceBinding(ctx context.Context, payload BindPayload) (BindingResponse, error)
+	FetchInstance(context.Context, GetInstanceRequest) (GetInstanceResponse, error)
+	FetchBinding(context.Context, GetBindingRequest) (GetBindingResponse, error)
 }
 
 //counterfeiter:generate -o fake -fake-name BrokerClientFactory code.cloudfoundry.org/korifi/controllers/controllers/services/osbapi.BrokerClientFactory
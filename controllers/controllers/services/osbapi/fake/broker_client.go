@@ -37,6 +37,34 @@ type BrokerClient struct {
 		result1 osbapi.ProvisionResponse
 		result2 error
 	}
+	FetchBindingStub        func(context.Context, osbapi.GetBindingRequest) (osbapi.GetBindingResponse, error)
+	fetchBindingMutex       sync.RWMutex
+	fetchBindingArgsForCall []struct {
+		arg1 context.Context
+		arg2 osbapi.GetBindingRequest
+	}
+	fetchBindingReturns struct {
+		result1 osbapi.GetBindingResponse
+		result2 error
+	}
+	fetchBindingReturnsOnCall map[int]struct {
+		result1 osbapi.GetBindingResponse
+		result2 error
+	}
+	FetchInstanceStub        func(context.Context, osbapi.GetInstanceRequest) (osbapi.GetInstanceResponse, error)
+	fetchInstanceMutex       sync.RWMutex
+	fetchInstanceArgsForCall []struct {
+		arg1 context.Context
+		arg2 osbapi.GetInstanceRequest
+	}
+	fetchInstanceReturns struct {
+		result1 osbapi.GetInstanceResponse
+		result2 error
+	}
+	fetchInstanceReturnsOnCall map[int]struct {
+		result1 osbapi.GetInstanceResponse
+		result2 error
+	}
 	GetCatalogStub        func(context.Context) (osbapi.Catalog, error)
 	getCatalogMutex       sync.RWMutex
 	getCatalogArgsForCall []struct {
@@ -254,6 +282,136 @@ func (fake *BrokerClient) DeprovisionReturnsOnCall(i int, result1 osbapi.Provisi
 	}{result1, result2}
 }
 
+func (fake *BrokerClient) FetchBinding(arg1 context.Context, arg2 osbapi.GetBindingRequest) (osbapi.GetBindingResponse, error) {
+	fake.fetchBindingMutex.Lock()
+	ret, specificReturn := fake.fetchBindingReturnsOnCall[len(fake.fetchBindingArgsForCall)]
+	fake.fetchBindingArgsForCall = append(fake.fetchBindingArgsForCall, struct {
+		arg1 context.Context
+		arg2 osbapi.GetBindingRequest
+	}{arg1, arg2})
+	stub := fake.FetchBindingStub
+	fakeReturns := fake.fetchBindingReturns
+	fake.recordInvocation("FetchBinding", []interface{}{arg1, arg2})
+	fake.fetchBindingMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *BrokerClient) FetchBindingCallCount() int {
+	fake.fetchBindingMutex.RLock()
+	defer fake.fetchBindingMutex.RUnlock()
+	return len(fake.fetchBindingArgsForCall)
+}
+
+func (fake *BrokerClient) FetchBindingCalls(stub func(context.Context, osbapi.GetBindingRequest) (osbapi.GetBindingResponse, error)) {
+	fake.fetchBindingMutex.Lock()
+	defer fake.fetchBindingMutex.Unlock()
+	fake.FetchBindingStub = stub
+}
+
+func (fake *BrokerClient) FetchBindingArgsForCall(i int) (context.Context, osbapi.GetBindingRequest) {
+	fake.fetchBindingMutex.RLock()
+	defer fake.fetchBindingMutex.RUnlock()
+	argsForCall := fake.fetchBindingArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *BrokerClient) FetchBindingReturns(result1 osbapi.GetBindingResponse, result2 error) {
+	fake.fetchBindingMutex.Lock()
+	defer fake.fetchBindingMutex.Unlock()
+	fake.FetchBindingStub = nil
+	fake.fetchBindingReturns = struct {
+		result1 osbapi.GetBindingResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *BrokerClient) FetchBindingReturnsOnCall(i int, result1 osbapi.GetBindingResponse, result2 error) {
+	fake.fetchBindingMutex.Lock()
+	defer fake.fetchBindingMutex.Unlock()
+	fake.FetchBindingStub = nil
+	if fake.fetchBindingReturnsOnCall == nil {
+		fake.fetchBindingReturnsOnCall = make(map[int]struct {
+			result1 osbapi.GetBindingResponse
+			result2 error
+		})
+	}
+	fake.fetchBindingReturnsOnCall[i] = struct {
+		result1 osbapi.GetBindingResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *BrokerClient) FetchInstance(arg1 context.Context, arg2 osbapi.GetInstanceRequest) (osbapi.GetInstanceResponse, error) {
+	fake.fetchInstanceMutex.Lock()
+	ret, specificReturn := fake.fetchInstanceReturnsOnCall[len(fake.fetchInstanceArgsForCall)]
+	fake.fetchInstanceArgsForCall = append(fake.fetchInstanceArgsForCall, struct {
+		arg1 context.Context
+		arg2 osbapi.GetInstanceRequest
+	}{arg1, arg2})
+	stub := fake.FetchInstanceStub
+	fakeReturns := fake.fetchInstanceReturns
+	fake.recordInvocation("FetchInstance", []interface{}{arg1, arg2})
+	fake.fetchInstanceMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *BrokerClient) FetchInstanceCallCount() int {
+	fake.fetchInstanceMutex.RLock()
+	defer fake.fetchInstanceMutex.RUnlock()
+	return len(fake.fetchInstanceArgsForCall)
+}
+
+func (fake *BrokerClient) FetchInstanceCalls(stub func(context.Context, osbapi.GetInstanceRequest) (osbapi.GetInstanceResponse, error)) {
+	fake.fetchInstanceMutex.Lock()
+	defer fake.fetchInstanceMutex.Unlock()
+	fake.FetchInstanceStub = stub
+}
+
+func (fake *BrokerClient) FetchInstanceArgsForCall(i int) (context.Context, osbapi.GetInstanceRequest) {
+	fake.fetchInstanceMutex.RLock()
+	defer fake.fetchInstanceMutex.RUnlock()
+	argsForCall := fake.fetchInstanceArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *BrokerClient) FetchInstanceReturns(result1 osbapi.GetInstanceResponse, result2 error) {
+	fake.fetchInstanceMutex.Lock()
+	defer fake.fetchInstanceMutex.Unlock()
+	fake.FetchInstanceStub = nil
+	fake.fetchInstanceReturns = struct {
+		result1 osbapi.GetInstanceResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *BrokerClient) FetchInstanceReturnsOnCall(i int, result1 osbapi.GetInstanceResponse, result2 error) {
+	fake.fetchInstanceMutex.Lock()
+	defer fake.fetchInstanceMutex.Unlock()
+	fake.FetchInstanceStub = nil
+	if fake.fetchInstanceReturnsOnCall == nil {
+		fake.fetchInstanceReturnsOnCall = make(map[int]struct {
+			result1 osbapi.GetInstanceResponse
+			result2 error
+		})
+	}
+	fake.fetchInstanceReturnsOnCall[i] = struct {
+		result1 osbapi.GetInstanceResponse
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *BrokerClient) GetCatalog(arg1 context.Context) (osbapi.Catalog, error) {
 	fake.getCatalogMutex.Lock()
 	ret, specificReturn := fake.getCatalogReturnsOnCall[len(fake.getCatalogArgsForCall)]
@@ -650,6 +808,10 @@ func (fake *BrokerClient) Invocations() map[string][][]interface{} {
 	defer fake.bindMutex.RUnlock()
 	fake.deprovisionMutex.RLock()
 	defer fake.deprovisionMutex.RUnlock()
+	fake.fetchBindingMutex.RLock()
+	defer fake.fetchBindingMutex.RUnlock()
+	fake.fetchInstanceMutex.RLock()
+	defer fake.fetchInstanceMutex.RUnlock()
 	fake.getCatalogMutex.RLock()
 	defer fake.getCatalogMutex.RUnlock()
 	fake.getServiceBindingMutex.RLock()
@@ -61,6 +61,17 @@ type ProvisionResponse struct {
 	Operation string `json:"operation,omitempty"`
 }
 
+type GetInstanceRequest struct {
+	InstanceID string
+	ServiceId  string
+	PlanID     string
+}
+
+type GetInstanceResponse struct {
+	DashboardURL string         `json:"dashboard_url,omitempty"`
+	Parameters   map[string]any `json:"parameters"`
+}
+
 type GetBindingRequest struct {
 	InstanceID string
 	BindingID  string
@@ -69,7 +80,9 @@ type GetBindingRequest struct {
 }
 
 type GetBindingResponse struct {
-	Credentials map[string]any `json:"credentials"`
+	Credentials  map[string]any `json:"credentials"`
+	VolumeMounts []VolumeMount  `json:"volume_mounts"`
+	Parameters   map[string]any `json:"parameters"`
 }
 
 type GetInstanceLastOperationRequest struct {
@@ -113,9 +126,23 @@ type BindPayload struct {
 }
 
 type BindResponse struct {
-	Credentials map[string]any `json:"credentials"`
-	Operation   string         `json:"operation"`
-	IsAsync     bool
+	Credentials  map[string]any `json:"credentials"`
+	VolumeMounts []VolumeMount  `json:"volume_mounts"`
+	Operation    string         `json:"operation"`
+	IsAsync      bool
+}
+
+type VolumeMount struct {
+	Driver       string       `json:"driver"`
+	ContainerDir string       `json:"container_dir"`
+	Mode         string       `json:"mode"`
+	DeviceType   string       `json:"device_type"`
+	Device       VolumeDevice `json:"device"`
+}
+
+type VolumeDevice struct {
+	VolumeID    string         `json:"volume_id"`
+	MountConfig map[string]any `json:"mount_config"`
 }
 
 type BindingResponse struct {
@@ -0,0 +1,9 @@
+package shared
+
+const minCPURequestMillicores int64 = 5
+
+// CPURequestMillicores returns the CPU request of a workload with the given
+// amount of memory, given the millicores it is entitled to for each GiB of it
+func CPURequestMillicores(memoryMiB int64, millicoresPerGB int64) int64 {
+	return max(millicoresPerGB*memoryMiB/1024, minCPURequestMillicores)
+}
@@ -22,6 +22,7 @@ const (
 	IndexOrgNamespaceName                     = "orgNamespace"
 	IndexServiceBrokerCredentialsSecretName   = "serviceBrokerCredentialsSecretName"
 	IndexServiceInstancePlanGUID              = "serviceInstancePlanGUID"
+	IndexServiceInstanceServiceSecret         = "serviceInstanceServiceSecret"
 )
 
 func SetupIndexWithManager(mgr manager.Manager) error {
@@ -93,6 +94,11 @@ func SetupIndexWithManager(mgr manager.Manager) error {
 		return err
 	}
 
+	err = mgr.GetFieldIndexer().IndexField(context.Background(), &korifiv1alpha1.CFServiceInstance{}, IndexServiceInstanceServiceSecret, serviceInstanceServiceSecretIndexFn)
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -120,6 +126,31 @@ func serviceBindingServiceInstanceGUIDIndexFn(rawObj client.Object) []string {
 	return []string{serviceBinding.Spec.Service.Name}
 }
 
+func serviceInstanceServiceSecretIndexFn(rawObj client.Object) []string {
+	serviceInstance := rawObj.(*korifiv1alpha1.CFServiceInstance)
+
+	keys := []string{}
+	if ref := serviceInstance.Spec.ServiceRef; ref != nil && ref.Kind == "Secret" {
+		namespace := ref.Namespace
+		if namespace == "" {
+			namespace = serviceInstance.Namespace
+		}
+		keys = append(keys, ServiceSecretIndexKey(namespace, ref.Name))
+	}
+
+	if secret := serviceInstance.Status.ServiceSecret; secret != nil {
+		keys = append(keys, ServiceSecretIndexKey(secret.Namespace, secret.Name))
+	}
+
+	return keys
+}
+
+// ServiceSecretIndexKey returns the IndexServiceInstanceServiceSecret key of
+// the secret with the given namespace and name
+func ServiceSecretIndexKey(namespace, name string) string {
+	return namespace + "/" + name
+}
+
 func RemovePackageManagerKeys(src map[string]string, log logr.Logger) map[string]string {
 	if src == nil {
 		return src
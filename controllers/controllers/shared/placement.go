@@ -0,0 +1,37 @@
+package shared
+
+import (
+	"context"
+	"fmt"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+type WorkloadPlacement struct {
+	NodeSelector map[string]string
+	Tolerations  []corev1.Toleration
+}
+
+// GetWorkloadPlacement returns the node placement of workloads running in the
+// given space namespace, as inherited from the isolation segment the space is
+// assigned to. Namespaces that do not belong to a space get no placement.
+func GetWorkloadPlacement(ctx context.Context, k8sClient client.Client, namespace string) (WorkloadPlacement, error) {
+	spaces := korifiv1alpha1.CFSpaceList{}
+	if err := k8sClient.List(ctx, &spaces, client.MatchingFields{
+		IndexSpaceNamespaceName: namespace,
+	}); err != nil {
+		return WorkloadPlacement{}, fmt.Errorf("error listing cfSpaces: %w", err)
+	}
+
+	if len(spaces.Items) != 1 {
+		return WorkloadPlacement{}, nil
+	}
+
+	return WorkloadPlacement{
+		NodeSelector: spaces.Items[0].Status.NodeSelector,
+		Tolerations:  spaces.Items[0].Status.Tolerations,
+	}, nil
+}
@@ -0,0 +1,242 @@
+package usage
+
+import (
+	"context"
+	"fmt"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+
+	"github.com/google/uuid"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Recorder records app and service usage events in the root namespace
+type Recorder struct {
+	k8sClient     client.Client
+	apiReader     client.Reader
+	rootNamespace string
+}
+
+func NewRecorder(k8sClient client.Client, apiReader client.Reader, rootNamespace string) *Recorder {
+	return &Recorder{
+		k8sClient:     k8sClient,
+		apiReader:     apiReader,
+		rootNamespace: rootNamespace,
+	}
+}
+
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfappusageevents;cfserviceusageevents,verbs=create
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfserviceplans;cfserviceofferings;cfservicebrokers,verbs=get;list;watch
+
+func (r *Recorder) RecordAppUsage(
+	ctx context.Context,
+	cfApp *korifiv1alpha1.CFApp,
+	cfProcess *korifiv1alpha1.CFProcess,
+	previous korifiv1alpha1.ProcessUsage,
+	current korifiv1alpha1.ProcessUsage,
+) error {
+	space, err := r.getSpace(ctx, cfProcess.Namespace)
+	if err != nil {
+		return err
+	}
+
+	event := &korifiv1alpha1.CFAppUsageEvent{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: r.rootNamespace,
+			Name:      AppUsageEventName(cfProcess.Name, cfApp.Generation, cfProcess.Generation, previous, current),
+		},
+		Spec: korifiv1alpha1.CFAppUsageEventSpec{
+			State:                         current.State,
+			PreviousState:                 previous.State,
+			AppGUID:                       cfApp.Name,
+			AppName:                       cfApp.Spec.DisplayName,
+			ProcessGUID:                   cfProcess.Name,
+			ProcessType:                   cfProcess.Spec.ProcessType,
+			SpaceGUID:                     cfProcess.Namespace,
+			SpaceName:                     space.name,
+			OrgGUID:                       space.orgGUID,
+			InstanceCount:                 current.Instances,
+			PreviousInstanceCount:         previous.Instances,
+			MemoryInMBPerInstance:         current.MemoryMB,
+			PreviousMemoryInMBPerInstance: previous.MemoryMB,
+		},
+	}
+
+	return r.create(ctx, event, AppEventsSequenceKey, &event.Spec.Sequence)
+}
+
+// RecordServiceInstanceUsage records a service usage event when the service
+// instance is seen for the first time (CREATED) or when its name or plan have
+// changed since the last recorded event (UPDATED). The recorded usage is
+// stored in the service instance status.
+func (r *Recorder) RecordServiceInstanceUsage(ctx context.Context, serviceInstance *korifiv1alpha1.CFServiceInstance) error {
+	currentUsage := korifiv1alpha1.ServiceInstanceUsage{
+		Name:     serviceInstance.Spec.DisplayName,
+		PlanGUID: serviceInstance.Spec.PlanGUID,
+	}
+
+	state := korifiv1alpha1.ServiceUsageEventCreated
+	if serviceInstance.Status.Usage != nil {
+		if *serviceInstance.Status.Usage == currentUsage {
+			return nil
+		}
+		state = korifiv1alpha1.ServiceUsageEventUpdated
+	}
+
+	err := r.recordServiceUsage(ctx, serviceInstance, state)
+	if err != nil {
+		return err
+	}
+
+	serviceInstance.Status.Usage = &currentUsage
+	return nil
+}
+
+// RecordServiceInstanceDeleted records a DELETED service usage event, unless
+// no usage has ever been recorded for the service instance
+func (r *Recorder) RecordServiceInstanceDeleted(ctx context.Context, serviceInstance *korifiv1alpha1.CFServiceInstance) error {
+	if serviceInstance.Status.Usage == nil {
+		return nil
+	}
+
+	err := r.recordServiceUsage(ctx, serviceInstance, korifiv1alpha1.ServiceUsageEventDeleted)
+	if err != nil {
+		return err
+	}
+
+	serviceInstance.Status.Usage = nil
+	return nil
+}
+
+func (r *Recorder) recordServiceUsage(ctx context.Context, serviceInstance *korifiv1alpha1.CFServiceInstance, state string) error {
+	space, err := r.getSpace(ctx, serviceInstance.Namespace)
+	if err != nil {
+		return err
+	}
+
+	event := &korifiv1alpha1.CFServiceUsageEvent{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: r.rootNamespace,
+			Name:      ServiceUsageEventName(serviceInstance.Name, serviceInstance.Generation, state),
+		},
+		Spec: korifiv1alpha1.CFServiceUsageEventSpec{
+			State:               state,
+			ServiceInstanceGUID: serviceInstance.Name,
+			ServiceInstanceName: serviceInstance.Spec.DisplayName,
+			ServiceInstanceType: serviceInstance.Spec.Type,
+			SpaceGUID:           serviceInstance.Namespace,
+			SpaceName:           space.name,
+			OrgGUID:             space.orgGUID,
+		},
+	}
+
+	if serviceInstance.Spec.Type == korifiv1alpha1.ManagedType {
+		err = r.setServicePlanDetails(ctx, serviceInstance.Spec.PlanGUID, &event.Spec)
+		if err != nil {
+			return err
+		}
+	}
+
+	return r.create(ctx, event, ServiceEventsSequenceKey, &event.Spec.Sequence)
+}
+
+// create treats an already existing event as recorded, as event names are
+// derived from the transition they record and the status update that follows
+// the event creation may have failed in a previous reconcile. New events are
+// given the next sequence number of their kind, which is released once the
+// event has been created.
+func (r *Recorder) create(ctx context.Context, event client.Object, sequenceKey string, sequence *int64) error {
+	err := r.apiReader.Get(ctx, client.ObjectKeyFromObject(event), event)
+	if err == nil {
+		return nil
+	}
+	if !k8serrors.IsNotFound(err) {
+		return fmt.Errorf("failed to get usage event %q: %w", event.GetName(), err)
+	}
+
+	*sequence, err = NextSequence(ctx, r.apiReader, r.k8sClient, r.rootNamespace, sequenceKey)
+	if err != nil {
+		return err
+	}
+
+	createErr := client.IgnoreAlreadyExists(r.k8sClient.Create(ctx, event))
+
+	err = ReleaseSequence(ctx, r.apiReader, r.k8sClient, r.rootNamespace, sequenceKey, *sequence)
+	if err != nil {
+		return err
+	}
+
+	return createErr
+}
+
+func (r *Recorder) setServicePlanDetails(ctx context.Context, planGUID string, eventSpec *korifiv1alpha1.CFServiceUsageEventSpec) error {
+	servicePlan := &korifiv1alpha1.CFServicePlan{}
+	err := r.k8sClient.Get(ctx, client.ObjectKey{Namespace: r.rootNamespace, Name: planGUID}, servicePlan)
+	if err != nil {
+		return fmt.Errorf("failed to get service plan %q: %w", planGUID, err)
+	}
+	eventSpec.ServicePlanGUID = servicePlan.Name
+	eventSpec.ServicePlanName = servicePlan.Spec.Name
+
+	serviceOffering := &korifiv1alpha1.CFServiceOffering{}
+	offeringGUID := servicePlan.Labels[korifiv1alpha1.RelServiceOfferingGUIDLabel]
+	err = r.k8sClient.Get(ctx, client.ObjectKey{Namespace: r.rootNamespace, Name: offeringGUID}, serviceOffering)
+	if err != nil {
+		return fmt.Errorf("failed to get service offering %q: %w", offeringGUID, err)
+	}
+	eventSpec.ServiceOfferingGUID = serviceOffering.Name
+	eventSpec.ServiceOfferingName = serviceOffering.Spec.Name
+
+	serviceBroker := &korifiv1alpha1.CFServiceBroker{}
+	brokerGUID := servicePlan.Labels[korifiv1alpha1.RelServiceBrokerGUIDLabel]
+	err = r.k8sClient.Get(ctx, client.ObjectKey{Namespace: r.rootNamespace, Name: brokerGUID}, serviceBroker)
+	if err != nil {
+		return fmt.Errorf("failed to get service broker %q: %w", brokerGUID, err)
+	}
+	eventSpec.ServiceBrokerGUID = serviceBroker.Name
+	eventSpec.ServiceBrokerName = serviceBroker.Spec.Name
+
+	return nil
+}
+
+type spaceInfo struct {
+	name    string
+	orgGUID string
+}
+
+func (r *Recorder) getSpace(ctx context.Context, spaceGUID string) (spaceInfo, error) {
+	namespace := &corev1.Namespace{}
+	err := r.k8sClient.Get(ctx, client.ObjectKey{Name: spaceGUID}, namespace)
+	if err != nil {
+		return spaceInfo{}, fmt.Errorf("failed to get namespace %q: %w", spaceGUID, err)
+	}
+
+	return spaceInfo{
+		name:    namespace.Annotations[korifiv1alpha1.SpaceNameKey],
+		orgGUID: namespace.Labels[korifiv1alpha1.OrgGUIDKey],
+	}, nil
+}
+
+// AppUsageEventName returns the name of the event recording the process
+// usage transition. The name is derived from the process and the generations
+// of the app (start and stop) and of the process (scale) the transition was
+// observed at, so that recording the same transition twice is a no-op.
+func AppUsageEventName(processGUID string, appGeneration, processGeneration int64, previous, current korifiv1alpha1.ProcessUsage) string {
+	return eventName("app", processGUID, appGeneration, processGeneration,
+		previous.State, previous.Instances, previous.MemoryMB,
+		current.State, current.Instances, current.MemoryMB,
+	)
+}
+
+// ServiceUsageEventName returns the name of the event recording the service
+// instance usage state at the given generation of the service instance
+func ServiceUsageEventName(serviceInstanceGUID string, generation int64, state string) string {
+	return eventName("service", serviceInstanceGUID, generation, state)
+}
+
+func eventName(kind string, values ...any) string {
+	return uuid.NewSHA1(uuid.NameSpaceOID, []byte(fmt.Sprintln(append([]any{kind}, values...)...))).String()
+}
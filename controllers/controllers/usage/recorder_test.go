@@ -0,0 +1,48 @@
+package usage_test
+
+import (
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/controllers/usage"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Event names", func() {
+	var stopped, started korifiv1alpha1.ProcessUsage
+
+	BeforeEach(func() {
+		stopped = korifiv1alpha1.ProcessUsage{State: korifiv1alpha1.StoppedState}
+		started = korifiv1alpha1.ProcessUsage{State: korifiv1alpha1.StartedState, Instances: 2, MemoryMB: 256}
+	})
+
+	Describe("AppUsageEventName", func() {
+		It("is the same for the same transition", func() {
+			Expect(usage.AppUsageEventName("process-guid", 2, 1, stopped, started)).To(Equal(usage.AppUsageEventName("process-guid", 2, 1, stopped, started)))
+		})
+
+		It("differs between processes", func() {
+			Expect(usage.AppUsageEventName("process-guid", 2, 1, stopped, started)).NotTo(Equal(usage.AppUsageEventName("other-process-guid", 2, 1, stopped, started)))
+		})
+
+		It("differs between transitions", func() {
+			Expect(usage.AppUsageEventName("process-guid", 2, 1, stopped, started)).NotTo(Equal(usage.AppUsageEventName("process-guid", 2, 1, started, stopped)))
+		})
+
+		It("differs when the same transition happens at another generation", func() {
+			Expect(usage.AppUsageEventName("process-guid", 2, 1, stopped, started)).NotTo(Equal(usage.AppUsageEventName("process-guid", 4, 1, stopped, started)))
+			Expect(usage.AppUsageEventName("process-guid", 2, 1, stopped, started)).NotTo(Equal(usage.AppUsageEventName("process-guid", 2, 3, stopped, started)))
+		})
+	})
+
+	Describe("ServiceUsageEventName", func() {
+		It("is the same for the same state", func() {
+			Expect(usage.ServiceUsageEventName("instance-guid", 1, korifiv1alpha1.ServiceUsageEventCreated)).To(Equal(usage.ServiceUsageEventName("instance-guid", 1, korifiv1alpha1.ServiceUsageEventCreated)))
+		})
+
+		It("differs between states and generations", func() {
+			Expect(usage.ServiceUsageEventName("instance-guid", 1, korifiv1alpha1.ServiceUsageEventCreated)).NotTo(Equal(usage.ServiceUsageEventName("instance-guid", 1, korifiv1alpha1.ServiceUsageEventDeleted)))
+			Expect(usage.ServiceUsageEventName("instance-guid", 2, korifiv1alpha1.ServiceUsageEventUpdated)).NotTo(Equal(usage.ServiceUsageEventName("instance-guid", 3, korifiv1alpha1.ServiceUsageEventUpdated)))
+		})
+	})
+})
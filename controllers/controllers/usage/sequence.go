@@ -0,0 +1,185 @@
+package usage
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	SequenceConfigMapName = "korifi-usage-event-sequence"
+
+	AppEventsSequenceKey     = "app"
+	ServiceEventsSequenceKey = "service"
+
+	// Sequence numbers allocated by recorders that did not release them in
+	// time, e.g. because they crashed, are no longer considered in flight
+	inFlightTimeout = time.Minute
+)
+
+//+kubebuilder:rbac:groups="",resources=configmaps,verbs=create
+//+kubebuilder:rbac:groups="",resources=configmaps,verbs=get;update,resourceNames=korifi-usage-event-sequence
+
+// NextSequence allocates the next sequence number of the app or service usage
+// events. The last allocated numbers are stored in a ConfigMap in the root
+// namespace, which is updated with optimistic locking so that concurrent
+// recorders never get the same number. The ConfigMap is read with the given
+// reader, which must not be cached, as a stale read only results in conflicts.
+//
+// The allocated number stays in flight until it is released with
+// ReleaseSequence once the event has been created, so that events recorded
+// concurrently with higher numbers are not listed before it.
+func NextSequence(ctx context.Context, reader client.Reader, k8sClient client.Client, rootNamespace string, key string) (int64, error) {
+	var next int64
+
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		next = 0
+		now := time.Now()
+
+		configMap := &corev1.ConfigMap{}
+		err := reader.Get(ctx, client.ObjectKey{Namespace: rootNamespace, Name: SequenceConfigMapName}, configMap)
+		if k8serrors.IsNotFound(err) {
+			next = 1
+			err = k8sClient.Create(ctx, &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: rootNamespace,
+					Name:      SequenceConfigMapName,
+				},
+				Data: map[string]string{
+					key:                    strconv.FormatInt(next, 10),
+					inFlightKey(key, next): now.UTC().Format(time.RFC3339),
+				},
+			})
+			if k8serrors.IsAlreadyExists(err) {
+				return k8serrors.NewConflict(corev1.Resource("configmaps"), SequenceConfigMapName, err)
+			}
+			return err
+		}
+		if err != nil {
+			return err
+		}
+
+		if last, ok := configMap.Data[key]; ok {
+			next, err = strconv.ParseInt(last, 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid %s usage event sequence %q: %w", key, last, err)
+			}
+		}
+		next++
+
+		if configMap.Data == nil {
+			configMap.Data = map[string]string{}
+		}
+		for _, sequence := range allocatedSequences(configMap, key, now) {
+			if !sequence.inFlight {
+				delete(configMap.Data, inFlightKey(key, sequence.number))
+			}
+		}
+		configMap.Data[key] = strconv.FormatInt(next, 10)
+		configMap.Data[inFlightKey(key, next)] = now.UTC().Format(time.RFC3339)
+
+		return k8sClient.Update(ctx, configMap)
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to allocate %s usage event sequence number: %w", key, err)
+	}
+
+	return next, nil
+}
+
+// ReleaseSequence marks the sequence number allocated by NextSequence as no
+// longer in flight. It must be called whether the event has been created or
+// not.
+func ReleaseSequence(ctx context.Context, reader client.Reader, k8sClient client.Client, rootNamespace string, key string, sequence int64) error {
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		configMap := &corev1.ConfigMap{}
+		err := reader.Get(ctx, client.ObjectKey{Namespace: rootNamespace, Name: SequenceConfigMapName}, configMap)
+		if err != nil {
+			return client.IgnoreNotFound(err)
+		}
+
+		if _, ok := configMap.Data[inFlightKey(key, sequence)]; !ok {
+			return nil
+		}
+		delete(configMap.Data, inFlightKey(key, sequence))
+
+		return k8sClient.Update(ctx, configMap)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to release %s usage event sequence number %d: %w", key, sequence, err)
+	}
+
+	return nil
+}
+
+// FirstInFlightSequence returns the lowest app or service usage event sequence
+// number that is still in flight, if any. Events with this number or higher
+// must not be listed yet, as the event with this number may still be created.
+func FirstInFlightSequence(ctx context.Context, reader client.Reader, rootNamespace string, key string) (int64, bool, error) {
+	configMap := &corev1.ConfigMap{}
+	err := reader.Get(ctx, client.ObjectKey{Namespace: rootNamespace, Name: SequenceConfigMapName}, configMap)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return 0, false, nil
+		}
+		return 0, false, fmt.Errorf("failed to get the usage event sequence: %w", err)
+	}
+
+	var first int64
+	found := false
+	for _, sequence := range allocatedSequences(configMap, key, time.Now()) {
+		if sequence.inFlight && (!found || sequence.number < first) {
+			first = sequence.number
+			found = true
+		}
+	}
+
+	return first, found, nil
+}
+
+type allocatedSequence struct {
+	number   int64
+	inFlight bool
+}
+
+// allocatedSequences returns the allocated sequence numbers that have not
+// been released yet. The ones allocated longer than inFlightTimeout ago are
+// no longer in flight.
+func allocatedSequences(configMap *corev1.ConfigMap, key string, now time.Time) []allocatedSequence {
+	sequences := []allocatedSequence{}
+	for dataKey, allocatedAt := range configMap.Data {
+		numberString, ok := strings.CutPrefix(dataKey, inFlightKeyPrefix(key))
+		if !ok {
+			continue
+		}
+
+		number, err := strconv.ParseInt(numberString, 10, 64)
+		if err != nil {
+			continue
+		}
+
+		allocationTime, err := time.Parse(time.RFC3339, allocatedAt)
+		sequences = append(sequences, allocatedSequence{
+			number:   number,
+			inFlight: err == nil && now.Sub(allocationTime) < inFlightTimeout,
+		})
+	}
+
+	return sequences
+}
+
+func inFlightKeyPrefix(key string) string {
+	return key + ".in-flight."
+}
+
+func inFlightKey(key string, sequence int64) string {
+	return inFlightKeyPrefix(key) + strconv.FormatInt(sequence, 10)
+}
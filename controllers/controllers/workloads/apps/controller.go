@@ -32,21 +32,45 @@ type EnvValueBuilder interface {
 	BuildEnvValue(context.Context, *korifiv1alpha1.CFApp) (map[string][]byte, error)
 }
 
+type AppUsageRecorder interface {
+	RecordAppUsage(context.Context, *korifiv1alpha1.CFApp, *korifiv1alpha1.CFProcess, korifiv1alpha1.ProcessUsage, korifiv1alpha1.ProcessUsage) error
+}
+
+//counterfeiter:generate -o fake -fake-name RepositoryDeleter . RepositoryDeleter
+
+type RepositoryDeleter interface {
+	DeleteRepository(ctx context.Context, name string) error
+}
+
 type Reconciler struct {
 	log                       logr.Logger
 	k8sClient                 client.Client
 	scheme                    *runtime.Scheme
 	vcapServicesEnvBuilder    EnvValueBuilder
 	vcapApplicationEnvBuilder EnvValueBuilder
+	usageRecorder             AppUsageRecorder
+	repositoryDeleter         RepositoryDeleter
+	repositoryPrefix          string
 }
 
-func NewReconciler(k8sClient client.Client, scheme *runtime.Scheme, log logr.Logger, vcapServicesBuilder, vcapApplicationBuilder EnvValueBuilder) *k8s.PatchingReconciler[korifiv1alpha1.CFApp, *korifiv1alpha1.CFApp] {
+func NewReconciler(
+	k8sClient client.Client,
+	scheme *runtime.Scheme,
+	log logr.Logger,
+	vcapServicesBuilder, vcapApplicationBuilder EnvValueBuilder,
+	usageRecorder AppUsageRecorder,
+	repositoryDeleter RepositoryDeleter,
+	repositoryPrefix string,
+) *k8s.PatchingReconciler[korifiv1alpha1.CFApp, *korifiv1alpha1.CFApp] {
 	appReconciler := Reconciler{
 		log:                       log,
 		k8sClient:                 k8sClient,
 		scheme:                    scheme,
 		vcapServicesEnvBuilder:    vcapServicesBuilder,
 		vcapApplicationEnvBuilder: vcapApplicationBuilder,
+		usageRecorder:             usageRecorder,
+		repositoryDeleter:         repositoryDeleter,
+		repositoryPrefix:          repositoryPrefix,
 	}
 	return k8s.NewPatchingReconciler(log, k8sClient, &appReconciler)
 }
@@ -196,8 +220,9 @@ func bindingObjectRefs(bindings []korifiv1alpha1.CFServiceBinding) []korifiv1alp
 		}
 
 		return korifiv1alpha1.ServiceBinding{
-			Name:   bindingName,
-			Secret: binding.Status.MountSecretRef.Name,
+			Name:         bindingName,
+			Secret:       binding.Status.MountSecretRef.Name,
+			VolumeMounts: binding.Status.VolumeMounts,
 		}
 	}))
 }
@@ -206,6 +231,12 @@ func getActualState(processes []*korifiv1alpha1.CFProcess) korifiv1alpha1.AppSta
 	processInstances := int32(0)
 	for _, p := range processes {
 		processInstances += p.Status.ActualInstances
+
+		// a process scaled to zero is started, even though it has no
+		// instances until it gets a request
+		if p.Status.ScaledToZero {
+			return korifiv1alpha1.StartedState
+		}
 	}
 
 	if processInstances == 0 {
@@ -359,6 +390,13 @@ func (r *Reconciler) finalizeCFApp(ctx context.Context, cfApp *korifiv1alpha1.CF
 		return sbFinalizationResult, nil
 	}
 
+	err = r.recordProcessesStopped(ctx, cfApp)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	r.deleteAppRepositories(ctx, cfApp)
+
 	if controllerutil.RemoveFinalizer(cfApp, korifiv1alpha1.CFAppFinalizerName) {
 		log.V(1).Info("finalizer removed")
 	}
@@ -366,6 +404,66 @@ func (r *Reconciler) finalizeCFApp(ctx context.Context, cfApp *korifiv1alpha1.CF
 	return ctrl.Result{}, nil
 }
 
+// deleteAppRepositories deletes the package and droplet repositories of the
+// app. Failures are only logged in order not to block the app deletion on an
+// unavailable registry.
+func (r *Reconciler) deleteAppRepositories(ctx context.Context, cfApp *korifiv1alpha1.CFApp) {
+	log := logr.FromContextOrDiscard(ctx).WithName("deleteAppRepositories")
+
+	if r.repositoryPrefix == "" {
+		return
+	}
+
+	for _, suffix := range []string{"-packages", "-droplets"} {
+		repository := r.repositoryPrefix + cfApp.Name + suffix
+		if err := r.repositoryDeleter.DeleteRepository(ctx, repository); err != nil {
+			log.Info("failed to delete repository", "repository", repository, "reason", err)
+		}
+	}
+}
+
+// recordProcessesStopped records a stop app usage event for every process that
+// is still reported as started, as processes are not reconciled anymore once
+// their app is being deleted
+func (r *Reconciler) recordProcessesStopped(ctx context.Context, cfApp *korifiv1alpha1.CFApp) error {
+	log := logr.FromContextOrDiscard(ctx).WithName("recordProcessesStopped")
+
+	processList := korifiv1alpha1.CFProcessList{}
+	err := r.k8sClient.List(ctx, &processList, client.InNamespace(cfApp.Namespace), client.MatchingLabels{korifiv1alpha1.CFAppGUIDLabelKey: cfApp.Name})
+	if err != nil {
+		log.Info("failed to list app processes", "reason", err)
+		return err
+	}
+
+	for i := range processList.Items {
+		cfProcess := &processList.Items[i]
+		if cfProcess.Status.Usage == nil || cfProcess.Status.Usage.State != korifiv1alpha1.StartedState {
+			continue
+		}
+
+		stoppedUsage := korifiv1alpha1.ProcessUsage{
+			State:     korifiv1alpha1.StoppedState,
+			Instances: cfProcess.Status.Usage.Instances,
+			MemoryMB:  cfProcess.Status.Usage.MemoryMB,
+		}
+		err = r.usageRecorder.RecordAppUsage(ctx, cfApp, cfProcess, *cfProcess.Status.Usage, stoppedUsage)
+		if err != nil {
+			log.Info("failed to record app usage", "processName", cfProcess.Name, "reason", err)
+			return err
+		}
+
+		err = k8s.Patch(ctx, r.k8sClient, cfProcess, func() {
+			cfProcess.Status.Usage = &stoppedUsage
+		})
+		if err != nil {
+			log.Info("failed to patch process usage", "processName", cfProcess.Name, "reason", err)
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (r *Reconciler) finalizeCFAppRoutes(ctx context.Context, cfApp *korifiv1alpha1.CFApp) error {
 	cfRoutes, err := r.getCFRoutes(ctx, cfApp.Name, cfApp.Namespace)
 	if err != nil {
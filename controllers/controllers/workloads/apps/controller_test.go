@@ -306,6 +306,31 @@ var _ = Describe("CFAppReconciler Integration Tests", func() {
 		})
 	})
 
+	When("the app process is scaled to zero", func() {
+		BeforeEach(func() {
+			Eventually(func(g Gomega) {
+				cfProcessList := &korifiv1alpha1.CFProcessList{}
+				g.Expect(adminClient.List(ctx, cfProcessList, &client.ListOptions{
+					Namespace: cfApp.Namespace,
+				})).To(Succeed())
+				g.Expect(cfProcessList.Items).To(HaveLen(1))
+
+				process := &cfProcessList.Items[0]
+				g.Expect(k8s.Patch(ctx, adminClient, process, func() {
+					process.Status.ActualInstances = 0
+					process.Status.ScaledToZero = true
+				})).To(Succeed())
+			}).Should(Succeed())
+		})
+
+		It("sets the actual state to started", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfApp), cfApp)).To(Succeed())
+				g.Expect(cfApp.Status.ActualState).To(Equal(korifiv1alpha1.StartedState))
+			}).Should(Succeed())
+		})
+	})
+
 	When("the cfapp droplet ref is not set", func() {
 		BeforeEach(func() {
 			Expect(k8s.Patch(ctx, adminClient, cfApp, func() {
@@ -435,6 +460,33 @@ var _ = Describe("CFAppReconciler Integration Tests", func() {
 				}).Should(Succeed())
 			})
 
+			When("the binding has volume mounts", func() {
+				BeforeEach(func() {
+					Expect(k8s.Patch(ctx, adminClient, binding, func() {
+						binding.Status.VolumeMounts = []korifiv1alpha1.ServiceVolumeMount{{
+							Driver:       "nfs",
+							ContainerDir: "/data",
+							Mode:         korifiv1alpha1.VolumeMountModeReadWrite,
+							VolumeID:     "volume-id",
+						}}
+					})).To(Succeed())
+				})
+
+				It("sets the volume mounts on the app service binding", func() {
+					Eventually(func(g Gomega) {
+						g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfApp), cfApp)).To(Succeed())
+						g.Expect(cfApp.Status.ServiceBindings).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+							"VolumeMounts": ConsistOf(korifiv1alpha1.ServiceVolumeMount{
+								Driver:       "nfs",
+								ContainerDir: "/data",
+								Mode:         korifiv1alpha1.VolumeMountModeReadWrite,
+								VolumeID:     "volume-id",
+							}),
+						})))
+					}).Should(Succeed())
+				})
+			})
+
 			When("the binding has a display name", func() {
 				BeforeEach(func() {
 					Expect(k8s.PatchResource(ctx, adminClient, binding, func() {
@@ -459,6 +511,7 @@ var _ = Describe("CFAppReconciler Integration Tests", func() {
 		var (
 			cfDomainGUID string
 			cfRoute      *korifiv1alpha1.CFRoute
+			cfProcess    *korifiv1alpha1.CFProcess
 		)
 
 		BeforeEach(func() {
@@ -515,6 +568,28 @@ var _ = Describe("CFAppReconciler Integration Tests", func() {
 			}
 			Expect(adminClient.Create(ctx, &cfServiceBinding)).To(Succeed())
 
+			cfProcess = &korifiv1alpha1.CFProcess{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      uuid.NewString(),
+					Namespace: testNamespace,
+					Labels: map[string]string{
+						korifiv1alpha1.CFAppGUIDLabelKey: cfApp.Name,
+					},
+				},
+				Spec: korifiv1alpha1.CFProcessSpec{
+					AppRef:      corev1.LocalObjectReference{Name: cfApp.Name},
+					ProcessType: "web",
+				},
+			}
+			Expect(adminClient.Create(ctx, cfProcess)).To(Succeed())
+			Expect(k8s.Patch(ctx, adminClient, cfProcess, func() {
+				cfProcess.Status.Usage = &korifiv1alpha1.ProcessUsage{
+					State:     korifiv1alpha1.StartedState,
+					Instances: 2,
+					MemoryMB:  256,
+				}
+			})).To(Succeed())
+
 			Expect(adminClient.Delete(ctx, cfApp)).To(Succeed())
 			Eventually(func(g Gomega) {
 				err := adminClient.Get(ctx, client.ObjectKeyFromObject(cfApp), cfApp)
@@ -529,6 +604,38 @@ var _ = Describe("CFAppReconciler Integration Tests", func() {
 			}).Should(Succeed())
 		})
 
+		It("records app usage stop events for the started processes", func() {
+			Eventually(func(g Gomega) {
+				eventsList := &korifiv1alpha1.CFAppUsageEventList{}
+				g.Expect(adminClient.List(ctx, eventsList, client.InNamespace(rootNamespace))).To(Succeed())
+				g.Expect(eventsList.Items).To(ContainElement(MatchFields(IgnoreExtras, Fields{
+					"Spec": MatchFields(IgnoreExtras, Fields{
+						"State":                 Equal(korifiv1alpha1.StoppedState),
+						"PreviousState":         Equal(korifiv1alpha1.StartedState),
+						"AppGUID":               Equal(cfApp.Name),
+						"ProcessGUID":           Equal(cfProcess.Name),
+						"InstanceCount":         BeEquivalentTo(2),
+						"MemoryInMBPerInstance": BeEquivalentTo(256),
+					}),
+				})))
+			}).Should(Succeed())
+		})
+
+		It("deletes the app package and droplet repositories", func() {
+			Eventually(func(g Gomega) {
+				deletedRepositories := []string{}
+				for i := range repositoryDeleter.DeleteRepositoryCallCount() {
+					_, repository := repositoryDeleter.DeleteRepositoryArgsForCall(i)
+					deletedRepositories = append(deletedRepositories, repository)
+				}
+
+				g.Expect(deletedRepositories).To(ContainElements(
+					"my.registry/korifi/"+cfApp.Name+"-packages",
+					"my.registry/korifi/"+cfApp.Name+"-droplets",
+				))
+			}).Should(Succeed())
+		})
+
 		It("deletes the referencing service bindings", func() {
 			Eventually(func(g Gomega) {
 				sbList := korifiv1alpha1.CFServiceBindingList{}
@@ -8,7 +8,9 @@ import (
 
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	"code.cloudfoundry.org/korifi/controllers/controllers/shared"
+	"code.cloudfoundry.org/korifi/controllers/controllers/usage"
 	"code.cloudfoundry.org/korifi/controllers/controllers/workloads/apps"
+	"code.cloudfoundry.org/korifi/controllers/controllers/workloads/apps/fake"
 	"code.cloudfoundry.org/korifi/controllers/controllers/workloads/env"
 	"code.cloudfoundry.org/korifi/tests/helpers"
 	"code.cloudfoundry.org/korifi/tools/k8s"
@@ -28,12 +30,14 @@ import (
 )
 
 var (
-	ctx             context.Context
-	stopManager     context.CancelFunc
-	stopClientCache context.CancelFunc
-	testEnv         *envtest.Environment
-	adminClient     client.Client
-	testNamespace   string
+	ctx               context.Context
+	stopManager       context.CancelFunc
+	stopClientCache   context.CancelFunc
+	testEnv           *envtest.Environment
+	adminClient       client.Client
+	testNamespace     string
+	rootNamespace     string
+	repositoryDeleter *fake.RepositoryDeleter
 )
 
 func TestWorkloadsControllers(t *testing.T) {
@@ -67,12 +71,23 @@ var _ = BeforeSuite(func() {
 
 	adminClient, stopClientCache = helpers.NewCachedClient(testEnv.Config)
 
+	rootNamespace = uuid.NewString()
+	Expect(adminClient.Create(ctx, &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: rootNamespace,
+		},
+	})).To(Succeed())
+
+	repositoryDeleter = new(fake.RepositoryDeleter)
 	err = apps.NewReconciler(
 		k8sManager.GetClient(),
 		k8sManager.GetScheme(),
 		ctrl.Log.WithName("controllers").WithName("CFApp"),
 		env.NewVCAPServicesEnvValueBuilder(k8sManager.GetClient()),
 		env.NewVCAPApplicationEnvValueBuilder(k8sManager.GetClient(), nil),
+		usage.NewRecorder(k8sManager.GetClient(), k8sManager.GetAPIReader(), rootNamespace),
+		repositoryDeleter,
+		"my.registry/korifi/",
 	).SetupWithManager(k8sManager)
 	Expect(err).NotTo(HaveOccurred())
 
@@ -23,6 +23,7 @@ import (
 
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	"code.cloudfoundry.org/korifi/controllers/config"
+	"code.cloudfoundry.org/korifi/controllers/controllers/shared"
 	"code.cloudfoundry.org/korifi/controllers/controllers/workloads/build"
 	"code.cloudfoundry.org/korifi/tools/k8s"
 
@@ -250,6 +251,14 @@ func (r *buildpackBuildReconciler) createBuildWorkload(ctx context.Context, cfBu
 	}
 	desiredWorkload.Spec.Env = imageEnvironment
 
+	placement, err := shared.GetWorkloadPlacement(ctx, r.k8sClient, namespace)
+	if err != nil {
+		log.Info("failed to get the workload placement", "reason", err)
+		return err
+	}
+	desiredWorkload.Spec.NodeSelector = placement.NodeSelector
+	desiredWorkload.Spec.Tolerations = placement.Tolerations
+
 	err = controllerutil.SetControllerReference(cfBuild, &desiredWorkload, r.scheme)
 	if err != nil {
 		log.Info("failed to set OwnerRef on BuildWorkload", "reason", err)
@@ -78,7 +78,7 @@ var _ = BeforeSuite(func() {
 		k8sManager.GetScheme(),
 		ctrl.Log.WithName("controllers").WithName("CFBuildpackBuild"),
 		controllerConfig,
-		env.NewAppEnvBuilder(k8sManager.GetClient()),
+		env.NewAppEnvBuilder(k8sManager.GetClient(), "cf", korifiv1alpha1.StagingEnvironmentVariableGroupName),
 	)
 	err = (cfBuildpackBuildReconciler).SetupWithManager(k8sManager)
 	Expect(err).NotTo(HaveOccurred())
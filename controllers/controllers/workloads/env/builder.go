@@ -12,6 +12,7 @@ import (
 	"code.cloudfoundry.org/korifi/controllers/controllers/workloads/ports"
 
 	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -28,18 +29,43 @@ type ServiceDetails struct {
 	BindingName    *string        `json:"binding_name"`
 	Credentials    map[string]any `json:"credentials"`
 	SyslogDrainURL *string        `json:"syslog_drain_url"`
-	VolumeMounts   []string       `json:"volume_mounts"`
+	VolumeMounts   []VolumeMount  `json:"volume_mounts"`
+}
+
+type VolumeMount struct {
+	ContainerDir string `json:"container_dir"`
+	Mode         string `json:"mode"`
+	DeviceType   string `json:"device_type"`
 }
 
 type AppEnvBuilder struct {
-	k8sClient client.Client
+	k8sClient       client.Client
+	rootNamespace   string
+	envVarGroupName string
 }
 
-func NewAppEnvBuilder(k8sClient client.Client) *AppEnvBuilder {
-	return &AppEnvBuilder{k8sClient: k8sClient}
+// NewAppEnvBuilder returns a builder that merges the environment variable
+// group with the given name (either running or staging) into the app env
+func NewAppEnvBuilder(k8sClient client.Client, rootNamespace string, envVarGroupName string) *AppEnvBuilder {
+	return &AppEnvBuilder{
+		k8sClient:       k8sClient,
+		rootNamespace:   rootNamespace,
+		envVarGroupName: envVarGroupName,
+	}
 }
 
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfenvironmentvariablegroups,verbs=get;list;watch
+
 func (b *AppEnvBuilder) Build(ctx context.Context, cfApp *korifiv1alpha1.CFApp) ([]corev1.EnvVar, error) {
+	groupEnvVars, err := b.GetEnvVarGroup(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return b.buildWithEnvVarGroup(ctx, cfApp, groupEnvVars)
+}
+
+func (b *AppEnvBuilder) buildWithEnvVarGroup(ctx context.Context, cfApp *korifiv1alpha1.CFApp, groupEnvVars map[string]string) ([]corev1.EnvVar, error) {
 	var appEnvSecret, vcapServicesSecret, vcapApplicationSecret corev1.Secret
 
 	if cfApp.Spec.EnvSecretName != "" {
@@ -64,7 +90,31 @@ func (b *AppEnvBuilder) Build(ctx context.Context, cfApp *korifiv1alpha1.CFApp)
 	}
 
 	// We explicitly order the vcapServicesSecret last so that its "VCAP_*" contents win
-	return sortEnvVars(envVarsFromSecrets(appEnvSecret, vcapServicesSecret, vcapApplicationSecret)), nil
+	envVars := envVarsFromSecrets(appEnvSecret, vcapServicesSecret, vcapApplicationSecret)
+
+	// App level env vars take precedence over the ones in the group
+	for name, value := range groupEnvVars {
+		if slices.ContainsFunc(envVars, func(envVar corev1.EnvVar) bool { return envVar.Name == name }) {
+			continue
+		}
+		envVars = append(envVars, corev1.EnvVar{Name: name, Value: value})
+	}
+
+	return sortEnvVars(envVars), nil
+}
+
+// GetEnvVarGroup returns the current environment variables of the group
+func (b *AppEnvBuilder) GetEnvVarGroup(ctx context.Context) (map[string]string, error) {
+	envVarGroup := &korifiv1alpha1.CFEnvironmentVariableGroup{}
+	err := b.k8sClient.Get(ctx, types.NamespacedName{Namespace: b.rootNamespace, Name: b.envVarGroupName}, envVarGroup)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error when trying to fetch the %s environment variable group: %w", b.envVarGroupName, err)
+	}
+
+	return envVarGroup.Spec.EnvironmentVariables, nil
 }
 
 func sortEnvVars(envVars []corev1.EnvVar) []corev1.EnvVar {
@@ -98,15 +148,21 @@ type ProcessEnvBuilder struct {
 	k8sClient     client.Client
 }
 
-func NewProcessEnvBuilder(k8sClient client.Client) *ProcessEnvBuilder {
+func NewProcessEnvBuilder(k8sClient client.Client, rootNamespace string) *ProcessEnvBuilder {
 	return &ProcessEnvBuilder{
-		appEnvBuilder: NewAppEnvBuilder(k8sClient),
+		appEnvBuilder: NewAppEnvBuilder(k8sClient, rootNamespace, korifiv1alpha1.RunningEnvironmentVariableGroupName),
 		k8sClient:     k8sClient,
 	}
 }
 
-func (b *ProcessEnvBuilder) Build(ctx context.Context, cfApp *korifiv1alpha1.CFApp, cfProcess *korifiv1alpha1.CFProcess) ([]corev1.EnvVar, error) {
-	env, err := b.appEnvBuilder.Build(ctx, cfApp)
+func (b *ProcessEnvBuilder) GetEnvVarGroup(ctx context.Context) (map[string]string, error) {
+	return b.appEnvBuilder.GetEnvVarGroup(ctx)
+}
+
+// Build merges the given running environment variable group rather than the
+// current one, so that callers decide when group changes reach app instances
+func (b *ProcessEnvBuilder) Build(ctx context.Context, cfApp *korifiv1alpha1.CFApp, cfProcess *korifiv1alpha1.CFProcess, envVarGroup map[string]string) ([]corev1.EnvVar, error) {
+	env, err := b.appEnvBuilder.buildWithEnvVarGroup(ctx, cfApp, envVarGroup)
 	if err != nil {
 		return nil, err
 	}
@@ -101,7 +101,7 @@ var _ = Describe("EnvBuilder", func() {
 		var builder *env.AppEnvBuilder
 
 		BeforeEach(func() {
-			builder = env.NewAppEnvBuilder(controllersClient)
+			builder = env.NewAppEnvBuilder(controllersClient, rootNamespace, korifiv1alpha1.RunningEnvironmentVariableGroupName)
 		})
 
 		JustBeforeEach(func() {
@@ -242,6 +242,53 @@ var _ = Describe("EnvBuilder", func() {
 			})
 		})
 
+		When("the environment variable group exists", func() {
+			BeforeEach(func() {
+				helpers.EnsureCreate(controllersClient, &korifiv1alpha1.CFEnvironmentVariableGroup{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: rootNamespace,
+						Name:      korifiv1alpha1.RunningEnvironmentVariableGroupName,
+					},
+					Spec: korifiv1alpha1.CFEnvironmentVariableGroupSpec{
+						EnvironmentVariables: map[string]string{
+							"HTTP_PROXY": "http://proxy.example.com",
+							"app-secret": "group-value",
+						},
+					},
+				})
+			})
+
+			It("merges the group env vars giving precedence to the app env", func() {
+				Expect(buildErr).NotTo(HaveOccurred())
+				Expect(envVars).To(ConsistOf(
+					appSecretEnv,
+					vcapServicesEnv,
+					vcapApplicationEnv,
+					Equal(corev1.EnvVar{Name: "HTTP_PROXY", Value: "http://proxy.example.com"}),
+				))
+			})
+		})
+
+		When("the builder uses a different environment variable group", func() {
+			BeforeEach(func() {
+				helpers.EnsureCreate(controllersClient, &korifiv1alpha1.CFEnvironmentVariableGroup{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: rootNamespace,
+						Name:      korifiv1alpha1.RunningEnvironmentVariableGroupName,
+					},
+					Spec: korifiv1alpha1.CFEnvironmentVariableGroupSpec{
+						EnvironmentVariables: map[string]string{"HTTP_PROXY": "http://proxy.example.com"},
+					},
+				})
+				builder = env.NewAppEnvBuilder(controllersClient, rootNamespace, korifiv1alpha1.StagingEnvironmentVariableGroupName)
+			})
+
+			It("does not merge the other group", func() {
+				Expect(buildErr).NotTo(HaveOccurred())
+				Expect(envVars).NotTo(ContainElement(MatchFields(IgnoreExtras, Fields{"Name": Equal("HTTP_PROXY")})))
+			})
+		})
+
 		When("the app does not have an associated app vcap application secret", func() {
 			BeforeEach(func() {
 				helpers.EnsurePatch(controllersClient, cfApp, func(a *korifiv1alpha1.CFApp) {
@@ -261,8 +308,9 @@ var _ = Describe("EnvBuilder", func() {
 
 	Describe("ProcessEnvBuilder", func() {
 		var (
-			builder   *env.ProcessEnvBuilder
-			cfProcess *korifiv1alpha1.CFProcess
+			builder     *env.ProcessEnvBuilder
+			cfProcess   *korifiv1alpha1.CFProcess
+			envVarGroup map[string]string
 		)
 
 		BeforeEach(func() {
@@ -277,11 +325,12 @@ var _ = Describe("EnvBuilder", func() {
 				},
 			}
 			helpers.EnsureCreate(controllersClient, cfProcess)
-			builder = env.NewProcessEnvBuilder(controllersClient)
+			builder = env.NewProcessEnvBuilder(controllersClient, rootNamespace)
+			envVarGroup = nil
 		})
 
 		JustBeforeEach(func() {
-			envVars, buildErr = builder.Build(context.Background(), cfApp, cfProcess)
+			envVars, buildErr = builder.Build(context.Background(), cfApp, cfProcess, envVarGroup)
 		})
 
 		It("returns the process env vars", func() {
@@ -311,6 +360,30 @@ var _ = Describe("EnvBuilder", func() {
 			Expect(slices.IsSorted(envVarNames)).To(BeTrue())
 		})
 
+		When("the running environment variable group is given", func() {
+			BeforeEach(func() {
+				helpers.EnsureCreate(controllersClient, &korifiv1alpha1.CFEnvironmentVariableGroup{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: rootNamespace,
+						Name:      korifiv1alpha1.RunningEnvironmentVariableGroupName,
+					},
+					Spec: korifiv1alpha1.CFEnvironmentVariableGroupSpec{
+						EnvironmentVariables: map[string]string{"HTTP_PROXY": "http://current-proxy.example.com"},
+					},
+				})
+				envVarGroup = map[string]string{"HTTP_PROXY": "http://proxy.example.com"}
+			})
+
+			It("merges the given group rather than the current one", func() {
+				Expect(buildErr).NotTo(HaveOccurred())
+				Expect(envVars).To(ContainElement(corev1.EnvVar{Name: "HTTP_PROXY", Value: "http://proxy.example.com"}))
+			})
+
+			It("returns the current group", func() {
+				Expect(builder.GetEnvVarGroup(context.Background())).To(Equal(map[string]string{"HTTP_PROXY": "http://current-proxy.example.com"}))
+			})
+		})
+
 		Describe("ports env vars", func() {
 			var cfRoute *korifiv1alpha1.CFRoute
 
@@ -138,6 +138,19 @@ func fromServiceBinding(
 		BindingName:    bindingName,
 		Credentials:    creds,
 		SyslogDrainURL: nil,
-		VolumeMounts:   []string{},
+		VolumeMounts:   toVCAPVolumeMounts(serviceBinding.Status.VolumeMounts),
 	}, nil
 }
+
+func toVCAPVolumeMounts(volumeMounts []korifiv1alpha1.ServiceVolumeMount) []VolumeMount {
+	result := []VolumeMount{}
+	for _, volumeMount := range volumeMounts {
+		result = append(result, VolumeMount{
+			ContainerDir: volumeMount.ContainerDir,
+			Mode:         string(volumeMount.Mode),
+			DeviceType:   "shared",
+		})
+	}
+
+	return result
+}
@@ -186,6 +186,31 @@ var _ = Describe("Builder", func() {
 			}))
 		})
 
+		When("the service binding has volume mounts", func() {
+			BeforeEach(func() {
+				helpers.EnsurePatch(controllersClient, serviceBinding, func(s *korifiv1alpha1.CFServiceBinding) {
+					s.Status.VolumeMounts = []korifiv1alpha1.ServiceVolumeMount{{
+						Driver:       "nfs",
+						ContainerDir: "/data",
+						Mode:         korifiv1alpha1.VolumeMountModeReadOnly,
+						VolumeID:     "volume-id",
+					}}
+				})
+			})
+
+			It("presents the volume mounts", func() {
+				Expect(parseVcapServices(vcapServices)).To(MatchKeys(IgnoreExtras, Keys{
+					"sb-1-type": ConsistOf(MatchKeys(IgnoreExtras, Keys{
+						"volume_mounts": ConsistOf(MatchAllKeys(Keys{
+							"container_dir": Equal("/data"),
+							"mode":          Equal("r"),
+							"device_type":   Equal("shared"),
+						})),
+					})),
+				}))
+			})
+		})
+
 		When("the service binding has no name", func() {
 			BeforeEach(func() {
 				helpers.EnsurePatch(controllersClient, serviceBinding, func(s *korifiv1alpha1.CFServiceBinding) {
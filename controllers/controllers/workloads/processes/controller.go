@@ -19,8 +19,10 @@ package processes
 import (
 	"context"
 	"crypto/sha1"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"strconv"
 
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	"code.cloudfoundry.org/korifi/controllers/config"
@@ -32,6 +34,7 @@ import (
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
 
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -46,7 +49,12 @@ import (
 )
 
 type ProcessEnvBuilder interface {
-	Build(context.Context, *korifiv1alpha1.CFApp, *korifiv1alpha1.CFProcess) ([]corev1.EnvVar, error)
+	GetEnvVarGroup(context.Context) (map[string]string, error)
+	Build(context.Context, *korifiv1alpha1.CFApp, *korifiv1alpha1.CFProcess, map[string]string) ([]corev1.EnvVar, error)
+}
+
+type AppUsageRecorder interface {
+	RecordAppUsage(context.Context, *korifiv1alpha1.CFApp, *korifiv1alpha1.CFProcess, korifiv1alpha1.ProcessUsage, korifiv1alpha1.ProcessUsage) error
 }
 
 type Reconciler struct {
@@ -55,6 +63,7 @@ type Reconciler struct {
 	log              logr.Logger
 	controllerConfig *config.ControllerConfig
 	envBuilder       ProcessEnvBuilder
+	usageRecorder    AppUsageRecorder
 }
 
 func NewReconciler(
@@ -63,13 +72,14 @@ func NewReconciler(
 	log logr.Logger,
 	controllerConfig *config.ControllerConfig,
 	envBuilder ProcessEnvBuilder,
+	usageRecorder AppUsageRecorder,
 ) *k8s.PatchingReconciler[korifiv1alpha1.CFProcess, *korifiv1alpha1.CFProcess] {
-	processReconciler := Reconciler{k8sClient: client, scheme: scheme, log: log, controllerConfig: controllerConfig, envBuilder: envBuilder}
+	processReconciler := Reconciler{k8sClient: client, scheme: scheme, log: log, controllerConfig: controllerConfig, envBuilder: envBuilder, usageRecorder: usageRecorder}
 	return k8s.NewPatchingReconciler[korifiv1alpha1.CFProcess, *korifiv1alpha1.CFProcess](log, client, &processReconciler)
 }
 
 func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) *builder.Builder {
-	return ctrl.NewControllerManagedBy(mgr).
+	b := ctrl.NewControllerManagedBy(mgr).
 		For(&korifiv1alpha1.CFProcess{}).
 		Owns(&korifiv1alpha1.AppWorkload{}).
 		Watches(
@@ -79,7 +89,58 @@ func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) *builder.Builder {
 		Watches(
 			&korifiv1alpha1.CFRoute{},
 			handler.EnqueueRequestsFromMapFunc(r.enqueueCFProcessRequestsForRoute),
+		).
+		Watches(
+			&korifiv1alpha1.CFSpace{},
+			handler.EnqueueRequestsFromMapFunc(r.enqueueCFProcessRequestsForSpace),
+		)
+
+	// As in CF, changes to the running environment variable group only
+	// apply to app instances when they are restarted, unless the operator
+	// opted into rolling them out to all apps straight away
+	if r.controllerConfig.RollOutRunningEnvVarGroup {
+		b = b.Watches(
+			&korifiv1alpha1.CFEnvironmentVariableGroup{},
+			handler.EnqueueRequestsFromMapFunc(r.enqueueCFProcessRequestsForEnvVarGroup),
 		)
+	}
+
+	return b
+}
+
+// enqueueCFProcessRequestsForEnvVarGroup rolls changes to the running
+// environment variable group out to the workloads of all apps
+func (r *Reconciler) enqueueCFProcessRequestsForEnvVarGroup(ctx context.Context, o client.Object) []reconcile.Request {
+	if o.GetNamespace() != r.controllerConfig.CFRootNamespace || o.GetName() != korifiv1alpha1.RunningEnvironmentVariableGroupName {
+		return []reconcile.Request{}
+	}
+
+	cfProcessList := &korifiv1alpha1.CFProcessList{}
+	if err := r.k8sClient.List(ctx, cfProcessList); err != nil {
+		r.log.Error(fmt.Errorf("listing CFProcesses for environment variable group failed: %w", err), "group", o.GetName())
+		return []reconcile.Request{}
+	}
+
+	requests := make([]reconcile.Request, len(cfProcessList.Items))
+	for i := range cfProcessList.Items {
+		requests[i] = reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&cfProcessList.Items[i])}
+	}
+
+	return requests
+}
+
+func (r *Reconciler) enqueueCFProcessRequestsForSpace(ctx context.Context, o client.Object) []reconcile.Request {
+	cfProcessList := &korifiv1alpha1.CFProcessList{}
+	if err := r.k8sClient.List(ctx, cfProcessList, client.InNamespace(o.GetName())); err != nil {
+		return []reconcile.Request{}
+	}
+
+	requests := make([]reconcile.Request, len(cfProcessList.Items))
+	for i := range cfProcessList.Items {
+		requests[i] = reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&cfProcessList.Items[i])}
+	}
+
+	return requests
 }
 
 func (r *Reconciler) enqueueCFProcessRequestsForApp(ctx context.Context, o client.Object) []reconcile.Request {
@@ -147,6 +208,12 @@ func (r *Reconciler) ReconcileResource(ctx context.Context, cfProcess *korifiv1a
 		return ctrl.Result{}, err
 	}
 
+	err = r.recordUsage(ctx, cfApp, cfProcess)
+	if err != nil {
+		log.Info("error when trying to record app usage", "reason", err)
+		return ctrl.Result{}, err
+	}
+
 	if needsAppWorkload(cfApp, cfProcess) {
 		err = r.createOrPatchAppWorkload(ctx, cfApp, cfProcess)
 		if err != nil {
@@ -164,12 +231,43 @@ func (r *Reconciler) ReconcileResource(ctx context.Context, cfProcess *korifiv1a
 		return ctrl.Result{}, err
 	}
 
+	desiredAppWorkload := getDesiredAppWorkload(getDesiredAppWorkloadName(cfApp, cfProcess), appWorkloads)
 	cfProcess.Status.ActualInstances = getActualInstances(appWorkloads)
-	cfProcess.Status.InstancesStatus = getCurrentInstancesStatus(getDesiredAppWorkloadName(cfApp, cfProcess), appWorkloads)
+	cfProcess.Status.InstancesStatus = getCurrentInstancesStatus(desiredAppWorkload)
+	cfProcess.Status.ScaledToZero = desiredAppWorkload != nil && desiredAppWorkload.Status.ScaledToZero
 
 	return ctrl.Result{}, nil
 }
 
+// recordUsage records an app usage event whenever the process is started,
+// stopped or scaled while started
+func (r *Reconciler) recordUsage(ctx context.Context, cfApp *korifiv1alpha1.CFApp, cfProcess *korifiv1alpha1.CFProcess) error {
+	currentUsage := korifiv1alpha1.ProcessUsage{
+		State:     cfApp.Spec.DesiredState,
+		Instances: tools.ZeroIfNil(cfProcess.Spec.DesiredInstances),
+		MemoryMB:  cfProcess.Spec.MemoryMB,
+	}
+
+	previousUsage := korifiv1alpha1.ProcessUsage{State: korifiv1alpha1.StoppedState}
+	if cfProcess.Status.Usage != nil {
+		previousUsage = *cfProcess.Status.Usage
+	}
+
+	if currentUsage == previousUsage {
+		return nil
+	}
+
+	if currentUsage.State != korifiv1alpha1.StoppedState || previousUsage.State != korifiv1alpha1.StoppedState {
+		err := r.usageRecorder.RecordAppUsage(ctx, cfApp, cfProcess, previousUsage, currentUsage)
+		if err != nil {
+			return err
+		}
+	}
+
+	cfProcess.Status.Usage = &currentUsage
+	return nil
+}
+
 func getRevision(app *korifiv1alpha1.CFApp) string {
 	return tools.GetMapValue(app.Annotations, korifiv1alpha1.CFAppRevisionKey, korifiv1alpha1.CFAppDefaultRevision)
 }
@@ -186,16 +284,37 @@ func getActualInstances(appWorkloads []korifiv1alpha1.AppWorkload) int32 {
 	return actualInstances
 }
 
-func getCurrentInstancesStatus(desiredAppWorkloadName string, appWorkloads []korifiv1alpha1.AppWorkload) map[string]korifiv1alpha1.InstanceStatus {
+func getDesiredAppWorkload(desiredAppWorkloadName string, appWorkloads []korifiv1alpha1.AppWorkload) *korifiv1alpha1.AppWorkload {
 	for _, workload := range appWorkloads {
 		if workload.Name == desiredAppWorkloadName {
-			return workload.Status.InstancesStatus
+			return &workload
 		}
 	}
 
 	return nil
 }
 
+func getCurrentInstancesStatus(appWorkload *korifiv1alpha1.AppWorkload) map[string]korifiv1alpha1.InstanceStatus {
+	if appWorkload == nil {
+		return nil
+	}
+
+	if !appWorkload.Status.ScaledToZero {
+		return appWorkload.Status.InstancesStatus
+	}
+
+	// instances of a workload scaled to zero are down rather than missing,
+	// as they start again on the next request
+	instancesStatus := map[string]korifiv1alpha1.InstanceStatus{}
+	for i := range appWorkload.Spec.Instances {
+		instancesStatus[strconv.Itoa(int(i))] = korifiv1alpha1.InstanceStatus{
+			State: korifiv1alpha1.InstanceStateDown,
+		}
+	}
+
+	return instancesStatus
+}
+
 func needsAppWorkload(cfApp *korifiv1alpha1.CFApp, cfProcess *korifiv1alpha1.CFProcess) bool {
 	if cfApp.Spec.DesiredState != korifiv1alpha1.StartedState {
 		return false
@@ -231,16 +350,35 @@ func (r *Reconciler) createOrPatchAppWorkload(ctx context.Context, cfApp *korifi
 
 	appPorts := ports.FromRoutes(cfRoutesForProcess.Items, cfApp.Name, cfProcess.Spec.ProcessType)
 
-	envVars, err := r.envBuilder.Build(ctx, cfApp, cfProcess)
+	appWorkloadKey := types.NamespacedName{Namespace: cfProcess.Namespace, Name: getDesiredAppWorkloadName(cfApp, cfProcess)}
+
+	envVarGroup, err := r.getRunningEnvVarGroup(ctx, appWorkloadKey)
+	if err != nil {
+		log.Info("error when trying to get the running environment variable group", "reason", err)
+		return err
+	}
+
+	envVarGroupJSON, err := json.Marshal(envVarGroup)
+	if err != nil {
+		return err
+	}
+
+	envVars, err := r.envBuilder.Build(ctx, cfApp, cfProcess, envVarGroup)
 	if err != nil {
 		log.Info("error when trying build the process environment for app", "namespace", cfProcess.Namespace, "name", cfApp.Spec.DisplayName, "reason", err)
 		return err
 	}
 
+	placement, err := shared.GetWorkloadPlacement(ctx, r.k8sClient, cfProcess.Namespace)
+	if err != nil {
+		log.Info("error when trying to get the workload placement", "namespace", cfProcess.Namespace, "reason", err)
+		return err
+	}
+
 	appWorkload := &korifiv1alpha1.AppWorkload{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      getDesiredAppWorkloadName(cfApp, cfProcess),
-			Namespace: cfProcess.Namespace,
+			Name:      appWorkloadKey.Name,
+			Namespace: appWorkloadKey.Namespace,
 		},
 	}
 
@@ -257,11 +395,12 @@ func (r *Reconciler) createOrPatchAppWorkload(ctx context.Context, cfApp *korifi
 
 		appWorkload.Annotations = make(map[string]string)
 		appWorkload.Annotations[korifiv1alpha1.CFAppLastStopRevisionKey] = getLastStopRevision(cfApp)
+		appWorkload.Annotations[korifiv1alpha1.RunningEnvironmentVariableGroupAnnotationKey] = string(envVarGroupJSON)
 
 		appWorkload.Spec.GUID = cfProcess.Name
 		appWorkload.Spec.Version = getRevision(cfApp)
 		appWorkload.Spec.Resources.Requests = corev1.ResourceList{
-			corev1.ResourceCPU:              calculateCPURequest(cfProcess.Spec.MemoryMB),
+			corev1.ResourceCPU:              r.calculateCPURequest(cfProcess),
 			corev1.ResourceEphemeralStorage: mebibyteQuantity(cfProcess.Spec.DiskQuotaMB),
 			corev1.ResourceMemory:           mebibyteQuantity(cfProcess.Spec.MemoryMB),
 		}
@@ -269,6 +408,9 @@ func (r *Reconciler) createOrPatchAppWorkload(ctx context.Context, cfApp *korifi
 			corev1.ResourceEphemeralStorage: mebibyteQuantity(cfProcess.Spec.DiskQuotaMB),
 			corev1.ResourceMemory:           mebibyteQuantity(cfProcess.Spec.MemoryMB),
 		}
+		if cfProcess.Spec.CPUMillicores > 0 {
+			appWorkload.Spec.Resources.Limits[corev1.ResourceCPU] = *resource.NewScaledQuantity(cfProcess.Spec.CPUMillicores, resource.Milli)
+		}
 		appWorkload.Spec.ProcessType = cfProcess.Spec.ProcessType
 		appWorkload.Spec.Command = commandForProcess(cfProcess, cfApp)
 		appWorkload.Spec.AppGUID = cfApp.Name
@@ -277,12 +419,15 @@ func (r *Reconciler) createOrPatchAppWorkload(ctx context.Context, cfApp *korifi
 
 		appWorkload.Spec.Ports = appPorts
 		appWorkload.Spec.Instances = tools.ZeroIfNil(cfProcess.Spec.DesiredInstances)
+		appWorkload.Spec.Autoscaling = cfProcess.Spec.Autoscaling
 
 		appWorkload.Spec.Env = envVars
 
 		appWorkload.Spec.StartupProbe = startupProbe(cfProcess, appPorts)
 		appWorkload.Spec.LivenessProbe = livenessProbe(cfProcess, appPorts)
 		appWorkload.Spec.RunnerName = r.controllerConfig.RunnerName
+		appWorkload.Spec.NodeSelector = placement.NodeSelector
+		appWorkload.Spec.Tolerations = placement.Tolerations
 
 		return controllerutil.SetControllerReference(cfProcess, appWorkload, r.scheme)
 	})
@@ -293,6 +438,37 @@ func (r *Reconciler) createOrPatchAppWorkload(ctx context.Context, cfApp *korifi
 	return nil
 }
 
+// getRunningEnvVarGroup returns the running environment variable group the
+// app workload has been created with. As restarting an app creates a new app
+// workload, changes to the group only reach app instances on restart, as in
+// CF, unless the operator opted into rolling them out straight away.
+func (r *Reconciler) getRunningEnvVarGroup(ctx context.Context, appWorkloadKey types.NamespacedName) (map[string]string, error) {
+	if r.controllerConfig.RollOutRunningEnvVarGroup {
+		return r.envBuilder.GetEnvVarGroup(ctx)
+	}
+
+	appWorkload := new(korifiv1alpha1.AppWorkload)
+	err := r.k8sClient.Get(ctx, appWorkloadKey, appWorkload)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return r.envBuilder.GetEnvVarGroup(ctx)
+		}
+		return nil, err
+	}
+
+	envVarGroupJSON, ok := appWorkload.Annotations[korifiv1alpha1.RunningEnvironmentVariableGroupAnnotationKey]
+	if !ok {
+		return r.envBuilder.GetEnvVarGroup(ctx)
+	}
+
+	envVarGroup := map[string]string{}
+	if err = json.Unmarshal([]byte(envVarGroupJSON), &envVarGroup); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal the running environment variable group of app workload %s: %w", appWorkloadKey.Name, err)
+	}
+
+	return envVarGroup, nil
+}
+
 func (r *Reconciler) cleanUpAppWorkloads(ctx context.Context, cfApp *korifiv1alpha1.CFApp, cfProcess *korifiv1alpha1.CFProcess) error {
 	log := logr.FromContextOrDiscard(ctx).WithName("cleanUpAppWorkloads")
 
@@ -324,15 +500,12 @@ func needsToDeleteAppWorkload(
 		appWorkload.Name != getDesiredAppWorkloadName(cfApp, cfProcess)
 }
 
-func calculateCPURequest(memoryMiB int64) resource.Quantity {
-	const (
-		cpuRequestRatio         int64 = 1024
-		cpuRequestMinMillicores int64 = 5
-	)
-	cpuMillicores := int64(100) * memoryMiB / cpuRequestRatio
-	if cpuMillicores < cpuRequestMinMillicores {
-		cpuMillicores = cpuRequestMinMillicores
+func (r *Reconciler) calculateCPURequest(cfProcess *korifiv1alpha1.CFProcess) resource.Quantity {
+	if cfProcess.Spec.CPUMillicores > 0 {
+		return *resource.NewScaledQuantity(cfProcess.Spec.CPUMillicores, resource.Milli)
 	}
+
+	cpuMillicores := shared.CPURequestMillicores(cfProcess.Spec.MemoryMB, r.controllerConfig.CFProcessDefaults.CPUMillicoresPerGB)
 	return *resource.NewScaledQuantity(cpuMillicores, resource.Milli)
 }
 
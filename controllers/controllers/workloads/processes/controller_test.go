@@ -174,6 +174,17 @@ var _ = Describe("CFProcessReconciler Integration Tests", func() {
 		}).Should(Succeed())
 	})
 
+	It("does not record app usage events for stopped apps", func() {
+		Eventually(func(g Gomega) {
+			g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfProcess), cfProcess)).To(Succeed())
+			g.Expect(cfProcess.Status.Usage).NotTo(BeNil())
+		}).Should(Succeed())
+
+		Consistently(func(g Gomega) {
+			g.Expect(listAppUsageEvents(g, cfProcess.Name)).To(BeEmpty())
+		}).Should(Succeed())
+	})
+
 	It("sets the ObservedGeneration status field", func() {
 		Eventually(func(g Gomega) {
 			g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfProcess), cfProcess)).To(Succeed())
@@ -378,6 +389,64 @@ var _ = Describe("CFProcessReconciler Integration Tests", func() {
 			})
 		})
 
+		When("the app workload is scaled to zero", func() {
+			JustBeforeEach(func() {
+				withAppWorkload(func(g Gomega, appWorkload korifiv1alpha1.AppWorkload) {
+					g.Expect(k8s.Patch(ctx, adminClient, &appWorkload, func() {
+						appWorkload.Status.ActualInstances = 0
+						appWorkload.Status.ScaledToZero = true
+					})).To(Succeed())
+				})
+			})
+
+			It("reports the desired instances as down", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfProcess), cfProcess)).To(Succeed())
+					g.Expect(cfProcess.Status.ScaledToZero).To(BeTrue())
+					g.Expect(cfProcess.Status.InstancesStatus).To(Equal(map[string]korifiv1alpha1.InstanceStatus{
+						"0": {
+							State: korifiv1alpha1.InstanceStateDown,
+						},
+					}))
+				}).Should(Succeed())
+			})
+		})
+
+		When("the CFProcess has an autoscaling policy", func() {
+			BeforeEach(func() {
+				cfProcess.Spec.Autoscaling = &korifiv1alpha1.AutoscalingPolicy{
+					MinInstances:   1,
+					MaxInstances:   5,
+					CPUUtilization: tools.PtrTo[int32](70),
+				}
+			})
+
+			It("sets the autoscaling policy on the AppWorkload", func() {
+				withAppWorkload(func(g Gomega, appWorkload korifiv1alpha1.AppWorkload) {
+					g.Expect(appWorkload.Spec.Autoscaling).To(Equal(cfProcess.Spec.Autoscaling))
+				})
+			})
+		})
+
+		It("does not limit the AppWorkload CPU", func() {
+			withAppWorkload(func(g Gomega, appWorkload korifiv1alpha1.AppWorkload) {
+				g.Expect(appWorkload.Spec.Resources.Limits).NotTo(HaveKey(corev1.ResourceCPU))
+			})
+		})
+
+		When("the CFProcess has a CPU entitlement", func() {
+			BeforeEach(func() {
+				cfProcess.Spec.CPUMillicores = 1500
+			})
+
+			It("requests and limits the AppWorkload CPU to it", func() {
+				withAppWorkload(func(g Gomega, appWorkload korifiv1alpha1.AppWorkload) {
+					g.Expect(appWorkload.Spec.Resources.Requests.Cpu()).To(matchers.RepresentResourceQuantity(1500, "m"))
+					g.Expect(appWorkload.Spec.Resources.Limits.Cpu()).To(matchers.RepresentResourceQuantity(1500, "m"))
+				})
+			})
+		})
+
 		When("the app has service bindings", func() {
 			BeforeEach(func() {
 				Expect(k8s.Patch(ctx, adminClient, cfApp, func() {
@@ -422,6 +491,96 @@ var _ = Describe("CFProcessReconciler Integration Tests", func() {
 			})
 		})
 
+		When("the space is assigned to an isolation segment", func() {
+			BeforeEach(func() {
+				cfSpace := &korifiv1alpha1.CFSpace{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      testNamespace,
+						Namespace: testNamespace,
+					},
+					Spec: korifiv1alpha1.CFSpaceSpec{
+						DisplayName: "my-space",
+					},
+				}
+				Expect(adminClient.Create(ctx, cfSpace)).To(Succeed())
+				Expect(k8s.Patch(ctx, adminClient, cfSpace, func() {
+					cfSpace.Status.GUID = testNamespace
+					cfSpace.Status.NodeSelector = map[string]string{"pool": "regulated"}
+					cfSpace.Status.Tolerations = []corev1.Toleration{{
+						Key:      "dedicated",
+						Operator: corev1.TolerationOpExists,
+					}}
+				})).To(Succeed())
+			})
+
+			It("sets the isolation segment placement on the app workload", func() {
+				withAppWorkload(func(g Gomega, appWorkload korifiv1alpha1.AppWorkload) {
+					g.Expect(appWorkload.Spec.NodeSelector).To(Equal(map[string]string{"pool": "regulated"}))
+					g.Expect(appWorkload.Spec.Tolerations).To(ConsistOf(corev1.Toleration{
+						Key:      "dedicated",
+						Operator: corev1.TolerationOpExists,
+					}))
+				})
+			})
+		})
+
+		When("the running environment variable group changes after the workload has been created", func() {
+			BeforeEach(func() {
+				withAppWorkload(func(g Gomega, appWorkload korifiv1alpha1.AppWorkload) {
+					g.Expect(appWorkload.Spec.Env).NotTo(ContainElement(MatchFields(IgnoreExtras, Fields{"Name": Equal("GROUP_KEY")})))
+				})
+
+				envVarGroup := &korifiv1alpha1.CFEnvironmentVariableGroup{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      korifiv1alpha1.RunningEnvironmentVariableGroupName,
+						Namespace: rootNamespace,
+					},
+					Spec: korifiv1alpha1.CFEnvironmentVariableGroupSpec{
+						EnvironmentVariables: map[string]string{"GROUP_KEY": "group-value"},
+					},
+				}
+				Expect(adminClient.Create(ctx, envVarGroup)).To(Succeed())
+				DeferCleanup(func() {
+					Expect(adminClient.Delete(ctx, envVarGroup)).To(Succeed())
+				})
+			})
+
+			It("keeps the group the app workload has been created with", func() {
+				Expect(k8s.Patch(ctx, adminClient, cfProcess, func() {
+					cfProcess.Spec.MemoryMB = 2048
+				})).To(Succeed())
+
+				withAppWorkload(func(g Gomega, appWorkload korifiv1alpha1.AppWorkload) {
+					g.Expect(appWorkload.Spec.Resources.Requests.Memory()).To(matchers.RepresentResourceQuantity(2048, "Mi"))
+				})
+
+				Consistently(func(g Gomega) {
+					var appWorkloads korifiv1alpha1.AppWorkloadList
+					g.Expect(adminClient.List(ctx, &appWorkloads, client.InNamespace(testNamespace))).To(Succeed())
+					g.Expect(appWorkloads.Items).To(HaveLen(1))
+					g.Expect(appWorkloads.Items[0].Spec.Env).NotTo(ContainElement(MatchFields(IgnoreExtras, Fields{"Name": Equal("GROUP_KEY")})))
+				}, "1s").Should(Succeed())
+			})
+
+			When("the app is restarted", func() {
+				BeforeEach(func() {
+					Expect(k8s.Patch(ctx, adminClient, cfApp, func() {
+						cfApp.Annotations[korifiv1alpha1.CFAppRevisionKey] = "6"
+						cfApp.Annotations[korifiv1alpha1.CFAppLastStopRevisionKey] = "6"
+					})).To(Succeed())
+				})
+
+				It("applies the group to the new app workload", func() {
+					withAppWorkload(func(g Gomega, appWorkload korifiv1alpha1.AppWorkload) {
+						g.Expect(appWorkload.Spec.Env).To(ContainElement(corev1.EnvVar{Name: "GROUP_KEY", Value: "group-value"}))
+						g.Expect(appWorkload.Annotations).To(HaveKeyWithValue(
+							korifiv1alpha1.RunningEnvironmentVariableGroupAnnotationKey, MatchJSON(`{"GROUP_KEY":"group-value"}`),
+						))
+					})
+				})
+			})
+		})
+
 		When("The process command field isn't set", func() {
 			BeforeEach(func() {
 				cfProcess.Spec.Command = ""
@@ -458,6 +617,72 @@ var _ = Describe("CFProcessReconciler Integration Tests", func() {
 			})
 		})
 
+		It("records an app usage event", func() {
+			Eventually(func(g Gomega) {
+				appUsageEvents := listAppUsageEvents(g, cfProcess.Name)
+				g.Expect(appUsageEvents).To(HaveLen(1))
+				g.Expect(appUsageEvents[0].Spec.Sequence).To(BeNumerically(">", 0))
+				g.Expect(appUsageEvents[0]).To(MatchFields(IgnoreExtras, Fields{
+					"Spec": Equal(korifiv1alpha1.CFAppUsageEventSpec{
+						Sequence:                      appUsageEvents[0].Spec.Sequence,
+						State:                         korifiv1alpha1.StartedState,
+						PreviousState:                 korifiv1alpha1.StoppedState,
+						AppGUID:                       cfApp.Name,
+						AppName:                       "test-app-name",
+						ProcessGUID:                   cfProcess.Name,
+						ProcessType:                   korifiv1alpha1.ProcessTypeWeb,
+						SpaceGUID:                     testNamespace,
+						InstanceCount:                 1,
+						PreviousInstanceCount:         0,
+						MemoryInMBPerInstance:         1024,
+						PreviousMemoryInMBPerInstance: 0,
+					}),
+				}))
+
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfProcess), cfProcess)).To(Succeed())
+				g.Expect(cfProcess.Status.Usage).To(PointTo(Equal(korifiv1alpha1.ProcessUsage{
+					State:     korifiv1alpha1.StartedState,
+					Instances: 1,
+					MemoryMB:  1024,
+				})))
+			}).Should(Succeed())
+		})
+
+		When("the process is scaled", func() {
+			JustBeforeEach(func() {
+				withAppWorkload(func(g Gomega, appWorkload korifiv1alpha1.AppWorkload) {})
+				Expect(k8s.Patch(ctx, adminClient, cfProcess, func() {
+					cfProcess.Spec.DesiredInstances = tools.PtrTo[int32](3)
+				})).To(Succeed())
+			})
+
+			It("records a scale app usage event", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(listAppUsageEvents(g, cfProcess.Name)).To(ContainElement(MatchFields(IgnoreExtras, Fields{
+						"Spec": MatchFields(IgnoreExtras, Fields{
+							"State":                 Equal(korifiv1alpha1.StartedState),
+							"PreviousState":         Equal(korifiv1alpha1.StartedState),
+							"InstanceCount":         BeEquivalentTo(3),
+							"PreviousInstanceCount": BeEquivalentTo(1),
+						}),
+					})))
+				}).Should(Succeed())
+			})
+
+			It("gives the scale event a greater sequence number than the start event", func() {
+				Eventually(func(g Gomega) {
+					appUsageEvents := listAppUsageEvents(g, cfProcess.Name)
+					g.Expect(appUsageEvents).To(HaveLen(2))
+
+					sequences := map[korifiv1alpha1.AppState]int64{}
+					for _, event := range appUsageEvents {
+						sequences[event.Spec.PreviousState] = event.Spec.Sequence
+					}
+					g.Expect(sequences[korifiv1alpha1.StartedState]).To(BeNumerically(">", sequences[korifiv1alpha1.StoppedState]))
+				}).Should(Succeed())
+			})
+		})
+
 		When("a CFApp desired state is updated to STOPPED", func() {
 			JustBeforeEach(func() {
 				withAppWorkload(func(g Gomega, appWorkload korifiv1alpha1.AppWorkload) {})
@@ -473,6 +698,17 @@ var _ = Describe("CFProcessReconciler Integration Tests", func() {
 					g.Expect(appWorkloads.Items).To(BeEmpty())
 				}).Should(Succeed())
 			})
+
+			It("records a stop app usage event", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(listAppUsageEvents(g, cfProcess.Name)).To(ContainElement(MatchFields(IgnoreExtras, Fields{
+						"Spec": MatchFields(IgnoreExtras, Fields{
+							"State":         Equal(korifiv1alpha1.StoppedState),
+							"PreviousState": Equal(korifiv1alpha1.StartedState),
+						}),
+					})))
+				}).Should(Succeed())
+			})
 		})
 
 		When("the app process instances are scaled down to 0", func() {
@@ -563,3 +799,17 @@ func withAppWorkload(shouldFn func(Gomega, korifiv1alpha1.AppWorkload)) {
 		shouldFn(g, appWorkloads.Items[0])
 	}).Should(Succeed())
 }
+
+func listAppUsageEvents(g Gomega, processGUID string) []korifiv1alpha1.CFAppUsageEvent {
+	var appUsageEvents korifiv1alpha1.CFAppUsageEventList
+	g.Expect(adminClient.List(ctx, &appUsageEvents, client.InNamespace(rootNamespace))).To(Succeed())
+
+	var processEvents []korifiv1alpha1.CFAppUsageEvent
+	for _, event := range appUsageEvents.Items {
+		if event.Spec.ProcessGUID == processGUID {
+			processEvents = append(processEvents, event)
+		}
+	}
+
+	return processEvents
+}
@@ -9,6 +9,7 @@ import (
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	"code.cloudfoundry.org/korifi/controllers/config"
 	"code.cloudfoundry.org/korifi/controllers/controllers/shared"
+	"code.cloudfoundry.org/korifi/controllers/controllers/usage"
 	"code.cloudfoundry.org/korifi/controllers/controllers/workloads/env"
 	"code.cloudfoundry.org/korifi/controllers/controllers/workloads/processes"
 	"code.cloudfoundry.org/korifi/tests/helpers"
@@ -35,6 +36,7 @@ var (
 	testEnv         *envtest.Environment
 	adminClient     client.Client
 	testNamespace   string
+	rootNamespace   string
 	k8sManager      manager.Manager
 )
 
@@ -69,8 +71,19 @@ var _ = BeforeSuite(func() {
 
 	adminClient, stopClientCache = helpers.NewCachedClient(testEnv.Config)
 
+	rootNamespace = uuid.NewString()
+	Expect(adminClient.Create(ctx, &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: rootNamespace,
+		},
+	})).To(Succeed())
+
 	controllerConfig := &config.ControllerConfig{
-		RunnerName: "cf-process-controller-test",
+		RunnerName:      "cf-process-controller-test",
+		CFRootNamespace: rootNamespace,
+		CFProcessDefaults: config.CFProcessDefaults{
+			CPUMillicoresPerGB: 100,
+		},
 	}
 
 	err = processes.NewReconciler(
@@ -78,7 +91,8 @@ var _ = BeforeSuite(func() {
 		k8sManager.GetScheme(),
 		ctrl.Log.WithName("controllers").WithName("CFProcess"),
 		controllerConfig,
-		env.NewProcessEnvBuilder(k8sManager.GetClient()),
+		env.NewProcessEnvBuilder(k8sManager.GetClient(), rootNamespace),
+		usage.NewRecorder(k8sManager.GetClient(), k8sManager.GetAPIReader(), rootNamespace),
 	).SetupWithManager(k8sManager)
 	Expect(err).NotTo(HaveOccurred())
 
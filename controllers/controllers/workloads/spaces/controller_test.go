@@ -321,4 +321,79 @@ var _ = Describe("CFSpaceReconciler Integration Tests", func() {
 			}).Should(Succeed())
 		})
 	})
+
+	When("the space is assigned to an isolation segment", func() {
+		var isolationSegment *korifiv1alpha1.CFIsolationSegment
+
+		BeforeEach(func() {
+			isolationSegment = &korifiv1alpha1.CFIsolationSegment{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      uuid.NewString(),
+					Namespace: cfRootNamespace,
+				},
+				Spec: korifiv1alpha1.CFIsolationSegmentSpec{
+					Name:          uuid.NewString(),
+					Organizations: []string{cfSpace.Namespace},
+					NodeSelector:  map[string]string{"pool": "regulated"},
+					Tolerations: []corev1.Toleration{{
+						Key:      "dedicated",
+						Operator: corev1.TolerationOpEqual,
+						Value:    "regulated",
+						Effect:   corev1.TaintEffectNoSchedule,
+					}},
+				},
+			}
+			Expect(adminClient.Create(ctx, isolationSegment)).To(Succeed())
+
+			Expect(k8s.PatchResource(ctx, adminClient, cfSpace, func() {
+				cfSpace.Spec.IsolationSegmentGUID = isolationSegment.Name
+			})).To(Succeed())
+		})
+
+		It("sets the isolation segment placement on the space status", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfSpace), cfSpace)).To(Succeed())
+				g.Expect(meta.IsStatusConditionTrue(cfSpace.Status.Conditions, korifiv1alpha1.StatusConditionReady)).To(BeTrue())
+				g.Expect(cfSpace.Status.NodeSelector).To(Equal(map[string]string{"pool": "regulated"}))
+				g.Expect(cfSpace.Status.Tolerations).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+					"Key":   Equal("dedicated"),
+					"Value": Equal("regulated"),
+				})))
+			}).Should(Succeed())
+		})
+
+		When("the isolation segment placement changes", func() {
+			BeforeEach(func() {
+				Expect(k8s.PatchResource(ctx, adminClient, isolationSegment, func() {
+					isolationSegment.Spec.NodeSelector = map[string]string{"pool": "other"}
+				})).To(Succeed())
+			})
+
+			It("updates the space status", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfSpace), cfSpace)).To(Succeed())
+					g.Expect(cfSpace.Status.NodeSelector).To(Equal(map[string]string{"pool": "other"}))
+				}).Should(Succeed())
+			})
+		})
+
+		When("the isolation segment is not entitled to the space org", func() {
+			BeforeEach(func() {
+				Expect(k8s.PatchResource(ctx, adminClient, isolationSegment, func() {
+					isolationSegment.Spec.Organizations = nil
+				})).To(Succeed())
+			})
+
+			It("sets the ready condition to false", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfSpace), cfSpace)).To(Succeed())
+					readyCondition := meta.FindStatusCondition(cfSpace.Status.Conditions, korifiv1alpha1.StatusConditionReady)
+					g.Expect(readyCondition).NotTo(BeNil())
+					g.Expect(readyCondition.Status).To(Equal(metav1.ConditionFalse))
+					g.Expect(readyCondition.Reason).To(Equal("IsolationSegmentResolution"))
+					g.Expect(cfSpace.Status.NodeSelector).To(BeEmpty())
+				}).Should(Succeed())
+			})
+		})
+	})
 })
@@ -23,6 +23,9 @@ import (
 	"time"
 
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/config"
+	"code.cloudfoundry.org/korifi/controllers/controllers/shared"
+	"code.cloudfoundry.org/korifi/tools"
 	"code.cloudfoundry.org/korifi/tools/k8s"
 
 	"github.com/go-logr/logr"
@@ -50,12 +53,13 @@ type TaskEnvBuilder interface {
 }
 
 type Reconciler struct {
-	k8sClient       client.Client
-	scheme          *runtime.Scheme
-	recorder        record.EventRecorder
-	log             logr.Logger
-	envBuilder      TaskEnvBuilder
-	taskTTLDuration time.Duration
+	k8sClient         client.Client
+	scheme            *runtime.Scheme
+	recorder          record.EventRecorder
+	log               logr.Logger
+	envBuilder        TaskEnvBuilder
+	taskTTLDuration   time.Duration
+	cfProcessDefaults config.CFProcessDefaults
 }
 
 func NewReconciler(
@@ -65,14 +69,16 @@ func NewReconciler(
 	log logr.Logger,
 	envBuilder TaskEnvBuilder,
 	taskTTLDuration time.Duration,
+	cfProcessDefaults config.CFProcessDefaults,
 ) *k8s.PatchingReconciler[korifiv1alpha1.CFTask, *korifiv1alpha1.CFTask] {
 	taskReconciler := Reconciler{
-		k8sClient:       client,
-		scheme:          scheme,
-		recorder:        recorder,
-		log:             log,
-		envBuilder:      envBuilder,
-		taskTTLDuration: taskTTLDuration,
+		k8sClient:         client,
+		scheme:            scheme,
+		recorder:          recorder,
+		log:               log,
+		envBuilder:        envBuilder,
+		taskTTLDuration:   taskTTLDuration,
+		cfProcessDefaults: cfProcessDefaults,
 	}
 	return k8s.NewPatchingReconciler[korifiv1alpha1.CFTask, *korifiv1alpha1.CFTask](log, client, &taskReconciler)
 }
@@ -131,7 +137,7 @@ func (r *Reconciler) ReconcileResource(ctx context.Context, cfTask *korifiv1alph
 
 	r.initializeStatus(ctx, cfTask, cfDroplet)
 
-	webProcess, err := r.getWebProcess(ctx, cfApp)
+	webProcess, err := getWebProcess(ctx, r.k8sClient, cfApp)
 	if err != nil {
 		log.Info("failed to get web processes", "reason", err)
 		return r.reconcileResult(cfTask, err)
@@ -148,18 +154,21 @@ func (r *Reconciler) ReconcileResource(ctx context.Context, cfTask *korifiv1alph
 		return r.reconcileResult(cfTask, err)
 	}
 
-	r.setTaskStatus(cfTask, taskWorkload.Status.Conditions)
+	r.setTaskStatus(cfTask, taskWorkload.Status)
 
 	return r.reconcileResult(cfTask, nil)
 }
 
-func (r *Reconciler) setTaskStatus(cfTask *korifiv1alpha1.CFTask, taskWorkloadConditions []metav1.Condition) {
+func (r *Reconciler) setTaskStatus(cfTask *korifiv1alpha1.CFTask, taskWorkloadStatus korifiv1alpha1.TaskWorkloadStatus) {
+	cfTask.Status.Attempts = taskWorkloadStatus.Attempts
+	cfTask.Status.FailedAttempts = taskWorkloadStatus.FailedAttempts
+
 	for _, conditionType := range []string{
 		korifiv1alpha1.TaskStartedConditionType,
 		korifiv1alpha1.TaskSucceededConditionType,
 		korifiv1alpha1.TaskFailedConditionType,
 	} {
-		cond := meta.FindStatusCondition(taskWorkloadConditions, conditionType)
+		cond := meta.FindStatusCondition(taskWorkloadStatus.Conditions, conditionType)
 		if cond == nil {
 			continue
 		}
@@ -195,7 +204,7 @@ func (r *Reconciler) getApp(ctx context.Context, cfTask *korifiv1alpha1.CFTask)
 		return nil, errors.New("app not ready")
 	}
 
-	if cfApp.Spec.CurrentDropletRef.Name == "" {
+	if cfApp.Spec.CurrentDropletRef.Name == "" && cfTask.Spec.DropletRef.Name == "" {
 		log.Info("app droplet ref not set")
 		r.recorder.Eventf(cfTask, "Warning", "AppCurrentDropletRefNotSet", "App %s does not have a current droplet", cfTask.Spec.AppRef.Name)
 		return nil, errors.New("app droplet ref not set")
@@ -205,16 +214,21 @@ func (r *Reconciler) getApp(ctx context.Context, cfTask *korifiv1alpha1.CFTask)
 }
 
 func (r *Reconciler) getDroplet(ctx context.Context, cfTask *korifiv1alpha1.CFTask, cfApp *korifiv1alpha1.CFApp) (*korifiv1alpha1.CFBuild, error) {
-	log := logr.FromContextOrDiscard(ctx).WithName("getDroplet").WithValues("dropletName", cfApp.Spec.CurrentDropletRef.Name)
+	dropletName := cfApp.Spec.CurrentDropletRef.Name
+	if cfTask.Spec.DropletRef.Name != "" {
+		dropletName = cfTask.Spec.DropletRef.Name
+	}
+
+	log := logr.FromContextOrDiscard(ctx).WithName("getDroplet").WithValues("dropletName", dropletName)
 
 	cfDroplet := new(korifiv1alpha1.CFBuild)
 	err := r.k8sClient.Get(ctx, types.NamespacedName{
 		Namespace: cfApp.Namespace,
-		Name:      cfApp.Spec.CurrentDropletRef.Name,
+		Name:      dropletName,
 	}, cfDroplet)
 	if err != nil {
 		if k8serrors.IsNotFound(err) {
-			r.recorder.Eventf(cfTask, "Warning", "AppCurrentDropletNotFound", "Current droplet %s for app %s does not exist", cfApp.Spec.CurrentDropletRef.Name, cfTask.Spec.AppRef.Name)
+			r.recorder.Eventf(cfTask, "Warning", "AppCurrentDropletNotFound", "Droplet %s for app %s does not exist", dropletName, cfTask.Spec.AppRef.Name)
 		} else {
 			log.Info("error getting CFDroplet", "reason", err)
 		}
@@ -222,18 +236,24 @@ func (r *Reconciler) getDroplet(ctx context.Context, cfTask *korifiv1alpha1.CFTa
 		return nil, err
 	}
 
+	if cfDroplet.Spec.AppRef.Name != cfApp.Name {
+		log.Info("droplet belongs to another app", "dropletApp", cfDroplet.Spec.AppRef.Name)
+		r.recorder.Eventf(cfTask, "Warning", "DropletAppMismatch", "Droplet %s does not belong to app %s", dropletName, cfTask.Spec.AppRef.Name)
+		return nil, errors.New("droplet belongs to another app")
+	}
+
 	if cfDroplet.Status.Droplet == nil {
 		log.Info("droplet build status not set")
-		r.recorder.Eventf(cfTask, "Warning", "DropletBuildStatusNotSet", "Current droplet %s from app %s does not have a droplet image", cfApp.Spec.CurrentDropletRef.Name, cfTask.Spec.AppRef.Name)
+		r.recorder.Eventf(cfTask, "Warning", "DropletBuildStatusNotSet", "Droplet %s from app %s does not have a droplet image", dropletName, cfTask.Spec.AppRef.Name)
 		return nil, errors.New("droplet build status not set")
 	}
 
 	return cfDroplet, nil
 }
 
-func (r *Reconciler) getWebProcess(ctx context.Context, cfApp *korifiv1alpha1.CFApp) (korifiv1alpha1.CFProcess, error) {
+func getWebProcess(ctx context.Context, k8sClient client.Client, cfApp *korifiv1alpha1.CFApp) (korifiv1alpha1.CFProcess, error) {
 	var processList korifiv1alpha1.CFProcessList
-	err := r.k8sClient.List(ctx, &processList, client.InNamespace(cfApp.Namespace), client.MatchingLabels{
+	err := k8sClient.List(ctx, &processList, client.InNamespace(cfApp.Namespace), client.MatchingLabels{
 		korifiv1alpha1.CFAppGUIDLabelKey:     cfApp.Name,
 		korifiv1alpha1.CFProcessTypeLabelKey: korifiv1alpha1.ProcessTypeWeb,
 	})
@@ -251,6 +271,12 @@ func (r *Reconciler) getWebProcess(ctx context.Context, cfApp *korifiv1alpha1.CF
 func (r *Reconciler) createOrPatchTaskWorkload(ctx context.Context, cfTask *korifiv1alpha1.CFTask, cfDroplet *korifiv1alpha1.CFBuild, webProcess korifiv1alpha1.CFProcess, env []corev1.EnvVar) (*korifiv1alpha1.TaskWorkload, error) {
 	log := logr.FromContextOrDiscard(ctx).WithName("createOrPatchTaskWorkload")
 
+	placement, err := shared.GetWorkloadPlacement(ctx, r.k8sClient, cfTask.Namespace)
+	if err != nil {
+		log.Info("failed to get the workload placement", "reason", err)
+		return nil, err
+	}
+
 	taskWorkload := &korifiv1alpha1.TaskWorkload{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      cfTask.Name,
@@ -265,24 +291,24 @@ func (r *Reconciler) createOrPatchTaskWorkload(ctx context.Context, cfTask *kori
 
 		taskWorkload.Labels[korifiv1alpha1.CFTaskGUIDLabelKey] = cfTask.Name
 
-		taskWorkload.Spec.Command = []string{LifecycleLauncherPath, cfTask.Spec.Command}
-		taskWorkload.Spec.Image = cfDroplet.Status.Droplet.Registry.Image
-		taskWorkload.Spec.ImagePullSecrets = cfDroplet.Status.Droplet.Registry.ImagePullSecrets
-
-		if taskWorkload.Spec.Resources.Requests == nil {
-			taskWorkload.Spec.Resources.Requests = corev1.ResourceList{}
+		// runs of task schedules are started by the runner on schedule, the
+		// label tells it not to start them again
+		if scheduleGUID, ok := cfTask.Labels[korifiv1alpha1.CFTaskScheduleGUIDLabelKey]; ok {
+			taskWorkload.Labels[korifiv1alpha1.CFTaskScheduleGUIDLabelKey] = scheduleGUID
 		}
 
-		if taskWorkload.Spec.Resources.Limits == nil {
-			taskWorkload.Spec.Resources.Limits = corev1.ResourceList{}
-		}
-
-		taskWorkload.Spec.Resources.Requests[corev1.ResourceMemory] = *resource.NewScaledQuantity(cfTask.Status.MemoryMB, resource.Mega)
-		taskWorkload.Spec.Resources.Limits[corev1.ResourceMemory] = *resource.NewScaledQuantity(cfTask.Status.MemoryMB, resource.Mega)
-		taskWorkload.Spec.Resources.Requests[corev1.ResourceEphemeralStorage] = *resource.NewScaledQuantity(cfTask.Status.DiskQuotaMB, resource.Mega)
-		taskWorkload.Spec.Resources.Limits[corev1.ResourceEphemeralStorage] = *resource.NewScaledQuantity(cfTask.Status.DiskQuotaMB, resource.Mega)
-		taskWorkload.Spec.Resources.Requests[corev1.ResourceCPU] = *resource.NewScaledQuantity(calculateDefaultCPURequestMillicores(webProcess.Spec.MemoryMB), resource.Milli)
-		taskWorkload.Spec.Env = env
+		taskWorkload.Spec = taskWorkloadSpec(
+			cfTask.Spec.Command,
+			cfTask.Status.MemoryMB,
+			cfTask.Status.DiskQuotaMB,
+			cfTask.Spec.TimeoutSeconds,
+			cfDroplet,
+			webProcess,
+			r.cfProcessDefaults.CPUMillicoresPerGB,
+			env,
+			placement,
+		)
+		taskWorkload.Spec.RetryPolicy = retryPolicy(cfTask.Spec.Retry)
 
 		if err := ctrl.SetControllerReference(cfTask, taskWorkload, r.scheme); err != nil {
 			log.Info("failed to set owner ref", "reason", err)
@@ -303,18 +329,53 @@ func (r *Reconciler) createOrPatchTaskWorkload(ctx context.Context, cfTask *kori
 	return taskWorkload, nil
 }
 
-func calculateDefaultCPURequestMillicores(memoryMiB int64) int64 {
-	const (
-		cpuRequestRatio         int64 = 1024
-		cpuRequestMinMillicores int64 = 5
-	)
+func taskWorkloadSpec(
+	command string,
+	memoryMB int64,
+	diskQuotaMB int64,
+	timeoutSeconds int64,
+	cfDroplet *korifiv1alpha1.CFBuild,
+	webProcess korifiv1alpha1.CFProcess,
+	cpuMillicoresPerGB int64,
+	env []corev1.EnvVar,
+	placement shared.WorkloadPlacement,
+) korifiv1alpha1.TaskWorkloadSpec {
+	spec := korifiv1alpha1.TaskWorkloadSpec{
+		Command:          []string{LifecycleLauncherPath, command},
+		Image:            cfDroplet.Status.Droplet.Registry.Image,
+		ImagePullSecrets: cfDroplet.Status.Droplet.Registry.ImagePullSecrets,
+		Resources: corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{
+				corev1.ResourceMemory:           *resource.NewScaledQuantity(memoryMB, resource.Mega),
+				corev1.ResourceEphemeralStorage: *resource.NewScaledQuantity(diskQuotaMB, resource.Mega),
+				corev1.ResourceCPU:              *resource.NewScaledQuantity(shared.CPURequestMillicores(webProcess.Spec.MemoryMB, cpuMillicoresPerGB), resource.Milli),
+			},
+			Limits: corev1.ResourceList{
+				corev1.ResourceMemory:           *resource.NewScaledQuantity(memoryMB, resource.Mega),
+				corev1.ResourceEphemeralStorage: *resource.NewScaledQuantity(diskQuotaMB, resource.Mega),
+			},
+		},
+		Env:          env,
+		NodeSelector: placement.NodeSelector,
+		Tolerations:  placement.Tolerations,
+	}
+
+	if timeoutSeconds > 0 {
+		spec.ActiveDeadlineSeconds = tools.PtrTo(timeoutSeconds)
+	}
+
+	return spec
+}
 
-	cpuMillicores := int64(100) * memoryMiB / cpuRequestRatio
-	if cpuMillicores < cpuRequestMinMillicores {
-		cpuMillicores = cpuRequestMinMillicores
+func retryPolicy(retry *korifiv1alpha1.TaskRetryPolicy) *korifiv1alpha1.TaskWorkloadRetryPolicy {
+	if retry == nil || retry.MaxAttempts <= 1 {
+		return nil
 	}
 
-	return cpuMillicores
+	return &korifiv1alpha1.TaskWorkloadRetryPolicy{
+		BackoffLimit:          retry.MaxAttempts - 1,
+		NonRetryableExitCodes: retry.NonRetryableExitCodes,
+	}
 }
 
 func (r *Reconciler) initializeStatus(ctx context.Context, cfTask *korifiv1alpha1.CFTask, cfDroplet *korifiv1alpha1.CFBuild) {
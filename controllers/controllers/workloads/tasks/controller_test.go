@@ -277,6 +277,54 @@ var _ = Describe("CFTaskReconciler Integration Tests", func() {
 			))
 		})
 
+		When("the task has a timeout", func() {
+			BeforeEach(func() {
+				Expect(k8s.PatchResource(ctx, adminClient, cfTask, func() {
+					cfTask.Spec.TimeoutSeconds = 300
+				})).To(Succeed())
+			})
+
+			It("sets the active deadline on the TaskWorkload", func() {
+				Eventually(func(g Gomega) {
+					var taskWorkloads korifiv1alpha1.TaskWorkloadList
+
+					g.Expect(adminClient.List(ctx, &taskWorkloads,
+						client.InNamespace(testNamespace),
+						client.MatchingLabels{korifiv1alpha1.CFTaskGUIDLabelKey: cfTask.Name},
+					)).To(Succeed())
+					g.Expect(taskWorkloads.Items).To(HaveLen(1))
+					g.Expect(taskWorkloads.Items[0].Spec.ActiveDeadlineSeconds).To(PointTo(BeEquivalentTo(300)))
+				}).Should(Succeed())
+			})
+		})
+
+		When("the task has a retry policy", func() {
+			BeforeEach(func() {
+				Expect(k8s.PatchResource(ctx, adminClient, cfTask, func() {
+					cfTask.Spec.Retry = &korifiv1alpha1.TaskRetryPolicy{
+						MaxAttempts:           3,
+						NonRetryableExitCodes: []int32{2},
+					}
+				})).To(Succeed())
+			})
+
+			It("sets the retry policy on the TaskWorkload", func() {
+				Eventually(func(g Gomega) {
+					var taskWorkloads korifiv1alpha1.TaskWorkloadList
+
+					g.Expect(adminClient.List(ctx, &taskWorkloads,
+						client.InNamespace(testNamespace),
+						client.MatchingLabels{korifiv1alpha1.CFTaskGUIDLabelKey: cfTask.Name},
+					)).To(Succeed())
+					g.Expect(taskWorkloads.Items).To(HaveLen(1))
+					g.Expect(taskWorkloads.Items[0].Spec.RetryPolicy).To(PointTo(Equal(korifiv1alpha1.TaskWorkloadRetryPolicy{
+						BackoffLimit:          2,
+						NonRetryableExitCodes: []int32{2},
+					})))
+				}).Should(Succeed())
+			})
+		})
+
 		It("records a TaskWorkloadCreated event", func() {
 			Expect(eventRecorder.EventfCallCount()).To(Equal(eventCallCount+1), "eventRecorder.Eventf call count mismatch")
 			eventTaskObj, eventType, eventReason, eventMessage, eventMessageArgs := eventRecorder.EventfArgsForCall(eventCallCount)
@@ -334,6 +382,13 @@ var _ = Describe("CFTaskReconciler Integration Tests", func() {
 							Reason:  "task_started",
 							Message: "task started",
 						})
+						modifiedTaskWorkload.Status.Attempts = 2
+						modifiedTaskWorkload.Status.FailedAttempts = []korifiv1alpha1.TaskAttemptFailure{{
+							ExitCode:   1,
+							Reason:     "Error",
+							Message:    "Failed with exit code: 1",
+							FinishedAt: metav1.Now(),
+						}}
 					})).To(Succeed())
 				}).Should(Succeed())
 			})
@@ -344,6 +399,14 @@ var _ = Describe("CFTaskReconciler Integration Tests", func() {
 					g.Expect(meta.IsStatusConditionTrue(cfTask.Status.Conditions, korifiv1alpha1.TaskStartedConditionType)).To(BeTrue())
 				}).Should(Succeed())
 			})
+
+			It("reflects the attempts in the korifi task", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfTask), cfTask)).To(Succeed())
+					g.Expect(cfTask.Status.Attempts).To(BeEquivalentTo(2))
+					g.Expect(cfTask.Status.FailedAttempts).To(ConsistOf(HaveField("Message", "Failed with exit code: 1")))
+				}).Should(Succeed())
+			})
 		})
 	})
 
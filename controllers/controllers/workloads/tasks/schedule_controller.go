@@ -0,0 +1,320 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"context"
+	"errors"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/config"
+	"code.cloudfoundry.org/korifi/controllers/controllers/shared"
+	"code.cloudfoundry.org/korifi/tools/k8s"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// ScheduleReconciler reconciles a CFTaskSchedule into a TaskScheduleWorkload
+// and records each run reported by the runner as a CFTask
+type ScheduleReconciler struct {
+	k8sClient                 client.Client
+	scheme                    *runtime.Scheme
+	log                       logr.Logger
+	envBuilder                TaskEnvBuilder
+	cfProcessDefaults         config.CFProcessDefaults
+	rootNamespace             string
+	rollOutRunningEnvVarGroup bool
+}
+
+func NewScheduleReconciler(
+	client client.Client,
+	scheme *runtime.Scheme,
+	log logr.Logger,
+	envBuilder TaskEnvBuilder,
+	cfProcessDefaults config.CFProcessDefaults,
+	rootNamespace string,
+	rollOutRunningEnvVarGroup bool,
+) *k8s.PatchingReconciler[korifiv1alpha1.CFTaskSchedule, *korifiv1alpha1.CFTaskSchedule] {
+	scheduleReconciler := ScheduleReconciler{
+		k8sClient:                 client,
+		scheme:                    scheme,
+		log:                       log,
+		envBuilder:                envBuilder,
+		cfProcessDefaults:         cfProcessDefaults,
+		rootNamespace:             rootNamespace,
+		rollOutRunningEnvVarGroup: rollOutRunningEnvVarGroup,
+	}
+	return k8s.NewPatchingReconciler[korifiv1alpha1.CFTaskSchedule, *korifiv1alpha1.CFTaskSchedule](log, client, &scheduleReconciler)
+}
+
+func (r *ScheduleReconciler) SetupWithManager(mgr ctrl.Manager) *builder.Builder {
+	b := ctrl.NewControllerManagedBy(mgr).
+		For(&korifiv1alpha1.CFTaskSchedule{}).
+		Owns(&korifiv1alpha1.TaskScheduleWorkload{}).
+		Watches(
+			&korifiv1alpha1.CFApp{},
+			handler.EnqueueRequestsFromMapFunc(r.appToSchedules),
+		)
+
+	if r.rollOutRunningEnvVarGroup {
+		b = b.Watches(
+			&korifiv1alpha1.CFEnvironmentVariableGroup{},
+			handler.EnqueueRequestsFromMapFunc(r.envVarGroupToSchedules),
+		)
+	}
+
+	return b
+}
+
+// envVarGroupToSchedules rolls changes to the running environment variable
+// group out to the schedules of all apps
+func (r *ScheduleReconciler) envVarGroupToSchedules(ctx context.Context, o client.Object) []reconcile.Request {
+	if o.GetNamespace() != r.rootNamespace || o.GetName() != korifiv1alpha1.RunningEnvironmentVariableGroupName {
+		return nil
+	}
+
+	schedules := korifiv1alpha1.CFTaskScheduleList{}
+	if err := r.k8sClient.List(ctx, &schedules); err != nil {
+		r.log.Info("failed to list task schedules for environment variable group", "group", o.GetName(), "reason", err)
+		return nil
+	}
+
+	requests := []reconcile.Request{}
+	for _, schedule := range schedules.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&schedule)})
+	}
+
+	return requests
+}
+
+func (r *ScheduleReconciler) appToSchedules(ctx context.Context, o client.Object) []reconcile.Request {
+	schedules := korifiv1alpha1.CFTaskScheduleList{}
+	if err := r.k8sClient.List(ctx, &schedules,
+		client.InNamespace(o.GetNamespace()),
+		client.MatchingLabels{korifiv1alpha1.CFAppGUIDLabelKey: o.GetName()},
+	); err != nil {
+		r.log.Info("failed to list task schedules for app", "app", o.GetName(), "reason", err)
+		return nil
+	}
+
+	requests := []reconcile.Request{}
+	for _, schedule := range schedules.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&schedule)})
+	}
+
+	return requests
+}
+
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cftaskschedules,verbs=get;list;watch;update;patch
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cftaskschedules/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cftaskschedules/finalizers,verbs=update
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=taskscheduleworkloads,verbs=get;list;watch;create;patch;delete
+
+func (r *ScheduleReconciler) ReconcileResource(ctx context.Context, cfTaskSchedule *korifiv1alpha1.CFTaskSchedule) (ctrl.Result, error) {
+	log := logr.FromContextOrDiscard(ctx)
+
+	if !cfTaskSchedule.GetDeletionTimestamp().IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	cfTaskSchedule.Status.ObservedGeneration = cfTaskSchedule.Generation
+	log.V(1).Info("set observed generation", "generation", cfTaskSchedule.Status.ObservedGeneration)
+
+	cfApp, cfDroplet, err := r.getAppAndDroplet(ctx, cfTaskSchedule)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	err = controllerutil.SetOwnerReference(cfApp, cfTaskSchedule, r.scheme)
+	if err != nil {
+		log.Info("unable to set owner reference on CFTaskSchedule", "reason", err)
+		return ctrl.Result{}, err
+	}
+
+	webProcess, err := getWebProcess(ctx, r.k8sClient, cfApp)
+	if err != nil {
+		log.Info("failed to get web processes", "reason", err)
+		return ctrl.Result{}, err
+	}
+
+	env, err := r.envBuilder.Build(ctx, cfApp)
+	if err != nil {
+		log.Info("failed to build env", "reason", err)
+		return ctrl.Result{}, err
+	}
+
+	scheduleWorkload, err := r.createOrPatchScheduleWorkload(ctx, cfTaskSchedule, cfDroplet, webProcess, env)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	cfTaskSchedule.Status.LastScheduleTime = scheduleWorkload.Status.LastScheduleTime
+
+	if err = r.recordRuns(ctx, cfTaskSchedule, cfDroplet, scheduleWorkload.Status.Runs); err != nil {
+		log.Info("failed to record runs", "reason", err)
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{}, nil
+}
+
+func (r *ScheduleReconciler) getAppAndDroplet(ctx context.Context, cfTaskSchedule *korifiv1alpha1.CFTaskSchedule) (*korifiv1alpha1.CFApp, *korifiv1alpha1.CFBuild, error) {
+	cfApp := new(korifiv1alpha1.CFApp)
+	err := r.k8sClient.Get(ctx, types.NamespacedName{
+		Namespace: cfTaskSchedule.Namespace,
+		Name:      cfTaskSchedule.Spec.AppRef.Name,
+	}, cfApp)
+	if err != nil {
+		return nil, nil, k8s.NewNotReadyError().WithCause(err).WithReason("AppNotFound")
+	}
+
+	if !meta.IsStatusConditionTrue(cfApp.Status.Conditions, korifiv1alpha1.StatusConditionReady) {
+		return nil, nil, k8s.NewNotReadyError().WithReason("AppNotReady").WithMessage("App is not ready")
+	}
+
+	if cfApp.Spec.CurrentDropletRef.Name == "" {
+		return nil, nil, k8s.NewNotReadyError().WithReason("AppCurrentDropletRefNotSet").WithMessage("App does not have a current droplet")
+	}
+
+	cfDroplet := new(korifiv1alpha1.CFBuild)
+	err = r.k8sClient.Get(ctx, types.NamespacedName{
+		Namespace: cfApp.Namespace,
+		Name:      cfApp.Spec.CurrentDropletRef.Name,
+	}, cfDroplet)
+	if err != nil {
+		return nil, nil, k8s.NewNotReadyError().WithCause(err).WithReason("AppCurrentDropletNotFound")
+	}
+
+	if cfDroplet.Status.Droplet == nil {
+		return nil, nil, k8s.NewNotReadyError().WithCause(errors.New("droplet build status not set")).WithReason("DropletBuildStatusNotSet")
+	}
+
+	return cfApp, cfDroplet, nil
+}
+
+func (r *ScheduleReconciler) createOrPatchScheduleWorkload(
+	ctx context.Context,
+	cfTaskSchedule *korifiv1alpha1.CFTaskSchedule,
+	cfDroplet *korifiv1alpha1.CFBuild,
+	webProcess korifiv1alpha1.CFProcess,
+	env []corev1.EnvVar,
+) (*korifiv1alpha1.TaskScheduleWorkload, error) {
+	log := logr.FromContextOrDiscard(ctx).WithName("createOrPatchScheduleWorkload")
+
+	placement, err := shared.GetWorkloadPlacement(ctx, r.k8sClient, cfTaskSchedule.Namespace)
+	if err != nil {
+		log.Info("failed to get the workload placement", "reason", err)
+		return nil, err
+	}
+
+	scheduleWorkload := &korifiv1alpha1.TaskScheduleWorkload{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cfTaskSchedule.Name,
+			Namespace: cfTaskSchedule.Namespace,
+		},
+	}
+
+	opResult, err := controllerutil.CreateOrPatch(ctx, r.k8sClient, scheduleWorkload, func() error {
+		if scheduleWorkload.Labels == nil {
+			scheduleWorkload.Labels = map[string]string{}
+		}
+
+		scheduleWorkload.Labels[korifiv1alpha1.CFTaskScheduleGUIDLabelKey] = cfTaskSchedule.Name
+
+		scheduleWorkload.Spec.Schedule = cfTaskSchedule.Spec.Schedule
+		scheduleWorkload.Spec.ConcurrencyPolicy = cfTaskSchedule.Spec.ConcurrencyPolicy
+		scheduleWorkload.Spec.Suspend = cfTaskSchedule.Spec.Suspend
+		scheduleWorkload.Spec.Template = taskWorkloadSpec(
+			cfTaskSchedule.Spec.Command,
+			r.memoryMB(cfTaskSchedule),
+			r.diskQuotaMB(cfTaskSchedule),
+			cfTaskSchedule.Spec.TimeoutSeconds,
+			cfDroplet,
+			webProcess,
+			r.cfProcessDefaults.CPUMillicoresPerGB,
+			env,
+			placement,
+		)
+
+		return ctrl.SetControllerReference(cfTaskSchedule, scheduleWorkload, r.scheme)
+	})
+	if err != nil {
+		log.Info("error-creating-or-patching-task-schedule-workload", "opResult", opResult, "reason", err)
+		return nil, err
+	}
+
+	return scheduleWorkload, nil
+}
+
+// recordRuns creates a CFTask for each run the runner has started. The task
+// has the same name as the run, so that its TaskWorkload adopts the run rather
+// than starting another one.
+func (r *ScheduleReconciler) recordRuns(ctx context.Context, cfTaskSchedule *korifiv1alpha1.CFTaskSchedule, cfDroplet *korifiv1alpha1.CFBuild, runs []string) error {
+	for _, run := range runs {
+		cfTask := &korifiv1alpha1.CFTask{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      run,
+				Namespace: cfTaskSchedule.Namespace,
+				Labels: map[string]string{
+					korifiv1alpha1.CFTaskScheduleGUIDLabelKey: cfTaskSchedule.Name,
+				},
+			},
+			Spec: korifiv1alpha1.CFTaskSpec{
+				DisplayName:    cfTaskSchedule.Spec.DisplayName,
+				Command:        cfTaskSchedule.Spec.Command,
+				AppRef:         cfTaskSchedule.Spec.AppRef,
+				DropletRef:     corev1.LocalObjectReference{Name: cfDroplet.Name},
+				MemoryMB:       cfTaskSchedule.Spec.MemoryMB,
+				DiskQuotaMB:    cfTaskSchedule.Spec.DiskQuotaMB,
+				TimeoutSeconds: cfTaskSchedule.Spec.TimeoutSeconds,
+			},
+		}
+
+		err := r.k8sClient.Create(ctx, cfTask)
+		if err != nil && !k8serrors.IsAlreadyExists(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *ScheduleReconciler) memoryMB(cfTaskSchedule *korifiv1alpha1.CFTaskSchedule) int64 {
+	if cfTaskSchedule.Spec.MemoryMB != 0 {
+		return cfTaskSchedule.Spec.MemoryMB
+	}
+	return r.cfProcessDefaults.MemoryMB
+}
+
+func (r *ScheduleReconciler) diskQuotaMB(cfTaskSchedule *korifiv1alpha1.CFTaskSchedule) int64 {
+	if cfTaskSchedule.Spec.DiskQuotaMB != 0 {
+		return cfTaskSchedule.Spec.DiskQuotaMB
+	}
+	return r.cfProcessDefaults.DiskQuotaMB
+}
@@ -0,0 +1,212 @@
+package tasks_test
+
+import (
+	"time"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools/k8s"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("CFTaskSchedule Reconciler Integration Tests", func() {
+	var (
+		cfApp          *korifiv1alpha1.CFApp
+		cfDroplet      *korifiv1alpha1.CFBuild
+		cfTaskSchedule *korifiv1alpha1.CFTaskSchedule
+	)
+
+	BeforeEach(func() {
+		cfAppName := uuid.NewString()
+
+		cfDroplet = &korifiv1alpha1.CFBuild{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: testNamespace,
+				Name:      uuid.NewString(),
+			},
+			Spec: korifiv1alpha1.CFBuildSpec{
+				PackageRef: corev1.LocalObjectReference{Name: uuid.NewString()},
+				AppRef:     corev1.LocalObjectReference{Name: cfAppName},
+				Lifecycle:  korifiv1alpha1.Lifecycle{Type: "buildpack"},
+			},
+		}
+		Expect(adminClient.Create(ctx, cfDroplet)).To(Succeed())
+		Expect(k8s.Patch(ctx, adminClient, cfDroplet, func() {
+			cfDroplet.Status.Droplet = &korifiv1alpha1.BuildDropletStatus{
+				Registry: korifiv1alpha1.Registry{
+					Image: "registry.io/my/image",
+				},
+			}
+		})).To(Succeed())
+
+		Expect(adminClient.Create(ctx, &korifiv1alpha1.CFProcess{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: testNamespace,
+				Name:      uuid.NewString(),
+				Labels: map[string]string{
+					korifiv1alpha1.CFProcessTypeLabelKey: "web",
+					korifiv1alpha1.CFAppGUIDLabelKey:     cfAppName,
+				},
+			},
+			Spec: korifiv1alpha1.CFProcessSpec{
+				AppRef:      corev1.LocalObjectReference{Name: cfAppName},
+				ProcessType: "web",
+				MemoryMB:    1024,
+				HealthCheck: korifiv1alpha1.HealthCheck{Type: "process"},
+			},
+		})).To(Succeed())
+
+		cfApp = &korifiv1alpha1.CFApp{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: testNamespace,
+				Name:      cfAppName,
+			},
+			Spec: korifiv1alpha1.CFAppSpec{
+				Lifecycle:         korifiv1alpha1.Lifecycle{Type: "buildpack"},
+				CurrentDropletRef: corev1.LocalObjectReference{Name: cfDroplet.Name},
+				DesiredState:      "STOPPED",
+				DisplayName:       "app",
+			},
+		}
+		Expect(adminClient.Create(ctx, cfApp)).To(Succeed())
+		Expect(k8s.Patch(ctx, adminClient, cfApp, func() {
+			meta.SetStatusCondition(&cfApp.Status.Conditions, k8s.NewReadyConditionBuilder(cfApp).Ready().Build())
+		})).To(Succeed())
+
+		cfTaskSchedule = &korifiv1alpha1.CFTaskSchedule{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: testNamespace,
+				Name:      uuid.NewString(),
+				Labels: map[string]string{
+					korifiv1alpha1.CFAppGUIDLabelKey: cfApp.Name,
+				},
+			},
+			Spec: korifiv1alpha1.CFTaskScheduleSpec{
+				DisplayName:       "nightly",
+				AppRef:            corev1.LocalObjectReference{Name: cfApp.Name},
+				Command:           "rake db:cleanup",
+				Schedule:          "0 0 * * *",
+				ConcurrencyPolicy: korifiv1alpha1.ConcurrencyPolicyForbid,
+				TimeoutSeconds:    600,
+			},
+		}
+		Expect(adminClient.Create(ctx, cfTaskSchedule)).To(Succeed())
+	})
+
+	getScheduleWorkload := func(g Gomega) *korifiv1alpha1.TaskScheduleWorkload {
+		scheduleWorkload := &korifiv1alpha1.TaskScheduleWorkload{}
+		g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfTaskSchedule), scheduleWorkload)).To(Succeed())
+		return scheduleWorkload
+	}
+
+	It("becomes ready", func() {
+		Eventually(func(g Gomega) {
+			g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfTaskSchedule), cfTaskSchedule)).To(Succeed())
+			g.Expect(meta.IsStatusConditionTrue(cfTaskSchedule.Status.Conditions, korifiv1alpha1.StatusConditionReady)).To(BeTrue())
+			g.Expect(cfTaskSchedule.Status.ObservedGeneration).To(Equal(cfTaskSchedule.Generation))
+		}).Should(Succeed())
+	})
+
+	It("creates a TaskScheduleWorkload", func() {
+		Eventually(func(g Gomega) {
+			scheduleWorkload := getScheduleWorkload(g)
+			g.Expect(scheduleWorkload.Labels).To(HaveKeyWithValue(korifiv1alpha1.CFTaskScheduleGUIDLabelKey, cfTaskSchedule.Name))
+			g.Expect(scheduleWorkload.Spec.Schedule).To(Equal("0 0 * * *"))
+			g.Expect(scheduleWorkload.Spec.ConcurrencyPolicy).To(Equal(korifiv1alpha1.ConcurrencyPolicyForbid))
+			g.Expect(scheduleWorkload.Spec.Suspend).To(BeFalse())
+			g.Expect(scheduleWorkload.Spec.Template.Image).To(Equal("registry.io/my/image"))
+			g.Expect(scheduleWorkload.Spec.Template.Command).To(Equal([]string{"/cnb/lifecycle/launcher", "rake db:cleanup"}))
+			g.Expect(scheduleWorkload.Spec.Template.Resources.Limits.Memory().String()).To(Equal("500M"))
+			g.Expect(scheduleWorkload.Spec.Template.Resources.Limits.StorageEphemeral().String()).To(Equal("512M"))
+			g.Expect(scheduleWorkload.Spec.Template.Resources.Requests.Cpu().String()).To(Equal("100m"))
+			g.Expect(scheduleWorkload.Spec.Template.ActiveDeadlineSeconds).To(PointTo(BeEquivalentTo(600)))
+			g.Expect(scheduleWorkload.GetOwnerReferences()).To(ConsistOf(SatisfyAll(
+				HaveField("Name", cfTaskSchedule.Name),
+				HaveField("Controller", PointTo(BeTrue())),
+			)))
+		}).Should(Succeed())
+	})
+
+	When("the schedule is suspended", func() {
+		BeforeEach(func() {
+			Expect(k8s.PatchResource(ctx, adminClient, cfTaskSchedule, func() {
+				cfTaskSchedule.Spec.Suspend = true
+			})).To(Succeed())
+		})
+
+		It("suspends the TaskScheduleWorkload", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(getScheduleWorkload(g).Spec.Suspend).To(BeTrue())
+			}).Should(Succeed())
+		})
+	})
+
+	When("the runner reports runs", func() {
+		var (
+			runName          string
+			lastScheduleTime metav1.Time
+		)
+
+		BeforeEach(func() {
+			runName = cfTaskSchedule.Name + "-29000000"
+			lastScheduleTime = metav1.NewTime(time.Now().Truncate(time.Second))
+
+			Eventually(func(g Gomega) {
+				scheduleWorkload := getScheduleWorkload(g)
+				g.Expect(k8s.Patch(ctx, adminClient, scheduleWorkload, func() {
+					scheduleWorkload.Status.Runs = []string{runName}
+					scheduleWorkload.Status.LastScheduleTime = &lastScheduleTime
+				})).To(Succeed())
+			}).Should(Succeed())
+		})
+
+		It("records each run as a task", func() {
+			Eventually(func(g Gomega) {
+				cfTask := &korifiv1alpha1.CFTask{}
+				g.Expect(adminClient.Get(ctx, client.ObjectKey{Namespace: testNamespace, Name: runName}, cfTask)).To(Succeed())
+				g.Expect(cfTask.Labels).To(HaveKeyWithValue(korifiv1alpha1.CFTaskScheduleGUIDLabelKey, cfTaskSchedule.Name))
+				g.Expect(cfTask.Spec.DisplayName).To(Equal("nightly"))
+				g.Expect(cfTask.Spec.Command).To(Equal("rake db:cleanup"))
+				g.Expect(cfTask.Spec.AppRef.Name).To(Equal(cfApp.Name))
+				g.Expect(cfTask.Spec.DropletRef.Name).To(Equal(cfDroplet.Name))
+				g.Expect(cfTask.Spec.TimeoutSeconds).To(BeEquivalentTo(600))
+			}).Should(Succeed())
+		})
+
+		It("reports the last schedule time", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfTaskSchedule), cfTaskSchedule)).To(Succeed())
+				g.Expect(cfTaskSchedule.Status.LastScheduleTime).To(PointTo(Equal(lastScheduleTime)))
+			}).Should(Succeed())
+		})
+	})
+
+	When("the app is not ready", func() {
+		BeforeEach(func() {
+			Expect(k8s.Patch(ctx, adminClient, cfApp, func() {
+				meta.SetStatusCondition(&cfApp.Status.Conditions, metav1.Condition{
+					Type:   korifiv1alpha1.StatusConditionReady,
+					Status: metav1.ConditionFalse,
+					Reason: "NotReady",
+				})
+			})).To(Succeed())
+		})
+
+		It("is not ready", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfTaskSchedule), cfTaskSchedule)).To(Succeed())
+				readyCondition := meta.FindStatusCondition(cfTaskSchedule.Status.Conditions, korifiv1alpha1.StatusConditionReady)
+				g.Expect(readyCondition).NotTo(BeNil())
+				g.Expect(readyCondition.Status).To(Equal(metav1.ConditionFalse))
+				g.Expect(readyCondition.Reason).To(Equal("AppNotReady"))
+			}).Should(Succeed())
+		})
+	})
+})
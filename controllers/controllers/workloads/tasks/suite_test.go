@@ -7,6 +7,7 @@ import (
 	"time"
 
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/config"
 	"code.cloudfoundry.org/korifi/controllers/controllers/shared"
 	"code.cloudfoundry.org/korifi/controllers/controllers/workloads/env"
 	"code.cloudfoundry.org/korifi/controllers/controllers/workloads/tasks"
@@ -77,8 +78,26 @@ var _ = BeforeSuite(func() {
 		k8sManager.GetScheme(),
 		eventRecorder,
 		ctrl.Log.WithName("controllers").WithName("CFTask"),
-		env.NewAppEnvBuilder(k8sManager.GetClient()),
+		env.NewAppEnvBuilder(k8sManager.GetClient(), "cf", korifiv1alpha1.RunningEnvironmentVariableGroupName),
 		2*time.Second,
+		config.CFProcessDefaults{
+			CPUMillicoresPerGB: 100,
+		},
+	).SetupWithManager(k8sManager)
+	Expect(err).NotTo(HaveOccurred())
+
+	err = tasks.NewScheduleReconciler(
+		k8sManager.GetClient(),
+		k8sManager.GetScheme(),
+		ctrl.Log.WithName("controllers").WithName("CFTaskSchedule"),
+		env.NewAppEnvBuilder(k8sManager.GetClient(), "cf", korifiv1alpha1.RunningEnvironmentVariableGroupName),
+		config.CFProcessDefaults{
+			MemoryMB:           500,
+			DiskQuotaMB:        512,
+			CPUMillicoresPerGB: 100,
+		},
+		"cf",
+		false,
 	).SetupWithManager(k8sManager)
 	Expect(err).NotTo(HaveOccurred())
 
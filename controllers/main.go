@@ -30,13 +30,16 @@ import (
 	"code.cloudfoundry.org/korifi/controllers/controllers/networking/domains"
 	"code.cloudfoundry.org/korifi/controllers/controllers/networking/routes"
 	"code.cloudfoundry.org/korifi/controllers/controllers/services/bindings"
+	kubernetes_bindings "code.cloudfoundry.org/korifi/controllers/controllers/services/bindings/kubernetes"
 	managed_bindings "code.cloudfoundry.org/korifi/controllers/controllers/services/bindings/managed"
 	upsi_bindings "code.cloudfoundry.org/korifi/controllers/controllers/services/bindings/upsi"
 	"code.cloudfoundry.org/korifi/controllers/controllers/services/brokers"
+	kubernetes_instances "code.cloudfoundry.org/korifi/controllers/controllers/services/instances/kubernetes"
 	"code.cloudfoundry.org/korifi/controllers/controllers/services/instances/managed"
 	upsi_instances "code.cloudfoundry.org/korifi/controllers/controllers/services/instances/upsi"
 	"code.cloudfoundry.org/korifi/controllers/controllers/services/osbapi"
 	"code.cloudfoundry.org/korifi/controllers/controllers/shared"
+	"code.cloudfoundry.org/korifi/controllers/controllers/usage"
 	"code.cloudfoundry.org/korifi/controllers/controllers/workloads/apps"
 	"code.cloudfoundry.org/korifi/controllers/controllers/workloads/build/buildpack"
 	"code.cloudfoundry.org/korifi/controllers/controllers/workloads/build/docker"
@@ -64,6 +67,7 @@ import (
 	taskswebhook "code.cloudfoundry.org/korifi/controllers/webhooks/workloads/tasks"
 	"code.cloudfoundry.org/korifi/tools"
 	"code.cloudfoundry.org/korifi/tools/image"
+	"code.cloudfoundry.org/korifi/tools/registry"
 	"code.cloudfoundry.org/korifi/version"
 
 	buildv1alpha2 "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
@@ -165,6 +169,12 @@ func main() {
 	if os.Getenv("ENABLE_CONTROLLERS") != "false" {
 		controllersLog := ctrl.Log.WithName("controllers")
 		imageClient := image.NewClient(k8sClient)
+		usageRecorder := usage.NewRecorder(mgr.GetClient(), mgr.GetAPIReader(), controllerConfig.CFRootNamespace)
+		rootNamespaceRegistryCreds := image.Creds{
+			Namespace:   controllerConfig.CFRootNamespace,
+			SecretNames: controllerConfig.ContainerRegistrySecretNames,
+		}
+		repositoryManager := registry.NewRepositoryManager(controllerConfig.ContainerRegistryType, imageClient, rootNamespaceRegistryCreds)
 
 		if err = apps.NewReconciler(
 			mgr.GetClient(),
@@ -172,19 +182,22 @@ func main() {
 			controllersLog,
 			env.NewVCAPServicesEnvValueBuilder(mgr.GetClient()),
 			env.NewVCAPApplicationEnvValueBuilder(mgr.GetClient(), controllerConfig.ExtraVCAPApplicationValues),
+			usageRecorder,
+			repositoryManager,
+			controllerConfig.ContainerRepositoryPrefix,
 		).SetupWithManager(mgr); err != nil {
 			setupLog.Error(err, "unable to create controller", "controller", "CFApp")
 			os.Exit(1)
 		}
 
-		buildCleaner := cleanup.NewBuildCleaner(mgr.GetClient(), controllerConfig.MaxRetainedBuildsPerApp)
+		buildCleaner := cleanup.NewBuildCleaner(mgr.GetClient(), imageClient, controllerConfig.ContainerRegistrySecretNames, controllerConfig.MaxRetainedBuildsPerApp)
 		if err = buildpack.NewReconciler(
 			mgr.GetClient(),
 			buildCleaner,
 			mgr.GetScheme(),
 			controllersLog,
 			controllerConfig,
-			env.NewAppEnvBuilder(mgr.GetClient()),
+			env.NewAppEnvBuilder(mgr.GetClient(), controllerConfig.CFRootNamespace, korifiv1alpha1.StagingEnvironmentVariableGroupName),
 		).SetupWithManager(mgr); err != nil {
 			setupLog.Error(err, "unable to create controller", "controller", "CFBuildpackBuild")
 			os.Exit(1)
@@ -218,7 +231,8 @@ func main() {
 			mgr.GetScheme(),
 			controllersLog,
 			controllerConfig,
-			env.NewProcessEnvBuilder(mgr.GetClient()),
+			env.NewProcessEnvBuilder(mgr.GetClient(), controllerConfig.CFRootNamespace),
+			usageRecorder,
 		).SetupWithManager(mgr); err != nil {
 			setupLog.Error(err, "unable to create controller", "controller", "CFProcess")
 			os.Exit(1)
@@ -228,11 +242,29 @@ func main() {
 			mgr.GetClient(),
 			mgr.GetScheme(),
 			controllersLog,
+			usageRecorder,
 		)).SetupWithManager(mgr); err != nil {
 			setupLog.Error(err, "unable to create controller", "controller", "UPSICFServiceInstance")
 			os.Exit(1)
 		}
 
+		if err = (kubernetes_instances.NewReconciler(
+			mgr.GetClient(),
+			mgr.GetScheme(),
+			controllersLog,
+			usageRecorder,
+		)).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "KubernetesCFServiceInstance")
+			os.Exit(1)
+		}
+
+		var credentialRotationGracePeriod time.Duration
+		credentialRotationGracePeriod, err = controllerConfig.ParseCredentialRotationGracePeriod()
+		if err != nil {
+			setupLog.Error(err, "failed to parse credential rotation grace period", "controller", "CFServiceBinding", "credentialRotationGracePeriod", controllerConfig.CredentialRotationGracePeriod)
+			os.Exit(1)
+		}
+
 		if err = (bindings.NewReconciler(
 			mgr.GetClient(),
 			mgr.GetScheme(),
@@ -243,7 +275,10 @@ func main() {
 				osbapi.NewClientFactory(mgr.GetClient(), controllerConfig.TrustInsecureServiceBrokers),
 				controllerConfig.CFRootNamespace,
 				mgr.GetScheme(),
+				credentialRotationGracePeriod,
+				controllerConfig.VolumeServices,
 			),
+			kubernetes_bindings.NewReconciler(mgr.GetClient(), mgr.GetScheme()),
 		)).SetupWithManager(mgr); err != nil {
 			setupLog.Error(err, "unable to create controller", "controller", "CFServiceBinding")
 			os.Exit(1)
@@ -290,13 +325,27 @@ func main() {
 			mgr.GetScheme(),
 			mgr.GetEventRecorderFor("cftask-controller"),
 			controllersLog,
-			env.NewAppEnvBuilder(mgr.GetClient()),
+			env.NewAppEnvBuilder(mgr.GetClient(), controllerConfig.CFRootNamespace, korifiv1alpha1.RunningEnvironmentVariableGroupName),
 			taskTTL,
+			controllerConfig.CFProcessDefaults,
 		).SetupWithManager(mgr); err != nil {
 			setupLog.Error(err, "unable to create controller", "controller", "CFTask")
 			os.Exit(1)
 		}
 
+		if err = tasks.NewScheduleReconciler(
+			mgr.GetClient(),
+			mgr.GetScheme(),
+			controllersLog,
+			env.NewAppEnvBuilder(mgr.GetClient(), controllerConfig.CFRootNamespace, korifiv1alpha1.RunningEnvironmentVariableGroupName),
+			controllerConfig.CFProcessDefaults,
+			controllerConfig.CFRootNamespace,
+			controllerConfig.RollOutRunningEnvVarGroup,
+		).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "CFTaskSchedule")
+			os.Exit(1)
+		}
+
 		if err = domains.NewReconciler(
 			mgr.GetClient(),
 			mgr.GetScheme(),
@@ -307,11 +356,18 @@ func main() {
 		}
 
 		if controllerConfig.ExperimentalManagedServicesEnabled {
+			var catalogResyncInterval time.Duration
+			catalogResyncInterval, err = controllerConfig.ParseServiceBrokerCatalogResyncInterval()
+			if err != nil {
+				setupLog.Error(err, "failed to parse service broker catalog resync interval", "controller", "CFServiceBroker", "serviceBrokerCatalogResyncInterval", controllerConfig.ServiceBrokerCatalogResyncInterval)
+				os.Exit(1)
+			}
 			if err = brokers.NewReconciler(
 				mgr.GetClient(),
 				osbapi.NewClientFactory(mgr.GetClient(), controllerConfig.TrustInsecureServiceBrokers),
 				mgr.GetScheme(),
 				controllersLog,
+				catalogResyncInterval,
 			).SetupWithManager(mgr); err != nil {
 				setupLog.Error(err, "unable to create controller", "controller", "CFServiceBroker")
 				os.Exit(1)
@@ -323,12 +379,35 @@ func main() {
 				mgr.GetScheme(),
 				controllerConfig.CFRootNamespace,
 				controllersLog,
+				usageRecorder,
 			).SetupWithManager(mgr); err != nil {
 				setupLog.Error(err, "unable to create controller", "controller", "ManagedCFServiceInstance")
 				os.Exit(1)
 			}
 		}
 
+		if controllerConfig.RegistrySweeper.Enabled {
+			var registrySweeperInterval time.Duration
+			registrySweeperInterval, err = controllerConfig.ParseRegistrySweeperInterval()
+			if err != nil {
+				setupLog.Error(err, "failed to parse registry sweeper interval", "registrySweeperInterval", controllerConfig.RegistrySweeper.Interval)
+				os.Exit(1)
+			}
+			if err = mgr.Add(cleanup.NewRegistrySweeper(
+				mgr.GetClient(),
+				imageClient,
+				repositoryManager,
+				rootNamespaceRegistryCreds,
+				controllerConfig.ContainerRepositoryPrefix,
+				registrySweeperInterval,
+				controllerConfig.RegistrySweeper.DryRun,
+				controllersLog,
+			)); err != nil {
+				setupLog.Error(err, "unable to add registry sweeper")
+				os.Exit(1)
+			}
+		}
+
 		//+kubebuilder:scaffold:builder
 
 		// Setup Index with Manager
@@ -386,8 +465,11 @@ func main() {
 			os.Exit(1)
 		}
 
+		featureFlagValidator := validation.NewFeatureFlagValidator(uncachedClient, controllerConfig.CFRootNamespace)
+
 		if err = appswebhook.NewValidator(
 			validation.NewDuplicateValidator(coordination.NewNameRegistry(uncachedClient, appswebhook.AppEntityType)),
+			featureFlagValidator,
 		).SetupWebhookWithManager(mgr); err != nil {
 			setupLog.Error(err, "unable to create webhook", "webhook", "CFApp")
 			os.Exit(1)
@@ -404,6 +486,7 @@ func main() {
 
 		if err = instanceswebhook.NewValidator(
 			validation.NewDuplicateValidator(coordination.NewNameRegistry(uncachedClient, instanceswebhook.ServiceInstanceEntityType)),
+			featureFlagValidator,
 		).SetupWebhookWithManager(mgr); err != nil {
 			setupLog.Error(err, "unable to create webhook", "webhook", "CFServiceInstance")
 			os.Exit(1)
@@ -464,7 +547,7 @@ func main() {
 		versionwebhook.NewVersionWebhook(version.Version).SetupWebhookWithManager(mgr)
 		controllersfinalizer.NewControllersFinalizerWebhook().SetupWebhookWithManager(mgr)
 
-		if err = packageswebhook.NewValidator().SetupWebhookWithManager(mgr); err != nil {
+		if err = packageswebhook.NewValidator(featureFlagValidator).SetupWebhookWithManager(mgr); err != nil {
 			setupLog.Error(err, "unable to create webhook", "webhook", "CFPackage")
 			os.Exit(1)
 		}
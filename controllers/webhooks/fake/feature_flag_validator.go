@@ -0,0 +1,114 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fake
+
+import (
+	"context"
+	"sync"
+
+	"code.cloudfoundry.org/korifi/controllers/webhooks"
+)
+
+type FeatureFlagValidator struct {
+	ValidateFeatureEnabledStub        func(context.Context, string) error
+	validateFeatureEnabledMutex       sync.RWMutex
+	validateFeatureEnabledArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	validateFeatureEnabledReturns struct {
+		result1 error
+	}
+	validateFeatureEnabledReturnsOnCall map[int]struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FeatureFlagValidator) ValidateFeatureEnabled(arg1 context.Context, arg2 string) error {
+	fake.validateFeatureEnabledMutex.Lock()
+	ret, specificReturn := fake.validateFeatureEnabledReturnsOnCall[len(fake.validateFeatureEnabledArgsForCall)]
+	fake.validateFeatureEnabledArgsForCall = append(fake.validateFeatureEnabledArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.ValidateFeatureEnabledStub
+	fakeReturns := fake.validateFeatureEnabledReturns
+	fake.recordInvocation("ValidateFeatureEnabled", []interface{}{arg1, arg2})
+	fake.validateFeatureEnabledMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FeatureFlagValidator) ValidateFeatureEnabledCallCount() int {
+	fake.validateFeatureEnabledMutex.RLock()
+	defer fake.validateFeatureEnabledMutex.RUnlock()
+	return len(fake.validateFeatureEnabledArgsForCall)
+}
+
+func (fake *FeatureFlagValidator) ValidateFeatureEnabledCalls(stub func(context.Context, string) error) {
+	fake.validateFeatureEnabledMutex.Lock()
+	defer fake.validateFeatureEnabledMutex.Unlock()
+	fake.ValidateFeatureEnabledStub = stub
+}
+
+func (fake *FeatureFlagValidator) ValidateFeatureEnabledArgsForCall(i int) (context.Context, string) {
+	fake.validateFeatureEnabledMutex.RLock()
+	defer fake.validateFeatureEnabledMutex.RUnlock()
+	argsForCall := fake.validateFeatureEnabledArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FeatureFlagValidator) ValidateFeatureEnabledReturns(result1 error) {
+	fake.validateFeatureEnabledMutex.Lock()
+	defer fake.validateFeatureEnabledMutex.Unlock()
+	fake.ValidateFeatureEnabledStub = nil
+	fake.validateFeatureEnabledReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FeatureFlagValidator) ValidateFeatureEnabledReturnsOnCall(i int, result1 error) {
+	fake.validateFeatureEnabledMutex.Lock()
+	defer fake.validateFeatureEnabledMutex.Unlock()
+	fake.ValidateFeatureEnabledStub = nil
+	if fake.validateFeatureEnabledReturnsOnCall == nil {
+		fake.validateFeatureEnabledReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.validateFeatureEnabledReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FeatureFlagValidator) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.validateFeatureEnabledMutex.RLock()
+	defer fake.validateFeatureEnabledMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FeatureFlagValidator) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ webhooks.FeatureFlagValidator = new(FeatureFlagValidator)
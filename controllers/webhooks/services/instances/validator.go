@@ -25,14 +25,16 @@ var cfserviceinstancelog = logf.Log.WithName("cfserviceinstance-validate")
 //+kubebuilder:webhook:path=/validate-korifi-cloudfoundry-org-v1alpha1-cfserviceinstance,mutating=false,failurePolicy=fail,sideEffects=NoneOnDryRun,groups=korifi.cloudfoundry.org,resources=cfserviceinstances,verbs=create;update;delete,versions=v1alpha1,name=vcfserviceinstance.korifi.cloudfoundry.org,admissionReviewVersions={v1,v1beta1}
 
 type Validator struct {
-	duplicateValidator webhooks.NameValidator
+	duplicateValidator   webhooks.NameValidator
+	featureFlagValidator webhooks.FeatureFlagValidator
 }
 
 var _ webhook.CustomValidator = &Validator{}
 
-func NewValidator(duplicateValidator webhooks.NameValidator) *Validator {
+func NewValidator(duplicateValidator webhooks.NameValidator, featureFlagValidator webhooks.FeatureFlagValidator) *Validator {
 	return &Validator{
-		duplicateValidator: duplicateValidator,
+		duplicateValidator:   duplicateValidator,
+		featureFlagValidator: featureFlagValidator,
 	}
 }
 
@@ -49,6 +51,10 @@ func (v *Validator) ValidateCreate(ctx context.Context, obj runtime.Object) (adm
 		return nil, apierrors.NewBadRequest(fmt.Sprintf("expected a CFServiceInstance but got a %T", obj))
 	}
 
+	if err := v.validateSharing(ctx, serviceInstance); err != nil {
+		return nil, err
+	}
+
 	return nil, v.duplicateValidator.ValidateCreate(ctx, cfserviceinstancelog, serviceInstance.Namespace, serviceInstance)
 }
 
@@ -73,9 +79,29 @@ func (v *Validator) ValidateUpdate(ctx context.Context, oldObj, obj runtime.Obje
 			Message: fmt.Sprintf(validationwebhook.ImmutableFieldErrorMessageTemplate, "CFServiceInstance.Spec.Type"),
 		}.ExportJSONError()
 	}
+
+	if err := v.validateSharing(ctx, serviceInstance); err != nil {
+		return nil, err
+	}
+
 	return nil, v.duplicateValidator.ValidateUpdate(ctx, cfserviceinstancelog, serviceInstance.Namespace, oldServiceInstance, serviceInstance)
 }
 
+// validateSharing enforces the service_instance_sharing feature flag on
+// `kubernetes` service instances referring to a service in another namespace,
+// which is how services are shared across spaces
+func (v *Validator) validateSharing(ctx context.Context, serviceInstance *korifiv1alpha1.CFServiceInstance) error {
+	if serviceInstance.Spec.Type != korifiv1alpha1.KubernetesType || serviceInstance.Spec.ServiceRef == nil {
+		return nil
+	}
+
+	if serviceInstance.Spec.ServiceRef.Namespace == "" || serviceInstance.Spec.ServiceRef.Namespace == serviceInstance.Namespace {
+		return nil
+	}
+
+	return v.featureFlagValidator.ValidateFeatureEnabled(ctx, korifiv1alpha1.FeatureFlagServiceInstanceSharing)
+}
+
 func (v *Validator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
 	serviceInstance, ok := obj.(*korifiv1alpha1.CFServiceInstance)
 	if !ok {
@@ -14,6 +14,7 @@ import (
 	"github.com/google/uuid"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
@@ -24,11 +25,12 @@ var _ = Describe("CFServiceInstanceValidatingWebhook", func() {
 	)
 
 	var (
-		ctx                context.Context
-		duplicateValidator *fake.NameValidator
-		serviceInstance    *korifiv1alpha1.CFServiceInstance
-		validatingWebhook  *instances.Validator
-		retErr             error
+		ctx                  context.Context
+		duplicateValidator   *fake.NameValidator
+		featureFlagValidator *fake.FeatureFlagValidator
+		serviceInstance      *korifiv1alpha1.CFServiceInstance
+		validatingWebhook    *instances.Validator
+		retErr               error
 	)
 
 	BeforeEach(func() {
@@ -50,7 +52,8 @@ var _ = Describe("CFServiceInstanceValidatingWebhook", func() {
 		}
 
 		duplicateValidator = new(fake.NameValidator)
-		validatingWebhook = instances.NewValidator(duplicateValidator)
+		featureFlagValidator = new(fake.FeatureFlagValidator)
+		validatingWebhook = instances.NewValidator(duplicateValidator, featureFlagValidator)
 	})
 
 	Describe("ValidateCreate", func() {
@@ -80,6 +83,47 @@ var _ = Describe("CFServiceInstanceValidatingWebhook", func() {
 				Expect(retErr).To(MatchError("foo"))
 			})
 		})
+
+		It("does not check the service_instance_sharing feature flag", func() {
+			Expect(featureFlagValidator.ValidateFeatureEnabledCallCount()).To(BeZero())
+		})
+
+		When("the instance refers to a kubernetes service in another namespace", func() {
+			BeforeEach(func() {
+				serviceInstance.Spec.Type = korifiv1alpha1.KubernetesType
+				serviceInstance.Spec.ServiceRef = &corev1.ObjectReference{
+					Kind:      "Secret",
+					Namespace: "other-namespace",
+					Name:      "my-secret",
+				}
+			})
+
+			It("checks the service_instance_sharing feature flag", func() {
+				Expect(featureFlagValidator.ValidateFeatureEnabledCallCount()).To(Equal(1))
+				_, actualName := featureFlagValidator.ValidateFeatureEnabledArgsForCall(0)
+				Expect(actualName).To(Equal(korifiv1alpha1.FeatureFlagServiceInstanceSharing))
+			})
+
+			When("service instance sharing is disabled", func() {
+				BeforeEach(func() {
+					featureFlagValidator.ValidateFeatureEnabledReturns(errors.New("sharing-disabled"))
+				})
+
+				It("denies the request", func() {
+					Expect(retErr).To(MatchError("sharing-disabled"))
+				})
+			})
+
+			When("the service is in the instance namespace", func() {
+				BeforeEach(func() {
+					serviceInstance.Spec.ServiceRef.Namespace = defaultNamespace
+				})
+
+				It("does not check the service_instance_sharing feature flag", func() {
+					Expect(featureFlagValidator.ValidateFeatureEnabledCallCount()).To(BeZero())
+				})
+			})
+		})
 	})
 
 	Describe("ValidateUpdate", func() {
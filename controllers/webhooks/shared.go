@@ -32,6 +32,12 @@ type NamespaceValidator interface {
 	ValidateSpaceCreate(space korifiv1alpha1.CFSpace) error
 }
 
+//counterfeiter:generate -o fake -fake-name FeatureFlagValidator . FeatureFlagValidator
+
+type FeatureFlagValidator interface {
+	ValidateFeatureEnabled(ctx context.Context, name string) error
+}
+
 //go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
 //counterfeiter:generate -o fake -fake-name NameRegistry . NameRegistry
 
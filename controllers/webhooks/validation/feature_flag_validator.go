@@ -0,0 +1,123 @@
+package validation
+
+import (
+	"context"
+	"fmt"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+
+	authv1 "k8s.io/api/authorization/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+const (
+	FeatureDisabledErrorType            = "FeatureDisabledError"
+	FeatureDisabledErrorMessageTemplate = "Feature Disabled: %s"
+)
+
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cffeatureflags,verbs=get;list;watch
+//+kubebuilder:rbac:groups=authorization.k8s.io,resources=subjectaccessreviews,verbs=create
+
+type FeatureFlagValidator struct {
+	client        client.Client
+	rootNamespace string
+}
+
+func NewFeatureFlagValidator(client client.Client, rootNamespace string) *FeatureFlagValidator {
+	return &FeatureFlagValidator{client: client, rootNamespace: rootNamespace}
+}
+
+// ValidateFeatureEnabled returns a feature disabled error when the named
+// feature flag is turned off, unless the request under admission comes from an
+// admin. As in CF, admins are not subject to feature flags.
+func (v FeatureFlagValidator) ValidateFeatureEnabled(ctx context.Context, name string) error {
+	err := v.validateFeatureFlag(ctx, name)
+	if err == nil || !isFeatureDisabledError(err) {
+		return err
+	}
+
+	isAdmin, reviewErr := v.isAdminRequest(ctx)
+	if reviewErr != nil {
+		return reviewErr
+	}
+	if isAdmin {
+		return nil
+	}
+
+	return err
+}
+
+func (v FeatureFlagValidator) validateFeatureFlag(ctx context.Context, name string) error {
+	featureFlag := korifiv1alpha1.CFFeatureFlag{}
+	err := v.client.Get(ctx, types.NamespacedName{Namespace: v.rootNamespace, Name: name}, &featureFlag)
+	if err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return fmt.Errorf("failed to get feature flag %q: %w", name, err)
+		}
+
+		if korifiv1alpha1.FeatureFlagDefaults[name] {
+			return nil
+		}
+
+		return featureDisabledError(name)
+	}
+
+	if featureFlag.Spec.Enabled {
+		return nil
+	}
+
+	if featureFlag.Spec.CustomErrorMessage != "" {
+		return featureDisabledError(featureFlag.Spec.CustomErrorMessage)
+	}
+
+	return featureDisabledError(name)
+}
+
+// isAdminRequest tells whether the user making the request under admission can
+// patch feature flags, which only admins are allowed to
+func (v FeatureFlagValidator) isAdminRequest(ctx context.Context) (bool, error) {
+	req, err := admission.RequestFromContext(ctx)
+	if err != nil {
+		return false, nil
+	}
+
+	extra := map[string]authv1.ExtraValue{}
+	for key, value := range req.UserInfo.Extra {
+		extra[key] = authv1.ExtraValue(value)
+	}
+
+	review := authv1.SubjectAccessReview{
+		Spec: authv1.SubjectAccessReviewSpec{
+			User:   req.UserInfo.Username,
+			Groups: req.UserInfo.Groups,
+			UID:    req.UserInfo.UID,
+			Extra:  extra,
+			ResourceAttributes: &authv1.ResourceAttributes{
+				Namespace: v.rootNamespace,
+				Verb:      "patch",
+				Group:     "korifi.cloudfoundry.org",
+				Resource:  "cffeatureflags",
+			},
+		},
+	}
+	if err = v.client.Create(ctx, &review); err != nil {
+		return false, fmt.Errorf("failed to review the access of %q: %w", req.UserInfo.Username, err)
+	}
+
+	return review.Status.Allowed, nil
+}
+
+func isFeatureDisabledError(err error) bool {
+	validationErr, ok := WebhookErrorToValidationError(err)
+	return ok && validationErr.Type == FeatureDisabledErrorType
+}
+
+func featureDisabledError(detail string) error {
+	return ValidationError{
+		Type:    FeatureDisabledErrorType,
+		Message: fmt.Sprintf(FeatureDisabledErrorMessageTemplate, detail),
+	}.ExportJSONError()
+}
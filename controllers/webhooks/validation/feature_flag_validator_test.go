@@ -0,0 +1,184 @@
+package validation_test
+
+import (
+	"context"
+	"errors"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/fake"
+	"code.cloudfoundry.org/korifi/controllers/webhooks/validation"
+	"code.cloudfoundry.org/korifi/tests/matchers"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authv1 "k8s.io/api/authorization/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+var _ = Describe("FeatureFlagValidator", func() {
+	var (
+		fakeClient           *fake.Client
+		featureFlagValidator *validation.FeatureFlagValidator
+		featureFlagName      string
+		ctx                  context.Context
+		validationErr        error
+	)
+
+	BeforeEach(func() {
+		fakeClient = new(fake.Client)
+		ctx = context.Background()
+		featureFlagName = korifiv1alpha1.FeatureFlagDiegoDocker
+		featureFlagValidator = validation.NewFeatureFlagValidator(fakeClient, "cf")
+
+		fakeClient.GetStub = func(_ context.Context, _ client.ObjectKey, obj client.Object, _ ...client.GetOption) error {
+			featureFlag, ok := obj.(*korifiv1alpha1.CFFeatureFlag)
+			Expect(ok).To(BeTrue())
+			featureFlag.Spec.Enabled = true
+			return nil
+		}
+	})
+
+	JustBeforeEach(func() {
+		validationErr = featureFlagValidator.ValidateFeatureEnabled(ctx, featureFlagName)
+	})
+
+	It("gets the feature flag from the root namespace", func() {
+		Expect(validationErr).NotTo(HaveOccurred())
+
+		Expect(fakeClient.GetCallCount()).To(Equal(1))
+		_, key, _, _ := fakeClient.GetArgsForCall(0)
+		Expect(key.Namespace).To(Equal("cf"))
+		Expect(key.Name).To(Equal(korifiv1alpha1.FeatureFlagDiegoDocker))
+	})
+
+	When("the feature flag is disabled", func() {
+		BeforeEach(func() {
+			fakeClient.GetStub = func(_ context.Context, _ client.ObjectKey, obj client.Object, _ ...client.GetOption) error {
+				obj.(*korifiv1alpha1.CFFeatureFlag).Spec.Enabled = false
+				return nil
+			}
+		})
+
+		It("returns a feature disabled error", func() {
+			Expect(validationErr).To(matchers.BeValidationError(
+				validation.FeatureDisabledErrorType,
+				Equal("Feature Disabled: diego_docker"),
+			))
+		})
+
+		When("validating an admission request", func() {
+			BeforeEach(func() {
+				ctx = admission.NewContextWithRequest(ctx, admission.Request{
+					AdmissionRequest: admissionv1.AdmissionRequest{
+						UserInfo: authenticationv1.UserInfo{
+							Username: "alice",
+							Groups:   []string{"devs"},
+						},
+					},
+				})
+			})
+
+			It("reviews whether the user can update feature flags", func() {
+				Expect(fakeClient.CreateCallCount()).To(Equal(1))
+				_, obj, _ := fakeClient.CreateArgsForCall(0)
+				review, ok := obj.(*authv1.SubjectAccessReview)
+				Expect(ok).To(BeTrue())
+				Expect(review.Spec.User).To(Equal("alice"))
+				Expect(review.Spec.Groups).To(ConsistOf("devs"))
+				Expect(review.Spec.ResourceAttributes).To(Equal(&authv1.ResourceAttributes{
+					Namespace: "cf",
+					Verb:      "patch",
+					Group:     "korifi.cloudfoundry.org",
+					Resource:  "cffeatureflags",
+				}))
+			})
+
+			It("returns a feature disabled error", func() {
+				Expect(validationErr).To(matchers.BeValidationError(
+					validation.FeatureDisabledErrorType,
+					Equal("Feature Disabled: diego_docker"),
+				))
+			})
+
+			When("the user is an admin", func() {
+				BeforeEach(func() {
+					fakeClient.CreateStub = func(_ context.Context, obj client.Object, _ ...client.CreateOption) error {
+						obj.(*authv1.SubjectAccessReview).Status.Allowed = true
+						return nil
+					}
+				})
+
+				It("succeeds", func() {
+					Expect(validationErr).NotTo(HaveOccurred())
+				})
+			})
+
+			When("reviewing the user access fails", func() {
+				BeforeEach(func() {
+					fakeClient.CreateReturns(errors.New("review-err"))
+				})
+
+				It("returns the error", func() {
+					Expect(validationErr).To(MatchError(ContainSubstring("review-err")))
+				})
+			})
+		})
+
+		When("the feature flag has a custom error message", func() {
+			BeforeEach(func() {
+				fakeClient.GetStub = func(_ context.Context, _ client.ObjectKey, obj client.Object, _ ...client.GetOption) error {
+					obj.(*korifiv1alpha1.CFFeatureFlag).Spec.CustomErrorMessage = "no docker here"
+					return nil
+				}
+			})
+
+			It("uses the custom error message", func() {
+				Expect(validationErr).To(matchers.BeValidationError(
+					validation.FeatureDisabledErrorType,
+					Equal("Feature Disabled: no docker here"),
+				))
+			})
+		})
+	})
+
+	When("the feature flag does not exist", func() {
+		BeforeEach(func() {
+			fakeClient.GetReturns(k8serrors.NewNotFound(schema.GroupResource{}, "diego_docker"))
+			fakeClient.GetStub = nil
+		})
+
+		It("uses the default value of the feature flag", func() {
+			Expect(validationErr).NotTo(HaveOccurred())
+		})
+
+		When("the feature flag is disabled by default", func() {
+			BeforeEach(func() {
+				featureFlagName = korifiv1alpha1.FeatureFlagServiceInstanceSharing
+			})
+
+			It("returns a feature disabled error", func() {
+				Expect(validationErr).To(matchers.BeValidationError(
+					validation.FeatureDisabledErrorType,
+					Equal("Feature Disabled: service_instance_sharing"),
+				))
+			})
+		})
+	})
+
+	When("getting the feature flag fails", func() {
+		BeforeEach(func() {
+			fakeClient.GetStub = nil
+			fakeClient.GetReturns(errors.New("get-err"))
+		})
+
+		It("returns the error", func() {
+			Expect(validationErr).To(MatchError(ContainSubstring("get-err")))
+		})
+	})
+})
@@ -35,6 +35,7 @@ var (
 
 	ctx           context.Context
 	testNamespace string
+	rootNamespace string
 )
 
 func TestWorkloadsWebhooks(t *testing.T) {
@@ -79,11 +80,21 @@ var _ = BeforeSuite(func() {
 
 	adminClient, stopClientCache = helpers.NewCachedClient(testEnv.Config)
 
+	rootNamespace = uuid.NewString()
+	Expect(adminClient.Create(context.Background(), &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: rootNamespace,
+		},
+	})).To(Succeed())
+
 	(&apps.AppRevWebhook{}).SetupWebhookWithManager(k8sManager)
 
 	uncachedClient := helpers.NewUncachedClient(k8sManager.GetConfig())
 	appNameDuplicateValidator := validation.NewDuplicateValidator(coordination.NewNameRegistry(uncachedClient, apps.AppEntityType))
-	Expect(apps.NewValidator(appNameDuplicateValidator).SetupWebhookWithManager(k8sManager)).To(Succeed())
+	Expect(apps.NewValidator(
+		appNameDuplicateValidator,
+		validation.NewFeatureFlagValidator(uncachedClient, rootNamespace),
+	).SetupWebhookWithManager(k8sManager)).To(Succeed())
 
 	stopManager = helpers.StartK8sManager(k8sManager)
 })
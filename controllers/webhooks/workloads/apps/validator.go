@@ -26,14 +26,16 @@ var cfapplog = logf.Log.WithName("cfapp-validate")
 //+kubebuilder:webhook:path=/validate-korifi-cloudfoundry-org-v1alpha1-cfapp,mutating=false,failurePolicy=fail,sideEffects=NoneOnDryRun,groups=korifi.cloudfoundry.org,resources=cfapps,verbs=create;update;delete,versions=v1alpha1,name=vcfapp.korifi.cloudfoundry.org,admissionReviewVersions={v1,v1beta1}
 
 type Validator struct {
-	duplicateValidator webhooks.NameValidator
+	duplicateValidator   webhooks.NameValidator
+	featureFlagValidator webhooks.FeatureFlagValidator
 }
 
 var _ webhook.CustomValidator = &Validator{}
 
-func NewValidator(duplicateValidator webhooks.NameValidator) *Validator {
+func NewValidator(duplicateValidator webhooks.NameValidator, featureFlagValidator webhooks.FeatureFlagValidator) *Validator {
 	return &Validator{
-		duplicateValidator: duplicateValidator,
+		duplicateValidator:   duplicateValidator,
+		featureFlagValidator: featureFlagValidator,
 	}
 }
 
@@ -50,6 +52,12 @@ func (v *Validator) ValidateCreate(ctx context.Context, obj runtime.Object) (adm
 		return nil, apierrors.NewBadRequest(fmt.Sprintf("expected a CFApp but got a %T", obj))
 	}
 
+	if app.Spec.Lifecycle.Type == korifiv1alpha1.DockerLifecycle {
+		if err := v.featureFlagValidator.ValidateFeatureEnabled(ctx, korifiv1alpha1.FeatureFlagDiegoDocker); err != nil {
+			return nil, err
+		}
+	}
+
 	return nil, v.duplicateValidator.ValidateCreate(ctx, cfapplog, app.Namespace, app)
 }
 
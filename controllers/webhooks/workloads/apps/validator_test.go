@@ -47,6 +47,41 @@ var _ = Describe("CFAppValidatingWebhook", func() {
 			Expect(createErr).NotTo(HaveOccurred())
 		})
 
+		When("the app has a docker lifecycle", func() {
+			BeforeEach(func() {
+				app.Spec.Lifecycle = korifiv1alpha1.Lifecycle{
+					Type: korifiv1alpha1.DockerLifecycle,
+				}
+			})
+
+			It("should succeed", func() {
+				Expect(createErr).NotTo(HaveOccurred())
+			})
+
+			When("the diego_docker feature flag is disabled", func() {
+				BeforeEach(func() {
+					featureFlag := &korifiv1alpha1.CFFeatureFlag{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      korifiv1alpha1.FeatureFlagDiegoDocker,
+							Namespace: rootNamespace,
+						},
+						Spec: korifiv1alpha1.CFFeatureFlagSpec{
+							Enabled:            false,
+							CustomErrorMessage: "docker is not allowed",
+						},
+					}
+					Expect(adminClient.Create(ctx, featureFlag)).To(Succeed())
+					DeferCleanup(func() {
+						Expect(adminClient.Delete(ctx, featureFlag)).To(Succeed())
+					})
+				})
+
+				It("should fail", func() {
+					Expect(createErr).To(MatchError(ContainSubstring("Feature Disabled: docker is not allowed")))
+				})
+			})
+		})
+
 		When("another CFApp exists with a different name in the same namespace", func() {
 			BeforeEach(func() {
 				Expect(adminClient.Create(ctx, &korifiv1alpha1.CFApp{
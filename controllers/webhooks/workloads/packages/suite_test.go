@@ -8,6 +8,7 @@ import (
 	"time"
 
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/webhooks/validation"
 	"code.cloudfoundry.org/korifi/tests/helpers"
 
 	"code.cloudfoundry.org/korifi/controllers/webhooks/workloads/packages"
@@ -33,6 +34,7 @@ var (
 
 	ctx           context.Context
 	testNamespace string
+	rootNamespace string
 )
 
 func TestWorkloadsWebhooks(t *testing.T) {
@@ -77,7 +79,16 @@ var _ = BeforeSuite(func() {
 
 	adminClient, stopClientCache = helpers.NewCachedClient(testEnv.Config)
 
-	Expect(packages.NewValidator().SetupWebhookWithManager(k8sManager)).To(Succeed())
+	rootNamespace = uuid.NewString()
+	Expect(adminClient.Create(context.Background(), &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: rootNamespace,
+		},
+	})).To(Succeed())
+
+	Expect(packages.NewValidator(
+		validation.NewFeatureFlagValidator(helpers.NewUncachedClient(k8sManager.GetConfig()), rootNamespace),
+	).SetupWebhookWithManager(k8sManager)).To(Succeed())
 
 	stopManager = helpers.StartK8sManager(k8sManager)
 })
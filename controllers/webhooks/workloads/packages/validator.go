@@ -37,16 +37,19 @@ var (
 	cfpackagelog = logf.Log.WithName("cftask-resource")
 )
 
-//+kubebuilder:webhook:path=/validate-korifi-cloudfoundry-org-v1alpha1-cfpackage,mutating=false,failurePolicy=fail,sideEffects=None,groups=korifi.cloudfoundry.org,resources=cfpackages,verbs=update,versions=v1alpha1,name=vcfpackage.korifi.cloudfoundry.org,admissionReviewVersions={v1,v1beta1}
+//+kubebuilder:webhook:path=/validate-korifi-cloudfoundry-org-v1alpha1-cfpackage,mutating=false,failurePolicy=fail,sideEffects=None,groups=korifi.cloudfoundry.org,resources=cfpackages,verbs=create;update,versions=v1alpha1,name=vcfpackage.korifi.cloudfoundry.org,admissionReviewVersions={v1,v1beta1}
 
 type Validator struct {
-	client client.Client
+	client               client.Client
+	featureFlagValidator webhooks.FeatureFlagValidator
 }
 
 var _ webhook.CustomValidator = &Validator{}
 
-func NewValidator() *Validator {
-	return &Validator{}
+func NewValidator(featureFlagValidator webhooks.FeatureFlagValidator) *Validator {
+	return &Validator{
+		featureFlagValidator: featureFlagValidator,
+	}
 }
 
 func (v *Validator) SetupWebhookWithManager(mgr ctrl.Manager) error {
@@ -61,6 +64,15 @@ func (v *Validator) SetupWebhookWithManager(mgr ctrl.Manager) error {
 var _ webhook.CustomValidator = &Validator{}
 
 func (v *Validator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	cfPackage, ok := obj.(*v1alpha1.CFPackage)
+	if !ok {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("expected a CFPackage but got a %T", obj))
+	}
+
+	if cfPackage.Spec.Type == v1alpha1.DockerPackage {
+		return nil, v.featureFlagValidator.ValidateFeatureEnabled(ctx, v1alpha1.FeatureFlagDiegoDocker)
+	}
+
 	return nil, nil
 }
 
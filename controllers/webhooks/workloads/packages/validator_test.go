@@ -27,6 +27,55 @@ var _ = Describe("CFPackage Validation", func() {
 		Expect(adminClient.Create(context.Background(), cfPackage)).To(Succeed())
 	})
 
+	Describe("docker packages", func() {
+		var (
+			dockerPackage *korifiv1alpha1.CFPackage
+			createErr     error
+		)
+
+		BeforeEach(func() {
+			dockerPackage = &korifiv1alpha1.CFPackage{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: testNamespace,
+					Name:      uuid.NewString(),
+				},
+				Spec: korifiv1alpha1.CFPackageSpec{
+					Type: korifiv1alpha1.DockerPackage,
+				},
+			}
+		})
+
+		JustBeforeEach(func() {
+			createErr = adminClient.Create(context.Background(), dockerPackage)
+		})
+
+		It("allows creating them", func() {
+			Expect(createErr).NotTo(HaveOccurred())
+		})
+
+		When("the diego_docker feature flag is disabled", func() {
+			BeforeEach(func() {
+				featureFlag := &korifiv1alpha1.CFFeatureFlag{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      korifiv1alpha1.FeatureFlagDiegoDocker,
+						Namespace: rootNamespace,
+					},
+					Spec: korifiv1alpha1.CFFeatureFlagSpec{
+						Enabled: false,
+					},
+				}
+				Expect(adminClient.Create(context.Background(), featureFlag)).To(Succeed())
+				DeferCleanup(func() {
+					Expect(adminClient.Delete(context.Background(), featureFlag)).To(Succeed())
+				})
+			})
+
+			It("does not allow creating them", func() {
+				Expect(createErr).To(MatchError(ContainSubstring("Feature Disabled: diego_docker")))
+			})
+		})
+	})
+
 	Describe("package type immutability", func() {
 		var updateErr error
 
@@ -56,6 +56,18 @@ var _ = Describe("CFTaskMutatingWebhook", func() {
 		Expect(cfTask.Status.DiskQuotaMB).To(BeNumerically("==", 512))
 	})
 
+	When("the task specifies its resources", func() {
+		BeforeEach(func() {
+			cfTask.Spec.MemoryMB = 2048
+			cfTask.Spec.DiskQuotaMB = 1024
+		})
+
+		It("uses them in the status", func() {
+			Expect(cfTask.Status.MemoryMB).To(BeNumerically("==", 2048))
+			Expect(cfTask.Status.DiskQuotaMB).To(BeNumerically("==", 1024))
+		})
+	})
+
 	Describe("subsequent updates", func() {
 		var (
 			updateTaskFunc func()
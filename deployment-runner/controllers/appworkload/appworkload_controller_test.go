@@ -0,0 +1,242 @@
+package appworkload_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/deployment-runner/controllers/appworkload"
+	"code.cloudfoundry.org/korifi/deployment-runner/controllers/appworkload/fake"
+	"code.cloudfoundry.org/korifi/tools"
+	"code.cloudfoundry.org/korifi/tools/k8s"
+	"code.cloudfoundry.org/korifi/tools/runner"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	v1 "k8s.io/api/apps/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("AppWorkload Reconcile", func() {
+	var (
+		reconciler            *k8s.PatchingReconciler[korifiv1alpha1.AppWorkload, *korifiv1alpha1.AppWorkload]
+		reconcileResult       ctrl.Result
+		reconcileErr          error
+		ctx                   context.Context
+		req                   ctrl.Request
+		appWorkload           *korifiv1alpha1.AppWorkload
+		deployment            *v1.Deployment
+		fakeWorkloadToDeploy  *fake.WorkloadToDeploymentConverter
+		fakePDB               *fake.PDB
+		fakeInstanceIndexer   *fake.InstanceIndexer
+		getAppWorkloadError   error
+		getDeploymentError    error
+		createDeploymentError error
+	)
+
+	BeforeEach(func() {
+		appWorkload = &korifiv1alpha1.AppWorkload{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      uuid.NewString(),
+				Namespace: uuid.NewString(),
+			},
+		}
+
+		appWorkload.Spec.GUID = uuid.NewString()
+
+		deployment = &v1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      uuid.NewString(),
+				Namespace: appWorkload.Namespace,
+			},
+		}
+
+		fakeWorkloadToDeploy = new(fake.WorkloadToDeploymentConverter)
+		fakeWorkloadToDeploy.ConvertReturns(deployment, nil)
+
+		fakePDB = new(fake.PDB)
+		fakeInstanceIndexer = new(fake.InstanceIndexer)
+
+		ctx = context.Background()
+		req = ctrl.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      uuid.NewString(),
+				Namespace: appWorkload.Namespace,
+			},
+		}
+
+		getAppWorkloadError = nil
+		getDeploymentError = apierrors.NewNotFound(schema.GroupResource{
+			Group:    "apps",
+			Resource: "Deployment",
+		}, "some-resource")
+		createDeploymentError = nil
+
+		fakeClient.GetStub = func(_ context.Context, _ types.NamespacedName, obj client.Object, _ ...client.GetOption) error {
+			switch obj := obj.(type) {
+			case *korifiv1alpha1.AppWorkload:
+				appWorkload.DeepCopyInto(obj)
+				return getAppWorkloadError
+			case *v1.Deployment:
+				if getDeploymentError == nil {
+					deployment.DeepCopyInto(obj)
+				}
+				return getDeploymentError
+			default:
+				panic("TestClient Get provided an unexpected object type")
+			}
+		}
+
+		fakeClient.CreateStub = func(_ context.Context, obj client.Object, _ ...client.CreateOption) error {
+			switch obj.(type) {
+			case *v1.Deployment:
+				return createDeploymentError
+			default:
+				panic("TestClient Create provided an unexpected object type")
+			}
+		}
+
+		reconciler = appworkload.NewAppWorkloadReconciler(
+			fakeClient,
+			scheme.Scheme,
+			fakeWorkloadToDeploy,
+			fakePDB,
+			fakeInstanceIndexer,
+			ctrl.Log.WithName("controllers").WithName("TestAppWorkload"),
+			runner.NewAppWorkloadStateCollector(fakeClient),
+		)
+	})
+
+	JustBeforeEach(func() {
+		reconcileResult, reconcileErr = reconciler.Reconcile(ctx, req)
+	})
+
+	When("the appworkload is being created", func() {
+		It("returns an empty result and does not return error", func() {
+			Expect(reconcileResult).To(Equal(ctrl.Result{}))
+			Expect(reconcileErr).NotTo(HaveOccurred())
+		})
+
+		It("converts the app workload to a deployment", func() {
+			Expect(fakeWorkloadToDeploy.ConvertCallCount()).To(Equal(1))
+			actualWorkload := fakeWorkloadToDeploy.ConvertArgsForCall(0)
+			Expect(actualWorkload.Name).To(Equal(appWorkload.Name))
+		})
+
+		It("sets the appworkload status", func() {
+			Expect(fakeStatusWriter.PatchCallCount()).To(Equal(1))
+			_, object, _, _ := fakeStatusWriter.PatchArgsForCall(0)
+			patchedAppWorkload, ok := object.(*korifiv1alpha1.AppWorkload)
+			Expect(ok).To(BeTrue())
+			Expect(patchedAppWorkload.Status.ObservedGeneration).To(Equal(patchedAppWorkload.Generation))
+		})
+
+		When("converting the app workload to a deployment fails", func() {
+			BeforeEach(func() {
+				fakeWorkloadToDeploy.ConvertReturns(nil, errors.New("convert-error"))
+			})
+
+			It("returns the error", func() {
+				Expect(reconcileErr).To(MatchError("convert-error"))
+			})
+		})
+
+		It("creates a Deployment", func() {
+			Expect(fakeClient.CreateCallCount()).To(Equal(1), "Client.Create call count mismatch")
+			_, obj, _ := fakeClient.CreateArgsForCall(0)
+			Expect(obj).To(BeAssignableToTypeOf(new(v1.Deployment)))
+		})
+
+		When("creating the Deployment fails", func() {
+			BeforeEach(func() {
+				createDeploymentError = errors.New("big sad")
+			})
+
+			It("returns an error", func() {
+				Expect(reconcileErr).To(MatchError("big sad"))
+			})
+		})
+
+		It("assigns indexes to the workload pods", func() {
+			Expect(fakeInstanceIndexer.AssignIndexesCallCount()).To(Equal(1))
+			_, actualGUID := fakeInstanceIndexer.AssignIndexesArgsForCall(0)
+			Expect(actualGUID).To(Equal(appWorkload.Spec.GUID))
+		})
+
+		When("assigning the indexes fails", func() {
+			BeforeEach(func() {
+				fakeInstanceIndexer.AssignIndexesReturns(errors.New("index-error"))
+			})
+
+			It("returns the error", func() {
+				Expect(reconcileErr).To(MatchError("index-error"))
+			})
+		})
+	})
+
+	When("the appworkload is being deleted", func() {
+		BeforeEach(func() {
+			getAppWorkloadError = apierrors.NewNotFound(schema.GroupResource{
+				Group:    "v1alpha1",
+				Resource: "AppWorkload",
+			}, "some-resource")
+		})
+
+		It("returns an empty result and does not return error", func() {
+			Expect(reconcileResult).To(Equal(ctrl.Result{}))
+			Expect(reconcileErr).NotTo(HaveOccurred())
+		})
+	})
+
+	When("the appworkload is being deleted gracefully", func() {
+		BeforeEach(func() {
+			appWorkload.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+		})
+
+		It("returns an empty result and does not return error", func() {
+			Expect(reconcileResult).To(Equal(ctrl.Result{}))
+			Expect(reconcileErr).NotTo(HaveOccurred())
+		})
+
+		It("creates no deployment", func() {
+			Expect(fakeWorkloadToDeploy.ConvertCallCount()).To(Equal(0))
+			Expect(fakeClient.CreateCallCount()).To(Equal(0))
+		})
+	})
+
+	When("the appworkload is being updated", func() {
+		BeforeEach(func() {
+			getDeploymentError = nil
+
+			desiredDeployment := deployment.DeepCopy()
+			desiredDeployment.Spec.Replicas = tools.PtrTo(int32(2))
+			fakeWorkloadToDeploy.ConvertReturns(desiredDeployment, nil)
+		})
+
+		It("scales instances", func() {
+			Expect(fakeClient.PatchCallCount()).To(BeNumerically(">", 1))
+			_, updatedObject, _, _ := fakeClient.PatchArgsForCall(0)
+			updatedDeployment, ok := updatedObject.(*v1.Deployment)
+			Expect(ok).To(BeTrue())
+			Expect(updatedDeployment.Spec.Replicas).To(Equal(tools.PtrTo(int32(2))))
+		})
+
+		When("updating the pod disruption budget fails", func() {
+			BeforeEach(func() {
+				fakePDB.UpdateReturns(errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				Expect(reconcileErr).To(MatchError("boom"))
+			})
+		})
+	})
+})
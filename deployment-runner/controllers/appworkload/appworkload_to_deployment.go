@@ -0,0 +1,297 @@
+package appworkload
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/deployment-runner/controllers"
+	"code.cloudfoundry.org/korifi/tools"
+	"code.cloudfoundry.org/korifi/tools/runner"
+	"github.com/BooleanCat/go-functional/v2/it"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const (
+	bindingRootPath = "/bindings"
+
+	// MaxSurge and MaxUnavailable make rolling updates start the new
+	// instances before stopping the old ones, so that single instance apps
+	// are updated without downtime
+	MaxSurge       = "25%"
+	MaxUnavailable = "0"
+)
+
+type AppWorkloadToDeploymentConverter struct {
+	scheme *runtime.Scheme
+}
+
+func NewAppWorkloadToDeploymentConverter(scheme *runtime.Scheme) *AppWorkloadToDeploymentConverter {
+	return &AppWorkloadToDeploymentConverter{
+		scheme: scheme,
+	}
+}
+
+func getDeploymentName(appWorkload *korifiv1alpha1.AppWorkload) (string, error) {
+	lastStopAppRev := appWorkload.Spec.Version
+	if annotationVal, ok := appWorkload.Annotations[korifiv1alpha1.CFAppLastStopRevisionKey]; ok {
+		lastStopAppRev = annotationVal
+	}
+	nameSuffix, err := hash(fmt.Sprintf("%s-%s", appWorkload.Spec.GUID, lastStopAppRev))
+	if err != nil {
+		return "", fmt.Errorf("failed to generate hash for deployment name: %w", err)
+	}
+
+	namePrefix := fmt.Sprintf("%s-%s", appWorkload.Spec.AppGUID, appWorkload.Namespace)
+	namePrefix = sanitizeName(namePrefix, appWorkload.Spec.GUID)
+
+	return fmt.Sprintf("%s-%s", namePrefix, nameSuffix), nil
+}
+
+func (r *AppWorkloadToDeploymentConverter) Convert(appWorkload *korifiv1alpha1.AppWorkload) (*appsv1.Deployment, error) {
+	envs := appWorkload.Spec.Env
+
+	fieldEnvs := []corev1.EnvVar{
+		{
+			Name: EnvPodName,
+			ValueFrom: &corev1.EnvVarSource{
+				FieldRef: &corev1.ObjectFieldSelector{
+					FieldPath: "metadata.name",
+				},
+			},
+		},
+		{
+			Name: EnvCFInstanceGUID,
+			ValueFrom: &corev1.EnvVarSource{
+				FieldRef: &corev1.ObjectFieldSelector{
+					FieldPath: "metadata.uid",
+				},
+			},
+		},
+		{
+			Name: EnvCFInstanceIndex,
+			ValueFrom: &corev1.EnvVarSource{
+				FieldRef: &corev1.ObjectFieldSelector{
+					FieldPath: fmt.Sprintf("metadata.annotations['%s']", runner.AnnotationInstanceIndex),
+				},
+			},
+		},
+		{
+			Name: EnvCFInstanceIP,
+			ValueFrom: &corev1.EnvVarSource{
+				FieldRef: &corev1.ObjectFieldSelector{
+					FieldPath: "status.hostIP",
+				},
+			},
+		},
+		{
+			Name: EnvCFInstanceInternalIP,
+			ValueFrom: &corev1.EnvVarSource{
+				FieldRef: &corev1.ObjectFieldSelector{
+					FieldPath: "status.podIP",
+				},
+			},
+		},
+	}
+
+	envs = append(envs, fieldEnvs...)
+
+	if len(appWorkload.Spec.Services) != 0 {
+		envs = append(envs, corev1.EnvVar{
+			Name:  EnvServiceBindingRoot,
+			Value: bindingRootPath,
+		})
+	}
+	// Sort env vars to guarantee idempotency
+	sort.SliceStable(envs, func(i, j int) bool {
+		return envs[i].Name < envs[j].Name
+	})
+
+	containers := []corev1.Container{
+		{
+			Name:            ApplicationContainerName,
+			Image:           appWorkload.Spec.Image,
+			ImagePullPolicy: corev1.PullAlways,
+			Command:         appWorkload.Spec.Command,
+			Env:             envs,
+			Ports: slices.Collect(it.Map(slices.Values(appWorkload.Spec.Ports), func(port int32) corev1.ContainerPort {
+				return corev1.ContainerPort{ContainerPort: port}
+			})),
+			SecurityContext: &corev1.SecurityContext{
+				AllowPrivilegeEscalation: tools.PtrTo(false),
+				Capabilities: &corev1.Capabilities{
+					Drop: []corev1.Capability{"ALL"},
+				},
+				SeccompProfile: &corev1.SeccompProfile{
+					Type: corev1.SeccompProfileTypeRuntimeDefault,
+				},
+			},
+			Resources:     appWorkload.Spec.Resources,
+			StartupProbe:  appWorkload.Spec.StartupProbe,
+			LivenessProbe: appWorkload.Spec.LivenessProbe,
+			VolumeMounts: slices.Collect(it.Map(slices.Values(appWorkload.Spec.Services), func(s korifiv1alpha1.ServiceBinding) corev1.VolumeMount {
+				return corev1.VolumeMount{
+					Name:      s.Name,
+					ReadOnly:  true,
+					MountPath: filepath.Join(bindingRootPath, s.Name),
+				}
+			})),
+		},
+	}
+
+	deploymentName, err := getDeploymentName(appWorkload)
+	if err != nil {
+		return nil, err
+	}
+
+	maxSurge := intstr.FromString(MaxSurge)
+	maxUnavailable := intstr.Parse(MaxUnavailable)
+
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      deploymentName,
+			Namespace: appWorkload.Namespace,
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &appWorkload.Spec.Instances,
+			Strategy: appsv1.DeploymentStrategy{
+				Type: appsv1.RollingUpdateDeploymentStrategyType,
+				RollingUpdate: &appsv1.RollingUpdateDeployment{
+					MaxSurge:       &maxSurge,
+					MaxUnavailable: &maxUnavailable,
+				},
+			},
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers:       containers,
+					ImagePullSecrets: appWorkload.Spec.ImagePullSecrets,
+					SecurityContext: &corev1.PodSecurityContext{
+						RunAsNonRoot: tools.PtrTo(true),
+						SeccompProfile: &corev1.SeccompProfile{
+							Type: corev1.SeccompProfileTypeRuntimeDefault,
+						},
+					},
+					ServiceAccountName: ServiceAccountName,
+					NodeSelector:       appWorkload.Spec.NodeSelector,
+					Tolerations:        appWorkload.Spec.Tolerations,
+					Volumes: slices.Collect(it.Map(slices.Values(appWorkload.Spec.Services), func(s korifiv1alpha1.ServiceBinding) corev1.Volume {
+						return corev1.Volume{
+							Name: s.Name,
+							VolumeSource: corev1.VolumeSource{
+								Secret: &corev1.SecretVolumeSource{
+									SecretName:  s.Secret,
+									DefaultMode: tools.PtrTo[int32](0o644),
+								},
+							},
+						}
+					})),
+				},
+			},
+		},
+	}
+
+	deployment.Spec.Template.Spec.AutomountServiceAccountToken = tools.PtrTo(false)
+	deployment.Spec.Selector = deploymentLabelSelector(appWorkload)
+
+	deployment.Spec.Template.Spec.TopologySpreadConstraints = []corev1.TopologySpreadConstraint{
+		{
+			TopologyKey:       "topology.kubernetes.io/zone",
+			MaxSkew:           1,
+			WhenUnsatisfiable: "ScheduleAnyway",
+			LabelSelector:     deployment.Spec.Selector,
+			MatchLabelKeys: []string{
+				"pod-template-hash",
+			},
+		},
+		{
+			TopologyKey:       "kubernetes.io/hostname",
+			MaxSkew:           1,
+			WhenUnsatisfiable: "ScheduleAnyway",
+			LabelSelector:     deployment.Spec.Selector,
+			MatchLabelKeys: []string{
+				"pod-template-hash",
+			},
+		},
+	}
+
+	err = controllerutil.SetControllerReference(appWorkload, deployment, r.scheme)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set OwnerRef on Deployment :%w", err)
+	}
+
+	labels := map[string]string{
+		controllers.LabelGUID: appWorkload.Spec.GUID,
+		LabelProcessType:      appWorkload.Spec.ProcessType,
+		LabelVersion:          appWorkload.Spec.Version,
+		LabelAppGUID:          appWorkload.Spec.AppGUID,
+		LabelAppWorkloadGUID:  appWorkload.Name,
+	}
+
+	deployment.Spec.Template.Labels = labels
+	deployment.Labels = labels
+
+	annotations := map[string]string{
+		AnnotationAppID:       appWorkload.Spec.AppGUID,
+		AnnotationVersion:     appWorkload.Spec.Version,
+		AnnotationProcessGUID: fmt.Sprintf("%s-%s", appWorkload.Spec.GUID, appWorkload.Spec.Version),
+	}
+
+	deployment.Annotations = annotations
+	deployment.Spec.Template.Annotations = annotations
+
+	return deployment, nil
+}
+
+func sanitizeName(name, fallback string) string {
+	const sanitizedNameMaxLen = 40
+
+	validNameRegex := regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$`)
+	sanitizedName := strings.ReplaceAll(strings.ToLower(name), "_", "-")
+
+	if validNameRegex.MatchString(sanitizedName) {
+		return truncateString(sanitizedName, sanitizedNameMaxLen)
+	}
+
+	return truncateString(fallback, sanitizedNameMaxLen)
+}
+
+func truncateString(str string, num int) string {
+	if len(str) > num {
+		return str[0:num]
+	}
+
+	return str
+}
+
+func deploymentLabelSelector(appWorkload *korifiv1alpha1.AppWorkload) *metav1.LabelSelector {
+	return &metav1.LabelSelector{
+		MatchLabels: map[string]string{
+			controllers.LabelGUID: appWorkload.Spec.GUID,
+		},
+	}
+}
+
+func hash(s string) (string, error) {
+	const MaxHashLength = 10
+
+	sha := sha256.New()
+
+	if _, err := sha.Write([]byte(s)); err != nil {
+		return "", fmt.Errorf("failed to calculate sha: %w", err)
+	}
+
+	hashValue := hex.EncodeToString(sha.Sum(nil))
+
+	return hashValue[:MaxHashLength], nil
+}
@@ -0,0 +1,215 @@
+package appworkload_test
+
+import (
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/deployment-runner/controllers"
+	"code.cloudfoundry.org/korifi/deployment-runner/controllers/appworkload"
+	"code.cloudfoundry.org/korifi/tools"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes/scheme"
+)
+
+var _ = Describe("AppWorkload to Deployment Converter", func() {
+	var (
+		deployment  *appsv1.Deployment
+		appWorkload *korifiv1alpha1.AppWorkload
+		converter   *appworkload.AppWorkloadToDeploymentConverter
+	)
+
+	BeforeEach(func() {
+		Expect(korifiv1alpha1.AddToScheme(scheme.Scheme)).To(Succeed())
+		appWorkload = &korifiv1alpha1.AppWorkload{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       "guid_1234",
+				Namespace:  "some-namespace",
+				Generation: 1,
+				Annotations: map[string]string{
+					korifiv1alpha1.CFAppLastStopRevisionKey: "lastStopAppRev",
+				},
+			},
+			Spec: korifiv1alpha1.AppWorkloadSpec{
+				AppGUID:          "premium_app_guid_1234",
+				GUID:             "guid_1234",
+				Version:          "version_1234",
+				Image:            "gcr.io/foo/bar",
+				ImagePullSecrets: []corev1.LocalObjectReference{{Name: "some-secret-name"}},
+				Command:          []string{"/bin/sh", "-c", "while true; do echo hello; sleep 10;done"},
+				ProcessType:      "worker",
+				StartupProbe: &corev1.Probe{
+					ProbeHandler: corev1.ProbeHandler{
+						HTTPGet: &corev1.HTTPGetAction{
+							Path: "/healthz",
+							Port: intstr.FromInt32(8080),
+						},
+					},
+				},
+				Ports:      []int32{8888, 9999},
+				Instances:  3,
+				RunnerName: "deployment-runner",
+				Resources: corev1.ResourceRequirements{
+					Limits: corev1.ResourceList{
+						corev1.ResourceMemory: resource.MustParse("1024Mi"),
+					},
+				},
+				Services: []korifiv1alpha1.ServiceBinding{
+					{Name: "my-db", Secret: "my-db-secret"},
+				},
+			},
+		}
+
+		converter = appworkload.NewAppWorkloadToDeploymentConverter(scheme.Scheme)
+	})
+
+	JustBeforeEach(func() {
+		var err error
+		deployment, err = converter.Convert(appWorkload)
+
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should be owned by the AppWorkload", func() {
+		Expect(deployment.OwnerReferences).To(HaveLen(1))
+		Expect(deployment.OwnerReferences[0].Kind).To(Equal("AppWorkload"))
+	})
+
+	It("should base the name and namespace on the appworkload", func() {
+		Expect(deployment.Namespace).To(Equal(appWorkload.Namespace))
+		Expect(deployment.Name).To(HavePrefix("premium-app-guid-1234-some-namespace-"))
+	})
+
+	It("should have a stable name when appWorkload lastStopAppRev is unchanged but version changes", func() {
+		originalName := deployment.Name
+
+		appWorkload.Spec.Version = "another_version"
+		var err error
+		deployment, err = converter.Convert(appWorkload)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(deployment.Name).To(Equal(originalName))
+	})
+
+	It("should have a new name when appWorkload lastStopAppRev changes", func() {
+		originalName := deployment.Name
+
+		appWorkload.Annotations[korifiv1alpha1.CFAppLastStopRevisionKey] = "another_version"
+		var err error
+		deployment, err = converter.Convert(appWorkload)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(deployment.Name).NotTo(Equal(originalName))
+	})
+
+	It("should set the replicas", func() {
+		Expect(deployment.Spec.Replicas).To(PointTo(BeEquivalentTo(3)))
+	})
+
+	It("should surge new instances before stopping old ones", func() {
+		Expect(deployment.Spec.Strategy.Type).To(Equal(appsv1.RollingUpdateDeploymentStrategyType))
+		Expect(deployment.Spec.Strategy.RollingUpdate.MaxSurge).To(PointTo(Equal(intstr.FromString("25%"))))
+		Expect(deployment.Spec.Strategy.RollingUpdate.MaxUnavailable).To(PointTo(Equal(intstr.FromInt32(0))))
+	})
+
+	It("should select the pods by the workload guid", func() {
+		Expect(deployment.Spec.Selector.MatchLabels).To(Equal(map[string]string{
+			controllers.LabelGUID: "guid_1234",
+		}))
+		Expect(deployment.Spec.Template.Labels).To(HaveKeyWithValue(controllers.LabelGUID, "guid_1234"))
+	})
+
+	DescribeTable("Deployment Labels",
+		func(labelName, expectedValue string) {
+			Expect(deployment.Labels).To(HaveKeyWithValue(labelName, expectedValue))
+			Expect(deployment.Spec.Template.Labels).To(HaveKeyWithValue(labelName, expectedValue))
+		},
+		Entry("AppGUID", appworkload.LabelAppGUID, "premium_app_guid_1234"),
+		Entry("AppWorkloadGUID", appworkload.LabelAppWorkloadGUID, "guid_1234"),
+		Entry("ProcessType", appworkload.LabelProcessType, "worker"),
+		Entry("Version", appworkload.LabelVersion, "version_1234"),
+	)
+
+	DescribeTable("Deployment Annotations",
+		func(annotationName, expectedValue string) {
+			Expect(deployment.Annotations).To(HaveKeyWithValue(annotationName, expectedValue))
+			Expect(deployment.Spec.Template.Annotations).To(HaveKeyWithValue(annotationName, expectedValue))
+		},
+		Entry("ProcessGUID", appworkload.AnnotationProcessGUID, "guid_1234-version_1234"),
+		Entry("AppID", appworkload.AnnotationAppID, "premium_app_guid_1234"),
+		Entry("Version", appworkload.AnnotationVersion, "version_1234"),
+	)
+
+	It("should set the container", func() {
+		Expect(deployment.Spec.Template.Spec.Containers).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+			"Name":            Equal(appworkload.ApplicationContainerName),
+			"Image":           Equal("gcr.io/foo/bar"),
+			"ImagePullPolicy": Equal(corev1.PullAlways),
+			"Command":         Equal(appWorkload.Spec.Command),
+			"Ports":           ConsistOf(corev1.ContainerPort{ContainerPort: 8888}, corev1.ContainerPort{ContainerPort: 9999}),
+			"Resources":       Equal(appWorkload.Spec.Resources),
+			"StartupProbe":    Equal(appWorkload.Spec.StartupProbe),
+		})))
+	})
+
+	It("should set the container environment variables", func() {
+		Expect(deployment.Spec.Template.Spec.Containers[0].Env).To(ContainElements(
+			corev1.EnvVar{Name: appworkload.EnvPodName, ValueFrom: expectedValFrom("metadata.name")},
+			corev1.EnvVar{Name: appworkload.EnvCFInstanceGUID, ValueFrom: expectedValFrom("metadata.uid")},
+			corev1.EnvVar{Name: appworkload.EnvCFInstanceInternalIP, ValueFrom: expectedValFrom("status.podIP")},
+			corev1.EnvVar{Name: appworkload.EnvCFInstanceIP, ValueFrom: expectedValFrom("status.hostIP")},
+			corev1.EnvVar{Name: appworkload.EnvServiceBindingRoot, Value: "/bindings"},
+		))
+	})
+
+	It("should read the instance index from the pod index annotation", func() {
+		Expect(deployment.Spec.Template.Spec.Containers[0].Env).To(ContainElement(
+			corev1.EnvVar{
+				Name:      appworkload.EnvCFInstanceIndex,
+				ValueFrom: expectedValFrom("metadata.annotations['korifi.cloudfoundry.org/instance-index']"),
+			},
+		))
+	})
+
+	It("should mount the service bindings", func() {
+		Expect(deployment.Spec.Template.Spec.Containers[0].VolumeMounts).To(ConsistOf(corev1.VolumeMount{
+			Name:      "my-db",
+			ReadOnly:  true,
+			MountPath: "/bindings/my-db",
+		}))
+		Expect(deployment.Spec.Template.Spec.Volumes).To(ConsistOf(corev1.Volume{
+			Name: "my-db",
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName:  "my-db-secret",
+					DefaultMode: tools.PtrTo[int32](0o644),
+				},
+			},
+		}))
+	})
+
+	It("should run the pods securely", func() {
+		Expect(deployment.Spec.Template.Spec.ServiceAccountName).To(Equal("korifi-app"))
+		Expect(deployment.Spec.Template.Spec.AutomountServiceAccountToken).To(Equal(tools.PtrTo(false)))
+		Expect(deployment.Spec.Template.Spec.SecurityContext.RunAsNonRoot).To(Equal(tools.PtrTo(true)))
+		Expect(deployment.Spec.Template.Spec.Containers[0].SecurityContext.AllowPrivilegeEscalation).To(Equal(tools.PtrTo(false)))
+		Expect(deployment.Spec.Template.Spec.Containers[0].SecurityContext.Capabilities.Drop).To(ConsistOf(corev1.Capability("ALL")))
+	})
+
+	It("should copy the image pull secrets", func() {
+		Expect(deployment.Spec.Template.Spec.ImagePullSecrets).To(Equal(appWorkload.Spec.ImagePullSecrets))
+	})
+})
+
+func expectedValFrom(fieldPath string) *corev1.EnvVarSource {
+	return &corev1.EnvVarSource{
+		FieldRef: &corev1.ObjectFieldSelector{
+			FieldPath: fieldPath,
+		},
+	}
+}
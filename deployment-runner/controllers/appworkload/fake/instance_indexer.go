@@ -0,0 +1,114 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fake
+
+import (
+	"context"
+	"sync"
+
+	"code.cloudfoundry.org/korifi/deployment-runner/controllers/appworkload"
+)
+
+type InstanceIndexer struct {
+	AssignIndexesStub        func(context.Context, string) error
+	assignIndexesMutex       sync.RWMutex
+	assignIndexesArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	assignIndexesReturns struct {
+		result1 error
+	}
+	assignIndexesReturnsOnCall map[int]struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *InstanceIndexer) AssignIndexes(arg1 context.Context, arg2 string) error {
+	fake.assignIndexesMutex.Lock()
+	ret, specificReturn := fake.assignIndexesReturnsOnCall[len(fake.assignIndexesArgsForCall)]
+	fake.assignIndexesArgsForCall = append(fake.assignIndexesArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.AssignIndexesStub
+	fakeReturns := fake.assignIndexesReturns
+	fake.recordInvocation("AssignIndexes", []interface{}{arg1, arg2})
+	fake.assignIndexesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *InstanceIndexer) AssignIndexesCallCount() int {
+	fake.assignIndexesMutex.RLock()
+	defer fake.assignIndexesMutex.RUnlock()
+	return len(fake.assignIndexesArgsForCall)
+}
+
+func (fake *InstanceIndexer) AssignIndexesCalls(stub func(context.Context, string) error) {
+	fake.assignIndexesMutex.Lock()
+	defer fake.assignIndexesMutex.Unlock()
+	fake.AssignIndexesStub = stub
+}
+
+func (fake *InstanceIndexer) AssignIndexesArgsForCall(i int) (context.Context, string) {
+	fake.assignIndexesMutex.RLock()
+	defer fake.assignIndexesMutex.RUnlock()
+	argsForCall := fake.assignIndexesArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *InstanceIndexer) AssignIndexesReturns(result1 error) {
+	fake.assignIndexesMutex.Lock()
+	defer fake.assignIndexesMutex.Unlock()
+	fake.AssignIndexesStub = nil
+	fake.assignIndexesReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *InstanceIndexer) AssignIndexesReturnsOnCall(i int, result1 error) {
+	fake.assignIndexesMutex.Lock()
+	defer fake.assignIndexesMutex.Unlock()
+	fake.AssignIndexesStub = nil
+	if fake.assignIndexesReturnsOnCall == nil {
+		fake.assignIndexesReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.assignIndexesReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *InstanceIndexer) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.assignIndexesMutex.RLock()
+	defer fake.assignIndexesMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *InstanceIndexer) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ appworkload.InstanceIndexer = new(InstanceIndexer)
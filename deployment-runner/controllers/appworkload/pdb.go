@@ -0,0 +1,79 @@
+package appworkload
+
+import (
+	"context"
+	"fmt"
+
+	"code.cloudfoundry.org/korifi/deployment-runner/controllers"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+
+	appsv1 "k8s.io/api/apps/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const PdbMinAvailableInstances = "50%"
+
+type PDBUpdater struct {
+	client client.Client
+}
+
+func NewPDBUpdater(client client.Client) *PDBUpdater {
+	return &PDBUpdater{
+		client: client,
+	}
+}
+
+func (c *PDBUpdater) Update(ctx context.Context, deployment *appsv1.Deployment) error {
+	if *deployment.Spec.Replicas > 1 {
+		return c.createPDB(ctx, deployment)
+	}
+
+	return c.deletePDB(ctx, deployment)
+}
+
+func (c *PDBUpdater) createPDB(ctx context.Context, deployment *appsv1.Deployment) error {
+	minAvailable := intstr.FromString(PdbMinAvailableInstances)
+
+	pdb := &policyv1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      deployment.Name,
+			Namespace: deployment.Namespace,
+			Labels: map[string]string{
+				controllers.LabelGUID: deployment.Labels[controllers.LabelGUID],
+				LabelVersion:          deployment.Labels[LabelVersion],
+			},
+		},
+		Spec: policyv1.PodDisruptionBudgetSpec{
+			MinAvailable: &minAvailable,
+			Selector:     deployment.Spec.Selector,
+		},
+	}
+
+	if err := controllerutil.SetControllerReference(deployment, pdb, scheme.Scheme); err != nil {
+		return fmt.Errorf("pdb updater failed to set owner ref: %w", err)
+	}
+
+	err := c.client.Create(ctx, pdb)
+	if err != nil {
+		if k8serrors.IsAlreadyExists(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to create pod disruption budget: %w", err)
+	}
+
+	return nil
+}
+
+func (c *PDBUpdater) deletePDB(ctx context.Context, deployment *appsv1.Deployment) error {
+	err := c.client.DeleteAllOf(ctx, &policyv1.PodDisruptionBudget{}, client.InNamespace(deployment.Namespace), client.MatchingFields{"metadata.name": deployment.Name})
+	if err != nil {
+		return fmt.Errorf("failed to delete pod disruption budget: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,10 @@
+package controllers
+
+// RBAC of the shared RunnerInfo controller
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=runnerinfos,verbs=get;list;watch;create;patch;delete
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=runnerinfos/status,verbs=get;patch
+
+const (
+	LabelGUID                 = "korifi.cloudfoundry.org/guid"
+	AppWorkloadReconcilerName = "deployment-runner"
+)
@@ -5,36 +5,116 @@ import (
 	"context"
 	"sync"
 
+	"code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	"code.cloudfoundry.org/korifi/job-task-runner/controllers"
-	v1a "k8s.io/api/batch/v1"
-	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	v1 "k8s.io/api/batch/v1"
+	v1a "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 type TaskStatusGetter struct {
-	GetStatusConditionsStub        func(context.Context, *v1a.Job) ([]v1.Condition, error)
+	GetFailedAttemptsStub        func(context.Context, *v1.Job) ([]v1alpha1.TaskAttemptFailure, error)
+	getFailedAttemptsMutex       sync.RWMutex
+	getFailedAttemptsArgsForCall []struct {
+		arg1 context.Context
+		arg2 *v1.Job
+	}
+	getFailedAttemptsReturns struct {
+		result1 []v1alpha1.TaskAttemptFailure
+		result2 error
+	}
+	getFailedAttemptsReturnsOnCall map[int]struct {
+		result1 []v1alpha1.TaskAttemptFailure
+		result2 error
+	}
+	GetStatusConditionsStub        func(context.Context, *v1.Job) ([]v1a.Condition, error)
 	getStatusConditionsMutex       sync.RWMutex
 	getStatusConditionsArgsForCall []struct {
 		arg1 context.Context
-		arg2 *v1a.Job
+		arg2 *v1.Job
 	}
 	getStatusConditionsReturns struct {
-		result1 []v1.Condition
+		result1 []v1a.Condition
 		result2 error
 	}
 	getStatusConditionsReturnsOnCall map[int]struct {
-		result1 []v1.Condition
+		result1 []v1a.Condition
 		result2 error
 	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *TaskStatusGetter) GetStatusConditions(arg1 context.Context, arg2 *v1a.Job) ([]v1.Condition, error) {
+func (fake *TaskStatusGetter) GetFailedAttempts(arg1 context.Context, arg2 *v1.Job) ([]v1alpha1.TaskAttemptFailure, error) {
+	fake.getFailedAttemptsMutex.Lock()
+	ret, specificReturn := fake.getFailedAttemptsReturnsOnCall[len(fake.getFailedAttemptsArgsForCall)]
+	fake.getFailedAttemptsArgsForCall = append(fake.getFailedAttemptsArgsForCall, struct {
+		arg1 context.Context
+		arg2 *v1.Job
+	}{arg1, arg2})
+	stub := fake.GetFailedAttemptsStub
+	fakeReturns := fake.getFailedAttemptsReturns
+	fake.recordInvocation("GetFailedAttempts", []interface{}{arg1, arg2})
+	fake.getFailedAttemptsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *TaskStatusGetter) GetFailedAttemptsCallCount() int {
+	fake.getFailedAttemptsMutex.RLock()
+	defer fake.getFailedAttemptsMutex.RUnlock()
+	return len(fake.getFailedAttemptsArgsForCall)
+}
+
+func (fake *TaskStatusGetter) GetFailedAttemptsCalls(stub func(context.Context, *v1.Job) ([]v1alpha1.TaskAttemptFailure, error)) {
+	fake.getFailedAttemptsMutex.Lock()
+	defer fake.getFailedAttemptsMutex.Unlock()
+	fake.GetFailedAttemptsStub = stub
+}
+
+func (fake *TaskStatusGetter) GetFailedAttemptsArgsForCall(i int) (context.Context, *v1.Job) {
+	fake.getFailedAttemptsMutex.RLock()
+	defer fake.getFailedAttemptsMutex.RUnlock()
+	argsForCall := fake.getFailedAttemptsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *TaskStatusGetter) GetFailedAttemptsReturns(result1 []v1alpha1.TaskAttemptFailure, result2 error) {
+	fake.getFailedAttemptsMutex.Lock()
+	defer fake.getFailedAttemptsMutex.Unlock()
+	fake.GetFailedAttemptsStub = nil
+	fake.getFailedAttemptsReturns = struct {
+		result1 []v1alpha1.TaskAttemptFailure
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *TaskStatusGetter) GetFailedAttemptsReturnsOnCall(i int, result1 []v1alpha1.TaskAttemptFailure, result2 error) {
+	fake.getFailedAttemptsMutex.Lock()
+	defer fake.getFailedAttemptsMutex.Unlock()
+	fake.GetFailedAttemptsStub = nil
+	if fake.getFailedAttemptsReturnsOnCall == nil {
+		fake.getFailedAttemptsReturnsOnCall = make(map[int]struct {
+			result1 []v1alpha1.TaskAttemptFailure
+			result2 error
+		})
+	}
+	fake.getFailedAttemptsReturnsOnCall[i] = struct {
+		result1 []v1alpha1.TaskAttemptFailure
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *TaskStatusGetter) GetStatusConditions(arg1 context.Context, arg2 *v1.Job) ([]v1a.Condition, error) {
 	fake.getStatusConditionsMutex.Lock()
 	ret, specificReturn := fake.getStatusConditionsReturnsOnCall[len(fake.getStatusConditionsArgsForCall)]
 	fake.getStatusConditionsArgsForCall = append(fake.getStatusConditionsArgsForCall, struct {
 		arg1 context.Context
-		arg2 *v1a.Job
+		arg2 *v1.Job
 	}{arg1, arg2})
 	stub := fake.GetStatusConditionsStub
 	fakeReturns := fake.getStatusConditionsReturns
@@ -55,41 +135,41 @@ func (fake *TaskStatusGetter) GetStatusConditionsCallCount() int {
 	return len(fake.getStatusConditionsArgsForCall)
 }
 
-func (fake *TaskStatusGetter) GetStatusConditionsCalls(stub func(context.Context, *v1a.Job) ([]v1.Condition, error)) {
+func (fake *TaskStatusGetter) GetStatusConditionsCalls(stub func(context.Context, *v1.Job) ([]v1a.Condition, error)) {
 	fake.getStatusConditionsMutex.Lock()
 	defer fake.getStatusConditionsMutex.Unlock()
 	fake.GetStatusConditionsStub = stub
 }
 
-func (fake *TaskStatusGetter) GetStatusConditionsArgsForCall(i int) (context.Context, *v1a.Job) {
+func (fake *TaskStatusGetter) GetStatusConditionsArgsForCall(i int) (context.Context, *v1.Job) {
 	fake.getStatusConditionsMutex.RLock()
 	defer fake.getStatusConditionsMutex.RUnlock()
 	argsForCall := fake.getStatusConditionsArgsForCall[i]
 	return argsForCall.arg1, argsForCall.arg2
 }
 
-func (fake *TaskStatusGetter) GetStatusConditionsReturns(result1 []v1.Condition, result2 error) {
+func (fake *TaskStatusGetter) GetStatusConditionsReturns(result1 []v1a.Condition, result2 error) {
 	fake.getStatusConditionsMutex.Lock()
 	defer fake.getStatusConditionsMutex.Unlock()
 	fake.GetStatusConditionsStub = nil
 	fake.getStatusConditionsReturns = struct {
-		result1 []v1.Condition
+		result1 []v1a.Condition
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *TaskStatusGetter) GetStatusConditionsReturnsOnCall(i int, result1 []v1.Condition, result2 error) {
+func (fake *TaskStatusGetter) GetStatusConditionsReturnsOnCall(i int, result1 []v1a.Condition, result2 error) {
 	fake.getStatusConditionsMutex.Lock()
 	defer fake.getStatusConditionsMutex.Unlock()
 	fake.GetStatusConditionsStub = nil
 	if fake.getStatusConditionsReturnsOnCall == nil {
 		fake.getStatusConditionsReturnsOnCall = make(map[int]struct {
-			result1 []v1.Condition
+			result1 []v1a.Condition
 			result2 error
 		})
 	}
 	fake.getStatusConditionsReturnsOnCall[i] = struct {
-		result1 []v1.Condition
+		result1 []v1a.Condition
 		result2 error
 	}{result1, result2}
 }
@@ -97,6 +177,8 @@ func (fake *TaskStatusGetter) GetStatusConditionsReturnsOnCall(i int, result1 []
 func (fake *TaskStatusGetter) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.getFailedAttemptsMutex.RLock()
+	defer fake.getFailedAttemptsMutex.RUnlock()
 	fake.getStatusConditionsMutex.RLock()
 	defer fake.getStatusConditionsMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
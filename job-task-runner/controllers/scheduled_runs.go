@@ -0,0 +1,69 @@
+package controllers
+
+import (
+	"slices"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ScheduledRuns remembers the jobs started by the cron jobs of
+// TaskScheduleWorkloads as they are observed, so that a run whose job is
+// deleted before its TaskScheduleWorkload is reconciled is still reported
+type ScheduledRuns struct {
+	mutex sync.Mutex
+	runs  map[types.NamespacedName]map[string]struct{}
+}
+
+func NewScheduledRuns() *ScheduledRuns {
+	return &ScheduledRuns{
+		runs: map[types.NamespacedName]map[string]struct{}{},
+	}
+}
+
+// Record remembers the job as a run of the TaskScheduleWorkload it has been
+// started for. Jobs that have not been started by a cron job are ignored.
+func (s *ScheduledRuns) Record(job client.Object) {
+	scheduleWorkloadName, ok := job.GetLabels()[TaskScheduleWorkloadLabelKey]
+	if !ok {
+		return
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	key := types.NamespacedName{Namespace: job.GetNamespace(), Name: scheduleWorkloadName}
+	if s.runs[key] == nil {
+		s.runs[key] = map[string]struct{}{}
+	}
+	s.runs[key][job.GetName()] = struct{}{}
+}
+
+func (s *ScheduledRuns) get(scheduleWorkload types.NamespacedName) []string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	runs := []string{}
+	for run := range s.runs[scheduleWorkload] {
+		runs = append(runs, run)
+	}
+	slices.Sort(runs)
+
+	return runs
+}
+
+// forget drops runs that no longer need to be remembered, i.e. runs that have
+// been reported in the status or that have been recorded as TaskWorkloads
+func (s *ScheduledRuns) forget(scheduleWorkload types.NamespacedName, runs []string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for _, run := range runs {
+		delete(s.runs[scheduleWorkload], run)
+	}
+
+	if len(s.runs[scheduleWorkload]) == 0 {
+		delete(s.runs, scheduleWorkload)
+	}
+}
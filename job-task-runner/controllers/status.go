@@ -3,14 +3,18 @@ package controllers
 import (
 	"context"
 	"fmt"
+	"slices"
 
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+const TaskTimedOutReason = "TaskTimedOut"
+
 type StatusGetter struct {
 	k8sClient client.Client
 }
@@ -50,6 +54,20 @@ func (s *StatusGetter) GetStatusConditions(ctx context.Context, job *batchv1.Job
 		})
 	}
 
+	if deadlineExceeded := getDeadlineExceededCondition(job.Status); deadlineExceeded != nil {
+		// the pod is killed when the deadline is reached, so there is no
+		// container status to report
+		conditions = append(conditions, metav1.Condition{
+			Type:               korifiv1alpha1.TaskFailedConditionType,
+			Status:             metav1.ConditionTrue,
+			LastTransitionTime: deadlineExceeded.LastTransitionTime,
+			Reason:             TaskTimedOutReason,
+			Message:            fmt.Sprintf("Task exceeded its timeout of %d seconds", tools.ZeroIfNil(job.Spec.ActiveDeadlineSeconds)),
+		})
+
+		return conditions, nil
+	}
+
 	lastFailureTimestamp := getLastFailureTimestamp(job.Status)
 	if job.Status.Failed > 0 && lastFailureTimestamp != nil {
 		terminationState, err := s.getFailedContainerStatus(ctx, job)
@@ -69,21 +87,48 @@ func (s *StatusGetter) GetStatusConditions(ctx context.Context, job *batchv1.Job
 	return conditions, nil
 }
 
-func (s *StatusGetter) getFailedContainerStatus(ctx context.Context, job *batchv1.Job) (*corev1.ContainerStateTerminated, error) {
-	var jobPods corev1.PodList
-	if err := s.k8sClient.List(ctx, &jobPods, client.InNamespace(job.Namespace), client.MatchingLabels{"job-name": job.Name}); err != nil {
+// GetFailedAttempts returns the failed attempts at running the job, oldest
+// first. Retried jobs have a pod per attempt.
+func (s *StatusGetter) GetFailedAttempts(ctx context.Context, job *batchv1.Job) ([]korifiv1alpha1.TaskAttemptFailure, error) {
+	if job.Status.Failed == 0 {
+		return nil, nil
+	}
+
+	jobPods, err := s.listJobPods(ctx, job)
+	if err != nil {
 		return nil, err
 	}
 
-	if len(jobPods.Items) > 1 {
-		return nil, fmt.Errorf("found more than one pod for job %s:%s", job.Namespace, job.Name)
+	failedAttempts := []korifiv1alpha1.TaskAttemptFailure{}
+	for _, jobPod := range jobPods {
+		terminationState := workloadTerminationState(jobPod)
+		if terminationState == nil || terminationState.ExitCode == 0 {
+			continue
+		}
+
+		failedAttempts = append(failedAttempts, korifiv1alpha1.TaskAttemptFailure{
+			ExitCode:   terminationState.ExitCode,
+			Reason:     terminationState.Reason,
+			Message:    fmt.Sprintf("Failed with exit code: %d", terminationState.ExitCode),
+			FinishedAt: terminationState.FinishedAt,
+		})
+	}
+
+	return failedAttempts, nil
+}
+
+func (s *StatusGetter) getFailedContainerStatus(ctx context.Context, job *batchv1.Job) (*corev1.ContainerStateTerminated, error) {
+	jobPods, err := s.listJobPods(ctx, job)
+	if err != nil {
+		return nil, err
 	}
 
-	if len(jobPods.Items) == 0 {
+	if len(jobPods) == 0 {
 		return nil, fmt.Errorf("no pods found for job %s:%s", job.Namespace, job.Name)
 	}
 
-	jobPod := jobPods.Items[0]
+	// the last attempt is the one that failed the job
+	jobPod := jobPods[len(jobPods)-1]
 
 	for _, containerStatus := range jobPod.Status.ContainerStatuses {
 		if containerStatus.Name != workloadContainerName {
@@ -100,6 +145,40 @@ func (s *StatusGetter) getFailedContainerStatus(ctx context.Context, job *batchv
 	return nil, fmt.Errorf("no workload container found for job %s:%s", job.Namespace, job.Name)
 }
 
+// listJobPods returns the pods of the job, oldest first
+func (s *StatusGetter) listJobPods(ctx context.Context, job *batchv1.Job) ([]corev1.Pod, error) {
+	var jobPods corev1.PodList
+	if err := s.k8sClient.List(ctx, &jobPods, client.InNamespace(job.Namespace), client.MatchingLabels{"job-name": job.Name}); err != nil {
+		return nil, err
+	}
+
+	slices.SortStableFunc(jobPods.Items, func(a, b corev1.Pod) int {
+		return a.CreationTimestamp.Compare(b.CreationTimestamp.Time)
+	})
+
+	return jobPods.Items, nil
+}
+
+func workloadTerminationState(pod corev1.Pod) *corev1.ContainerStateTerminated {
+	for _, containerStatus := range pod.Status.ContainerStatuses {
+		if containerStatus.Name == workloadContainerName {
+			return containerStatus.State.Terminated
+		}
+	}
+
+	return nil
+}
+
+func getDeadlineExceededCondition(jobStatus batchv1.JobStatus) *batchv1.JobCondition {
+	for _, condition := range jobStatus.Conditions {
+		if condition.Type == batchv1.JobFailed && condition.Status == corev1.ConditionTrue && condition.Reason == batchv1.JobReasonDeadlineExceeded {
+			return &condition
+		}
+	}
+
+	return nil
+}
+
 func getLastFailureTimestamp(jobStatus batchv1.JobStatus) *metav1.Time {
 	var lastFailure *metav1.Time
 
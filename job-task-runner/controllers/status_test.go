@@ -7,6 +7,7 @@ import (
 
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	"code.cloudfoundry.org/korifi/job-task-runner/controllers"
+	"code.cloudfoundry.org/korifi/tools"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -184,6 +185,32 @@ var _ = Describe("StatusGetter", func() {
 			Expect(failedCondition.Message).To(Equal("Failed with exit code: 42"))
 		})
 
+		When("the job exceeded its deadline", func() {
+			BeforeEach(func() {
+				job.Spec.ActiveDeadlineSeconds = tools.PtrTo(int64(60))
+				job.Status.Conditions = []batchv1.JobCondition{{
+					Type:               batchv1.JobFailed,
+					Status:             corev1.ConditionTrue,
+					Reason:             batchv1.JobReasonDeadlineExceeded,
+					LastTransitionTime: later,
+				}}
+			})
+
+			It("returns a timed out failed status", func() {
+				Expect(conditionsErr).NotTo(HaveOccurred())
+				failedCondition := meta.FindStatusCondition(conditions, korifiv1alpha1.TaskFailedConditionType)
+				Expect(failedCondition).NotTo(BeNil())
+				Expect(failedCondition.Status).To(Equal(metav1.ConditionTrue))
+				Expect(failedCondition.LastTransitionTime).To(Equal(later))
+				Expect(failedCondition.Reason).To(Equal("TaskTimedOut"))
+				Expect(failedCondition.Message).To(Equal("Task exceeded its timeout of 60 seconds"))
+			})
+
+			It("does not look up the job pods", func() {
+				Expect(fakeClient.ListCallCount()).To(BeZero())
+			})
+		})
+
 		When("listing the job pods fails", func() {
 			BeforeEach(func() {
 				fakeClient.ListReturns(errors.New("boom"))
@@ -194,13 +221,22 @@ var _ = Describe("StatusGetter", func() {
 			})
 		})
 
-		When("we get more than one pod for a job", func() {
+		When("the job was retried", func() {
 			BeforeEach(func() {
-				podList.Items = append(podList.Items, podList.Items[0])
+				firstAttempt := *podList.Items[0].DeepCopy()
+				firstAttempt.CreationTimestamp = now
+				firstAttempt.Status.ContainerStatuses[1].State.Terminated.ExitCode = 7
+
+				lastAttempt := podList.Items[0]
+				lastAttempt.CreationTimestamp = later
+
+				podList.Items = []corev1.Pod{lastAttempt, firstAttempt}
 			})
 
-			It("returns an error", func() {
-				Expect(conditionsErr).To(MatchError(ContainSubstring("found more than one pod for job")))
+			It("returns a failed status with values from the last attempt", func() {
+				Expect(conditionsErr).NotTo(HaveOccurred())
+				failedCondition := meta.FindStatusCondition(conditions, korifiv1alpha1.TaskFailedConditionType)
+				Expect(failedCondition.Message).To(Equal("Failed with exit code: 42"))
 			})
 		})
 
@@ -235,3 +271,101 @@ var _ = Describe("StatusGetter", func() {
 		})
 	})
 })
+
+var _ = Describe("StatusGetter failed attempts", func() {
+	var (
+		statusGetter   *controllers.StatusGetter
+		job            *batchv1.Job
+		podList        corev1.PodList
+		failedAttempts []korifiv1alpha1.TaskAttemptFailure
+		attemptsErr    error
+	)
+
+	workloadPod := func(created time.Time, terminated *corev1.ContainerStateTerminated) corev1.Pod {
+		return corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				CreationTimestamp: metav1.NewTime(created),
+			},
+			Status: corev1.PodStatus{
+				ContainerStatuses: []corev1.ContainerStatus{{
+					Name:  "workload",
+					State: corev1.ContainerState{Terminated: terminated},
+				}},
+			},
+		}
+	}
+
+	BeforeEach(func() {
+		job = &batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "my-job",
+				Namespace: "my-ns",
+			},
+			Status: batchv1.JobStatus{
+				Failed: 2,
+			},
+		}
+
+		now := time.Now()
+		podList = corev1.PodList{
+			Items: []corev1.Pod{
+				workloadPod(now.Add(2*time.Minute), nil),
+				workloadPod(now.Add(time.Minute), &corev1.ContainerStateTerminated{ExitCode: 2, Reason: "Error"}),
+				workloadPod(now, &corev1.ContainerStateTerminated{ExitCode: 1, Reason: "OOMKilled"}),
+			},
+		}
+
+		fakeClient.ListStub = func(ctx context.Context, objList client.ObjectList, opts ...client.ListOption) error {
+			list, ok := objList.(*corev1.PodList)
+			Expect(ok).To(BeTrue())
+			*list = podList
+
+			return nil
+		}
+
+		statusGetter = controllers.NewStatusGetter(fakeClient)
+	})
+
+	JustBeforeEach(func() {
+		failedAttempts, attemptsErr = statusGetter.GetFailedAttempts(context.Background(), job)
+	})
+
+	It("returns the failed attempts, oldest first", func() {
+		Expect(attemptsErr).NotTo(HaveOccurred())
+		Expect(failedAttempts).To(HaveLen(2))
+		Expect(failedAttempts[0].ExitCode).To(BeEquivalentTo(1))
+		Expect(failedAttempts[0].Reason).To(Equal("OOMKilled"))
+		Expect(failedAttempts[0].Message).To(Equal("Failed with exit code: 1"))
+		Expect(failedAttempts[1].ExitCode).To(BeEquivalentTo(2))
+		Expect(failedAttempts[1].Reason).To(Equal("Error"))
+	})
+
+	It("lists the job pods", func() {
+		Expect(fakeClient.ListCallCount()).To(Equal(1))
+		_, _, opts := fakeClient.ListArgsForCall(0)
+		Expect(opts).To(ContainElement(client.InNamespace("my-ns")))
+		Expect(opts).To(ContainElement(client.MatchingLabels{"job-name": "my-job"}))
+	})
+
+	When("no attempt has failed", func() {
+		BeforeEach(func() {
+			job.Status.Failed = 0
+		})
+
+		It("does not look up the job pods", func() {
+			Expect(attemptsErr).NotTo(HaveOccurred())
+			Expect(failedAttempts).To(BeEmpty())
+			Expect(fakeClient.ListCallCount()).To(BeZero())
+		})
+	})
+
+	When("listing the job pods fails", func() {
+		BeforeEach(func() {
+			fakeClient.ListReturns(errors.New("boom"))
+		})
+
+		It("returns the error", func() {
+			Expect(attemptsErr).To(MatchError(ContainSubstring("boom")))
+		})
+	})
+})
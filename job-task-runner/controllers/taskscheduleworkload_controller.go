@@ -0,0 +1,225 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools/k8s"
+
+	"github.com/go-logr/logr"
+	batchv1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// TaskScheduleWorkloadLabelKey is set on the jobs started by the cron job of
+// a TaskScheduleWorkload
+const TaskScheduleWorkloadLabelKey = "korifi.cloudfoundry.org/task-schedule-workload"
+
+// TaskScheduleWorkloadReconciler reconciles a TaskScheduleWorkload object
+type TaskScheduleWorkloadReconciler struct {
+	k8sClient     client.Client
+	logger        logr.Logger
+	scheme        *runtime.Scheme
+	jobTTL        time.Duration
+	scheduledRuns *ScheduledRuns
+}
+
+func NewTaskScheduleWorkloadReconciler(
+	logger logr.Logger,
+	k8sClient client.Client,
+	scheme *runtime.Scheme,
+	jobTTL time.Duration,
+	scheduledRuns *ScheduledRuns,
+) *k8s.PatchingReconciler[korifiv1alpha1.TaskScheduleWorkload, *korifiv1alpha1.TaskScheduleWorkload] {
+	scheduleReconciler := TaskScheduleWorkloadReconciler{
+		k8sClient:     k8sClient,
+		logger:        logger,
+		scheme:        scheme,
+		jobTTL:        jobTTL,
+		scheduledRuns: scheduledRuns,
+	}
+
+	return k8s.NewPatchingReconciler[korifiv1alpha1.TaskScheduleWorkload, *korifiv1alpha1.TaskScheduleWorkload](logger, k8sClient, &scheduleReconciler)
+}
+
+func (r *TaskScheduleWorkloadReconciler) SetupWithManager(mgr ctrl.Manager) *builder.Builder {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&korifiv1alpha1.TaskScheduleWorkload{}).
+		Owns(&batchv1.CronJob{}).
+		Watches(
+			&batchv1.Job{},
+			r.recordingScheduledRuns(handler.EnqueueRequestsFromMapFunc(scheduledJobToTaskScheduleWorkload)),
+		)
+}
+
+// recordingScheduledRuns records the jobs as they are created or deleted, so
+// that jobs garbage collected before the TaskScheduleWorkload is reconciled
+// are still reported as runs
+func (r *TaskScheduleWorkloadReconciler) recordingScheduledRuns(enqueue handler.EventHandler) handler.EventHandler {
+	return handler.Funcs{
+		CreateFunc: func(ctx context.Context, e event.CreateEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+			r.scheduledRuns.Record(e.Object)
+			enqueue.Create(ctx, e, q)
+		},
+		UpdateFunc: enqueue.Update,
+		DeleteFunc: func(ctx context.Context, e event.DeleteEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+			r.scheduledRuns.Record(e.Object)
+			enqueue.Delete(ctx, e, q)
+		},
+		GenericFunc: enqueue.Generic,
+	}
+}
+
+func scheduledJobToTaskScheduleWorkload(ctx context.Context, o client.Object) []reconcile.Request {
+	scheduleWorkloadName, ok := o.GetLabels()[TaskScheduleWorkloadLabelKey]
+	if !ok {
+		return nil
+	}
+
+	return []reconcile.Request{{NamespacedName: client.ObjectKey{Namespace: o.GetNamespace(), Name: scheduleWorkloadName}}}
+}
+
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=taskscheduleworkloads,verbs=get;list;watch;patch
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=taskscheduleworkloads/status,verbs=get;patch
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=taskscheduleworkloads/finalizers,verbs=update
+//+kubebuilder:rbac:groups=batch,resources=cronjobs,verbs=create;get;list;watch;patch
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=taskworkloads,verbs=get;list;watch
+
+func (r *TaskScheduleWorkloadReconciler) ReconcileResource(ctx context.Context, scheduleWorkload *korifiv1alpha1.TaskScheduleWorkload) (ctrl.Result, error) {
+	log := logr.FromContextOrDiscard(ctx)
+
+	if !scheduleWorkload.GetDeletionTimestamp().IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	scheduleWorkload.Status.ObservedGeneration = scheduleWorkload.Generation
+	log.V(1).Info("set observed generation", "generation", scheduleWorkload.Status.ObservedGeneration)
+
+	cronJob, err := r.createOrPatchCronJob(ctx, scheduleWorkload)
+	if err != nil {
+		log.Info("failed to create or patch cron job", "reason", err)
+		return ctrl.Result{}, err
+	}
+
+	runs, err := r.listRuns(ctx, scheduleWorkload)
+	if err != nil {
+		log.Info("failed to list runs", "reason", err)
+		return ctrl.Result{}, err
+	}
+
+	scheduleWorkload.Status.LastScheduleTime = cronJob.Status.LastScheduleTime
+	scheduleWorkload.Status.Runs = runs
+
+	return ctrl.Result{}, nil
+}
+
+func (r *TaskScheduleWorkloadReconciler) createOrPatchCronJob(ctx context.Context, scheduleWorkload *korifiv1alpha1.TaskScheduleWorkload) (*batchv1.CronJob, error) {
+	cronJob := &batchv1.CronJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      scheduleWorkload.Name,
+			Namespace: scheduleWorkload.Namespace,
+		},
+	}
+
+	_, err := controllerutil.CreateOrPatch(ctx, r.k8sClient, cronJob, func() error {
+		cronJob.Spec.Schedule = scheduleWorkload.Spec.Schedule
+		cronJob.Spec.ConcurrencyPolicy = toConcurrencyPolicy(scheduleWorkload.Spec.ConcurrencyPolicy)
+		cronJob.Spec.Suspend = &scheduleWorkload.Spec.Suspend
+		cronJob.Spec.JobTemplate = batchv1.JobTemplateSpec{
+			ObjectMeta: metav1.ObjectMeta{
+				Labels: map[string]string{
+					TaskScheduleWorkloadLabelKey: scheduleWorkload.Name,
+				},
+			},
+			Spec: jobSpec(scheduleWorkload.Spec.Template, r.jobTTL),
+		}
+
+		return controllerutil.SetControllerReference(scheduleWorkload, cronJob, r.scheme)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return cronJob, nil
+}
+
+// listRuns returns the runs that have not been recorded as TaskWorkloads yet.
+// Runs are kept in the status until then, even if their jobs have been
+// deleted in the meantime.
+func (r *TaskScheduleWorkloadReconciler) listRuns(ctx context.Context, scheduleWorkload *korifiv1alpha1.TaskScheduleWorkload) ([]string, error) {
+	key := client.ObjectKeyFromObject(scheduleWorkload)
+	r.scheduledRuns.forget(key, scheduleWorkload.Status.Runs)
+
+	jobs := batchv1.JobList{}
+	err := r.k8sClient.List(ctx, &jobs,
+		client.InNamespace(scheduleWorkload.Namespace),
+		client.MatchingLabels{TaskScheduleWorkloadLabelKey: scheduleWorkload.Name},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	taskWorkloads := korifiv1alpha1.TaskWorkloadList{}
+	err = r.k8sClient.List(ctx, &taskWorkloads,
+		client.InNamespace(scheduleWorkload.Namespace),
+		client.MatchingLabels{korifiv1alpha1.CFTaskScheduleGUIDLabelKey: scheduleWorkload.Name},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	recordedRuns := []string{}
+	for _, taskWorkload := range taskWorkloads.Items {
+		recordedRuns = append(recordedRuns, taskWorkload.Name)
+	}
+	r.scheduledRuns.forget(key, recordedRuns)
+
+	runs := append(slices.Clone(scheduleWorkload.Status.Runs), r.scheduledRuns.get(key)...)
+	for _, job := range jobs.Items {
+		runs = append(runs, job.Name)
+	}
+	runs = slices.DeleteFunc(runs, func(run string) bool {
+		return slices.Contains(recordedRuns, run)
+	})
+	slices.Sort(runs)
+
+	return slices.Compact(runs), nil
+}
+
+func toConcurrencyPolicy(policy string) batchv1.ConcurrencyPolicy {
+	switch policy {
+	case korifiv1alpha1.ConcurrencyPolicyForbid:
+		return batchv1.ForbidConcurrent
+	case korifiv1alpha1.ConcurrencyPolicyReplace:
+		return batchv1.ReplaceConcurrent
+	default:
+		return batchv1.AllowConcurrent
+	}
+}
@@ -0,0 +1,273 @@
+package controllers_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/job-task-runner/controllers"
+	"code.cloudfoundry.org/korifi/tools"
+	"code.cloudfoundry.org/korifi/tools/k8s"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	batchv1 "k8s.io/api/batch/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+)
+
+var _ = Describe("TaskScheduleWorkloadController", func() {
+	var (
+		reconciler       *k8s.PatchingReconciler[korifiv1alpha1.TaskScheduleWorkload, *korifiv1alpha1.TaskScheduleWorkload]
+		reconcileErr     error
+		scheduleWorkload *korifiv1alpha1.TaskScheduleWorkload
+		existingCronJob  *batchv1.CronJob
+		getCronJobErr    error
+		createdCronJob   *batchv1.CronJob
+		jobs             []batchv1.Job
+		taskWorkloads    []korifiv1alpha1.TaskWorkload
+		scheduledRuns    *controllers.ScheduledRuns
+		lastScheduleTime metav1.Time
+	)
+
+	BeforeEach(func() {
+		Expect(korifiv1alpha1.AddToScheme(scheme.Scheme)).To(Succeed())
+
+		lastScheduleTime = metav1.NewTime(time.Now().Add(-time.Minute).Truncate(time.Second))
+
+		scheduleWorkload = &korifiv1alpha1.TaskScheduleWorkload{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       "my-schedule",
+				Namespace:  "my-namespace",
+				Generation: 2,
+			},
+			Spec: korifiv1alpha1.TaskScheduleWorkloadSpec{
+				Schedule:          "*/5 * * * *",
+				ConcurrencyPolicy: korifiv1alpha1.ConcurrencyPolicyForbid,
+				Suspend:           true,
+				Template: korifiv1alpha1.TaskWorkloadSpec{
+					Image:                 "my-image",
+					Command:               []string{"my", "command"},
+					ActiveDeadlineSeconds: tools.PtrTo(int64(60)),
+				},
+			},
+		}
+
+		existingCronJob = nil
+		getCronJobErr = k8serrors.NewNotFound(schema.GroupResource{}, "cronjob")
+		createdCronJob = nil
+		jobs = []batchv1.Job{
+			{ObjectMeta: metav1.ObjectMeta{Name: "my-schedule-2"}},
+			{ObjectMeta: metav1.ObjectMeta{Name: "my-schedule-1"}},
+		}
+		taskWorkloads = nil
+		scheduledRuns = controllers.NewScheduledRuns()
+
+		fakeClient.GetStub = func(_ context.Context, _ types.NamespacedName, obj client.Object, _ ...client.GetOption) error {
+			switch obj := obj.(type) {
+			case *korifiv1alpha1.TaskScheduleWorkload:
+				scheduleWorkload.DeepCopyInto(obj)
+				return nil
+			case *batchv1.CronJob:
+				if existingCronJob != nil {
+					existingCronJob.DeepCopyInto(obj)
+				}
+				return getCronJobErr
+			default:
+				panic("TestClient Get provided an unexpected object type")
+			}
+		}
+
+		fakeClient.CreateStub = func(_ context.Context, obj client.Object, _ ...client.CreateOption) error {
+			cronJob, ok := obj.(*batchv1.CronJob)
+			Expect(ok).To(BeTrue())
+			createdCronJob = cronJob.DeepCopy()
+			return nil
+		}
+
+		fakeClient.ListStub = func(_ context.Context, list client.ObjectList, _ ...client.ListOption) error {
+			switch list := list.(type) {
+			case *batchv1.JobList:
+				list.Items = jobs
+			case *korifiv1alpha1.TaskWorkloadList:
+				list.Items = taskWorkloads
+			default:
+				panic("TestClient List provided an unexpected list type")
+			}
+			return nil
+		}
+
+		reconciler = controllers.NewTaskScheduleWorkloadReconciler(zap.New(zap.WriteTo(GinkgoWriter), zap.UseDevMode(true)), fakeClient, scheme.Scheme, time.Hour, scheduledRuns)
+	})
+
+	JustBeforeEach(func() {
+		_, reconcileErr = reconciler.Reconcile(context.Background(), ctrl.Request{NamespacedName: client.ObjectKeyFromObject(scheduleWorkload)})
+	})
+
+	It("creates a cron job", func() {
+		Expect(reconcileErr).NotTo(HaveOccurred())
+		Expect(createdCronJob).NotTo(BeNil())
+		Expect(createdCronJob.Name).To(Equal("my-schedule"))
+		Expect(createdCronJob.Namespace).To(Equal("my-namespace"))
+		Expect(createdCronJob.Spec.Schedule).To(Equal("*/5 * * * *"))
+		Expect(createdCronJob.Spec.ConcurrencyPolicy).To(Equal(batchv1.ForbidConcurrent))
+		Expect(createdCronJob.Spec.Suspend).To(PointTo(BeTrue()))
+		Expect(createdCronJob.OwnerReferences).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+			"Name":       Equal("my-schedule"),
+			"Controller": PointTo(BeTrue()),
+		})))
+	})
+
+	It("builds the job template from the workload template", func() {
+		Expect(reconcileErr).NotTo(HaveOccurred())
+		jobTemplate := createdCronJob.Spec.JobTemplate
+		Expect(jobTemplate.Labels).To(HaveKeyWithValue(controllers.TaskScheduleWorkloadLabelKey, "my-schedule"))
+		Expect(jobTemplate.Spec.BackoffLimit).To(PointTo(BeEquivalentTo(0)))
+		Expect(jobTemplate.Spec.ActiveDeadlineSeconds).To(PointTo(BeEquivalentTo(60)))
+		Expect(jobTemplate.Spec.TTLSecondsAfterFinished).To(PointTo(BeEquivalentTo(3600)))
+		Expect(jobTemplate.Spec.Template.Spec.ServiceAccountName).To(Equal(controllers.ServiceAccountName))
+		Expect(jobTemplate.Spec.Template.Spec.Containers).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+			"Name":    Equal("workload"),
+			"Image":   Equal("my-image"),
+			"Command": Equal([]string{"my", "command"}),
+		})))
+	})
+
+	It("lists the runs of the schedule and the task workloads recording them", func() {
+		Expect(fakeClient.ListCallCount()).To(Equal(2))
+		_, _, opts := fakeClient.ListArgsForCall(0)
+		Expect(opts).To(ContainElements(
+			client.InNamespace("my-namespace"),
+			client.MatchingLabels{controllers.TaskScheduleWorkloadLabelKey: "my-schedule"},
+		))
+		_, _, opts = fakeClient.ListArgsForCall(1)
+		Expect(opts).To(ContainElements(
+			client.InNamespace("my-namespace"),
+			client.MatchingLabels{korifiv1alpha1.CFTaskScheduleGUIDLabelKey: "my-schedule"},
+		))
+	})
+
+	It("reports the runs in the status", func() {
+		Expect(fakeStatusWriter.PatchCallCount()).To(Equal(1))
+		_, patchedObj, _, _ := fakeStatusWriter.PatchArgsForCall(0)
+		patchedWorkload, ok := patchedObj.(*korifiv1alpha1.TaskScheduleWorkload)
+		Expect(ok).To(BeTrue())
+		Expect(patchedWorkload.Status.Runs).To(Equal([]string{"my-schedule-1", "my-schedule-2"}))
+		Expect(patchedWorkload.Status.ObservedGeneration).To(BeEquivalentTo(2))
+	})
+
+	When("a job has been garbage collected before the workload is reconciled", func() {
+		BeforeEach(func() {
+			scheduledRuns.Record(&batchv1.Job{ObjectMeta: metav1.ObjectMeta{
+				Name:      "my-schedule-0",
+				Namespace: "my-namespace",
+				Labels:    map[string]string{controllers.TaskScheduleWorkloadLabelKey: "my-schedule"},
+			}})
+		})
+
+		It("reports the recorded run in the status", func() {
+			_, patchedObj, _, _ := fakeStatusWriter.PatchArgsForCall(0)
+			patchedWorkload, ok := patchedObj.(*korifiv1alpha1.TaskScheduleWorkload)
+			Expect(ok).To(BeTrue())
+			Expect(patchedWorkload.Status.Runs).To(Equal([]string{"my-schedule-0", "my-schedule-1", "my-schedule-2"}))
+		})
+	})
+
+	When("the jobs of runs reported in the status have been deleted", func() {
+		BeforeEach(func() {
+			scheduleWorkload.Status.Runs = []string{"my-schedule-0", "my-schedule-1"}
+		})
+
+		It("keeps reporting the runs", func() {
+			_, patchedObj, _, _ := fakeStatusWriter.PatchArgsForCall(0)
+			patchedWorkload, ok := patchedObj.(*korifiv1alpha1.TaskScheduleWorkload)
+			Expect(ok).To(BeTrue())
+			Expect(patchedWorkload.Status.Runs).To(Equal([]string{"my-schedule-0", "my-schedule-1", "my-schedule-2"}))
+		})
+	})
+
+	When("runs have been recorded as task workloads", func() {
+		BeforeEach(func() {
+			scheduleWorkload.Status.Runs = []string{"my-schedule-0"}
+			taskWorkloads = []korifiv1alpha1.TaskWorkload{
+				{ObjectMeta: metav1.ObjectMeta{Name: "my-schedule-0"}},
+				{ObjectMeta: metav1.ObjectMeta{Name: "my-schedule-1"}},
+			}
+		})
+
+		It("stops reporting them", func() {
+			_, patchedObj, _, _ := fakeStatusWriter.PatchArgsForCall(0)
+			patchedWorkload, ok := patchedObj.(*korifiv1alpha1.TaskScheduleWorkload)
+			Expect(ok).To(BeTrue())
+			Expect(patchedWorkload.Status.Runs).To(Equal([]string{"my-schedule-2"}))
+		})
+	})
+
+	When("the cron job exists", func() {
+		BeforeEach(func() {
+			existingCronJob = &batchv1.CronJob{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "my-schedule",
+					Namespace: "my-namespace",
+				},
+				Spec: batchv1.CronJobSpec{
+					Schedule: "0 * * * *",
+				},
+				Status: batchv1.CronJobStatus{
+					LastScheduleTime: &lastScheduleTime,
+				},
+			}
+			getCronJobErr = nil
+		})
+
+		It("patches it", func() {
+			Expect(reconcileErr).NotTo(HaveOccurred())
+			Expect(fakeClient.CreateCallCount()).To(Equal(0))
+
+			var patchedCronJob *batchv1.CronJob
+			for i := range fakeClient.PatchCallCount() {
+				_, patchedObj, _, _ := fakeClient.PatchArgsForCall(i)
+				if cronJob, ok := patchedObj.(*batchv1.CronJob); ok {
+					patchedCronJob = cronJob
+				}
+			}
+			Expect(patchedCronJob).NotTo(BeNil())
+			Expect(patchedCronJob.Spec.Schedule).To(Equal("*/5 * * * *"))
+		})
+
+		It("reports the last schedule time in the status", func() {
+			_, patchedObj, _, _ := fakeStatusWriter.PatchArgsForCall(0)
+			patchedWorkload, ok := patchedObj.(*korifiv1alpha1.TaskScheduleWorkload)
+			Expect(ok).To(BeTrue())
+			Expect(patchedWorkload.Status.LastScheduleTime).To(PointTo(Equal(lastScheduleTime)))
+		})
+	})
+
+	When("getting the cron job fails", func() {
+		BeforeEach(func() {
+			getCronJobErr = errors.New("get-cronjob-failed")
+		})
+
+		It("returns the error", func() {
+			Expect(reconcileErr).To(MatchError(ContainSubstring("get-cronjob-failed")))
+		})
+	})
+
+	When("listing the runs fails", func() {
+		BeforeEach(func() {
+			fakeClient.ListReturns(errors.New("list-jobs-failed"))
+			fakeClient.ListStub = nil
+		})
+
+		It("returns the error", func() {
+			Expect(reconcileErr).To(MatchError(ContainSubstring("list-jobs-failed")))
+		})
+	})
+})
@@ -36,6 +36,8 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
 const (
@@ -47,6 +49,7 @@ const (
 
 type TaskStatusGetter interface {
 	GetStatusConditions(ctx context.Context, job *batchv1.Job) ([]metav1.Condition, error)
+	GetFailedAttempts(ctx context.Context, job *batchv1.Job) ([]korifiv1alpha1.TaskAttemptFailure, error)
 }
 
 // TaskWorkloadReconciler reconciles a TaskWorkload object
@@ -79,7 +82,21 @@ func NewTaskWorkloadReconciler(
 func (r *TaskWorkloadReconciler) SetupWithManager(mgr ctrl.Manager) *builder.Builder {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&korifiv1alpha1.TaskWorkload{}).
-		Owns(&batchv1.Job{})
+		Owns(&batchv1.Job{}).
+		Watches(
+			&batchv1.Job{},
+			handler.EnqueueRequestsFromMapFunc(scheduledJobToTaskWorkload),
+		)
+}
+
+// scheduledJobToTaskWorkload maps jobs started by a cron job to the
+// TaskWorkload adopting them, which has the same name as the job
+func scheduledJobToTaskWorkload(ctx context.Context, o client.Object) []reconcile.Request {
+	if _, ok := o.GetLabels()[TaskScheduleWorkloadLabelKey]; !ok {
+		return nil
+	}
+
+	return []reconcile.Request{{NamespacedName: client.ObjectKeyFromObject(o)}}
 }
 
 //+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=taskworkloads,verbs=get;list;watch;patch
@@ -128,6 +145,11 @@ func (r TaskWorkloadReconciler) getOrCreateJob(ctx context.Context, logger logr.
 			return nil, nil
 		}
 
+		if _, isScheduledRun := taskWorkload.Labels[korifiv1alpha1.CFTaskScheduleGUIDLabelKey]; isScheduledRun {
+			logger.V(1).Info("job of scheduled run not found, not creating it")
+			return nil, nil
+		}
+
 		return r.createJob(ctx, logger, taskWorkload)
 	}
 
@@ -161,50 +183,92 @@ func (r *TaskWorkloadReconciler) workloadToJob(taskWorkload *korifiv1alpha1.Task
 			Name:      taskWorkload.Name,
 			Namespace: taskWorkload.Namespace,
 		},
-		Spec: batchv1.JobSpec{
-			BackoffLimit:            tools.PtrTo(int32(0)),
-			Parallelism:             tools.PtrTo(int32(1)),
-			Completions:             tools.PtrTo(int32(1)),
-			TTLSecondsAfterFinished: tools.PtrTo(int32(r.jobTTL.Seconds())),
-			Template: corev1.PodTemplateSpec{
-				Spec: corev1.PodSpec{
-					RestartPolicy: corev1.RestartPolicyNever,
-					SecurityContext: &corev1.PodSecurityContext{
-						RunAsNonRoot: tools.PtrTo(true),
+		Spec: jobSpec(taskWorkload.Spec, r.jobTTL),
+	}
+
+	err := controllerutil.SetControllerReference(taskWorkload, job, r.scheme)
+	if err != nil {
+		return nil, err
+	}
+
+	return job, nil
+}
+
+func jobSpec(workloadSpec korifiv1alpha1.TaskWorkloadSpec, jobTTL time.Duration) batchv1.JobSpec {
+	backoffLimit, podFailurePolicy := retryPolicy(workloadSpec.RetryPolicy)
+
+	return batchv1.JobSpec{
+		BackoffLimit:            tools.PtrTo(backoffLimit),
+		PodFailurePolicy:        podFailurePolicy,
+		Parallelism:             tools.PtrTo(int32(1)),
+		Completions:             tools.PtrTo(int32(1)),
+		TTLSecondsAfterFinished: tools.PtrTo(int32(jobTTL.Seconds())),
+		ActiveDeadlineSeconds:   workloadSpec.ActiveDeadlineSeconds,
+		Template: corev1.PodTemplateSpec{
+			Spec: corev1.PodSpec{
+				RestartPolicy: corev1.RestartPolicyNever,
+				SecurityContext: &corev1.PodSecurityContext{
+					RunAsNonRoot: tools.PtrTo(true),
+					SeccompProfile: &corev1.SeccompProfile{
+						Type: corev1.SeccompProfileTypeRuntimeDefault,
+					},
+				},
+				AutomountServiceAccountToken: tools.PtrTo(false),
+				ImagePullSecrets:             workloadSpec.ImagePullSecrets,
+				Containers: []corev1.Container{{
+					Name:      workloadContainerName,
+					Image:     workloadSpec.Image,
+					Command:   workloadSpec.Command,
+					Resources: workloadSpec.Resources,
+					Env:       workloadSpec.Env,
+					SecurityContext: &corev1.SecurityContext{
+						Capabilities: &corev1.Capabilities{
+							Drop: []corev1.Capability{"ALL"},
+						},
+						AllowPrivilegeEscalation: tools.PtrTo(false),
 						SeccompProfile: &corev1.SeccompProfile{
 							Type: corev1.SeccompProfileTypeRuntimeDefault,
 						},
 					},
-					AutomountServiceAccountToken: tools.PtrTo(false),
-					ImagePullSecrets:             taskWorkload.Spec.ImagePullSecrets,
-					Containers: []corev1.Container{{
-						Name:      workloadContainerName,
-						Image:     taskWorkload.Spec.Image,
-						Command:   taskWorkload.Spec.Command,
-						Resources: taskWorkload.Spec.Resources,
-						Env:       taskWorkload.Spec.Env,
-						SecurityContext: &corev1.SecurityContext{
-							Capabilities: &corev1.Capabilities{
-								Drop: []corev1.Capability{"ALL"},
-							},
-							AllowPrivilegeEscalation: tools.PtrTo(false),
-							SeccompProfile: &corev1.SeccompProfile{
-								Type: corev1.SeccompProfileTypeRuntimeDefault,
-							},
-						},
-					}},
-					ServiceAccountName: ServiceAccountName,
-				},
+				}},
+				ServiceAccountName: ServiceAccountName,
+				NodeSelector:       workloadSpec.NodeSelector,
+				Tolerations:        workloadSpec.Tolerations,
 			},
 		},
 	}
+}
 
-	err := controllerutil.SetControllerReference(taskWorkload, job, r.scheme)
-	if err != nil {
-		return nil, err
+// retryPolicy maps the task retry policy to the job backoff limit and pod
+// failure policy. Pod disruptions, such as evictions, are not counted as
+// failed attempts of retried tasks.
+func retryPolicy(policy *korifiv1alpha1.TaskWorkloadRetryPolicy) (int32, *batchv1.PodFailurePolicy) {
+	if policy == nil {
+		return 0, nil
 	}
 
-	return job, nil
+	podFailurePolicy := &batchv1.PodFailurePolicy{
+		Rules: []batchv1.PodFailurePolicyRule{{
+			Action: batchv1.PodFailurePolicyActionIgnore,
+			OnPodConditions: []batchv1.PodFailurePolicyOnPodConditionsPattern{{
+				Type:   corev1.DisruptionTarget,
+				Status: corev1.ConditionTrue,
+			}},
+		}},
+	}
+
+	if len(policy.NonRetryableExitCodes) > 0 {
+		podFailurePolicy.Rules = append(podFailurePolicy.Rules, batchv1.PodFailurePolicyRule{
+			Action: batchv1.PodFailurePolicyActionFailJob,
+			OnExitCodes: &batchv1.PodFailurePolicyOnExitCodesRequirement{
+				ContainerName: tools.PtrTo(workloadContainerName),
+				Operator:      batchv1.PodFailurePolicyOnExitCodesOpIn,
+				Values:        policy.NonRetryableExitCodes,
+			},
+		})
+	}
+
+	return policy.BackoffLimit, podFailurePolicy
 }
 
 func (r *TaskWorkloadReconciler) updateTaskWorkloadStatus(ctx context.Context, taskWorkload *korifiv1alpha1.TaskWorkload, job *batchv1.Job) error {
@@ -218,5 +282,13 @@ func (r *TaskWorkloadReconciler) updateTaskWorkloadStatus(ctx context.Context, t
 		meta.SetStatusCondition(&taskWorkload.Status.Conditions, condition)
 	}
 
+	failedAttempts, err := r.statusGetter.GetFailedAttempts(ctx, job)
+	if err != nil {
+		return fmt.Errorf("failed to get failed attempts for job %s:%s: %w", job.Namespace, job.Name, err)
+	}
+
+	taskWorkload.Status.Attempts = job.Status.Active + job.Status.Succeeded + job.Status.Failed
+	taskWorkload.Status.FailedAttempts = failedAttempts
+
 	return nil
 }
@@ -8,10 +8,13 @@ import (
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	"code.cloudfoundry.org/korifi/job-task-runner/controllers"
 	"code.cloudfoundry.org/korifi/job-task-runner/controllers/fake"
+	"code.cloudfoundry.org/korifi/tools"
 	"code.cloudfoundry.org/korifi/tools/k8s"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
 	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -156,6 +159,133 @@ var _ = Describe("TaskworkloadController", func() {
 			Expect(job.Name).To(Equal(taskWorkload.Name))
 		})
 
+		When("the taskworkload is a run of a task schedule", func() {
+			BeforeEach(func() {
+				taskWorkload.Labels = map[string]string{korifiv1alpha1.CFTaskScheduleGUIDLabelKey: "my-schedule"}
+			})
+
+			It("does not create a job, as the cron job starts it", func() {
+				Expect(reconcileErr).NotTo(HaveOccurred())
+				Expect(fakeClient.CreateCallCount()).To(Equal(0))
+				Expect(statusGetter.GetStatusConditionsCallCount()).To(Equal(0))
+			})
+		})
+
+		When("the job is created", func() {
+			var jobToCreate *batchv1.Job
+
+			BeforeEach(func() {
+				fakeClient.CreateStub = func(_ context.Context, obj client.Object, _ ...client.CreateOption) error {
+					jobToCreate = obj.(*batchv1.Job).DeepCopy()
+					return nil
+				}
+			})
+
+			It("does not retry the job", func() {
+				Expect(reconcileErr).NotTo(HaveOccurred())
+				Expect(jobToCreate.Spec.BackoffLimit).To(PointTo(BeEquivalentTo(0)))
+				Expect(jobToCreate.Spec.PodFailurePolicy).To(BeNil())
+			})
+		})
+
+		When("the taskworkload has a retry policy", func() {
+			var jobToCreate *batchv1.Job
+
+			BeforeEach(func() {
+				taskWorkload.Spec.RetryPolicy = &korifiv1alpha1.TaskWorkloadRetryPolicy{
+					BackoffLimit:          2,
+					NonRetryableExitCodes: []int32{3, 4},
+				}
+
+				fakeClient.CreateStub = func(_ context.Context, obj client.Object, _ ...client.CreateOption) error {
+					jobToCreate = obj.(*batchv1.Job).DeepCopy()
+					return nil
+				}
+			})
+
+			It("sets the backoff limit on the job", func() {
+				Expect(reconcileErr).NotTo(HaveOccurred())
+				Expect(jobToCreate.Spec.BackoffLimit).To(PointTo(BeEquivalentTo(2)))
+			})
+
+			It("does not count pod disruptions as failed attempts", func() {
+				Expect(jobToCreate.Spec.PodFailurePolicy).NotTo(BeNil())
+				Expect(jobToCreate.Spec.PodFailurePolicy.Rules).To(ContainElement(batchv1.PodFailurePolicyRule{
+					Action: batchv1.PodFailurePolicyActionIgnore,
+					OnPodConditions: []batchv1.PodFailurePolicyOnPodConditionsPattern{{
+						Type:   corev1.DisruptionTarget,
+						Status: corev1.ConditionTrue,
+					}},
+				}))
+			})
+
+			It("fails the job on non retryable exit codes", func() {
+				Expect(jobToCreate.Spec.PodFailurePolicy.Rules).To(ContainElement(batchv1.PodFailurePolicyRule{
+					Action: batchv1.PodFailurePolicyActionFailJob,
+					OnExitCodes: &batchv1.PodFailurePolicyOnExitCodesRequirement{
+						ContainerName: tools.PtrTo("workload"),
+						Operator:      batchv1.PodFailurePolicyOnExitCodesOpIn,
+						Values:        []int32{3, 4},
+					},
+				}))
+			})
+
+			When("there are no non retryable exit codes", func() {
+				BeforeEach(func() {
+					taskWorkload.Spec.RetryPolicy.NonRetryableExitCodes = nil
+				})
+
+				It("only ignores pod disruptions", func() {
+					Expect(jobToCreate.Spec.PodFailurePolicy.Rules).To(HaveLen(1))
+					Expect(jobToCreate.Spec.PodFailurePolicy.Rules[0].Action).To(Equal(batchv1.PodFailurePolicyActionIgnore))
+				})
+			})
+		})
+
+		When("the taskworkload has an active deadline", func() {
+			var jobToCreate *batchv1.Job
+
+			BeforeEach(func() {
+				taskWorkload.Spec.ActiveDeadlineSeconds = tools.PtrTo(int64(300))
+
+				fakeClient.CreateStub = func(_ context.Context, obj client.Object, _ ...client.CreateOption) error {
+					jobToCreate = obj.(*batchv1.Job).DeepCopy()
+					return nil
+				}
+			})
+
+			It("sets the deadline on the job", func() {
+				Expect(reconcileErr).NotTo(HaveOccurred())
+				Expect(jobToCreate.Spec.ActiveDeadlineSeconds).To(PointTo(BeEquivalentTo(300)))
+			})
+		})
+
+		When("the taskworkload has a node placement", func() {
+			var jobToCreate *batchv1.Job
+
+			BeforeEach(func() {
+				taskWorkload.Spec.NodeSelector = map[string]string{"pool": "regulated"}
+				taskWorkload.Spec.Tolerations = []corev1.Toleration{{
+					Key:      "dedicated",
+					Operator: corev1.TolerationOpExists,
+				}}
+
+				fakeClient.CreateStub = func(_ context.Context, obj client.Object, _ ...client.CreateOption) error {
+					jobToCreate = obj.(*batchv1.Job).DeepCopy()
+					return nil
+				}
+			})
+
+			It("sets the placement on the job pod template", func() {
+				Expect(reconcileErr).NotTo(HaveOccurred())
+				Expect(jobToCreate.Spec.Template.Spec.NodeSelector).To(Equal(map[string]string{"pool": "regulated"}))
+				Expect(jobToCreate.Spec.Template.Spec.Tolerations).To(ConsistOf(corev1.Toleration{
+					Key:      "dedicated",
+					Operator: corev1.TolerationOpExists,
+				}))
+			})
+		})
+
 		When("the taskworkload has the initialized true condition", func() {
 			BeforeEach(func() {
 				meta.SetStatusCondition(&taskWorkload.Status.Conditions, metav1.Condition{
@@ -215,6 +345,44 @@ var _ = Describe("TaskworkloadController", func() {
 		Expect(meta.IsStatusConditionTrue(patchedTaskWorkload.Status.Conditions, "foo")).To(BeTrue())
 	})
 
+	When("the job has been attempted several times", func() {
+		BeforeEach(func() {
+			existingJob.Name = "my-task-workload"
+			existingJob.Status.Active = 1
+			existingJob.Status.Failed = 2
+			statusGetter.GetFailedAttemptsReturns([]korifiv1alpha1.TaskAttemptFailure{
+				{ExitCode: 1, Reason: "Error", Message: "Failed with exit code: 1"},
+				{ExitCode: 2, Reason: "Error", Message: "Failed with exit code: 2"},
+			}, nil)
+		})
+
+		It("reports the attempts in the task status", func() {
+			Expect(statusGetter.GetFailedAttemptsCallCount()).To(Equal(1))
+			_, actualJob := statusGetter.GetFailedAttemptsArgsForCall(0)
+			Expect(actualJob.Name).To(Equal("my-task-workload"))
+
+			Expect(fakeStatusWriter.PatchCallCount()).To(Equal(1))
+			_, object, _, _ := fakeStatusWriter.PatchArgsForCall(0)
+			patchedTaskWorkload, ok := object.(*korifiv1alpha1.TaskWorkload)
+			Expect(ok).To(BeTrue())
+			Expect(patchedTaskWorkload.Status.Attempts).To(BeEquivalentTo(3))
+			Expect(patchedTaskWorkload.Status.FailedAttempts).To(ConsistOf(
+				HaveField("ExitCode", BeEquivalentTo(1)),
+				HaveField("ExitCode", BeEquivalentTo(2)),
+			))
+		})
+
+		When("getting the failed attempts fails", func() {
+			BeforeEach(func() {
+				statusGetter.GetFailedAttemptsReturns(nil, errors.New("get-attempts-error"))
+			})
+
+			It("returns the error", func() {
+				Expect(reconcileErr).To(MatchError(ContainSubstring("get-attempts-error")))
+			})
+		})
+	})
+
 	When("the taskworkload is being deleted gracefully", func() {
 		BeforeEach(func() {
 			taskWorkload.DeletionTimestamp = &metav1.Time{Time: time.Now()}
@@ -88,6 +88,17 @@ func main() {
 		os.Exit(1)
 	}
 
+	if err = jobtaskcontrollers.NewTaskScheduleWorkloadReconciler(
+		controllersLog,
+		mgr.GetClient(),
+		mgr.GetScheme(),
+		jobTTL,
+		jobtaskcontrollers.NewScheduledRuns(),
+	).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "TaskScheduleWorkload")
+		os.Exit(1)
+	}
+
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
 		setupLog.Error(err, "unable to set up health check")
 		os.Exit(1)
@@ -0,0 +1,316 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package appworkload
+
+import (
+	"context"
+	"fmt"
+	"maps"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/knative-runner/controllers"
+	"code.cloudfoundry.org/korifi/tools/k8s"
+	"code.cloudfoundry.org/korifi/tools/runner"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	// Environment Variable Names
+	EnvPodName              = "POD_NAME"
+	EnvCFInstanceIP         = "CF_INSTANCE_IP"
+	EnvCFInstanceGUID       = "CF_INSTANCE_GUID"
+	EnvCFInstanceInternalIP = "CF_INSTANCE_INTERNAL_IP"
+	EnvCFInstanceIndex      = "CF_INSTANCE_INDEX"
+	EnvServiceBindingRoot   = "SERVICE_BINDING_ROOT"
+
+	// Knative Service Keys
+	AnnotationVersion     = "korifi.cloudfoundry.org/version"
+	AnnotationAppID       = "korifi.cloudfoundry.org/application-id"
+	AnnotationProcessGUID = "korifi.cloudfoundry.org/process-guid"
+
+	LabelVersion         = "korifi.cloudfoundry.org/version"
+	LabelAppGUID         = "korifi.cloudfoundry.org/app-guid"
+	LabelAppWorkloadGUID = "korifi.cloudfoundry.org/appworkload-guid"
+	LabelProcessType     = "korifi.cloudfoundry.org/process-type"
+
+	ApplicationContainerName = "application"
+	ServiceAccountName       = "korifi-app"
+
+	// Knative creates a Service named after each revision, whose endpoints
+	// are the activator while the revision is scaled to zero and the pods
+	// otherwise. The activator tells the revisions apart by these headers.
+	RevisionServicePort    = 80
+	HeaderKnativeRevision  = "Knative-Serving-Revision"
+	HeaderKnativeNamespace = "Knative-Serving-Namespace"
+)
+
+// KnativeServiceGVK is the kind of the Knative Serving services the runner
+// creates. The runner does not depend on the Knative libraries and handles
+// the services as unstructured objects.
+var KnativeServiceGVK = schema.GroupVersionKind{
+	Group:   "serving.knative.dev",
+	Version: "v1",
+	Kind:    "Service",
+}
+
+func NewKnativeService() *unstructured.Unstructured {
+	service := &unstructured.Unstructured{}
+	service.SetGroupVersionKind(KnativeServiceGVK)
+	return service
+}
+
+//go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
+//counterfeiter:generate -o ./fake -fake-name WorkloadToServiceConverter . WorkloadToServiceConverter
+type WorkloadToServiceConverter interface {
+	Convert(appWorkload *korifiv1alpha1.AppWorkload) (*unstructured.Unstructured, error)
+}
+
+//counterfeiter:generate -o ./fake -fake-name InstanceIndexer . InstanceIndexer
+type InstanceIndexer interface {
+	AssignIndexes(ctx context.Context, appWorkloadGUID string) error
+}
+
+// AppWorkloadReconciler reconciles a AppWorkload object
+type AppWorkloadReconciler struct {
+	k8sClient          client.Client
+	scheme             *runtime.Scheme
+	workloadsToService WorkloadToServiceConverter
+	instanceIndexer    InstanceIndexer
+	log                logr.Logger
+	stateCollector     *runner.AppWorkloadStateCollector
+}
+
+func NewAppWorkloadReconciler(
+	c client.Client,
+	scheme *runtime.Scheme,
+	workloadsToService WorkloadToServiceConverter,
+	instanceIndexer InstanceIndexer,
+	log logr.Logger,
+	stateCollector *runner.AppWorkloadStateCollector,
+) *k8s.PatchingReconciler[korifiv1alpha1.AppWorkload, *korifiv1alpha1.AppWorkload] {
+	appWorkloadReconciler := AppWorkloadReconciler{
+		k8sClient:          c,
+		scheme:             scheme,
+		workloadsToService: workloadsToService,
+		instanceIndexer:    instanceIndexer,
+		log:                log,
+		stateCollector:     stateCollector,
+	}
+	return k8s.NewPatchingReconciler[korifiv1alpha1.AppWorkload, *korifiv1alpha1.AppWorkload](log, c, &appWorkloadReconciler)
+}
+
+func (r *AppWorkloadReconciler) SetupWithManager(mgr ctrl.Manager) *builder.Builder {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&korifiv1alpha1.AppWorkload{}).
+		Owns(NewKnativeService()).
+		Watches(
+			&corev1.Pod{},
+			handler.EnqueueRequestsFromMapFunc(r.enqueueAppWorkloadRequests),
+		).
+		WithEventFilter(predicate.NewPredicateFuncs(filterAppWorkloads))
+}
+
+func (r *AppWorkloadReconciler) enqueueAppWorkloadRequests(ctx context.Context, o client.Object) []reconcile.Request {
+	var requests []reconcile.Request
+
+	if appWorkloadName, ok := o.GetLabels()[LabelAppWorkloadGUID]; ok {
+		requests = append(requests, reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      appWorkloadName,
+				Namespace: o.GetNamespace(),
+			},
+		})
+	}
+
+	return requests
+}
+
+func filterAppWorkloads(object client.Object) bool {
+	appWorkload, ok := object.(*korifiv1alpha1.AppWorkload)
+	if !ok {
+		return true
+	}
+
+	return appWorkload.Spec.RunnerName == controllers.AppWorkloadReconcilerName
+}
+
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=appworkloads,verbs=get;list;watch;create;patch;delete
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=appworkloads/status,verbs=get;patch
+
+//+kubebuilder:rbac:groups=serving.knative.dev,resources=services,verbs=create;patch;get;list;watch;delete
+//+kubebuilder:rbac:groups=serving.knative.dev,resources=services/finalizers,verbs=update
+
+//+kubebuilder:rbac:groups="",resources=pods,verbs=list;get;watch;patch
+
+func (r *AppWorkloadReconciler) ReconcileResource(ctx context.Context, appWorkload *korifiv1alpha1.AppWorkload) (ctrl.Result, error) {
+	log := logr.FromContextOrDiscard(ctx)
+
+	appWorkload.Status.ObservedGeneration = appWorkload.Generation
+	log.V(1).Info("set observed generation", "generation", appWorkload.Status.ObservedGeneration)
+
+	if !appWorkload.GetDeletionTimestamp().IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	service, err := r.workloadsToService.Convert(appWorkload)
+	if err != nil {
+		log.Info("error when converting AppWorkload", "reason", err)
+		return ctrl.Result{}, err
+	}
+
+	// Knative treats a max scale of zero as unbounded, so stopped workloads
+	// have no service at all
+	if appWorkload.Spec.Instances == 0 {
+		err = r.k8sClient.Delete(ctx, service)
+		if client.IgnoreNotFound(err) != nil {
+			log.Info("error when deleting Knative service", "reason", err)
+			return ctrl.Result{}, err
+		}
+
+		appWorkload.Status.ActualInstances = 0
+		appWorkload.Status.ScaledToZero = false
+		appWorkload.Status.Backend = nil
+		appWorkload.Status.InstancesStatus = map[string]korifiv1alpha1.InstanceStatus{}
+
+		return ctrl.Result{}, nil
+	}
+
+	actualService := NewKnativeService()
+	actualService.SetName(service.GetName())
+	actualService.SetNamespace(service.GetNamespace())
+	serviceReady := false
+	readyRevision := ""
+	_, err = controllerutil.CreateOrPatch(ctx, r.k8sClient, actualService, func() error {
+		// CreateOrPatch drops the status of unstructured objects it patches,
+		// so it is read before mutating the service
+		serviceReady = isServiceReady(actualService)
+		readyRevision, _, _ = unstructured.NestedString(actualService.Object, "status", "latestReadyRevisionName")
+
+		// Knative records the creator of the service in immutable
+		// annotations, so the runner only adds its own metadata
+		actualService.SetLabels(mergeMaps(actualService.GetLabels(), service.GetLabels()))
+		actualService.SetAnnotations(mergeMaps(actualService.GetAnnotations(), service.GetAnnotations()))
+		actualService.SetOwnerReferences(service.GetOwnerReferences())
+		actualService.Object["spec"] = service.Object["spec"]
+
+		return nil
+	})
+	if err != nil {
+		log.Info("error when creating or updating Knative service", "reason", err)
+		return ctrl.Result{}, err
+	}
+
+	err = r.instanceIndexer.AssignIndexes(ctx, appWorkload.Spec.GUID)
+	if err != nil {
+		log.Info("error when assigning instance indexes", "reason", err)
+		return ctrl.Result{}, err
+	}
+
+	runningPods, err := r.countRunningPods(ctx, appWorkload.Spec.GUID)
+	if err != nil {
+		log.Info("error when counting workload pods", "reason", err)
+		return ctrl.Result{}, err
+	}
+	appWorkload.Status.ActualInstances = runningPods
+	appWorkload.Status.ScaledToZero = runningPods == 0 && serviceReady
+	appWorkload.Status.Backend = revisionBackend(appWorkload.Namespace, readyRevision)
+
+	instancesState, err := r.stateCollector.CollectState(ctx, appWorkload.Spec.GUID)
+	if err != nil {
+		log.Info("error when collecting instances state", "reason", err)
+		return ctrl.Result{}, err
+	}
+	appWorkload.Status.InstancesStatus = instancesState
+
+	return ctrl.Result{}, nil
+}
+
+func (r *AppWorkloadReconciler) countRunningPods(ctx context.Context, appWorkloadGUID string) (int32, error) {
+	workloadPods := &corev1.PodList{}
+	err := r.k8sClient.List(ctx, workloadPods, client.MatchingLabels{
+		controllers.LabelGUID: appWorkloadGUID,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to list pods for workload %q: %w", appWorkloadGUID, err)
+	}
+
+	var count int32
+	for _, pod := range workloadPods.Items {
+		if pod.DeletionTimestamp.IsZero() {
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+// revisionBackend points the routes to the workload at the Service of its
+// ready revision, so that requests reach the activator and start the
+// revision when it is scaled to zero
+func revisionBackend(namespace, revision string) *korifiv1alpha1.AppWorkloadBackend {
+	if revision == "" {
+		return nil
+	}
+
+	return &korifiv1alpha1.AppWorkloadBackend{
+		ServiceName: revision,
+		Port:        RevisionServicePort,
+		RequestHeaders: map[string]string{
+			HeaderKnativeRevision:  revision,
+			HeaderKnativeNamespace: namespace,
+		},
+	}
+}
+
+// isServiceReady tells whether Knative is ready to route requests to the
+// service, i.e. whether a service without pods has been scaled to zero
+// rather than failed to start
+func isServiceReady(service *unstructured.Unstructured) bool {
+	conditions, _, _ := unstructured.NestedSlice(service.Object, "status", "conditions")
+	for _, c := range conditions {
+		condition, ok := c.(map[string]any)
+		if !ok {
+			continue
+		}
+
+		if condition["type"] == "Ready" {
+			return condition["status"] == string(corev1.ConditionTrue)
+		}
+	}
+
+	return false
+}
+
+func mergeMaps(existing, desired map[string]string) map[string]string {
+	merged := map[string]string{}
+	maps.Copy(merged, existing)
+	maps.Copy(merged, desired)
+	return merged
+}
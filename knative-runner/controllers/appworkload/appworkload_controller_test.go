@@ -0,0 +1,358 @@
+package appworkload_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/knative-runner/controllers"
+	"code.cloudfoundry.org/korifi/knative-runner/controllers/appworkload"
+	"code.cloudfoundry.org/korifi/knative-runner/controllers/appworkload/fake"
+	"code.cloudfoundry.org/korifi/tools/k8s"
+	"code.cloudfoundry.org/korifi/tools/runner"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("AppWorkload Reconcile", func() {
+	var (
+		reconciler          *k8s.PatchingReconciler[korifiv1alpha1.AppWorkload, *korifiv1alpha1.AppWorkload]
+		reconcileResult     ctrl.Result
+		reconcileErr        error
+		ctx                 context.Context
+		req                 ctrl.Request
+		appWorkload         *korifiv1alpha1.AppWorkload
+		service             *unstructured.Unstructured
+		existingService     *unstructured.Unstructured
+		pods                []corev1.Pod
+		fakeWorkloadToSvc   *fake.WorkloadToServiceConverter
+		fakeInstanceIndexer *fake.InstanceIndexer
+		getAppWorkloadError error
+		createServiceError  error
+	)
+
+	patchedAppWorkload := func() *korifiv1alpha1.AppWorkload {
+		Expect(fakeStatusWriter.PatchCallCount()).To(Equal(1))
+		_, object, _, _ := fakeStatusWriter.PatchArgsForCall(0)
+		patched, ok := object.(*korifiv1alpha1.AppWorkload)
+		Expect(ok).To(BeTrue())
+		return patched
+	}
+
+	BeforeEach(func() {
+		appWorkload = &korifiv1alpha1.AppWorkload{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      uuid.NewString(),
+				Namespace: uuid.NewString(),
+			},
+			Spec: korifiv1alpha1.AppWorkloadSpec{
+				GUID:      uuid.NewString(),
+				Instances: 2,
+			},
+		}
+
+		service = appworkload.NewKnativeService()
+		service.SetName(uuid.NewString())
+		service.SetNamespace(appWorkload.Namespace)
+		service.Object["spec"] = map[string]any{"template": map[string]any{}}
+
+		existingService = nil
+		pods = nil
+
+		fakeWorkloadToSvc = new(fake.WorkloadToServiceConverter)
+		fakeWorkloadToSvc.ConvertReturns(service, nil)
+
+		fakeInstanceIndexer = new(fake.InstanceIndexer)
+
+		ctx = context.Background()
+		req = ctrl.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      uuid.NewString(),
+				Namespace: appWorkload.Namespace,
+			},
+		}
+
+		getAppWorkloadError = nil
+		createServiceError = nil
+
+		fakeClient.GetStub = func(_ context.Context, _ types.NamespacedName, obj client.Object, _ ...client.GetOption) error {
+			switch obj := obj.(type) {
+			case *korifiv1alpha1.AppWorkload:
+				appWorkload.DeepCopyInto(obj)
+				return getAppWorkloadError
+			case *unstructured.Unstructured:
+				if existingService == nil {
+					return apierrors.NewNotFound(schema.GroupResource{
+						Group:    "serving.knative.dev",
+						Resource: "services",
+					}, "some-resource")
+				}
+				existingService.DeepCopyInto(obj)
+				return nil
+			default:
+				panic("TestClient Get provided an unexpected object type")
+			}
+		}
+
+		fakeClient.CreateStub = func(_ context.Context, obj client.Object, _ ...client.CreateOption) error {
+			switch obj.(type) {
+			case *unstructured.Unstructured:
+				return createServiceError
+			default:
+				panic("TestClient Create provided an unexpected object type")
+			}
+		}
+
+		fakeClient.ListStub = func(_ context.Context, list client.ObjectList, _ ...client.ListOption) error {
+			podList, ok := list.(*corev1.PodList)
+			Expect(ok).To(BeTrue())
+			podList.Items = pods
+			return nil
+		}
+
+		reconciler = appworkload.NewAppWorkloadReconciler(
+			fakeClient,
+			scheme.Scheme,
+			fakeWorkloadToSvc,
+			fakeInstanceIndexer,
+			ctrl.Log.WithName("controllers").WithName("TestAppWorkload"),
+			runner.NewAppWorkloadStateCollector(fakeClient),
+		)
+	})
+
+	JustBeforeEach(func() {
+		reconcileResult, reconcileErr = reconciler.Reconcile(ctx, req)
+	})
+
+	When("the appworkload is being created", func() {
+		It("returns an empty result and does not return error", func() {
+			Expect(reconcileResult).To(Equal(ctrl.Result{}))
+			Expect(reconcileErr).NotTo(HaveOccurred())
+		})
+
+		It("converts the app workload to a knative service", func() {
+			Expect(fakeWorkloadToSvc.ConvertCallCount()).To(Equal(1))
+			actualWorkload := fakeWorkloadToSvc.ConvertArgsForCall(0)
+			Expect(actualWorkload.Name).To(Equal(appWorkload.Name))
+		})
+
+		It("creates the knative service", func() {
+			Expect(fakeClient.CreateCallCount()).To(Equal(1))
+			_, obj, _ := fakeClient.CreateArgsForCall(0)
+			createdService, ok := obj.(*unstructured.Unstructured)
+			Expect(ok).To(BeTrue())
+			Expect(createdService.GroupVersionKind()).To(Equal(appworkload.KnativeServiceGVK))
+			Expect(createdService.GetName()).To(Equal(service.GetName()))
+		})
+
+		It("sets the appworkload status", func() {
+			Expect(patchedAppWorkload().Status.ObservedGeneration).To(Equal(appWorkload.Generation))
+		})
+
+		It("does not publish a backend before a revision is ready", func() {
+			Expect(patchedAppWorkload().Status.Backend).To(BeNil())
+		})
+
+		It("assigns indexes to the workload pods", func() {
+			Expect(fakeInstanceIndexer.AssignIndexesCallCount()).To(Equal(1))
+			_, actualGUID := fakeInstanceIndexer.AssignIndexesArgsForCall(0)
+			Expect(actualGUID).To(Equal(appWorkload.Spec.GUID))
+		})
+
+		When("converting the app workload fails", func() {
+			BeforeEach(func() {
+				fakeWorkloadToSvc.ConvertReturns(nil, errors.New("convert-error"))
+			})
+
+			It("returns the error", func() {
+				Expect(reconcileErr).To(MatchError("convert-error"))
+			})
+		})
+
+		When("creating the knative service fails", func() {
+			BeforeEach(func() {
+				createServiceError = errors.New("big sad")
+			})
+
+			It("returns an error", func() {
+				Expect(reconcileErr).To(MatchError("big sad"))
+			})
+		})
+
+		When("assigning the indexes fails", func() {
+			BeforeEach(func() {
+				fakeInstanceIndexer.AssignIndexesReturns(errors.New("index-error"))
+			})
+
+			It("returns the error", func() {
+				Expect(reconcileErr).To(MatchError("index-error"))
+			})
+		})
+	})
+
+	When("the knative service exists", func() {
+		BeforeEach(func() {
+			existingService = service.DeepCopy()
+			existingService.SetAnnotations(map[string]string{"serving.knative.dev/creator": "someone"})
+			Expect(unstructured.SetNestedSlice(existingService.Object, []any{
+				map[string]any{"type": "Ready", "status": "True"},
+			}, "status", "conditions")).To(Succeed())
+
+			service.SetAnnotations(map[string]string{"foo": "bar"})
+		})
+
+		It("patches the service keeping the knative annotations", func() {
+			Expect(fakeClient.CreateCallCount()).To(BeZero())
+			Expect(fakeClient.PatchCallCount()).To(BeNumerically(">=", 1))
+			_, obj, _, _ := fakeClient.PatchArgsForCall(0)
+			patchedService, ok := obj.(*unstructured.Unstructured)
+			Expect(ok).To(BeTrue())
+			Expect(patchedService.GetAnnotations()).To(Equal(map[string]string{
+				"serving.knative.dev/creator": "someone",
+				"foo":                         "bar",
+			}))
+		})
+
+		When("the service has a ready revision", func() {
+			BeforeEach(func() {
+				Expect(unstructured.SetNestedField(existingService.Object, "my-revision", "status", "latestReadyRevisionName")).To(Succeed())
+			})
+
+			It("publishes the revision service as the workload backend", func() {
+				Expect(patchedAppWorkload().Status.Backend).To(Equal(&korifiv1alpha1.AppWorkloadBackend{
+					ServiceName: "my-revision",
+					Port:        appworkload.RevisionServicePort,
+					RequestHeaders: map[string]string{
+						appworkload.HeaderKnativeRevision:  "my-revision",
+						appworkload.HeaderKnativeNamespace: appWorkload.Namespace,
+					},
+				}))
+			})
+		})
+
+		When("the service has pods", func() {
+			BeforeEach(func() {
+				pods = []corev1.Pod{
+					newPod("0"),
+					newPod("1"),
+				}
+				terminating := newPod("2")
+				terminating.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+				pods = append(pods, terminating)
+			})
+
+			It("reports the running pods as actual instances", func() {
+				Expect(patchedAppWorkload().Status.ActualInstances).To(BeEquivalentTo(2))
+				Expect(patchedAppWorkload().Status.ScaledToZero).To(BeFalse())
+			})
+
+			It("collects the instances state", func() {
+				Expect(patchedAppWorkload().Status.InstancesStatus).To(HaveKey("0"))
+				Expect(patchedAppWorkload().Status.InstancesStatus).To(HaveKey("1"))
+			})
+		})
+
+		When("the service has no pods", func() {
+			It("reports the workload as scaled to zero", func() {
+				Expect(patchedAppWorkload().Status.ActualInstances).To(BeZero())
+				Expect(patchedAppWorkload().Status.ScaledToZero).To(BeTrue())
+			})
+
+			When("the service is not ready", func() {
+				BeforeEach(func() {
+					Expect(unstructured.SetNestedSlice(existingService.Object, []any{
+						map[string]any{"type": "Ready", "status": "False"},
+					}, "status", "conditions")).To(Succeed())
+				})
+
+				It("does not report the workload as scaled to zero", func() {
+					Expect(patchedAppWorkload().Status.ScaledToZero).To(BeFalse())
+				})
+			})
+		})
+	})
+
+	When("the appworkload has no instances", func() {
+		BeforeEach(func() {
+			appWorkload.Spec.Instances = 0
+			appWorkload.Status.ScaledToZero = true
+			appWorkload.Status.Backend = &korifiv1alpha1.AppWorkloadBackend{ServiceName: "my-revision"}
+		})
+
+		It("deletes the knative service", func() {
+			Expect(fakeClient.DeleteCallCount()).To(Equal(1))
+			_, obj, _ := fakeClient.DeleteArgsForCall(0)
+			Expect(obj.GetName()).To(Equal(service.GetName()))
+			Expect(fakeClient.CreateCallCount()).To(BeZero())
+		})
+
+		It("reports no instances", func() {
+			Expect(patchedAppWorkload().Status.ActualInstances).To(BeZero())
+			Expect(patchedAppWorkload().Status.ScaledToZero).To(BeFalse())
+			Expect(patchedAppWorkload().Status.InstancesStatus).To(BeEmpty())
+			Expect(patchedAppWorkload().Status.Backend).To(BeNil())
+		})
+
+		When("the service does not exist", func() {
+			BeforeEach(func() {
+				fakeClient.DeleteReturns(apierrors.NewNotFound(schema.GroupResource{}, "service"))
+			})
+
+			It("succeeds", func() {
+				Expect(reconcileErr).NotTo(HaveOccurred())
+			})
+		})
+
+		When("deleting the service fails", func() {
+			BeforeEach(func() {
+				fakeClient.DeleteReturns(errors.New("delete-error"))
+			})
+
+			It("returns the error", func() {
+				Expect(reconcileErr).To(MatchError("delete-error"))
+			})
+		})
+	})
+
+	When("the appworkload is being deleted gracefully", func() {
+		BeforeEach(func() {
+			appWorkload.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+		})
+
+		It("returns an empty result and does not return error", func() {
+			Expect(reconcileResult).To(Equal(ctrl.Result{}))
+			Expect(reconcileErr).NotTo(HaveOccurred())
+		})
+
+		It("creates no knative service", func() {
+			Expect(fakeWorkloadToSvc.ConvertCallCount()).To(Equal(0))
+			Expect(fakeClient.CreateCallCount()).To(Equal(0))
+		})
+	})
+})
+
+func newPod(index string) corev1.Pod {
+	return corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: uuid.NewString(),
+			Labels: map[string]string{
+				controllers.LabelGUID: "workload-guid",
+			},
+			Annotations: map[string]string{
+				"korifi.cloudfoundry.org/instance-index": index,
+			},
+		},
+	}
+}
@@ -0,0 +1,264 @@
+package appworkload
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/knative-runner/controllers"
+	"code.cloudfoundry.org/korifi/tools"
+	"code.cloudfoundry.org/korifi/tools/runner"
+	"github.com/BooleanCat/go-functional/v2/it"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const bindingRootPath = "/bindings"
+
+// AppWorkloadToServiceConverter turns app workloads into Knative services.
+// Knative only accepts a subset of the pod spec by default: the instance
+// environment variables need the kubernetes.podspec-fieldref feature and
+// node selectors and tolerations need the kubernetes.podspec-nodeselector and
+// kubernetes.podspec-tolerations features.
+type AppWorkloadToServiceConverter struct {
+	scheme *runtime.Scheme
+}
+
+func NewAppWorkloadToServiceConverter(scheme *runtime.Scheme) *AppWorkloadToServiceConverter {
+	return &AppWorkloadToServiceConverter{
+		scheme: scheme,
+	}
+}
+
+func getServiceName(appWorkload *korifiv1alpha1.AppWorkload) (string, error) {
+	lastStopAppRev := appWorkload.Spec.Version
+	if annotationVal, ok := appWorkload.Annotations[korifiv1alpha1.CFAppLastStopRevisionKey]; ok {
+		lastStopAppRev = annotationVal
+	}
+	nameSuffix, err := hash(fmt.Sprintf("%s-%s", appWorkload.Spec.GUID, lastStopAppRev))
+	if err != nil {
+		return "", fmt.Errorf("failed to generate hash for knative service name: %w", err)
+	}
+
+	namePrefix := fmt.Sprintf("%s-%s", appWorkload.Spec.AppGUID, appWorkload.Namespace)
+	namePrefix = sanitizeName(namePrefix, appWorkload.Spec.GUID)
+
+	// knative service names must start with a letter, unlike guids
+	return fmt.Sprintf("cf-%s-%s", namePrefix, nameSuffix), nil
+}
+
+func (r *AppWorkloadToServiceConverter) Convert(appWorkload *korifiv1alpha1.AppWorkload) (*unstructured.Unstructured, error) {
+	envs := appWorkload.Spec.Env
+
+	fieldEnvs := []corev1.EnvVar{
+		{
+			Name: EnvPodName,
+			ValueFrom: &corev1.EnvVarSource{
+				FieldRef: &corev1.ObjectFieldSelector{
+					FieldPath: "metadata.name",
+				},
+			},
+		},
+		{
+			Name: EnvCFInstanceGUID,
+			ValueFrom: &corev1.EnvVarSource{
+				FieldRef: &corev1.ObjectFieldSelector{
+					FieldPath: "metadata.uid",
+				},
+			},
+		},
+		{
+			Name: EnvCFInstanceIndex,
+			ValueFrom: &corev1.EnvVarSource{
+				FieldRef: &corev1.ObjectFieldSelector{
+					FieldPath: fmt.Sprintf("metadata.annotations['%s']", runner.AnnotationInstanceIndex),
+				},
+			},
+		},
+		{
+			Name: EnvCFInstanceIP,
+			ValueFrom: &corev1.EnvVarSource{
+				FieldRef: &corev1.ObjectFieldSelector{
+					FieldPath: "status.hostIP",
+				},
+			},
+		},
+		{
+			Name: EnvCFInstanceInternalIP,
+			ValueFrom: &corev1.EnvVarSource{
+				FieldRef: &corev1.ObjectFieldSelector{
+					FieldPath: "status.podIP",
+				},
+			},
+		},
+	}
+
+	envs = append(envs, fieldEnvs...)
+
+	if len(appWorkload.Spec.Services) != 0 {
+		envs = append(envs, corev1.EnvVar{
+			Name:  EnvServiceBindingRoot,
+			Value: bindingRootPath,
+		})
+	}
+	// Sort env vars to guarantee idempotency
+	sort.SliceStable(envs, func(i, j int) bool {
+		return envs[i].Name < envs[j].Name
+	})
+
+	// Knative routes requests to a single port and has no startup probes,
+	// the readiness probe keeps requests away from instances still starting
+	var ports []corev1.ContainerPort
+	if len(appWorkload.Spec.Ports) > 0 {
+		ports = []corev1.ContainerPort{{ContainerPort: appWorkload.Spec.Ports[0]}}
+	}
+
+	podSpec := corev1.PodSpec{
+		Containers: []corev1.Container{{
+			Name:            ApplicationContainerName,
+			Image:           appWorkload.Spec.Image,
+			ImagePullPolicy: corev1.PullAlways,
+			Command:         appWorkload.Spec.Command,
+			Env:             envs,
+			Ports:           ports,
+			SecurityContext: &corev1.SecurityContext{
+				AllowPrivilegeEscalation: tools.PtrTo(false),
+				RunAsNonRoot:             tools.PtrTo(true),
+				Capabilities: &corev1.Capabilities{
+					Drop: []corev1.Capability{"ALL"},
+				},
+				SeccompProfile: &corev1.SeccompProfile{
+					Type: corev1.SeccompProfileTypeRuntimeDefault,
+				},
+			},
+			Resources:      appWorkload.Spec.Resources,
+			ReadinessProbe: appWorkload.Spec.StartupProbe,
+			LivenessProbe:  appWorkload.Spec.LivenessProbe,
+			VolumeMounts: slices.Collect(it.Map(slices.Values(appWorkload.Spec.Services), func(s korifiv1alpha1.ServiceBinding) corev1.VolumeMount {
+				return corev1.VolumeMount{
+					Name:      s.Name,
+					ReadOnly:  true,
+					MountPath: filepath.Join(bindingRootPath, s.Name),
+				}
+			})),
+		}},
+		ImagePullSecrets:   appWorkload.Spec.ImagePullSecrets,
+		ServiceAccountName: ServiceAccountName,
+		NodeSelector:       appWorkload.Spec.NodeSelector,
+		Tolerations:        appWorkload.Spec.Tolerations,
+		Volumes: slices.Collect(it.Map(slices.Values(appWorkload.Spec.Services), func(s korifiv1alpha1.ServiceBinding) corev1.Volume {
+			return corev1.Volume{
+				Name: s.Name,
+				VolumeSource: corev1.VolumeSource{
+					Secret: &corev1.SecretVolumeSource{
+						SecretName:  s.Secret,
+						DefaultMode: tools.PtrTo[int32](0o644),
+					},
+				},
+			}
+		})),
+	}
+
+	unstructuredPodSpec, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&podSpec)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert the pod spec of the knative service: %w", err)
+	}
+
+	serviceName, err := getServiceName(appWorkload)
+	if err != nil {
+		return nil, err
+	}
+
+	labels := map[string]string{
+		controllers.LabelGUID: appWorkload.Spec.GUID,
+		LabelProcessType:      appWorkload.Spec.ProcessType,
+		LabelVersion:          appWorkload.Spec.Version,
+		LabelAppGUID:          appWorkload.Spec.AppGUID,
+		LabelAppWorkloadGUID:  appWorkload.Name,
+	}
+
+	annotations := map[string]string{
+		AnnotationAppID:       appWorkload.Spec.AppGUID,
+		AnnotationVersion:     appWorkload.Spec.Version,
+		AnnotationProcessGUID: fmt.Sprintf("%s-%s", appWorkload.Spec.GUID, appWorkload.Spec.Version),
+	}
+
+	templateAnnotations := map[string]any{
+		controllers.AnnotationMinScale: "0",
+		controllers.AnnotationMaxScale: strconv.Itoa(int(appWorkload.Spec.Instances)),
+	}
+	for k, v := range annotations {
+		templateAnnotations[k] = v
+	}
+
+	templateLabels := map[string]any{}
+	for k, v := range labels {
+		templateLabels[k] = v
+	}
+
+	service := NewKnativeService()
+	service.SetName(serviceName)
+	service.SetNamespace(appWorkload.Namespace)
+	service.SetLabels(labels)
+	service.SetAnnotations(annotations)
+	service.Object["spec"] = map[string]any{
+		"template": map[string]any{
+			"metadata": map[string]any{
+				"labels":      templateLabels,
+				"annotations": templateAnnotations,
+			},
+			"spec": unstructuredPodSpec,
+		},
+	}
+
+	err = controllerutil.SetControllerReference(appWorkload, service, r.scheme)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set OwnerRef on Knative service :%w", err)
+	}
+
+	return service, nil
+}
+
+func sanitizeName(name, fallback string) string {
+	const sanitizedNameMaxLen = 40
+
+	validNameRegex := regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`)
+	sanitizedName := strings.ReplaceAll(strings.ToLower(name), "_", "-")
+
+	if validNameRegex.MatchString(sanitizedName) {
+		return truncateString(sanitizedName, sanitizedNameMaxLen)
+	}
+
+	return truncateString(fallback, sanitizedNameMaxLen)
+}
+
+func truncateString(str string, num int) string {
+	if len(str) > num {
+		return str[0:num]
+	}
+
+	return str
+}
+
+func hash(s string) (string, error) {
+	const MaxHashLength = 10
+
+	sha := sha256.New()
+
+	if _, err := sha.Write([]byte(s)); err != nil {
+		return "", fmt.Errorf("failed to calculate sha: %w", err)
+	}
+
+	hashValue := hex.EncodeToString(sha.Sum(nil))
+
+	return hashValue[:MaxHashLength], nil
+}
@@ -0,0 +1,221 @@
+package appworkload_test
+
+import (
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/knative-runner/controllers"
+	"code.cloudfoundry.org/korifi/knative-runner/controllers/appworkload"
+	"code.cloudfoundry.org/korifi/tools"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes/scheme"
+)
+
+var _ = Describe("AppWorkload to Knative Service Converter", func() {
+	var (
+		service     *unstructured.Unstructured
+		appWorkload *korifiv1alpha1.AppWorkload
+		converter   *appworkload.AppWorkloadToServiceConverter
+	)
+
+	templateMetadata := func() metav1.ObjectMeta {
+		template, found, err := unstructured.NestedMap(service.Object, "spec", "template", "metadata")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found).To(BeTrue())
+
+		metadata := metav1.ObjectMeta{}
+		Expect(runtime.DefaultUnstructuredConverter.FromUnstructured(template, &metadata)).To(Succeed())
+		return metadata
+	}
+
+	podSpec := func() corev1.PodSpec {
+		template, found, err := unstructured.NestedMap(service.Object, "spec", "template", "spec")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found).To(BeTrue())
+
+		spec := corev1.PodSpec{}
+		Expect(runtime.DefaultUnstructuredConverter.FromUnstructured(template, &spec)).To(Succeed())
+		return spec
+	}
+
+	BeforeEach(func() {
+		Expect(korifiv1alpha1.AddToScheme(scheme.Scheme)).To(Succeed())
+		appWorkload = &korifiv1alpha1.AppWorkload{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       "guid_1234",
+				Namespace:  "some-namespace",
+				Generation: 1,
+				Annotations: map[string]string{
+					korifiv1alpha1.CFAppLastStopRevisionKey: "lastStopAppRev",
+				},
+			},
+			Spec: korifiv1alpha1.AppWorkloadSpec{
+				AppGUID:          "premium_app_guid_1234",
+				GUID:             "guid_1234",
+				Version:          "version_1234",
+				Image:            "gcr.io/foo/bar",
+				ImagePullSecrets: []corev1.LocalObjectReference{{Name: "some-secret-name"}},
+				Command:          []string{"/bin/sh", "-c", "while true; do echo hello; sleep 10;done"},
+				ProcessType:      "web",
+				StartupProbe: &corev1.Probe{
+					ProbeHandler: corev1.ProbeHandler{
+						HTTPGet: &corev1.HTTPGetAction{
+							Path: "/healthz",
+							Port: intstr.FromInt32(8080),
+						},
+					},
+				},
+				Ports:      []int32{8080, 9999},
+				Instances:  3,
+				RunnerName: "knative-runner",
+				Resources: corev1.ResourceRequirements{
+					Limits: corev1.ResourceList{
+						corev1.ResourceMemory: resource.MustParse("1024Mi"),
+					},
+				},
+				Services: []korifiv1alpha1.ServiceBinding{
+					{Name: "my-db", Secret: "my-db-secret"},
+				},
+			},
+		}
+
+		converter = appworkload.NewAppWorkloadToServiceConverter(scheme.Scheme)
+	})
+
+	JustBeforeEach(func() {
+		var err error
+		service, err = converter.Convert(appWorkload)
+
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should be a knative service", func() {
+		Expect(service.GroupVersionKind()).To(Equal(appworkload.KnativeServiceGVK))
+	})
+
+	It("should be owned by the AppWorkload", func() {
+		Expect(service.GetOwnerReferences()).To(HaveLen(1))
+		Expect(service.GetOwnerReferences()[0].Kind).To(Equal("AppWorkload"))
+	})
+
+	It("should base the name and namespace on the appworkload", func() {
+		Expect(service.GetNamespace()).To(Equal(appWorkload.Namespace))
+		Expect(service.GetName()).To(HavePrefix("cf-premium-app-guid-1234-some-namespace-"))
+	})
+
+	It("should have a new name when appWorkload lastStopAppRev changes", func() {
+		originalName := service.GetName()
+
+		appWorkload.Annotations[korifiv1alpha1.CFAppLastStopRevisionKey] = "another_version"
+		var err error
+		service, err = converter.Convert(appWorkload)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(service.GetName()).NotTo(Equal(originalName))
+	})
+
+	It("should scale between zero and the desired instances", func() {
+		Expect(templateMetadata().Annotations).To(SatisfyAll(
+			HaveKeyWithValue(controllers.AnnotationMinScale, "0"),
+			HaveKeyWithValue(controllers.AnnotationMaxScale, "3"),
+		))
+	})
+
+	DescribeTable("Labels",
+		func(labelName, expectedValue string) {
+			Expect(service.GetLabels()).To(HaveKeyWithValue(labelName, expectedValue))
+			Expect(templateMetadata().Labels).To(HaveKeyWithValue(labelName, expectedValue))
+		},
+		Entry("GUID", controllers.LabelGUID, "guid_1234"),
+		Entry("AppGUID", appworkload.LabelAppGUID, "premium_app_guid_1234"),
+		Entry("AppWorkloadGUID", appworkload.LabelAppWorkloadGUID, "guid_1234"),
+		Entry("ProcessType", appworkload.LabelProcessType, "web"),
+		Entry("Version", appworkload.LabelVersion, "version_1234"),
+	)
+
+	DescribeTable("Annotations",
+		func(annotationName, expectedValue string) {
+			Expect(service.GetAnnotations()).To(HaveKeyWithValue(annotationName, expectedValue))
+			Expect(templateMetadata().Annotations).To(HaveKeyWithValue(annotationName, expectedValue))
+		},
+		Entry("ProcessGUID", appworkload.AnnotationProcessGUID, "guid_1234-version_1234"),
+		Entry("AppID", appworkload.AnnotationAppID, "premium_app_guid_1234"),
+		Entry("Version", appworkload.AnnotationVersion, "version_1234"),
+	)
+
+	It("should set the container", func() {
+		Expect(podSpec().Containers).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+			"Name":            Equal(appworkload.ApplicationContainerName),
+			"Image":           Equal("gcr.io/foo/bar"),
+			"ImagePullPolicy": Equal(corev1.PullAlways),
+			"Command":         Equal(appWorkload.Spec.Command),
+			"Ports":           ConsistOf(corev1.ContainerPort{ContainerPort: 8080}),
+			"ReadinessProbe":  Equal(appWorkload.Spec.StartupProbe),
+			"StartupProbe":    BeNil(),
+		})))
+	})
+
+	It("should set the container resources", func() {
+		Expect(podSpec().Containers[0].Resources.Limits.Memory().String()).To(Equal("1Gi"))
+	})
+
+	It("should read the instance index from the pod index annotation", func() {
+		Expect(podSpec().Containers[0].Env).To(ContainElement(
+			corev1.EnvVar{
+				Name: appworkload.EnvCFInstanceIndex,
+				ValueFrom: &corev1.EnvVarSource{
+					FieldRef: &corev1.ObjectFieldSelector{
+						FieldPath: "metadata.annotations['korifi.cloudfoundry.org/instance-index']",
+					},
+				},
+			},
+		))
+	})
+
+	It("should mount the service bindings", func() {
+		Expect(podSpec().Containers[0].VolumeMounts).To(ConsistOf(corev1.VolumeMount{
+			Name:      "my-db",
+			ReadOnly:  true,
+			MountPath: "/bindings/my-db",
+		}))
+		Expect(podSpec().Containers[0].Env).To(ContainElement(corev1.EnvVar{Name: appworkload.EnvServiceBindingRoot, Value: "/bindings"}))
+		Expect(podSpec().Volumes).To(ConsistOf(corev1.Volume{
+			Name: "my-db",
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName:  "my-db-secret",
+					DefaultMode: tools.PtrTo[int32](0o644),
+				},
+			},
+		}))
+	})
+
+	It("should run the pods securely", func() {
+		spec := podSpec()
+		Expect(spec.ServiceAccountName).To(Equal("korifi-app"))
+		Expect(spec.Containers[0].SecurityContext.RunAsNonRoot).To(Equal(tools.PtrTo(true)))
+		Expect(spec.Containers[0].SecurityContext.AllowPrivilegeEscalation).To(Equal(tools.PtrTo(false)))
+		Expect(spec.Containers[0].SecurityContext.Capabilities.Drop).To(ConsistOf(corev1.Capability("ALL")))
+	})
+
+	It("should copy the image pull secrets", func() {
+		Expect(podSpec().ImagePullSecrets).To(Equal(appWorkload.Spec.ImagePullSecrets))
+	})
+
+	When("the workload has no ports", func() {
+		BeforeEach(func() {
+			appWorkload.Spec.Ports = nil
+		})
+
+		It("does not set container ports", func() {
+			Expect(podSpec().Containers[0].Ports).To(BeEmpty())
+		})
+	})
+})
@@ -0,0 +1,164 @@
+package integration_test
+
+import (
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/knative-runner/controllers"
+	"code.cloudfoundry.org/korifi/knative-runner/controllers/appworkload"
+	"code.cloudfoundry.org/korifi/tools/k8s"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("AppWorkloadsController", func() {
+	var appWorkload *korifiv1alpha1.AppWorkload
+
+	BeforeEach(func() {
+		appWorkload = &korifiv1alpha1.AppWorkload{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       uuid.NewString(),
+				Namespace:  namespaceName,
+				Generation: 1,
+			},
+			Spec: korifiv1alpha1.AppWorkloadSpec{
+				GUID:    uuid.NewString(),
+				Version: uuid.NewString(),
+				AppGUID: uuid.NewString(),
+
+				ProcessType: "web",
+				Image:       uuid.NewString(),
+				Instances:   3,
+				RunnerName:  "knative-runner",
+			},
+		}
+	})
+
+	getServiceForAppWorkload := func(g Gomega) unstructured.Unstructured {
+		serviceList := &unstructured.UnstructuredList{}
+		serviceList.SetGroupVersionKind(appworkload.KnativeServiceGVK.GroupVersion().WithKind("ServiceList"))
+		g.Eventually(func(g Gomega) {
+			g.Expect(k8sClient.List(ctx, serviceList, client.InNamespace(namespaceName), client.MatchingLabels{
+				controllers.LabelGUID: appWorkload.Spec.GUID,
+			})).To(Succeed())
+			g.Expect(serviceList.Items).To(HaveLen(1))
+		}).Should(Succeed())
+
+		return serviceList.Items[0]
+	}
+
+	When("AppWorkload is created", func() {
+		BeforeEach(func() {
+			Expect(k8sClient.Create(ctx, appWorkload)).To(Succeed())
+		})
+
+		It("creates the knative service", func() {
+			service := getServiceForAppWorkload(Default)
+
+			Expect(service.GetOwnerReferences()).To(HaveLen(1))
+			Expect(service.GetOwnerReferences()[0].Name).To(Equal(appWorkload.Name))
+
+			annotations, _, err := unstructured.NestedStringMap(service.Object, "spec", "template", "metadata", "annotations")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(annotations).To(SatisfyAll(
+				HaveKeyWithValue(controllers.AnnotationMinScale, "0"),
+				HaveKeyWithValue(controllers.AnnotationMaxScale, "3"),
+			))
+		})
+
+		It("sets the observed generation", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(appWorkload), appWorkload)).To(Succeed())
+				g.Expect(appWorkload.Status.ObservedGeneration).To(BeEquivalentTo(1))
+			}).Should(Succeed())
+		})
+
+		When("the knative service becomes ready without pods", func() {
+			BeforeEach(func() {
+				service := getServiceForAppWorkload(Default)
+				Expect(unstructured.SetNestedSlice(service.Object, []any{
+					map[string]any{"type": "Ready", "status": "True"},
+				}, "status", "conditions")).To(Succeed())
+				Expect(k8sClient.Status().Update(ctx, &service)).To(Succeed())
+			})
+
+			It("reports the workload as scaled to zero", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(appWorkload), appWorkload)).To(Succeed())
+					g.Expect(appWorkload.Status.ScaledToZero).To(BeTrue())
+					g.Expect(appWorkload.Status.ActualInstances).To(BeZero())
+				}).Should(Succeed())
+			})
+
+			When("a request starts an instance", func() {
+				BeforeEach(func() {
+					Expect(k8sClient.Create(ctx, &corev1.Pod{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      uuid.NewString(),
+							Namespace: namespaceName,
+							Labels: map[string]string{
+								controllers.LabelGUID:            appWorkload.Spec.GUID,
+								appworkload.LabelAppWorkloadGUID: appWorkload.Name,
+							},
+						},
+						Spec: corev1.PodSpec{
+							Containers: []corev1.Container{{Name: "application", Image: "app"}},
+						},
+					})).To(Succeed())
+				})
+
+				It("indexes the instance and reports it", func() {
+					Eventually(func(g Gomega) {
+						g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(appWorkload), appWorkload)).To(Succeed())
+						g.Expect(appWorkload.Status.ScaledToZero).To(BeFalse())
+						g.Expect(appWorkload.Status.ActualInstances).To(BeEquivalentTo(1))
+						g.Expect(appWorkload.Status.InstancesStatus).To(HaveKey("0"))
+					}).Should(Succeed())
+				})
+			})
+		})
+
+		When("the app workload is stopped", func() {
+			BeforeEach(func() {
+				getServiceForAppWorkload(Default)
+				Expect(k8s.PatchResource(ctx, k8sClient, appWorkload, func() {
+					appWorkload.Spec.Instances = 0
+				})).To(Succeed())
+			})
+
+			It("deletes the knative service", func() {
+				Eventually(func(g Gomega) {
+					serviceList := &unstructured.UnstructuredList{}
+					serviceList.SetGroupVersionKind(appworkload.KnativeServiceGVK.GroupVersion().WithKind("ServiceList"))
+					g.Expect(k8sClient.List(ctx, serviceList, client.InNamespace(namespaceName))).To(Succeed())
+					g.Expect(serviceList.Items).To(BeEmpty())
+				}).Should(Succeed())
+			})
+		})
+
+		When("the appworkload runner name is not 'knative-runner'", func() {
+			BeforeEach(func() {
+				otherWorkload := appWorkload.DeepCopy()
+				otherWorkload.ObjectMeta = metav1.ObjectMeta{
+					Name:      uuid.NewString(),
+					Namespace: namespaceName,
+				}
+				otherWorkload.Spec.GUID = uuid.NewString()
+				otherWorkload.Spec.RunnerName = "statefulset-runner"
+				Expect(k8sClient.Create(ctx, otherWorkload)).To(Succeed())
+				appWorkload = otherWorkload
+			})
+
+			It("does not reconcile it", func() {
+				Consistently(func(g Gomega) {
+					g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(appWorkload), appWorkload)).To(Succeed())
+					g.Expect(appWorkload.Status.ObservedGeneration).To(BeZero())
+				}).Should(Succeed())
+			})
+		})
+	})
+})
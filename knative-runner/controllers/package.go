@@ -0,0 +1,16 @@
+package controllers
+
+// RBAC of the shared RunnerInfo controller
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=runnerinfos,verbs=get;list;watch;create;patch;delete
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=runnerinfos/status,verbs=get;patch
+
+const (
+	LabelGUID                 = "korifi.cloudfoundry.org/guid"
+	AppWorkloadReconcilerName = "knative-runner"
+
+	// Knative scales the revisions of a service between these bounds. The
+	// runner always allows scaling to zero and caps the instances to the
+	// ones the app asks for.
+	AnnotationMinScale = "autoscaling.knative.dev/min-scale"
+	AnnotationMaxScale = "autoscaling.knative.dev/max-scale"
+)
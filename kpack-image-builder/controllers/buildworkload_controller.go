@@ -703,9 +703,11 @@ func (r *BuildWorkloadReconciler) reconcileKpackImage(
 				},
 			},
 			Build: &buildv1alpha2.ImageBuild{
-				Services:  buildWorkload.Spec.Services,
-				Env:       buildWorkload.Spec.Env,
-				Resources: GetBuildResources(r.controllerConfig.CFStagingResources.DiskMB, r.controllerConfig.CFStagingResources.MemoryMB),
+				Services:     buildWorkload.Spec.Services,
+				Env:          buildWorkload.Spec.Env,
+				Resources:    GetBuildResources(r.controllerConfig.CFStagingResources.DiskMB, r.controllerConfig.CFStagingResources.MemoryMB),
+				NodeSelector: buildWorkload.Spec.NodeSelector,
+				Tolerations:  buildWorkload.Spec.Tolerations,
 			},
 			Cache: &buildv1alpha2.ImageCacheConfig{
 				Volume: &buildv1alpha2.ImagePersistentVolumeCache{
@@ -505,6 +505,31 @@ var _ = Describe("BuildWorkloadReconciler", func() {
 		})
 	})
 
+	When("the BuildWorkload has a node placement", func() {
+		BeforeEach(func() {
+			buildWorkload = buildWorkloadObject(buildWorkloadGUID, namespaceGUID, source, env, services, reconcilerName, buildpacks)
+			buildWorkload.Spec.NodeSelector = map[string]string{"pool": "regulated"}
+			buildWorkload.Spec.Tolerations = []corev1.Toleration{{
+				Key:      "dedicated",
+				Operator: corev1.TolerationOpExists,
+			}}
+			Expect(adminClient.Create(ctx, buildWorkload)).To(Succeed())
+		})
+
+		It("sets the placement on the kpack.Image build", func() {
+			Eventually(func(g Gomega) {
+				kpackImage := new(buildv1alpha2.Image)
+				g.Expect(adminClient.Get(ctx, types.NamespacedName{Name: appGUID, Namespace: namespaceGUID}, kpackImage)).To(Succeed())
+				g.Expect(kpackImage.Spec.Build).NotTo(BeNil())
+				g.Expect(kpackImage.Spec.Build.NodeSelector).To(Equal(map[string]string{"pool": "regulated"}))
+				g.Expect(kpackImage.Spec.Build.Tolerations).To(ConsistOf(corev1.Toleration{
+					Key:      "dedicated",
+					Operator: corev1.TolerationOpExists,
+				}))
+			}).Should(Succeed())
+		})
+	})
+
 	Describe("once the kpack.Image has been created", func() {
 		var (
 			createdKpackImage    *buildv1alpha2.Image
@@ -122,7 +122,10 @@ func setupControllers(mgr manager.Manager, restConf *rest.Config, configPath str
 		controllersLog,
 		controllerConfig,
 		imageClient,
-		registry.NewRepositoryCreator(controllerConfig.ContainerRegistryType),
+		registry.NewRepositoryManager(controllerConfig.ContainerRegistryType, imageClient, image.Creds{
+			Namespace:          controllerConfig.CFRootNamespace,
+			ServiceAccountName: controllerConfig.BuilderServiceAccount,
+		}),
 	).SetupWithManager(mgr); err != nil {
 		return fmt.Errorf("unable to create BuildWorkload controller: %v", err)
 	}
@@ -7,8 +7,17 @@ type CFResourceState int
 const (
 	CFResourceStateUnknown CFResourceState = iota
 	CFResourceStateReady
+	CFResourceStateFailed
 )
 
+// CFResourceOperation describes the outcome of the last asynchronous
+// operation on a resource, as recorded in its status
+type CFResourceOperation struct {
+	State    CFResourceState
+	Errors   []string
+	Warnings []string
+}
+
 type CFResource struct {
 	GUID      string     `json:"guid"`
 	CreatedAt time.Time  `json:"created_at"`
@@ -18,6 +18,7 @@ package appworkload
 
 import (
 	"context"
+	"time"
 
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	"code.cloudfoundry.org/korifi/statefulset-runner/controllers"
@@ -71,6 +72,16 @@ type PDB interface {
 	Update(ctx context.Context, statefulSet *appsv1.StatefulSet) error
 }
 
+//counterfeiter:generate -o ./fake -fake-name HPA . HPA
+type HPA interface {
+	Update(ctx context.Context, appWorkload *korifiv1alpha1.AppWorkload, statefulSet *appsv1.StatefulSet) error
+}
+
+//counterfeiter:generate -o ./fake -fake-name Volumes . Volumes
+type Volumes interface {
+	Update(ctx context.Context, appWorkload *korifiv1alpha1.AppWorkload) error
+}
+
 //counterfeiter:generate -o ./fake -fake-name WorkloadToStatefulsetConverter . WorkloadToStatefulsetConverter
 type WorkloadToStatefulsetConverter interface {
 	Convert(appWorkload *korifiv1alpha1.AppWorkload) (*appsv1.StatefulSet, error)
@@ -82,6 +93,8 @@ type AppWorkloadReconciler struct {
 	scheme           *runtime.Scheme
 	workloadsToStSet WorkloadToStatefulsetConverter
 	pdb              PDB
+	hpa              HPA
+	volumes          Volumes
 	log              logr.Logger
 	stateCollector   *state.AppWorkloadStateCollector
 }
@@ -91,6 +104,8 @@ func NewAppWorkloadReconciler(
 	scheme *runtime.Scheme,
 	workloadsToStSet WorkloadToStatefulsetConverter,
 	pdb PDB,
+	hpa HPA,
+	volumes Volumes,
 	log logr.Logger,
 	stateCollector *state.AppWorkloadStateCollector,
 ) *k8s.PatchingReconciler[korifiv1alpha1.AppWorkload, *korifiv1alpha1.AppWorkload] {
@@ -99,6 +114,8 @@ func NewAppWorkloadReconciler(
 		scheme:           scheme,
 		workloadsToStSet: workloadsToStSet,
 		pdb:              pdb,
+		hpa:              hpa,
+		volumes:          volumes,
 		log:              log,
 		stateCollector:   stateCollector,
 	}
@@ -154,6 +171,11 @@ func filterAppWorkloads(object client.Object) bool {
 
 //+kubebuilder:rbac:groups=policy,resources=poddisruptionbudgets,verbs=create;patch;deletecollection
 
+//+kubebuilder:rbac:groups=autoscaling,resources=horizontalpodautoscalers,verbs=get;list;watch;create;patch;delete
+
+//+kubebuilder:rbac:groups="",resources=persistentvolumes,verbs=get;list;watch;create;delete
+//+kubebuilder:rbac:groups="",resources=persistentvolumeclaims,verbs=get;list;watch;create;delete
+
 func (r *AppWorkloadReconciler) ReconcileResource(ctx context.Context, appWorkload *korifiv1alpha1.AppWorkload) (ctrl.Result, error) {
 	log := logr.FromContextOrDiscard(ctx)
 
@@ -170,6 +192,12 @@ func (r *AppWorkloadReconciler) ReconcileResource(ctx context.Context, appWorklo
 		return ctrl.Result{}, err
 	}
 
+	err = r.volumes.Update(ctx, appWorkload)
+	if err != nil {
+		log.Info("error when creating service volumes", "reason", err)
+		return ctrl.Result{}, err
+	}
+
 	createdStSet := &appsv1.StatefulSet{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      statefulSet.Name,
@@ -180,8 +208,15 @@ func (r *AppWorkloadReconciler) ReconcileResource(ctx context.Context, appWorklo
 		createdStSet.Labels = statefulSet.Labels
 		createdStSet.Annotations = statefulSet.Annotations
 		createdStSet.OwnerReferences = statefulSet.OwnerReferences
+		replicas := createdStSet.Spec.Replicas
 		createdStSet.Spec = statefulSet.Spec
 
+		// the horizontal pod autoscaler owns the replicas of autoscaled
+		// workloads once they are created
+		if appWorkload.Spec.Autoscaling != nil && replicas != nil {
+			createdStSet.Spec.Replicas = replicas
+		}
+
 		return nil
 	})
 	if err != nil {
@@ -195,6 +230,12 @@ func (r *AppWorkloadReconciler) ReconcileResource(ctx context.Context, appWorklo
 		return ctrl.Result{}, err
 	}
 
+	err = r.hpa.Update(ctx, appWorkload, createdStSet)
+	if err != nil {
+		log.Info("error when creating or patching horizontal pod autoscaler", "reason", err)
+		return ctrl.Result{}, err
+	}
+
 	appWorkload.Status.ActualInstances = createdStSet.Status.Replicas
 
 	instancesState, err := r.stateCollector.CollectState(ctx, appWorkload.Spec.GUID)
@@ -204,5 +245,10 @@ func (r *AppWorkloadReconciler) ReconcileResource(ctx context.Context, appWorklo
 	}
 	appWorkload.Status.InstancesStatus = instancesState
 
+	// the instance limits of autoscaling schedules change over time
+	if appWorkload.Spec.Autoscaling != nil && len(appWorkload.Spec.Autoscaling.Schedules) > 0 {
+		return ctrl.Result{RequeueAfter: time.Minute}, nil
+	}
+
 	return ctrl.Result{}, nil
 }
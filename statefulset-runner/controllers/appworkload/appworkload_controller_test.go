@@ -37,6 +37,8 @@ var _ = Describe("AppWorkload Reconcile", func() {
 		statefulSet            *v1.StatefulSet
 		fakeWorkloadToStSet    *fake.WorkloadToStatefulsetConverter
 		fakePDB                *fake.PDB
+		fakeHPA                *fake.HPA
+		fakeVolumes            *fake.Volumes
 		getAppWorkloadError    error
 		getStatefulSetError    error
 		createStatefulSetError error
@@ -61,6 +63,8 @@ var _ = Describe("AppWorkload Reconcile", func() {
 		fakeWorkloadToStSet.ConvertReturns(statefulSet, nil)
 
 		fakePDB = new(fake.PDB)
+		fakeHPA = new(fake.HPA)
+		fakeVolumes = new(fake.Volumes)
 
 		ctx = context.Background()
 		req = ctrl.Request{
@@ -106,6 +110,8 @@ var _ = Describe("AppWorkload Reconcile", func() {
 			scheme.Scheme,
 			fakeWorkloadToStSet,
 			fakePDB,
+			fakeHPA,
+			fakeVolumes,
 			ctrl.Log.WithName("controllers").WithName("TestAppWorkload"),
 			state.NewAppWorkloadStateCollector(fakeClient),
 		)
@@ -145,6 +151,59 @@ var _ = Describe("AppWorkload Reconcile", func() {
 			})
 		})
 
+		It("updates the service volumes", func() {
+			Expect(fakeVolumes.UpdateCallCount()).To(Equal(1))
+			_, actualWorkload := fakeVolumes.UpdateArgsForCall(0)
+			Expect(actualWorkload.Name).To(Equal(appWorkload.Name))
+		})
+
+		When("updating the service volumes fails", func() {
+			BeforeEach(func() {
+				fakeVolumes.UpdateReturns(errors.New("volumes-error"))
+			})
+
+			It("returns the error", func() {
+				Expect(reconcileErr).To(MatchError("volumes-error"))
+			})
+		})
+
+		It("updates the horizontal pod autoscaler", func() {
+			Expect(fakeHPA.UpdateCallCount()).To(Equal(1))
+			_, actualWorkload, actualStSet := fakeHPA.UpdateArgsForCall(0)
+			Expect(actualWorkload.Name).To(Equal(appWorkload.Name))
+			Expect(actualStSet.Name).To(Equal(statefulSet.Name))
+		})
+
+		When("updating the horizontal pod autoscaler fails", func() {
+			BeforeEach(func() {
+				fakeHPA.UpdateReturns(errors.New("hpa-error"))
+			})
+
+			It("returns the error", func() {
+				Expect(reconcileErr).To(MatchError("hpa-error"))
+			})
+		})
+
+		When("the appworkload has autoscaling schedules", func() {
+			BeforeEach(func() {
+				appWorkload.Spec.Autoscaling = &korifiv1alpha1.AutoscalingPolicy{
+					MinInstances: 1,
+					MaxInstances: 3,
+					Schedules: []korifiv1alpha1.AutoscalingSchedule{{
+						StartTime:    "08:00",
+						EndTime:      "18:00",
+						MinInstances: 2,
+						MaxInstances: 5,
+					}},
+				}
+			})
+
+			It("requeues to follow the schedules", func() {
+				Expect(reconcileErr).NotTo(HaveOccurred())
+				Expect(reconcileResult).To(Equal(ctrl.Result{RequeueAfter: time.Minute}))
+			})
+		})
+
 		It("creates a StatefulSet", func() {
 			Expect(fakeClient.CreateCallCount()).To(Equal(1), "Client.Create call count mismatch")
 			_, obj, _ := fakeClient.CreateArgsForCall(0)
@@ -209,6 +268,29 @@ var _ = Describe("AppWorkload Reconcile", func() {
 			Expect(updatedStSet.Spec.Replicas).To(Equal(tools.PtrTo(int32(2))))
 		})
 
+		When("the appworkload is autoscaled", func() {
+			BeforeEach(func() {
+				appWorkload.Spec.Autoscaling = &korifiv1alpha1.AutoscalingPolicy{
+					MinInstances: 1,
+					MaxInstances: 5,
+				}
+				statefulSet.Spec.Replicas = tools.PtrTo(int32(4))
+
+				desiredStSet := statefulSet.DeepCopy()
+				desiredStSet.Labels = map[string]string{"foo": "bar"}
+				desiredStSet.Spec.Replicas = tools.PtrTo(int32(2))
+				fakeWorkloadToStSet.ConvertReturns(desiredStSet, nil)
+			})
+
+			It("keeps the replicas set by the autoscaler", func() {
+				_, updatedObject, _, _ := fakeClient.PatchArgsForCall(0)
+				updatedStSet, ok := updatedObject.(*v1.StatefulSet)
+				Expect(ok).To(BeTrue())
+				Expect(updatedStSet.Labels).To(HaveKeyWithValue("foo", "bar"))
+				Expect(updatedStSet.Spec.Replicas).To(Equal(tools.PtrTo(int32(4))))
+			})
+		})
+
 		When("updating the pod disruption budget fails", func() {
 			BeforeEach(func() {
 				fakePDB.UpdateReturns(errors.New("boom"))
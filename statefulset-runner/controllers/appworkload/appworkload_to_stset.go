@@ -108,6 +108,11 @@ func (r *AppWorkloadToStatefulsetConverter) Convert(appWorkload *korifiv1alpha1.
 		return envs[i].Name < envs[j].Name
 	})
 
+	volumes, volumeMounts, err := serviceVolumes(appWorkload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert service volume mounts: %w", err)
+	}
+
 	containers := []corev1.Container{
 		{
 			Name:            ApplicationContainerName,
@@ -130,13 +135,13 @@ func (r *AppWorkloadToStatefulsetConverter) Convert(appWorkload *korifiv1alpha1.
 			Resources:     appWorkload.Spec.Resources,
 			StartupProbe:  appWorkload.Spec.StartupProbe,
 			LivenessProbe: appWorkload.Spec.LivenessProbe,
-			VolumeMounts: slices.Collect(it.Map(slices.Values(appWorkload.Spec.Services), func(s korifiv1alpha1.ServiceBinding) corev1.VolumeMount {
+			VolumeMounts: append(slices.Collect(it.Map(slices.Values(appWorkload.Spec.Services), func(s korifiv1alpha1.ServiceBinding) corev1.VolumeMount {
 				return corev1.VolumeMount{
 					Name:      s.Name,
 					ReadOnly:  true,
 					MountPath: filepath.Join(bindingRootPath, s.Name),
 				}
-			})),
+			})), volumeMounts...),
 		},
 	}
 
@@ -164,7 +169,9 @@ func (r *AppWorkloadToStatefulsetConverter) Convert(appWorkload *korifiv1alpha1.
 						},
 					},
 					ServiceAccountName: ServiceAccountName,
-					Volumes: slices.Collect(it.Map(slices.Values(appWorkload.Spec.Services), func(s korifiv1alpha1.ServiceBinding) corev1.Volume {
+					NodeSelector:       appWorkload.Spec.NodeSelector,
+					Tolerations:        appWorkload.Spec.Tolerations,
+					Volumes: append(slices.Collect(it.Map(slices.Values(appWorkload.Spec.Services), func(s korifiv1alpha1.ServiceBinding) corev1.Volume {
 						return corev1.Volume{
 							Name: s.Name,
 							VolumeSource: corev1.VolumeSource{
@@ -174,7 +181,7 @@ func (r *AppWorkloadToStatefulsetConverter) Convert(appWorkload *korifiv1alpha1.
 								},
 							},
 						}
-					})),
+					})), volumes...),
 				},
 			},
 		},
@@ -411,6 +411,102 @@ var _ = Describe("AppWorkload to StatefulSet Converter", func() {
 		})
 	})
 
+	When("the app workload services have volume mounts", func() {
+		BeforeEach(func() {
+			appWorkload.Spec.Services = []korifiv1alpha1.ServiceBinding{{
+				Secret: "service-secret",
+				Name:   "binding-name",
+				VolumeMounts: []korifiv1alpha1.ServiceVolumeMount{
+					{
+						Driver:       "nfs",
+						ContainerDir: "/data",
+						Mode:         korifiv1alpha1.VolumeMountModeReadWrite,
+						VolumeID:     "nfs-volume",
+						MountConfig: map[string]string{
+							"source": "nfs://nfs.example.com/export",
+						},
+					},
+					{
+						Driver:       "smbdriver",
+						ContainerDir: "/shared",
+						Mode:         korifiv1alpha1.VolumeMountModeReadOnly,
+						VolumeID:     "smb-volume",
+						MountConfig: map[string]string{
+							"source": "//smb.example.com/share",
+						},
+					},
+				},
+			}}
+		})
+
+		It("mounts nfs volumes through persistent volume claims", func() {
+			Expect(statefulSet.Spec.Template.Spec.Volumes).To(ContainElement(MatchFields(IgnoreExtras, Fields{
+				"Name": HavePrefix("service-volume-"),
+				"VolumeSource": MatchFields(IgnoreExtras, Fields{
+					"PersistentVolumeClaim": PointTo(MatchAllFields(Fields{
+						"ClaimName": HavePrefix("guid_1234-service-volume-"),
+						"ReadOnly":  BeFalse(),
+					})),
+				}),
+			})))
+		})
+
+		It("mounts other volumes as inline csi volumes", func() {
+			Expect(statefulSet.Spec.Template.Spec.Volumes).To(ContainElement(MatchFields(IgnoreExtras, Fields{
+				"Name": HavePrefix("service-volume-"),
+				"VolumeSource": MatchFields(IgnoreExtras, Fields{
+					"CSI": PointTo(MatchFields(IgnoreExtras, Fields{
+						"Driver":   Equal("smb.csi.k8s.io"),
+						"ReadOnly": PointTo(BeTrue()),
+						"VolumeAttributes": Equal(map[string]string{
+							"source": "//smb.example.com/share",
+						}),
+					})),
+				}),
+			})))
+		})
+
+		It("mounts the volumes at the container dir honouring the mode", func() {
+			Expect(statefulSet.Spec.Template.Spec.Containers[0].VolumeMounts).To(ContainElements(
+				MatchFields(IgnoreExtras, Fields{
+					"Name":      HavePrefix("service-volume-"),
+					"MountPath": Equal("/data"),
+					"ReadOnly":  BeFalse(),
+				}),
+				MatchFields(IgnoreExtras, Fields{
+					"Name":      HavePrefix("service-volume-"),
+					"MountPath": Equal("/shared"),
+					"ReadOnly":  BeTrue(),
+				}),
+			))
+		})
+	})
+
+	When("the app workload has a node placement", func() {
+		BeforeEach(func() {
+			appWorkload.Spec.NodeSelector = map[string]string{"pool": "regulated"}
+			appWorkload.Spec.Tolerations = []corev1.Toleration{{
+				Key:      "dedicated",
+				Operator: corev1.TolerationOpEqual,
+				Value:    "regulated",
+				Effect:   corev1.TaintEffectNoSchedule,
+			}}
+		})
+
+		It("sets the node selector on the pod", func() {
+			Expect(statefulSet.Spec.Template.Spec.NodeSelector).To(Equal(map[string]string{"pool": "regulated"}))
+		})
+
+		It("sets the tolerations on the pod", func() {
+			Expect(statefulSet.Spec.Template.Spec.Tolerations).To(ConsistOf(corev1.Toleration{
+				Key:      "dedicated",
+				Operator: corev1.TolerationOpEqual,
+				Value:    "regulated",
+				Effect:   corev1.TaintEffectNoSchedule,
+			}))
+		})
+	})
+
 	It("should produce a stable statefulset regardless of labels iteration order", func() {
 		for i := 0; i < 100; i++ {
 			ss, err := converter.Convert(appWorkload)
@@ -0,0 +1,183 @@
+package appworkload
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/statefulset-runner/controllers"
+
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+type HPAUpdater struct {
+	client client.Client
+}
+
+func NewHPAUpdater(client client.Client) *HPAUpdater {
+	return &HPAUpdater{
+		client: client,
+	}
+}
+
+func (c *HPAUpdater) Update(ctx context.Context, appWorkload *korifiv1alpha1.AppWorkload, statefulSet *appsv1.StatefulSet) error {
+	if appWorkload.Spec.Autoscaling == nil {
+		return c.deleteHPA(ctx, statefulSet)
+	}
+
+	return c.createOrPatchHPA(ctx, appWorkload.Spec.Autoscaling, statefulSet)
+}
+
+func (c *HPAUpdater) createOrPatchHPA(ctx context.Context, policy *korifiv1alpha1.AutoscalingPolicy, statefulSet *appsv1.StatefulSet) error {
+	minInstances, maxInstances, err := ActiveInstanceLimits(policy, time.Now())
+	if err != nil {
+		return err
+	}
+
+	hpa := &autoscalingv2.HorizontalPodAutoscaler{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      statefulSet.Name,
+			Namespace: statefulSet.Namespace,
+		},
+	}
+
+	_, err = controllerutil.CreateOrPatch(ctx, c.client, hpa, func() error {
+		hpa.Labels = map[string]string{
+			controllers.LabelGUID: statefulSet.Labels[controllers.LabelGUID],
+			LabelVersion:          statefulSet.Labels[LabelVersion],
+		}
+		hpa.Spec = autoscalingv2.HorizontalPodAutoscalerSpec{
+			ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{
+				APIVersion: appsv1.SchemeGroupVersion.String(),
+				Kind:       "StatefulSet",
+				Name:       statefulSet.Name,
+			},
+			MinReplicas: &minInstances,
+			MaxReplicas: maxInstances,
+			Metrics:     utilizationMetrics(policy),
+		}
+
+		return controllerutil.SetControllerReference(statefulSet, hpa, scheme.Scheme)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create or patch horizontal pod autoscaler: %w", err)
+	}
+
+	return nil
+}
+
+func (c *HPAUpdater) deleteHPA(ctx context.Context, statefulSet *appsv1.StatefulSet) error {
+	err := c.client.Delete(ctx, &autoscalingv2.HorizontalPodAutoscaler{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      statefulSet.Name,
+			Namespace: statefulSet.Namespace,
+		},
+	})
+	if client.IgnoreNotFound(err) != nil {
+		return fmt.Errorf("failed to delete horizontal pod autoscaler: %w", err)
+	}
+
+	return nil
+}
+
+func utilizationMetrics(policy *korifiv1alpha1.AutoscalingPolicy) []autoscalingv2.MetricSpec {
+	var metrics []autoscalingv2.MetricSpec
+
+	if policy.CPUUtilization != nil {
+		metrics = append(metrics, utilizationMetric(corev1.ResourceCPU, *policy.CPUUtilization))
+	}
+
+	if policy.MemoryUtilization != nil {
+		metrics = append(metrics, utilizationMetric(corev1.ResourceMemory, *policy.MemoryUtilization))
+	}
+
+	return metrics
+}
+
+func utilizationMetric(resourceName corev1.ResourceName, utilization int32) autoscalingv2.MetricSpec {
+	return autoscalingv2.MetricSpec{
+		Type: autoscalingv2.ResourceMetricSourceType,
+		Resource: &autoscalingv2.ResourceMetricSource{
+			Name: resourceName,
+			Target: autoscalingv2.MetricTarget{
+				Type:               autoscalingv2.UtilizationMetricType,
+				AverageUtilization: &utilization,
+			},
+		},
+	}
+}
+
+// ActiveInstanceLimits returns the instance limits of the first schedule of
+// the policy active at the given time, or the policy ones if none is
+func ActiveInstanceLimits(policy *korifiv1alpha1.AutoscalingPolicy, now time.Time) (int32, int32, error) {
+	for _, schedule := range policy.Schedules {
+		active, err := isScheduleActive(schedule, now)
+		if err != nil {
+			return 0, 0, err
+		}
+
+		if active {
+			return schedule.MinInstances, schedule.MaxInstances, nil
+		}
+	}
+
+	return policy.MinInstances, policy.MaxInstances, nil
+}
+
+func isScheduleActive(schedule korifiv1alpha1.AutoscalingSchedule, now time.Time) (bool, error) {
+	location, err := time.LoadLocation(schedule.TimeZone)
+	if err != nil {
+		return false, fmt.Errorf("invalid autoscaling schedule time zone %q: %w", schedule.TimeZone, err)
+	}
+
+	start, err := minuteOfDay(schedule.StartTime)
+	if err != nil {
+		return false, err
+	}
+
+	end, err := minuteOfDay(schedule.EndTime)
+	if err != nil {
+		return false, err
+	}
+
+	localNow := now.In(location)
+	minute := localNow.Hour()*60 + localNow.Minute()
+
+	if start < end {
+		return startsOn(schedule, localNow) && minute >= start && minute < end, nil
+	}
+
+	// the window ends on the day after it starts
+	yesterday := localNow.AddDate(0, 0, -1)
+	return (startsOn(schedule, localNow) && minute >= start) || (startsOn(schedule, yesterday) && minute < end), nil
+}
+
+func startsOn(schedule korifiv1alpha1.AutoscalingSchedule, day time.Time) bool {
+	if len(schedule.DaysOfWeek) == 0 {
+		return true
+	}
+
+	isoWeekday := int32(day.Weekday())
+	if isoWeekday == 0 {
+		isoWeekday = 7
+	}
+
+	return slices.Contains(schedule.DaysOfWeek, isoWeekday)
+}
+
+func minuteOfDay(hhmm string) (int, error) {
+	t, err := time.Parse("15:04", hhmm)
+	if err != nil {
+		return 0, fmt.Errorf("invalid autoscaling schedule time %q: %w", hhmm, err)
+	}
+
+	return t.Hour()*60 + t.Minute(), nil
+}
@@ -0,0 +1,261 @@
+package appworkload_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/statefulset-runner/controllers"
+	"code.cloudfoundry.org/korifi/statefulset-runner/controllers/appworkload"
+	"code.cloudfoundry.org/korifi/tools"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+var _ = Describe("HPA", func() {
+	var (
+		updater     *appworkload.HPAUpdater
+		appWorkload *korifiv1alpha1.AppWorkload
+		stSet       *appsv1.StatefulSet
+		ctx         context.Context
+	)
+
+	BeforeEach(func() {
+		updater = appworkload.NewHPAUpdater(fakeClient)
+
+		appWorkload = &korifiv1alpha1.AppWorkload{
+			Spec: korifiv1alpha1.AppWorkloadSpec{
+				Autoscaling: &korifiv1alpha1.AutoscalingPolicy{
+					MinInstances:      2,
+					MaxInstances:      5,
+					CPUUtilization:    tools.PtrTo[int32](70),
+					MemoryUtilization: tools.PtrTo[int32](80),
+				},
+			},
+		}
+
+		stSet = &appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "name",
+				Namespace: "namespace",
+				UID:       "uid",
+				Labels: map[string]string{
+					controllers.LabelGUID:    "label-guid",
+					appworkload.LabelVersion: "label-version",
+				},
+			},
+		}
+
+		fakeClient.GetReturns(k8serrors.NewNotFound(schema.GroupResource{}, "hpa"))
+
+		ctx = context.Background()
+	})
+
+	Describe("Update", func() {
+		var updateErr error
+
+		JustBeforeEach(func() {
+			updateErr = updater.Update(ctx, appWorkload, stSet)
+		})
+
+		It("succeeds", func() {
+			Expect(updateErr).NotTo(HaveOccurred())
+		})
+
+		It("creates a horizontal pod autoscaler", func() {
+			Expect(fakeClient.CreateCallCount()).To(Equal(1))
+
+			_, obj, _ := fakeClient.CreateArgsForCall(0)
+			Expect(obj).To(BeAssignableToTypeOf(&autoscalingv2.HorizontalPodAutoscaler{}))
+			hpa := obj.(*autoscalingv2.HorizontalPodAutoscaler)
+
+			Expect(hpa.Namespace).To(Equal("namespace"))
+			Expect(hpa.Name).To(Equal("name"))
+			Expect(hpa.Labels).To(HaveKeyWithValue(controllers.LabelGUID, "label-guid"))
+			Expect(hpa.Labels).To(HaveKeyWithValue(appworkload.LabelVersion, "label-version"))
+			Expect(hpa.Spec.ScaleTargetRef).To(Equal(autoscalingv2.CrossVersionObjectReference{
+				APIVersion: "apps/v1",
+				Kind:       "StatefulSet",
+				Name:       "name",
+			}))
+			Expect(hpa.Spec.MinReplicas).To(PointTo(BeEquivalentTo(2)))
+			Expect(hpa.Spec.MaxReplicas).To(BeEquivalentTo(5))
+			Expect(hpa.Spec.Metrics).To(ConsistOf(
+				MatchFields(IgnoreExtras, Fields{
+					"Type": Equal(autoscalingv2.ResourceMetricSourceType),
+					"Resource": PointTo(Equal(autoscalingv2.ResourceMetricSource{
+						Name: corev1.ResourceCPU,
+						Target: autoscalingv2.MetricTarget{
+							Type:               autoscalingv2.UtilizationMetricType,
+							AverageUtilization: tools.PtrTo[int32](70),
+						},
+					})),
+				}),
+				MatchFields(IgnoreExtras, Fields{
+					"Type": Equal(autoscalingv2.ResourceMetricSourceType),
+					"Resource": PointTo(Equal(autoscalingv2.ResourceMetricSource{
+						Name: corev1.ResourceMemory,
+						Target: autoscalingv2.MetricTarget{
+							Type:               autoscalingv2.UtilizationMetricType,
+							AverageUtilization: tools.PtrTo[int32](80),
+						},
+					})),
+				}),
+			))
+			Expect(hpa.OwnerReferences).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+				"Kind": Equal("StatefulSet"),
+				"Name": Equal("name"),
+				"UID":  BeEquivalentTo("uid"),
+			})))
+		})
+
+		When("the policy has no utilization targets", func() {
+			BeforeEach(func() {
+				appWorkload.Spec.Autoscaling.CPUUtilization = nil
+				appWorkload.Spec.Autoscaling.MemoryUtilization = nil
+			})
+
+			It("leaves the metrics to the autoscaler defaults", func() {
+				_, obj, _ := fakeClient.CreateArgsForCall(0)
+				Expect(obj.(*autoscalingv2.HorizontalPodAutoscaler).Spec.Metrics).To(BeEmpty())
+			})
+		})
+
+		When("the horizontal pod autoscaler already exists", func() {
+			BeforeEach(func() {
+				fakeClient.GetReturns(nil)
+			})
+
+			It("patches it", func() {
+				Expect(fakeClient.CreateCallCount()).To(BeZero())
+				Expect(fakeClient.PatchCallCount()).To(Equal(1))
+				_, obj, _, _ := fakeClient.PatchArgsForCall(0)
+				Expect(obj.(*autoscalingv2.HorizontalPodAutoscaler).Spec.MaxReplicas).To(BeEquivalentTo(5))
+			})
+		})
+
+		When("creating the horizontal pod autoscaler fails", func() {
+			BeforeEach(func() {
+				fakeClient.CreateReturns(errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				Expect(updateErr).To(MatchError(ContainSubstring("boom")))
+			})
+		})
+
+		When("a schedule has an invalid time zone", func() {
+			BeforeEach(func() {
+				appWorkload.Spec.Autoscaling.Schedules = []korifiv1alpha1.AutoscalingSchedule{{
+					StartTime:    "08:00",
+					EndTime:      "18:00",
+					TimeZone:     "Not/AZone",
+					MinInstances: 1,
+					MaxInstances: 1,
+				}}
+			})
+
+			It("returns an error", func() {
+				Expect(updateErr).To(MatchError(ContainSubstring("Not/AZone")))
+			})
+		})
+
+		When("the workload is not autoscaled", func() {
+			BeforeEach(func() {
+				appWorkload.Spec.Autoscaling = nil
+			})
+
+			It("deletes the horizontal pod autoscaler", func() {
+				Expect(fakeClient.CreateCallCount()).To(BeZero())
+				Expect(fakeClient.DeleteCallCount()).To(Equal(1))
+				_, obj, _ := fakeClient.DeleteArgsForCall(0)
+				Expect(obj).To(BeAssignableToTypeOf(&autoscalingv2.HorizontalPodAutoscaler{}))
+				Expect(obj.GetNamespace()).To(Equal("namespace"))
+				Expect(obj.GetName()).To(Equal("name"))
+			})
+
+			When("the horizontal pod autoscaler does not exist", func() {
+				BeforeEach(func() {
+					fakeClient.DeleteReturns(k8serrors.NewNotFound(schema.GroupResource{}, "hpa"))
+				})
+
+				It("succeeds", func() {
+					Expect(updateErr).NotTo(HaveOccurred())
+				})
+			})
+
+			When("deleting the horizontal pod autoscaler fails", func() {
+				BeforeEach(func() {
+					fakeClient.DeleteReturns(errors.New("boom"))
+				})
+
+				It("returns an error", func() {
+					Expect(updateErr).To(MatchError(ContainSubstring("boom")))
+				})
+			})
+		})
+	})
+
+	DescribeTable("ActiveInstanceLimits",
+		func(schedule korifiv1alpha1.AutoscalingSchedule, now string, expectedMin, expectedMax int32) {
+			nowTime, err := time.Parse(time.RFC3339, now)
+			Expect(err).NotTo(HaveOccurred())
+
+			schedule.MinInstances = 3
+			schedule.MaxInstances = 10
+			policy := &korifiv1alpha1.AutoscalingPolicy{
+				MinInstances: 1,
+				MaxInstances: 2,
+				Schedules:    []korifiv1alpha1.AutoscalingSchedule{schedule},
+			}
+
+			minInstances, maxInstances, err := appworkload.ActiveInstanceLimits(policy, nowTime)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(minInstances).To(Equal(expectedMin))
+			Expect(maxInstances).To(Equal(expectedMax))
+		},
+		// 2024-01-01 is a Monday
+		Entry("within a daily window",
+			korifiv1alpha1.AutoscalingSchedule{StartTime: "08:00", EndTime: "18:00"},
+			"2024-01-01T12:00:00Z", int32(3), int32(10)),
+		Entry("before a daily window",
+			korifiv1alpha1.AutoscalingSchedule{StartTime: "08:00", EndTime: "18:00"},
+			"2024-01-01T07:59:00Z", int32(1), int32(2)),
+		Entry("at the end of a daily window",
+			korifiv1alpha1.AutoscalingSchedule{StartTime: "08:00", EndTime: "18:00"},
+			"2024-01-01T18:00:00Z", int32(1), int32(2)),
+		Entry("within a window on a listed day",
+			korifiv1alpha1.AutoscalingSchedule{StartTime: "08:00", EndTime: "18:00", DaysOfWeek: []int32{1, 2}},
+			"2024-01-01T12:00:00Z", int32(3), int32(10)),
+		Entry("within a window on an unlisted day",
+			korifiv1alpha1.AutoscalingSchedule{StartTime: "08:00", EndTime: "18:00", DaysOfWeek: []int32{2, 3}},
+			"2024-01-01T12:00:00Z", int32(1), int32(2)),
+		Entry("on sunday",
+			korifiv1alpha1.AutoscalingSchedule{StartTime: "08:00", EndTime: "18:00", DaysOfWeek: []int32{7}},
+			"2023-12-31T12:00:00Z", int32(3), int32(10)),
+		Entry("within an overnight window after midnight",
+			korifiv1alpha1.AutoscalingSchedule{StartTime: "22:00", EndTime: "02:00", DaysOfWeek: []int32{7}},
+			"2024-01-01T01:00:00Z", int32(3), int32(10)),
+		Entry("within an overnight window before midnight",
+			korifiv1alpha1.AutoscalingSchedule{StartTime: "22:00", EndTime: "02:00", DaysOfWeek: []int32{1}},
+			"2024-01-01T23:00:00Z", int32(3), int32(10)),
+		Entry("after an overnight window started on an unlisted day",
+			korifiv1alpha1.AutoscalingSchedule{StartTime: "22:00", EndTime: "02:00", DaysOfWeek: []int32{1}},
+			"2024-01-01T01:00:00Z", int32(1), int32(2)),
+		Entry("within a window in another time zone",
+			korifiv1alpha1.AutoscalingSchedule{StartTime: "08:00", EndTime: "18:00", TimeZone: "America/New_York"},
+			"2024-01-01T20:00:00Z", int32(3), int32(10)),
+		Entry("outside a window in another time zone",
+			korifiv1alpha1.AutoscalingSchedule{StartTime: "08:00", EndTime: "18:00", TimeZone: "America/New_York"},
+			"2024-01-01T12:00:00Z", int32(1), int32(2)),
+	)
+})
@@ -0,0 +1,298 @@
+package appworkload
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"slices"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// NFS volumes are not size constrained, the capacity is only there because
+// persistent volumes and claims require one
+const nfsVolumeCapacity = "1Gi"
+
+// Cloud Foundry volume service drivers and the CSI drivers that replace them
+var csiDriverAliases = map[string]string{
+	"smb":       "smb.csi.k8s.io",
+	"smbdriver": "smb.csi.k8s.io",
+}
+
+type VolumesUpdater struct {
+	client client.Client
+	scheme *runtime.Scheme
+	// Persistent volumes are cluster-scoped, so the runner only creates them
+	// for the NFS servers the operator has allowed
+	allowedNFSServers []string
+}
+
+func NewVolumesUpdater(client client.Client, scheme *runtime.Scheme, allowedNFSServers []string) *VolumesUpdater {
+	return &VolumesUpdater{
+		client:            client,
+		scheme:            scheme,
+		allowedNFSServers: allowedNFSServers,
+	}
+}
+
+// Update creates the persistent volumes and claims the NFS volume mounts of
+// the app workload service bindings are mounted through, and deletes the ones
+// that are no longer needed
+func (u *VolumesUpdater) Update(ctx context.Context, appWorkload *korifiv1alpha1.AppWorkload) error {
+	desiredClaims := []string{}
+	for _, binding := range appWorkload.Spec.Services {
+		for _, mount := range binding.VolumeMounts {
+			if !isNFSVolumeMount(mount) {
+				continue
+			}
+
+			claimName, err := serviceVolumeClaimName(appWorkload, binding, mount)
+			if err != nil {
+				return err
+			}
+
+			if err = u.createNFSVolume(ctx, appWorkload, claimName, mount); err != nil {
+				return err
+			}
+			desiredClaims = append(desiredClaims, claimName)
+		}
+	}
+
+	if err := u.deleteStaleClaims(ctx, appWorkload, desiredClaims); err != nil {
+		return err
+	}
+
+	return u.deleteReleasedVolumes(ctx)
+}
+
+func (u *VolumesUpdater) createNFSVolume(ctx context.Context, appWorkload *korifiv1alpha1.AppWorkload, claimName string, mount korifiv1alpha1.ServiceVolumeMount) error {
+	nfsSource, err := nfsVolumeSource(mount)
+	if err != nil {
+		return err
+	}
+
+	if !slices.Contains(u.allowedNFSServers, nfsSource.Server) {
+		return fmt.Errorf("volume %q: nfs server %q is not allowed", mount.VolumeID, nfsSource.Server)
+	}
+
+	accessModes := []corev1.PersistentVolumeAccessMode{volumeAccessMode(mount)}
+	capacity := corev1.ResourceList{corev1.ResourceStorage: resource.MustParse(nfsVolumeCapacity)}
+	labels := map[string]string{LabelAppWorkloadGUID: appWorkload.Name}
+
+	volume := &corev1.PersistentVolume{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   claimName,
+			Labels: labels,
+		},
+		Spec: corev1.PersistentVolumeSpec{
+			Capacity:    capacity,
+			AccessModes: accessModes,
+			PersistentVolumeSource: corev1.PersistentVolumeSource{
+				NFS: nfsSource,
+			},
+			// The volume is shared with other apps and must survive this one
+			PersistentVolumeReclaimPolicy: corev1.PersistentVolumeReclaimRetain,
+			ClaimRef: &corev1.ObjectReference{
+				Namespace: appWorkload.Namespace,
+				Name:      claimName,
+			},
+		},
+	}
+	if err = u.client.Create(ctx, volume); client.IgnoreAlreadyExists(err) != nil {
+		return fmt.Errorf("failed to create persistent volume: %w", err)
+	}
+
+	claim := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      claimName,
+			Namespace: appWorkload.Namespace,
+			Labels:    labels,
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes:      accessModes,
+			StorageClassName: tools.PtrTo(""),
+			VolumeName:       volume.Name,
+			Resources: corev1.VolumeResourceRequirements{
+				Requests: capacity,
+			},
+		},
+	}
+	if err = controllerutil.SetControllerReference(appWorkload, claim, u.scheme); err != nil {
+		return fmt.Errorf("volumes updater failed to set owner ref: %w", err)
+	}
+	if err = u.client.Create(ctx, claim); client.IgnoreAlreadyExists(err) != nil {
+		return fmt.Errorf("failed to create persistent volume claim: %w", err)
+	}
+
+	return nil
+}
+
+func (u *VolumesUpdater) deleteStaleClaims(ctx context.Context, appWorkload *korifiv1alpha1.AppWorkload, desiredClaims []string) error {
+	claims := &corev1.PersistentVolumeClaimList{}
+	err := u.client.List(ctx, claims, client.InNamespace(appWorkload.Namespace), client.MatchingLabels{LabelAppWorkloadGUID: appWorkload.Name})
+	if err != nil {
+		return fmt.Errorf("failed to list persistent volume claims: %w", err)
+	}
+
+	for i := range claims.Items {
+		if slices.Contains(desiredClaims, claims.Items[i].Name) {
+			continue
+		}
+
+		if err = u.client.Delete(ctx, &claims.Items[i]); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed to delete persistent volume claim: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// deleteReleasedVolumes deletes the service persistent volumes whose claims
+// are gone, either because the volume mount has been removed from the app
+// workload, or because the app workload itself has been deleted. Deleting a
+// retained volume does not touch the data on the NFS share.
+func (u *VolumesUpdater) deleteReleasedVolumes(ctx context.Context) error {
+	volumes := &corev1.PersistentVolumeList{}
+	if err := u.client.List(ctx, volumes, client.HasLabels{LabelAppWorkloadGUID}); err != nil {
+		return fmt.Errorf("failed to list persistent volumes: %w", err)
+	}
+
+	for i := range volumes.Items {
+		if volumes.Items[i].Status.Phase != corev1.VolumeReleased {
+			continue
+		}
+
+		if err := u.client.Delete(ctx, &volumes.Items[i]); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed to delete persistent volume: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func serviceVolumes(appWorkload *korifiv1alpha1.AppWorkload) ([]corev1.Volume, []corev1.VolumeMount, error) {
+	volumes := []corev1.Volume{}
+	volumeMounts := []corev1.VolumeMount{}
+
+	for _, binding := range appWorkload.Spec.Services {
+		for _, mount := range binding.VolumeMounts {
+			volumeName, err := serviceVolumeName(binding, mount)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			volumeSource, err := serviceVolumeSource(appWorkload, binding, mount)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			volumes = append(volumes, corev1.Volume{
+				Name:         volumeName,
+				VolumeSource: volumeSource,
+			})
+			volumeMounts = append(volumeMounts, corev1.VolumeMount{
+				Name:      volumeName,
+				ReadOnly:  isReadOnly(mount),
+				MountPath: mount.ContainerDir,
+			})
+		}
+	}
+
+	return volumes, volumeMounts, nil
+}
+
+func serviceVolumeSource(appWorkload *korifiv1alpha1.AppWorkload, binding korifiv1alpha1.ServiceBinding, mount korifiv1alpha1.ServiceVolumeMount) (corev1.VolumeSource, error) {
+	if isNFSVolumeMount(mount) {
+		claimName, err := serviceVolumeClaimName(appWorkload, binding, mount)
+		if err != nil {
+			return corev1.VolumeSource{}, err
+		}
+
+		return corev1.VolumeSource{
+			PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+				ClaimName: claimName,
+				ReadOnly:  isReadOnly(mount),
+			},
+		}, nil
+	}
+
+	driver := mount.Driver
+	if alias, ok := csiDriverAliases[driver]; ok {
+		driver = alias
+	}
+
+	// The CSI driver must support the ephemeral volume lifecycle mode
+	return corev1.VolumeSource{
+		CSI: &corev1.CSIVolumeSource{
+			Driver:           driver,
+			ReadOnly:         tools.PtrTo(isReadOnly(mount)),
+			VolumeAttributes: mount.MountConfig,
+		},
+	}, nil
+}
+
+func nfsVolumeSource(mount korifiv1alpha1.ServiceVolumeMount) (*corev1.NFSVolumeSource, error) {
+	source, err := url.Parse(mount.MountConfig["source"])
+	if err != nil || source.Scheme != "nfs" || source.Host == "" {
+		return nil, fmt.Errorf("volume %q: expected the mount config source to be an nfs://<server>/<path> URL, got %q", mount.VolumeID, mount.MountConfig["source"])
+	}
+
+	path := source.Path
+	if path == "" {
+		path = "/"
+	}
+
+	return &corev1.NFSVolumeSource{
+		Server:   source.Host,
+		Path:     path,
+		ReadOnly: isReadOnly(mount),
+	}, nil
+}
+
+func serviceVolumeName(binding korifiv1alpha1.ServiceBinding, mount korifiv1alpha1.ServiceVolumeMount) (string, error) {
+	volumeHash, err := hash(fmt.Sprintf("%s-%s-%s-%s", binding.Secret, mount.Driver, mount.VolumeID, mount.ContainerDir))
+	if err != nil {
+		return "", fmt.Errorf("failed to generate hash for service volume name: %w", err)
+	}
+
+	return "service-volume-" + volumeHash, nil
+}
+
+// serviceVolumeClaimName returns the name of both the persistent volume
+// claim and the persistent volume of an NFS volume mount. App workload
+// names are unique across namespaces, so the name is unique cluster-wide.
+func serviceVolumeClaimName(appWorkload *korifiv1alpha1.AppWorkload, binding korifiv1alpha1.ServiceBinding, mount korifiv1alpha1.ServiceVolumeMount) (string, error) {
+	volumeName, err := serviceVolumeName(binding, mount)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%s-%s", appWorkload.Name, volumeName), nil
+}
+
+// NFS volume mounts are mounted through statically provisioned persistent
+// volumes. App namespaces enforce the restricted pod security standard, which
+// does not allow NFS volumes in pod specs.
+func isNFSVolumeMount(mount korifiv1alpha1.ServiceVolumeMount) bool {
+	return slices.Contains(korifiv1alpha1.NFSVolumeDrivers, mount.Driver)
+}
+
+func isReadOnly(mount korifiv1alpha1.ServiceVolumeMount) bool {
+	return mount.Mode != korifiv1alpha1.VolumeMountModeReadWrite
+}
+
+func volumeAccessMode(mount korifiv1alpha1.ServiceVolumeMount) corev1.PersistentVolumeAccessMode {
+	if isReadOnly(mount) {
+		return corev1.ReadOnlyMany
+	}
+
+	return corev1.ReadWriteMany
+}
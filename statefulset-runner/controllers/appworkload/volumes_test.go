@@ -0,0 +1,187 @@
+package appworkload_test
+
+import (
+	"context"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/statefulset-runner/controllers/appworkload"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("Volumes", func() {
+	var (
+		updater     *appworkload.VolumesUpdater
+		appWorkload *korifiv1alpha1.AppWorkload
+		ctx         context.Context
+		updateErr   error
+	)
+
+	BeforeEach(func() {
+		updater = appworkload.NewVolumesUpdater(fakeClient, scheme.Scheme, []string{"nfs.example.com"})
+
+		appWorkload = &korifiv1alpha1.AppWorkload{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "workload",
+				Namespace: "namespace",
+				UID:       "uid",
+			},
+			Spec: korifiv1alpha1.AppWorkloadSpec{
+				Services: []korifiv1alpha1.ServiceBinding{{
+					Name:   "binding",
+					Secret: "binding-secret",
+					VolumeMounts: []korifiv1alpha1.ServiceVolumeMount{{
+						Driver:       "nfs",
+						ContainerDir: "/data",
+						Mode:         korifiv1alpha1.VolumeMountModeReadOnly,
+						VolumeID:     "volume-id",
+						MountConfig: map[string]string{
+							"source": "nfs://nfs.example.com/export/data",
+						},
+					}},
+				}},
+			},
+		}
+
+		ctx = context.Background()
+	})
+
+	JustBeforeEach(func() {
+		updateErr = updater.Update(ctx, appWorkload)
+	})
+
+	It("succeeds", func() {
+		Expect(updateErr).NotTo(HaveOccurred())
+	})
+
+	It("creates a persistent volume for the nfs share", func() {
+		Expect(fakeClient.CreateCallCount()).To(Equal(2))
+
+		_, obj, _ := fakeClient.CreateArgsForCall(0)
+		Expect(obj).To(BeAssignableToTypeOf(&corev1.PersistentVolume{}))
+		volume := obj.(*corev1.PersistentVolume)
+
+		Expect(volume.Name).To(HavePrefix("workload-service-volume-"))
+		Expect(volume.Labels).To(HaveKeyWithValue(appworkload.LabelAppWorkloadGUID, "workload"))
+		Expect(volume.Spec.AccessModes).To(ConsistOf(corev1.ReadOnlyMany))
+		Expect(volume.Spec.PersistentVolumeReclaimPolicy).To(Equal(corev1.PersistentVolumeReclaimRetain))
+		Expect(volume.Spec.NFS).To(PointTo(Equal(corev1.NFSVolumeSource{
+			Server:   "nfs.example.com",
+			Path:     "/export/data",
+			ReadOnly: true,
+		})))
+		Expect(volume.Spec.ClaimRef).To(PointTo(MatchFields(IgnoreExtras, Fields{
+			"Namespace": Equal("namespace"),
+			"Name":      Equal(volume.Name),
+		})))
+	})
+
+	It("creates a persistent volume claim bound to the volume", func() {
+		Expect(fakeClient.CreateCallCount()).To(Equal(2))
+
+		_, volumeObj, _ := fakeClient.CreateArgsForCall(0)
+		_, obj, _ := fakeClient.CreateArgsForCall(1)
+		Expect(obj).To(BeAssignableToTypeOf(&corev1.PersistentVolumeClaim{}))
+		claim := obj.(*corev1.PersistentVolumeClaim)
+
+		Expect(claim.Namespace).To(Equal("namespace"))
+		Expect(claim.Name).To(Equal(volumeObj.GetName()))
+		Expect(claim.Spec.VolumeName).To(Equal(volumeObj.GetName()))
+		Expect(claim.Spec.StorageClassName).To(PointTo(BeEmpty()))
+		Expect(claim.Spec.AccessModes).To(ConsistOf(corev1.ReadOnlyMany))
+		Expect(claim.OwnerReferences).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+			"Name": Equal("workload"),
+			"UID":  BeEquivalentTo("uid"),
+		})))
+	})
+
+	When("the volume is mounted read-write", func() {
+		BeforeEach(func() {
+			appWorkload.Spec.Services[0].VolumeMounts[0].Mode = korifiv1alpha1.VolumeMountModeReadWrite
+		})
+
+		It("creates a read-write-many volume", func() {
+			_, obj, _ := fakeClient.CreateArgsForCall(0)
+			volume := obj.(*corev1.PersistentVolume)
+			Expect(volume.Spec.AccessModes).To(ConsistOf(corev1.ReadWriteMany))
+			Expect(volume.Spec.NFS.ReadOnly).To(BeFalse())
+		})
+	})
+
+	When("the nfs source is invalid", func() {
+		BeforeEach(func() {
+			appWorkload.Spec.Services[0].VolumeMounts[0].MountConfig["source"] = "not-a-url"
+		})
+
+		It("returns an error", func() {
+			Expect(updateErr).To(MatchError(ContainSubstring("nfs://<server>/<path>")))
+		})
+	})
+
+	When("the nfs server is not allowed", func() {
+		BeforeEach(func() {
+			appWorkload.Spec.Services[0].VolumeMounts[0].MountConfig["source"] = "nfs://evil.example.com/export"
+		})
+
+		It("returns an error", func() {
+			Expect(updateErr).To(MatchError(ContainSubstring(`nfs server "evil.example.com" is not allowed`)))
+		})
+
+		It("does not create persistent volumes", func() {
+			Expect(fakeClient.CreateCallCount()).To(BeZero())
+		})
+	})
+
+	When("the volume mount uses a csi driver", func() {
+		BeforeEach(func() {
+			appWorkload.Spec.Services[0].VolumeMounts[0].Driver = "smb.csi.k8s.io"
+		})
+
+		It("does not create persistent volumes", func() {
+			Expect(updateErr).NotTo(HaveOccurred())
+			Expect(fakeClient.CreateCallCount()).To(BeZero())
+		})
+	})
+
+	When("there are stale claims and released volumes", func() {
+		BeforeEach(func() {
+			fakeClient.ListStub = func(_ context.Context, list client.ObjectList, _ ...client.ListOption) error {
+				switch list := list.(type) {
+				case *corev1.PersistentVolumeClaimList:
+					list.Items = []corev1.PersistentVolumeClaim{{
+						ObjectMeta: metav1.ObjectMeta{Name: "stale-claim", Namespace: "namespace"},
+					}}
+				case *corev1.PersistentVolumeList:
+					list.Items = []corev1.PersistentVolume{
+						{
+							ObjectMeta: metav1.ObjectMeta{Name: "released-volume"},
+							Status:     corev1.PersistentVolumeStatus{Phase: corev1.VolumeReleased},
+						},
+						{
+							ObjectMeta: metav1.ObjectMeta{Name: "bound-volume"},
+							Status:     corev1.PersistentVolumeStatus{Phase: corev1.VolumeBound},
+						},
+					}
+				}
+				return nil
+			}
+		})
+
+		It("deletes the stale claims and the released volumes", func() {
+			Expect(updateErr).NotTo(HaveOccurred())
+			Expect(fakeClient.DeleteCallCount()).To(Equal(2))
+
+			_, deletedClaim, _ := fakeClient.DeleteArgsForCall(0)
+			Expect(deletedClaim.GetName()).To(Equal("stale-claim"))
+
+			_, deletedVolume, _ := fakeClient.DeleteArgsForCall(1)
+			Expect(deletedVolume.GetName()).To(Equal("released-volume"))
+		})
+	})
+})
@@ -87,6 +87,8 @@ var _ = BeforeEach(func() {
 		k8sManager.GetScheme(),
 		appworkload.NewAppWorkloadToStatefulsetConverter(k8sManager.GetScheme()),
 		appworkload.NewPDBUpdater(k8sManager.GetClient()),
+		appworkload.NewHPAUpdater(k8sManager.GetClient()),
+		appworkload.NewVolumesUpdater(k8sManager.GetClient(), k8sManager.GetScheme(), nil),
 		ctrl.Log.WithName("statefulset-runner").WithName("AppWorkload"),
 		state.NewAppWorkloadStateCollector(k8sManager.GetClient()),
 	)
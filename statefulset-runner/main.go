@@ -10,6 +10,7 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"strings"
 
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	"code.cloudfoundry.org/korifi/statefulset-runner/controllers/appworkload"
@@ -45,6 +46,7 @@ func main() {
 		metricsAddr          string
 		enableLeaderElection bool
 		probeAddr            string
+		allowedNFSServers    []string
 	)
 
 	flag.StringVar(&metricsAddr, "metrics-bind-address", ":8080", "The address the metric endpoint binds to.")
@@ -52,6 +54,13 @@ func main() {
 	flag.BoolVar(&enableLeaderElection, "leader-elect", false,
 		"Enable leader election for controller manager. "+
 			"Enabling this will ensure there is only one active controller manager.")
+	flag.Func("allowed-nfs-servers", "Comma separated list of the NFS servers service volumes may be mounted from. "+
+		"No NFS volumes are mounted by default.", func(value string) error {
+		if value != "" {
+			allowedNFSServers = strings.Split(value, ",")
+		}
+		return nil
+	})
 	flag.Parse()
 
 	logger, _, err := tools.NewZapLogger(zapcore.InfoLevel)
@@ -82,7 +91,7 @@ func main() {
 		os.Exit(1)
 	}
 
-	if err := setupControllers(mgr); err != nil {
+	if err := setupControllers(mgr, allowedNFSServers); err != nil {
 		setupLog.Error(err, "unable to set up controllers")
 		os.Exit(1)
 	}
@@ -99,13 +108,15 @@ func main() {
 	}
 }
 
-func setupControllers(mgr manager.Manager) error {
+func setupControllers(mgr manager.Manager, allowedNFSServers []string) error {
 	controllersLog := ctrl.Log.WithName("controllers")
 	if err := appworkload.NewAppWorkloadReconciler(
 		mgr.GetClient(),
 		mgr.GetScheme(),
 		appworkload.NewAppWorkloadToStatefulsetConverter(mgr.GetScheme()),
 		appworkload.NewPDBUpdater(mgr.GetClient()),
+		appworkload.NewHPAUpdater(mgr.GetClient()),
+		appworkload.NewVolumesUpdater(mgr.GetClient(), mgr.GetScheme(), allowedNFSServers),
 		controllersLog,
 		state.NewAppWorkloadStateCollector(mgr.GetClient()),
 	).SetupWithManager(mgr); err != nil {
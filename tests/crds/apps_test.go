@@ -10,6 +10,7 @@ import (
 	"code.cloudfoundry.org/korifi/kpack-image-builder/controllers/config"
 	"code.cloudfoundry.org/korifi/tests/helpers"
 	"code.cloudfoundry.org/korifi/tests/helpers/fail_handler"
+	"code.cloudfoundry.org/korifi/tools/image"
 	"code.cloudfoundry.org/korifi/tools/k8s"
 	"code.cloudfoundry.org/korifi/tools/registry"
 	"github.com/google/uuid"
@@ -185,8 +186,11 @@ func uploadAppBits(appGUID, packageGUID string) {
 
 	kpackBuilderConfig := config.Config{}
 	Expect(yaml.Unmarshal([]byte(kpackBuilderConfigMap.Data["config.yaml"]), &kpackBuilderConfig)).To(Succeed())
-	repoCreator := registry.NewRepositoryCreator(kpackBuilderConfig.ContainerRegistryType)
-	Expect(repoCreator.CreateRepository(ctx, fmt.Sprintf("%s%s-packages",
+	repoManager := registry.NewRepositoryManager(kpackBuilderConfig.ContainerRegistryType, image.NewClient(k8sClientSet), image.Creds{
+		Namespace:          kpackBuilderConfig.CFRootNamespace,
+		ServiceAccountName: kpackBuilderConfig.BuilderServiceAccount,
+	})
+	Expect(repoManager.CreateRepository(ctx, fmt.Sprintf("%s%s-packages",
 		kpackBuilderConfig.ContainerRepositoryPrefix,
 		appGUID,
 	))).To(Succeed())
@@ -7,6 +7,7 @@ import (
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2" //lint:ignore ST1001 this is a test file
 	. "github.com/onsi/gomega"    //lint:ignore ST1001 this is a test file
@@ -31,12 +32,24 @@ func (b *BrokerServer) WithResponse(pattern string, response map[string]any, sta
 	respBytes, err := json.Marshal(response)
 	Expect(err).NotTo(HaveOccurred())
 
+	return b.WithRawResponse(pattern, respBytes, statusCode)
+}
+
+func (b *BrokerServer) WithRawResponse(pattern string, respBytes []byte, statusCode int) *BrokerServer {
+	return b.WithDelayedRawResponse(pattern, respBytes, statusCode, 0)
+}
+
+func (b *BrokerServer) WithDelayedRawResponse(pattern string, respBytes []byte, statusCode int, delay time.Duration) *BrokerServer {
 	return b.withHandler(pattern, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		Expect(r.Header).To(HaveKeyWithValue("Content-Type", ConsistOf("application/json")))
 		Expect(r.Header).To(HaveKeyWithValue("X-Broker-Api-Version", ConsistOf("2.17")))
+		time.Sleep(delay)
 		w.WriteHeader(statusCode)
 		_, err := w.Write(respBytes)
-		Expect(err).NotTo(HaveOccurred())
+		if delay == 0 {
+			// the client might have given up on delayed responses
+			Expect(err).NotTo(HaveOccurred())
+		}
 	}))
 }
 
@@ -227,6 +227,18 @@ func PrintKorifiLogs(config *rest.Config, correlationId string, since time.Time)
 			LabelValue: "korifi-statefulset-runner",
 			Since:      tools.PtrTo(metav1.NewTime(since)),
 		},
+		{
+			Namespace:  "korifi",
+			LabelKey:   "app",
+			LabelValue: "korifi-deployment-runner",
+			Since:      tools.PtrTo(metav1.NewTime(since)),
+		},
+		{
+			Namespace:  "korifi",
+			LabelKey:   "app",
+			LabelValue: "korifi-knative-runner",
+			Since:      tools.PtrTo(metav1.NewTime(since)),
+		},
 		{
 			Namespace:  "korifi",
 			LabelKey:   "app",
@@ -206,6 +206,65 @@ func (c Client) Delete(ctx context.Context, creds Creds, imageRef string, tagsTo
 	return err
 }
 
+// Catalog lists the repositories of the given registry host as full
+// repository references. Not all registries implement the catalog API.
+func (c Client) Catalog(ctx context.Context, creds Creds, registry string) ([]string, error) {
+	reg, err := name.NewRegistry(registry)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing registry %s: %w", registry, err)
+	}
+
+	authOpt, err := c.authOpt(ctx, creds)
+	if err != nil {
+		return nil, fmt.Errorf("error creating keychain: %w", err)
+	}
+
+	repositories, err := remote.Catalog(ctx, reg, authOpt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list repositories: %w", err)
+	}
+
+	refs := []string{}
+	for _, repository := range repositories {
+		refs = append(refs, reg.Name()+"/"+repository)
+	}
+
+	return refs, nil
+}
+
+// Tags returns the tags of the given repository along with the digests they
+// point to. Repositories that do not exist have no tags.
+func (c Client) Tags(ctx context.Context, creds Creds, repoRef string) (map[string]string, error) {
+	repo, err := name.NewRepository(repoRef)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing repository reference %s: %w", repoRef, err)
+	}
+
+	authOpt, err := c.authOpt(ctx, creds)
+	if err != nil {
+		return nil, fmt.Errorf("error creating keychain: %w", err)
+	}
+
+	tags, err := remote.List(repo, authOpt, remote.WithContext(ctx))
+	if err != nil {
+		if structuredErr, ok := err.(*transport.Error); ok && structuredErr.StatusCode == http.StatusNotFound {
+			return map[string]string{}, nil
+		}
+		return nil, fmt.Errorf("failed to list tags: %w", err)
+	}
+
+	tagDigests := map[string]string{}
+	for _, tag := range tags {
+		descriptor, err := remote.Head(repo.Tag(tag), authOpt, remote.WithContext(ctx))
+		if err != nil {
+			return nil, fmt.Errorf("couldn't get tag %s: %w", tag, err)
+		}
+		tagDigests[tag] = descriptor.Digest.String()
+	}
+
+	return tagDigests, nil
+}
+
 func (c Client) getTagSet(ref name.Reference, authOpt remote.Option) (map[string]bool, error) {
 	allTags, err := remote.List(ref.Context(), authOpt)
 	if err != nil {
@@ -258,7 +317,37 @@ func (c Client) deleteTag(ref name.Reference, tag string, authOpt remote.Option)
 	return nil
 }
 
+// Authorization resolves the credentials for the given registry host the
+// same way they are resolved when pushing or deleting images
+func (c Client) Authorization(ctx context.Context, creds Creds, registry string) (*authn.AuthConfig, error) {
+	reg, err := name.NewRegistry(registry)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing registry %s: %w", registry, err)
+	}
+
+	keychain, err := c.keychain(ctx, creds)
+	if err != nil {
+		return nil, fmt.Errorf("error creating keychain: %w", err)
+	}
+
+	authenticator, err := keychain.Resolve(reg)
+	if err != nil {
+		return nil, fmt.Errorf("error resolving credentials for %s: %w", registry, err)
+	}
+
+	return authn.Authorization(ctx, authenticator)
+}
+
 func (c Client) authOpt(ctx context.Context, creds Creds) (remote.Option, error) {
+	keychain, err := c.keychain(ctx, creds)
+	if err != nil {
+		return nil, err
+	}
+
+	return remote.WithAuthFromKeychain(keychain), nil
+}
+
+func (c Client) keychain(ctx context.Context, creds Creds) (authn.Keychain, error) {
 	var keychain authn.Keychain
 	var err error
 
@@ -279,5 +368,5 @@ func (c Client) authOpt(ctx context.Context, creds Creds) (remote.Option, error)
 		return nil, err
 	}
 
-	return remote.WithAuthFromKeychain(keychain), nil
+	return keychain, nil
 }
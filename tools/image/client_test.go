@@ -1,10 +1,13 @@
 package image_test
 
 import (
+	"net/url"
 	"os"
+	"strings"
 
 	"code.cloudfoundry.org/korifi/tests/helpers/oci"
 	"code.cloudfoundry.org/korifi/tools/image"
+	"github.com/google/go-containerregistry/pkg/authn"
 	v1 "github.com/google/go-containerregistry/pkg/v1"
 	"github.com/google/uuid"
 	. "github.com/onsi/ginkgo/v2"
@@ -303,6 +306,114 @@ var _ = Describe("Client", func() {
 		})
 	})
 
+	Describe("Authorization", func() {
+		var (
+			registryHost string
+			authConfig   *authn.AuthConfig
+		)
+
+		BeforeEach(func() {
+			serverURL, err := url.Parse(containerRegistry.URL())
+			Expect(err).NotTo(HaveOccurred())
+			registryHost = serverURL.Host
+		})
+
+		JustBeforeEach(func() {
+			authConfig, testErr = imgClient.Authorization(ctx, creds, registryHost)
+		})
+
+		It("resolves the registry credentials from the secrets", func() {
+			Expect(testErr).NotTo(HaveOccurred())
+			Expect(authConfig.Username).To(Equal("user"))
+			Expect(authConfig.Password).To(Equal("password"))
+		})
+
+		When("using a service account for secrets", func() {
+			BeforeEach(func() {
+				creds.SecretNames = nil
+				creds.ServiceAccountName = serviceAccountName
+			})
+
+			It("resolves the registry credentials from the service account secrets", func() {
+				Expect(testErr).NotTo(HaveOccurred())
+				Expect(authConfig.Username).To(Equal("user"))
+			})
+		})
+
+		When("the secret doesn't exist", func() {
+			BeforeEach(func() {
+				creds.SecretNames = []string{"not-a-secret"}
+			})
+
+			It("resolves anonymous credentials", func() {
+				Expect(testErr).NotTo(HaveOccurred())
+				Expect(authConfig.Username).To(BeEmpty())
+				Expect(authConfig.Password).To(BeEmpty())
+			})
+		})
+	})
+
+	Describe("Tags", func() {
+		var tags map[string]string
+
+		BeforeEach(func() {
+			pushRef = containerRegistry.ImageRef("foo/" + uuid.NewString())
+			var err error
+			imgRef, err = imgClient.Push(ctx, creds, pushRef, zipFile, "jim", "bob")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		JustBeforeEach(func() {
+			tags, testErr = imgClient.Tags(ctx, creds, pushRef)
+		})
+
+		It("returns the tags along with the digests they point to", func() {
+			Expect(testErr).NotTo(HaveOccurred())
+			_, digest, _ := strings.Cut(imgRef, "@")
+			Expect(tags).To(Equal(map[string]string{
+				"jim": digest,
+				"bob": digest,
+			}))
+		})
+
+		When("the repository does not exist", func() {
+			BeforeEach(func() {
+				pushRef = containerRegistry.ImageRef("foo/" + uuid.NewString())
+			})
+
+			It("returns no tags", func() {
+				Expect(testErr).NotTo(HaveOccurred())
+				Expect(tags).To(BeEmpty())
+			})
+		})
+	})
+
+	Describe("Catalog", func() {
+		var (
+			registryHost string
+			repositories []string
+		)
+
+		BeforeEach(func() {
+			serverURL, err := url.Parse(containerRegistry.URL())
+			Expect(err).NotTo(HaveOccurred())
+			registryHost = serverURL.Host
+
+			pushRef = containerRegistry.ImageRef("foo/" + uuid.NewString())
+			_, err = imgClient.Push(ctx, creds, pushRef, zipFile)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		JustBeforeEach(func() {
+			repositories, testErr = imgClient.Catalog(ctx, creds, registryHost)
+		})
+
+		It("returns the full references of the registry repositories", func() {
+			Expect(testErr).NotTo(HaveOccurred())
+			Expect(repositories).To(ContainElement(pushRef))
+		})
+	})
+
 	for _, reg := range registries {
 		// 'Serial` because we use the same image for ECR in this test and above
 		// and otherwise they interfere
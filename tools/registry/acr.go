@@ -0,0 +1,45 @@
+package registry
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"code.cloudfoundry.org/korifi/tools/image"
+)
+
+// ACRRepositoryManager manages repositories via the Azure Container Registry
+// data plane API. ACR creates repositories on push, so only deletion requires
+// an API call.
+type ACRRepositoryManager struct {
+	apiClient apiClient
+}
+
+func NewACRRepositoryManager(httpClient *http.Client, authorizer Authorizer, creds image.Creds) ACRRepositoryManager {
+	return ACRRepositoryManager{
+		apiClient: apiClient{
+			httpClient: httpClient,
+			authorizer: authorizer,
+			creds:      creds,
+		},
+	}
+}
+
+func (m ACRRepositoryManager) CreateRepository(_ context.Context, _ string) error {
+	return nil
+}
+
+func (m ACRRepositoryManager) DeleteRepository(ctx context.Context, ref string) error {
+	repo, _, err := parseRepository(ref)
+	if err != nil {
+		return err
+	}
+
+	return m.apiClient.do(ctx,
+		http.MethodDelete,
+		fmt.Sprintf("%s://%s/acr/v1/%s", repo.Registry.Scheme(), repo.RegistryStr(), repo.RepositoryStr()),
+		repo.RegistryStr(),
+		nil,
+		http.StatusAccepted, http.StatusOK, http.StatusNotFound,
+	)
+}
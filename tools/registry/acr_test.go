@@ -0,0 +1,96 @@
+package registry_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"code.cloudfoundry.org/korifi/tools/image"
+	"code.cloudfoundry.org/korifi/tools/registry"
+	"code.cloudfoundry.org/korifi/tools/registry/fake"
+	"github.com/google/go-containerregistry/pkg/authn"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ACR Repository Manager", func() {
+	var (
+		server         *httptest.Server
+		requests       []recordedRequest
+		responseStatus int
+		authorizer     *fake.Authorizer
+		manager        registry.ACRRepositoryManager
+		registryHost   string
+	)
+
+	BeforeEach(func() {
+		requests = []recordedRequest{}
+		responseStatus = http.StatusAccepted
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests = append(requests, recordRequest(r))
+			w.WriteHeader(responseStatus)
+		}))
+		DeferCleanup(server.Close)
+		registryHost = strings.TrimPrefix(server.URL, "http://")
+
+		authorizer = new(fake.Authorizer)
+		authorizer.AuthorizationReturns(&authn.AuthConfig{Username: "sp-id", Password: "sp-secret"}, nil)
+
+		manager = registry.NewACRRepositoryManager(server.Client(), authorizer, image.Creds{})
+	})
+
+	Describe("CreateRepository", func() {
+		It("does not call the API as ACR creates repositories on push", func() {
+			Expect(manager.CreateRepository(context.Background(), registryHost+"/korifi/app-guid-packages")).To(Succeed())
+			Expect(requests).To(BeEmpty())
+		})
+	})
+
+	Describe("DeleteRepository", func() {
+		var deleteErr error
+
+		JustBeforeEach(func() {
+			deleteErr = manager.DeleteRepository(context.Background(), registryHost+"/korifi/app-guid-packages")
+		})
+
+		It("deletes the repository", func() {
+			Expect(deleteErr).NotTo(HaveOccurred())
+			Expect(requests).To(ConsistOf(recordedRequest{
+				Method:        http.MethodDelete,
+				Path:          "/acr/v1/korifi/app-guid-packages",
+				Authorization: basicAuth("sp-id", "sp-secret"),
+			}))
+		})
+
+		When("the credentials are a registry token", func() {
+			BeforeEach(func() {
+				authorizer.AuthorizationReturns(&authn.AuthConfig{RegistryToken: "registry-token"}, nil)
+			})
+
+			It("uses it as bearer token", func() {
+				Expect(requests).To(ConsistOf(HaveField("Authorization", "Bearer registry-token")))
+			})
+		})
+
+		When("the repository does not exist", func() {
+			BeforeEach(func() {
+				responseStatus = http.StatusNotFound
+			})
+
+			It("succeeds", func() {
+				Expect(deleteErr).NotTo(HaveOccurred())
+			})
+		})
+
+		When("the API returns an unexpected status", func() {
+			BeforeEach(func() {
+				responseStatus = http.StatusUnauthorized
+			})
+
+			It("returns an error", func() {
+				Expect(deleteErr).To(MatchError(ContainSubstring("status 401")))
+			})
+		})
+	})
+})
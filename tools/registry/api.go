@@ -0,0 +1,89 @@
+package registry
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+
+	"code.cloudfoundry.org/korifi/tools/image"
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+)
+
+// Token based keychains (e.g. the Google one) return the access token as the
+// password of these well-known user names
+var tokenUserNames = []string{"_token", "oauth2accesstoken"}
+
+type apiClient struct {
+	httpClient *http.Client
+	authorizer Authorizer
+	creds      image.Creds
+}
+
+// do sends a request to the API of the given registry host and fails unless
+// the response status is one of the accepted ones
+func (c apiClient) do(ctx context.Context, method, url, registry string, body any, acceptedStatuses ...int) error {
+	var bodyReader io.Reader
+	if body != nil {
+		bodyBytes, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to marshal request body: %w", err)
+		}
+		bodyReader = bytes.NewReader(bodyBytes)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, bodyReader)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	auth, err := c.authorizer.Authorization(ctx, c.creds, registry)
+	if err != nil {
+		return fmt.Errorf("failed to resolve credentials for %s: %w", registry, err)
+	}
+	setAuthorization(req, auth)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("%s %s failed: %w", method, url, err)
+	}
+	defer resp.Body.Close()
+
+	if !slices.Contains(acceptedStatuses, resp.StatusCode) {
+		respBody, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("%s %s failed with status %d: %s", method, url, resp.StatusCode, string(respBody))
+	}
+
+	return nil
+}
+
+func setAuthorization(req *http.Request, auth *authn.AuthConfig) {
+	switch {
+	case auth.RegistryToken != "":
+		req.Header.Set("Authorization", "Bearer "+auth.RegistryToken)
+	case slices.Contains(tokenUserNames, auth.Username):
+		req.Header.Set("Authorization", "Bearer "+auth.Password)
+	case auth.Username != "" || auth.Password != "":
+		req.SetBasicAuth(auth.Username, auth.Password)
+	case auth.Auth != "":
+		req.Header.Set("Authorization", "Basic "+auth.Auth)
+	}
+}
+
+// parseRepository splits a repository reference into its registry and path
+func parseRepository(ref string) (name.Repository, []string, error) {
+	repo, err := name.NewRepository(ref)
+	if err != nil {
+		return name.Repository{}, nil, fmt.Errorf("error parsing repository reference %s: %w", ref, err)
+	}
+
+	return repo, strings.Split(repo.RepositoryStr(), "/"), nil
+}
@@ -0,0 +1,96 @@
+package registry
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"code.cloudfoundry.org/korifi/tools/image"
+)
+
+const (
+	ArtifactRegistryAPIURL = "https://artifactregistry.googleapis.com"
+
+	artifactRegistryHostSuffix = "-docker.pkg.dev"
+)
+
+// ArtifactRegistryRepositoryManager manages repositories via the Google
+// Artifact Registry API. Image references have the form
+// `<location>-docker.pkg.dev/<project>/<repository>/<image>`. Artifact Registry
+// repositories must exist before pushing, while images (called packages by the
+// API) are created on push.
+type ArtifactRegistryRepositoryManager struct {
+	apiClient apiClient
+	apiURL    string
+}
+
+func NewArtifactRegistryRepositoryManager(httpClient *http.Client, apiURL string, authorizer Authorizer, creds image.Creds) ArtifactRegistryRepositoryManager {
+	return ArtifactRegistryRepositoryManager{
+		apiClient: apiClient{
+			httpClient: httpClient,
+			authorizer: authorizer,
+			creds:      creds,
+		},
+		apiURL: strings.TrimSuffix(apiURL, "/"),
+	}
+}
+
+type artifactRegistryRepository struct {
+	registry string
+	parent   string
+	id       string
+	image    string
+}
+
+func (m ArtifactRegistryRepositoryManager) CreateRepository(ctx context.Context, ref string) error {
+	repo, err := parseArtifactRegistryRepository(ref)
+	if err != nil {
+		return err
+	}
+
+	return m.apiClient.do(ctx,
+		http.MethodPost,
+		fmt.Sprintf("%s/v1/%s/repositories?repositoryId=%s", m.apiURL, repo.parent, url.QueryEscape(repo.id)),
+		repo.registry,
+		map[string]string{
+			"format": "DOCKER",
+		},
+		http.StatusOK, http.StatusConflict,
+	)
+}
+
+func (m ArtifactRegistryRepositoryManager) DeleteRepository(ctx context.Context, ref string) error {
+	repo, err := parseArtifactRegistryRepository(ref)
+	if err != nil {
+		return err
+	}
+
+	return m.apiClient.do(ctx,
+		http.MethodDelete,
+		fmt.Sprintf("%s/v1/%s/repositories/%s/packages/%s", m.apiURL, repo.parent, url.PathEscape(repo.id), url.PathEscape(repo.image)),
+		repo.registry,
+		nil,
+		http.StatusOK, http.StatusNotFound,
+	)
+}
+
+func parseArtifactRegistryRepository(ref string) (artifactRegistryRepository, error) {
+	repo, path, err := parseRepository(ref)
+	if err != nil {
+		return artifactRegistryRepository{}, err
+	}
+
+	location, isArtifactRegistry := strings.CutSuffix(repo.RegistryStr(), artifactRegistryHostSuffix)
+	if !isArtifactRegistry || len(path) < 3 {
+		return artifactRegistryRepository{}, fmt.Errorf("%s is not an artifact registry image reference", ref)
+	}
+
+	return artifactRegistryRepository{
+		registry: repo.RegistryStr(),
+		parent:   fmt.Sprintf("projects/%s/locations/%s", url.PathEscape(path[0]), url.PathEscape(location)),
+		id:       path[1],
+		image:    strings.Join(path[2:], "/"),
+	}, nil
+}
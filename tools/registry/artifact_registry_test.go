@@ -0,0 +1,136 @@
+package registry_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+
+	"code.cloudfoundry.org/korifi/tools/image"
+	"code.cloudfoundry.org/korifi/tools/registry"
+	"code.cloudfoundry.org/korifi/tools/registry/fake"
+	"github.com/google/go-containerregistry/pkg/authn"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Artifact Registry Repository Manager", func() {
+	var (
+		server         *httptest.Server
+		requests       []recordedRequest
+		responseStatus int
+		authorizer     *fake.Authorizer
+		manager        registry.ArtifactRegistryRepositoryManager
+		repositoryRef  string
+	)
+
+	BeforeEach(func() {
+		requests = []recordedRequest{}
+		responseStatus = http.StatusOK
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests = append(requests, recordRequest(r))
+			w.WriteHeader(responseStatus)
+		}))
+		DeferCleanup(server.Close)
+
+		authorizer = new(fake.Authorizer)
+		authorizer.AuthorizationReturns(&authn.AuthConfig{Username: "_token", Password: "access-token"}, nil)
+
+		manager = registry.NewArtifactRegistryRepositoryManager(server.Client(), server.URL, authorizer, image.Creds{})
+		repositoryRef = "europe-docker.pkg.dev/my-project/korifi/foo/app-guid-packages"
+	})
+
+	Describe("CreateRepository", func() {
+		var createErr error
+
+		JustBeforeEach(func() {
+			createErr = manager.CreateRepository(context.Background(), repositoryRef)
+		})
+
+		It("creates the artifact registry repository", func() {
+			Expect(createErr).NotTo(HaveOccurred())
+			Expect(requests).To(ConsistOf(recordedRequest{
+				Method:        http.MethodPost,
+				Path:          "/v1/projects/my-project/locations/europe/repositories",
+				Query:         "repositoryId=korifi",
+				Authorization: "Bearer access-token",
+				Body:          `{"format":"DOCKER"}`,
+			}))
+		})
+
+		It("resolves the credentials for the registry host", func() {
+			Expect(authorizer.AuthorizationCallCount()).To(Equal(1))
+			_, _, actualRegistry := authorizer.AuthorizationArgsForCall(0)
+			Expect(actualRegistry).To(Equal("europe-docker.pkg.dev"))
+		})
+
+		When("the repository already exists", func() {
+			BeforeEach(func() {
+				responseStatus = http.StatusConflict
+			})
+
+			It("succeeds", func() {
+				Expect(createErr).NotTo(HaveOccurred())
+			})
+		})
+
+		When("the reference is not an artifact registry one", func() {
+			BeforeEach(func() {
+				repositoryRef = "gcr.io/my-project/app-guid-packages"
+			})
+
+			It("returns an error", func() {
+				Expect(createErr).To(MatchError(ContainSubstring("not an artifact registry image reference")))
+				Expect(requests).To(BeEmpty())
+			})
+		})
+
+		When("resolving the credentials fails", func() {
+			BeforeEach(func() {
+				authorizer.AuthorizationReturns(nil, errors.New("auth-err"))
+			})
+
+			It("returns an error", func() {
+				Expect(createErr).To(MatchError(ContainSubstring("auth-err")))
+				Expect(requests).To(BeEmpty())
+			})
+		})
+	})
+
+	Describe("DeleteRepository", func() {
+		var deleteErr error
+
+		JustBeforeEach(func() {
+			deleteErr = manager.DeleteRepository(context.Background(), repositoryRef)
+		})
+
+		It("deletes the image package", func() {
+			Expect(deleteErr).NotTo(HaveOccurred())
+			Expect(requests).To(ConsistOf(recordedRequest{
+				Method:        http.MethodDelete,
+				Path:          "/v1/projects/my-project/locations/europe/repositories/korifi/packages/foo%2Fapp-guid-packages",
+				Authorization: "Bearer access-token",
+			}))
+		})
+
+		When("the package does not exist", func() {
+			BeforeEach(func() {
+				responseStatus = http.StatusNotFound
+			})
+
+			It("succeeds", func() {
+				Expect(deleteErr).NotTo(HaveOccurred())
+			})
+		})
+
+		When("the API returns an unexpected status", func() {
+			BeforeEach(func() {
+				responseStatus = http.StatusForbidden
+			})
+
+			It("returns an error", func() {
+				Expect(deleteErr).To(MatchError(ContainSubstring("status 403")))
+			})
+		})
+	})
+})
@@ -0,0 +1,123 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fake
+
+import (
+	"context"
+	"sync"
+
+	"code.cloudfoundry.org/korifi/tools/image"
+	"code.cloudfoundry.org/korifi/tools/registry"
+	"github.com/google/go-containerregistry/pkg/authn"
+)
+
+type Authorizer struct {
+	AuthorizationStub        func(context.Context, image.Creds, string) (*authn.AuthConfig, error)
+	authorizationMutex       sync.RWMutex
+	authorizationArgsForCall []struct {
+		arg1 context.Context
+		arg2 image.Creds
+		arg3 string
+	}
+	authorizationReturns struct {
+		result1 *authn.AuthConfig
+		result2 error
+	}
+	authorizationReturnsOnCall map[int]struct {
+		result1 *authn.AuthConfig
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *Authorizer) Authorization(arg1 context.Context, arg2 image.Creds, arg3 string) (*authn.AuthConfig, error) {
+	fake.authorizationMutex.Lock()
+	ret, specificReturn := fake.authorizationReturnsOnCall[len(fake.authorizationArgsForCall)]
+	fake.authorizationArgsForCall = append(fake.authorizationArgsForCall, struct {
+		arg1 context.Context
+		arg2 image.Creds
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.AuthorizationStub
+	fakeReturns := fake.authorizationReturns
+	fake.recordInvocation("Authorization", []interface{}{arg1, arg2, arg3})
+	fake.authorizationMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Authorizer) AuthorizationCallCount() int {
+	fake.authorizationMutex.RLock()
+	defer fake.authorizationMutex.RUnlock()
+	return len(fake.authorizationArgsForCall)
+}
+
+func (fake *Authorizer) AuthorizationCalls(stub func(context.Context, image.Creds, string) (*authn.AuthConfig, error)) {
+	fake.authorizationMutex.Lock()
+	defer fake.authorizationMutex.Unlock()
+	fake.AuthorizationStub = stub
+}
+
+func (fake *Authorizer) AuthorizationArgsForCall(i int) (context.Context, image.Creds, string) {
+	fake.authorizationMutex.RLock()
+	defer fake.authorizationMutex.RUnlock()
+	argsForCall := fake.authorizationArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *Authorizer) AuthorizationReturns(result1 *authn.AuthConfig, result2 error) {
+	fake.authorizationMutex.Lock()
+	defer fake.authorizationMutex.Unlock()
+	fake.AuthorizationStub = nil
+	fake.authorizationReturns = struct {
+		result1 *authn.AuthConfig
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Authorizer) AuthorizationReturnsOnCall(i int, result1 *authn.AuthConfig, result2 error) {
+	fake.authorizationMutex.Lock()
+	defer fake.authorizationMutex.Unlock()
+	fake.AuthorizationStub = nil
+	if fake.authorizationReturnsOnCall == nil {
+		fake.authorizationReturnsOnCall = make(map[int]struct {
+			result1 *authn.AuthConfig
+			result2 error
+		})
+	}
+	fake.authorizationReturnsOnCall[i] = struct {
+		result1 *authn.AuthConfig
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Authorizer) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.authorizationMutex.RLock()
+	defer fake.authorizationMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *Authorizer) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ registry.Authorizer = new(Authorizer)
@@ -25,6 +25,21 @@ type ECRClient struct {
 		result1 *ecr.CreateRepositoryOutput
 		result2 error
 	}
+	DeleteRepositoryStub        func(context.Context, *ecr.DeleteRepositoryInput, ...func(*ecr.Options)) (*ecr.DeleteRepositoryOutput, error)
+	deleteRepositoryMutex       sync.RWMutex
+	deleteRepositoryArgsForCall []struct {
+		arg1 context.Context
+		arg2 *ecr.DeleteRepositoryInput
+		arg3 []func(*ecr.Options)
+	}
+	deleteRepositoryReturns struct {
+		result1 *ecr.DeleteRepositoryOutput
+		result2 error
+	}
+	deleteRepositoryReturnsOnCall map[int]struct {
+		result1 *ecr.DeleteRepositoryOutput
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -95,11 +110,79 @@ func (fake *ECRClient) CreateRepositoryReturnsOnCall(i int, result1 *ecr.CreateR
 	}{result1, result2}
 }
 
+func (fake *ECRClient) DeleteRepository(arg1 context.Context, arg2 *ecr.DeleteRepositoryInput, arg3 ...func(*ecr.Options)) (*ecr.DeleteRepositoryOutput, error) {
+	fake.deleteRepositoryMutex.Lock()
+	ret, specificReturn := fake.deleteRepositoryReturnsOnCall[len(fake.deleteRepositoryArgsForCall)]
+	fake.deleteRepositoryArgsForCall = append(fake.deleteRepositoryArgsForCall, struct {
+		arg1 context.Context
+		arg2 *ecr.DeleteRepositoryInput
+		arg3 []func(*ecr.Options)
+	}{arg1, arg2, arg3})
+	stub := fake.DeleteRepositoryStub
+	fakeReturns := fake.deleteRepositoryReturns
+	fake.recordInvocation("DeleteRepository", []interface{}{arg1, arg2, arg3})
+	fake.deleteRepositoryMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3...)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *ECRClient) DeleteRepositoryCallCount() int {
+	fake.deleteRepositoryMutex.RLock()
+	defer fake.deleteRepositoryMutex.RUnlock()
+	return len(fake.deleteRepositoryArgsForCall)
+}
+
+func (fake *ECRClient) DeleteRepositoryCalls(stub func(context.Context, *ecr.DeleteRepositoryInput, ...func(*ecr.Options)) (*ecr.DeleteRepositoryOutput, error)) {
+	fake.deleteRepositoryMutex.Lock()
+	defer fake.deleteRepositoryMutex.Unlock()
+	fake.DeleteRepositoryStub = stub
+}
+
+func (fake *ECRClient) DeleteRepositoryArgsForCall(i int) (context.Context, *ecr.DeleteRepositoryInput, []func(*ecr.Options)) {
+	fake.deleteRepositoryMutex.RLock()
+	defer fake.deleteRepositoryMutex.RUnlock()
+	argsForCall := fake.deleteRepositoryArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *ECRClient) DeleteRepositoryReturns(result1 *ecr.DeleteRepositoryOutput, result2 error) {
+	fake.deleteRepositoryMutex.Lock()
+	defer fake.deleteRepositoryMutex.Unlock()
+	fake.DeleteRepositoryStub = nil
+	fake.deleteRepositoryReturns = struct {
+		result1 *ecr.DeleteRepositoryOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ECRClient) DeleteRepositoryReturnsOnCall(i int, result1 *ecr.DeleteRepositoryOutput, result2 error) {
+	fake.deleteRepositoryMutex.Lock()
+	defer fake.deleteRepositoryMutex.Unlock()
+	fake.DeleteRepositoryStub = nil
+	if fake.deleteRepositoryReturnsOnCall == nil {
+		fake.deleteRepositoryReturnsOnCall = make(map[int]struct {
+			result1 *ecr.DeleteRepositoryOutput
+			result2 error
+		})
+	}
+	fake.deleteRepositoryReturnsOnCall[i] = struct {
+		result1 *ecr.DeleteRepositoryOutput
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *ECRClient) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
 	fake.createRepositoryMutex.RLock()
 	defer fake.createRepositoryMutex.RUnlock()
+	fake.deleteRepositoryMutex.RLock()
+	defer fake.deleteRepositoryMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
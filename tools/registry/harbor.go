@@ -0,0 +1,74 @@
+package registry
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"code.cloudfoundry.org/korifi/tools/image"
+)
+
+// HarborRepositoryManager manages repositories via the Harbor v2 API. Harbor
+// creates repositories on push, but only within existing projects, so creating
+// a repository ensures that its project (the first path segment) exists.
+type HarborRepositoryManager struct {
+	apiClient apiClient
+}
+
+func NewHarborRepositoryManager(httpClient *http.Client, authorizer Authorizer, creds image.Creds) HarborRepositoryManager {
+	return HarborRepositoryManager{
+		apiClient: apiClient{
+			httpClient: httpClient,
+			authorizer: authorizer,
+			creds:      creds,
+		},
+	}
+}
+
+func (m HarborRepositoryManager) CreateRepository(ctx context.Context, ref string) error {
+	repo, path, err := parseRepository(ref)
+	if err != nil {
+		return err
+	}
+
+	return m.apiClient.do(ctx,
+		http.MethodPost,
+		harborAPIURL(repo.Registry.Scheme(), repo.RegistryStr(), "projects"),
+		repo.RegistryStr(),
+		map[string]any{
+			"project_name": path[0],
+			"metadata": map[string]string{
+				"public": "false",
+			},
+		},
+		http.StatusCreated, http.StatusConflict,
+	)
+}
+
+func (m HarborRepositoryManager) DeleteRepository(ctx context.Context, ref string) error {
+	repo, path, err := parseRepository(ref)
+	if err != nil {
+		return err
+	}
+
+	if len(path) < 2 {
+		return fmt.Errorf("harbor repository %s is not within a project", ref)
+	}
+
+	// Harbor expects slashes in repository names to be double encoded
+	repoName := url.PathEscape(url.PathEscape(strings.Join(path[1:], "/")))
+
+	return m.apiClient.do(ctx,
+		http.MethodDelete,
+		harborAPIURL(repo.Registry.Scheme(), repo.RegistryStr(), "projects", url.PathEscape(path[0]), "repositories", repoName),
+		repo.RegistryStr(),
+		nil,
+		http.StatusOK, http.StatusNotFound,
+	)
+}
+
+func harborAPIURL(scheme, host string, pathSegments ...string) string {
+	return fmt.Sprintf("%s://%s/api/v2.0/%s", scheme, host, strings.Join(pathSegments, "/"))
+}
@@ -0,0 +1,164 @@
+package registry_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"code.cloudfoundry.org/korifi/tools/image"
+	"code.cloudfoundry.org/korifi/tools/registry"
+	"code.cloudfoundry.org/korifi/tools/registry/fake"
+	"github.com/google/go-containerregistry/pkg/authn"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Harbor Repository Manager", func() {
+	var (
+		server         *httptest.Server
+		requests       []recordedRequest
+		responseStatus int
+		authorizer     *fake.Authorizer
+		manager        registry.HarborRepositoryManager
+		registryHost   string
+	)
+
+	BeforeEach(func() {
+		requests = []recordedRequest{}
+		responseStatus = http.StatusOK
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests = append(requests, recordRequest(r))
+			w.WriteHeader(responseStatus)
+		}))
+		DeferCleanup(server.Close)
+		registryHost = strings.TrimPrefix(server.URL, "http://")
+
+		authorizer = new(fake.Authorizer)
+		authorizer.AuthorizationReturns(&authn.AuthConfig{Username: "user", Password: "pass"}, nil)
+
+		manager = registry.NewHarborRepositoryManager(server.Client(), authorizer, image.Creds{
+			Namespace:   "cf",
+			SecretNames: []string{"registry-secret"},
+		})
+	})
+
+	Describe("CreateRepository", func() {
+		var createErr error
+
+		BeforeEach(func() {
+			responseStatus = http.StatusCreated
+		})
+
+		JustBeforeEach(func() {
+			createErr = manager.CreateRepository(context.Background(), registryHost+"/korifi/foo/app-guid-packages")
+		})
+
+		It("creates the harbor project", func() {
+			Expect(createErr).NotTo(HaveOccurred())
+			Expect(requests).To(ConsistOf(recordedRequest{
+				Method:        http.MethodPost,
+				Path:          "/api/v2.0/projects",
+				Authorization: basicAuth("user", "pass"),
+				Body:          `{"metadata":{"public":"false"},"project_name":"korifi"}`,
+			}))
+		})
+
+		It("resolves the credentials for the registry", func() {
+			Expect(authorizer.AuthorizationCallCount()).To(Equal(1))
+			_, actualCreds, actualRegistry := authorizer.AuthorizationArgsForCall(0)
+			Expect(actualCreds).To(Equal(image.Creds{
+				Namespace:   "cf",
+				SecretNames: []string{"registry-secret"},
+			}))
+			Expect(actualRegistry).To(Equal(registryHost))
+		})
+
+		When("the project already exists", func() {
+			BeforeEach(func() {
+				responseStatus = http.StatusConflict
+			})
+
+			It("succeeds", func() {
+				Expect(createErr).NotTo(HaveOccurred())
+			})
+		})
+
+		When("the API returns an unexpected status", func() {
+			BeforeEach(func() {
+				responseStatus = http.StatusForbidden
+			})
+
+			It("returns an error", func() {
+				Expect(createErr).To(MatchError(ContainSubstring("status 403")))
+			})
+		})
+	})
+
+	Describe("DeleteRepository", func() {
+		var deleteErr error
+
+		JustBeforeEach(func() {
+			deleteErr = manager.DeleteRepository(context.Background(), registryHost+"/korifi/foo/app-guid-packages")
+		})
+
+		It("deletes the repository within the project", func() {
+			Expect(deleteErr).NotTo(HaveOccurred())
+			Expect(requests).To(ConsistOf(recordedRequest{
+				Method:        http.MethodDelete,
+				Path:          "/api/v2.0/projects/korifi/repositories/foo%252Fapp-guid-packages",
+				Authorization: basicAuth("user", "pass"),
+			}))
+		})
+
+		When("the repository does not exist", func() {
+			BeforeEach(func() {
+				responseStatus = http.StatusNotFound
+			})
+
+			It("succeeds", func() {
+				Expect(deleteErr).NotTo(HaveOccurred())
+			})
+		})
+
+		When("the API returns an unexpected status", func() {
+			BeforeEach(func() {
+				responseStatus = http.StatusInternalServerError
+			})
+
+			It("returns an error", func() {
+				Expect(deleteErr).To(MatchError(ContainSubstring("status 500")))
+			})
+		})
+	})
+})
+
+type recordedRequest struct {
+	Method        string
+	Path          string
+	Query         string
+	Authorization string
+	Body          string
+}
+
+func recordRequest(r *http.Request) recordedRequest {
+	body, err := io.ReadAll(r.Body)
+	Expect(err).NotTo(HaveOccurred())
+
+	return recordedRequest{
+		Method:        r.Method,
+		Path:          r.URL.EscapedPath(),
+		Query:         r.URL.RawQuery,
+		Authorization: r.Header.Get("Authorization"),
+		Body:          string(body),
+	}
+}
+
+func basicAuth(username, password string) string {
+	req, err := http.NewRequest(http.MethodGet, "/", nil)
+	Expect(err).NotTo(HaveOccurred())
+	req.SetBasicAuth(username, password)
+
+	return req.Header.Get("Authorization")
+}
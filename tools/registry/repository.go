@@ -3,26 +3,48 @@ package registry
 import (
 	"context"
 	"errors"
+	"net/http"
 	"os"
 	"strings"
 
 	"code.cloudfoundry.org/korifi/tools"
+	"code.cloudfoundry.org/korifi/tools/image"
 	awsconfig "github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/service/ecr"
 	"github.com/aws/aws-sdk-go-v2/service/ecr/types"
+	"github.com/google/go-containerregistry/pkg/authn"
 	ctrl "sigs.k8s.io/controller-runtime"
 )
 
-const ECRContainerRegistryType = "ECR"
+const (
+	ECRContainerRegistryType              = "ECR"
+	HarborContainerRegistryType           = "Harbor"
+	ArtifactRegistryContainerRegistryType = "ArtifactRegistry"
+	ACRContainerRegistryType              = "ACR"
+)
 
 //counterfeiter:generate -o fake -fake-name ECRClient . ECRClient
 
 type ECRClient interface {
 	CreateRepository(ctx context.Context, params *ecr.CreateRepositoryInput, optFns ...func(*ecr.Options)) (*ecr.CreateRepositoryOutput, error)
+	DeleteRepository(ctx context.Context, params *ecr.DeleteRepositoryInput, optFns ...func(*ecr.Options)) (*ecr.DeleteRepositoryOutput, error)
 }
 
-type RepositoryCreator interface {
+//counterfeiter:generate -o fake -fake-name Authorizer . Authorizer
+
+// Authorizer resolves the credentials used to call the registry APIs. It is
+// implemented by image.Client, so that repositories are managed with the same
+// credentials images are pushed with.
+type Authorizer interface {
+	Authorization(ctx context.Context, creds image.Creds, registry string) (*authn.AuthConfig, error)
+}
+
+// RepositoryManager creates the repositories app images are pushed to and
+// deletes them once the app is gone. Repositories are identified by their
+// full reference, e.g. `my.registry/foo/bar/<appGUID>-packages`.
+type RepositoryManager interface {
 	CreateRepository(ctx context.Context, name string) error
+	DeleteRepository(ctx context.Context, name string) error
 }
 
 func createECRClient() *ecr.Client {
@@ -35,28 +57,35 @@ func createECRClient() *ecr.Client {
 	return ecr.NewFromConfig(awsConfig)
 }
 
-func NewRepositoryCreator(registryType string) RepositoryCreator {
-	if registryType == ECRContainerRegistryType {
-		return NewECRRepositoryCreator(createECRClient())
+func NewRepositoryManager(registryType string, authorizer Authorizer, creds image.Creds) RepositoryManager {
+	switch registryType {
+	case ECRContainerRegistryType:
+		return NewECRRepositoryManager(createECRClient())
+	case HarborContainerRegistryType:
+		return NewHarborRepositoryManager(http.DefaultClient, authorizer, creds)
+	case ArtifactRegistryContainerRegistryType:
+		return NewArtifactRegistryRepositoryManager(http.DefaultClient, ArtifactRegistryAPIURL, authorizer, creds)
+	case ACRContainerRegistryType:
+		return NewACRRepositoryManager(http.DefaultClient, authorizer, creds)
 	}
 
-	return NoopRepositoryCreator{}
+	return NoopRepositoryManager{}
 }
 
-type ECRRepositoryCreator struct {
+type ECRRepositoryManager struct {
 	ecrClient ECRClient
 }
 
-func NewECRRepositoryCreator(ecrClient ECRClient) ECRRepositoryCreator {
-	return ECRRepositoryCreator{
+func NewECRRepositoryManager(ecrClient ECRClient) ECRRepositoryManager {
+	return ECRRepositoryManager{
 		ecrClient: ecrClient,
 	}
 }
 
-func (c ECRRepositoryCreator) CreateRepository(ctx context.Context, ref string) error {
+func (m ECRRepositoryManager) CreateRepository(ctx context.Context, ref string) error {
 	_, path, _ := strings.Cut(ref, "/")
 
-	_, err := c.ecrClient.CreateRepository(ctx, &ecr.CreateRepositoryInput{
+	_, err := m.ecrClient.CreateRepository(ctx, &ecr.CreateRepositoryInput{
 		RepositoryName: tools.PtrTo(path),
 	})
 	if err != nil {
@@ -69,8 +98,29 @@ func (c ECRRepositoryCreator) CreateRepository(ctx context.Context, ref string)
 	return err
 }
 
-type NoopRepositoryCreator struct{}
+func (m ECRRepositoryManager) DeleteRepository(ctx context.Context, ref string) error {
+	_, path, _ := strings.Cut(ref, "/")
+
+	_, err := m.ecrClient.DeleteRepository(ctx, &ecr.DeleteRepositoryInput{
+		RepositoryName: tools.PtrTo(path),
+		Force:          true,
+	})
+	if err != nil {
+		var notFound *types.RepositoryNotFoundException
+		if errors.As(err, &notFound) {
+			return nil
+		}
+	}
+
+	return err
+}
+
+type NoopRepositoryManager struct{}
+
+func (m NoopRepositoryManager) CreateRepository(_ context.Context, _ string) error {
+	return nil
+}
 
-func (c NoopRepositoryCreator) CreateRepository(_ context.Context, _ string) error {
+func (m NoopRepositoryManager) DeleteRepository(_ context.Context, _ string) error {
 	return nil
 }
@@ -14,11 +14,10 @@ import (
 	"github.com/onsi/gomega/gstruct"
 )
 
-var _ = Describe("ECR Repository Creator", func() {
+var _ = Describe("ECR Repository Manager", func() {
 	var (
 		ecrClient *fake.ECRClient
-		creator   registry.RepositoryCreator
-		createErr error
+		manager   registry.RepositoryManager
 	)
 
 	BeforeEach(func() {
@@ -28,32 +27,84 @@ var _ = Describe("ECR Repository Creator", func() {
 				RepositoryUri: tools.PtrTo("repo-uri"),
 			},
 		}, nil)
-		creator = registry.NewECRRepositoryCreator(ecrClient)
+		manager = registry.NewECRRepositoryManager(ecrClient)
 	})
 
-	JustBeforeEach(func() {
-		createErr = creator.CreateRepository(context.Background(), "my.registry/my-repo")
-	})
+	Describe("CreateRepository", func() {
+		var createErr error
 
-	It("succeeds", func() {
-		Expect(createErr).NotTo(HaveOccurred())
-	})
+		JustBeforeEach(func() {
+			createErr = manager.CreateRepository(context.Background(), "my.registry/my-repo")
+		})
+
+		It("succeeds", func() {
+			Expect(createErr).NotTo(HaveOccurred())
+		})
+
+		It("creates the repo", func() {
+			Expect(ecrClient.CreateRepositoryCallCount()).To(Equal(1))
+			_, actualCreateInput, _ := ecrClient.CreateRepositoryArgsForCall(0)
+			Expect(actualCreateInput).To(gstruct.PointTo(Equal(ecr.CreateRepositoryInput{
+				RepositoryName: tools.PtrTo("my-repo"),
+			})))
+		})
+
+		When("registry creation fails", func() {
+			BeforeEach(func() {
+				ecrClient.CreateRepositoryReturns(nil, errors.New("registry create err"))
+			})
+
+			It("returns an error", func() {
+				Expect(createErr).To(MatchError("registry create err"))
+			})
+		})
 
-	It("creates the repo", func() {
-		Expect(ecrClient.CreateRepositoryCallCount()).To(Equal(1))
-		_, actualCreateInput, _ := ecrClient.CreateRepositoryArgsForCall(0)
-		Expect(actualCreateInput).To(gstruct.PointTo(Equal(ecr.CreateRepositoryInput{
-			RepositoryName: tools.PtrTo("my-repo"),
-		})))
+		When("the repository already exists", func() {
+			BeforeEach(func() {
+				ecrClient.CreateRepositoryReturns(nil, &types.RepositoryAlreadyExistsException{})
+			})
+
+			It("succeeds", func() {
+				Expect(createErr).NotTo(HaveOccurred())
+			})
+		})
 	})
 
-	When("registry creation fails", func() {
-		BeforeEach(func() {
-			ecrClient.CreateRepositoryReturns(nil, errors.New("registry create err"))
+	Describe("DeleteRepository", func() {
+		var deleteErr error
+
+		JustBeforeEach(func() {
+			deleteErr = manager.DeleteRepository(context.Background(), "my.registry/my-repo")
+		})
+
+		It("deletes the repo along with its images", func() {
+			Expect(deleteErr).NotTo(HaveOccurred())
+			Expect(ecrClient.DeleteRepositoryCallCount()).To(Equal(1))
+			_, actualDeleteInput, _ := ecrClient.DeleteRepositoryArgsForCall(0)
+			Expect(actualDeleteInput).To(gstruct.PointTo(Equal(ecr.DeleteRepositoryInput{
+				RepositoryName: tools.PtrTo("my-repo"),
+				Force:          true,
+			})))
 		})
 
-		It("returns an error", func() {
-			Expect(createErr).To(MatchError("registry create err"))
+		When("the repository does not exist", func() {
+			BeforeEach(func() {
+				ecrClient.DeleteRepositoryReturns(nil, &types.RepositoryNotFoundException{})
+			})
+
+			It("succeeds", func() {
+				Expect(deleteErr).NotTo(HaveOccurred())
+			})
+		})
+
+		When("deleting the repository fails", func() {
+			BeforeEach(func() {
+				ecrClient.DeleteRepositoryReturns(nil, errors.New("registry delete err"))
+			})
+
+			It("returns an error", func() {
+				Expect(deleteErr).To(MatchError("registry delete err"))
+			})
 		})
 	})
 })
@@ -0,0 +1,33 @@
+// Package runner holds the pieces that the deployment and knative runners
+// share: the RunnerInfo controller and the indexing of the pods they run.
+// Neither Deployments nor Knative Services number their pods, so both
+// runners assign the ordinals that statefulset pods get for free.
+package runner
+
+import (
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	LabelGUID = "korifi.cloudfoundry.org/guid"
+
+	// AnnotationInstanceIndex holds the ordinal the runner assigns to each
+	// pod. The runner keeps the ordinals stable for the lifetime of each pod.
+	AnnotationInstanceIndex = "korifi.cloudfoundry.org/instance-index"
+
+	// PodDeletionCostAnnotation tells ReplicaSets which pods to remove first
+	// when scaling down
+	PodDeletionCostAnnotation = "controller.kubernetes.io/pod-deletion-cost"
+)
+
+// PodIndex returns the ordinal the runner assigned to the pod, if any
+func PodIndex(pod corev1.Pod) (int, bool) {
+	index, err := strconv.Atoi(pod.Annotations[AnnotationInstanceIndex])
+	if err != nil || index < 0 {
+		return 0, false
+	}
+
+	return index, true
+}
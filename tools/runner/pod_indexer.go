@@ -0,0 +1,113 @@
+package runner
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools/k8s"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// PodIndexer gives the pods of a workload the ordinals that statefulset
+// pods get for free. Pods are indexed per ReplicaSet, so that the pods of a
+// rollout get the indexes 0 to N-1 while the pods they replace are still
+// running, just like CF instances during a rolling deployment. A pod keeps
+// its index for its whole life, unless the index is no longer in the 0 to
+// N-1 range of the live pods of its ReplicaSet. New pods get the lowest index
+// that no other live pod of the ReplicaSet holds.
+type PodIndexer struct {
+	client client.Client
+}
+
+func NewPodIndexer(client client.Client) *PodIndexer {
+	return &PodIndexer{
+		client: client,
+	}
+}
+
+func (i *PodIndexer) AssignIndexes(ctx context.Context, appWorkloadGUID string) error {
+	workloadPods := &corev1.PodList{}
+	err := i.client.List(ctx, workloadPods, client.MatchingLabels{
+		LabelGUID: appWorkloadGUID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list pods for workload %q: %w", appWorkloadGUID, err)
+	}
+
+	pods := slices.DeleteFunc(workloadPods.Items, func(pod corev1.Pod) bool {
+		return !pod.DeletionTimestamp.IsZero()
+	})
+	slices.SortStableFunc(pods, func(a, b corev1.Pod) int {
+		return a.CreationTimestamp.Compare(b.CreationTimestamp.Time)
+	})
+
+	replicaSetPods := map[string][]corev1.Pod{}
+	for _, pod := range pods {
+		hash := pod.Labels[appsv1.DefaultDeploymentUniqueLabelKey]
+		replicaSetPods[hash] = append(replicaSetPods[hash], pod)
+	}
+
+	for _, hash := range slices.Sorted(maps.Keys(replicaSetPods)) {
+		if err = i.assignReplicaSetIndexes(ctx, replicaSetPods[hash]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (i *PodIndexer) assignReplicaSetIndexes(ctx context.Context, pods []corev1.Pod) error {
+	takenIndexes := map[int]bool{}
+	var unindexedPods []corev1.Pod
+	for _, pod := range pods {
+		index, ok := PodIndex(pod)
+		if !ok || index >= len(pods) || takenIndexes[index] {
+			unindexedPods = append(unindexedPods, pod)
+			continue
+		}
+		takenIndexes[index] = true
+	}
+
+	nextIndex := 0
+	for _, pod := range unindexedPods {
+		for takenIndexes[nextIndex] {
+			nextIndex++
+		}
+		takenIndexes[nextIndex] = true
+
+		if err := i.setIndex(ctx, &pod, nextIndex); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (i *PodIndexer) setIndex(ctx context.Context, pod *corev1.Pod, index int) error {
+	err := k8s.PatchResource(ctx, i.client, pod, func() {
+		if pod.Annotations == nil {
+			pod.Annotations = map[string]string{}
+		}
+		pod.Annotations[AnnotationInstanceIndex] = strconv.Itoa(index)
+		// scaling down removes the pods with the highest indexes first, so
+		// that the remaining indexes stay in the 0 to N-1 range
+		pod.Annotations[PodDeletionCostAnnotation] = strconv.Itoa(-index)
+
+		// the api reads instance indexes from the statefulset pod index label
+		if pod.Labels == nil {
+			pod.Labels = map[string]string{}
+		}
+		pod.Labels[korifiv1alpha1.PodIndexLabelKey] = strconv.Itoa(index)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to set the index of pod %q: %w", pod.Name, err)
+	}
+
+	return nil
+}
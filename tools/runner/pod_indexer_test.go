@@ -0,0 +1,228 @@
+package runner_test
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools/runner"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("PodIndexer", func() {
+	var (
+		indexer   *runner.PodIndexer
+		pods      []corev1.Pod
+		assignErr error
+	)
+
+	newReplicaSetPod := func(replicaSetHash string, name string, age time.Duration, index string) corev1.Pod {
+		pod := corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				Namespace:         "namespace",
+				CreationTimestamp: metav1.NewTime(time.Now().Add(-age)),
+				Labels:            map[string]string{appsv1.DefaultDeploymentUniqueLabelKey: replicaSetHash},
+			},
+		}
+		if index != "" {
+			pod.Annotations = map[string]string{runner.AnnotationInstanceIndex: index}
+		}
+
+		return pod
+	}
+
+	newPod := func(name string, age time.Duration, index string) corev1.Pod {
+		return newReplicaSetPod("hash", name, age, index)
+	}
+
+	patchedIndexes := func() map[string]string {
+		indexes := map[string]string{}
+		for i := range fakeClient.PatchCallCount() {
+			_, obj, _, _ := fakeClient.PatchArgsForCall(i)
+			pod := obj.(*corev1.Pod)
+			Expect(pod.Labels).To(HaveKeyWithValue(korifiv1alpha1.PodIndexLabelKey, pod.Annotations[runner.AnnotationInstanceIndex]))
+			index, err := strconv.Atoi(pod.Annotations[runner.AnnotationInstanceIndex])
+			Expect(err).NotTo(HaveOccurred())
+			Expect(pod.Annotations).To(HaveKeyWithValue(runner.PodDeletionCostAnnotation, strconv.Itoa(-index)))
+			indexes[pod.Name] = pod.Annotations[runner.AnnotationInstanceIndex]
+		}
+
+		return indexes
+	}
+
+	BeforeEach(func() {
+		indexer = runner.NewPodIndexer(fakeClient)
+		pods = nil
+
+		fakeClient.ListStub = func(_ context.Context, list client.ObjectList, _ ...client.ListOption) error {
+			podList, ok := list.(*corev1.PodList)
+			Expect(ok).To(BeTrue())
+			podList.Items = pods
+			return nil
+		}
+	})
+
+	JustBeforeEach(func() {
+		assignErr = indexer.AssignIndexes(context.Background(), "workload-guid")
+	})
+
+	It("lists the pods of the workload", func() {
+		Expect(assignErr).NotTo(HaveOccurred())
+		Expect(fakeClient.ListCallCount()).To(Equal(1))
+		_, _, listOpts := fakeClient.ListArgsForCall(0)
+		Expect(listOpts).To(ConsistOf(client.MatchingLabels{runner.LabelGUID: "workload-guid"}))
+	})
+
+	When("the pods have no index", func() {
+		BeforeEach(func() {
+			pods = []corev1.Pod{
+				newPod("young", time.Minute, ""),
+				newPod("old", time.Hour, ""),
+			}
+		})
+
+		It("indexes them by age", func() {
+			Expect(assignErr).NotTo(HaveOccurred())
+			Expect(patchedIndexes()).To(Equal(map[string]string{
+				"old":   "0",
+				"young": "1",
+			}))
+		})
+	})
+
+	When("some pods have an index", func() {
+		BeforeEach(func() {
+			pods = []corev1.Pod{
+				newPod("first", time.Hour, "0"),
+				newPod("third", time.Hour, "2"),
+				newPod("replacement", time.Minute, ""),
+			}
+		})
+
+		It("keeps their indexes and fills the gaps", func() {
+			Expect(assignErr).NotTo(HaveOccurred())
+			Expect(patchedIndexes()).To(Equal(map[string]string{
+				"replacement": "1",
+			}))
+		})
+	})
+
+	When("a pod holds an index beyond the number of pods", func() {
+		BeforeEach(func() {
+			pods = []corev1.Pod{
+				newPod("first", time.Hour, "0"),
+				newPod("third", time.Hour, "2"),
+			}
+		})
+
+		It("compacts the indexes", func() {
+			Expect(assignErr).NotTo(HaveOccurred())
+			Expect(patchedIndexes()).To(Equal(map[string]string{
+				"third": "1",
+			}))
+		})
+	})
+
+	When("the deployment is rolled out", func() {
+		BeforeEach(func() {
+			pods = []corev1.Pod{
+				newReplicaSetPod("old-hash", "old-0", time.Hour, "0"),
+				newReplicaSetPod("old-hash", "old-1", time.Hour, "1"),
+				newReplicaSetPod("old-hash", "old-2", time.Hour, "2"),
+				newReplicaSetPod("new-hash", "new-a", 3*time.Minute, ""),
+				newReplicaSetPod("new-hash", "new-b", 2*time.Minute, ""),
+			}
+		})
+
+		It("indexes the pods of the new replica set from 0", func() {
+			Expect(assignErr).NotTo(HaveOccurred())
+			Expect(patchedIndexes()).To(Equal(map[string]string{
+				"new-a": "0",
+				"new-b": "1",
+			}))
+		})
+
+		When("the rollout completes", func() {
+			BeforeEach(func() {
+				for i := range pods[:3] {
+					pods[i].DeletionTimestamp = &metav1.Time{Time: time.Now()}
+				}
+				pods[3].Annotations = map[string]string{runner.AnnotationInstanceIndex: "0"}
+				pods[4].Annotations = map[string]string{runner.AnnotationInstanceIndex: "1"}
+				pods = append(pods, newReplicaSetPod("new-hash", "new-c", time.Minute, ""))
+			})
+
+			It("indexes the pods from 0 to N-1", func() {
+				Expect(assignErr).NotTo(HaveOccurred())
+				Expect(patchedIndexes()).To(Equal(map[string]string{
+					"new-c": "2",
+				}))
+			})
+		})
+	})
+
+	When("a terminating pod holds an index", func() {
+		BeforeEach(func() {
+			terminating := newPod("terminating", time.Hour, "0")
+			terminating.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+			pods = []corev1.Pod{
+				terminating,
+				newPod("replacement", time.Minute, ""),
+			}
+		})
+
+		It("gives the index to the replacement", func() {
+			Expect(assignErr).NotTo(HaveOccurred())
+			Expect(patchedIndexes()).To(Equal(map[string]string{
+				"replacement": "0",
+			}))
+		})
+	})
+
+	When("two pods claim the same index", func() {
+		BeforeEach(func() {
+			pods = []corev1.Pod{
+				newPod("young", time.Minute, "0"),
+				newPod("old", time.Hour, "0"),
+			}
+		})
+
+		It("reindexes the younger one", func() {
+			Expect(assignErr).NotTo(HaveOccurred())
+			Expect(patchedIndexes()).To(Equal(map[string]string{
+				"young": "1",
+			}))
+		})
+	})
+
+	When("listing the pods fails", func() {
+		BeforeEach(func() {
+			fakeClient.ListReturns(errors.New("list-error"))
+			fakeClient.ListStub = nil
+		})
+
+		It("returns the error", func() {
+			Expect(assignErr).To(MatchError(ContainSubstring("list-error")))
+		})
+	})
+
+	When("patching a pod fails", func() {
+		BeforeEach(func() {
+			pods = []corev1.Pod{newPod("pod", time.Minute, "")}
+			fakeClient.PatchReturns(errors.New("patch-error"))
+		})
+
+		It("returns the error", func() {
+			Expect(assignErr).To(MatchError(ContainSubstring("patch-error")))
+		})
+	})
+})
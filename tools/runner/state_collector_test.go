@@ -0,0 +1,97 @@
+package runner_test
+
+import (
+	"context"
+	"time"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools/runner"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("AppWorkloadStateCollector", func() {
+	var (
+		pods           []corev1.Pod
+		instancesState map[string]korifiv1alpha1.InstanceStatus
+		collectErr     error
+	)
+
+	newPod := func(index string, conditions ...corev1.PodCondition) corev1.Pod {
+		pod := corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{},
+			},
+			Status: corev1.PodStatus{
+				Conditions: conditions,
+			},
+		}
+		if index != "" {
+			pod.Annotations[runner.AnnotationInstanceIndex] = index
+		}
+
+		return pod
+	}
+
+	scheduled := corev1.PodCondition{Type: corev1.PodScheduled, Status: corev1.ConditionTrue}
+	ready := corev1.PodCondition{Type: corev1.PodReady, Status: corev1.ConditionTrue}
+
+	BeforeEach(func() {
+		pods = nil
+
+		fakeClient.ListStub = func(_ context.Context, list client.ObjectList, _ ...client.ListOption) error {
+			list.(*corev1.PodList).Items = pods
+			return nil
+		}
+	})
+
+	JustBeforeEach(func() {
+		instancesState, collectErr = runner.NewAppWorkloadStateCollector(fakeClient).CollectState(context.Background(), "workload-guid")
+	})
+
+	When("the pods are indexed", func() {
+		BeforeEach(func() {
+			pods = []corev1.Pod{
+				newPod("0", scheduled, ready),
+				newPod("1", scheduled),
+				newPod("2"),
+			}
+		})
+
+		It("reports the state of each instance by index", func() {
+			Expect(collectErr).NotTo(HaveOccurred())
+			Expect(instancesState).To(HaveLen(3))
+			Expect(instancesState["0"].State).To(Equal(korifiv1alpha1.InstanceStateRunning))
+			Expect(instancesState["1"].State).To(Equal(korifiv1alpha1.InstanceStateStarting))
+			Expect(instancesState["2"].State).To(Equal(korifiv1alpha1.InstanceStateDown))
+		})
+	})
+
+	When("a pod has not been indexed yet", func() {
+		BeforeEach(func() {
+			pods = []corev1.Pod{newPod("", scheduled)}
+		})
+
+		It("does not report it", func() {
+			Expect(collectErr).NotTo(HaveOccurred())
+			Expect(instancesState).To(BeEmpty())
+		})
+	})
+
+	When("a terminating pod shares its index with its replacement", func() {
+		BeforeEach(func() {
+			terminating := newPod("0", scheduled, ready)
+			terminating.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+			pods = []corev1.Pod{newPod("0", scheduled), terminating}
+		})
+
+		It("reports the state of the replacement", func() {
+			Expect(collectErr).NotTo(HaveOccurred())
+			Expect(instancesState).To(HaveKeyWithValue("0", HaveField("State", korifiv1alpha1.InstanceStateStarting)))
+		})
+	})
+})